@@ -0,0 +1,24 @@
+{{- /*
+  The admin-tls partials take the adminTLS values of a chart, whose secretName
+  holds the tls.crt and tls.key the admin server of a container is served
+  with. When requireClientCert is set, the clients other than the probes must
+  present a certificate verified by the ca.crt of that Secret.
+*/ -}}
+{{ define "partials.admin-tls.args" -}}
+- -admin-tls-dir=/var/run/linkerd/admin-tls
+{{- if .requireClientCert }}
+- -admin-tls-client-ca=/var/run/linkerd/admin-tls/ca.crt
+{{- end }}
+{{- end }}
+
+{{ define "partials.admin-tls.volumeMount" -}}
+- mountPath: /var/run/linkerd/admin-tls
+  name: admin-tls
+  readOnly: true
+{{- end }}
+
+{{ define "partials.admin-tls.volume" -}}
+- name: admin-tls
+  secret:
+    secretName: {{.secretName}}
+{{- end }}
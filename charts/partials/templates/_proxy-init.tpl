@@ -24,7 +24,7 @@ args:
 - --log-level
 - {{ .Values.proxyInit.logLevel }}
 {{- end }}
-image: {{.Values.proxyInit.image.name}}:{{.Values.proxyInit.image.version}}
+image: {{.Values.proxyInit.image.name}}{{ if .Values.proxyInit.image.digest }}@{{.Values.proxyInit.image.digest}}{{ else }}:{{.Values.proxyInit.image.version}}{{ end }}
 imagePullPolicy: {{.Values.proxyInit.image.pullPolicy | default .Values.imagePullPolicy}}
 name: linkerd-init
 {{ include "partials.resources" .Values.proxyInit.resources }}
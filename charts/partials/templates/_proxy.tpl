@@ -16,6 +16,13 @@ env:
 {{- if .Values.proxy.cores }}
 - name: LINKERD2_PROXY_CORES
   value: {{.Values.proxy.cores | quote}}
+{{- else if .Values.proxy.coresFromNode }}
+- name: LINKERD2_PROXY_CORES
+  valueFrom:
+    resourceFieldRef:
+      containerName: linkerd-proxy
+      resource: limits.cpu
+      divisor: "1"
 {{- end }}
 {{ if .Values.proxy.requireIdentityOnInboundPorts -}}
 - name: LINKERD2_PROXY_INBOUND_PORTS_REQUIRE_IDENTITY
@@ -86,7 +93,7 @@ env:
 {{ end -}}
 - name: LINKERD2_PROXY_DESTINATION_CONTEXT
   value: |
-    {"ns":"$(_pod_ns)", "nodeName":"$(_pod_nodeName)"}
+    {"ns":"$(_pod_ns)", "nodeName":"$(_pod_nodeName)"{{ with .Values.proxy.workload }}, "workloadKind":"{{.kind}}", "workloadName":"{{.name}}"{{ end }}}
 {{ if .Values.proxy.disableIdentity -}}
 - name: LINKERD2_PROXY_IDENTITY_DISABLED
   value: disabled
@@ -129,7 +136,10 @@ be used in other contexts.
 - name: LINKERD2_PROXY_POLICY_SVC_NAME
   value: linkerd-destination.{{.Release.Namespace}}.serviceaccount.identity.{{.Release.Namespace}}.{{$trustDomain}}
 {{ end -}}
-image: {{.Values.proxy.image.name}}:{{.Values.proxy.image.version | default .Values.linkerdVersion}}
+{{ if .Values.proxy.additionalEnv -}}
+{{ toYaml .Values.proxy.additionalEnv }}
+{{ end -}}
+image: {{.Values.proxy.image.name}}{{ if .Values.proxy.image.digest }}@{{.Values.proxy.image.digest}}{{ else }}:{{.Values.proxy.image.version | default .Values.linkerdVersion}}{{ end }}
 imagePullPolicy: {{.Values.proxy.image.pullPolicy | default .Values.imagePullPolicy}}
 livenessProbe:
   httpGet:
@@ -191,3 +201,20 @@ volumeMounts:
 {{- end -}}
 {{- end -}}
 {{- end }}
+
+{{ define "partials.proxy.await-installer" -}}
+env:
+- name: LINKERD2_AWAIT_INSTALL_PATH
+  value: /var/run/linkerd/await/linkerd-await
+image: {{.Values.proxy.image.name}}{{ if .Values.proxy.image.digest }}@{{.Values.proxy.image.digest}}{{ else }}:{{.Values.proxy.image.version | default .Values.linkerdVersion}}{{ end }}
+imagePullPolicy: {{.Values.proxy.image.pullPolicy | default .Values.imagePullPolicy}}
+name: linkerd-await-installer
+securityContext:
+  allowPrivilegeEscalation: false
+  readOnlyRootFilesystem: true
+  runAsUser: {{.Values.proxy.uid}}
+terminationMessagePolicy: FallbackToLogsOnError
+volumeMounts:
+- mountPath: /var/run/linkerd/await
+  name: linkerd-await
+{{- end }}
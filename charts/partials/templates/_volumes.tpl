@@ -16,5 +16,7 @@ projected:
   - serviceAccountToken:
       path: linkerd-identity-token
       expirationSeconds: 86400 {{- /* # 24 hours */}}
-      audience: identity.l5d.io
+      {{- /* the tokens are issued for the first of the audiences the identity
+      controller reviews them for */}}
+      audience: {{ with .Values.identity.tokenAudiences }}{{ first . }}{{ else }}identity.l5d.io{{ end }}
 {{- end -}}
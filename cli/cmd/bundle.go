@@ -0,0 +1,344 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// bundleRoot is the directory the files of the bundle are archived under
+	bundleRoot = "linkerd-bundle"
+
+	// redacted replaces the values of the secrets that aren't certificates
+	redacted = "REDACTED"
+
+	prometheusDeployment = "prometheus"
+	prometheusPort       = 9090
+)
+
+// bundlePrometheusQueries are the queries whose results are bundled, when the
+// viz extension's Prometheus is installed
+var bundlePrometheusQueries = []struct {
+	name  string
+	query string
+}{
+	{"request-rate", `sum(rate(request_total{direction="inbound"}[1m])) by (namespace, deployment)`},
+	{"success-rate", `sum(rate(response_total{direction="inbound", classification="success"}[1m])) by (namespace, deployment) / sum(rate(response_total{direction="inbound"}[1m])) by (namespace, deployment)`},
+	{"latency-p99", `histogram_quantile(0.99, sum(rate(response_latency_ms_bucket{direction="inbound"}[1m])) by (le, namespace, deployment))`},
+	{"tcp-open-connections", `sum(tcp_open_connections{direction="inbound"}) by (namespace, deployment)`},
+	{"tcp-connection-errors", `sum(increase(tcp_close_total{direction="inbound", errno!=""}[5m])) by (namespace, deployment, errno)`},
+}
+
+type bundleOptions struct {
+	output         string
+	includeSecrets bool
+	logLines       int64
+}
+
+func newBundleOptions() *bundleOptions {
+	return &bundleOptions{
+		output:         "linkerd-bundle.tar.gz",
+		includeSecrets: false,
+		logLines:       1000,
+	}
+}
+
+// newCmdBundle creates a new cobra command `bundle` which collects the
+// diagnostics of the cluster into an archive
+func newCmdBundle() *cobra.Command {
+	options := newBundleOptions()
+
+	cmd := &cobra.Command{
+		Use:   "bundle [flags]",
+		Args:  cobra.NoArgs,
+		Short: "Collect the diagnostics of the cluster into an archive",
+		Long: `Collect the diagnostics of the cluster into an archive.
+
+The archive is meant to be attached to support tickets. It holds:
+  * the resources read by "linkerd check"
+  * the logs of the control plane containers
+  * the output of "linkerd check"
+  * the results of key Prometheus queries, when the viz extension is installed
+
+The resources form a snapshot of the cluster, which can be checked with
+"linkerd check --snapshot" by someone without access to the cluster.
+
+The values of the control plane secrets are redacted, except for their
+certificates; the checks validating the private keys of the identity issuer
+and of the webhooks fail against a redacted snapshot. Collection failures are
+listed in the errors.txt file of the archive.`,
+		Example: `  # Collect the diagnostics of the cluster into linkerd-bundle.tar.gz
+  linkerd diagnostics bundle
+
+  # Check the snapshot of the bundle
+  linkerd check --snapshot linkerd-bundle.tar.gz`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.logLines <= 0 {
+				return fmt.Errorf("--log-lines must be positive")
+			}
+
+			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return err
+			}
+
+			files, err := collectBundle(cmd.Context(), k8sAPI, options)
+			if err != nil {
+				return err
+			}
+
+			f, err := os.OpenFile(options.output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+			if err != nil {
+				return err
+			}
+			if err := healthcheck.WriteArchive(f, bundleRoot, files); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(stdout, "Collected the diagnostics of the cluster into %s\n", options.output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.output, "output", "o", options.output, "Path of the archive")
+	cmd.Flags().BoolVar(&options.includeSecrets, "include-secrets", options.includeSecrets, "Don't redact the control plane secrets, including their private keys")
+	cmd.Flags().Int64Var(&options.logLines, "log-lines", options.logLines, "Number of lines collected from the end of the logs of each container")
+
+	return cmd
+}
+
+// collectBundle returns the files of the bundle, keyed by their
+// slash-separated path. Only the failure to export the snapshot is fatal, the
+// other failures are reported in errors.txt.
+func collectBundle(ctx context.Context, k8sAPI *k8s.KubernetesAPI, options *bundleOptions) (map[string][]byte, error) {
+	failures := []string{}
+	fail := func(format string, args ...interface{}) {
+		failure := fmt.Sprintf(format, args...)
+		fmt.Fprintln(stderr, failure)
+		failures = append(failures, failure)
+	}
+
+	snapshot, err := exportSnapshot(ctx, k8sAPI, options, fail)
+	if err != nil {
+		return nil, err
+	}
+	files, err := snapshot.Files()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := collectLogs(ctx, k8sAPI, options.logLines, files, fail); err != nil {
+		fail("Failed to collect the control plane logs: %s", err)
+	}
+
+	var checks bytes.Buffer
+	hc := healthcheck.NewHealthChecker(
+		append([]healthcheck.CategoryID{
+			healthcheck.KubernetesAPIChecks,
+			healthcheck.KubernetesVersionChecks,
+			healthcheck.LinkerdVersionChecks,
+		}, installedChecks("", false)...),
+		&healthcheck.Options{
+			ControlPlaneNamespace: controlPlaneNamespace,
+			CNINamespace:          cniNamespace,
+			KubeConfig:            kubeconfigPath,
+			KubeContext:           kubeContext,
+			Impersonate:           impersonate,
+			ImpersonateGroup:      impersonateGroup,
+			APIAddr:               apiAddr,
+		},
+	)
+	healthcheck.RunChecks(&checks, &checks, hc, healthcheck.JSONOutput)
+	files["check.json"] = checks.Bytes()
+
+	if err := collectPrometheusQueries(ctx, k8sAPI, files); err != nil {
+		fail("Failed to query Prometheus: %s", err)
+	}
+
+	if len(failures) > 0 {
+		files["errors.txt"] = []byte(strings.Join(failures, "\n") + "\n")
+	}
+	return files, nil
+}
+
+// exportSnapshot reads the resources checked by `linkerd check`
+func exportSnapshot(ctx context.Context, k8sAPI *k8s.KubernetesAPI, options *bundleOptions, fail func(string, ...interface{})) (*healthcheck.Snapshot, error) {
+	version, err := k8sAPI.GetVersionInfo()
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &healthcheck.Snapshot{
+		Version: version,
+	}
+
+	all := metav1.ListOptions{}
+	lists := []func() (runtime.Object, error){
+		func() (runtime.Object, error) { return k8sAPI.CoreV1().Namespaces().List(ctx, all) },
+		func() (runtime.Object, error) { return k8sAPI.CoreV1().Nodes().List(ctx, all) },
+		func() (runtime.Object, error) { return k8sAPI.CoreV1().Pods("").List(ctx, all) },
+		func() (runtime.Object, error) { return k8sAPI.CoreV1().Services("").List(ctx, all) },
+		func() (runtime.Object, error) { return k8sAPI.CoreV1().ServiceAccounts("").List(ctx, all) },
+		func() (runtime.Object, error) { return k8sAPI.AppsV1().Deployments("").List(ctx, all) },
+		func() (runtime.Object, error) { return k8sAPI.AppsV1().ReplicaSets("").List(ctx, all) },
+		func() (runtime.Object, error) { return k8sAPI.AppsV1().DaemonSets("").List(ctx, all) },
+		func() (runtime.Object, error) { return k8sAPI.RbacV1().ClusterRoles().List(ctx, all) },
+		func() (runtime.Object, error) { return k8sAPI.RbacV1().ClusterRoleBindings().List(ctx, all) },
+		func() (runtime.Object, error) {
+			return k8sAPI.AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, all)
+		},
+		func() (runtime.Object, error) {
+			return k8sAPI.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, all)
+		},
+		func() (runtime.Object, error) {
+			return k8sAPI.Apiextensions.ApiextensionsV1().CustomResourceDefinitions().List(ctx, all)
+		},
+		func() (runtime.Object, error) {
+			return k8sAPI.Apiregistration.ApiregistrationV1().APIServices().List(ctx, all)
+		},
+		func() (runtime.Object, error) { return k8sAPI.L5dCrdClient.ServerV1beta1().Servers("").List(ctx, all) },
+		func() (runtime.Object, error) {
+			secrets, err := k8sAPI.CoreV1().Secrets(controlPlaneNamespace).List(ctx, all)
+			if err == nil && !options.includeSecrets {
+				redactSecrets(secrets.Items)
+			}
+			return secrets, err
+		},
+	}
+	// the config maps of the workloads aren't checked
+	for _, ns := range configMapNamespaces() {
+		ns := ns // pin
+		lists = append(lists, func() (runtime.Object, error) {
+			return k8sAPI.CoreV1().ConfigMaps(ns).List(ctx, all)
+		})
+	}
+
+	for _, list := range lists {
+		obj, err := list()
+		if err != nil {
+			return nil, err
+		}
+		if err := addListItems(snapshot, obj); err != nil {
+			return nil, err
+		}
+	}
+
+	return snapshot, nil
+}
+
+// redactSecrets replaces the values of the secrets, except for their
+// certificates, which the checks validate. The last applied configuration is
+// dropped as it holds the values too.
+func redactSecrets(secrets []corev1.Secret) {
+	for i := range secrets {
+		for key := range secrets[i].Data {
+			if !strings.HasSuffix(key, "crt") && !strings.HasSuffix(key, "crt.pem") {
+				secrets[i].Data[key] = []byte(redacted)
+			}
+		}
+		delete(secrets[i].Annotations, corev1.LastAppliedConfigAnnotation)
+	}
+}
+
+// collectLogs adds the last lines of the logs of the control plane
+// containers, including the ones of their previous instance when they were
+// restarted
+func collectLogs(ctx context.Context, k8sAPI *k8s.KubernetesAPI, lines int64, files map[string][]byte, fail func(string, ...interface{})) error {
+	pods, err := k8sAPI.CoreV1().Pods(controlPlaneNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	for _, pod := range pods.Items {
+		statuses := []corev1.ContainerStatus{}
+		statuses = append(statuses, pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			previous := []bool{false}
+			if status.RestartCount > 0 {
+				previous = append(previous, true)
+			}
+			for _, p := range previous {
+				logs, err := k8sAPI.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+					Container: status.Name,
+					TailLines: &lines,
+					Previous:  p,
+				}).DoRaw(ctx)
+				if err != nil {
+					fail("Failed to get the logs of container %s of pod %s: %s", status.Name, pod.Name, err)
+					continue
+				}
+				name := status.Name + ".log"
+				if p {
+					name = status.Name + ".previous.log"
+				}
+				files[path.Join("logs", pod.Name, name)] = logs
+			}
+		}
+	}
+	return nil
+}
+
+// collectPrometheusQueries adds the results of bundlePrometheusQueries,
+// queried through a port-forward to the viz extension's Prometheus
+func collectPrometheusQueries(ctx context.Context, k8sAPI *k8s.KubernetesAPI, files map[string][]byte) error {
+	ns, err := k8sAPI.GetNamespaceWithExtensionLabel(ctx, "viz")
+	if err != nil {
+		return err
+	}
+
+	portForward, err := k8s.NewPortForward(ctx, k8sAPI, ns.Name, prometheusDeployment, "localhost", 0, prometheusPort, verbose)
+	if err != nil {
+		return err
+	}
+	defer portForward.Stop()
+	if err = portForward.Init(); err != nil {
+		return err
+	}
+
+	for _, q := range bundlePrometheusQueries {
+		result, err := getResponse(portForward.URLFor("/api/v1/query?query=" + url.QueryEscape(q.query)))
+		if err != nil {
+			return fmt.Errorf("%s query failed: %s", q.name, err)
+		}
+		files[path.Join("prometheus", q.name+".json")] = result
+	}
+	return nil
+}
+
+// configMapNamespaces returns the namespaces whose config maps are checked
+func configMapNamespaces() []string {
+	namespaces := []string{controlPlaneNamespace}
+	for _, ns := range []string{cniNamespace, "kube-system"} {
+		if ns != controlPlaneNamespace {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
+// addListItems adds the items of a list returned by a typed client to the
+// snapshot
+func addListItems(snapshot *healthcheck.Snapshot, list runtime.Object) error {
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	return snapshot.AddResources(items...)
+}
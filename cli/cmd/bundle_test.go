@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRedactSecrets(t *testing.T) {
+	secrets := []corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "linkerd-identity-issuer",
+				Annotations: map[string]string{
+					corev1.LastAppliedConfigAnnotation: `{"data":{"key.pem":"c2VjcmV0"}}`,
+					"linkerd.io/created-by":            "linkerd/cli stable-2.11.0",
+				},
+			},
+			Data: map[string][]byte{
+				"crt.pem": []byte("issuer certificate"),
+				"key.pem": []byte("issuer key"),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "linkerd-proxy-injector-k8s-tls"},
+			Data: map[string][]byte{
+				"ca.crt":  []byte("ca certificate"),
+				"tls.crt": []byte("webhook certificate"),
+				"tls.key": []byte("webhook key"),
+			},
+		},
+	}
+
+	redactSecrets(secrets)
+
+	expected := []map[string][]byte{
+		{
+			"crt.pem": []byte("issuer certificate"),
+			"key.pem": []byte(redacted),
+		},
+		{
+			"ca.crt":  []byte("ca certificate"),
+			"tls.crt": []byte("webhook certificate"),
+			"tls.key": []byte(redacted),
+		},
+	}
+	for i, secret := range secrets {
+		if !reflect.DeepEqual(secret.Data, expected[i]) {
+			t.Fatalf("Expected data %q of secret %s, got %q", expected[i], secret.Name, secret.Data)
+		}
+	}
+	expectedAnnotations := map[string]string{"linkerd.io/created-by": "linkerd/cli stable-2.11.0"}
+	if !reflect.DeepEqual(secrets[0].Annotations, expectedAnnotations) {
+		t.Fatalf("Expected annotations %v, got %v", expectedAnnotations, secrets[0].Annotations)
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -24,11 +25,14 @@ type checkOptions struct {
 	versionOverride    string
 	preInstallOnly     bool
 	dataPlaneOnly      bool
+	capacity           bool
 	wait               time.Duration
+	waitForReady       bool
 	namespace          string
 	cniEnabled         bool
 	output             string
 	cliVersionOverride string
+	snapshot           string
 }
 
 func newCheckOptions() *checkOptions {
@@ -36,11 +40,14 @@ func newCheckOptions() *checkOptions {
 		versionOverride:    "",
 		preInstallOnly:     false,
 		dataPlaneOnly:      false,
+		capacity:           false,
 		wait:               300 * time.Second,
+		waitForReady:       false,
 		namespace:          "",
 		cniEnabled:         false,
 		output:             tableOutput,
 		cliVersionOverride: "",
+		snapshot:           "",
 	}
 }
 
@@ -52,6 +59,7 @@ func (options *checkOptions) nonConfigFlagSet() *pflag.FlagSet {
 	flags.StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace to use for --proxy checks (default: all namespaces)")
 	flags.BoolVar(&options.preInstallOnly, "pre", options.preInstallOnly, "Only run pre-installation checks, to determine if the control plane can be installed")
 	flags.BoolVar(&options.dataPlaneOnly, "proxy", options.dataPlaneOnly, "Only run data-plane checks, to determine if the data plane is healthy")
+	flags.BoolVar(&options.capacity, "capacity", options.capacity, "Also estimate whether the control plane is sized for the cluster; with JSON output, the numbers the estimates are based on are reported as metrics")
 
 	return flags
 }
@@ -61,9 +69,11 @@ func (options *checkOptions) checkFlagSet() *pflag.FlagSet {
 	flags := pflag.NewFlagSet("check", pflag.ExitOnError)
 
 	flags.StringVar(&options.versionOverride, "expected-version", options.versionOverride, "Overrides the version used when checking if Linkerd is running the latest version (mostly for testing)")
+	flags.StringVar(&options.snapshot, "snapshot", options.snapshot, "Check the cluster snapshot of the archive written by \"linkerd diagnostics bundle\", or of the directory it was extracted to, instead of the current cluster")
 	flags.StringVar(&options.cliVersionOverride, "cli-version-override", "", "Used to override the version of the cli (mostly for testing)")
 	flags.StringVarP(&options.output, "output", "o", options.output, "Output format. One of: basic, json, short")
 	flags.DurationVar(&options.wait, "wait", options.wait, "Maximum allowed time for all tests to pass")
+	flags.BoolVar(&options.waitForReady, "wait-for-ready", options.waitForReady, "Retry every failing check until it passes or --wait expires; with JSON output, the progress is reported on stderr as JSON lines")
 
 	return flags
 }
@@ -72,9 +82,18 @@ func (options *checkOptions) validate() error {
 	if options.preInstallOnly && options.dataPlaneOnly {
 		return errors.New("--pre and --proxy flags are mutually exclusive")
 	}
+	if options.capacity && (options.preInstallOnly || options.dataPlaneOnly) {
+		return errors.New("--capacity cannot be used with --pre or --proxy")
+	}
 	if !options.preInstallOnly && options.cniEnabled {
 		return errors.New("--linkerd-cni-enabled can only be used with --pre")
 	}
+	if options.snapshot != "" && options.preInstallOnly {
+		return errors.New("--snapshot cannot be used with --pre")
+	}
+	if options.snapshot != "" && options.waitForReady {
+		return errors.New("--snapshot cannot be used with --wait-for-ready")
+	}
 	if options.output != tableOutput && options.output != jsonOutput && options.output != shortOutput {
 		return fmt.Errorf("Invalid output type '%s'. Supported output types are: %s, %s, %s", options.output, jsonOutput, tableOutput, shortOutput)
 	}
@@ -129,7 +148,16 @@ non-zero exit code.`,
   linkerd check config
 
   # Check that the Linkerd data plane proxies in the "app" namespace are up and running
-  linkerd check --proxy --namespace app`,
+  linkerd check --proxy --namespace app
+
+  # Check whether the control plane is sized for the cluster
+  linkerd check --capacity -o json
+
+  # Wait for up to 10 minutes for a fresh install to be ready
+  linkerd check --wait 10m --wait-for-ready
+
+  # Check a cluster snapshot exported by "linkerd diagnostics bundle"
+  linkerd check --snapshot linkerd-bundle.tar.gz`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return configureAndRunChecks(cmd, stdout, stderr, "", options)
 		},
@@ -156,6 +184,17 @@ func configureAndRunChecks(cmd *cobra.Command, wout io.Writer, werr io.Writer, s
 		version.Version = options.cliVersionOverride
 	}
 
+	var snapshot *healthcheck.Snapshot
+	retryDeadline := time.Now().Add(options.wait)
+	if options.snapshot != "" {
+		snapshot, err = healthcheck.ReadSnapshot(options.snapshot)
+		if err != nil {
+			return fmt.Errorf("failed to read the snapshot: %s", err)
+		}
+		// a snapshot doesn't change, there's no point in retrying
+		retryDeadline = time.Time{}
+	}
+
 	checks := []healthcheck.CategoryID{
 		healthcheck.KubernetesAPIChecks,
 		healthcheck.KubernetesVersionChecks,
@@ -175,23 +214,9 @@ func configureAndRunChecks(cmd *cobra.Command, wout io.Writer, werr io.Writer, s
 			os.Exit(1)
 		}
 	} else {
-		checks = append(checks, healthcheck.LinkerdConfigChecks)
-
-		if stage != configStage {
-			checks = append(checks, healthcheck.LinkerdControlPlaneExistenceChecks)
-			checks = append(checks, healthcheck.LinkerdIdentity)
-			checks = append(checks, healthcheck.LinkerdWebhooksAndAPISvcTLS)
-			checks = append(checks, healthcheck.LinkerdControlPlaneProxyChecks)
-
-			if options.dataPlaneOnly {
-				checks = append(checks, healthcheck.LinkerdDataPlaneChecks)
-				checks = append(checks, healthcheck.LinkerdIdentityDataPlane)
-				checks = append(checks, healthcheck.LinkerdOpaquePortsDefinitionChecks)
-			} else {
-				checks = append(checks, healthcheck.LinkerdControlPlaneVersionChecks)
-			}
-			checks = append(checks, healthcheck.LinkerdCNIPluginChecks)
-			checks = append(checks, healthcheck.LinkerdHAChecks)
+		checks = append(checks, installedChecks(stage, options.dataPlaneOnly)...)
+		if options.capacity {
+			checks = append(checks, healthcheck.LinkerdCapacityChecks)
 		}
 	}
 
@@ -206,10 +231,13 @@ func configureAndRunChecks(cmd *cobra.Command, wout io.Writer, werr io.Writer, s
 		ImpersonateGroup:      impersonateGroup,
 		APIAddr:               apiAddr,
 		VersionOverride:       options.versionOverride,
-		RetryDeadline:         time.Now().Add(options.wait),
+		RetryDeadline:         retryDeadline,
 		CNIEnabled:            options.cniEnabled,
 		InstallManifest:       installManifest,
 		ChartValues:           values,
+		WaitForReady:          options.waitForReady,
+		WaitObserver:          waitObserver(werr, options.output),
+		Snapshot:              snapshot,
 	})
 
 	if options.output == tableOutput {
@@ -217,11 +245,15 @@ func configureAndRunChecks(cmd *cobra.Command, wout io.Writer, werr io.Writer, s
 	}
 	success, warning := healthcheck.RunChecks(wout, werr, hc, options.output)
 
-	extensionSuccess, extensionWarning, err := runExtensionChecks(cmd, wout, werr, options)
-	if err != nil {
-		err = fmt.Errorf("failed to run extensions checks: %s", err)
-		fmt.Fprintln(werr, err)
-		os.Exit(1)
+	// the extensions' checks run against the cluster
+	extensionSuccess, extensionWarning := true, false
+	if snapshot == nil {
+		extensionSuccess, extensionWarning, err = runExtensionChecks(cmd, wout, werr, options)
+		if err != nil {
+			err = fmt.Errorf("failed to run extensions checks: %s", err)
+			fmt.Fprintln(werr, err)
+			os.Exit(1)
+		}
 	}
 
 	totalSuccess := success && extensionSuccess
@@ -235,6 +267,44 @@ func configureAndRunChecks(cmd *cobra.Command, wout io.Writer, werr io.Writer, s
 	return nil
 }
 
+// installedChecks returns the categories checking an installed control plane,
+// and its data plane when dataPlaneOnly is set
+func installedChecks(stage string, dataPlaneOnly bool) []healthcheck.CategoryID {
+	checks := []healthcheck.CategoryID{healthcheck.LinkerdConfigChecks}
+	if stage == configStage {
+		return checks
+	}
+
+	checks = append(checks, healthcheck.LinkerdControlPlaneExistenceChecks)
+	checks = append(checks, healthcheck.LinkerdIdentity)
+	checks = append(checks, healthcheck.LinkerdWebhooksAndAPISvcTLS)
+	checks = append(checks, healthcheck.LinkerdControlPlaneProxyChecks)
+
+	if dataPlaneOnly {
+		checks = append(checks, healthcheck.LinkerdDataPlaneChecks)
+		checks = append(checks, healthcheck.LinkerdIdentityDataPlane)
+		checks = append(checks, healthcheck.LinkerdOpaquePortsDefinitionChecks)
+	} else {
+		checks = append(checks, healthcheck.LinkerdControlPlaneVersionChecks)
+	}
+	checks = append(checks, healthcheck.LinkerdCNIPluginChecks)
+	checks = append(checks, healthcheck.LinkerdHAChecks)
+	checks = append(checks, healthcheck.LinkerdStrictCryptoChecks)
+	return checks
+}
+
+// waitObserver reports the progress of the checks as JSON lines with the JSON
+// output; the other outputs report it themselves.
+func waitObserver(werr io.Writer, output string) healthcheck.WaitObserver {
+	if output != jsonOutput {
+		return nil
+	}
+	encoder := json.NewEncoder(werr)
+	return func(progress *healthcheck.WaitProgress) {
+		encoder.Encode(progress)
+	}
+}
+
 func runExtensionChecks(cmd *cobra.Command, wout io.Writer, werr io.Writer, opts *checkOptions) (bool, bool, error) {
 	kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
 	if err != nil {
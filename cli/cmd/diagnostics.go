@@ -27,9 +27,13 @@ This command provides subcommands to diagnose the functionality of Linkerd.`,
  
   # Get the endpoints for authorities in Linkerd's control-plane itself
   linkerd diagnostics endpoints web.linkerd-viz.svc.cluster.local:8084
+
+  # Export a snapshot of the cluster for "linkerd check --snapshot"
+  linkerd diagnostics bundle -o linkerd-bundle
   `,
 	}
 
+	diagnosticsCmd.AddCommand(newCmdBundle())
 	diagnosticsCmd.AddCommand(newCmdControllerMetrics())
 	diagnosticsCmd.AddCommand(newCmdEndpoints())
 	diagnosticsCmd.AddCommand(newCmdMetrics())
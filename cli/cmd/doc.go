@@ -188,6 +188,10 @@ func generateAnnotationsDocs() []annotationDoc {
 			Name:        k8s.ProxyCPULimitAnnotation,
 			Description: "Maximum amount of CPU units that the proxy sidecar can use",
 		},
+		{
+			Name:        k8s.ProxyCPURatioAnnotation,
+			Description: "Ratio of the application containers' CPU requests and limits given to the proxy sidecar, which also sizes its number of threads; when the application has no CPU limit, the proxy runs one thread per core of its node",
+		},
 		{
 			Name:        k8s.ProxyMemoryLimitAnnotation,
 			Description: "Maximum amount of Memory that the proxy sidecar can use",
@@ -232,6 +236,14 @@ func generateAnnotationsDocs() []annotationDoc {
 			Name:        k8s.ProxyAwait,
 			Description: "The application container will not start until the proxy is ready; accepted values are `enabled` and `disabled`",
 		},
+		{
+			Name:        k8s.ProxyEnvAnnotation,
+			Description: "Additional environment variables for the proxy sidecar, as a JSON object, e.g. `{\"LINKERD2_PROXY_OUTBOUND_MAX_IN_FLIGHT\": \"1000\"}`; variables set by the injector can't be overridden",
+		},
+		{
+			Name:        k8s.ProxySkipProbePortsAnnotation,
+			Description: "Skip the ports of the startup, liveness and readiness probes of the containers in the proxy, so that the probes aren't denied by a policy only authorizing meshed clients; accepted values are `true` and `false`",
+		},
 		{
 			Name:        k8s.CloseWaitTimeoutAnnotation,
 			Description: "Sets nf_conntrack_tcp_timeout_close_wait. Accepts a duration string, e.g. `1m` or `3600s`",
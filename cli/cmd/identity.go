@@ -130,6 +130,10 @@ This command initiates a port-forward to a given pod or a set of pods and fetche
 	pkgcmd.ConfigureNamespaceFlagCompletion(cmd, []string{"namespace"},
 		kubeconfigPath, impersonate, impersonateGroup, kubeContext)
 
+	cmd.AddCommand(newCmdIdentityRevoke(options))
+	cmd.AddCommand(newCmdIdentityAlertRules())
+	cmd.AddCommand(newCmdIdentityRotateIssuer())
+
 	return cmd
 }
 
@@ -0,0 +1,346 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/identity"
+	"github.com/linkerd/linkerd2/pkg/issuercerts"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/common/expfmt"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// previousIssuerSecretName is the name of the Secret holding the issuer
+	// being rotated, until the new one is known to work
+	previousIssuerSecretName = k8s.IdentityIssuerSecretName + "-previous"
+
+	// issuerRotatedAtAnnotation is set on the identity pods to have the
+	// kubelet refresh their issuer volume right away
+	issuerRotatedAtAnnotation = k8s.Prefix + "/issuer-rotated-at"
+
+	identityAdminPortName = "admin-http"
+	issuerPollInterval    = 5 * time.Second
+)
+
+type alertRulesOptions struct {
+	warningBefore  time.Duration
+	criticalBefore time.Duration
+}
+
+type rotateIssuerOptions struct {
+	crtFile string
+	keyFile string
+	timeout time.Duration
+}
+
+// issuerStatus is the issuer reported by an identity replica
+type issuerStatus struct {
+	pod    string
+	expiry time.Time
+	issued int
+}
+
+func newCmdIdentityAlertRules() *cobra.Command {
+	options := &alertRulesOptions{
+		warningBefore:  7 * 24 * time.Hour,
+		criticalBefore: 24 * time.Hour,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "alert-rules [flags]",
+		Short: "Output the Prometheus alerting rules for the expiry of the identity issuer",
+		Long: `Output the Prometheus alerting rules for the expiry of the identity issuer.
+
+The rules fire when the issuer certificate reported by the identity controller
+expires soon, before the proxies fail to renew their certificates.`,
+		Example: `  # Alert a week, and then a day, before the issuer expires
+  linkerd identity alert-rules > identity_alerts.yml
+
+  # Alert a month before the issuer expires
+  linkerd identity alert-rules --warning-before 720h`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return renderIdentityAlertRules(os.Stdout, options)
+		},
+	}
+
+	cmd.Flags().DurationVar(&options.warningBefore, "warning-before", options.warningBefore, "How long before the issuer expires a warning fires")
+	cmd.Flags().DurationVar(&options.criticalBefore, "critical-before", options.criticalBefore, "How long before the issuer expires a critical alert fires")
+
+	return cmd
+}
+
+func renderIdentityAlertRules(w io.Writer, options *alertRulesOptions) error {
+	if options.criticalBefore <= 0 || options.warningBefore <= options.criticalBefore {
+		return errors.New("--critical-before must be positive and shorter than --warning-before")
+	}
+
+	rules := map[string][]identity.AlertRuleGroup{
+		"groups": {identity.IssuerExpiryAlertRules(options.warningBefore, options.criticalBefore)},
+	}
+	out, err := yaml.Marshal(rules)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(w, string(out))
+	return err
+}
+
+func newCmdIdentityRotateIssuer() *cobra.Command {
+	options := &rotateIssuerOptions{
+		timeout: 10 * time.Minute,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "rotate-issuer [flags]",
+		Short: "Replace the identity issuer certificate and key",
+		Long: `Replace the identity issuer certificate and key.
+
+The new issuer must be signed by the trust anchors of the control plane. The
+current issuer is kept in the linkerd-identity-issuer-previous Secret while the
+new one is rolled out: the identity controller is made to reload the issuer,
+and once every identity replica uses the new issuer and proxies have been
+issued certificates by it, the previous issuer is deleted.
+
+If the rotation doesn't complete in time, the previous issuer is kept, so that
+it can be restored by copying it back to the linkerd-identity-issuer Secret.
+
+This only applies to issuers managed by Linkerd, not to issuers provided by
+an external solution such as cert-manager.`,
+		Example: `  # Rotate the issuer with a new certificate and key
+  linkerd identity rotate-issuer --issuer-crt issuer.crt --issuer-key issuer.key`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.crtFile == "" || options.keyFile == "" {
+				return errors.New("--issuer-crt and --issuer-key are required")
+			}
+			key, crt, err := issuercerts.LoadIssuerCrtAndKeyFromFiles(options.keyFile, options.crtFile)
+			if err != nil {
+				return err
+			}
+
+			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return err
+			}
+
+			return rotateIssuer(cmd.Context(), k8sAPI, crt, key, options, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.crtFile, "issuer-crt", options.crtFile, "A path to a PEM-encoded file containing the new issuer certificate")
+	cmd.Flags().StringVar(&options.keyFile, "issuer-key", options.keyFile, "A path to a PEM-encoded file containing the new issuer private key")
+	cmd.Flags().DurationVar(&options.timeout, "timeout", options.timeout, "How long to wait for the identity controller and the proxies to use the new issuer")
+
+	return cmd
+}
+
+func rotateIssuer(ctx context.Context, k8sAPI *k8s.KubernetesAPI, crt, key string, options *rotateIssuerOptions, w io.Writer) error {
+	trustAnchors, err := healthcheck.FetchTrustBundle(ctx, *k8sAPI, controlPlaneNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to read the trust anchors: %s", err)
+	}
+	issuer := issuercerts.IssuerCertData{TrustAnchors: trustAnchors, IssuerCrt: crt, IssuerKey: key}
+	creds, err := issuer.VerifyAndBuildCreds()
+	if err != nil {
+		return fmt.Errorf("invalid issuer: %s", err)
+	}
+
+	if err := replaceIssuerSecret(ctx, k8sAPI, controlPlaneNamespace, crt, key); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Replaced the issuer, the previous one is kept in the %s Secret\n", previousIssuerSecretName)
+
+	if err := triggerIssuerReload(ctx, k8sAPI, controlPlaneNamespace); err != nil {
+		return err
+	}
+
+	expiry := creds.Certificate.NotAfter
+	deadline := time.Now().Add(options.timeout)
+	loaded := false
+	for {
+		statuses, err := getIssuerStatuses(k8sAPI, controlPlaneNamespace)
+		if err == nil {
+			if !loaded && issuerLoaded(statuses, expiry) {
+				loaded = true
+				fmt.Fprintln(w, "The identity controller uses the new issuer")
+			}
+			if loaded && issuerAccepted(statuses, expiry) {
+				break
+			}
+		}
+		if time.Now().After(deadline) {
+			if !loaded {
+				return fmt.Errorf("the identity controller didn't load the new issuer within %s; the previous issuer is kept in the %s Secret", options.timeout, previousIssuerSecretName)
+			}
+			return fmt.Errorf("no proxy was issued a certificate by the new issuer within %s; restart a meshed workload to check that its proxy gets one, then delete the %s Secret", options.timeout, previousIssuerSecretName)
+		}
+		time.Sleep(issuerPollInterval)
+	}
+	fmt.Fprintln(w, "Proxies were issued certificates by the new issuer")
+
+	err = k8sAPI.CoreV1().Secrets(controlPlaneNamespace).Delete(ctx, previousIssuerSecretName, metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	fmt.Fprintf(w, "Deleted the previous issuer, it expires on %s\n", expiry.Format(time.RFC3339))
+	return nil
+}
+
+// replaceIssuerSecret copies the issuer secret to the previous issuer secret,
+// replacing any left by an incomplete rotation, and then replaces its
+// certificate and key
+func replaceIssuerSecret(ctx context.Context, client kubernetes.Interface, namespace, crt, key string) error {
+	secrets := client.CoreV1().Secrets(namespace)
+	secret, err := secrets.Get(ctx, k8s.IdentityIssuerSecretName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if _, ok := secret.Data[k8s.IdentityIssuerCrtName]; !ok {
+		return fmt.Errorf("the %s Secret has no %s key, the issuer may be managed by an external solution", k8s.IdentityIssuerSecretName, k8s.IdentityIssuerCrtName)
+	}
+
+	previous := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      previousIssuerSecretName,
+			Namespace: namespace,
+			Labels:    secret.Labels,
+		},
+		Type: secret.Type,
+		Data: secret.Data,
+	}
+	err = secrets.Delete(ctx, previousIssuerSecretName, metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	if _, err := secrets.Create(ctx, previous, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to keep the previous issuer: %s", err)
+	}
+
+	updated := secret.DeepCopy()
+	updated.Data = map[string][]byte{
+		k8s.IdentityIssuerCrtName: []byte(crt),
+		k8s.IdentityIssuerKeyName: []byte(key),
+	}
+	_, err = secrets.Update(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+// triggerIssuerReload annotates the identity pods, as the kubelet refreshes
+// the Secret volumes of a pod when it's updated, rather than on its next
+// periodic sync; the identity controller reloads the issuer as soon as its
+// files change
+func triggerIssuerReload(ctx context.Context, client kubernetes.Interface, namespace string) error {
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=identity", k8s.ControllerComponentLabel),
+	})
+	if err != nil {
+		return err
+	}
+	patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, issuerRotatedAtAnnotation, time.Now().UTC().Format(time.RFC3339)))
+	for _, pod := range pods.Items {
+		_, err := client.CoreV1().Pods(namespace).Patch(ctx, pod.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to annotate pod %s: %s", pod.Name, err)
+		}
+	}
+	return nil
+}
+
+// getIssuerStatuses returns the issuer reported by each running identity
+// replica through its metrics
+func getIssuerStatuses(k8sAPI *k8s.KubernetesAPI, namespace string) ([]issuerStatus, error) {
+	pods, err := k8sAPI.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=identity", k8s.ControllerComponentLabel),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []issuerStatus{}
+	for _, result := range getMetrics(k8sAPI, pods.Items, identityAdminPortName, 30*time.Second, false) {
+		if result.err != nil {
+			return nil, result.err
+		}
+		status, err := parseIssuerStatus(result.metrics)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the metrics of %s: %s", result.pod, err)
+		}
+		status.pod = result.pod
+		statuses = append(statuses, status)
+	}
+	if len(statuses) == 0 {
+		return nil, errors.New("no running identity replica")
+	}
+	return statuses, nil
+}
+
+// parseIssuerStatus reads the issuer gauges from the metrics of an identity
+// replica
+func parseIssuerStatus(metrics []byte) (issuerStatus, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(metrics))
+	if err != nil {
+		return issuerStatus{}, err
+	}
+
+	gauge := func(name string) (float64, error) {
+		family, ok := families[name]
+		if !ok || len(family.GetMetric()) == 0 || family.GetMetric()[0].GetGauge() == nil {
+			return 0, fmt.Errorf("missing %s gauge", name)
+		}
+		return family.GetMetric()[0].GetGauge().GetValue(), nil
+	}
+	expiry, err := gauge(identity.IssuerExpiryMetric)
+	if err != nil {
+		return issuerStatus{}, err
+	}
+	issued, err := gauge(identity.IssuerIssuedMetric)
+	if err != nil {
+		return issuerStatus{}, err
+	}
+	return issuerStatus{
+		expiry: time.Unix(int64(expiry), 0),
+		issued: int(issued),
+	}, nil
+}
+
+// issuerLoaded returns whether every identity replica uses the issuer
+// expiring at expiry
+func issuerLoaded(statuses []issuerStatus, expiry time.Time) bool {
+	for _, status := range statuses {
+		if status.expiry.Unix() != expiry.Unix() {
+			return false
+		}
+	}
+	return len(statuses) > 0
+}
+
+// issuerAccepted returns whether every identity replica uses the issuer
+// expiring at expiry and certificates were issued by it, proving that proxies
+// get certificates from it
+func issuerAccepted(statuses []issuerStatus, expiry time.Time) bool {
+	if !issuerLoaded(statuses, expiry) {
+		return false
+	}
+	for _, status := range statuses {
+		if status.issued > 0 {
+			return true
+		}
+	}
+	return false
+}
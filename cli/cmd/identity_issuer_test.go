@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRenderIdentityAlertRules(t *testing.T) {
+	var buf bytes.Buffer
+	options := &alertRulesOptions{warningBefore: 7 * 24 * time.Hour, criticalBefore: 24 * time.Hour}
+	if err := renderIdentityAlertRules(&buf, options); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	testDataDiffer.DiffTestdata(t, "identity_alert_rules.golden", buf.String())
+
+	options = &alertRulesOptions{warningBefore: time.Hour, criticalBefore: 2 * time.Hour}
+	if err := renderIdentityAlertRules(&buf, options); err == nil {
+		t.Fatal("Expected an error when the critical alert fires before the warning")
+	}
+}
+
+func TestReplaceIssuerSecret(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name     string
+		secrets  []*corev1.Secret
+		expected string
+		err      bool
+	}{
+		{
+			name: "linkerd issuer",
+			secrets: []*corev1.Secret{
+				issuerSecret(k8s.IdentityIssuerSecretName, "old-crt"),
+			},
+			expected: "old-crt",
+		},
+		{
+			name: "leftover previous issuer",
+			secrets: []*corev1.Secret{
+				issuerSecret(k8s.IdentityIssuerSecretName, "old-crt"),
+				issuerSecret(previousIssuerSecretName, "older-crt"),
+			},
+			expected: "old-crt",
+		},
+		{
+			name: "external issuer",
+			secrets: []*corev1.Secret{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: k8s.IdentityIssuerSecretName, Namespace: "linkerd"},
+					Data:       map[string][]byte{"tls.crt": []byte("crt")},
+				},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			for _, secret := range tc.secrets {
+				if _, err := client.CoreV1().Secrets("linkerd").Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+			}
+
+			err := replaceIssuerSecret(ctx, client, "linkerd", "new-crt", "new-key")
+			if tc.err {
+				if err == nil {
+					t.Fatal("Expected an error, got none")
+				}
+				_, err := client.CoreV1().Secrets("linkerd").Get(ctx, previousIssuerSecretName, metav1.GetOptions{})
+				if !kerrors.IsNotFound(err) {
+					t.Fatalf("Expected no previous issuer, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			issuer, err := client.CoreV1().Secrets("linkerd").Get(ctx, k8s.IdentityIssuerSecretName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if crt := string(issuer.Data[k8s.IdentityIssuerCrtName]); crt != "new-crt" {
+				t.Fatalf("Expected the issuer certificate new-crt, got %s", crt)
+			}
+			if key := string(issuer.Data[k8s.IdentityIssuerKeyName]); key != "new-key" {
+				t.Fatalf("Expected the issuer key new-key, got %s", key)
+			}
+
+			previous, err := client.CoreV1().Secrets("linkerd").Get(ctx, previousIssuerSecretName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if crt := string(previous.Data[k8s.IdentityIssuerCrtName]); crt != tc.expected {
+				t.Fatalf("Expected the previous issuer certificate %s, got %s", tc.expected, crt)
+			}
+		})
+	}
+}
+
+func TestTriggerIssuerReload(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      "linkerd-identity-1",
+			Namespace: "linkerd",
+			Labels:    map[string]string{k8s.ControllerComponentLabel: "identity"},
+		}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      "linkerd-destination-1",
+			Namespace: "linkerd",
+			Labels:    map[string]string{k8s.ControllerComponentLabel: "destination"},
+		}},
+	)
+
+	if err := triggerIssuerReload(ctx, client, "linkerd"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for pod, annotated := range map[string]bool{"linkerd-identity-1": true, "linkerd-destination-1": false} {
+		p, err := client.CoreV1().Pods("linkerd").Get(ctx, pod, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if _, ok := p.Annotations[issuerRotatedAtAnnotation]; ok != annotated {
+			t.Fatalf("Expected pod %s to be annotated: %t, got %t", pod, annotated, ok)
+		}
+	}
+}
+
+func TestParseIssuerStatus(t *testing.T) {
+	testCases := []struct {
+		name     string
+		metrics  string
+		expected issuerStatus
+		err      bool
+	}{
+		{
+			name: "issuer metrics",
+			metrics: `# TYPE identity_cert_issuer_expiry_timestamp_seconds gauge
+identity_cert_issuer_expiry_timestamp_seconds 1.7e+09
+# TYPE identity_cert_issuer_issued_certificates gauge
+identity_cert_issuer_issued_certificates 12
+`,
+			expected: issuerStatus{expiry: time.Unix(1700000000, 0), issued: 12},
+		},
+		{
+			name: "missing issuer metrics",
+			metrics: `# TYPE identity_cert_issuer_expiry_timestamp_seconds gauge
+identity_cert_issuer_expiry_timestamp_seconds 1.7e+09
+`,
+			err: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			status, err := parseIssuerStatus([]byte(tc.metrics))
+			if tc.err {
+				if err == nil {
+					t.Fatal("Expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !status.expiry.Equal(tc.expected.expiry) || status.issued != tc.expected.issued {
+				t.Fatalf("Expected issuer status %+v, got %+v", tc.expected, status)
+			}
+		})
+	}
+}
+
+func TestIssuerAccepted(t *testing.T) {
+	expiry := time.Unix(1700000000, 0)
+	older := time.Unix(1600000000, 0)
+
+	testCases := []struct {
+		statuses []issuerStatus
+		loaded   bool
+		accepted bool
+	}{
+		{
+			statuses: []issuerStatus{},
+		},
+		{
+			statuses: []issuerStatus{{expiry: expiry}, {expiry: older, issued: 3}},
+		},
+		{
+			statuses: []issuerStatus{{expiry: expiry}, {expiry: expiry}},
+			loaded:   true,
+		},
+		{
+			statuses: []issuerStatus{{expiry: expiry}, {expiry: expiry, issued: 1}},
+			loaded:   true,
+			accepted: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			if loaded := issuerLoaded(tc.statuses, expiry); loaded != tc.loaded {
+				t.Fatalf("Expected loaded %t, got %t", tc.loaded, loaded)
+			}
+			if accepted := issuerAccepted(tc.statuses, expiry); accepted != tc.accepted {
+				t.Fatalf("Expected accepted %t, got %t", tc.accepted, accepted)
+			}
+		})
+	}
+}
+
+func issuerSecret(name, crt string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "linkerd"},
+		Data: map[string][]byte{
+			k8s.IdentityIssuerCrtName: []byte(crt),
+			k8s.IdentityIssuerKeyName: []byte("key"),
+		},
+	}
+}
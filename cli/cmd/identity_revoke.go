@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	pkgcmd "github.com/linkerd/linkerd2/pkg/cmd"
+	"github.com/linkerd/linkerd2/pkg/identity"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+type revokeOptions struct {
+	ttl    time.Duration
+	remove bool
+}
+
+func newCmdIdentityRevoke(identityOptions *identityOptions) *cobra.Command {
+	options := &revokeOptions{
+		ttl: identity.DefaultIssuanceLifetime,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "revoke [flags] (sa/NAME | pod/NAME)",
+		Short: "Refuse to issue certificates for a service account or pod",
+		Long: `Refuse to issue certificates for a service account or pod.
+
+The identity controller stops issuing certificates for the workload until the
+revocation expires, cutting it out of the mesh once its current certificate
+expires, or right away when its pods are restarted. Revoking a pod only applies
+to the pods using tokens bound to them.
+
+This requires the control plane to be installed with identity.enableRevocations.`,
+		Example: `  # Revoke the certificates of the web service account in the emojivoto namespace for a day.
+  linkerd identity revoke sa/web -n emojivoto
+
+  # Revoke the certificates of a single pod for an hour.
+  linkerd identity revoke pod/web-5f7f9f8f8-abcde -n emojivoto --ttl 1h
+
+  # Lift the revocation of the web service account.
+  linkerd identity revoke sa/web -n emojivoto --remove`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace := identityOptions.namespace
+			if namespace == "" {
+				namespace = pkgcmd.GetDefaultNamespace(kubeconfigPath, kubeContext)
+			}
+			revocation, err := parseRevocation(namespace, args[0])
+			if err != nil {
+				return err
+			}
+			if !options.remove && options.ttl <= 0 {
+				return fmt.Errorf("--ttl must be positive")
+			}
+
+			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return err
+			}
+
+			var until time.Time
+			if !options.remove {
+				until = time.Now().Add(options.ttl).UTC().Truncate(time.Second)
+			}
+			if err := updateRevocation(cmd.Context(), k8sAPI, controlPlaneNamespace, revocation, until); err != nil {
+				return err
+			}
+
+			if options.remove {
+				fmt.Printf("Lifted the revocation of %s %s/%s\n", revocation.Kind, revocation.Namespace, revocation.Name)
+			} else {
+				fmt.Printf("Revoked the certificates of %s %s/%s until %s\n", revocation.Kind, revocation.Namespace, revocation.Name, until.Format(time.RFC3339))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&options.ttl, "ttl", options.ttl, "Amount of time the revocation lasts")
+	cmd.Flags().BoolVar(&options.remove, "remove", options.remove, "Lift the revocation instead")
+
+	return cmd
+}
+
+// parseRevocation parses a sa/NAME or pod/NAME argument
+func parseRevocation(namespace, arg string) (identity.Revocation, error) {
+	parts := strings.Split(arg, "/")
+	if len(parts) != 2 || parts[1] == "" {
+		return identity.Revocation{}, fmt.Errorf("invalid resource %q, must be sa/NAME or pod/NAME", arg)
+	}
+
+	revocation := identity.Revocation{Namespace: namespace, Name: parts[1]}
+	switch strings.ToLower(parts[0]) {
+	case "sa", "serviceaccount", "serviceaccounts":
+		revocation.Kind = identity.RevokedServiceAccount
+	case "po", "pod", "pods":
+		revocation.Kind = identity.RevokedPod
+	default:
+		return identity.Revocation{}, fmt.Errorf("cannot revoke the certificates of %s, only service accounts and pods can be revoked", parts[0])
+	}
+	return revocation, nil
+}
+
+// updateRevocation adds the revocation to the revocations ConfigMap of the
+// control plane, expiring at until, or removes it when until is zero
+func updateRevocation(ctx context.Context, client kubernetes.Interface, controlPlaneNamespace string, revocation identity.Revocation, until time.Time) error {
+	configMaps := client.CoreV1().ConfigMaps(controlPlaneNamespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := configMaps.Get(ctx, k8s.IdentityRevocationsConfigMapName, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			if until.IsZero() {
+				return nil
+			}
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      k8s.IdentityRevocationsConfigMapName,
+					Namespace: controlPlaneNamespace,
+					Labels: map[string]string{
+						k8s.ControllerNSLabel: controlPlaneNamespace,
+					},
+				},
+				Data: map[string]string{
+					revocation.Key(): until.Format(time.RFC3339),
+				},
+			}
+			_, err = configMaps.Create(ctx, cm, metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		if until.IsZero() {
+			if _, ok := cm.Data[revocation.Key()]; !ok {
+				return nil
+			}
+			delete(cm.Data, revocation.Key())
+		} else {
+			if cm.Data == nil {
+				cm.Data = map[string]string{}
+			}
+			cm.Data[revocation.Key()] = until.Format(time.RFC3339)
+		}
+		_, err = configMaps.Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
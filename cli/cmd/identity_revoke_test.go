@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/identity"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseRevocation(t *testing.T) {
+	testCases := []struct {
+		arg      string
+		expected identity.Revocation
+		err      string
+	}{
+		{
+			arg:      "sa/web",
+			expected: identity.Revocation{Kind: identity.RevokedServiceAccount, Namespace: "emojivoto", Name: "web"},
+		},
+		{
+			arg:      "pod/web-5f7f9f8f8-abcde",
+			expected: identity.Revocation{Kind: identity.RevokedPod, Namespace: "emojivoto", Name: "web-5f7f9f8f8-abcde"},
+		},
+		{
+			arg: "deploy/web",
+			err: "cannot revoke the certificates of deploy, only service accounts and pods can be revoked",
+		},
+		{
+			arg: "web",
+			err: `invalid resource "web", must be sa/NAME or pod/NAME`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.arg, func(t *testing.T) {
+			revocation, err := parseRevocation("emojivoto", tc.arg)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("Expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if revocation != tc.expected {
+				t.Fatalf("Expected revocation %+v, got %+v", tc.expected, revocation)
+			}
+		})
+	}
+}
+
+func TestUpdateRevocation(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset()
+	web := identity.Revocation{Kind: identity.RevokedServiceAccount, Namespace: "emojivoto", Name: "web"}
+	pod := identity.Revocation{Kind: identity.RevokedPod, Namespace: "emojivoto", Name: "web-5f7f9f8f8-abcde"}
+	until := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	expectData := func(expected map[string]string) {
+		t.Helper()
+		cm, err := client.CoreV1().ConfigMaps("linkerd").Get(ctx, k8s.IdentityRevocationsConfigMapName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(cm.Data, expected) {
+			t.Fatalf("Expected revocations %v, got %v", expected, cm.Data)
+		}
+	}
+
+	// lifting a revocation that doesn't exist is a no-op
+	if err := updateRevocation(ctx, client, "linkerd", web, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := updateRevocation(ctx, client, "linkerd", web, until); err != nil {
+		t.Fatal(err)
+	}
+	expectData(map[string]string{"serviceaccount.emojivoto.web": "2021-10-01T12:00:00Z"})
+
+	if err := updateRevocation(ctx, client, "linkerd", pod, until.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	expectData(map[string]string{
+		"serviceaccount.emojivoto.web":      "2021-10-01T12:00:00Z",
+		"pod.emojivoto.web-5f7f9f8f8-abcde": "2021-10-01T13:00:00Z",
+	})
+
+	if err := updateRevocation(ctx, client, "linkerd", web, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	expectData(map[string]string{"pod.emojivoto.web-5f7f9f8f8-abcde": "2021-10-01T13:00:00Z"})
+}
@@ -422,6 +422,13 @@ func getOverrideAnnotations(values *charts.Values, base *charts.Values) map[stri
 		overrideAnnotations[k8s.DebugImageAnnotation] = values.DebugContainer.Image.Name
 	}
 
+	if proxy.Image.Digest != baseProxy.Image.Digest {
+		overrideAnnotations[k8s.ProxyImageDigestAnnotation] = proxy.Image.Digest
+	}
+	if values.ProxyInit.Image.Digest != base.ProxyInit.Image.Digest {
+		overrideAnnotations[k8s.ProxyInitImageDigestAnnotation] = values.ProxyInit.Image.Digest
+	}
+
 	if values.ProxyInit.Image.Version != base.ProxyInit.Image.Version {
 		overrideAnnotations[k8s.ProxyInitImageVersionAnnotation] = values.ProxyInit.Image.Version
 	}
@@ -103,6 +103,11 @@ func TestUninjectAndInject(t *testing.T) {
 	proxyIgnorePortsConfig.ProxyInit.IgnoreInboundPorts = "22,8100-8102"
 	proxyIgnorePortsConfig.ProxyInit.IgnoreOutboundPorts = "5432"
 
+	additionalWorkloadsConfig := defaultConfig()
+	additionalWorkloadsConfig.ProxyInjector.AdditionalWorkloads = []linkerd2.AdditionalWorkload{
+		{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout", Resource: "rollouts", PodTemplatePath: "spec.template"},
+	}
+
 	testCases := []testCase{
 		{
 			inputFileName:    "inject_emojivoto_deployment.input.yml",
@@ -189,6 +194,13 @@ func TestUninjectAndInject(t *testing.T) {
 			injectProxy:      false,
 			testInjectConfig: defaultValues,
 		},
+		{
+			inputFileName:    "inject_emojivoto_rollout.input.yml",
+			goldenFileName:   "inject_emojivoto_rollout.golden.yml",
+			reportFileName:   "inject_emojivoto_rollout.report",
+			injectProxy:      true,
+			testInjectConfig: additionalWorkloadsConfig,
+		},
 		{
 			inputFileName:    "inject_emojivoto_pod.input.yml",
 			goldenFileName:   "inject_emojivoto_pod.golden.yml",
@@ -203,6 +215,20 @@ func TestUninjectAndInject(t *testing.T) {
 			injectProxy:      true,
 			testInjectConfig: proxyResourceConfig,
 		},
+		{
+			inputFileName:    "inject_emojivoto_pod_cpu_ratio.input.yml",
+			goldenFileName:   "inject_emojivoto_pod_cpu_ratio.golden.yml",
+			reportFileName:   "inject_emojivoto_pod.report",
+			injectProxy:      true,
+			testInjectConfig: defaultValues,
+		},
+		{
+			inputFileName:    "inject_emojivoto_pod_proxy_env.input.yml",
+			goldenFileName:   "inject_emojivoto_pod_proxy_env.golden.yml",
+			reportFileName:   "inject_emojivoto_pod.report",
+			injectProxy:      true,
+			testInjectConfig: defaultValues,
+		},
 		{
 			inputFileName:    "inject_emojivoto_deployment_udp.input.yml",
 			goldenFileName:   "inject_emojivoto_deployment_udp.golden.yml",
@@ -701,12 +727,14 @@ func TestProxyImageAnnotations(t *testing.T) {
 		Name:       "my.registry/linkerd/proxy",
 		Version:    "test-proxy-version",
 		PullPolicy: "Always",
+		Digest:     "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
 	}
 
 	expectedOverrides := map[string]string{
 		k8s.ProxyImageAnnotation:           "my.registry/linkerd/proxy",
 		k8s.ProxyVersionOverrideAnnotation: "test-proxy-version",
 		k8s.ProxyImagePullPolicyAnnotation: "Always",
+		k8s.ProxyImageDigestAnnotation:     "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
 	}
 
 	overrides := getOverrideAnnotations(values, baseValues)
@@ -726,11 +754,13 @@ func TestProxyInitImageAnnotations(t *testing.T) {
 	values.ProxyInit.Image = &linkerd2.Image{
 		Name:    "my.registry/linkerd/proxy-init",
 		Version: "test-proxy-init-version",
+		Digest:  "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
 	}
 
 	expectedOverrides := map[string]string{
 		k8s.ProxyInitImageAnnotation:        "my.registry/linkerd/proxy-init",
 		k8s.ProxyInitImageVersionAnnotation: "test-proxy-init-version",
+		k8s.ProxyInitImageDigestAnnotation:  "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
 	}
 
 	overrides := getOverrideAnnotations(values, baseValues)
@@ -40,6 +40,7 @@ type cniPluginOptions struct {
 	destCNINetDir       string
 	destCNIBinDir       string
 	useWaitFlag         bool
+	ipv6                bool
 	priorityClassName   string
 }
 
@@ -120,6 +121,7 @@ assumes that the 'linkerd install' command will be executed with the
 		"use-wait-flag",
 		options.useWaitFlag,
 		"Configures the CNI plugin to use the \"-w\" flag for the iptables command. (default false)")
+	cmd.PersistentFlags().BoolVar(&options.ipv6, "ipv6", options.ipv6, "Configures the CNI plugin to also redirect the IPv6 traffic of the pods to the proxy, on IPv6 and dual-stack clusters")
 
 	return cmd
 }
@@ -144,6 +146,7 @@ func newCNIInstallOptionsWithDefaults() (*cniPluginOptions, error) {
 		destCNINetDir:       defaults.DestCNINetDir,
 		destCNIBinDir:       defaults.DestCNIBinDir,
 		useWaitFlag:         defaults.UseWaitFlag,
+		ipv6:                defaults.IPv6,
 		priorityClassName:   defaults.PriorityClassName,
 	}
 
@@ -181,6 +184,7 @@ func (options *cniPluginOptions) buildValues() (*cnicharts.Values, error) {
 	installValues.DestCNINetDir = options.destCNINetDir
 	installValues.DestCNIBinDir = options.destCNIBinDir
 	installValues.UseWaitFlag = options.useWaitFlag
+	installValues.IPv6 = options.ipv6
 	installValues.PriorityClassName = options.priorityClassName
 	return installValues, nil
 }
@@ -61,6 +61,8 @@ Otherwise, you can use the --ignore-cluster flag to overwrite the existing globa
 
 var (
 	templatesCrdFiles = []string{
+		"templates/defaultprofile-crd.yaml",
+		"templates/injectionpolicy-crd.yaml",
 		"templates/policy-crd.yaml",
 		"templates/serviceprofile-crd.yaml",
 	}
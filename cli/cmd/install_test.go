@@ -37,6 +37,8 @@ func TestRender(t *testing.T) {
 		WebhookFailurePolicy:    "WebhookFailurePolicy",
 		HeartbeatSchedule:       "1 2 3 4 5",
 		Identity:                defaultValues.Identity,
+		Destination:             defaultValues.Destination,
+		AdminTLS:                defaultValues.AdminTLS,
 		NodeSelector:            defaultValues.NodeSelector,
 		Tolerations:             defaultValues.Tolerations,
 		ClusterDomain:           "cluster.local",
@@ -45,6 +47,7 @@ func TestRender(t *testing.T) {
 		CliVersion:              "CliVersion",
 		ControllerLogLevel:      "ControllerLogLevel",
 		ControllerLogFormat:     "ControllerLogFormat",
+		ControllerCryptoMode:    "ControllerCryptoMode",
 		ControllerImageVersion:  "ControllerImageVersion",
 		ProxyContainerName:      "ProxyContainerName",
 		CNIEnabled:              false,
@@ -227,6 +230,8 @@ func TestRender(t *testing.T) {
 		{defaultValues, "install_custom_domain.golden", values.Options{}},
 		{defaultValues, "install_values_file.golden", values.Options{ValueFiles: []string{filepath.Join("testdata", "install_config.yaml")}}},
 		{defaultValues, "install_default_token.golden", values.Options{Values: []string{"identity.serviceAccountTokenProjection=false"}}},
+		{defaultValues, "install_destination_config.golden", values.Options{Values: []string{"destination.configMap=linkerd-destination-config", "destination.opaquePortNamePrefix=opaque-", "destination.maxAuthoritiesPerClient=100", "destination.defaultPort=0", "destination.clockSkewWarningThreshold=1m", "destination.leaderElection.enabled=true", "destination.leaderElection.leaseDuration=15s", "destination.streamHashing=true", "proxy.enableExternalProfiles=true"}}},
+		{defaultValues, "install_admin_tls.golden", values.Options{Values: []string{"adminTLS.secretName=linkerd-admin-tls", "adminTLS.requireClientCert=true"}}},
 	}
 
 	for i, tc := range testCases {
@@ -407,6 +412,24 @@ func TestValidate(t *testing.T) {
 		}
 	})
 
+	t.Run("Rejects invalid controller crypto mode", func(t *testing.T) {
+		values, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+
+		values.ControllerCryptoMode = "fips"
+		expected := "--controller-crypto-mode must be one of: default, strict"
+
+		err = validateValues(context.Background(), nil, values)
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != expected {
+			t.Fatalf("Expected error string\"%s\", got \"%s\"", expected, err)
+		}
+	})
+
 	t.Run("Properly validates proxy log level", func(t *testing.T) {
 		testCases := []struct {
 			input string
@@ -64,8 +64,12 @@ func getContainerMetrics(
 		return nil, err
 	}
 
-	metricsURL := portForward.URLFor("/metrics")
-	return getResponse(metricsURL)
+	resp, err := portForward.HTTPClient().Get(portForward.URLFor("/metrics"))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return ioutil.ReadAll(resp.Body)
 }
 
 // getAllContainersWithPort returns all the containers within
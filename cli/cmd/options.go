@@ -50,6 +50,12 @@ func makeInstallUpgradeFlags(defaults *l5dcharts.Values) ([]flag.Flag, *pflag.Fl
 				return nil
 			}),
 
+		flag.NewStringFlag(installUpgradeFlags, "controller-crypto-mode", defaults.ControllerCryptoMode,
+			"TLS versions, cipher suites and keys accepted by the control plane components; one of: default, strict", func(values *l5dcharts.Values, value string) error {
+				values.ControllerCryptoMode = value
+				return nil
+			}),
+
 		// The HA flag must be processed before flags that set these values individually so that the
 		// individual flags can override the HA defaults.
 		flag.NewBoolFlag(installUpgradeFlags, "ha", false, "Enable HA deployment config for the control plane (default false)",
@@ -301,6 +307,18 @@ func makeProxyFlags(defaults *l5dcharts.Values) ([]flag.Flag, *pflag.FlagSet) {
 				return nil
 			}),
 
+		flag.NewStringFlag(proxyFlags, "proxy-image-digest", defaults.Proxy.Image.Digest,
+			"Digest pinning the Linkerd proxy container image, instead of its version", func(values *l5dcharts.Values, value string) error {
+				values.Proxy.Image.Digest = value
+				return nil
+			}),
+
+		flag.NewStringFlag(proxyFlags, "init-image-digest", defaults.ProxyInit.Image.Digest,
+			"Digest pinning the Linkerd init container image, instead of its version", func(values *l5dcharts.Values, value string) error {
+				values.ProxyInit.Image.Digest = value
+				return nil
+			}),
+
 		flag.NewStringFlag(proxyFlags, "image-pull-policy", defaults.ImagePullPolicy,
 			"Docker image pull policy", func(values *l5dcharts.Values, value string) error {
 				values.ImagePullPolicy = value
@@ -492,11 +510,44 @@ func makeInjectFlags(defaults *l5dcharts.Values) ([]flag.Flag, *pflag.FlagSet) {
 				values.Proxy.OpaquePorts = strings.Join(value, ",")
 				return nil
 			}),
+
+		flag.NewStringSliceFlag(injectFlags, "additional-workloads", []string{},
+			"Workload kinds whose pod template is injected, in addition to the built-in ones and the ones configured "+
+				"in the control plane, in the GROUP/VERSION/KIND=PATH format, e.g. argoproj.io/v1alpha1/Rollout=spec.template",
+			func(values *l5dcharts.Values, value []string) error {
+				for _, w := range value {
+					workload, err := parseAdditionalWorkload(w)
+					if err != nil {
+						return err
+					}
+					values.ProxyInjector.AdditionalWorkloads = append(values.ProxyInjector.AdditionalWorkloads, workload)
+				}
+				return nil
+			}),
 	}
 
 	return flags, injectFlags
 }
 
+// parseAdditionalWorkload parses a workload kind in the GROUP/VERSION/KIND=PATH
+// format, where PATH is the dot-separated path of its pod template
+func parseAdditionalWorkload(workload string) (l5dcharts.AdditionalWorkload, error) {
+	kind := strings.SplitN(workload, "=", 2)
+	if len(kind) != 2 || kind[1] == "" {
+		return l5dcharts.AdditionalWorkload{}, fmt.Errorf("invalid workload %q: expected GROUP/VERSION/KIND=PATH", workload)
+	}
+	gvk := strings.Split(kind[0], "/")
+	if len(gvk) != 3 || gvk[0] == "" || gvk[1] == "" || gvk[2] == "" {
+		return l5dcharts.AdditionalWorkload{}, fmt.Errorf("invalid workload %q: expected GROUP/VERSION/KIND=PATH", workload)
+	}
+	return l5dcharts.AdditionalWorkload{
+		Group:           gvk[0],
+		Version:         gvk[1],
+		Kind:            gvk[2],
+		PodTemplatePath: kind[1],
+	}, nil
+}
+
 /* Validation */
 
 func validateValues(ctx context.Context, k *k8s.KubernetesAPI, values *l5dcharts.Values) error {
@@ -508,6 +559,10 @@ func validateValues(ctx context.Context, k *k8s.KubernetesAPI, values *l5dcharts
 		return fmt.Errorf("--controller-log-level must be one of: panic, fatal, error, warn, info, debug")
 	}
 
+	if _, err := tls.ParseCryptoMode(values.ControllerCryptoMode); err != nil {
+		return fmt.Errorf("--controller-crypto-mode must be one of: %s, %s", tls.CryptoModeDefault, tls.CryptoModeStrict)
+	}
+
 	if values.Proxy.LogLevel == "" {
 		return errors.New("--proxy-log-level must not be empty")
 	}
@@ -586,6 +641,14 @@ func validateProxyValues(values *l5dcharts.Values) error {
 		return fmt.Errorf("%s is not a valid version", values.ProxyInit.Image.Version)
 	}
 
+	if values.Proxy.Image.Digest != "" && !inject.ImageDigestRegexp.MatchString(values.Proxy.Image.Digest) {
+		return fmt.Errorf("%s is not a valid image digest", values.Proxy.Image.Digest)
+	}
+
+	if values.ProxyInit.Image.Digest != "" && !inject.ImageDigestRegexp.MatchString(values.ProxyInit.Image.Digest) {
+		return fmt.Errorf("%s is not a valid image digest", values.ProxyInit.Image.Digest)
+	}
+
 	if values.ImagePullPolicy != "Always" && values.ImagePullPolicy != "IfNotPresent" && values.ImagePullPolicy != "Never" {
 		return fmt.Errorf("--image-pull-policy must be one of: Always, IfNotPresent, Never")
 	}
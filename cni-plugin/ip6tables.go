@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/linkerd/linkerd2-proxy-init/iptables"
+	"github.com/linkerd/linkerd2-proxy-init/ports"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	ip6tablesOutputChainName   = "PROXY_INIT_OUTPUT"
+	ip6tablesRedirectChainName = "PROXY_INIT_REDIRECT"
+)
+
+var ip6tablesChainRegex = regexp.MustCompile(`-A (PROXY_INIT_OUTPUT|PROXY_INIT_REDIRECT).*`)
+
+// configureIP6Firewall writes the rules of the firewall configuration with
+// ip6tables, so that the IPv6 traffic of the pods of IPv6 and dual-stack
+// clusters is redirected to the proxy too. proxy-init only writes the IPv4
+// rules; these mirror them, leaving out the IPv4 subnets to ignore.
+func configureIP6Firewall(fc iptables.FirewallConfiguration) error {
+	var rules bytes.Buffer
+	if err := executeIP6Command(fc, exec.Command("ip6tables-save", "-t", "nat"), &rules); err != nil {
+		return err
+	}
+	if ip6tablesChainRegex.MatchString(rules.String()) {
+		logrus.Info("linkerd-cni: found existing ip6tables chains, skipping ip6tables setup")
+		return nil
+	}
+
+	for _, args := range ip6tablesRules(fc) {
+		if err := executeIP6Command(fc, exec.Command("ip6tables", args...), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ip6tablesRules returns the arguments of the ip6tables commands redirecting
+// the inbound and outbound traffic to the proxy
+func ip6tablesRules(fc iptables.FirewallConfiguration) [][]string {
+	rules := [][]string{
+		{"-N", ip6tablesRedirectChainName},
+	}
+	for _, destinations := range multiportDestinations(fc.InboundPortsToIgnore) {
+		rules = append(rules, []string{"-A", ip6tablesRedirectChainName, "-p", "tcp", "--match", "multiport", "--dports", destinations, "-j", "RETURN"})
+	}
+	for _, subnet := range fc.SubnetsToIgnore {
+		if ip, _, err := net.ParseCIDR(subnet); err == nil && ip.To4() == nil {
+			rules = append(rules, []string{"-A", ip6tablesRedirectChainName, "-p", "all", "-j", "RETURN", "-s", subnet})
+		}
+	}
+	if fc.Mode == iptables.RedirectListedMode {
+		for _, port := range fc.PortsToRedirectInbound {
+			rules = append(rules, []string{"-A", ip6tablesRedirectChainName, "-p", "tcp", "--destination-port", strconv.Itoa(port), "-j", "REDIRECT", "--to-port", strconv.Itoa(fc.ProxyInboundPort)})
+		}
+	} else {
+		rules = append(rules, []string{"-A", ip6tablesRedirectChainName, "-p", "tcp", "-j", "REDIRECT", "--to-port", strconv.Itoa(fc.ProxyInboundPort)})
+	}
+	rules = append(rules, []string{"-A", iptables.IptablesPreroutingChainName, "-j", ip6tablesRedirectChainName})
+
+	rules = append(rules, []string{"-N", ip6tablesOutputChainName})
+	if fc.ProxyUID > 0 {
+		rules = append(rules, []string{"-A", ip6tablesOutputChainName, "-m", "owner", "--uid-owner", strconv.Itoa(fc.ProxyUID), "-j", "RETURN"})
+	}
+	rules = append(rules, []string{"-A", ip6tablesOutputChainName, "-o", "lo", "-j", "RETURN"})
+	for _, destinations := range multiportDestinations(fc.OutboundPortsToIgnore) {
+		rules = append(rules, []string{"-A", ip6tablesOutputChainName, "-p", "tcp", "--match", "multiport", "--dports", destinations, "-j", "RETURN"})
+	}
+	rules = append(rules, []string{"-A", ip6tablesOutputChainName, "-p", "tcp", "-j", "REDIRECT", "--to-port", strconv.Itoa(fc.ProxyOutgoingPort)})
+	rules = append(rules, []string{"-A", iptables.IptablesOutputChainName, "-j", ip6tablesOutputChainName})
+
+	for i := range rules {
+		rules[i] = append([]string{"-t", "nat"}, rules[i]...)
+		rules[i] = append(rules[i], "-m", "comment", "--comment", fmt.Sprintf("proxy-init/ipv6/%s", iptables.ExecutionTraceID))
+	}
+	return rules
+}
+
+// multiportDestinations groups the ports and port ranges to ignore into
+// lists of at most iptables.IptablesMultiportLimit ports, a range counting
+// for two
+func multiportDestinations(portsToIgnore []string) []string {
+	groups := []string{}
+	group := []string{}
+	count := 0
+	for _, portOrRange := range portsToIgnore {
+		portRange, err := ports.ParsePortRange(portOrRange)
+		if err != nil {
+			logrus.Errorf("linkerd-cni: invalid port configuration of %q: %s", portOrRange, err)
+			continue
+		}
+		destination, size := strconv.Itoa(portRange.LowerBound), 1
+		if portRange.LowerBound != portRange.UpperBound {
+			destination, size = fmt.Sprintf("%d:%d", portRange.LowerBound, portRange.UpperBound), 2
+		}
+		if count+size > iptables.IptablesMultiportLimit {
+			groups = append(groups, strings.Join(group, ","))
+			group, count = []string{}, 0
+		}
+		group = append(group, destination)
+		count += size
+	}
+	if len(group) > 0 {
+		groups = append(groups, strings.Join(group, ","))
+	}
+	return groups
+}
+
+func executeIP6Command(fc iptables.FirewallConfiguration, cmd *exec.Cmd, out *bytes.Buffer) error {
+	if cmd.Args[0] == "ip6tables" && fc.UseWaitFlag {
+		cmd.Args = append(cmd.Args, "-w")
+	}
+	if fc.NetNs != "" {
+		args := append([]string{fmt.Sprintf("--net=%s", fc.NetNs), "--"}, cmd.Args...)
+		cmd = exec.Command("nsenter", args...)
+	}
+
+	logrus.Info(strings.Join(cmd.Args, " "))
+	if fc.SimulateOnly {
+		return nil
+	}
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %s: %s", strings.Join(cmd.Args, " "), err, output)
+	}
+	if out != nil {
+		out.Write(output)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2-proxy-init/iptables"
+)
+
+func TestIP6tablesRules(t *testing.T) {
+	fc := iptables.FirewallConfiguration{
+		Mode:                  iptables.RedirectAllMode,
+		InboundPortsToIgnore:  []string{"4190", "4191"},
+		OutboundPortsToIgnore: []string{"443", "5000-5010"},
+		SubnetsToIgnore:       []string{"10.0.0.0/8", "fd00:10::/64"},
+		ProxyInboundPort:      4143,
+		ProxyOutgoingPort:     4140,
+		ProxyUID:              2102,
+	}
+
+	expected := []string{
+		"-N PROXY_INIT_REDIRECT",
+		"-A PROXY_INIT_REDIRECT -p tcp --match multiport --dports 4190,4191 -j RETURN",
+		"-A PROXY_INIT_REDIRECT -p all -j RETURN -s fd00:10::/64",
+		"-A PROXY_INIT_REDIRECT -p tcp -j REDIRECT --to-port 4143",
+		"-A PREROUTING -j PROXY_INIT_REDIRECT",
+		"-N PROXY_INIT_OUTPUT",
+		"-A PROXY_INIT_OUTPUT -m owner --uid-owner 2102 -j RETURN",
+		"-A PROXY_INIT_OUTPUT -o lo -j RETURN",
+		"-A PROXY_INIT_OUTPUT -p tcp --match multiport --dports 443,5000:5010 -j RETURN",
+		"-A PROXY_INIT_OUTPUT -p tcp -j REDIRECT --to-port 4140",
+		"-A OUTPUT -j PROXY_INIT_OUTPUT",
+	}
+
+	rules := []string{}
+	for _, args := range ip6tablesRules(fc) {
+		rule := strings.Join(args, " ")
+		if !strings.HasPrefix(rule, "-t nat ") {
+			t.Fatalf("Expected rule in the nat table, got: %s", rule)
+		}
+		// leave out the table and the comment
+		rules = append(rules, strings.Join(args[2:len(args)-4], " "))
+	}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Fatalf("Expected rules:\n%s\nGot:\n%s", strings.Join(expected, "\n"), strings.Join(rules, "\n"))
+	}
+}
+
+func TestMultiportDestinations(t *testing.T) {
+	portsToIgnore := []string{}
+	for i := 0; i < 8; i++ {
+		portsToIgnore = append(portsToIgnore, "8000-8010")
+	}
+	portsToIgnore = append(portsToIgnore, "9000", "not-a-port")
+
+	expected := []string{
+		"8000:8010,8000:8010,8000:8010,8000:8010,8000:8010,8000:8010,8000:8010",
+		"8000:8010,9000",
+	}
+	if destinations := multiportDestinations(portsToIgnore); !reflect.DeepEqual(destinations, expected) {
+		t.Fatalf("Expected %v, got %v", expected, destinations)
+	}
+}
@@ -47,6 +47,7 @@ type ProxyInit struct {
 	OutboundPortsToIgnore []string `json:"outbound-ports-to-ignore"`
 	Simulate              bool     `json:"simulate"`
 	UseWaitFlag           bool     `json:"use-wait-flag"`
+	IPv6                  bool     `json:"ipv6"`
 }
 
 // Kubernetes a K8s specific struct to hold config
@@ -243,6 +244,15 @@ func cmdAdd(args *skel.CmdArgs) error {
 				logEntry.Errorf("linkerd-cni: could not configure firewall: %v", err)
 				return err
 			}
+
+			if conf.ProxyInit.IPv6 {
+				logEntry.Debug("linkerd-cni: setting up ip6tables firewall")
+				err = configureIP6Firewall(*firewallConfiguration)
+				if err != nil {
+					logEntry.Errorf("linkerd-cni: could not configure ip6tables firewall: %v", err)
+					return err
+				}
+			}
 		} else {
 			if containsInitContainer {
 				logEntry.Debug("linkerd-cni: linkerd-init initContainer is present, skipping.")
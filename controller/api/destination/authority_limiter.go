@@ -0,0 +1,139 @@
+package destination
+
+import (
+	"container/list"
+	"net"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var authorityEvictionsCounter = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "destination_authority_evictions_total",
+	Help: "Number of subscriptions evicted because their client exceeded the maximum number of distinct authorities",
+})
+
+type (
+	// authorityLimiter bounds the number of distinct authorities each client
+	// can be subscribed to at a time. Once a client goes past the limit, the
+	// subscriptions for its least recently used authority are evicted.
+	authorityLimiter struct {
+		limit int
+
+		sync.Mutex
+		clients map[string]*clientAuthorities
+	}
+
+	// clientAuthorities tracks the authorities a single client is subscribed
+	// to, most recently used first
+	clientAuthorities struct {
+		lru      *list.List
+		elements map[string]*list.Element
+	}
+
+	authorityEntry struct {
+		authority string
+		// each stream subscribed to the authority is notified through its own
+		// channel, closed on eviction
+		streams map[chan struct{}]struct{}
+	}
+)
+
+func newAuthorityLimiter(limit int) *authorityLimiter {
+	return &authorityLimiter{
+		limit:   limit,
+		clients: make(map[string]*clientAuthorities),
+	}
+}
+
+// acquire registers a stream from client for authority. The returned channel
+// is closed if the subscription gets evicted, and the returned func must be
+// called once the stream ends. A nil limiter never evicts anything.
+func (l *authorityLimiter) acquire(client, authority string) (<-chan struct{}, func()) {
+	if l == nil || client == "" {
+		return nil, func() {}
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	ca, ok := l.clients[client]
+	if !ok {
+		ca = &clientAuthorities{
+			lru:      list.New(),
+			elements: make(map[string]*list.Element),
+		}
+		l.clients[client] = ca
+	}
+
+	elem, ok := ca.elements[authority]
+	if ok {
+		ca.lru.MoveToFront(elem)
+	} else {
+		elem = ca.lru.PushFront(&authorityEntry{
+			authority: authority,
+			streams:   make(map[chan struct{}]struct{}),
+		})
+		ca.elements[authority] = elem
+	}
+	entry := elem.Value.(*authorityEntry)
+	evicted := make(chan struct{})
+	entry.streams[evicted] = struct{}{}
+
+	for ca.lru.Len() > l.limit {
+		oldest := ca.lru.Back().Value.(*authorityEntry)
+		for stream := range oldest.streams {
+			close(stream)
+			authorityEvictionsCounter.Inc()
+		}
+		ca.lru.Remove(ca.lru.Back())
+		delete(ca.elements, oldest.authority)
+	}
+
+	return evicted, func() { l.release(client, authority, evicted) }
+}
+
+func (l *authorityLimiter) release(client, authority string, stream chan struct{}) {
+	l.Lock()
+	defer l.Unlock()
+
+	ca, ok := l.clients[client]
+	if !ok {
+		return
+	}
+	elem, ok := ca.elements[authority]
+	if !ok {
+		// already evicted
+		return
+	}
+	entry := elem.Value.(*authorityEntry)
+	delete(entry.streams, stream)
+	if len(entry.streams) == 0 {
+		ca.lru.Remove(elem)
+		delete(ca.elements, authority)
+	}
+	if ca.lru.Len() == 0 {
+		delete(l.clients, client)
+	}
+}
+
+func errAuthorityEvicted(authority string) error {
+	return status.Errorf(codes.ResourceExhausted, "too many distinct authorities resolved by client, evicted %s", authority)
+}
+
+// clientKey identifies the client of a stream by its IP, so that all the
+// connections from a given proxy are accounted together
+func clientKey(client *peer.Peer) string {
+	if client == nil || client.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(client.Addr.String())
+	if err != nil {
+		return client.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,58 @@
+package destination
+
+import (
+	"testing"
+)
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestAuthorityLimiter(t *testing.T) {
+	t.Run("Evicts the least recently used authority past the limit", func(t *testing.T) {
+		limiter := newAuthorityLimiter(2)
+
+		foo, _ := limiter.acquire("10.0.0.1", "foo.ns.svc.cluster.local:80")
+		bar, _ := limiter.acquire("10.0.0.1", "bar.ns.svc.cluster.local:80")
+		// using foo again makes bar the least recently used
+		fooAgain, _ := limiter.acquire("10.0.0.1", "foo.ns.svc.cluster.local:80")
+		// other clients are accounted separately
+		other, _ := limiter.acquire("10.0.0.2", "baz.ns.svc.cluster.local:80")
+		baz, _ := limiter.acquire("10.0.0.1", "baz.ns.svc.cluster.local:80")
+
+		if !isClosed(bar) {
+			t.Fatal("Expected the least recently used authority to be evicted")
+		}
+		for _, ch := range []<-chan struct{}{foo, fooAgain, other, baz} {
+			if isClosed(ch) {
+				t.Fatal("Expected only the least recently used authority to be evicted")
+			}
+		}
+	})
+
+	t.Run("Only accounts for authorities with active streams", func(t *testing.T) {
+		limiter := newAuthorityLimiter(1)
+
+		foo, release := limiter.acquire("10.0.0.1", "foo.ns.svc.cluster.local:80")
+		release()
+		bar, _ := limiter.acquire("10.0.0.1", "bar.ns.svc.cluster.local:80")
+
+		if isClosed(foo) || isClosed(bar) {
+			t.Fatal("Expected released authorities not to count towards the limit")
+		}
+	})
+
+	t.Run("Never evicts when disabled", func(t *testing.T) {
+		var limiter *authorityLimiter
+		evicted, release := limiter.acquire("10.0.0.1", "foo.ns.svc.cluster.local:80")
+		release()
+		if evicted != nil {
+			t.Fatal("Expected no eviction channel when the limiter is disabled")
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package destination
+
+import (
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/k8s"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	logging "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+var nodeClockSkewGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "node_clock_skew_seconds",
+	Help: "Estimated offset of each node's clock relative to the destination controller's, from the kubelet heartbeats",
+}, []string{"node"})
+
+// nodeClockSkewWatcher estimates the clock skew of every node by comparing
+// the time at which the kubelet reports its Ready heartbeat with the time the
+// update is observed. Proxies running on a node whose clock is skewed by more
+// than the issuer's clock skew allowance reject the certificates they're
+// issued, so such nodes are called out in the logs.
+type nodeClockSkewWatcher struct {
+	threshold time.Duration
+	now       func() time.Time
+	log       *logging.Entry
+}
+
+// WatchNodeClockSkew starts estimating the clock skew of the nodes, warning
+// about the ones whose skew exceeds threshold.
+func WatchNodeClockSkew(k8sAPI *k8s.API, threshold time.Duration) {
+	w := &nodeClockSkewWatcher{
+		threshold: threshold,
+		now:       time.Now,
+		log:       logging.WithField("component", "clock-skew-watcher"),
+	}
+	k8sAPI.Node().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: w.updateNode,
+		DeleteFunc: w.deleteNode,
+	})
+}
+
+func (w *nodeClockSkewWatcher) updateNode(oldObj, newObj interface{}) {
+	oldNode := oldObj.(*corev1.Node)
+	newNode := newObj.(*corev1.Node)
+
+	heartbeat, ok := readyHeartbeat(newNode)
+	if !ok {
+		return
+	}
+	if previous, ok := readyHeartbeat(oldNode); ok && previous.Equal(heartbeat) {
+		// resync, not a new heartbeat
+		return
+	}
+
+	// heartbeats are reported with a one second resolution, and are observed
+	// shortly after being reported, so this slightly overestimates how much
+	// the node's clock lags behind
+	skew := heartbeat.Sub(w.now())
+	nodeClockSkewGauge.WithLabelValues(newNode.GetName()).Set(skew.Seconds())
+
+	if skew > w.threshold || -skew > w.threshold {
+		w.log.WithFields(logging.Fields{
+			"node":      newNode.GetName(),
+			"skew":      skew.Round(time.Second).String(),
+			"threshold": w.threshold.String(),
+		}).Warn("node clock is skewed, proxies on this node may reject their certificates")
+	}
+}
+
+func (w *nodeClockSkewWatcher) deleteNode(obj interface{}) {
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			w.log.Errorf("couldn't get object from DeletedFinalStateUnknown %#v", obj)
+			return
+		}
+		node, ok = tombstone.Obj.(*corev1.Node)
+		if !ok {
+			w.log.Errorf("DeletedFinalStateUnknown contained object that is not a Node %#v", obj)
+			return
+		}
+	}
+	nodeClockSkewGauge.DeleteLabelValues(node.GetName())
+}
+
+func readyHeartbeat(node *corev1.Node) (time.Time, bool) {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.LastHeartbeatTime.Time, !cond.LastHeartbeatTime.IsZero()
+		}
+	}
+	return time.Time{}, false
+}
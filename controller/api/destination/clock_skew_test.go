@@ -0,0 +1,63 @@
+package destination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	logging "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func nodeWithHeartbeat(name string, heartbeat time.Time) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:              corev1.NodeReady,
+					Status:            corev1.ConditionTrue,
+					LastHeartbeatTime: metav1.NewTime(heartbeat),
+				},
+			},
+		},
+	}
+}
+
+func TestNodeClockSkewWatcher(t *testing.T) {
+	now := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	w := &nodeClockSkewWatcher{
+		threshold: 20 * time.Second,
+		now:       func() time.Time { return now },
+		log:       logging.WithField("test", t.Name()),
+	}
+
+	t.Run("Estimates the skew from new heartbeats", func(t *testing.T) {
+		w.updateNode(
+			nodeWithHeartbeat("node-ahead", now.Add(-time.Minute)),
+			nodeWithHeartbeat("node-ahead", now.Add(45*time.Second)),
+		)
+		if skew := testutil.ToFloat64(nodeClockSkewGauge.WithLabelValues("node-ahead")); skew != 45 {
+			t.Fatalf("Expected a skew of 45s, got %fs", skew)
+		}
+	})
+
+	t.Run("Ignores resyncs", func(t *testing.T) {
+		heartbeat := now.Add(-10 * time.Minute)
+		w.updateNode(
+			nodeWithHeartbeat("node-resync", heartbeat),
+			nodeWithHeartbeat("node-resync", heartbeat),
+		)
+		if testutil.CollectAndCount(nodeClockSkewGauge, "node_clock_skew_seconds") != 1 {
+			t.Fatal("Expected resyncs not to be considered heartbeats")
+		}
+	})
+
+	t.Run("Forgets deleted nodes", func(t *testing.T) {
+		w.deleteNode(nodeWithHeartbeat("node-ahead", now))
+		if testutil.CollectAndCount(nodeClockSkewGauge, "node_clock_skew_seconds") != 0 {
+			t.Fatal("Expected the skew of deleted nodes to be forgotten")
+		}
+	})
+}
@@ -0,0 +1,219 @@
+package destination
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/destination/watcher"
+	"github.com/linkerd/linkerd2/pkg/util"
+	logging "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// The keys of the ConfigMap overriding the settings of the server. The
+// clusterDomain key holds a comma-separated list of cluster domain suffixes.
+const (
+	configIdentityTrustDomainKey = "identityTrustDomain"
+	configClusterDomainKey       = "clusterDomain"
+	configDefaultOpaquePortsKey  = "defaultOpaquePorts"
+)
+
+type (
+	// serverConfig holds the settings of the server that can be updated
+	// without restarting it, from a ConfigMap. The settings the server was
+	// started with apply when the ConfigMap doesn't set them.
+	serverConfig struct {
+		initial configSettings
+		current configSettings
+		sync.RWMutex
+	}
+
+	configSettings struct {
+		identityTrustDomain string
+		// the names in any of the cluster domains are resolved, while the
+		// first one is used to build the fully-qualified names of the services
+		clusterDomains     []string
+		defaultOpaquePorts map[uint32]struct{}
+	}
+)
+
+func newServerConfig(identityTrustDomain string, clusterDomains []string, defaultOpaquePorts map[uint32]struct{}) *serverConfig {
+	settings := configSettings{
+		identityTrustDomain: identityTrustDomain,
+		clusterDomains:      clusterDomains,
+		defaultOpaquePorts:  defaultOpaquePorts,
+	}
+	return &serverConfig{
+		initial: settings,
+		current: settings,
+	}
+}
+
+func (c *serverConfig) get() configSettings {
+	c.RLock()
+	defer c.RUnlock()
+	return c.current
+}
+
+// update applies the settings of the ConfigMap, falling back to the initial
+// settings for the keys it doesn't set, or all of them when cm is nil. The
+// settings are left unchanged when the ConfigMap is invalid.
+func (c *serverConfig) update(cm *corev1.ConfigMap) (configSettings, error) {
+	settings := c.initial
+	if cm != nil {
+		if trustDomain, ok := cm.Data[configIdentityTrustDomainKey]; ok {
+			settings.identityTrustDomain = trustDomain
+		}
+		if clusterDomain, ok := cm.Data[configClusterDomainKey]; ok {
+			domains, err := parseClusterDomains(clusterDomain)
+			if err != nil {
+				return configSettings{}, fmt.Errorf("invalid %s: %s", configClusterDomainKey, err)
+			}
+			settings.clusterDomains = domains
+		}
+		if opaquePorts, ok := cm.Data[configDefaultOpaquePortsKey]; ok {
+			ports, err := util.ParsePorts(opaquePorts)
+			if err != nil {
+				return configSettings{}, fmt.Errorf("invalid %s: %s", configDefaultOpaquePortsKey, err)
+			}
+			settings.defaultOpaquePorts = ports
+		}
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.current = settings
+	return settings, nil
+}
+
+// parseClusterDomains parses a comma-separated list of cluster domain
+// suffixes, such as "cluster.local,cluster.example"
+func parseClusterDomains(list string) ([]string, error) {
+	domains := []string{}
+	for _, domain := range strings.Split(list, ",") {
+		domain = strings.Trim(strings.TrimSpace(domain), ".")
+		if domain == "" {
+			return nil, fmt.Errorf("the cluster domains can't be empty: %q", list)
+		}
+		domains = append(domains, domain)
+	}
+	return domains, nil
+}
+
+// configWatcher applies the settings of a ConfigMap to the server whenever it
+// changes or when a reload is requested
+type configWatcher struct {
+	config      *serverConfig
+	opaquePorts *watcher.OpaquePortsWatcher
+	namespace   string
+	name        string
+	client      kubernetes.Interface
+	log         *logging.Entry
+	// serializes the updates from the informer and the reloads
+	sync.Mutex
+}
+
+// watchConfig starts watching the ConfigMap with the given name in the given
+// namespace, applying its settings to config and pushing the changes of the
+// default opaque ports to the opaque ports watcher. The ConfigMap is also
+// re-read each time reload receives a value.
+func watchConfig(
+	client kubernetes.Interface,
+	namespace, name string,
+	config *serverConfig,
+	opaquePorts *watcher.OpaquePortsWatcher,
+	reload <-chan struct{},
+	shutdown <-chan struct{},
+) {
+	cw := &configWatcher{
+		config:      config,
+		opaquePorts: opaquePorts,
+		namespace:   namespace,
+		name:        name,
+		client:      client,
+		log: logging.WithFields(logging.Fields{
+			"component": "config-watcher",
+			"configmap": fmt.Sprintf("%s/%s", namespace, name),
+		}),
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(
+		client,
+		10*time.Minute,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+		}),
+	)
+	factory.Core().V1().ConfigMaps().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { cw.apply(obj.(*corev1.ConfigMap)) },
+		UpdateFunc: func(_, obj interface{}) { cw.apply(obj.(*corev1.ConfigMap)) },
+		DeleteFunc: func(interface{}) { cw.apply(nil) },
+	})
+	factory.Start(shutdown)
+
+	go func() {
+		for {
+			select {
+			case <-reload:
+				cw.reload()
+			case <-shutdown:
+				return
+			}
+		}
+	}()
+}
+
+// reload reads the ConfigMap from the API server, so that the settings are
+// applied even if a change was missed
+func (cw *configWatcher) reload() {
+	cw.log.Info("Reloading config")
+	cm, err := cw.client.CoreV1().ConfigMaps(cw.namespace).Get(context.Background(), cw.name, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			cw.log.Errorf("Failed to read config: %s", err)
+			return
+		}
+		cm = nil
+	}
+	cw.apply(cm)
+}
+
+func (cw *configWatcher) apply(cm *corev1.ConfigMap) {
+	cw.Lock()
+	defer cw.Unlock()
+	settings, err := cw.config.update(cm)
+	if err != nil {
+		cw.log.Errorf("Ignoring invalid config: %s", err)
+		return
+	}
+	cw.log.Infof("Using identity trust domain %q, cluster domains %v and default opaque ports %v", settings.identityTrustDomain, settings.clusterDomains, settings.defaultOpaquePorts)
+	cw.opaquePorts.SetDefaultOpaquePorts(settings.defaultOpaquePorts)
+}
+
+func (s *server) identityTrustDomain() string {
+	return s.config.get().identityTrustDomain
+}
+
+// clusterDomain returns the canonical cluster domain, the first of the
+// configured ones
+func (s *server) clusterDomain() string {
+	return s.config.get().clusterDomains[0]
+}
+
+func (s *server) clusterDomains() []string {
+	return s.config.get().clusterDomains
+}
+
+func (s *server) defaultOpaquePorts() map[uint32]struct{} {
+	return s.config.get().defaultOpaquePorts
+}
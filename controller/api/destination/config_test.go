@@ -0,0 +1,152 @@
+package destination
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/linkerd/linkerd2/controller/api/destination/watcher"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	logging "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestServerConfigUpdate(t *testing.T) {
+	initial := configSettings{
+		identityTrustDomain: "cluster.local",
+		clusterDomains:      []string{"cluster.local"},
+		defaultOpaquePorts:  map[uint32]struct{}{3306: {}},
+	}
+
+	testCases := []struct {
+		name     string
+		data     map[string]string
+		deleted  bool
+		expected configSettings
+		err      bool
+	}{
+		{
+			name:     "no ConfigMap",
+			deleted:  true,
+			expected: initial,
+		},
+		{
+			name:     "empty ConfigMap",
+			data:     map[string]string{},
+			expected: initial,
+		},
+		{
+			name: "overridden settings",
+			data: map[string]string{
+				"identityTrustDomain": "example.org",
+				"clusterDomain":       "cluster.example",
+				"defaultOpaquePorts":  "25,5432-5433",
+			},
+			expected: configSettings{
+				identityTrustDomain: "example.org",
+				clusterDomains:      []string{"cluster.example"},
+				defaultOpaquePorts:  map[uint32]struct{}{25: {}, 5432: {}, 5433: {}},
+			},
+		},
+		{
+			name: "no default opaque ports",
+			data: map[string]string{"defaultOpaquePorts": ""},
+			expected: configSettings{
+				identityTrustDomain: "cluster.local",
+				clusterDomains:      []string{"cluster.local"},
+				defaultOpaquePorts:  map[uint32]struct{}{},
+			},
+		},
+		{
+			name: "several cluster domains",
+			data: map[string]string{"clusterDomain": "cluster.local, cluster.example."},
+			expected: configSettings{
+				identityTrustDomain: "cluster.local",
+				clusterDomains:      []string{"cluster.local", "cluster.example"},
+				defaultOpaquePorts:  map[uint32]struct{}{3306: {}},
+			},
+		},
+		{
+			name: "empty cluster domain",
+			data: map[string]string{"clusterDomain": ""},
+			err:  true,
+		},
+		{
+			name: "empty cluster domain in the list",
+			data: map[string]string{"clusterDomain": "cluster.local,,cluster.example"},
+			err:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			config := newServerConfig(initial.identityTrustDomain, initial.clusterDomains, initial.defaultOpaquePorts)
+			// start from other settings to check they're replaced
+			config.current = configSettings{clusterDomains: []string{"previous.local"}}
+
+			var cm *corev1.ConfigMap
+			if !tc.deleted {
+				cm = &corev1.ConfigMap{Data: tc.data}
+			}
+			settings, err := config.update(cm)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("Expected an error, got %v", settings)
+				}
+				if !reflect.DeepEqual(config.get().clusterDomains, []string{"previous.local"}) {
+					t.Fatalf("Expected the settings to be left unchanged, got %v", config.get())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(settings, tc.expected) || !reflect.DeepEqual(config.get(), tc.expected) {
+				t.Fatalf("Expected settings %v, got %v", tc.expected, config.get())
+			}
+		})
+	}
+}
+
+func TestConfigWatcherReload(t *testing.T) {
+	k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: linkerd-destination-config
+  namespace: linkerd
+data:
+  clusterDomain: cluster.example
+  defaultOpaquePorts: "8080"`)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+	log := logging.WithField("test", t.Name())
+	opaquePorts := watcher.NewOpaquePortsWatcher(k8sAPI, log, map[uint32]struct{}{3306: {}}, "")
+	k8sAPI.Sync(nil)
+
+	config := newServerConfig("cluster.local", []string{"cluster.local"}, map[uint32]struct{}{3306: {}})
+	s := &server{config: config}
+	cw := &configWatcher{
+		config:      config,
+		opaquePorts: opaquePorts,
+		namespace:   "linkerd",
+		name:        "linkerd-destination-config",
+		client:      k8sAPI.Client,
+		log:         log,
+	}
+
+	cw.reload()
+	if s.clusterDomain() != "cluster.example" || s.identityTrustDomain() != "cluster.local" {
+		t.Fatalf("Expected the cluster domain to be overridden, got %q and trust domain %q", s.clusterDomain(), s.identityTrustDomain())
+	}
+	if !reflect.DeepEqual(s.defaultOpaquePorts(), map[uint32]struct{}{8080: {}}) {
+		t.Fatalf("Expected default opaque ports 8080, got %v", s.defaultOpaquePorts())
+	}
+
+	cw.name = "missing"
+	cw.reload()
+	if s.clusterDomain() != "cluster.local" || !reflect.DeepEqual(s.defaultOpaquePorts(), map[uint32]struct{}{3306: {}}) {
+		t.Fatalf("Expected the initial settings to be restored, got %q and %v", s.clusterDomain(), s.defaultOpaquePorts())
+	}
+}
@@ -0,0 +1,80 @@
+package destination
+
+import (
+	"sync"
+
+	"github.com/linkerd/linkerd2/controller/api/destination/watcher"
+	dp "github.com/linkerd/linkerd2/controller/gen/apis/defaultprofile/v1alpha1"
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultRouteName is the name of the catch-all route synthesized from a
+// DefaultProfile, as reported in the route metrics.
+const defaultRouteName = "default"
+
+// defaultProfileAdaptor holds an underlying ProfileUpdateListener and updates
+// that listener with a profile synthesized from the namespace's
+// DefaultProfile whenever the service has no ServiceProfile. It implements
+// DefaultProfileUpdateListener and should be passed to a source of profile
+// updates and default profile updates.
+type defaultProfileAdaptor struct {
+	listener       watcher.ProfileUpdateListener
+	profile        *sp.ServiceProfile
+	defaultProfile *dp.DefaultProfile
+	mutex          sync.Mutex
+}
+
+func newDefaultProfileAdaptor(listener watcher.ProfileUpdateListener) *defaultProfileAdaptor {
+	return &defaultProfileAdaptor{
+		listener: listener,
+	}
+}
+
+func (dpa *defaultProfileAdaptor) Update(profile *sp.ServiceProfile) {
+	dpa.mutex.Lock()
+	defer dpa.mutex.Unlock()
+	dpa.profile = profile
+	dpa.publish()
+}
+
+func (dpa *defaultProfileAdaptor) UpdateDefaultProfile(profile *dp.DefaultProfile) {
+	dpa.mutex.Lock()
+	defer dpa.mutex.Unlock()
+	// the update is only visible when the service has no ServiceProfile
+	changed := dpa.profile == nil && (dpa.defaultProfile != nil || profile != nil)
+	dpa.defaultProfile = profile
+	if changed {
+		dpa.publish()
+	}
+}
+
+func (dpa *defaultProfileAdaptor) publish() {
+	if dpa.profile != nil || dpa.defaultProfile == nil {
+		dpa.listener.Update(dpa.profile)
+		return
+	}
+	dpa.listener.Update(toServiceProfile(dpa.defaultProfile))
+}
+
+// toServiceProfile returns a ServiceProfile with a single route matching all
+// the requests, configured with the timeout and retries of the DefaultProfile.
+func toServiceProfile(profile *dp.DefaultProfile) *sp.ServiceProfile {
+	return &sp.ServiceProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      profile.Name,
+			Namespace: profile.Namespace,
+		},
+		Spec: sp.ServiceProfileSpec{
+			Routes: []*sp.RouteSpec{
+				{
+					Name:        defaultRouteName,
+					Condition:   &sp.RequestMatch{PathRegex: ".*"},
+					IsRetryable: profile.Spec.IsRetryable,
+					Timeout:     profile.Spec.Timeout,
+				},
+			},
+			RetryBudget: profile.Spec.RetryBudget,
+		},
+	}
+}
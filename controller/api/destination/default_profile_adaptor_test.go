@@ -0,0 +1,98 @@
+package destination
+
+import (
+	"reflect"
+	"testing"
+
+	dp "github.com/linkerd/linkerd2/controller/gen/apis/defaultprofile/v1alpha1"
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha2"
+	logging "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDefaultProfileAdaptor(t *testing.T) {
+	serviceProfile := sp.ServiceProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo.ns.svc.cluster.local",
+		},
+	}
+
+	defaultProfile := dp.DefaultProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "timeouts",
+			Namespace: "ns",
+		},
+		Spec: dp.DefaultProfileSpec{
+			Timeout:     "10s",
+			IsRetryable: true,
+			RetryBudget: &sp.RetryBudget{
+				RetryRatio:          0.1,
+				MinRetriesPerSecond: 5,
+				TTL:                 "20s",
+			},
+		},
+	}
+
+	t.Run("Without default profile", func(t *testing.T) {
+		listener := &mockListener{}
+		adaptor := newDefaultProfileAdaptor(listener)
+
+		adaptor.UpdateDefaultProfile(nil)
+		adaptor.Update(nil)
+		adaptor.Update(&serviceProfile)
+
+		assertEq(t, listener.received, []*sp.ServiceProfile{nil, &serviceProfile})
+	})
+
+	t.Run("Service profile overrides default profile", func(t *testing.T) {
+		listener := &mockListener{}
+		adaptor := newDefaultProfileAdaptor(listener)
+
+		adaptor.Update(&serviceProfile)
+		adaptor.UpdateDefaultProfile(&defaultProfile)
+
+		assertEq(t, listener.received, []*sp.ServiceProfile{&serviceProfile})
+	})
+
+	t.Run("Default profile replaces missing service profile", func(t *testing.T) {
+		listener := &mockListener{}
+		adaptor := newDefaultProfileAdaptor(listener)
+
+		adaptor.UpdateDefaultProfile(&defaultProfile)
+		adaptor.Update(&serviceProfile)
+		adaptor.Update(nil)
+		adaptor.UpdateDefaultProfile(nil)
+
+		synthesized := toServiceProfile(&defaultProfile)
+		expected := []*sp.ServiceProfile{synthesized, &serviceProfile, synthesized, nil}
+		if !reflect.DeepEqual(listener.received, expected) {
+			t.Fatalf("Expected profile updates %v, got %v", expected, listener.received)
+		}
+	})
+
+	t.Run("Default profile is translated to a catch-all route", func(t *testing.T) {
+		translator := newProfileTranslator(nil, logging.WithField("test", t.Name()), "foo.ns.svc.cluster.local", 80)
+
+		profile, err := translator.createDestinationProfile(toServiceProfile(&defaultProfile))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if len(profile.Routes) != 1 {
+			t.Fatalf("Expected 1 route, got %d", len(profile.Routes))
+		}
+		route := profile.Routes[0]
+		if route.GetCondition().GetPath().GetRegex() != ".*" {
+			t.Fatalf("Expected the route to match all paths, got %v", route.GetCondition())
+		}
+		if route.GetTimeout().GetSeconds() != 10 || !route.GetIsRetryable() {
+			t.Fatalf("Expected a retryable route with a 10s timeout, got %v", route)
+		}
+		if route.GetMetricsLabels()["route"] != defaultRouteName {
+			t.Fatalf("Expected the route to be named %s, got %v", defaultRouteName, route.GetMetricsLabels())
+		}
+		if profile.GetRetryBudget().GetRetryRatio() != 0.1 || profile.GetRetryBudget().GetTtl().GetSeconds() != 20 {
+			t.Fatalf("Unexpected retry budget %v", profile.GetRetryBudget())
+		}
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	pb "github.com/linkerd/linkerd2-proxy-api/go/destination"
 	"github.com/linkerd/linkerd2-proxy-api/go/net"
@@ -26,16 +28,28 @@ const (
 // endpointTranslator satisfies EndpointUpdateListener and translates updates
 // into Destination.Get messages.
 type endpointTranslator struct {
-	controllerNS        string
-	identityTrustDomain string
-	enableH2Upgrade     bool
-	nodeTopologyZone    string
-	defaultOpaquePorts  map[uint32]struct{}
+	controllerNS         string
+	identityTrustDomain  string
+	enableH2Upgrade      bool
+	nodeTopologyZone     string
+	defaultOpaquePorts   map[uint32]struct{}
+	opaquePortNamePrefix string
+	weights              endpointWeights
 
 	availableEndpoints watcher.AddressSet
 	filteredSnapshot   watcher.AddressSet
 	stream             pb.Destination_GetServer
 	log                *logging.Entry
+
+	// mu serializes the updates from the watcher with the updates of the
+	// weights of the endpoints in slow start
+	mu sync.Mutex
+	// slowStarting holds the endpoints whose weight was last sent while they
+	// were in slow start
+	slowStarting map[watcher.ID]struct{}
+	refresh      *time.Timer
+	stopped      bool
+	now          func() time.Time
 }
 
 func newEndpointTranslator(
@@ -44,7 +58,10 @@ func newEndpointTranslator(
 	enableH2Upgrade bool,
 	service string,
 	srcNodeName string,
+	srcZone string,
 	defaultOpaquePorts map[uint32]struct{},
+	opaquePortNamePrefix string,
+	weights endpointWeights,
 	nodes coreinformers.NodeInformer,
 	stream pb.Destination_GetServer,
 	log *logging.Entry,
@@ -54,28 +71,40 @@ func newEndpointTranslator(
 		"service":   service,
 	})
 
-	nodeTopologyZone, err := getNodeTopologyZone(nodes, srcNodeName)
-	if err != nil {
-		log.Errorf("Failed to get node topology zone for node %s: %s", srcNodeName, err)
+	// the zone sent by the client takes precedence over the zone of its node
+	nodeTopologyZone := srcZone
+	if nodeTopologyZone == "" {
+		var err error
+		nodeTopologyZone, err = getNodeTopologyZone(nodes, srcNodeName)
+		if err != nil {
+			log.Errorf("Failed to get node topology zone for node %s: %s", srcNodeName, err)
+		}
 	}
 	availableEndpoints := newEmptyAddressSet()
 
 	filteredSnapshot := newEmptyAddressSet()
 
 	return &endpointTranslator{
-		controllerNS,
-		identityTrustDomain,
-		enableH2Upgrade,
-		nodeTopologyZone,
-		defaultOpaquePorts,
-		availableEndpoints,
-		filteredSnapshot,
-		stream,
-		log,
+		controllerNS:         controllerNS,
+		identityTrustDomain:  identityTrustDomain,
+		enableH2Upgrade:      enableH2Upgrade,
+		nodeTopologyZone:     nodeTopologyZone,
+		defaultOpaquePorts:   defaultOpaquePorts,
+		opaquePortNamePrefix: opaquePortNamePrefix,
+		weights:              weights,
+		availableEndpoints:   availableEndpoints,
+		filteredSnapshot:     filteredSnapshot,
+		stream:               stream,
+		log:                  log,
+		slowStarting:         make(map[watcher.ID]struct{}),
+		now:                  time.Now,
 	}
 }
 
 func (et *endpointTranslator) Add(set watcher.AddressSet) {
+	et.mu.Lock()
+	defer et.mu.Unlock()
+
 	for id, address := range set.Addresses {
 		et.availableEndpoints.Addresses[id] = address
 	}
@@ -84,6 +113,9 @@ func (et *endpointTranslator) Add(set watcher.AddressSet) {
 }
 
 func (et *endpointTranslator) Remove(set watcher.AddressSet) {
+	et.mu.Lock()
+	defer et.mu.Unlock()
+
 	for id := range set.Addresses {
 		delete(et.availableEndpoints.Addresses, id)
 	}
@@ -192,6 +224,9 @@ func (et *endpointTranslator) diffEndpoints(filtered watcher.AddressSet) (watche
 }
 
 func (et *endpointTranslator) NoEndpoints(exists bool) {
+	et.mu.Lock()
+	defer et.mu.Unlock()
+
 	et.log.Debugf("NoEndpoints(%+v)", exists)
 
 	et.availableEndpoints.Addresses = map[watcher.ID]watcher.Address{}
@@ -212,15 +247,16 @@ func (et *endpointTranslator) NoEndpoints(exists bool) {
 }
 
 func (et *endpointTranslator) sendClientAdd(set watcher.AddressSet) {
+	now := et.now()
 	addrs := []*pb.WeightedAddr{}
-	for _, address := range set.Addresses {
+	for id, address := range set.Addresses {
 		var (
 			wa          *pb.WeightedAddr
 			opaquePorts map[uint32]struct{}
 			err         error
 		)
 		if address.Pod != nil {
-			opaquePorts, err = getAnnotatedOpaquePorts(address.Pod, et.defaultOpaquePorts)
+			opaquePorts, err = getAnnotatedOpaquePorts(address.Pod, et.defaultOpaquePorts, et.opaquePortNamePrefix)
 			if err != nil {
 				et.log.Errorf("failed to get opaque ports for pod %s/%s: %s", address.Pod.Namespace, address.Pod.Name, err)
 			}
@@ -264,8 +300,15 @@ func (et *endpointTranslator) sendClientAdd(set watcher.AddressSet) {
 			et.log.Errorf("Failed to translate endpoints to weighted addr: %s", err)
 			continue
 		}
+
+		var slowStarting bool
+		wa.Weight, slowStarting = et.weight(address, now)
+		if slowStarting {
+			et.slowStarting[id] = struct{}{}
+		}
 		addrs = append(addrs, wa)
 	}
+	et.scheduleSlowStartRefresh()
 
 	add := &pb.Update{Update: &pb.Update_Add{
 		Add: &pb.WeightedAddrSet{
@@ -304,7 +347,7 @@ func (et *endpointTranslator) sendClientRemove(set watcher.AddressSet) {
 }
 
 func toAddr(address watcher.Address) (*net.TcpAddress, error) {
-	ip, err := addr.ParseProxyIPV4(address.IP)
+	ip, err := addr.ParseProxyIP(address.IP)
 	if err != nil {
 		return nil, err
 	}
@@ -330,6 +373,7 @@ func createWeightedAddr(address watcher.Address, opaquePorts map[uint32]struct{}
 	// If the address is not backed by a pod, there is no additional metadata
 	// to add.
 	if address.Pod == nil {
+		addTopologyLabels(weightedAddr.MetricLabels, address)
 		return &weightedAddr, nil
 	}
 
@@ -341,6 +385,7 @@ func createWeightedAddr(address watcher.Address, opaquePorts map[uint32]struct{}
 	controllerNSLabel := address.Pod.Labels[k8s.ControllerNSLabel]
 	sa, ns := k8s.GetServiceAccountAndNS(address.Pod)
 	weightedAddr.MetricLabels = k8s.GetPodLabels(address.OwnerKind, address.OwnerName, address.Pod)
+	addTopologyLabels(weightedAddr.MetricLabels, address)
 	_, isSkippedInboundPort := skippedInboundPorts[address.Port]
 
 	// If the pod is controlled by any Linkerd control plane, then it can be
@@ -392,6 +437,18 @@ func createWeightedAddr(address watcher.Address, opaquePorts map[uint32]struct{}
 	return &weightedAddr, nil
 }
 
+// addTopologyLabels adds the zone and node of the address to its metric
+// labels, so that the proxies' metrics can be broken down by the zone of
+// their destinations, e.g. to analyze the cost of cross-zone traffic
+func addTopologyLabels(labels map[string]string, address watcher.Address) {
+	if address.Zone != "" {
+		labels["zone"] = address.Zone
+	}
+	if address.NodeName != "" {
+		labels["node"] = address.NodeName
+	}
+}
+
 func getNodeTopologyZone(nodes coreinformers.NodeInformer, srcNode string) (string, error) {
 	node, err := nodes.Lister().Get(srcNode)
 	if err != nil {
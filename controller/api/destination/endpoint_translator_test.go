@@ -132,6 +132,12 @@ var (
 )
 
 func makeEndpointTranslator(t *testing.T) (*mockDestinationGetServer, *endpointTranslator) {
+	return makeEndpointTranslatorInZone(t, "")
+}
+
+// makeEndpointTranslatorInZone creates a translator for a client running on
+// a node of the west-1a zone, which sent the given zone in its context token
+func makeEndpointTranslatorInZone(t *testing.T, srcZone string) (*mockDestinationGetServer, *endpointTranslator) {
 	k8sAPI, err := pkgk8s.NewFakeAPI(`
 apiVersion: v1
 kind: Node
@@ -162,7 +168,10 @@ metadata:
 		true,
 		"service-name.service-ns",
 		"test-123",
+		srcZone,
 		map[uint32]struct{}{},
+		"",
+		defaultEndpointWeights,
 		k8sAPI.Node(),
 		mockGetServer,
 		logging.WithField("test", t.Name()),
@@ -340,6 +349,28 @@ func TestEndpointTranslatorForPods(t *testing.T) {
 		}
 	})
 
+	t.Run("Sends the zone and node of the addresses as metric labels", func(t *testing.T) {
+		mockGetServer, translator := makeEndpointTranslator(t)
+
+		zonedPod := normalPod
+		zonedPod.Zone = "west-1a"
+		zonedPod.NodeName = "node-1"
+		translator.Add(mkAddressSetForPods(zonedPod))
+
+		actualMetricLabels := mockGetServer.updatesReceived[0].GetAdd().Addrs[0].MetricLabels
+		expectedMetricLabels := map[string]string{
+			"pod":                   "pod1",
+			"replicationcontroller": "rc-name",
+			"serviceaccount":        "serviceaccount-name",
+			"control_plane_ns":      "linkerd",
+			"zone":                  "west-1a",
+			"node":                  "node-1",
+		}
+		if !reflect.DeepEqual(actualMetricLabels, expectedMetricLabels) {
+			t.Fatalf("Expected metric labels sent to be [%v] but was [%v]", expectedMetricLabels, actualMetricLabels)
+		}
+	})
+
 	t.Run("Sends TlsIdentity when enabled", func(t *testing.T) {
 		expectedTLSIdentity := &pb.TlsIdentity_DnsLikeIdentity{
 			Name: "serviceaccount-name.ns.serviceaccount.identity.linkerd.trust.domain",
@@ -422,6 +453,16 @@ func TestEndpointTranslatorForZonedAddresses(t *testing.T) {
 			t.Fatalf("Expecting [%d] updates, got [%d]. Updates: %v", expectedNumUpdates, actualNumUpdates, mockGetServer.updatesReceived)
 		}
 	})
+	t.Run("Filters addresses by the zone sent by the client", func(t *testing.T) {
+		mockGetServer, translator := makeEndpointTranslatorInZone(t, "west-1b")
+
+		translator.Add(mkAddressSetForServices(west1aAddress, west1bAddress))
+
+		addrs := mockGetServer.updatesReceived[0].GetAdd().GetAddrs()
+		if len(addrs) != 1 || addrs[0].GetAddr().GetPort() != west1bAddress.Port {
+			t.Fatalf("Expected only the west-1b address to be added, got %v", addrs)
+		}
+	})
 }
 
 func mkAddressSetForServices(gatewayAddresses ...watcher.Address) watcher.AddressSet {
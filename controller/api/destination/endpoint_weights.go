@@ -0,0 +1,153 @@
+package destination
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/destination/watcher"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	labels "github.com/linkerd/linkerd2/pkg/k8s"
+	logging "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// minSlowStartFactor is the fraction of its weight an endpoint gets right
+	// after its pod becomes ready
+	minSlowStartFactor = 0.1
+	// slowStartSteps is the number of times the weights of the endpoints in
+	// slow start are updated over the slow start duration
+	slowStartSteps = 10
+	// minSlowStartStep bounds how often the weights are updated
+	minSlowStartStep = time.Second
+)
+
+// endpointWeights configures how the weights of a service's endpoints are
+// scaled down from the default weight, to hint the proxy's balancer
+type endpointWeights struct {
+	// crossZoneFactor scales the weight of the endpoints in a different zone
+	// than the client; 1 when not configured
+	crossZoneFactor float64
+	// slowStart is the duration over which the weight of the endpoints is
+	// ramped up after their pod becomes ready; 0 when not configured
+	slowStart time.Duration
+}
+
+var defaultEndpointWeights = endpointWeights{crossZoneFactor: 1}
+
+// getEndpointWeights reads the weights configuration from the annotations of
+// the service. Invalid annotations are ignored.
+func getEndpointWeights(k8sAPI *k8s.API, id watcher.ServiceID, log *logging.Entry) endpointWeights {
+	weights := defaultEndpointWeights
+	svc, err := k8sAPI.Svc().Lister().Services(id.Namespace).Get(id.Name)
+	if err != nil {
+		return weights
+	}
+
+	if override, ok := svc.Annotations[labels.CrossZoneWeightAnnotation]; ok {
+		percent, err := strconv.ParseUint(override, 10, 32)
+		if err != nil || percent > 100 {
+			log.Warnf("unrecognized value used for the %s annotation of %s, a percentage is expected: %s",
+				labels.CrossZoneWeightAnnotation, id, override)
+		} else {
+			weights.crossZoneFactor = float64(percent) / 100
+		}
+	}
+
+	if override, ok := svc.Annotations[labels.SlowStartDurationAnnotation]; ok {
+		duration, err := time.ParseDuration(override)
+		if err != nil || duration < 0 {
+			log.Warnf("unrecognized value used for the %s annotation of %s, a duration is expected: %s",
+				labels.SlowStartDurationAnnotation, id, override)
+		} else {
+			weights.slowStart = duration
+		}
+	}
+
+	return weights
+}
+
+// weight returns the weight of the address for the client, and whether the
+// address is in slow start, in which case its weight will grow over time
+func (et *endpointTranslator) weight(address watcher.Address, now time.Time) (uint32, bool) {
+	weight := float64(defaultWeight)
+
+	if et.nodeTopologyZone != "" && address.Zone != "" && address.Zone != et.nodeTopologyZone {
+		weight *= et.weights.crossZoneFactor
+	}
+
+	slowStarting := false
+	if et.weights.slowStart > 0 && address.Pod != nil {
+		if ready, ok := podReadyTime(address.Pod); ok {
+			if elapsed := now.Sub(ready); elapsed < et.weights.slowStart {
+				slowStarting = true
+				weight *= math.Max(minSlowStartFactor, float64(elapsed)/float64(et.weights.slowStart))
+			}
+		}
+	}
+
+	// a null weight would take the endpoint out of the balancer altogether
+	return uint32(math.Max(1, math.Round(weight))), slowStarting
+}
+
+// scheduleSlowStartRefresh sends the endpoints in slow start again once their
+// weight has grown. It must be called with the translator's lock held.
+func (et *endpointTranslator) scheduleSlowStartRefresh() {
+	if et.stopped || et.refresh != nil || len(et.slowStarting) == 0 {
+		return
+	}
+
+	step := et.weights.slowStart / slowStartSteps
+	if step < minSlowStartStep {
+		step = minSlowStartStep
+	}
+	et.refresh = time.AfterFunc(step, et.refreshSlowStart)
+}
+
+func (et *endpointTranslator) refreshSlowStart() {
+	et.mu.Lock()
+	defer et.mu.Unlock()
+
+	et.refresh = nil
+	if et.stopped {
+		return
+	}
+
+	set := watcher.AddressSet{
+		Addresses: make(map[watcher.ID]watcher.Address),
+		Labels:    et.filteredSnapshot.Labels,
+	}
+	for id := range et.slowStarting {
+		if address, ok := et.filteredSnapshot.Addresses[id]; ok {
+			set.Addresses[id] = address
+		}
+	}
+	et.slowStarting = make(map[watcher.ID]struct{})
+
+	if len(set.Addresses) > 0 {
+		et.sendClientAdd(set)
+	}
+}
+
+// stop cancels the pending updates of the endpoints in slow start, once the
+// stream is closed
+func (et *endpointTranslator) stop() {
+	et.mu.Lock()
+	defer et.mu.Unlock()
+
+	et.stopped = true
+	if et.refresh != nil {
+		et.refresh.Stop()
+		et.refresh = nil
+	}
+}
+
+func podReadyTime(pod *corev1.Pod) (time.Time, bool) {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+			return condition.LastTransitionTime.Time, true
+		}
+	}
+	return time.Time{}, false
+}
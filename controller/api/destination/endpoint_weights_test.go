@@ -0,0 +1,168 @@
+package destination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/destination/watcher"
+	pkgk8s "github.com/linkerd/linkerd2/controller/k8s"
+	logging "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func readyPod(name string, readySince time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns",
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{
+				{
+					Type:               corev1.PodReady,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(readySince),
+				},
+			},
+		},
+	}
+}
+
+func TestGetEndpointWeights(t *testing.T) {
+	k8sAPI, err := pkgk8s.NewFakeAPI(`
+apiVersion: v1
+kind: Service
+metadata:
+  name: weighted
+  namespace: ns
+  annotations:
+    config.linkerd.io/cross-zone-weight: "25"
+    config.linkerd.io/slow-start-duration: 30s
+`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: invalid
+  namespace: ns
+  annotations:
+    config.linkerd.io/cross-zone-weight: "250"
+    config.linkerd.io/slow-start-duration: soon
+`)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+	k8sAPI.Sync(nil)
+	log := logging.WithField("test", t.Name())
+
+	for _, tc := range []struct {
+		service  string
+		expected endpointWeights
+	}{
+		{
+			service:  "weighted",
+			expected: endpointWeights{crossZoneFactor: 0.25, slowStart: 30 * time.Second},
+		},
+		{
+			service:  "invalid",
+			expected: defaultEndpointWeights,
+		},
+		{
+			service:  "missing",
+			expected: defaultEndpointWeights,
+		},
+	} {
+		tc := tc // pin
+		t.Run(tc.service, func(t *testing.T) {
+			weights := getEndpointWeights(k8sAPI, watcher.ServiceID{Namespace: "ns", Name: tc.service}, log)
+			if weights != tc.expected {
+				t.Fatalf("Expected weights %+v, got %+v", tc.expected, weights)
+			}
+		})
+	}
+}
+
+func TestEndpointTranslatorWeights(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Scales down the weight of the endpoints in other zones", func(t *testing.T) {
+		mockGetServer, translator := makeEndpointTranslator(t)
+		translator.weights = endpointWeights{crossZoneFactor: 0.25}
+
+		west1a := normalPod
+		west1a.Zone = "west-1a"
+		west1b := tlsOptionalPod
+		west1b.Zone = "west-1b"
+		translator.Add(mkAddressSetForPods(west1a, west1b, tlsDisabledPod))
+
+		weights := make(map[uint32]uint32)
+		for _, addr := range mockGetServer.updatesReceived[0].GetAdd().GetAddrs() {
+			weights[addr.GetAddr().GetPort()] = addr.GetWeight()
+		}
+		expected := map[uint32]uint32{
+			west1a.Port:         defaultWeight,
+			west1b.Port:         defaultWeight / 4,
+			tlsDisabledPod.Port: defaultWeight,
+		}
+		for port, weight := range expected {
+			if weights[port] != weight {
+				t.Fatalf("Expected weight %d for port %d, got %d", weight, port, weights[port])
+			}
+		}
+	})
+
+	t.Run("Ramps up the weight of the endpoints in slow start", func(t *testing.T) {
+		mockGetServer, translator := makeEndpointTranslator(t)
+		defer translator.stop()
+		translator.weights = endpointWeights{crossZoneFactor: 1, slowStart: 100 * time.Second}
+		translator.now = func() time.Time { return now }
+
+		started := watcher.Address{IP: "1.1.1.1", Port: 1, Pod: readyPod("started", now.Add(-time.Hour))}
+		starting := watcher.Address{IP: "1.1.1.2", Port: 2, Pod: readyPod("starting", now.Add(-50*time.Second))}
+		justReady := watcher.Address{IP: "1.1.1.3", Port: 3, Pod: readyPod("just-ready", now)}
+		translator.Add(mkAddressSetForPods(started, starting, justReady))
+
+		weights := make(map[uint32]uint32)
+		for _, addr := range mockGetServer.updatesReceived[0].GetAdd().GetAddrs() {
+			weights[addr.GetAddr().GetPort()] = addr.GetWeight()
+		}
+		expected := map[uint32]uint32{
+			started.Port:   defaultWeight,
+			starting.Port:  defaultWeight / 2,
+			justReady.Port: defaultWeight / 10,
+		}
+		for port, weight := range expected {
+			if weights[port] != weight {
+				t.Fatalf("Expected weight %d for port %d, got %d", weight, port, weights[port])
+			}
+		}
+
+		// once the slow start is over, only the endpoints that were in slow
+		// start are sent again
+		translator.now = func() time.Time { return now.Add(time.Minute) }
+		translator.refreshSlowStart()
+
+		if len(mockGetServer.updatesReceived) != 2 {
+			t.Fatalf("Expected 2 updates, got %d: %v", len(mockGetServer.updatesReceived), mockGetServer.updatesReceived)
+		}
+		addrs := mockGetServer.updatesReceived[1].GetAdd().GetAddrs()
+		if len(addrs) != 2 {
+			t.Fatalf("Expected 2 addresses to be sent again, got %v", addrs)
+		}
+		for _, addr := range addrs {
+			expected := defaultWeight
+			if addr.GetAddr().GetPort() == justReady.Port {
+				expected = defaultWeight * 6 / 10
+			}
+			if addr.GetWeight() != expected {
+				t.Fatalf("Expected weight %d for port %d, got %d", expected, addr.GetAddr().GetPort(), addr.GetWeight())
+			}
+		}
+
+		// the endpoint still in slow start is sent again on the next refresh
+		translator.refreshSlowStart()
+		if len(mockGetServer.updatesReceived) != 3 || len(mockGetServer.updatesReceived[2].GetAdd().GetAddrs()) != 1 {
+			t.Fatalf("Expected only the endpoint in slow start to be sent again, got %v", mockGetServer.updatesReceived)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package destination
+
+import (
+	"strings"
+
+	pb "github.com/linkerd/linkerd2-proxy-api/go/destination"
+	"github.com/linkerd/linkerd2/controller/api/destination/watcher"
+	logging "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// isExternalName returns whether the host is the fully-qualified name of a
+// destination outside of the cluster, such as api.example.com, whose
+// ServiceProfile, if any, is named after it
+func (s *server) isExternalName(host string) bool {
+	if !strings.Contains(host, ".") {
+		return false
+	}
+	// the names under the cluster domains that aren't services are invalid
+	// rather than external
+	for _, clusterDomain := range s.clusterDomains() {
+		if hasSuffix(strings.Split(host, "."), strings.Split(clusterDomain, ".")) {
+			return false
+		}
+	}
+	return len(validation.IsDNS1123Subdomain(host)) == 0
+}
+
+// getExternalProfile streams the ServiceProfile of an external destination.
+// The profile in the namespace of the client takes precedence over the one in
+// the controller's namespace, which applies to all the clients.
+//
+// The profiles of external destinations have no fully-qualified name, so that
+// the proxy forwards the requests to their original destination rather than
+// resolving it.
+func (s *server) getExternalProfile(
+	dest *pb.GetDestination,
+	host string,
+	port uint32,
+	ctxToken contextToken,
+	stream *queuedGetProfileStream,
+	evicted <-chan struct{},
+	log *logging.Entry,
+) error {
+	log = log.WithField("external", host)
+	translator := newProfileTranslator(stream, log, "", port)
+	primary, secondary := newFallbackProfileListener(translator)
+
+	if ctxToken.Ns != "" && ctxToken.Ns != s.controllerNS {
+		profile := watcher.ProfileID{Name: host, Namespace: ctxToken.Ns}
+		if err := s.profiles.Subscribe(profile, primary); err != nil {
+			log.Warnf("Failed to subscribe to profile %s: %s", host, err)
+			return err
+		}
+		defer s.profiles.Unsubscribe(profile, primary)
+	}
+
+	streamsCounter.WithLabelValues("get_profile", s.clientZone(ctxToken)).Inc()
+
+	profile := watcher.ProfileID{Name: host, Namespace: s.controllerNS}
+	if err := s.profiles.Subscribe(profile, secondary); err != nil {
+		log.Warnf("Failed to subscribe to profile %s: %s", host, err)
+		return err
+	}
+	defer s.profiles.Unsubscribe(profile, secondary)
+
+	select {
+	case <-s.shutdown:
+	case <-stream.Context().Done():
+		log.Debugf("GetProfile(%+v) cancelled", dest)
+	case <-evicted:
+		log.Debugf("GetProfile(%+v) evicted", dest)
+		return errAuthorityEvicted(dest.GetPath())
+	case <-stream.queue.reset:
+		return errSlowConsumer(dest.GetPath())
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package destination
+
+import (
+	"strconv"
+
+	"github.com/linkerd/linkerd2/controller/k8s"
+	labels "github.com/linkerd/linkerd2/pkg/k8s"
+	logging "github.com/sirupsen/logrus"
+)
+
+// getH2Upgrade returns whether the endpoints of the service are hinted to
+// support the upgrade of HTTP/1 requests to HTTP/2. The annotation of the
+// service takes precedence over the one of its namespace, which takes
+// precedence over the control plane's setting. An empty service only looks up
+// the namespace. Invalid annotations are ignored.
+func getH2Upgrade(k8sAPI *k8s.API, namespace, service string, enableH2Upgrade bool, log *logging.Entry) bool {
+	if service != "" {
+		svc, err := k8sAPI.Svc().Lister().Services(namespace).Get(service)
+		if err == nil {
+			if enabled, ok := parseH2UpgradeAnnotation(svc.Annotations, "service "+namespace+"/"+service, log); ok {
+				return enabled
+			}
+		}
+	}
+
+	ns, err := k8sAPI.NS().Lister().Get(namespace)
+	if err == nil {
+		if enabled, ok := parseH2UpgradeAnnotation(ns.Annotations, "namespace "+namespace, log); ok {
+			return enabled
+		}
+	}
+
+	return enableH2Upgrade
+}
+
+func parseH2UpgradeAnnotation(annotations map[string]string, resource string, log *logging.Entry) (bool, bool) {
+	override, ok := annotations[labels.H2UpgradeAnnotation]
+	if !ok {
+		return false, false
+	}
+	enabled, err := strconv.ParseBool(override)
+	if err != nil {
+		log.Warnf("unrecognized value used for the %s annotation of %s, true or false is expected: %s",
+			labels.H2UpgradeAnnotation, resource, override)
+		return false, false
+	}
+	return enabled, true
+}
@@ -0,0 +1,117 @@
+package destination
+
+import (
+	"testing"
+
+	pkgk8s "github.com/linkerd/linkerd2/controller/k8s"
+	logging "github.com/sirupsen/logrus"
+)
+
+func TestGetH2Upgrade(t *testing.T) {
+	k8sAPI, err := pkgk8s.NewFakeAPI(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: legacy
+  annotations:
+    config.linkerd.io/enable-h2-upgrade: "false"
+`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: forced
+  namespace: legacy
+  annotations:
+    config.linkerd.io/enable-h2-upgrade: "true"
+`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: plain
+  namespace: legacy
+`, `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: ns
+`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: disabled
+  namespace: ns
+  annotations:
+    config.linkerd.io/enable-h2-upgrade: "false"
+`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: invalid
+  namespace: ns
+  annotations:
+    config.linkerd.io/enable-h2-upgrade: "maybe"
+`)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+	k8sAPI.Sync(nil)
+	log := logging.WithField("test", t.Name())
+
+	for _, tc := range []struct {
+		name            string
+		namespace       string
+		service         string
+		enableH2Upgrade bool
+		expected        bool
+	}{
+		{
+			name:            "service annotation disables the upgrade",
+			namespace:       "ns",
+			service:         "disabled",
+			enableH2Upgrade: true,
+			expected:        false,
+		},
+		{
+			name:            "service annotation takes precedence over the namespace's",
+			namespace:       "legacy",
+			service:         "forced",
+			enableH2Upgrade: true,
+			expected:        true,
+		},
+		{
+			name:            "namespace annotation applies to its services",
+			namespace:       "legacy",
+			service:         "plain",
+			enableH2Upgrade: true,
+			expected:        false,
+		},
+		{
+			name:            "namespace annotation applies without a service",
+			namespace:       "legacy",
+			enableH2Upgrade: true,
+			expected:        false,
+		},
+		{
+			name:            "invalid annotation is ignored",
+			namespace:       "ns",
+			service:         "invalid",
+			enableH2Upgrade: true,
+			expected:        true,
+		},
+		{
+			name:            "global setting applies to missing services",
+			namespace:       "ns",
+			service:         "missing",
+			enableH2Upgrade: false,
+			expected:        false,
+		},
+	} {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			enabled := getH2Upgrade(k8sAPI, tc.namespace, tc.service, tc.enableH2Upgrade, log)
+			if enabled != tc.expected {
+				t.Fatalf("Expected H2 upgrade to be %t, got %t", tc.expected, enabled)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package destination
+
+import (
+	"github.com/linkerd/linkerd2/controller/api/destination/watcher"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	labels "github.com/linkerd/linkerd2/pkg/k8s"
+	logging "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// getLocalOrigin returns the local service at the origin of the mirror
+// service id, when the mirror loops back to this cluster, e.g. for a service
+// mirrored by a hub cluster and mirrored back from the hub. Resolving the
+// local service instead spares the clients the hops through the gateways.
+//
+// The origin is recognized by its UID, so the services of other clusters
+// having the same name are never mistaken for it.
+func getLocalOrigin(k8sAPI *k8s.API, id watcher.ServiceID, clusterDomain string, log *logging.Entry) (watcher.ServiceID, bool) {
+	svc, err := k8sAPI.Svc().Lister().Services(id.Namespace).Get(id.Name)
+	if err != nil {
+		return id, false
+	}
+
+	// the mirrors of headless services resolve to the hosts of the target
+	// cluster, which aren't looked up locally
+	if svc.Labels[labels.MirroredResourceLabel] != "true" ||
+		svc.Labels[labels.MirroredHeadlessSvcNameLabel] != "" ||
+		svc.Spec.ClusterIP == corev1.ClusterIPNone {
+		return id, false
+	}
+
+	uid, fqName := svc.Annotations[labels.OriginServiceUID], svc.Annotations[labels.OriginServiceFqName]
+	if uid == "" || fqName == "" {
+		return id, false
+	}
+
+	// an origin out of the cluster domain is in another cluster
+	origin, instanceID, err := parseK8sServiceName(fqName, clusterDomain)
+	if err != nil || instanceID != "" {
+		return id, false
+	}
+	originSvc, err := k8sAPI.Svc().Lister().Services(origin.Namespace).Get(origin.Name)
+	if err != nil || string(originSvc.UID) != uid {
+		return id, false
+	}
+
+	log.Debugf("Resolving the mirror service %s to its local origin %s", id, origin)
+	return origin, true
+}
@@ -0,0 +1,326 @@
+package destination
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/linkerd/linkerd2-proxy-api/go/destination"
+	"github.com/linkerd/linkerd2-proxy-api/go/net"
+	"github.com/linkerd/linkerd2/pkg/addr"
+	logging "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// number of updates buffered for a stream before the intermediate ones
+	// are dropped
+	sendQueueCapacity = 100
+
+	// amount of time a single send can block before the stream is reset
+	sendQueueTimeout = 30 * time.Second
+)
+
+// sendQueue decouples the watchers, which push updates from the informers'
+// event handlers, from the gRPC stream of a proxy, so that a proxy that stops
+// reading its stream can't block the updates of the other streams.
+//
+// Updates are buffered and sent by a dedicated goroutine. When the buffer is
+// full, the intermediate updates are dropped, only keeping the latest state;
+// when a send blocks for too long, reset is closed so that the stream is
+// closed.
+type sendQueue struct {
+	method   string
+	send     func(interface{}) error
+	compact  func([]interface{}) []interface{}
+	capacity int
+	timeout  time.Duration
+	log      *logging.Entry
+
+	mu      sync.Mutex
+	pending []interface{}
+	closed  bool
+	err     error
+	slow    bool
+
+	notify    chan struct{}
+	stopped   chan struct{}
+	reset     chan struct{}
+	resetOnce sync.Once
+}
+
+// queuedGetStream sends the updates of a Get stream through a sendQueue
+type queuedGetStream struct {
+	pb.Destination_GetServer
+	queue *sendQueue
+}
+
+// queuedGetProfileStream sends the updates of a GetProfile stream through a
+// sendQueue
+type queuedGetProfileStream struct {
+	pb.Destination_GetProfileServer
+	queue *sendQueue
+}
+
+func newSendQueue(
+	method string,
+	send func(interface{}) error,
+	compact func([]interface{}) []interface{},
+	capacity int,
+	timeout time.Duration,
+	log *logging.Entry,
+) *sendQueue {
+	q := &sendQueue{
+		method:   method,
+		send:     send,
+		compact:  compact,
+		capacity: capacity,
+		timeout:  timeout,
+		log:      log.WithField("component", "send-queue"),
+		notify:   make(chan struct{}, 1),
+		stopped:  make(chan struct{}),
+		reset:    make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+func newGetStream(stream pb.Destination_GetServer, log *logging.Entry) *queuedGetStream {
+	send := func(update interface{}) error {
+		return stream.Send(update.(*pb.Update))
+	}
+	return &queuedGetStream{
+		Destination_GetServer: stream,
+		queue:                 newSendQueue("get", send, compactUpdates, sendQueueCapacity, sendQueueTimeout, log),
+	}
+}
+
+func newGetProfileStream(stream pb.Destination_GetProfileServer, log *logging.Entry) *queuedGetProfileStream {
+	send := func(profile interface{}) error {
+		return stream.Send(profile.(*pb.DestinationProfile))
+	}
+	return &queuedGetProfileStream{
+		Destination_GetProfileServer: stream,
+		queue:                        newSendQueue("get_profile", send, compactProfiles, sendQueueCapacity, sendQueueTimeout, log),
+	}
+}
+
+func (s *queuedGetStream) Send(update *pb.Update) error {
+	return s.queue.enqueue(update)
+}
+
+func (s *queuedGetProfileStream) Send(profile *pb.DestinationProfile) error {
+	return s.queue.enqueue(profile)
+}
+
+// enqueue buffers an update, returning the error of the last send, if it
+// failed
+func (q *sendQueue) enqueue(update interface{}) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.err != nil {
+		return q.err
+	}
+	if q.closed {
+		return status.Error(codes.Canceled, "stream closed")
+	}
+
+	q.pending = append(q.pending, update)
+	if len(q.pending) > q.capacity {
+		compacted := q.compact(q.pending)
+		streamUpdatesDropped.WithLabelValues(q.method).Add(float64(len(q.pending) - len(compacted)))
+		if !q.slow {
+			q.slow = true
+			q.log.Warnf("Client isn't reading its %s stream, dropping intermediate updates", q.method)
+		}
+		q.pending = compacted
+	}
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// close stops the queue once its pending updates are sent, or right away
+// when the stream has been reset
+func (q *sendQueue) close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+
+	select {
+	case <-q.stopped:
+	case <-q.reset:
+	}
+}
+
+func (q *sendQueue) run() {
+	defer close(q.stopped)
+
+	for {
+		q.mu.Lock()
+		if len(q.pending) == 0 {
+			if q.slow {
+				// the client caught up, so that the updates are dropped and
+				// logged again if it falls behind again
+				q.slow = false
+				q.log.Infof("Client caught up with its %s stream", q.method)
+			}
+			closed := q.closed
+			q.mu.Unlock()
+			if closed {
+				return
+			}
+			<-q.notify
+			continue
+		}
+		update := q.pending[0]
+		q.pending = q.pending[1:]
+		q.mu.Unlock()
+
+		timer := time.AfterFunc(q.timeout, q.resetStream)
+		err := q.send(update)
+		timer.Stop()
+
+		if err != nil {
+			q.mu.Lock()
+			q.err = err
+			q.pending = nil
+			q.mu.Unlock()
+			q.log.Debugf("Failed to send %s update: %s", q.method, err)
+			return
+		}
+	}
+}
+
+func (q *sendQueue) resetStream() {
+	q.resetOnce.Do(func() {
+		q.log.Warnf("Client hasn't read its %s stream for %s, resetting it", q.method, q.timeout)
+		slowConsumerResets.WithLabelValues(q.method).Inc()
+		close(q.reset)
+	})
+}
+
+func errSlowConsumer(authority string) error {
+	return status.Errorf(codes.Unavailable, "client too slow to read the updates of %s", authority)
+}
+
+// compactProfiles only keeps the latest profile, as each one replaces the
+// previous ones
+func compactProfiles(pending []interface{}) []interface{} {
+	return pending[len(pending)-1:]
+}
+
+// compactUpdates merges endpoint updates into the ones leading to the same
+// state: the last NoEndpoints update, if any, followed by the net removals
+// and additions of the updates after it. The additions are grouped by their
+// metric labels, so that each address keeps the labels it was last added with.
+func compactUpdates(pending []interface{}) []interface{} {
+	type addition struct {
+		addr   *pb.WeightedAddr
+		labels string
+	}
+	var (
+		noEndpoints *pb.Update
+		order       []string
+		added       map[string]addition
+		removed     map[string]*net.TcpAddress
+		labelSets   map[string]map[string]string
+	)
+	reset := func() {
+		order = []string{}
+		added = map[string]addition{}
+		removed = map[string]*net.TcpAddress{}
+		labelSets = map[string]map[string]string{}
+	}
+	reset()
+	track := func(key string) {
+		if _, ok := added[key]; ok {
+			return
+		}
+		if _, ok := removed[key]; ok {
+			return
+		}
+		order = append(order, key)
+	}
+
+	for _, p := range pending {
+		update := p.(*pb.Update)
+		switch u := update.GetUpdate().(type) {
+		case *pb.Update_NoEndpoints:
+			noEndpoints = update
+			reset()
+		case *pb.Update_Add:
+			labels := metricLabelsKey(u.Add.GetMetricLabels())
+			labelSets[labels] = u.Add.GetMetricLabels()
+			for _, wa := range u.Add.GetAddrs() {
+				key := addr.ProxyAddressToString(wa.GetAddr())
+				track(key)
+				delete(removed, key)
+				added[key] = addition{wa, labels}
+			}
+		case *pb.Update_Remove:
+			for _, a := range u.Remove.GetAddrs() {
+				key := addr.ProxyAddressToString(a)
+				track(key)
+				delete(added, key)
+				removed[key] = a
+			}
+		}
+	}
+
+	compacted := []interface{}{}
+	if noEndpoints != nil {
+		compacted = append(compacted, noEndpoints)
+	}
+	removals := []*net.TcpAddress{}
+	// the additions by label set, in the order the label sets first appear
+	additions := map[string][]*pb.WeightedAddr{}
+	labelsOrder := []string{}
+	for _, key := range order {
+		if a, ok := removed[key]; ok {
+			removals = append(removals, a)
+		} else if a, ok := added[key]; ok {
+			if _, ok := additions[a.labels]; !ok {
+				labelsOrder = append(labelsOrder, a.labels)
+			}
+			additions[a.labels] = append(additions[a.labels], a.addr)
+		}
+	}
+	if len(removals) > 0 {
+		compacted = append(compacted, &pb.Update{Update: &pb.Update_Remove{
+			Remove: &pb.AddrSet{Addrs: removals},
+		}})
+	}
+	for _, labels := range labelsOrder {
+		compacted = append(compacted, &pb.Update{Update: &pb.Update_Add{
+			Add: &pb.WeightedAddrSet{Addrs: additions[labels], MetricLabels: labelSets[labels]},
+		}})
+	}
+	return compacted
+}
+
+// metricLabelsKey returns a string identifying a set of metric labels
+func metricLabelsKey(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%q=%q,", k, labels[k])
+	}
+	return b.String()
+}
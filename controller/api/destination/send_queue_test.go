@@ -0,0 +1,198 @@
+package destination
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	pb "github.com/linkerd/linkerd2-proxy-api/go/destination"
+	"github.com/linkerd/linkerd2-proxy-api/go/net"
+	"github.com/linkerd/linkerd2/pkg/addr"
+	logging "github.com/sirupsen/logrus"
+)
+
+func TestCompactUpdates(t *testing.T) {
+	tcpAddr := func(ip string) *net.TcpAddress {
+		a, err := toAddress(ip, 8080)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return a
+	}
+	add := func(ips ...string) *pb.Update {
+		addrs := []*pb.WeightedAddr{}
+		for _, ip := range ips {
+			addrs = append(addrs, &pb.WeightedAddr{Addr: tcpAddr(ip), Weight: defaultWeight})
+		}
+		return &pb.Update{Update: &pb.Update_Add{Add: &pb.WeightedAddrSet{Addrs: addrs}}}
+	}
+	remove := func(ips ...string) *pb.Update {
+		addrs := []*net.TcpAddress{}
+		for _, ip := range ips {
+			addrs = append(addrs, tcpAddr(ip))
+		}
+		return &pb.Update{Update: &pb.Update_Remove{Remove: &pb.AddrSet{Addrs: addrs}}}
+	}
+	labeled := func(zone string, ips ...string) *pb.Update {
+		update := add(ips...)
+		update.GetAdd().MetricLabels = map[string]string{"zone": zone}
+		return update
+	}
+	noEndpoints := &pb.Update{Update: &pb.Update_NoEndpoints{NoEndpoints: &pb.NoEndpoints{Exists: true}}}
+
+	testCases := []struct {
+		name     string
+		pending  []*pb.Update
+		expected []*pb.Update
+	}{
+		{
+			name:     "net additions and removals",
+			pending:  []*pb.Update{add("10.0.0.1", "10.0.0.2"), remove("10.0.0.1"), add("10.0.0.3"), remove("10.0.0.4"), add("10.0.0.4")},
+			expected: []*pb.Update{remove("10.0.0.1"), add("10.0.0.2", "10.0.0.3", "10.0.0.4")},
+		},
+		{
+			name:     "updates before the last NoEndpoints are dropped",
+			pending:  []*pb.Update{add("10.0.0.1"), noEndpoints, add("10.0.0.2"), add("10.0.0.3"), remove("10.0.0.2")},
+			expected: []*pb.Update{noEndpoints, remove("10.0.0.2"), add("10.0.0.3")},
+		},
+		{
+			name:     "additions are grouped by metric labels",
+			pending:  []*pb.Update{labeled("west", "10.0.0.1"), labeled("east", "10.0.0.2"), labeled("west", "10.0.0.3"), labeled("east", "10.0.0.1")},
+			expected: []*pb.Update{labeled("east", "10.0.0.1", "10.0.0.2"), labeled("west", "10.0.0.3")},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			pending := []interface{}{}
+			for _, update := range tc.pending {
+				pending = append(pending, update)
+			}
+			compacted := compactUpdates(pending)
+			if len(compacted) != len(tc.expected) {
+				t.Fatalf("Expected %d updates, got %d: %v", len(tc.expected), len(compacted), compacted)
+			}
+			for i, expected := range tc.expected {
+				if !reflect.DeepEqual(updateAddrs(compacted[i].(*pb.Update)), updateAddrs(expected)) {
+					t.Fatalf("Expected update %d to be %v, got %v", i, expected, compacted[i])
+				}
+				if labels := compacted[i].(*pb.Update).GetAdd().GetMetricLabels(); !reflect.DeepEqual(labels, expected.GetAdd().GetMetricLabels()) {
+					t.Fatalf("Expected update %d to have the labels %v, got %v", i, expected.GetAdd().GetMetricLabels(), labels)
+				}
+			}
+		})
+	}
+}
+
+func TestSendQueue(t *testing.T) {
+	log := logging.WithField("test", t.Name())
+
+	t.Run("Drops the intermediate updates of a slow consumer", func(t *testing.T) {
+		unblock := make(chan struct{})
+		sent := make(chan interface{}, 10)
+		send := func(update interface{}) error {
+			<-unblock
+			sent <- update
+			return nil
+		}
+		queue := newSendQueue("get_profile", send, compactProfiles, 2, time.Minute, log)
+
+		for i := 0; i < 5; i++ {
+			if err := queue.enqueue(i); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+		close(unblock)
+		queue.close()
+		close(sent)
+
+		received := []interface{}{}
+		for update := range sent {
+			received = append(received, update)
+		}
+		// the first update may already be in flight when the others are
+		// queued, on top of the capacity of the queue; the latest one must
+		// always be sent
+		if len(received) == 0 || len(received) > 3 || received[len(received)-1] != 4 {
+			t.Fatalf("Expected the latest update to be sent, got %v", received)
+		}
+	})
+
+	t.Run("Clears the slow flag once the consumer caught up", func(t *testing.T) {
+		unblock := make(chan struct{})
+		sent := make(chan interface{}, 10)
+		send := func(update interface{}) error {
+			<-unblock
+			sent <- update
+			return nil
+		}
+		queue := newSendQueue("get_profile", send, compactProfiles, 1, time.Minute, log)
+
+		for i := 0; i < 3; i++ {
+			if err := queue.enqueue(i); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+		queue.mu.Lock()
+		slow := queue.slow
+		queue.mu.Unlock()
+		if !slow {
+			t.Fatal("Expected the consumer to be flagged as slow")
+		}
+
+		close(unblock)
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			queue.mu.Lock()
+			slow = queue.slow
+			queue.mu.Unlock()
+			if !slow {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("Expected the slow flag to be cleared once the queue drained")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		queue.close()
+	})
+
+	t.Run("Resets the stream of a consumer that stopped reading", func(t *testing.T) {
+		unblock := make(chan struct{})
+		defer close(unblock)
+		send := func(update interface{}) error {
+			<-unblock
+			return nil
+		}
+		queue := newSendQueue("get", send, compactUpdates, 2, 10*time.Millisecond, log)
+
+		if err := queue.enqueue(&pb.Update{}); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		select {
+		case <-queue.reset:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected the stream to be reset")
+		}
+		// closing must not wait for the blocked send
+		queue.close()
+	})
+}
+
+func updateAddrs(update *pb.Update) []string {
+	addrs := []string{}
+	switch u := update.GetUpdate().(type) {
+	case *pb.Update_NoEndpoints:
+		addrs = append(addrs, "none")
+	case *pb.Update_Add:
+		for _, wa := range u.Add.GetAddrs() {
+			addrs = append(addrs, "+"+addr.ProxyAddressToString(wa.GetAddr()))
+		}
+	case *pb.Update_Remove:
+		for _, a := range u.Remove.GetAddrs() {
+			addrs = append(addrs, "-"+addr.ProxyAddressToString(a))
+		}
+	}
+	return addrs
+}
@@ -3,8 +3,10 @@ package destination
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -20,24 +22,43 @@ import (
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 )
 
+// DefaultPort is the port of the authorities without one, unless configured
+// otherwise
+const DefaultPort watcher.Port = 80
+
 type (
 	server struct {
 		pb.UnimplementedDestinationServer
 
-		endpoints   *watcher.EndpointsWatcher
-		opaquePorts *watcher.OpaquePortsWatcher
-		profiles    *watcher.ProfileWatcher
-		servers     *watcher.ServerWatcher
-		nodes       coreinformers.NodeInformer
-
-		enableH2Upgrade     bool
-		controllerNS        string
-		identityTrustDomain string
-		clusterDomain       string
-		defaultOpaquePorts  map[uint32]struct{}
+		endpoints       *watcher.EndpointsWatcher
+		opaquePorts     *watcher.OpaquePortsWatcher
+		profiles        *watcher.ProfileWatcher
+		defaultProfiles *watcher.DefaultProfileWatcher
+		servers         *watcher.ServerWatcher
+		nodes           coreinformers.NodeInformer
+
+		enableH2Upgrade        bool
+		enableEndpointSlices   bool
+		enableExternalProfiles bool
+		controllerNS           string
+		// config holds the identity trust domain, cluster domain and default
+		// opaque ports
+		config               *serverConfig
+		opaquePortNamePrefix string
+		// defaultPort is 0 when authorities must include a port
+		defaultPort watcher.Port
+
+		// authorities is nil when the number of authorities per client isn't
+		// limited
+		authorities *authorityLimiter
+
+		// streamRing is nil when stream hashing is disabled
+		streamRing *replicaRing
 
 		k8sAPI   *k8s.API
 		log      *logging.Entry
@@ -52,11 +73,49 @@ type (
 // destination paths to be of the form:
 // <service>.<namespace>.svc.cluster.local:<port>
 //
-// If the port is omitted, 80 is used as a default.  If the namespace is
-// omitted, "default" is used as a default.append
+// If the port is omitted, defaultPort is used, unless it's 0 in which case
+// the authority is rejected.  If the namespace is omitted, "default" is used as
+// a default.
+//
+// SRV-style paths, of the form
+// _<port-name>._<protocol>.<service>.<namespace>.svc.cluster.local, are
+// resolved to the port of the service with that name and protocol.
 //
 // Addresses for the given destination are fetched from the Kubernetes Endpoints
 // API.
+//
+// When maxAuthoritiesPerClient is positive, a client subscribing to more
+// distinct authorities than that gets the streams for its least recently
+// used authorities closed with a ResourceExhausted status.
+//
+// When opaquePortNamePrefix is not empty, the container ports whose name starts
+// with it are opaque, in addition to the annotated or default opaque ports.
+//
+// When enableExternalProfiles is true, the profiles of destinations outside of
+// the cluster, such as api.example.com:443, are looked up among the
+// ServiceProfiles named after them, in the client's namespace and then in the
+// controller's namespace. Otherwise, such authorities are rejected.
+//
+// The mirror services of services of this cluster, mirrored back from another
+// cluster, are resolved to the endpoints of the local services.
+//
+// clusterDomain may be a comma-separated list of cluster domain suffixes, the
+// names in any of them being resolved; the first one is the canonical domain
+// of the services.
+//
+// When configMapName is not empty, the identityTrustDomain, clusterDomain and
+// defaultOpaquePorts settings are overridden by the identityTrustDomain,
+// clusterDomain and defaultOpaquePorts keys of the ConfigMap with that name in
+// controllerNS, which is watched so that they're updated without a restart.
+// The ConfigMap is also re-read whenever reload receives a value. The changes
+// of the default opaque ports are pushed to the existing GetProfile streams;
+// the other settings apply to the new streams.
+//
+// When podName is not empty, streams are consistently hashed onto the running
+// replicas of the destination controller, podName identifying this one: the
+// streams for destinations owned by another replica are forwarded to it, so
+// that each destination is only watched by its owner. They're served locally
+// when the owner can't be reached.
 func NewServer(
 	addr string,
 	controllerNS string,
@@ -66,6 +125,13 @@ func NewServer(
 	k8sAPI *k8s.API,
 	clusterDomain string,
 	defaultOpaquePorts map[uint32]struct{},
+	opaquePortNamePrefix string,
+	defaultPort watcher.Port,
+	maxAuthoritiesPerClient int,
+	enableExternalProfiles bool,
+	configMapName string,
+	podName string,
+	reload <-chan struct{},
 	shutdown <-chan struct{},
 ) (*grpc.Server, error) {
 	log := logging.WithFields(logging.Fields{
@@ -79,23 +145,55 @@ func NewServer(
 		return nil, err
 	}
 
-	endpoints := watcher.NewEndpointsWatcher(k8sAPI, log, enableEndpointSlices)
-	opaquePorts := watcher.NewOpaquePortsWatcher(k8sAPI, log, defaultOpaquePorts)
+	// the protocols of the pods' ports set by the Servers are cached once for
+	// the endpoints and the profiles
+	protocols := watcher.NewProtocolCache(k8sAPI, log)
+	endpoints := watcher.NewEndpointsWatcher(k8sAPI, log, enableEndpointSlices, protocols)
+	opaquePorts := watcher.NewOpaquePortsWatcher(k8sAPI, log, defaultOpaquePorts, opaquePortNamePrefix)
 	profiles := watcher.NewProfileWatcher(k8sAPI, log)
-	servers := watcher.NewServerWatcher(k8sAPI, log)
+	defaultProfiles := watcher.NewDefaultProfileWatcher(k8sAPI, log)
+	servers := watcher.NewServerWatcher(k8sAPI, log, protocols)
+
+	var authorities *authorityLimiter
+	if maxAuthoritiesPerClient > 0 {
+		authorities = newAuthorityLimiter(maxAuthoritiesPerClient)
+	}
+
+	var streamRing *replicaRing
+	if podName != "" {
+		_, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		streamRing = newReplicaRing(k8sAPI, controllerNS, podName, port, log)
+	}
+
+	clusterDomains, err := parseClusterDomains(clusterDomain)
+	if err != nil {
+		return nil, err
+	}
+	config := newServerConfig(identityTrustDomain, clusterDomains, defaultOpaquePorts)
+	if configMapName != "" {
+		watchConfig(k8sAPI.Client, controllerNS, configMapName, config, opaquePorts, reload, shutdown)
+	}
 
 	srv := server{
 		pb.UnimplementedDestinationServer{},
 		endpoints,
 		opaquePorts,
 		profiles,
+		defaultProfiles,
 		servers,
 		k8sAPI.Node(),
 		enableH2Upgrade,
+		enableEndpointSlices,
+		enableExternalProfiles,
 		controllerNS,
-		identityTrustDomain,
-		clusterDomain,
-		defaultOpaquePorts,
+		config,
+		opaquePortNamePrefix,
+		defaultPort,
+		authorities,
+		streamRing,
 		k8sAPI,
 		log,
 		shutdown,
@@ -114,27 +212,42 @@ func (s *server) Get(dest *pb.GetDestination, stream pb.Destination_GetServer) e
 		log = s.log.WithField("remote", client.Addr)
 	}
 	log.Debugf("Get %s", dest.GetPath())
+	if s.k8sAPI.IsStale() {
+		log = log.WithField("stale", true)
+		log.Warnf("Get %s served from the last known state of the cluster", dest.GetPath())
+	}
+
+	evicted, release := s.authorities.acquire(streamClientKey(stream.Context(), client), dest.GetPath())
+	defer release()
+
+	if owner, ok := s.streamRing.owner(stream.Context(), dest.GetPath()); ok {
+		log.Debugf("Forwarding Get %s to %s", dest.GetPath(), owner.name)
+		err := s.forwardGet(owner, dest, stream, evicted)
+		if !errors.Is(err, errOwnerUnavailable) {
+			return err
+		}
+		log.Warnf("Serving Get %s locally: replica %s owning it is unavailable", dest.GetPath(), owner.name)
+	}
+
+	queued := newGetStream(stream, log)
+	defer queued.queue.close()
+	stream = queued
 
 	var token contextToken
 	if dest.GetContextToken() != "" {
 		token = s.parseContextToken(dest.GetContextToken())
+		log = log.WithFields(token.logFields())
 		log.Debugf("Dest token: %v", token)
 	}
 
-	translator := newEndpointTranslator(
-		s.controllerNS,
-		s.identityTrustDomain,
-		s.enableH2Upgrade,
-		dest.GetPath(),
-		token.NodeName,
-		s.defaultOpaquePorts,
-		s.nodes,
-		stream,
-		log,
-	)
+	authority, err := s.resolveSRVName(dest.GetPath())
+	if err != nil {
+		log.Debugf("Invalid SRV-style authority %s: %s", dest.GetPath(), err)
+		return status.Errorf(codes.InvalidArgument, "Invalid authority: %s", dest.GetPath())
+	}
 
 	// The host must be fully-qualified or be an IP address.
-	host, port, err := getHostAndPort(dest.GetPath())
+	host, port, err := getHostAndPort(authority, s.defaultPort)
 	if err != nil {
 		log.Debugf("Invalid service %s", dest.GetPath())
 		return status.Errorf(codes.InvalidArgument, "Invalid authority: %s", dest.GetPath())
@@ -145,12 +258,35 @@ func (s *server) Get(dest *pb.GetDestination, stream pb.Destination_GetServer) e
 		return status.Errorf(codes.InvalidArgument, "IP queries not supported by Get API: host=%s", host)
 	}
 
-	service, instanceID, err := parseK8sServiceName(host, s.clusterDomain)
+	service, instanceID, err := s.parseServiceName(host)
 	if err != nil {
 		log.Debugf("Invalid service %s", dest.GetPath())
 		return status.Errorf(codes.InvalidArgument, "Invalid authority: %s", dest.GetPath())
 	}
 
+	if instanceID == "" {
+		if origin, ok := getLocalOrigin(s.k8sAPI, service, s.clusterDomain(), log); ok {
+			service = origin
+		}
+	}
+
+	translator := newEndpointTranslator(
+		s.controllerNS,
+		s.identityTrustDomain(),
+		getH2Upgrade(s.k8sAPI, service.Namespace, service.Name, s.enableH2Upgrade, log),
+		dest.GetPath(),
+		token.NodeName,
+		token.Zone,
+		s.defaultOpaquePorts(),
+		s.opaquePortNamePrefix,
+		getEndpointWeights(s.k8sAPI, service, log),
+		s.nodes,
+		stream,
+		log,
+	)
+	defer translator.stop()
+	streamsCounter.WithLabelValues("get", translator.nodeTopologyZone).Inc()
+
 	err = s.endpoints.Subscribe(service, port, instanceID, translator)
 	if err != nil {
 		if _, ok := err.(watcher.InvalidService); ok {
@@ -166,6 +302,11 @@ func (s *server) Get(dest *pb.GetDestination, stream pb.Destination_GetServer) e
 	case <-s.shutdown:
 	case <-stream.Context().Done():
 		log.Debugf("Get %s cancelled", dest.GetPath())
+	case <-evicted:
+		log.Debugf("Get %s evicted", dest.GetPath())
+		return errAuthorityEvicted(dest.GetPath())
+	case <-queued.queue.reset:
+		return errSlowConsumer(dest.GetPath())
 	}
 
 	return nil
@@ -178,10 +319,40 @@ func (s *server) GetProfile(dest *pb.GetDestination, stream pb.Destination_GetPr
 		log = log.WithField("remote", client.Addr)
 	}
 	log.Debugf("GetProfile(%+v)", dest)
+	if s.k8sAPI.IsStale() {
+		log = log.WithField("stale", true)
+		log.Warnf("GetProfile %s served from the last known state of the cluster", dest.GetPath())
+	}
 
-	path := dest.GetPath()
-	// The host must be fully-qualified or be an IP address.
-	host, port, err := getHostAndPort(path)
+	evicted, release := s.authorities.acquire(streamClientKey(stream.Context(), client), dest.GetPath())
+	defer release()
+
+	if owner, ok := s.streamRing.owner(stream.Context(), dest.GetPath()); ok {
+		log.Debugf("Forwarding GetProfile %s to %s", dest.GetPath(), owner.name)
+		err := s.forwardGetProfile(owner, dest, stream, evicted)
+		if !errors.Is(err, errOwnerUnavailable) {
+			return err
+		}
+		log.Warnf("Serving GetProfile %s locally: replica %s owning it is unavailable", dest.GetPath(), owner.name)
+	}
+
+	queued := newGetProfileStream(stream, log)
+	defer queued.queue.close()
+	stream = queued
+
+	var ctxToken contextToken
+	if dest.GetContextToken() != "" {
+		ctxToken = s.parseContextToken(dest.GetContextToken())
+		log = log.WithFields(ctxToken.logFields())
+	}
+
+	path, err := s.resolveSRVName(dest.GetPath())
+	if err != nil {
+		log.Debugf("Invalid SRV-style authority %s", dest.GetPath())
+		return status.Errorf(codes.InvalidArgument, "invalid authority: %s", err)
+	}
+	// The host must be a service name or an IP address.
+	host, port, err := getHostAndPort(path, s.defaultPort)
 	if err != nil {
 		log.Debugf("Invalid authority %s", path)
 		return status.Errorf(codes.InvalidArgument, "invalid authority: %s", err)
@@ -201,7 +372,7 @@ func (s *server) GetProfile(dest *pb.GetDestination, stream pb.Destination_GetPr
 		}
 		if svcID != nil {
 			service = *svcID
-			fqn = fmt.Sprintf("%s.%s.svc.%s", service.Name, service.Namespace, s.clusterDomain)
+			fqn = fmt.Sprintf("%s.%s.svc.%s", service.Name, service.Namespace, s.clusterDomain())
 		} else {
 			// If the IP does not map to a service, check if it maps to a pod
 			pod, err := getPodByIP(s.k8sAPI, ip.String(), port, log)
@@ -209,18 +380,19 @@ func (s *server) GetProfile(dest *pb.GetDestination, stream pb.Destination_GetPr
 				return err
 			}
 
-			opaquePorts, err := getAnnotatedOpaquePorts(pod, s.defaultOpaquePorts)
+			opaquePorts, err := getAnnotatedOpaquePorts(pod, s.defaultOpaquePorts(), s.opaquePortNamePrefix)
 			if err != nil {
 				return fmt.Errorf("failed to get opaque ports for pod: %s", err)
 			}
 			var address watcher.Address
 			var endpoint *pb.WeightedAddr
 			if pod != nil {
-				address, err = s.createAddress(pod, port)
+				address, err = s.createAddress(pod, ip.String(), port)
 				if err != nil {
 					return fmt.Errorf("failed to create address: %s", err)
 				}
-				endpoint, err = s.createEndpoint(address, opaquePorts)
+				enableH2Upgrade := getH2Upgrade(s.k8sAPI, pod.Namespace, "", s.enableH2Upgrade, log)
+				endpoint, err = s.createEndpoint(address, opaquePorts, enableH2Upgrade)
 				if err != nil {
 					return fmt.Errorf("failed to create endpoint: %s", err)
 				}
@@ -244,12 +416,24 @@ func (s *server) GetProfile(dest *pb.GetDestination, stream pb.Destination_GetPr
 			case <-s.shutdown:
 			case <-stream.Context().Done():
 				log.Debugf("GetProfile(%+v) cancelled", dest)
+			case <-evicted:
+				log.Debugf("GetProfile(%+v) evicted", dest)
+				return errAuthorityEvicted(path)
+			case <-queued.queue.reset:
+				return errSlowConsumer(path)
 			}
 			return nil
 		}
 	} else {
+		// Short names are resolved the way the DNS search path of the client
+		// does.
+		host = s.qualifyServiceName(host, ctxToken.Ns)
+
 		var hostname string
-		service, hostname, err = parseK8sServiceName(host, s.clusterDomain)
+		service, hostname, err = s.parseServiceName(host)
+		if err != nil && s.enableExternalProfiles && s.isExternalName(host) {
+			return s.getExternalProfile(dest, host, port, ctxToken, queued, evicted, log)
+		}
 		if err != nil {
 			log.Debugf("Invalid service %s", path)
 			return status.Errorf(codes.InvalidArgument, "invalid service: %s", err)
@@ -263,12 +447,13 @@ func (s *server) GetProfile(dest *pb.GetDestination, stream pb.Destination_GetPr
 			if err != nil {
 				return fmt.Errorf("failed to get pod for hostname %s: %v", hostname, err)
 			}
-			opaquePorts, err := getAnnotatedOpaquePorts(address.Pod, s.defaultOpaquePorts)
+			opaquePorts, err := getAnnotatedOpaquePorts(address.Pod, s.defaultOpaquePorts(), s.opaquePortNamePrefix)
 			if err != nil {
 				return fmt.Errorf("failed to get opaque ports for pod: %s", err)
 			}
 			var endpoint *pb.WeightedAddr
-			endpoint, err = s.createEndpoint(*address, opaquePorts)
+			enableH2Upgrade := getH2Upgrade(s.k8sAPI, service.Namespace, service.Name, s.enableH2Upgrade, log)
+			endpoint, err = s.createEndpoint(*address, opaquePorts, enableH2Upgrade)
 			if err != nil {
 				return fmt.Errorf("failed to create endpoint: %s", err)
 			}
@@ -290,6 +475,11 @@ func (s *server) GetProfile(dest *pb.GetDestination, stream pb.Destination_GetPr
 			case <-s.shutdown:
 			case <-stream.Context().Done():
 				log.Debugf("GetProfile(%+v) cancelled", dest)
+			case <-evicted:
+				log.Debugf("GetProfile(%+v) evicted", dest)
+				return errAuthorityEvicted(path)
+			case <-queued.queue.reset:
+				return errSlowConsumer(path)
 			}
 			return nil
 		}
@@ -315,9 +505,22 @@ func (s *server) GetProfile(dest *pb.GetDestination, stream pb.Destination_GetPr
 	}
 	defer s.opaquePorts.Unsubscribe(service, opaquePortsAdaptor)
 
+	// The default profile adaptor replaces the missing profiles with the one
+	// synthesized from the DefaultProfile of the service's namespace, if any;
+	// it then publishes the result to the opaque ports adaptor.
+	defaultProfileAdaptor := newDefaultProfileAdaptor(opaquePortsAdaptor)
+
+	// Subscribe the adaptor to default profile updates.
+	err = s.defaultProfiles.Subscribe(service.Namespace, defaultProfileAdaptor)
+	if err != nil {
+		log.Warnf("Failed to subscribe to default profile updates for %s: %s", service.Namespace, err)
+		return err
+	}
+	defer s.defaultProfiles.Unsubscribe(service.Namespace, defaultProfileAdaptor)
+
 	// The fallback accepts updates from a primary and secondary source and
 	// passes the appropriate profile updates to the adaptor.
-	primary, secondary := newFallbackProfileListener(opaquePortsAdaptor)
+	primary, secondary := newFallbackProfileListener(defaultProfileAdaptor)
 
 	// If we have a context token, we create two subscriptions: one with the
 	// context token which sends updates to the primary listener and one without
@@ -325,9 +528,7 @@ func (s *server) GetProfile(dest *pb.GetDestination, stream pb.Destination_GetPr
 	// up to the fallbackProfileListener to merge updates from the primary and
 	// secondary listeners and send the appropriate updates to the stream.
 	if dest.GetContextToken() != "" {
-		ctxToken := s.parseContextToken(dest.GetContextToken())
-
-		profile, err := profileID(fqn, ctxToken, s.clusterDomain)
+		profile, err := profileID(fqn, ctxToken, s.clusterDomains())
 		if err != nil {
 			log.Debugf("Invalid service %s", path)
 			return status.Errorf(codes.InvalidArgument, "invalid profile ID: %s", err)
@@ -341,7 +542,9 @@ func (s *server) GetProfile(dest *pb.GetDestination, stream pb.Destination_GetPr
 		defer s.profiles.Unsubscribe(profile, primary)
 	}
 
-	profile, err := profileID(fqn, contextToken{}, s.clusterDomain)
+	streamsCounter.WithLabelValues("get_profile", s.clientZone(ctxToken)).Inc()
+
+	profile, err := profileID(fqn, contextToken{}, s.clusterDomains())
 	if err != nil {
 		log.Debugf("Invalid service %s", path)
 		return status.Errorf(codes.InvalidArgument, "invalid profile ID: %s", err)
@@ -357,29 +560,36 @@ func (s *server) GetProfile(dest *pb.GetDestination, stream pb.Destination_GetPr
 	case <-s.shutdown:
 	case <-stream.Context().Done():
 		log.Debugf("GetProfile(%+v) cancelled", dest)
+	case <-evicted:
+		log.Debugf("GetProfile(%+v) evicted", dest)
+		return errAuthorityEvicted(path)
+	case <-queued.queue.reset:
+		return errSlowConsumer(path)
 	}
 
 	return nil
 }
 
-func (s *server) createAddress(pod *corev1.Pod, port uint32) (watcher.Address, error) {
+// createAddress returns the address of the pod reached at the given IP, which
+// is one of its IPs on dual-stack clusters
+func (s *server) createAddress(pod *corev1.Pod, ip string, port uint32) (watcher.Address, error) {
 	ownerKind, ownerName := s.k8sAPI.GetOwnerKindAndName(context.Background(), pod, true)
 	address := watcher.Address{
-		IP:        pod.Status.PodIP,
+		IP:        ip,
 		Port:      port,
 		Pod:       pod,
 		OwnerName: ownerName,
 		OwnerKind: ownerKind,
 	}
-	err := watcher.SetToServerProtocol(s.k8sAPI, &address, port)
+	err := s.servers.SetToServerProtocol(&address, port)
 	if err != nil {
 		return watcher.Address{}, fmt.Errorf("failed to set address OpaqueProtocol: %s", err)
 	}
 	return address, nil
 }
 
-func (s *server) createEndpoint(address watcher.Address, opaquePorts map[uint32]struct{}) (*pb.WeightedAddr, error) {
-	weightedAddr, err := createWeightedAddr(address, opaquePorts, s.enableH2Upgrade, s.identityTrustDomain, s.controllerNS, s.log)
+func (s *server) createEndpoint(address watcher.Address, opaquePorts map[uint32]struct{}, enableH2Upgrade bool) (*pb.WeightedAddr, error) {
+	weightedAddr, err := createWeightedAddr(address, opaquePorts, enableH2Upgrade, s.identityTrustDomain(), s.controllerNS, s.log)
 	if err != nil {
 		return nil, err
 	}
@@ -426,8 +636,13 @@ func getSvcID(k8sAPI *k8s.API, clusterIP string, log *logging.Entry) (*watcher.S
 // getEndpointByHostname returns a pod that maps to the given hostname (or an
 // instanceID). The hostname is generally the prefix of the pod's DNS name;
 // since it may be arbitrary we need to look at the corresponding service's
-// Endpoints object to see whether the hostname matches a pod.
+// Endpoints object (or EndpointSlices) to see whether the hostname matches a
+// pod.
 func (s *server) getEndpointByHostname(k8sAPI *k8s.API, hostname string, svcID watcher.ServiceID, port uint32) (*watcher.Address, error) {
+	if s.enableEndpointSlices {
+		return s.getEndpointSliceByHostname(k8sAPI, hostname, svcID, port)
+	}
+
 	ep, err := k8sAPI.Endpoint().Lister().Endpoints(svcID.Namespace).Get(svcID.Name)
 	if err != nil {
 		return nil, err
@@ -444,7 +659,7 @@ func (s *server) getEndpointByHostname(k8sAPI *k8s.API, hostname string, svcID w
 					if err != nil {
 						return nil, err
 					}
-					address, err := s.createAddress(pod, port)
+					address, err := s.createAddress(pod, addr.IP, port)
 					if err != nil {
 						return nil, err
 					}
@@ -462,13 +677,49 @@ func (s *server) getEndpointByHostname(k8sAPI *k8s.API, hostname string, svcID w
 	return nil, fmt.Errorf("no pod found in Endpoints %s/%s for hostname %s", svcID.Namespace, svcID.Name, hostname)
 }
 
+func (s *server) getEndpointSliceByHostname(k8sAPI *k8s.API, hostname string, svcID watcher.ServiceID, port uint32) (*watcher.Address, error) {
+	selector := k8slabels.Set{discovery.LabelServiceName: svcID.Name}.AsSelector()
+	slices, err := k8sAPI.ES().Lister().EndpointSlices(svcID.Namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	// go through the slices in a deterministic order, in case the hostname
+	// shows up in more than one of them
+	sort.Slice(slices, func(i, j int) bool { return slices[i].Name < slices[j].Name })
+	for _, slice := range slices {
+		for _, ep := range slice.Endpoints {
+			if ep.Hostname == nil || *ep.Hostname != hostname || len(ep.Addresses) == 0 {
+				continue
+			}
+			if ep.TargetRef != nil && ep.TargetRef.Kind == "Pod" {
+				pod, err := k8sAPI.Pod().Lister().Pods(ep.TargetRef.Namespace).Get(ep.TargetRef.Name)
+				if err != nil {
+					return nil, err
+				}
+				address, err := s.createAddress(pod, ep.Addresses[0], port)
+				if err != nil {
+					return nil, err
+				}
+				return &address, nil
+			}
+			return &watcher.Address{
+				IP:   ep.Addresses[0],
+				Port: port,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no pod found in EndpointSlices for %s/%s for hostname %s", svcID.Namespace, svcID.Name, hostname)
+}
+
 // getPodByIP returns a pod that maps to the given IP address. The pod can either
 // be in the host network or the pod network. If the pod is in the host
 // network, then it must have a container port that exposes `port` as a host
 // port.
 func getPodByIP(k8sAPI *k8s.API, podIP string, port uint32, log *logging.Entry) (*corev1.Pod, error) {
 	// First we check if the address maps to a pod in the host network.
-	addr := fmt.Sprintf("%s:%d", podIP, port)
+	addr := net.JoinHostPort(podIP, fmt.Sprint(port))
 	hostIPPods, err := getIndexedPods(k8sAPI, watcher.HostIPIndex, addr)
 	if err != nil {
 		return nil, status.Error(codes.Unknown, err.Error())
@@ -517,7 +768,7 @@ func getIndexedPods(k8sAPI *k8s.API, indexName string, podIP string) ([]*corev1.
 	pods := make([]*corev1.Pod, 0)
 	for _, obj := range objs {
 		pod := obj.(*corev1.Pod)
-		if !podReceivingTraffic(pod) {
+		if !podReceivingTraffic(pod) || watcher.IsPodExcludedFromEndpoints(pod) {
 			continue
 		}
 		pods = append(pods, pod)
@@ -537,9 +788,18 @@ func podReceivingTraffic(pod *corev1.Pod) bool {
 /// util ///
 ////////////
 
+// contextToken is sent by the proxies in their requests to identify their
+// client. It's a JSON object; the oldest proxies send it in the ns:<namespace>
+// form instead.
 type contextToken struct {
 	Ns       string `json:"ns,omitempty"`
 	NodeName string `json:"nodeName,omitempty"`
+	// Zone overrides the topology zone of the client's node, e.g. for clients
+	// not running on a node
+	Zone         string `json:"zone,omitempty"`
+	WorkloadKind string `json:"workloadKind,omitempty"`
+	WorkloadName string `json:"workloadName,omitempty"`
+	TrustDomain  string `json:"trustDomain,omitempty"`
 }
 
 func (s *server) parseContextToken(token string) contextToken {
@@ -556,20 +816,55 @@ func (s *server) parseContextToken(token string) contextToken {
 			s.log.Errorf("context token %s is invalid: %s", token, err)
 		}
 	}
+	if ctxToken.TrustDomain != "" && ctxToken.TrustDomain != s.identityTrustDomain() {
+		s.log.Warnf("context token %s has a trust domain different from %s", token, s.identityTrustDomain())
+	}
 	return ctxToken
 }
 
-func profileID(authority string, ctxToken contextToken, clusterDomain string) (watcher.ProfileID, error) {
-	host, _, err := getHostAndPort(authority)
+// logFields returns the log fields describing the client of a stream
+func (t contextToken) logFields() logging.Fields {
+	fields := logging.Fields{}
+	if t.Ns != "" {
+		fields["client-ns"] = t.Ns
+	}
+	if t.WorkloadName != "" {
+		fields["client-workload"] = fmt.Sprintf("%s/%s", t.WorkloadKind, t.WorkloadName)
+	}
+	if t.Zone != "" {
+		fields["client-zone"] = t.Zone
+	}
+	return fields
+}
+
+// clientZone returns the topology zone of the client that sent the token,
+// which is either set in the token or the zone of the client's node
+func (s *server) clientZone(t contextToken) string {
+	if t.Zone != "" || t.NodeName == "" {
+		return t.Zone
+	}
+	zone, err := getNodeTopologyZone(s.nodes, t.NodeName)
+	if err != nil {
+		s.log.Debugf("Failed to get node topology zone for node %s: %s", t.NodeName, err)
+	}
+	return zone
+}
+
+// profileID returns the ID of the ServiceProfile of the authority, which is
+// named after the service in the first of the cluster domains whichever of
+// them the authority is in.
+func profileID(authority string, ctxToken contextToken, clusterDomains []string) (watcher.ProfileID, error) {
+	// the port is ignored, so any default will do
+	host, _, err := getHostAndPort(authority, DefaultPort)
 	if err != nil {
 		return watcher.ProfileID{}, fmt.Errorf("invalid authority: %s", err)
 	}
-	service, _, err := parseK8sServiceName(host, clusterDomain)
+	service, _, err := parseK8sServiceNameInDomains(host, clusterDomains)
 	if err != nil {
 		return watcher.ProfileID{}, fmt.Errorf("invalid k8s service name: %s", err)
 	}
 	id := watcher.ProfileID{
-		Name:      fmt.Sprintf("%s.%s.svc.%s", service.Name, service.Namespace, clusterDomain),
+		Name:      fmt.Sprintf("%s.%s.svc.%s", service.Name, service.Namespace, clusterDomains[0]),
 		Namespace: service.Namespace,
 	}
 	if ctxToken.Ns != "" {
@@ -578,23 +873,57 @@ func profileID(authority string, ctxToken contextToken, clusterDomain string) (w
 	return id, nil
 }
 
-func getHostAndPort(authority string) (string, watcher.Port, error) {
+// getHostAndPort splits an authority into its host and port, falling back to
+// defaultPort when it has no port; when defaultPort is 0, such authorities
+// are invalid
+func getHostAndPort(authority string, defaultPort watcher.Port) (string, watcher.Port, error) {
+	// IPv6 addresses are enclosed in square brackets
+	if strings.HasPrefix(authority, "[") {
+		host, port := authority, ""
+		if strings.HasSuffix(authority, "]") {
+			host = authority[1 : len(authority)-1]
+		} else {
+			var err error
+			host, port, err = net.SplitHostPort(authority)
+			if err != nil {
+				return "", 0, fmt.Errorf("invalid destination %s", authority)
+			}
+		}
+		if net.ParseIP(host) == nil {
+			return "", 0, fmt.Errorf("invalid destination %s", authority)
+		}
+		if port == "" {
+			return defaultHostPort(host, authority, defaultPort)
+		}
+		p, err := strconv.Atoi(port)
+		if err != nil || p <= 0 || p > 65535 {
+			return "", 0, fmt.Errorf("invalid port %s", port)
+		}
+		return host, watcher.Port(p), nil
+	}
+
 	hostPort := strings.Split(authority, ":")
 	if len(hostPort) > 2 {
 		return "", 0, fmt.Errorf("invalid destination %s", authority)
 	}
 	host := hostPort[0]
-	port := 80
-	if len(hostPort) == 2 {
-		var err error
-		port, err = strconv.Atoi(hostPort[1])
-		if err != nil || port <= 0 || port > 65535 {
-			return "", 0, fmt.Errorf("invalid port %s", hostPort[1])
-		}
+	if len(hostPort) == 1 {
+		return defaultHostPort(host, authority, defaultPort)
+	}
+	port, err := strconv.Atoi(hostPort[1])
+	if err != nil || port <= 0 || port > 65535 {
+		return "", 0, fmt.Errorf("invalid port %s", hostPort[1])
 	}
 	return host, watcher.Port(port), nil
 }
 
+func defaultHostPort(host, authority string, defaultPort watcher.Port) (string, watcher.Port, error) {
+	if defaultPort == 0 {
+		return "", 0, fmt.Errorf("missing port in destination %s", authority)
+	}
+	return host, defaultPort, nil
+}
+
 type instanceID = string
 
 // parseK8sServiceName is a utility that destructures a Kubernetes service hostname into its constituent components.
@@ -634,6 +963,94 @@ func parseK8sServiceName(fqdn, clusterDomain string) (watcher.ServiceID, instanc
 	return watcher.ServiceID{}, "", fmt.Errorf("invalid k8s service %s", fqdn)
 }
 
+// parseK8sServiceNameInDomains parses a Kubernetes service hostname in any of
+// the cluster domains.
+func parseK8sServiceNameInDomains(fqdn string, clusterDomains []string) (watcher.ServiceID, instanceID, error) {
+	for _, clusterDomain := range clusterDomains {
+		if hasSuffix(strings.Split(fqdn, "."), append([]string{"svc"}, strings.Split(clusterDomain, ".")...)) {
+			return parseK8sServiceName(fqdn, clusterDomain)
+		}
+	}
+	return watcher.ServiceID{}, "", fmt.Errorf("name %s does not match cluster domains %s", fqdn, strings.Join(clusterDomains, ","))
+}
+
+// parseServiceName parses a Kubernetes service hostname in any of the cluster
+// domains of the server.
+func (s *server) parseServiceName(fqdn string) (watcher.ServiceID, instanceID, error) {
+	return parseK8sServiceNameInDomains(fqdn, s.clusterDomains())
+}
+
+// resolveSRVName resolves an SRV-style authority, of the form
+// _<port-name>._<protocol>.<service>.<namespace>.svc.<cluster-domain>, into the
+// authority of the service port it names, the way kube-dns answers SRV
+// queries. Other authorities are returned as is.
+func (s *server) resolveSRVName(authority string) (string, error) {
+	labels := strings.Split(authority, ".")
+	if len(labels) < 3 || !strings.HasPrefix(labels[0], "_") || !strings.HasPrefix(labels[1], "_") {
+		return authority, nil
+	}
+	portName, protocol := labels[0][1:], labels[1][1:]
+
+	// the port is the named one, so any default will do
+	host, _, err := getHostAndPort(strings.Join(labels[2:], "."), DefaultPort)
+	if err != nil {
+		return "", err
+	}
+	service, instanceID, err := s.parseServiceName(host)
+	if err != nil {
+		return "", err
+	}
+	if instanceID != "" {
+		return "", fmt.Errorf("SRV-style authority %s must name a service", authority)
+	}
+
+	svc, err := s.k8sAPI.Svc().Lister().Services(service.Namespace).Get(service.Name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get service %s: %s", service, err)
+	}
+	for _, port := range svc.Spec.Ports {
+		if port.Name == portName && strings.EqualFold(string(port.Protocol), protocol) {
+			return fmt.Sprintf("%s:%d", host, port.Port), nil
+		}
+	}
+	return "", fmt.Errorf("service %s has no %s port named %s", service, protocol, portName)
+}
+
+// qualifyServiceName returns the fully-qualified form of a service name. Short
+// names such as <svc> or <svc>.<ns> are resolved the way the DNS search path
+// of a pod in the client's namespace resolves them: each search domain is
+// tried in turn, and the first one naming an existing service wins. Names
+// that are already fully-qualified, or that don't name any service, are
+// returned as is.
+func (s *server) qualifyServiceName(host, clientNs string) string {
+	host = strings.TrimSuffix(host, ".")
+	for _, clusterDomain := range s.clusterDomains() {
+		if hasSuffix(strings.Split(host, "."), append([]string{"svc"}, strings.Split(clusterDomain, ".")...)) {
+			return host
+		}
+	}
+
+	searchDomains := []string{
+		fmt.Sprintf("svc.%s", s.clusterDomain()),
+		s.clusterDomain(),
+	}
+	if clientNs != "" {
+		searchDomains = append([]string{fmt.Sprintf("%s.svc.%s", clientNs, s.clusterDomain())}, searchDomains...)
+	}
+	for _, domain := range searchDomains {
+		fqdn := fmt.Sprintf("%s.%s", host, domain)
+		service, _, err := parseK8sServiceName(fqdn, s.clusterDomain())
+		if err != nil {
+			continue
+		}
+		if _, err := s.k8sAPI.Svc().Lister().Services(service.Namespace).Get(service.Name); err == nil {
+			s.log.Debugf("Resolved %s to %s", host, fqdn)
+			return fqdn
+		}
+	}
+	return host
+}
+
 func hasSuffix(slice []string, suffix []string) bool {
 	if len(slice) < len(suffix) {
 		return false
@@ -646,15 +1063,22 @@ func hasSuffix(slice []string, suffix []string) bool {
 	return true
 }
 
-func getAnnotatedOpaquePorts(pod *corev1.Pod, defaultPorts map[uint32]struct{}) (map[uint32]struct{}, error) {
+// getAnnotatedOpaquePorts returns the opaque ports of the pod: the ports of
+// its opaque ports annotation, or the default ones when it isn't annotated,
+// along with the container ports whose name starts with namePrefix.
+func getAnnotatedOpaquePorts(pod *corev1.Pod, defaultPorts map[uint32]struct{}, namePrefix string) (map[uint32]struct{}, error) {
 	if pod == nil {
 		return defaultPorts, nil
 	}
+	prefixed := util.GetPrefixedContainerPorts(namePrefix, pod.Spec.Containers)
 	annotation, ok := pod.Annotations[labels.ProxyOpaquePortsAnnotation]
 	if !ok {
+		if len(prefixed) != 0 {
+			return util.MergePorts(defaultPorts, prefixed), nil
+		}
 		return defaultPorts, nil
 	}
-	opaquePorts := make(map[uint32]struct{})
+	opaquePorts := prefixed
 	if annotation != "" {
 		for _, portStr := range util.ParseContainerOpaquePorts(annotation, pod.Spec.Containers) {
 			port, err := strconv.ParseUint(portStr, 10, 32)
@@ -0,0 +1,115 @@
+package destination
+
+import (
+	"context"
+	"time"
+
+	serverv1beta1 "github.com/linkerd/linkerd2/controller/gen/apis/server/v1beta1"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/leader"
+	logging "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// serverConflictReason is the reason of the Events recorded on the
+	// conflicting Servers
+	serverConflictReason = "ServerConflict"
+
+	// ServerConflictsInterval is the default interval at which the Servers
+	// are checked for conflicts
+	ServerConflictsInterval = time.Minute
+)
+
+// serverConflictReporter records a Warning Event on the Servers selecting the
+// same port of a pod with different proxy protocols, as the proxy only
+// applies one of them. Recording the Events is write-side work, performed by
+// the leader only.
+type serverConflictReporter struct {
+	k8sAPI   *k8s.API
+	recorder record.EventRecorder
+	log      *logging.Entry
+
+	// the conflicts already reported during the current leadership term,
+	// without their pod
+	reported map[pkgK8s.ServerConflict]struct{}
+}
+
+// ReportServerConflicts returns the leader task checking the Servers for
+// conflicts every interval and recording a Warning Event on both Servers of
+// each new conflict.
+func ReportServerConflicts(k8sAPI *k8s.API, recorder record.EventRecorder, interval time.Duration) leader.Task {
+	return func(ctx context.Context) {
+		r := &serverConflictReporter{
+			k8sAPI:   k8sAPI,
+			recorder: recorder,
+			log:      logging.WithField("component", "server-conflict-reporter"),
+			reported: make(map[pkgK8s.ServerConflict]struct{}),
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := r.report(); err != nil {
+				r.log.Errorf("failed to check the Servers for conflicts: %s", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+func (r *serverConflictReporter) report() error {
+	servers, err := r.k8sAPI.Srv().Lister().List(k8slabels.Everything())
+	if err != nil {
+		return err
+	}
+	pods, err := r.k8sAPI.Pod().Lister().List(k8slabels.Everything())
+	if err != nil {
+		return err
+	}
+
+	serverValues := make([]serverv1beta1.Server, len(servers))
+	byName := make(map[string]*serverv1beta1.Server, len(servers))
+	for i, server := range servers {
+		serverValues[i] = *server
+		byName[server.Namespace+"/"+server.Name] = server
+	}
+	podValues := make([]corev1.Pod, len(pods))
+	for i, pod := range pods {
+		podValues[i] = *pod
+	}
+
+	conflicts, err := pkgK8s.ConflictingServers(serverValues, podValues)
+	if err != nil {
+		return err
+	}
+
+	current := make(map[pkgK8s.ServerConflict]struct{}, len(conflicts))
+	for _, conflict := range conflicts {
+		key := conflict
+		key.Pod = ""
+		current[key] = struct{}{}
+		if _, ok := r.reported[key]; ok {
+			continue
+		}
+
+		r.log.Warn(conflict.String())
+		for _, name := range conflict.Servers {
+			if server, ok := byName[conflict.Namespace+"/"+name]; ok {
+				r.recorder.Eventf(server, corev1.EventTypeWarning, serverConflictReason,
+					"Conflicting proxy protocols: %s", conflict)
+			}
+		}
+	}
+	// the conflicts that were resolved get reported again if they reappear
+	r.reported = current
+
+	return nil
+}
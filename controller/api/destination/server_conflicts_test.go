@@ -0,0 +1,99 @@
+package destination
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	serverv1beta1 "github.com/linkerd/linkerd2/controller/gen/apis/server/v1beta1"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func conflictingServer(name, protocol string) string {
+	return fmt.Sprintf(`
+apiVersion: policy.linkerd.io/v1beta1
+kind: Server
+metadata:
+  name: %s
+  namespace: ns
+spec:
+  podSelector:
+    matchLabels:
+      app: web
+  port: 8080
+  proxyProtocol: %s`, name, protocol)
+}
+
+// serverEventRecorder sends the name of the Server of each Warning Event
+type serverEventRecorder struct {
+	servers chan string
+}
+
+func (r *serverEventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if eventtype == corev1.EventTypeWarning && reason == serverConflictReason {
+		r.servers <- object.(*serverv1beta1.Server).Name
+	}
+}
+
+func (r *serverEventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *serverEventRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Eventf(object, eventtype, reason, messageFmt, args...)
+}
+
+func TestReportServerConflicts(t *testing.T) {
+	k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web-0
+  namespace: ns
+  labels:
+    app: web
+spec:
+  containers:
+  - name: web
+    ports:
+    - containerPort: 8080`,
+		conflictingServer("web-http", "HTTP/1"),
+		conflictingServer("web-opaque", "opaque"),
+		conflictingServer("web-opaque-too", "opaque"),
+	)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+	k8sAPI.Sync(nil)
+
+	recorder := &serverEventRecorder{servers: make(chan string, 10)}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go ReportServerConflicts(k8sAPI, recorder, 10*time.Millisecond)(ctx)
+
+	// each conflict is reported on both of its Servers
+	expected := map[string]int{"web-http": 2, "web-opaque": 1, "web-opaque-too": 1}
+	for i := 0; i < 4; i++ {
+		select {
+		case name := <-recorder.servers:
+			expected[name]--
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected 4 events, got %d", i)
+		}
+	}
+	for name, remaining := range expected {
+		if remaining != 0 {
+			t.Fatalf("Expected the conflicts of %s to be reported, %d events missing", name, remaining)
+		}
+	}
+
+	// the conflicts are only reported once per leadership term
+	select {
+	case name := <-recorder.servers:
+		t.Fatalf("Unexpected event on %s", name)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -2,6 +2,8 @@ package destination
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
 
@@ -11,7 +13,12 @@ import (
 	"github.com/linkerd/linkerd2/controller/api/util"
 	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/pkg/addr"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
 	logging "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const fullyQualifiedName = "name1.ns.svc.mycluster.local"
@@ -63,6 +70,7 @@ kind: Service
 metadata:
   name: name1
   namespace: ns
+  uid: name1-uid
 spec:
   type: LoadBalancer
   clusterIP: 172.17.12.0
@@ -156,6 +164,32 @@ spec:
       pathRegex: "/x/y/z"`,
 	}
 
+	externalSPs := []string{
+		`
+apiVersion: linkerd.io/v1alpha2
+kind: ServiceProfile
+metadata:
+  name: api.example.com
+  namespace: linkerd
+spec:
+  routes:
+  - name: global
+    condition:
+      pathRegex: "/.*"`,
+		`
+apiVersion: linkerd.io/v1alpha2
+kind: ServiceProfile
+metadata:
+  name: api.example.com
+  namespace: client-ns
+spec:
+  routes:
+  - name: charges
+    isRetryable: true
+    condition:
+      pathRegex: "/v1/charges"`,
+	}
+
 	unmeshedPod := `
 apiVersion: v1
 kind: Pod
@@ -353,13 +387,126 @@ spec:
   proxyProtocol: opaque`,
 	}
 
+	headlessServiceResources := []string{
+		`
+apiVersion: v1
+kind: Service
+metadata:
+  name: headless-svc
+  namespace: ns
+spec:
+  clusterIP: None
+  ports:
+  - name: admin
+    port: 9990
+    protocol: UDP
+  - name: http
+    port: 8080
+    protocol: TCP`,
+		`
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: headless-svc
+  namespace: ns
+subsets:
+- addresses:
+  - ip: 172.17.0.20
+    hostname: headless-0
+  - ip: 172.17.0.21
+    hostname: headless-1
+  ports:
+  - name: admin
+    port: 9990
+    protocol: UDP
+  - name: http
+    port: 8080
+    protocol: TCP`,
+	}
+
+	mirrorResources := []string{
+		`
+apiVersion: v1
+kind: Service
+metadata:
+  name: name1-hub
+  namespace: ns
+  labels:
+    mirror.linkerd.io/mirrored-service: "true"
+    mirror.linkerd.io/cluster-name: hub
+  annotations:
+    mirror.linkerd.io/remote-svc-fq-name: name1-spoke.ns.svc.cluster.local
+    mirror.linkerd.io/origin-svc-uid: name1-uid
+    mirror.linkerd.io/origin-svc-fq-name: name1.ns.svc.mycluster.local
+spec:
+  type: ClusterIP
+  clusterIP: 172.17.12.30
+  ports:
+  - port: 8989`,
+		`
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: name1-hub
+  namespace: ns
+  labels:
+    mirror.linkerd.io/mirrored-service: "true"
+    mirror.linkerd.io/cluster-name: hub
+  annotations:
+    mirror.linkerd.io/remote-svc-fq-name: name1-spoke.ns.svc.cluster.local
+    mirror.linkerd.io/remote-gateway-identity: gateway.linkerd-multicluster.serviceaccount.identity.linkerd.cluster.local
+subsets:
+- addresses:
+  - ip: 172.17.0.30
+  ports:
+  - port: 8989`,
+		`
+apiVersion: v1
+kind: Service
+metadata:
+  name: name1-west
+  namespace: ns
+  labels:
+    mirror.linkerd.io/mirrored-service: "true"
+    mirror.linkerd.io/cluster-name: west
+  annotations:
+    mirror.linkerd.io/remote-svc-fq-name: name1.ns.svc.cluster.local
+    mirror.linkerd.io/origin-svc-uid: west-name1-uid
+    mirror.linkerd.io/origin-svc-fq-name: name1.ns.svc.mycluster.local
+spec:
+  type: ClusterIP
+  clusterIP: 172.17.12.31
+  ports:
+  - port: 8989`,
+		`
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: name1-west
+  namespace: ns
+  labels:
+    mirror.linkerd.io/mirrored-service: "true"
+    mirror.linkerd.io/cluster-name: west
+  annotations:
+    mirror.linkerd.io/remote-svc-fq-name: name1.ns.svc.cluster.local
+    mirror.linkerd.io/remote-gateway-identity: gateway.linkerd-multicluster.serviceaccount.identity.linkerd.cluster.local
+subsets:
+- addresses:
+  - ip: 172.17.0.31
+  ports:
+  - port: 8989`,
+	}
+
 	res := append(meshedPodResources, clientSP...)
+	res = append(res, externalSPs...)
 	res = append(res, unmeshedPod)
 	res = append(res, meshedOpaquePodResources...)
 	res = append(res, meshedOpaqueServiceResources...)
 	res = append(res, meshedSkippedPodResource...)
 	res = append(res, meshedStatefulSetPodResource...)
 	res = append(res, policyResources...)
+	res = append(res, headlessServiceResources...)
+	res = append(res, mirrorResources...)
 	k8sAPI, err := k8s.NewFakeAPI(res...)
 	if err != nil {
 		t.Fatalf("NewFakeAPI returned an error: %s", err)
@@ -379,10 +526,12 @@ spec:
 		t.Fatalf("initializeIndexers returned an error: %s", err)
 	}
 
-	endpoints := watcher.NewEndpointsWatcher(k8sAPI, log, false)
-	opaquePorts := watcher.NewOpaquePortsWatcher(k8sAPI, log, defaultOpaquePorts)
+	protocols := watcher.NewProtocolCache(k8sAPI, log)
+	endpoints := watcher.NewEndpointsWatcher(k8sAPI, log, false, protocols)
+	opaquePorts := watcher.NewOpaquePortsWatcher(k8sAPI, log, defaultOpaquePorts, "")
 	profiles := watcher.NewProfileWatcher(k8sAPI, log)
-	servers := watcher.NewServerWatcher(k8sAPI, log)
+	defaultProfiles := watcher.NewDefaultProfileWatcher(k8sAPI, log)
+	servers := watcher.NewServerWatcher(k8sAPI, log, protocols)
 
 	// Sync after creating watchers so that the the indexers added get updated
 	// properly
@@ -393,13 +542,18 @@ spec:
 		endpoints,
 		opaquePorts,
 		profiles,
+		defaultProfiles,
 		servers,
 		k8sAPI.Node(),
 		true,
+		false,
+		false,
 		"linkerd",
-		"trust.domain",
-		"mycluster.local",
-		defaultOpaquePorts,
+		newServerConfig("trust.domain", []string{"mycluster.local"}, defaultOpaquePorts),
+		"",
+		DefaultPort,
+		nil,
+		nil,
 		k8sAPI,
 		log,
 		make(<-chan struct{}),
@@ -471,6 +625,76 @@ func TestGet(t *testing.T) {
 
 	})
 
+	t.Run("Returns the endpoints of the port named by an SRV-style authority", func(t *testing.T) {
+		server := makeServer(t)
+
+		stream := &bufferingGetStream{
+			updates:          []*pb.Update{},
+			MockServerStream: util.NewMockServerStream(),
+		}
+		stream.Cancel()
+
+		err := server.Get(&pb.GetDestination{Scheme: "k8s", Path: "_http._tcp.headless-svc.ns.svc.mycluster.local"}, stream)
+		if err != nil {
+			t.Fatalf("Got error: %s", err)
+		}
+
+		if len(stream.updates) != 1 {
+			t.Fatalf("Expected 1 update but got %d: %v", len(stream.updates), stream.updates)
+		}
+
+		addrs := updateAddAddress(t, stream.updates[0])
+		sort.Strings(addrs)
+		expected := []string{"172.17.0.20:8080", "172.17.0.21:8080"}
+		if !reflect.DeepEqual(addrs, expected) {
+			t.Fatalf("Expected %v but got %v", expected, addrs)
+		}
+	})
+
+	t.Run("Returns the local endpoints of a mirror service looping back to the cluster", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			expected string
+		}{
+			{
+				name:     "name1-hub",
+				expected: fmt.Sprintf("%s:%d", podIP1, port),
+			},
+			{
+				// the origin in the other cluster has the same name, but
+				// not the same UID
+				name:     "name1-west",
+				expected: fmt.Sprintf("172.17.0.31:%d", port),
+			},
+		}
+
+		for _, tc := range testCases {
+			tc := tc // pin
+			t.Run(tc.name, func(t *testing.T) {
+				server := makeServer(t)
+				stream := &bufferingGetStream{
+					updates:          []*pb.Update{},
+					MockServerStream: util.NewMockServerStream(),
+				}
+				stream.Cancel()
+
+				err := server.Get(&pb.GetDestination{Scheme: "k8s", Path: fmt.Sprintf("%s.ns.svc.mycluster.local:%d", tc.name, port)}, stream)
+				if err != nil {
+					t.Fatalf("Got error: %s", err)
+				}
+
+				if len(stream.updates) != 1 {
+					t.Fatalf("Expected 1 update but got %d: %v", len(stream.updates), stream.updates)
+				}
+
+				addrs := updateAddAddress(t, stream.updates[0])
+				if len(addrs) != 1 || addrs[0] != tc.expected {
+					t.Fatalf("Expected [%s] but got %v", tc.expected, addrs)
+				}
+			})
+		}
+	})
+
 	t.Run("Return endpoint with unknown protocol hint and identity when service name contains skipped inbound port", func(t *testing.T) {
 		server := makeServer(t)
 		stream := &bufferingGetStream{
@@ -525,6 +749,101 @@ func TestGetProfiles(t *testing.T) {
 		}
 	})
 
+	t.Run("Returns error for an external name when external profiles are disabled", func(t *testing.T) {
+		server := makeServer(t)
+
+		stream := &bufferingGetProfileStream{
+			updates:          []*pb.DestinationProfile{},
+			MockServerStream: util.NewMockServerStream(),
+		}
+
+		err := server.GetProfile(&pb.GetDestination{Scheme: "k8s", Path: "api.example.com:443"}, stream)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("Expected InvalidArgument error, got %v", err)
+		}
+	})
+
+	t.Run("Returns the profile of external names", func(t *testing.T) {
+		for _, tc := range []struct {
+			name          string
+			path          string
+			contextToken  string
+			expectedRoute string
+		}{
+			{
+				name:          "profile of the client's namespace",
+				path:          "api.example.com:443",
+				contextToken:  `{"ns":"client-ns"}`,
+				expectedRoute: "charges",
+			},
+			{
+				name:          "profile of the controller's namespace",
+				path:          "api.example.com:443",
+				contextToken:  `{"ns":"other"}`,
+				expectedRoute: "global",
+			},
+			{
+				name:         "no profile",
+				path:         "www.example.com:443",
+				contextToken: `{"ns":"client-ns"}`,
+			},
+		} {
+			tc := tc // pin
+			t.Run(tc.name, func(t *testing.T) {
+				server := makeServer(t)
+				server.enableExternalProfiles = true
+
+				stream := &bufferingGetProfileStream{
+					updates:          []*pb.DestinationProfile{},
+					MockServerStream: util.NewMockServerStream(),
+				}
+
+				stream.Cancel() // See note above on pre-emptive cancellation.
+				err := server.GetProfile(&pb.GetDestination{
+					Scheme:       "k8s",
+					Path:         tc.path,
+					ContextToken: tc.contextToken,
+				}, stream)
+				if err != nil {
+					t.Fatalf("Got error: %s", err)
+				}
+
+				if len(stream.updates) == 0 {
+					t.Fatalf("Expected at least 1 update but got none")
+				}
+				lastUpdate := stream.updates[len(stream.updates)-1]
+				if lastUpdate.FullyQualifiedName != "" {
+					t.Fatalf("Expected no fully qualified name, but got '%s'", lastUpdate.FullyQualifiedName)
+				}
+				routes := lastUpdate.GetRoutes()
+				if tc.expectedRoute == "" {
+					if len(routes) != 0 {
+						t.Fatalf("Expected no routes but got %v", routes)
+					}
+					return
+				}
+				if len(routes) != 1 || routes[0].GetMetricsLabels()["route"] != tc.expectedRoute {
+					t.Fatalf("Expected route %s but got %v", tc.expectedRoute, routes)
+				}
+			})
+		}
+	})
+
+	t.Run("Returns error for names under the cluster domain that aren't services", func(t *testing.T) {
+		server := makeServer(t)
+		server.enableExternalProfiles = true
+
+		stream := &bufferingGetProfileStream{
+			updates:          []*pb.DestinationProfile{},
+			MockServerStream: util.NewMockServerStream(),
+		}
+
+		err := server.GetProfile(&pb.GetDestination{Scheme: "k8s", Path: "name1.ns.mycluster.local:80"}, stream)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("Expected InvalidArgument error, got %v", err)
+		}
+	})
+
 	t.Run("Returns server profile", func(t *testing.T) {
 		server := makeServer(t)
 
@@ -576,6 +895,37 @@ func TestGetProfiles(t *testing.T) {
 		}
 	})
 
+	t.Run("Returns server profile of a short service name", func(t *testing.T) {
+		server := makeServer(t)
+
+		stream := &bufferingGetProfileStream{
+			updates:          []*pb.DestinationProfile{},
+			MockServerStream: util.NewMockServerStream(),
+		}
+
+		stream.Cancel() // See note above on pre-emptive cancellation.
+		err := server.GetProfile(&pb.GetDestination{
+			Scheme:       "k8s",
+			Path:         fmt.Sprintf("name1:%d", port),
+			ContextToken: "{\"ns\":\"ns\"}",
+		}, stream)
+		if err != nil {
+			t.Fatalf("Got error: %s", err)
+		}
+
+		if len(stream.updates) == 0 {
+			t.Fatalf("Expected at least 1 update but got none")
+		}
+		firstUpdate := stream.updates[0]
+		if firstUpdate.FullyQualifiedName != fullyQualifiedName {
+			t.Fatalf("Expected fully qualified name '%s', but got '%s'", fullyQualifiedName, firstUpdate.FullyQualifiedName)
+		}
+		routes := stream.updates[len(stream.updates)-1].GetRoutes()
+		if len(routes) != 1 {
+			t.Fatalf("Expected 1 route but got %d: %v", len(routes), routes)
+		}
+	})
+
 	t.Run("Return service profile when using json token", func(t *testing.T) {
 		server := makeServer(t)
 
@@ -1086,6 +1436,29 @@ func TestTokenStructure(t *testing.T) {
 		}
 	})
 
+	t.Run("when JSON carries the client's zone and workload", func(t *testing.T) {
+		server := makeServer(t)
+		dest := &pb.GetDestination{ContextToken: `{"ns":"ns-1", "nodeName":"node-1", "zone":"west-1b", "workloadKind":"deployment", "workloadName":"web", "trustDomain":"trust.domain"}`}
+		token := server.parseContextToken(dest.ContextToken)
+
+		expected := contextToken{
+			Ns:           "ns-1",
+			NodeName:     "node-1",
+			Zone:         "west-1b",
+			WorkloadKind: "deployment",
+			WorkloadName: "web",
+			TrustDomain:  "trust.domain",
+		}
+		if token != expected {
+			t.Fatalf("Expected token to be %+v got %+v", expected, token)
+		}
+
+		fields := token.logFields()
+		if fields["client-workload"] != "deployment/web" || fields["client-zone"] != "west-1b" {
+			t.Fatalf("Unexpected log fields: %v", fields)
+		}
+	})
+
 	t.Run("when JSON is invalid and old token format used", func(t *testing.T) {
 		server := makeServer(t)
 		dest := &pb.GetDestination{ContextToken: "ns:ns-2"}
@@ -1276,4 +1649,240 @@ status:
 			t.Fatalf("expected error to be pod IP address conflict, but got: %s", err)
 		}
 	})
+
+	t.Run("get pod by any of its dual-stack IPs", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: dual-stack
+  namespace: ns
+status:
+  phase: Running
+  podIP: 10.255.0.2
+  podIPs:
+  - ip: 10.255.0.2
+  - ip: fd00:10:244::2`)
+		if err != nil {
+			t.Fatalf("failed to create new fake API: %s", err)
+		}
+
+		err = watcher.InitializeIndexers(k8sAPI)
+		if err != nil {
+			t.Fatalf("initializeIndexers returned an error: %s", err)
+		}
+
+		k8sAPI.Sync(nil)
+		for _, ip := range []string{"10.255.0.2", "fd00:10:244::2"} {
+			pod, err := getPodByIP(k8sAPI, ip, 8080, logging.WithFields(nil))
+			if err != nil {
+				t.Fatalf("failed to get pod: %s", err)
+			}
+			if pod == nil || pod.Name != "dual-stack" {
+				t.Fatalf("expected to find pod dual-stack for %s, got %v", ip, pod)
+			}
+		}
+	})
+}
+
+func TestGetAnnotatedOpaquePorts(t *testing.T) {
+	defaultOpaquePorts := map[uint32]struct{}{3306: {}}
+	pod := func(annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Ports: []corev1.ContainerPort{
+						{Name: "http", ContainerPort: 8080},
+						{Name: "opaque-redis", ContainerPort: 6379},
+					},
+				}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		pod      *corev1.Pod
+		prefix   string
+		expected map[uint32]struct{}
+	}{
+		{
+			name:     "default ports",
+			pod:      pod(nil),
+			expected: map[uint32]struct{}{3306: {}},
+		},
+		{
+			name:     "default and prefixed ports",
+			pod:      pod(nil),
+			prefix:   "opaque-",
+			expected: map[uint32]struct{}{3306: {}, 6379: {}},
+		},
+		{
+			name:     "annotated and prefixed ports",
+			pod:      pod(map[string]string{pkgK8s.ProxyOpaquePortsAnnotation: "http"}),
+			prefix:   "opaque-",
+			expected: map[uint32]struct{}{8080: {}, 6379: {}},
+		},
+		{
+			name:     "explicitly not opaque pod with prefixed ports",
+			pod:      pod(map[string]string{pkgK8s.ProxyOpaquePortsAnnotation: ""}),
+			prefix:   "opaque-",
+			expected: map[uint32]struct{}{6379: {}},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			ports, err := getAnnotatedOpaquePorts(tc.pod, defaultOpaquePorts, tc.prefix)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(ports, tc.expected) {
+				t.Fatalf("Expected %v, got %v", tc.expected, ports)
+			}
+		})
+	}
+	if len(defaultOpaquePorts) != 1 {
+		t.Fatalf("Default opaque ports were modified: %v", defaultOpaquePorts)
+	}
+}
+
+func TestGetHostAndPort(t *testing.T) {
+	testCases := []struct {
+		authority    string
+		defaultPort  watcher.Port
+		strict       bool
+		expectedHost string
+		expectedPort watcher.Port
+		expectedErr  bool
+	}{
+		{authority: "name1.ns.svc.mycluster.local:8989", expectedHost: "name1.ns.svc.mycluster.local", expectedPort: 8989},
+		{authority: "name1.ns.svc.mycluster.local", expectedHost: "name1.ns.svc.mycluster.local", expectedPort: 80},
+		{authority: "172.17.0.12:8989", expectedHost: "172.17.0.12", expectedPort: 8989},
+		{authority: "[fd00:10:244::1e]:8989", expectedHost: "fd00:10:244::1e", expectedPort: 8989},
+		{authority: "[fd00:10:244::1e]", expectedHost: "fd00:10:244::1e", expectedPort: 80},
+		{authority: "[fd00:10:244::1e]:0", expectedErr: true},
+		{authority: "[name1.ns]:8989", expectedErr: true},
+		{authority: "fd00:10:244::1e", expectedErr: true},
+		{authority: "name1.ns.svc.mycluster.local", defaultPort: 8080, expectedHost: "name1.ns.svc.mycluster.local", expectedPort: 8080},
+		{authority: "name1.ns.svc.mycluster.local:8989", strict: true, expectedHost: "name1.ns.svc.mycluster.local", expectedPort: 8989},
+		{authority: "name1.ns.svc.mycluster.local", strict: true, expectedErr: true},
+		{authority: "[fd00:10:244::1e]", strict: true, expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.authority, func(t *testing.T) {
+			defaultPort := DefaultPort
+			if tc.defaultPort != 0 {
+				defaultPort = tc.defaultPort
+			}
+			if tc.strict {
+				defaultPort = 0
+			}
+			host, port, err := getHostAndPort(tc.authority, defaultPort)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatalf("Expected an error, got %s and %d", host, port)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if host != tc.expectedHost || port != tc.expectedPort {
+				t.Fatalf("Expected %s and %d, got %s and %d", tc.expectedHost, tc.expectedPort, host, port)
+			}
+		})
+	}
+}
+
+func TestResolveSRVName(t *testing.T) {
+	server := makeServer(t)
+
+	testCases := []struct {
+		authority string
+		expected  string
+		err       string
+	}{
+		{authority: "_http._tcp.headless-svc.ns.svc.mycluster.local", expected: "headless-svc.ns.svc.mycluster.local:8080"},
+		{authority: "_http._TCP.headless-svc.ns.svc.mycluster.local:80", expected: "headless-svc.ns.svc.mycluster.local:8080"},
+		{authority: "_admin._udp.headless-svc.ns.svc.mycluster.local", expected: "headless-svc.ns.svc.mycluster.local:9990"},
+		{authority: "headless-svc.ns.svc.mycluster.local:8080", expected: "headless-svc.ns.svc.mycluster.local:8080"},
+		{authority: "_admin._tcp.headless-svc.ns.svc.mycluster.local", err: "service ns/headless-svc has no tcp port named admin"},
+		{authority: "_http._tcp.headless-0.headless-svc.ns.svc.mycluster.local", err: "SRV-style authority _http._tcp.headless-0.headless-svc.ns.svc.mycluster.local must name a service"},
+		{authority: "_http._tcp.missing.ns.svc.mycluster.local", err: "failed to get service ns/missing: service \"missing\" not found"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.authority, func(t *testing.T) {
+			authority, err := server.resolveSRVName(tc.authority)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("Expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if authority != tc.expected {
+				t.Fatalf("Expected %s, got %s", tc.expected, authority)
+			}
+		})
+	}
+}
+
+func TestQualifyServiceName(t *testing.T) {
+	server := makeServer(t)
+
+	testCases := []struct {
+		host     string
+		clientNs string
+		expected string
+	}{
+		{host: "name1", clientNs: "ns", expected: fullyQualifiedName},
+		{host: "name1", clientNs: "other", expected: "name1"},
+		{host: "name1", expected: "name1"},
+		{host: "name1.ns", clientNs: "other", expected: fullyQualifiedName},
+		{host: "name1.ns.svc", expected: fullyQualifiedName},
+		{host: "name1.ns.svc.mycluster.local.", expected: fullyQualifiedName},
+		{host: fullyQualifiedName, clientNs: "other", expected: fullyQualifiedName},
+		{host: "pod-0.statefulset-svc", clientNs: "ns", expected: fullyQualifiedPodDNS},
+		{host: "pod-0.statefulset-svc.ns", expected: fullyQualifiedPodDNS},
+		{host: "missing.ns", expected: "missing.ns"},
+		{host: "linkerd.io", expected: "linkerd.io"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%s from %s", tc.host, tc.clientNs), func(t *testing.T) {
+			fqdn := server.qualifyServiceName(tc.host, tc.clientNs)
+			if fqdn != tc.expected {
+				t.Fatalf("Expected %s, got %s", tc.expected, fqdn)
+			}
+		})
+	}
+}
+
+func TestProfileIDInClusterDomains(t *testing.T) {
+	clusterDomains := []string{"mycluster.local", "alias.local"}
+
+	for _, authority := range []string{"name1.ns.svc.mycluster.local:8989", "name1.ns.svc.alias.local:8989"} {
+		id, err := profileID(authority, contextToken{}, clusterDomains)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expected := watcher.ProfileID{Name: fullyQualifiedName, Namespace: "ns"}
+		if id != expected {
+			t.Fatalf("Expected profile %v for %s, got %v", expected, authority, id)
+		}
+	}
+
+	if _, err := profileID("name1.ns.svc.other.local:8989", contextToken{}, clusterDomains); err == nil {
+		t.Fatal("Expected an error for a name outside of the cluster domains")
+	}
 }
@@ -0,0 +1,303 @@
+package destination
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	pb "github.com/linkerd/linkerd2-proxy-api/go/destination"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	labels "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	logging "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// number of points each replica gets on the hash ring, so that the
+	// destinations are evenly spread across the replicas
+	virtualNodesPerReplica = 64
+
+	// forwardedByKey is the metadata key of the streams forwarded by another
+	// replica, set to the name of that replica. Such streams are always served
+	// locally, so that they're never forwarded twice.
+	forwardedByKey = "l5d-dst-forwarded-by"
+)
+
+var (
+	forwardedStreamsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "destination_forwarded_streams_total",
+		Help: "Number of streams forwarded to the replica owning their destination",
+	}, []string{"method"})
+
+	// errOwnerUnavailable is returned when a stream couldn't be forwarded to
+	// the replica owning its destination, in which case it's served locally
+	errOwnerUnavailable = errors.New("owner replica unavailable")
+)
+
+type (
+	// replicaRing hashes destinations onto the running replicas of the
+	// destination controller, so that the streams for a destination are all
+	// served by the same replica and the replicas only watch the resources of
+	// the destinations they own. The ring is rebuilt whenever the set of
+	// replicas, read from the shared pod informer, changes.
+	replicaRing struct {
+		self string
+		port string
+		// replicas returns the running replicas, sorted by name
+		replicas func() ([]replica, error)
+		log      *logging.Entry
+
+		sync.Mutex
+		members string
+		points  []uint32
+		owners  map[uint32]replica
+		// clients to the other replicas, by address
+		conns map[string]*grpc.ClientConn
+	}
+
+	replica struct {
+		name string
+		addr string
+	}
+)
+
+func newReplicaRing(k8sAPI *k8s.API, controllerNS, self, port string, log *logging.Entry) *replicaRing {
+	selector := k8slabels.Set{labels.ControllerComponentLabel: "destination"}.AsSelector()
+	return &replicaRing{
+		self: self,
+		port: port,
+		replicas: func() ([]replica, error) {
+			pods, err := k8sAPI.Pod().Lister().Pods(controllerNS).List(selector)
+			if err != nil {
+				return nil, err
+			}
+			return runningReplicas(pods, port), nil
+		},
+		log:   log.WithField("component", "replica-ring"),
+		conns: make(map[string]*grpc.ClientConn),
+	}
+}
+
+// owner returns the replica owning the destination of a stream, unless it's
+// this replica, stream hashing is disabled or the stream was forwarded by
+// another replica. When the replicas can't be determined, every destination
+// is served locally.
+func (r *replicaRing) owner(ctx context.Context, path string) (replica, bool) {
+	if r == nil || isForwarded(ctx) {
+		return replica{}, false
+	}
+
+	replicas, err := r.replicas()
+	if err != nil {
+		r.log.Errorf("failed to list destination replicas: %s", err)
+		return replica{}, false
+	}
+	i := sort.Search(len(replicas), func(i int) bool { return replicas[i].name >= r.self })
+	if len(replicas) < 2 || i == len(replicas) || replicas[i].name != r.self {
+		return replica{}, false
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	members := make([]string, len(replicas))
+	for i, replica := range replicas {
+		members[i] = replica.name + "=" + replica.addr
+	}
+	if m := strings.Join(members, ","); m != r.members {
+		r.rebuild(replicas)
+		r.members = m
+		r.log.Debugf("rebuilt ring with replicas %s", m)
+	}
+
+	h := hash(path)
+	i = sort.Search(len(r.points), func(i int) bool { return r.points[i] >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	owner := r.owners[r.points[i]]
+	if owner.name == r.self {
+		return replica{}, false
+	}
+	return owner, true
+}
+
+// client returns a client to the given replica, reusing the connection to
+// it until it leaves the ring
+func (r *replicaRing) client(owner replica) (pb.DestinationClient, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	conn, ok := r.conns[owner.addr]
+	if !ok {
+		var err error
+		_, conn, err = NewClient(owner.addr)
+		if err != nil {
+			return nil, err
+		}
+		r.conns[owner.addr] = conn
+	}
+	return pb.NewDestinationClient(conn), nil
+}
+
+// rebuild must be called with the lock held
+func (r *replicaRing) rebuild(replicas []replica) {
+	r.points = make([]uint32, 0, len(replicas)*virtualNodesPerReplica)
+	r.owners = make(map[uint32]replica, len(replicas)*virtualNodesPerReplica)
+	current := make(map[string]struct{}, len(replicas))
+	for _, replica := range replicas {
+		current[replica.addr] = struct{}{}
+		for i := 0; i < virtualNodesPerReplica; i++ {
+			point := hash(replica.name + "#" + strconv.Itoa(i))
+			r.points = append(r.points, point)
+			r.owners[point] = replica
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool { return r.points[i] < r.points[j] })
+
+	for addr, conn := range r.conns {
+		if _, ok := current[addr]; !ok {
+			conn.Close()
+			delete(r.conns, addr)
+		}
+	}
+}
+
+// runningReplicas returns the running destination pods that have an IP,
+// sorted by name
+func runningReplicas(pods []*corev1.Pod, port string) []replica {
+	replicas := make([]replica, 0, len(pods))
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		replicas = append(replicas, replica{
+			name: pod.Name,
+			addr: net.JoinHostPort(pod.Status.PodIP, port),
+		})
+	}
+	sort.Slice(replicas, func(i, j int) bool { return replicas[i].name < replicas[j].name })
+	return replicas
+}
+
+func hash(s string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	return h.Sum32()
+}
+
+func isForwarded(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	return ok && len(md.Get(forwardedByKey)) > 0
+}
+
+// streamClientKey returns the key the stream's client is limited by. The
+// forwarded streams were already limited by the replica that received them
+// from the client.
+func streamClientKey(ctx context.Context, client *peer.Peer) string {
+	if isForwarded(ctx) {
+		return ""
+	}
+	return clientKey(client)
+}
+
+// forwardGet relays the updates of the Get stream for dest from the replica
+// owning it. errOwnerUnavailable is returned if the owner couldn't be reached
+// before any update was relayed.
+func (s *server) forwardGet(owner replica, dest *pb.GetDestination, stream pb.Destination_GetServer, evicted <-chan struct{}) error {
+	client, err := s.streamRing.client(owner)
+	if err != nil {
+		return errOwnerUnavailable
+	}
+	ctx, cancel := s.forwardingContext(stream.Context(), evicted)
+	defer cancel()
+
+	forwardedStreamsCounter.WithLabelValues("get").Inc()
+	upstream, err := client.Get(ctx, dest)
+	relayed := false
+	for err == nil {
+		var update *pb.Update
+		update, err = upstream.Recv()
+		if err == nil {
+			relayed = true
+			err = stream.Send(update)
+		}
+	}
+	return forwardingError(err, relayed, dest.GetPath(), evicted)
+}
+
+// forwardGetProfile relays the profiles of the GetProfile stream for dest from
+// the replica owning it. errOwnerUnavailable is returned if the owner couldn't
+// be reached before any profile was relayed.
+func (s *server) forwardGetProfile(owner replica, dest *pb.GetDestination, stream pb.Destination_GetProfileServer, evicted <-chan struct{}) error {
+	client, err := s.streamRing.client(owner)
+	if err != nil {
+		return errOwnerUnavailable
+	}
+	ctx, cancel := s.forwardingContext(stream.Context(), evicted)
+	defer cancel()
+
+	forwardedStreamsCounter.WithLabelValues("get_profile").Inc()
+	upstream, err := client.GetProfile(ctx, dest)
+	relayed := false
+	for err == nil {
+		var profile *pb.DestinationProfile
+		profile, err = upstream.Recv()
+		if err == nil {
+			relayed = true
+			err = stream.Send(profile)
+		}
+	}
+	return forwardingError(err, relayed, dest.GetPath(), evicted)
+}
+
+// forwardingContext returns the context of a stream forwarded from ctx, which
+// is cancelled on shutdown or when the authority is evicted
+func (s *server) forwardingContext(ctx context.Context, evicted <-chan struct{}) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(metadata.AppendToOutgoingContext(ctx, forwardedByKey, s.streamRing.self))
+	go func() {
+		select {
+		case <-s.shutdown:
+		case <-evicted:
+		case <-ctx.Done():
+		}
+		cancel()
+	}()
+	return ctx, cancel
+}
+
+func forwardingError(err error, relayed bool, path string, evicted <-chan struct{}) error {
+	select {
+	case <-evicted:
+		return errAuthorityEvicted(path)
+	default:
+	}
+
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	switch status.Code(err) {
+	case codes.Unavailable:
+		if !relayed {
+			return errOwnerUnavailable
+		}
+	case codes.Canceled:
+		// the client went away or the server is shutting down
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,225 @@
+package destination
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/linkerd/linkerd2-proxy-api/go/destination"
+	"github.com/linkerd/linkerd2/controller/api/util"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	logging "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func destinationPod(name, phase, ip string) string {
+	return fmt.Sprintf(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: %s
+  namespace: linkerd
+  labels:
+    linkerd.io/control-plane-component: destination
+status:
+  phase: %s
+  podIP: %s`, name, phase, ip)
+}
+
+// ownerServer is a destination replica recording the streams forwarded to
+// it and sending a single update on each
+type ownerServer struct {
+	pb.UnimplementedDestinationServer
+	forwardedBy chan string
+}
+
+func (o *ownerServer) Get(dest *pb.GetDestination, stream pb.Destination_GetServer) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	o.forwardedBy <- md.Get(forwardedByKey)[0]
+	return stream.Send(&pb.Update{Update: &pb.Update_NoEndpoints{NoEndpoints: &pb.NoEndpoints{Exists: true}}})
+}
+
+type signalingGetStream struct {
+	updates chan *pb.Update
+	util.MockServerStream
+}
+
+func (sgs *signalingGetStream) Send(update *pb.Update) error {
+	select {
+	case sgs.updates <- update:
+	default:
+	}
+	return nil
+}
+
+// staticRing returns a ring of two replicas, self and other, along with a
+// path owned by other
+func staticRing(t *testing.T, self, other replica) (*replicaRing, string) {
+	replicas := []replica{self, other}
+	if other.name < self.name {
+		replicas = []replica{other, self}
+	}
+	ring := &replicaRing{
+		self:     self.name,
+		replicas: func() ([]replica, error) { return replicas, nil },
+		log:      logging.WithField("test", t.Name()),
+		conns:    make(map[string]*grpc.ClientConn),
+	}
+	for i := 0; i < 100; i++ {
+		path := fmt.Sprintf("name1.ns.svc.mycluster.local:%d", 8000+i)
+		if owner, ok := ring.owner(context.Background(), path); ok && owner == other {
+			return ring, path
+		}
+	}
+	t.Fatal("Expected the other replica to own some destination")
+	return nil, ""
+}
+
+func TestReplicaRing(t *testing.T) {
+	log := logging.WithField("test", t.Name())
+
+	t.Run("Partitions destinations among the running replicas", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI(
+			destinationPod("linkerd-destination-a", "Running", "10.0.0.1"),
+			destinationPod("linkerd-destination-b", "Running", "10.0.0.2"),
+			destinationPod("linkerd-destination-c", "Pending", ""),
+		)
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+		k8sAPI.Sync(nil)
+
+		a := newReplicaRing(k8sAPI, "linkerd", "linkerd-destination-a", "8086", log)
+		b := newReplicaRing(k8sAPI, "linkerd", "linkerd-destination-b", "8086", log)
+
+		ownedByA := 0
+		for i := 0; i < 100; i++ {
+			path := fmt.Sprintf("svc-%d.ns.svc.cluster.local:8080", i)
+			ownerOfA, forwardedByA := a.owner(context.Background(), path)
+			ownerOfB, forwardedByB := b.owner(context.Background(), path)
+			if forwardedByA == forwardedByB {
+				t.Fatalf("Expected %s to be owned by exactly one replica", path)
+			}
+			if forwardedByA && ownerOfA.addr != "10.0.0.2:8086" {
+				t.Fatalf("Expected %s to be forwarded to 10.0.0.2:8086, got %s", path, ownerOfA.addr)
+			}
+			if forwardedByB && ownerOfB.addr != "10.0.0.1:8086" {
+				t.Fatalf("Expected %s to be forwarded to 10.0.0.1:8086, got %s", path, ownerOfB.addr)
+			}
+			if !forwardedByA {
+				ownedByA++
+			}
+		}
+		if ownedByA == 0 || ownedByA == 100 {
+			t.Fatalf("Expected destinations to be spread across replicas, replica a owns %d/100", ownedByA)
+		}
+	})
+
+	t.Run("Owns every destination when running alone", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI(destinationPod("linkerd-destination-a", "Running", "10.0.0.1"))
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+		k8sAPI.Sync(nil)
+
+		ring := newReplicaRing(k8sAPI, "linkerd", "linkerd-destination-a", "8086", log)
+		if _, ok := ring.owner(context.Background(), "web.emojivoto.svc.cluster.local:80"); ok {
+			t.Fatal("Expected a single replica to own every destination")
+		}
+	})
+
+	t.Run("Serves the forwarded streams locally", func(t *testing.T) {
+		ring, path := staticRing(t,
+			replica{"linkerd-destination-a", "10.0.0.1:8086"},
+			replica{"linkerd-destination-b", "10.0.0.2:8086"},
+		)
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(forwardedByKey, "linkerd-destination-b"))
+		if _, ok := ring.owner(ctx, path); ok {
+			t.Fatal("Expected a forwarded stream not to be forwarded again")
+		}
+	})
+
+	t.Run("Serves every destination locally when disabled", func(t *testing.T) {
+		var ring *replicaRing
+		if _, ok := ring.owner(context.Background(), "web.emojivoto.svc.cluster.local:80"); ok {
+			t.Fatal("Expected no stream to be forwarded when stream hashing is disabled")
+		}
+	})
+}
+
+func TestStreamForwarding(t *testing.T) {
+	t.Run("Forwards the streams to the owner of their destination", func(t *testing.T) {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Failed to listen: %s", err)
+		}
+		owner := &ownerServer{forwardedBy: make(chan string, 1)}
+		grpcServer := grpc.NewServer()
+		pb.RegisterDestinationServer(grpcServer, owner)
+		go grpcServer.Serve(lis)
+		defer grpcServer.Stop()
+
+		server := makeServer(t)
+		var path string
+		server.streamRing, path = staticRing(t,
+			replica{"linkerd-destination-a", "10.0.0.1:8086"},
+			replica{"linkerd-destination-b", lis.Addr().String()},
+		)
+
+		stream := &bufferingGetStream{
+			updates:          []*pb.Update{},
+			MockServerStream: util.NewMockServerStream(),
+		}
+		err = server.Get(&pb.GetDestination{Scheme: "k8s", Path: path}, stream)
+		if err != nil {
+			t.Fatalf("Got error: %s", err)
+		}
+
+		if forwardedBy := <-owner.forwardedBy; forwardedBy != "linkerd-destination-a" {
+			t.Fatalf("Expected the stream to be forwarded by linkerd-destination-a, got %s", forwardedBy)
+		}
+		if len(stream.updates) != 1 || stream.updates[0].GetNoEndpoints() == nil {
+			t.Fatalf("Expected the update of the owner to be relayed, got %v", stream.updates)
+		}
+	})
+
+	t.Run("Serves the streams locally when their owner is unavailable", func(t *testing.T) {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Failed to listen: %s", err)
+		}
+		// nothing listens on the owner's port anymore
+		unavailable := lis.Addr().String()
+		lis.Close()
+
+		server := makeServer(t)
+		var path string
+		server.streamRing, path = staticRing(t,
+			replica{"linkerd-destination-a", "10.0.0.1:8086"},
+			replica{"linkerd-destination-b", unavailable},
+		)
+
+		stream := &signalingGetStream{
+			updates:          make(chan *pb.Update, 1),
+			MockServerStream: util.NewMockServerStream(),
+		}
+		errs := make(chan error, 1)
+		go func() {
+			errs <- server.Get(&pb.GetDestination{Scheme: "k8s", Path: path}, stream)
+		}()
+
+		select {
+		case <-stream.updates:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected the stream to be served locally")
+		}
+		// the stream is served locally until it's cancelled
+		stream.Cancel()
+		if err := <-errs; err != nil {
+			t.Fatalf("Got error: %s", err)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package destination
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// streamsCounter counts the streams opened by the proxies, by the topology
+// zone of their client, which is empty when it's unknown
+var streamsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "destination_streams_total",
+	Help: "Number of Get and GetProfile streams opened, by the topology zone of their client",
+}, []string{"method", "client_zone"})
+
+// streamUpdatesDropped counts the intermediate updates dropped from the send
+// queues of the streams whose client isn't reading them
+var streamUpdatesDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "destination_stream_updates_dropped_total",
+	Help: "Number of intermediate Get and GetProfile updates dropped because their client wasn't reading them",
+}, []string{"method"})
+
+// slowConsumerResets counts the streams reset because their client stopped
+// reading them
+var slowConsumerResets = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "destination_slow_consumer_resets_total",
+	Help: "Number of Get and GetProfile streams reset because their client stopped reading them",
+}, []string{"method"})
@@ -0,0 +1,196 @@
+package watcher
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	dp "github.com/linkerd/linkerd2/controller/gen/apis/defaultprofile/v1alpha1"
+	dplisters "github.com/linkerd/linkerd2/controller/gen/client/listers/defaultprofile/v1alpha1"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	"github.com/prometheus/client_golang/prometheus"
+	logging "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+type (
+	// DefaultProfileWatcher watches all the default profiles in the Kubernetes
+	// cluster. Listeners can subscribe to a namespace and DefaultProfileWatcher
+	// will publish the default profile that applies to it, and all future
+	// changes to it.
+	DefaultProfileWatcher struct {
+		lister     dplisters.DefaultProfileLister
+		publishers map[string]*defaultProfilePublisher
+
+		log          *logging.Entry
+		sync.RWMutex // This mutex protects modification of the map itself.
+	}
+
+	defaultProfilePublisher struct {
+		profile   *dp.DefaultProfile
+		listeners []DefaultProfileUpdateListener
+
+		log     *logging.Entry
+		metrics metrics
+		// All access to the defaultProfilePublisher is explicitly synchronized
+		// by this mutex.
+		sync.Mutex
+	}
+
+	// DefaultProfileUpdateListener is the interface that subscribers must
+	// implement.
+	DefaultProfileUpdateListener interface {
+		UpdateDefaultProfile(profile *dp.DefaultProfile)
+	}
+)
+
+var defaultProfileVecs = newMetricsVecs("default_profile", []string{"namespace"})
+
+// NewDefaultProfileWatcher creates a DefaultProfileWatcher and begins watching
+// the k8sAPI for default profile changes.
+func NewDefaultProfileWatcher(k8sAPI *k8s.API, log *logging.Entry) *DefaultProfileWatcher {
+	watcher := &DefaultProfileWatcher{
+		lister:     k8sAPI.DP().Lister(),
+		publishers: make(map[string]*defaultProfilePublisher),
+		log:        log.WithField("component", "default-profile-watcher"),
+	}
+
+	k8sAPI.DP().Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    watcher.updateNamespace,
+			UpdateFunc: func(_, obj interface{}) { watcher.updateNamespace(obj) },
+			DeleteFunc: watcher.updateNamespace,
+		},
+	)
+
+	return watcher
+}
+
+// Subscribe to a namespace.
+// The provided listener will be updated each time the default profile that
+// applies to the namespace changes.
+func (dpw *DefaultProfileWatcher) Subscribe(namespace string, listener DefaultProfileUpdateListener) error {
+	dpw.log.Debugf("Establishing watch on default profile of namespace %s", namespace)
+
+	publisher := dpw.getOrNewPublisher(namespace)
+
+	publisher.subscribe(listener)
+	return nil
+}
+
+// Unsubscribe removes a listener from the subscribers list for this namespace.
+func (dpw *DefaultProfileWatcher) Unsubscribe(namespace string, listener DefaultProfileUpdateListener) error {
+	dpw.log.Debugf("Stopping watch on default profile of namespace %s", namespace)
+
+	dpw.RLock()
+	publisher, ok := dpw.publishers[namespace]
+	dpw.RUnlock()
+	if !ok {
+		return fmt.Errorf("cannot unsubscribe from unknown namespace [%s] ", namespace)
+	}
+	publisher.unsubscribe(listener)
+	return nil
+}
+
+func (dpw *DefaultProfileWatcher) updateNamespace(obj interface{}) {
+	profile, ok := obj.(*dp.DefaultProfile)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			dpw.log.Errorf("couldn't get object from DeletedFinalStateUnknown %#v", obj)
+			return
+		}
+		profile, ok = tombstone.Obj.(*dp.DefaultProfile)
+		if !ok {
+			dpw.log.Errorf("DeletedFinalStateUnknown contained object that is not a DefaultProfile %#v", obj)
+			return
+		}
+	}
+
+	dpw.RLock()
+	publisher, ok := dpw.publishers[profile.Namespace]
+	dpw.RUnlock()
+	if ok {
+		publisher.update(dpw.defaultProfile(profile.Namespace))
+	}
+}
+
+// defaultProfile returns the default profile that applies to the namespace:
+// the first one by name when there are several of them.
+func (dpw *DefaultProfileWatcher) defaultProfile(namespace string) *dp.DefaultProfile {
+	profiles, err := dpw.lister.DefaultProfiles(namespace).List(labels.Everything())
+	if err != nil {
+		dpw.log.Errorf("error getting default profiles: %s", err)
+		return nil
+	}
+	if len(profiles) == 0 {
+		return nil
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return profiles[0]
+}
+
+func (dpw *DefaultProfileWatcher) getOrNewPublisher(namespace string) *defaultProfilePublisher {
+	dpw.Lock()
+	defer dpw.Unlock()
+
+	publisher, ok := dpw.publishers[namespace]
+	if !ok {
+		publisher = &defaultProfilePublisher{
+			profile:   dpw.defaultProfile(namespace),
+			listeners: make([]DefaultProfileUpdateListener, 0),
+			log: dpw.log.WithFields(logging.Fields{
+				"component": "default-profile-publisher",
+				"ns":        namespace,
+			}),
+			metrics: defaultProfileVecs.newMetrics(prometheus.Labels{
+				"namespace": namespace,
+			}),
+		}
+		dpw.publishers[namespace] = publisher
+	}
+
+	return publisher
+}
+
+func (dpp *defaultProfilePublisher) subscribe(listener DefaultProfileUpdateListener) {
+	dpp.Lock()
+	defer dpp.Unlock()
+
+	dpp.listeners = append(dpp.listeners, listener)
+	listener.UpdateDefaultProfile(dpp.profile)
+
+	dpp.metrics.setSubscribers(len(dpp.listeners))
+}
+
+func (dpp *defaultProfilePublisher) unsubscribe(listener DefaultProfileUpdateListener) {
+	dpp.Lock()
+	defer dpp.Unlock()
+
+	for i, item := range dpp.listeners {
+		if item == listener {
+			// delete the item from the slice
+			n := len(dpp.listeners)
+			dpp.listeners[i] = dpp.listeners[n-1]
+			dpp.listeners[n-1] = nil
+			dpp.listeners = dpp.listeners[:n-1]
+			break
+		}
+	}
+
+	dpp.metrics.setSubscribers(len(dpp.listeners))
+}
+
+func (dpp *defaultProfilePublisher) update(profile *dp.DefaultProfile) {
+	dpp.Lock()
+	defer dpp.Unlock()
+	dpp.log.Debug("Updating default profile")
+
+	dpp.profile = profile
+	for _, listener := range dpp.listeners {
+		listener.UpdateDefaultProfile(profile)
+	}
+
+	dpp.metrics.incUpdates()
+}
@@ -0,0 +1,102 @@
+package watcher
+
+import (
+	"testing"
+
+	dp "github.com/linkerd/linkerd2/controller/gen/apis/defaultprofile/v1alpha1"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	logging "github.com/sirupsen/logrus"
+)
+
+type bufferingDefaultProfileListener struct {
+	profiles []*dp.DefaultProfile
+}
+
+func (l *bufferingDefaultProfileListener) UpdateDefaultProfile(profile *dp.DefaultProfile) {
+	l.profiles = append(l.profiles, profile)
+}
+
+func TestDefaultProfileWatcher(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		k8sConfigs []string
+		namespace  string
+		expected   []string
+	}{
+		{
+			name: "default profile",
+			k8sConfigs: []string{`
+apiVersion: linkerd.io/v1alpha1
+kind: DefaultProfile
+metadata:
+  name: timeouts
+  namespace: ns
+spec:
+  timeout: 10s`,
+			},
+			namespace: "ns",
+			expected:  []string{"timeouts"},
+		},
+		{
+			name: "several default profiles",
+			k8sConfigs: []string{`
+apiVersion: linkerd.io/v1alpha1
+kind: DefaultProfile
+metadata:
+  name: timeouts
+  namespace: ns
+spec:
+  timeout: 10s`, `
+apiVersion: linkerd.io/v1alpha1
+kind: DefaultProfile
+metadata:
+  name: retries
+  namespace: ns
+spec:
+  isRetryable: true`,
+			},
+			namespace: "ns",
+			expected:  []string{"retries"},
+		},
+		{
+			name: "namespace without default profile",
+			k8sConfigs: []string{`
+apiVersion: linkerd.io/v1alpha1
+kind: DefaultProfile
+metadata:
+  name: timeouts
+  namespace: other
+spec:
+  timeout: 10s`,
+			},
+			namespace: "ns",
+			expected:  []string{""},
+		},
+	} {
+		tt := tt // pin
+		t.Run(tt.name, func(t *testing.T) {
+			k8sAPI, err := k8s.NewFakeAPI(tt.k8sConfigs...)
+			if err != nil {
+				t.Fatalf("NewFakeAPI returned an error: %s", err)
+			}
+
+			watcher := NewDefaultProfileWatcher(k8sAPI, logging.WithField("test", t.Name()))
+
+			k8sAPI.Sync(nil)
+
+			listener := &bufferingDefaultProfileListener{}
+			watcher.Subscribe(tt.namespace, listener)
+
+			actual := []string{}
+			for _, profile := range listener.profiles {
+				if profile == nil {
+					actual = append(actual, "")
+				} else {
+					actual = append(actual, profile.Name)
+				}
+			}
+
+			testCompare(t, tt.expected, actual)
+		})
+	}
+}
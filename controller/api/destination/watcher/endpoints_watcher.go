@@ -3,10 +3,14 @@ package watcher
 import (
 	"context"
 	"fmt"
+	"net"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/linkerd/linkerd2-proxy-init/ports"
 	"github.com/linkerd/linkerd2/controller/gen/apis/server/v1beta1"
 	"github.com/linkerd/linkerd2/controller/k8s"
 	consts "github.com/linkerd/linkerd2/pkg/k8s"
@@ -54,7 +58,11 @@ type (
 		Identity          string
 		AuthorityOverride string
 		ForZones          []discovery.ForZone
-		OpaqueProtocol    bool
+		// Zone is the topology zone of the endpoint, when known
+		Zone string
+		// NodeName is the name of the node hosting the endpoint, when known
+		NodeName       string
+		OpaqueProtocol bool
 	}
 
 	// AddressSet is a set of Address, indexed by ID.
@@ -75,6 +83,9 @@ type (
 	EndpointsWatcher struct {
 		publishers map[ServiceID]*servicePublisher
 		k8sAPI     *k8s.API
+		// protocols caches the protocols of the pods' ports set by the
+		// Servers, shared by all the publishers
+		protocols *ProtocolCache
 
 		log                  *logging.Entry
 		enableEndpointSlices bool
@@ -94,6 +105,7 @@ type (
 		id                   ServiceID
 		log                  *logging.Entry
 		k8sAPI               *k8s.API
+		protocols            *ProtocolCache
 		enableEndpointSlices bool
 		ports                map[portAndHostname]*portPublisher
 		// All access to the servicePublisher and its portPublishers is explicitly synchronized by
@@ -113,11 +125,18 @@ type (
 		hostname             string
 		log                  *logging.Entry
 		k8sAPI               *k8s.API
+		protocols            *ProtocolCache
 		enableEndpointSlices bool
-		exists               bool
-		addresses            AddressSet
-		listeners            []EndpointUpdateListener
-		metrics              endpointsMetrics
+		// addressType is the type of the addresses of the service's primary
+		// IP family; the EndpointSlices of its other family are ignored
+		addressType discovery.AddressType
+		exists      bool
+		addresses   AddressSet
+		// slices holds the addresses of each EndpointSlice of the service,
+		// keyed by slice name; addresses is the merge of all of them
+		slices    map[string]AddressSet
+		listeners []EndpointUpdateListener
+		metrics   endpointsMetrics
 	}
 
 	// EndpointUpdateListener is the interface that subscribers must implement.
@@ -135,10 +154,11 @@ var undefinedEndpointPort = Port(0)
 // NewEndpointsWatcher creates an EndpointsWatcher and begins watching the
 // k8sAPI for pod, service, and endpoint changes. An EndpointsWatcher will
 // watch on Endpoints or EndpointSlice resources, depending on cluster configuration.
-func NewEndpointsWatcher(k8sAPI *k8s.API, log *logging.Entry, enableEndpointSlices bool) *EndpointsWatcher {
+func NewEndpointsWatcher(k8sAPI *k8s.API, log *logging.Entry, enableEndpointSlices bool, protocols *ProtocolCache) *EndpointsWatcher {
 	ew := &EndpointsWatcher{
 		publishers:           make(map[ServiceID]*servicePublisher),
 		k8sAPI:               k8sAPI,
+		protocols:            protocols,
 		enableEndpointSlices: enableEndpointSlices,
 		log: log.WithFields(logging.Fields{
 			"component": "endpoints-watcher",
@@ -157,6 +177,10 @@ func NewEndpointsWatcher(k8sAPI *k8s.API, log *logging.Entry, enableEndpointSlic
 		UpdateFunc: func(_, obj interface{}) { ew.addServer(obj) },
 	})
 
+	k8sAPI.Pod().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: ew.updatePod,
+	})
+
 	if ew.enableEndpointSlices {
 		ew.log.Debugf("Watching EndpointSlice resources")
 		k8sAPI.ES().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -400,6 +424,7 @@ func (ew *EndpointsWatcher) getOrNewServicePublisher(id ServiceID) *servicePubli
 				"svc":       id.Name,
 			}),
 			k8sAPI:               ew.k8sAPI,
+			protocols:            ew.protocols,
 			ports:                make(map[portAndHostname]*portPublisher),
 			enableEndpointSlices: ew.enableEndpointSlices,
 		}
@@ -433,10 +458,65 @@ func (ew *EndpointsWatcher) deleteServer(obj interface{}) {
 	}
 }
 
+// updatePod resyncs the endpoints of the services of the pod's namespace when
+// the pod gets excluded from them, or stops being excluded, as that doesn't
+// change its endpoints
+func (ew *EndpointsWatcher) updatePod(oldObj interface{}, newObj interface{}) {
+	oldPod := oldObj.(*corev1.Pod)
+	newPod := newObj.(*corev1.Pod)
+	if IsPodExcludedFromEndpoints(oldPod) == IsPodExcludedFromEndpoints(newPod) {
+		return
+	}
+
+	ew.RLock()
+	defer ew.RUnlock()
+	for id, sp := range ew.publishers {
+		if id.Namespace == newPod.Namespace {
+			sp.resync()
+		}
+	}
+}
+
 ////////////////////////
 /// servicePublisher ///
 ////////////////////////
 
+// resync recomputes the addresses of all the ports from the current endpoints
+// of the service
+func (sp *servicePublisher) resync() {
+	sp.Lock()
+	defer sp.Unlock()
+	sp.log.Debugf("Resyncing endpoints for %s", sp.id)
+
+	if sp.enableEndpointSlices {
+		selector := labels.Set{discovery.LabelServiceName: sp.id.Name}.AsSelector()
+		slices, err := sp.k8sAPI.ES().Lister().EndpointSlices(sp.id.Namespace).List(selector)
+		if err != nil {
+			sp.log.Errorf("error getting endpointSlice list: %s", err)
+			return
+		}
+		for _, port := range sp.ports {
+			port.slices = make(map[string]AddressSet)
+			for _, slice := range slices {
+				port.slices[slice.Name] = port.endpointSliceToAddresses(slice)
+			}
+			port.publishSlices()
+		}
+		return
+	}
+
+	endpoints, err := sp.k8sAPI.Endpoint().Lister().Endpoints(sp.id.Namespace).Get(sp.id.Name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			sp.log.Errorf("error getting endpoints: %s", err)
+		}
+		return
+	}
+	for _, port := range sp.ports {
+		port.updateEndpoints(endpoints)
+	}
+}
+
 func (sp *servicePublisher) updateEndpoints(newEndpoints *corev1.Endpoints) {
 	sp.Lock()
 	defer sp.Unlock()
@@ -487,9 +567,11 @@ func (sp *servicePublisher) updateService(newService *corev1.Service) {
 	defer sp.Unlock()
 	sp.log.Debugf("Updating service for %s", sp.id)
 
+	addressType := serviceAddressType(newService)
 	for key, port := range sp.ports {
 		newTargetPort := getTargetPort(newService, key.port)
-		if newTargetPort != port.targetPort {
+		if newTargetPort != port.targetPort || addressType != port.addressType {
+			port.addressType = addressType
 			port.updatePort(newTargetPort)
 		}
 	}
@@ -537,8 +619,10 @@ func (sp *servicePublisher) newPortPublisher(srcPort Port, hostname string) *por
 		sp.log.Errorf("error getting service: %s", err)
 	}
 	exists := false
+	addressType := discovery.AddressTypeIPv4
 	if err == nil {
 		targetPort = getTargetPort(svc, srcPort)
+		addressType = serviceAddressType(svc)
 		exists = true
 	}
 
@@ -551,9 +635,12 @@ func (sp *servicePublisher) newPortPublisher(srcPort Port, hostname string) *por
 		hostname:             hostname,
 		exists:               exists,
 		k8sAPI:               sp.k8sAPI,
+		protocols:            sp.protocols,
 		log:                  log,
 		metrics:              endpointsVecs.newEndpointsMetrics(sp.metricsLabels(srcPort, hostname)),
 		enableEndpointSlices: sp.enableEndpointSlices,
+		addressType:          addressType,
+		slices:               make(map[string]AddressSet),
 	}
 
 	if port.enableEndpointSlices {
@@ -566,7 +653,10 @@ func (sp *servicePublisher) newPortPublisher(srcPort Port, hostname string) *por
 		}
 		if err == nil {
 			for _, slice := range sliceList {
-				port.addEndpointSlice(slice)
+				port.slices[slice.Name] = port.endpointSliceToAddresses(slice)
+			}
+			if len(port.slices) > 0 {
+				port.publishSlices()
 			}
 		}
 	} else {
@@ -630,60 +720,79 @@ func (pp *portPublisher) updateEndpoints(endpoints *corev1.Endpoints) {
 }
 
 func (pp *portPublisher) addEndpointSlice(slice *discovery.EndpointSlice) {
-	newAddressSet := pp.endpointSliceToAddresses(slice)
-	for id, addr := range pp.addresses.Addresses {
-		newAddressSet.Addresses[id] = addr
+	pp.slices[slice.Name] = pp.endpointSliceToAddresses(slice)
+	pp.publishSlices()
+}
+
+func (pp *portPublisher) updateEndpointSlice(oldSlice *discovery.EndpointSlice, newSlice *discovery.EndpointSlice) {
+	if oldSlice.Name != newSlice.Name {
+		delete(pp.slices, oldSlice.Name)
 	}
+	pp.slices[newSlice.Name] = pp.endpointSliceToAddresses(newSlice)
+	pp.publishSlices()
+}
 
-	add, _ := diffAddresses(pp.addresses, newAddressSet)
-	if len(add.Addresses) > 0 {
+// publishSlices merges the addresses of all the slices and publishes the
+// difference with the current address set to the listeners
+func (pp *portPublisher) publishSlices() {
+	merged := pp.mergeSlices()
+
+	if len(merged.Addresses) == 0 && len(pp.addresses.Addresses) > 0 {
+		for _, listener := range pp.listeners {
+			listener.NoEndpoints(true)
+		}
+	} else {
+		add, remove := diffAddresses(pp.addresses, merged)
 		for _, listener := range pp.listeners {
-			listener.Add(add)
+			if len(remove.Addresses) > 0 {
+				listener.Remove(remove)
+			}
+			if len(add.Addresses) > 0 {
+				listener.Add(add)
+			}
 		}
 	}
 
-	pp.addresses = newAddressSet
+	pp.addresses = merged
 	pp.exists = true
 	pp.metrics.incUpdates()
 	pp.metrics.setPods(len(pp.addresses.Addresses))
 	pp.metrics.setExists(true)
 }
 
-func (pp *portPublisher) updateEndpointSlice(oldSlice *discovery.EndpointSlice, newSlice *discovery.EndpointSlice) {
-	updatedAddressSet := AddressSet{
-		Addresses: make(map[ID]Address),
-		Labels:    pp.addresses.Labels,
-	}
-
-	for id, address := range pp.addresses.Addresses {
-		updatedAddressSet.Addresses[id] = address
-	}
+// mergeSlices merges the addresses of all the slices of the service. Slices
+// are merged in name order and, when an address is listed in more than one
+// slice (e.g. while an endpoint moves between slices), the first slice wins,
+// so that the result doesn't depend on the order in which the slice events
+// were received.
+func (pp *portPublisher) mergeSlices() AddressSet {
+	start := time.Now()
 
-	oldAddressSet := pp.endpointSliceToAddresses(oldSlice)
-	for id := range oldAddressSet.Addresses {
-		delete(updatedAddressSet.Addresses, id)
+	names := make([]string, 0, len(pp.slices))
+	for name := range pp.slices {
+		names = append(names, name)
 	}
+	sort.Strings(names)
 
-	newAddressSet := pp.endpointSliceToAddresses(newSlice)
-	for id, address := range newAddressSet.Addresses {
-		updatedAddressSet.Addresses[id] = address
+	merged := AddressSet{
+		Addresses: make(map[ID]Address),
+		Labels:    pp.addresses.Labels,
 	}
-
-	add, remove := diffAddresses(pp.addresses, updatedAddressSet)
-	for _, listener := range pp.listeners {
-		if len(remove.Addresses) > 0 {
-			listener.Remove(remove)
+	for i, name := range names {
+		slice := pp.slices[name]
+		if i == 0 {
+			merged.Labels = slice.Labels
 		}
-		if len(add.Addresses) > 0 {
-			listener.Add(add)
+		for id, address := range slice.Addresses {
+			if _, ok := merged.Addresses[id]; !ok {
+				merged.Addresses[id] = address
+			}
 		}
 	}
 
-	pp.addresses = updatedAddressSet
-	pp.exists = true
-	pp.metrics.incUpdates()
-	pp.metrics.setPods(len(pp.addresses.Addresses))
-	pp.metrics.setExists(true)
+	pp.metrics.setSlices(len(pp.slices))
+	pp.metrics.observeSliceMerge(time.Since(start))
+	return merged
 }
 
 func metricLabels(resource interface{}) map[string]string {
@@ -723,7 +832,7 @@ func metricLabels(resource interface{}) map[string]string {
 
 func (pp *portPublisher) endpointSliceToAddresses(es *discovery.EndpointSlice) AddressSet {
 	resolvedPort := pp.resolveESTargetPort(es.Ports)
-	if resolvedPort == undefinedEndpointPort {
+	if resolvedPort == undefinedEndpointPort || (es.AddressType != "" && es.AddressType != pp.addressType) {
 		return AddressSet{
 			Labels:    metricLabels(es),
 			Addresses: make(map[ID]Address),
@@ -756,6 +865,8 @@ func (pp *portPublisher) endpointSliceToAddresses(es *discovery.EndpointSlice) A
 				identity := es.Annotations[consts.RemoteGatewayIdentity]
 				address, id := pp.newServiceRefAddress(resolvedPort, IPAddr, serviceID.Name, es.Namespace)
 				address.Identity, address.AuthorityOverride = identity, authorityOverride
+				address.Zone = endpoint.Topology[corev1.LabelTopologyZone]
+				address.NodeName = getNodeName(endpoint.NodeName)
 
 				if endpoint.Hints != nil {
 					zones := make([]discovery.ForZone, len(endpoint.Hints.ForZones))
@@ -774,11 +885,17 @@ func (pp *portPublisher) endpointSliceToAddresses(es *discovery.EndpointSlice) A
 					pp.log.Errorf("Unable to create new address:%v", err)
 					continue
 				}
-				err = SetToServerProtocol(pp.k8sAPI, &address, resolvedPort)
+				if IsPodExcludedFromEndpoints(address.Pod) {
+					pp.log.Debugf("Skipping pod %s excluded from the endpoints", id)
+					continue
+				}
+				err = pp.protocols.setToServerProtocol(pp.k8sAPI, &address, resolvedPort)
 				if err != nil {
 					pp.log.Errorf("failed to set address OpaqueProtocol: %s", err)
 					continue
 				}
+				address.Zone = endpoint.Topology[corev1.LabelTopologyZone]
+				address.NodeName = getNodeName(endpoint.NodeName)
 				if endpoint.Hints != nil {
 					zones := make([]discovery.ForZone, len(endpoint.Hints.ForZones))
 					copy(zones, endpoint.Hints.ForZones)
@@ -827,11 +944,16 @@ func (pp *portPublisher) endpointsToAddresses(endpoints *corev1.Endpoints) Addre
 					pp.log.Errorf("Unable to create new address:%v", err)
 					continue
 				}
-				err = SetToServerProtocol(pp.k8sAPI, &address, resolvedPort)
+				if IsPodExcludedFromEndpoints(address.Pod) {
+					pp.log.Debugf("Skipping pod %s excluded from the endpoints", id)
+					continue
+				}
+				err = pp.protocols.setToServerProtocol(pp.k8sAPI, &address, resolvedPort)
 				if err != nil {
 					pp.log.Errorf("failed to set address OpaqueProtocol: %s", err)
 					continue
 				}
+				address.NodeName = getNodeName(endpoint.NodeName)
 				addresses[id] = address
 			}
 		}
@@ -842,6 +964,13 @@ func (pp *portPublisher) endpointsToAddresses(endpoints *corev1.Endpoints) Addre
 	}
 }
 
+func getNodeName(nodeName *string) string {
+	if nodeName == nil {
+		return ""
+	}
+	return *nodeName
+}
+
 func (pp *portPublisher) newServiceRefAddress(endpointPort Port, endpointIP, serviceName, serviceNamespace string) (Address, ServiceID) {
 	id := ServiceID{
 		Name: strings.Join([]string{
@@ -876,6 +1005,22 @@ func (pp *portPublisher) newPodRefAddress(endpointPort Port, endpointIP, podName
 	return addr, id, nil
 }
 
+// serviceAddressType returns the type of the addresses of the service's
+// primary IP family. The endpoints of dual-stack services are resolved to the
+// addresses of that family only, so that each pod is only listed once.
+func serviceAddressType(svc *corev1.Service) discovery.AddressType {
+	if len(svc.Spec.IPFamilies) > 0 {
+		if svc.Spec.IPFamilies[0] == corev1.IPv6Protocol {
+			return discovery.AddressTypeIPv6
+		}
+		return discovery.AddressTypeIPv4
+	}
+	if ip := net.ParseIP(svc.Spec.ClusterIP); ip != nil && ip.To4() == nil {
+		return discovery.AddressTypeIPv6
+	}
+	return discovery.AddressTypeIPv4
+}
+
 func (pp *portPublisher) resolveESTargetPort(slicePorts []discovery.EndpointPort) Port {
 	if slicePorts == nil {
 		return undefinedEndpointPort
@@ -922,8 +1067,12 @@ func (pp *portPublisher) updatePort(targetPort namedPort) {
 		endpointSlices, err := pp.k8sAPI.ES().Lister().EndpointSlices(pp.id.Namespace).List(selector)
 		if err == nil {
 			pp.addresses = AddressSet{}
+			pp.slices = make(map[string]AddressSet)
 			for _, slice := range endpointSlices {
-				pp.addEndpointSlice(slice)
+				pp.slices[slice.Name] = pp.endpointSliceToAddresses(slice)
+			}
+			if len(pp.slices) > 0 {
+				pp.publishSlices()
 			}
 		} else {
 			pp.log.Errorf("Unable to get EndpointSlices during port update: %s", err)
@@ -939,17 +1088,17 @@ func (pp *portPublisher) updatePort(targetPort namedPort) {
 }
 
 func (pp *portPublisher) deleteEndpointSlice(es *discovery.EndpointSlice) {
-	addrSet := pp.endpointSliceToAddresses(es)
-	for id := range addrSet.Addresses {
-		delete(pp.addresses.Addresses, id)
+	delete(pp.slices, es.Name)
+	if len(pp.slices) > 0 {
+		pp.publishSlices()
+		return
 	}
 
 	for _, listener := range pp.listeners {
-		listener.Remove(addrSet)
+		listener.Remove(pp.addresses)
 	}
-
-	svcExists := len(pp.addresses.Addresses) > 0
-	pp.noEndpoints(svcExists)
+	pp.metrics.setSlices(0)
+	pp.noEndpoints(false)
 }
 
 func (pp *portPublisher) noEndpoints(exists bool) {
@@ -996,24 +1145,7 @@ func (pp *portPublisher) unsubscribe(listener EndpointUpdateListener) {
 func (pp *portPublisher) updateServer(server *v1beta1.Server, selector labels.Selector, isAdd bool) {
 	for id, address := range pp.addresses.Addresses {
 		if address.Pod != nil && selector.Matches(labels.Set(address.Pod.Labels)) {
-			var portMatch bool
-			switch server.Spec.Port.Type {
-			case intstr.Int:
-				if server.Spec.Port.IntVal == int32(address.Port) {
-					portMatch = true
-				}
-			case intstr.String:
-				for _, c := range address.Pod.Spec.Containers {
-					for _, p := range c.Ports {
-						if p.ContainerPort == int32(address.Port) && p.Name == server.Spec.Port.StrVal {
-							portMatch = true
-						}
-					}
-				}
-			default:
-				continue
-			}
-			if portMatch {
+			if serverSelectsPort(server, address.Pod, address.Port) {
 				if isAdd && server.Spec.ProxyProtocol == opaqueProtocol {
 					address.OpaqueProtocol = true
 				} else {
@@ -1149,41 +1281,70 @@ func isValidSlice(es *discovery.EndpointSlice) bool {
 // SetToServerProtocol sets the address's OpaqueProtocol field based off any
 // Servers that select it and override the expected protocol.
 func SetToServerProtocol(k8sAPI *k8s.API, address *Address, port Port) error {
-	servers, err := k8sAPI.Srv().Lister().Servers("").List(labels.Everything())
-	if err != nil {
-		return fmt.Errorf("failed to list Servers: %s", err)
-	}
 	if address.Pod == nil {
 		return fmt.Errorf("endpoint not backed by Pod: %s:%d", address.IP, address.Port)
 	}
+	opaque, err := isOpaqueServerPort(k8sAPI, address.Pod, port)
+	if err != nil {
+		return err
+	}
+	if opaque {
+		address.OpaqueProtocol = true
+	}
+	return nil
+}
+
+// isOpaqueServerPort returns true if an opaque Server selects the port of the
+// pod.
+func isOpaqueServerPort(k8sAPI *k8s.API, pod *corev1.Pod, port Port) (bool, error) {
+	servers, err := k8sAPI.Srv().Lister().Servers("").List(labels.Everything())
+	if err != nil {
+		return false, fmt.Errorf("failed to list Servers: %s", err)
+	}
 	for _, server := range servers {
 		selector, err := metav1.LabelSelectorAsSelector(server.Spec.PodSelector)
 		if err != nil {
-			return fmt.Errorf("failed to create Selector: %s", err)
+			return false, fmt.Errorf("failed to create Selector: %s", err)
+		}
+		if server.Spec.ProxyProtocol == opaqueProtocol && selector.Matches(labels.Set(pod.Labels)) {
+			if serverSelectsPort(server, pod, port) {
+				return true, nil
+			}
 		}
-		if server.Spec.ProxyProtocol == opaqueProtocol && selector.Matches(labels.Set(address.Pod.Labels)) {
-			var portMatch bool
-			switch server.Spec.Port.Type {
-			case intstr.Int:
-				if server.Spec.Port.IntVal == int32(port) {
-					portMatch = true
+	}
+	return false, nil
+}
+
+// serverSelectsPort returns true if the port of the Server selects the port of
+// the pod. The Server's port is either a number, the name of a container port,
+// or a range of numbers such as `4000-4100`; as a range is also a valid port
+// name, the names of the container ports take precedence.
+func serverSelectsPort(server *v1beta1.Server, pod *corev1.Pod, port Port) bool {
+	switch server.Spec.Port.Type {
+	case intstr.Int:
+		return server.Spec.Port.IntVal == int32(port)
+	case intstr.String:
+		named := false
+		for _, c := range pod.Spec.Containers {
+			for _, p := range c.Ports {
+				if p.Name != server.Spec.Port.StrVal {
+					continue
 				}
-			case intstr.String:
-				for _, c := range address.Pod.Spec.Containers {
-					for _, p := range c.Ports {
-						if p.ContainerPort == int32(port) && p.Name == server.Spec.Port.StrVal {
-							portMatch = true
-						}
-					}
+				if p.ContainerPort == int32(port) {
+					return true
 				}
-			default:
-				continue
-			}
-			if portMatch {
-				address.OpaqueProtocol = true
-				return nil
+				named = true
 			}
 		}
+		if named {
+			return false
+		}
+		portRange, err := ports.ParsePortRange(server.Spec.Port.StrVal)
+		if err != nil {
+			return false
+		}
+		return int(port) >= portRange.LowerBound && int(port) <= portRange.UpperBound
+	default:
+		return false
 	}
-	return nil
 }
@@ -6,12 +6,14 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/linkerd/linkerd2/controller/gen/apis/server/v1beta1"
 	"github.com/linkerd/linkerd2/controller/k8s"
 	consts "github.com/linkerd/linkerd2/pkg/k8s"
 	logging "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	dv1beta1 "k8s.io/api/discovery/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type bufferingEndpointListener struct {
@@ -660,7 +662,8 @@ status:
 				t.Fatalf("NewFakeAPI returned an error: %s", err)
 			}
 
-			watcher := NewEndpointsWatcher(k8sAPI, logging.WithField("test", t.Name()), false)
+			log := logging.WithField("test", t.Name())
+			watcher := NewEndpointsWatcher(k8sAPI, log, false, NewProtocolCache(k8sAPI, log))
 
 			k8sAPI.Sync(nil)
 
@@ -1268,6 +1271,107 @@ status:
 			expectedNoEndpoints:              true,
 			expectedNoEndpointsServiceExists: true,
 			expectedError:                    false,
+		},
+		{
+			serviceType: "dual-stack service with EndpointSlices of both families",
+			k8sConfigs: []string{`
+kind: APIResourceList
+apiVersion: v1
+groupVersion: discovery.k8s.io/v1beta1
+resources:
+  - name: endpointslices
+    singularName: endpointslice
+    namespaced: true
+    kind: EndpointSlice
+    verbs:
+      - delete
+      - deletecollection
+      - get
+      - list
+      - patch
+      - create
+      - update
+      - watch
+`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: name-6
+  namespace: ns
+spec:
+  type: ClusterIP
+  clusterIP: fd00:10:96::a
+  clusterIPs:
+  - fd00:10:96::a
+  - 10.96.0.10
+  ipFamilies:
+  - IPv6
+  - IPv4
+  ports:
+  - port: 9000`, `
+addressType: IPv4
+apiVersion: discovery.k8s.io/v1beta1
+endpoints:
+- addresses:
+  - 172.17.0.30
+  conditions:
+    ready: true
+  targetRef:
+    kind: Pod
+    name: name-6-1
+    namespace: ns
+kind: EndpointSlice
+metadata:
+  labels:
+    kubernetes.io/service-name: name-6
+  name: name-6-ipv4
+  namespace: ns
+ports:
+- name: ""
+  port: 9000`, `
+addressType: IPv6
+apiVersion: discovery.k8s.io/v1beta1
+endpoints:
+- addresses:
+  - fd00:10:244::1e
+  conditions:
+    ready: true
+  targetRef:
+    kind: Pod
+    name: name-6-1
+    namespace: ns
+kind: EndpointSlice
+metadata:
+  labels:
+    kubernetes.io/service-name: name-6
+  name: name-6-ipv6
+  namespace: ns
+ports:
+- name: ""
+  port: 9000`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: name-6-1
+  namespace: ns
+  ownerReferences:
+  - kind: ReplicaSet
+    name: rs-1
+status:
+  phase: Running
+  podIP: fd00:10:244::1e
+  podIPs:
+  - ip: fd00:10:244::1e
+  - ip: 172.17.0.30`,
+			},
+			id:   ServiceID{Name: "name-6", Namespace: "ns"},
+			port: 9000,
+			expectedAddresses: []string{
+				"fd00:10:244::1e:9000",
+			},
+			expectedNoEndpoints:              false,
+			expectedNoEndpointsServiceExists: false,
+			expectedError:                    false,
 		}} {
 		tt := tt // pin
 		t.Run("subscribes listener to "+tt.serviceType, func(t *testing.T) {
@@ -1276,7 +1380,8 @@ status:
 				t.Fatalf("NewFakeAPI returned an error: %s", err)
 			}
 
-			watcher := NewEndpointsWatcher(k8sAPI, logging.WithField("test", t.Name()), true)
+			log := logging.WithField("test", t.Name())
+			watcher := NewEndpointsWatcher(k8sAPI, log, true, NewProtocolCache(k8sAPI, log))
 
 			k8sAPI.Sync(nil)
 
@@ -1393,7 +1498,8 @@ status:
 				t.Fatalf("NewFakeAPI returned an error: %s", err)
 			}
 
-			watcher := NewEndpointsWatcher(k8sAPI, logging.WithField("test", t.Name()), false)
+			log := logging.WithField("test", t.Name())
+			watcher := NewEndpointsWatcher(k8sAPI, log, false, NewProtocolCache(k8sAPI, log))
 
 			k8sAPI.Sync(nil)
 
@@ -1514,7 +1620,8 @@ status:
 				t.Fatalf("NewFakeAPI returned an error: %s", err)
 			}
 
-			watcher := NewEndpointsWatcher(k8sAPI, logging.WithField("test", t.Name()), true)
+			log := logging.WithField("test", t.Name())
+			watcher := NewEndpointsWatcher(k8sAPI, log, true, NewProtocolCache(k8sAPI, log))
 
 			k8sAPI.Sync(nil)
 
@@ -1741,7 +1848,8 @@ subsets:
 				t.Fatalf("NewFakeAPI returned an error: %s", err)
 			}
 
-			watcher := NewEndpointsWatcher(k8sAPI, logging.WithField("test", t.Name()), tt.enableEndpointSlices)
+			log := logging.WithField("test", t.Name())
+			watcher := NewEndpointsWatcher(k8sAPI, log, tt.enableEndpointSlices, NewProtocolCache(k8sAPI, log))
 
 			k8sAPI.Sync(nil)
 
@@ -1901,7 +2009,8 @@ subsets:
 				t.Fatalf("NewFakeAPI returned an error: %s", err)
 			}
 
-			watcher := NewEndpointsWatcher(k8sAPI, logging.WithField("test", t.Name()), false)
+			log := logging.WithField("test", t.Name())
+			watcher := NewEndpointsWatcher(k8sAPI, log, false, NewProtocolCache(k8sAPI, log))
 
 			k8sAPI.Sync(nil)
 
@@ -2024,7 +2133,8 @@ status:
 				t.Fatalf("NewFakeAPI returned an error: %s", err)
 			}
 
-			watcher := NewEndpointsWatcher(k8sAPI, logging.WithField("test", t.Name()), false)
+			log := logging.WithField("test", t.Name())
+			watcher := NewEndpointsWatcher(k8sAPI, log, false, NewProtocolCache(k8sAPI, log))
 
 			k8sAPI.Sync(nil)
 
@@ -2046,3 +2156,276 @@ status:
 		})
 	}
 }
+
+func TestPodExcludedFromEndpoints(t *testing.T) {
+	k8sConfigs := []string{`
+apiVersion: v1
+kind: Service
+metadata:
+  name: name1
+  namespace: ns
+spec:
+  type: LoadBalancer
+  ports:
+  - port: 8989`,
+		`
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: name1
+  namespace: ns
+subsets:
+- addresses:
+  - ip: 172.17.0.12
+    targetRef:
+      kind: Pod
+      name: name1-1
+      namespace: ns
+  - ip: 172.17.0.13
+    targetRef:
+      kind: Pod
+      name: name1-2
+      namespace: ns
+  - ip: 172.17.0.14
+    targetRef:
+      kind: Pod
+      name: name1-3
+      namespace: ns
+  ports:
+  - port: 8989`,
+		`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: name1-1
+  namespace: ns
+status:
+  phase: Running
+  podIP: 172.17.0.12`,
+		`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: name1-2
+  namespace: ns
+  annotations:
+    linkerd.io/skip-endpoints: "true"
+status:
+  phase: Running
+  podIP: 172.17.0.13`,
+		`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: name1-3
+  namespace: ns
+spec:
+  ephemeralContainers:
+  - name: debugger
+    image: busybox
+status:
+  phase: Running
+  podIP: 172.17.0.14
+  ephemeralContainerStatuses:
+  - name: debugger
+    state:
+      running: {}`}
+
+	k8sAPI, err := k8s.NewFakeAPI(k8sConfigs...)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+
+	log := logging.WithField("test", t.Name())
+	watcher := NewEndpointsWatcher(k8sAPI, log, false, NewProtocolCache(k8sAPI, log))
+
+	k8sAPI.Sync(nil)
+
+	listener := newBufferingEndpointListener()
+
+	err = watcher.Subscribe(ServiceID{Name: "name1", Namespace: "ns"}, 8989, "", listener)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener.ExpectAdded([]string{"172.17.0.12:8989"}, t)
+
+	// the debugging session of the third pod is over
+	oldPod, err := k8sAPI.Pod().Lister().Pods("ns").Get("name1-3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newPod := oldPod.DeepCopy()
+	newPod.Status.EphemeralContainerStatuses[0].State = corev1.ContainerState{
+		Terminated: &corev1.ContainerStateTerminated{ExitCode: 0},
+	}
+	err = k8sAPI.Pod().Informer().GetStore().Update(newPod)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	watcher.updatePod(oldPod, newPod)
+	listener.ExpectAdded([]string{"172.17.0.12:8989", "172.17.0.14:8989"}, t)
+}
+
+func TestEndpointSliceMerge(t *testing.T) {
+	k8sConfigs := []string{`
+kind: APIResourceList
+apiVersion: v1
+groupVersion: discovery.k8s.io/v1beta1
+resources:
+  - name: endpointslices
+    singularName: endpointslice
+    namespaced: true
+    kind: EndpointSlice
+    verbs:
+      - delete
+      - deletecollection
+      - get
+      - list
+      - patch
+      - create
+      - update
+      - watch
+`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: name1
+  namespace: ns
+spec:
+  type: LoadBalancer
+  ports:
+  - port: 8989`, `
+addressType: IPv4
+apiVersion: discovery.k8s.io/v1beta1
+endpoints:
+- addresses:
+  - 172.17.0.12
+  conditions:
+    ready: true
+  targetRef:
+    kind: Pod
+    name: name1-1
+    namespace: ns
+kind: EndpointSlice
+metadata:
+  labels:
+    kubernetes.io/service-name: name1
+  name: name1-del
+  namespace: ns
+ports:
+- name: ""
+  port: 8989`, `
+addressType: IPv4
+apiVersion: discovery.k8s.io/v1beta1
+endpoints:
+- addresses:
+  - 172.17.0.12
+  conditions:
+    ready: true
+  targetRef:
+    kind: Pod
+    name: name1-1
+    namespace: ns
+- addresses:
+  - 172.17.0.13
+  conditions:
+    ready: true
+  targetRef:
+    kind: Pod
+    name: name1-2
+    namespace: ns
+kind: EndpointSlice
+metadata:
+  labels:
+    kubernetes.io/service-name: name1
+  name: name1-abc
+  namespace: ns
+ports:
+- name: ""
+  port: 8989`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: name1-1
+  namespace: ns
+status:
+  phase: Running
+  podIP: 172.17.0.12`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: name1-2
+  namespace: ns
+status:
+  phase: Running
+  podIP: 172.17.0.13`}
+
+	k8sAPI, err := k8s.NewFakeAPI(k8sConfigs...)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+
+	log := logging.WithField("test", t.Name())
+	watcher := NewEndpointsWatcher(k8sAPI, log, true, NewProtocolCache(k8sAPI, log))
+
+	k8sAPI.Sync(nil)
+
+	listener := newBufferingEndpointListener()
+
+	err = watcher.Subscribe(ServiceID{Name: "name1", Namespace: "ns"}, 8989, "", listener)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// addresses listed in several slices are only published once
+	listener.ExpectAdded([]string{"172.17.0.12:8989", "172.17.0.13:8989"}, t)
+
+	// deleting a slice doesn't remove the addresses still listed in another
+	watcher.deleteEndpointSlice(createTestEndpointSlice())
+
+	listener.ExpectRemoved([]string{}, t)
+	if listener.endpointsAreNotCalled() {
+		t.Fatal("Expected NoEndpoints not to be called")
+	}
+}
+
+func TestServerSelectsPort(t *testing.T) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Ports: []corev1.ContainerPort{
+						{Name: "http", ContainerPort: 8080},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		serverPort intstr.IntOrString
+		port       Port
+		expected   bool
+	}{
+		{serverPort: intstr.FromInt(8080), port: 8080, expected: true},
+		{serverPort: intstr.FromInt(8080), port: 8081, expected: false},
+		{serverPort: intstr.FromString("http"), port: 8080, expected: true},
+		{serverPort: intstr.FromString("http"), port: 8081, expected: false},
+		{serverPort: intstr.FromString("4000-4100"), port: 4000, expected: true},
+		{serverPort: intstr.FromString("4000-4100"), port: 4100, expected: true},
+		{serverPort: intstr.FromString("4000-4100"), port: 4101, expected: false},
+		{serverPort: intstr.FromString("4100-4000"), port: 4050, expected: false},
+		{serverPort: intstr.FromString("admin"), port: 4191, expected: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%s/%d", tc.serverPort.String(), tc.port), func(t *testing.T) {
+			server := &v1beta1.Server{Spec: v1beta1.ServerSpec{Port: tc.serverPort}}
+			if selected := serverSelectsPort(server, pod, tc.port); selected != tc.expected {
+				t.Fatalf("Expected port %d to be selected: %t, got %t", tc.port, tc.expected, selected)
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@ package watcher
 
 import (
 	"fmt"
+	"net"
 
 	"github.com/linkerd/linkerd2/controller/k8s"
+	consts "github.com/linkerd/linkerd2/pkg/k8s"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/cache"
@@ -56,6 +58,10 @@ func (i ID) String() string {
 func InitializeIndexers(k8sAPI *k8s.API) error {
 	err := k8sAPI.Svc().Informer().AddIndexers(cache.Indexers{PodIPIndex: func(obj interface{}) ([]string, error) {
 		if svc, ok := obj.(*corev1.Service); ok {
+			// dual-stack services have a cluster IP per family
+			if len(svc.Spec.ClusterIPs) > 0 {
+				return svc.Spec.ClusterIPs, nil
+			}
 			return []string{svc.Spec.ClusterIP}, nil
 		}
 		return nil, fmt.Errorf("object is not a service")
@@ -74,7 +80,15 @@ func InitializeIndexers(k8sAPI *k8s.API) error {
 			if pod.Spec.HostNetwork {
 				return nil, nil
 			}
-			return []string{pod.Status.PodIP}, nil
+			// pods of dual-stack clusters have an IP per family
+			ips := []string{}
+			for _, ip := range pod.Status.PodIPs {
+				ips = append(ips, ip.IP)
+			}
+			if len(ips) == 0 {
+				ips = append(ips, pod.Status.PodIP)
+			}
+			return ips, nil
 		}
 		return nil, fmt.Errorf("object is not a pod")
 	}})
@@ -93,7 +107,7 @@ func InitializeIndexers(k8sAPI *k8s.API) error {
 				for _, c := range pod.Spec.Containers {
 					for _, p := range c.Ports {
 						if p.HostPort != 0 {
-							addr := fmt.Sprintf("%s:%d", pod.Status.HostIP, p.HostPort)
+							addr := net.JoinHostPort(pod.Status.HostIP, fmt.Sprint(p.HostPort))
 							hostIPPods = append(hostIPPods, addr)
 						}
 					}
@@ -110,3 +124,24 @@ func InitializeIndexers(k8sAPI *k8s.API) error {
 
 	return nil
 }
+
+// IsPodExcludedFromEndpoints returns true if the pod shouldn't receive the
+// traffic of its services: it has the skip endpoints annotation, or it's being
+// debugged through an ephemeral container that hasn't terminated yet
+func IsPodExcludedFromEndpoints(pod *corev1.Pod) bool {
+	if pod.Annotations[consts.SkipEndpointsAnnotation] == "true" {
+		return true
+	}
+
+	terminated := make(map[string]bool)
+	for _, status := range pod.Status.EphemeralContainerStatuses {
+		terminated[status.Name] = status.State.Terminated != nil
+	}
+	for _, container := range pod.Spec.EphemeralContainers {
+		// the ephemeral containers without a status yet are being started
+		if !terminated[container.Name] {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package watcher
 
 import (
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/linkerd/linkerd2-proxy-init/ports"
@@ -10,18 +11,22 @@ import (
 	"github.com/linkerd/linkerd2/pkg/util"
 	logging "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/cache"
 )
 
 type (
 	// OpaquePortsWatcher watches all the services in the cluster. If the
 	// opaque ports annotation is added to a service, the watcher will update
-	// listeners—if any—subscribed to that service.
+	// listeners—if any—subscribed to that service. Service ports targeting a
+	// container port whose name starts with opaquePortNamePrefix are opaque
+	// as well.
 	OpaquePortsWatcher struct {
-		subscriptions      map[ServiceID]*svcSubscriptions
-		k8sAPI             *k8s.API
-		log                *logging.Entry
-		defaultOpaquePorts map[uint32]struct{}
+		subscriptions        map[ServiceID]*svcSubscriptions
+		k8sAPI               *k8s.API
+		log                  *logging.Entry
+		defaultOpaquePorts   map[uint32]struct{}
+		opaquePortNamePrefix string
 		sync.RWMutex
 	}
 
@@ -37,13 +42,15 @@ type (
 )
 
 // NewOpaquePortsWatcher creates a OpaquePortsWatcher and begins watching for
-// k8sAPI for service changes.
-func NewOpaquePortsWatcher(k8sAPI *k8s.API, log *logging.Entry, opaquePorts map[uint32]struct{}) *OpaquePortsWatcher {
+// k8sAPI for service changes. An empty opaquePortNamePrefix disables the
+// discovery of opaque ports from the container port names.
+func NewOpaquePortsWatcher(k8sAPI *k8s.API, log *logging.Entry, opaquePorts map[uint32]struct{}, opaquePortNamePrefix string) *OpaquePortsWatcher {
 	opw := &OpaquePortsWatcher{
-		subscriptions:      make(map[ServiceID]*svcSubscriptions),
-		k8sAPI:             k8sAPI,
-		log:                log.WithField("component", "opaque-ports-watcher"),
-		defaultOpaquePorts: opaquePorts,
+		subscriptions:        make(map[ServiceID]*svcSubscriptions),
+		k8sAPI:               k8sAPI,
+		log:                  log.WithField("component", "opaque-ports-watcher"),
+		defaultOpaquePorts:   opaquePorts,
+		opaquePortNamePrefix: opaquePortNamePrefix,
 	}
 	k8sAPI.Svc().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    opw.addService,
@@ -113,16 +120,11 @@ func (opw *OpaquePortsWatcher) addService(obj interface{}) {
 		Namespace: svc.Namespace,
 		Name:      svc.Name,
 	}
-	opaquePorts, ok, err := getServiceOpaquePortsAnnotation(svc)
+	opaquePorts, err := opw.serviceOpaquePorts(svc)
 	if err != nil {
 		opw.log.Errorf("failed to get %s service opaque ports annotation: %s", id, err)
 		return
 	}
-	// If the opaque ports annotation was not set, then set the service's
-	// opaque ports to the default value.
-	if !ok {
-		opaquePorts = opw.defaultOpaquePorts
-	}
 	ss, ok := opw.subscriptions[id]
 	// If there are no subscriptions for this service, create one with the
 	// opaque ports.
@@ -182,6 +184,60 @@ func (opw *OpaquePortsWatcher) deleteService(obj interface{}) {
 	}
 }
 
+// SetDefaultOpaquePorts replaces the default opaque ports, which apply to the
+// services without the opaque ports annotation. The listeners of the services
+// whose opaque ports change are updated.
+func (opw *OpaquePortsWatcher) SetDefaultOpaquePorts(opaquePorts map[uint32]struct{}) {
+	opw.Lock()
+	defer opw.Unlock()
+	if portsEqual(opw.defaultOpaquePorts, opaquePorts) {
+		return
+	}
+	opw.log.Infof("Updating default opaque ports to %v", opaquePorts)
+	opw.defaultOpaquePorts = opaquePorts
+	for id, ss := range opw.subscriptions {
+		ports := opw.defaultOpaquePorts
+		// Services that don't exist, or aren't watched, have the default
+		// opaque ports
+		svc, err := opw.k8sAPI.Svc().Lister().Services(id.Namespace).Get(id.Name)
+		if err == nil && svc.Namespace != kubeSystem {
+			ports, err = opw.serviceOpaquePorts(svc)
+			if err != nil {
+				opw.log.Errorf("failed to get %s service opaque ports annotation: %s", id, err)
+				continue
+			}
+		}
+		if portsEqual(ss.opaquePorts, ports) {
+			continue
+		}
+		ss.opaquePorts = ports
+		for _, listener := range ss.listeners {
+			listener.UpdateService(ss.opaquePorts)
+		}
+	}
+}
+
+// serviceOpaquePorts returns the opaque ports of the service, from its opaque
+// ports annotation or the default opaque ports, and from the container port
+// names. It must be called with the lock held.
+func (opw *OpaquePortsWatcher) serviceOpaquePorts(svc *corev1.Service) (map[uint32]struct{}, error) {
+	opaquePorts, ok, err := getServiceOpaquePortsAnnotation(svc)
+	if err != nil {
+		return nil, err
+	}
+	// If the opaque ports annotation was not set, then set the service's
+	// opaque ports to the default value.
+	if !ok {
+		opaquePorts = opw.defaultOpaquePorts
+	}
+	// Merge the ports discovered from the container port names into a new
+	// map, so that the default opaque ports are never modified.
+	if prefixed := getServicePrefixedPorts(svc, opw.opaquePortNamePrefix); len(prefixed) != 0 {
+		opaquePorts = util.MergePorts(opaquePorts, prefixed)
+	}
+	return opaquePorts, nil
+}
+
 func getServiceOpaquePortsAnnotation(svc *corev1.Service) (map[uint32]struct{}, bool, error) {
 	annotation, ok := svc.Annotations[labels.ProxyOpaquePortsAnnotation]
 	if !ok {
@@ -200,6 +256,21 @@ func getServiceOpaquePortsAnnotation(svc *corev1.Service) (map[uint32]struct{},
 	return opaquePorts, true, nil
 }
 
+// getServicePrefixedPorts returns the service ports whose target port is a
+// container port name starting with the given prefix.
+func getServicePrefixedPorts(svc *corev1.Service, prefix string) map[uint32]struct{} {
+	prefixed := make(map[uint32]struct{})
+	if prefix == "" {
+		return prefixed
+	}
+	for _, sp := range svc.Spec.Ports {
+		if sp.TargetPort.Type == intstr.String && strings.HasPrefix(sp.TargetPort.StrVal, prefix) {
+			prefixed[uint32(sp.Port)] = struct{}{}
+		}
+	}
+	return prefixed
+}
+
 func parseServiceOpaquePorts(annotation string, sps []corev1.ServicePort) []string {
 	portRanges := util.GetPortRanges(annotation)
 	var values []string
@@ -7,6 +7,7 @@ import (
 	logging "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 var (
@@ -71,6 +72,18 @@ metadata:
 			Ports: []corev1.ServicePort{{Port: 3306}},
 		},
 	}
+	prefixedServiceObject = corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "svc",
+			Namespace: "ns",
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Port: 8080},
+				{Port: 5433, TargetPort: intstr.FromString("opaque-postgres")},
+			},
+		},
+	}
 )
 
 type testOpaquePortsListener struct {
@@ -180,12 +193,32 @@ func TestOpaquePortsWatcher(t *testing.T) {
 			// 4. svc added: update with no ports
 			expectedOpaquePorts: []map[uint32]struct{}{{}, {11211: {}, 25: {}, 3306: {}, 443: {}, 5432: {}, 587: {}}, {}},
 		},
+		{
+			name:         "namespace and service, create service targeting an opaque named port",
+			initialState: []string{testNS, baseService},
+			nsObject:     &testNSObject,
+			svcObject:    &prefixedServiceObject,
+			service: ServiceID{
+				Name:      "svc",
+				Namespace: "ns",
+			},
+			// 1: default opaque ports
+			// 2. svc updated: update with default ports and port 5433
+			// 3. svc deleted: update with default ports
+			// 4. svc added: update with default ports and port 5433
+			expectedOpaquePorts: []map[uint32]struct{}{
+				{11211: {}, 25: {}, 3306: {}, 443: {}, 5432: {}, 587: {}},
+				{11211: {}, 25: {}, 3306: {}, 443: {}, 5432: {}, 5433: {}, 587: {}},
+				{11211: {}, 25: {}, 3306: {}, 443: {}, 5432: {}, 587: {}},
+				{11211: {}, 25: {}, 3306: {}, 443: {}, 5432: {}, 5433: {}, 587: {}},
+			},
+		},
 	} {
 		k8sAPI, err := k8s.NewFakeAPI(tt.initialState...)
 		if err != nil {
 			t.Fatalf("NewFakeAPI returned an error: %s", err)
 		}
-		watcher := NewOpaquePortsWatcher(k8sAPI, logging.WithField("test", t.Name()), defaultOpaquePorts)
+		watcher := NewOpaquePortsWatcher(k8sAPI, logging.WithField("test", t.Name()), defaultOpaquePorts, "opaque-")
 		k8sAPI.Sync(nil)
 		listener := newTestOpaquePortsListener()
 		watcher.Subscribe(tt.service, listener)
@@ -195,3 +228,42 @@ func TestOpaquePortsWatcher(t *testing.T) {
 		testCompare(t, tt.expectedOpaquePorts, listener.updates)
 	}
 }
+
+func TestSetDefaultOpaquePorts(t *testing.T) {
+	k8sAPI, err := k8s.NewFakeAPI(testNS, baseService, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: opaque-svc
+  namespace: ns
+  annotations:
+    config.linkerd.io/opaque-ports: "3306"
+spec:
+  ports:
+  - port: 3306`)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+	watcher := NewOpaquePortsWatcher(k8sAPI, logging.WithField("test", t.Name()), map[uint32]struct{}{25: {}}, "")
+	k8sAPI.Sync(nil)
+
+	listeners := map[string]*testOpaquePortsListener{
+		"svc":         newTestOpaquePortsListener(),
+		"opaque-svc":  newTestOpaquePortsListener(),
+		"missing-svc": newTestOpaquePortsListener(),
+	}
+	for name, listener := range listeners {
+		if err := watcher.Subscribe(ServiceID{Namespace: "ns", Name: name}, listener); err != nil {
+			t.Fatalf("Subscribe returned an error: %s", err)
+		}
+	}
+
+	watcher.SetDefaultOpaquePorts(map[uint32]struct{}{25: {}})
+	watcher.SetDefaultOpaquePorts(map[uint32]struct{}{8080: {}})
+
+	// the services without the annotation get the new default opaque ports
+	testCompare(t, []map[uint32]struct{}{{25: {}}, {8080: {}}}, listeners["svc"].updates)
+	testCompare(t, []map[uint32]struct{}{{8080: {}}}, listeners["missing-svc"].updates)
+	// the annotated services keep their opaque ports
+	testCompare(t, []map[uint32]struct{}{{3306: {}}}, listeners["opaque-svc"].updates)
+}
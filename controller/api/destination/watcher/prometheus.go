@@ -2,6 +2,7 @@ package watcher
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -23,14 +24,18 @@ type (
 
 	endpointsMetricsVecs struct {
 		metricsVecs
-		pods   *prometheus.GaugeVec
-		exists *prometheus.GaugeVec
+		pods       *prometheus.GaugeVec
+		exists     *prometheus.GaugeVec
+		slices     *prometheus.GaugeVec
+		sliceMerge *prometheus.HistogramVec
 	}
 
 	endpointsMetrics struct {
 		metrics
-		pods   prometheus.Gauge
-		exists prometheus.Gauge
+		pods       prometheus.Gauge
+		exists     prometheus.Gauge
+		slices     prometheus.Gauge
+		sliceMerge prometheus.Observer
 	}
 )
 
@@ -95,10 +100,29 @@ func newEndpointsMetricsVecs() endpointsMetricsVecs {
 		labels,
 	)
 
+	slices := promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "endpoints_slices",
+			Help: "A gauge for the current number of EndpointSlices backing a service.",
+		},
+		labels,
+	)
+
+	sliceMerge := promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "endpoints_slice_merge_seconds",
+			Help:    "A histogram of the time spent merging the EndpointSlices of a service.",
+			Buckets: prometheus.ExponentialBuckets(0.00001, 4, 8),
+		},
+		labels,
+	)
+
 	return endpointsMetricsVecs{
 		metricsVecs: vecs,
 		pods:        pods,
 		exists:      exists,
+		slices:      slices,
+		sliceMerge:  sliceMerge,
 	}
 }
 
@@ -113,9 +137,11 @@ func (mv metricsVecs) newMetrics(labels prometheus.Labels) metrics {
 func (emv endpointsMetricsVecs) newEndpointsMetrics(labels prometheus.Labels) endpointsMetrics {
 	metrics := emv.newMetrics(labels)
 	return endpointsMetrics{
-		metrics: metrics,
-		pods:    emv.pods.With(labels),
-		exists:  emv.exists.With(labels),
+		metrics:    metrics,
+		pods:       emv.pods.With(labels),
+		exists:     emv.exists.With(labels),
+		slices:     emv.slices.With(labels),
+		sliceMerge: emv.sliceMerge.With(labels),
 	}
 }
 
@@ -132,6 +158,12 @@ func (emv endpointsMetricsVecs) unregister(labels prometheus.Labels) {
 	if !emv.exists.Delete(labels) {
 		log.Warnf("unable to delete endpoints_exists metric with labels %s", labels)
 	}
+	if !emv.slices.Delete(labels) {
+		log.Warnf("unable to delete endpoints_slices metric with labels %s", labels)
+	}
+	if !emv.sliceMerge.Delete(labels) {
+		log.Warnf("unable to delete endpoints_slice_merge_seconds metric with labels %s", labels)
+	}
 }
 
 func (m metrics) setSubscribers(n int) {
@@ -153,3 +185,11 @@ func (em endpointsMetrics) setExists(exists bool) {
 		em.exists.Set(0.0)
 	}
 }
+
+func (em endpointsMetrics) setSlices(n int) {
+	em.slices.Set(float64(n))
+}
+
+func (em endpointsMetrics) observeSliceMerge(d time.Duration) {
+	em.sliceMerge.Observe(d.Seconds())
+}
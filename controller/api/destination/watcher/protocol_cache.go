@@ -0,0 +1,151 @@
+package watcher
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/linkerd/linkerd2/controller/k8s"
+	logging "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+type (
+	// ProtocolCache caches whether the ports of pods are made opaque by a
+	// Server, so that the Servers aren't listed and matched against the pod on
+	// each resolution of the same pod. It's shared by the watchers resolving
+	// pods. Entries are indexed by the pod's name rather than the pod itself,
+	// and are only valid for the resource version of the pod they were
+	// computed for, as its labels and ports may change.
+	ProtocolCache struct {
+		pods map[podKey]*podProtocols
+		// generation is bumped on each invalidation, so that a protocol
+		// computed from Servers that changed since isn't cached
+		generation uint64
+		log        *logging.Entry
+		sync.RWMutex
+	}
+
+	podKey struct {
+		namespace string
+		name      string
+	}
+
+	// podProtocols holds whether the ports of a pod are opaque, for the
+	// resource version of the pod they were computed for
+	podProtocols struct {
+		resourceVersion string
+		opaque          map[Port]bool
+	}
+)
+
+// NewProtocolCache creates a ProtocolCache, which is invalidated whenever a
+// Server changes and drops the entries of the deleted pods.
+func NewProtocolCache(k8sAPI *k8s.API, log *logging.Entry) *ProtocolCache {
+	c := newProtocolCache()
+	c.log = log.WithField("component", "protocol-cache")
+	k8sAPI.Srv().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { c.invalidate() },
+		DeleteFunc: func(interface{}) { c.invalidate() },
+		UpdateFunc: func(_, _ interface{}) { c.invalidate() },
+	})
+	k8sAPI.Pod().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: c.deletePodObj,
+	})
+	return c
+}
+
+func newProtocolCache() *ProtocolCache {
+	return &ProtocolCache{
+		pods: make(map[podKey]*podProtocols),
+		log:  logging.WithField("component", "protocol-cache"),
+	}
+}
+
+// setToServerProtocol sets the address's OpaqueProtocol field based off any
+// Servers that select it and override the expected protocol, like the
+// SetToServerProtocol function, caching the result for the pod and port.
+func (c *ProtocolCache) setToServerProtocol(k8sAPI *k8s.API, address *Address, port Port) error {
+	if address.Pod == nil {
+		return fmt.Errorf("endpoint not backed by Pod: %s:%d", address.IP, address.Port)
+	}
+	opaque, err := c.get(address.Pod, port, func() (bool, error) {
+		return isOpaqueServerPort(k8sAPI, address.Pod, port)
+	})
+	if err != nil {
+		return err
+	}
+	if opaque {
+		address.OpaqueProtocol = true
+	}
+	return nil
+}
+
+// get returns whether the port of the pod is opaque, computing it with
+// compute when it isn't cached
+func (c *ProtocolCache) get(pod *corev1.Pod, port Port, compute func() (bool, error)) (bool, error) {
+	key := podKey{pod.Namespace, pod.Name}
+
+	c.RLock()
+	protocols, ok := c.pods[key]
+	var opaque, cached bool
+	if ok && protocols.resourceVersion == pod.ResourceVersion {
+		opaque, cached = protocols.opaque[port]
+	}
+	generation := c.generation
+	c.RUnlock()
+	if cached {
+		return opaque, nil
+	}
+
+	opaque, err := compute()
+	if err != nil {
+		return false, err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if c.generation != generation {
+		return opaque, nil
+	}
+	protocols, ok = c.pods[key]
+	if !ok || protocols.resourceVersion != pod.ResourceVersion {
+		protocols = &podProtocols{resourceVersion: pod.ResourceVersion, opaque: make(map[Port]bool)}
+		c.pods[key] = protocols
+	}
+	protocols.opaque[port] = opaque
+	return opaque, nil
+}
+
+// invalidate drops all the entries, as a change to a Server may change the
+// protocol of any pod it selects
+func (c *ProtocolCache) invalidate() {
+	c.Lock()
+	defer c.Unlock()
+	c.pods = make(map[podKey]*podProtocols)
+	c.generation++
+}
+
+func (c *ProtocolCache) deletePodObj(obj interface{}) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			c.log.Errorf("couldn't get object from DeletedFinalStateUnknown %#v", obj)
+			return
+		}
+		pod, ok = tombstone.Obj.(*corev1.Pod)
+		if !ok {
+			c.log.Errorf("DeletedFinalStateUnknown contained object that is not a Pod %#v", obj)
+			return
+		}
+	}
+	c.deletePod(pod.Namespace, pod.Name)
+}
+
+// deletePod drops the entries of a deleted pod
+func (c *ProtocolCache) deletePod(namespace, name string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.pods, podKey{namespace, name})
+}
@@ -0,0 +1,161 @@
+package watcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/k8s"
+	logging "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestProtocolCache(t *testing.T) {
+	c := newProtocolCache()
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ns", ResourceVersion: "1"}}
+
+	computed := 0
+	compute := func(opaque bool) func() (bool, error) {
+		return func() (bool, error) {
+			computed++
+			return opaque, nil
+		}
+	}
+	expect := func(port Port, opaque bool, expectedOpaque bool, expectedComputed int) {
+		t.Helper()
+		got, err := c.get(pod, port, compute(opaque))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if got != expectedOpaque {
+			t.Fatalf("Expected opaque %t, got %t", expectedOpaque, got)
+		}
+		if computed != expectedComputed {
+			t.Fatalf("Expected the protocol to be computed %d times, got %d", expectedComputed, computed)
+		}
+	}
+
+	expect(80, true, true, 1)
+	// cached
+	expect(80, false, true, 1)
+	// another port
+	expect(81, false, false, 2)
+
+	// the pod changed
+	pod = pod.DeepCopy()
+	pod.ResourceVersion = "2"
+	expect(80, false, false, 3)
+	expect(80, true, false, 3)
+
+	// a Server changed
+	c.invalidate()
+	expect(80, true, true, 4)
+
+	// the pod was deleted, leaving the entries of the other pods
+	other := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ns", ResourceVersion: "1"}}
+	if _, err := c.get(other, 80, func() (bool, error) { return true, nil }); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	c.deletePod("ns", "pod")
+	expect(80, false, false, 5)
+	if opaque, err := c.get(other, 80, compute(false)); err != nil || !opaque {
+		t.Fatalf("Expected the protocol of the other pod to stay cached, got %t (%v)", opaque, err)
+	}
+
+	// a Server changed while computing the protocol
+	c.invalidate()
+	_, err := c.get(pod, 80, func() (bool, error) {
+		c.invalidate()
+		return true, nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expect(80, false, false, 6)
+}
+
+func TestServerWatcherSetToServerProtocol(t *testing.T) {
+	k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+  namespace: ns
+  labels:
+    app: policy-test
+  resourceVersion: "1"
+spec:
+  containers:
+  - name: app
+    ports:
+    - containerPort: 80`, `
+apiVersion: policy.linkerd.io/v1beta1
+kind: Server
+metadata:
+  name: srv
+  namespace: ns
+spec:
+  podSelector:
+    matchLabels:
+      app: policy-test
+  port: 80
+  proxyProtocol: opaque`)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+	log := logging.WithField("test", t.Name())
+	sw := NewServerWatcher(k8sAPI, log, NewProtocolCache(k8sAPI, log))
+	k8sAPI.Sync(nil)
+
+	pod, err := k8sAPI.Pod().Lister().Pods("ns").Get("pod")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	opaque := func(port Port) bool {
+		t.Helper()
+		address := Address{IP: "172.17.0.12", Port: port, Pod: pod}
+		if err := sw.SetToServerProtocol(&address, port); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		return address.OpaqueProtocol
+	}
+	// the cache is invalidated by the Server events, delivered asynchronously
+	eventually := func(port Port, expected bool) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for opaque(port) != expected {
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected port %d to be opaque: %t", port, expected)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	if !opaque(80) {
+		t.Fatal("Expected port 80 to be opaque")
+	}
+	if opaque(8080) {
+		t.Fatal("Expected port 8080 not to be opaque")
+	}
+
+	// Deleting the Server invalidates the cached protocols
+	server, err := k8sAPI.Srv().Lister().Servers("ns").Get("srv")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	err = k8sAPI.L5dClient.ServerV1beta1().Servers("ns").Delete(context.Background(), "srv", metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	eventually(80, false)
+
+	// Adding it back too
+	server = server.DeepCopy()
+	server.ResourceVersion = ""
+	_, err = k8sAPI.L5dClient.ServerV1beta1().Servers("ns").Create(context.Background(), server, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	eventually(80, true)
+}
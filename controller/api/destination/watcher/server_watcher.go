@@ -9,7 +9,6 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -18,6 +17,7 @@ import (
 // is changed by the Server.
 type ServerWatcher struct {
 	subscriptions map[podPort][]ServerUpdateListener
+	protocols     *ProtocolCache
 	k8sAPI        *k8s.API
 	log           *logging.Entry
 	sync.RWMutex
@@ -37,11 +37,12 @@ type ServerUpdateListener interface {
 }
 
 // NewServerWatcher creates a new ServerWatcher.
-func NewServerWatcher(k8sAPI *k8s.API, log *logging.Entry) *ServerWatcher {
+func NewServerWatcher(k8sAPI *k8s.API, log *logging.Entry, protocols *ProtocolCache) *ServerWatcher {
 	sw := &ServerWatcher{
 		subscriptions: make(map[podPort][]ServerUpdateListener),
+		protocols:     protocols,
 		k8sAPI:        k8sAPI,
-		log:           log,
+		log:           log.WithField("component", "server-watcher"),
 	}
 	k8sAPI.Srv().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    sw.addServer,
@@ -51,6 +52,15 @@ func NewServerWatcher(k8sAPI *k8s.API, log *logging.Entry) *ServerWatcher {
 	return sw
 }
 
+// SetToServerProtocol sets the address's OpaqueProtocol field based off any
+// Servers that select it and override the expected protocol. Unlike the
+// SetToServerProtocol function, the result is cached for the pod and port
+// until the Servers or the pod change, so that it's shared across the streams
+// resolving the same pod.
+func (sw *ServerWatcher) SetToServerProtocol(address *Address, port Port) error {
+	return sw.protocols.setToServerProtocol(sw.k8sAPI, address, port)
+}
+
 // Subscribe subscribes a listener for any Server updates that may select the
 // endpoint and change its expected protocol.
 func (sw *ServerWatcher) Subscribe(pod *corev1.Pod, port Port, listener ServerUpdateListener) {
@@ -118,24 +128,7 @@ func (sw *ServerWatcher) updateServer(server *v1beta1.Server, selector labels.Se
 	defer sw.Unlock()
 	for pp, listeners := range sw.subscriptions {
 		if selector.Matches(labels.Set(pp.pod.Labels)) {
-			var portMatch bool
-			switch server.Spec.Port.Type {
-			case intstr.Int:
-				if server.Spec.Port.IntVal == int32(pp.port) {
-					portMatch = true
-				}
-			case intstr.String:
-				for _, c := range pp.pod.Spec.Containers {
-					for _, p := range c.Ports {
-						if p.ContainerPort == int32(pp.port) && p.Name == server.Spec.Port.StrVal {
-							portMatch = true
-						}
-					}
-				}
-			default:
-				continue
-			}
-			if portMatch {
+			if serverSelectsPort(server, pp.pod, pp.port) {
 				var isOpaque bool
 				if isAdd && server.Spec.ProxyProtocol == opaqueProtocol {
 					isOpaque = true
@@ -9,13 +9,19 @@ import (
 	"syscall"
 
 	"github.com/linkerd/linkerd2/controller/api/destination"
+	l5dscheme "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/scheme"
 	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/pkg/admin"
 	"github.com/linkerd/linkerd2/pkg/flags"
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/leader"
+	"github.com/linkerd/linkerd2/pkg/tls"
 	"github.com/linkerd/linkerd2/pkg/trace"
 	"github.com/linkerd/linkerd2/pkg/util"
 	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
 )
 
 // Main executes the destination subcommand
@@ -30,16 +36,29 @@ func Main(args []string) {
 	controllerNamespace := cmd.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
 	enableEndpointSlices := cmd.Bool("enable-endpoint-slices", true, "Enable the usage of EndpointSlice informers and resources")
 	trustDomain := cmd.String("identity-trust-domain", "", "configures the name suffix used for identities")
-	clusterDomain := cmd.String("cluster-domain", "", "kubernetes cluster domain")
+	clusterDomain := cmd.String("cluster-domain", "", "kubernetes cluster domain, or comma-separated list of cluster domain suffixes whose first one is the canonical domain of the services")
 	defaultOpaquePorts := cmd.String("default-opaque-ports", "", "configures the default opaque ports")
-
+	opaquePortNamePrefix := cmd.String("opaque-port-name-prefix", "", "Container ports whose name starts with this prefix (e.g. \"opaque-\") are opaque, in addition to the annotated or default ones; disabled when empty")
+	defaultPort := cmd.Uint("default-port", uint(destination.DefaultPort), "Port of the destinations whose authority has no port; when 0, such authorities are rejected instead")
+	maxAuthoritiesPerClient := cmd.Int("max-authorities-per-client", 0, "Maximum number of distinct authorities a client can be subscribed to; past it, the least recently used subscriptions are evicted (0 means no limit)")
+	clockSkewThreshold := cmd.Duration("clock-skew-warning-threshold", tls.DefaultClockSkewAllowance, "Nodes whose clock is estimated to be skewed by more than this duration are reported, as their proxies may reject their certificates")
+	enableExternalProfiles := cmd.Bool("enable-external-profiles", false, "Serve the ServiceProfiles named after the fully-qualified names of destinations outside of the cluster, such as api.example.com, to the clients calling them")
+	enableLeaderElection := cmd.Bool("enable-leader-election", false, "Elect a leader among the replicas, in charge of the write-side work: recording Events on the conflicting Servers")
+	enableStreamHashing := cmd.Bool("enable-stream-hashing", false, "Consistently hash the destinations onto the running replicas, forwarding the streams of the destinations owned by other replicas to them")
+	configMapName := cmd.String("config-map", "", "Name of a ConfigMap in the controller namespace whose identityTrustDomain, clusterDomain and defaultOpaquePorts keys override the corresponding flags; it's watched for changes and re-read on SIGHUP (disabled when empty)")
+
+	adminTLS := flags.AddAdminTLSFlags(cmd)
 	traceCollector := flags.AddTraceFlags(cmd)
+	leaderElection := leader.AddFlags(cmd)
 
 	flags.ConfigureAndParse(cmd, args)
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+
 	done := make(chan struct{})
 
 	lis, err := net.Listen("tcp", *addr)
@@ -65,6 +84,9 @@ func Main(args []string) {
 	if err != nil {
 		log.Fatalf("Failed to parse opaque Ports %s: %s", *defaultOpaquePorts, err)
 	}
+	if *defaultPort > 65535 {
+		log.Fatalf("Invalid default port %d", *defaultPort)
+	}
 
 	log.Infof("Using default opaque ports: %v", opaquePorts)
 
@@ -94,20 +116,42 @@ func Main(args []string) {
 			ctx,
 			*kubeConfigPath,
 			true,
-			k8s.Endpoint, k8s.ES, k8s.Pod, k8s.RS, k8s.Svc, k8s.SP, k8s.Job, k8s.NS, k8s.Node, k8s.Srv,
+			k8s.ES, k8s.Pod, k8s.RS, k8s.Svc, k8s.SP, k8s.Job, k8s.NS, k8s.Node, k8s.Srv, k8s.DP,
 		)
 	} else {
 		k8sAPI, err = k8s.InitializeAPI(
 			ctx,
 			*kubeConfigPath,
 			true,
-			k8s.Endpoint, k8s.Pod, k8s.RS, k8s.Svc, k8s.SP, k8s.Job, k8s.NS, k8s.Node, k8s.Srv,
+			k8s.Endpoint, k8s.Pod, k8s.RS, k8s.Svc, k8s.SP, k8s.Job, k8s.NS, k8s.Node, k8s.Srv, k8s.DP,
 		)
 	}
 	if err != nil {
 		log.Fatalf("Failed to initialize K8s API: %s", err)
 	}
 
+	// SIGHUP re-reads the config
+	reload := make(chan struct{})
+	go func() {
+		for range hangup {
+			if *configMapName == "" {
+				log.Warn("Ignoring SIGHUP: no config ConfigMap was configured")
+				continue
+			}
+			reload <- struct{}{}
+		}
+	}()
+
+	// the pod name identifies this replica among the others
+	podName, err := os.Hostname()
+	if err != nil {
+		log.Fatalf("Failed to get hostname: %s", err)
+	}
+	streamHashingID := ""
+	if *enableStreamHashing {
+		streamHashingID = podName
+	}
+
 	server, err := destination.NewServer(
 		*addr,
 		*controllerNamespace,
@@ -117,6 +161,13 @@ func Main(args []string) {
 		k8sAPI,
 		*clusterDomain,
 		opaquePorts,
+		*opaquePortNamePrefix,
+		uint32(*defaultPort),
+		*maxAuthoritiesPerClient,
+		*enableExternalProfiles,
+		*configMapName,
+		streamHashingID,
+		reload,
 		done,
 	)
 
@@ -124,24 +175,54 @@ func Main(args []string) {
 		log.Fatalf("Failed to initialize destination server: %s", err)
 	}
 
+	destination.WatchNodeClockSkew(k8sAPI, *clockSkewThreshold)
+
 	k8sAPI.Sync(nil) // blocks until caches are synced
 
+	leaderCtx, cancelLeader := context.WithCancel(ctx)
+	defer cancelLeader()
+	if *enableLeaderElection {
+		eventBroadcaster := record.NewBroadcaster()
+		eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{
+			// the Events are recorded in the namespaces of the Servers
+			Interface: k8sAPI.Client.CoreV1().Events(""),
+		})
+		recorder := eventBroadcaster.NewRecorder(l5dscheme.Scheme, corev1.EventSource{Component: "linkerd-destination"})
+
+		elector, err := leader.NewElector(
+			k8sAPI.Client,
+			*controllerNamespace,
+			"linkerd-destination-leader",
+			podName,
+			*leaderElection,
+			destination.ReportServerConflicts(k8sAPI, recorder, destination.ServerConflictsInterval),
+		)
+		if err != nil {
+			log.Fatalf("Failed to initialize leader election: %s", err)
+		}
+		go elector.Run(leaderCtx)
+	}
+
 	go func() {
 		log.Infof("starting gRPC server on %s", *addr)
 		server.Serve(lis)
 	}()
 
 	adminServer := admin.NewServer(*metricsAddr)
+	if err := admin.EnableTLS(ctx, adminServer, adminTLS); err != nil {
+		log.Fatalf("Failed to enable TLS on the admin server: %s", err)
+	}
 
 	go func() {
 		log.Infof("starting admin server on %s", *metricsAddr)
-		adminServer.ListenAndServe()
+		admin.ListenAndServe(adminServer)
 	}()
 
 	<-stop
 
 	log.Infof("shutting down gRPC server on %s", *addr)
 	close(done)
+	cancelLeader()
 	server.GracefulStop()
 	adminServer.Shutdown(ctx)
 }
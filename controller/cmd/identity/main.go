@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
@@ -42,6 +44,16 @@ func Main(args []string) {
 	trustDomain := cmd.String("identity-trust-domain", "", "configures the name suffix used for identities")
 	identityIssuanceLifeTime := cmd.String("identity-issuance-lifetime", "", "the amount of time for which the Identity issuer should certify identity")
 	identityClockSkewAllowance := cmd.String("identity-clock-skew-allowance", "", "the amount of time to allow for clock skew within a Linkerd cluster")
+	issuanceConcurrency := cmd.Int("issuance-concurrency", 16, "maximum number of certificate signing requests processed concurrently; 0 disables queueing")
+	issuanceQueueSize := cmd.Int("issuance-queue-size", 1000, "maximum number of certificate signing requests waiting to be processed")
+	enableAudit := cmd.Bool("enable-audit", false, "log every issued certificate as JSON and serve the latest ones on the admin server's /audit endpoint")
+	auditSize := cmd.Int("audit-size", 1000, "maximum number of issued certificates kept in memory for the /audit endpoint")
+	tokenAudiences := cmd.String("token-audiences", idctl.LinkerdAudienceKey, "comma-separated list of the audiences the service account tokens are reviewed for")
+	requireBoundTokens := cmd.Bool("require-bound-tokens", false, "reject the service account tokens that aren't bound to a pod or issued for one of the token audiences, such as legacy unbound tokens")
+	namespaceIssuanceLifetimes := cmd.String("namespace-issuance-lifetimes", "", "comma-separated list of <namespace>=<lifetime> pairs capping the lifetime of the certificates issued in those namespaces")
+	issuancePolicyWebhookURL := cmd.String("issuance-policy-webhook-url", "", "URL of a webhook deciding whether each certificate is issued, and for how long")
+	issuancePolicyWebhookTimeout := cmd.Duration("issuance-policy-webhook-timeout", 5*time.Second, "timeout of the requests to the issuance policy webhook")
+	enableRevocations := cmd.Bool("enable-revocations", false, fmt.Sprintf("refuse to issue certificates for the service accounts and pods listed in the %s ConfigMap", k8s.IdentityRevocationsConfigMapName))
 
 	issuerPath := cmd.String("issuer",
 		"/var/run/linkerd/identity/issuer",
@@ -49,6 +61,8 @@ func Main(args []string) {
 
 	var issuerPathCrt string
 	var issuerPathKey string
+	cryptoMode := flags.AddCryptoModeFlag(cmd)
+	adminTLS := flags.AddAdminTLSFlags(cmd)
 	traceCollector := flags.AddTraceFlags(cmd)
 	componentName := "linkerd-identity"
 
@@ -129,7 +143,14 @@ func Main(args []string) {
 	if err != nil {
 		log.Fatalf("Failed to load kubeconfig: %s: %s", *kubeConfigPath, err)
 	}
-	v, err := idctl.NewK8sTokenValidator(ctx, k8sAPI, dom)
+	var revocations *idctl.Revocations
+	if *enableRevocations {
+		revocations, err = idctl.NewRevocations(ctx, k8sAPI, *controllerNS)
+		if err != nil {
+			log.Fatalf("Failed to watch the revocations: %s", err)
+		}
+	}
+	v, err := idctl.NewK8sTokenValidator(ctx, k8sAPI, dom, strings.Split(*tokenAudiences, ","), *requireBoundTokens, revocations)
 	if err != nil {
 		log.Fatalf("Failed to initialize identity service: %s", err)
 	}
@@ -156,7 +177,24 @@ func Main(args []string) {
 	//
 	// Create, initialize and run service
 	//
-	svc := identity.NewService(v, trustAnchors, &validity, recordEventFunc, expectedName, issuerPathCrt, issuerPathKey)
+	var auditor *identity.Auditor
+	adminRoutes := map[string]http.Handler{}
+	if *enableAudit {
+		auditor = identity.NewAuditor(os.Stdout, *auditSize)
+		adminRoutes["/audit"] = auditor
+	}
+	var policies []identity.Policy
+	if *namespaceIssuanceLifetimes != "" {
+		lifetimes, err := idctl.ParseNamespaceLifetimes(*namespaceIssuanceLifetimes)
+		if err != nil {
+			log.Fatalf("Invalid namespace issuance lifetimes: %s", err)
+		}
+		policies = append(policies, lifetimes)
+	}
+	if *issuancePolicyWebhookURL != "" {
+		policies = append(policies, idctl.NewWebhookPolicy(*issuancePolicyWebhookURL, *issuancePolicyWebhookTimeout))
+	}
+	svc := identity.NewService(v, trustAnchors, &validity, recordEventFunc, expectedName, issuerPathCrt, issuerPathKey, *issuanceConcurrency, *issuanceQueueSize, auditor, policies, *cryptoMode)
 	if err = svc.Initialize(); err != nil {
 		log.Fatalf("Failed to initialize identity service: %s", err)
 	}
@@ -167,11 +205,15 @@ func Main(args []string) {
 	//
 	// Bind and serve
 	//
-	adminServer := admin.NewServer(*adminAddr)
+	adminServer := admin.NewServerWithRoutes(*adminAddr, adminRoutes)
+	adminTLS.CryptoMode = *cryptoMode
+	if err := admin.EnableTLS(ctx, adminServer, adminTLS); err != nil {
+		log.Fatalf("Failed to enable TLS on the admin server: %s", err)
+	}
 
 	go func() {
 		log.Infof("starting admin server on %s", *adminAddr)
-		adminServer.ListenAndServe()
+		admin.ListenAndServe(adminServer)
 	}()
 
 	lis, err := net.Listen("tcp", *addr)
@@ -18,15 +18,21 @@ func Main(args []string) {
 	addr := cmd.String("addr", ":8443", "address to serve on")
 	kubeconfig := cmd.String("kubeconfig", "", "path to kubeconfig")
 	linkerdNamespace := cmd.String("linkerd-namespace", "linkerd", "control plane namespace")
+	inferOpaquePorts := cmd.Bool("infer-opaque-ports", false,
+		"infer the opaque ports of the pods from the images of their containers")
+	cryptoMode := flags.AddCryptoModeFlag(cmd)
+	adminTLS := flags.AddAdminTLSFlags(cmd)
 	flags.ConfigureAndParse(cmd, args)
 
 	webhook.Launch(
 		context.Background(),
-		[]k8s.APIResource{k8s.NS, k8s.Deploy, k8s.RC, k8s.RS, k8s.Job, k8s.DS, k8s.SS, k8s.Pod, k8s.CJ},
-		injector.Inject(*linkerdNamespace),
+		[]k8s.APIResource{k8s.NS, k8s.Deploy, k8s.RC, k8s.RS, k8s.Job, k8s.DS, k8s.SS, k8s.Pod, k8s.CJ, k8s.IP},
+		injector.Inject(*linkerdNamespace, *inferOpaquePorts),
 		"linkerd-proxy-injector",
 		*metricsAddr,
 		*addr,
 		*kubeconfig,
+		*cryptoMode,
+		adminTLS,
 	)
 }
@@ -16,6 +16,8 @@ func Main(args []string) {
 	metricsAddr := cmd.String("metrics-addr", fmt.Sprintf(":%d", 9997), "address to serve scrapable metrics on")
 	addr := cmd.String("addr", ":8443", "address to serve on")
 	kubeconfig := cmd.String("kubeconfig", "", "path to kubeconfig")
+	cryptoMode := flags.AddCryptoModeFlag(cmd)
+	adminTLS := flags.AddAdminTLSFlags(cmd)
 	flags.ConfigureAndParse(cmd, args)
 
 	webhook.Launch(
@@ -26,5 +28,7 @@ func Main(args []string) {
 		*metricsAddr,
 		*addr,
 		*kubeconfig,
+		*cryptoMode,
+		adminTLS,
 	)
 }
@@ -0,0 +1,4 @@
+package defaultprofile
+
+// GroupName identifies the API Group Name for a DefaultProfile.
+const GroupName = "linkerd.io"
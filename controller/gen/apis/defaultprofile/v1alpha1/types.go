@@ -0,0 +1,51 @@
+package v1alpha1
+
+import (
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +groupName=linkerd.io
+
+// DefaultProfile configures the profile served for the services of its
+// namespace which don't have a ServiceProfile.
+type DefaultProfile struct {
+	// TypeMeta is the metadata for the resource, like kind and apiversion
+	metav1.TypeMeta `json:",inline"`
+
+	// ObjectMeta contains the metadata for the particular object, including
+	// things like...
+	//  - name
+	//  - namespace
+	//  - self link
+	//  - labels
+	//  - ... etc ...
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the custom resource spec
+	Spec DefaultProfileSpec `json:"spec"`
+}
+
+// DefaultProfileSpec specifies the catch-all route served for the services
+// of the namespace.
+type DefaultProfileSpec struct {
+	// Timeout applies to all the requests to the services
+	Timeout string `json:"timeout,omitempty"`
+	// IsRetryable allows the failed requests to be retried, within the
+	// RetryBudget
+	IsRetryable bool            `json:"isRetryable,omitempty"`
+	RetryBudget *sp.RetryBudget `json:"retryBudget,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DefaultProfileList is a list of DefaultProfile resources.
+type DefaultProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []DefaultProfile `json:"items"`
+}
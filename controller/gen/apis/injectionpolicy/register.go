@@ -0,0 +1,4 @@
+package injectionpolicy
+
+// GroupName identifies the API Group Name for an InjectionPolicy.
+const GroupName = "config.linkerd.io"
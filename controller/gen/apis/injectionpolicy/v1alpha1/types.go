@@ -0,0 +1,64 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +groupName=config.linkerd.io
+
+// InjectionPolicy constrains the proxy configuration of the workloads
+// injected by the proxy injector, in the namespaces it selects. The workloads
+// violating it are rejected at admission time.
+type InjectionPolicy struct {
+	// TypeMeta is the metadata for the resource, like kind and apiversion
+	metav1.TypeMeta `json:",inline"`
+
+	// ObjectMeta contains the metadata for the particular object, including
+	// things like...
+	//  - name
+	//  - self link
+	//  - labels
+	//  - ... etc ...
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the custom resource spec
+	Spec InjectionPolicySpec `json:"spec"`
+}
+
+// InjectionPolicySpec specifies the constraints on the proxy configuration,
+// as resolved from the config.linkerd.io annotations of the workloads and
+// their namespace, and the values Linkerd was installed with.
+type InjectionPolicySpec struct {
+	// NamespaceSelector selects the namespaces the policy applies to; it
+	// applies to all of them when nil
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// RequireIdentity rejects the workloads disabling the identity of their
+	// proxy, and hence mTLS
+	RequireIdentity bool `json:"requireIdentity,omitempty"`
+	// MaxProxyLogLevel is the most verbose log level of the proxies, among
+	// error, warn, info, debug and trace
+	MaxProxyLogLevel string `json:"maxProxyLogLevel,omitempty"`
+	// RequireProxyResourceLimits rejects the workloads whose proxy has no
+	// CPU or memory limit
+	RequireProxyResourceLimits bool `json:"requireProxyResourceLimits,omitempty"`
+	// ForbiddenAnnotations are the config annotations the workloads and
+	// their namespace can't set
+	ForbiddenAnnotations []string `json:"forbiddenAnnotations,omitempty"`
+	// ForcedAnnotations are config annotations set on all the workloads; the
+	// workloads setting them to another value are rejected
+	ForcedAnnotations map[string]string `json:"forcedAnnotations,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// InjectionPolicyList is a list of InjectionPolicy resources.
+type InjectionPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []InjectionPolicy `json:"items"`
+}
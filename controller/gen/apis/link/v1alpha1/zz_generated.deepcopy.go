@@ -90,6 +90,11 @@ func (in *LinkSpec) DeepCopyInto(out *LinkSpec) {
 	*out = *in
 	out.ProbeSpec = in.ProbeSpec
 	in.Selector.DeepCopyInto(&out.Selector)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
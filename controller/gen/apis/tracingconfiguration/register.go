@@ -0,0 +1,4 @@
+package tracingconfiguration
+
+// GroupName identifies the API Group Name for a TracingConfiguration.
+const GroupName = "jaeger.linkerd.io"
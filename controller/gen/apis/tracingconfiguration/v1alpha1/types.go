@@ -0,0 +1,57 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +groupName=jaeger.linkerd.io
+
+// TracingConfiguration configures the tracing of the proxies injected by the
+// jaeger extension in its namespace.
+type TracingConfiguration struct {
+	// TypeMeta is the metadata for the resource, like kind and apiversion
+	metav1.TypeMeta `json:",inline"`
+
+	// ObjectMeta contains the metadata for the particular object, including
+	// things like...
+	//  - name
+	//  - namespace
+	//  - self link
+	//  - labels
+	//  - ... etc ...
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the custom resource spec
+	Spec TracingConfigurationSpec `json:"spec"`
+}
+
+// TracingConfigurationSpec specifies where the proxies of the namespace
+// export their spans, and how these are described. The empty fields default
+// to the values the jaeger extension was installed with.
+//
+// It has no sampling rate: the proxies only report the spans of the requests
+// whose trace context was sampled by the client that started the trace.
+type TracingConfigurationSpec struct {
+	// CollectorAddress is the address of the collector the spans are
+	// exported to, e.g. collector.linkerd-jaeger:55678
+	CollectorAddress string `json:"collectorAddress,omitempty"`
+	// CollectorServiceAccount is the service account of the collector, used
+	// to verify its identity
+	CollectorServiceAccount string `json:"collectorServiceAccount,omitempty"`
+	// ServiceNameTemplate is a Go template rendering the service name the
+	// spans are reported with, e.g. {{.Labels.app}}.{{.Namespace}}
+	ServiceNameTemplate string `json:"serviceNameTemplate,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TracingConfigurationList is a list of TracingConfiguration resources.
+type TracingConfigurationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []TracingConfiguration `json:"items"`
+}
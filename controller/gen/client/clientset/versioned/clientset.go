@@ -21,10 +21,13 @@ package versioned
 import (
 	"fmt"
 
+	defaultprofilev1alpha1 "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/typed/defaultprofile/v1alpha1"
+	injectionpolicyv1alpha1 "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/typed/injectionpolicy/v1alpha1"
 	linkv1alpha1 "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/typed/link/v1alpha1"
 	serverv1beta1 "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/typed/server/v1beta1"
 	serverauthorizationv1beta1 "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/typed/serverauthorization/v1beta1"
 	linkerdv1alpha2 "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/typed/serviceprofile/v1alpha2"
+	tracingconfigurationv1alpha1 "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/typed/tracingconfiguration/v1alpha1"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
@@ -32,20 +35,36 @@ import (
 
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
+	DefaultprofileV1alpha1() defaultprofilev1alpha1.DefaultprofileV1alpha1Interface
+	InjectionpolicyV1alpha1() injectionpolicyv1alpha1.InjectionpolicyV1alpha1Interface
 	LinkV1alpha1() linkv1alpha1.LinkV1alpha1Interface
 	ServerV1beta1() serverv1beta1.ServerV1beta1Interface
 	ServerauthorizationV1beta1() serverauthorizationv1beta1.ServerauthorizationV1beta1Interface
 	LinkerdV1alpha2() linkerdv1alpha2.LinkerdV1alpha2Interface
+	TracingconfigurationV1alpha1() tracingconfigurationv1alpha1.TracingconfigurationV1alpha1Interface
 }
 
 // Clientset contains the clients for groups. Each group has exactly one
 // version included in a Clientset.
 type Clientset struct {
 	*discovery.DiscoveryClient
-	linkV1alpha1               *linkv1alpha1.LinkV1alpha1Client
-	serverV1beta1              *serverv1beta1.ServerV1beta1Client
-	serverauthorizationV1beta1 *serverauthorizationv1beta1.ServerauthorizationV1beta1Client
-	linkerdV1alpha2            *linkerdv1alpha2.LinkerdV1alpha2Client
+	defaultprofileV1alpha1       *defaultprofilev1alpha1.DefaultprofileV1alpha1Client
+	injectionpolicyV1alpha1      *injectionpolicyv1alpha1.InjectionpolicyV1alpha1Client
+	linkV1alpha1                 *linkv1alpha1.LinkV1alpha1Client
+	serverV1beta1                *serverv1beta1.ServerV1beta1Client
+	serverauthorizationV1beta1   *serverauthorizationv1beta1.ServerauthorizationV1beta1Client
+	linkerdV1alpha2              *linkerdv1alpha2.LinkerdV1alpha2Client
+	tracingconfigurationV1alpha1 *tracingconfigurationv1alpha1.TracingconfigurationV1alpha1Client
+}
+
+// DefaultprofileV1alpha1 retrieves the DefaultprofileV1alpha1Client
+func (c *Clientset) DefaultprofileV1alpha1() defaultprofilev1alpha1.DefaultprofileV1alpha1Interface {
+	return c.defaultprofileV1alpha1
+}
+
+// InjectionpolicyV1alpha1 retrieves the InjectionpolicyV1alpha1Client
+func (c *Clientset) InjectionpolicyV1alpha1() injectionpolicyv1alpha1.InjectionpolicyV1alpha1Interface {
+	return c.injectionpolicyV1alpha1
 }
 
 // LinkV1alpha1 retrieves the LinkV1alpha1Client
@@ -68,6 +87,11 @@ func (c *Clientset) LinkerdV1alpha2() linkerdv1alpha2.LinkerdV1alpha2Interface {
 	return c.linkerdV1alpha2
 }
 
+// TracingconfigurationV1alpha1 retrieves the TracingconfigurationV1alpha1Client
+func (c *Clientset) TracingconfigurationV1alpha1() tracingconfigurationv1alpha1.TracingconfigurationV1alpha1Interface {
+	return c.tracingconfigurationV1alpha1
+}
+
 // Discovery retrieves the DiscoveryClient
 func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 	if c == nil {
@@ -89,6 +113,14 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 	}
 	var cs Clientset
 	var err error
+	cs.defaultprofileV1alpha1, err = defaultprofilev1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.injectionpolicyV1alpha1, err = injectionpolicyv1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
 	cs.linkV1alpha1, err = linkv1alpha1.NewForConfig(&configShallowCopy)
 	if err != nil {
 		return nil, err
@@ -105,6 +137,10 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 	if err != nil {
 		return nil, err
 	}
+	cs.tracingconfigurationV1alpha1, err = tracingconfigurationv1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfig(&configShallowCopy)
 	if err != nil {
@@ -117,10 +153,13 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 // panics if there is an error in the config.
 func NewForConfigOrDie(c *rest.Config) *Clientset {
 	var cs Clientset
+	cs.defaultprofileV1alpha1 = defaultprofilev1alpha1.NewForConfigOrDie(c)
+	cs.injectionpolicyV1alpha1 = injectionpolicyv1alpha1.NewForConfigOrDie(c)
 	cs.linkV1alpha1 = linkv1alpha1.NewForConfigOrDie(c)
 	cs.serverV1beta1 = serverv1beta1.NewForConfigOrDie(c)
 	cs.serverauthorizationV1beta1 = serverauthorizationv1beta1.NewForConfigOrDie(c)
 	cs.linkerdV1alpha2 = linkerdv1alpha2.NewForConfigOrDie(c)
+	cs.tracingconfigurationV1alpha1 = tracingconfigurationv1alpha1.NewForConfigOrDie(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClientForConfigOrDie(c)
 	return &cs
@@ -129,10 +168,13 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 // New creates a new Clientset for the given RESTClient.
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
+	cs.defaultprofileV1alpha1 = defaultprofilev1alpha1.New(c)
+	cs.injectionpolicyV1alpha1 = injectionpolicyv1alpha1.New(c)
 	cs.linkV1alpha1 = linkv1alpha1.New(c)
 	cs.serverV1beta1 = serverv1beta1.New(c)
 	cs.serverauthorizationV1beta1 = serverauthorizationv1beta1.New(c)
 	cs.linkerdV1alpha2 = linkerdv1alpha2.New(c)
+	cs.tracingconfigurationV1alpha1 = tracingconfigurationv1alpha1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
@@ -20,6 +20,10 @@ package fake
 
 import (
 	clientset "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned"
+	defaultprofilev1alpha1 "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/typed/defaultprofile/v1alpha1"
+	fakedefaultprofilev1alpha1 "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/typed/defaultprofile/v1alpha1/fake"
+	injectionpolicyv1alpha1 "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/typed/injectionpolicy/v1alpha1"
+	fakeinjectionpolicyv1alpha1 "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/typed/injectionpolicy/v1alpha1/fake"
 	linkv1alpha1 "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/typed/link/v1alpha1"
 	fakelinkv1alpha1 "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/typed/link/v1alpha1/fake"
 	serverv1beta1 "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/typed/server/v1beta1"
@@ -28,6 +32,8 @@ import (
 	fakeserverauthorizationv1beta1 "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/typed/serverauthorization/v1beta1/fake"
 	linkerdv1alpha2 "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/typed/serviceprofile/v1alpha2"
 	fakelinkerdv1alpha2 "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/typed/serviceprofile/v1alpha2/fake"
+	tracingconfigurationv1alpha1 "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/typed/tracingconfiguration/v1alpha1"
+	faketracingconfigurationv1alpha1 "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/typed/tracingconfiguration/v1alpha1/fake"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/discovery"
@@ -85,6 +91,16 @@ var (
 	_ testing.FakeClient  = &Clientset{}
 )
 
+// DefaultprofileV1alpha1 retrieves the DefaultprofileV1alpha1Client
+func (c *Clientset) DefaultprofileV1alpha1() defaultprofilev1alpha1.DefaultprofileV1alpha1Interface {
+	return &fakedefaultprofilev1alpha1.FakeDefaultprofileV1alpha1{Fake: &c.Fake}
+}
+
+// InjectionpolicyV1alpha1 retrieves the InjectionpolicyV1alpha1Client
+func (c *Clientset) InjectionpolicyV1alpha1() injectionpolicyv1alpha1.InjectionpolicyV1alpha1Interface {
+	return &fakeinjectionpolicyv1alpha1.FakeInjectionpolicyV1alpha1{Fake: &c.Fake}
+}
+
 // LinkV1alpha1 retrieves the LinkV1alpha1Client
 func (c *Clientset) LinkV1alpha1() linkv1alpha1.LinkV1alpha1Interface {
 	return &fakelinkv1alpha1.FakeLinkV1alpha1{Fake: &c.Fake}
@@ -104,3 +120,8 @@ func (c *Clientset) ServerauthorizationV1beta1() serverauthorizationv1beta1.Serv
 func (c *Clientset) LinkerdV1alpha2() linkerdv1alpha2.LinkerdV1alpha2Interface {
 	return &fakelinkerdv1alpha2.FakeLinkerdV1alpha2{Fake: &c.Fake}
 }
+
+// TracingconfigurationV1alpha1 retrieves the TracingconfigurationV1alpha1Client
+func (c *Clientset) TracingconfigurationV1alpha1() tracingconfigurationv1alpha1.TracingconfigurationV1alpha1Interface {
+	return &faketracingconfigurationv1alpha1.FakeTracingconfigurationV1alpha1{Fake: &c.Fake}
+}
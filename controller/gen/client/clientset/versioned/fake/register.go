@@ -19,10 +19,13 @@ limitations under the License.
 package fake
 
 import (
+	defaultprofilev1alpha1 "github.com/linkerd/linkerd2/controller/gen/apis/defaultprofile/v1alpha1"
+	injectionpolicyv1alpha1 "github.com/linkerd/linkerd2/controller/gen/apis/injectionpolicy/v1alpha1"
 	linkv1alpha1 "github.com/linkerd/linkerd2/controller/gen/apis/link/v1alpha1"
 	serverv1beta1 "github.com/linkerd/linkerd2/controller/gen/apis/server/v1beta1"
 	serverauthorizationv1beta1 "github.com/linkerd/linkerd2/controller/gen/apis/serverauthorization/v1beta1"
 	linkerdv1alpha2 "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha2"
+	tracingconfigurationv1alpha1 "github.com/linkerd/linkerd2/controller/gen/apis/tracingconfiguration/v1alpha1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
@@ -34,10 +37,13 @@ var scheme = runtime.NewScheme()
 var codecs = serializer.NewCodecFactory(scheme)
 
 var localSchemeBuilder = runtime.SchemeBuilder{
+	defaultprofilev1alpha1.AddToScheme,
+	injectionpolicyv1alpha1.AddToScheme,
 	linkv1alpha1.AddToScheme,
 	serverv1beta1.AddToScheme,
 	serverauthorizationv1beta1.AddToScheme,
 	linkerdv1alpha2.AddToScheme,
+	tracingconfigurationv1alpha1.AddToScheme,
 }
 
 // AddToScheme adds all types of this clientset into the given scheme. This allows composition
@@ -24,11 +24,14 @@ import (
 	time "time"
 
 	versioned "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned"
+	defaultprofile "github.com/linkerd/linkerd2/controller/gen/client/informers/externalversions/defaultprofile"
+	injectionpolicy "github.com/linkerd/linkerd2/controller/gen/client/informers/externalversions/injectionpolicy"
 	internalinterfaces "github.com/linkerd/linkerd2/controller/gen/client/informers/externalversions/internalinterfaces"
 	link "github.com/linkerd/linkerd2/controller/gen/client/informers/externalversions/link"
 	server "github.com/linkerd/linkerd2/controller/gen/client/informers/externalversions/server"
 	serverauthorization "github.com/linkerd/linkerd2/controller/gen/client/informers/externalversions/serverauthorization"
 	serviceprofile "github.com/linkerd/linkerd2/controller/gen/client/informers/externalversions/serviceprofile"
+	tracingconfiguration "github.com/linkerd/linkerd2/controller/gen/client/informers/externalversions/tracingconfiguration"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
@@ -175,10 +178,21 @@ type SharedInformerFactory interface {
 	ForResource(resource schema.GroupVersionResource) (GenericInformer, error)
 	WaitForCacheSync(stopCh <-chan struct{}) map[reflect.Type]bool
 
+	Defaultprofile() defaultprofile.Interface
+	Injectionpolicy() injectionpolicy.Interface
 	Link() link.Interface
 	Server() server.Interface
 	Serverauthorization() serverauthorization.Interface
 	Linkerd() serviceprofile.Interface
+	Tracingconfiguration() tracingconfiguration.Interface
+}
+
+func (f *sharedInformerFactory) Defaultprofile() defaultprofile.Interface {
+	return defaultprofile.New(f, f.namespace, f.tweakListOptions)
+}
+
+func (f *sharedInformerFactory) Injectionpolicy() injectionpolicy.Interface {
+	return injectionpolicy.New(f, f.namespace, f.tweakListOptions)
 }
 
 func (f *sharedInformerFactory) Link() link.Interface {
@@ -196,3 +210,7 @@ func (f *sharedInformerFactory) Serverauthorization() serverauthorization.Interf
 func (f *sharedInformerFactory) Linkerd() serviceprofile.Interface {
 	return serviceprofile.New(f, f.namespace, f.tweakListOptions)
 }
+
+func (f *sharedInformerFactory) Tracingconfiguration() tracingconfiguration.Interface {
+	return tracingconfiguration.New(f, f.namespace, f.tweakListOptions)
+}
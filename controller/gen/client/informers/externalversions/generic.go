@@ -21,10 +21,13 @@ package externalversions
 import (
 	"fmt"
 
-	v1alpha1 "github.com/linkerd/linkerd2/controller/gen/apis/link/v1alpha1"
+	v1alpha1 "github.com/linkerd/linkerd2/controller/gen/apis/defaultprofile/v1alpha1"
+	injectionpolicyv1alpha1 "github.com/linkerd/linkerd2/controller/gen/apis/injectionpolicy/v1alpha1"
+	linkv1alpha1 "github.com/linkerd/linkerd2/controller/gen/apis/link/v1alpha1"
 	v1beta1 "github.com/linkerd/linkerd2/controller/gen/apis/server/v1beta1"
 	serverauthorizationv1beta1 "github.com/linkerd/linkerd2/controller/gen/apis/serverauthorization/v1beta1"
 	v1alpha2 "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha2"
+	tracingconfigurationv1alpha1 "github.com/linkerd/linkerd2/controller/gen/apis/tracingconfiguration/v1alpha1"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
 )
@@ -55,8 +58,16 @@ func (f *genericInformer) Lister() cache.GenericLister {
 // TODO extend this to unknown resources with a client pool
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
-	// Group=link, Version=v1alpha1
-	case v1alpha1.SchemeGroupVersion.WithResource("links"):
+	// Group=defaultprofile, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("defaultprofiles"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Defaultprofile().V1alpha1().DefaultProfiles().Informer()}, nil
+
+		// Group=injectionpolicy, Version=v1alpha1
+	case injectionpolicyv1alpha1.SchemeGroupVersion.WithResource("injectionpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Injectionpolicy().V1alpha1().InjectionPolicies().Informer()}, nil
+
+		// Group=link, Version=v1alpha1
+	case linkv1alpha1.SchemeGroupVersion.WithResource("links"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Link().V1alpha1().Links().Informer()}, nil
 
 		// Group=linkerd.io, Version=v1alpha2
@@ -71,6 +82,10 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	case serverauthorizationv1beta1.SchemeGroupVersion.WithResource("serverauthorizations"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Serverauthorization().V1beta1().ServerAuthorizations().Informer()}, nil
 
+		// Group=tracingconfiguration, Version=v1alpha1
+	case tracingconfigurationv1alpha1.SchemeGroupVersion.WithResource("tracingconfigurations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tracingconfiguration().V1alpha1().TracingConfigurations().Informer()}, nil
+
 	}
 
 	return nil, fmt.Errorf("no informer found for %v", resource)
@@ -54,7 +54,7 @@ func K8sValues(ctx context.Context, kubeAPI *k8s.KubernetesAPI, controlPlaneName
 		}
 	}
 
-	err = k8s.ServiceProfilesAccess(ctx, kubeAPI)
+	err = k8s.ServiceProfilesAccess(ctx, kubeAPI, "")
 	if err != nil {
 		log.Errorf("Failed to verify service profile access: %s", err)
 		return v
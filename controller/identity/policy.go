@@ -0,0 +1,141 @@
+package identity
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/identity"
+)
+
+type (
+	// NamespaceLifetimes implements Policy by capping the lifetime of the
+	// certificates issued to the proxies of some namespaces.
+	NamespaceLifetimes map[string]time.Duration
+
+	// WebhookPolicy implements Policy by submitting the requests to an HTTP
+	// endpoint, which decides whether they're allowed and for how long.
+	//
+	// The requests are POSTed as JSON objects with the identity,
+	// serviceAccount, namespace and lifetime fields, the lifetime being a Go
+	// duration string. The endpoint responds with a JSON object with the
+	// allowed, reason and lifetime fields.
+	WebhookPolicy struct {
+		url    string
+		client *http.Client
+	}
+
+	webhookRequest struct {
+		Identity       string `json:"identity"`
+		ServiceAccount string `json:"serviceAccount"`
+		Namespace      string `json:"namespace"`
+		Lifetime       string `json:"lifetime"`
+	}
+
+	webhookResponse struct {
+		Allowed  bool   `json:"allowed"`
+		Reason   string `json:"reason,omitempty"`
+		Lifetime string `json:"lifetime,omitempty"`
+	}
+)
+
+// ParseNamespaceLifetimes parses a comma-separated list of
+// <namespace>=<lifetime> pairs, such as "payments=1h,batch=30m".
+func ParseNamespaceLifetimes(s string) (NamespaceLifetimes, error) {
+	lifetimes := make(NamespaceLifetimes)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid namespace lifetime %q: expected <namespace>=<lifetime>", pair)
+		}
+		lifetime, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid lifetime for namespace %s: %s", parts[0], err)
+		}
+		if lifetime <= 0 {
+			return nil, fmt.Errorf("invalid lifetime for namespace %s: must be positive", parts[0])
+		}
+		lifetimes[parts[0]] = lifetime
+	}
+	return lifetimes, nil
+}
+
+// Check implements Policy
+func (nl NamespaceLifetimes) Check(_ context.Context, req identity.IssuanceRequest) (identity.IssuanceDecision, error) {
+	lifetime, ok := nl[req.Namespace]
+	if !ok {
+		return identity.IssuanceDecision{Allowed: true}, nil
+	}
+	return identity.IssuanceDecision{
+		Allowed:  true,
+		Reason:   fmt.Sprintf("certificates of namespace %s are issued for %s", req.Namespace, lifetime),
+		Lifetime: lifetime,
+	}, nil
+}
+
+// NewWebhookPolicy returns a policy submitting the requests to the URL, each
+// request failing after the timeout.
+func NewWebhookPolicy(url string, timeout time.Duration) *WebhookPolicy {
+	return &WebhookPolicy{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Check implements Policy
+func (wp *WebhookPolicy) Check(ctx context.Context, req identity.IssuanceRequest) (identity.IssuanceDecision, error) {
+	body, err := json.Marshal(webhookRequest{
+		Identity:       req.Identity,
+		ServiceAccount: req.ServiceAccount,
+		Namespace:      req.Namespace,
+		Lifetime:       req.Lifetime.String(),
+	})
+	if err != nil {
+		return identity.IssuanceDecision{}, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, wp.url, bytes.NewReader(body))
+	if err != nil {
+		return identity.IssuanceDecision{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	rsp, err := wp.client.Do(httpReq)
+	if err != nil {
+		return identity.IssuanceDecision{}, fmt.Errorf("issuance policy webhook failed: %s", err)
+	}
+	defer rsp.Body.Close()
+
+	data, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return identity.IssuanceDecision{}, fmt.Errorf("failed to read the issuance policy webhook response: %s", err)
+	}
+	if rsp.StatusCode != http.StatusOK {
+		return identity.IssuanceDecision{}, fmt.Errorf("issuance policy webhook returned %s: %s", rsp.Status, strings.TrimSpace(string(data)))
+	}
+
+	var decision webhookResponse
+	if err := json.Unmarshal(data, &decision); err != nil {
+		return identity.IssuanceDecision{}, fmt.Errorf("invalid issuance policy webhook response: %s", err)
+	}
+	var lifetime time.Duration
+	if decision.Lifetime != "" {
+		lifetime, err = time.ParseDuration(decision.Lifetime)
+		if err != nil {
+			return identity.IssuanceDecision{}, fmt.Errorf("invalid lifetime in the issuance policy webhook response: %s", err)
+		}
+	}
+	return identity.IssuanceDecision{
+		Allowed:  decision.Allowed,
+		Reason:   decision.Reason,
+		Lifetime: lifetime,
+	}, nil
+}
@@ -0,0 +1,144 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/identity"
+)
+
+func TestParseNamespaceLifetimes(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected NamespaceLifetimes
+		err      bool
+	}{
+		{
+			input:    "",
+			expected: NamespaceLifetimes{},
+		},
+		{
+			input:    "payments=1h, batch=30m",
+			expected: NamespaceLifetimes{"payments": time.Hour, "batch": 30 * time.Minute},
+		},
+		{input: "payments", err: true},
+		{input: "=1h", err: true},
+		{input: "payments=soon", err: true},
+		{input: "payments=-1h", err: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.input, func(t *testing.T) {
+			lifetimes, err := ParseNamespaceLifetimes(tc.input)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("Expected an error, got %v", lifetimes)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(lifetimes, tc.expected) {
+				t.Fatalf("Expected %v, got %v", tc.expected, lifetimes)
+			}
+		})
+	}
+}
+
+func TestNamespaceLifetimes(t *testing.T) {
+	policy := NamespaceLifetimes{"payments": time.Hour}
+
+	decision, err := policy.Check(context.Background(), identity.IssuanceRequest{Namespace: "payments"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !decision.Allowed || decision.Lifetime != time.Hour {
+		t.Fatalf("Expected the lifetime to be capped to 1h, got %+v", decision)
+	}
+
+	decision, err = policy.Check(context.Background(), identity.IssuanceRequest{Namespace: "emojivoto"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !decision.Allowed || decision.Lifetime != 0 {
+		t.Fatalf("Expected the request to be allowed as is, got %+v", decision)
+	}
+}
+
+func TestWebhookPolicy(t *testing.T) {
+	testCases := []struct {
+		name     string
+		handler  http.HandlerFunc
+		expected identity.IssuanceDecision
+		err      bool
+	}{
+		{
+			name: "allowed with a shorter lifetime",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				var req webhookRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Namespace != "emojivoto" || req.Lifetime != "24h0m0s" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.Write([]byte(`{"allowed":true,"lifetime":"1h"}`))
+			},
+			expected: identity.IssuanceDecision{Allowed: true, Lifetime: time.Hour},
+		},
+		{
+			name: "denied",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"allowed":false,"reason":"not today"}`))
+			},
+			expected: identity.IssuanceDecision{Reason: "not today"},
+		},
+		{
+			name: "webhook failure",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			err: true,
+		},
+		{
+			name: "invalid lifetime",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"allowed":true,"lifetime":"soon"}`))
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+
+			policy := NewWebhookPolicy(server.URL, time.Second)
+			decision, err := policy.Check(context.Background(), identity.IssuanceRequest{
+				Identity:       "web.emojivoto.serviceaccount.identity.linkerd.cluster.local",
+				ServiceAccount: "web",
+				Namespace:      "emojivoto",
+				Lifetime:       24 * time.Hour,
+			})
+			if tc.err {
+				if err == nil {
+					t.Fatalf("Expected an error, got %+v", decision)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if decision != tc.expected {
+				t.Fatalf("Expected %+v, got %+v", tc.expected, decision)
+			}
+		})
+	}
+}
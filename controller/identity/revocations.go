@@ -0,0 +1,76 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/identity"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	k8s "k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Revocations reads the service accounts and pods that are denied
+// certificates from the revocations ConfigMap of the control plane
+// namespace.
+type Revocations struct {
+	configMaps corelisters.ConfigMapNamespaceLister
+	now        func() time.Time
+}
+
+// NewRevocations watches the revocations ConfigMap in the given namespace,
+// and returns once its cache is synced.
+func NewRevocations(ctx context.Context, k8s k8s.Interface, namespace string) (*Revocations, error) {
+	factory := informers.NewSharedInformerFactoryWithOptions(
+		k8s,
+		10*time.Minute,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", pkgK8s.IdentityRevocationsConfigMapName).String()
+		}),
+	)
+	informer := factory.Core().V1().ConfigMaps()
+	// the informer must be requested before the factory is started
+	lister := informer.Lister().ConfigMaps(namespace)
+	factory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), informer.Informer().HasSynced) {
+		return nil, errors.New("failed to sync the revocations cache")
+	}
+	return &Revocations{lister, time.Now}, nil
+}
+
+// check returns a Revoked error when one of the revocations applies and
+// hasn't expired yet. Revocations whose expiry can't be parsed never expire.
+func (r *Revocations) check(revocations ...identity.Revocation) error {
+	cm, err := r.configMaps.Get(pkgK8s.IdentityRevocationsConfigMapName)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	now := r.now()
+	for _, revocation := range revocations {
+		value, ok := cm.Data[revocation.Key()]
+		if !ok {
+			continue
+		}
+		until, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			log.Warnf("Invalid expiry %q of revocation %s, it never expires: %s", value, revocation.Key(), err)
+			return identity.Revoked{Revocation: revocation}
+		}
+		if now.Before(until) {
+			return identity.Revoked{Revocation: revocation, Until: until}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package identity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/identity"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newFakeRevocations(t *testing.T, now time.Time, data map[string]string) *Revocations {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if data != nil {
+		err := indexer.Add(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: pkgK8s.IdentityRevocationsConfigMapName, Namespace: "linkerd"},
+			Data:       data,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &Revocations{
+		configMaps: corelisters.NewConfigMapLister(indexer).ConfigMaps("linkerd"),
+		now:        func() time.Time { return now },
+	}
+}
+
+func TestRevocationsCheck(t *testing.T) {
+	now := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	web := identity.Revocation{Kind: identity.RevokedServiceAccount, Namespace: "emojivoto", Name: "web"}
+	pod := identity.Revocation{Kind: identity.RevokedPod, Namespace: "emojivoto", Name: "web-5f7f9f8f8-abcde"}
+
+	testCases := []struct {
+		name  string
+		data  map[string]string
+		until time.Time
+		err   string
+	}{
+		{
+			name: "no revocations ConfigMap",
+		},
+		{
+			name: "service account revoked",
+			data: map[string]string{"serviceaccount.emojivoto.web": "2021-10-01T13:00:00Z"},
+			err:  "the certificates of serviceaccount emojivoto/web are revoked until 2021-10-01T13:00:00Z",
+		},
+		{
+			name: "pod revoked",
+			data: map[string]string{"pod.emojivoto.web-5f7f9f8f8-abcde": "2021-10-01T13:00:00Z"},
+			err:  "the certificates of pod emojivoto/web-5f7f9f8f8-abcde are revoked until 2021-10-01T13:00:00Z",
+		},
+		{
+			name: "expired revocation",
+			data: map[string]string{"serviceaccount.emojivoto.web": "2021-10-01T11:00:00Z"},
+		},
+		{
+			name: "other service account revoked",
+			data: map[string]string{"serviceaccount.emojivoto.voting": "2021-10-01T13:00:00Z"},
+		},
+		{
+			name: "invalid expiry",
+			data: map[string]string{"serviceaccount.emojivoto.web": "tomorrow"},
+			err:  "the certificates of serviceaccount emojivoto/web are revoked",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			err := newFakeRevocations(t, now, tc.data).check(web, pod)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				return
+			}
+			var revoked identity.Revoked
+			if !errors.As(err, &revoked) || err.Error() != tc.err {
+				t.Fatalf("Expected Revoked error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -20,16 +20,32 @@ const (
 	// LinkerdAudienceKey is the audience key used for the Linkerd token creation
 	// and  review requests.
 	LinkerdAudienceKey = "identity.l5d.io"
+
+	// boundPodNameKey is the extra info of the user of the tokens bound to a
+	// pod, which legacy service account tokens lack
+	boundPodNameKey = "authentication.kubernetes.io/pod-name"
 )
 
 // K8sTokenValidator implements Validator for Kubernetes bearer tokens.
 type K8sTokenValidator struct {
 	authn  kauthn.AuthenticationV1Interface
 	domain *TrustDomain
+	// audiences are the audiences the tokens are reviewed for
+	audiences []string
+	// requireBoundTokens rejects the tokens that aren't bound to a pod or
+	// issued for one of the audiences, instead of falling back to reviewing
+	// them for the API server audience
+	requireBoundTokens bool
+	// revocations are checked after the tokens are validated, when not nil
+	revocations *Revocations
 }
 
 // NewK8sTokenValidator takes a kubernetes client and trust domain to create a
-// K8sTokenValidator.
+// K8sTokenValidator. The tokens are reviewed for the given audiences, which
+// default to LinkerdAudienceKey. When requireBoundTokens is set, legacy
+// service account tokens and tokens issued for other audiences are rejected.
+// The tokens of the service accounts and pods listed in revocations are
+// rejected when it isn't nil.
 //
 // The kubernetes client is used immediately to validate that the client has
 // sufficient privileges to perform token reviews. An error is returned if this
@@ -38,25 +54,31 @@ func NewK8sTokenValidator(
 	ctx context.Context,
 	k8s k8s.Interface,
 	domain *TrustDomain,
+	audiences []string,
+	requireBoundTokens bool,
+	revocations *Revocations,
 ) (identity.Validator, error) {
 	if err := checkAccess(ctx, k8s.AuthorizationV1()); err != nil {
 		return nil, err
 	}
 
+	if len(audiences) == 0 {
+		audiences = []string{LinkerdAudienceKey}
+	}
 	authn := k8s.AuthenticationV1()
-	return &K8sTokenValidator{authn, domain}, nil
+	return &K8sTokenValidator{authn, domain, audiences, requireBoundTokens, revocations}, nil
 }
 
 // Validate accepts kubernetes bearer tokens and returns a DNS-form linkerd ID.
 func (k *K8sTokenValidator) Validate(ctx context.Context, tok []byte) (string, error) {
-	tr := kauthnApi.TokenReview{Spec: kauthnApi.TokenReviewSpec{Token: string(tok), Audiences: []string{LinkerdAudienceKey}}}
+	tr := kauthnApi.TokenReview{Spec: kauthnApi.TokenReviewSpec{Token: string(tok), Audiences: k.audiences}}
 	rvw, err := k.authn.TokenReviews().Create(ctx, &tr, metav1.CreateOptions{})
 	if err != nil {
 		return "", err
 	}
 
 	if rvw.Status.Error != "" {
-		if strings.Contains(rvw.Status.Error, "token audiences") {
+		if !k.requireBoundTokens && strings.Contains(rvw.Status.Error, "token audiences") {
 			// Fallback to the default service account token validation if the error is realted to audiences
 			log.Debugf("TokenReview with audiences Failed. Falling back to the default")
 			tr = kauthnApi.TokenReview{Spec: kauthnApi.TokenReviewSpec{Token: string(tok), Audiences: []string{}}}
@@ -75,6 +97,12 @@ func (k *K8sTokenValidator) Validate(ctx context.Context, tok []byte) (string, e
 		return "", identity.NotAuthenticated{}
 	}
 
+	if k.requireBoundTokens {
+		if err := k.checkBound(rvw.Status); err != nil {
+			return "", err
+		}
+	}
+
 	// Determine the identity associated with the token's userinfo.
 	uns := strings.Split(rvw.Status.User.Username, ":")
 	if len(uns) != 4 || uns[0] != "system" {
@@ -88,9 +116,35 @@ func (k *K8sTokenValidator) Validate(ctx context.Context, tok []byte) (string, e
 		}
 	}
 
+	if k.revocations != nil {
+		revocations := []identity.Revocation{{Kind: identity.RevokedServiceAccount, Namespace: uns[1], Name: uns[2]}}
+		for _, pod := range rvw.Status.User.Extra[boundPodNameKey] {
+			revocations = append(revocations, identity.Revocation{Kind: identity.RevokedPod, Namespace: uns[1], Name: pod})
+		}
+		if err := k.revocations.check(revocations...); err != nil {
+			return "", err
+		}
+	}
+
 	return k.domain.Identity(uns[0], uns[2], uns[1])
 }
 
+// checkBound ensures that an authenticated token is bound to a pod and was
+// issued for one of the audiences
+func (k *K8sTokenValidator) checkBound(status kauthnApi.TokenReviewStatus) error {
+	if len(status.User.Extra[boundPodNameKey]) == 0 {
+		return identity.InvalidToken{Reason: "token is not bound to a pod"}
+	}
+	for _, aud := range status.Audiences {
+		for _, expected := range k.audiences {
+			if aud == expected {
+				return nil
+			}
+		}
+	}
+	return identity.InvalidToken{Reason: fmt.Sprintf("token audiences %v don't include any of %v", status.Audiences, k.audiences)}
+}
+
 func checkAccess(ctx context.Context, authz kauthz.AuthorizationV1Interface) error {
 	r := &kauthzApi.SelfSubjectAccessReview{
 		Spec: kauthzApi.SelfSubjectAccessReviewSpec{
@@ -0,0 +1,96 @@
+package identity
+
+import (
+	"context"
+	"testing"
+
+	kauthnApi "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newFakeValidator returns a K8sTokenValidator whose token reviews get the
+// given status
+func newFakeValidator(t *testing.T, status kauthnApi.TokenReviewStatus, requireBoundTokens bool) *K8sTokenValidator {
+	domain, err := NewTrustDomain("linkerd", "cluster.local")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		tr := action.(k8stesting.CreateAction).GetObject().(*kauthnApi.TokenReview)
+		if len(tr.Spec.Audiences) != 1 || tr.Spec.Audiences[0] != LinkerdAudienceKey {
+			t.Fatalf("Expected the token to be reviewed for %s, got %v", LinkerdAudienceKey, tr.Spec.Audiences)
+		}
+		tr.Status = status
+		return true, tr, nil
+	})
+
+	return &K8sTokenValidator{client.AuthenticationV1(), domain, []string{LinkerdAudienceKey}, requireBoundTokens, nil}
+}
+
+func TestValidate(t *testing.T) {
+	user := kauthnApi.UserInfo{Username: "system:serviceaccount:emojivoto:web"}
+	boundUser := kauthnApi.UserInfo{
+		Username: user.Username,
+		Extra:    map[string]kauthnApi.ExtraValue{boundPodNameKey: {"web-5f7f9f8f8-abcde"}},
+	}
+	expected := "web.emojivoto.serviceaccount.identity.linkerd.cluster.local"
+
+	testCases := []struct {
+		name               string
+		status             kauthnApi.TokenReviewStatus
+		requireBoundTokens bool
+		err                string
+	}{
+		{
+			name:   "legacy token",
+			status: kauthnApi.TokenReviewStatus{Authenticated: true, User: user},
+		},
+		{
+			name:               "bound token",
+			status:             kauthnApi.TokenReviewStatus{Authenticated: true, User: boundUser, Audiences: []string{LinkerdAudienceKey}},
+			requireBoundTokens: true,
+		},
+		{
+			name:               "legacy token when bound tokens are required",
+			status:             kauthnApi.TokenReviewStatus{Authenticated: true, User: user, Audiences: []string{LinkerdAudienceKey}},
+			requireBoundTokens: true,
+			err:                "token is not bound to a pod",
+		},
+		{
+			name:               "bound token for another audience",
+			status:             kauthnApi.TokenReviewStatus{Authenticated: true, User: boundUser, Audiences: []string{"https://kubernetes.default.svc"}},
+			requireBoundTokens: true,
+			err:                "token audiences [https://kubernetes.default.svc] don't include any of [identity.l5d.io]",
+		},
+		{
+			name:               "token audiences error when bound tokens are required",
+			status:             kauthnApi.TokenReviewStatus{Error: "[invalid bearer token, token audiences [\"identity.l5d.io\"] is invalid for the target audiences]"},
+			requireBoundTokens: true,
+			err:                "[invalid bearer token, token audiences [\"identity.l5d.io\"] is invalid for the target audiences]",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			v := newFakeValidator(t, tc.status, tc.requireBoundTokens)
+			id, err := v.Validate(context.Background(), []byte("token"))
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("Expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if id != expected {
+				t.Fatalf("Expected identity %s, got %s", expected, id)
+			}
+		})
+	}
+}
@@ -12,8 +12,12 @@ import (
 	spv1alpha2 "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha2"
 	l5dcrdclient "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned"
 	l5dcrdinformer "github.com/linkerd/linkerd2/controller/gen/client/informers/externalversions"
+	dpinformers "github.com/linkerd/linkerd2/controller/gen/client/informers/externalversions/defaultprofile/v1alpha1"
+	ipinformers "github.com/linkerd/linkerd2/controller/gen/client/informers/externalversions/injectionpolicy/v1alpha1"
 	srvinformers "github.com/linkerd/linkerd2/controller/gen/client/informers/externalversions/server/v1beta1"
+	sazinformers "github.com/linkerd/linkerd2/controller/gen/client/informers/externalversions/serverauthorization/v1beta1"
 	spinformers "github.com/linkerd/linkerd2/controller/gen/client/informers/externalversions/serviceprofile/v1alpha2"
+	tcinformers "github.com/linkerd/linkerd2/controller/gen/client/informers/externalversions/tracingconfiguration/v1alpha1"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
@@ -64,12 +68,16 @@ const (
 	ES // EndpointSlice resource
 	Srv
 	Saz
+	DP // DefaultProfile resource
+	TC // TracingConfiguration resource
+	IP // InjectionPolicy resource
 )
 
 // API provides shared informers for all Kubernetes objects
 type API struct {
 	Client        kubernetes.Interface
 	DynamicClient dynamic.Interface
+	L5dClient     l5dcrdclient.Interface
 
 	cj       batchv1beta1informers.CronJobInformer
 	cm       coreinformers.ConfigMapInformer
@@ -89,12 +97,17 @@ type API struct {
 	node     coreinformers.NodeInformer
 	secret   coreinformers.SecretInformer
 	srv      srvinformers.ServerInformer
+	saz      sazinformers.ServerAuthorizationInformer
+	dp       dpinformers.DefaultProfileInformer
+	tc       tcinformers.TracingConfigurationInformer
+	ip       ipinformers.InjectionPolicyInformer
 
 	syncChecks            []cache.InformerSynced
 	sharedInformers       informers.SharedInformerFactory
 	l5dCrdSharedInformers l5dcrdinformer.SharedInformerFactory
 
 	gauges []prometheus.GaugeFunc
+	stale  staleWatches
 }
 
 // InitializeAPI creates Kubernetes clients and returns an initialized API wrapper.
@@ -114,7 +127,7 @@ func InitializeAPI(ctx context.Context, kubeConfig string, ensureClusterWideAcce
 		return nil, err
 	}
 
-	return initAPI(ctx, k8sClient, dynamicClient, config, ensureClusterWideAccess, resources...)
+	return initAPI(ctx, k8sClient, dynamicClient, config, ensureClusterWideAccess, metav1.NamespaceAll, resources...)
 }
 
 // InitializeAPIForConfig creates Kubernetes clients and returns an initialized API wrapper.
@@ -124,10 +137,22 @@ func InitializeAPIForConfig(ctx context.Context, kubeConfig *rest.Config, ensure
 		return nil, err
 	}
 
-	return initAPI(ctx, k8sClient, nil, kubeConfig, ensureClusterWideAccess, resources...)
+	return initAPI(ctx, k8sClient, nil, kubeConfig, ensureClusterWideAccess, metav1.NamespaceAll, resources...)
 }
 
-func initAPI(ctx context.Context, k8sClient *k8s.KubernetesAPI, dynamicClient dynamic.Interface, kubeConfig *rest.Config, ensureClusterWideAccess bool, resources ...APIResource) (*API, error) {
+// InitializeNamespacedAPIForConfig creates Kubernetes clients and returns an
+// initialized API wrapper whose informers only watch the given namespace, for
+// when the credentials don't grant cluster-wide access.
+func InitializeNamespacedAPIForConfig(ctx context.Context, kubeConfig *rest.Config, namespace string, resources ...APIResource) (*API, error) {
+	k8sClient, err := k8s.NewAPIForConfig(kubeConfig, "", []string{}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return initAPI(ctx, k8sClient, nil, kubeConfig, false, namespace, resources...)
+}
+
+func initAPI(ctx context.Context, k8sClient *k8s.KubernetesAPI, dynamicClient dynamic.Interface, kubeConfig *rest.Config, ensureClusterWideAccess bool, namespace string, resources ...APIResource) (*API, error) {
 	// check for cluster-wide access
 	var err error
 
@@ -138,17 +163,34 @@ func initAPI(ctx context.Context, k8sClient *k8s.KubernetesAPI, dynamicClient dy
 		}
 	}
 
-	// check for need and access to Linkerd CRD clients
+	// check for need and access to Linkerd CRD clients. The access of
+	// namespaced APIs is checked in their namespace, as their credentials are
+	// only expected to grant access to it.
 	var l5dCrdClient *l5dcrdclient.Clientset
 	for _, res := range resources {
 		switch {
 		case res == SP:
-			err := k8s.ServiceProfilesAccess(ctx, k8sClient)
+			err := k8s.ServiceProfilesAccess(ctx, k8sClient, namespace)
+			if err != nil {
+				return nil, err
+			}
+		case res == DP:
+			err := k8s.DefaultProfilesAccess(ctx, k8sClient, namespace)
+			if err != nil {
+				return nil, err
+			}
+		case res == TC:
+			err := k8s.TracingConfigurationsAccess(ctx, k8sClient, namespace)
+			if err != nil {
+				return nil, err
+			}
+		case res == IP:
+			err := k8s.InjectionPoliciesAccess(ctx, k8sClient)
 			if err != nil {
 				return nil, err
 			}
-		case res == Srv:
-			err := k8s.ServersAccess(ctx, k8sClient)
+		case res == Srv || res == Saz:
+			err := k8s.ServersAccess(ctx, k8sClient, namespace)
 			if err != nil {
 				return nil, err
 			}
@@ -162,7 +204,7 @@ func initAPI(ctx context.Context, k8sClient *k8s.KubernetesAPI, dynamicClient dy
 		break
 	}
 
-	api := NewAPI(k8sClient, dynamicClient, l5dCrdClient, resources...)
+	api := newNamespacedAPI(k8sClient, dynamicClient, l5dCrdClient, namespace, resources...)
 	for _, gauge := range api.gauges {
 		prometheus.Register(gauge)
 	}
@@ -176,19 +218,31 @@ func NewAPI(
 	l5dCrdClient l5dcrdclient.Interface,
 	resources ...APIResource,
 ) *API {
-	sharedInformers := informers.NewSharedInformerFactory(k8sClient, 10*time.Minute)
+	return newNamespacedAPI(k8sClient, dynamicClient, l5dCrdClient, metav1.NamespaceAll, resources...)
+}
+
+func newNamespacedAPI(
+	k8sClient kubernetes.Interface,
+	dynamicClient dynamic.Interface,
+	l5dCrdClient l5dcrdclient.Interface,
+	namespace string,
+	resources ...APIResource,
+) *API {
+	sharedInformers := informers.NewSharedInformerFactoryWithOptions(k8sClient, 10*time.Minute, informers.WithNamespace(namespace))
 
 	var l5dCrdSharedInformers l5dcrdinformer.SharedInformerFactory
 	if l5dCrdClient != nil {
-		l5dCrdSharedInformers = l5dcrdinformer.NewSharedInformerFactory(l5dCrdClient, 10*time.Minute)
+		l5dCrdSharedInformers = l5dcrdinformer.NewSharedInformerFactoryWithOptions(l5dCrdClient, 10*time.Minute, l5dcrdinformer.WithNamespace(namespace))
 	}
 
 	api := &API{
 		Client:                k8sClient,
 		DynamicClient:         dynamicClient,
+		L5dClient:             l5dCrdClient,
 		syncChecks:            make([]cache.InformerSynced, 0),
 		sharedInformers:       sharedInformers,
 		l5dCrdSharedInformers: l5dCrdSharedInformers,
+		stale:                 staleWatches{informers: make(map[string]*staleInformer)},
 	}
 
 	for _, resource := range resources {
@@ -196,81 +250,109 @@ func NewAPI(
 		case CJ:
 			api.cj = sharedInformers.Batch().V1beta1().CronJobs()
 			api.syncChecks = append(api.syncChecks, api.cj.Informer().HasSynced)
-			api.addInformerSizeGauge("cron_job", api.cj.Informer())
+			api.trackInformer("cron_job", api.cj.Informer())
 		case CM:
 			api.cm = sharedInformers.Core().V1().ConfigMaps()
 			api.syncChecks = append(api.syncChecks, api.cm.Informer().HasSynced)
-			api.addInformerSizeGauge("config_map", api.cm.Informer())
+			api.trackInformer("config_map", api.cm.Informer())
 		case Deploy:
 			api.deploy = sharedInformers.Apps().V1().Deployments()
 			api.syncChecks = append(api.syncChecks, api.deploy.Informer().HasSynced)
-			api.addInformerSizeGauge("deployment", api.deploy.Informer())
+			api.trackInformer("deployment", api.deploy.Informer())
 		case DS:
 			api.ds = sharedInformers.Apps().V1().DaemonSets()
 			api.syncChecks = append(api.syncChecks, api.ds.Informer().HasSynced)
-			api.addInformerSizeGauge("daemon_set", api.ds.Informer())
+			api.trackInformer("daemon_set", api.ds.Informer())
 		case Endpoint:
 			api.endpoint = sharedInformers.Core().V1().Endpoints()
 			api.syncChecks = append(api.syncChecks, api.endpoint.Informer().HasSynced)
-			api.addInformerSizeGauge("endpoint", api.endpoint.Informer())
+			api.trackInformer("endpoint", api.endpoint.Informer())
 		case ES:
 			api.es = sharedInformers.Discovery().V1beta1().EndpointSlices()
 			api.syncChecks = append(api.syncChecks, api.es.Informer().HasSynced)
-			api.addInformerSizeGauge("endpoint_slice", api.es.Informer())
+			api.trackInformer("endpoint_slice", api.es.Informer())
 		case Job:
 			api.job = sharedInformers.Batch().V1().Jobs()
 			api.syncChecks = append(api.syncChecks, api.job.Informer().HasSynced)
-			api.addInformerSizeGauge("job", api.job.Informer())
+			api.trackInformer("job", api.job.Informer())
 		case MWC:
 			api.mwc = sharedInformers.Admissionregistration().V1beta1().MutatingWebhookConfigurations()
 			api.syncChecks = append(api.syncChecks, api.mwc.Informer().HasSynced)
-			api.addInformerSizeGauge("mutating_webhook_configuration", api.mwc.Informer())
+			api.trackInformer("mutating_webhook_configuration", api.mwc.Informer())
 		case NS:
 			api.ns = sharedInformers.Core().V1().Namespaces()
 			api.syncChecks = append(api.syncChecks, api.ns.Informer().HasSynced)
-			api.addInformerSizeGauge("namespace", api.ns.Informer())
+			api.trackInformer("namespace", api.ns.Informer())
 		case Pod:
 			api.pod = sharedInformers.Core().V1().Pods()
 			api.syncChecks = append(api.syncChecks, api.pod.Informer().HasSynced)
-			api.addInformerSizeGauge("pod", api.pod.Informer())
+			api.trackInformer("pod", api.pod.Informer())
 		case RC:
 			api.rc = sharedInformers.Core().V1().ReplicationControllers()
 			api.syncChecks = append(api.syncChecks, api.rc.Informer().HasSynced)
-			api.addInformerSizeGauge("replication_controller", api.rc.Informer())
+			api.trackInformer("replication_controller", api.rc.Informer())
 		case RS:
 			api.rs = sharedInformers.Apps().V1().ReplicaSets()
 			api.syncChecks = append(api.syncChecks, api.rs.Informer().HasSynced)
-			api.addInformerSizeGauge("replica_set", api.rs.Informer())
+			api.trackInformer("replica_set", api.rs.Informer())
 		case SP:
 			if l5dCrdSharedInformers == nil {
 				panic("Linkerd CRD shared informer not configured")
 			}
 			api.sp = l5dCrdSharedInformers.Linkerd().V1alpha2().ServiceProfiles()
 			api.syncChecks = append(api.syncChecks, api.sp.Informer().HasSynced)
-			api.addInformerSizeGauge("service_profile", api.sp.Informer())
+			api.trackInformer("service_profile", api.sp.Informer())
+		case DP:
+			if l5dCrdSharedInformers == nil {
+				panic("Linkerd CRD shared informer not configured")
+			}
+			api.dp = l5dCrdSharedInformers.Defaultprofile().V1alpha1().DefaultProfiles()
+			api.syncChecks = append(api.syncChecks, api.dp.Informer().HasSynced)
+			api.trackInformer("default_profile", api.dp.Informer())
+		case TC:
+			if l5dCrdSharedInformers == nil {
+				panic("Linkerd CRD shared informer not configured")
+			}
+			api.tc = l5dCrdSharedInformers.Tracingconfiguration().V1alpha1().TracingConfigurations()
+			api.syncChecks = append(api.syncChecks, api.tc.Informer().HasSynced)
+			api.trackInformer("tracing_configuration", api.tc.Informer())
+		case IP:
+			if l5dCrdSharedInformers == nil {
+				panic("Linkerd CRD shared informer not configured")
+			}
+			api.ip = l5dCrdSharedInformers.Injectionpolicy().V1alpha1().InjectionPolicies()
+			api.syncChecks = append(api.syncChecks, api.ip.Informer().HasSynced)
+			api.trackInformer("injection_policy", api.ip.Informer())
 		case Srv:
 			if l5dCrdSharedInformers == nil {
 				panic("Linkerd CRD shared informer not configured")
 			}
 			api.srv = l5dCrdSharedInformers.Server().V1beta1().Servers()
 			api.syncChecks = append(api.syncChecks, api.srv.Informer().HasSynced)
-			api.addInformerSizeGauge("server", api.srv.Informer())
+			api.trackInformer("server", api.srv.Informer())
+		case Saz:
+			if l5dCrdSharedInformers == nil {
+				panic("Linkerd CRD shared informer not configured")
+			}
+			api.saz = l5dCrdSharedInformers.Serverauthorization().V1beta1().ServerAuthorizations()
+			api.syncChecks = append(api.syncChecks, api.saz.Informer().HasSynced)
+			api.trackInformer("server_authorization", api.saz.Informer())
 		case SS:
 			api.ss = sharedInformers.Apps().V1().StatefulSets()
 			api.syncChecks = append(api.syncChecks, api.ss.Informer().HasSynced)
-			api.addInformerSizeGauge("stateful_set", api.ss.Informer())
+			api.trackInformer("stateful_set", api.ss.Informer())
 		case Svc:
 			api.svc = sharedInformers.Core().V1().Services()
 			api.syncChecks = append(api.syncChecks, api.svc.Informer().HasSynced)
-			api.addInformerSizeGauge("service", api.svc.Informer())
+			api.trackInformer("service", api.svc.Informer())
 		case Node:
 			api.node = sharedInformers.Core().V1().Nodes()
 			api.syncChecks = append(api.syncChecks, api.node.Informer().HasSynced)
-			api.addInformerSizeGauge("node", api.node.Informer())
+			api.trackInformer("node", api.node.Informer())
 		case Secret:
 			api.secret = sharedInformers.Core().V1().Secrets()
 			api.syncChecks = append(api.syncChecks, api.secret.Informer().HasSynced)
-			api.addInformerSizeGauge("secret", api.secret.Informer())
+			api.trackInformer("secret", api.secret.Informer())
 		}
 	}
 	return api
@@ -391,6 +473,31 @@ func (api *API) SP() spinformers.ServiceProfileInformer {
 	return api.sp
 }
 
+// DP provides access to a shared informer and lister for DefaultProfiles.
+func (api *API) DP() dpinformers.DefaultProfileInformer {
+	if api.dp == nil {
+		panic("DP informer not configured")
+	}
+	return api.dp
+}
+
+// TC provides access to a shared informer and lister for
+// TracingConfigurations.
+func (api *API) TC() tcinformers.TracingConfigurationInformer {
+	if api.tc == nil {
+		panic("TC informer not configured")
+	}
+	return api.tc
+}
+
+// IP provides access to a shared informer and lister for InjectionPolicies.
+func (api *API) IP() ipinformers.InjectionPolicyInformer {
+	if api.ip == nil {
+		panic("IP informer not configured")
+	}
+	return api.ip
+}
+
 // Srv provides access to a shared informer and lister for Servers.
 func (api *API) Srv() srvinformers.ServerInformer {
 	if api.srv == nil {
@@ -399,6 +506,15 @@ func (api *API) Srv() srvinformers.ServerInformer {
 	return api.srv
 }
 
+// Saz provides access to a shared informer and lister for
+// ServerAuthorizations.
+func (api *API) Saz() sazinformers.ServerAuthorizationInformer {
+	if api.saz == nil {
+		panic("Saz informer not configured")
+	}
+	return api.saz
+}
+
 // MWC provides access to a shared informer and lister for MutatingWebhookConfigurations.
 func (api *API) MWC() arinformers.MutatingWebhookConfigurationInformer {
 	if api.mwc == nil {
@@ -480,8 +596,11 @@ func (api *API) GetObjects(namespace, restype, name string, label labels.Selecto
 // references from the Kubernetes API. The kind is represented as the Kubernetes
 // singular resource type (e.g. deployment, daemonset, job, etc.).
 // If retry is true, when the shared informer cache doesn't return anything
-// we try again with a direct Kubernetes API call.
+// we try again with a direct Kubernetes API call, unless the watches are
+// broken, in which case that call would only time out.
 func (api *API) GetOwnerKindAndName(ctx context.Context, pod *corev1.Pod, retry bool) (string, string) {
+	retry = retry && !api.IsStale()
+
 	ownerRefs := pod.GetOwnerReferences()
 	if len(ownerRefs) == 0 {
 		// pod without a parent
@@ -0,0 +1,139 @@
+package k8s
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/cache"
+)
+
+// staleWatches tracks the informers whose watch is broken, e.g. during a
+// kube-apiserver outage. Their caches keep serving the last known state, while
+// their reflectors keep retrying to re-establish the watch with a jittered
+// exponential backoff.
+type staleWatches struct {
+	// informers holds the informers whose watch is broken, by kind
+	informers map[string]*staleInformer
+	sync.Mutex
+}
+
+type staleInformer struct {
+	informer cache.SharedIndexInformer
+	// resourceVersion is the last resource version the informer synced
+	// before its watch broke; the watch is re-established once it changes,
+	// or once the informer gets an event from the API server
+	resourceVersion string
+}
+
+// trackInformer exposes the size of the informer's cache and whether it is
+// stale, and flags it as stale whenever its watch breaks.
+func (api *API) trackInformer(kind string, inf cache.SharedIndexInformer) {
+	api.addInformerSizeGauge(kind, inf)
+
+	err := inf.SetWatchErrorHandler(api.watchErrorHandler(kind, inf))
+	if err != nil {
+		// the informer has already been started
+		log.Debugf("failed to set the watch error handler of the %s informer: %s", kind, err)
+	}
+	inf.AddEventHandler(api.watchEventHandler(kind))
+	api.gauges = append(api.gauges, prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: fmt.Sprintf("%s_cache_stale", kind),
+		Help: fmt.Sprintf("Whether the watch of the client-go %s cache is broken, the cache serving its last known state", kind),
+	}, func() float64 {
+		if api.isStale(kind) {
+			return 1
+		}
+		return 0
+	}))
+}
+
+func (api *API) watchErrorHandler(kind string, inf cache.SharedIndexInformer) cache.WatchErrorHandler {
+	return func(r *cache.Reflector, err error) {
+		cache.DefaultWatchErrorHandler(r, err)
+
+		// the expired and closed watches are re-established right away
+		if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) || err == io.EOF {
+			return
+		}
+
+		api.stale.Lock()
+		defer api.stale.Unlock()
+		if _, ok := api.stale.informers[kind]; ok {
+			return
+		}
+		log.Warnf("%s watch broken, serving the last known state until it is re-established: %s", kind, err)
+		api.stale.informers[kind] = &staleInformer{
+			informer:        inf,
+			resourceVersion: inf.LastSyncResourceVersion(),
+		}
+	}
+}
+
+// watchEventHandler clears the stale flag of the informer on the events it
+// gets from the API server, which it only gets once it has listed or watched
+// the resources again, even if their resource version didn't change. The
+// updates whose objects are the ones of the cache are left out, as they're
+// replayed from the cache by the periodic resyncs.
+func (api *API) watchEventHandler(kind string) cache.ResourceEventHandlerFuncs {
+	reestablished := func() {
+		api.stale.Lock()
+		defer api.stale.Unlock()
+		api.clearStale(kind)
+	}
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(interface{}) { reestablished() },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if oldObj != newObj {
+				reestablished()
+			}
+		},
+		DeleteFunc: func(interface{}) { reestablished() },
+	}
+}
+
+// IsStale returns true when the watch of any of the informers is broken, in
+// which case the listers serve the last known state of the cluster.
+func (api *API) IsStale() bool {
+	api.stale.Lock()
+	defer api.stale.Unlock()
+	for kind := range api.stale.informers {
+		if api.revalidate(kind) {
+			return true
+		}
+	}
+	return false
+}
+
+func (api *API) isStale(kind string) bool {
+	api.stale.Lock()
+	defer api.stale.Unlock()
+	return api.revalidate(kind)
+}
+
+// revalidate returns true if the watch of the informer is still broken; the
+// stale lock must be held.
+func (api *API) revalidate(kind string) bool {
+	stale, ok := api.stale.informers[kind]
+	if !ok {
+		return false
+	}
+	if stale.informer.LastSyncResourceVersion() == stale.resourceVersion {
+		return true
+	}
+	api.clearStale(kind)
+	return false
+}
+
+// clearStale flags the watch of the informer as re-established; the stale
+// lock must be held.
+func (api *API) clearStale(kind string) {
+	if _, ok := api.stale.informers[kind]; !ok {
+		return
+	}
+	log.Infof("%s watch re-established", kind)
+	delete(api.stale.informers, kind)
+}
@@ -0,0 +1,102 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestStaleWatches(t *testing.T) {
+	api, err := NewFakeAPI()
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+	api.Sync(nil)
+
+	if api.IsStale() {
+		t.Fatal("Expected the API not to be stale")
+	}
+
+	handler := api.watchErrorHandler("pod", api.Pod().Informer())
+
+	handler(&cache.Reflector{}, apierrors.NewResourceExpired("too old resource version"))
+	if api.IsStale() {
+		t.Fatal("Expected the API not to be stale after the watch expired")
+	}
+
+	handler(&cache.Reflector{}, apierrors.NewGone("gone"))
+	handler(&cache.Reflector{}, errors.New("connection refused"))
+	if !api.IsStale() || !api.isStale("pod") {
+		t.Fatal("Expected the API to be stale after the watch broke")
+	}
+	if api.isStale("service") {
+		t.Fatal("Expected the service cache not to be stale")
+	}
+
+	// the pods created while the watch is broken are missing from the cache,
+	// but the API isn't queried for their owner
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "web",
+			Namespace:       "ns",
+			ResourceVersion: "2",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-1"}},
+		},
+	}
+	kind, name := api.GetOwnerKindAndName(context.Background(), pod, true)
+	if kind != "replicaset" || name != "web-1" {
+		t.Fatalf("Expected owner replicaset/web-1, got %s/%s", kind, name)
+	}
+
+	// the watch is re-established once the informer syncs a new resource
+	// version
+	_, err = api.Client.CoreV1().Pods("ns").Create(context.Background(), pod, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for api.IsStale() {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the API not to be stale after the watch was re-established")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestStaleWatchesResync(t *testing.T) {
+	api, err := NewFakeAPI()
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+	api.Sync(nil)
+
+	handler := api.watchErrorHandler("pod", api.Pod().Informer())
+	events := api.watchEventHandler("pod")
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "ns", ResourceVersion: "1"}}
+
+	handler(&cache.Reflector{}, errors.New("connection refused"))
+	// the periodic resyncs replay the objects of the cache
+	events.OnUpdate(pod, pod)
+	if !api.isStale("pod") {
+		t.Fatal("Expected the pod cache to stay stale after a resync")
+	}
+
+	// a relist gets the objects from the API server again, even though their
+	// resource version didn't change
+	events.OnUpdate(pod, pod.DeepCopy())
+	if api.isStale("pod") {
+		t.Fatal("Expected the pod cache not to be stale after a relist")
+	}
+
+	handler(&cache.Reflector{}, errors.New("connection refused"))
+	events.OnDelete(pod)
+	if api.isStale("pod") {
+		t.Fatal("Expected the pod cache not to be stale after a watch event")
+	}
+}
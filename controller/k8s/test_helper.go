@@ -32,5 +32,9 @@ func NewFakeAPI(configs ...string) (*API, error) {
 		Node,
 		ES,
 		Srv,
+		Saz,
+		DP,
+		TC,
+		IP,
 	), nil
 }
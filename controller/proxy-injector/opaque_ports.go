@@ -0,0 +1,32 @@
+package injector
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// readOpaquePortsImages reads the map of images to their opaque ports from the
+// directory where the linkerd-opaque-ports-images ConfigMap is mounted, so
+// that its changes are picked up without restarting the injector.
+func readOpaquePortsImages(dir string) (map[string]string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	images := make(map[string]string)
+	for _, file := range files {
+		// skip the hidden files and directories Kubernetes uses to update the
+		// ConfigMap atomically
+		if strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+		ports, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		images[file.Name()] = strings.TrimSpace(string(ports))
+	}
+	return images, nil
+}
@@ -0,0 +1,35 @@
+package injector
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadOpaquePortsImages(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"postgres":           "5432\n",
+		"..data/postgres":    "5432",
+		"..2021_10_01/mysql": "3306",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	images, err := readOpaquePortsImages(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := map[string]string{"postgres": "5432"}
+	if !reflect.DeepEqual(images, expected) {
+		t.Fatalf("Expected %v, got %v", expected, images)
+	}
+}
@@ -0,0 +1,164 @@
+package injector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	ipv1alpha1 "github.com/linkerd/linkerd2/controller/gen/apis/injectionpolicy/v1alpha1"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	"github.com/linkerd/linkerd2/pkg/inject"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// proxyLogLevels are the levels of the proxy logs, from the least to the most
+// verbose
+var proxyLogLevels = []string{"off", "error", "warn", "info", "debug", "trace"}
+
+// getInjectionPolicies returns the InjectionPolicies selecting the namespace,
+// sorted by name. The policies with an invalid namespace selector are logged
+// and ignored.
+func getInjectionPolicies(api *k8s.API, namespace *corev1.Namespace) ([]*ipv1alpha1.InjectionPolicy, error) {
+	policies, err := api.IP().Lister().List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	selected := []*ipv1alpha1.InjectionPolicy{}
+	for _, policy := range policies {
+		if policy.Spec.NamespaceSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector)
+			if err != nil {
+				log.Warnf("ignoring InjectionPolicy %s with an invalid namespace selector: %s", policy.Name, err)
+				continue
+			}
+			if !selector.Matches(labels.Set(namespace.Labels)) {
+				continue
+			}
+		}
+		selected = append(selected, policy)
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Name < selected[j].Name })
+	return selected, nil
+}
+
+// enforceInjectionPolicies applies the InjectionPolicies to the resource, in
+// order, and returns the ways its proxy configuration violates them. When
+// several policies force the same annotation, the last one wins. The
+// annotations of the resources that aren't injected are checked too, so that
+// opting out of injection doesn't escape the policies.
+func enforceInjectionPolicies(policies []*ipv1alpha1.InjectionPolicy, conf *inject.ResourceConfig, injected bool) ([]string, error) {
+	violations := []string{}
+	for _, policy := range policies {
+		policyViolations, err := enforceInjectionPolicy(policy, conf, injected)
+		if err != nil {
+			return nil, err
+		}
+		for _, violation := range policyViolations {
+			violations = append(violations, fmt.Sprintf("%s (InjectionPolicy %s)", violation, policy.Name))
+		}
+	}
+	return violations, nil
+}
+
+// enforceInjectionPolicy appends the annotations forced by the policy to the
+// resource and returns the ways its proxy configuration, as resolved from its
+// annotations, the ones of its namespace and the install-time values,
+// violates the policy. The proxy configuration is only checked when the
+// resource is injected, as there's no proxy otherwise.
+func enforceInjectionPolicy(policy *ipv1alpha1.InjectionPolicy, conf *inject.ResourceConfig, injected bool) ([]string, error) {
+	violations := []string{}
+	spec := policy.Spec
+
+	// The forbidden annotations are checked first, as they may be forced too
+	for _, key := range spec.ForbiddenAnnotations {
+		if conf.HasWorkloadAnnotation(key) {
+			violations = append(violations, fmt.Sprintf("the %s annotation can't be set", key))
+		}
+	}
+
+	keys := make([]string, 0, len(spec.ForcedAnnotations))
+	for key := range spec.ForcedAnnotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := spec.ForcedAnnotations[key]
+		if current, ok := conf.GetWorkloadAnnotation(key); ok && current != value {
+			violations = append(violations, fmt.Sprintf("the %s annotation must be set to %q, got %q", key, value, current))
+			continue
+		}
+		conf.AppendPodAnnotation(key, value)
+	}
+
+	if !injected {
+		return violations, nil
+	}
+
+	values, err := conf.GetOverriddenValues()
+	if err != nil {
+		return nil, err
+	}
+
+	if spec.RequireIdentity && values.Proxy.DisableIdentity {
+		violations = append(violations, "the proxy identity, and hence mTLS, can't be disabled")
+	}
+
+	if spec.MaxProxyLogLevel != "" {
+		max := logLevelIndex(spec.MaxProxyLogLevel)
+		if max < 0 {
+			log.Warnf("ignoring the invalid max proxy log level %q of InjectionPolicy %s", spec.MaxProxyLogLevel, policy.Name)
+		} else if proxyLogVerbosity(values.Proxy.LogLevel) > max {
+			violations = append(violations, fmt.Sprintf("the proxy log level can't be more verbose than %s, got %q", spec.MaxProxyLogLevel, values.Proxy.LogLevel))
+		}
+	}
+
+	if spec.RequireProxyResourceLimits {
+		if values.Proxy.Resources == nil || values.Proxy.Resources.CPU.Limit == "" {
+			violations = append(violations, "the proxy must have a CPU limit")
+		}
+		if values.Proxy.Resources == nil || values.Proxy.Resources.Memory.Limit == "" {
+			violations = append(violations, "the proxy must have a memory limit")
+		}
+	}
+
+	return violations, nil
+}
+
+// proxyLogVerbosity returns the index in proxyLogLevels of the most verbose
+// directive of a proxy log filter, such as "warn,linkerd=info". As with the
+// proxy, a directive naming a target without a level enables all of them.
+func proxyLogVerbosity(filter string) int {
+	verbosity := 0
+	for _, directive := range strings.Split(filter, ",") {
+		directive = strings.TrimSpace(directive)
+		if directive == "" {
+			continue
+		}
+		level := directive
+		if i := strings.LastIndex(directive, "="); i >= 0 {
+			level = directive[i+1:]
+		}
+		index := logLevelIndex(level)
+		if index < 0 {
+			index = len(proxyLogLevels) - 1
+		}
+		if index > verbosity {
+			verbosity = index
+		}
+	}
+	return verbosity
+}
+
+func logLevelIndex(level string) int {
+	level = strings.ToLower(strings.TrimSpace(level))
+	for i, l := range proxyLogLevels {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
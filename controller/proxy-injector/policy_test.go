@@ -0,0 +1,216 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	ipv1alpha1 "github.com/linkerd/linkerd2/controller/gen/apis/injectionpolicy/v1alpha1"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	"github.com/linkerd/linkerd2/pkg/charts/linkerd2"
+	"github.com/linkerd/linkerd2/pkg/inject"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const policyTestPod = `{
+  "apiVersion": "v1",
+  "kind": "Pod",
+  "metadata": {
+    "name": "web",
+    "namespace": "emojivoto",
+    "annotations": %s
+  },
+  "spec": {
+    "containers": [{"name": "web", "image": "web"}]
+  }
+}`
+
+func policyTestConf(t *testing.T, nsAnnotations map[string]string, podAnnotations string) *inject.ResourceConfig {
+	t.Helper()
+	values, err := linkerd2.NewValues()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	conf := inject.NewResourceConfig(values, inject.OriginWebhook, "linkerd").
+		WithNsAnnotations(nsAnnotations).
+		WithKind("Pod")
+	if _, err := conf.ParseMetaAndYAML([]byte(fmt.Sprintf(policyTestPod, podAnnotations))); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	conf.AppendNamespaceAnnotations()
+	return conf
+}
+
+func TestGetInjectionPolicies(t *testing.T) {
+	api, err := k8s.NewFakeAPI(`
+apiVersion: config.linkerd.io/v1alpha1
+kind: InjectionPolicy
+metadata:
+  name: prod
+spec:
+  namespaceSelector:
+    matchLabels:
+      env: prod
+  requireIdentity: true`, `
+apiVersion: config.linkerd.io/v1alpha1
+kind: InjectionPolicy
+metadata:
+  name: all
+spec:
+  maxProxyLogLevel: info`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	api.Sync(nil)
+
+	for _, tc := range []struct {
+		name     string
+		labels   map[string]string
+		expected []string
+	}{
+		{
+			name:     "selected namespace",
+			labels:   map[string]string{"env": "prod"},
+			expected: []string{"all", "prod"},
+		},
+		{
+			name:     "other namespace",
+			labels:   map[string]string{"env": "dev"},
+			expected: []string{"all"},
+		},
+	} {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "emojivoto", Labels: tc.labels}}
+			policies, err := getInjectionPolicies(api, ns)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			names := []string{}
+			for _, policy := range policies {
+				names = append(names, policy.Name)
+			}
+			if !reflect.DeepEqual(names, tc.expected) {
+				t.Fatalf("Expected policies %v, got %v", tc.expected, names)
+			}
+		})
+	}
+}
+
+func TestEnforceInjectionPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		spec           ipv1alpha1.InjectionPolicySpec
+		nsAnnotations  map[string]string
+		podAnnotations string
+		uninjected     bool
+		expected       []string
+	}{
+		{
+			name:           "compliant workload",
+			spec:           ipv1alpha1.InjectionPolicySpec{RequireIdentity: true, MaxProxyLogLevel: "info"},
+			podAnnotations: `{"config.linkerd.io/proxy-log-level": "warn,linkerd=info"}`,
+			expected:       []string{},
+		},
+		{
+			name:           "identity disabled",
+			spec:           ipv1alpha1.InjectionPolicySpec{RequireIdentity: true},
+			podAnnotations: `{"config.linkerd.io/disable-identity": "true"}`,
+			expected:       []string{"the proxy identity, and hence mTLS, can't be disabled"},
+		},
+		{
+			name:           "log level too verbose",
+			spec:           ipv1alpha1.InjectionPolicySpec{MaxProxyLogLevel: "info"},
+			podAnnotations: `{"config.linkerd.io/proxy-log-level": "warn,linkerd=debug"}`,
+			expected:       []string{`the proxy log level can't be more verbose than info, got "warn,linkerd=debug"`},
+		},
+		{
+			name:           "log level inherited from the namespace",
+			spec:           ipv1alpha1.InjectionPolicySpec{MaxProxyLogLevel: "warn"},
+			nsAnnotations:  map[string]string{pkgK8s.ProxyLogLevelAnnotation: "linkerd"},
+			podAnnotations: `{}`,
+			expected:       []string{`the proxy log level can't be more verbose than warn, got "linkerd"`},
+		},
+		{
+			name:           "missing resource limits",
+			spec:           ipv1alpha1.InjectionPolicySpec{RequireProxyResourceLimits: true},
+			podAnnotations: `{"config.linkerd.io/proxy-cpu-limit": "1"}`,
+			expected:       []string{"the proxy must have a memory limit"},
+		},
+		{
+			name:           "forbidden annotation set by the namespace",
+			spec:           ipv1alpha1.InjectionPolicySpec{ForbiddenAnnotations: []string{pkgK8s.ProxyIgnoreOutboundPortsAnnotation}},
+			nsAnnotations:  map[string]string{pkgK8s.ProxyIgnoreOutboundPortsAnnotation: "3306"},
+			podAnnotations: `{}`,
+			expected:       []string{"the config.linkerd.io/skip-outbound-ports annotation can't be set"},
+		},
+		{
+			name:           "forbidden annotation of a workload opting out of injection",
+			spec:           ipv1alpha1.InjectionPolicySpec{ForbiddenAnnotations: []string{pkgK8s.ProxyInjectAnnotation}},
+			podAnnotations: `{"linkerd.io/inject": "disabled"}`,
+			uninjected:     true,
+			expected:       []string{"the linkerd.io/inject annotation can't be set"},
+		},
+		{
+			name:           "proxy configuration of a workload that isn't injected",
+			spec:           ipv1alpha1.InjectionPolicySpec{RequireIdentity: true, RequireProxyResourceLimits: true},
+			podAnnotations: `{"config.linkerd.io/disable-identity": "true"}`,
+			uninjected:     true,
+			expected:       []string{},
+		},
+		{
+			name:           "forced annotation set to another value",
+			spec:           ipv1alpha1.InjectionPolicySpec{ForcedAnnotations: map[string]string{pkgK8s.ProxyLogFormatAnnotation: "json"}},
+			podAnnotations: `{"config.linkerd.io/proxy-log-format": "plain"}`,
+			expected:       []string{`the config.linkerd.io/proxy-log-format annotation must be set to "json", got "plain"`},
+		},
+	} {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			conf := policyTestConf(t, tc.nsAnnotations, tc.podAnnotations)
+			policy := &ipv1alpha1.InjectionPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy"}, Spec: tc.spec}
+			violations, err := enforceInjectionPolicy(policy, conf, !tc.uninjected)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(violations, tc.expected) {
+				t.Fatalf("Expected violations %v, got %v", tc.expected, violations)
+			}
+		})
+	}
+
+	t.Run("applies forced annotations", func(t *testing.T) {
+		conf := policyTestConf(t, nil, `{}`)
+		policy := &ipv1alpha1.InjectionPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+			Spec: ipv1alpha1.InjectionPolicySpec{
+				ForcedAnnotations: map[string]string{pkgK8s.ProxyLogFormatAnnotation: "json"},
+			},
+		}
+		if _, err := enforceInjectionPolicy(policy, conf, true); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		values, err := conf.GetOverriddenValues()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if values.Proxy.LogFormat != "json" {
+			t.Fatalf("Expected log format json, got %s", values.Proxy.LogFormat)
+		}
+	})
+}
+
+func TestProxyLogVerbosity(t *testing.T) {
+	for filter, expected := range map[string]string{
+		"warn,linkerd=info,trust_dns=error": "info",
+		"error":                             "error",
+		"linkerd=TRACE":                     "trace",
+		"warn,linkerd":                      "trace",
+	} {
+		if verbosity := proxyLogVerbosity(filter); proxyLogLevels[verbosity] != expected {
+			t.Fatalf("Expected %q to be as verbose as %s, got %s", filter, expected, proxyLogLevels[verbosity])
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"strings"
 
 	"github.com/linkerd/linkerd2/controller/k8s"
@@ -11,10 +12,12 @@ import (
 	"github.com/linkerd/linkerd2/pkg/config"
 	"github.com/linkerd/linkerd2/pkg/inject"
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/util"
 	"github.com/linkerd/linkerd2/pkg/version"
 	log "github.com/sirupsen/logrus"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
@@ -23,12 +26,19 @@ import (
 const (
 	eventTypeSkipped  = "InjectionSkipped"
 	eventTypeInjected = "Injected"
+	eventTypeRejected = "InjectionRejected"
+
+	// reasonInjectionPolicy is the skip reason recorded in the metrics for the
+	// resources rejected by an InjectionPolicy
+	reasonInjectionPolicy = "injection_policy"
 )
 
 // Inject returns the function that produces an AdmissionResponse containing
 // the patch, if any, to apply to the pod (proxy sidecar and eventually the
-// init container to set it up)
-func Inject(linkerdNamespace string) webhook.Handler {
+// init container to set it up). When inferOpaquePorts is set, the opaque ports
+// of the pods without the opaque ports annotation are also inferred from the
+// images of their containers.
+func Inject(linkerdNamespace string, inferOpaquePorts bool) webhook.Handler {
 	return func(
 		ctx context.Context,
 		api *k8s.API,
@@ -96,7 +106,42 @@ func Inject(linkerdNamespace string) webhook.Handler {
 			// If namespace has annotations that do not exist on pod then copy them
 			// over to pod's template.
 			resourceConfig.AppendNamespaceAnnotations()
+		}
+
+		// Reject the workload if its proxy configuration violates the
+		// InjectionPolicies selecting its namespace, which may also force some
+		// annotations onto it. This happens whether or not it's injectable, so
+		// that opting out of injection doesn't escape the policies.
+		if resourceConfig.IsPod() || resourceConfig.HasPodTemplate() {
+			policies, err := getInjectionPolicies(api, namespace)
+			if err != nil {
+				return nil, err
+			}
+			violations, err := enforceInjectionPolicies(policies, resourceConfig, injectable)
+			if err != nil {
+				return nil, err
+			}
+			if len(violations) != 0 {
+				message := fmt.Sprintf("Linkerd sidecar proxy injection rejected: %s", strings.Join(violations, "; "))
+				if parent != nil {
+					recorder.Event(*parent, v1.EventTypeWarning, eventTypeRejected, message)
+				}
+				log.Infof("rejected %s: %s", report.ResName(), strings.Join(violations, "; "))
+				proxyInjectionAdmissionResponses.With(admissionResponseLabels(ownerKind, request.Namespace, "true", reasonInjectionPolicy, report.InjectAnnotationAt, configLabels)).Inc()
+				return &admissionv1beta1.AdmissionResponse{
+					UID:     request.UID,
+					Allowed: false,
+					Result: &metav1.Status{
+						Status:  metav1.StatusFailure,
+						Reason:  metav1.StatusReasonForbidden,
+						Code:    http.StatusForbidden,
+						Message: message,
+					},
+				}, nil
+			}
+		}
 
+		if injectable {
 			// If the pod did not inherit the opaque ports annotation from the
 			// namespace, then add the default value from the config values. This
 			// ensures that the generated patch always sets the opaue ports
@@ -104,8 +149,20 @@ func Inject(linkerdNamespace string) webhook.Handler {
 			if !resourceConfig.HasWorkloadAnnotation(pkgK8s.ProxyOpaquePortsAnnotation) {
 				defaultPorts := strings.Split(resourceConfig.GetValues().Proxy.OpaquePorts, ",")
 				filteredPorts := resourceConfig.FilterPodOpaquePorts(defaultPorts)
+				if inferOpaquePorts {
+					images, err := readOpaquePortsImages(pkgK8s.MountPathOpaquePortsImages)
+					if err != nil {
+						log.Warnf("couldn't read the opaque ports of the images; error: %s", err)
+					}
+					for _, port := range resourceConfig.InferOpaquePorts(images) {
+						if !util.ContainsString(port, filteredPorts) {
+							filteredPorts = append(filteredPorts, port)
+						}
+					}
+				}
 				// Only add the annotation if there are ports that the pod exposes
-				// that are in the default opaque ports list.
+				// that are in the default opaque ports list, or ports inferred
+				// from its images.
 				if len(filteredPorts) != 0 {
 					ports := strings.Join(filteredPorts, ",")
 					resourceConfig.AppendPodAnnotation(pkgK8s.ProxyOpaquePortsAnnotation, ports)
@@ -10,6 +10,7 @@ import (
 	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/pkg/admin"
 	pkgk8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/tls"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -22,6 +23,8 @@ func Launch(
 	metricsAddr string,
 	addr string,
 	kubeconfig string,
+	cryptoMode tls.CryptoMode,
+	adminTLS *admin.TLSOptions,
 ) {
 	stop := make(chan os.Signal, 1)
 	defer close(stop)
@@ -32,7 +35,7 @@ func Launch(
 		log.Fatalf("failed to initialize Kubernetes API: %s", err)
 	}
 
-	s, err := NewServer(ctx, k8sAPI, addr, pkgk8s.MountPathTLSBase, handler, component)
+	s, err := NewServer(ctx, k8sAPI, addr, pkgk8s.MountPathTLSBase, handler, component, cryptoMode)
 	if err != nil {
 		log.Fatalf("failed to initialize the webhook server: %s", err)
 	}
@@ -42,10 +45,14 @@ func Launch(
 	k8sAPI.Sync(nil)
 
 	adminServer := admin.NewServer(metricsAddr)
+	adminTLS.CryptoMode = cryptoMode
+	if err := admin.EnableTLS(ctx, adminServer, adminTLS); err != nil {
+		log.Fatalf("failed to enable TLS on the admin server: %s", err)
+	}
 
 	go func() {
 		log.Infof("starting admin server on %s", metricsAddr)
-		adminServer.ListenAndServe()
+		admin.ListenAndServe(adminServer)
 	}()
 
 	<-stop
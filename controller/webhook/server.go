@@ -47,6 +47,7 @@ func NewServer(
 	addr, certPath string,
 	handler Handler,
 	component string,
+	cryptoMode pkgTls.CryptoMode,
 ) (*Server, error) {
 	updateEvent := make(chan struct{})
 	errEvent := make(chan error)
@@ -62,6 +63,7 @@ func NewServer(
 		Addr:      addr,
 		TLSConfig: &tls.Config{},
 	}
+	cryptoMode.ConfigureServer(server.TLSConfig)
 
 	eventBroadcaster := record.NewBroadcaster()
 	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{
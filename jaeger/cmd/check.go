@@ -38,6 +38,17 @@ type checkOptions struct {
 	namespace string
 }
 
+// NewHealthChecker returns a checker running the checks of the jaeger
+// extension, e.g. to report its health in the dashboard
+func NewHealthChecker(options *healthcheck.Options) (*healthcheck.HealthChecker, error) {
+	hc := healthcheck.NewHealthChecker([]healthcheck.CategoryID{}, options)
+	if err := hc.InitializeKubeAPIClient(); err != nil {
+		return nil, err
+	}
+	hc.AppendCategories(jaegerCategory(hc))
+	return hc, nil
+}
+
 func jaegerCategory(hc *healthcheck.HealthChecker) *healthcheck.Category {
 
 	checkers := []healthcheck.Checker{}
@@ -220,7 +231,7 @@ func configureAndRunChecks(wout io.Writer, werr io.Writer, options *checkOptions
 		return fmt.Errorf("Validation error when executing check command: %v", err)
 	}
 
-	hc := healthcheck.NewHealthChecker([]healthcheck.CategoryID{}, &healthcheck.Options{
+	hc, err := NewHealthChecker(&healthcheck.Options{
 		ControlPlaneNamespace: controlPlaneNamespace,
 		KubeConfig:            kubeconfigPath,
 		KubeContext:           kubeContext,
@@ -230,16 +241,12 @@ func configureAndRunChecks(wout io.Writer, werr io.Writer, options *checkOptions
 		RetryDeadline:         time.Now().Add(options.wait),
 		DataPlaneNamespace:    options.namespace,
 	})
-
-	err = hc.InitializeKubeAPIClient()
 	if err != nil {
 		err = fmt.Errorf("Error initializing k8s API client: %s", err)
 		fmt.Fprintln(werr, err)
 		os.Exit(1)
 	}
 
-	hc.AppendCategories(jaegerCategory(hc))
-
 	success, warning := healthcheck.RunChecks(wout, werr, hc, options.output)
 	healthcheck.PrintChecksResult(wout, options.output, success, warning)
 
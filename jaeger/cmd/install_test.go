@@ -38,6 +38,14 @@ func TestRender(t *testing.T) {
 			},
 			"install_collector_disabled.golden",
 		},
+		{
+			map[string]interface{}{
+				"webhook": map[string]interface{}{
+					"adminTLS": map[string]interface{}{"secretName": "jaeger-injector-admin-tls"},
+				},
+			},
+			"install_admin_tls.golden",
+		},
 	}
 
 	for i, tc := range testCases {
@@ -24,16 +24,20 @@ func main() {
 		"service account associated with the collector instance")
 	clusterDomain := cmd.String("cluster-domain", "cluster.local", "kubernetes cluster domain")
 	linkerdNamespace := cmd.String("linkerd-namespace", "linkerd", "namespace in which Linkerd control-plane is installed")
+	cryptoMode := flags.AddCryptoModeFlag(cmd)
+	adminTLS := flags.AddAdminTLSFlags(cmd)
 
 	flags.ConfigureAndParse(cmd, os.Args[1:])
 
 	webhook.Launch(
 		context.Background(),
-		[]k8s.APIResource{k8s.NS},
+		[]k8s.APIResource{k8s.NS, k8s.TC},
 		mutator.Mutate(*collectorSvcAddr, *collectorSvcAccount, *clusterDomain, *linkerdNamespace),
 		"linkerd-jaeger-injector",
 		*metricsAddr,
 		*addr,
 		*kubeconfig,
+		*cryptoMode,
+		adminTLS,
 	)
 }
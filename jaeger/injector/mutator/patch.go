@@ -1,58 +1,93 @@
 package mutator
 
-const tpl = `[
-  {
-    "op": "add",
-    "path": "/metadata/annotations/jaeger.linkerd.io~1tracing-enabled",
-    "value": "true"
-  },
-  {
-    "op": "add",
-    "path": "/spec/containers/{{.ProxyIndex}}/env/-",
-    "value": {
-      "name": "LINKERD2_PROXY_TRACE_ATTRIBUTES_PATH",
-      "value": "/var/run/linkerd/podinfo/labels"
-    }
-  },
-  {
-    "op": "add",
-    "path": "/spec/containers/{{.ProxyIndex}}/env/-",
-    "value": {
-      "name": "LINKERD2_PROXY_TRACE_COLLECTOR_SVC_ADDR",
-      "value": "{{.CollectorSvcAddr}}"
-    }
-  },
-  {
-    "op": "add",
-    "path": "/spec/containers/{{.ProxyIndex}}/env/-",
-    "value": {
-      "name": "LINKERD2_PROXY_TRACE_COLLECTOR_SVC_NAME",
-      "value": "{{.CollectorSvcAccount}}.serviceaccount.identity.{{.LinkerdNamespace}}.{{.ClusterDomain}}"
-    }
-  },
-  {
-    "op": "add",
-    "path": "/spec/containers/{{.ProxyIndex}}/volumeMounts/-",
-    "value": {
-      "mountPath": "var/run/linkerd/podinfo",
-      "name": "podinfo"
-    }
-  },
-  {
-    "op": "add",
-    "path": "/spec/volumes/-",
-    "value": {
-       "downwardAPI": {
-         "items": [
-	   {
-             "fieldRef": {
-               "fieldPath": "metadata.labels"
-	     },
-             "path": "labels"
-	   }
-	 ]
-       },
-       "name": "podinfo"
-     }
-  }
-]`
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// patchOperation is a JSON patch operation, as described by RFC 6902
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// buildPatch returns the JSON patch enabling tracing on the proxy container
+// of the pod. It's marshalled rather than templated, so that the values
+// coming from the TracingConfigurations and annotations are always escaped.
+func buildPatch(params Params) ([]byte, error) {
+	envPath := fmt.Sprintf("/spec/containers/%d/env/-", params.ProxyIndex)
+	patch := []patchOperation{
+		{
+			Op:    "add",
+			Path:  "/metadata/annotations/jaeger.linkerd.io~1tracing-enabled",
+			Value: "true",
+		},
+		{
+			Op:   "add",
+			Path: envPath,
+			Value: corev1.EnvVar{
+				Name:  "LINKERD2_PROXY_TRACE_ATTRIBUTES_PATH",
+				Value: "/var/run/linkerd/podinfo/labels",
+			},
+		},
+		{
+			Op:   "add",
+			Path: envPath,
+			Value: corev1.EnvVar{
+				Name:  "LINKERD2_PROXY_TRACE_COLLECTOR_SVC_ADDR",
+				Value: params.CollectorSvcAddr,
+			},
+		},
+		{
+			Op:   "add",
+			Path: envPath,
+			Value: corev1.EnvVar{
+				Name: "LINKERD2_PROXY_TRACE_COLLECTOR_SVC_NAME",
+				Value: fmt.Sprintf("%s.serviceaccount.identity.%s.%s",
+					params.CollectorSvcAccount, params.LinkerdNamespace, params.ClusterDomain),
+			},
+		},
+	}
+	if params.ServiceName != "" {
+		patch = append(patch, patchOperation{
+			Op:   "add",
+			Path: envPath,
+			Value: corev1.EnvVar{
+				Name:  "LINKERD2_PROXY_TRACE_SERVICE_NAME",
+				Value: params.ServiceName,
+			},
+		})
+	}
+	patch = append(patch,
+		patchOperation{
+			Op:   "add",
+			Path: fmt.Sprintf("/spec/containers/%d/volumeMounts/-", params.ProxyIndex),
+			Value: corev1.VolumeMount{
+				MountPath: "var/run/linkerd/podinfo",
+				Name:      "podinfo",
+			},
+		},
+		patchOperation{
+			Op:   "add",
+			Path: "/spec/volumes/-",
+			Value: corev1.Volume{
+				Name: "podinfo",
+				VolumeSource: corev1.VolumeSource{
+					DownwardAPI: &corev1.DownwardAPIVolumeSource{
+						Items: []corev1.DownwardAPIVolumeFile{
+							{
+								Path:     "labels",
+								FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels"},
+							},
+						},
+					},
+				},
+			},
+		},
+	)
+
+	return json.Marshal(patch)
+}
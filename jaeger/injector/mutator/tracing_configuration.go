@@ -0,0 +1,96 @@
+package mutator
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	tcv1alpha1 "github.com/linkerd/linkerd2/controller/gen/apis/tracingconfiguration/v1alpha1"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// serviceNameValues holds the values available to the service name template
+// of a TracingConfiguration
+type serviceNameValues struct {
+	Namespace string
+	Name      string
+	Labels    map[string]string
+}
+
+// getTracingConfiguration returns the TracingConfiguration that applies to
+// the namespace: the first one by name when there are several of them.
+func getTracingConfiguration(api *k8s.API, namespace string) (*tcv1alpha1.TracingConfiguration, error) {
+	configs, err := api.TC().Lister().TracingConfigurations(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	if len(configs) == 0 {
+		return nil, nil
+	}
+	sort.Slice(configs, func(i, j int) bool { return configs[i].Name < configs[j].Name })
+	return configs[0], nil
+}
+
+// applyTracingConfiguration overrides the install-time values with the ones
+// of the namespace's TracingConfiguration. Invalid values are ignored, so that
+// a faulty configuration doesn't prevent the pods from being created.
+func applyTracingConfiguration(config *tcv1alpha1.TracingConfiguration, pod *corev1.Pod, params *Params) {
+	if config == nil {
+		return
+	}
+	logger := log.WithFields(log.Fields{
+		"namespace":            config.Namespace,
+		"tracingconfiguration": config.Name,
+	})
+
+	if config.Spec.CollectorAddress != "" {
+		params.CollectorSvcAddr = config.Spec.CollectorAddress
+	}
+	if config.Spec.CollectorServiceAccount != "" {
+		params.CollectorSvcAccount = config.Spec.CollectorServiceAccount
+	}
+
+	if config.Spec.ServiceNameTemplate != "" {
+		serviceName, err := renderServiceName(config.Spec.ServiceNameTemplate, pod)
+		if err != nil {
+			logger.Warnf("ignoring service name template: %s", err)
+		} else {
+			params.ServiceName = serviceName
+		}
+	}
+}
+
+// renderServiceName renders the service name template with the pod's
+// namespace, name and labels. As the name of the pods of a workload is
+// generated after their admission, their generateName prefix is used instead.
+func renderServiceName(tpl string, pod *corev1.Pod) (string, error) {
+	t, err := template.New("serviceName").Option("missingkey=zero").Parse(tpl)
+	if err != nil {
+		return "", err
+	}
+
+	name := pod.Name
+	if name == "" {
+		name = strings.TrimSuffix(pod.GenerateName, "-")
+	}
+	values := serviceNameValues{
+		Namespace: pod.Namespace,
+		Name:      name,
+		Labels:    pod.Labels,
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, values); err != nil {
+		return "", err
+	}
+	serviceName := strings.TrimSpace(buf.String())
+	if serviceName == "" {
+		return "", fmt.Errorf("template %q rendered an empty service name", tpl)
+	}
+	return serviceName, nil
+}
@@ -0,0 +1,164 @@
+package mutator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/linkerd/linkerd2/controller/k8s"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const testPod = `{
+  "apiVersion": "v1",
+  "kind": "Pod",
+  "metadata": {
+    "generateName": "web-5f7d8b9c4-",
+    "namespace": "emojivoto",
+    "labels": {"app": "web"},
+    "annotations": {"linkerd.io/inject": "enabled"}
+  },
+  "spec": {
+    "containers": [
+      {"name": "web", "image": "web"},
+      {"name": "linkerd-proxy", "image": "proxy"}
+    ]
+  }
+}`
+
+func TestMutateWithTracingConfiguration(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		k8sConfigs []string
+		expected   map[string]string
+		unexpected []string
+	}{
+		{
+			name: "install-time values",
+			expected: map[string]string{
+				"LINKERD2_PROXY_TRACE_COLLECTOR_SVC_ADDR": "collector.linkerd-jaeger:55678",
+				"LINKERD2_PROXY_TRACE_COLLECTOR_SVC_NAME": "collector.linkerd-jaeger.serviceaccount.identity.linkerd.cluster.local",
+			},
+			unexpected: []string{"LINKERD2_PROXY_TRACE_SERVICE_NAME"},
+		},
+		{
+			name: "tracing configuration",
+			k8sConfigs: []string{`
+apiVersion: jaeger.linkerd.io/v1alpha1
+kind: TracingConfiguration
+metadata:
+  name: tracing
+  namespace: emojivoto
+spec:
+  collectorAddress: otel.tracing:4317
+  collectorServiceAccount: otel
+  serviceNameTemplate: "{{.Labels.app}}.{{.Namespace}}"`,
+			},
+			expected: map[string]string{
+				"LINKERD2_PROXY_TRACE_COLLECTOR_SVC_ADDR": "otel.tracing:4317",
+				"LINKERD2_PROXY_TRACE_COLLECTOR_SVC_NAME": "otel.tracing.serviceaccount.identity.linkerd.cluster.local",
+				"LINKERD2_PROXY_TRACE_SERVICE_NAME":       "web.emojivoto",
+			},
+		},
+		{
+			name: "values are escaped",
+			k8sConfigs: []string{`
+apiVersion: jaeger.linkerd.io/v1alpha1
+kind: TracingConfiguration
+metadata:
+  name: tracing
+  namespace: emojivoto
+spec:
+  serviceNameTemplate: '{{.Labels.app}}"<&>'`,
+			},
+			expected: map[string]string{
+				"LINKERD2_PROXY_TRACE_SERVICE_NAME": `web"<&>`,
+			},
+		},
+		{
+			name: "invalid values are ignored",
+			k8sConfigs: []string{`
+apiVersion: jaeger.linkerd.io/v1alpha1
+kind: TracingConfiguration
+metadata:
+  name: tracing
+  namespace: emojivoto
+spec:
+  serviceNameTemplate: "{{.Labels.missing}}"`,
+			},
+			expected: map[string]string{
+				"LINKERD2_PROXY_TRACE_COLLECTOR_SVC_ADDR": "collector.linkerd-jaeger:55678",
+			},
+			unexpected: []string{"LINKERD2_PROXY_TRACE_SERVICE_NAME"},
+		},
+		{
+			name: "tracing configuration of another namespace",
+			k8sConfigs: []string{`
+apiVersion: jaeger.linkerd.io/v1alpha1
+kind: TracingConfiguration
+metadata:
+  name: tracing
+  namespace: books
+spec:
+  collectorAddress: otel.tracing:4317`,
+			},
+			expected: map[string]string{
+				"LINKERD2_PROXY_TRACE_COLLECTOR_SVC_ADDR": "collector.linkerd-jaeger:55678",
+			},
+		},
+	} {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			configs := append([]string{`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: emojivoto`}, tc.k8sConfigs...)
+			k8sAPI, err := k8s.NewFakeAPI(configs...)
+			if err != nil {
+				t.Fatalf("NewFakeAPI returned an error: %s", err)
+			}
+			k8sAPI.Sync(nil)
+
+			mutate := Mutate("collector.linkerd-jaeger:55678", "collector", "cluster.local", "linkerd")
+			response, err := mutate(context.Background(), k8sAPI, &admissionv1beta1.AdmissionRequest{
+				Namespace: "emojivoto",
+				Object:    runtime.RawExtension{Raw: []byte(testPod)},
+			}, nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			var patch []struct {
+				Path  string          `json:"path"`
+				Value json.RawMessage `json:"value"`
+			}
+			if err := json.Unmarshal(response.Patch, &patch); err != nil {
+				t.Fatalf("Invalid patch: %s\n%s", err, response.Patch)
+			}
+			env := map[string]string{}
+			for _, op := range patch {
+				if op.Path != "/spec/containers/1/env/-" {
+					continue
+				}
+				var envVar struct{ Name, Value string }
+				if err := json.Unmarshal(op.Value, &envVar); err != nil {
+					t.Fatalf("Invalid env var: %s", err)
+				}
+				env[envVar.Name] = envVar.Value
+			}
+
+			for name, expected := range tc.expected {
+				if env[name] != expected {
+					t.Fatalf("Expected %s to be %q, got %q", name, expected, env[name])
+				}
+			}
+			for _, unexpected := range tc.unexpected {
+				if _, ok := env[unexpected]; ok {
+					t.Fatalf("Expected %s not to be set, got %q", unexpected, env[unexpected])
+				}
+			}
+		})
+	}
+}
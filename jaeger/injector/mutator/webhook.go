@@ -1,10 +1,8 @@
 package mutator
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"html/template"
 	"strings"
 
 	"github.com/linkerd/linkerd2/controller/k8s"
@@ -24,13 +22,14 @@ const (
 		"/trace-collector-service-account"
 )
 
-// Params holds the values used in the patch template
+// Params holds the values used to build the patch
 type Params struct {
 	ProxyIndex          int
 	CollectorSvcAddr    string
 	CollectorSvcAccount string
 	ClusterDomain       string
 	LinkerdNamespace    string
+	ServiceName         string
 }
 
 // Mutate returns an AdmissionResponse containing the patch, if any, to apply
@@ -72,20 +71,21 @@ func Mutate(collectorSvcAddr, collectorSvcAccount, clusterDomain, linkerdNamespa
 		if err != nil {
 			return nil, err
 		}
+		config, err := getTracingConfiguration(api, request.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		applyTracingConfiguration(config, pod, &params)
 		applyOverrides(namespace, pod, &params)
 		amendSvcAccount(pod.Namespace, &params)
 
-		t, err := template.New("tpl").Parse(tpl)
+		patchJSON, err := buildPatch(params)
 		if err != nil {
 			return nil, err
 		}
-		var patchJSON bytes.Buffer
-		if err = t.Execute(&patchJSON, params); err != nil {
-			return nil, err
-		}
 
 		patchType := admissionv1beta1.PatchTypeJSONPatch
-		admissionResponse.Patch = patchJSON.Bytes()
+		admissionResponse.Patch = patchJSON
 		admissionResponse.PatchType = &patchType
 
 		return admissionResponse, nil
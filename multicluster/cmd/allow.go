@@ -23,6 +23,7 @@ type (
 	allowOptions struct {
 		namespace          string
 		serviceAccountName string
+		namespaceScope     []string
 		ignoreCluster      bool
 	}
 )
@@ -79,6 +80,7 @@ func newAllowCommand() *cobra.Command {
 	cmd.Flags().StringVar(&opts.namespace, "namespace", defaultMulticlusterNamespace, "The destination namespace for the service account.")
 	cmd.Flags().BoolVar(&opts.ignoreCluster, "ignore-cluster", false, "Ignore cluster configuration")
 	cmd.Flags().StringVar(&opts.serviceAccountName, "service-account-name", "", "The name of the multicluster access service account")
+	cmd.Flags().StringSliceVar(&opts.namespaceScope, "namespace-scope", nil, "Limit the access of the service account to these namespaces (comma separated list)")
 
 	pkgcmd.ConfigureNamespaceFlagCompletion(
 		cmd, []string{"namespace"},
@@ -112,6 +114,7 @@ func buildMulticlusterAllowValues(ctx context.Context, opts *allowOptions) (*mcc
 	defaults.ServiceMirror = false
 	defaults.RemoteMirrorServiceAccount = true
 	defaults.RemoteMirrorServiceAccountName = opts.serviceAccountName
+	defaults.RemoteMirrorServiceAccountNamespaces = opts.namespaceScope
 
 	if !opts.ignoreCluster {
 		acc, err := kubeAPI.CoreV1().ServiceAccounts(opts.namespace).Get(ctx, defaults.RemoteMirrorServiceAccountName, metav1.GetOptions{})
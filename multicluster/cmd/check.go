@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"context"
+	cryptotls "crypto/tls"
 	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
+	"net"
+	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -74,6 +78,21 @@ func newHealthChecker(linkerdHC *healthcheck.HealthChecker) *healthChecker {
 	}
 }
 
+// NewHealthChecker returns a checker running the checks of the multicluster
+// extension, e.g. to report its health in the dashboard
+func NewHealthChecker(ctx context.Context, options *healthcheck.Options) (*healthcheck.HealthChecker, error) {
+	linkerdHC := healthcheck.NewHealthChecker([]healthcheck.CategoryID{linkerdMulticlusterExtensionCheck}, options)
+	if err := linkerdHC.InitializeKubeAPIClient(); err != nil {
+		return nil, err
+	}
+	if err := linkerdHC.InitializeLinkerdGlobalConfig(ctx); err != nil {
+		return nil, err
+	}
+	hc := newHealthChecker(linkerdHC)
+	hc.AppendCategories(multiclusterCategory(hc))
+	return hc.HealthChecker, nil
+}
+
 // NewCmdCheck generates a new cobra command for the multicluster extension.
 func NewCmdCheck() *cobra.Command {
 	options := newCheckOptions()
@@ -210,6 +229,17 @@ func multiclusterCategory(hc *healthChecker) *healthcheck.Category {
 			WithCheck(func(ctx context.Context) error {
 				return hc.checkIfGatewayMirrorsHaveEndpoints(ctx)
 			}))
+	checkers = append(checkers,
+		*healthcheck.NewChecker("probes through the gateways succeed").
+			WithHintAnchor("l5d-multicluster-gateways-probe").
+			Warning().
+			WithCheck(func(ctx context.Context) error {
+				localAnchors, err := tls.DecodePEMCertificates(hc.LinkerdConfig().IdentityTrustAnchorsPEM)
+				if err != nil {
+					return fmt.Errorf("Cannot parse source trust anchors: %s", err)
+				}
+				return hc.checkGatewayProbes(ctx, tls.CertificatesToPool(localAnchors))
+			}))
 	checkers = append(checkers,
 		*healthcheck.NewChecker("all mirror services have endpoints").
 			WithHintAnchor("l5d-multicluster-services-endpoints").
@@ -363,10 +393,16 @@ func (hc *healthChecker) checkRemoteClusterConnectivity(ctx context.Context) err
 			errors = append(errors, fmt.Errorf("* failed to connect to API for cluster: [%s]: %s", link.TargetClusterName, err))
 			continue
 		}
+		namespaces := link.Namespaces
+		if len(namespaces) == 0 {
+			namespaces = []string{corev1.NamespaceAll}
+		}
 		verbs := []string{"get", "list", "watch"}
-		for _, verb := range verbs {
-			if err := healthcheck.CheckCanPerformAction(ctx, remoteAPI, verb, corev1.NamespaceAll, "", "v1", "services"); err != nil {
-				errors = append(errors, fmt.Errorf("* missing service permission [%s] for cluster [%s]: %s", verb, link.TargetClusterName, err))
+		for _, ns := range namespaces {
+			for _, verb := range verbs {
+				if err := healthcheck.CheckCanPerformAction(ctx, remoteAPI, verb, ns, "", "v1", "services"); err != nil {
+					errors = append(errors, fmt.Errorf("* missing service permission [%s] for cluster [%s]: %s", verb, link.TargetClusterName, err))
+				}
 			}
 		}
 		links = append(links, fmt.Sprintf("\t* %s", link.TargetClusterName))
@@ -613,6 +649,112 @@ func (hc *healthChecker) checkIfGatewayMirrorsHaveEndpoints(ctx context.Context)
 	return &healthcheck.VerboseSuccess{Message: strings.Join(links, "\n")}
 }
 
+// checkGatewayProbes sends a probe request to the gateway of each link, and
+// performs a TLS handshake with it, validating its identity against the local
+// trust anchors, which the clusters are expected to share
+func (hc *healthChecker) checkGatewayProbes(ctx context.Context, roots *x509.CertPool) error {
+	links := []string{}
+	errs := []error{}
+	for _, link := range hc.links {
+		result, err := probeGateway(ctx, link, roots)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("* %s: %s", link.TargetClusterName, err))
+			continue
+		}
+		links = append(links, fmt.Sprintf("\t* %s: %s", link.TargetClusterName, result))
+	}
+	if len(errs) > 0 {
+		return joinErrors(errs, 2)
+	}
+	if len(links) == 0 {
+		return &healthcheck.SkipError{Reason: "no links"}
+	}
+	return &healthcheck.VerboseSuccess{Message: strings.Join(links, "\n")}
+}
+
+// gatewayProbe is the outcome of probing the gateway of a link
+type gatewayProbe struct {
+	address string
+	rtt     time.Duration
+	// handshake is the duration of the TLS handshake with the gateway; it's
+	// only set when the link has a gateway identity
+	handshake time.Duration
+}
+
+func (p gatewayProbe) String() string {
+	s := fmt.Sprintf("gateway %s answered the probe in %dms", p.address, p.rtt.Milliseconds())
+	if p.handshake > 0 {
+		s += fmt.Sprintf(", TLS handshake succeeded in %dms", p.handshake.Milliseconds())
+	}
+	return s
+}
+
+// probeGateway sends a probe request to the first reachable address of the
+// gateway of a link, and performs a TLS handshake with its gateway port
+func probeGateway(ctx context.Context, link multicluster.Link, roots *x509.CertPool) (gatewayProbe, error) {
+	timeout := link.ProbeSpec.Timeout
+	if timeout == 0 {
+		timeout = multicluster.DefaultProbeTimeout
+	}
+
+	var err error
+	for _, address := range strings.Split(link.GatewayAddress, ",") {
+		probe := gatewayProbe{address: address}
+		probe.rtt, err = probeGatewayAddress(ctx, address, link.ProbeSpec, timeout)
+		if err != nil {
+			continue
+		}
+		if link.GatewayIdentity != "" {
+			probe.handshake, err = gatewayHandshake(ctx, address, link.GatewayPort, link.GatewayIdentity, roots, timeout)
+			if err != nil {
+				return gatewayProbe{}, err
+			}
+		}
+		return probe, nil
+	}
+	return gatewayProbe{}, err
+}
+
+func probeGatewayAddress(ctx context.Context, address string, spec multicluster.ProbeSpec, timeout time.Duration) (time.Duration, error) {
+	url := fmt.Sprintf("http://%s%s", net.JoinHostPort(address, strconv.FormatUint(uint64(spec.Port), 10)), spec.Path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	client := http.Client{Timeout: timeout}
+	start := time.Now()
+	rsp, err := client.Do(req)
+	rtt := time.Since(start)
+	if err != nil {
+		return 0, fmt.Errorf("probe request to %s failed: %s", address, err)
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("probe request to %s returned unexpected status %d", address, rsp.StatusCode)
+	}
+	return rtt, nil
+}
+
+func gatewayHandshake(ctx context.Context, address string, port uint32, identity string, roots *x509.CertPool, timeout time.Duration) (time.Duration, error) {
+	dialer := &cryptotls.Dialer{
+		NetDialer: &net.Dialer{Timeout: timeout},
+		Config: &cryptotls.Config{
+			ServerName: identity,
+			RootCAs:    roots,
+			MinVersion: cryptotls.VersionTLS12,
+		},
+	}
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(address, strconv.FormatUint(uint64(port), 10)))
+	handshake := time.Since(start)
+	if err != nil {
+		return 0, fmt.Errorf("TLS handshake with %s as %s failed: %s", address, identity, err)
+	}
+	conn.Close()
+	return handshake, nil
+}
+
 func (hc *healthChecker) checkIfMirrorServicesHaveEndpoints(ctx context.Context) error {
 	var servicesWithNoEndpoints []string
 	selector := fmt.Sprintf("%s, !%s", k8s.MirroredResourceLabel, k8s.MirroredGatewayLabel)
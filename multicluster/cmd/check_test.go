@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/multicluster"
+)
+
+func splitHostPort(t *testing.T, addr string) (string, uint32) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := strconv.ParseUint(port, 10, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return host, uint32(p)
+}
+
+func TestProbeGateway(t *testing.T) {
+	probe := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ready" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer probe.Close()
+	gateway := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer gateway.Close()
+
+	probeHost, probePort := splitHostPort(t, probe.Listener.Addr().String())
+	_, gatewayPort := splitHostPort(t, gateway.Listener.Addr().String())
+	roots := x509.NewCertPool()
+	roots.AddCert(gateway.Certificate())
+
+	link := func(address, path, identity string) multicluster.Link {
+		return multicluster.Link{
+			TargetClusterName: "remote",
+			GatewayAddress:    address,
+			GatewayPort:       gatewayPort,
+			GatewayIdentity:   identity,
+			ProbeSpec: multicluster.ProbeSpec{
+				Path:    path,
+				Port:    probePort,
+				Timeout: time.Second,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		link     multicluster.Link
+		expected string
+		err      string
+	}{
+		{
+			name:     "probe and handshake succeed",
+			link:     link(probeHost, "/ready", "example.com"),
+			expected: "gateway 127.0.0.1 answered the probe in",
+		},
+		{
+			name:     "falls back to the next gateway address",
+			link:     link("192.0.2.1.invalid,"+probeHost, "/ready", ""),
+			expected: "gateway 127.0.0.1 answered the probe in",
+		},
+		{
+			name: "probe fails",
+			link: link(probeHost, "/notready", ""),
+			err:  "probe request to 127.0.0.1 returned unexpected status 404",
+		},
+		{
+			name: "gateway identity mismatch",
+			link: link(probeHost, "/ready", "linkerd-gateway.linkerd-multicluster.serviceaccount.identity.linkerd.cluster.local"),
+			err:  "TLS handshake with 127.0.0.1 as linkerd-gateway.linkerd-multicluster.serviceaccount.identity.linkerd.cluster.local failed",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := probeGateway(context.Background(), tc.link, roots)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("Expected error containing %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !strings.HasPrefix(result.String(), tc.expected) {
+				t.Fatalf("Expected result starting with %q, got %q", tc.expected, result)
+			}
+			if tc.link.GatewayIdentity != "" && !strings.Contains(result.String(), "TLS handshake succeeded") {
+				t.Fatalf("Expected a TLS handshake, got %q", result)
+			}
+		})
+	}
+}
@@ -5,14 +5,19 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/linkerd/linkerd2/cli/table"
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	mc "github.com/linkerd/linkerd2/pkg/multicluster"
 	vizCmd "github.com/linkerd/linkerd2/viz/cmd"
 	"github.com/linkerd/linkerd2/viz/metrics-api/client"
 	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/duration"
 )
 
 type (
@@ -30,7 +35,12 @@ func newGatewaysCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "gateways",
 		Short: "Display stats information about the gateways in target clusters",
-		Args:  cobra.NoArgs,
+		Long: `Display stats information about the gateways in target clusters.
+
+The PROBE column shows the period, timeout and failure threshold of the health
+probes of each gateway, as configured in its Link. The LAST_TRANSITION column
+shows how long ago the gateway was last marked as alive or not alive.`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			req := &pb.GatewaysRequest{
 				RemoteClusterName: opts.clusterName,
@@ -61,7 +71,18 @@ func newGatewaysCommand() *cobra.Command {
 				os.Exit(1)
 			}
 
-			renderGateways(resp.GetOk().GatewaysTable.Rows, stdout)
+			// the probe configuration and status are read from the links, which
+			// are only reported when they can be listed
+			links := make(map[string]mc.Link)
+			linkList, err := mc.GetLinks(ctx, k8sAPI.DynamicClient)
+			if err != nil {
+				log.Debugf("Failed to list the links: %s", err)
+			}
+			for _, link := range linkList {
+				links[link.TargetClusterName] = link
+			}
+
+			renderGateways(resp.GetOk().GatewaysTable.Rows, links, time.Now(), stdout)
 			return nil
 		},
 	}
@@ -84,12 +105,13 @@ func requestGatewaysFromAPI(client pb.ApiClient, req *pb.GatewaysRequest) (*pb.G
 	return resp, nil
 }
 
-func renderGateways(rows []*pb.GatewaysTable_Row, w io.Writer) {
+func renderGateways(rows []*pb.GatewaysTable_Row, links map[string]mc.Link, now time.Time, w io.Writer) {
 	t := buildGatewaysTable()
 	t.Data = []table.Row{}
 	for _, row := range rows {
 		row := row // Copy to satisfy golint.
-		t.Data = append(t.Data, gatewaysRowToTableRow(row))
+		link, found := links[row.ClusterName]
+		t.Data = append(t.Data, gatewaysRowToTableRow(row, link, found, now))
 	}
 	t.Render(w)
 }
@@ -101,6 +123,8 @@ var (
 	latencyP50Header     = "LATENCY_P50"
 	latencyP95Header     = "LATENCY_P95"
 	latencyP99Header     = "LATENCY_P99"
+	probeHeader          = "PROBE"
+	lastTransitionHeader = "LAST_TRANSITION"
 )
 
 func buildGatewaysTable() table.Table {
@@ -133,13 +157,22 @@ func buildGatewaysTable() table.Table {
 			Header: latencyP99Header,
 			Width:  11,
 		},
+		table.Column{
+			Header:   probeHeader,
+			Width:    5,
+			Flexible: true,
+		},
+		table.Column{
+			Header: lastTransitionHeader,
+			Width:  15,
+		},
 	}
 	t := table.NewTable(columns, []table.Row{})
 	t.Sort = []int{0, 1} // Sort by namespace, then name.
 	return t
 }
 
-func gatewaysRowToTableRow(row *pb.GatewaysTable_Row) []string {
+func gatewaysRowToTableRow(row *pb.GatewaysTable_Row, link mc.Link, linkFound bool, now time.Time) []string {
 	valueOrPlaceholder := func(value string) string {
 		if row.Alive {
 			return value
@@ -152,6 +185,16 @@ func gatewaysRowToTableRow(row *pb.GatewaysTable_Row) []string {
 	if row.Alive {
 		alive = "True"
 	}
+	probe := "-"
+	lastTransition := "-"
+	if linkFound {
+		spec := link.ProbeSpec
+		probe = fmt.Sprintf("%s/%s/%d", spec.Period, spec.Timeout, spec.FailureThreshold)
+		if condition := meta.FindStatusCondition(link.Conditions, mc.GatewayAliveCondition); condition != nil {
+			lastTransition = duration.HumanDuration(now.Sub(condition.LastTransitionTime.Time))
+		}
+	}
+
 	return []string{
 		row.ClusterName,
 		alive,
@@ -159,8 +202,9 @@ func gatewaysRowToTableRow(row *pb.GatewaysTable_Row) []string {
 		valueOrPlaceholder(fmt.Sprintf("%dms", row.LatencyMsP50)),
 		valueOrPlaceholder(fmt.Sprintf("%dms", row.LatencyMsP95)),
 		valueOrPlaceholder(fmt.Sprintf("%dms", row.LatencyMsP99)),
+		probe,
+		lastTransition,
 	}
-
 }
 
 func extractGatewayPort(gateway *corev1.Service) (uint32, error) {
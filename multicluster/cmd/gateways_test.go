@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	mc "github.com/linkerd/linkerd2/pkg/multicluster"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRenderGateways(t *testing.T) {
+	now := time.Date(2021, 12, 1, 12, 0, 0, 0, time.UTC)
+	rows := []*pb.GatewaysTable_Row{
+		{
+			ClusterName:    "east",
+			Alive:          true,
+			PairedServices: 3,
+			LatencyMsP50:   1,
+			LatencyMsP95:   2,
+			LatencyMsP99:   3,
+		},
+		{
+			ClusterName:    "west",
+			Alive:          false,
+			PairedServices: 1,
+		},
+		{
+			ClusterName: "north",
+			Alive:       true,
+		},
+	}
+	links := map[string]mc.Link{
+		"east": {
+			TargetClusterName: "east",
+			ProbeSpec: mc.ProbeSpec{
+				Period:           3 * time.Second,
+				Timeout:          30 * time.Second,
+				FailureThreshold: 3,
+			},
+			Conditions: []metav1.Condition{
+				{
+					Type:               mc.GatewayAliveCondition,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(now.Add(-2 * time.Hour)),
+				},
+			},
+		},
+		"west": {
+			TargetClusterName: "west",
+			ProbeSpec: mc.ProbeSpec{
+				Period:           3 * time.Second,
+				Timeout:          mc.DefaultProbeTimeout,
+				FailureThreshold: mc.DefaultProbeFailureThreshold,
+			},
+			Conditions: []metav1.Condition{
+				{
+					Type:               mc.GatewayAliveCondition,
+					Status:             metav1.ConditionFalse,
+					LastTransitionTime: metav1.NewTime(now.Add(-90 * time.Second)),
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	renderGateways(rows, links, now, &buf)
+	testDataDiffer.DiffTestdata(t, "gateways_output.golden", buf.String())
+}
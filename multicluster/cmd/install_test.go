@@ -41,6 +41,20 @@ func TestRender(t *testing.T) {
 			nil,
 			"install_ha.golden",
 		},
+		{
+			map[string]interface{}{
+				"remoteMirrorServiceAccountNamespaces": []string{"ns1", "ns2"},
+			},
+			nil,
+			"install_namespace_scope.golden",
+		},
+		{
+			map[string]interface{}{
+				"remoteVizNamespace": "linkerd-viz",
+			},
+			nil,
+			"install_remote_viz.golden",
+		},
 	}
 
 	for i, tc := range testCases {
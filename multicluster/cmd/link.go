@@ -7,6 +7,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/linkerd/linkerd2/multicluster/static"
 	multicluster "github.com/linkerd/linkerd2/multicluster/values"
@@ -26,6 +27,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 	"sigs.k8s.io/yaml"
@@ -46,6 +48,12 @@ type (
 		selector                string
 		gatewayAddresses        string
 		gatewayPort             uint32
+		probeTimeout            time.Duration
+		probeFailureThreshold   uint32
+		namespaceScope          []string
+		mirrorPolicies          bool
+		conflictPolicy          string
+		targetClusterDomain     string
 	}
 )
 
@@ -65,6 +73,11 @@ func newLinkCommand() *cobra.Command {
 		Example: `  # To link the west cluster to east
   linkerd --context=east multicluster link --cluster-name east | kubectl --context=west apply -f -
 
+  # To only mirror services from the emojivoto namespace of east, with a
+  # service account only allowed to access that namespace
+  linkerd --context=east multicluster allow --service-account-name emojivoto-access --namespace-scope emojivoto | kubectl --context=east apply -f -
+  linkerd --context=east multicluster link --cluster-name east --service-account-name emojivoto-access --namespace-scope emojivoto | kubectl --context=west apply -f -
+
 The command can be configured by using the --set, --values, --set-string and --set-file flags.
 A full list of configurable values can be found at https://github.com/linkerd/linkerd2/blob/main/multicluster/charts/linkerd-multicluster-link/README.md
   `,
@@ -206,6 +219,11 @@ A full list of configurable values can be found at https://github.com/linkerd/li
 			if err != nil {
 				return err
 			}
+			if opts.probeFailureThreshold == 0 {
+				return errors.New("--probe-failure-threshold must be at least 1")
+			}
+			probeSpec.Timeout = opts.probeTimeout
+			probeSpec.FailureThreshold = opts.probeFailureThreshold
 
 			gatewayPort, err := extractGatewayPort(gateway)
 			if err != nil {
@@ -222,11 +240,34 @@ A full list of configurable values can be found at https://github.com/linkerd/li
 				return err
 			}
 
+			for _, ns := range opts.namespaceScope {
+				if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+					return fmt.Errorf("invalid namespace %q in --namespace-scope: %s", ns, strings.Join(errs, ", "))
+				}
+			}
+
+			if !mc.IsConflictPolicy(opts.conflictPolicy) {
+				return fmt.Errorf("invalid --conflict-policy %q: must be one of %s", opts.conflictPolicy, strings.Join(mc.ConflictPolicies, ", "))
+			}
+
+			// the cluster domain reported by the target cluster's config is
+			// used unless overridden, e.g. for clusters installed without it
+			targetClusterDomain := opts.targetClusterDomain
+			if targetClusterDomain == "" {
+				targetClusterDomain = configMap.ClusterDomain
+			}
+			if targetClusterDomain == "" {
+				targetClusterDomain = mc.DefaultClusterDomain
+			}
+			if errs := validation.IsDNS1123Subdomain(targetClusterDomain); len(errs) > 0 {
+				return fmt.Errorf("invalid target cluster domain %q: %s", targetClusterDomain, strings.Join(errs, ", "))
+			}
+
 			link := mc.Link{
 				Name:                          opts.clusterName,
 				Namespace:                     opts.namespace,
 				TargetClusterName:             opts.clusterName,
-				TargetClusterDomain:           configMap.ClusterDomain,
+				TargetClusterDomain:           targetClusterDomain,
 				TargetClusterLinkerdNamespace: controlPlaneNamespace,
 				ClusterCredentialsSecret:      fmt.Sprintf("cluster-credentials-%s", opts.clusterName),
 				GatewayAddress:                gatewayAddresses,
@@ -234,6 +275,9 @@ A full list of configurable values can be found at https://github.com/linkerd/li
 				GatewayIdentity:               gatewayIdentity,
 				ProbeSpec:                     probeSpec,
 				Selector:                      *selector,
+				Namespaces:                    opts.namespaceScope,
+				MirrorPolicies:                opts.mirrorPolicies,
+				ConflictPolicy:                opts.conflictPolicy,
 			}
 
 			obj, err := link.ToUnstructured()
@@ -353,6 +397,12 @@ A full list of configurable values can be found at https://github.com/linkerd/li
 	cmd.Flags().StringVarP(&opts.selector, "selector", "l", opts.selector, "Selector (label query) to filter which services in the target cluster to mirror")
 	cmd.Flags().StringVar(&opts.gatewayAddresses, "gateway-addresses", opts.gatewayAddresses, "If specified, overwrites gateway addresses when gateway service is not type LoadBalancer (comma separated list)")
 	cmd.Flags().Uint32Var(&opts.gatewayPort, "gateway-port", opts.gatewayPort, "If specified, overwrites gateway port when gateway service is not type LoadBalancer")
+	cmd.Flags().DurationVar(&opts.probeTimeout, "probe-timeout", opts.probeTimeout, "The time after which a gateway health probe is failed")
+	cmd.Flags().Uint32Var(&opts.probeFailureThreshold, "probe-failure-threshold", opts.probeFailureThreshold, "The number of consecutive failed probes after which the gateway is considered down")
+	cmd.Flags().StringSliceVar(&opts.namespaceScope, "namespace-scope", opts.namespaceScope, "Only mirror services from these namespaces of the target cluster (comma separated list). The service account must have access to these namespaces, see 'linkerd multicluster allow --namespace-scope'")
+	cmd.Flags().BoolVar(&opts.mirrorPolicies, "mirror-policies", opts.mirrorPolicies, "Also mirror the Servers and ServerAuthorizations selecting the pods of the exported services")
+	cmd.Flags().StringVar(&opts.conflictPolicy, "conflict-policy", opts.conflictPolicy, fmt.Sprintf("How to handle the exported services whose mirror name is taken by a local service, one of %s", strings.Join(mc.ConflictPolicies, ", ")))
+	cmd.Flags().StringVar(&opts.targetClusterDomain, "target-cluster-domain", opts.targetClusterDomain, "The cluster domain of the target cluster; defaults to the one of its Linkerd installation")
 
 	pkgcmd.ConfigureNamespaceFlagCompletion(
 		cmd, []string{"namespace", "gateway-namespace"},
@@ -375,6 +425,9 @@ func newLinkOptionsWithDefault() (*linkOptions, error) {
 		selector:                k8s.DefaultExportedServiceSelector,
 		gatewayAddresses:        "",
 		gatewayPort:             0,
+		probeTimeout:            mc.DefaultProbeTimeout,
+		probeFailureThreshold:   mc.DefaultProbeFailureThreshold,
+		conflictPolicy:          mc.ConflictPolicySkip,
 	}, nil
 }
 
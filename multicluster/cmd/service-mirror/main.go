@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/linkerd/linkerd2/pkg/admin"
 	"github.com/linkerd/linkerd2/pkg/flags"
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/leader"
 	"github.com/linkerd/linkerd2/pkg/multicluster"
 	sm "github.com/linkerd/linkerd2/pkg/servicemirror"
 	log "github.com/sirupsen/logrus"
@@ -40,6 +42,10 @@ func Main(args []string) {
 	namespace := cmd.String("namespace", "", "namespace containing Link and credentials Secret")
 	repairPeriod := cmd.Duration("endpoint-refresh-period", 1*time.Minute, "frequency to refresh endpoint resolution")
 	enableHeadlessSvc := cmd.Bool("enable-headless-services", false, "toggle support for headless service mirroring")
+	clusterDomain := cmd.String("cluster-domain", "cluster.local", "kubernetes cluster domain of the local cluster, used to name the mirrored ServiceProfiles")
+	enableLeaderElection := cmd.Bool("enable-leader-election", false, "Elect a leader among the replicas, the only one mirroring the services of the target cluster")
+	leaderElection := leader.AddFlags(cmd)
+	adminTLS := flags.AddAdminTLSFlags(cmd)
 
 	flags.ConfigureAndParse(cmd, args)
 	linkName := cmd.Arg(0)
@@ -52,7 +58,8 @@ func Main(args []string) {
 	// resources.
 	//
 	// controllerK8sAPI is used by the cluster watcher to manage
-	// mirror resources such as services, namespaces, and endpoints.
+	// mirror resources such as services, namespaces, endpoints,
+	// ServiceProfiles and policies.
 	k8sAPI, err := k8s.NewAPI(*kubeConfigPath, "", "", []string{}, 0)
 	//TODO: Use can-i to check for required permissions
 	if err != nil {
@@ -67,6 +74,9 @@ func Main(args []string) {
 		controllerK8s.NS,
 		controllerK8s.Svc,
 		controllerK8s.Endpoint,
+		controllerK8s.SP,
+		controllerK8s.Srv,
+		controllerK8s.Saz,
 	)
 	if err != nil {
 		log.Fatalf("Failed to initialize K8s API: %s", err)
@@ -77,76 +87,170 @@ func Main(args []string) {
 	metrics := servicemirror.NewProbeMetricVecs()
 
 	adminServer := admin.NewServer(*metricsAddr)
+	if err := admin.EnableTLS(ctx, adminServer, adminTLS); err != nil {
+		log.Fatalf("Failed to enable TLS on the admin server: %s", err)
+	}
 
 	go func() {
 		log.Infof("starting admin server on %s", *metricsAddr)
-		adminServer.ListenAndServe()
+		admin.ListenAndServe(adminServer)
 	}()
 
 	controllerK8sAPI.Sync(nil)
 
-main:
-	for {
-		// Start link watch
-		linkWatch, err := linkClient.Watch(ctx, metav1.ListOptions{})
-		if err != nil {
-			log.Fatalf("Failed to watch Link %s: %s", linkName, err)
+	// the probe worker reports the health of the gateway in the status of
+	// the link
+	updateProbeStatus := func(alive bool, message string) {
+		condition := metav1.Condition{
+			Type:    multicluster.GatewayAliveCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  "ProbeSucceeded",
+			Message: message,
+		}
+		if !alive {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "ProbeFailed"
+		}
+		if err := multicluster.SetLinkCondition(ctx, linkClient, linkName, condition); err != nil {
+			log.Errorf("Failed to update the status of link %s: %s", linkName, err)
+		}
+	}
+
+	// the cluster watcher reports the exported services whose mirror name is
+	// taken by a local service in the status of the link
+	updateConflicts := func(conflicts []string) {
+		condition := metav1.Condition{
+			Type:    multicluster.MirrorConflictsCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "NoConflicts",
+			Message: "No mirror name conflicts with local services",
+		}
+		if len(conflicts) > 0 {
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = "NameConflicts"
+			condition.Message = fmt.Sprintf("Mirror names conflicting with local services: %s", strings.Join(conflicts, ", "))
 		}
-		results := linkWatch.ResultChan()
+		if err := multicluster.SetLinkCondition(ctx, linkClient, linkName, condition); err != nil {
+			log.Errorf("Failed to update the status of link %s: %s", linkName, err)
+		}
+	}
 
-		// Each time the link resource is updated, reload the config and restart the
-		// cluster watcher.
+	// watchLink mirrors the services of the target cluster according to the
+	// link until ctx is cancelled. When leader election is enabled, it's only
+	// run by the leader, so that the mirror resources have a single writer.
+	watchLink := func(ctx context.Context) {
+		// generation of the last link the watchers were started for. It's only
+		// bumped by changes to the spec of the link, not its status.
+		var linkGeneration int64
+
+	main:
 		for {
-			select {
-			case <-stop:
+			// Start link watch
+			linkWatch, err := linkClient.Watch(ctx, metav1.ListOptions{})
+			if err != nil && ctx.Err() != nil {
 				break main
-			case event, ok := <-results:
-				if !ok {
-					log.Info("Link watch terminated; restarting watch")
-					continue main
-				}
-				switch obj := event.Object.(type) {
-				case *dynamic.Unstructured:
-					if obj.GetName() == linkName {
-						switch event.Type {
-						case watch.Added, watch.Modified:
-							link, err := multicluster.NewLink(*obj)
-							if err != nil {
-								log.Errorf("Failed to parse link %s: %s", linkName, err)
-								continue
-							}
-							log.Infof("Got updated link %s: %+v", linkName, link)
-							creds, err := loadCredentials(ctx, link, *namespace, k8sAPI)
-							if err != nil {
-								log.Errorf("Failed to load remote cluster credentials: %s", err)
-							}
-							err = restartClusterWatcher(ctx, link, *namespace, creds, controllerK8sAPI, *requeueLimit, *repairPeriod, metrics, *enableHeadlessSvc)
-							if err != nil {
-								// failed to restart cluster watcher; give a bit of slack
-								// and restart the link watch to give it another try
-								log.Error(err)
-								time.Sleep(linkWatchRestartAfter)
-								linkWatch.Stop()
-							}
-						case watch.Deleted:
-							log.Infof("Link %s deleted", linkName)
-							if clusterWatcher != nil {
-								clusterWatcher.Stop(false)
-								clusterWatcher = nil
-							}
-							if probeWorker != nil {
-								probeWorker.Stop()
-								probeWorker = nil
+			}
+			if err != nil {
+				log.Fatalf("Failed to watch Link %s: %s", linkName, err)
+			}
+			results := linkWatch.ResultChan()
+
+			// Each time the link resource is updated, reload the config and restart the
+			// cluster watcher.
+			for {
+				select {
+				case <-ctx.Done():
+					break main
+				case event, ok := <-results:
+					if !ok {
+						log.Info("Link watch terminated; restarting watch")
+						continue main
+					}
+					switch obj := event.Object.(type) {
+					case *dynamic.Unstructured:
+						if obj.GetName() == linkName {
+							switch event.Type {
+							case watch.Added, watch.Modified:
+								if event.Type == watch.Modified && obj.GetGeneration() == linkGeneration {
+									log.Debugf("Ignoring status update of link %s", linkName)
+									continue
+								}
+								linkGeneration = obj.GetGeneration()
+								link, err := multicluster.NewLink(*obj)
+								if err != nil {
+									log.Errorf("Failed to parse link %s: %s", linkName, err)
+									continue
+								}
+								log.Infof("Got updated link %s: %+v", linkName, link)
+								creds, err := loadCredentials(ctx, link, *namespace, k8sAPI)
+								if err != nil {
+									log.Errorf("Failed to load remote cluster credentials: %s", err)
+								}
+								err = restartClusterWatcher(ctx, link, *namespace, creds, controllerK8sAPI, *clusterDomain, *requeueLimit, *repairPeriod, metrics, *enableHeadlessSvc, updateProbeStatus, updateConflicts)
+								if err != nil {
+									// failed to restart cluster watcher; give a bit of slack
+									// and restart the link watch to give it another try
+									log.Error(err)
+									time.Sleep(linkWatchRestartAfter)
+									linkWatch.Stop()
+								}
+							case watch.Deleted:
+								log.Infof("Link %s deleted", linkName)
+								linkGeneration = 0
+								if clusterWatcher != nil {
+									clusterWatcher.Stop(false)
+									clusterWatcher = nil
+								}
+								if probeWorker != nil {
+									probeWorker.Stop()
+									probeWorker = nil
+								}
+							default:
+								log.Infof("Ignoring event type %s", event.Type)
 							}
-						default:
-							log.Infof("Ignoring event type %s", event.Type)
 						}
+					default:
+						log.Errorf("Unknown object type detected: %+v", obj)
 					}
-				default:
-					log.Errorf("Unknown object type detected: %+v", obj)
 				}
 			}
 		}
+		if clusterWatcher != nil {
+			clusterWatcher.Stop(false)
+			clusterWatcher = nil
+		}
+		if probeWorker != nil {
+			probeWorker.Stop()
+			probeWorker = nil
+		}
+	}
+
+	linkCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		<-stop
+		cancel()
+	}()
+
+	if *enableLeaderElection {
+		// the pod name identifies this replica among the others
+		podName, err := os.Hostname()
+		if err != nil {
+			log.Fatalf("Failed to get hostname: %s", err)
+		}
+		elector, err := leader.NewElector(
+			k8sAPI.Interface,
+			*namespace,
+			fmt.Sprintf("linkerd-service-mirror-%s", linkName),
+			podName,
+			*leaderElection,
+			watchLink,
+		)
+		if err != nil {
+			log.Fatalf("Failed to initialize leader election: %s", err)
+		}
+		elector.Run(linkCtx)
+	} else {
+		watchLink(linkCtx)
 	}
 	log.Info("Shutting down")
 }
@@ -166,10 +270,13 @@ func restartClusterWatcher(
 	namespace string,
 	creds []byte,
 	controllerK8sAPI *controllerK8s.API,
+	clusterDomain string,
 	requeueLimit int,
 	repairPeriod time.Duration,
 	metrics servicemirror.ProbeMetricVecs,
 	enableHeadlessSvc bool,
+	updateProbeStatus func(alive bool, message string),
+	updateConflicts func(conflicts []string),
 ) error {
 	if clusterWatcher != nil {
 		clusterWatcher.Stop(false)
@@ -187,11 +294,13 @@ func restartClusterWatcher(
 		ctx,
 		namespace,
 		controllerK8sAPI,
+		clusterDomain,
 		cfg,
 		&link,
 		requeueLimit,
 		repairPeriod,
 		enableHeadlessSvc,
+		updateConflicts,
 	)
 	if err != nil {
 		return fmt.Errorf("Unable to create cluster watcher: %s", err)
@@ -206,7 +315,7 @@ func restartClusterWatcher(
 	if err != nil {
 		return fmt.Errorf("Failed to create metrics for cluster watcher: %s", err)
 	}
-	probeWorker = servicemirror.NewProbeWorker(fmt.Sprintf("probe-gateway-%s", link.TargetClusterName), &link.ProbeSpec, workerMetrics, link.TargetClusterName)
+	probeWorker = servicemirror.NewProbeWorker(fmt.Sprintf("probe-gateway-%s", link.TargetClusterName), &link.ProbeSpec, workerMetrics, link.TargetClusterName, updateProbeStatus)
 	probeWorker.Start()
 	return nil
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -25,7 +27,16 @@ import (
 	"k8s.io/client-go/util/workqueue"
 )
 
-const eventTypeSkipped = "ServiceMirroringSkipped"
+const (
+	eventTypeSkipped        = "ServiceMirroringSkipped"
+	eventTypeSyncFailed     = "SyncFailed"
+	eventTypeMirrorConflict = "MirrorConflict"
+
+	// conflictSuffix is appended to the name of the mirror services whose
+	// default name is taken by a local service, under the suffix conflict
+	// policy
+	conflictSuffix = "mirror"
+)
 
 type (
 	// RemoteClusterServiceWatcher is a watcher instantiated for every cluster that is being watched
@@ -36,17 +47,33 @@ type (
 	// it can be requeued up to N times, to ensure that the failure is not due to some temporary network
 	// problems or general glitch in the Matrix.
 	RemoteClusterServiceWatcher struct {
-		serviceMirrorNamespace  string
-		link                    *multicluster.Link
-		remoteAPIClient         *k8s.API
+		serviceMirrorNamespace string
+		link                   *multicluster.Link
+		// remoteAPIs watches the target cluster. It holds a single
+		// cluster-wide API keyed by metav1.NamespaceAll, or an API per
+		// namespace when the link is scoped to some namespaces.
+		remoteAPIs map[string]*k8s.API
+		// mirrorProfiles is false when the link's credentials don't grant
+		// access to the ServiceProfiles of the target cluster, as with the
+		// links created by earlier versions, in which case they're not
+		// mirrored
+		mirrorProfiles          bool
 		localAPIClient          *k8s.API
+		localClusterDomain      string
 		stopper                 chan struct{}
 		recorder                record.EventRecorder
+		linkRecorder            record.EventRecorder
 		log                     *logging.Entry
 		eventsQueue             workqueue.RateLimitingInterface
 		requeueLimit            int
 		repairPeriod            time.Duration
 		headlessServicesEnabled bool
+		// conflicts maps the exported services whose mirror name is taken by
+		// a local service to how the conflict was resolved. It's only
+		// accessed by the events processing loop.
+		conflicts map[string]string
+		// updateConflicts reports the conflicts in the status of the link
+		updateConflicts func(conflicts []string)
 	}
 
 	// RemoteServiceCreated is generated whenever a remote service is created Observing
@@ -141,16 +168,26 @@ func NewRemoteClusterServiceWatcher(
 	ctx context.Context,
 	serviceMirrorNamespace string,
 	localAPI *k8s.API,
+	localClusterDomain string,
 	cfg *rest.Config,
 	link *multicluster.Link,
 	requeueLimit int,
 	repairPeriod time.Duration,
 	enableHeadlessSvc bool,
+	updateConflicts func(conflicts []string),
 ) (*RemoteClusterServiceWatcher, error) {
-	remoteAPI, err := k8s.InitializeAPIForConfig(ctx, cfg, false, k8s.Svc, k8s.Endpoint)
+	clusterName := link.TargetClusterName
+	cfg = rest.CopyConfig(cfg)
+	cfg.Wrap(instrumentRemoteAPI(clusterName))
+	remoteAPIs, mirrorProfiles, err := initializeRemoteAPIs(ctx, cfg, link.Namespaces, link.MirrorPolicies)
 	if err != nil {
 		return nil, fmt.Errorf("cannot initialize api for target cluster %s: %s", clusterName, err)
 	}
+	var remoteAPI *k8s.API
+	for _, api := range remoteAPIs {
+		remoteAPI = api
+		break
+	}
 	_, err = remoteAPI.Client.Discovery().ServerVersion()
 	if err != nil {
 		return nil, fmt.Errorf("cannot connect to api for target cluster %s: %s", clusterName, err)
@@ -165,14 +202,26 @@ func NewRemoteClusterServiceWatcher(
 		Component: fmt.Sprintf("linkerd-service-mirror-%s", clusterName),
 	})
 
+	// The events about the link itself are recorded in the local cluster
+	linkEventBroadcaster := record.NewBroadcaster()
+	linkEventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{
+		Interface: localAPI.Client.CoreV1().Events(""),
+	})
+	linkRecorder := linkEventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{
+		Component: fmt.Sprintf("linkerd-service-mirror-%s", clusterName),
+	})
+
 	stopper := make(chan struct{})
 	return &RemoteClusterServiceWatcher{
 		serviceMirrorNamespace: serviceMirrorNamespace,
 		link:                   link,
-		remoteAPIClient:        remoteAPI,
+		remoteAPIs:             remoteAPIs,
+		mirrorProfiles:         mirrorProfiles,
 		localAPIClient:         localAPI,
+		localClusterDomain:     localClusterDomain,
 		stopper:                stopper,
 		recorder:               recorder,
+		linkRecorder:           linkRecorder,
 		log: logging.WithFields(logging.Fields{
 			"cluster":    clusterName,
 			"apiAddress": cfg.Host,
@@ -181,21 +230,190 @@ func NewRemoteClusterServiceWatcher(
 		requeueLimit:            requeueLimit,
 		repairPeriod:            repairPeriod,
 		headlessServicesEnabled: enableHeadlessSvc,
+		conflicts:               make(map[string]string),
+		updateConflicts:         updateConflicts,
 	}, nil
 }
 
+// initializeRemoteAPIs creates the APIs watching the target cluster. When the
+// link is scoped to some namespaces, its credentials are only expected to
+// grant access to those, so each of them is watched separately. The Servers
+// and ServerAuthorizations are only watched when the link mirrors policies.
+// The ServiceProfiles are only watched when the credentials grant access to
+// them, which is reported by the returned bool.
+func initializeRemoteAPIs(ctx context.Context, cfg *rest.Config, namespaces []string, mirrorPolicies bool) (map[string]*k8s.API, bool, error) {
+	mirrorProfiles, err := remoteProfilesAccess(ctx, cfg, namespaces)
+	if err != nil {
+		return nil, false, err
+	}
+
+	resources := []k8s.APIResource{k8s.Svc, k8s.Endpoint}
+	if mirrorProfiles {
+		resources = append(resources, k8s.SP)
+	}
+	if mirrorPolicies {
+		resources = append(resources, k8s.Srv, k8s.Saz)
+	}
+
+	if len(namespaces) == 0 {
+		api, err := k8s.InitializeAPIForConfig(ctx, cfg, false, resources...)
+		if err != nil {
+			return nil, false, err
+		}
+		return map[string]*k8s.API{metav1.NamespaceAll: api}, mirrorProfiles, nil
+	}
+
+	apis := make(map[string]*k8s.API, len(namespaces))
+	for _, ns := range namespaces {
+		api, err := k8s.InitializeNamespacedAPIForConfig(ctx, cfg, ns, resources...)
+		if err != nil {
+			return nil, false, err
+		}
+		apis[ns] = api
+	}
+	return apis, mirrorProfiles, nil
+}
+
+// remoteProfilesAccess returns true if the credentials grant access to the
+// ServiceProfiles of the target cluster, in all the namespaces of the link.
+// The remote RBAC of the links created before ServiceProfiles were mirrored
+// doesn't, and these links must keep working until they're re-created.
+func remoteProfilesAccess(ctx context.Context, cfg *rest.Config, namespaces []string) (bool, error) {
+	client, err := consts.NewAPIForConfig(cfg, "", []string{}, 0)
+	if err != nil {
+		return false, err
+	}
+
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+	for _, ns := range namespaces {
+		if err := consts.ServiceProfilesAccess(ctx, client, ns); err != nil {
+			logging.Warnf("Not mirroring the ServiceProfiles of the target cluster: %s; re-create the link to grant access to them", err)
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// remoteAPI returns the API watching the namespace of the target cluster. The
+// namespaces out of the scope of the link are reported as not found, so that
+// their mirror services get cleaned up.
+func (rcsw *RemoteClusterServiceWatcher) remoteAPI(namespace string) (*k8s.API, error) {
+	if api, ok := rcsw.remoteAPIs[metav1.NamespaceAll]; ok {
+		return api, nil
+	}
+	if api, ok := rcsw.remoteAPIs[namespace]; ok {
+		return api, nil
+	}
+	return nil, kerrors.NewNotFound(corev1.Resource("namespaces"), namespace)
+}
+
+func (rcsw *RemoteClusterServiceWatcher) remoteService(namespace, name string) (*corev1.Service, error) {
+	api, err := rcsw.remoteAPI(namespace)
+	if err != nil {
+		return nil, err
+	}
+	return api.Svc().Lister().Services(namespace).Get(name)
+}
+
+func (rcsw *RemoteClusterServiceWatcher) remoteEndpoints(namespace, name string) (*corev1.Endpoints, error) {
+	api, err := rcsw.remoteAPI(namespace)
+	if err != nil {
+		return nil, err
+	}
+	return api.Endpoint().Lister().Endpoints(namespace).Get(name)
+}
+
 func (rcsw *RemoteClusterServiceWatcher) mirroredResourceName(remoteName string) string {
 	return fmt.Sprintf("%s-%s", remoteName, rcsw.link.TargetClusterName)
 }
 
+// suffixedResourceName is the name of the mirror of a remote service whose
+// mirroredResourceName is taken by a local service
+func (rcsw *RemoteClusterServiceWatcher) suffixedResourceName(remoteName string) string {
+	return fmt.Sprintf("%s-%s", rcsw.mirroredResourceName(remoteName), conflictSuffix)
+}
+
 func (rcsw *RemoteClusterServiceWatcher) targetResourceName(mirrorName string) string {
-	return strings.TrimSuffix(mirrorName, "-"+rcsw.link.TargetClusterName)
+	return rcsw.originalResourceName(mirrorName)
 }
 
 func (rcsw *RemoteClusterServiceWatcher) originalResourceName(mirroredName string) string {
+	mirroredName = strings.TrimSuffix(mirroredName, fmt.Sprintf("-%s-%s", rcsw.link.TargetClusterName, conflictSuffix))
 	return strings.TrimSuffix(mirroredName, fmt.Sprintf("-%s", rcsw.link.TargetClusterName))
 }
 
+// isMirrorService returns whether a local service is a mirror of a service of
+// the target cluster
+func (rcsw *RemoteClusterServiceWatcher) isMirrorService(svc *corev1.Service) bool {
+	return svc.Labels[consts.MirroredResourceLabel] == "true" &&
+		svc.Labels[consts.RemoteClusterNameLabel] == rcsw.link.TargetClusterName
+}
+
+// mirrorServiceName returns the name of the local mirror of a remote service.
+// When a local service that isn't a mirror of the target cluster already has
+// that name, the conflict policy of the link decides whether the remote
+// service is mirrored under a suffixed name, adopts the local service, or is
+// skipped. The returned conflict describes how the conflict was resolved, and
+// is empty when there isn't any; ok is false when the service isn't mirrored.
+func (rcsw *RemoteClusterServiceWatcher) mirrorServiceName(namespace, remoteName string) (name string, conflict string, ok bool) {
+	name = rcsw.mirroredResourceName(remoteName)
+	local, err := rcsw.localAPIClient.Svc().Lister().Services(namespace).Get(name)
+	if err != nil || rcsw.isMirrorService(local) {
+		return name, "", true
+	}
+
+	switch rcsw.link.ConflictPolicy {
+	case multicluster.ConflictPolicySuffix:
+		suffixed := rcsw.suffixedResourceName(remoteName)
+		local, err := rcsw.localAPIClient.Svc().Lister().Services(namespace).Get(suffixed)
+		if err == nil && !rcsw.isMirrorService(local) {
+			return name, fmt.Sprintf("skipped, %s/%s is taken as well", namespace, suffixed), false
+		}
+		return suffixed, fmt.Sprintf("mirrored as %s/%s", namespace, suffixed), true
+	case multicluster.ConflictPolicyAdoptIfLabeled:
+		if local.Labels[consts.AdoptByClusterLabel] == rcsw.link.TargetClusterName {
+			return name, "", true
+		}
+		return name, fmt.Sprintf("skipped, %s/%s isn't labeled %s=%s", namespace, name, consts.AdoptByClusterLabel, rcsw.link.TargetClusterName), false
+	default:
+		return name, fmt.Sprintf("skipped, %s/%s already exists", namespace, name), false
+	}
+}
+
+// recordConflict keeps track of how the conflict over the mirror name of a
+// remote service was resolved, an empty conflict meaning there's none, and
+// reports the changes in the status of the link
+func (rcsw *RemoteClusterServiceWatcher) recordConflict(namespace, remoteName, conflict string) {
+	key := fmt.Sprintf("%s/%s", namespace, remoteName)
+	if rcsw.conflicts[key] == conflict {
+		return
+	}
+	if conflict == "" {
+		delete(rcsw.conflicts, key)
+	} else {
+		rcsw.conflicts[key] = conflict
+		rcsw.log.Warnf("Mirror name of service %s conflicts with a local service: %s", key, conflict)
+		if rcsw.linkRecorder != nil {
+			rcsw.linkRecorder.Eventf(rcsw.linkReference(), v1.EventTypeWarning, eventTypeMirrorConflict, "Mirror name of service %s conflicts with a local service: %s", key, conflict)
+		}
+	}
+	rcsw.reportConflicts()
+}
+
+func (rcsw *RemoteClusterServiceWatcher) reportConflicts() {
+	if rcsw.updateConflicts == nil {
+		return
+	}
+	conflicts := make([]string, 0, len(rcsw.conflicts))
+	for key, conflict := range rcsw.conflicts {
+		conflicts = append(conflicts, fmt.Sprintf("%s (%s)", key, conflict))
+	}
+	sort.Strings(conflicts)
+	rcsw.updateConflicts(conflicts)
+}
+
 // Provides labels for mirrored service.
 // "remoteService" is an optional parameter. If provided, copies all labels
 // from the remote service to mirrored service (except labels with the
@@ -222,15 +440,21 @@ func (rcsw *RemoteClusterServiceWatcher) getMirroredServiceLabels(remoteService
 
 // Provides annotations for mirrored service
 func (rcsw *RemoteClusterServiceWatcher) getMirroredServiceAnnotations(remoteService *corev1.Service) map[string]string {
-	annotations := map[string]string{
-		consts.RemoteResourceVersionAnnotation: remoteService.ResourceVersion, // needed to detect real changes
-		consts.RemoteServiceFqName:             fmt.Sprintf("%s.%s.svc.%s", remoteService.Name, remoteService.Namespace, rcsw.link.TargetClusterDomain),
-	}
-
+	annotations := map[string]string{}
 	for key, value := range remoteService.ObjectMeta.Annotations {
 		annotations[key] = value
 	}
 
+	// When the remote service is itself a mirror, its own mirroring
+	// annotations are overridden, but its origin is kept so that the cluster
+	// at the origin of the chain recognizes its own service
+	annotations[consts.RemoteResourceVersionAnnotation] = remoteService.ResourceVersion // needed to detect real changes
+	annotations[consts.RemoteServiceFqName] = serviceFqdn(remoteService.Name, remoteService.Namespace, rcsw.link.TargetClusterDomain)
+	if _, ok := annotations[consts.OriginServiceUID]; !ok {
+		annotations[consts.OriginServiceUID] = string(remoteService.UID)
+		annotations[consts.OriginServiceFqName] = annotations[consts.RemoteServiceFqName]
+	}
+
 	value, ok := remoteService.GetAnnotations()[consts.ProxyOpaquePortsAnnotation]
 	if ok {
 		annotations[consts.ProxyOpaquePortsAnnotation] = value
@@ -302,7 +526,7 @@ func (rcsw *RemoteClusterServiceWatcher) cleanupOrphanedServices(ctx context.Con
 
 	var errors []error
 	for _, srv := range servicesOnLocalCluster {
-		_, err := rcsw.remoteAPIClient.Svc().Lister().Services(srv.Namespace).Get(rcsw.originalResourceName(srv.Name))
+		_, err := rcsw.remoteService(srv.Namespace, rcsw.originalResourceName(srv.Name))
 		if err != nil {
 			if kerrors.IsNotFound(err) {
 				// service does not exist anymore. Need to delete
@@ -311,6 +535,7 @@ func (rcsw *RemoteClusterServiceWatcher) cleanupOrphanedServices(ctx context.Con
 					errors = append(errors, err)
 				} else {
 					rcsw.log.Infof("Deleted service %s/%s while cleaning up mirror services", srv.Namespace, srv.Name)
+					rcsw.countMirrorServices(operationDeleted)
 				}
 			} else {
 				// something went wrong getting the service, we can retry
@@ -322,12 +547,12 @@ func (rcsw *RemoteClusterServiceWatcher) cleanupOrphanedServices(ctx context.Con
 		return RetryableError{errors}
 	}
 
-	return nil
+	return rcsw.cleanupOrphanedMirroredResources()
 }
 
 // Whenever we stop watching a cluster, we need to cleanup everything that we have
 // created. This piece of code is responsible for doing just that. It takes care of
-// services, endpoints and namespaces (if needed)
+// services, endpoints, namespaces (if needed), ServiceProfiles and policies
 func (rcsw *RemoteClusterServiceWatcher) cleanupMirroredResources(ctx context.Context) error {
 	matchLabels := rcsw.getMirroredServiceLabels(nil)
 
@@ -350,6 +575,7 @@ func (rcsw *RemoteClusterServiceWatcher) cleanupMirroredResources(ctx context.Co
 			errors = append(errors, fmt.Errorf("Could not delete service %s/%s: %s", svc.Namespace, svc.Name, err))
 		} else {
 			rcsw.log.Infof("Deleted service %s/%s", svc.Namespace, svc.Name)
+			rcsw.countMirrorServices(operationDeleted)
 		}
 	}
 
@@ -373,6 +599,8 @@ func (rcsw *RemoteClusterServiceWatcher) cleanupMirroredResources(ctx context.Co
 		}
 	}
 
+	errors = append(errors, rcsw.cleanupMirroredProfilesAndPolicies(ctx)...)
+
 	if len(errors) > 0 {
 		return RetryableError{errors}
 	}
@@ -381,16 +609,32 @@ func (rcsw *RemoteClusterServiceWatcher) cleanupMirroredResources(ctx context.Co
 
 // Deletes a locally mirrored service as it is not present on the remote cluster anymore
 func (rcsw *RemoteClusterServiceWatcher) handleRemoteServiceDeleted(ctx context.Context, ev *RemoteServiceDeleted) error {
-	localServiceName := rcsw.mirroredResourceName(ev.Name)
-	localService, err := rcsw.localAPIClient.Svc().Lister().Services(ev.Namespace).Get(localServiceName)
-	var errors []error
-	if err != nil {
-		if kerrors.IsNotFound(err) {
-			rcsw.log.Debugf("Failed to delete mirror service %s/%s: %v", ev.Namespace, ev.Name, err)
-			return nil
+	rcsw.recordConflict(ev.Namespace, ev.Name, "")
+
+	// the mirror may have either name, depending on the local services when
+	// it was created; the local services that aren't mirrors of the target
+	// cluster are never deleted
+	var localService *corev1.Service
+	for _, name := range []string{rcsw.mirroredResourceName(ev.Name), rcsw.suffixedResourceName(ev.Name)} {
+		svc, err := rcsw.localAPIClient.Svc().Lister().Services(ev.Namespace).Get(name)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return RetryableError{[]error{fmt.Errorf("could not fetch service %s/%s: %s", ev.Namespace, name, err)}}
+		}
+		if rcsw.isMirrorService(svc) {
+			localService = svc
+			break
 		}
-		errors = append(errors, fmt.Errorf("could not fetch service %s/%s: %s", ev.Namespace, localServiceName, err))
 	}
+	if localService == nil {
+		rcsw.log.Debugf("Failed to delete mirror service of %s/%s: not found", ev.Namespace, ev.Name)
+		return nil
+	}
+	localServiceName := localService.Name
+
+	var errors []error
 
 	// If the mirror service is headless, also delete its endpoint mirror
 	// services.
@@ -427,6 +671,7 @@ func (rcsw *RemoteClusterServiceWatcher) handleRemoteServiceDeleted(ctx context.
 	}
 
 	rcsw.log.Infof("Successfully deleted service: %s/%s", ev.Namespace, localServiceName)
+	rcsw.countMirrorServices(operationDeleted)
 	return nil
 }
 
@@ -463,6 +708,7 @@ func (rcsw *RemoteClusterServiceWatcher) handleRemoteServiceUpdated(ctx context.
 	if _, err := rcsw.localAPIClient.Client.CoreV1().Services(ev.localService.Namespace).Update(ctx, ev.localService, metav1.UpdateOptions{}); err != nil {
 		return RetryableError{[]error{err}}
 	}
+	rcsw.countMirrorServices(operationUpdated)
 	return nil
 }
 
@@ -488,7 +734,11 @@ func (rcsw *RemoteClusterServiceWatcher) handleRemoteServiceCreated(ctx context.
 	}
 
 	serviceInfo := fmt.Sprintf("%s/%s", remoteService.Namespace, remoteService.Name)
-	localServiceName := rcsw.mirroredResourceName(remoteService.Name)
+	localServiceName, conflict, ok := rcsw.mirrorServiceName(remoteService.Namespace, remoteService.Name)
+	rcsw.recordConflict(remoteService.Namespace, remoteService.Name, conflict)
+	if !ok {
+		return nil
+	}
 
 	if err := rcsw.mirrorNamespaceIfNecessary(ctx, remoteService.Namespace); err != nil {
 		return err
@@ -512,9 +762,41 @@ func (rcsw *RemoteClusterServiceWatcher) handleRemoteServiceCreated(ctx context.
 			// we might have created it during earlier attempt, if that is not the case, we retry
 			return RetryableError{[]error{err}}
 		}
+		if err := rcsw.adoptService(ctx, serviceToCreate); err != nil {
+			return err
+		}
+	} else {
+		rcsw.countMirrorServices(operationMirrored)
 	}
 
-	return rcsw.createGatewayEndpoints(ctx, remoteService)
+	return rcsw.createGatewayEndpoints(ctx, remoteService, localServiceName)
+}
+
+// adoptService turns the existing local service having the name of a mirror
+// service into that mirror, when it's labeled to be adopted by the target
+// cluster. The mirrors created during earlier attempts are left as they are.
+func (rcsw *RemoteClusterServiceWatcher) adoptService(ctx context.Context, mirror *corev1.Service) error {
+	local, err := rcsw.localAPIClient.Client.CoreV1().Services(mirror.Namespace).Get(ctx, mirror.Name, metav1.GetOptions{})
+	if err != nil {
+		return RetryableError{[]error{err}}
+	}
+	if rcsw.isMirrorService(local) || local.Labels[consts.AdoptByClusterLabel] != rcsw.link.TargetClusterName {
+		return nil
+	}
+
+	rcsw.log.Infof("Adopting service %s/%s as a mirror service", local.Namespace, local.Name)
+	local.Labels = mirror.Labels
+	local.Labels[consts.AdoptByClusterLabel] = rcsw.link.TargetClusterName
+	local.Annotations = mirror.Annotations
+	local.Spec.Ports = mirror.Spec.Ports
+	// the endpoints of the mirror services point to the gateway, they aren't
+	// managed by the endpoints controller
+	local.Spec.Selector = nil
+	if _, err := rcsw.localAPIClient.Client.CoreV1().Services(local.Namespace).Update(ctx, local, metav1.UpdateOptions{}); err != nil {
+		return RetryableError{[]error{err}}
+	}
+	rcsw.countMirrorServices(operationMirrored)
+	return nil
 }
 
 // isEmptyService returns true if any of these conditions are true:
@@ -523,7 +805,7 @@ func (rcsw *RemoteClusterServiceWatcher) handleRemoteServiceCreated(ctx context.
 // - svc's Endpoint has Subsets, but none have addresses (only notReadyAddresses,
 // when the pod is not ready yet)
 func (rcsw *RemoteClusterServiceWatcher) isEmptyService(svc *corev1.Service) (bool, error) {
-	ep, err := rcsw.remoteAPIClient.Endpoint().Lister().Endpoints(svc.Namespace).Get(svc.Name)
+	ep, err := rcsw.remoteEndpoints(svc.Namespace, svc.Name)
 	if err != nil {
 		if kerrors.IsNotFound(err) {
 			rcsw.log.Debugf("target endpoint %s/%s not found", svc.Namespace, svc.Name)
@@ -554,7 +836,7 @@ func (rcsw *RemoteClusterServiceWatcher) isEmptyEndpoints(ep *corev1.Endpoints)
 	return true
 }
 
-func (rcsw *RemoteClusterServiceWatcher) createGatewayEndpoints(ctx context.Context, exportedService *corev1.Service) error {
+func (rcsw *RemoteClusterServiceWatcher) createGatewayEndpoints(ctx context.Context, exportedService *corev1.Service, localServiceName string) error {
 	empty, err := rcsw.isEmptyService(exportedService)
 	if err != nil {
 		return RetryableError{[]error{err}}
@@ -565,7 +847,6 @@ func (rcsw *RemoteClusterServiceWatcher) createGatewayEndpoints(ctx context.Cont
 		return err
 	}
 
-	localServiceName := rcsw.mirroredResourceName(exportedService.Name)
 	serviceInfo := fmt.Sprintf("%s/%s", exportedService.Namespace, exportedService.Name)
 	endpointsToCreate := &corev1.Endpoints{
 		ObjectMeta: metav1.ObjectMeta{
@@ -600,8 +881,19 @@ func (rcsw *RemoteClusterServiceWatcher) createGatewayEndpoints(ctx context.Cont
 
 	rcsw.log.Infof("Creating a new endpoints for %s", serviceInfo)
 	if _, err := rcsw.localAPIClient.Client.CoreV1().Endpoints(exportedService.Namespace).Create(ctx, endpointsToCreate, metav1.CreateOptions{}); err != nil {
-		// we clean up after ourselves
-		rcsw.localAPIClient.Client.CoreV1().Services(exportedService.Namespace).Delete(ctx, localServiceName, metav1.DeleteOptions{})
+		if kerrors.IsAlreadyExists(err) {
+			// the endpoints of an adopted service, or the ones created during
+			// an earlier attempt, are updated instead
+			if err := rcsw.createOrUpdateEndpoints(ctx, endpointsToCreate); err != nil {
+				return RetryableError{[]error{err}}
+			}
+			return nil
+		}
+		// we clean up after ourselves, unless the service was adopted
+		svc, getErr := rcsw.localAPIClient.Client.CoreV1().Services(exportedService.Namespace).Get(ctx, localServiceName, metav1.GetOptions{})
+		if getErr == nil && rcsw.isMirrorService(svc) && svc.Labels[consts.AdoptByClusterLabel] == "" {
+			rcsw.localAPIClient.Client.CoreV1().Services(exportedService.Namespace).Delete(ctx, localServiceName, metav1.DeleteOptions{})
+		}
 		// and retry
 		return RetryableError{[]error{err}}
 	}
@@ -621,9 +913,13 @@ func (rcsw *RemoteClusterServiceWatcher) isExportedService(service *corev1.Servi
 // offline for some time due to a crash a CREATE for a service that we have
 // observed before is simply a case of UPDATE
 func (rcsw *RemoteClusterServiceWatcher) createOrUpdateService(service *corev1.Service) error {
-	localName := rcsw.mirroredResourceName(service.Name)
+	localName, conflict, ok := rcsw.mirrorServiceName(service.Namespace, service.Name)
 
 	if rcsw.isExportedService(service) {
+		rcsw.recordConflict(service.Namespace, service.Name, conflict)
+		if !ok {
+			return nil
+		}
 		localService, err := rcsw.localAPIClient.Svc().Lister().Services(service.Namespace).Get(localName)
 		if err != nil {
 			if kerrors.IsNotFound(err) {
@@ -634,6 +930,13 @@ func (rcsw *RemoteClusterServiceWatcher) createOrUpdateService(service *corev1.S
 			}
 			return RetryableError{[]error{err}}
 		}
+		// a local service to adopt is handled as a new mirror
+		if !rcsw.isMirrorService(localService) {
+			rcsw.eventsQueue.Add(&RemoteServiceCreated{
+				service: service,
+			})
+			return nil
+		}
 		// if we have the local service present, we need to issue an update
 		lastMirroredRemoteVersion, ok := localService.Annotations[consts.RemoteResourceVersionAnnotation]
 		if ok && lastMirroredRemoteVersion != service.ResourceVersion {
@@ -651,6 +954,7 @@ func (rcsw *RemoteClusterServiceWatcher) createOrUpdateService(service *corev1.S
 
 		return nil
 	}
+	rcsw.recordConflict(service.Namespace, service.Name, "")
 	localSvc, err := rcsw.localAPIClient.Svc().Lister().Services(service.Namespace).Get(localName)
 	if err == nil {
 		if localSvc.Labels != nil {
@@ -725,6 +1029,10 @@ func (rcsw *RemoteClusterServiceWatcher) processNextEvent(ctx context.Context) (
 		err = rcsw.cleanupOrphanedServices(ctx)
 	case *RepairEndpoints:
 		err = rcsw.repairEndpoints(ctx)
+	case *SyncServiceProfile:
+		err = rcsw.handleSyncServiceProfile(ctx, ev)
+	case *SyncPolicies:
+		err = rcsw.handleSyncPolicies(ctx, ev)
 	default:
 		if ev != nil || !done { // we get a nil in case we are shutting down...
 			rcsw.log.Warnf("Received unknown event: %v", ev)
@@ -735,6 +1043,50 @@ func (rcsw *RemoteClusterServiceWatcher) processNextEvent(ctx context.Context) (
 
 }
 
+func (rcsw *RemoteClusterServiceWatcher) countMirrorServices(operation string) {
+	mirrorServicesCounter.With(prometheus.Labels{
+		gatewayClusterName: rcsw.link.TargetClusterName,
+		operationLabelName: operation,
+	}).Inc()
+}
+
+// recordSyncError counts the failures to process an event. Once the event is
+// given up on, the failure is also reported as an event of the link, as the
+// mirror services are out of sync until the next update.
+func (rcsw *RemoteClusterServiceWatcher) recordSyncError(event interface{}, err error, givingUp bool) {
+	syncErrorsCounter.With(prometheus.Labels{
+		gatewayClusterName: rcsw.link.TargetClusterName,
+		eventTypeLabelName: eventTypeName(event),
+	}).Inc()
+
+	if givingUp && rcsw.linkRecorder != nil {
+		rcsw.linkRecorder.Eventf(rcsw.linkReference(), v1.EventTypeWarning, eventTypeSyncFailed, "Failed to process %s: %s", eventTypeName(event), err)
+	}
+}
+
+// linkReference refers to the link of the watcher in the events recorded
+// about it
+func (rcsw *RemoteClusterServiceWatcher) linkReference() *v1.ObjectReference {
+	return &v1.ObjectReference{
+		APIVersion: consts.LinkAPIGroupVersion,
+		Kind:       consts.LinkKind,
+		Namespace:  rcsw.link.Namespace,
+		Name:       rcsw.link.Name,
+		UID:        rcsw.link.UID,
+	}
+}
+
+func eventTypeName(event interface{}) string {
+	t := reflect.TypeOf(event)
+	if t == nil {
+		return "unknown"
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
 // the main processing loop in which we handle more domain specific events
 // and deal with retries
 func (rcsw *RemoteClusterServiceWatcher) processEvents(ctx context.Context) {
@@ -754,15 +1106,18 @@ func (rcsw *RemoteClusterServiceWatcher) processEvents(ctx context.Context) {
 					rcsw.log.Warnf("Requeues: %d, Limit: %d for event %s", rcsw.eventsQueue.NumRequeues(event), rcsw.requeueLimit, event)
 					if (rcsw.eventsQueue.NumRequeues(event) < rcsw.requeueLimit) && !done {
 						rcsw.log.Errorf("Error processing %s (will retry): %s", event, e)
+						rcsw.recordSyncError(event, e, false)
 						rcsw.eventsQueue.AddRateLimited(event)
 					} else {
 						rcsw.log.Errorf("Error processing %s (giving up): %s", event, e)
+						rcsw.recordSyncError(event, e, true)
 						rcsw.eventsQueue.Forget(event)
 					}
 				}
 			default:
 				rcsw.log.Errorf("Error processing %s (will not retry): %s", event, e)
 				rcsw.log.Error(e)
+				rcsw.recordSyncError(event, e, true)
 			}
 		}
 		if done {
@@ -774,12 +1129,60 @@ func (rcsw *RemoteClusterServiceWatcher) processEvents(ctx context.Context) {
 
 // Start starts watching the remote cluster
 func (rcsw *RemoteClusterServiceWatcher) Start(ctx context.Context) error {
-	rcsw.remoteAPIClient.Sync(rcsw.stopper)
+	for _, remoteAPI := range rcsw.remoteAPIs {
+		remoteAPI.Sync(rcsw.stopper)
+	}
 	rcsw.eventsQueue.Add(&OrphanedServicesGcTriggered{})
-	rcsw.remoteAPIClient.Svc().Informer().AddEventHandler(
+	for _, remoteAPI := range rcsw.remoteAPIs {
+		rcsw.addRemoteEventHandlers(remoteAPI)
+	}
+
+	// the conflicts reported by an earlier watcher are cleared; the current
+	// ones get reported again as the services get synced
+	rcsw.reportConflicts()
+	go rcsw.processEvents(ctx)
+
+	// We need to issue a RepairEndpoints immediately to populate the gateway
+	// mirror endpoints.
+	ev := RepairEndpoints{}
+	rcsw.eventsQueue.Add(&ev)
+
+	go func() {
+		ticker := time.NewTicker(rcsw.repairPeriod)
+		for {
+			select {
+			case <-ticker.C:
+				ev := RepairEndpoints{}
+				rcsw.eventsQueue.Add(&ev)
+			case <-rcsw.stopper:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// syncMirroredResources enqueues the syncing of the ServiceProfile of a remote
+// service, and of the policies of its namespace
+func (rcsw *RemoteClusterServiceWatcher) syncMirroredResources(svc *corev1.Service) {
+	if rcsw.mirrorProfiles {
+		rcsw.eventsQueue.Add(&SyncServiceProfile{Name: svc.Name, Namespace: svc.Namespace})
+	}
+	if rcsw.link.MirrorPolicies {
+		rcsw.eventsQueue.Add(&SyncPolicies{Namespace: svc.Namespace})
+	}
+}
+
+// addRemoteEventHandlers maps the events of the services, endpoints,
+// ServiceProfiles and policies watched by the remote API to the events of the
+// watcher
+func (rcsw *RemoteClusterServiceWatcher) addRemoteEventHandlers(remoteAPI *k8s.API) {
+	remoteAPI.Svc().Informer().AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
 			AddFunc: func(svc interface{}) {
 				rcsw.eventsQueue.Add(&OnAddCalled{svc.(*corev1.Service)})
+				rcsw.syncMirroredResources(svc.(*corev1.Service))
 			},
 			DeleteFunc: func(obj interface{}) {
 				service, ok := obj.(*corev1.Service)
@@ -796,14 +1199,66 @@ func (rcsw *RemoteClusterServiceWatcher) Start(ctx context.Context) error {
 					}
 				}
 				rcsw.eventsQueue.Add(&OnDeleteCalled{service})
+				rcsw.syncMirroredResources(service)
 			},
 			UpdateFunc: func(old, new interface{}) {
 				rcsw.eventsQueue.Add(&OnUpdateCalled{new.(*corev1.Service)})
+				rcsw.syncMirroredResources(new.(*corev1.Service))
 			},
 		},
 	)
 
-	rcsw.remoteAPIClient.Endpoint().Informer().AddEventHandler(
+	// the ServiceProfiles are named after the FQDN of their service
+	syncServiceProfile := func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		meta, ok := obj.(metav1.Object)
+		if !ok {
+			rcsw.log.Errorf("couldn't get object meta from %#v", obj)
+			return
+		}
+		suffix := fmt.Sprintf(".%s.svc.%s", meta.GetNamespace(), rcsw.link.TargetClusterDomain)
+		if !strings.HasSuffix(meta.GetName(), suffix) {
+			return
+		}
+		rcsw.eventsQueue.Add(&SyncServiceProfile{
+			Name:      strings.TrimSuffix(meta.GetName(), suffix),
+			Namespace: meta.GetNamespace(),
+		})
+	}
+	if rcsw.mirrorProfiles {
+		remoteAPI.SP().Informer().AddEventHandler(
+			cache.ResourceEventHandlerFuncs{
+				AddFunc:    syncServiceProfile,
+				UpdateFunc: func(_, new interface{}) { syncServiceProfile(new) },
+				DeleteFunc: syncServiceProfile,
+			},
+		)
+	}
+
+	if rcsw.link.MirrorPolicies {
+		syncPolicies := func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			meta, ok := obj.(metav1.Object)
+			if !ok {
+				rcsw.log.Errorf("couldn't get object meta from %#v", obj)
+				return
+			}
+			rcsw.eventsQueue.Add(&SyncPolicies{Namespace: meta.GetNamespace()})
+		}
+		handlers := cache.ResourceEventHandlerFuncs{
+			AddFunc:    syncPolicies,
+			UpdateFunc: func(_, new interface{}) { syncPolicies(new) },
+			DeleteFunc: syncPolicies,
+		}
+		remoteAPI.Srv().Informer().AddEventHandler(handlers)
+		remoteAPI.Saz().Informer().AddEventHandler(handlers)
+	}
+
+	remoteAPI.Endpoint().Informer().AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
 			// AddFunc only relevant for exported headless endpoints
 			AddFunc: func(obj interface{}) {
@@ -831,28 +1286,6 @@ func (rcsw *RemoteClusterServiceWatcher) Start(ctx context.Context) error {
 			},
 		},
 	)
-
-	go rcsw.processEvents(ctx)
-
-	// We need to issue a RepairEndpoints immediately to populate the gateway
-	// mirror endpoints.
-	ev := RepairEndpoints{}
-	rcsw.eventsQueue.Add(&ev)
-
-	go func() {
-		ticker := time.NewTicker(rcsw.repairPeriod)
-		for {
-			select {
-			case <-ticker.C:
-				ev := RepairEndpoints{}
-				rcsw.eventsQueue.Add(&ev)
-			case <-rcsw.stopper:
-				return
-			}
-		}
-	}()
-
-	return nil
 }
 
 // Stop stops watching the cluster and cleans up all mirrored resources
@@ -976,6 +1409,15 @@ func (rcsw *RemoteClusterServiceWatcher) repairEndpoints(ctx context.Context) er
 		}
 		updatedEndpoints.Annotations[consts.RemoteGatewayIdentity] = rcsw.link.GatewayIdentity
 
+		// The remote name is refreshed as well, so that the mirrors follow
+		// changes to the cluster domain of the target cluster
+		remoteFqName := serviceFqdn(rcsw.targetResourceName(svc.Name), svc.Namespace, rcsw.link.TargetClusterDomain)
+		updatedEndpoints.Annotations[consts.RemoteServiceFqName] = remoteFqName
+		if updatedService.Annotations == nil {
+			updatedService.Annotations = make(map[string]string)
+		}
+		updatedService.Annotations[consts.RemoteServiceFqName] = remoteFqName
+
 		_, err = rcsw.localAPIClient.Client.CoreV1().Services(updatedService.Namespace).Update(ctx, updatedService, metav1.UpdateOptions{})
 		if err != nil {
 			rcsw.log.Error(err)
@@ -1029,7 +1471,10 @@ func (rcsw *RemoteClusterServiceWatcher) handleCreateOrUpdateEndpoints(
 		return nil
 	}
 
-	localServiceName := rcsw.mirroredResourceName(exportedEndpoints.Name)
+	localServiceName, _, ok := rcsw.mirrorServiceName(exportedEndpoints.Namespace, exportedEndpoints.Name)
+	if !ok {
+		return nil
+	}
 	ep, err := rcsw.localAPIClient.Endpoint().Lister().Endpoints(exportedEndpoints.Namespace).Get(localServiceName)
 	if err != nil {
 		return RetryableError{[]error{err}}
@@ -1044,7 +1489,7 @@ func (rcsw *RemoteClusterServiceWatcher) handleCreateOrUpdateEndpoints(
 	if rcsw.isEmptyEndpoints(exportedEndpoints) {
 		ep.Subsets = []corev1.EndpointSubset{}
 	} else {
-		exportedService, err := rcsw.remoteAPIClient.Svc().Lister().Services(exportedEndpoints.Namespace).Get(exportedEndpoints.Name)
+		exportedService, err := rcsw.remoteService(exportedEndpoints.Namespace, exportedEndpoints.Name)
 		if err != nil {
 			return RetryableError{[]error{
 				fmt.Errorf("error retrieving exported service %s/%s: %v", exportedEndpoints.Namespace, exportedEndpoints.Name, err),
@@ -1088,7 +1533,7 @@ func (rcsw *RemoteClusterServiceWatcher) handleCreateOrUpdateEndpoints(
 // headless mirror exists and has an endpoints object, we simply update by
 // either creating or deleting endpoint mirror services.
 func (rcsw *RemoteClusterServiceWatcher) createOrUpdateHeadlessEndpoints(ctx context.Context, exportedEndpoints *corev1.Endpoints) error {
-	exportedService, err := rcsw.remoteAPIClient.Svc().Lister().Services(exportedEndpoints.Namespace).Get(exportedEndpoints.Name)
+	exportedService, err := rcsw.remoteService(exportedEndpoints.Namespace, exportedEndpoints.Name)
 	if err != nil {
 		rcsw.log.Debugf("failed to retrieve exported service %s/%s when updating its headless mirror endpoints: %v", exportedEndpoints.Namespace, exportedEndpoints.Name, err)
 		return fmt.Errorf("error retrieving exported service %s/%s: %v", exportedEndpoints.Namespace, exportedEndpoints.Name, err)
@@ -1128,7 +1573,7 @@ func (rcsw *RemoteClusterServiceWatcher) createOrUpdateHeadlessEndpoints(ctx con
 		}
 
 		if mirrorService.Spec.ClusterIP != corev1.ClusterIPNone {
-			return rcsw.createGatewayEndpoints(ctx, exportedService)
+			return rcsw.createGatewayEndpoints(ctx, exportedService, mirrorService.Name)
 		}
 
 		// Create endpoint mirrors for headless mirror
@@ -1282,6 +1727,8 @@ func (rcsw *RemoteClusterServiceWatcher) createRemoteHeadlessService(ctx context
 			// we might have created it during earlier attempt, if that is not the case, we retry
 			return &corev1.Service{}, RetryableError{[]error{err}}
 		}
+	} else {
+		rcsw.countMirrorServices(operationMirrored)
 	}
 
 	return svc, err
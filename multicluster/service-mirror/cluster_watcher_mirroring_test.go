@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	consts "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/multicluster"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/workqueue"
@@ -18,6 +19,7 @@ type mirroringTestCase struct {
 	expectedLocalServices  []*corev1.Service
 	expectedLocalEndpoints []*corev1.Endpoints
 	expectedEventsInQueue  []interface{}
+	expectedConflicts      []string
 }
 
 func (tc *mirroringTestCase) run(t *testing.T) {
@@ -68,6 +70,11 @@ func (tc *mirroringTestCase) run(t *testing.T) {
 			}
 		}
 
+		if (len(tc.expectedConflicts) > 0 || len(tc.environment.reportedConflicts) > 0) &&
+			!reflect.DeepEqual(tc.expectedConflicts, tc.environment.reportedConflicts) {
+			t.Fatalf("Was expecting conflicts %v but got %v", tc.expectedConflicts, tc.environment.reportedConflicts)
+		}
+
 		expectedNumEvents := len(tc.expectedEventsInQueue)
 		actualNumEvents := q.Len()
 
@@ -212,6 +219,155 @@ func TestRemoteServiceDeletedMirroring(t *testing.T) {
 	}
 }
 
+func TestMirrorNameConflicts(t *testing.T) {
+	ports := []corev1.ServicePort{
+		{
+			Name:     "port1",
+			Protocol: "TCP",
+			Port:     555,
+		},
+	}
+	gatewayEndpoints := func(name string) *corev1.Endpoints {
+		ep := endpoints(name, "ns1", "192.0.2.127", "gateway-identity", []corev1.EndpointPort{
+			{
+				Name:     "port1",
+				Port:     888,
+				Protocol: "TCP",
+			},
+		})
+		ep.Annotations[consts.RemoteServiceFqName] = "service-one.ns1.svc.cluster.local"
+		return ep
+	}
+
+	suffixedMirror := mirrorService("service-one-remote-mirror", "ns1", "111", ports)
+	suffixedMirror.Annotations[consts.RemoteServiceFqName] = "service-one.ns1.svc.cluster.local"
+	suffixedMirror.Annotations[consts.OriginServiceUID] = "ns1-service-one-uid"
+	suffixedMirror.Annotations[consts.OriginServiceFqName] = "service-one.ns1.svc.cluster.local"
+
+	adopted := mirrorService("service-one-remote", "ns1", "111", ports)
+	adopted.Labels[consts.AdoptByClusterLabel] = clusterName
+
+	for _, tt := range []mirroringTestCase{
+		{
+			description: "skips the service by default",
+			environment: conflictingService("", nil),
+			expectedLocalServices: []*corev1.Service{
+				localService("service-one-remote", "ns1", nil),
+			},
+			expectedConflicts: []string{
+				"ns1/service-one (skipped, ns1/service-one-remote already exists)",
+			},
+		},
+		{
+			description: "mirrors the service under a suffixed name",
+			environment: conflictingService(multicluster.ConflictPolicySuffix, nil),
+			expectedLocalServices: []*corev1.Service{
+				localService("service-one-remote", "ns1", nil),
+				suffixedMirror,
+			},
+			expectedLocalEndpoints: []*corev1.Endpoints{
+				gatewayEndpoints("service-one-remote-mirror"),
+			},
+			expectedConflicts: []string{
+				"ns1/service-one (mirrored as ns1/service-one-remote-mirror)",
+			},
+		},
+		{
+			description: "adopts the local service labeled for the cluster",
+			environment: conflictingService(multicluster.ConflictPolicyAdoptIfLabeled, map[string]string{
+				consts.AdoptByClusterLabel: clusterName,
+			}),
+			expectedLocalServices: []*corev1.Service{
+				adopted,
+			},
+			expectedLocalEndpoints: []*corev1.Endpoints{
+				gatewayEndpoints("service-one-remote"),
+			},
+		},
+		{
+			description: "skips the local service labeled for another cluster",
+			environment: conflictingService(multicluster.ConflictPolicyAdoptIfLabeled, map[string]string{
+				consts.AdoptByClusterLabel: "other",
+			}),
+			expectedLocalServices: []*corev1.Service{
+				localService("service-one-remote", "ns1", map[string]string{
+					consts.AdoptByClusterLabel: "other",
+				}),
+			},
+			expectedConflicts: []string{
+				fmt.Sprintf("ns1/service-one (skipped, ns1/service-one-remote isn't labeled %s=remote)", consts.AdoptByClusterLabel),
+			},
+		},
+		{
+			description: "doesn't delete the local service",
+			environment: deleteConflictingService,
+			expectedLocalServices: []*corev1.Service{
+				localService("service-one-remote", "ns1", nil),
+			},
+		},
+	} {
+		tc := tt // pin
+		tc.run(t)
+	}
+}
+
+func TestRepairEndpointsClusterDomain(t *testing.T) {
+	mirror := mirrorService("service-one-remote", "ns1", "111", []corev1.ServicePort{
+		{
+			Name:     "port1",
+			Protocol: "TCP",
+			Port:     555,
+		},
+	})
+	mirror.Annotations[consts.RemoteServiceFqName] = "service-one.ns1.svc.example.org"
+
+	ep := endpoints("service-one-remote", "ns1", "192.0.2.127", "gateway-identity", []corev1.EndpointPort{
+		{
+			Name:     "port1",
+			Port:     888,
+			Protocol: "TCP",
+		},
+	})
+	ep.Annotations[consts.RemoteServiceFqName] = "service-one.ns1.svc.example.org"
+
+	tc := mirroringTestCase{
+		description:            "refreshes the remote names in the target cluster domain",
+		environment:            repairEndpointsOtherClusterDomain,
+		expectedLocalServices:  []*corev1.Service{mirror},
+		expectedLocalEndpoints: []*corev1.Endpoints{ep},
+	}
+	tc.run(t)
+}
+
+func TestMirrorChainOrigin(t *testing.T) {
+	remote := remoteService("service-one-west", "ns1", "111", map[string]string{
+		consts.DefaultExportedServiceSelector: "true",
+		consts.MirroredResourceLabel:          "true",
+		consts.RemoteClusterNameLabel:         "west",
+	}, nil)
+	remote.Annotations = map[string]string{
+		consts.RemoteResourceVersionAnnotation: "42",
+		consts.RemoteServiceFqName:             "service-one.ns1.svc.west.local",
+		consts.OriginServiceUID:                "west-uid",
+		consts.OriginServiceFqName:             "service-one.ns1.svc.west.local",
+	}
+
+	watcher := RemoteClusterServiceWatcher{
+		link: &multicluster.Link{TargetClusterName: clusterName, TargetClusterDomain: clusterDomain},
+	}
+	annotations := watcher.getMirroredServiceAnnotations(remote)
+
+	expected := map[string]string{
+		consts.RemoteResourceVersionAnnotation: "111",
+		consts.RemoteServiceFqName:             "service-one-west.ns1.svc.cluster.local",
+		consts.OriginServiceUID:                "west-uid",
+		consts.OriginServiceFqName:             "service-one.ns1.svc.west.local",
+	}
+	if !reflect.DeepEqual(expected, annotations) {
+		t.Fatalf("Expected annotations %v, got %v", expected, annotations)
+	}
+}
+
 func TestRemoteServiceUpdatedMirroring(t *testing.T) {
 	for _, tt := range []mirroringTestCase{
 		{
@@ -381,6 +537,17 @@ func TestGcOrphanedServicesMirroring(t *testing.T) {
 				mirrorService("test-service-1-remote", "test-namespace", "", nil),
 			},
 
+			expectedLocalEndpoints: []*corev1.Endpoints{
+				endpoints("test-service-1-remote", "test-namespace", "", "", nil),
+			},
+		},
+		{
+			description: "deletes mirrored resources from namespaces out of the scope of the link",
+			environment: gcTriggeredOutOfScope,
+			expectedLocalServices: []*corev1.Service{
+				mirrorService("test-service-1-remote", "test-namespace", "", nil),
+			},
+
 			expectedLocalEndpoints: []*corev1.Endpoints{
 				endpoints("test-service-1-remote", "test-namespace", "", "", nil),
 			},
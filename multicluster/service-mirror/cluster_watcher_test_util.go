@@ -15,6 +15,7 @@ import (
 	logging "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 )
 
@@ -40,6 +41,9 @@ type testEnvironment struct {
 	remoteResources []string
 	localResources  []string
 	link            multicluster.Link
+	// reportedConflicts are the mirror name conflicts last reported by the
+	// watcher
+	reportedConflicts []string
 }
 
 func (te *testEnvironment) runEnvironment(watcherQueue workqueue.RateLimitingInterface) (*k8s.API, error) {
@@ -54,15 +58,29 @@ func (te *testEnvironment) runEnvironment(watcherQueue workqueue.RateLimitingInt
 	remoteAPI.Sync(nil)
 	localAPI.Sync(nil)
 
+	remoteAPIs := map[string]*k8s.API{metav1.NamespaceAll: remoteAPI}
+	if len(te.link.Namespaces) > 0 {
+		remoteAPIs = make(map[string]*k8s.API)
+		for _, ns := range te.link.Namespaces {
+			remoteAPIs[ns] = remoteAPI
+		}
+	}
+
 	watcher := RemoteClusterServiceWatcher{
 		link:                    &te.link,
-		remoteAPIClient:         remoteAPI,
+		remoteAPIs:              remoteAPIs,
+		mirrorProfiles:          true,
 		localAPIClient:          localAPI,
+		localClusterDomain:      clusterDomain,
 		stopper:                 nil,
 		log:                     logging.WithFields(logging.Fields{"cluster": clusterName}),
 		eventsQueue:             watcherQueue,
 		requeueLimit:            0,
 		headlessServicesEnabled: true,
+		conflicts:               make(map[string]string),
+		updateConflicts: func(conflicts []string) {
+			te.reportedConflicts = conflicts
+		},
 	}
 
 	for _, ev := range te.events {
@@ -444,6 +462,26 @@ var gcTriggered = &testEnvironment{
 	},
 }
 
+var gcTriggeredOutOfScope = &testEnvironment{
+	events: []interface{}{
+		&OrphanedServicesGcTriggered{},
+	},
+	localResources: []string{
+		mirrorServiceAsYaml("test-service-1-remote", "test-namespace", "", nil),
+		endpointsAsYaml("test-service-1-remote", "test-namespace", "", "", nil),
+		mirrorServiceAsYaml("test-service-2-remote", "other-namespace", "", nil),
+		endpointsAsYaml("test-service-2-remote", "other-namespace", "", "", nil),
+	},
+	remoteResources: []string{
+		remoteServiceAsYaml("test-service-1", "test-namespace", "", nil),
+		remoteServiceAsYaml("test-service-2", "other-namespace", "", nil),
+	},
+	link: multicluster.Link{
+		TargetClusterName: clusterName,
+		Namespaces:        []string{"test-namespace"},
+	},
+}
+
 func onAddOrUpdateExportedSvc(isAdd bool) *testEnvironment {
 	return &testEnvironment{
 		events: []interface{}{
@@ -570,6 +608,96 @@ var onDeleteNonExportedService = &testEnvironment{
 // the following tests ensure that onAdd, onUpdate and onDelete result in
 // queueing more specific events to be processed
 
+// conflictingService creates an exported service whose mirror name is taken
+// by a local service that isn't a mirror
+func conflictingService(conflictPolicy string, localLabels map[string]string) *testEnvironment {
+	return &testEnvironment{
+		events: []interface{}{
+			&RemoteServiceCreated{
+				service: remoteService("service-one", "ns1", "111", map[string]string{
+					consts.DefaultExportedServiceSelector: "true",
+				}, []corev1.ServicePort{
+					{
+						Name:     "port1",
+						Protocol: "TCP",
+						Port:     555,
+					},
+				}),
+			},
+		},
+		remoteResources: []string{
+			gatewayAsYaml("existing-gateway", "existing-namespace", "222", "192.0.2.127", "mc-gateway", 888, "gateway-identity", defaultProbePort, defaultProbePath, defaultProbePeriod),
+			endpointsAsYaml("service-one", "ns1", "192.0.2.127", "gateway-identity", []corev1.EndpointPort{}),
+		},
+		localResources: []string{
+			localServiceAsYaml("service-one-remote", "ns1", localLabels),
+			localEndpointsAsYaml("service-one-remote", "ns1"),
+		},
+		link: multicluster.Link{
+			TargetClusterName:   clusterName,
+			TargetClusterDomain: clusterDomain,
+			GatewayIdentity:     "gateway-identity",
+			GatewayAddress:      "192.0.2.127",
+			GatewayPort:         888,
+			ProbeSpec:           defaultProbeSpec,
+			Selector:            *defaultSelector,
+			ConflictPolicy:      conflictPolicy,
+		},
+	}
+}
+
+var deleteConflictingService = &testEnvironment{
+	events: []interface{}{
+		&RemoteServiceDeleted{
+			Name:      "service-one",
+			Namespace: "ns1",
+		},
+	},
+	localResources: []string{
+		localServiceAsYaml("service-one-remote", "ns1", nil),
+	},
+	link: multicluster.Link{
+		TargetClusterName:   clusterName,
+		TargetClusterDomain: clusterDomain,
+		GatewayIdentity:     "gateway-identity",
+		GatewayAddress:      "192.0.2.127",
+		GatewayPort:         888,
+		ProbeSpec:           defaultProbeSpec,
+		Selector:            *defaultSelector,
+	},
+}
+
+// repairEndpointsOtherClusterDomain repairs a mirror created before the
+// target cluster domain was known, which is in another domain than the local
+// one
+var repairEndpointsOtherClusterDomain = &testEnvironment{
+	events: []interface{}{
+		&RepairEndpoints{},
+	},
+	remoteResources: []string{
+		endpointsAsYaml("service-one", "ns1", "192.0.2.128", "", []corev1.EndpointPort{}),
+	},
+	localResources: []string{
+		mirrorServiceAsYaml("service-one-remote", "ns1", "111", []corev1.ServicePort{
+			{
+				Name:     "port1",
+				Protocol: "TCP",
+				Port:     555,
+			},
+		}),
+		endpointsAsYaml("service-one-remote", "ns1", "192.0.2.127", "gateway-identity", []corev1.EndpointPort{}),
+	},
+	link: multicluster.Link{
+		TargetClusterName:   clusterName,
+		TargetClusterDomain: "example.org",
+		GatewayIdentity:     "gateway-identity",
+		GatewayAddress:      "192.0.2.127",
+		GatewayPort:         888,
+		ProbeSpec:           defaultProbeSpec,
+		Selector:            *defaultSelector,
+	},
+}
+
 func onAddOrUpdateEvent(isAdd bool, svc *corev1.Service) interface{} {
 	if isAdd {
 		return &OnAddCalled{svc: svc}
@@ -631,6 +759,7 @@ func remoteService(name, namespace, resourceVersion string, labels map[string]st
 			Name:            name,
 			Namespace:       namespace,
 			ResourceVersion: resourceVersion,
+			UID:             remoteServiceUID(name, namespace),
 			Labels:          labels,
 		},
 		Spec: corev1.ServiceSpec{
@@ -639,6 +768,10 @@ func remoteService(name, namespace, resourceVersion string, labels map[string]st
 	}
 }
 
+func remoteServiceUID(name, namespace string) types.UID {
+	return types.UID(fmt.Sprintf("%s-%s-uid", namespace, name))
+}
+
 func remoteHeadlessService(name, namespace, resourceVersion string, labels map[string]string, ports []corev1.ServicePort) *corev1.Service {
 	return &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
@@ -649,6 +782,7 @@ func remoteHeadlessService(name, namespace, resourceVersion string, labels map[s
 			Name:            name,
 			Namespace:       namespace,
 			ResourceVersion: resourceVersion,
+			UID:             remoteServiceUID(name, namespace),
 			Labels:          labels,
 		},
 		Spec: corev1.ServiceSpec{
@@ -765,6 +899,8 @@ func mirrorService(name, namespace, resourceVersion string, ports []corev1.Servi
 	annotations := make(map[string]string)
 	annotations[consts.RemoteResourceVersionAnnotation] = resourceVersion
 	annotations[consts.RemoteServiceFqName] = fmt.Sprintf("%s.%s.svc.cluster.local", strings.Replace(name, "-remote", "", 1), namespace)
+	annotations[consts.OriginServiceUID] = string(remoteServiceUID(strings.Replace(name, "-remote", "", 1), namespace))
+	annotations[consts.OriginServiceFqName] = annotations[consts.RemoteServiceFqName]
 
 	return &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
@@ -786,6 +922,65 @@ func mirrorService(name, namespace, resourceVersion string, ports []corev1.Servi
 	}
 }
 
+func localService(name, namespace string, labels map[string]string) *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": name},
+			Ports: []corev1.ServicePort{
+				{
+					Name:     "http",
+					Protocol: "TCP",
+					Port:     80,
+				},
+			},
+		},
+	}
+}
+
+func localServiceAsYaml(name, namespace string, labels map[string]string) string {
+	svc := localService(name, namespace, labels)
+
+	bytes, err := yaml.Marshal(svc)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return string(bytes)
+}
+
+func localEndpointsAsYaml(name, namespace string) string {
+	ep := &corev1.Endpoints{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Endpoints",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Subsets: []corev1.EndpointSubset{
+			{
+				Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}},
+				Ports:     []corev1.EndpointPort{{Name: "http", Port: 80, Protocol: "TCP"}},
+			},
+		},
+	}
+
+	bytes, err := yaml.Marshal(ep)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return string(bytes)
+}
+
 func headlessMirrorService(name, namespace, resourceVersion string, ports []corev1.ServicePort) *corev1.Service {
 	svc := mirrorService(name, namespace, resourceVersion, ports)
 	svc.Spec.ClusterIP = "None"
@@ -1061,3 +1256,178 @@ func endpointMirrorEndpointsAsYaml(name, namespace, hostname, gatewayIP, gateway
 
 	return string(bytes)
 }
+
+var exportedServiceWithSelectorAsYaml = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: emojivoto
+  labels:
+    mirror.linkerd.io/exported: "true"
+spec:
+  selector:
+    app: web
+  ports:
+  - port: 80`
+
+var remoteServiceProfileAsYaml = `
+apiVersion: linkerd.io/v1alpha2
+kind: ServiceProfile
+metadata:
+  name: web.emojivoto.svc.cluster.local
+  namespace: emojivoto
+  resourceVersion: "10"
+spec:
+  routes:
+  - name: GET /api/list
+    condition:
+      method: GET
+      pathRegex: /api/list
+  dstOverrides:
+  - authority: web.emojivoto.svc.cluster.local:80
+    weight: 500m
+  - authority: web-canary.emojivoto.svc.cluster.local:80
+    weight: 500m
+  - authority: web.example.com
+    weight: 0`
+
+var syncServiceProfile = &testEnvironment{
+	events: []interface{}{
+		&SyncServiceProfile{Name: "web", Namespace: "emojivoto"},
+	},
+	remoteResources: []string{
+		exportedServiceWithSelectorAsYaml,
+		remoteServiceProfileAsYaml,
+	},
+	link: multicluster.Link{
+		TargetClusterName:   clusterName,
+		TargetClusterDomain: clusterDomain,
+		Selector:            *defaultSelector,
+	},
+}
+
+var syncServiceProfileNotExported = &testEnvironment{
+	events: []interface{}{
+		&SyncServiceProfile{Name: "web", Namespace: "emojivoto"},
+	},
+	remoteResources: []string{
+		remoteServiceProfileAsYaml,
+	},
+	localResources: []string{`
+apiVersion: linkerd.io/v1alpha2
+kind: ServiceProfile
+metadata:
+  name: web-remote.emojivoto.svc.cluster.local
+  namespace: emojivoto
+  labels:
+    mirror.linkerd.io/mirrored-service: "true"
+    mirror.linkerd.io/cluster-name: remote
+  annotations:
+    mirror.linkerd.io/remote-resource-version: "10"
+spec:
+  routes:
+  - name: GET /api/list
+    condition:
+      method: GET
+      pathRegex: /api/list`,
+	},
+	link: multicluster.Link{
+		TargetClusterName:   clusterName,
+		TargetClusterDomain: clusterDomain,
+		Selector:            *defaultSelector,
+	},
+}
+
+var syncPolicies = &testEnvironment{
+	events: []interface{}{
+		&SyncPolicies{Namespace: "emojivoto"},
+	},
+	remoteResources: []string{
+		exportedServiceWithSelectorAsYaml,
+		`
+apiVersion: policy.linkerd.io/v1beta1
+kind: Server
+metadata:
+  name: web-http
+  namespace: emojivoto
+  labels:
+    app: web
+spec:
+  podSelector:
+    matchLabels:
+      app: web
+  port: http
+  proxyProtocol: HTTP/1`,
+		`
+apiVersion: policy.linkerd.io/v1beta1
+kind: Server
+metadata:
+  name: voting-grpc
+  namespace: emojivoto
+spec:
+  podSelector:
+    matchLabels:
+      app: voting
+  port: grpc
+  proxyProtocol: gRPC`,
+		`
+apiVersion: policy.linkerd.io/v1beta1
+kind: ServerAuthorization
+metadata:
+  name: web-public
+  namespace: emojivoto
+spec:
+  server:
+    name: web-http
+  client:
+    unauthenticated: true`,
+		`
+apiVersion: policy.linkerd.io/v1beta1
+kind: ServerAuthorization
+metadata:
+  name: web-selected
+  namespace: emojivoto
+spec:
+  server:
+    selector:
+      matchLabels:
+        app: web
+  client:
+    meshTLS:
+      identities:
+      - "*"`,
+		`
+apiVersion: policy.linkerd.io/v1beta1
+kind: ServerAuthorization
+metadata:
+  name: voting-grpc
+  namespace: emojivoto
+spec:
+  server:
+    name: voting-grpc
+  client:
+    unauthenticated: true`,
+	},
+	localResources: []string{`
+apiVersion: policy.linkerd.io/v1beta1
+kind: Server
+metadata:
+  name: stale-remote
+  namespace: emojivoto
+  labels:
+    mirror.linkerd.io/mirrored-service: "true"
+    mirror.linkerd.io/cluster-name: remote
+spec:
+  podSelector:
+    matchLabels:
+      app: stale
+  port: http`,
+	},
+	link: multicluster.Link{
+		TargetClusterName:   clusterName,
+		TargetClusterDomain: clusterDomain,
+		Selector:            *defaultSelector,
+		MirrorPolicies:      true,
+	},
+}
@@ -1,6 +1,10 @@
 package servicemirror
 
 import (
+	"net/http"
+	"strconv"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	logging "github.com/sirupsen/logrus"
@@ -10,6 +14,13 @@ const (
 	gatewayClusterName   = "target_cluster_name"
 	eventTypeLabelName   = "event_type"
 	probeSuccessfulLabel = "probe_successful"
+	operationLabelName   = "operation"
+	methodLabelName      = "method"
+	codeLabelName        = "code"
+
+	operationMirrored = "mirrored"
+	operationUpdated  = "updated"
+	operationDeleted  = "deleted"
 )
 
 // ProbeMetricVecs stores metrics about about gateways collected by probe
@@ -31,7 +42,12 @@ type ProbeMetrics struct {
 	unregister func()
 }
 
-var endpointRepairCounter *prometheus.CounterVec
+var (
+	endpointRepairCounter *prometheus.CounterVec
+	mirrorServicesCounter *prometheus.CounterVec
+	syncErrorsCounter     *prometheus.CounterVec
+	remoteAPILatency      *prometheus.HistogramVec
+)
 
 func init() {
 	endpointRepairCounter = promauto.NewCounterVec(
@@ -41,6 +57,65 @@ func init() {
 		},
 		[]string{gatewayClusterName},
 	)
+
+	mirrorServicesCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "service_mirror_services_total",
+			Help: "A counter for the number of mirror services created, updated and deleted for a target cluster",
+		},
+		[]string{gatewayClusterName, operationLabelName},
+	)
+
+	syncErrorsCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "service_mirror_sync_errors_total",
+			Help: "A counter for the number of events the service mirror controller failed to process for a target cluster",
+		},
+		[]string{gatewayClusterName, eventTypeLabelName},
+	)
+
+	remoteAPILatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "service_mirror_remote_api_request_latency_seconds",
+			Help:    "A histogram of latencies of the requests to the API server of a target cluster, watches excluded.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{gatewayClusterName, methodLabelName, codeLabelName},
+	)
+}
+
+// remoteAPIRoundTripper records the latency of the requests to the API server
+// of a target cluster
+type remoteAPIRoundTripper struct {
+	rt      http.RoundTripper
+	latency prometheus.ObserverVec
+}
+
+func instrumentRemoteAPI(remoteClusterName string) func(http.RoundTripper) http.RoundTripper {
+	latency := remoteAPILatency.MustCurryWith(prometheus.Labels{gatewayClusterName: remoteClusterName})
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &remoteAPIRoundTripper{rt, latency}
+	}
+}
+
+func (r *remoteAPIRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// watches last until they're closed, their duration says nothing about
+	// the responsiveness of the API server
+	if req.URL.Query().Get("watch") == "true" {
+		return r.rt.RoundTrip(req)
+	}
+
+	start := time.Now()
+	rsp, err := r.rt.RoundTrip(req)
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(rsp.StatusCode)
+	}
+	r.latency.With(prometheus.Labels{
+		methodLabelName: req.Method,
+		codeLabelName:   code,
+	}).Observe(time.Since(start).Seconds())
+	return rsp, err
 }
 
 // NewProbeMetricVecs creates a new ProbeMetricVecs.
@@ -0,0 +1,73 @@
+package servicemirror
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/multicluster"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	logging "github.com/sirupsen/logrus"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestRemoteAPILatency(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: instrumentRemoteAPI("latency-test")(http.DefaultTransport)}
+	for _, url := range []string{server.URL + "/api/v1/services", server.URL + "/api/v1/services?watch=true"} {
+		rsp, err := client.Get(url)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		rsp.Body.Close()
+	}
+
+	// only the request that isn't a watch is observed
+	observed := testutil.CollectAndCount(remoteAPILatency.MustCurryWith(prometheus.Labels{
+		gatewayClusterName: "latency-test",
+		methodLabelName:    http.MethodGet,
+		codeLabelName:      "404",
+	}))
+	if observed != 1 {
+		t.Fatalf("Expected 1 latency series, got %d", observed)
+	}
+}
+
+func TestRecordSyncError(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	watcher := RemoteClusterServiceWatcher{
+		link: &multicluster.Link{
+			Name:              "sync-test",
+			Namespace:         "linkerd-multicluster",
+			TargetClusterName: "sync-test",
+		},
+		linkRecorder: recorder,
+		log:          logging.WithField("test", t.Name()),
+	}
+	errorsCounter := syncErrorsCounter.With(prometheus.Labels{
+		gatewayClusterName: "sync-test",
+		eventTypeLabelName: "RemoteServiceCreated",
+	})
+
+	watcher.recordSyncError(&RemoteServiceCreated{}, errors.New("retrying"), false)
+	if len(recorder.Events) != 0 {
+		t.Fatalf("Expected no event until the error is given up on, got %s", <-recorder.Events)
+	}
+
+	watcher.recordSyncError(&RemoteServiceCreated{}, errors.New("giving up"), true)
+	if count := testutil.ToFloat64(errorsCounter); count != 2 {
+		t.Fatalf("Expected 2 sync errors, got %f", count)
+	}
+	event := <-recorder.Events
+	expected := "Warning SyncFailed Failed to process RemoteServiceCreated: giving up"
+	if !strings.HasPrefix(event, expected) {
+		t.Fatalf("Expected event %q, got %q", expected, event)
+	}
+}
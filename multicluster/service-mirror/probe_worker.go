@@ -11,8 +11,6 @@ import (
 	logging "github.com/sirupsen/logrus"
 )
 
-const httpGatewayTimeoutMillis = 50000
-
 // ProbeWorker is responsible for monitoring gateways using a probe specification
 type ProbeWorker struct {
 	localGatewayName string
@@ -21,10 +19,18 @@ type ProbeWorker struct {
 	stopCh    chan struct{}
 	metrics   *ProbeMetrics
 	log       *logging.Entry
+
+	// updateStatus is called with the health of the gateway each time it
+	// changes, along with a message detailing the last probe
+	updateStatus func(alive bool, message string)
+	// failures is the number of consecutive failed probes
+	failures uint32
+	// alive is nil until the health of the gateway is known
+	alive *bool
 }
 
 // NewProbeWorker creates a new probe worker associated with a particular gateway
-func NewProbeWorker(localGatewayName string, spec *multicluster.ProbeSpec, metrics *ProbeMetrics, probekey string) *ProbeWorker {
+func NewProbeWorker(localGatewayName string, spec *multicluster.ProbeSpec, metrics *ProbeMetrics, probekey string, updateStatus func(alive bool, message string)) *ProbeWorker {
 	return &ProbeWorker{
 		localGatewayName: localGatewayName,
 		RWMutex:          &sync.RWMutex{},
@@ -34,6 +40,7 @@ func NewProbeWorker(localGatewayName string, spec *multicluster.ProbeSpec, metri
 		log: logging.WithFields(logging.Fields{
 			"probe-key": probekey,
 		}),
+		updateStatus: updateStatus,
 	}
 }
 
@@ -80,8 +87,12 @@ func (pw *ProbeWorker) doProbe() {
 	successLabel := prometheus.Labels{probeSuccessfulLabel: "true"}
 	notSuccessLabel := prometheus.Labels{probeSuccessfulLabel: "false"}
 
+	timeout := pw.probeSpec.Timeout
+	if timeout == 0 {
+		timeout = multicluster.DefaultProbeTimeout
+	}
 	client := http.Client{
-		Timeout: httpGatewayTimeoutMillis * time.Millisecond,
+		Timeout: timeout,
 	}
 
 	req, err := http.NewRequest("GET", fmt.Sprintf("http://%s:%d%s", pw.localGatewayName, pw.probeSpec.Port, pw.probeSpec.Path), nil)
@@ -94,23 +105,53 @@ func (pw *ProbeWorker) doProbe() {
 	resp, err := client.Do(req)
 	end := time.Since(start)
 	if err != nil {
-		pw.log.Warnf("Problem connecting with gateway. Marking as unhealthy %s", err)
-		pw.metrics.alive.Set(0)
+		pw.log.Warnf("Problem connecting with gateway: %s", err)
 		pw.metrics.probes.With(notSuccessLabel).Inc()
+		pw.probeFailed(fmt.Sprintf("Problem connecting with gateway: %s", err))
 		return
 	} else if resp.StatusCode != 200 {
-		pw.log.Warnf("Gateway returned unexpected status %d. Marking as unhealthy", resp.StatusCode)
-		pw.metrics.alive.Set(0)
+		pw.log.Warnf("Gateway returned unexpected status %d", resp.StatusCode)
 		pw.metrics.probes.With(notSuccessLabel).Inc()
+		pw.probeFailed(fmt.Sprintf("Gateway returned unexpected status %d", resp.StatusCode))
 	} else {
 		pw.log.Debug("Gateway is healthy")
-		pw.metrics.alive.Set(1)
 		pw.metrics.latencies.Observe(float64(end.Milliseconds()))
 		pw.metrics.probes.With(successLabel).Inc()
+		pw.failures = 0
+		pw.setAlive(true, fmt.Sprintf("Gateway answered the probe in %dms", end.Milliseconds()))
 	}
 
 	if err := resp.Body.Close(); err != nil {
 		pw.log.Warnf("Failed to close response body %s", err)
 	}
+}
+
+// probeFailed marks the gateway as unhealthy once the number of consecutive
+// failed probes reaches the failure threshold
+func (pw *ProbeWorker) probeFailed(message string) {
+	pw.failures++
+	if pw.failures < pw.probeSpec.FailureThreshold {
+		pw.log.Debugf("Failed probe %d out of %d before marking the gateway as unhealthy", pw.failures, pw.probeSpec.FailureThreshold)
+		return
+	}
+	if pw.alive == nil || *pw.alive {
+		pw.log.Warnf("Marking gateway as unhealthy after %d failed probes", pw.failures)
+	}
+	pw.setAlive(false, message)
+}
+
+func (pw *ProbeWorker) setAlive(alive bool, message string) {
+	if alive {
+		pw.metrics.alive.Set(1)
+	} else {
+		pw.metrics.alive.Set(0)
+	}
 
+	if pw.alive != nil && *pw.alive == alive {
+		return
+	}
+	pw.alive = &alive
+	if pw.updateStatus != nil {
+		pw.updateStatus(alive, message)
+	}
 }
@@ -0,0 +1,84 @@
+package servicemirror
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/multicluster"
+)
+
+func TestProbeWorker(t *testing.T) {
+	var unhealthy int32
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&unhealthy) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer gateway.Close()
+
+	host, portStr, err := net.SplitHostPort(gateway.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.ParseUint(portStr, 10, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metrics, err := NewProbeMetricVecs().NewWorkerMetrics("remote")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var transitions []bool
+	worker := NewProbeWorker(host, &multicluster.ProbeSpec{
+		Path:             "/ready",
+		Port:             uint32(port),
+		Period:           time.Second,
+		Timeout:          time.Second,
+		FailureThreshold: 3,
+	}, metrics, "remote", func(alive bool, message string) {
+		transitions = append(transitions, alive)
+	})
+
+	expectTransitions := func(expected ...bool) {
+		t.Helper()
+		if len(transitions) != len(expected) {
+			t.Fatalf("Expected transitions %v, got %v", expected, transitions)
+		}
+		for i := range expected {
+			if transitions[i] != expected[i] {
+				t.Fatalf("Expected transitions %v, got %v", expected, transitions)
+			}
+		}
+	}
+
+	worker.doProbe()
+	worker.doProbe()
+	expectTransitions(true)
+
+	// the gateway is only marked as not alive after 3 consecutive failures
+	atomic.StoreInt32(&unhealthy, 1)
+	worker.doProbe()
+	worker.doProbe()
+	expectTransitions(true)
+	atomic.StoreInt32(&unhealthy, 0)
+	worker.doProbe()
+	atomic.StoreInt32(&unhealthy, 1)
+	worker.doProbe()
+	worker.doProbe()
+	expectTransitions(true)
+	worker.doProbe()
+	expectTransitions(true, false)
+	worker.doProbe()
+	expectTransitions(true, false)
+
+	atomic.StoreInt32(&unhealthy, 0)
+	worker.doProbe()
+	expectTransitions(true, false, true)
+}
@@ -0,0 +1,520 @@
+package servicemirror
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+
+	serverv1beta1 "github.com/linkerd/linkerd2/controller/gen/apis/server/v1beta1"
+	sazv1beta1 "github.com/linkerd/linkerd2/controller/gen/apis/serverauthorization/v1beta1"
+	spv1alpha2 "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha2"
+	consts "github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type (
+	// SyncServiceProfile is issued when the ServiceProfile of a remote
+	// service, or the service itself, changes. The ServiceProfile is mirrored
+	// for the mirror service as long as the service is exported.
+	SyncServiceProfile struct {
+		Name      string
+		Namespace string
+	}
+
+	// SyncPolicies is issued when the Servers, ServerAuthorizations or
+	// services of a remote namespace change, when the link mirrors policies.
+	// The Servers selecting the pods of exported services, and their
+	// ServerAuthorizations, are mirrored.
+	SyncPolicies struct {
+		Namespace string
+	}
+)
+
+// serviceFqdn returns the fully qualified name of a service in a cluster
+// domain, which is also the name of its ServiceProfile
+func serviceFqdn(name, namespace, clusterDomain string) string {
+	return fmt.Sprintf("%s.%s.svc.%s", name, namespace, clusterDomain)
+}
+
+// mirroredAuthority rewrites an authority of the target cluster into the
+// authority of its mirror service. Authorities out of the target cluster
+// domain are left unchanged.
+func (rcsw *RemoteClusterServiceWatcher) mirroredAuthority(authority string) string {
+	host, port, err := net.SplitHostPort(authority)
+	if err != nil {
+		host, port = authority, ""
+	}
+	suffix := fmt.Sprintf(".svc.%s", rcsw.link.TargetClusterDomain)
+	parts := strings.Split(strings.TrimSuffix(host, suffix), ".")
+	if !strings.HasSuffix(host, suffix) || len(parts) != 2 {
+		return authority
+	}
+
+	mirrored := serviceFqdn(rcsw.mirroredResourceName(parts[0]), parts[1], rcsw.localClusterDomain)
+	if port != "" {
+		return net.JoinHostPort(mirrored, port)
+	}
+	return mirrored
+}
+
+// isMirroredByLink returns true if a local resource was created by this
+// watcher
+func (rcsw *RemoteClusterServiceWatcher) isMirroredByLink(meta metav1.Object) bool {
+	return meta.GetLabels()[consts.MirroredResourceLabel] == "true" &&
+		meta.GetLabels()[consts.RemoteClusterNameLabel] == rcsw.link.TargetClusterName
+}
+
+// exportedService returns the remote service if it's exported, or nil
+func (rcsw *RemoteClusterServiceWatcher) exportedService(namespace, name string) (*corev1.Service, error) {
+	svc, err := rcsw.remoteService(namespace, name)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !rcsw.isExportedService(svc) {
+		return nil, nil
+	}
+	return svc, nil
+}
+
+// handleSyncServiceProfile mirrors the ServiceProfile of an exported service
+// for its mirror service, rewriting the authorities of its destination
+// overrides to the mirror services. The mirrored ServiceProfile is deleted
+// once the service or its ServiceProfile is gone, or the service isn't
+// exported anymore, or the ServiceProfiles of the target cluster can't be
+// accessed.
+func (rcsw *RemoteClusterServiceWatcher) handleSyncServiceProfile(ctx context.Context, ev *SyncServiceProfile) error {
+	localName := serviceFqdn(rcsw.mirroredResourceName(ev.Name), ev.Namespace, rcsw.localClusterDomain)
+	localSP, err := rcsw.localAPIClient.SP().Lister().ServiceProfiles(ev.Namespace).Get(localName)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return RetryableError{[]error{err}}
+		}
+		localSP = nil
+	}
+
+	svc, err := rcsw.exportedService(ev.Namespace, ev.Name)
+	if err != nil {
+		return RetryableError{[]error{err}}
+	}
+	var remoteSP *spv1alpha2.ServiceProfile
+	if svc != nil && rcsw.mirrorProfiles {
+		remoteName := serviceFqdn(ev.Name, ev.Namespace, rcsw.link.TargetClusterDomain)
+		api, err := rcsw.remoteAPI(ev.Namespace)
+		if err != nil {
+			return RetryableError{[]error{err}}
+		}
+		remoteSP, err = api.SP().Lister().ServiceProfiles(ev.Namespace).Get(remoteName)
+		if err != nil {
+			if !kerrors.IsNotFound(err) {
+				return RetryableError{[]error{err}}
+			}
+			remoteSP = nil
+		}
+	}
+
+	if remoteSP == nil {
+		if localSP == nil || !rcsw.isMirroredByLink(localSP) {
+			return nil
+		}
+		rcsw.log.Infof("Deleting mirrored ServiceProfile %s/%s", ev.Namespace, localName)
+		err := rcsw.localAPIClient.L5dClient.LinkerdV1alpha2().ServiceProfiles(ev.Namespace).Delete(ctx, localName, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return RetryableError{[]error{err}}
+		}
+		return nil
+	}
+
+	if localSP != nil && localSP.Annotations[consts.RemoteResourceVersionAnnotation] == remoteSP.ResourceVersion {
+		return nil
+	}
+
+	spec := remoteSP.Spec.DeepCopy()
+	for _, dst := range spec.DstOverrides {
+		dst.Authority = rcsw.mirroredAuthority(dst.Authority)
+	}
+	mirroredSP := &spv1alpha2.ServiceProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      localName,
+			Namespace: ev.Namespace,
+			Labels:    rcsw.getMirroredServiceLabels(nil),
+			Annotations: map[string]string{
+				consts.RemoteResourceVersionAnnotation: remoteSP.ResourceVersion,
+			},
+		},
+		Spec: *spec,
+	}
+
+	if localSP == nil {
+		if err := rcsw.mirrorNamespaceIfNecessary(ctx, ev.Namespace); err != nil {
+			return err
+		}
+		rcsw.log.Infof("Creating mirrored ServiceProfile %s/%s", ev.Namespace, localName)
+		_, err := rcsw.localAPIClient.L5dClient.LinkerdV1alpha2().ServiceProfiles(ev.Namespace).Create(ctx, mirroredSP, metav1.CreateOptions{})
+		if err != nil && !kerrors.IsAlreadyExists(err) {
+			return RetryableError{[]error{err}}
+		}
+		return nil
+	}
+
+	if !rcsw.isMirroredByLink(localSP) {
+		rcsw.log.Warnf("Skipped mirroring ServiceProfile %s/%s: a ServiceProfile not mirrored from this cluster already exists", ev.Namespace, localName)
+		return nil
+	}
+	rcsw.log.Infof("Updating mirrored ServiceProfile %s/%s", ev.Namespace, localName)
+	mirroredSP.ResourceVersion = localSP.ResourceVersion
+	_, err = rcsw.localAPIClient.L5dClient.LinkerdV1alpha2().ServiceProfiles(ev.Namespace).Update(ctx, mirroredSP, metav1.UpdateOptions{})
+	if err != nil {
+		return RetryableError{[]error{err}}
+	}
+	return nil
+}
+
+// mirroredServerSelector restricts the Server selector of a
+// ServerAuthorization to the Servers mirrored from the target cluster, which
+// carry its name as a label, so that the mirrored authorizations never apply
+// to local Servers, nor to the Servers mirrored from other clusters
+func (rcsw *RemoteClusterServiceWatcher) mirroredServerSelector(selector *metav1.LabelSelector) *metav1.LabelSelector {
+	mirrored := &metav1.LabelSelector{}
+	if selector != nil {
+		mirrored = selector.DeepCopy()
+	}
+	if mirrored.MatchLabels == nil {
+		mirrored.MatchLabels = make(map[string]string)
+	}
+	mirrored.MatchLabels[consts.RemoteClusterNameLabel] = rcsw.link.TargetClusterName
+	return mirrored
+}
+
+// mirroredPolicyLabels returns the labels of a mirrored policy, which are
+// the labels of the remote policy along with the labels of mirrored resources
+func (rcsw *RemoteClusterServiceWatcher) mirroredPolicyLabels(remote map[string]string) map[string]string {
+	mirrored := rcsw.getMirroredServiceLabels(nil)
+	for key, value := range remote {
+		if strings.HasPrefix(key, consts.SvcMirrorPrefix) {
+			continue
+		}
+		mirrored[key] = value
+	}
+	return mirrored
+}
+
+// remotePolicies returns the Servers of a remote namespace that select the
+// pods of exported services, and the ServerAuthorizations of these Servers
+func (rcsw *RemoteClusterServiceWatcher) remotePolicies(namespace string) ([]*serverv1beta1.Server, []*sazv1beta1.ServerAuthorization, error) {
+	api, err := rcsw.remoteAPI(namespace)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	services, err := api.Svc().Lister().Services(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, nil, err
+	}
+	var podLabels []labels.Set
+	for _, svc := range services {
+		if len(svc.Spec.Selector) > 0 && rcsw.isExportedService(svc) {
+			podLabels = append(podLabels, labels.Set(svc.Spec.Selector))
+		}
+	}
+	if len(podLabels) == 0 {
+		return nil, nil, nil
+	}
+
+	remoteServers, err := api.Srv().Lister().Servers(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, nil, err
+	}
+	var servers []*serverv1beta1.Server
+	for _, server := range remoteServers {
+		selector, err := metav1.LabelSelectorAsSelector(server.Spec.PodSelector)
+		if err != nil {
+			rcsw.log.Errorf("Invalid pod selector of Server %s/%s: %s", server.Namespace, server.Name, err)
+			continue
+		}
+		for _, set := range podLabels {
+			if selector.Matches(set) {
+				servers = append(servers, server)
+				break
+			}
+		}
+	}
+	if len(servers) == 0 {
+		return nil, nil, nil
+	}
+
+	remoteSazs, err := api.Saz().Lister().ServerAuthorizations(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, nil, err
+	}
+	var sazs []*sazv1beta1.ServerAuthorization
+	for _, saz := range remoteSazs {
+		var selector labels.Selector
+		if saz.Spec.Server.Selector != nil {
+			selector, err = metav1.LabelSelectorAsSelector(saz.Spec.Server.Selector)
+			if err != nil {
+				rcsw.log.Errorf("Invalid server selector of ServerAuthorization %s/%s: %s", saz.Namespace, saz.Name, err)
+				continue
+			}
+		}
+		for _, server := range servers {
+			if saz.Spec.Server.Name == server.Name || (selector != nil && selector.Matches(labels.Set(server.Labels))) {
+				sazs = append(sazs, saz)
+				break
+			}
+		}
+	}
+	return servers, sazs, nil
+}
+
+// handleSyncPolicies mirrors the Servers of a remote namespace that select
+// the pods of exported services, along with their ServerAuthorizations. The
+// pod selectors of the mirrored policies are restricted to the pods of the
+// target cluster, so that they don't apply to local pods. The mirrored
+// policies that aren't relevant anymore are deleted.
+func (rcsw *RemoteClusterServiceWatcher) handleSyncPolicies(ctx context.Context, ev *SyncPolicies) error {
+	var servers []*serverv1beta1.Server
+	var sazs []*sazv1beta1.ServerAuthorization
+	if rcsw.link.MirrorPolicies {
+		var err error
+		servers, sazs, err = rcsw.remotePolicies(ev.Namespace)
+		if err != nil {
+			return RetryableError{[]error{err}}
+		}
+	}
+	if len(servers) > 0 {
+		if err := rcsw.mirrorNamespaceIfNecessary(ctx, ev.Namespace); err != nil {
+			return err
+		}
+	}
+
+	var errors []error
+	mirroredServers := make(map[string]struct{}, len(servers))
+	for _, server := range servers {
+		mirrored := &serverv1beta1.Server{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      rcsw.mirroredResourceName(server.Name),
+				Namespace: ev.Namespace,
+				Labels:    rcsw.mirroredPolicyLabels(server.Labels),
+			},
+			Spec: serverv1beta1.ServerSpec{
+				PodSelector:   server.Spec.PodSelector.DeepCopy(),
+				Port:          server.Spec.Port,
+				ProxyProtocol: server.Spec.ProxyProtocol,
+			},
+		}
+		mirroredServers[mirrored.Name] = struct{}{}
+		if err := rcsw.createOrUpdateServer(ctx, mirrored); err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	mirroredSazs := make(map[string]struct{}, len(sazs))
+	for _, saz := range sazs {
+		mirrored := &sazv1beta1.ServerAuthorization{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      rcsw.mirroredResourceName(saz.Name),
+				Namespace: ev.Namespace,
+				Labels:    rcsw.mirroredPolicyLabels(saz.Labels),
+			},
+			Spec: *saz.Spec.DeepCopy(),
+		}
+		if mirrored.Spec.Server.Name != "" {
+			mirrored.Spec.Server.Name = rcsw.mirroredResourceName(mirrored.Spec.Server.Name)
+		}
+		if mirrored.Spec.Server.Selector != nil {
+			mirrored.Spec.Server.Selector = rcsw.mirroredServerSelector(mirrored.Spec.Server.Selector)
+		}
+		mirroredSazs[mirrored.Name] = struct{}{}
+		if err := rcsw.createOrUpdateServerAuthorization(ctx, mirrored); err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	selector := labels.Set(rcsw.getMirroredServiceLabels(nil)).AsSelector()
+	localSazs, err := rcsw.localAPIClient.Saz().Lister().ServerAuthorizations(ev.Namespace).List(selector)
+	if err != nil {
+		errors = append(errors, err)
+	}
+	for _, saz := range localSazs {
+		if _, ok := mirroredSazs[saz.Name]; ok {
+			continue
+		}
+		rcsw.log.Infof("Deleting mirrored ServerAuthorization %s/%s", saz.Namespace, saz.Name)
+		err := rcsw.localAPIClient.L5dClient.ServerauthorizationV1beta1().ServerAuthorizations(saz.Namespace).Delete(ctx, saz.Name, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			errors = append(errors, err)
+		}
+	}
+	localServers, err := rcsw.localAPIClient.Srv().Lister().Servers(ev.Namespace).List(selector)
+	if err != nil {
+		errors = append(errors, err)
+	}
+	for _, server := range localServers {
+		if _, ok := mirroredServers[server.Name]; ok {
+			continue
+		}
+		rcsw.log.Infof("Deleting mirrored Server %s/%s", server.Namespace, server.Name)
+		err := rcsw.localAPIClient.L5dClient.ServerV1beta1().Servers(server.Namespace).Delete(ctx, server.Name, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			errors = append(errors, err)
+		}
+	}
+
+	if len(errors) > 0 {
+		return RetryableError{errors}
+	}
+	return nil
+}
+
+func (rcsw *RemoteClusterServiceWatcher) createOrUpdateServer(ctx context.Context, server *serverv1beta1.Server) error {
+	local, err := rcsw.localAPIClient.Srv().Lister().Servers(server.Namespace).Get(server.Name)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return err
+		}
+		rcsw.log.Infof("Creating mirrored Server %s/%s", server.Namespace, server.Name)
+		_, err := rcsw.localAPIClient.L5dClient.ServerV1beta1().Servers(server.Namespace).Create(ctx, server, metav1.CreateOptions{})
+		if err != nil && !kerrors.IsAlreadyExists(err) {
+			return err
+		}
+		return nil
+	}
+
+	if !rcsw.isMirroredByLink(local) {
+		rcsw.log.Warnf("Skipped mirroring Server %s/%s: a Server not mirrored from this cluster already exists", server.Namespace, server.Name)
+		return nil
+	}
+	if reflect.DeepEqual(local.Labels, server.Labels) && reflect.DeepEqual(local.Spec, server.Spec) {
+		return nil
+	}
+	rcsw.log.Infof("Updating mirrored Server %s/%s", server.Namespace, server.Name)
+	server.ResourceVersion = local.ResourceVersion
+	_, err = rcsw.localAPIClient.L5dClient.ServerV1beta1().Servers(server.Namespace).Update(ctx, server, metav1.UpdateOptions{})
+	return err
+}
+
+func (rcsw *RemoteClusterServiceWatcher) createOrUpdateServerAuthorization(ctx context.Context, saz *sazv1beta1.ServerAuthorization) error {
+	local, err := rcsw.localAPIClient.Saz().Lister().ServerAuthorizations(saz.Namespace).Get(saz.Name)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return err
+		}
+		rcsw.log.Infof("Creating mirrored ServerAuthorization %s/%s", saz.Namespace, saz.Name)
+		_, err := rcsw.localAPIClient.L5dClient.ServerauthorizationV1beta1().ServerAuthorizations(saz.Namespace).Create(ctx, saz, metav1.CreateOptions{})
+		if err != nil && !kerrors.IsAlreadyExists(err) {
+			return err
+		}
+		return nil
+	}
+
+	if !rcsw.isMirroredByLink(local) {
+		rcsw.log.Warnf("Skipped mirroring ServerAuthorization %s/%s: a ServerAuthorization not mirrored from this cluster already exists", saz.Namespace, saz.Name)
+		return nil
+	}
+	if reflect.DeepEqual(local.Labels, saz.Labels) && reflect.DeepEqual(local.Spec, saz.Spec) {
+		return nil
+	}
+	rcsw.log.Infof("Updating mirrored ServerAuthorization %s/%s", saz.Namespace, saz.Name)
+	saz.ResourceVersion = local.ResourceVersion
+	_, err = rcsw.localAPIClient.L5dClient.ServerauthorizationV1beta1().ServerAuthorizations(saz.Namespace).Update(ctx, saz, metav1.UpdateOptions{})
+	return err
+}
+
+// cleanupOrphanedMirroredResources enqueues the syncing of the mirrored
+// ServiceProfiles and policies, so that the ones whose remote resources went
+// away while the watcher wasn't running get deleted
+func (rcsw *RemoteClusterServiceWatcher) cleanupOrphanedMirroredResources() error {
+	selector := labels.Set(rcsw.getMirroredServiceLabels(nil)).AsSelector()
+	sps, err := rcsw.localAPIClient.SP().Lister().List(selector)
+	if err != nil {
+		return RetryableError{[]error{err}}
+	}
+	for _, sp := range sps {
+		suffix := fmt.Sprintf("-%s.%s.svc.%s", rcsw.link.TargetClusterName, sp.Namespace, rcsw.localClusterDomain)
+		if !strings.HasSuffix(sp.Name, suffix) {
+			continue
+		}
+		rcsw.eventsQueue.Add(&SyncServiceProfile{
+			Name:      strings.TrimSuffix(sp.Name, suffix),
+			Namespace: sp.Namespace,
+		})
+	}
+
+	servers, err := rcsw.localAPIClient.Srv().Lister().List(selector)
+	if err != nil {
+		return RetryableError{[]error{err}}
+	}
+	namespaces := make(map[string]struct{})
+	for _, server := range servers {
+		namespaces[server.Namespace] = struct{}{}
+	}
+	for ns := range namespaces {
+		rcsw.eventsQueue.Add(&SyncPolicies{Namespace: ns})
+	}
+	return nil
+}
+
+// cleanupMirroredProfilesAndPolicies deletes all the ServiceProfiles and
+// policies mirrored from the target cluster
+func (rcsw *RemoteClusterServiceWatcher) cleanupMirroredProfilesAndPolicies(ctx context.Context) []error {
+	selector := labels.Set(rcsw.getMirroredServiceLabels(nil)).AsSelector()
+	var errors []error
+
+	sps, err := rcsw.localAPIClient.SP().Lister().List(selector)
+	if err != nil {
+		return []error{fmt.Errorf("could not retrieve mirrored ServiceProfiles that need cleaning up: %s", err)}
+	}
+	for _, sp := range sps {
+		if err := rcsw.localAPIClient.L5dClient.LinkerdV1alpha2().ServiceProfiles(sp.Namespace).Delete(ctx, sp.Name, metav1.DeleteOptions{}); err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			errors = append(errors, fmt.Errorf("Could not delete ServiceProfile %s/%s: %s", sp.Namespace, sp.Name, err))
+		} else {
+			rcsw.log.Infof("Deleted ServiceProfile %s/%s", sp.Namespace, sp.Name)
+		}
+	}
+
+	sazs, err := rcsw.localAPIClient.Saz().Lister().List(selector)
+	if err != nil {
+		return append(errors, fmt.Errorf("could not retrieve mirrored ServerAuthorizations that need cleaning up: %s", err))
+	}
+	for _, saz := range sazs {
+		if err := rcsw.localAPIClient.L5dClient.ServerauthorizationV1beta1().ServerAuthorizations(saz.Namespace).Delete(ctx, saz.Name, metav1.DeleteOptions{}); err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			errors = append(errors, fmt.Errorf("Could not delete ServerAuthorization %s/%s: %s", saz.Namespace, saz.Name, err))
+		} else {
+			rcsw.log.Infof("Deleted ServerAuthorization %s/%s", saz.Namespace, saz.Name)
+		}
+	}
+
+	servers, err := rcsw.localAPIClient.Srv().Lister().List(selector)
+	if err != nil {
+		return append(errors, fmt.Errorf("could not retrieve mirrored Servers that need cleaning up: %s", err))
+	}
+	for _, server := range servers {
+		if err := rcsw.localAPIClient.L5dClient.ServerV1beta1().Servers(server.Namespace).Delete(ctx, server.Name, metav1.DeleteOptions{}); err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			errors = append(errors, fmt.Errorf("Could not delete Server %s/%s: %s", server.Namespace, server.Name, err))
+		} else {
+			rcsw.log.Infof("Deleted Server %s/%s", server.Namespace, server.Name)
+		}
+	}
+
+	return errors
+}
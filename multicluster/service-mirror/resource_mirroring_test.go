@@ -0,0 +1,120 @@
+package servicemirror
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	consts "github.com/linkerd/linkerd2/pkg/k8s"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func TestSyncServiceProfileMirroring(t *testing.T) {
+	t.Run("mirrors the ServiceProfile of an exported service", func(t *testing.T) {
+		q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		localAPI, err := syncServiceProfile.runEnvironment(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sp, err := localAPI.L5dClient.LinkerdV1alpha2().ServiceProfiles("emojivoto").Get(context.Background(), "web-remote.emojivoto.svc.cluster.local", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Could not find mirrored ServiceProfile: %s", err)
+		}
+
+		expectedLabels := map[string]string{
+			consts.MirroredResourceLabel:  "true",
+			consts.RemoteClusterNameLabel: clusterName,
+		}
+		if !reflect.DeepEqual(sp.Labels, expectedLabels) {
+			t.Fatalf("Expected labels %v, got %v", expectedLabels, sp.Labels)
+		}
+		if version := sp.Annotations[consts.RemoteResourceVersionAnnotation]; version != "10" {
+			t.Fatalf("Expected remote resource version 10, got %q", version)
+		}
+		if len(sp.Spec.Routes) != 1 || sp.Spec.Routes[0].Name != "GET /api/list" {
+			t.Fatalf("Expected the routes of the remote ServiceProfile, got %v", sp.Spec.Routes)
+		}
+
+		expectedAuthorities := []string{
+			"web-remote.emojivoto.svc.cluster.local:80",
+			"web-canary-remote.emojivoto.svc.cluster.local:80",
+			"web.example.com",
+		}
+		authorities := []string{}
+		for _, dst := range sp.Spec.DstOverrides {
+			authorities = append(authorities, dst.Authority)
+		}
+		if !reflect.DeepEqual(authorities, expectedAuthorities) {
+			t.Fatalf("Expected dstOverrides authorities %v, got %v", expectedAuthorities, authorities)
+		}
+	})
+
+	t.Run("deletes the mirrored ServiceProfile of a service that isn't exported anymore", func(t *testing.T) {
+		q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		localAPI, err := syncServiceProfileNotExported.runEnvironment(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = localAPI.L5dClient.LinkerdV1alpha2().ServiceProfiles("emojivoto").Get(context.Background(), "web-remote.emojivoto.svc.cluster.local", metav1.GetOptions{})
+		if !kerrors.IsNotFound(err) {
+			t.Fatalf("Expected the mirrored ServiceProfile to be deleted, got %v", err)
+		}
+	})
+}
+
+func TestSyncPoliciesMirroring(t *testing.T) {
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	localAPI, err := syncPolicies.runEnvironment(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	servers, err := localAPI.L5dClient.ServerV1beta1().Servers("emojivoto").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(servers.Items) != 1 {
+		t.Fatalf("Expected 1 mirrored Server, got %d", len(servers.Items))
+	}
+	server := servers.Items[0]
+	if server.Name != "web-http-remote" {
+		t.Fatalf("Expected Server web-http-remote, got %s", server.Name)
+	}
+	expectedSelector := map[string]string{
+		"app": "web",
+	}
+	if !reflect.DeepEqual(server.Spec.PodSelector.MatchLabels, expectedSelector) {
+		t.Fatalf("Expected pod selector %v, got %v", expectedSelector, server.Spec.PodSelector.MatchLabels)
+	}
+	expectedLabels := map[string]string{
+		"app":                         "web",
+		consts.MirroredResourceLabel:  "true",
+		consts.RemoteClusterNameLabel: clusterName,
+	}
+	if !reflect.DeepEqual(server.Labels, expectedLabels) {
+		t.Fatalf("Expected labels %v, got %v", expectedLabels, server.Labels)
+	}
+
+	sazs, err := localAPI.L5dClient.ServerauthorizationV1beta1().ServerAuthorizations("emojivoto").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mirrored := map[string]string{}
+	for _, saz := range sazs.Items {
+		mirrored[saz.Name] = saz.Spec.Server.Name
+		if saz.Spec.Server.Selector != nil && saz.Spec.Server.Selector.MatchLabels[consts.RemoteClusterNameLabel] != clusterName {
+			t.Fatalf("Expected the server selector of %s to be restricted to the target cluster, got %v", saz.Name, saz.Spec.Server.Selector)
+		}
+	}
+	expectedSazs := map[string]string{
+		"web-public-remote":   "web-http-remote",
+		"web-selected-remote": "",
+	}
+	if !reflect.DeepEqual(mirrored, expectedSazs) {
+		t.Fatalf("Expected ServerAuthorizations %v, got %v", expectedSazs, mirrored)
+	}
+}
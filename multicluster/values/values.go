@@ -18,22 +18,28 @@ const (
 
 // Values contains the top-level elements in the Helm charts
 type Values struct {
-	CliVersion                     string   `json:"cliVersion"`
-	ControllerImage                string   `json:"controllerImage"`
-	ControllerImageVersion         string   `json:"controllerImageVersion"`
-	Gateway                        *Gateway `json:"gateway"`
-	IdentityTrustDomain            string   `json:"identityTrustDomain"`
-	LinkerdNamespace               string   `json:"linkerdNamespace"`
-	LinkerdVersion                 string   `json:"linkerdVersion"`
-	ProxyOutboundPort              uint32   `json:"proxyOutboundPort"`
-	ServiceMirror                  bool     `json:"serviceMirror"`
-	LogLevel                       string   `json:"logLevel"`
-	ServiceMirrorRetryLimit        uint32   `json:"serviceMirrorRetryLimit"`
-	ServiceMirrorUID               int64    `json:"serviceMirrorUID"`
-	RemoteMirrorServiceAccount     bool     `json:"remoteMirrorServiceAccount"`
-	RemoteMirrorServiceAccountName string   `json:"remoteMirrorServiceAccountName"`
-	TargetClusterName              string   `json:"targetClusterName"`
-	EnablePodAntiAffinity          bool     `json:"enablePodAntiAffinity"`
+	CliVersion                           string          `json:"cliVersion"`
+	ClusterDomain                        string          `json:"clusterDomain"`
+	ControllerImage                      string          `json:"controllerImage"`
+	ControllerImageVersion               string          `json:"controllerImageVersion"`
+	Gateway                              *Gateway        `json:"gateway"`
+	IdentityTrustDomain                  string          `json:"identityTrustDomain"`
+	LinkerdNamespace                     string          `json:"linkerdNamespace"`
+	LinkerdVersion                       string          `json:"linkerdVersion"`
+	ProxyOutboundPort                    uint32          `json:"proxyOutboundPort"`
+	ServiceMirror                        bool            `json:"serviceMirror"`
+	LogLevel                             string          `json:"logLevel"`
+	ServiceMirrorRetryLimit              uint32          `json:"serviceMirrorRetryLimit"`
+	ServiceMirrorUID                     int64           `json:"serviceMirrorUID"`
+	ServiceMirrorReplicas                uint32          `json:"serviceMirrorReplicas,omitempty"`
+	LeaderElection                       *LeaderElection `json:"leaderElection,omitempty"`
+	AdminTLS                             *AdminTLS       `json:"adminTLS,omitempty"`
+	RemoteMirrorServiceAccount           bool            `json:"remoteMirrorServiceAccount"`
+	RemoteMirrorServiceAccountName       string          `json:"remoteMirrorServiceAccountName"`
+	RemoteMirrorServiceAccountNamespaces []string        `json:"remoteMirrorServiceAccountNamespaces"`
+	RemoteVizNamespace                   string          `json:"remoteVizNamespace"`
+	TargetClusterName                    string          `json:"targetClusterName"`
+	EnablePodAntiAffinity                bool            `json:"enablePodAntiAffinity"`
 }
 
 // Gateway contains all options related to the Gateway Service
@@ -49,6 +55,22 @@ type Gateway struct {
 	LoadBalancerIP     string            `json:"loadBalancerIP"`
 }
 
+// LeaderElection contains the options of the election of the Service Mirror
+// leader
+type LeaderElection struct {
+	Enabled       bool   `json:"enabled"`
+	LeaseDuration string `json:"leaseDuration"`
+	RenewDeadline string `json:"renewDeadline"`
+	RetryPeriod   string `json:"retryPeriod"`
+}
+
+// AdminTLS contains the options to serve the admin server of the Service
+// Mirror over TLS
+type AdminTLS struct {
+	SecretName        string `json:"secretName"`
+	RequireClientCert bool   `json:"requireClientCert"`
+}
+
 // Probe contains all options for the Probe Service
 type Probe struct {
 	Path     string `json:"path"`
@@ -45,7 +45,13 @@ func PublicIPToString(ip *l5dNetPb.IPAddress) string {
 }
 
 // ProxyAddressToString formats a Proxy API TCPAddress as a string.
+//
+// If Ipv6, the IP address is enclosed in square brackets followed by the
+// port.
 func ProxyAddressToString(addr *pb.TcpAddress) string {
+	if addr.GetIp().GetIpv6() != nil {
+		return fmt.Sprintf("[%s]:%d", ProxyIPToString(addr.GetIp()), addr.GetPort())
+	}
 	octects := decodeIPToOctets(addr.GetIp().GetIpv4())
 	return fmt.Sprintf("%d.%d.%d.%d:%d", octects[0], octects[1], octects[2], octects[3], addr.GetPort())
 }
@@ -61,6 +67,12 @@ func ProxyAddressesToString(addrs []pb.TcpAddress) string {
 
 // ProxyIPToString formats a Proxy API IPAddress as a string.
 func ProxyIPToString(ip *pb.IPAddress) string {
+	if ipv6 := ip.GetIpv6(); ipv6 != nil {
+		b := make([]byte, 16)
+		binary.BigEndian.PutUint64(b[:8], ipv6.GetFirst())
+		binary.BigEndian.PutUint64(b[8:], ipv6.GetLast())
+		return net.IP(b).String()
+	}
 	octets := decodeIPToOctets(ip.GetIpv4())
 	return fmt.Sprintf("%d.%d.%d.%d", octets[0], octets[1], octets[2], octets[3])
 }
@@ -92,6 +104,33 @@ func ParseProxyIPV4(ip string) (*pb.IPAddress, error) {
 	return ProxyIPV4(octets[0], octets[1], octets[2], octets[3]), nil
 }
 
+// ProxyIPV6 encodes the 16 bytes of an IPv6 address as a Proxy API
+// IPAddress. The bytes are ordered big-endian.
+func ProxyIPV6(ip net.IP) *pb.IPAddress {
+	ip = ip.To16()
+	return &pb.IPAddress{
+		Ip: &pb.IPAddress_Ipv6{
+			Ipv6: &pb.IPv6{
+				First: binary.BigEndian.Uint64(ip[:8]),
+				Last:  binary.BigEndian.Uint64(ip[8:]),
+			},
+		},
+	}
+}
+
+// ParseProxyIP parses an IPv4 or IPv6 address string into a Proxy API
+// IPAddress.
+func ParseProxyIP(ip string) (*pb.IPAddress, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return nil, fmt.Errorf("Invalid IP address: %s", ip)
+	}
+	if ipv4 := parsed.To4(); ipv4 != nil {
+		return ProxyIPV4(ipv4[0], ipv4[1], ipv4[2], ipv4[3]), nil
+	}
+	return ProxyIPV6(parsed), nil
+}
+
 // PublicIPV4 encodes 4 octets as a Viz API IPAddress.
 func PublicIPV4(a1, a2, a3, a4 uint8) *l5dNetPb.IPAddress {
 	ip := (uint32(a1) << 24) | (uint32(a2) << 16) | (uint32(a3) << 8) | uint32(a4)
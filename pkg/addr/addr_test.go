@@ -122,6 +122,18 @@ func TestProxyIPToString(t *testing.T) {
 			},
 			expected: "192.168.0.1",
 		},
+		{
+			name: "ipv6",
+			ip: &pb.IPAddress{
+				Ip: &pb.IPAddress_Ipv6{
+					Ipv6: &pb.IPv6{
+						First: 0xfd00000000000000,
+						Last:  1,
+					},
+				},
+			},
+			expected: "fd00::1",
+		},
 		{
 			name:     "nil",
 			ip:       nil,
@@ -240,6 +252,53 @@ func TestParseProxyIPV4(t *testing.T) {
 	}
 }
 
+func TestParseProxyIP(t *testing.T) {
+	var testCases = []struct {
+		ip      string
+		expAddr *pb.IPAddress
+		expErr  bool
+	}{
+		{
+			ip:     "10.0",
+			expErr: true,
+		},
+		{
+			ip:     "fd00::1::2",
+			expErr: true,
+		},
+		{
+			ip: "10.10.10.10",
+			expAddr: &pb.IPAddress{
+				Ip: &pb.IPAddress_Ipv4{Ipv4: 168430090},
+			},
+		},
+		{
+			ip: "fd00::a:1",
+			expAddr: &pb.IPAddress{
+				Ip: &pb.IPAddress_Ipv6{Ipv6: &pb.IPv6{First: 0xfd00000000000000, Last: 0xa0001}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		res, err := ParseProxyIP(testCase.ip)
+		if testCase.expErr && err == nil {
+			t.Fatalf("expected get err, but get nil")
+		}
+		if !testCase.expErr {
+			if err != nil {
+				t.Fatalf("Unexpected err %v", err)
+			}
+			if !proto.Equal(res, testCase.expAddr) {
+				t.Fatalf("Unexpected TCP Address: [%+v] expected: [%+v]", res, testCase.expAddr)
+			}
+			if ProxyIPToString(res) != testCase.ip {
+				t.Fatalf("Unexpected IP string: %s expected: %s", ProxyIPToString(res), testCase.ip)
+			}
+		}
+	}
+}
+
 func TestParsePublicIPV4(t *testing.T) {
 	var testCases = []struct {
 		ip      string
@@ -300,6 +359,13 @@ func TestProxyAddressToString(t *testing.T) {
 			},
 			expStr: "0.0.255.255:5678",
 		},
+		{
+			addr: &pb.TcpAddress{
+				Ip:   &pb.IPAddress{Ip: &pb.IPAddress_Ipv6{Ipv6: &pb.IPv6{First: 0xfd00000000000000, Last: 0x10}}},
+				Port: 8080,
+			},
+			expStr: "[fd00::10]:8080",
+		},
 	}
 
 	for _, testCase := range testCases {
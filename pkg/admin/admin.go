@@ -2,21 +2,33 @@ package admin
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 	"net/http/pprof"
 	"strings"
 
+	"github.com/linkerd/linkerd2/pkg/logging"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 type handler struct {
-	promHandler http.Handler
+	promHandler     http.Handler
+	logLevelHandler http.Handler
+	routes          map[string]http.Handler
 }
 
 // NewServer returns an initialized `http.Server`, configured to listen on an address.
 func NewServer(addr string) *http.Server {
+	return NewServerWithRoutes(addr, nil)
+}
+
+// NewServerWithRoutes returns an initialized `http.Server` like NewServer,
+// which also serves the component specific handlers at their path.
+func NewServerWithRoutes(addr string, routes map[string]http.Handler) *http.Server {
 	h := &handler{
-		promHandler: promhttp.Handler(),
+		promHandler:     promhttp.Handler(),
+		logLevelHandler: logging.NewHandler(),
+		routes:          routes,
 	}
 
 	return &http.Server{
@@ -34,6 +46,14 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		h.servePing(w)
 	case "/ready":
 		h.serveReady(w)
+	case "/log-level":
+		// the admin port is reachable from the other pods, so the log levels
+		// can only be changed locally, e.g. through kubectl port-forward
+		if !isLoopback(req.RemoteAddr) {
+			http.Error(w, "the log level can only be accessed from localhost", http.StatusForbidden)
+			return
+		}
+		h.logLevelHandler.ServeHTTP(w, req)
 	case fmt.Sprintf("%scmdline", debugPathPrefix):
 		pprof.Cmdline(w, req)
 	case fmt.Sprintf("%sprofile", debugPathPrefix):
@@ -43,7 +63,9 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	case fmt.Sprintf("%ssymbol", debugPathPrefix):
 		pprof.Symbol(w, req)
 	default:
-		if strings.HasPrefix(req.URL.Path, "/debug/pprof/") {
+		if route, ok := h.routes[req.URL.Path]; ok {
+			route.ServeHTTP(w, req)
+		} else if strings.HasPrefix(req.URL.Path, "/debug/pprof/") {
 			pprof.Index(w, req)
 		} else {
 			http.NotFound(w, req)
@@ -58,3 +80,13 @@ func (h *handler) servePing(w http.ResponseWriter) {
 func (h *handler) serveReady(w http.ResponseWriter) {
 	w.Write([]byte("ok\n"))
 }
+
+// isLoopback returns whether a request's remote address is a loopback address
+func isLoopback(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
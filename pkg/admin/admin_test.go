@@ -0,0 +1,33 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLogLevelIsLocal(t *testing.T) {
+	server := NewServer(":0")
+
+	testCases := []struct {
+		remoteAddr string
+		expected   int
+	}{
+		{"127.0.0.1:51234", http.StatusOK},
+		{"[::1]:51234", http.StatusOK},
+		{"10.42.0.12:51234", http.StatusForbidden},
+		{"invalid", http.StatusForbidden},
+	}
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.remoteAddr, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/log-level", nil)
+			req.RemoteAddr = tc.remoteAddr
+			rsp := httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+			if rsp.Code != tc.expected {
+				t.Fatalf("Expected status %d, got %d", tc.expected, rsp.Code)
+			}
+		})
+	}
+}
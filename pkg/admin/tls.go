@@ -0,0 +1,108 @@
+package admin
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sync/atomic"
+
+	pkgTls "github.com/linkerd/linkerd2/pkg/tls"
+	log "github.com/sirupsen/logrus"
+)
+
+// TLSOptions enables TLS on the admin server, so that its metrics and debug
+// endpoints aren't served in plaintext on the pod network
+type TLSOptions struct {
+	// CredsDir is the directory holding the tls.crt and tls.key PEM files of
+	// the server, e.g. a secret issued off the mesh's trust anchors; TLS is
+	// disabled when it's empty
+	CredsDir string
+
+	// ClientCAPath is the PEM bundle the client certificates are verified
+	// against. When it's set, the endpoints other than the probes' require a
+	// verified client certificate; the probes' are still served without one,
+	// as the kubelet doesn't present any
+	ClientCAPath string
+
+	// CryptoMode restricts the TLS versions and cipher suites of the server
+	CryptoMode pkgTls.CryptoMode
+}
+
+// probePaths are the paths served without a client certificate
+var probePaths = map[string]struct{}{
+	"/ping":   {},
+	"/ready":  {},
+	"/health": {},
+}
+
+// EnableTLS configures server to serve TLS with the credentials of options,
+// which are reloaded when they change. It's a no-op when options.CredsDir is
+// empty.
+func EnableTLS(ctx context.Context, server *http.Server, options *TLSOptions) error {
+	if options == nil || options.CredsDir == "" {
+		return nil
+	}
+
+	updateEvent := make(chan struct{})
+	errEvent := make(chan error)
+	watcher := pkgTls.NewFsCredsWatcher(options.CredsDir, updateEvent, errEvent).
+		WithFilePaths(filepath.Join(options.CredsDir, "tls.crt"), filepath.Join(options.CredsDir, "tls.key"))
+	var certValue atomic.Value
+	if err := watcher.UpdateCert(&certValue); err != nil {
+		return fmt.Errorf("failed to read the admin server credentials: %s", err)
+	}
+	go func() {
+		if err := watcher.StartWatching(ctx); err != nil {
+			log.Errorf("Failed to watch the admin server credentials: %s", err)
+		}
+	}()
+	go watcher.ProcessEvents(log.WithField("component", "admin"), &certValue, updateEvent, errEvent)
+
+	server.TLSConfig = &tls.Config{
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return certValue.Load().(*tls.Certificate), nil
+		},
+	}
+	if options.ClientCAPath != "" {
+		caPEM, err := ioutil.ReadFile(options.ClientCAPath)
+		if err != nil {
+			return fmt.Errorf("failed to read the admin server client CA: %s", err)
+		}
+		clientCAs, err := pkgTls.DecodePEMCertPool(string(caPEM))
+		if err != nil {
+			return fmt.Errorf("failed to decode the admin server client CA: %s", err)
+		}
+		server.TLSConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		server.TLSConfig.ClientCAs = clientCAs
+		server.Handler = requireClientCert(server.Handler)
+	}
+	options.CryptoMode.ConfigureServer(server.TLSConfig)
+
+	return nil
+}
+
+// requireClientCert wraps h so that the requests to paths other than the
+// probes' are rejected unless they come with a verified client certificate
+func requireClientCert(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if _, ok := probePaths[req.URL.Path]; !ok {
+			if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 {
+				http.Error(w, "a client certificate is required", http.StatusUnauthorized)
+				return
+			}
+		}
+		h.ServeHTTP(w, req)
+	})
+}
+
+// ListenAndServe serves server over TLS when it's been enabled with
+// EnableTLS, and in plaintext otherwise
+func ListenAndServe(server *http.Server) error {
+	if server.TLSConfig != nil {
+		return server.ListenAndServeTLS("", "")
+	}
+	return server.ListenAndServe()
+}
@@ -0,0 +1,110 @@
+package admin
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	pkgTls "github.com/linkerd/linkerd2/pkg/tls"
+)
+
+func TestEnableTLS(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ca, err := pkgTls.GenerateRootCAWithDefaults("admin-test-ca")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	serverCred, err := ca.GenerateEndEntityCred("admin.linkerd.svc")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	clientCred, err := ca.GenerateEndEntityCred("prometheus.linkerd-viz.svc")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	dir := t.TempDir()
+	for file, content := range map[string]string{
+		"tls.crt": serverCred.EncodeCertificatePEM(),
+		"tls.key": serverCred.EncodePrivateKeyPEM(),
+		"ca.crt":  ca.Cred.EncodeCertificatePEM(),
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, file), []byte(content), 0600); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	plaintext := NewServer("localhost:0")
+	if err := EnableTLS(ctx, plaintext, &TLSOptions{}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if plaintext.TLSConfig != nil {
+		t.Fatal("Expected TLS to be left disabled without credentials")
+	}
+
+	if err := EnableTLS(ctx, NewServer("localhost:0"), &TLSOptions{CredsDir: t.TempDir()}); err == nil {
+		t.Fatal("Expected an error without tls.crt and tls.key")
+	}
+
+	server := NewServer("localhost:0")
+	err = EnableTLS(ctx, server, &TLSOptions{
+		CredsDir:     dir,
+		ClientCAPath: filepath.Join(dir, "ca.crt"),
+		CryptoMode:   pkgTls.CryptoModeStrict,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	go server.ServeTLS(lis, "", "")
+	defer server.Close()
+
+	clientCert, err := tls.X509KeyPair([]byte(clientCred.EncodeCertificatePEM()), []byte(clientCred.EncodePrivateKeyPEM()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	testCases := []struct {
+		path     string
+		cert     bool
+		expected int
+	}{
+		{"/ready", false, http.StatusOK},
+		{"/ping", false, http.StatusOK},
+		{"/metrics", false, http.StatusUnauthorized},
+		{"/metrics", true, http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%s/%t", tc.path, tc.cert), func(t *testing.T) {
+			config := &tls.Config{
+				RootCAs:    ca.Cred.Crt.CertPool(),
+				ServerName: "admin.linkerd.svc",
+			}
+			if tc.cert {
+				config.Certificates = []tls.Certificate{clientCert}
+			}
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: config}}
+
+			rsp, err := client.Get(fmt.Sprintf("https://%s%s", lis.Addr(), tc.path))
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			rsp.Body.Close()
+			if rsp.StatusCode != tc.expected {
+				t.Fatalf("Expected status %d, got %d", tc.expected, rsp.StatusCode)
+			}
+		})
+	}
+}
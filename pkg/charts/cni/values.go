@@ -30,6 +30,7 @@ type Values struct {
 	DestCNINetDir       string `json:"destCNINetDir"`
 	DestCNIBinDir       string `json:"destCNIBinDir"`
 	UseWaitFlag         bool   `json:"useWaitFlag"`
+	IPv6                bool   `json:"ipv6"`
 	PriorityClassName   string `json:"priorityClassName"`
 	ProxyAdminPort      string `json:"proxyAdminPort"`
 	ProxyControlPort    string `json:"proxyControlPort"`
@@ -42,6 +42,7 @@ type (
 		ControllerImageVersion       string              `json:"controllerImageVersion"`
 		ControllerLogLevel           string              `json:"controllerLogLevel"`
 		ControllerLogFormat          string              `json:"controllerLogFormat"`
+		ControllerCryptoMode         string              `json:"controllerCryptoMode"`
 		ProxyContainerName           string              `json:"proxyContainerName"`
 		HighAvailability             bool                `json:"highAvailability"`
 		CNIEnabled                   bool                `json:"cniEnabled"`
@@ -64,13 +65,16 @@ type (
 		ProxyInit        *ProxyInit        `json:"proxyInit"`
 		Identity         *Identity         `json:"identity"`
 		DebugContainer   *DebugContainer   `json:"debugContainer"`
-		ProxyInjector    *Webhook          `json:"proxyInjector"`
+		ProxyInjector    *ProxyInjector    `json:"proxyInjector"`
 		ProfileValidator *Webhook          `json:"profileValidator"`
 		PolicyValidator  *Webhook          `json:"policyValidator"`
 		NodeSelector     map[string]string `json:"nodeSelector"`
 		Tolerations      []interface{}     `json:"tolerations"`
 		Stage            string            `json:"stage"`
 
+		Destination *Destination `json:"destination"`
+		AdminTLS    *AdminTLS    `json:"adminTLS"`
+
 		DestinationResources   *Resources `json:"destinationResources"`
 		HeartbeatResources     *Resources `json:"heartbeatResources"`
 		IdentityResources      *Resources `json:"identityResources"`
@@ -81,6 +85,13 @@ type (
 		ProxyInjectorProxyResources *Resources `json:"proxyInjectorProxyResources"`
 	}
 
+	// AdminTLS contains the fields to serve the admin servers of the control
+	// plane components over TLS
+	AdminTLS struct {
+		SecretName        string `json:"secretName"`
+		RequireClientCert bool   `json:"requireClientCert"`
+	}
+
 	// ConfigJSONs is the JSON encoding of the Linkerd configuration
 	ConfigJSONs struct {
 		Global  string `json:"global"`
@@ -92,7 +103,10 @@ type (
 	Proxy struct {
 		Capabilities *Capabilities `json:"capabilities"`
 		// This should match .Resources.CPU.Limit, but must be a whole number
-		Cores                         int64            `json:"cores,omitempty"`
+		Cores int64 `json:"cores,omitempty"`
+		// When set and Cores is zero, the proxy runs one thread per core of
+		// its CPU limit, or of the node when it has no limit
+		CoresFromNode                 bool             `json:"coresFromNode,omitempty"`
 		DisableIdentity               bool             `json:"disableIdentity"`
 		EnableExternalProfiles        bool             `json:"enableExternalProfiles"`
 		Image                         *Image           `json:"image"`
@@ -112,6 +126,33 @@ type (
 		OpaquePorts                   string           `json:"opaquePorts"`
 		Await                         bool             `json:"await"`
 		DefaultInboundPolicy          string           `json:"defaultInboundPolicy"`
+		// Set by the injector when the application containers' commands are
+		// wrapped with linkerd-await
+		WrapCommand bool `json:"wrapCommand,omitempty"`
+		// Environment variables appended to the proxy container's ones
+		AdditionalEnv []corev1.EnvVar `json:"additionalEnv,omitempty"`
+		// Set by the injector to the workload the proxy is injected into
+		Workload *Workload `json:"workload,omitempty"`
+	}
+
+	// Destination contains the fields to configure the destination controller
+	Destination struct {
+		ConfigMap                 string          `json:"configMap"`
+		OpaquePortNamePrefix      string          `json:"opaquePortNamePrefix"`
+		MaxAuthoritiesPerClient   int             `json:"maxAuthoritiesPerClient"`
+		DefaultPort               uint            `json:"defaultPort"`
+		ClockSkewWarningThreshold string          `json:"clockSkewWarningThreshold"`
+		LeaderElection            *LeaderElection `json:"leaderElection"`
+		StreamHashing             bool            `json:"streamHashing"`
+	}
+
+	// LeaderElection contains the fields to configure the election of a
+	// leader among the replicas of a controller
+	LeaderElection struct {
+		Enabled       bool   `json:"enabled"`
+		LeaseDuration string `json:"leaseDuration"`
+		RenewDeadline string `json:"renewDeadline"`
+		RetryPeriod   string `json:"retryPeriod"`
 	}
 
 	// ProxyInit contains the fields to set the proxy-init container
@@ -147,6 +188,7 @@ type (
 		Name       string `json:"name"`
 		PullPolicy string `json:"pullPolicy"`
 		Version    string `json:"version"`
+		Digest     string `json:"digest,omitempty"`
 	}
 
 	// Ports contains all the port-related setups
@@ -163,6 +205,12 @@ type (
 		Request string `json:"request"`
 	}
 
+	// Workload identifies the workload a proxy is injected into
+	Workload struct {
+		Kind string `json:"kind"`
+		Name string `json:"name"`
+	}
+
 	// Capabilities contains the SecurityContext capabilities to add/drop into the injected
 	// containers
 	Capabilities struct {
@@ -187,8 +235,21 @@ type (
 	// Identity contains the fields to set the identity variables in the proxy
 	// sidecar container
 	Identity struct {
-		ServiceAccountTokenProjection bool    `json:"serviceAccountTokenProjection"`
-		Issuer                        *Issuer `json:"issuer"`
+		ServiceAccountTokenProjection bool            `json:"serviceAccountTokenProjection"`
+		TokenAudiences                []string        `json:"tokenAudiences"`
+		RequireBoundTokens            bool            `json:"requireBoundTokens"`
+		EnableRevocations             bool            `json:"enableRevocations"`
+		EnableAudit                   bool            `json:"enableAudit"`
+		IssuancePolicy                *IssuancePolicy `json:"issuancePolicy"`
+		Issuer                        *Issuer         `json:"issuer"`
+	}
+
+	// IssuancePolicy has the Helm variables of the policies checked by the
+	// identity controller before issuing certificates
+	IssuancePolicy struct {
+		NamespaceLifetimes map[string]string `json:"namespaceLifetimes"`
+		WebhookURL         string            `json:"webhookURL"`
+		WebhookTimeout     string            `json:"webhookTimeout"`
 	}
 
 	// Issuer has the Helm variables of the identity issuer
@@ -206,6 +267,27 @@ type (
 		NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector"`
 	}
 
+	// ProxyInjector has the config values of the proxy injector webhook
+	ProxyInjector struct {
+		*Webhook
+		InferOpaquePorts    bool                 `json:"inferOpaquePorts"`
+		OpaquePortsImages   map[string]string    `json:"opaquePortsImages"`
+		AdditionalWorkloads []AdditionalWorkload `json:"additionalWorkloads"`
+	}
+
+	// AdditionalWorkload describes a workload kind, other than the built-in
+	// ones, whose pod template is injected
+	AdditionalWorkload struct {
+		Group   string `json:"group"`
+		Version string `json:"version"`
+		Kind    string `json:"kind"`
+		// Resource is the plural name of the kind, used by the webhook rules
+		Resource string `json:"resource"`
+		// PodTemplatePath is the dot-separated path of the pod template in the
+		// workload, e.g. spec.template
+		PodTemplatePath string `json:"podTemplatePath"`
+	}
+
 	// TLS has a pair of PEM-encoded key and certificate variables used in the
 	// Helm templates
 	TLS struct {
@@ -42,6 +42,7 @@ func TestNewValues(t *testing.T) {
 		CliVersion:                   "linkerd/cli dev-undefined",
 		ControllerLogLevel:           "info",
 		ControllerLogFormat:          "plain",
+		ControllerCryptoMode:         "default",
 		ControllerImageVersion:       testVersion,
 		LinkerdVersion:               version.Version,
 		ProxyContainerName:           "linkerd-proxy",
@@ -124,8 +125,25 @@ func TestNewValues(t *testing.T) {
 				MountPath: "/run",
 			},
 		},
+		AdminTLS: &AdminTLS{
+			SecretName:        "",
+			RequireClientCert: false,
+		},
+		Destination: &Destination{
+			DefaultPort: 80,
+			LeaderElection: &LeaderElection{
+				LeaseDuration: "30s",
+				RenewDeadline: "10s",
+				RetryPeriod:   "2s",
+			},
+		},
 		Identity: &Identity{
 			ServiceAccountTokenProjection: true,
+			TokenAudiences:                []string{},
+			IssuancePolicy: &IssuancePolicy{
+				NamespaceLifetimes: map[string]string{},
+				WebhookTimeout:     "5s",
+			},
 			Issuer: &Issuer{
 				ClockSkewAllowance: "20s",
 				IssuanceLifetime:   "24h0m0s",
@@ -143,7 +161,17 @@ func TestNewValues(t *testing.T) {
 			},
 		},
 
-		ProxyInjector:    &Webhook{TLS: &TLS{}, NamespaceSelector: namespaceSelector},
+		ProxyInjector: &ProxyInjector{
+			Webhook: &Webhook{TLS: &TLS{}, NamespaceSelector: namespaceSelector},
+			OpaquePortsImages: map[string]string{
+				"kafka":    "9092",
+				"mysql":    "3306",
+				"postgres": "5432",
+				"redis":    "6379",
+				"smtp":     "25,587",
+			},
+			AdditionalWorkloads: []AdditionalWorkload{},
+		},
 		ProfileValidator: &Webhook{TLS: &TLS{}, NamespaceSelector: namespaceSelector},
 		PolicyValidator:  &Webhook{TLS: &TLS{}, NamespaceSelector: namespaceSelector},
 	}
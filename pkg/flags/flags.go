@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/linkerd/linkerd2/pkg/admin"
+	"github.com/linkerd/linkerd2/pkg/logging"
+	"github.com/linkerd/linkerd2/pkg/tls"
 	"github.com/linkerd/linkerd2/pkg/version"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
@@ -56,12 +59,33 @@ func AddTraceFlags(cmd *flag.FlagSet) *string {
 	return traceCollector
 }
 
+// AddCryptoModeFlag adds the crypto-mode flag to the flagSet and returns its
+// pointer for usage
+func AddCryptoModeFlag(cmd *flag.FlagSet) *tls.CryptoMode {
+	cryptoMode := tls.CryptoModeDefault
+	cmd.Var(&cryptoMode, "crypto-mode", "TLS versions, cipher suites and keys accepted by the TLS listeners, must be one of: default, strict")
+
+	return &cryptoMode
+}
+
+// AddAdminTLSFlags adds the flags enabling TLS on the admin server to the
+// flagSet and returns the options they set
+func AddAdminTLSFlags(cmd *flag.FlagSet) *admin.TLSOptions {
+	options := &admin.TLSOptions{CryptoMode: tls.CryptoModeDefault}
+	cmd.StringVar(&options.CredsDir, "admin-tls-dir", "",
+		"directory holding the tls.crt and tls.key files the admin server is served with; the admin server is served in plaintext if empty")
+	cmd.StringVar(&options.ClientCAPath, "admin-tls-client-ca", "",
+		"path to the CA bundle verifying the certificates the admin server clients must present, except for the probes; requires -admin-tls-dir")
+
+	return options
+}
+
 func setLogLevel(logLevel string) {
 	level, err := log.ParseLevel(logLevel)
 	if err != nil {
 		log.Fatalf("invalid log-level: %s", logLevel)
 	}
-	log.SetLevel(level)
+	logging.SetLevel(level)
 
 	if level == log.DebugLevel {
 		flag.Set("stderrthreshold", "INFO")
@@ -83,9 +107,9 @@ func maybePrintVersionAndExit(printVersion bool) {
 func getFormatter(format string) log.Formatter {
 	switch format {
 	case "json":
-		return &log.JSONFormatter{}
+		return logging.NewJSONFormatter()
 	default:
-		return &log.TextFormatter{FullTimestamp: true}
+		return logging.NewTextFormatter()
 	}
 }
 
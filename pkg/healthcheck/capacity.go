@@ -0,0 +1,353 @@
+package healthcheck
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The figures the control plane's capacity is estimated from. They're rough
+// averages, meant to flag the control planes approaching their limits rather
+// than to size them precisely.
+const (
+	// destinationBaseMemory is the memory used by an idle destination
+	// controller
+	destinationBaseMemory = 100 << 20
+
+	// destinationMemoryPerEndpoint is the memory used by a destination
+	// controller replica to watch an endpoint address; every replica watches
+	// all of them
+	destinationMemoryPerEndpoint = 4 << 10
+
+	// destinationMemoryPerStream is the memory held by an open discovery
+	// stream, which are spread across the replicas
+	destinationMemoryPerStream = 16 << 10
+
+	// streamsPerProxy is the number of discovery streams a proxy is assumed to
+	// hold open when they can't be measured
+	streamsPerProxy = 10
+
+	// streamsPerCore is the number of discovery streams a core of the
+	// destination controller can serve
+	streamsPerCore = 10000
+
+	// maxPodsPerNamespace is the number of pods beyond which the updates of a
+	// namespace's endpoints get expensive to fan out to the proxies
+	maxPodsPerNamespace = 1000
+
+	// haMeshedPods is the number of meshed pods beyond which the control
+	// plane should run in HA mode, with at least haReplicas replicas
+	haMeshedPods = 200
+	haReplicas   = 3
+
+	destinationContainerName = "destination"
+	destinationAdminPortName = "admin-http"
+	destinationService       = "io.linkerd.proxy.destination.Destination"
+	capacitySkipReason       = "the cluster's size couldn't be measured"
+)
+
+// clusterCapacity holds the size of the cluster and the resources of the
+// destination controller; the limits are 0 when unbounded, and
+// discoveryStreams is -1 until measured
+type clusterCapacity struct {
+	services               int
+	endpoints              int
+	meshedPods             int
+	podsByNamespace        map[string]int
+	destinationReplicas    int
+	destinationMemoryLimit int64
+	destinationCPULimit    float64
+	discoveryStreams       int
+}
+
+// measureClusterCapacity counts the services, endpoints and pods of the
+// cluster, and reads the resources of the running destination controller
+// replicas
+func (hc *HealthChecker) measureClusterCapacity(ctx context.Context) (*clusterCapacity, error) {
+	c := &clusterCapacity{
+		podsByNamespace:  map[string]int{},
+		discoveryStreams: -1,
+	}
+
+	services, err := hc.kubeAPI.CoreV1().Services("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	c.services = len(services.Items)
+
+	endpoints, err := hc.kubeAPI.CoreV1().Endpoints("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, ep := range endpoints.Items {
+		for _, subset := range ep.Subsets {
+			c.endpoints += len(subset.Addresses) + len(subset.NotReadyAddresses)
+		}
+	}
+
+	pods, err := hc.kubeAPI.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		c.podsByNamespace[pod.Namespace]++
+		if containsProxy(pod) {
+			c.meshedPods++
+		}
+	}
+
+	for _, pod := range hc.destinationPods(pods.Items) {
+		c.destinationReplicas++
+		for _, container := range pod.Spec.Containers {
+			if container.Name != destinationContainerName {
+				continue
+			}
+			if limit, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
+				c.destinationMemoryLimit = limit.Value()
+			}
+			if limit, ok := container.Resources.Limits[corev1.ResourceCPU]; ok {
+				c.destinationCPULimit = float64(limit.MilliValue()) / 1000
+			}
+		}
+	}
+	return c, nil
+}
+
+// destinationPods returns the running destination controller pods
+func (hc *HealthChecker) destinationPods(pods []corev1.Pod) []corev1.Pod {
+	destinationPods := []corev1.Pod{}
+	for _, pod := range pods {
+		if pod.Namespace == hc.ControlPlaneNamespace &&
+			pod.Labels[k8s.ControllerComponentLabel] == "destination" &&
+			pod.Status.Phase == corev1.PodRunning {
+			destinationPods = append(destinationPods, pod)
+		}
+	}
+	return destinationPods
+}
+
+// capacityCheck runs the check against the measured cluster capacity, and is
+// skipped when it couldn't be measured
+func (hc *HealthChecker) capacityCheck(check func(*clusterCapacity) error) error {
+	if hc.capacity == nil {
+		return &SkipError{Reason: capacitySkipReason}
+	}
+	return check(hc.capacity)
+}
+
+// measureDiscoveryStreams sums the discovery streams open on the destination
+// controller replicas, from their metrics
+func (hc *HealthChecker) measureDiscoveryStreams(ctx context.Context) error {
+	pods, err := hc.kubeAPI.CoreV1().Pods(hc.ControlPlaneNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=destination", k8s.ControllerComponentLabel),
+	})
+	if err != nil {
+		return err
+	}
+
+	streams := 0
+	for _, pod := range hc.destinationPods(pods.Items) {
+		metrics, err := getDestinationMetrics(hc.kubeAPI, pod)
+		if err != nil {
+			return err
+		}
+		podStreams, err := openDiscoveryStreams(metrics)
+		if err != nil {
+			return fmt.Errorf("failed to parse the metrics of %s: %s", pod.Name, err)
+		}
+		streams += podStreams
+	}
+	hc.capacity.discoveryStreams = streams
+	return nil
+}
+
+func getDestinationMetrics(kubeAPI *k8s.KubernetesAPI, pod corev1.Pod) ([]byte, error) {
+	var container *corev1.Container
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == destinationContainerName {
+			container = &pod.Spec.Containers[i]
+		}
+	}
+	if container == nil {
+		return nil, fmt.Errorf("no destination container found for pod %s", pod.GetName())
+	}
+
+	portForward, err := k8s.NewContainerMetricsForward(kubeAPI, pod, *container, false, destinationAdminPortName)
+	if err != nil {
+		return nil, err
+	}
+	defer portForward.Stop()
+	if err = portForward.Init(); err != nil {
+		return nil, err
+	}
+
+	resp, err := portForward.HTTPClient().Get(portForward.URLFor("/metrics"))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return ioutil.ReadAll(resp.Body)
+}
+
+// openDiscoveryStreams returns the number of streams of the Destination API
+// that were started but not handled yet, i.e. that are still open
+func openDiscoveryStreams(metrics []byte) (int, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(metrics))
+	if err != nil {
+		return 0, err
+	}
+
+	sum := func(name string) float64 {
+		total := 0.0
+		for _, metric := range families[name].GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "grpc_service" && label.GetValue() == destinationService {
+					total += metric.GetCounter().GetValue()
+				}
+			}
+		}
+		return total
+	}
+	streams := sum("grpc_server_started_total") - sum("grpc_server_handled_total")
+	if streams < 0 {
+		return 0, nil
+	}
+	return int(streams), nil
+}
+
+// streams returns the measured number of discovery streams, or its estimate
+// from the number of meshed pods
+func (c *clusterCapacity) streams() int {
+	if c.discoveryStreams >= 0 {
+		return c.discoveryStreams
+	}
+	return c.meshedPods * streamsPerProxy
+}
+
+// streamsPerReplica returns the number of discovery streams served by each
+// destination controller replica
+func (c *clusterCapacity) streamsPerReplica() int {
+	if c.destinationReplicas == 0 {
+		return c.streams()
+	}
+	return int(math.Ceil(float64(c.streams()) / float64(c.destinationReplicas)))
+}
+
+// destinationMemoryEstimate returns the memory each destination controller
+// replica is estimated to need
+func (c *clusterCapacity) destinationMemoryEstimate() int64 {
+	return destinationBaseMemory +
+		int64(c.endpoints)*destinationMemoryPerEndpoint +
+		int64(c.streamsPerReplica())*destinationMemoryPerStream
+}
+
+// recommendedDestinationReplicas returns the number of destination controller
+// replicas recommended for the cluster
+func (c *clusterCapacity) recommendedDestinationReplicas() int {
+	if c.meshedPods < haMeshedPods {
+		return 1
+	}
+	replicas := int(math.Ceil(float64(c.streams()) / streamsPerCore))
+	if replicas < haReplicas {
+		return haReplicas
+	}
+	return replicas
+}
+
+func checkPodsPerNamespace(c *clusterCapacity) error {
+	largest := 0
+	crowded := []string{}
+	for ns, pods := range c.podsByNamespace {
+		if pods > largest {
+			largest = pods
+		}
+		if pods > maxPodsPerNamespace {
+			crowded = append(crowded, fmt.Sprintf("* %s (%d pods)", ns, pods))
+		}
+	}
+	result := &MeasuredResult{
+		Metrics: map[string]float64{
+			"namespaces":               float64(len(c.podsByNamespace)),
+			"meshed_pods":              float64(c.meshedPods),
+			"largest_namespace_pods":   float64(largest),
+			"pods_per_namespace_limit": maxPodsPerNamespace,
+		},
+	}
+	if len(crowded) > 0 {
+		sort.Strings(crowded)
+		result.Err = fmt.Errorf("Some namespaces have more than %d pods, the updates of their endpoints are expensive to propagate to the proxies:\n\t%s", maxPodsPerNamespace, strings.Join(crowded, "\n\t"))
+	}
+	return result
+}
+
+func checkDiscoveryStreams(c *clusterCapacity) error {
+	result := &MeasuredResult{
+		Metrics: map[string]float64{
+			"discovery_streams":             float64(c.streams()),
+			"discovery_streams_per_replica": float64(c.streamsPerReplica()),
+			"destination_cpu_limit_cores":   c.destinationCPULimit,
+		},
+	}
+	if c.destinationCPULimit == 0 {
+		return result
+	}
+	maxStreams := int(c.destinationCPULimit * streamsPerCore)
+	result.Metrics["max_discovery_streams_per_replica"] = float64(maxStreams)
+	if c.streamsPerReplica() > maxStreams {
+		result.Err = fmt.Errorf("Each destination controller replica serves %d discovery streams, more than the %d its %v cores CPU limit can serve; raise destinationResources.cpu.limit or add replicas", c.streamsPerReplica(), maxStreams, c.destinationCPULimit)
+	}
+	return result
+}
+
+func checkDestinationMemory(c *clusterCapacity) error {
+	estimate := c.destinationMemoryEstimate()
+	result := &MeasuredResult{
+		Metrics: map[string]float64{
+			"services":                          float64(c.services),
+			"endpoints":                         float64(c.endpoints),
+			"destination_memory_estimate_bytes": float64(estimate),
+			"destination_memory_limit_bytes":    float64(c.destinationMemoryLimit),
+		},
+	}
+	if c.destinationMemoryLimit > 0 && estimate > c.destinationMemoryLimit {
+		result.Err = fmt.Errorf("The destination controller is estimated to need %s of memory for %d endpoints, above its %s limit; raise destinationResources.memory.limit",
+			formatBytes(estimate), c.endpoints, formatBytes(c.destinationMemoryLimit))
+	}
+	return result
+}
+
+func checkDestinationReplicas(c *clusterCapacity) error {
+	recommended := c.recommendedDestinationReplicas()
+	result := &MeasuredResult{
+		Metrics: map[string]float64{
+			"destination_replicas":             float64(c.destinationReplicas),
+			"recommended_destination_replicas": float64(recommended),
+		},
+	}
+	if c.destinationReplicas < recommended {
+		result.Err = fmt.Errorf("%d meshed pods are served by %d destination controller replicas, %d are recommended; install the control plane in HA mode (--ha) and scale it to at least %d replicas",
+			c.meshedPods, c.destinationReplicas, recommended, recommended)
+	}
+	return result
+}
+
+// formatBytes formats the number of bytes as a quantity, rounded up to the
+// mebibyte
+func formatBytes(b int64) string {
+	mib := int64(math.Ceil(float64(b) / (1 << 20)))
+	return resource.NewQuantity(mib<<20, resource.BinarySI).String()
+}
@@ -0,0 +1,276 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestMeasureClusterCapacity(t *testing.T) {
+	hc := NewHealthChecker([]CategoryID{}, &Options{ControlPlaneNamespace: "linkerd"})
+	var err error
+	hc.kubeAPI, err = k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: linkerd-destination-1
+  namespace: linkerd
+  labels:
+    linkerd.io/control-plane-component: destination
+spec:
+  containers:
+  - name: destination
+    resources:
+      limits:
+        cpu: 500m
+        memory: 250Mi
+  - name: linkerd-proxy
+status:
+  phase: Running`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+  namespace: emojivoto
+spec:
+  containers:
+  - name: web
+  - name: linkerd-proxy
+status:
+  phase: Running`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: vote-bot
+  namespace: emojivoto
+spec:
+  containers:
+  - name: vote-bot
+status:
+  phase: Pending`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web-svc
+  namespace: emojivoto`, `
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: web-svc
+  namespace: emojivoto
+subsets:
+- addresses:
+  - ip: 10.0.0.1
+  - ip: 10.0.0.2
+  notReadyAddresses:
+  - ip: 10.0.0.3`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	c, err := hc.measureClusterCapacity(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if c.services != 1 || c.endpoints != 3 || c.meshedPods != 2 {
+		t.Fatalf("Expected 1 service, 3 endpoints and 2 meshed pods, got %d, %d and %d", c.services, c.endpoints, c.meshedPods)
+	}
+	if c.podsByNamespace["emojivoto"] != 1 {
+		t.Fatalf("Expected 1 running pod in emojivoto, got %d", c.podsByNamespace["emojivoto"])
+	}
+	if c.destinationReplicas != 1 || c.destinationMemoryLimit != 250<<20 || c.destinationCPULimit != 0.5 {
+		t.Fatalf("Expected 1 destination replica limited to 250Mi and 0.5 cores, got %d, %d and %v", c.destinationReplicas, c.destinationMemoryLimit, c.destinationCPULimit)
+	}
+	if c.discoveryStreams != -1 {
+		t.Fatalf("Expected the discovery streams not to be measured, got %d", c.discoveryStreams)
+	}
+}
+
+func TestOpenDiscoveryStreams(t *testing.T) {
+	metrics := `# TYPE grpc_server_started_total counter
+grpc_server_started_total{grpc_method="Get",grpc_service="io.linkerd.proxy.destination.Destination",grpc_type="server_stream"} 120
+grpc_server_started_total{grpc_method="GetProfile",grpc_service="io.linkerd.proxy.destination.Destination",grpc_type="server_stream"} 80
+grpc_server_started_total{grpc_method="Certify",grpc_service="io.linkerd.proxy.identity.Identity",grpc_type="unary"} 10
+# TYPE grpc_server_handled_total counter
+grpc_server_handled_total{grpc_code="OK",grpc_method="Get",grpc_service="io.linkerd.proxy.destination.Destination",grpc_type="server_stream"} 20
+grpc_server_handled_total{grpc_code="Canceled",grpc_method="GetProfile",grpc_service="io.linkerd.proxy.destination.Destination",grpc_type="server_stream"} 30
+grpc_server_handled_total{grpc_code="OK",grpc_method="Certify",grpc_service="io.linkerd.proxy.identity.Identity",grpc_type="unary"} 10
+`
+	streams, err := openDiscoveryStreams([]byte(metrics))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if streams != 150 {
+		t.Fatalf("Expected 150 open streams, got %d", streams)
+	}
+}
+
+func TestCapacityChecks(t *testing.T) {
+	testCases := []struct {
+		name     string
+		check    func(*clusterCapacity) error
+		capacity clusterCapacity
+		metrics  map[string]float64
+		expected string
+	}{
+		{
+			name:  "namespaces within limits",
+			check: checkPodsPerNamespace,
+			capacity: clusterCapacity{
+				meshedPods:      30,
+				podsByNamespace: map[string]int{"emojivoto": 30, "kube-system": 10},
+			},
+			metrics: map[string]float64{
+				"namespaces":               2,
+				"meshed_pods":              30,
+				"largest_namespace_pods":   30,
+				"pods_per_namespace_limit": 1000,
+			},
+		},
+		{
+			name:  "crowded namespaces",
+			check: checkPodsPerNamespace,
+			capacity: clusterCapacity{
+				podsByNamespace: map[string]int{"jobs": 1500, "batch": 1200, "web": 10},
+			},
+			expected: "Some namespaces have more than 1000 pods, the updates of their endpoints are expensive to propagate to the proxies:\n\t* batch (1200 pods)\n\t* jobs (1500 pods)",
+		},
+		{
+			name:  "measured discovery streams within the CPU limit",
+			check: checkDiscoveryStreams,
+			capacity: clusterCapacity{
+				destinationReplicas: 2,
+				destinationCPULimit: 0.5,
+				discoveryStreams:    6000,
+			},
+			metrics: map[string]float64{
+				"discovery_streams":                 6000,
+				"discovery_streams_per_replica":     3000,
+				"destination_cpu_limit_cores":       0.5,
+				"max_discovery_streams_per_replica": 5000,
+			},
+		},
+		{
+			name:  "estimated discovery streams above the CPU limit",
+			check: checkDiscoveryStreams,
+			capacity: clusterCapacity{
+				meshedPods:          1000,
+				destinationReplicas: 1,
+				destinationCPULimit: 0.5,
+				discoveryStreams:    -1,
+			},
+			expected: "Each destination controller replica serves 10000 discovery streams, more than the 5000 its 0.5 cores CPU limit can serve; raise destinationResources.cpu.limit or add replicas",
+		},
+		{
+			name:  "unbounded destination memory",
+			check: checkDestinationMemory,
+			capacity: clusterCapacity{
+				services:            10,
+				endpoints:           100000,
+				destinationReplicas: 1,
+				discoveryStreams:    0,
+			},
+			metrics: map[string]float64{
+				"services":                          10,
+				"endpoints":                         100000,
+				"destination_memory_estimate_bytes": destinationBaseMemory + 100000*destinationMemoryPerEndpoint,
+				"destination_memory_limit_bytes":    0,
+			},
+		},
+		{
+			name:  "destination memory above the limit",
+			check: checkDestinationMemory,
+			capacity: clusterCapacity{
+				endpoints:              50000,
+				destinationReplicas:    1,
+				destinationMemoryLimit: 250 << 20,
+				discoveryStreams:       0,
+			},
+			expected: "The destination controller is estimated to need 296Mi of memory for 50000 endpoints, above its 250Mi limit; raise destinationResources.memory.limit",
+		},
+		{
+			name:  "small cluster",
+			check: checkDestinationReplicas,
+			capacity: clusterCapacity{
+				meshedPods:          50,
+				destinationReplicas: 1,
+				discoveryStreams:    -1,
+			},
+			metrics: map[string]float64{
+				"destination_replicas":             1,
+				"recommended_destination_replicas": 1,
+			},
+		},
+		{
+			name:  "large cluster without HA",
+			check: checkDestinationReplicas,
+			capacity: clusterCapacity{
+				meshedPods:          5000,
+				destinationReplicas: 1,
+				discoveryStreams:    -1,
+			},
+			expected: "5000 meshed pods are served by 1 destination controller replicas, 5 are recommended; install the control plane in HA mode (--ha) and scale it to at least 5 replicas",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			result, ok := tc.check(&tc.capacity).(*MeasuredResult)
+			if !ok {
+				t.Fatalf("Expected a MeasuredResult, got %T", result)
+			}
+			if tc.expected == "" {
+				if result.Err != nil {
+					t.Fatalf("Unexpected error: %s", result.Err)
+				}
+			} else if result.Err == nil || result.Err.Error() != tc.expected {
+				t.Fatalf("Expected error %q, got %v", tc.expected, result.Err)
+			}
+			for name, expected := range tc.metrics {
+				if actual, ok := result.Metrics[name]; !ok || actual != expected {
+					t.Fatalf("Expected metric %s to be %v, got %v", name, expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestMeasuredResult(t *testing.T) {
+	hc := NewHealthChecker([]CategoryID{}, &Options{})
+	hc.AppendCategories(NewCategory(
+		"cat1",
+		[]Checker{
+			{
+				description: "desc1",
+				check: func(context.Context) error {
+					return &MeasuredResult{Metrics: map[string]float64{"endpoints": 3}}
+				},
+			},
+			{
+				description: "desc2",
+				warning:     true,
+				check: func(context.Context) error {
+					return &MeasuredResult{Metrics: map[string]float64{"endpoints": 4}, Err: errors.New("too many endpoints")}
+				},
+			},
+		},
+		true,
+	))
+
+	results := []*CheckResult{}
+	success, warning := hc.RunChecks(func(result *CheckResult) {
+		results = append(results, result)
+	})
+	if !success || !warning {
+		t.Fatalf("Expected the checks to succeed with a warning, got success=%t warning=%t", success, warning)
+	}
+	if results[0].Err != nil || results[0].Metrics["endpoints"] != 3 {
+		t.Fatalf("Expected a success with 3 endpoints, got %v and %v", results[0].Err, results[0].Metrics)
+	}
+	if results[1].Err == nil || results[1].Metrics["endpoints"] != 4 {
+		t.Fatalf("Expected a warning with 4 endpoints, got %v and %v", results[1].Err, results[1].Metrics)
+	}
+}
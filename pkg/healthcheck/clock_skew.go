@@ -0,0 +1,172 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/tls"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxClockProbes bounds the number of proxies probed concurrently
+const maxClockProbes = 10
+
+// proxyClockOffset is the estimated offset of a proxy's clock relative to the
+// clock of the machine running the CLI
+type proxyClockOffset struct {
+	pod    string
+	offset time.Duration
+	err    error
+}
+
+// checkDataPlaneProxiesClockSkew compares the clocks of the data plane proxies
+// with the clock of the identity controller's proxy. Proxies whose clock is
+// skewed by more than the issuer's clock skew allowance consider the
+// certificates they're issued not yet valid, or expired, which surfaces as
+// opaque TLS failures.
+func (hc *HealthChecker) checkDataPlaneProxiesClockSkew(ctx context.Context) error {
+	identityPods, err := hc.kubeAPI.CoreV1().Pods(hc.ControlPlaneNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=identity", k8s.ControllerComponentLabel),
+	})
+	if err != nil {
+		return err
+	}
+	var reference *proxyClockOffset
+	for _, pod := range identityPods.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		offset := measureProxyClockOffset(hc.kubeAPI, pod)
+		if offset.err == nil {
+			reference = &offset
+			break
+		}
+	}
+	if reference == nil {
+		return &SkipError{Reason: "could not read the clock of the identity controller"}
+	}
+
+	pods, err := hc.GetDataPlanePods(ctx)
+	if err != nil {
+		return err
+	}
+
+	offsets := make([]proxyClockOffset, len(pods))
+	sem := make(chan struct{}, maxClockProbes)
+	var wg sync.WaitGroup
+	for i, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning {
+			offsets[i] = proxyClockOffset{pod: podName(hc.DataPlaneNamespace, pod), err: fmt.Errorf("pod not running")}
+			continue
+		}
+		wg.Add(1)
+		go func(i int, pod corev1.Pod) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			offsets[i] = measureProxyClockOffset(hc.kubeAPI, pod)
+			offsets[i].pod = podName(hc.DataPlaneNamespace, pod)
+		}(i, pod)
+	}
+	wg.Wait()
+
+	return checkProxiesClockSkew(reference.offset, offsets, hc.clockSkewAllowance())
+}
+
+// clockSkewAllowance returns the clock skew tolerated by the identity issuer
+func (hc *HealthChecker) clockSkewAllowance() time.Duration {
+	if hc.linkerdConfig != nil && hc.linkerdConfig.Identity != nil && hc.linkerdConfig.Identity.Issuer != nil {
+		if allowance, err := time.ParseDuration(hc.linkerdConfig.Identity.Issuer.ClockSkewAllowance); err == nil {
+			return allowance
+		}
+	}
+	return tls.DefaultClockSkewAllowance
+}
+
+func checkProxiesClockSkew(reference time.Duration, offsets []proxyClockOffset, allowance time.Duration) error {
+	skewed := []string{}
+	measured := 0
+	for _, o := range offsets {
+		if o.err != nil {
+			continue
+		}
+		measured++
+		skew := o.offset - reference
+		if skew > allowance || -skew > allowance {
+			skewed = append(skewed, fmt.Sprintf("* %s (%+.0fs)", o.pod, skew.Seconds()))
+		}
+	}
+	if len(offsets) > 0 && measured == 0 {
+		return &SkipError{Reason: "could not read the clock of any data plane proxy"}
+	}
+	if len(skewed) == 0 {
+		return nil
+	}
+	sort.Strings(skewed)
+	return fmt.Errorf("Some proxies' clocks are skewed from the identity controller's by more than the %s allowance, their certificates may be rejected:\n\t%s", allowance, strings.Join(skewed, "\n\t"))
+}
+
+// measureProxyClockOffset estimates the offset of the proxy's clock from the
+// Date header of its admin server's responses. That header has a one second
+// resolution, which is plenty given skew allowances are in the tens of
+// seconds.
+func measureProxyClockOffset(kubeAPI *k8s.KubernetesAPI, pod corev1.Pod) proxyClockOffset {
+	result := proxyClockOffset{pod: pod.GetName()}
+
+	var container *corev1.Container
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == k8s.ProxyContainerName {
+			container = &pod.Spec.Containers[i]
+		}
+	}
+	if container == nil {
+		result.err = fmt.Errorf("no proxy container found for pod %s", pod.GetName())
+		return result
+	}
+
+	portForward, err := k8s.NewContainerMetricsForward(kubeAPI, pod, *container, false, k8s.ProxyAdminPortName)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	defer portForward.Stop()
+	if err = portForward.Init(); err != nil {
+		result.err = err
+		return result
+	}
+
+	sent := time.Now()
+	resp, err := http.Get(portForward.URLFor("/ready"))
+	if err != nil {
+		result.err = err
+		return result
+	}
+	received := time.Now()
+	resp.Body.Close()
+
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		result.err = fmt.Errorf("invalid Date header: %s", err)
+		return result
+	}
+
+	// the header is truncated to the second, and the proxy's clock was read
+	// somewhere during the round-trip
+	local := sent.Add(received.Sub(sent) / 2)
+	result.offset = date.Add(500 * time.Millisecond).Sub(local)
+	return result
+}
+
+func podName(namespace string, pod corev1.Pod) string {
+	if namespace == "" {
+		return fmt.Sprintf("%s/%s", pod.GetNamespace(), pod.GetName())
+	}
+	return pod.GetName()
+}
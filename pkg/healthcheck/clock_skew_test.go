@@ -0,0 +1,66 @@
+package healthcheck
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckProxiesClockSkew(t *testing.T) {
+	reference := 3 * time.Second
+
+	testCases := []struct {
+		name     string
+		offsets  []proxyClockOffset
+		expected string
+		skipped  bool
+	}{
+		{
+			name: "clocks in sync",
+			offsets: []proxyClockOffset{
+				{pod: "web", offset: 10 * time.Second},
+				{pod: "emoji", offset: -15 * time.Second},
+			},
+		},
+		{
+			name: "skewed clocks",
+			offsets: []proxyClockOffset{
+				{pod: "web", offset: 10 * time.Second},
+				{pod: "voting", offset: 30 * time.Second},
+				{pod: "emoji", offset: -40 * time.Second},
+				{pod: "vote-bot", err: errors.New("port-forward failed")},
+			},
+			expected: "Some proxies' clocks are skewed from the identity controller's by more than the 20s allowance, their certificates may be rejected:\n\t* emoji (-43s)\n\t* voting (+27s)",
+		},
+		{
+			name: "no proxy could be probed",
+			offsets: []proxyClockOffset{
+				{pod: "web", err: errors.New("port-forward failed")},
+			},
+			skipped: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkProxiesClockSkew(reference, tc.offsets, 20*time.Second)
+			if tc.skipped {
+				var skip *SkipError
+				if !errors.As(err, &skip) {
+					t.Fatalf("Expected the check to be skipped, got %v", err)
+				}
+				return
+			}
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -129,6 +129,11 @@ const (
 	// is correct. These checks are no ops if linkerd is not in HA mode
 	LinkerdHAChecks CategoryID = "linkerd-ha-checks"
 
+	// LinkerdStrictCryptoChecks adds checks to validate that the control plane
+	// complies with the strict crypto mode. These checks are no ops if the
+	// strict crypto mode is not enabled
+	LinkerdStrictCryptoChecks CategoryID = "linkerd-strict-crypto"
+
 	// LinkerdCNIPluginChecks adds checks to validate that the CNI
 	/// plugin is installed and ready
 	LinkerdCNIPluginChecks CategoryID = "linkerd-cni-plugin"
@@ -138,6 +143,12 @@ const (
 	// corresponding pods
 	LinkerdOpaquePortsDefinitionChecks CategoryID = "linkerd-opaque-ports-definition"
 
+	// LinkerdCapacityChecks adds checks estimating whether the control plane
+	// is sized for the cluster, i.e. for its endpoints, pods and discovery
+	// streams. These checks only warn, and report the numbers they're based on
+	// with the JSON output
+	LinkerdCapacityChecks CategoryID = "linkerd-capacity"
+
 	// LinkerdCNIResourceLabel is the label key that is used to identify
 	// whether a Kubernetes resource is related to the install-cni command
 	// The value is expected to be "true", "false" or "", where "false" and
@@ -265,6 +276,22 @@ func (e *VerboseSuccess) Error() string {
 	return ""
 }
 
+// MeasuredResult implements the error interface, and is returned by checks
+// that report numbers along with their outcome, e.g. for dashboards to track
+// them. Err is the error of the check, nil when it succeeded.
+type MeasuredResult struct {
+	Metrics map[string]float64
+	Err     error
+}
+
+// Error satisfies the error interface for MeasuredResult
+func (e *MeasuredResult) Error() string {
+	if e.Err == nil {
+		return ""
+	}
+	return e.Err.Error()
+}
+
 // Checker is a smallest unit performing a single check
 type Checker struct {
 	// description is the short description that's printed to the command line
@@ -293,6 +320,11 @@ type Checker struct {
 	// contains the current status of the check.
 	surfaceErrorOnRetry bool
 
+	// requiresCluster indicates that the check can't run against a Snapshot,
+	// e.g. because it talks to the proxies or depends on the permissions of
+	// the user; such checks are skipped in offline mode (default false)
+	requiresCluster bool
+
 	// check is the function that's called to execute the check; if the function
 	// returns an error, the check fails
 	check func(context.Context) error
@@ -352,6 +384,8 @@ type CheckResult struct {
 	Retry       bool
 	Warning     bool
 	Err         error
+	// Metrics is left out of the JSON of the checks not reporting any
+	Metrics map[string]float64 `json:",omitempty"`
 }
 
 // CheckObserver receives the results of each check.
@@ -399,6 +433,15 @@ type Options struct {
 	CNIEnabled            bool
 	InstallManifest       string
 	ChartValues           *l5dcharts.Values
+	// WaitForReady retries the categories with failing checks until the
+	// RetryDeadline, instead of only the checks that support retries
+	WaitForReady bool
+	// WaitObserver receives the progress of the checks when WaitForReady is
+	// set
+	WaitObserver WaitObserver
+	// Snapshot, when set, is checked instead of the cluster, skipping the
+	// checks that require access to it
+	Snapshot *Snapshot
 }
 
 // HealthChecker encapsulates all health check checkers, and clients required to
@@ -418,6 +461,7 @@ type HealthChecker struct {
 	issuerCert       *tls.Cred
 	trustAnchors     []*x509.Certificate
 	cniDaemonSet     *appsv1.DaemonSet
+	capacity         *clusterCapacity
 }
 
 // Runner is implemented by any health-checkers that can be triggered with RunChecks()
@@ -450,6 +494,15 @@ func NewHealthChecker(categoryIDs []CategoryID, options *Options) *HealthChecker
 // having to require the KubernetesAPIChecks check to run in order for the
 // HealthChecker to run other checks.
 func (hc *HealthChecker) InitializeKubeAPIClient() error {
+	if hc.Snapshot != nil {
+		k8sAPI, err := hc.Snapshot.kubernetesAPI()
+		if err != nil {
+			return err
+		}
+		hc.kubeAPI = k8sAPI
+		return nil
+	}
+
 	k8sAPI, err := k8s.NewAPI(hc.KubeConfig, hc.KubeContext, hc.Impersonate, hc.ImpersonateGroup, RequestTimeout)
 	if err != nil {
 		return err
@@ -536,8 +589,9 @@ func (hc *HealthChecker) allCategories() []*Category {
 					},
 				},
 				{
-					description: "is running the minimum kubectl version",
-					hintAnchor:  "kubectl-version",
+					description:     "is running the minimum kubectl version",
+					hintAnchor:      "kubectl-version",
+					requiresCluster: true,
 					check: func(context.Context) error {
 						return k8s.CheckKubectlVersion()
 					},
@@ -556,72 +610,82 @@ func (hc *HealthChecker) allCategories() []*Category {
 					},
 				},
 				{
-					description: "can create non-namespaced resources",
-					hintAnchor:  "pre-k8s-cluster-k8s",
+					description:     "can create non-namespaced resources",
+					hintAnchor:      "pre-k8s-cluster-k8s",
+					requiresCluster: true,
 					check: func(ctx context.Context) error {
 						return hc.checkCanCreateNonNamespacedResources(ctx)
 					},
 				},
 				{
-					description: "can create ServiceAccounts",
-					hintAnchor:  "pre-k8s",
+					description:     "can create ServiceAccounts",
+					hintAnchor:      "pre-k8s",
+					requiresCluster: true,
 					check: func(ctx context.Context) error {
 						return hc.checkCanCreate(ctx, hc.ControlPlaneNamespace, "", "v1", "serviceaccounts")
 					},
 				},
 				{
-					description: "can create Services",
-					hintAnchor:  "pre-k8s",
+					description:     "can create Services",
+					hintAnchor:      "pre-k8s",
+					requiresCluster: true,
 					check: func(ctx context.Context) error {
 						return hc.checkCanCreate(ctx, hc.ControlPlaneNamespace, "", "v1", "services")
 					},
 				},
 				{
-					description: "can create Deployments",
-					hintAnchor:  "pre-k8s",
+					description:     "can create Deployments",
+					hintAnchor:      "pre-k8s",
+					requiresCluster: true,
 					check: func(ctx context.Context) error {
 						return hc.checkCanCreate(ctx, hc.ControlPlaneNamespace, "apps", "v1", "deployments")
 					},
 				},
 				{
-					description: "can create CronJobs",
-					hintAnchor:  "pre-k8s",
+					description:     "can create CronJobs",
+					hintAnchor:      "pre-k8s",
+					requiresCluster: true,
 					check: func(ctx context.Context) error {
 						return hc.checkCanCreate(ctx, hc.ControlPlaneNamespace, "batch", "v1beta1", "cronjobs")
 					},
 				},
 				{
-					description: "can create ConfigMaps",
-					hintAnchor:  "pre-k8s",
+					description:     "can create ConfigMaps",
+					hintAnchor:      "pre-k8s",
+					requiresCluster: true,
 					check: func(ctx context.Context) error {
 						return hc.checkCanCreate(ctx, hc.ControlPlaneNamespace, "", "v1", "configmaps")
 					},
 				},
 				{
-					description: "can create Secrets",
-					hintAnchor:  "pre-k8s",
+					description:     "can create Secrets",
+					hintAnchor:      "pre-k8s",
+					requiresCluster: true,
 					check: func(ctx context.Context) error {
 						return hc.checkCanCreate(ctx, hc.ControlPlaneNamespace, "", "v1", "secrets")
 					},
 				},
 				{
-					description: "can read Secrets",
-					hintAnchor:  "pre-k8s",
+					description:     "can read Secrets",
+					hintAnchor:      "pre-k8s",
+					requiresCluster: true,
 					check: func(ctx context.Context) error {
 						return hc.checkCanGet(ctx, hc.ControlPlaneNamespace, "", "v1", "secrets")
 					},
 				},
 				{
-					description: "can read extension-apiserver-authentication configmap",
-					hintAnchor:  "pre-k8s",
+					description:     "can read extension-apiserver-authentication configmap",
+					hintAnchor:      "pre-k8s",
+					requiresCluster: true,
 					check: func(ctx context.Context) error {
 						return hc.checkExtensionAPIServerAuthentication(ctx)
 					},
 				},
 				{
-					description: "no clock skew detected",
-					hintAnchor:  "pre-k8s-clock-skew",
-					warning:     true,
+					description:     "no clock skew detected",
+					hintAnchor:      "pre-k8s-clock-skew",
+					warning:         true,
+					requiresCluster: true,
 					check: func(ctx context.Context) error {
 						return hc.checkClockSkew(ctx)
 					},
@@ -1173,6 +1237,14 @@ func (hc *HealthChecker) allCategories() []*Category {
 						return hc.checkDataPlaneProxiesCertificate(ctx)
 					},
 				},
+				{
+					description: "data plane proxies certificates are valid",
+					hintAnchor:  "l5d-identity-data-plane-proxies-certs-valid",
+					warning:     true,
+					check: func(ctx context.Context) error {
+						return hc.checkDataPlaneProxiesCertificateValidity(ctx)
+					},
+				},
 			},
 			false,
 		),
@@ -1351,6 +1423,15 @@ func (hc *HealthChecker) allCategories() []*Category {
 						return checkMisconfiguredPodsLabels(pods)
 					},
 				},
+				{
+					description:     "data plane proxies clocks are in sync",
+					hintAnchor:      "l5d-data-plane-clock-skew",
+					warning:         true,
+					requiresCluster: true,
+					check: func(ctx context.Context) error {
+						return hc.checkDataPlaneProxiesClockSkew(ctx)
+					},
+				},
 				{
 					description: "data plane service labels are configured correctly",
 					hintAnchor:  "l5d-data-plane-services-labels",
@@ -1385,6 +1466,13 @@ func (hc *HealthChecker) allCategories() []*Category {
 						return hc.checkMisconfiguredOpaquePortAnnotations(ctx)
 					},
 				},
+				{
+					description: "servers don't select the same port with different protocols",
+					hintAnchor:  "l5d-data-plane-server-conflicts",
+					check: func(ctx context.Context) error {
+						return hc.checkConflictingServers(ctx)
+					},
+				},
 			},
 			false,
 		),
@@ -1421,6 +1509,80 @@ func (hc *HealthChecker) allCategories() []*Category {
 			},
 			false,
 		),
+		NewCategory(
+			LinkerdStrictCryptoChecks,
+			[]Checker{
+				{
+					description:   "control plane components run in the strict crypto mode",
+					hintAnchor:    "l5d-strict-crypto-components",
+					retryDeadline: hc.RetryDeadline,
+					check: func(ctx context.Context) error {
+						if !hc.isStrictCrypto() {
+							return &SkipError{Reason: strictCryptoSkipReason}
+						}
+						pods, err := hc.kubeAPI.GetPodsByNamespace(ctx, hc.ControlPlaneNamespace)
+						if err != nil {
+							return err
+						}
+						return checkStrictCryptoPods(pods)
+					},
+				},
+			},
+			false,
+		),
+		NewCategory(
+			LinkerdCapacityChecks,
+			[]Checker{
+				{
+					description: "can measure the cluster's size",
+					hintAnchor:  "l5d-capacity-measure",
+					check: func(ctx context.Context) (err error) {
+						hc.capacity, err = hc.measureClusterCapacity(ctx)
+						return
+					},
+				},
+				{
+					description: "namespaces have a manageable number of pods",
+					hintAnchor:  "l5d-capacity-pods-per-namespace",
+					warning:     true,
+					check: func(context.Context) error {
+						return hc.capacityCheck(checkPodsPerNamespace)
+					},
+				},
+				{
+					description:     "destination controller can serve the discovery streams",
+					hintAnchor:      "l5d-capacity-discovery-streams",
+					warning:         true,
+					requiresCluster: true,
+					check: func(ctx context.Context) error {
+						if hc.capacity == nil {
+							return &SkipError{Reason: capacitySkipReason}
+						}
+						if err := hc.measureDiscoveryStreams(ctx); err != nil {
+							return err
+						}
+						return checkDiscoveryStreams(hc.capacity)
+					},
+				},
+				{
+					description: "destination controller memory fits the cluster's endpoints",
+					hintAnchor:  "l5d-capacity-destination-memory",
+					warning:     true,
+					check: func(context.Context) error {
+						return hc.capacityCheck(checkDestinationMemory)
+					},
+				},
+				{
+					description: "control plane replicas are sized for the cluster",
+					hintAnchor:  "l5d-capacity-replicas",
+					warning:     true,
+					check: func(context.Context) error {
+						return hc.capacityCheck(checkDestinationReplicas)
+					},
+				},
+			},
+			false,
+		),
 	}
 }
 
@@ -1585,7 +1747,13 @@ func (hc *HealthChecker) checkMinReplicasAvailable(ctx context.Context) error {
 // remaining checks are skipped. If at least one check fails, RunChecks returns
 // false; if all checks passed, RunChecks returns true.  Checks which are
 // designated as warnings will not cause RunCheck to return false, however.
+// With the WaitForReady option, the categories with failing checks are retried
+// until the RetryDeadline.
 func (hc *HealthChecker) RunChecks(observer CheckObserver) (bool, bool) {
+	if hc.WaitForReady {
+		return hc.runChecksUntilReady(observer)
+	}
+
 	success := true
 	warning := false
 	for _, c := range hc.categories {
@@ -1617,6 +1785,11 @@ func (hc *HealthChecker) LinkerdConfig() *l5dcharts.Values {
 }
 
 func (hc *HealthChecker) runCheck(category *Category, c *Checker, observer CheckObserver) bool {
+	if c.requiresCluster && hc.Snapshot != nil {
+		log.Debugf("Skipping check: %s. Reason: %s", c.description, snapshotSkipReason)
+		return true
+	}
+
 	for {
 		ctx, cancel := context.WithTimeout(context.Background(), RequestTimeout)
 		defer cancel()
@@ -1632,6 +1805,10 @@ func (hc *HealthChecker) runCheck(category *Category, c *Checker, observer Check
 			Warning:     c.warning,
 			HintURL:     fmt.Sprintf("%s%s", category.hintBaseURL, c.hintAnchor),
 		}
+		if mr, ok := err.(*MeasuredResult); ok {
+			checkResult.Metrics = mr.Metrics
+			err = mr.Err
+		}
 		if vs, ok := err.(*VerboseSuccess); ok {
 			checkResult.Description = fmt.Sprintf("%s\n%s", checkResult.Description, vs.Message)
 		} else if err != nil {
@@ -2300,6 +2477,38 @@ func (hc *HealthChecker) checkMisconfiguredOpaquePortAnnotations(ctx context.Con
 	return nil
 }
 
+// checkConflictingServers checks that the pods of the data plane aren't
+// selected by several Servers with different proxy protocols on the same port,
+// which the proxies fail to serve
+func (hc *HealthChecker) checkConflictingServers(ctx context.Context) error {
+	servers, err := hc.kubeAPI.L5dCrdClient.ServerV1beta1().Servers(hc.DataPlaneNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	if len(servers.Items) < 2 {
+		return nil
+	}
+
+	pods, err := hc.GetDataPlanePods(ctx)
+	if err != nil {
+		return err
+	}
+
+	conflicts, err := k8s.ConflictingServers(servers.Items, pods)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	errStrings := make([]string, len(conflicts))
+	for i, conflict := range conflicts {
+		errStrings[i] = fmt.Sprintf("\t* %s with different proxy protocols", conflict)
+	}
+	return fmt.Errorf("some servers conflict:\n    %s", strings.Join(errStrings, "\n    "))
+}
+
 // getEndpointsPods takes a collection of endpoints and returns the set of all
 // the pods that they target.
 func getEndpointsPods(endpoints *corev1.Endpoints, kubeAPI *controllerK8s.API, namespace string) (map[*corev1.Pod]struct{}, error) {
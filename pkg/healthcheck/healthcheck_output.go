@@ -266,10 +266,11 @@ type checkCategory struct {
 // check is a user-facing version of `healthcheck.CheckResult`, for output via
 // `linkerd check -o json`.
 type check struct {
-	Description string      `json:"description"`
-	Hint        string      `json:"hint,omitempty"`
-	Error       string      `json:"error,omitempty"`
-	Result      checkResult `json:"result"`
+	Description string             `json:"description"`
+	Hint        string             `json:"hint,omitempty"`
+	Error       string             `json:"error,omitempty"`
+	Result      checkResult        `json:"result"`
+	Metrics     map[string]float64 `json:"metrics,omitempty"`
 }
 
 type checkResult string
@@ -306,6 +307,7 @@ func runChecksJSON(wout io.Writer, werr io.Writer, hc Runner) (bool, bool) {
 			currentCheck := &check{
 				Description: result.Description,
 				Result:      status,
+				Metrics:     result.Metrics,
 			}
 
 			if result.Err != nil {
@@ -358,6 +360,7 @@ func parseJSONCheckOutput(data []byte) (CheckResults, error) {
 				Err:         err,
 				HintURL:     check.Hint,
 				Warning:     check.Result == checkWarn,
+				Metrics:     check.Metrics,
 			})
 		}
 	}
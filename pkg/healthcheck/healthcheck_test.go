@@ -325,6 +325,103 @@ func TestHealthChecker(t *testing.T) {
 		}
 	})
 
+	t.Run("Retries failing categories when waiting for ready", func(t *testing.T) {
+		initialBackoff := waitInitialBackoff
+		waitInitialBackoff = time.Millisecond
+		t.Cleanup(func() { waitInitialBackoff = initialBackoff })
+		attempts := 0
+
+		flakyCheck := NewCategory(
+			"cat10",
+			[]Checker{
+				{
+					description: "desc10a",
+					check: func(context.Context) error {
+						attempts++
+						return nil
+					},
+				},
+				{
+					description: "desc10b",
+					check: func(context.Context) error {
+						if attempts < 3 {
+							return fmt.Errorf("not ready")
+						}
+						return nil
+					},
+				},
+			},
+			true,
+		)
+
+		var progress []*WaitProgress
+		hc := NewHealthChecker(
+			[]CategoryID{},
+			&Options{
+				RetryDeadline: time.Now().Add(100 * time.Second),
+				WaitForReady:  true,
+				WaitObserver:  func(p *WaitProgress) { progress = append(progress, p) },
+			},
+		)
+		hc.AppendCategories(passingCheck1)
+		hc.AppendCategories(flakyCheck)
+
+		observedResults := make([]string, 0)
+		observer := func(result *CheckResult) {
+			observedResults = append(observedResults, fmt.Sprintf("%s %s retry=%t", result.Category, result.Description, result.Retry))
+		}
+
+		success, _ := hc.RunChecks(observer)
+		if !success {
+			t.Fatal("Expecting checks to be successful")
+		}
+
+		expectedResults := []string{
+			"cat1 desc1 retry=false",
+			"cat10 desc10b retry=true",
+			"cat10 desc10b retry=true",
+			"cat10 desc10a retry=false",
+			"cat10 desc10b retry=false",
+		}
+		if !reflect.DeepEqual(observedResults, expectedResults) {
+			t.Fatalf("Expected results %v, but got %v", expectedResults, observedResults)
+		}
+
+		if len(progress) != 2 {
+			t.Fatalf("Expected 2 progress events, got %d", len(progress))
+		}
+		if progress[1].Attempt != 2 || progress[1].RetryIn != 2*time.Millisecond || progress[1].Failed["desc10b"] != "not ready" {
+			t.Fatalf("Unexpected progress: %+v", *progress[1])
+		}
+	})
+
+	t.Run("Reports failing categories once the wait deadline is reached", func(t *testing.T) {
+		hc := NewHealthChecker(
+			[]CategoryID{},
+			&Options{
+				RetryDeadline: time.Now(),
+				WaitForReady:  true,
+			},
+		)
+		hc.AppendCategories(failingCheck)
+		hc.AppendCategories(fatalCheck)
+		hc.AppendCategories(passingCheck1)
+
+		expectedResults := []string{
+			"cat3 desc3: error",
+			"cat6 desc6: fatal",
+		}
+
+		obs := newObserver()
+		success, _ := hc.RunChecks(obs.resultFn)
+		if success {
+			t.Fatal("Expecting checks to not be successful")
+		}
+		if !reflect.DeepEqual(obs.results, expectedResults) {
+			t.Fatalf("Expected results %v, but got %v", expectedResults, obs.results)
+		}
+	})
+
 	t.Run("Does not notify observer of skipped checks", func(t *testing.T) {
 		hc := NewHealthChecker(
 			[]CategoryID{},
@@ -3547,3 +3644,136 @@ func generateAllControlPlaneDef(replicaOptions *controlPlaneReplicaOptions, t *t
 	}
 	return resourceDefs
 }
+
+func TestCheckConflictingServers(t *testing.T) {
+	pod := `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji-1
+  namespace: test-ns
+  labels:
+    app: emoji
+    linkerd.io/control-plane-ns: linkerd
+spec:
+  containers:
+  - name: emoji
+    ports:
+    - name: grpc
+      containerPort: 8080
+`
+	grpcServer := `
+apiVersion: policy.linkerd.io/v1beta1
+kind: Server
+metadata:
+  name: emoji-grpc
+  namespace: test-ns
+spec:
+  podSelector:
+    matchLabels:
+      app: emoji
+  port: grpc
+  proxyProtocol: gRPC
+`
+
+	testCases := []struct {
+		name      string
+		resources []string
+		expected  string
+	}{
+		{
+			name: "servers with the same protocol",
+			resources: []string{pod, grpcServer, `
+apiVersion: policy.linkerd.io/v1beta1
+kind: Server
+metadata:
+  name: emoji-port
+  namespace: test-ns
+spec:
+  podSelector:
+    matchLabels:
+      app: emoji
+  port: 8080
+  proxyProtocol: gRPC
+`,
+			},
+		},
+		{
+			name: "servers on different ports",
+			resources: []string{pod, grpcServer, `
+apiVersion: policy.linkerd.io/v1beta1
+kind: Server
+metadata:
+  name: emoji-admin
+  namespace: test-ns
+spec:
+  podSelector:
+    matchLabels:
+      app: emoji
+  port: 4191
+`,
+			},
+		},
+		{
+			name: "servers with conflicting protocols",
+			resources: []string{pod, grpcServer, `
+apiVersion: policy.linkerd.io/v1beta1
+kind: Server
+metadata:
+  name: emoji-http
+  namespace: test-ns
+spec:
+  podSelector:
+    matchLabels:
+      app: emoji
+  port: 8080
+  proxyProtocol: HTTP/1
+`, `
+apiVersion: policy.linkerd.io/v1beta1
+kind: Server
+metadata:
+  name: emoji-unknown
+  namespace: test-ns
+spec:
+  podSelector:
+    matchLabels:
+      app: emoji
+  port: grpc
+`,
+			},
+			expected: `some servers conflict:
+    	* servers test-ns/emoji-grpc (gRPC) and test-ns/emoji-http (HTTP/1) select port 8080 of pod test-ns/emoji-1 with different proxy protocols
+    	* servers test-ns/emoji-grpc (gRPC) and test-ns/emoji-unknown (unknown) select port 8080 of pod test-ns/emoji-1 with different proxy protocols
+    	* servers test-ns/emoji-http (HTTP/1) and test-ns/emoji-unknown (unknown) select port 8080 of pod test-ns/emoji-1 with different proxy protocols`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			hc := NewHealthChecker(
+				[]CategoryID{LinkerdDataPlaneChecks},
+				&Options{
+					ControlPlaneNamespace: "linkerd",
+					DataPlaneNamespace:    "test-ns",
+				},
+			)
+			var err error
+			hc.kubeAPI, err = k8s.NewFakeAPI(tc.resources...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			err = hc.checkConflictingServers(context.Background())
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error:\n%s\nGot:\n%v", tc.expected, err)
+			}
+		})
+	}
+}
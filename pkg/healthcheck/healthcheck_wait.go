@@ -0,0 +1,135 @@
+package healthcheck
+
+import (
+	"fmt"
+	"time"
+)
+
+var (
+	// waitInitialBackoff is the time waited before retrying a failed category
+	// for the first time, when waiting for the checks to pass
+	waitInitialBackoff = time.Second
+	// waitMaxBackoff bounds the time waited between two attempts
+	waitMaxBackoff = 30 * time.Second
+)
+
+// WaitProgress reports that a category of checks failed and is going to be
+// retried, when waiting for the checks to pass.
+type WaitProgress struct {
+	Category CategoryID `json:"category"`
+	// Attempt is the number of times the category has been run
+	Attempt int `json:"attempt"`
+	// Failed holds the descriptions of the failed checks, by error
+	Failed   map[string]string `json:"failed"`
+	RetryIn  time.Duration     `json:"retryIn"`
+	Deadline time.Time         `json:"deadline"`
+}
+
+// WaitObserver receives the progress of the checks when waiting for them to
+// pass.
+type WaitObserver func(*WaitProgress)
+
+// runChecksUntilReady runs the categories one after the other. When a check of
+// a category fails, the whole category is retried with an exponential backoff
+// until all of its checks pass or the RetryDeadline is reached. The results of
+// a category are only passed to the observer once it's done; in the meantime
+// the observer receives a result marked for retry for each failed attempt.
+func (hc *HealthChecker) runChecksUntilReady(observer CheckObserver) (bool, bool) {
+	success := true
+	warning := false
+	for _, c := range hc.categories {
+		if !c.enabled {
+			continue
+		}
+
+		backoff := waitInitialBackoff
+		for attempt := 1; ; attempt++ {
+			results, categorySuccess, categoryWarning, fatal := hc.runCategory(c)
+
+			retryIn := backoff
+			if remaining := time.Until(hc.RetryDeadline); remaining < retryIn {
+				retryIn = remaining
+			}
+			if categorySuccess || retryIn <= 0 {
+				for _, result := range results {
+					observer(result)
+				}
+				success = success && categorySuccess
+				warning = warning || categoryWarning
+				if fatal {
+					return success, warning
+				}
+				break
+			}
+
+			progress := &WaitProgress{
+				Category: c.ID,
+				Attempt:  attempt,
+				Failed:   map[string]string{},
+				RetryIn:  retryIn,
+				Deadline: hc.RetryDeadline,
+			}
+			var firstFailure *CheckResult
+			for _, result := range results {
+				if result.Err != nil && !result.Warning {
+					progress.Failed[result.Description] = result.Err.Error()
+					if firstFailure == nil {
+						firstFailure = result
+					}
+				}
+			}
+			if hc.WaitObserver != nil {
+				hc.WaitObserver(progress)
+			}
+			observer(&CheckResult{
+				Category:    c.ID,
+				Description: firstFailure.Description,
+				HintURL:     firstFailure.HintURL,
+				Retry:       true,
+				Err: fmt.Errorf("waiting for %d check(s) to pass, retrying in %s (attempt %d): %s",
+					len(progress.Failed), retryIn.Round(time.Second), attempt, firstFailure.Err),
+			})
+
+			time.Sleep(retryIn)
+			backoff *= 2
+			if backoff > waitMaxBackoff {
+				backoff = waitMaxBackoff
+			}
+		}
+	}
+
+	return success, warning
+}
+
+// runCategory runs the checkers of the category once, without retrying them,
+// and returns their results. The remaining checkers are skipped after a fatal
+// check fails.
+func (hc *HealthChecker) runCategory(c *Category) ([]*CheckResult, bool, bool, bool) {
+	results := []*CheckResult{}
+	collect := func(result *CheckResult) {
+		results = append(results, result)
+	}
+
+	success := true
+	warning := false
+	for _, checker := range c.checkers {
+		checker := checker // pin
+		if checker.check == nil {
+			continue
+		}
+		// the whole category is retried instead
+		checker.retryDeadline = time.Time{}
+		if !hc.runCheck(c, &checker, collect) {
+			if !checker.warning {
+				success = false
+			} else {
+				warning = true
+			}
+			if checker.fatal {
+				return results, success, warning, true
+			}
+		}
+	}
+
+	return results, success, warning, false
+}
@@ -0,0 +1,196 @@
+package healthcheck
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	pkgtls "github.com/linkerd/linkerd2/pkg/tls"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// maxCertificateProbes bounds the number of proxies whose certificate is
+	// fetched concurrently
+	maxCertificateProbes = 10
+
+	// proxyCertRenewalMargin is the fraction of its lifetime under which a
+	// proxy certificate is reported as about to expire. Proxies renew their
+	// certificate once 70% of its lifetime has elapsed, so certificates
+	// getting closer to their expiry aren't being renewed.
+	proxyCertRenewalMargin = 0.25
+)
+
+// proxyCertificate is the certificate chain presented by a proxy
+type proxyCertificate struct {
+	namespace string
+	pod       string
+	chain     []*x509.Certificate
+	err       error
+}
+
+// checkDataPlaneProxiesCertificateValidity fetches the certificate of each
+// data plane proxy from its admin server, and verifies that it chains up to
+// the current trust anchors and that it's being renewed.
+func (hc *HealthChecker) checkDataPlaneProxiesCertificateValidity(ctx context.Context) error {
+	trustAnchorsPem, err := FetchTrustBundle(ctx, *hc.kubeAPI, hc.ControlPlaneNamespace)
+	if err != nil {
+		return err
+	}
+	roots, err := pkgtls.DecodePEMCertPool(trustAnchorsPem)
+	if err != nil {
+		return fmt.Errorf("failed to read the trust anchors: %s", err)
+	}
+
+	pods, err := hc.GetDataPlanePods(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Skip control plane pods since they load their trust anchors from the linkerd-identity-trust-anchors configmap.
+	probed := []corev1.Pod{}
+	for _, pod := range pods {
+		if pod.Namespace != hc.ControlPlaneNamespace && pod.Status.Phase == corev1.PodRunning {
+			probed = append(probed, pod)
+		}
+	}
+
+	certs := make([]proxyCertificate, len(probed))
+	sem := make(chan struct{}, maxCertificateProbes)
+	var wg sync.WaitGroup
+	for i, pod := range probed {
+		wg.Add(1)
+		go func(i int, pod corev1.Pod) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			chain, err := fetchProxyCertificate(hc.kubeAPI, pod)
+			certs[i] = proxyCertificate{namespace: pod.Namespace, pod: pod.Name, chain: chain, err: err}
+		}(i, pod)
+	}
+	wg.Wait()
+
+	return checkProxiesCertificates(certs, roots, time.Now())
+}
+
+// checkProxiesCertificates reports, per namespace, the proxies whose
+// certificate doesn't chain up to the roots, as well as the proxies whose
+// certificate is about to expire. Proxies whose certificate couldn't be
+// fetched are ignored.
+func checkProxiesCertificates(certs []proxyCertificate, roots *x509.CertPool, now time.Time) error {
+	mismatched := map[string][]string{}
+	total := map[string]int{}
+	expiring := []string{}
+	fetched := 0
+	for _, cert := range certs {
+		if cert.err != nil || len(cert.chain) == 0 {
+			continue
+		}
+		fetched++
+		total[cert.namespace]++
+
+		leaf := cert.chain[0]
+		intermediates := x509.NewCertPool()
+		for _, c := range cert.chain[1:] {
+			intermediates.AddCert(c)
+		}
+		// the expiry of the certificate is reported on its own
+		verifyTime := now
+		if verifyTime.After(leaf.NotAfter) {
+			verifyTime = leaf.NotAfter
+		}
+		_, err := leaf.Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			CurrentTime:   verifyTime,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		})
+		if err != nil {
+			mismatched[cert.namespace] = append(mismatched[cert.namespace], cert.pod)
+		}
+
+		lifetime := leaf.NotAfter.Sub(leaf.NotBefore)
+		remaining := leaf.NotAfter.Sub(now)
+		if remaining <= 0 {
+			expiring = append(expiring, fmt.Sprintf("* %s/%s (expired %s ago)", cert.namespace, cert.pod, (-remaining).Round(time.Second)))
+		} else if float64(remaining) < float64(lifetime)*proxyCertRenewalMargin {
+			expiring = append(expiring, fmt.Sprintf("* %s/%s (expires in %s)", cert.namespace, cert.pod, remaining.Round(time.Second)))
+		}
+	}
+
+	if len(certs) > 0 && fetched == 0 {
+		return &SkipError{Reason: "could not fetch the certificate of any data plane proxy"}
+	}
+
+	problems := []string{}
+	if len(mismatched) > 0 {
+		namespaces := make([]string, 0, len(mismatched))
+		for ns := range mismatched {
+			namespaces = append(namespaces, ns)
+		}
+		sort.Strings(namespaces)
+		summary := make([]string, len(namespaces))
+		for i, ns := range namespaces {
+			pods := mismatched[ns]
+			sort.Strings(pods)
+			summary[i] = fmt.Sprintf("* %s: %d of %d proxies (%s)", ns, len(pods), total[ns], strings.Join(pods, ", "))
+		}
+		problems = append(problems, fmt.Sprintf("Some proxies' certificates don't chain up to the current trust anchors, they must be restarted:\n\t%s", strings.Join(summary, "\n\t")))
+	}
+	if len(expiring) > 0 {
+		sort.Strings(expiring)
+		problems = append(problems, fmt.Sprintf("Some proxies' certificates are not being renewed:\n\t%s", strings.Join(expiring, "\n\t")))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(problems, "\n"))
+}
+
+// fetchProxyCertificate returns the certificate chain presented by the admin
+// server of the pod's proxy, which serves TLS to clients using the proxy's
+// identity as server name.
+func fetchProxyCertificate(kubeAPI *k8s.KubernetesAPI, pod corev1.Pod) ([]*x509.Certificate, error) {
+	var container *corev1.Container
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == k8s.ProxyContainerName {
+			container = &pod.Spec.Containers[i]
+		}
+	}
+	if container == nil {
+		return nil, fmt.Errorf("no proxy container found for pod %s", pod.GetName())
+	}
+
+	serverName, err := k8s.PodIdentity(&pod)
+	if err != nil {
+		return nil, err
+	}
+
+	portForward, err := k8s.NewContainerMetricsForward(kubeAPI, pod, *container, false, k8s.ProxyAdminPortName)
+	if err != nil {
+		return nil, err
+	}
+	defer portForward.Stop()
+	if err = portForward.Init(); err != nil {
+		return nil, err
+	}
+
+	conn, err := tls.Dial("tcp", portForward.AddressAndPort(), &tls.Config{
+		// the chain is verified against the trust anchors afterwards
+		// #nosec G402
+		InsecureSkipVerify: true,
+		ServerName:         serverName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return conn.ConnectionState().PeerCertificates, nil
+}
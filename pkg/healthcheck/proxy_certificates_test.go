@@ -0,0 +1,115 @@
+package healthcheck
+
+import (
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/tls"
+)
+
+func TestCheckProxiesCertificates(t *testing.T) {
+	issuerChain := func(root *tls.CA) []*x509.Certificate {
+		issuer, err := root.GenerateCA("identity.linkerd.cluster.local", -1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		cred, err := issuer.GenerateEndEntityCred("default.emojivoto.serviceaccount.identity.linkerd.cluster.local")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		return append([]*x509.Certificate{cred.Crt.Certificate}, cred.Crt.TrustChain...)
+	}
+
+	root, err := tls.GenerateRootCAWithDefaults("root.linkerd.cluster.local")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	oldRoot, err := tls.GenerateRootCAWithDefaults("root.linkerd.cluster.local")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(root.Cred.Crt.Certificate)
+
+	valid := issuerChain(root)
+	mismatched := issuerChain(oldRoot)
+	now := time.Now()
+
+	testCases := []struct {
+		name     string
+		certs    []proxyCertificate
+		now      time.Time
+		expected string
+		skipped  bool
+	}{
+		{
+			name: "certificates chain up to the trust anchors",
+			certs: []proxyCertificate{
+				{namespace: "emojivoto", pod: "web", chain: valid},
+				{namespace: "emojivoto", pod: "emoji", chain: valid},
+				{namespace: "booksapp", pod: "books", err: errors.New("port-forward failed")},
+			},
+			now: now,
+		},
+		{
+			name: "certificates issued from other trust anchors",
+			certs: []proxyCertificate{
+				{namespace: "emojivoto", pod: "web", chain: valid},
+				{namespace: "emojivoto", pod: "voting", chain: mismatched},
+				{namespace: "emojivoto", pod: "emoji", chain: mismatched},
+				{namespace: "booksapp", pod: "books", chain: mismatched},
+			},
+			now:      now,
+			expected: "Some proxies' certificates don't chain up to the current trust anchors, they must be restarted:\n\t* booksapp: 1 of 1 proxies (books)\n\t* emojivoto: 2 of 3 proxies (emoji, voting)",
+		},
+		{
+			name: "certificates about to expire",
+			certs: []proxyCertificate{
+				{namespace: "emojivoto", pod: "web", chain: valid},
+			},
+			now:      valid[0].NotAfter.Add(-time.Hour),
+			expected: "Some proxies' certificates are not being renewed:\n\t* emojivoto/web (expires in 1h0m0s)",
+		},
+		{
+			name: "expired certificates",
+			certs: []proxyCertificate{
+				{namespace: "emojivoto", pod: "web", chain: valid},
+			},
+			now:      valid[0].NotAfter.Add(time.Hour),
+			expected: "Some proxies' certificates are not being renewed:\n\t* emojivoto/web (expired 1h0m0s ago)",
+		},
+		{
+			name: "no certificate could be fetched",
+			certs: []proxyCertificate{
+				{namespace: "emojivoto", pod: "web", err: errors.New("port-forward failed")},
+			},
+			now:     now,
+			skipped: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkProxiesCertificates(tc.certs, roots, tc.now)
+			if tc.skipped {
+				var skip *SkipError
+				if !errors.As(err, &skip) {
+					t.Fatalf("Expected the check to be skipped, got %v", err)
+				}
+				return
+			}
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
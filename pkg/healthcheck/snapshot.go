@@ -0,0 +1,233 @@
+package healthcheck
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sVersion "k8s.io/apimachinery/pkg/version"
+	discoveryfake "k8s.io/client-go/discovery/fake"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	snapshotResourcesFile = "resources.yaml"
+	snapshotVersionFile   = "version.json"
+
+	snapshotSkipReason = "skipping check because it requires access to the cluster"
+)
+
+// Snapshot is the state of a cluster, as exported by `linkerd diagnostics
+// bundle` along with other diagnostics. The checks can run against it instead
+// of a live cluster, to triage the issues of a cluster one doesn't have access
+// to.
+type Snapshot struct {
+	// Resources holds the resources of the cluster, serialized as YAML
+	// documents
+	Resources []byte
+
+	// Version is the version of the cluster's API server
+	Version *k8sVersion.Info
+}
+
+// ReadSnapshot reads the snapshot from the archive written by `linkerd
+// diagnostics bundle`, or from the directory it was extracted to
+func ReadSnapshot(bundle string) (*Snapshot, error) {
+	info, err := os.Stat(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	var files map[string][]byte
+	if info.IsDir() {
+		files, err = readDir(bundle)
+	} else {
+		files, err = readArchive(bundle)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewSnapshot(files)
+}
+
+// NewSnapshot parses a snapshot from the contents of its files, keyed by
+// their slash-separated path, as returned by Snapshot.Files
+func NewSnapshot(files map[string][]byte) (*Snapshot, error) {
+	resources, ok := files[snapshotResourcesFile]
+	if !ok {
+		return nil, fmt.Errorf("%s not found in the snapshot", snapshotResourcesFile)
+	}
+
+	rawVersion, ok := files[snapshotVersionFile]
+	if !ok {
+		return nil, fmt.Errorf("%s not found in the snapshot", snapshotVersionFile)
+	}
+	var version k8sVersion.Info
+	if err := json.Unmarshal(rawVersion, &version); err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", snapshotVersionFile, err)
+	}
+
+	return &Snapshot{
+		Resources: resources,
+		Version:   &version,
+	}, nil
+}
+
+// Files returns the contents of the snapshot's files, keyed by their
+// slash-separated path
+func (s *Snapshot) Files() (map[string][]byte, error) {
+	rawVersion, err := json.MarshalIndent(s.Version, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string][]byte{
+		snapshotResourcesFile: s.Resources,
+		snapshotVersionFile:   rawVersion,
+	}, nil
+}
+
+// WriteArchive writes the files of a bundle, such as the ones of a snapshot,
+// to a gzipped tarball, under the root directory. As they may hold secrets,
+// the files are only readable by their owner once extracted.
+func WriteArchive(w io.Writer, root string, files map[string][]byte) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	now := time.Now()
+	for _, name := range names {
+		header := &tar.Header{
+			Name:    path.Join(root, name),
+			Mode:    0600,
+			Size:    int64(len(files[name])),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// readArchive reads the files of an archive written by WriteArchive,
+// stripping their root directory
+func readArchive(archive string) (map[string][]byte, error) {
+	f, err := os.Open(archive)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive %s: %s", archive, err)
+	}
+	tr := tar.NewReader(gz)
+
+	files := map[string][]byte{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive %s: %s", archive, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimPrefix(path.Clean(header.Name), "/")
+		if i := strings.Index(name, "/"); i >= 0 {
+			name = name[i+1:]
+		}
+		files[name] = content
+	}
+	return files, nil
+}
+
+// readDir reads the files of an extracted archive
+func readDir(dir string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		name, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(name)] = content
+		return nil
+	})
+	return files, err
+}
+
+// AddResources serializes the given objects into the snapshot's resources.
+// Objects returned by the typed clients lack their kind, which is set from
+// the scheme.
+func (s *Snapshot) AddResources(objs ...runtime.Object) error {
+	var buf bytes.Buffer
+	for _, obj := range objs {
+		gvks, _, err := k8s.ObjectKinds(obj)
+		if err != nil {
+			return err
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvks[0])
+		doc, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		buf.WriteString("---\n")
+		buf.Write(doc)
+	}
+	s.Resources = append(s.Resources, buf.Bytes()...)
+	return nil
+}
+
+// kubernetesAPI returns a client serving the snapshot's resources
+func (s *Snapshot) kubernetesAPI() (*k8s.KubernetesAPI, error) {
+	api, err := k8s.NewFakeAPIFromManifests([]io.Reader{bytes.NewReader(s.Resources)})
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", snapshotResourcesFile, err)
+	}
+	if discovery, ok := api.Discovery().(*discoveryfake.FakeDiscovery); ok {
+		discovery.FakedServerVersion = s.Version
+	}
+	return api, nil
+}
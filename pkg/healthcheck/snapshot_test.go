@@ -0,0 +1,86 @@
+package healthcheck
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sVersion "k8s.io/apimachinery/pkg/version"
+)
+
+func TestSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	snapshot := &Snapshot{
+		Version: &k8sVersion.Info{Major: "1", Minor: "21", GitVersion: "v1.21.1"},
+	}
+	err = snapshot.AddResources(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "linkerd"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "linkerd-config", Namespace: "linkerd"}},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	files, err := snapshot.Files()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	// other diagnostics are bundled along with the snapshot
+	files["check.json"] = []byte("{}")
+
+	archive := filepath.Join(dir, "bundle.tar.gz")
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteArchive(f, "linkerd-bundle", files); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	f.Close()
+
+	read, err := ReadSnapshot(archive)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(read, snapshot) {
+		t.Fatalf("Expected snapshot %+v, got %+v", snapshot, read)
+	}
+
+	hc := NewHealthChecker([]CategoryID{KubernetesAPIChecks, KubernetesVersionChecks}, &Options{Snapshot: read})
+	observed := []string{}
+	observer := func(result *CheckResult) {
+		if result.Err != nil {
+			t.Fatalf("Unexpected error in check %q: %s", result.Description, result.Err)
+		}
+		observed = append(observed, result.Description)
+	}
+	hc.RunChecks(observer)
+
+	// the kubectl version check is skipped, as it doesn't tell anything about
+	// the cluster
+	expected := []string{
+		"can initialize the client",
+		"can query the Kubernetes API",
+		"is running the minimum Kubernetes API version",
+	}
+	if !reflect.DeepEqual(observed, expected) {
+		t.Fatalf("Expected checks %v, got %v", expected, observed)
+	}
+
+	cm, err := hc.KubeAPIClient().CoreV1().ConfigMaps("linkerd").Get(context.Background(), "linkerd-config", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if cm.GetName() != "linkerd-config" {
+		t.Fatalf("Expected the linkerd-config ConfigMap, got %s", cm.GetName())
+	}
+}
@@ -0,0 +1,51 @@
+package healthcheck
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/tls"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const strictCryptoSkipReason = "not run unless the strict crypto mode is enabled"
+
+// strictCryptoContainers are the containers of the control plane serving TLS,
+// or signing certificates, which must run in the crypto mode of the install
+var strictCryptoContainers = map[string]struct{}{
+	"identity":       {},
+	"proxy-injector": {},
+	"sp-validator":   {},
+}
+
+func (hc *HealthChecker) isStrictCrypto() bool {
+	return hc.linkerdConfig != nil && hc.linkerdConfig.ControllerCryptoMode == string(tls.CryptoModeStrict)
+}
+
+// checkStrictCryptoPods returns an error listing the control plane containers
+// not running in the strict crypto mode, e.g. because their pods predate its
+// enablement
+func checkStrictCryptoPods(pods []corev1.Pod) error {
+	expectedArg := fmt.Sprintf("-crypto-mode=%s", tls.CryptoModeStrict)
+	var lax []string
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			if _, ok := strictCryptoContainers[container.Name]; !ok {
+				continue
+			}
+			strict := false
+			for _, arg := range container.Args {
+				if arg == expectedArg {
+					strict = true
+				}
+			}
+			if !strict {
+				lax = append(lax, fmt.Sprintf("* %s/%s", pod.Name, container.Name))
+			}
+		}
+	}
+	if len(lax) > 0 {
+		return fmt.Errorf("Some containers aren't running in the strict crypto mode; restart them to apply it:\n\t%s", strings.Join(lax, "\n\t"))
+	}
+	return nil
+}
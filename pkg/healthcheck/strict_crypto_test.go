@@ -0,0 +1,58 @@
+package healthcheck
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckStrictCryptoPods(t *testing.T) {
+	pod := func(name string, containers ...corev1.Container) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1.PodSpec{Containers: containers},
+		}
+	}
+	container := func(name string, args ...string) corev1.Container {
+		return corev1.Container{Name: name, Args: args}
+	}
+
+	testCases := []struct {
+		name     string
+		pods     []corev1.Pod
+		expected string
+	}{
+		{
+			name: "strict control plane",
+			pods: []corev1.Pod{
+				pod("linkerd-identity-1", container("identity", "identity", "-crypto-mode=strict"), container("linkerd-proxy")),
+				pod("linkerd-destination-1", container("destination"), container("sp-validator", "sp-validator", "-crypto-mode=strict")),
+			},
+		},
+		{
+			name: "pods predating the strict crypto mode",
+			pods: []corev1.Pod{
+				pod("linkerd-identity-1", container("identity", "identity", "-crypto-mode=default")),
+				pod("linkerd-proxy-injector-1", container("proxy-injector", "proxy-injector")),
+			},
+			expected: "Some containers aren't running in the strict crypto mode; restart them to apply it:\n\t* linkerd-identity-1/identity\n\t* linkerd-proxy-injector-1/proxy-injector",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkStrictCryptoPods(tc.pods)
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error [%s], got [%v]", tc.expected, err)
+			}
+		})
+	}
+}
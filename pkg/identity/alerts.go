@@ -0,0 +1,67 @@
+package identity
+
+import (
+	"fmt"
+	"time"
+)
+
+const alertRuleGroup = "linkerd-identity"
+
+// AlertRuleGroup is a group of Prometheus alerting rules, as found in a rule
+// file
+type AlertRuleGroup struct {
+	Name  string      `json:"name"`
+	Rules []AlertRule `json:"rules"`
+}
+
+// AlertRule is a Prometheus alerting rule
+type AlertRule struct {
+	Alert       string            `json:"alert"`
+	Expr        string            `json:"expr"`
+	For         string            `json:"for,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// IssuerExpiryAlertRules returns the rules alerting when the issuer
+// certificate of a control plane expires within warning, with a warning
+// severity, and within critical, with a critical severity. The alerts fire
+// on the soonest expiry reported by the identity replicas of each control
+// plane namespace, so a replica that didn't reload a rotated issuer is caught.
+func IssuerExpiryAlertRules(warning, critical time.Duration) AlertRuleGroup {
+	expiry := fmt.Sprintf("min by (namespace) (%s) - time()", IssuerExpiryMetric)
+	rule := func(alert, severity string, within time.Duration) AlertRule {
+		return AlertRule{
+			Alert: alert,
+			Expr:  fmt.Sprintf("%s < %d", expiry, int64(within.Seconds())),
+			For:   "10m",
+			Labels: map[string]string{
+				"severity": severity,
+			},
+			Annotations: map[string]string{
+				"summary":     "The Linkerd identity issuer certificate expires soon",
+				"description": fmt.Sprintf("The identity issuer certificate of the control plane in the {{ $labels.namespace }} namespace expires in {{ $value | humanizeDuration }}, less than %s; rotate it with `linkerd identity rotate-issuer` before the proxies fail to renew their certificates.", formatDuration(within)),
+			},
+		}
+	}
+
+	return AlertRuleGroup{
+		Name: alertRuleGroup,
+		Rules: []AlertRule{
+			rule("LinkerdIdentityIssuerExpiringSoon", "warning", warning),
+			rule("LinkerdIdentityIssuerExpiryCritical", "critical", critical),
+		},
+	}
+}
+
+// formatDuration formats durations in days when they're whole days
+func formatDuration(d time.Duration) string {
+	day := 24 * time.Hour
+	switch {
+	case d == day:
+		return "1 day"
+	case d > day && d%day == 0:
+		return fmt.Sprintf("%d days", d/day)
+	}
+	return d.String()
+}
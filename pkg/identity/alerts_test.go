@@ -0,0 +1,54 @@
+package identity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIssuerExpiryAlertRules(t *testing.T) {
+	group := IssuerExpiryAlertRules(7*24*time.Hour, 36*time.Hour)
+	if group.Name != "linkerd-identity" || len(group.Rules) != 2 {
+		t.Fatalf("Expected the linkerd-identity group with 2 rules, got %+v", group)
+	}
+
+	testCases := []struct {
+		rule        AlertRule
+		alert       string
+		expr        string
+		severity    string
+		description string
+	}{
+		{
+			rule:        group.Rules[0],
+			alert:       "LinkerdIdentityIssuerExpiringSoon",
+			expr:        "min by (namespace) (identity_cert_issuer_expiry_timestamp_seconds) - time() < 604800",
+			severity:    "warning",
+			description: "The identity issuer certificate of the control plane in the {{ $labels.namespace }} namespace expires in {{ $value | humanizeDuration }}, less than 7 days; rotate it with `linkerd identity rotate-issuer` before the proxies fail to renew their certificates.",
+		},
+		{
+			rule:        group.Rules[1],
+			alert:       "LinkerdIdentityIssuerExpiryCritical",
+			expr:        "min by (namespace) (identity_cert_issuer_expiry_timestamp_seconds) - time() < 129600",
+			severity:    "critical",
+			description: "The identity issuer certificate of the control plane in the {{ $labels.namespace }} namespace expires in {{ $value | humanizeDuration }}, less than 36h0m0s; rotate it with `linkerd identity rotate-issuer` before the proxies fail to renew their certificates.",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.alert, func(t *testing.T) {
+			if tc.rule.Alert != tc.alert {
+				t.Fatalf("Expected alert %s, got %s", tc.alert, tc.rule.Alert)
+			}
+			if tc.rule.Expr != tc.expr {
+				t.Fatalf("Expected expression %q, got %q", tc.expr, tc.rule.Expr)
+			}
+			if tc.rule.Labels["severity"] != tc.severity {
+				t.Fatalf("Expected severity %s, got %s", tc.severity, tc.rule.Labels["severity"])
+			}
+			if tc.rule.Annotations["description"] != tc.description {
+				t.Fatalf("Expected description %q, got %q", tc.description, tc.rule.Annotations["description"])
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package identity
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type (
+	// AuditRecord describes a certificate issued by the identity service.
+	AuditRecord struct {
+		Time           time.Time `json:"time"`
+		Identity       string    `json:"identity"`
+		CSRFingerprint string    `json:"csrFingerprint"`
+		TTL            string    `json:"ttl"`
+		ValidUntil     time.Time `json:"validUntil"`
+		ClientAddress  string    `json:"clientAddress"`
+	}
+
+	// Auditor logs every certificate issuance as a JSON line and keeps the
+	// latest ones in memory, so that they can be queried through the admin
+	// server.
+	Auditor struct {
+		log *log.Logger
+
+		mu      sync.Mutex
+		records []AuditRecord
+		// next is the index of the ring where the next record is stored
+		next int
+		full bool
+	}
+)
+
+// NewAuditor returns an Auditor writing to out and keeping up to size records
+// in memory.
+func NewAuditor(out io.Writer, size int) *Auditor {
+	logger := log.New()
+	logger.SetOutput(out)
+	logger.SetFormatter(&log.JSONFormatter{})
+	if size < 1 {
+		size = 1
+	}
+	return &Auditor{
+		log:     logger,
+		records: make([]AuditRecord, size),
+	}
+}
+
+func newAuditRecord(now time.Time, identity string, csr *x509.CertificateRequest, validUntil time.Time, clientAddress string) AuditRecord {
+	fingerprint := sha256.Sum256(csr.Raw)
+	return AuditRecord{
+		Time:           now,
+		Identity:       identity,
+		CSRFingerprint: hex.EncodeToString(fingerprint[:]),
+		TTL:            validUntil.Sub(now).Round(time.Second).String(),
+		ValidUntil:     validUntil,
+		ClientAddress:  clientAddress,
+	}
+}
+
+func (a *Auditor) record(r AuditRecord) {
+	if a == nil {
+		return
+	}
+
+	a.log.WithFields(log.Fields{
+		"identity":       r.Identity,
+		"csrFingerprint": r.CSRFingerprint,
+		"ttl":            r.TTL,
+		"validUntil":     r.ValidUntil,
+		"clientAddress":  r.ClientAddress,
+	}).WithTime(r.Time).Info("issued certificate")
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.records[a.next] = r
+	a.next = (a.next + 1) % len(a.records)
+	if a.next == 0 {
+		a.full = true
+	}
+}
+
+// Records returns the records kept in memory for the identity, or for all the
+// identities if empty, oldest first.
+func (a *Auditor) Records(identity string) []AuditRecord {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	ordered := a.records[:a.next]
+	if a.full {
+		ordered = append(append([]AuditRecord{}, a.records[a.next:]...), a.records[:a.next]...)
+	}
+
+	records := []AuditRecord{}
+	for _, r := range ordered {
+		if identity == "" || r.Identity == identity {
+			records = append(records, r)
+		}
+	}
+	return records
+}
+
+// ServeHTTP serves the records kept in memory as a JSON array. The records can
+// be filtered with the identity query parameter, and the limit query parameter
+// only returns the latest ones.
+func (a *Auditor) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	records := a.Records(req.URL.Query().Get("identity"))
+	if l := req.URL.Query().Get("limit"); l != "" {
+		limit, err := strconv.Atoi(l)
+		if err != nil || limit < 0 {
+			http.Error(w, "limit must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+		if limit < len(records) {
+			records = records[len(records)-limit:]
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(records); err != nil {
+		log.Errorf("failed to write audit records: %s", err)
+	}
+}
@@ -0,0 +1,89 @@
+package identity
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuditor(t *testing.T) {
+	now := time.Now()
+	csr := &x509.CertificateRequest{Raw: []byte("csr")}
+
+	t.Run("Logs the issued certificates as JSON", func(t *testing.T) {
+		var out bytes.Buffer
+		auditor := NewAuditor(&out, 10)
+		auditor.record(newAuditRecord(now, "foo.ns.serviceaccount.identity.linkerd.cluster.local", csr, now.Add(time.Hour), "10.0.0.1:4000"))
+
+		entry := map[string]interface{}{}
+		if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+			t.Fatalf("Expected a JSON log line, got %q: %s", out.String(), err)
+		}
+		expected := map[string]interface{}{
+			"identity":       "foo.ns.serviceaccount.identity.linkerd.cluster.local",
+			"csrFingerprint": "c27338c453067b437471afbce792704d816112c6031bb996b62c698ea599ef80",
+			"ttl":            "1h0m0s",
+			"clientAddress":  "10.0.0.1:4000",
+			"msg":            "issued certificate",
+		}
+		for key, value := range expected {
+			if entry[key] != value {
+				t.Fatalf("Expected %s to be %v, got %v", key, value, entry[key])
+			}
+		}
+	})
+
+	t.Run("Keeps the latest records", func(t *testing.T) {
+		auditor := NewAuditor(&bytes.Buffer{}, 3)
+		for i := 0; i < 5; i++ {
+			identity := fmt.Sprintf("id-%d", i%2)
+			auditor.record(newAuditRecord(now.Add(time.Duration(i)*time.Second), identity, csr, now.Add(time.Hour), ""))
+		}
+
+		records := auditor.Records("")
+		if len(records) != 3 {
+			t.Fatalf("Expected 3 records, got %d", len(records))
+		}
+		for i, r := range records {
+			if expected := now.Add(time.Duration(i+2) * time.Second); !r.Time.Equal(expected) {
+				t.Fatalf("Expected record %d at %s, got %s", i, expected, r.Time)
+			}
+		}
+
+		if records := auditor.Records("id-1"); len(records) != 1 || records[0].Identity != "id-1" {
+			t.Fatalf("Expected a single record for id-1, got %v", records)
+		}
+	})
+
+	t.Run("Serves the records", func(t *testing.T) {
+		auditor := NewAuditor(&bytes.Buffer{}, 10)
+		for i := 0; i < 3; i++ {
+			auditor.record(newAuditRecord(now, fmt.Sprintf("id-%d", i), csr, now.Add(time.Hour), ""))
+		}
+
+		rec := httptest.NewRecorder()
+		auditor.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/audit?limit=2", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", rec.Code)
+		}
+		var records []AuditRecord
+		if err := json.NewDecoder(rec.Body).Decode(&records); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(records) != 2 || records[0].Identity != "id-1" || records[1].Identity != "id-2" {
+			t.Fatalf("Expected the 2 latest records, got %v", records)
+		}
+
+		rec = httptest.NewRecorder()
+		auditor.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/audit?limit=-1", nil))
+		if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "limit") {
+			t.Fatalf("Expected a bad request, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+}
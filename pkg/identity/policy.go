@@ -0,0 +1,71 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type (
+	// IssuanceRequest describes a certificate signing request whose token was
+	// validated, as submitted to the issuance policies.
+	IssuanceRequest struct {
+		// Identity is the DNS-like identity the certificate is requested for
+		Identity       string
+		ServiceAccount string
+		Namespace      string
+		// Lifetime is the lifetime the certificate would be issued for
+		Lifetime time.Duration
+	}
+
+	// IssuanceDecision is the outcome of an issuance policy for a request.
+	IssuanceDecision struct {
+		Allowed bool
+		// Reason explains why the request is denied or constrained
+		Reason string
+		// Lifetime caps the lifetime of the certificate; zero keeps the
+		// requested lifetime, and longer ones are ignored
+		Lifetime time.Duration
+	}
+
+	// Policy implementors decide whether a certificate is issued, and for how
+	// long, before the issuer signs it. This allows enforcing org-specific
+	// issuance rules.
+	Policy interface {
+		// Check returns the decision of the policy for the request. An error
+		// is returned when the policy couldn't be evaluated, in which case the
+		// request is denied until it can be.
+		Check(context.Context, IssuanceRequest) (IssuanceDecision, error)
+	}
+
+	// PolicyDenied is an error type returned when an issuance policy rejects
+	// a request.
+	PolicyDenied struct{ Reason string }
+)
+
+// checkPolicies submits the request to the policies in turn, stopping at the
+// first one denying it. It returns the lifetime the certificate should be
+// issued for, the shortest one any policy allows.
+func checkPolicies(ctx context.Context, policies []Policy, req IssuanceRequest) (time.Duration, error) {
+	lifetime := req.Lifetime
+	for _, policy := range policies {
+		decision, err := policy.Check(ctx, req)
+		if err != nil {
+			return 0, err
+		}
+		if !decision.Allowed {
+			return 0, PolicyDenied{decision.Reason}
+		}
+		if decision.Lifetime > 0 && decision.Lifetime < lifetime {
+			lifetime = decision.Lifetime
+		}
+	}
+	return lifetime, nil
+}
+
+func (e PolicyDenied) Error() string {
+	if e.Reason == "" {
+		return "certificate issuance denied by policy"
+	}
+	return fmt.Sprintf("certificate issuance denied by policy: %s", e.Reason)
+}
@@ -0,0 +1,85 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakePolicy struct {
+	decision IssuanceDecision
+	err      error
+}
+
+func (fp *fakePolicy) Check(context.Context, IssuanceRequest) (IssuanceDecision, error) {
+	return fp.decision, fp.err
+}
+
+func TestCheckPolicies(t *testing.T) {
+	allow := &fakePolicy{decision: IssuanceDecision{Allowed: true}}
+	shorten := func(lifetime time.Duration) *fakePolicy {
+		return &fakePolicy{decision: IssuanceDecision{Allowed: true, Lifetime: lifetime}}
+	}
+
+	testCases := []struct {
+		name             string
+		policies         []Policy
+		expectedLifetime time.Duration
+		expectedErr      string
+	}{
+		{
+			name:             "no policies",
+			expectedLifetime: 24 * time.Hour,
+		},
+		{
+			name:             "allowed",
+			policies:         []Policy{allow},
+			expectedLifetime: 24 * time.Hour,
+		},
+		{
+			name:             "shortest lifetime wins",
+			policies:         []Policy{shorten(2 * time.Hour), shorten(time.Hour), allow},
+			expectedLifetime: time.Hour,
+		},
+		{
+			name:             "longer lifetimes are ignored",
+			policies:         []Policy{shorten(48 * time.Hour)},
+			expectedLifetime: 24 * time.Hour,
+		},
+		{
+			name:        "denied",
+			policies:    []Policy{allow, &fakePolicy{decision: IssuanceDecision{Reason: "namespace is quarantined"}}},
+			expectedErr: "certificate issuance denied by policy: namespace is quarantined",
+		},
+		{
+			name:        "policy failure",
+			policies:    []Policy{&fakePolicy{err: errors.New("webhook unavailable")}, allow},
+			expectedErr: "webhook unavailable",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			lifetime, err := checkPolicies(context.Background(), tc.policies, IssuanceRequest{
+				Identity:       "foo.emojivoto.serviceaccount.identity.linkerd.cluster.local",
+				ServiceAccount: "foo",
+				Namespace:      "emojivoto",
+				Lifetime:       24 * time.Hour,
+			})
+			if tc.expectedErr != "" {
+				if err == nil || err.Error() != tc.expectedErr {
+					t.Fatalf("Expected error %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if lifetime != tc.expectedLifetime {
+				t.Fatalf("Expected lifetime %s, got %s", tc.expectedLifetime, lifetime)
+			}
+		})
+	}
+}
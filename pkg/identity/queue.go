@@ -0,0 +1,268 @@
+package identity
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	priorityExpiring = "expiring"
+	priorityNew      = "new"
+
+	// keys whose certificates have expired are forgotten at most this often
+	pruneInterval = time.Minute
+)
+
+var (
+	issuanceQueueLength = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "identity_issuance_queue_length",
+		Help: "Number of certificate signing requests waiting to be processed",
+	}, []string{"priority"})
+
+	issuanceInflight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "identity_issuance_inflight",
+		Help: "Number of certificate signing requests being processed",
+	})
+
+	issuanceWaitSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "identity_issuance_wait_seconds",
+		Help:    "Time certificate signing requests spent waiting in the issuance queue",
+		Buckets: []float64{0.001, 0.01, 0.05, 0.1, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"priority"})
+
+	issuanceRejectedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "identity_issuance_rejected_total",
+		Help: "Number of certificate signing requests rejected because the issuance queue was full",
+	})
+)
+
+type (
+	// issuanceQueue bounds the number of certificate signing requests
+	// processed concurrently. Requests renewing a certificate that is about
+	// to expire are served before requests for new certificates, and within
+	// each priority the namespaces are served round-robin so that a mass
+	// restart in one namespace doesn't starve the others.
+	issuanceQueue struct {
+		concurrency int
+		capacity    int
+		// renewal requests for certificates expiring within this window are
+		// prioritized
+		expiringWindow time.Duration
+
+		sync.Mutex
+		inflight int
+		queued   int
+		expiring *fairQueue
+		new      *fairQueue
+
+		// expiry of the last certificate issued for each public key. Proxies
+		// reuse their key pair across renewals, which allows to tell renewals
+		// apart from first issuances.
+		issued    map[string]time.Time
+		lastPrune time.Time
+	}
+
+	// fairQueue holds a FIFO of tickets per namespace, served round-robin
+	fairQueue struct {
+		namespaces map[string][]*ticket
+		order      []string
+	}
+
+	ticket struct {
+		ready     chan struct{}
+		granted   bool
+		cancelled bool
+	}
+)
+
+func newIssuanceQueue(concurrency, capacity int, lifetime time.Duration) *issuanceQueue {
+	if concurrency <= 0 {
+		return nil
+	}
+	return &issuanceQueue{
+		concurrency:    concurrency,
+		capacity:       capacity,
+		expiringWindow: lifetime / 2,
+		expiring:       newFairQueue(),
+		new:            newFairQueue(),
+		issued:         make(map[string]time.Time),
+	}
+}
+
+func newFairQueue() *fairQueue {
+	return &fairQueue{namespaces: make(map[string][]*ticket)}
+}
+
+func (fq *fairQueue) push(namespace string, t *ticket) {
+	if _, ok := fq.namespaces[namespace]; !ok {
+		fq.order = append(fq.order, namespace)
+	}
+	fq.namespaces[namespace] = append(fq.namespaces[namespace], t)
+}
+
+// pop returns the oldest ticket of the next namespace in line, skipping
+// cancelled tickets
+func (fq *fairQueue) pop() *ticket {
+	for len(fq.order) > 0 {
+		namespace := fq.order[0]
+		fq.order = fq.order[1:]
+
+		tickets := fq.namespaces[namespace]
+		t := tickets[0]
+		if len(tickets) > 1 {
+			fq.namespaces[namespace] = tickets[1:]
+			fq.order = append(fq.order, namespace)
+		} else {
+			delete(fq.namespaces, namespace)
+		}
+
+		if !t.cancelled {
+			return t
+		}
+	}
+	return nil
+}
+
+// wait blocks until the request is allowed to be processed, and returns a
+// func that must be called once it's done. The identity must have been
+// authenticated, as its namespace decides the request's turn. A nil queue lets
+// every request through.
+func (q *issuanceQueue) wait(ctx context.Context, identity string, publicKey []byte) (func(), error) {
+	if q == nil {
+		return func() {}, nil
+	}
+
+	priority := q.priority(publicKey)
+
+	q.Lock()
+	if q.inflight < q.concurrency && q.queued == 0 {
+		q.inflight++
+		issuanceInflight.Set(float64(q.inflight))
+		q.Unlock()
+		issuanceWaitSeconds.WithLabelValues(priority).Observe(0)
+		return q.release, nil
+	}
+	if q.queued >= q.capacity {
+		q.Unlock()
+		issuanceRejectedCounter.Inc()
+		return nil, status.Error(codes.ResourceExhausted, "too many pending certificate signing requests")
+	}
+
+	t := &ticket{ready: make(chan struct{})}
+	if priority == priorityExpiring {
+		q.expiring.push(identityNamespace(identity), t)
+	} else {
+		q.new.push(identityNamespace(identity), t)
+	}
+	q.queued++
+	issuanceQueueLength.WithLabelValues(priority).Inc()
+	q.Unlock()
+
+	start := time.Now()
+	select {
+	case <-t.ready:
+		issuanceQueueLength.WithLabelValues(priority).Dec()
+		issuanceWaitSeconds.WithLabelValues(priority).Observe(time.Since(start).Seconds())
+		return q.release, nil
+	case <-ctx.Done():
+		q.Lock()
+		defer q.Unlock()
+		issuanceQueueLength.WithLabelValues(priority).Dec()
+		if t.granted {
+			// the ticket got granted concurrently, so hand the slot over to
+			// the next request
+			q.inflight--
+			q.dispatch()
+		} else {
+			t.cancelled = true
+			q.queued--
+		}
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+func (q *issuanceQueue) release() {
+	q.Lock()
+	defer q.Unlock()
+	q.inflight--
+	q.dispatch()
+}
+
+// dispatch grants the free processing slots to the next tickets in line. It
+// must be called with the lock held.
+func (q *issuanceQueue) dispatch() {
+	for q.inflight < q.concurrency {
+		t := q.expiring.pop()
+		if t == nil {
+			t = q.new.pop()
+		}
+		if t == nil {
+			break
+		}
+		q.queued--
+		q.inflight++
+		t.granted = true
+		close(t.ready)
+	}
+	issuanceInflight.Set(float64(q.inflight))
+}
+
+// priority returns priorityExpiring if a certificate was issued for the
+// public key and it's about to expire. The issued certificates are only
+// tracked in memory, so all the requests are considered new after a restart
+// of the identity service.
+func (q *issuanceQueue) priority(publicKey []byte) string {
+	q.Lock()
+	defer q.Unlock()
+	notAfter, ok := q.issued[keyHash(publicKey)]
+	if ok && time.Until(notAfter) < q.expiringWindow {
+		return priorityExpiring
+	}
+	return priorityNew
+}
+
+// issuedFor records the expiry of the certificate issued for the public key
+func (q *issuanceQueue) issuedFor(publicKey []byte, notAfter time.Time) {
+	if q == nil {
+		return
+	}
+
+	q.Lock()
+	defer q.Unlock()
+	q.issued[keyHash(publicKey)] = notAfter
+
+	now := time.Now()
+	if now.Sub(q.lastPrune) < pruneInterval {
+		return
+	}
+	q.lastPrune = now
+	for key, expiry := range q.issued {
+		if now.After(expiry) {
+			delete(q.issued, key)
+		}
+	}
+}
+
+func keyHash(publicKey []byte) string {
+	hash := sha256.Sum256(publicKey)
+	return hex.EncodeToString(hash[:])
+}
+
+// identityNamespace extracts the namespace from an identity of the form
+// <serviceaccount>.<namespace>.serviceaccount.identity.<...>
+func identityNamespace(identity string) string {
+	segments := strings.SplitN(identity, ".", 3)
+	if len(segments) < 2 {
+		return ""
+	}
+	return segments[1]
+}
@@ -0,0 +1,111 @@
+package identity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIssuanceQueue(t *testing.T) {
+	t.Run("Serves expiring certificates first, then namespaces round-robin", func(t *testing.T) {
+		q := newIssuanceQueue(1, 10, 24*time.Hour)
+		q.issuedFor([]byte("expiring-key"), time.Now().Add(time.Hour))
+		q.issuedFor([]byte("fresh-key"), time.Now().Add(23*time.Hour))
+
+		// take the only slot so that everything else gets queued
+		release, err := q.wait(context.Background(), "sa.ns1.serviceaccount.identity.linkerd.cluster.local", []byte("key-0"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		requests := []struct{ name, identity, key string }{
+			{"ns1-a", "sa.ns1.serviceaccount.identity.linkerd.cluster.local", "key-1"},
+			{"ns1-b", "sa.ns1.serviceaccount.identity.linkerd.cluster.local", "fresh-key"},
+			{"ns2-a", "sa.ns2.serviceaccount.identity.linkerd.cluster.local", "key-2"},
+			{"ns3-expiring", "sa.ns3.serviceaccount.identity.linkerd.cluster.local", "expiring-key"},
+		}
+		served := make(chan string, len(requests))
+		for i, req := range requests {
+			req := req
+			go func() {
+				done, err := q.wait(context.Background(), req.identity, []byte(req.key))
+				if err != nil {
+					t.Errorf("Unexpected error: %s", err)
+					return
+				}
+				served <- req.name
+				done()
+			}()
+			// make sure requests are queued in order
+			waitForQueued(t, q, i+1)
+		}
+
+		release()
+
+		expected := []string{"ns3-expiring", "ns1-a", "ns2-a", "ns1-b"}
+		for _, name := range expected {
+			select {
+			case got := <-served:
+				if got != name {
+					t.Fatalf("Expected %s to be served, got %s", name, got)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("Timed out waiting for %s to be served", name)
+			}
+		}
+	})
+
+	t.Run("Rejects requests when the queue is full", func(t *testing.T) {
+		q := newIssuanceQueue(1, 0, 24*time.Hour)
+		release, err := q.wait(context.Background(), "sa.ns.serviceaccount.identity.linkerd.cluster.local", []byte("key-0"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		defer release()
+
+		_, err = q.wait(context.Background(), "sa.ns.serviceaccount.identity.linkerd.cluster.local", []byte("key-1"))
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("Expected ResourceExhausted error, got %v", err)
+		}
+	})
+
+	t.Run("Drops requests whose caller went away", func(t *testing.T) {
+		q := newIssuanceQueue(1, 1, 24*time.Hour)
+		release, err := q.wait(context.Background(), "sa.ns.serviceaccount.identity.linkerd.cluster.local", []byte("key-0"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = q.wait(ctx, "sa.ns.serviceaccount.identity.linkerd.cluster.local", []byte("key-1"))
+		if status.Code(err) != codes.Canceled {
+			t.Fatalf("Expected Canceled error, got %v", err)
+		}
+
+		release()
+		done, err := q.wait(context.Background(), "sa.ns.serviceaccount.identity.linkerd.cluster.local", []byte("key-2"))
+		if err != nil {
+			t.Fatalf("Expected the cancelled request to free its place in the queue, got %s", err)
+		}
+		done()
+	})
+}
+
+func waitForQueued(t *testing.T, q *issuanceQueue, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		q.Lock()
+		queued := q.queued
+		q.Unlock()
+		if queued >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("Timed out waiting for %d queued requests", n)
+}
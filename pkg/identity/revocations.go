@@ -0,0 +1,49 @@
+package identity
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// RevokedServiceAccount is the kind of the revocations of service
+	// accounts
+	RevokedServiceAccount = "serviceaccount"
+
+	// RevokedPod is the kind of the revocations of pods, which only apply to
+	// the tokens bound to a pod
+	RevokedPod = "pod"
+)
+
+type (
+	// Revocation is a service account or pod denied certificates until the
+	// revocation expires.
+	Revocation struct {
+		Kind      string
+		Namespace string
+		Name      string
+	}
+
+	// Revoked is an error type returned by Validators to indicate that the
+	// certificates of the workload presenting the token were revoked.
+	Revoked struct {
+		Revocation Revocation
+		Until      time.Time
+	}
+)
+
+// Key returns the key of the revocation in the revocations ConfigMap, as
+// <kind>.<namespace>.<name>. Its value is the time the revocation expires, in
+// RFC 3339 format.
+func (r Revocation) Key() string {
+	return fmt.Sprintf("%s.%s.%s", r.Kind, r.Namespace, r.Name)
+}
+
+func (e Revoked) Error() string {
+	if e.Until.IsZero() {
+		return fmt.Sprintf("the certificates of %s %s/%s are revoked",
+			e.Revocation.Kind, e.Revocation.Namespace, e.Revocation.Name)
+	}
+	return fmt.Sprintf("the certificates of %s %s/%s are revoked until %s",
+		e.Revocation.Kind, e.Revocation.Namespace, e.Revocation.Name, e.Until.Format(time.RFC3339))
+}
@@ -18,9 +18,12 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	pb "github.com/linkerd/linkerd2-proxy-api/go/identity"
 	"github.com/linkerd/linkerd2/pkg/tls"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -36,6 +39,26 @@ const (
 	eventTypeUpdated        = "IssuerUpdated"
 	eventTypeFailed         = "IssuerValidationFailed"
 	eventTypeIssuedLeafCert = "IssuedLeafCertificate"
+	eventTypeDenied         = "IssuanceDenied"
+
+	// IssuerExpiryMetric is the name of the gauge holding the expiry of the
+	// current issuer certificate, in seconds since the epoch
+	IssuerExpiryMetric = "identity_cert_issuer_expiry_timestamp_seconds"
+	// IssuerIssuedMetric is the name of the gauge holding the number of
+	// certificates issued since the current issuer was loaded
+	IssuerIssuedMetric = "identity_cert_issuer_issued_certificates"
+)
+
+var (
+	issuerExpiryGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: IssuerExpiryMetric,
+		Help: "Time at which the current issuer certificate expires, in seconds since the epoch",
+	})
+
+	issuerIssuedGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: IssuerIssuedMetric,
+		Help: "Number of certificates issued since the current issuer was loaded",
+	})
 )
 
 type (
@@ -48,6 +71,11 @@ type (
 		issuerMutex  *sync.RWMutex
 		validity     *tls.Validity
 		recordEvent  func(parent runtime.Object, eventType, reason, message string)
+		queue        *issuanceQueue
+		auditor      *Auditor
+		lifetime     time.Duration
+		policies     []Policy
+		cryptoMode   tls.CryptoMode
 
 		expectedName, issuerPathCrt, issuerPathKey string
 	}
@@ -88,6 +116,10 @@ func (svc *Service) Initialize() error {
 func (svc *Service) updateIssuer(newIssuer tls.Issuer) {
 	svc.issuerMutex.Lock()
 	svc.issuer = &newIssuer
+	if ca, ok := newIssuer.(*tls.CA); ok {
+		issuerExpiryGauge.Set(float64(ca.Cred.Certificate.NotAfter.Unix()))
+	}
+	issuerIssuedGauge.Set(0)
 	log.Debug("Issuer has been updated")
 	svc.issuerMutex.Unlock()
 }
@@ -131,12 +163,26 @@ func (svc *Service) loadCredentials() (tls.Issuer, error) {
 		return nil, fmt.Errorf("failed to verify issuer certificate: it must be an intermediate-CA, but it is not")
 	}
 
+	if err := svc.cryptoMode.CheckPublicKey(creds.Certificate.PublicKey); err != nil {
+		return nil, fmt.Errorf("failed to verify issuer certificate: %s", err)
+	}
+
 	log.Debugf("Loaded issuer cert: %s", creds.EncodeCertificatePEM())
 	return tls.NewCA(*creds, *svc.validity), nil
 }
 
-// NewService creates a new identity service.
-func NewService(validator Validator, trustAnchors *x509.CertPool, validity *tls.Validity, recordEvent func(parent runtime.Object, eventType, reason, message string), expectedName, issuerPathCrt, issuerPathKey string) *Service {
+// NewService creates a new identity service. At most issuanceConcurrency
+// certificate signing requests are processed at a time, with up to
+// issuanceQueueSize requests waiting for their turn; a zero concurrency
+// disables queueing. The issued certificates are audited when auditor isn't
+// nil. The policies are checked in turn before signing each certificate. The
+// keys of the issuer and of the certificate signing requests must be accepted
+// by cryptoMode.
+func NewService(validator Validator, trustAnchors *x509.CertPool, validity *tls.Validity, recordEvent func(parent runtime.Object, eventType, reason, message string), expectedName, issuerPathCrt, issuerPathKey string, issuanceConcurrency, issuanceQueueSize int, auditor *Auditor, policies []Policy, cryptoMode tls.CryptoMode) *Service {
+	lifetime := DefaultIssuanceLifetime
+	if validity != nil && validity.Lifetime != 0 {
+		lifetime = validity.Lifetime
+	}
 	return &Service{
 		pb.UnimplementedIdentityServer{},
 		validator,
@@ -145,6 +191,11 @@ func NewService(validator Validator, trustAnchors *x509.CertPool, validity *tls.
 		&sync.RWMutex{},
 		validity,
 		recordEvent,
+		newIssuanceQueue(issuanceConcurrency, issuanceQueueSize, lifetime),
+		auditor,
+		lifetime,
+		policies,
+		cryptoMode,
 		expectedName,
 		issuerPathCrt,
 		issuerPathKey,
@@ -173,9 +224,10 @@ func (svc *Service) ensureIssuerStillValid() error {
 // Certify validates identity and signs certificates.
 func (svc *Service) Certify(ctx context.Context, req *pb.CertifyRequest) (*pb.CertifyResponse, error) {
 	svc.issuerMutex.RLock()
-	defer svc.issuerMutex.RUnlock()
+	ready := svc.issuer != nil
+	svc.issuerMutex.RUnlock()
 
-	if svc.issuer == nil {
+	if !ready {
 		log.Warn("Certificate issuer is not ready")
 		return nil, status.Error(codes.Unavailable, "cert issuer not ready yet")
 	}
@@ -186,18 +238,6 @@ func (svc *Service) Certify(ctx context.Context, req *pb.CertifyRequest) (*pb.Ce
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	if err := svc.ensureIssuerStillValid(); err != nil {
-		log.Errorf("could not process CSR because of CA cert validation failure: %s - CSR Identity : %s", err, reqIdentity)
-		message := fmt.Sprintf("%s - CSR Identity : %s", err.Error(), reqIdentity)
-		svc.recordEvent(nil, v1.EventTypeWarning, eventTypeFailed, message)
-		return nil, err
-	}
-
-	if err = checkCSR(csr, reqIdentity); err != nil {
-		log.Debugf("requester sent invalid CSR: %s", err)
-		return nil, status.Error(codes.FailedPrecondition, err.Error())
-	}
-
 	// Authenticate the provided token against the Kubernetes API.
 	log.Debugf("Validating token for %s", reqIdentity)
 	tokIdentity, err := svc.validator.Validate(ctx, tok)
@@ -209,6 +249,9 @@ func (svc *Service) Certify(ctx context.Context, req *pb.CertifyRequest) (*pb.Ce
 		case InvalidToken:
 			log.Debugf("invalid token provided for %s: %s", reqIdentity, e)
 			return nil, status.Error(codes.InvalidArgument, e.Error())
+		case Revoked:
+			log.Warnf("refused to certify %s: %s", reqIdentity, e)
+			return nil, status.Error(codes.PermissionDenied, e.Error())
 		default:
 			msg := fmt.Sprintf("error validating token for %s: %s", reqIdentity, e)
 			log.Error(msg)
@@ -224,9 +267,74 @@ func (svc *Service) Certify(ctx context.Context, req *pb.CertifyRequest) (*pb.Ce
 		return nil, status.Error(codes.FailedPrecondition, msg)
 	}
 
+	// Wait for our turn before signing, so that mass restarts don't
+	// overwhelm the service. The token is authenticated first, so that the
+	// requests are queued under the namespace they were authenticated for.
+	// The issuer lock isn't held while waiting so that issuer updates aren't
+	// held back.
+	release, err := svc.queue.wait(ctx, tokIdentity, csr.RawSubjectPublicKeyInfo)
+	if err != nil {
+		log.Debugf("could not queue CSR for %s: %s", reqIdentity, err)
+		return nil, err
+	}
+	defer release()
+
+	svc.issuerMutex.RLock()
+	defer svc.issuerMutex.RUnlock()
+
+	if err := svc.ensureIssuerStillValid(); err != nil {
+		log.Errorf("could not process CSR because of CA cert validation failure: %s - CSR Identity : %s", err, reqIdentity)
+		message := fmt.Sprintf("%s - CSR Identity : %s", err.Error(), reqIdentity)
+		svc.recordEvent(nil, v1.EventTypeWarning, eventTypeFailed, message)
+		return nil, err
+	}
+
+	if err = checkCSR(csr, reqIdentity); err != nil {
+		log.Debugf("requester sent invalid CSR: %s", err)
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err = svc.cryptoMode.CheckPublicKey(csr.PublicKey); err != nil {
+		log.Debugf("requester sent a CSR with a rejected key: %s", err)
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	identitySegments := strings.Split(tokIdentity, ".")
+	sa := v1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      identitySegments[0],
+			Namespace: identitySegments[1],
+		},
+	}
+
+	// Check the request against the issuance policies, which may shorten the
+	// lifetime of the certificate.
+	lifetime, err := checkPolicies(ctx, svc.policies, IssuanceRequest{
+		Identity:       tokIdentity,
+		ServiceAccount: sa.Name,
+		Namespace:      sa.Namespace,
+		Lifetime:       svc.lifetime,
+	})
+	if err != nil {
+		switch e := err.(type) {
+		case PolicyDenied:
+			log.Warnf("refused to certify %s: %s", tokIdentity, e)
+			svc.recordEvent(&sa, v1.EventTypeWarning, eventTypeDenied, fmt.Sprintf("refused to certify %s: %s", tokIdentity, e))
+			return nil, status.Error(codes.PermissionDenied, e.Error())
+		default:
+			msg := fmt.Sprintf("error checking the issuance policies for %s: %s", tokIdentity, e)
+			log.Error(msg)
+			return nil, status.Error(codes.Unavailable, msg)
+		}
+	}
+
 	// Create a certificate
 	issuer := *svc.issuer
-	crt, err := issuer.IssueEndEntityCrt(csr)
+	var crt tls.Crt
+	if li, ok := issuer.(tls.LifetimeIssuer); ok && lifetime < svc.lifetime {
+		crt, err = li.IssueEndEntityCrtWithLifetime(csr, lifetime)
+	} else {
+		crt, err = issuer.IssueEndEntityCrt(csr)
+	}
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -234,6 +342,8 @@ func (svc *Service) Certify(ctx context.Context, req *pb.CertifyRequest) (*pb.Ce
 	if len(crts) == 0 {
 		log.Fatal("the issuer provided a certificate without key material")
 	}
+	svc.queue.issuedFor(csr.RawSubjectPublicKeyInfo, crt.Certificate.NotAfter)
+	issuerIssuedGauge.Inc()
 
 	validUntil, err := ptypes.TimestampProto(crt.Certificate.NotAfter)
 	if err != nil {
@@ -244,17 +354,18 @@ func (svc *Service) Certify(ctx context.Context, req *pb.CertifyRequest) (*pb.Ce
 	hasher := md5.New()
 	hasher.Write(crts[0])
 	hash := hex.EncodeToString(hasher.Sum(nil))
-	identitySegments := strings.Split(tokIdentity, ".")
 	msg := fmt.Sprintf("issued certificate for %s until %s: %s", tokIdentity, crt.Certificate.NotAfter, hash)
-	sa := v1.ServiceAccount{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      identitySegments[0],
-			Namespace: identitySegments[1],
-		},
-	}
 	svc.recordEvent(&sa, v1.EventTypeNormal, eventTypeIssuedLeafCert, msg)
 	log.Info(msg)
 
+	if svc.auditor != nil {
+		clientAddress := ""
+		if p, ok := peer.FromContext(ctx); ok {
+			clientAddress = p.Addr.String()
+		}
+		svc.auditor.record(newAuditRecord(time.Now(), tokIdentity, csr, crt.Certificate.NotAfter, clientAddress))
+	}
+
 	// Bundle issuer crt with certificate so the trust path to the root can be verified.
 	rsp := &pb.CertifyResponse{
 		LeafCertificate:          crts[0],
@@ -2,11 +2,17 @@ package identity
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/x509"
 	"testing"
 
 	pb "github.com/linkerd/linkerd2-proxy-api/go/identity"
 	"github.com/linkerd/linkerd2/pkg/tls"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type fakeValidator struct {
@@ -29,7 +35,7 @@ func (fk *fakeValidator) Validate(context.Context, []byte) (string, error) {
 
 func TestServiceNotReady(t *testing.T) {
 	//ch := make(chan tls.Issuer, 1)
-	svc := NewService(&fakeValidator{"successful-result", nil}, nil, nil, nil, "", "", "")
+	svc := NewService(&fakeValidator{"successful-result", nil}, nil, nil, nil, "", "", "", 0, 0, nil, nil, tls.CryptoModeDefault)
 	req := &pb.CertifyRequest{
 		Identity:                  "some-identity",
 		Token:                     []byte{},
@@ -50,7 +56,7 @@ func TestServiceNotReady(t *testing.T) {
 }
 
 func TestInvalidRequestArguments(t *testing.T) {
-	svc := NewService(&fakeValidator{"successful-result", nil}, nil, nil, nil, "", "", "")
+	svc := NewService(&fakeValidator{"successful-result", nil}, nil, nil, nil, "", "", "", 0, 0, nil, nil, tls.CryptoModeDefault)
 	svc.updateIssuer(&fakeIssuer{tls.Crt{}, nil})
 	fakeData := "fake-data"
 	invalidCsr := func() *pb.CertifyRequest {
@@ -97,3 +103,56 @@ func TestInvalidRequestArguments(t *testing.T) {
 	}
 
 }
+
+func TestIssuerMetrics(t *testing.T) {
+	svc := NewService(&fakeValidator{"successful-result", nil}, nil, nil, nil, "", "", "", 0, 0, nil, nil, tls.CryptoModeDefault)
+	ca, err := tls.GenerateRootCAWithDefaults("identity.linkerd.cluster.local")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	issuerIssuedGauge.Set(3)
+	svc.updateIssuer(ca)
+
+	expiry := float64(ca.Cred.Certificate.NotAfter.Unix())
+	if value := testutil.ToFloat64(issuerExpiryGauge); value != expiry {
+		t.Fatalf("Expected the issuer expiry to be %v, got %v", expiry, value)
+	}
+	if value := testutil.ToFloat64(issuerIssuedGauge); value != 0 {
+		t.Fatalf("Expected the issued certificates to be reset, got %v", value)
+	}
+}
+
+func TestCertifyAuthenticatesBeforeQueueing(t *testing.T) {
+	validator := &fakeValidator{"web.emojivoto.serviceaccount.identity.linkerd.cluster.local", nil}
+	svc := NewService(validator, nil, nil, nil, "", "", "", 1, 0, nil, nil, tls.CryptoModeDefault)
+	svc.updateIssuer(&fakeIssuer{tls.Crt{}, nil})
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	identity := "web.other.serviceaccount.identity.linkerd.cluster.local"
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: []string{identity}}, key)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// the only processing slot is taken and nothing can be queued
+	release, err := svc.queue.wait(context.Background(), validator.result, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer release()
+
+	// a request naming another namespace than its token's is rejected before
+	// getting a turn in the queue
+	_, err = svc.Certify(context.Background(), &pb.CertifyRequest{
+		Identity:                  identity,
+		Token:                     []byte("token"),
+		CertificateSigningRequest: csr,
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("Expected the request to fail authentication, got %v", err)
+	}
+}
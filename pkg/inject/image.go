@@ -0,0 +1,40 @@
+package inject
+
+import (
+	"fmt"
+	"regexp"
+
+	l5dcharts "github.com/linkerd/linkerd2/pkg/charts/linkerd2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	// imageNameRegexp matches an image name, optionally prefixed by a registry
+	// host and port, without its tag or digest
+	imageNameRegexp = regexp.MustCompile(`^([a-zA-Z0-9]+([.-][a-zA-Z0-9]+)*(:[0-9]+)?/)?[a-z0-9]+([._-]+[a-z0-9]+)*(/[a-z0-9]+([._-]+[a-z0-9]+)*)*$`)
+
+	// ImageDigestRegexp matches an image digest, which pins an image in place
+	// of its tag
+	ImageDigestRegexp = regexp.MustCompile(`^(sha256:[a-f0-9]{64}|sha512:[a-f0-9]{128})$`)
+)
+
+// validateImage checks the name, digest and pull policy of an injected
+// container image, after the registry, digest and pull policy overrides have
+// been applied
+func validateImage(container string, image *l5dcharts.Image) error {
+	if !imageNameRegexp.MatchString(image.Name) {
+		return fmt.Errorf("invalid %s image name %q, check its registry override", container, image.Name)
+	}
+
+	if image.Digest != "" && !ImageDigestRegexp.MatchString(image.Digest) {
+		return fmt.Errorf("invalid %s image digest %q, must be sha256:<64 hex digits> or sha512:<128 hex digits>", container, image.Digest)
+	}
+
+	switch corev1.PullPolicy(image.PullPolicy) {
+	case "", corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever:
+	default:
+		return fmt.Errorf("invalid %s image pull policy %q, must be one of: %s, %s, %s", container, image.PullPolicy, corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever)
+	}
+
+	return nil
+}
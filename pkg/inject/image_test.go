@@ -0,0 +1,63 @@
+package inject
+
+import (
+	"strings"
+	"testing"
+
+	l5dcharts "github.com/linkerd/linkerd2/pkg/charts/linkerd2"
+)
+
+func TestValidateImage(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("0123456789abcdef", 4)
+
+	testCases := []struct {
+		name  string
+		image l5dcharts.Image
+		err   string
+	}{
+		{
+			name:  "default image",
+			image: l5dcharts.Image{Name: "cr.l5d.io/linkerd/proxy"},
+		},
+		{
+			name:  "mirror with port and digest",
+			image: l5dcharts.Image{Name: "mirror.example.com:5000/linkerd/proxy", Digest: digest, PullPolicy: "IfNotPresent"},
+		},
+		{
+			name:  "invalid registry",
+			image: l5dcharts.Image{Name: "mirror example.com/proxy"},
+			err:   `invalid proxy image name "mirror example.com/proxy", check its registry override`,
+		},
+		{
+			name:  "tag in the name",
+			image: l5dcharts.Image{Name: "cr.l5d.io/linkerd/proxy:stable"},
+			err:   `invalid proxy image name "cr.l5d.io/linkerd/proxy:stable", check its registry override`,
+		},
+		{
+			name:  "truncated digest",
+			image: l5dcharts.Image{Name: "cr.l5d.io/linkerd/proxy", Digest: digest[:20]},
+			err:   `invalid proxy image digest "sha256:0123456789abc", must be sha256:<64 hex digits> or sha512:<128 hex digits>`,
+		},
+		{
+			name:  "invalid pull policy",
+			image: l5dcharts.Image{Name: "cr.l5d.io/linkerd/proxy", PullPolicy: "Sometimes"},
+			err:   `invalid proxy image pull policy "Sometimes", must be one of: Always, IfNotPresent, Never`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateImage("proxy", &tc.image)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("Expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"html/template"
+	"math"
 	"net"
 	"reflect"
 	"regexp"
@@ -19,6 +20,7 @@ import (
 	"github.com/linkerd/linkerd2/pkg/charts/linkerd2"
 	l5dcharts "github.com/linkerd/linkerd2/pkg/charts/linkerd2"
 	"github.com/linkerd/linkerd2/pkg/charts/static"
+	"github.com/linkerd/linkerd2/pkg/cmd"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/util"
 	log "github.com/sirupsen/logrus"
@@ -30,7 +32,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8sResource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/yaml"
 )
 
@@ -44,14 +49,18 @@ var (
 		k8s.ProxyDisableIdentityAnnotation,
 		k8s.ProxyEnableDebugAnnotation,
 		k8s.ProxyEnableExternalProfilesAnnotation,
+		k8s.ProxyImageDigestAnnotation,
 		k8s.ProxyImagePullPolicyAnnotation,
+		k8s.ProxyImageRegistryAnnotation,
 		k8s.ProxyInboundPortAnnotation,
 		k8s.ProxyInitImageAnnotation,
+		k8s.ProxyInitImageDigestAnnotation,
 		k8s.ProxyInitImageVersionAnnotation,
 		k8s.ProxyOutboundPortAnnotation,
 		k8s.ProxyPodInboundPortsAnnotation,
 		k8s.ProxyCPULimitAnnotation,
 		k8s.ProxyCPURequestAnnotation,
+		k8s.ProxyCPURatioAnnotation,
 		k8s.ProxyImageAnnotation,
 		k8s.ProxyLogFormatAnnotation,
 		k8s.ProxyLogLevelAnnotation,
@@ -69,11 +78,57 @@ var (
 		k8s.ProxyInboundConnectTimeout,
 		k8s.ProxyAwait,
 		k8s.ProxyDefaultInboundPolicyAnnotation,
+		k8s.ProxyEnvAnnotation,
+		k8s.ProxySkipProbePortsAnnotation,
 	}
 	// ProxyAlphaConfigAnnotations is the list of all alpha configuration
 	// (config.alpha prefix) that can be applied to a pod or namespace.
 	ProxyAlphaConfigAnnotations = []string{
 		k8s.ProxyWaitBeforeExitSecondsAnnotation,
+		k8s.ProxyWrapCommandAnnotation,
+	}
+
+	// reservedProxyEnvPrefixes are the prefixes of the proxy environment
+	// variables managed by the injector, which can't be set through the
+	// proxy-env annotation
+	reservedProxyEnvPrefixes = []string{
+		"_pod_",
+		"LINKERD2_PROXY_IDENTITY_",
+	}
+
+	// reservedProxyEnv are the proxy environment variables managed by the
+	// injector, which can't be set through the proxy-env annotation. Most of
+	// them can be configured through a dedicated annotation instead.
+	reservedProxyEnv = map[string]struct{}{
+		"LINKERD2_PROXY_CORES":                                    {},
+		"LINKERD2_PROXY_LOG":                                      {},
+		"LINKERD2_PROXY_LOG_FORMAT":                               {},
+		"LINKERD2_PROXY_DESTINATION_SVC_ADDR":                     {},
+		"LINKERD2_PROXY_DESTINATION_SVC_NAME":                     {},
+		"LINKERD2_PROXY_DESTINATION_PROFILE_NETWORKS":             {},
+		"LINKERD2_PROXY_DESTINATION_PROFILE_SUFFIXES":             {},
+		"LINKERD2_PROXY_DESTINATION_CONTEXT":                      {},
+		"LINKERD2_PROXY_POLICY_SVC_ADDR":                          {},
+		"LINKERD2_PROXY_POLICY_SVC_NAME":                          {},
+		"LINKERD2_PROXY_POLICY_WORKLOAD":                          {},
+		"LINKERD2_PROXY_POLICY_CLUSTER_NETWORKS":                  {},
+		"LINKERD2_PROXY_INBOUND_DEFAULT_POLICY":                   {},
+		"LINKERD2_PROXY_INBOUND_PORTS":                            {},
+		"LINKERD2_PROXY_INBOUND_IPS":                              {},
+		"LINKERD2_PROXY_INBOUND_PORTS_REQUIRE_IDENTITY":           {},
+		"LINKERD2_PROXY_INBOUND_PORTS_REQUIRE_TLS":                {},
+		"LINKERD2_PROXY_INBOUND_PORTS_DISABLE_PROTOCOL_DETECTION": {},
+		"LINKERD2_PROXY_INBOUND_CONNECT_TIMEOUT":                  {},
+		"LINKERD2_PROXY_OUTBOUND_CONNECT_TIMEOUT":                 {},
+		"LINKERD2_PROXY_INBOUND_ACCEPT_KEEPALIVE":                 {},
+		"LINKERD2_PROXY_OUTBOUND_CONNECT_KEEPALIVE":               {},
+		"LINKERD2_PROXY_INBOUND_GATEWAY_SUFFIXES":                 {},
+		"LINKERD2_PROXY_INGRESS_MODE":                             {},
+		"LINKERD2_PROXY_CONTROL_LISTEN_ADDR":                      {},
+		"LINKERD2_PROXY_ADMIN_LISTEN_ADDR":                        {},
+		"LINKERD2_PROXY_OUTBOUND_LISTEN_ADDR":                     {},
+		"LINKERD2_PROXY_INBOUND_LISTEN_ADDR":                      {},
+		"LINKERD2_AWAIT_INSTALL_PATH":                             {},
 	}
 )
 
@@ -122,6 +177,9 @@ type ResourceConfig struct {
 		// non-workload resources can be unmarshalled by the YAML parser
 		Meta     *metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
 		ownerRef *metav1.OwnerReference
+		// podTemplateFields is the path of the pod template of the additional
+		// workloads, which are held as unstructured objects
+		podTemplateFields []string
 	}
 
 	pod struct {
@@ -147,6 +205,7 @@ type podPatch struct {
 	AddRootVolumes        bool                      `json:"addRootVolumes"`
 	Labels                map[string]string         `json:"labels"`
 	DebugContainer        *l5dcharts.DebugContainer `json:"debugContainer"`
+	WrappedContainers     []wrappedContainer        `json:"wrappedContainers"`
 }
 
 type annotationPatch struct {
@@ -233,6 +292,9 @@ func (conf *ResourceConfig) AppendPodAnnotation(k, v string) {
 
 // YamlMarshalObj returns the yaml for the workload in conf
 func (conf *ResourceConfig) YamlMarshalObj() ([]byte, error) {
+	if err := conf.syncAdditionalWorkload(); err != nil {
+		return nil, err
+	}
 	j, err := getFilteredJSON(conf.workload.obj)
 	if err != nil {
 		return nil, err
@@ -240,6 +302,39 @@ func (conf *ResourceConfig) YamlMarshalObj() ([]byte, error) {
 	return yaml.JSONToYAML(j)
 }
 
+// syncAdditionalWorkload writes the metadata and pod template of an
+// additional workload back into its unstructured object, as they are parsed
+// into copies
+func (conf *ResourceConfig) syncAdditionalWorkload() error {
+	u, ok := conf.workload.obj.(*unstructured.Unstructured)
+	if !ok || len(conf.workload.podTemplateFields) == 0 {
+		return nil
+	}
+	if meta := conf.workload.Meta; len(meta.Labels) > 0 {
+		u.SetLabels(meta.Labels)
+	} else {
+		u.SetLabels(nil)
+	}
+	if meta := conf.workload.Meta; len(meta.Annotations) > 0 {
+		u.SetAnnotations(meta.Annotations)
+	} else {
+		u.SetAnnotations(nil)
+	}
+
+	j, err := jsonfilter.Marshal(&corev1.PodTemplateSpec{
+		ObjectMeta: *conf.pod.meta,
+		Spec:       *conf.pod.spec,
+	})
+	if err != nil {
+		return err
+	}
+	template := map[string]interface{}{}
+	if err := json.Unmarshal(j, &template); err != nil {
+		return err
+	}
+	return unstructured.SetNestedMap(u.Object, template, conf.workload.podTemplateFields...)
+}
+
 // ParseMetaAndYAML extracts the workload metadata and pod specs from the given
 // input bytes. The results are stored in the conf's fields.
 func (conf *ResourceConfig) ParseMetaAndYAML(bytes []byte) (*Report, error) {
@@ -265,10 +360,27 @@ func (conf *ResourceConfig) GetOverriddenValues() (*linkerd2.Values, error) {
 	}
 
 	copyValues.Proxy.PodInboundPorts = getPodInboundPorts(conf.pod.spec)
+	copyValues.Proxy.Workload = conf.getWorkload()
 	conf.applyAnnotationOverrides(copyValues)
 	return copyValues, nil
 }
 
+// getWorkload returns the workload the proxy is injected into, which is
+// either the owner of the pod or the workload holding the pod template. It
+// returns nil when the workload isn't known, e.g. for bare pods.
+func (conf *ResourceConfig) getWorkload() *l5dcharts.Workload {
+	if ref := conf.workload.ownerRef; ref != nil && ref.Name != "" {
+		return &l5dcharts.Workload{Kind: ref.Kind, Name: ref.Name}
+	}
+	if conf.IsPod() || conf.workload.Meta == nil || conf.workload.Meta.Name == "" {
+		return nil
+	}
+	return &l5dcharts.Workload{
+		Kind: strings.ToLower(conf.workload.metaType.Kind),
+		Name: conf.workload.Meta.Name,
+	}
+}
+
 // GetPodPatch returns the JSON patch containing the proxy and init containers specs, if any.
 // If injectProxy is false, only the config.linkerd.io annotations are set.
 func (conf *ResourceConfig) GetPodPatch(injectProxy bool) ([]byte, error) {
@@ -290,6 +402,13 @@ func (conf *ResourceConfig) GetPodPatch(injectProxy bool) ([]byte, error) {
 		}
 	}
 
+	if err := validateImage("proxy", values.Proxy.Image); err != nil {
+		return nil, err
+	}
+	if err := validateImage("proxy-init", values.ProxyInit.Image); err != nil {
+		return nil, err
+	}
+
 	patch := &podPatch{
 		Values:      *values,
 		Annotations: map[string]string{},
@@ -302,6 +421,9 @@ func (conf *ResourceConfig) GetPodPatch(injectProxy bool) ([]byte, error) {
 	default:
 		patch.PathPrefix = "/spec/template"
 	}
+	if fields := conf.workload.podTemplateFields; len(fields) > 0 {
+		patch.PathPrefix = "/" + strings.Join(fields, "/")
+	}
 
 	if conf.pod.spec != nil {
 		conf.injectPodAnnotations(patch)
@@ -437,6 +559,36 @@ func (conf *ResourceConfig) FilterPodOpaquePorts(defaultPorts []string) []string
 	return filteredPorts
 }
 
+// InferOpaquePorts returns the ports of the given images map for the images
+// of the pod's containers. The images are looked up by the last component of
+// their repository, so that e.g. `docker.io/bitnami/redis:6.2` matches
+// `redis`.
+func (conf *ResourceConfig) InferOpaquePorts(images map[string]string) []string {
+	var inferredPorts []string
+	for _, c := range conf.pod.spec.Containers {
+		ports, ok := images[imageName(c.Image)]
+		if !ok {
+			continue
+		}
+		for _, port := range util.ParseContainerOpaquePorts(ports, nil) {
+			if !util.ContainsString(port, inferredPorts) {
+				inferredPorts = append(inferredPorts, port)
+			}
+		}
+	}
+	return inferredPorts
+}
+
+// imageName returns the last component of the repository of the image,
+// without its tag nor digest
+func imageName(image string) string {
+	repository := strings.SplitN(image, "@", 2)[0]
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository = repository[:i]
+	}
+	return repository[strings.LastIndex(repository, "/")+1:]
+}
+
 // HasWorkloadAnnotation returns true if the workload has the annotation set
 // by the resource config or its metadata.
 func (conf *ResourceConfig) HasWorkloadAnnotation(annotation string) bool {
@@ -450,6 +602,17 @@ func (conf *ResourceConfig) HasWorkloadAnnotation(annotation string) bool {
 	return ok
 }
 
+// GetWorkloadAnnotation returns the value of the annotation set on the pod or
+// the workload itself, as opposed to the ones inherited from the namespace.
+// The second value is false when neither of them sets it.
+func (conf *ResourceConfig) GetWorkloadAnnotation(annotation string) (string, bool) {
+	if value, ok := conf.pod.meta.Annotations[annotation]; ok {
+		return value, true
+	}
+	value, ok := conf.workload.Meta.Annotations[annotation]
+	return value, ok
+}
+
 // CreateAnnotationPatch returns a json patch which adds the opaque ports
 // annotation with the `opaquePorts` value.
 func (conf *ResourceConfig) CreateAnnotationPatch(opaquePorts string) ([]byte, error) {
@@ -500,6 +663,28 @@ func (conf *ResourceConfig) getFreshWorkloadObj() runtime.Object {
 		return &corev1.Service{}
 	}
 
+	if conf.getAdditionalWorkload() != nil {
+		return &unstructured.Unstructured{}
+	}
+
+	return nil
+}
+
+// getAdditionalWorkload returns the additional workload of the values
+// matching the apiVersion and kind of the resource, if any
+func (conf *ResourceConfig) getAdditionalWorkload() *l5dcharts.AdditionalWorkload {
+	if conf.values == nil || conf.values.ProxyInjector == nil {
+		return nil
+	}
+	gv, err := schema.ParseGroupVersion(conf.workload.metaType.APIVersion)
+	if err != nil {
+		return nil
+	}
+	for i, w := range conf.values.ProxyInjector.AdditionalWorkloads {
+		if w.Group == gv.Group && w.Version == gv.Version && strings.EqualFold(w.Kind, conf.workload.metaType.Kind) {
+			return &conf.values.ProxyInjector.AdditionalWorkloads[i]
+		}
+	}
 	return nil
 }
 
@@ -681,6 +866,32 @@ func (conf *ResourceConfig) parse(bytes []byte) error {
 			conf.workload.Meta.Annotations = map[string]string{}
 		}
 
+	case *unstructured.Unstructured:
+		if err := yaml.Unmarshal(bytes, v); err != nil {
+			return err
+		}
+		if err := yaml.Unmarshal(bytes, &conf.workload); err != nil {
+			return err
+		}
+
+		conf.workload.obj = v
+		fields := strings.Split(strings.Trim(conf.getAdditionalWorkload().PodTemplatePath, "."), ".")
+		obj, found, err := unstructured.NestedMap(v.Object, fields...)
+		if err != nil {
+			return err
+		}
+		if !found {
+			log.Warnf("%s %s has no pod template at %s", conf.workload.metaType.Kind, conf.workload.Meta.Name, strings.Join(fields, "."))
+			return nil
+		}
+		template := &corev1.PodTemplateSpec{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, template); err != nil {
+			return err
+		}
+		conf.workload.podTemplateFields = fields
+		conf.pod.labels[k8s.WorkloadNamespaceLabel] = v.GetNamespace()
+		conf.complete(template)
+
 	default:
 		// unmarshal the metadata of other resource kinds like namespace, secret,
 		// config map etc. to be used in the report struct
@@ -749,6 +960,9 @@ func (conf *ResourceConfig) injectPodSpec(values *podPatch) {
 	}
 
 	conf.injectProxyInit(values)
+	if values.Proxy.WrapCommand {
+		conf.wrapCommands(values)
+	}
 	values.AddRootVolumes = len(conf.pod.spec.Volumes) == 0
 }
 
@@ -864,6 +1078,10 @@ func (conf *ResourceConfig) applyAnnotationOverrides(values *l5dcharts.Values) {
 		values.Proxy.Image.Version = override
 	}
 
+	if override, ok := annotations[k8s.ProxyImageDigestAnnotation]; ok {
+		values.Proxy.Image.Digest = override
+	}
+
 	if override, ok := annotations[k8s.ProxyImagePullPolicyAnnotation]; ok {
 		values.Proxy.Image.PullPolicy = override
 	}
@@ -872,6 +1090,10 @@ func (conf *ResourceConfig) applyAnnotationOverrides(values *l5dcharts.Values) {
 		values.ProxyInit.Image.Version = override
 	}
 
+	if override, ok := annotations[k8s.ProxyInitImageDigestAnnotation]; ok {
+		values.ProxyInit.Image.Digest = override
+	}
+
 	if override, ok := annotations[k8s.ProxyControlPortAnnotation]; ok {
 		controlPort, err := strconv.ParseInt(override, 10, 32)
 		if err == nil {
@@ -1000,6 +1222,16 @@ func (conf *ResourceConfig) applyAnnotationOverrides(values *l5dcharts.Values) {
 		}
 	}
 
+	if override, ok := annotations[k8s.ProxyCPURatioAnnotation]; ok {
+		ratio, err := strconv.ParseFloat(override, 64)
+		if err != nil || ratio <= 0 {
+			log.Warnf("unrecognized value used for the %s annotation, a positive number is expected: %s",
+				k8s.ProxyCPURatioAnnotation, override)
+		} else {
+			conf.applyProxyCPURatio(values, annotations, ratio)
+		}
+	}
+
 	if override, ok := annotations[k8s.ProxyMemoryLimitAnnotation]; ok {
 		_, err := k8sResource.ParseQuantity(override)
 		if err != nil {
@@ -1065,6 +1297,12 @@ func (conf *ResourceConfig) applyAnnotationOverrides(values *l5dcharts.Values) {
 		values.DebugContainer.Image.PullPolicy = override
 	}
 
+	if override, ok := annotations[k8s.ProxyImageRegistryAnnotation]; ok {
+		values.Proxy.Image.Name = cmd.RegistryOverride(values.Proxy.Image.Name, override)
+		values.ProxyInit.Image.Name = cmd.RegistryOverride(values.ProxyInit.Image.Name, override)
+		values.DebugContainer.Image.Name = cmd.RegistryOverride(values.DebugContainer.Image.Name, override)
+	}
+
 	if override, ok := annotations[k8s.ProxyAwait]; ok {
 		if override == k8s.Enabled || override == k8s.Disabled {
 			values.Proxy.Await = override == k8s.Enabled
@@ -1073,6 +1311,14 @@ func (conf *ResourceConfig) applyAnnotationOverrides(values *l5dcharts.Values) {
 		}
 	}
 
+	if override, ok := annotations[k8s.ProxyWrapCommandAnnotation]; ok {
+		if override == k8s.Enabled || override == k8s.Disabled {
+			values.Proxy.WrapCommand = override == k8s.Enabled
+		} else {
+			log.Warnf("unrecognized value used for the %s annotation, valid values are: [%s, %s]", k8s.ProxyWrapCommandAnnotation, k8s.Enabled, k8s.Disabled)
+		}
+	}
+
 	if override, ok := annotations[k8s.ProxyDefaultInboundPolicyAnnotation]; ok {
 		if override != k8s.AllUnauthenticated && override != k8s.AllAuthenticated && override != k8s.ClusterUnauthenticated && override != k8s.ClusterAuthenticated && override != k8s.Deny {
 			log.Warnf("unrecognized value used for the %s annotation, valid values are: [%s, %s, %s, %s, %s]", k8s.ProxyDefaultInboundPolicyAnnotation, k8s.AllUnauthenticated, k8s.AllAuthenticated, k8s.ClusterUnauthenticated, k8s.ClusterAuthenticated, k8s.Deny)
@@ -1080,6 +1326,132 @@ func (conf *ResourceConfig) applyAnnotationOverrides(values *l5dcharts.Values) {
 			values.Proxy.DefaultInboundPolicy = override
 		}
 	}
+
+	if override, ok := annotations[k8s.ProxyEnvAnnotation]; ok {
+		env, err := parseProxyEnv(override)
+		if err != nil {
+			log.Warnf("%s (%s)", err, k8s.ProxyEnvAnnotation)
+		}
+		values.Proxy.AdditionalEnv = env
+	}
+
+	if override, ok := annotations[k8s.ProxySkipProbePortsAnnotation]; ok {
+		skip, err := strconv.ParseBool(override)
+		if err != nil {
+			log.Warnf("unrecognized value used for the %s annotation, valid values are: [true, false]", k8s.ProxySkipProbePortsAnnotation)
+		} else if skip && conf.pod.spec != nil {
+			values.ProxyInit.IgnoreInboundPorts = skipProbePorts(values.ProxyInit.IgnoreInboundPorts, values.Proxy.RequireIdentityOnInboundPorts, conf.pod.spec.Containers)
+		}
+	}
+}
+
+// parseProxyEnv parses the value of the proxy-env annotation into environment
+// variables sorted by name. Invalid or reserved variables are skipped, and
+// reported in the returned error.
+func parseProxyEnv(override string) ([]corev1.EnvVar, error) {
+	vars := make(map[string]string)
+	if err := json.Unmarshal([]byte(override), &vars); err != nil {
+		return nil, fmt.Errorf("failed to parse the proxy environment variables: %w", err)
+	}
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var env []corev1.EnvVar
+	var invalid []string
+	for _, name := range names {
+		if errs := validation.IsEnvVarName(name); len(errs) > 0 {
+			invalid = append(invalid, fmt.Sprintf("%s: %s", name, strings.Join(errs, ", ")))
+			continue
+		}
+		if isReservedProxyEnv(name) {
+			invalid = append(invalid, fmt.Sprintf("%s: managed by the injector", name))
+			continue
+		}
+		env = append(env, corev1.EnvVar{Name: name, Value: vars[name]})
+	}
+
+	if len(invalid) > 0 {
+		return env, fmt.Errorf("ignored proxy environment variables: [%s]", strings.Join(invalid, "; "))
+	}
+	return env, nil
+}
+
+func isReservedProxyEnv(name string) bool {
+	if _, ok := reservedProxyEnv[name]; ok {
+		return true
+	}
+	for _, prefix := range reservedProxyEnvPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyProxyCPURatio sizes the proxy's CPU request and limit proportionally to
+// the application containers' ones, unless they're explicitly set through
+// annotations. The proxy's number of threads follows its CPU limit; when
+// there's none, the proxy runs one thread per core of the node it's
+// scheduled on.
+func (conf *ResourceConfig) applyProxyCPURatio(values *l5dcharts.Values, annotations map[string]string, ratio float64) {
+	request, limit := appCPUResources(conf.pod.spec)
+
+	if _, ok := annotations[k8s.ProxyCPURequestAnnotation]; !ok && !request.IsZero() {
+		q := scaleCPU(request, ratio)
+		values.Proxy.Resources.CPU.Request = q.String()
+	}
+
+	if _, ok := annotations[k8s.ProxyCPULimitAnnotation]; !ok && !limit.IsZero() {
+		q := scaleCPU(limit, ratio)
+		values.Proxy.Resources.CPU.Limit = q.String()
+
+		n, err := ToWholeCPUCores(q)
+		if err != nil {
+			log.Warnf("%s (%s)", err, k8s.ProxyCPURatioAnnotation)
+		}
+		values.Proxy.Cores = n
+	}
+
+	if values.Proxy.Cores == 0 {
+		values.Proxy.CoresFromNode = true
+	}
+}
+
+// appCPUResources returns the sum of the CPU requests and limits of the
+// application containers. The limit is zero if any of them is unbounded.
+func appCPUResources(podSpec *corev1.PodSpec) (k8sResource.Quantity, k8sResource.Quantity) {
+	var request, limit k8sResource.Quantity
+	if podSpec == nil {
+		return request, limit
+	}
+
+	unbounded := false
+	for _, container := range podSpec.Containers {
+		if container.Name == k8s.ProxyContainerName {
+			continue
+		}
+		if q, ok := container.Resources.Requests[corev1.ResourceCPU]; ok {
+			request.Add(q)
+		}
+		if q, ok := container.Resources.Limits[corev1.ResourceCPU]; ok {
+			limit.Add(q)
+		} else {
+			unbounded = true
+		}
+	}
+	if unbounded {
+		return request, k8sResource.Quantity{}
+	}
+	return request, limit
+}
+
+// scaleCPU multiplies a CPU quantity by ratio, rounding up to the millicore
+func scaleCPU(q k8sResource.Quantity, ratio float64) k8sResource.Quantity {
+	return *k8sResource.NewMilliQuantity(int64(math.Ceil(float64(q.MilliValue())*ratio)), k8sResource.DecimalSI)
 }
 
 // GetOverriddenConfiguration returns a map of the overridden proxy annotations
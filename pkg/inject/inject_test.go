@@ -1,7 +1,9 @@
 package inject
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	l5dcharts "github.com/linkerd/linkerd2/pkg/charts/linkerd2"
@@ -11,6 +13,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8sResource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/yaml"
 )
 
@@ -117,6 +120,30 @@ func TestGetOverriddenValues(t *testing.T) {
 				return values
 			},
 		},
+		{id: "use image registry and digest overrides",
+			nsAnnotations: make(map[string]string),
+			spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							k8s.ProxyImageRegistryAnnotation:   "mirror.example.com:5000/linkerd",
+							k8s.ProxyImageDigestAnnotation:     "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+							k8s.ProxyInitImageDigestAnnotation: "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+						},
+					},
+					Spec: corev1.PodSpec{},
+				},
+			},
+			expected: func() *l5dcharts.Values {
+				values, _ := l5dcharts.NewValues()
+				values.Proxy.Image.Name = "mirror.example.com:5000/linkerd/proxy"
+				values.Proxy.Image.Digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+				values.ProxyInit.Image.Name = "mirror.example.com:5000/linkerd/proxy-init"
+				values.ProxyInit.Image.Digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+				values.DebugContainer.Image.Name = "mirror.example.com:5000/linkerd/debug"
+				return values
+			},
+		},
 		{id: "use defaults",
 			nsAnnotations: make(map[string]string),
 			spec: appsv1.DeploymentSpec{
@@ -267,6 +294,172 @@ func TestGetOverriddenValues(t *testing.T) {
 				return values
 			},
 		},
+		{id: "use CPU ratio of the application containers",
+			nsAnnotations: map[string]string{
+				k8s.ProxyCPURatioAnnotation: "0.1",
+			},
+			spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "app",
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{corev1.ResourceCPU: k8sResource.MustParse("500m")},
+									Limits:   corev1.ResourceList{corev1.ResourceCPU: k8sResource.MustParse("10")},
+								},
+							},
+							{
+								Name: "sidecar",
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{corev1.ResourceCPU: k8sResource.MustParse("250m")},
+									Limits:   corev1.ResourceList{corev1.ResourceCPU: k8sResource.MustParse("5")},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: func() *l5dcharts.Values {
+				values, _ := l5dcharts.NewValues()
+				values.Proxy.Resources.CPU.Request = "75m"
+				values.Proxy.Resources.CPU.Limit = "1500m"
+				values.Proxy.Cores = 2
+				return values
+			},
+		},
+		{id: "use CPU ratio of unbounded application containers",
+			nsAnnotations: make(map[string]string),
+			spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							k8s.ProxyCPURatioAnnotation:   "0.5",
+							k8s.ProxyCPURequestAnnotation: "20m",
+						},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "app",
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{corev1.ResourceCPU: k8sResource.MustParse("500m")},
+									Limits:   corev1.ResourceList{corev1.ResourceCPU: k8sResource.MustParse("1")},
+								},
+							},
+							{
+								Name: "sidecar",
+							},
+						},
+					},
+				},
+			},
+			expected: func() *l5dcharts.Values {
+				values, _ := l5dcharts.NewValues()
+				values.Proxy.Resources.CPU.Request = "20m"
+				values.Proxy.CoresFromNode = true
+				return values
+			},
+		},
+		{id: "use invalid CPU ratio",
+			nsAnnotations: map[string]string{
+				k8s.ProxyCPURatioAnnotation: "-1",
+			},
+			spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "app",
+								Resources: corev1.ResourceRequirements{
+									Limits: corev1.ResourceList{corev1.ResourceCPU: k8sResource.MustParse("1")},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: func() *l5dcharts.Values {
+				values, _ := l5dcharts.NewValues()
+				return values
+			},
+		},
+		{id: "use proxy environment variables of the namespace",
+			nsAnnotations: map[string]string{
+				k8s.ProxyEnvAnnotation: `{"LINKERD2_PROXY_OUTBOUND_MAX_IN_FLIGHT": "1000", "LINKERD2_PROXY_INBOUND_MAX_IN_FLIGHT": "500"}`,
+			},
+			spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{},
+					Spec:       corev1.PodSpec{},
+				},
+			},
+			expected: func() *l5dcharts.Values {
+				values, _ := l5dcharts.NewValues()
+				values.Proxy.AdditionalEnv = []corev1.EnvVar{
+					{Name: "LINKERD2_PROXY_INBOUND_MAX_IN_FLIGHT", Value: "500"},
+					{Name: "LINKERD2_PROXY_OUTBOUND_MAX_IN_FLIGHT", Value: "1000"},
+				}
+				return values
+			},
+		},
+		{id: "skip reserved proxy environment variables",
+			nsAnnotations: map[string]string{
+				k8s.ProxyEnvAnnotation: `{"LINKERD2_PROXY_OUTBOUND_MAX_IN_FLIGHT": "1000"}`,
+			},
+			spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							k8s.ProxyEnvAnnotation: `{"LINKERD2_PROXY_LOG": "debug", "LINKERD2_PROXY_IDENTITY_DIR": "/tmp", "RUST_BACKTRACE": "1"}`,
+						},
+					},
+					Spec: corev1.PodSpec{},
+				},
+			},
+			expected: func() *l5dcharts.Values {
+				values, _ := l5dcharts.NewValues()
+				values.Proxy.AdditionalEnv = []corev1.EnvVar{
+					{Name: "RUST_BACKTRACE", Value: "1"},
+				}
+				return values
+			},
+		},
+		{id: "skip the probe ports of the namespace",
+			nsAnnotations: map[string]string{
+				k8s.ProxySkipProbePortsAnnotation: "true",
+			},
+			spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							k8s.ProxyIgnoreInboundPortsAnnotation: "4222",
+						},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "app",
+								Ports: []corev1.ContainerPort{{Name: "admin", ContainerPort: 9090}},
+								ReadinessProbe: &corev1.Probe{
+									ProbeHandler: corev1.ProbeHandler{
+										HTTPGet: &corev1.HTTPGetAction{Port: intstr.FromString("admin"), Scheme: corev1.URISchemeHTTPS},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: func() *l5dcharts.Values {
+				values, _ := l5dcharts.NewValues()
+				values.ProxyInit.IgnoreInboundPorts = "4222,9090"
+				values.Proxy.PodInboundPorts = "9090"
+				return values
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -297,6 +490,50 @@ func TestGetOverriddenValues(t *testing.T) {
 	}
 }
 
+func TestParseProxyEnv(t *testing.T) {
+	for _, c := range []struct {
+		annotation string
+		env        []corev1.EnvVar
+		err        string
+	}{
+		{
+			annotation: `{}`,
+			env:        nil,
+		},
+		{
+			annotation: `{"B": "2", "A": "1"}`,
+			env:        []corev1.EnvVar{{Name: "A", Value: "1"}, {Name: "B", Value: "2"}},
+		},
+		{
+			annotation: `{"1A": "1", "_pod_ns": "default", "A": "1"}`,
+			env:        []corev1.EnvVar{{Name: "A", Value: "1"}},
+			err:        "_pod_ns: managed by the injector]",
+		},
+		{
+			annotation: `{"LINKERD2_AWAIT_INSTALL_PATH": "/tmp", "LINKERD2_PROXY_INBOUND_ACCEPT_KEEPALIVE": "1s"}`,
+			err:        "LINKERD2_AWAIT_INSTALL_PATH: managed by the injector; LINKERD2_PROXY_INBOUND_ACCEPT_KEEPALIVE: managed by the injector]",
+		},
+		{
+			annotation: `A=1`,
+			err:        "failed to parse the proxy environment variables: invalid character 'A' looking for beginning of value",
+		},
+	} {
+		c := c
+		t.Run(c.annotation, func(t *testing.T) {
+			env, err := parseProxyEnv(c.annotation)
+			if c.err == "" && err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if c.err != "" && (err == nil || !strings.HasSuffix(err.Error(), c.err)) {
+				t.Fatalf("Expected error [%s] instead got [%v]", c.err, err)
+			}
+			if !reflect.DeepEqual(env, c.env) {
+				t.Fatalf("Expected env %v, got %v", c.env, env)
+			}
+		})
+	}
+}
+
 func TestWholeCPUCores(t *testing.T) {
 	for _, c := range []struct {
 		v string
@@ -320,3 +557,64 @@ func TestWholeCPUCores(t *testing.T) {
 		}
 	}
 }
+
+func TestInferOpaquePorts(t *testing.T) {
+	images := map[string]string{
+		"postgres": "5432",
+		"redis":    "6379",
+		"smtp":     "25,587",
+	}
+
+	for _, tc := range []struct {
+		images   []string
+		expected []string
+	}{
+		{
+			images:   []string{"postgres"},
+			expected: []string{"5432"},
+		},
+		{
+			images:   []string{"docker.io/bitnami/redis:6.2", "localhost:5000/smtp@sha256:abcd"},
+			expected: []string{"6379", "25", "587"},
+		},
+		{
+			images:   []string{"postgres:13", "docker.io/library/postgres:14"},
+			expected: []string{"5432"},
+		},
+		{
+			images:   []string{"nginx", "postgres-exporter:1.0"},
+			expected: nil,
+		},
+	} {
+		tc := tc // pin
+		t.Run(strings.Join(tc.images, ","), func(t *testing.T) {
+			pod := &corev1.Pod{
+				TypeMeta: metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+			}
+			for i, image := range tc.images {
+				pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
+					Name:  fmt.Sprintf("container-%d", i),
+					Image: image,
+				})
+			}
+			bytes, err := yaml.Marshal(pod)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			values, err := l5dcharts.NewValues()
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			conf := NewResourceConfig(values, OriginWebhook, "linkerd")
+			if _, err := conf.ParseMetaAndYAML(bytes); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			ports := conf.InferOpaquePorts(images)
+			if !reflect.DeepEqual(ports, tc.expected) {
+				t.Fatalf("Expected ports %v, got %v", tc.expected, ports)
+			}
+		})
+	}
+}
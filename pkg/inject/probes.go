@@ -0,0 +1,95 @@
+package inject
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/util"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// skipProbePorts returns the inbound ports skipped by the proxy, along with
+// the ports of the probes of the application containers. The kubelet's probes
+// then reach the containers directly, instead of being denied by a policy
+// only authorizing meshed clients. Ports requiring identity aren't skipped.
+func skipProbePorts(ignoreInboundPorts, requireIdentityPorts string, containers []corev1.Container) string {
+	ignored, _ := util.ParsePorts(ignoreInboundPorts)
+	required, _ := util.ParsePorts(requireIdentityPorts)
+
+	skipped := []string{}
+	for _, port := range probePorts(containers) {
+		if _, ok := ignored[port]; ok {
+			continue
+		}
+		if _, ok := required[port]; ok {
+			log.Warnf("not skipping probe port %d, as it requires identity (%s)", port, k8s.ProxyRequireIdentityOnInboundPortsAnnotation)
+			continue
+		}
+		skipped = append(skipped, strconv.FormatUint(uint64(port), 10))
+	}
+
+	if len(skipped) == 0 {
+		return ignoreInboundPorts
+	}
+	if ignoreInboundPorts == "" {
+		return strings.Join(skipped, ",")
+	}
+	return strings.TrimSuffix(ignoreInboundPorts, ",") + "," + strings.Join(skipped, ",")
+}
+
+// probePorts returns the sorted ports of the startup, liveness and readiness
+// probes of the containers, other than the proxy
+func probePorts(containers []corev1.Container) []uint32 {
+	portSet := make(map[uint32]struct{})
+	for _, c := range containers {
+		if c.Name == k8s.ProxyContainerName {
+			continue
+		}
+		for _, probe := range []*corev1.Probe{c.StartupProbe, c.LivenessProbe, c.ReadinessProbe} {
+			if probe == nil {
+				continue
+			}
+			if port, ok := probePort(probe, c); ok {
+				portSet[port] = struct{}{}
+			}
+		}
+	}
+
+	ports := make([]uint32, 0, len(portSet))
+	for port := range portSet {
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	return ports
+}
+
+// probePort returns the port a probe is sent to, resolving the named ports
+// against the ports of its container. Exec probes don't have a port.
+func probePort(probe *corev1.Probe, c corev1.Container) (uint32, bool) {
+	var port intstr.IntOrString
+	switch {
+	case probe.HTTPGet != nil:
+		port = probe.HTTPGet.Port
+	case probe.TCPSocket != nil:
+		port = probe.TCPSocket.Port
+	case probe.GRPC != nil:
+		return uint32(probe.GRPC.Port), true
+	default:
+		return 0, false
+	}
+
+	if port.Type == intstr.Int {
+		return uint32(port.IntVal), true
+	}
+	for _, p := range c.Ports {
+		if p.Name == port.StrVal {
+			return uint32(p.ContainerPort), true
+		}
+	}
+	log.Warnf("probe port %s not found in container %s", port.StrVal, c.Name)
+	return 0, false
+}
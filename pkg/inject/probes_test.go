@@ -0,0 +1,66 @@
+package inject
+
+import (
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestSkipProbePorts(t *testing.T) {
+	httpProbe := func(port intstr.IntOrString) *corev1.Probe {
+		return &corev1.Probe{ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Port: port}}}
+	}
+	containers := []corev1.Container{
+		{
+			Name:           "app",
+			Ports:          []corev1.ContainerPort{{Name: "health", ContainerPort: 8081}},
+			StartupProbe:   httpProbe(intstr.FromString("health")),
+			LivenessProbe:  httpProbe(intstr.FromInt(8081)),
+			ReadinessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(8443)}}},
+		},
+		{
+			Name:          "sidecar",
+			LivenessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{GRPC: &corev1.GRPCAction{Port: 9000}}},
+			// exec probes don't have a port
+			ReadinessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{Exec: &corev1.ExecAction{Command: []string{"true"}}}},
+		},
+		{
+			Name:           k8s.ProxyContainerName,
+			ReadinessProbe: httpProbe(intstr.FromInt(4191)),
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		ignored         string
+		requireIdentity string
+		expected        string
+	}{
+		{
+			name:     "skips the probe ports",
+			expected: "8081,8443,9000",
+		},
+		{
+			name:     "keeps the ports already skipped",
+			ignored:  "25,8000-8100",
+			expected: "25,8000-8100,8443,9000",
+		},
+		{
+			name:            "doesn't skip the ports requiring identity",
+			requireIdentity: "8443",
+			expected:        "8081,9000",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			actual := skipProbePorts(tc.ignored, tc.requireIdentity, containers)
+			if actual != tc.expected {
+				t.Fatalf("Expected skipped ports %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -9,7 +9,8 @@ import (
 )
 
 // Uninject removes from the workload in conf the init and proxy containers,
-// the TLS volumes and the extra annotations/labels that were added
+// the TLS volumes, the linkerd-await wrappers of the commands and the extra
+// annotations/labels that were added
 func (conf *ResourceConfig) Uninject(report *Report) ([]byte, error) {
 	if conf.IsNamespace() || conf.IsService() {
 		uninjectObjectMeta(conf.workload.Meta, report)
@@ -36,10 +37,12 @@ func (conf *ResourceConfig) uninjectPodSpec(report *Report) {
 	t := conf.pod.spec
 	initContainers := []v1.Container{}
 	for _, container := range t.InitContainers {
-		if container.Name != k8s.InitContainerName {
-			initContainers = append(initContainers, container)
-		} else {
+		switch container.Name {
+		case k8s.InitContainerName:
 			report.Uninjected.ProxyInit = true
+		case awaitInstallerContainerName:
+		default:
+			initContainers = append(initContainers, container)
 		}
 	}
 	t.InitContainers = initContainers
@@ -47,7 +50,7 @@ func (conf *ResourceConfig) uninjectPodSpec(report *Report) {
 	containers := []v1.Container{}
 	for _, container := range t.Containers {
 		if container.Name != k8s.ProxyContainerName {
-			containers = append(containers, container)
+			containers = append(containers, uninjectContainer(container))
 		} else {
 			report.Uninjected.Proxy = true
 		}
@@ -56,13 +59,28 @@ func (conf *ResourceConfig) uninjectPodSpec(report *Report) {
 
 	volumes := []v1.Volume{}
 	for _, volume := range t.Volumes {
-		if volume.Name != k8s.IdentityEndEntityVolumeName && volume.Name != k8s.InitXtablesLockVolumeMountName && volume.Name != k8s.LinkerdTokenVolumeMountName {
+		if volume.Name != k8s.IdentityEndEntityVolumeName && volume.Name != k8s.InitXtablesLockVolumeMountName && volume.Name != k8s.LinkerdTokenVolumeMountName && volume.Name != awaitVolumeName {
 			volumes = append(volumes, volume)
 		}
 	}
 	t.Volumes = volumes
 }
 
+// uninjectContainer removes from an application container the linkerd-await
+// wrapper of its command and the volume mount it's read from
+func uninjectContainer(container v1.Container) v1.Container {
+	container.Command = unwrapCommand(container.Command)
+
+	var mounts []v1.VolumeMount
+	for _, mount := range container.VolumeMounts {
+		if mount.Name != awaitVolumeName {
+			mounts = append(mounts, mount)
+		}
+	}
+	container.VolumeMounts = mounts
+	return container
+}
+
 func uninjectObjectMeta(t *metav1.ObjectMeta, report *Report) {
 	// We only uninject control plane components in the context
 	// of doing an inject --manual. This is done as a way to update
@@ -0,0 +1,74 @@
+package inject
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+)
+
+// awaitMountPath is where the linkerd-await binary of the proxy image is
+// copied to by the linkerd-await-installer init container, in a volume shared
+// with the application containers
+const awaitMountPath = "/var/run/linkerd/await"
+
+// awaitPath is the path of the linkerd-await binary in the application
+// containers
+const awaitPath = awaitMountPath + "/linkerd-await"
+
+// awaitInstallerContainerName is the name of the init container copying the
+// linkerd-await binary into the awaitVolumeName volume
+const awaitInstallerContainerName = "linkerd-await-installer"
+
+// awaitVolumeName is the name of the volume sharing the linkerd-await binary
+// with the application containers
+const awaitVolumeName = "linkerd-await"
+
+// wrappedContainer is an application container whose command is wrapped with
+// linkerd-await
+type wrappedContainer struct {
+	Index           int      `json:"index"`
+	Command         []string `json:"command"`
+	AddVolumeMounts bool     `json:"addVolumeMounts"`
+}
+
+// wrapCommands wraps the command of the application containers with
+// linkerd-await, so that they wait for the proxy to be ready before starting
+// and shut it down once they exit. The arguments of the containers are
+// appended to their command by the kubelet, so they're left as is.
+//
+// The containers without a command run the entrypoint of their image, which
+// the injector doesn't know about, so they can't be wrapped.
+func (conf *ResourceConfig) wrapCommands(values *podPatch) {
+	for i, container := range conf.pod.spec.Containers {
+		if container.Name == k8s.ProxyContainerName {
+			continue
+		}
+		if len(container.Command) == 0 {
+			log.Warnf("the command of the %s container can't be wrapped as it isn't set", container.Name)
+			continue
+		}
+		if container.Command[0] == awaitPath {
+			continue
+		}
+
+		command := []string{awaitPath, fmt.Sprintf("--port=%d", values.Proxy.Ports.Admin), "--shutdown", "--"}
+		values.WrappedContainers = append(values.WrappedContainers, wrappedContainer{
+			Index:           i,
+			Command:         append(command, container.Command...),
+			AddVolumeMounts: len(container.VolumeMounts) == 0,
+		})
+	}
+}
+
+// unwrapCommand returns the command of the container without the
+// linkerd-await wrapper added by wrapCommands, if any. The commands wrapped
+// by the users themselves are left as is.
+func unwrapCommand(command []string) []string {
+	if len(command) < 4 || command[0] != awaitPath || !strings.HasPrefix(command[1], "--port=") ||
+		command[2] != "--shutdown" || command[3] != "--" {
+		return command
+	}
+	return command[4:]
+}
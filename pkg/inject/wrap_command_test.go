@@ -0,0 +1,131 @@
+package inject
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	l5dcharts "github.com/linkerd/linkerd2/pkg/charts/linkerd2"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const wrapCommandTestPod = `{
+  "apiVersion": "v1",
+  "kind": "Pod",
+  "metadata": {
+    "name": "migrate",
+    "namespace": "emojivoto",
+    "annotations": {"config.alpha.linkerd.io/wrap-command": "enabled"}
+  },
+  "spec": {
+    "containers": [
+      {"name": "migrate", "image": "migrate", "command": ["/bin/migrate"], "args": ["--all"]},
+      {"name": "entrypoint", "image": "entrypoint"},
+      {
+        "name": "wrapped",
+        "image": "wrapped",
+        "command": ["/var/run/linkerd/await/linkerd-await", "--", "/bin/wrapped"],
+        "volumeMounts": [{"name": "data", "mountPath": "/data"}]
+      }
+    ]
+  }
+}`
+
+func TestWrapCommands(t *testing.T) {
+	values, err := l5dcharts.NewValues()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	values.IdentityTrustAnchorsPEM = "trust-anchors"
+	conf := NewResourceConfig(values, OriginWebhook, "linkerd").WithKind("Pod")
+	if _, err := conf.ParseMetaAndYAML([]byte(wrapCommandTestPod)); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	patchJSON, err := conf.GetPodPatch(true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	var patch []struct {
+		Path  string      `json:"path"`
+		Value interface{} `json:"value"`
+	}
+	if err := json.Unmarshal(patchJSON, &patch); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	ops := map[string]interface{}{}
+	installer := false
+	for _, op := range patch {
+		ops[op.Path] = op.Value
+		if container, ok := op.Value.(map[string]interface{}); ok && container["name"] == "linkerd-await-installer" {
+			installer = true
+		}
+	}
+	if !installer {
+		t.Fatalf("Expected the linkerd-await-installer init container to be added, got %s", patchJSON)
+	}
+
+	expectedCommand := []interface{}{"/var/run/linkerd/await/linkerd-await", "--port=4191", "--shutdown", "--", "/bin/migrate"}
+	if command := ops["/spec/containers/0/command"]; !reflect.DeepEqual(command, expectedCommand) {
+		t.Fatalf("Expected command %v, got %v", expectedCommand, command)
+	}
+	if _, ok := ops["/spec/containers/0/args"]; ok {
+		t.Fatal("Expected the args of the container to be left as is")
+	}
+	if _, ok := ops["/spec/containers/0/volumeMounts"]; !ok {
+		t.Fatal("Expected the volumeMounts of the container to be added")
+	}
+	for _, path := range []string{"/spec/containers/1/command", "/spec/containers/2/command"} {
+		if _, ok := ops[path]; ok {
+			t.Fatalf("Expected %s not to be patched", path)
+		}
+	}
+}
+
+func TestUninjectWrappedCommands(t *testing.T) {
+	values, err := l5dcharts.NewValues()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	values.IdentityTrustAnchorsPEM = "trust-anchors"
+	conf := NewResourceConfig(values, OriginWebhook, "linkerd").WithKind("Pod")
+	if _, err := conf.ParseMetaAndYAML([]byte(wrapCommandTestPod)); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	patchJSON, err := conf.GetPodPatch(true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	patch, err := jsonpatch.DecodePatch(patchJSON)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	injected, err := patch.Apply([]byte(wrapCommandTestPod))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	conf = NewResourceConfig(values, OriginCLI, "linkerd")
+	report, err := conf.ParseMetaAndYAML(injected)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	uninjected, err := conf.Uninject(report)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var expected, got corev1.Pod
+	if err := json.Unmarshal([]byte(wrapCommandTestPod), &expected); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := yaml.Unmarshal(uninjected, &got); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(got.Spec, expected.Spec) {
+		t.Fatalf("Expected the spec of the uninjected pod to be %+v, got %+v", expected.Spec, got.Spec)
+	}
+}
@@ -92,8 +92,9 @@ func evaluateAccessReviewStatus(group, resource string, status authV1.SubjectAcc
 }
 
 // ServiceProfilesAccess checks whether the ServiceProfile CRD is installed
-// on the cluster and the client is authorized to access ServiceProfiles.
-func ServiceProfilesAccess(ctx context.Context, k8sClient kubernetes.Interface) error {
+// on the cluster and the client is authorized to access ServiceProfiles in
+// the namespace, or in all namespaces when it's empty.
+func ServiceProfilesAccess(ctx context.Context, k8sClient kubernetes.Interface, namespace string) error {
 	res, err := k8sClient.Discovery().ServerResourcesForGroupVersion(ServiceProfileAPIVersion)
 	if err != nil {
 		return err
@@ -102,7 +103,7 @@ func ServiceProfilesAccess(ctx context.Context, k8sClient kubernetes.Interface)
 	if res.GroupVersion == ServiceProfileAPIVersion {
 		for _, apiRes := range res.APIResources {
 			if apiRes.Kind == ServiceProfileKind {
-				return ResourceAuthz(ctx, k8sClient, "", "list", "linkerd.io", "", "serviceprofiles", "")
+				return ResourceAuthz(ctx, k8sClient, namespace, "list", "linkerd.io", "", "serviceprofiles", "")
 			}
 		}
 	}
@@ -110,9 +111,70 @@ func ServiceProfilesAccess(ctx context.Context, k8sClient kubernetes.Interface)
 	return errors.New("ServiceProfile CRD not found")
 }
 
+// DefaultProfilesAccess checks whether the DefaultProfile CRD is installed
+// on the cluster and the client is authorized to access DefaultProfiles in
+// the namespace, or in all namespaces when it's empty.
+func DefaultProfilesAccess(ctx context.Context, k8sClient kubernetes.Interface, namespace string) error {
+	res, err := k8sClient.Discovery().ServerResourcesForGroupVersion(DefaultProfileAPIVersion)
+	if err != nil {
+		return err
+	}
+
+	if res.GroupVersion == DefaultProfileAPIVersion {
+		for _, apiRes := range res.APIResources {
+			if apiRes.Kind == DefaultProfileKind {
+				return ResourceAuthz(ctx, k8sClient, namespace, "list", "linkerd.io", "", "defaultprofiles", "")
+			}
+		}
+	}
+
+	return errors.New("DefaultProfile CRD not found")
+}
+
+// TracingConfigurationsAccess checks whether the TracingConfiguration CRD is
+// installed on the cluster and the client is authorized to access
+// TracingConfigurations in the namespace, or in all namespaces when it's
+// empty.
+func TracingConfigurationsAccess(ctx context.Context, k8sClient kubernetes.Interface, namespace string) error {
+	res, err := k8sClient.Discovery().ServerResourcesForGroupVersion(TracingConfigurationAPIVersion)
+	if err != nil {
+		return err
+	}
+
+	if res.GroupVersion == TracingConfigurationAPIVersion {
+		for _, apiRes := range res.APIResources {
+			if apiRes.Kind == TracingConfigurationKind {
+				return ResourceAuthz(ctx, k8sClient, namespace, "list", "jaeger.linkerd.io", "", "tracingconfigurations", "")
+			}
+		}
+	}
+
+	return errors.New("TracingConfiguration CRD not found")
+}
+
+// InjectionPoliciesAccess checks whether the InjectionPolicy CRD is installed
+// on the cluster and the client is authorized to access InjectionPolicies.
+func InjectionPoliciesAccess(ctx context.Context, k8sClient kubernetes.Interface) error {
+	res, err := k8sClient.Discovery().ServerResourcesForGroupVersion(InjectionPolicyAPIVersion)
+	if err != nil {
+		return err
+	}
+
+	if res.GroupVersion == InjectionPolicyAPIVersion {
+		for _, apiRes := range res.APIResources {
+			if apiRes.Kind == InjectionPolicyKind {
+				return ResourceAuthz(ctx, k8sClient, "", "list", "config.linkerd.io", "", "injectionpolicies", "")
+			}
+		}
+	}
+
+	return errors.New("InjectionPolicy CRD not found")
+}
+
 // ServersAccess checks whether the Server CRD is installed on the cluster
-// and the client is authorized to access Servers.
-func ServersAccess(ctx context.Context, k8sClient kubernetes.Interface) error {
+// and the client is authorized to access Servers in the namespace, or in all
+// namespaces when it's empty.
+func ServersAccess(ctx context.Context, k8sClient kubernetes.Interface, namespace string) error {
 	groupVersion := fmt.Sprintf("%s/%s", PolicyAPIGroup, PolicyAPIVersion)
 	res, err := k8sClient.Discovery().ServerResourcesForGroupVersion(groupVersion)
 	if err != nil {
@@ -121,7 +183,7 @@ func ServersAccess(ctx context.Context, k8sClient kubernetes.Interface) error {
 	if res.GroupVersion == groupVersion {
 		for _, apiRes := range res.APIResources {
 			if apiRes.Kind == Server {
-				return ResourceAuthz(ctx, k8sClient, "", "list", PolicyAPIGroup, "", "servers", "")
+				return ResourceAuthz(ctx, k8sClient, namespace, "list", PolicyAPIGroup, "", "servers", "")
 			}
 		}
 	}
@@ -89,7 +89,7 @@ resources:
 		t.Fatalf("NewFakeAPI error: %s", err)
 	}
 
-	err = ServiceProfilesAccess(context.Background(), api)
+	err = ServiceProfilesAccess(context.Background(), api, "")
 	// RBAC SSAR request failed, but the Discovery lookup succeeded
 	if !reflect.DeepEqual(err, errors.New("not authorized to access serviceprofiles.linkerd.io")) {
 		t.Fatalf("unexpected error: %s", err)
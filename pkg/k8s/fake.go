@@ -31,7 +31,7 @@ import (
 
 // NewFakeAPI provides a mock KubernetesAPI backed by hard-coded resources
 func NewFakeAPI(configs ...string) (*KubernetesAPI, error) {
-	client, apiextClient, apiregClient, _, err := NewFakeClientSets(configs...)
+	client, apiextClient, apiregClient, l5dCrdClient, err := NewFakeClientSets(configs...)
 	if err != nil {
 		return nil, err
 	}
@@ -41,21 +41,24 @@ func NewFakeAPI(configs ...string) (*KubernetesAPI, error) {
 		Interface:       client,
 		Apiextensions:   apiextClient,
 		Apiregistration: apiregClient,
+		L5dCrdClient:    l5dCrdClient,
 	}, nil
 }
 
 // NewFakeAPIFromManifests reads from a slice of readers, each representing a
 // manifest or collection of manifests, and returns a mock KubernetesAPI.
 func NewFakeAPIFromManifests(readers []io.Reader) (*KubernetesAPI, error) {
-	client, apiextClient, apiregClient, _, err := newFakeClientSetsFromManifests(readers)
+	client, apiextClient, apiregClient, l5dCrdClient, err := newFakeClientSetsFromManifests(readers)
 	if err != nil {
 		return nil, err
 	}
 
 	return &KubernetesAPI{
+		Config:          &rest.Config{},
 		Interface:       client,
 		Apiextensions:   apiextClient,
 		Apiregistration: apiregClient,
+		L5dCrdClient:    l5dCrdClient,
 	}, nil
 }
 
@@ -87,8 +90,16 @@ func NewFakeClientSets(configs ...string) (
 			discoveryObjs = append(discoveryObjs, obj)
 		case ServiceProfile:
 			spObjs = append(spObjs, obj)
+		case DefaultProfile:
+			spObjs = append(spObjs, obj)
+		case TracingConfiguration:
+			spObjs = append(spObjs, obj)
+		case InjectionPolicy:
+			spObjs = append(spObjs, obj)
 		case Server:
 			spObjs = append(spObjs, obj)
+		case ServerAuthorization:
+			spObjs = append(spObjs, obj)
 		default:
 			objs = append(objs, obj)
 		}
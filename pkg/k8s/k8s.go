@@ -15,7 +15,10 @@ const (
 	Authority             = "authority"
 	CronJob               = "cronjob"
 	DaemonSet             = "daemonset"
+	DefaultProfile        = "defaultprofile"
 	Deployment            = "deployment"
+	HTTPRoute             = "httproute"
+	InjectionPolicy       = "injectionpolicy"
 	Job                   = "job"
 	Namespace             = "namespace"
 	Pod                   = "pod"
@@ -24,6 +27,7 @@ const (
 	Service               = "service"
 	ServiceProfile        = "serviceprofile"
 	StatefulSet           = "statefulset"
+	TracingConfiguration  = "tracingconfiguration"
 	Node                  = "node"
 	Server                = "server"
 	ServerAuthorization   = "serverauthorization"
@@ -34,6 +38,15 @@ const (
 	ServiceProfileAPIVersion = "linkerd.io/v1alpha2"
 	ServiceProfileKind       = "ServiceProfile"
 
+	DefaultProfileAPIVersion = "linkerd.io/v1alpha1"
+	DefaultProfileKind       = "DefaultProfile"
+
+	TracingConfigurationAPIVersion = "jaeger.linkerd.io/v1alpha1"
+	TracingConfigurationKind       = "TracingConfiguration"
+
+	InjectionPolicyAPIVersion = "config.linkerd.io/v1alpha1"
+	InjectionPolicyKind       = "InjectionPolicy"
+
 	LinkAPIGroup        = "multicluster.linkerd.io"
 	LinkAPIVersion      = "v1alpha1"
 	LinkAPIGroupVersion = "multicluster.linkerd.io/v1alpha1"
@@ -55,6 +68,7 @@ var AllResources = []string{
 	CronJob,
 	DaemonSet,
 	Deployment,
+	HTTPRoute,
 	Job,
 	Namespace,
 	Pod,
@@ -101,6 +115,7 @@ var resourceNames = []resourceName{
 	{"cj", "cronjob", "cronjobs"},
 	{"ds", "daemonset", "daemonsets"},
 	{"deploy", "deployment", "deployments"},
+	{"httproute", "httproute", "httproutes"},
 	{"job", "job", "jobs"},
 	{"ns", "namespace", "namespaces"},
 	{"po", "pod", "pods"},
@@ -116,6 +116,17 @@ const (
 	// in service identity.
 	IdentityModeAnnotation = Prefix + "/identity-mode"
 
+	// AdminSchemeAnnotation is set to "https" on the control plane and
+	// extension pods whose admin servers are served over TLS, so that their
+	// metrics get scraped over TLS
+	AdminSchemeAnnotation = Prefix + "/admin-scheme"
+
+	// SkipEndpointsAnnotation, when set to "true" on a pod, makes the
+	// destination controller leave the pod out of the endpoints of its
+	// services, e.g. for a copy of a pod created with kubectl debug that
+	// shouldn't receive production traffic
+	SkipEndpointsAnnotation = Prefix + "/skip-endpoints"
+
 	/*
 	 * Proxy config annotations
 	 */
@@ -129,6 +140,14 @@ const (
 	// ProxyImageAnnotation can be used to override the proxyImage config.
 	ProxyImageAnnotation = ProxyConfigAnnotationsPrefix + "/proxy-image"
 
+	// ProxyImageDigestAnnotation can be used to pin the proxy image by digest,
+	// overriding its version.
+	ProxyImageDigestAnnotation = ProxyConfigAnnotationsPrefix + "/proxy-image-digest"
+
+	// ProxyImageRegistryAnnotation can be used to pull the proxy, proxy-init
+	// and debug images from a mirror registry.
+	ProxyImageRegistryAnnotation = ProxyConfigAnnotationsPrefix + "/image-registry"
+
 	// ProxyImagePullPolicyAnnotation can be used to override the
 	// proxyImagePullPolicy and proxyInitImagePullPolicy configs.
 	ProxyImagePullPolicyAnnotation = ProxyConfigAnnotationsPrefix + "/image-pull-policy"
@@ -140,6 +159,10 @@ const (
 	// ProxyInitImageVersionAnnotation can be used to override the proxy-init image version
 	ProxyInitImageVersionAnnotation = ProxyConfigAnnotationsPrefix + "/init-image-version"
 
+	// ProxyInitImageDigestAnnotation can be used to pin the proxy-init image
+	// by digest, overriding its version.
+	ProxyInitImageDigestAnnotation = ProxyConfigAnnotationsPrefix + "/init-image-digest"
+
 	// DebugImageAnnotation can be used to override the debugImage config.
 	DebugImageAnnotation = ProxyConfigAnnotationsPrefix + "/debug-image"
 
@@ -193,6 +216,12 @@ const (
 	// ProxyCPULimitAnnotation can be used to override the limitCPU config.
 	ProxyCPULimitAnnotation = ProxyConfigAnnotationsPrefix + "/proxy-cpu-limit"
 
+	// ProxyCPURatioAnnotation can be used to derive the proxy's CPU request
+	// and limit, and its number of threads, from the CPU resources of the
+	// application containers, scaled by the given ratio. The explicit CPU
+	// request and limit annotations take precedence.
+	ProxyCPURatioAnnotation = ProxyConfigAnnotationsPrefix + "/proxy-cpu-ratio"
+
 	// ProxyMemoryLimitAnnotation can be used to override the limitMemory config.
 	ProxyMemoryLimitAnnotation = ProxyConfigAnnotationsPrefix + "/proxy-memory-limit"
 
@@ -250,10 +279,46 @@ const (
 	// to be ready.
 	ProxyAwait = ProxyConfigAnnotationsPrefix + "/proxy-await"
 
+	// ProxyWrapCommandAnnotation can be set to "enabled" to have the command
+	// of the application containers wrapped with linkerd-await, copied from
+	// the proxy image, so that they wait for the proxy to be ready before
+	// starting and shut it down once they exit. This lets Jobs and CronJobs
+	// complete without changes to their images. Containers without an
+	// explicit command are left as is.
+	ProxyWrapCommandAnnotation = ProxyConfigAnnotationsPrefixAlpha + "/wrap-command"
+
 	// ProxyDefaultInboundPolicyAnnotation is used to configure the default
 	// inbound policy of the proxy
 	ProxyDefaultInboundPolicyAnnotation = ProxyConfigAnnotationsPrefix + "/default-inbound-policy"
 
+	// CrossZoneWeightAnnotation can be set on a Service to scale down, to the
+	// given percentage, the load balancing weight of its endpoints that are
+	// in a different zone than the client.
+	CrossZoneWeightAnnotation = ProxyConfigAnnotationsPrefix + "/cross-zone-weight"
+
+	// SlowStartDurationAnnotation can be set on a Service to ramp up the load
+	// balancing weight of its endpoints over the given duration after their
+	// pod becomes ready.
+	SlowStartDurationAnnotation = ProxyConfigAnnotationsPrefix + "/slow-start-duration"
+
+	// H2UpgradeAnnotation can be set on a Service or a Namespace to disable
+	// ("false") or force ("true") the upgrade of HTTP/1 requests to HTTP/2
+	// between the proxies, overriding the control plane's setting for the
+	// endpoints of the services.
+	H2UpgradeAnnotation = ProxyConfigAnnotationsPrefix + "/enable-h2-upgrade"
+
+	// ProxySkipProbePortsAnnotation can be set to "true" to have the ports of
+	// the startup, liveness and readiness probes of the containers skipped by
+	// the proxy, so that the kubelet's probes aren't denied when the inbound
+	// policy only authorizes meshed clients. All the traffic to these ports
+	// bypasses the proxy, they should be dedicated to the probes.
+	ProxySkipProbePortsAnnotation = ProxyConfigAnnotationsPrefix + "/skip-probe-ports"
+
+	// ProxyEnvAnnotation can be used to set additional environment variables
+	// on the proxy container, as a JSON object of names to values. Variables
+	// managed by the injector can't be overridden this way.
+	ProxyEnvAnnotation = ProxyConfigAnnotationsPrefix + "/proxy-env"
+
 	// IdentityModeDefault is assigned to IdentityModeAnnotation to
 	// use the control plane's default identity scheme.
 	IdentityModeDefault = "default"
@@ -313,6 +378,11 @@ const (
 	// IdentityIssuerSecretName is the name of the Secret that stores issuer credentials.
 	IdentityIssuerSecretName = "linkerd-identity-issuer"
 
+	// IdentityRevocationsConfigMapName is the name of the ConfigMap listing
+	// the service accounts and pods the identity controller refuses to issue
+	// certificates for.
+	IdentityRevocationsConfigMapName = "linkerd-identity-revocations"
+
 	// IdentityIssuerSchemeLinkerd is the issuer secret scheme used by linkerd
 	IdentityIssuerSchemeLinkerd = "linkerd.io/tls"
 
@@ -369,6 +439,10 @@ const (
 	// MountPathValuesConfig is the path at which the values config file is mounted.
 	MountPathValuesConfig = MountPathBase + "/config/values"
 
+	// MountPathOpaquePortsImages is the path at which the ConfigMap mapping
+	// well-known images to their opaque ports is mounted.
+	MountPathOpaquePortsImages = MountPathBase + "/opaque-ports-images"
+
 	// MountPathTLSBase is the path at which the TLS cert and key PEM files are mounted
 	MountPathTLSBase = MountPathBase + "/tls"
 
@@ -409,6 +483,11 @@ const (
 	// allows us to associate a mirrored service with a remote cluster
 	RemoteClusterNameLabel = SvcMirrorPrefix + "/cluster-name"
 
+	// AdoptByClusterLabel is put on a local service having the name of a
+	// mirror service, to allow the links to the cluster it names to take it
+	// over when their conflict policy is adopt-if-labeled
+	AdoptByClusterLabel = SvcMirrorPrefix + "/adopt-by-cluster"
+
 	// RemoteResourceVersionAnnotation is the last observed remote resource
 	// version of a mirrored resource. Useful when doing updates
 	RemoteResourceVersionAnnotation = SvcMirrorPrefix + "/remote-resource-version"
@@ -417,6 +496,15 @@ const (
 	// on the remote cluster
 	RemoteServiceFqName = SvcMirrorPrefix + "/remote-svc-fq-name"
 
+	// OriginServiceUID is the UID of the service at the origin of a chain of
+	// mirrors, e.g. of a service mirrored by a hub cluster and mirrored back
+	// from the hub. It's propagated as is along the chain.
+	OriginServiceUID = SvcMirrorPrefix + "/origin-svc-uid"
+
+	// OriginServiceFqName is the fully qualified name of the service at the
+	// origin of a chain of mirrors, in the cluster domain of its cluster
+	OriginServiceFqName = SvcMirrorPrefix + "/origin-svc-fq-name"
+
 	// RemoteGatewayIdentity follows the same kind of logic as RemoteGatewayNameLabel
 	RemoteGatewayIdentity = SvcMirrorPrefix + "/remote-gateway-identity"
 
@@ -4,12 +4,19 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
+	"github.com/linkerd/linkerd2-proxy-init/ports"
 	serverv1beta1 "github.com/linkerd/linkerd2/controller/gen/apis/server/v1beta1"
 	serverauthorizationv1beta1 "github.com/linkerd/linkerd2/controller/gen/apis/serverauthorization/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -19,14 +26,81 @@ type ServerAndAuthorization struct {
 	ServerAuthorization string
 }
 
+// ServerConflict describes two Servers selecting the same port of a pod with
+// different proxy protocols.
+type ServerConflict struct {
+	Namespace string
+	Servers   [2]string
+	Protocols [2]string
+	Pod       string
+	Port      int32
+}
+
+func (c ServerConflict) String() string {
+	return fmt.Sprintf("servers %s/%s (%s) and %s/%s (%s) select port %d of pod %s/%s",
+		c.Namespace, c.Servers[0], c.Protocols[0], c.Namespace, c.Servers[1], c.Protocols[1], c.Port, c.Namespace, c.Pod)
+}
+
 type id struct{ name, namespace string }
 
+// unknownProxyProtocol is the protocol of the Servers not setting one, which
+// the proxies detect
+const unknownProxyProtocol = "unknown"
+
 // SazGVR is the GroupVersionResource for the ServerAuthorization resource.
 var SazGVR = serverauthorizationv1beta1.SchemeGroupVersion.WithResource("serverauthorizations")
 
 // ServerGVR is the GroupVersionResource for the Server resource.
 var ServerGVR = serverv1beta1.SchemeGroupVersion.WithResource("servers")
 
+// GatewayAPIGroup is the API group of the Gateway API resources.
+const GatewayAPIGroup = "gateway.networking.k8s.io"
+
+// GatewayResource is the resource of the Gateway API Gateways.
+const GatewayResource = "gateways"
+
+// HTTPRouteResource is the resource of the Gateway API HTTPRoutes.
+const HTTPRouteResource = "httproutes"
+
+// GatewayAPIGVR returns the GroupVersionResource of a Gateway API resource in
+// the version the cluster prefers, as the versions served depend on the
+// Gateway API CRDs installed. It returns a NotFound error when no version of
+// the group serves the resource.
+func GatewayAPIGVR(client discovery.DiscoveryInterface, resource string) (schema.GroupVersionResource, error) {
+	groups, err := client.ServerGroups()
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+
+	for _, group := range groups.Groups {
+		if group.Name != GatewayAPIGroup {
+			continue
+		}
+		// the preferred version is tried first
+		versions := []metav1.GroupVersionForDiscovery{group.PreferredVersion}
+		versions = append(versions, group.Versions...)
+		for _, version := range versions {
+			if version.GroupVersion == "" {
+				continue
+			}
+			resources, err := client.ServerResourcesForGroupVersion(version.GroupVersion)
+			if err != nil {
+				if kerrors.IsNotFound(err) {
+					continue
+				}
+				return schema.GroupVersionResource{}, err
+			}
+			for _, res := range resources.APIResources {
+				if res.Name == resource {
+					return schema.GroupVersionResource{Group: GatewayAPIGroup, Version: version.Version, Resource: resource}, nil
+				}
+			}
+		}
+	}
+
+	return schema.GroupVersionResource{}, kerrors.NewNotFound(schema.GroupResource{Group: GatewayAPIGroup, Resource: resource}, "")
+}
+
 // ServerAuthorizationsForResource returns a list of Server-ServerAuthorization
 // pairs which select pods belonging to the given resource.
 func ServerAuthorizationsForResource(ctx context.Context, k8sAPI *KubernetesAPI, namespace string, resource string) ([]ServerAndAuthorization, error) {
@@ -195,6 +269,103 @@ func serverIncludesPod(server serverv1beta1.Server, serverPods []corev1.Pod, pod
 	return false
 }
 
+// ConflictingServers returns the pairs of Servers selecting the same port of
+// one of the pods with different proxy protocols. Each pair is reported once
+// per port, along with the first pod it conflicts on.
+func ConflictingServers(servers []serverv1beta1.Server, pods []corev1.Pod) ([]ServerConflict, error) {
+	sorted := make([]serverv1beta1.Server, len(servers))
+	copy(sorted, servers)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	conflicts := []ServerConflict{}
+	seen := make(map[ServerConflict]struct{})
+	for _, pod := range pods {
+		// the Servers selecting the pod, by port
+		selecting := make(map[int32][]serverv1beta1.Server)
+		ports := []int32{}
+		for _, server := range sorted {
+			if server.Namespace != pod.Namespace || server.Spec.PodSelector == nil {
+				continue
+			}
+			selector, err := metav1.LabelSelectorAsSelector(server.Spec.PodSelector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid podSelector in server %s/%s: %w", server.Namespace, server.Name, err)
+			}
+			if !selector.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+			for _, port := range serverPorts(server, pod) {
+				if _, ok := selecting[port]; !ok {
+					ports = append(ports, port)
+				}
+				selecting[port] = append(selecting[port], server)
+			}
+		}
+		sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+
+		for _, port := range ports {
+			selected := selecting[port]
+			for i := 0; i < len(selected); i++ {
+				for j := i + 1; j < len(selected); j++ {
+					protocols := [2]string{proxyProtocol(selected[i]), proxyProtocol(selected[j])}
+					if protocols[0] == protocols[1] {
+						continue
+					}
+					key := ServerConflict{
+						Namespace: pod.Namespace,
+						Servers:   [2]string{selected[i].Name, selected[j].Name},
+						Protocols: protocols,
+						Port:      port,
+					}
+					if _, ok := seen[key]; ok {
+						continue
+					}
+					seen[key] = struct{}{}
+					conflict := key
+					conflict.Pod = pod.Name
+					conflicts = append(conflicts, conflict)
+				}
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+// serverPorts returns the ports of the pod the Server selects: its port
+// number, the container ports with its port name or, when no container port
+// has that name, the numbers in its port range
+func serverPorts(server serverv1beta1.Server, pod corev1.Pod) []int32 {
+	if server.Spec.Port.Type == intstr.Int {
+		return []int32{server.Spec.Port.IntVal}
+	}
+	selected := []int32{}
+	for _, container := range pod.Spec.Containers {
+		for _, p := range container.Ports {
+			if p.Name == server.Spec.Port.StrVal {
+				selected = append(selected, p.ContainerPort)
+			}
+		}
+	}
+	if len(selected) > 0 {
+		return selected
+	}
+	portRange, err := ports.ParsePortRange(server.Spec.Port.StrVal)
+	if err != nil {
+		return selected
+	}
+	for port := portRange.LowerBound; port <= portRange.UpperBound; port++ {
+		selected = append(selected, int32(port))
+	}
+	return selected
+}
+
+func proxyProtocol(server serverv1beta1.Server) string {
+	if server.Spec.ProxyProtocol == "" {
+		return unknownProxyProtocol
+	}
+	return server.Spec.ProxyProtocol
+}
+
 // getPodsForResourceOrKind is similar to getPodsForResource, but also supports
 // querying for all resources of a given kind (i.e. when resource name is unspecified).
 func getPodsForResourceOrKind(ctx context.Context, k8sAPI kubernetes.Interface, namespace string, resource string, labelSelector string) ([]corev1.Pod, error) {
@@ -2,6 +2,7 @@ package k8s
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -33,6 +34,8 @@ type PortForward struct {
 	stopCh     chan struct{}
 	readyCh    chan struct{}
 	config     *rest.Config
+	// scheme is https when forwarding to an admin server served over TLS
+	scheme string
 }
 
 // NewContainerMetricsForward returns an instance of the PortForward struct that can
@@ -56,7 +59,15 @@ func NewContainerMetricsForward(
 		return nil, fmt.Errorf("no %s port found for container %s/%s", portName, pod.GetName(), container.Name)
 	}
 
-	return newPortForward(k8sAPI, pod.GetNamespace(), pod.GetName(), "localhost", 0, int(port.ContainerPort), emitLogs)
+	pf, err := newPortForward(k8sAPI, pod.GetNamespace(), pod.GetName(), "localhost", 0, int(port.ContainerPort), emitLogs)
+	if err != nil || pf == nil {
+		return pf, err
+	}
+	// the proxy's admin server is never served over TLS
+	if portName != ProxyAdminPortName && pod.GetAnnotations()[AdminSchemeAnnotation] == "https" {
+		pf.scheme = "https"
+	}
+	return pf, nil
 }
 
 // NewPortForward returns an instance of the PortForward struct that can be used
@@ -157,6 +168,7 @@ func newPortForward(
 		localPort:  localPort,
 		remotePort: remotePort,
 		emitLogs:   emitLogs,
+		scheme:     "http",
 		stopCh:     make(chan struct{}, 1),
 		readyCh:    make(chan struct{}),
 		config:     k8sAPI.Config,
@@ -236,7 +248,22 @@ func (pf *PortForward) GetStop() <-chan struct{} {
 
 // URLFor returns the URL for the port-forward connection.
 func (pf *PortForward) URLFor(path string) string {
-	return fmt.Sprintf("http://%s:%d%s", pf.host, pf.localPort, path)
+	return fmt.Sprintf("%s://%s:%d%s", pf.scheme, pf.host, pf.localPort, path)
+}
+
+// HTTPClient returns the client for the URLs of the port-forward connection.
+// The certificates of the admin servers served over TLS aren't verified, as
+// they don't name the forwarded address.
+func (pf *PortForward) HTTPClient() *http.Client {
+	if pf.scheme != "https" {
+		return http.DefaultClient
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			// #nosec G402
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
 }
 
 // AddressAndPort returns the address and port for the port-forward connection.
@@ -0,0 +1,182 @@
+package leader
+
+import (
+	"context"
+	"flag"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	logging "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+const (
+	// DefaultLeaseDuration is the default duration the non-leader replicas
+	// wait for before taking over a lease that wasn't renewed
+	DefaultLeaseDuration = 30 * time.Second
+	// DefaultRenewDeadline is the default duration the leader retries
+	// renewing its lease for before giving up leadership
+	DefaultRenewDeadline = 10 * time.Second
+	// DefaultRetryPeriod is the default duration the replicas wait for
+	// between attempts at acquiring or renewing the lease
+	DefaultRetryPeriod = 2 * time.Second
+)
+
+var (
+	leaderGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "leader_election_leader",
+		Help: "Set to 1 when this replica holds the lease, 0 otherwise",
+	}, []string{"lease"})
+
+	transitionsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "leader_election_transitions_total",
+		Help: "Number of times the leader of the lease changed, as observed by this replica",
+	}, []string{"lease"})
+)
+
+type (
+	// Config holds the timing parameters of a leader election, which trade
+	// failover speed for the load on the Kubernetes API.
+	Config struct {
+		LeaseDuration time.Duration
+		RenewDeadline time.Duration
+		RetryPeriod   time.Duration
+	}
+
+	// Task is write-side work that must only be performed by a single replica
+	// of a component at a time. It is started when the replica acquires
+	// leadership and must return once ctx is cancelled.
+	Task func(ctx context.Context)
+
+	// Elector elects, among the replicas of a component, the one in charge of
+	// the work that must not be performed concurrently.
+	Elector struct {
+		elector *leaderelection.LeaderElector
+		leading int32
+		log     *logging.Entry
+
+		// the last observed leader, empty until one is observed
+		sync.Mutex
+		leader string
+	}
+)
+
+// AddFlags adds the flags configuring the timing of the leader election to
+// the flagSet and returns the Config they're parsed into
+func AddFlags(cmd *flag.FlagSet) *Config {
+	config := &Config{}
+	cmd.DurationVar(&config.LeaseDuration, "leader-election-lease-duration", DefaultLeaseDuration,
+		"Duration the non-leader replicas wait for before taking over a lease that wasn't renewed")
+	cmd.DurationVar(&config.RenewDeadline, "leader-election-renew-deadline", DefaultRenewDeadline,
+		"Duration the leader retries renewing its lease for before giving up leadership; must be shorter than the lease duration")
+	cmd.DurationVar(&config.RetryPeriod, "leader-election-retry-period", DefaultRetryPeriod,
+		"Duration the replicas wait for between attempts at acquiring or renewing the lease")
+	return config
+}
+
+// NewElector returns an Elector competing for the named Lease in the given
+// namespace, identified by the given replica name. The tasks are run
+// whenever this replica becomes the leader. An error is returned if the
+// timing parameters are inconsistent.
+func NewElector(
+	client kubernetes.Interface,
+	namespace string,
+	leaseName string,
+	identity string,
+	config Config,
+	tasks ...Task,
+) (*Elector, error) {
+	le := &Elector{
+		log: logging.WithFields(logging.Fields{
+			"component": "leader-elector",
+			"lease":     leaseName,
+			"identity":  identity,
+		}),
+	}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      leaseName,
+			Namespace: namespace,
+		},
+		Client: client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: identity,
+		},
+	}
+
+	gauge := leaderGauge.WithLabelValues(leaseName)
+	transitions := transitionsCounter.WithLabelValues(leaseName)
+	gauge.Set(0)
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   config.LeaseDuration,
+		RenewDeadline:   config.RenewDeadline,
+		RetryPeriod:     config.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            leaseName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				le.log.Info("started leading")
+				atomic.StoreInt32(&le.leading, 1)
+				gauge.Set(1)
+				for _, task := range tasks {
+					go task(ctx)
+				}
+			},
+			OnStoppedLeading: func() {
+				le.log.Info("stopped leading")
+				atomic.StoreInt32(&le.leading, 0)
+				gauge.Set(0)
+			},
+			OnNewLeader: func(leader string) {
+				le.log.Debugf("current leader is %s", leader)
+				le.observeLeader(leader, transitions)
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	le.elector = elector
+
+	return le, nil
+}
+
+// Run competes for leadership until ctx is cancelled. Leadership is lost
+// whenever the lease can't be renewed, in which case this replica goes back
+// to competing for it.
+func (le *Elector) Run(ctx context.Context) {
+	for {
+		le.elector.Run(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+	}
+}
+
+// observeLeader counts a transition whenever the leader differs from the
+// previously observed one. The first observed leader isn't a transition, as
+// it may have been leading for a while.
+func (le *Elector) observeLeader(leader string, transitions prometheus.Counter) {
+	le.Lock()
+	defer le.Unlock()
+	if le.leader != "" && le.leader != leader {
+		transitions.Inc()
+	}
+	le.leader = leader
+}
+
+// IsLeader returns true if this replica currently holds the lease
+func (le *Elector) IsLeader() bool {
+	return atomic.LoadInt32(&le.leading) == 1
+}
@@ -0,0 +1,86 @@
+package leader
+
+import (
+	"context"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestAddFlags(t *testing.T) {
+	cmd := flag.NewFlagSet("test", flag.ContinueOnError)
+	config := AddFlags(cmd)
+	if err := cmd.Parse([]string{"-leader-election-lease-duration=15s", "-leader-election-renew-deadline=5s"}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := Config{
+		LeaseDuration: 15 * time.Second,
+		RenewDeadline: 5 * time.Second,
+		RetryPeriod:   DefaultRetryPeriod,
+	}
+	if *config != expected {
+		t.Fatalf("Expected config %+v, got %+v", expected, *config)
+	}
+}
+
+func TestNewElector(t *testing.T) {
+	t.Run("Rejects inconsistent timings", func(t *testing.T) {
+		_, err := NewElector(fake.NewSimpleClientset(), "linkerd", "test-inconsistent", "replica-1", Config{
+			LeaseDuration: 5 * time.Second,
+			RenewDeadline: 10 * time.Second,
+			RetryPeriod:   DefaultRetryPeriod,
+		})
+		if err == nil {
+			t.Fatal("Expected an error, got nil")
+		}
+	})
+
+	t.Run("Runs the tasks once leading", func(t *testing.T) {
+		started := make(chan struct{})
+		elector, err := NewElector(fake.NewSimpleClientset(), "linkerd", "test-lease", "replica-1", Config{
+			LeaseDuration: 3 * time.Second,
+			RenewDeadline: 2 * time.Second,
+			RetryPeriod:   100 * time.Millisecond,
+		}, func(ctx context.Context) {
+			close(started)
+			<-ctx.Done()
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go elector.Run(ctx)
+
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected the task to be started")
+		}
+		if !elector.IsLeader() {
+			t.Fatal("Expected the replica to be the leader")
+		}
+		if value := testutil.ToFloat64(leaderGauge.WithLabelValues("test-lease")); value != 1 {
+			t.Fatalf("Expected the leader gauge to be 1, got %f", value)
+		}
+	})
+}
+
+func TestObserveLeader(t *testing.T) {
+	elector := &Elector{}
+	transitions := transitionsCounter.WithLabelValues("test-transitions")
+
+	for _, leader := range []string{"replica-1", "replica-1", "replica-2", "replica-2", "replica-1"} {
+		elector.observeLeader(leader, transitions)
+	}
+
+	// the first observed leader isn't a transition
+	if value := testutil.ToFloat64(transitions); value != 2 {
+		t.Fatalf("Expected 2 transitions, got %f", value)
+	}
+}
@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type levelsResponse struct {
+	Level      string            `json:"level"`
+	Components map[string]string `json:"components"`
+}
+
+// NewHandler returns the handler serving the log levels of the process.
+//
+// GET returns the global log level and the levels of the components
+// overriding it. PUT sets the level given by the `level` parameter, for the
+// component given by the `component` parameter, or globally if it is
+// omitted. DELETE reverts the `component` to the global log level.
+func NewHandler() http.Handler {
+	return http.HandlerFunc(serveLevels)
+}
+
+func serveLevels(w http.ResponseWriter, req *http.Request) {
+	component := req.FormValue("component")
+
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPost:
+		level, err := log.ParseLevel(req.FormValue("level"))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid log level: %s", err), http.StatusBadRequest)
+			return
+		}
+		if component == "" {
+			SetLevel(level)
+		} else {
+			SetComponentLevel(component, level)
+		}
+		log.Infof("Set the log level of %s to %s", componentName(component), level)
+	case http.MethodDelete:
+		if component == "" {
+			http.Error(w, "missing component", http.StatusBadRequest)
+			return
+		}
+		ResetComponentLevel(component)
+		log.Infof("Reset the log level of %s", componentName(component))
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST, DELETE")
+		http.Error(w, fmt.Sprintf("method %s not allowed", req.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	global, components := Levels()
+	rsp := levelsResponse{
+		Level:      global.String(),
+		Components: make(map[string]string, len(components)),
+	}
+	for name, level := range components {
+		rsp.Components[name] = level.String()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(rsp); err != nil {
+		log.Errorf("Failed to write the log levels: %s", err)
+	}
+}
+
+func componentName(component string) string {
+	if component == "" {
+		return "all components"
+	}
+	return fmt.Sprintf("component %s", component)
+}
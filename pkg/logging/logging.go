@@ -0,0 +1,116 @@
+package logging
+
+import (
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ComponentKey is the field of the log entries naming the component that
+// emitted them
+const ComponentKey = "component"
+
+// levels holds the global log level and the levels of the components
+// overriding it
+type levels struct {
+	global     log.Level
+	components map[string]log.Level
+	sync.RWMutex
+}
+
+var current = &levels{
+	global:     log.InfoLevel,
+	components: make(map[string]log.Level),
+}
+
+// componentFormatter formats the entries enabled at the level of the
+// component that emitted them, and drops the others
+type componentFormatter struct {
+	formatter log.Formatter
+}
+
+// NewFormatter wraps formatter so that the entries of each component are
+// filtered by the component's log level. It must be installed as the
+// formatter of the standard logger for SetComponentLevel to take effect.
+func NewFormatter(formatter log.Formatter) log.Formatter {
+	return &componentFormatter{formatter}
+}
+
+func (f *componentFormatter) Format(entry *log.Entry) ([]byte, error) {
+	if !current.enabled(entry) {
+		return nil, nil
+	}
+	return f.formatter.Format(entry)
+}
+
+// NewJSONFormatter returns the formatter used for JSON output
+func NewJSONFormatter() log.Formatter {
+	return NewFormatter(&log.JSONFormatter{})
+}
+
+// NewTextFormatter returns the formatter used for plain text output
+func NewTextFormatter() log.Formatter {
+	return NewFormatter(&log.TextFormatter{FullTimestamp: true})
+}
+
+// SetLevel sets the log level of the components without a level of their
+// own
+func SetLevel(level log.Level) {
+	current.Lock()
+	defer current.Unlock()
+	current.global = level
+	current.apply()
+}
+
+// SetComponentLevel sets the log level of a component, overriding the
+// global one
+func SetComponentLevel(component string, level log.Level) {
+	current.Lock()
+	defer current.Unlock()
+	current.components[component] = level
+	current.apply()
+}
+
+// ResetComponentLevel reverts a component to the global log level
+func ResetComponentLevel(component string) {
+	current.Lock()
+	defer current.Unlock()
+	delete(current.components, component)
+	current.apply()
+}
+
+// Levels returns the global log level, and the components overriding it
+// with their level
+func Levels() (log.Level, map[string]log.Level) {
+	current.RLock()
+	defer current.RUnlock()
+	components := make(map[string]log.Level, len(current.components))
+	for component, level := range current.components {
+		components[component] = level
+	}
+	return current.global, components
+}
+
+// apply sets the level of the standard logger to the most verbose level in
+// use, so that the entries of every component reach the formatter
+func (l *levels) apply() {
+	max := l.global
+	for _, level := range l.components {
+		if level > max {
+			max = level
+		}
+	}
+	log.SetLevel(max)
+}
+
+func (l *levels) enabled(entry *log.Entry) bool {
+	l.RLock()
+	defer l.RUnlock()
+	level := l.global
+	if component, ok := entry.Data[ComponentKey].(string); ok {
+		if componentLevel, ok := l.components[component]; ok {
+			level = componentLevel
+		}
+	}
+	return entry.Level <= level
+}
@@ -0,0 +1,124 @@
+package logging
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func newTestLogger(t *testing.T) *bytes.Buffer {
+	out := &bytes.Buffer{}
+	log.SetOutput(out)
+	log.SetFormatter(NewFormatter(&log.TextFormatter{DisableTimestamp: true}))
+	SetLevel(log.InfoLevel)
+	t.Cleanup(func() {
+		for component := range current.components {
+			ResetComponentLevel(component)
+		}
+		log.SetOutput(&bytes.Buffer{})
+	})
+	return out
+}
+
+func TestComponentLevels(t *testing.T) {
+	out := newTestLogger(t)
+	SetComponentLevel("server-watcher", log.DebugLevel)
+
+	if level := log.GetLevel(); level != log.DebugLevel {
+		t.Fatalf("Expected the standard logger at level debug, got %s", level)
+	}
+
+	log.WithField(ComponentKey, "server-watcher").Debug("watcher debug")
+	log.WithField(ComponentKey, "endpoints-watcher").Debug("endpoints debug")
+	log.WithField(ComponentKey, "endpoints-watcher").Info("endpoints info")
+	log.Debug("global debug")
+
+	logs := out.String()
+	for _, expected := range []string{"watcher debug", "endpoints info"} {
+		if !strings.Contains(logs, expected) {
+			t.Fatalf("Expected the logs to contain %q, got %q", expected, logs)
+		}
+	}
+	for _, unexpected := range []string{"endpoints debug", "global debug"} {
+		if strings.Contains(logs, unexpected) {
+			t.Fatalf("Expected the logs not to contain %q, got %q", unexpected, logs)
+		}
+	}
+
+	ResetComponentLevel("server-watcher")
+	if level := log.GetLevel(); level != log.InfoLevel {
+		t.Fatalf("Expected the standard logger at level info, got %s", level)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	newTestLogger(t)
+	handler := NewHandler()
+
+	testCases := []struct {
+		method   string
+		query    string
+		code     int
+		expected string
+	}{
+		{
+			method:   http.MethodGet,
+			code:     http.StatusOK,
+			expected: `{"level":"info","components":{}}`,
+		},
+		{
+			method:   http.MethodPut,
+			query:    "component=server-watcher&level=debug",
+			code:     http.StatusOK,
+			expected: `{"level":"info","components":{"server-watcher":"debug"}}`,
+		},
+		{
+			method:   http.MethodPut,
+			query:    "level=warn",
+			code:     http.StatusOK,
+			expected: `{"level":"warning","components":{"server-watcher":"debug"}}`,
+		},
+		{
+			method:   http.MethodPut,
+			query:    "component=server-watcher&level=loud",
+			code:     http.StatusBadRequest,
+			expected: `invalid log level: not a valid logrus Level: "loud"`,
+		},
+		{
+			method:   http.MethodDelete,
+			query:    "component=server-watcher",
+			code:     http.StatusOK,
+			expected: `{"level":"warning","components":{}}`,
+		},
+		{
+			method:   http.MethodDelete,
+			code:     http.StatusBadRequest,
+			expected: "missing component",
+		},
+		{
+			method:   http.MethodPatch,
+			code:     http.StatusMethodNotAllowed,
+			expected: "method PATCH not allowed",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.method+" "+tc.query, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/log-level?"+tc.query, nil)
+			rsp := httptest.NewRecorder()
+			handler.ServeHTTP(rsp, req)
+
+			if rsp.Code != tc.code {
+				t.Fatalf("Expected status %d, got %d", tc.code, rsp.Code)
+			}
+			if body := strings.TrimSpace(rsp.Body.String()); body != tc.expected {
+				t.Fatalf("Expected body %s, got %s", tc.expected, body)
+			}
+		})
+	}
+}
@@ -12,20 +12,61 @@ import (
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	consts "github.com/linkerd/linkerd2/pkg/k8s"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 )
 
+const (
+	// DefaultProbeTimeout is the time after which a probe is failed, for the
+	// links that don't configure it
+	DefaultProbeTimeout = 50 * time.Second
+	// DefaultProbeFailureThreshold is the number of consecutive failed probes
+	// after which a gateway is considered down, for the links that don't
+	// configure it
+	DefaultProbeFailureThreshold = 1
+	// DefaultClusterDomain is the cluster domain of the target cluster, for
+	// the links that don't specify it
+	DefaultClusterDomain = "cluster.local"
+
+	// GatewayAliveCondition is the type of the Link status condition reporting
+	// whether the gateway of the target cluster passes its health probes
+	GatewayAliveCondition = "GatewayAlive"
+	// MirrorConflictsCondition is the type of the Link status condition
+	// reporting the exported services whose mirror name is already taken by
+	// a local service
+	MirrorConflictsCondition = "MirrorConflicts"
+
+	// ConflictPolicySkip doesn't mirror the exported services whose mirror
+	// name is taken by a local service
+	ConflictPolicySkip = "skip"
+	// ConflictPolicySuffix mirrors the exported services whose mirror name is
+	// taken by a local service under a suffixed name
+	ConflictPolicySuffix = "suffix"
+	// ConflictPolicyAdoptIfLabeled takes over the local services having the
+	// name of a mirror service when they're labeled to be adopted by the
+	// target cluster, and skips the others
+	ConflictPolicyAdoptIfLabeled = "adopt-if-labeled"
+)
+
+// ConflictPolicies are the valid conflict policies of a link
+var ConflictPolicies = []string{ConflictPolicySkip, ConflictPolicySuffix, ConflictPolicyAdoptIfLabeled}
+
 type (
 	// ProbeSpec defines how a gateway should be queried for health. Once per
 	// period, the probe workers will send an HTTP request to the remote gateway
-	// on the given  port with the given path and expect a HTTP 200 response.
+	// on the given  port with the given path and expect a HTTP 200 response
+	// within the timeout. The gateway is considered down after FailureThreshold
+	// consecutive failed probes.
 	ProbeSpec struct {
-		Path   string
-		Port   uint32
-		Period time.Duration
+		Path             string
+		Port             uint32
+		Period           time.Duration
+		Timeout          time.Duration
+		FailureThreshold uint32
 	}
 
 	// Link is an internal representation of the link.multicluster.linkerd.io
@@ -35,6 +76,7 @@ type (
 	Link struct {
 		Name                          string
 		Namespace                     string
+		UID                           types.UID
 		TargetClusterName             string
 		TargetClusterDomain           string
 		TargetClusterLinkerdNamespace string
@@ -44,6 +86,18 @@ type (
 		GatewayIdentity               string
 		ProbeSpec                     ProbeSpec
 		Selector                      metav1.LabelSelector
+		// Namespaces scopes the link to these namespaces of the target
+		// cluster; the link isn't scoped when empty
+		Namespaces []string
+		// MirrorPolicies enables the mirroring of the Servers and
+		// ServerAuthorizations of the exported services
+		MirrorPolicies bool
+		// ConflictPolicy is how the exported services whose mirror name is
+		// taken by a local service are handled, one of ConflictPolicies
+		ConflictPolicy string
+		// Conditions is the status of the link, as last reported by its
+		// service mirror controller
+		Conditions []metav1.Condition
 	}
 )
 
@@ -55,7 +109,7 @@ var LinkGVR = schema.GroupVersionResource{
 }
 
 func (ps ProbeSpec) String() string {
-	return fmt.Sprintf("ProbeSpec: {path: %s, port: %d, period: %s}", ps.Path, ps.Port, ps.Period)
+	return fmt.Sprintf("ProbeSpec: {path: %s, port: %d, period: %s, timeout: %s, failureThreshold: %d}", ps.Path, ps.Port, ps.Period, ps.Timeout, ps.FailureThreshold)
 }
 
 // NewLink parses an unstructured link.multicluster.linkerd.io resource and
@@ -90,9 +144,18 @@ func NewLink(u unstructured.Unstructured) (Link, error) {
 		return Link{}, err
 	}
 
-	targetClusterDomain, err := stringField(specObj, "targetClusterDomain")
-	if err != nil {
-		return Link{}, err
+	// the services of the target cluster are resolved under its own cluster
+	// domain, which is left empty by the links of clusters that don't report
+	// one
+	targetClusterDomain := DefaultClusterDomain
+	if _, ok := specObj["targetClusterDomain"]; ok {
+		targetClusterDomain, err = stringField(specObj, "targetClusterDomain")
+		if err != nil {
+			return Link{}, err
+		}
+		if targetClusterDomain == "" {
+			targetClusterDomain = DefaultClusterDomain
+		}
 	}
 
 	targetClusterLinkerdNamespace, err := stringField(specObj, "targetClusterLinkerdNamespace")
@@ -136,9 +199,38 @@ func NewLink(u unstructured.Unstructured) (Link, error) {
 		}
 	}
 
+	namespaces, _, err := unstructured.NestedStringSlice(specObj, "namespaces")
+	if err != nil {
+		return Link{}, err
+	}
+
+	mirrorPolicies, _, err := unstructured.NestedBool(specObj, "mirrorPolicies")
+	if err != nil {
+		return Link{}, err
+	}
+
+	// the conflict policy was added later on, so it's optional for the links
+	// created by older versions
+	conflictPolicy := ConflictPolicySkip
+	if _, ok := specObj["conflictPolicy"]; ok {
+		conflictPolicy, err = stringField(specObj, "conflictPolicy")
+		if err != nil {
+			return Link{}, err
+		}
+		if !IsConflictPolicy(conflictPolicy) {
+			return Link{}, fmt.Errorf("Field 'conflictPolicy' must be one of %s", strings.Join(ConflictPolicies, ", "))
+		}
+	}
+
+	conditions, err := linkConditions(u)
+	if err != nil {
+		return Link{}, err
+	}
+
 	return Link{
 		Name:                          u.GetName(),
 		Namespace:                     u.GetNamespace(),
+		UID:                           u.GetUID(),
 		TargetClusterName:             targetClusterName,
 		TargetClusterDomain:           targetClusterDomain,
 		TargetClusterLinkerdNamespace: targetClusterLinkerdNamespace,
@@ -148,6 +240,10 @@ func NewLink(u unstructured.Unstructured) (Link, error) {
 		GatewayIdentity:               gatewayIdentity,
 		ProbeSpec:                     probeSpec,
 		Selector:                      selector,
+		Namespaces:                    namespaces,
+		MirrorPolicies:                mirrorPolicies,
+		ConflictPolicy:                conflictPolicy,
+		Conditions:                    conditions,
 	}, nil
 }
 
@@ -163,9 +259,11 @@ func (l Link) ToUnstructured() (unstructured.Unstructured, error) {
 		"gatewayPort":                   fmt.Sprintf("%d", l.GatewayPort),
 		"gatewayIdentity":               l.GatewayIdentity,
 		"probeSpec": map[string]interface{}{
-			"path":   l.ProbeSpec.Path,
-			"port":   fmt.Sprintf("%d", l.ProbeSpec.Port),
-			"period": l.ProbeSpec.Period.String(),
+			"path":             l.ProbeSpec.Path,
+			"port":             fmt.Sprintf("%d", l.ProbeSpec.Port),
+			"period":           l.ProbeSpec.Period.String(),
+			"timeout":          l.ProbeSpec.Timeout.String(),
+			"failureThreshold": fmt.Sprintf("%d", l.ProbeSpec.FailureThreshold),
 		},
 	}
 
@@ -180,6 +278,22 @@ func (l Link) ToUnstructured() (unstructured.Unstructured, error) {
 	}
 	spec["selector"] = selector
 
+	if len(l.Namespaces) > 0 {
+		namespaces := make([]interface{}, len(l.Namespaces))
+		for i, ns := range l.Namespaces {
+			namespaces[i] = ns
+		}
+		spec["namespaces"] = namespaces
+	}
+
+	if l.MirrorPolicies {
+		spec["mirrorPolicies"] = true
+	}
+
+	if l.ConflictPolicy != "" && l.ConflictPolicy != ConflictPolicySkip {
+		spec["conflictPolicy"] = l.ConflictPolicy
+	}
+
 	return unstructured.Unstructured{
 		Object: map[string]interface{}{
 			"apiVersion": k8s.LinkAPIGroupVersion,
@@ -211,9 +325,11 @@ func ExtractProbeSpec(gateway *corev1.Service) (ProbeSpec, error) {
 	}
 
 	return ProbeSpec{
-		Path:   path,
-		Port:   port,
-		Period: time.Duration(period) * time.Second,
+		Path:             path,
+		Port:             port,
+		Period:           time.Duration(period) * time.Second,
+		Timeout:          DefaultProbeTimeout,
+		FailureThreshold: DefaultProbeFailureThreshold,
 	}, nil
 }
 
@@ -248,6 +364,41 @@ func GetLink(ctx context.Context, client dynamic.Interface, namespace, name stri
 	return NewLink(*unstructured)
 }
 
+// SetLinkCondition adds the condition to the status of a Link, replacing the
+// condition of the same type if any. The transition time of the condition is
+// only updated when its status changes.
+func SetLinkCondition(ctx context.Context, client dynamic.ResourceInterface, name string, condition metav1.Condition) error {
+	u, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	conditions, err := linkConditions(*u)
+	if err != nil {
+		return err
+	}
+	if existing := meta.FindStatusCondition(conditions, condition.Type); existing != nil &&
+		existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message {
+		return nil
+	}
+	meta.SetStatusCondition(&conditions, condition)
+
+	data, err := json.Marshal(conditions)
+	if err != nil {
+		return err
+	}
+	var obj []interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	if err := unstructured.SetNestedSlice(u.Object, obj, "status", "conditions"); err != nil {
+		return err
+	}
+
+	_, err = client.UpdateStatus(ctx, u, metav1.UpdateOptions{})
+	return err
+}
+
 func extractPort(spec corev1.ServiceSpec, portName string) (uint32, error) {
 	for _, p := range spec.Ports {
 		if p.Name == portName {
@@ -270,6 +421,35 @@ func newProbeSpec(obj map[string]interface{}) (ProbeSpec, error) {
 		return ProbeSpec{}, err
 	}
 
+	// the timeout and failure threshold were added later on, so they're
+	// optional for the links created by older versions
+	timeout := DefaultProbeTimeout
+	if _, ok := obj["timeout"]; ok {
+		timeoutStr, err := stringField(obj, "timeout")
+		if err != nil {
+			return ProbeSpec{}, err
+		}
+		timeout, err = time.ParseDuration(timeoutStr)
+		if err != nil {
+			return ProbeSpec{}, err
+		}
+	}
+
+	failureThreshold := uint64(DefaultProbeFailureThreshold)
+	if _, ok := obj["failureThreshold"]; ok {
+		thresholdStr, err := stringField(obj, "failureThreshold")
+		if err != nil {
+			return ProbeSpec{}, err
+		}
+		failureThreshold, err = strconv.ParseUint(thresholdStr, 10, 32)
+		if err != nil {
+			return ProbeSpec{}, err
+		}
+		if failureThreshold == 0 {
+			return ProbeSpec{}, errors.New("Field 'failureThreshold' must be at least 1")
+		}
+	}
+
 	path, err := stringField(obj, "path")
 	if err != nil {
 		return ProbeSpec{}, err
@@ -285,12 +465,40 @@ func newProbeSpec(obj map[string]interface{}) (ProbeSpec, error) {
 	}
 
 	return ProbeSpec{
-		Path:   path,
-		Port:   uint32(port),
-		Period: period,
+		Path:             path,
+		Port:             uint32(port),
+		Period:           period,
+		Timeout:          timeout,
+		FailureThreshold: uint32(failureThreshold),
 	}, nil
 }
 
+func linkConditions(u unstructured.Unstructured) ([]metav1.Condition, error) {
+	obj, ok, err := unstructured.NestedSlice(u.Object, "status", "conditions")
+	if err != nil || !ok {
+		return nil, err
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var conditions []metav1.Condition
+	if err := json.Unmarshal(data, &conditions); err != nil {
+		return nil, err
+	}
+	return conditions, nil
+}
+
+// IsConflictPolicy returns whether the policy is one of ConflictPolicies
+func IsConflictPolicy(policy string) bool {
+	for _, p := range ConflictPolicies {
+		if p == policy {
+			return true
+		}
+	}
+	return false
+}
+
 func stringField(obj map[string]interface{}, key string) (string, error) {
 	value, ok := obj[key]
 	if !ok {
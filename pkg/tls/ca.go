@@ -66,6 +66,13 @@ type (
 	Issuer interface {
 		IssueEndEntityCrt(*x509.CertificateRequest) (Crt, error)
 	}
+
+	// LifetimeIssuer implementors can also sign certificate requests for a
+	// shorter lifetime than the one they're configured with.
+	LifetimeIssuer interface {
+		Issuer
+		IssueEndEntityCrtWithLifetime(*x509.CertificateRequest, time.Duration) (Crt, error)
+	}
 )
 
 const (
@@ -106,7 +113,7 @@ func NewCA(cred Cred, validity Validity) *CA {
 
 func init() {
 	// Assert that the struct implements the interface.
-	var _ Issuer = &CA{}
+	var _ LifetimeIssuer = &CA{}
 }
 
 // CreateRootCA configures a new root CA with the given settings
@@ -204,12 +211,22 @@ func (ca *CA) GenerateEndEntityCred(dnsName string) (*Cred, error) {
 // IssueEndEntityCrt creates a new certificate that is valid for the
 // given DNS name, generating a new keypair for it.
 func (ca *CA) IssueEndEntityCrt(csr *x509.CertificateRequest) (Crt, error) {
+	return ca.IssueEndEntityCrtWithLifetime(csr, 0)
+}
+
+// IssueEndEntityCrtWithLifetime is like IssueEndEntityCrt, the certificate
+// being valid for the given lifetime when it's shorter than the one of the CA
+func (ca *CA) IssueEndEntityCrtWithLifetime(csr *x509.CertificateRequest, lifetime time.Duration) (Crt, error) {
 	pubkey, ok := csr.PublicKey.(*ecdsa.PublicKey)
 	if !ok {
 		return Crt{}, fmt.Errorf("CSR must contain an ECDSA public key: %+v", csr.PublicKey)
 	}
 
-	t := ca.createTemplate(pubkey)
+	validity := ca.Validity
+	if lifetime > 0 && (validity.Lifetime == 0 || lifetime < validity.Lifetime) {
+		validity.Lifetime = lifetime
+	}
+	t := ca.createTemplateWithValidity(pubkey, validity)
 	t.Issuer = ca.Cred.Crt.Certificate.Subject
 	t.Subject = csr.Subject
 	t.Extensions = csr.Extensions
@@ -226,7 +243,11 @@ func (ca *CA) IssueEndEntityCrt(csr *x509.CertificateRequest) (Crt, error) {
 // no subject name, no subjectAltNames. The t can then be modified into
 // a (root) CA t or an end-entity t by the caller.
 func (ca *CA) createTemplate(pubkey *ecdsa.PublicKey) *x509.Certificate {
-	c := createTemplate(ca.nextSerialNumber, pubkey, ca.Validity)
+	return ca.createTemplateWithValidity(pubkey, ca.Validity)
+}
+
+func (ca *CA) createTemplateWithValidity(pubkey *ecdsa.PublicKey, validity Validity) *x509.Certificate {
+	c := createTemplate(ca.nextSerialNumber, pubkey, validity)
 	ca.nextSerialNumber++
 	// if our trust chain contains a certificate that expires
 	// sooner than the one we intend to issue, we clamp the
@@ -1,6 +1,8 @@
 package tls
 
 import (
+	"crypto/rand"
+	"crypto/x509"
 	"testing"
 	"time"
 )
@@ -65,3 +67,58 @@ func TestCaIssuesCertsWithCorrectExpiration(t *testing.T) {
 	}
 
 }
+
+func TestCaIssuesCertsWithLifetime(t *testing.T) {
+	validFrom := time.Now().UTC().Round(time.Second)
+
+	testCases := []struct {
+		desc                   string
+		lifetime               time.Duration
+		expectedCertExpiration time.Time
+	}{
+		{
+			desc:                   "shorter lifetime is applied",
+			lifetime:               time.Hour,
+			expectedCertExpiration: validFrom.Add(time.Hour).Add(DefaultClockSkewAllowance),
+		},
+		{
+			desc:                   "longer lifetime is ignored",
+			lifetime:               time.Hour * 48,
+			expectedCertExpiration: validFrom.Add(time.Hour * 24).Add(DefaultClockSkewAllowance),
+		},
+		{
+			desc:                   "zero lifetime keeps the CA's",
+			expectedCertExpiration: validFrom.Add(time.Hour * 24).Add(DefaultClockSkewAllowance),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.desc, func(t *testing.T) {
+			ca, err := getCa(validFrom, time.Hour*48, time.Hour*24)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			key, err := GenerateKey()
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: []string{"fake-name"}}, key)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			csr, err := x509.ParseCertificateRequest(der)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			crt, err := ca.IssueEndEntityCrtWithLifetime(csr, tc.lifetime)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if crt.Certificate.NotAfter != tc.expectedCertExpiration {
+				t.Fatalf("Expected cert expiration %v but got %v", tc.expectedCertExpiration, crt.Certificate.NotAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/tls"
+	"fmt"
+)
+
+// CryptoMode sets the TLS versions, cipher suites and keys accepted by the
+// control plane components
+type CryptoMode string
+
+const (
+	// CryptoModeDefault accepts what the Go TLS stack accepts by default
+	CryptoModeDefault CryptoMode = "default"
+
+	// CryptoModeStrict only accepts TLS 1.3 with the AES-GCM cipher suites,
+	// and the key exchanges and ECDSA keys on the NIST P-256 and P-384 curves,
+	// as required by FIPS 140-2 compliant deployments
+	CryptoModeStrict CryptoMode = "strict"
+)
+
+// strictCipherSuites are the TLS 1.3 cipher suites accepted in the strict
+// mode, i.e. all of them but ChaCha20-Poly1305
+var strictCipherSuites = map[uint16]struct{}{
+	tls.TLS_AES_128_GCM_SHA256: {},
+	tls.TLS_AES_256_GCM_SHA384: {},
+}
+
+// ParseCryptoMode returns the CryptoMode named mode
+func ParseCryptoMode(mode string) (CryptoMode, error) {
+	switch m := CryptoMode(mode); m {
+	case CryptoModeDefault, CryptoModeStrict:
+		return m, nil
+	}
+	return "", fmt.Errorf("crypto mode must be one of: %s, %s", CryptoModeDefault, CryptoModeStrict)
+}
+
+// String implements flag.Value
+func (m *CryptoMode) String() string {
+	return string(*m)
+}
+
+// Set implements flag.Value
+func (m *CryptoMode) Set(mode string) error {
+	parsed, err := ParseCryptoMode(mode)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// ConfigureServer restricts the config of a TLS listener to what the mode
+// accepts. Go doesn't allow the TLS 1.3 cipher suites to be configured, so in
+// the strict mode the handshakes negotiating another cipher suite are
+// rejected once negotiated.
+func (m CryptoMode) ConfigureServer(config *tls.Config) {
+	if m != CryptoModeStrict {
+		return
+	}
+
+	config.MinVersion = tls.VersionTLS13
+	config.CurvePreferences = []tls.CurveID{tls.CurveP256, tls.CurveP384}
+	verify := config.VerifyConnection
+	config.VerifyConnection = func(cs tls.ConnectionState) error {
+		if _, ok := strictCipherSuites[cs.CipherSuite]; !ok {
+			return fmt.Errorf("cipher suite %s isn't allowed in the %s crypto mode", tls.CipherSuiteName(cs.CipherSuite), m)
+		}
+		if verify != nil {
+			return verify(cs)
+		}
+		return nil
+	}
+}
+
+// CheckPublicKey returns an error if the mode doesn't accept the public key of
+// a certificate or a certificate signing request
+func (m CryptoMode) CheckPublicKey(key interface{}) error {
+	if m != CryptoModeStrict {
+		return nil
+	}
+
+	ecKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("%T keys aren't allowed in the %s crypto mode, only ECDSA ones are", key, m)
+	}
+	switch ecKey.Curve {
+	case elliptic.P256(), elliptic.P384():
+		return nil
+	}
+	return fmt.Errorf("ECDSA keys on the %s curve aren't allowed in the %s crypto mode, only P-256 and P-384 ones are", ecKey.Curve.Params().Name, m)
+}
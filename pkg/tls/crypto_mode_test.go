@@ -0,0 +1,102 @@
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"testing"
+)
+
+func TestParseCryptoMode(t *testing.T) {
+	for mode, valid := range map[string]bool{
+		"default": true,
+		"strict":  true,
+		"":        false,
+		"fips":    false,
+	} {
+		parsed, err := ParseCryptoMode(mode)
+		if valid && (err != nil || string(parsed) != mode) {
+			t.Fatalf("Expected crypto mode %s, got %s (%v)", mode, parsed, err)
+		}
+		if !valid && err == nil {
+			t.Fatalf("Expected an error for crypto mode %q, got %s", mode, parsed)
+		}
+	}
+}
+
+func TestConfigureServer(t *testing.T) {
+	config := &tls.Config{}
+	CryptoModeDefault.ConfigureServer(config)
+	if config.MinVersion != 0 || config.VerifyConnection != nil {
+		t.Fatalf("Expected the default crypto mode to leave the config as is, got %+v", config)
+	}
+
+	verified := false
+	config.VerifyConnection = func(tls.ConnectionState) error {
+		verified = true
+		return nil
+	}
+	CryptoModeStrict.ConfigureServer(config)
+	if config.MinVersion != tls.VersionTLS13 {
+		t.Fatalf("Expected TLS 1.3 to be required, got version %x", config.MinVersion)
+	}
+	if len(config.CurvePreferences) != 2 {
+		t.Fatalf("Expected the key exchanges to be restricted to 2 curves, got %v", config.CurvePreferences)
+	}
+
+	if err := config.VerifyConnection(tls.ConnectionState{CipherSuite: tls.TLS_CHACHA20_POLY1305_SHA256}); err == nil {
+		t.Fatal("Expected ChaCha20-Poly1305 to be rejected")
+	}
+	if verified {
+		t.Fatal("Expected the connection to be rejected before it's verified")
+	}
+	if err := config.VerifyConnection(tls.ConnectionState{CipherSuite: tls.TLS_AES_128_GCM_SHA256}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !verified {
+		t.Fatal("Expected the previous connection verification to be called")
+	}
+}
+
+func TestCheckPublicKey(t *testing.T) {
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	p521, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	testCases := []struct {
+		name   string
+		key    interface{}
+		strict bool
+	}{
+		{"ECDSA P-256", &p256.PublicKey, true},
+		{"ECDSA P-521", &p521.PublicKey, false},
+		{"RSA", &rsaKey.PublicKey, false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			if err := CryptoModeDefault.CheckPublicKey(tc.key); err != nil {
+				t.Fatalf("Expected the default crypto mode to accept the key, got %s", err)
+			}
+			err := CryptoModeStrict.CheckPublicKey(tc.key)
+			if tc.strict && err != nil {
+				t.Fatalf("Expected the strict crypto mode to accept the key, got %s", err)
+			}
+			if !tc.strict && err == nil {
+				t.Fatal("Expected the strict crypto mode to reject the key")
+			}
+		})
+	}
+}
@@ -54,6 +54,34 @@ func ParseContainerOpaquePorts(override string, containers []corev1.Container) [
 	return values
 }
 
+// GetPrefixedContainerPorts returns the ports of the containers whose name
+// starts with the given prefix; none are returned when the prefix is empty.
+func GetPrefixedContainerPorts(prefix string, containers []corev1.Container) map[uint32]struct{} {
+	prefixed := make(map[uint32]struct{})
+	if prefix == "" {
+		return prefixed
+	}
+	for _, c := range containers {
+		for _, p := range c.Ports {
+			if strings.HasPrefix(p.Name, prefix) {
+				prefixed[uint32(p.ContainerPort)] = struct{}{}
+			}
+		}
+	}
+	return prefixed
+}
+
+// MergePorts returns a new map holding the ports of all the given maps
+func MergePorts(portSets ...map[uint32]struct{}) map[uint32]struct{} {
+	merged := make(map[uint32]struct{})
+	for _, ports := range portSets {
+		for port := range ports {
+			merged[port] = struct{}{}
+		}
+	}
+	return merged
+}
+
 // GetPortRanges gets port ranges from an override annotation
 func GetPortRanges(override string) []string {
 	return strings.Split(strings.TrimSuffix(override, ","), ",")
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestParsePorts(t *testing.T) {
@@ -55,3 +57,51 @@ func TestParsePorts(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPrefixedContainerPorts(t *testing.T) {
+	containers := []corev1.Container{
+		{
+			Ports: []corev1.ContainerPort{
+				{Name: "opaque-mysql", ContainerPort: 3306},
+				{Name: "http", ContainerPort: 8080},
+			},
+		},
+		{
+			Ports: []corev1.ContainerPort{
+				{Name: "opaque-redis", ContainerPort: 6379},
+				{ContainerPort: 9090},
+			},
+		},
+	}
+
+	testCases := []struct {
+		prefix string
+		result map[uint32]struct{}
+	}{
+		{
+			"opaque-",
+			map[uint32]struct{}{
+				3306: {},
+				6379: {},
+			},
+		},
+		{
+			"tcp-",
+			map[uint32]struct{}{},
+		},
+		{
+			"",
+			map[uint32]struct{}{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("test %q", tc.prefix), func(t *testing.T) {
+			ports := GetPrefixedContainerPorts(tc.prefix, containers)
+			if !reflect.DeepEqual(ports, tc.result) {
+				t.Fatalf("Expected output: \"%v\", got: \"%v\"", tc.result, ports)
+			}
+		})
+	}
+}
@@ -441,20 +441,41 @@ impl PodPorts {
     /// Finds all ports on this pod that match a server's port reference.
     ///
     /// Numeric port matches will only return a single server, generally, while named port
-    /// references may select an arbitrary number of server ports.
+    /// references and port ranges may select an arbitrary number of server ports.
     fn collect_port(&self, port_match: &policy::server::Port) -> Vec<u16> {
         match port_match {
             policy::server::Port::Number(ref port) => vec![*port],
-            policy::server::Port::Name(ref name) => {
-                self.by_name.get(name).cloned().unwrap_or_default()
-            }
+            policy::server::Port::Name(ref name) => match self.by_name.get(name) {
+                Some(ports) => ports.clone(),
+                None => match parse_port_range(name) {
+                    Some((floor, ceil)) => self
+                        .by_port
+                        .keys()
+                        .copied()
+                        .filter(|p| (floor..=ceil).contains(p))
+                        .collect(),
+                    None => vec![],
+                },
+            },
         }
     }
 }
 
+/// Parses a range of port numbers, e.g. `4000-4100`, that a server may reference in place of a
+/// port name. A single port number is a range of one port.
+fn parse_port_range(s: &str) -> Option<(u16, u16)> {
+    let (floor, ceil) = s.split_once('-').unwrap_or((s, s));
+    let floor = floor.parse::<u16>().ok()?;
+    let ceil = ceil.parse::<u16>().ok()?;
+    if floor == 0 || floor > ceil {
+        return None;
+    }
+    Some((floor, ceil))
+}
+
 #[cfg(test)]
 mod tests {
-    use super::PodAnnotations;
+    use super::{parse_port_range, PodAnnotations};
 
     #[test]
     fn parse_portset() {
@@ -482,4 +503,19 @@ mod tests {
         assert!(PodAnnotations::parse_portset("2-").is_err(), "2-");
         assert!(PodAnnotations::parse_portset("65537").is_err(), "65537");
     }
+
+    #[test]
+    fn port_range() {
+        assert_eq!(
+            parse_port_range("4000-4100"),
+            Some((4000, 4100)),
+            "4000-4100"
+        );
+        assert_eq!(parse_port_range("4000"), Some((4000, 4000)), "4000");
+        assert_eq!(parse_port_range("http"), None, "http");
+        assert_eq!(parse_port_range("0-10"), None, "0-10");
+        assert_eq!(parse_port_range("4100-4000"), None, "4100-4000");
+        assert_eq!(parse_port_range("4000-"), None, "4000-");
+        assert_eq!(parse_port_range("4000-65536"), None, "4000-65536");
+    }
 }
@@ -7,6 +7,7 @@ import (
 	"crypto/x509/pkix"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -24,9 +25,22 @@ const (
 	envDisabled     = "LINKERD2_PROXY_IDENTITY_DISABLED"
 	envLocalName    = "LINKERD2_PROXY_IDENTITY_LOCAL_NAME"
 	envTrustAnchors = "LINKERD2_PROXY_IDENTITY_TRUST_ANCHORS"
+
+	// envAwaitInstallPath is set by the injector on the init container that
+	// copies linkerd-await to a volume shared with the application containers,
+	// in which case the proxy isn't run
+	envAwaitInstallPath = "LINKERD2_AWAIT_INSTALL_PATH"
+	awaitPath           = "/usr/lib/linkerd/linkerd-await"
 )
 
 func main() {
+	if dst := os.Getenv(envAwaitInstallPath); dst != "" {
+		if err := installAwait(dst); err != nil {
+			log.Fatalf("Failed to install linkerd-await: %s", err)
+		}
+		return
+	}
+
 	defer runProxy()
 
 	if os.Getenv(envDisabled) != "" {
@@ -151,6 +165,26 @@ func generateAndStoreCSR(p, id string, key *ecdsa.PrivateKey) ([]byte, error) {
 	return csrb, nil
 }
 
+// installAwait copies the linkerd-await binary to dst, executable by all the
+// users, as the application containers may run as any of them
+func installAwait(dst string) error {
+	src, err := os.Open(awaitPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, src); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
 func runProxy() {
 	err := syscall.Exec("/usr/lib/linkerd/linkerd2-proxy", []string{}, os.Environ())
 	if err != nil {
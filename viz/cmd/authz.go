@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,20 +12,42 @@ import (
 	pkgcmd "github.com/linkerd/linkerd2/pkg/cmd"
 	"github.com/linkerd/linkerd2/pkg/healthcheck"
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
 	"github.com/linkerd/linkerd2/viz/metrics-api/util"
 	"github.com/linkerd/linkerd2/viz/pkg/api"
+	pkgUtil "github.com/linkerd/linkerd2/viz/pkg/util"
 	"github.com/spf13/cobra"
 )
 
+// authzSimulationOptions describe the client of the request whose
+// authorization is simulated with `authz --simulate`
+type authzSimulationOptions struct {
+	simulate bool
+	identity string
+	clientIP string
+	port     string
+}
+
 // NewCmdAuthz creates a new cobra command `authz`
 func NewCmdAuthz() *cobra.Command {
 	options := newStatOptions()
+	simulation := authzSimulationOptions{}
 
 	cmd := &cobra.Command{
 		Use:   "authz [flags] resource",
 		Short: "Display stats for server authorizations for a resource",
-		Long:  "Display stats for server authorizations for a resource.",
-		Args:  cobra.MinimumNArgs(1),
+		Long: `Display stats for server authorizations for a resource.
+
+With --simulate, the authorization of a request sent to the resource is
+evaluated instead, reporting whether it would be allowed and by which server
+authorization.`,
+		Example: `  # Display the stats of the server authorizations of the emoji deployment.
+  linkerd viz authz -n emojivoto deploy/emoji
+
+  # Check whether the web service account may call the grpc port of emoji.
+  linkerd viz authz -n emojivoto deploy/emoji --simulate --port grpc \
+    --identity web.emojivoto.serviceaccount.identity.linkerd.cluster.local`,
+		Args: cobra.MinimumNArgs(1),
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 
 			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
@@ -72,6 +95,33 @@ func NewCmdAuthz() *cobra.Command {
 				resource = args[0] + "/" + args[1]
 			}
 
+			if simulation.simulate {
+				target, err := pkgUtil.BuildResource(options.namespace, resource)
+				if err != nil {
+					return err
+				}
+				data, err := simulateAuthz(client, target, simulation)
+				if err != nil {
+					fmt.Fprint(os.Stderr, err.Error())
+					os.Exit(1)
+				}
+				return renderAuthzTable(data, options.outputFormat)
+			}
+
+			// the stats can be narrowed down to the traffic sent to a workload
+			// selected by the servers
+			toNamespace := options.namespace
+			if options.toNamespace != "" {
+				toNamespace = options.toNamespace
+			}
+			var toRes *pb.Resource
+			if options.toResource != "" {
+				toRes, err = pkgUtil.BuildResource(toNamespace, options.toResource)
+				if err != nil {
+					return err
+				}
+			}
+
 			cols := []table.Column{
 				table.NewColumn("SERVER").WithLeftAlign(),
 				table.NewColumn("AUTHZ").WithLeftAlign(),
@@ -103,10 +153,13 @@ func NewCmdAuthz() *cobra.Command {
 							Namespace:     options.namespace,
 							AllNamespaces: false,
 						},
-						ToNamespace: options.namespace,
+						ToNamespace: toNamespace,
+						ServerName:  server,
+					}
+					if toRes != nil {
+						requestParams.ToName = toRes.Name
+						requestParams.ToType = toRes.Type
 					}
-					requestParams.ToName = server
-					requestParams.ToType = k8s.Server
 
 					req, err := util.BuildStatSummaryRequest(requestParams)
 					if err != nil {
@@ -152,9 +205,13 @@ func NewCmdAuthz() *cobra.Command {
 						Namespace:     options.namespace,
 						AllNamespaces: false,
 					},
-					ToNamespace:   options.namespace,
+					ToNamespace:   toNamespace,
 					LabelSelector: options.labelSelector,
 				}
+				if toRes != nil {
+					requestParams.ToName = toRes.Name
+					requestParams.ToType = toRes.Type
+				}
 
 				req, err := util.BuildStatSummaryRequest(requestParams)
 				if err != nil {
@@ -180,18 +237,7 @@ func NewCmdAuthz() *cobra.Command {
 				}
 			}
 
-			data := table.NewTable(cols, rows)
-			if options.outputFormat == "json" {
-				err = renderJSON(data, os.Stdout)
-				if err != nil {
-					fmt.Fprint(os.Stderr, err.Error())
-					os.Exit(1)
-				}
-			} else {
-				data.Render(os.Stdout)
-			}
-
-			return nil
+			return renderAuthzTable(table.NewTable(cols, rows), options.outputFormat)
 		},
 	}
 
@@ -199,13 +245,60 @@ func NewCmdAuthz() *cobra.Command {
 	cmd.PersistentFlags().StringVarP(&options.timeWindow, "time-window", "t", options.timeWindow, "Stat window (for example: \"15s\", \"1m\", \"10m\", \"1h\"). Needs to be at least 15s.")
 	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; one of: \"table\" or \"json\" or \"wide\"")
 	cmd.PersistentFlags().StringVarP(&options.labelSelector, "selector", "l", options.labelSelector, "Selector (label query) to filter on, supports '=', '==', and '!='")
+	cmd.PersistentFlags().StringVar(&options.toResource, "to", options.toResource, "If present, restricts the stats to the traffic sent to the specified workload (for example: \"deploy/web\")")
+	cmd.PersistentFlags().StringVar(&options.toNamespace, "to-namespace", options.toNamespace, "Sets the namespace used to lookup the \"--to\" resource; by default the current \"--namespace\" is used")
+	cmd.PersistentFlags().BoolVar(&simulation.simulate, "simulate", simulation.simulate, "If present, evaluate whether a request sent to the resource would be authorized instead of displaying stats")
+	cmd.PersistentFlags().StringVar(&simulation.identity, "identity", simulation.identity, "Identity of the client of the simulated request; an unmeshed client is simulated when empty")
+	cmd.PersistentFlags().StringVar(&simulation.clientIP, "client-ip", simulation.clientIP, "IP of the client of the simulated request; the client is assumed to be in the authorized networks when empty")
+	cmd.PersistentFlags().StringVar(&simulation.port, "port", simulation.port, "Number or name of the port the simulated request is sent to, required unless the resource is a server")
 
 	pkgcmd.ConfigureNamespaceFlagCompletion(
-		cmd, []string{"namespace"},
+		cmd, []string{"namespace", "to-namespace"},
 		kubeconfigPath, impersonate, impersonateGroup, kubeContext)
 	return cmd
 }
 
+// simulateAuthz evaluates the authorization of a request sent to the target
+func simulateAuthz(client pb.ApiClient, target *pb.Resource, options authzSimulationOptions) (table.Table, error) {
+	rsp, err := client.Authz(context.Background(), &pb.AuthzRequest{
+		ClientIdentity: options.identity,
+		ClientIp:       options.clientIP,
+		Target:         target,
+		Port:           options.port,
+	})
+	if err != nil {
+		return table.Table{}, fmt.Errorf("Authz API error: %w", err)
+	}
+	if e := rsp.GetError(); e != nil {
+		return table.Table{}, fmt.Errorf("Authz API response error: %v", e.Error)
+	}
+
+	cols := []table.Column{
+		table.NewColumn("SERVER").WithLeftAlign(),
+		table.NewColumn("AUTHZ").WithLeftAlign(),
+		table.NewColumn("RESULT").WithLeftAlign(),
+	}
+	authz, result := "-", "denied"
+	if rsp.GetOk().GetAllowed() {
+		authz, result = rsp.GetOk().GetAuthorization().GetName(), "allowed"
+	}
+	rows := []table.Row{{rsp.GetOk().GetServer().GetName(), authz, result}}
+	return table.NewTable(cols, rows), nil
+}
+
+func renderAuthzTable(data table.Table, outputFormat string) error {
+	if outputFormat == jsonOutput {
+		err := renderJSON(data, os.Stdout)
+		if err != nil {
+			fmt.Fprint(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	} else {
+		data.Render(os.Stdout)
+	}
+	return nil
+}
+
 func renderJSON(t table.Table, w io.Writer) error {
 	rows := make([]map[string]interface{}, len(t.Data))
 	for i, data := range t.Data {
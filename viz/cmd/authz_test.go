@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/linkerd/linkerd2/cli/table"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	api "github.com/linkerd/linkerd2/viz/metrics-api"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+)
+
+func TestSimulateAuthz(t *testing.T) {
+	server := &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Server, Name: "emoji-grpc"}
+
+	testCases := []struct {
+		name     string
+		ok       *pb.AuthzResponse_Ok
+		expected table.Row
+	}{
+		{
+			name: "allowed",
+			ok: &pb.AuthzResponse_Ok{
+				Server:  server,
+				Allowed: true,
+				Authorization: &pb.Resource{
+					Namespace: "emojivoto",
+					Type:      pkgK8s.ServerAuthorization,
+					Name:      "emoji-grpc",
+				},
+			},
+			expected: table.Row{"emoji-grpc", "emoji-grpc", "allowed"},
+		},
+		{
+			name:     "denied",
+			ok:       &pb.AuthzResponse_Ok{Server: server},
+			expected: table.Row{"emoji-grpc", "-", "denied"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := &api.MockAPIClient{
+				AuthzResponseToReturn: &pb.AuthzResponse{
+					Response: &pb.AuthzResponse_Ok_{Ok: tc.ok},
+				},
+			}
+
+			data, err := simulateAuthz(mockClient, &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Deployment, Name: "emoji"}, authzSimulationOptions{
+				simulate: true,
+				identity: "web.emojivoto.serviceaccount.identity.linkerd.cluster.local",
+				port:     "grpc",
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if len(data.Data) != 1 || !reflect.DeepEqual(data.Data[0], tc.expected) {
+				t.Fatalf("Expected row %v, got %v", tc.expected, data.Data)
+			}
+		})
+	}
+}
@@ -0,0 +1,433 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/linkerd/linkerd2/viz/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+const (
+	statsAPIPath = "/api/tps-reports"
+
+	overviewFile = "index.html"
+
+	htmlFormat = "html"
+	pngFormat  = "png"
+)
+
+type dashboardsExportOptions struct {
+	namespace    string
+	resourceType string
+	timeWindow   string
+	outputDir    string
+	format       string
+	wait         time.Duration
+}
+
+// dashboardView holds the data backing a single exported HTML page
+type dashboardView struct {
+	Title       string
+	Description string
+	Generated   string
+	TimeWindow  string
+	Sections    []dashboardSection
+}
+
+type dashboardSection struct {
+	Title string
+	Rows  []dashboardRow
+}
+
+type dashboardRow struct {
+	Name    string
+	Link    string
+	Meshed  string
+	Success string
+	RPS     string
+	P50     string
+	P95     string
+	P99     string
+}
+
+var dashboardTemplate = template.Must(template.New("dashboard").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Linkerd - {{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #333; }
+table { border-collapse: collapse; margin-bottom: 2em; min-width: 60%; }
+th, td { border-bottom: 1px solid #ddd; padding: 6px 12px; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+th { background: #f4f4f4; }
+.meta { color: #777; font-size: 0.9em; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{.Description}}</p>
+<p class="meta">Time window: {{.TimeWindow}} &middot; Generated: {{.Generated}}</p>
+{{range .Sections}}<h2>{{.Title}}</h2>
+{{if .Rows}}<table>
+<tr><th>Name</th><th>Meshed</th><th>Success</th><th>RPS</th><th>P50</th><th>P95</th><th>P99</th></tr>
+{{range .Rows}}<tr><td>{{if .Link}}<a href="{{.Link}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}</td><td>{{.Meshed}}</td><td>{{.Success}}</td><td>{{.RPS}}</td><td>{{.P50}}</td><td>{{.P95}}</td><td>{{.P99}}</td></tr>
+{{end}}</table>
+{{else}}<p>No traffic found.</p>
+{{end}}{{end}}</body>
+</html>
+`))
+
+func newDashboardsExportOptions() *dashboardsExportOptions {
+	return &dashboardsExportOptions{
+		resourceType: k8s.Deployment,
+		timeWindow:   "1m",
+		outputDir:    "linkerd-dashboards",
+		format:       htmlFormat,
+		wait:         300 * time.Second,
+	}
+}
+
+// newCmdDashboards creates a new cobra command `dashboards` grouping the
+// commands that produce dashboards without requiring a browser
+func newCmdDashboards() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dashboards",
+		Short: "Produce dashboards for environments without browser access to the cluster",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newCmdDashboardsExport())
+	cmd.AddCommand(newCmdDashboardsGrafana())
+
+	return cmd
+}
+
+func newCmdDashboardsExport() *cobra.Command {
+	options := newDashboardsExportOptions()
+
+	cmd := &cobra.Command{
+		Use:   "export [flags]",
+		Short: "Render the Linkerd dashboard views to static HTML or PNG files",
+		Long: `Render the Linkerd dashboard views to static HTML or PNG files.
+
+The overview, per-namespace and per-workload views are rendered from the data
+served by the web API, so that a shareable snapshot of the mesh health can be
+produced from headless or air-gapped environments. Only the meshed namespaces
+and workloads get a view of their own.`,
+		Example: `  # Export the views for all namespaces into the ./linkerd-dashboards directory
+  linkerd viz dashboards export
+
+  # Export the views for statefulsets in the emojivoto namespace
+  linkerd viz dashboards export -n emojivoto --resource statefulset --output-dir /tmp/emojivoto
+
+  # Export the views as PNG images
+  linkerd viz dashboards export --format png`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+
+			// ensure we can connect to the viz API before starting the proxy
+			api.CheckClientOrRetryOrExit(healthcheck.Options{
+				ControlPlaneNamespace: controlPlaneNamespace,
+				KubeConfig:            kubeconfigPath,
+				Impersonate:           impersonate,
+				ImpersonateGroup:      impersonateGroup,
+				KubeContext:           kubeContext,
+				APIAddr:               apiAddr,
+				RetryDeadline:         time.Now().Add(options.wait),
+			}, true)
+
+			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return err
+			}
+
+			vizNs, err := k8sAPI.GetNamespaceWithExtensionLabel(cmd.Context(), ExtensionName)
+			if err != nil {
+				return err
+			}
+
+			portforward, err := k8s.NewPortForward(
+				cmd.Context(),
+				k8sAPI,
+				vizNs.Name,
+				webDeployment,
+				defaultHost,
+				0,
+				webPort,
+				verbose,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to initialize port-forward: %s", err)
+			}
+			if err = portforward.Init(); err != nil {
+				return fmt.Errorf("error running port-forward: %s", err)
+			}
+			defer portforward.Stop()
+
+			files, err := exportDashboards(cmd.Context(), http.DefaultClient, portforward.URLFor(""), options)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Exported %d dashboard views to %s\n", len(files), filepath.Join(options.outputDir, options.fileName(overviewFile)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Only export the views for this namespace (all namespaces by default)")
+	cmd.Flags().StringVar(&options.resourceType, "resource", options.resourceType, "Type of the workloads listed in the namespace views")
+	cmd.Flags().StringVarP(&options.timeWindow, "time-window", "t", options.timeWindow, "Stat window (for example: \"15s\", \"1m\", \"10m\", \"1h\"). Needs to be at least 15s.")
+	cmd.Flags().StringVar(&options.outputDir, "output-dir", options.outputDir, "Directory the files are written to")
+	cmd.Flags().StringVar(&options.format, "format", options.format, fmt.Sprintf("Format of the views; one of: \"%s\" or \"%s\"", htmlFormat, pngFormat))
+	cmd.Flags().DurationVar(&options.wait, "wait", options.wait, "Wait for the dashboard to become available if it's not available when the command is run")
+
+	return cmd
+}
+
+func (o *dashboardsExportOptions) validate() error {
+	if o.format != htmlFormat && o.format != pngFormat {
+		return fmt.Errorf("--format must be one of: %s, %s", htmlFormat, pngFormat)
+	}
+	return nil
+}
+
+// fileName returns the name of the file of a view in the export format
+func (o *dashboardsExportOptions) fileName(name string) string {
+	return strings.TrimSuffix(name, filepath.Ext(name)) + "." + o.format
+}
+
+// exportDashboards queries the web API served at baseURL and writes the
+// overview, namespace and workload views into options.outputDir, returning
+// the paths of the written files
+func exportDashboards(ctx context.Context, client *http.Client, baseURL string, options *dashboardsExportOptions) ([]string, error) {
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+	resourceType, err := k8s.CanonicalResourceNameFromFriendlyName(options.resourceType)
+	if err != nil {
+		return nil, err
+	}
+	pluralType, err := k8s.PluralResourceNameFromFriendlyName(resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(options.outputDir, 0755); err != nil {
+		return nil, err
+	}
+
+	generated := time.Now().UTC().Format(time.RFC3339)
+	newView := func(title, description string) *dashboardView {
+		return &dashboardView{
+			Title:       title,
+			Description: description,
+			Generated:   generated,
+			TimeWindow:  options.timeWindow,
+		}
+	}
+
+	nsParams := url.Values{"resource_type": {k8s.Namespace}}
+	if options.namespace != "" {
+		nsParams.Set("resource_name", options.namespace)
+	} else {
+		nsParams.Set("all_namespaces", "true")
+	}
+	nsRows, err := fetchStatRows(ctx, client, baseURL, options.timeWindow, nsParams)
+	if err != nil {
+		return nil, err
+	}
+
+	overview := newView("Overview", "Traffic stats for the namespaces; the unmeshed ones have no view of their own.")
+	overview.Sections = []dashboardSection{{Title: "Namespaces", Rows: toDashboardRows(nsRows, namespaceViewFile)}}
+
+	files := []string{}
+	write := func(name string, view *dashboardView) error {
+		path := filepath.Join(options.outputDir, options.fileName(name))
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		render := renderDashboardView
+		if options.format == pngFormat {
+			render = renderDashboardPNG
+		}
+		if err := render(f, view); err != nil {
+			return err
+		}
+		files = append(files, path)
+		return nil
+	}
+
+	for _, ns := range nsRows {
+		if ns.GetMeshedPodCount() == 0 {
+			continue
+		}
+		namespace := ns.GetResource().GetName()
+
+		rows, err := fetchStatRows(ctx, client, baseURL, options.timeWindow, url.Values{
+			"resource_type": {resourceType},
+			"namespace":     {namespace},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		nsView := newView(fmt.Sprintf("Namespace %s", namespace), fmt.Sprintf("Traffic stats for the %s in the %s namespace.", pluralType, namespace))
+		nsView.Sections = []dashboardSection{{Title: strings.ToUpper(pluralType[:1]) + pluralType[1:], Rows: toDashboardRows(rows, workloadViewFile)}}
+		if err := write(namespaceViewFile(ns.GetResource()), nsView); err != nil {
+			return nil, err
+		}
+
+		for _, workload := range rows {
+			if workload.GetMeshedPodCount() == 0 {
+				continue
+			}
+			res := workload.GetResource()
+
+			inbound, err := fetchStatRows(ctx, client, baseURL, options.timeWindow, url.Values{
+				"resource_type": {k8s.All},
+				"namespace":     {namespace},
+				"to_type":       {res.GetType()},
+				"to_name":       {res.GetName()},
+				"to_namespace":  {namespace},
+			})
+			if err != nil {
+				return nil, err
+			}
+			outbound, err := fetchStatRows(ctx, client, baseURL, options.timeWindow, url.Values{
+				"resource_type":  {k8s.All},
+				"namespace":      {namespace},
+				"from_type":      {res.GetType()},
+				"from_name":      {res.GetName()},
+				"from_namespace": {namespace},
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			wlView := newView(fmt.Sprintf("%s/%s", res.GetType(), res.GetName()), fmt.Sprintf("Traffic stats for %s/%s in the %s namespace.", res.GetType(), res.GetName(), namespace))
+			wlView.Sections = []dashboardSection{
+				{Title: "Stats", Rows: toDashboardRows([]*pb.StatTable_PodGroup_Row{workload}, nil)},
+				{Title: "Inbound", Rows: toDashboardRows(inbound, nil)},
+				{Title: "Outbound", Rows: toDashboardRows(outbound, nil)},
+			}
+			if err := write(workloadViewFile(res), wlView); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := write(overviewFile, overview); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func fetchStatRows(ctx context.Context, client *http.Client, baseURL, timeWindow string, params url.Values) ([]*pb.StatTable_PodGroup_Row, error) {
+	params.Set("window", timeWindow)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s?%s", strings.TrimSuffix(baseURL, "/"), statsAPIPath, params.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(rsp.Body)
+		return nil, fmt.Errorf("web API returned %s: %s", rsp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var statRsp pb.StatSummaryResponse
+	if err := (&jsonpb.Unmarshaler{AllowUnknownFields: true}).Unmarshal(rsp.Body, &statRsp); err != nil {
+		return nil, err
+	}
+	if e := statRsp.GetError(); e != nil {
+		return nil, fmt.Errorf("StatSummary API response error: %v", e.Error)
+	}
+
+	return respToRows(&statRsp), nil
+}
+
+func toDashboardRows(rows []*pb.StatTable_PodGroup_Row, link func(*pb.Resource) string) []dashboardRow {
+	sorted := make([]*pb.StatTable_PodGroup_Row, len(rows))
+	copy(sorted, rows)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].GetResource().GetType()+"/"+sorted[i].GetResource().GetName() <
+			sorted[j].GetResource().GetType()+"/"+sorted[j].GetResource().GetName()
+	})
+
+	// prefix the names with their type when the rows mix several resource
+	// types, as in `linkerd viz stat`
+	types := make(map[string]bool)
+	for _, r := range sorted {
+		types[r.GetResource().GetType()] = true
+	}
+
+	out := make([]dashboardRow, 0, len(sorted))
+	for _, r := range sorted {
+		row := dashboardRow{
+			Name:    r.GetResource().GetName(),
+			Meshed:  fmt.Sprintf("%d/%d", r.GetMeshedPodCount(), r.GetRunningPodCount()),
+			Success: "-",
+			RPS:     "-",
+			P50:     "-",
+			P95:     "-",
+			P99:     "-",
+		}
+		if len(types) > 1 {
+			row.Name = getNamePrefix(r.GetResource().GetType()) + row.Name
+		}
+		if !isPodOwnerResource(r.GetResource().GetType()) {
+			row.Meshed = "-"
+		}
+		// only the meshed resources get a view of their own to link to
+		if link != nil && r.GetMeshedPodCount() > 0 {
+			row.Link = link(r.GetResource())
+		}
+		if stats := r.GetStats(); statHasRequestData(stats) {
+			row.Success = fmt.Sprintf("%.2f%%", getSuccessRate(stats.GetSuccessCount(), stats.GetFailureCount())*100)
+			row.RPS = fmt.Sprintf("%.1frps", getRequestRate(stats.GetSuccessCount(), stats.GetFailureCount(), r.GetTimeWindow()))
+			row.P50 = fmt.Sprintf("%dms", stats.GetLatencyMsP50())
+			row.P95 = fmt.Sprintf("%dms", stats.GetLatencyMsP95())
+			row.P99 = fmt.Sprintf("%dms", stats.GetLatencyMsP99())
+		}
+		out = append(out, row)
+	}
+	return out
+}
+
+func renderDashboardView(w io.Writer, view *dashboardView) error {
+	return dashboardTemplate.Execute(w, view)
+}
+
+func namespaceViewFile(res *pb.Resource) string {
+	return fmt.Sprintf("namespace-%s.html", res.GetName())
+}
+
+func workloadViewFile(res *pb.Resource) string {
+	return fmt.Sprintf("%s-%s-%s.html", res.GetNamespace(), res.GetType(), res.GetName())
+}
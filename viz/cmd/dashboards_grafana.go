@@ -0,0 +1,390 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/linkerd/linkerd2/viz/metrics-api/util"
+	"github.com/linkerd/linkerd2/viz/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+const (
+	grafanaPanelWidth  = 8
+	grafanaPanelHeight = 8
+	// grafanaRateWindow is the window of the rates graphed by the panels
+	grafanaRateWindow = "1m"
+)
+
+type dashboardsGrafanaOptions struct {
+	namespace    string
+	resourceType string
+	datasource   string
+	outputDir    string
+}
+
+// grafanaDashboard is the subset of the Grafana dashboard JSON model the
+// generated dashboards rely on
+type grafanaDashboard struct {
+	UID           string           `json:"uid"`
+	Title         string           `json:"title"`
+	Tags          []string         `json:"tags"`
+	Editable      bool             `json:"editable"`
+	SchemaVersion int              `json:"schemaVersion"`
+	Refresh       string           `json:"refresh"`
+	Time          grafanaTimeRange `json:"time"`
+	Panels        []grafanaPanel   `json:"panels"`
+}
+
+type grafanaTimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type grafanaPanel struct {
+	ID          int                 `json:"id"`
+	Type        string              `json:"type"`
+	Title       string              `json:"title"`
+	Datasource  string              `json:"datasource,omitempty"`
+	GridPos     grafanaGridPos      `json:"gridPos"`
+	FieldConfig *grafanaFieldConfig `json:"fieldConfig,omitempty"`
+	Targets     []grafanaTarget     `json:"targets,omitempty"`
+}
+
+type grafanaGridPos struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+type grafanaFieldConfig struct {
+	Defaults grafanaFieldDefaults `json:"defaults"`
+}
+
+type grafanaFieldDefaults struct {
+	Unit string `json:"unit"`
+}
+
+type grafanaTarget struct {
+	RefID        string `json:"refId"`
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat"`
+}
+
+// grafanaGraph describes a time series panel, before it's laid out
+type grafanaGraph struct {
+	title   string
+	unit    string
+	targets []grafanaTarget
+}
+
+func newDashboardsGrafanaOptions() *dashboardsGrafanaOptions {
+	return &dashboardsGrafanaOptions{
+		resourceType: k8s.Deployment,
+		datasource:   "prometheus",
+		outputDir:    "linkerd-grafana-dashboards",
+	}
+}
+
+func newCmdDashboardsGrafana() *cobra.Command {
+	options := newDashboardsGrafanaOptions()
+
+	cmd := &cobra.Command{
+		Use:   "grafana [flags]",
+		Short: "Generate Grafana dashboards for the meshed namespaces and workloads",
+		Long: `Generate Grafana dashboards for the meshed namespaces and workloads.
+
+A dashboard is generated for each namespace with meshed workloads, graphing the
+success rate, request rate and latency of each of them, along with a dashboard
+for each of the workloads. The dashboards are generated from the workloads
+currently running, and query the given Prometheus datasource, so that they can
+be imported in a Grafana instance other than the one bundled with linkerd-viz.`,
+		Example: `  # Generate the dashboards of the deployments of all the namespaces into the
+  # ./linkerd-grafana-dashboards directory
+  linkerd viz dashboards grafana
+
+  # Generate the dashboards of the statefulsets of the emojivoto namespace,
+  # querying the "mesh-metrics" datasource
+  linkerd viz dashboards grafana -n emojivoto --resource statefulset --datasource mesh-metrics`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.CheckClientOrExit(healthcheck.Options{
+				ControlPlaneNamespace: controlPlaneNamespace,
+				KubeConfig:            kubeconfigPath,
+				Impersonate:           impersonate,
+				ImpersonateGroup:      impersonateGroup,
+				KubeContext:           kubeContext,
+				APIAddr:               apiAddr,
+			})
+
+			files, err := generateGrafanaDashboards(cmd.Context(), client, options)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Generated %d Grafana dashboards in %s\n", len(files), options.outputDir)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Only generate the dashboards of this namespace (all namespaces by default)")
+	cmd.Flags().StringVar(&options.resourceType, "resource", options.resourceType, "Type of the workloads the dashboards are generated for")
+	cmd.Flags().StringVar(&options.datasource, "datasource", options.datasource, "Name of the Grafana datasource of the Prometheus instance scraping the proxies")
+	cmd.Flags().StringVar(&options.outputDir, "output-dir", options.outputDir, "Directory the JSON files are written to")
+
+	return cmd
+}
+
+// generateGrafanaDashboards lists the meshed workloads through the metrics
+// API and writes their dashboards, along with the ones of their namespaces,
+// into options.outputDir, returning the paths of the written files
+func generateGrafanaDashboards(ctx context.Context, client pb.ApiClient, options *dashboardsGrafanaOptions) ([]string, error) {
+	if options.datasource == "" {
+		return nil, fmt.Errorf("--datasource is required")
+	}
+
+	req, err := util.BuildStatSummaryRequest(util.StatsSummaryRequestParams{
+		StatsBaseRequestParams: util.StatsBaseRequestParams{
+			ResourceType:  options.resourceType,
+			Namespace:     options.namespace,
+			AllNamespaces: options.namespace == "",
+		},
+		SkipStats: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch typ := req.GetSelector().GetResource().GetType(); {
+	case typ == k8s.All, typ == k8s.Namespace, !isPodOwnerResource(typ):
+		return nil, fmt.Errorf("--resource must be a workload resource")
+	}
+
+	resp, err := client.StatSummary(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("StatSummary API error: %v", err)
+	}
+	if e := resp.GetError(); e != nil {
+		return nil, fmt.Errorf("StatSummary API response error: %v", e.Error)
+	}
+
+	workloads := make(map[string][]*pb.Resource)
+	for _, row := range respToRows(resp) {
+		if row.GetMeshedPodCount() == 0 {
+			continue
+		}
+		res := row.GetResource()
+		workloads[res.GetNamespace()] = append(workloads[res.GetNamespace()], res)
+	}
+	namespaces := make([]string, 0, len(workloads))
+	for ns := range workloads {
+		namespaces = append(namespaces, ns)
+		sort.Slice(workloads[ns], func(i, j int) bool { return workloads[ns][i].GetName() < workloads[ns][j].GetName() })
+	}
+	sort.Strings(namespaces)
+
+	if err := os.MkdirAll(options.outputDir, 0755); err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	write := func(name string, dashboard *grafanaDashboard) error {
+		out, err := json.MarshalIndent(dashboard, "", "  ")
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(options.outputDir, name)
+		if err := os.WriteFile(path, append(out, '\n'), 0644); err != nil {
+			return err
+		}
+		files = append(files, path)
+		return nil
+	}
+
+	for _, ns := range namespaces {
+		if err := write(fmt.Sprintf("namespace-%s.json", ns), namespaceGrafanaDashboard(ns, workloads[ns], options.datasource)); err != nil {
+			return nil, err
+		}
+		for _, res := range workloads[ns] {
+			if err := write(fmt.Sprintf("%s-%s-%s.json", ns, res.GetType(), res.GetName()), workloadGrafanaDashboard(res, options.datasource)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// namespaceGrafanaDashboard graphs the stats of all the workloads of a
+// namespace, followed by a row of graphs for each of them
+func namespaceGrafanaDashboard(namespace string, workloads []*pb.Resource, datasource string) *grafanaDashboard {
+	label := k8s.KindToL5DLabel(workloads[0].GetType())
+	selector := fmt.Sprintf(`namespace="%s", %s!="", direction="inbound"`, namespace, label)
+	legend := fmt.Sprintf("{{%s}}", label)
+
+	dashboard := newGrafanaDashboard(fmt.Sprintf("namespace/%s", namespace), fmt.Sprintf("Linkerd namespace/%s", namespace), namespace)
+	dashboard.addRow(fmt.Sprintf("namespace/%s", namespace), datasource, []grafanaGraph{
+		successRateGraph(selector, label, legend),
+		requestRateGraph(selector, label, legend),
+		latencyGraph(selector, label, "0.95", legend),
+	})
+	for _, res := range workloads {
+		wlSelector := workloadSelector(res)
+		wlLegend := fmt.Sprintf("%s/%s", res.GetType(), res.GetName())
+		dashboard.addRow(wlLegend, datasource, []grafanaGraph{
+			successRateGraph(wlSelector, label, wlLegend),
+			requestRateGraph(wlSelector, label, wlLegend),
+			latencyGraph(wlSelector, label, "0.95", wlLegend),
+		})
+	}
+	return dashboard
+}
+
+// workloadGrafanaDashboard graphs the stats of a single workload
+func workloadGrafanaDashboard(res *pb.Resource, datasource string) *grafanaDashboard {
+	label := k8s.KindToL5DLabel(res.GetType())
+	selector := workloadSelector(res)
+	name := fmt.Sprintf("%s/%s", res.GetType(), res.GetName())
+
+	dashboard := newGrafanaDashboard(fmt.Sprintf("%s/%s", res.GetNamespace(), name), fmt.Sprintf("Linkerd %s/%s", res.GetNamespace(), name), res.GetNamespace())
+	latency := grafanaGraph{title: "Latency", unit: "ms"}
+	for i, quantile := range []string{"0.5", "0.95", "0.99"} {
+		target := latencyGraph(selector, label, quantile, fmt.Sprintf("p%s", percentile(quantile))).targets[0]
+		target.RefID = string(rune('A' + i))
+		latency.targets = append(latency.targets, target)
+	}
+	dashboard.addRow(name, datasource, []grafanaGraph{
+		successRateGraph(selector, label, "success rate"),
+		requestRateGraph(selector, label, "requests"),
+		latency,
+	})
+	dashboard.addRow("TCP", datasource, []grafanaGraph{
+		{
+			title: "Open connections",
+			unit:  "short",
+			targets: []grafanaTarget{{
+				RefID:        "A",
+				Expr:         fmt.Sprintf("sum(tcp_open_connections{%s}) by (%s)", selector, label),
+				LegendFormat: "connections",
+			}},
+		},
+		{
+			title: "Read bytes",
+			unit:  "Bps",
+			targets: []grafanaTarget{{
+				RefID:        "A",
+				Expr:         fmt.Sprintf("sum(rate(tcp_read_bytes_total{%s}[%s])) by (%s)", selector, grafanaRateWindow, label),
+				LegendFormat: "read",
+			}},
+		},
+		{
+			title: "Write bytes",
+			unit:  "Bps",
+			targets: []grafanaTarget{{
+				RefID:        "A",
+				Expr:         fmt.Sprintf("sum(rate(tcp_write_bytes_total{%s}[%s])) by (%s)", selector, grafanaRateWindow, label),
+				LegendFormat: "write",
+			}},
+		},
+	})
+	return dashboard
+}
+
+func newGrafanaDashboard(id, title, namespace string) *grafanaDashboard {
+	// the uids of Grafana are limited to 40 characters
+	return &grafanaDashboard{
+		UID:           fmt.Sprintf("linkerd-%x", sha256.Sum256([]byte(id)))[:40],
+		Title:         title,
+		Tags:          []string{"linkerd", namespace},
+		Editable:      true,
+		SchemaVersion: 30,
+		Refresh:       "1m",
+		Time:          grafanaTimeRange{From: "now-1h", To: "now"},
+	}
+}
+
+// addRow appends a row panel, followed by the graphs laid out side by side
+func (d *grafanaDashboard) addRow(title, datasource string, graphs []grafanaGraph) {
+	y := 0
+	for _, panel := range d.Panels {
+		if bottom := panel.GridPos.Y + panel.GridPos.H; bottom > y {
+			y = bottom
+		}
+	}
+
+	d.Panels = append(d.Panels, grafanaPanel{
+		ID:      len(d.Panels) + 1,
+		Type:    "row",
+		Title:   title,
+		GridPos: grafanaGridPos{X: 0, Y: y, W: len(graphs) * grafanaPanelWidth, H: 1},
+	})
+	for i, graph := range graphs {
+		d.Panels = append(d.Panels, grafanaPanel{
+			ID:          len(d.Panels) + 1,
+			Type:        "timeseries",
+			Title:       graph.title,
+			Datasource:  datasource,
+			GridPos:     grafanaGridPos{X: i * grafanaPanelWidth, Y: y + 1, W: grafanaPanelWidth, H: grafanaPanelHeight},
+			FieldConfig: &grafanaFieldConfig{Defaults: grafanaFieldDefaults{Unit: graph.unit}},
+			Targets:     graph.targets,
+		})
+	}
+}
+
+func workloadSelector(res *pb.Resource) string {
+	return fmt.Sprintf(`namespace="%s", %s="%s", direction="inbound"`, res.GetNamespace(), k8s.KindToL5DLabel(res.GetType()), res.GetName())
+}
+
+func successRateGraph(selector, groupBy, legend string) grafanaGraph {
+	return grafanaGraph{
+		title: "Success rate",
+		unit:  "percentunit",
+		targets: []grafanaTarget{{
+			RefID:        "A",
+			Expr:         fmt.Sprintf(`sum(rate(response_total{classification="success", %[1]s}[%[2]s])) by (%[3]s) / sum(rate(response_total{%[1]s}[%[2]s])) by (%[3]s)`, selector, grafanaRateWindow, groupBy),
+			LegendFormat: legend,
+		}},
+	}
+}
+
+func requestRateGraph(selector, groupBy, legend string) grafanaGraph {
+	return grafanaGraph{
+		title: "Request rate",
+		unit:  "reqps",
+		targets: []grafanaTarget{{
+			RefID:        "A",
+			Expr:         fmt.Sprintf("sum(rate(response_total{%s}[%s])) by (%s)", selector, grafanaRateWindow, groupBy),
+			LegendFormat: legend,
+		}},
+	}
+}
+
+func latencyGraph(selector, groupBy, quantile, legend string) grafanaGraph {
+	return grafanaGraph{
+		title: fmt.Sprintf("P%s latency", percentile(quantile)),
+		unit:  "ms",
+		targets: []grafanaTarget{{
+			RefID:        "A",
+			Expr:         fmt.Sprintf("histogram_quantile(%s, sum(rate(response_latency_ms_bucket{%s}[%s])) by (le, %s))", quantile, selector, grafanaRateWindow, groupBy),
+			LegendFormat: legend,
+		}},
+	}
+}
+
+// percentile returns the percentile of a quantile, e.g. 50 for 0.5
+func percentile(quantile string) string {
+	p := strings.TrimPrefix(quantile, "0.")
+	if len(p) == 1 {
+		p += "0"
+	}
+	return p
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// the glyphs are 5x7 pixels, drawn at pngScale with a pixel of spacing
+	glyphWidth  = 5
+	glyphHeight = 7
+	pngScale    = 2
+	pngAdvance  = (glyphWidth + 1) * pngScale
+	pngLine     = (glyphHeight + 5) * pngScale
+	pngMargin   = 24
+	pngCellPad  = 12
+)
+
+var (
+	pngBackground = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	pngText       = color.RGBA{0x33, 0x33, 0x33, 0xff}
+	pngMuted      = color.RGBA{0x77, 0x77, 0x77, 0xff}
+	pngHeader     = color.RGBA{0xf4, 0xf4, 0xf4, 0xff}
+	pngRule       = color.RGBA{0xdd, 0xdd, 0xdd, 0xff}
+)
+
+// dashboardFont holds the rows of the 5x7 glyphs, the leftmost pixel being
+// the fifth bit; the characters without a glyph are drawn as a '?'
+var dashboardFont = map[rune][glyphHeight]uint8{
+	' ': {0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+	'!': {0x04, 0x04, 0x04, 0x04, 0x04, 0x00, 0x04},
+	'%': {0x18, 0x19, 0x02, 0x04, 0x08, 0x13, 0x03},
+	'(': {0x02, 0x04, 0x08, 0x08, 0x08, 0x04, 0x02},
+	')': {0x08, 0x04, 0x02, 0x02, 0x02, 0x04, 0x08},
+	'+': {0x00, 0x04, 0x04, 0x1f, 0x04, 0x04, 0x00},
+	',': {0x00, 0x00, 0x00, 0x00, 0x0c, 0x04, 0x08},
+	'-': {0x00, 0x00, 0x00, 0x1f, 0x00, 0x00, 0x00},
+	'.': {0x00, 0x00, 0x00, 0x00, 0x00, 0x0c, 0x0c},
+	'/': {0x00, 0x01, 0x02, 0x04, 0x08, 0x10, 0x00},
+	'0': {0x0e, 0x11, 0x13, 0x15, 0x19, 0x11, 0x0e},
+	'1': {0x04, 0x0c, 0x04, 0x04, 0x04, 0x04, 0x0e},
+	'2': {0x0e, 0x11, 0x01, 0x02, 0x04, 0x08, 0x1f},
+	'3': {0x1f, 0x02, 0x04, 0x02, 0x01, 0x11, 0x0e},
+	'4': {0x02, 0x06, 0x0a, 0x12, 0x1f, 0x02, 0x02},
+	'5': {0x1f, 0x10, 0x1e, 0x01, 0x01, 0x11, 0x0e},
+	'6': {0x06, 0x08, 0x10, 0x1e, 0x11, 0x11, 0x0e},
+	'7': {0x1f, 0x01, 0x02, 0x04, 0x08, 0x08, 0x08},
+	'8': {0x0e, 0x11, 0x11, 0x0e, 0x11, 0x11, 0x0e},
+	'9': {0x0e, 0x11, 0x11, 0x0f, 0x01, 0x02, 0x0c},
+	':': {0x00, 0x0c, 0x0c, 0x00, 0x0c, 0x0c, 0x00},
+	'?': {0x0e, 0x11, 0x01, 0x02, 0x04, 0x00, 0x04},
+	'A': {0x0e, 0x11, 0x11, 0x1f, 0x11, 0x11, 0x11},
+	'B': {0x1e, 0x11, 0x11, 0x1e, 0x11, 0x11, 0x1e},
+	'C': {0x0e, 0x11, 0x10, 0x10, 0x10, 0x11, 0x0e},
+	'D': {0x1c, 0x12, 0x11, 0x11, 0x11, 0x12, 0x1c},
+	'E': {0x1f, 0x10, 0x10, 0x1e, 0x10, 0x10, 0x1f},
+	'F': {0x1f, 0x10, 0x10, 0x1e, 0x10, 0x10, 0x10},
+	'G': {0x0e, 0x11, 0x10, 0x17, 0x11, 0x11, 0x0f},
+	'H': {0x11, 0x11, 0x11, 0x1f, 0x11, 0x11, 0x11},
+	'I': {0x0e, 0x04, 0x04, 0x04, 0x04, 0x04, 0x0e},
+	'J': {0x07, 0x02, 0x02, 0x02, 0x02, 0x12, 0x0c},
+	'K': {0x11, 0x12, 0x14, 0x18, 0x14, 0x12, 0x11},
+	'L': {0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x1f},
+	'M': {0x11, 0x1b, 0x15, 0x15, 0x11, 0x11, 0x11},
+	'N': {0x11, 0x11, 0x19, 0x15, 0x13, 0x11, 0x11},
+	'O': {0x0e, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0e},
+	'P': {0x1e, 0x11, 0x11, 0x1e, 0x10, 0x10, 0x10},
+	'Q': {0x0e, 0x11, 0x11, 0x11, 0x15, 0x12, 0x0d},
+	'R': {0x1e, 0x11, 0x11, 0x1e, 0x14, 0x12, 0x11},
+	'S': {0x0f, 0x10, 0x10, 0x0e, 0x01, 0x01, 0x1e},
+	'T': {0x1f, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04},
+	'U': {0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0e},
+	'V': {0x11, 0x11, 0x11, 0x11, 0x11, 0x0a, 0x04},
+	'W': {0x11, 0x11, 0x11, 0x15, 0x15, 0x15, 0x0a},
+	'X': {0x11, 0x11, 0x0a, 0x04, 0x0a, 0x11, 0x11},
+	'Y': {0x11, 0x11, 0x0a, 0x04, 0x04, 0x04, 0x04},
+	'Z': {0x1f, 0x01, 0x02, 0x04, 0x08, 0x10, 0x1f},
+	'_': {0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x1f},
+	'a': {0x00, 0x00, 0x0e, 0x01, 0x0f, 0x11, 0x0f},
+	'b': {0x10, 0x10, 0x16, 0x19, 0x11, 0x11, 0x1e},
+	'c': {0x00, 0x00, 0x0e, 0x10, 0x10, 0x11, 0x0e},
+	'd': {0x01, 0x01, 0x0d, 0x13, 0x11, 0x11, 0x0f},
+	'e': {0x00, 0x00, 0x0e, 0x11, 0x1f, 0x10, 0x0e},
+	'f': {0x06, 0x09, 0x08, 0x1c, 0x08, 0x08, 0x08},
+	'g': {0x00, 0x0f, 0x11, 0x11, 0x0f, 0x01, 0x0e},
+	'h': {0x10, 0x10, 0x16, 0x19, 0x11, 0x11, 0x11},
+	'i': {0x04, 0x00, 0x0c, 0x04, 0x04, 0x04, 0x0e},
+	'j': {0x02, 0x00, 0x06, 0x02, 0x02, 0x12, 0x0c},
+	'k': {0x10, 0x10, 0x12, 0x14, 0x18, 0x14, 0x12},
+	'l': {0x0c, 0x04, 0x04, 0x04, 0x04, 0x04, 0x0e},
+	'm': {0x00, 0x00, 0x1a, 0x15, 0x15, 0x11, 0x11},
+	'n': {0x00, 0x00, 0x16, 0x19, 0x11, 0x11, 0x11},
+	'o': {0x00, 0x00, 0x0e, 0x11, 0x11, 0x11, 0x0e},
+	'p': {0x00, 0x00, 0x1e, 0x11, 0x1e, 0x10, 0x10},
+	'q': {0x00, 0x00, 0x0d, 0x13, 0x0f, 0x01, 0x01},
+	'r': {0x00, 0x00, 0x16, 0x19, 0x10, 0x10, 0x10},
+	's': {0x00, 0x00, 0x0e, 0x10, 0x0e, 0x01, 0x1e},
+	't': {0x08, 0x08, 0x1c, 0x08, 0x08, 0x09, 0x06},
+	'u': {0x00, 0x00, 0x11, 0x11, 0x11, 0x13, 0x0d},
+	'v': {0x00, 0x00, 0x11, 0x11, 0x11, 0x0a, 0x04},
+	'w': {0x00, 0x00, 0x11, 0x11, 0x15, 0x15, 0x0a},
+	'x': {0x00, 0x00, 0x11, 0x0a, 0x04, 0x0a, 0x11},
+	'y': {0x00, 0x00, 0x11, 0x11, 0x0f, 0x01, 0x0e},
+	'z': {0x00, 0x00, 0x1f, 0x02, 0x04, 0x08, 0x1f},
+}
+
+// pngCanvas draws text and rectangles on an image, clipped to its bounds
+type pngCanvas struct {
+	img *image.RGBA
+}
+
+// renderDashboardPNG writes the view as a PNG image, laid out like its HTML
+// page, for the environments where the snapshot is shared as a picture
+func renderDashboardPNG(w io.Writer, view *dashboardView) error {
+	headers := []string{"Name", "Meshed", "Success", "RPS", "P50", "P95", "P99"}
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = textWidth(h, 1)
+	}
+	for _, section := range view.Sections {
+		for _, row := range section.Rows {
+			for i, cell := range row.cells() {
+				if width := textWidth(cell, 1); width > widths[i] {
+					widths[i] = width
+				}
+			}
+		}
+	}
+	tableWidth := 0
+	for _, width := range widths {
+		tableWidth += width + 2*pngCellPad
+	}
+
+	meta := "Time window: " + view.TimeWindow + " - Generated: " + view.Generated
+	width := tableWidth
+	for _, tw := range []int{textWidth(view.Title, 2), textWidth(view.Description, 1), textWidth(meta, 1)} {
+		if tw > width {
+			width = tw
+		}
+	}
+	width += 2 * pngMargin
+	height := pngMargin + 2*pngLine + 3*pngLine
+	for _, section := range view.Sections {
+		height += 2*pngLine + pngLine*(len(section.Rows)+1)
+	}
+	height += pngMargin
+
+	c := &pngCanvas{img: image.NewRGBA(image.Rect(0, 0, width, height))}
+	c.fill(c.img.Bounds(), pngBackground)
+
+	y := pngMargin
+	c.text(pngMargin, y, view.Title, 2, pngText)
+	y += 2 * pngLine
+	c.text(pngMargin, y, view.Description, 1, pngText)
+	y += pngLine
+	c.text(pngMargin, y, meta, 1, pngMuted)
+	y += 2 * pngLine
+
+	for _, section := range view.Sections {
+		c.text(pngMargin, y, section.Title, 1, pngText)
+		y += pngLine + pngLine/2
+		if len(section.Rows) == 0 {
+			c.text(pngMargin, y, "No traffic found.", 1, pngMuted)
+			y += pngLine + pngLine/2
+			continue
+		}
+
+		c.fill(image.Rect(pngMargin, y-pngLine/4, pngMargin+tableWidth, y+pngLine-pngLine/4), pngHeader)
+		c.row(pngMargin, y, headers, widths, pngText)
+		y += pngLine
+		for _, row := range section.Rows {
+			ink := pngText
+			if strings.HasPrefix(row.Meshed, "0/") {
+				// the unmeshed resources are grayed out
+				ink = pngMuted
+			}
+			c.row(pngMargin, y, row.cells(), widths, ink)
+			c.fill(image.Rect(pngMargin, y+pngLine-pngLine/4-1, pngMargin+tableWidth, y+pngLine-pngLine/4), pngRule)
+			y += pngLine
+		}
+		y += pngLine / 2
+	}
+
+	return png.Encode(w, c.img)
+}
+
+// cells returns the values of the row, in the order of the table columns
+func (r dashboardRow) cells() []string {
+	return []string{r.Name, r.Meshed, r.Success, r.RPS, r.P50, r.P95, r.P99}
+}
+
+// row draws the cells of a table row, the names being left-aligned and the
+// stats right-aligned as in the HTML tables
+func (c *pngCanvas) row(x, y int, cells []string, widths []int, ink color.RGBA) {
+	for i, cell := range cells {
+		cx := x + pngCellPad
+		if i > 0 {
+			cx += widths[i] - textWidth(cell, 1)
+		}
+		c.text(cx, y, cell, 1, ink)
+		x += widths[i] + 2*pngCellPad
+	}
+}
+
+func (c *pngCanvas) text(x, y int, s string, scale int, ink color.RGBA) {
+	for _, r := range s {
+		glyph, ok := dashboardFont[r]
+		if !ok {
+			glyph = dashboardFont['?']
+		}
+		for gy, bits := range glyph {
+			for gx := 0; gx < glyphWidth; gx++ {
+				if bits&(1<<(glyphWidth-1-gx)) == 0 {
+					continue
+				}
+				px := x + gx*pngScale*scale
+				py := y + gy*pngScale*scale
+				c.fill(image.Rect(px, py, px+pngScale*scale, py+pngScale*scale), ink)
+			}
+		}
+		x += pngAdvance * scale
+	}
+}
+
+func (c *pngCanvas) fill(r image.Rectangle, ink color.RGBA) {
+	r = r.Intersect(c.img.Bounds())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			c.img.SetRGBA(x, y, ink)
+		}
+	}
+}
+
+func textWidth(s string, scale int) int {
+	return utf8.RuneCountInString(s) * pngAdvance * scale
+}
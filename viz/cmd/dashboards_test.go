@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"context"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	api "github.com/linkerd/linkerd2/viz/metrics-api"
+)
+
+func TestExportDashboards(t *testing.T) {
+	counts := &api.PodCounts{
+		MeshedPods:  1,
+		RunningPods: 1,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != statsAPIPath {
+			http.NotFound(w, req)
+			return
+		}
+
+		rsp := api.GenStatSummaryResponse("web", k8s.Deployment, []string{"emojivoto"}, counts, true, false)
+		if req.FormValue("resource_type") == k8s.Namespace {
+			rsp = api.GenStatSummaryResponse("emojivoto", k8s.Namespace, []string{""}, counts, true, false)
+			unmeshed := api.GenStatSummaryResponse("kube-system", k8s.Namespace, []string{""}, &api.PodCounts{RunningPods: 1}, false, false)
+			rows := rsp.GetOk().GetStatTables()[0].GetPodGroup()
+			rows.Rows = append(rows.Rows, unmeshed.GetOk().GetStatTables()[0].GetPodGroup().GetRows()...)
+		}
+		(&jsonpb.Marshaler{}).Marshal(w, rsp)
+	}))
+	defer server.Close()
+
+	options := newDashboardsExportOptions()
+	options.outputDir = t.TempDir()
+
+	files, err := exportDashboards(context.Background(), server.Client(), server.URL, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expectedFiles := []string{
+		"namespace-emojivoto.html",
+		"emojivoto-deployment-web.html",
+		overviewFile,
+	}
+	if len(files) != len(expectedFiles) {
+		t.Fatalf("Expected %d files, got %v", len(expectedFiles), files)
+	}
+	for i, file := range expectedFiles {
+		if files[i] != filepath.Join(options.outputDir, file) {
+			t.Fatalf("Expected file %s, got %s", file, files[i])
+		}
+	}
+
+	overview, err := os.ReadFile(files[2])
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	for _, expected := range []string{`<a href="namespace-emojivoto.html">emojivoto</a>`, "100.00%", "2.0rps", "123ms"} {
+		if !strings.Contains(string(overview), expected) {
+			t.Fatalf("Expected overview to contain %q, got:\n%s", expected, overview)
+		}
+	}
+	if strings.Contains(string(overview), "namespace-kube-system.html") {
+		t.Fatalf("Expected overview not to link to the unmeshed namespace, got:\n%s", overview)
+	}
+
+	namespace, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !strings.Contains(string(namespace), `<a href="emojivoto-deployment-web.html">web</a>`) {
+		t.Fatalf("Expected namespace view to link to the workload view, got:\n%s", namespace)
+	}
+
+	options.outputDir = t.TempDir()
+	options.format = pngFormat
+	files, err = exportDashboards(context.Background(), server.Client(), server.URL, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(files) != len(expectedFiles) {
+		t.Fatalf("Expected %d files, got %v", len(expectedFiles), files)
+	}
+	for _, file := range files {
+		if filepath.Ext(file) != ".png" {
+			t.Fatalf("Expected a PNG file, got %s", file)
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		img, err := png.Decode(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("Expected %s to be a PNG image: %s", file, err)
+		}
+		if img.Bounds().Dx() == 0 || img.Bounds().Dy() == 0 {
+			t.Fatalf("Expected %s to be a non-empty image", file)
+		}
+	}
+
+	options.format = "pdf"
+	if _, err := exportDashboards(context.Background(), server.Client(), server.URL, options); err == nil {
+		t.Fatal("Expected an error for an unsupported format")
+	}
+}
+
+func TestGenerateGrafanaDashboards(t *testing.T) {
+	rsp := api.GenStatSummaryResponse("web", k8s.Deployment, []string{"emojivoto"}, &api.PodCounts{MeshedPods: 1, RunningPods: 1}, false, false)
+	unmeshed := api.GenStatSummaryResponse("vote-bot", k8s.Deployment, []string{"emojivoto"}, &api.PodCounts{MeshedPods: 0, RunningPods: 1}, false, false)
+	rows := rsp.GetOk().GetStatTables()[0].GetPodGroup()
+	rows.Rows = append(rows.Rows, unmeshed.GetOk().GetStatTables()[0].GetPodGroup().GetRows()...)
+
+	options := newDashboardsGrafanaOptions()
+	options.outputDir = t.TempDir()
+
+	files, err := generateGrafanaDashboards(context.Background(), &api.MockAPIClient{StatSummaryResponseToReturn: rsp}, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expectedFiles := []string{
+		"namespace-emojivoto.json",
+		"emojivoto-deployment-web.json",
+	}
+	if len(files) != len(expectedFiles) {
+		t.Fatalf("Expected %d files, got %v", len(expectedFiles), files)
+	}
+	for i, file := range expectedFiles {
+		if files[i] != filepath.Join(options.outputDir, file) {
+			t.Fatalf("Expected file %s, got %s", file, files[i])
+		}
+	}
+
+	namespace, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	testDataDiffer.DiffTestdata(t, "dashboards_grafana_namespace.golden", string(namespace))
+
+	workload, err := os.ReadFile(files[1])
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	testDataDiffer.DiffTestdata(t, "dashboards_grafana_workload.golden", string(workload))
+
+	options.resourceType = k8s.Service
+	if _, err := generateGrafanaDashboards(context.Background(), &api.MockAPIClient{StatSummaryResponseToReturn: rsp}, options); err == nil {
+		t.Fatal("Expected an error for a resource type other than a workload's")
+	}
+}
@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	pkgcmd "github.com/linkerd/linkerd2/pkg/cmd"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/linkerd/linkerd2/viz/pkg/api"
+	pkgUtil "github.com/linkerd/linkerd2/viz/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+type dependenciesOptions struct {
+	statOptionsBase
+	maxDepth uint32
+}
+
+func newDependenciesOptions() *dependenciesOptions {
+	return &dependenciesOptions{
+		statOptionsBase: *newStatOptionsBase(),
+		maxDepth:        3,
+	}
+}
+
+// NewCmdDependencies creates a new cobra command `dependencies` for walking
+// the dependency tree of a resource
+func NewCmdDependencies() *cobra.Command {
+	options := newDependenciesOptions()
+
+	cmd := &cobra.Command{
+		Use:   "dependencies [flags] (RESOURCE)",
+		Short: "Display the upstream and downstream dependency trees of a resource",
+		Long: `Display the upstream and downstream dependency trees of a resource.
+
+  The RESOURCE argument specifies the resource whose dependencies are walked,
+  e.g. deploy/web. Upstreams are the resources it sends requests to,
+  transitively, and downstreams the resources sending requests to it. Each
+  resource is displayed with the stats of the requests on the edge leading to
+  it.
+
+  A resource appearing twice on the same path is marked as a cycle and not
+  walked further, and resources past the maximum depth are elided.`,
+		Example: `  # Get the dependencies of the web deployment in the emojivoto namespace.
+  linkerd viz dependencies deploy/web -n emojivoto
+
+  # Only get the direct dependencies of the web deployment.
+  linkerd viz dependencies deploy/web -n emojivoto --max-depth 1`,
+		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			if options.namespace == "" {
+				options.namespace = pkgcmd.GetDefaultNamespace(kubeconfigPath, kubeContext)
+			}
+
+			cc := k8s.NewCommandCompletion(k8sAPI, options.namespace)
+
+			results, err := cc.Complete(args, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return results, cobra.ShellCompDirectiveDefault
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.namespace == "" {
+				options.namespace = pkgcmd.GetDefaultNamespace(kubeconfigPath, kubeContext)
+			}
+
+			req, err := buildDependenciesRequest(args[0], options)
+			if err != nil {
+				return fmt.Errorf("Error creating dependencies request: %s", err)
+			}
+
+			client := api.CheckClientOrExit(healthcheck.Options{
+				ControlPlaneNamespace: controlPlaneNamespace,
+				KubeConfig:            kubeconfigPath,
+				Impersonate:           impersonate,
+				ImpersonateGroup:      impersonateGroup,
+				KubeContext:           kubeContext,
+				APIAddr:               apiAddr,
+			})
+
+			resp, err := requestDependenciesFromAPI(client, req)
+			if err != nil {
+				fmt.Fprint(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+
+			_, err = fmt.Print(renderDependencies(resp.GetOk(), options))
+			return err
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the specified resource")
+	cmd.PersistentFlags().StringVarP(&options.timeWindow, "time-window", "t", options.timeWindow, "Stat window (for example: \"10s\", \"1m\", \"10m\", \"1h\")")
+	cmd.PersistentFlags().Uint32Var(&options.maxDepth, "max-depth", options.maxDepth, "Maximum number of hops walked from the resource")
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; one of: \"table\" or \"json\"")
+
+	pkgcmd.ConfigureNamespaceFlagCompletion(
+		cmd, []string{"namespace"},
+		kubeconfigPath, impersonate, impersonateGroup, kubeContext)
+	return cmd
+}
+
+func buildDependenciesRequest(arg string, options *dependenciesOptions) (*pb.DependenciesRequest, error) {
+	switch options.outputFormat {
+	case tableOutput, jsonOutput:
+	default:
+		return nil, fmt.Errorf("--output supports %s and %s", tableOutput, jsonOutput)
+	}
+	if options.maxDepth == 0 {
+		return nil, fmt.Errorf("--max-depth must be at least 1")
+	}
+
+	target, err := pkgUtil.BuildResource(options.namespace, arg)
+	if err != nil {
+		return nil, err
+	}
+	if target.GetName() == "" {
+		return nil, fmt.Errorf("Dependencies can only be returned for a specific resource; specify it as %s/<name>", target.GetType())
+	}
+	switch target.GetType() {
+	case k8s.Authority, k8s.Service, k8s.Server, k8s.ServerAuthorization, k8s.HTTPRoute, k8s.Namespace, k8s.All:
+		return nil, fmt.Errorf("Resource type is not supported: %s", target.GetType())
+	}
+
+	return &pb.DependenciesRequest{
+		Resource:   target,
+		TimeWindow: options.timeWindow,
+		MaxDepth:   options.maxDepth,
+	}, nil
+}
+
+func requestDependenciesFromAPI(client pb.ApiClient, req *pb.DependenciesRequest) (*pb.DependenciesResponse, error) {
+	resp, err := client.Dependencies(context.Background(), req)
+	if err != nil {
+		return nil, fmt.Errorf("Dependencies API error: %+v", err)
+	}
+	if e := resp.GetError(); e != nil {
+		return nil, fmt.Errorf("Dependencies API response error: %+v", e.Error)
+	}
+	return resp, nil
+}
+
+func renderDependencies(deps *pb.DependenciesResponse_Ok, options *dependenciesOptions) string {
+	var buffer bytes.Buffer
+	switch options.outputFormat {
+	case jsonOutput:
+		printDependenciesJSON(deps, &buffer, options)
+	default:
+		printDependenciesTable("UPSTREAMS", deps.GetUpstreams(), &buffer, options)
+		fmt.Fprintln(&buffer)
+		printDependenciesTable("DOWNSTREAMS", deps.GetDownstreams(), &buffer, options)
+	}
+	return buffer.String()
+}
+
+// dependencyRow is a node of the dependency tree, flattened into a table row
+// whose name is prefixed with the branches leading to it
+type dependencyRow struct {
+	name      string
+	namespace string
+	stats     *pb.BasicStats
+}
+
+func flattenDependencies(node *pb.DependencyNode, prefix, branch string, rows []dependencyRow) []dependencyRow {
+	name := prefix + branch + node.GetResource().GetName()
+	if node.GetCycle() {
+		name += " (cycle)"
+	}
+	if node.GetTruncated() {
+		name += " ..."
+	}
+	rows = append(rows, dependencyRow{
+		name:      name,
+		namespace: node.GetResource().GetNamespace(),
+		stats:     node.GetStats(),
+	})
+
+	switch branch {
+	case "├── ":
+		prefix += "│   "
+	case "└── ":
+		prefix += "    "
+	}
+	children := node.GetChildren()
+	for i, child := range children {
+		childBranch := "├── "
+		if i == len(children)-1 {
+			childBranch = "└── "
+		}
+		rows = flattenDependencies(child, prefix, childBranch, rows)
+	}
+	return rows
+}
+
+func printDependenciesTable(title string, root *pb.DependencyNode, out io.Writer, options *dependenciesOptions) {
+	fmt.Fprintln(out, title)
+	if len(root.GetChildren()) == 0 {
+		fmt.Fprintln(out, "No dependencies found.")
+		return
+	}
+
+	rows := flattenDependencies(root, "", "", nil)
+	nameWidth := len("NAME")
+	for _, row := range rows {
+		if width := len([]rune(row.name)); width > nameWidth {
+			nameWidth = width
+		}
+	}
+	// template for left-aligning the name column
+	nameTemplate := fmt.Sprintf("%%-%ds", nameWidth)
+
+	var buffer bytes.Buffer
+	w := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', tabwriter.AlignRight)
+	headers := []string{
+		fmt.Sprintf(nameTemplate, "NAME"),
+		"NAMESPACE",
+		"SUCCESS",
+		"RPS",
+		"LATENCY_P50",
+		"LATENCY_P95",
+		"LATENCY_P99\t", // trailing \t is required to format last column
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	for _, row := range rows {
+		if row.stats == nil {
+			fmt.Fprintf(w, nameTemplate+"\t%s\t-\t-\t-\t-\t-\t\n", row.name, row.namespace)
+			continue
+		}
+		fmt.Fprintf(w, nameTemplate+"\t%s\t%.2f%%\t%.1frps\t%dms\t%dms\t%dms\t\n",
+			row.name,
+			row.namespace,
+			getSuccessRate(row.stats.GetSuccessCount(), row.stats.GetFailureCount())*100,
+			getRequestRate(row.stats.GetSuccessCount(), row.stats.GetFailureCount(), options.timeWindow),
+			row.stats.GetLatencyMsP50(),
+			row.stats.GetLatencyMsP95(),
+			row.stats.GetLatencyMsP99(),
+		)
+	}
+	w.Flush()
+
+	// strip left padding on the first column
+	table := string(buffer.Bytes()[padding:])
+	table = strings.Replace(table, "\n"+strings.Repeat(" ", padding), "\n", -1)
+	fmt.Fprint(out, table)
+}
+
+// jsonDependency represents a node of the dependency trees in the JSON output.
+// Using pointers there where the value is NA and the corresponding json is null
+type jsonDependency struct {
+	Name         string            `json:"name"`
+	Namespace    string            `json:"namespace"`
+	Type         string            `json:"type"`
+	Success      *float64          `json:"success"`
+	Rps          *float64          `json:"rps"`
+	LatencyMSp50 *uint64           `json:"latency_ms_p50"`
+	LatencyMSp95 *uint64           `json:"latency_ms_p95"`
+	LatencyMSp99 *uint64           `json:"latency_ms_p99"`
+	Cycle        bool              `json:"cycle"`
+	Truncated    bool              `json:"truncated"`
+	Children     []*jsonDependency `json:"children"`
+}
+
+func toJSONDependency(node *pb.DependencyNode, timeWindow string) *jsonDependency {
+	entry := &jsonDependency{
+		Name:      node.GetResource().GetName(),
+		Namespace: node.GetResource().GetNamespace(),
+		Type:      node.GetResource().GetType(),
+		Cycle:     node.GetCycle(),
+		Truncated: node.GetTruncated(),
+		// avoid nil initialization so that leaves get marshalled with an
+		// empty array vs null
+		Children: []*jsonDependency{},
+	}
+	if stats := node.GetStats(); stats != nil {
+		success := getSuccessRate(stats.GetSuccessCount(), stats.GetFailureCount())
+		rps := getRequestRate(stats.GetSuccessCount(), stats.GetFailureCount(), timeWindow)
+		p50, p95, p99 := stats.GetLatencyMsP50(), stats.GetLatencyMsP95(), stats.GetLatencyMsP99()
+		entry.Success = &success
+		entry.Rps = &rps
+		entry.LatencyMSp50 = &p50
+		entry.LatencyMSp95 = &p95
+		entry.LatencyMSp99 = &p99
+	}
+	for _, child := range node.GetChildren() {
+		entry.Children = append(entry.Children, toJSONDependency(child, timeWindow))
+	}
+	return entry
+}
+
+func printDependenciesJSON(deps *pb.DependenciesResponse_Ok, out io.Writer, options *dependenciesOptions) {
+	entries := map[string]*jsonDependency{
+		"upstreams":   toJSONDependency(deps.GetUpstreams(), options.timeWindow),
+		"downstreams": toJSONDependency(deps.GetDownstreams(), options.timeWindow),
+	}
+
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error marshalling JSON: %s\n", err)
+		return
+	}
+	fmt.Fprintf(out, "%s\n", b)
+}
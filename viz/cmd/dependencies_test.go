@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"testing"
+
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	api "github.com/linkerd/linkerd2/viz/metrics-api"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+)
+
+func dependencyNode(name string, stats *pb.BasicStats, children ...*pb.DependencyNode) *pb.DependencyNode {
+	return &pb.DependencyNode{
+		Resource: &pb.Resource{
+			Namespace: "emojivoto",
+			Name:      name,
+			Type:      pkgK8s.Deployment,
+		},
+		Stats:    stats,
+		Children: children,
+	}
+}
+
+func genDependenciesResponse() *pb.DependenciesResponse {
+	stats := &pb.BasicStats{
+		SuccessCount: 90,
+		FailureCount: 30,
+		LatencyMsP50: 12,
+		LatencyMsP95: 100,
+		LatencyMsP99: 250,
+	}
+	cycle := dependencyNode("web", stats)
+	cycle.Cycle = true
+	truncated := dependencyNode("api-gateway", stats)
+	truncated.Truncated = true
+
+	return &pb.DependenciesResponse{
+		Response: &pb.DependenciesResponse_Ok_{
+			Ok: &pb.DependenciesResponse_Ok{
+				Upstreams: dependencyNode("web", nil,
+					dependencyNode("emoji", stats),
+					dependencyNode("voting", stats,
+						dependencyNode("db", stats),
+						cycle,
+					),
+				),
+				Downstreams: dependencyNode("web", nil,
+					dependencyNode("vote-bot", stats, truncated),
+				),
+			},
+		},
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	t.Run("Returns dependencies", func(t *testing.T) {
+		options := newDependenciesOptions()
+		options.namespace = "emojivoto"
+		testDependenciesCall(t, options, "dependencies_output.golden")
+	})
+
+	t.Run("Returns dependencies (json)", func(t *testing.T) {
+		options := newDependenciesOptions()
+		options.namespace = "emojivoto"
+		options.outputFormat = jsonOutput
+		testDependenciesCall(t, options, "dependencies_output_json.golden")
+	})
+
+	t.Run("Returns an error if request doesn't include the resource name", func(t *testing.T) {
+		options := newDependenciesOptions()
+		expectedError := "Dependencies can only be returned for a specific resource; specify it as deployment/<name>"
+
+		_, err := buildDependenciesRequest("deploy", options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+
+	t.Run("Returns an error if request is for service", func(t *testing.T) {
+		options := newDependenciesOptions()
+		expectedError := "Resource type is not supported: service"
+
+		_, err := buildDependenciesRequest("svc/web", options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+
+	t.Run("Returns an error if outputFormat specified is not table or json", func(t *testing.T) {
+		options := newDependenciesOptions()
+		options.outputFormat = wideOutput
+		expectedError := "--output supports table and json"
+
+		_, err := buildDependenciesRequest("deploy/web", options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+}
+
+func testDependenciesCall(t *testing.T, options *dependenciesOptions, file string) {
+	t.Helper()
+	mockClient := &api.MockAPIClient{}
+	mockClient.DependenciesResponseToReturn = genDependenciesResponse()
+
+	req, err := buildDependenciesRequest("deploy/web", options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	resp, err := requestDependenciesFromAPI(mockClient, req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	testDataDiffer.DiffTestdata(t, file, renderDependencies(resp.GetOk(), options))
+}
@@ -27,10 +27,15 @@ var (
 
 )
 
+// edgesPageSize is the number of edges requested at once from the metrics
+// API, which can return a large number of them for the "all" resource type
+const edgesPageSize = 500
+
 type edgesOptions struct {
-	namespace     string
-	outputFormat  string
-	allNamespaces bool
+	namespace        string
+	outputFormat     string
+	allNamespaces    bool
+	allResourceTypes bool
 }
 
 func newEdgesOptions() *edgesOptions {
@@ -56,6 +61,8 @@ func NewCmdEdges() *cobra.Command {
 		Long: `Display connections between resources, and Linkerd proxy identities.
 
   The RESOURCETYPE argument specifies the type of resource to display edges within.
+  The "all" resource type displays the edges between the workloads of any type,
+  each pod being attributed to its top-level owner.
 
   Examples:
   * cronjob
@@ -68,6 +75,7 @@ func NewCmdEdges() *cobra.Command {
   * sts
 
   Valid resource types include:
+  * all
   * cronjobs
   * daemonsets
   * deployments
@@ -83,7 +91,10 @@ func NewCmdEdges() *cobra.Command {
   linkerd viz edges po
 
   # Get all edges between pods in all namespaces.
-  linkerd viz edges po --all-namespaces`,
+  linkerd viz edges po --all-namespaces
+
+  # Get the edges between all the workloads of the mesh.
+  linkerd viz edges all --all-namespaces`,
 		Args: cobra.ExactArgs(1),
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			// This command requires only one argument. If we already have
@@ -183,7 +194,7 @@ func validateEdgesRequestInputs(targets []*pb.Resource, options *edgesOptions) e
 			return fmt.Errorf("Edges cannot be returned for a specific resource name; remove %s from query", target.Name)
 		}
 		switch target.Type {
-		case "authority", "service", "all":
+		case k8s.Authority, k8s.Service:
 			return fmt.Errorf("Resource type is not supported: %s", target.Type)
 		}
 	}
@@ -209,6 +220,10 @@ func buildEdgesRequests(resources []string, options *edgesOptions) ([]*pb.EdgesR
 
 	requests := make([]*pb.EdgesRequest, 0)
 	for _, target := range targets {
+		if target.Type == k8s.All {
+			options.allResourceTypes = true
+		}
+
 		requestParams := util.EdgesRequestParams{
 			ResourceType:  target.Type,
 			Namespace:     options.namespace,
@@ -232,15 +247,30 @@ func edgesRespToRows(resp *pb.EdgesResponse) []*pb.Edge {
 	return rows
 }
 
+// requestEdgesFromAPI pages through the edges returned for the request, and
+// returns them in a single response
 func requestEdgesFromAPI(client pb.ApiClient, req *pb.EdgesRequest) (*pb.EdgesResponse, error) {
-	resp, err := client.Edges(context.Background(), req)
-	if err != nil {
-		return nil, fmt.Errorf("Edges API error: %+v", err)
-	}
-	if e := resp.GetError(); e != nil {
-		return nil, fmt.Errorf("Edges API response error: %+v", e.Error)
+	edges := []*pb.Edge{}
+	req.PageSize = edgesPageSize
+	for {
+		resp, err := client.Edges(context.Background(), req)
+		if err != nil {
+			return nil, fmt.Errorf("Edges API error: %+v", err)
+		}
+		if e := resp.GetError(); e != nil {
+			return nil, fmt.Errorf("Edges API response error: %+v", e.Error)
+		}
+		edges = append(edges, resp.GetOk().GetEdges()...)
+
+		if resp.GetOk().GetNextPageToken() == "" {
+			return &pb.EdgesResponse{
+				Response: &pb.EdgesResponse_Ok_{
+					Ok: &pb.EdgesResponse_Ok{Edges: edges},
+				},
+			}, nil
+		}
+		req.PageToken = resp.GetOk().GetNextPageToken()
 	}
-	return resp, nil
 }
 
 func renderEdgeStats(rows []*pb.Edge, options *edgesOptions) string {
@@ -303,22 +333,22 @@ func writeEdgesToBuffer(rows []*pb.Edge, w *tabwriter.Writer, options *edgesOpti
 				client:       clientID,
 				server:       serverID,
 				msg:          msg,
-				src:          r.Src.Name,
+				src:          edgeResourceName(r.Src, options),
 				srcNamespace: r.Src.Namespace,
-				dst:          r.Dst.Name,
+				dst:          edgeResourceName(r.Dst, options),
 				dstNamespace: r.Dst.Namespace,
 			}
 
 			edgeRows = append(edgeRows, row)
 
-			if len(r.Src.Name) > maxSrcLength {
-				maxSrcLength = len(r.Src.Name)
+			if len(row.src) > maxSrcLength {
+				maxSrcLength = len(row.src)
 			}
 			if len(r.Src.Namespace) > maxSrcNamespaceLength {
 				maxSrcNamespaceLength = len(r.Src.Namespace)
 			}
-			if len(r.Dst.Name) > maxDstLength {
-				maxDstLength = len(r.Dst.Name)
+			if len(row.dst) > maxDstLength {
+				maxDstLength = len(row.dst)
 			}
 			if len(r.Dst.Namespace) > maxDstNamespaceLength {
 				maxDstNamespaceLength = len(r.Dst.Namespace)
@@ -354,6 +384,15 @@ func writeEdgesToBuffer(rows []*pb.Edge, w *tabwriter.Writer, options *edgesOpti
 	}
 }
 
+// edgeResourceName returns the name of the resource at the end of an edge,
+// prefixed by its type when the edges are between workloads of any type
+func edgeResourceName(resource *pb.Resource, options *edgesOptions) string {
+	if options.allResourceTypes {
+		return k8s.ShortNameFromCanonicalResourceName(resource.Type) + "/" + resource.Name
+	}
+	return resource.Name
+}
+
 func printEdgeTable(edgeRows []edgeRow, w *tabwriter.Writer, maxSrcLength, maxSrcNamespaceLength, maxDstLength, maxDstNamespaceLength, maxClientLength, maxServerLength, maxMsgLength int, outputFormat string) {
 	srcTemplate := fmt.Sprintf("%%-%ds", maxSrcLength)
 	dstTemplate := fmt.Sprintf("%%-%ds", maxDstLength)
@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"context"
+	"strconv"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	api "github.com/linkerd/linkerd2/viz/metrics-api"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"google.golang.org/grpc"
 )
 
 type edgesParamsExp struct {
@@ -86,18 +91,76 @@ func TestEdges(t *testing.T) {
 		}
 	})
 
-	t.Run("Returns an error if request is for all resource types", func(t *testing.T) {
-		options.outputFormat = tableOutput
-		args := []string{"all"}
-		expectedError := "Resource type is not supported: all"
+	t.Run("Prefixes the resources with their type for all resource types", func(t *testing.T) {
+		options := newEdgesOptions()
+		options.allNamespaces = true
+		reqs, err := buildEdgesRequests([]string{"all"}, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if reqs[0].GetSelector().GetResource().GetType() != "all" {
+			t.Fatalf("Expected a request for all resource types, got %s", reqs[0].GetSelector().GetResource().GetType())
+		}
 
-		_, err := buildEdgesRequests(args, options)
-		if err == nil || err.Error() != expectedError {
-			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		src := &pb.Resource{Type: "deployment", Name: "web"}
+		if name := edgeResourceName(src, options); name != "deploy/web" {
+			t.Fatalf("Expected deploy/web, got %s", name)
 		}
 	})
 }
 
+func TestRequestEdgesFromAPI(t *testing.T) {
+	edges := api.GenEdgesResponse("deployment", "all").GetOk().GetEdges()
+	mockClient := &pagedEdgesClient{edges: edges, pageSize: 2}
+
+	resp, err := requestEdgesFromAPI(mockClient, &pb.EdgesRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(resp.GetOk().GetEdges()) != len(edges) {
+		t.Fatalf("Expected %d edges, got %d", len(edges), len(resp.GetOk().GetEdges()))
+	}
+	for i, edge := range resp.GetOk().GetEdges() {
+		if !proto.Equal(edge, edges[i]) {
+			t.Fatalf("Expected edge %+v, got %+v", edges[i], edge)
+		}
+	}
+	if mockClient.calls != 2 {
+		t.Fatalf("Expected 2 pages to be requested, got %d", mockClient.calls)
+	}
+}
+
+// pagedEdgesClient returns the edges by pages of pageSize, regardless of the
+// page size of the requests
+type pagedEdgesClient struct {
+	api.MockAPIClient
+	edges    []*pb.Edge
+	pageSize int
+	calls    int
+}
+
+func (c *pagedEdgesClient) Edges(ctx context.Context, in *pb.EdgesRequest, opts ...grpc.CallOption) (*pb.EdgesResponse, error) {
+	c.calls++
+	offset := 0
+	if in.GetPageToken() != "" {
+		offset, _ = strconv.Atoi(in.GetPageToken())
+	}
+	end := offset + c.pageSize
+	next := strconv.Itoa(end)
+	if end >= len(c.edges) {
+		end = len(c.edges)
+		next = ""
+	}
+	return &pb.EdgesResponse{
+		Response: &pb.EdgesResponse_Ok_{
+			Ok: &pb.EdgesResponse_Ok{
+				Edges:         c.edges[offset:end],
+				NextPageToken: next,
+			},
+		},
+	}, nil
+}
+
 func testEdgesCall(exp edgesParamsExp, t *testing.T) {
 	mockClient := &api.MockAPIClient{}
 	response := api.GenEdgesResponse(exp.resourceType, "all")
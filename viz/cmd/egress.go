@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	pkgcmd "github.com/linkerd/linkerd2/pkg/cmd"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/linkerd/linkerd2/viz/pkg/api"
+	pkgUtil "github.com/linkerd/linkerd2/viz/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+type egressOptions struct {
+	statOptionsBase
+	allNamespaces bool
+}
+
+func newEgressOptions() *egressOptions {
+	return &egressOptions{
+		statOptionsBase: *newStatOptionsBase(),
+	}
+}
+
+// NewCmdEgress creates a new cobra command `egress` for displaying the traffic
+// sent to destinations outside of the cluster
+func NewCmdEgress() *cobra.Command {
+	options := newEgressOptions()
+
+	cmd := &cobra.Command{
+		Use:   "egress [flags] (RESOURCE)",
+		Short: "Display the traffic sent by workloads to destinations outside of the cluster",
+		Long: `Display the traffic sent by workloads to destinations outside of the cluster.
+
+  The RESOURCE argument specifies the workloads whose traffic is displayed,
+  e.g. deploy for all the deployments or deploy/web for a single one. The
+  traffic of each workload is split by the authority of its destinations, i.e.
+  their host name, or IP, and port. Destinations under the cluster domain
+  aren't reported, even when they couldn't be resolved to a service.
+
+  TLS is the percentage of the requests sent over TLS by the proxy, while the
+  bytes rates cover all the traffic, including the connections opaque to the
+  proxy.`,
+		Example: `  # Get the external destinations of all the deployments in the emojivoto namespace.
+  linkerd viz egress deploy -n emojivoto
+
+  # Get the external destinations of the web deployment over the last 10 minutes.
+  linkerd viz egress deploy/web -n emojivoto -t 10m
+
+  # Get the external destinations of all the namespaces.
+  linkerd viz egress ns`,
+		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			if options.namespace == "" {
+				options.namespace = pkgcmd.GetDefaultNamespace(kubeconfigPath, kubeContext)
+			}
+
+			cc := k8s.NewCommandCompletion(k8sAPI, options.namespace)
+
+			results, err := cc.Complete(args, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return results, cobra.ShellCompDirectiveDefault
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.namespace == "" {
+				options.namespace = pkgcmd.GetDefaultNamespace(kubeconfigPath, kubeContext)
+			}
+
+			req, err := buildEgressRequest(args[0], options)
+			if err != nil {
+				return fmt.Errorf("Error creating egress request: %s", err)
+			}
+
+			client := api.CheckClientOrExit(healthcheck.Options{
+				ControlPlaneNamespace: controlPlaneNamespace,
+				KubeConfig:            kubeconfigPath,
+				Impersonate:           impersonate,
+				ImpersonateGroup:      impersonateGroup,
+				KubeContext:           kubeContext,
+				APIAddr:               apiAddr,
+			})
+
+			resp, err := requestEgressFromAPI(client, req)
+			if err != nil {
+				fmt.Fprint(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+
+			_, err = fmt.Print(renderEgress(resp.GetOk().GetRows(), options))
+			return err
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the specified resource")
+	cmd.PersistentFlags().BoolVarP(&options.allNamespaces, "all-namespaces", "A", options.allNamespaces, "If present, returns the traffic of the workloads of all namespaces, ignoring the \"--namespace\" flag")
+	cmd.PersistentFlags().StringVarP(&options.timeWindow, "time-window", "t", options.timeWindow, "Stat window (for example: \"10s\", \"1m\", \"10m\", \"1h\")")
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; one of: \"table\" or \"json\"")
+
+	pkgcmd.ConfigureNamespaceFlagCompletion(
+		cmd, []string{"namespace"},
+		kubeconfigPath, impersonate, impersonateGroup, kubeContext)
+	return cmd
+}
+
+func buildEgressRequest(arg string, options *egressOptions) (*pb.EgressRequest, error) {
+	switch options.outputFormat {
+	case tableOutput, jsonOutput:
+	default:
+		return nil, fmt.Errorf("--output supports %s and %s", tableOutput, jsonOutput)
+	}
+
+	resource, err := pkgUtil.BuildResource(options.namespace, arg)
+	if err != nil {
+		return nil, err
+	}
+	switch resource.GetType() {
+	case k8s.Authority, k8s.Service, k8s.Server, k8s.ServerAuthorization, k8s.HTTPRoute, k8s.All:
+		return nil, fmt.Errorf("Resource type is not supported: %s", resource.GetType())
+	}
+	if options.allNamespaces && resource.GetType() != k8s.Namespace {
+		resource.Namespace = ""
+	}
+
+	return &pb.EgressRequest{
+		Resource:   resource,
+		TimeWindow: options.timeWindow,
+	}, nil
+}
+
+func requestEgressFromAPI(client pb.ApiClient, req *pb.EgressRequest) (*pb.EgressResponse, error) {
+	resp, err := client.Egress(context.Background(), req)
+	if err != nil {
+		return nil, fmt.Errorf("Egress API error: %+v", err)
+	}
+	if e := resp.GetError(); e != nil {
+		return nil, fmt.Errorf("Egress API response error: %+v", e.Error)
+	}
+	return resp, nil
+}
+
+func renderEgress(rows []*pb.EgressRow, options *egressOptions) string {
+	var buffer bytes.Buffer
+	switch options.outputFormat {
+	case jsonOutput:
+		printEgressJSON(rows, &buffer, options)
+	default:
+		printEgressTable(rows, &buffer, options)
+	}
+	return buffer.String()
+}
+
+func egressResourceName(row *pb.EgressRow) string {
+	resource := row.GetResource()
+	return k8s.ShortNameFromCanonicalResourceName(resource.GetType()) + "/" + resource.GetName()
+}
+
+// egressTLSRate returns the fraction of the requests sent over TLS, and false
+// if no request was sent
+func egressTLSRate(row *pb.EgressRow) (float64, bool) {
+	total := row.GetStats().GetSuccessCount() + row.GetStats().GetFailureCount()
+	if total == 0 {
+		return 0, false
+	}
+	return float64(row.GetTlsRequestCount()) / float64(total), true
+}
+
+func printEgressTable(rows []*pb.EgressRow, out io.Writer, options *egressOptions) {
+	if len(rows) == 0 {
+		fmt.Fprintln(os.Stderr, "No traffic found.")
+		return
+	}
+
+	nameWidth := len("NAME")
+	for _, row := range rows {
+		if width := len(egressResourceName(row)); width > nameWidth {
+			nameWidth = width
+		}
+	}
+	// template for left-aligning the name column
+	nameTemplate := fmt.Sprintf("%%-%ds", nameWidth)
+
+	var buffer bytes.Buffer
+	w := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', tabwriter.AlignRight)
+	headers := []string{
+		fmt.Sprintf(nameTemplate, "NAME"),
+		"NAMESPACE",
+		"AUTHORITY",
+		"SUCCESS",
+		"RPS",
+		"LATENCY_P99",
+		"TLS",
+		"READ_BYTES/SEC",
+		"WRITE_BYTES/SEC\t", // trailing \t is required to format last column
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	for _, row := range rows {
+		stats := row.GetStats()
+		requests := "-\t-\t-\t-"
+		if tls, ok := egressTLSRate(row); ok {
+			requests = fmt.Sprintf("%.2f%%\t%.1frps\t%dms\t%.0f%%",
+				getSuccessRate(stats.GetSuccessCount(), stats.GetFailureCount())*100,
+				getRequestRate(stats.GetSuccessCount(), stats.GetFailureCount(), options.timeWindow),
+				stats.GetLatencyMsP99(),
+				tls*100,
+			)
+		}
+		fmt.Fprintf(w, nameTemplate+"\t%s\t%s\t%s\t%.1fB/s\t%.1fB/s\t\n",
+			egressResourceName(row),
+			row.GetResource().GetNamespace(),
+			row.GetAuthority(),
+			requests,
+			getByteRate(row.GetTcpStats().GetReadBytesTotal(), options.timeWindow),
+			getByteRate(row.GetTcpStats().GetWriteBytesTotal(), options.timeWindow),
+		)
+	}
+	w.Flush()
+
+	fmt.Fprint(out, renderStats(buffer, &options.statOptionsBase))
+}
+
+// jsonEgress represents the JSON output of `linkerd viz egress`. Using
+// pointers there where the value is NA and the corresponding json is null
+type jsonEgress struct {
+	Name           string   `json:"name"`
+	Namespace      string   `json:"namespace"`
+	Type           string   `json:"type"`
+	Authority      string   `json:"authority"`
+	Success        *float64 `json:"success"`
+	Rps            *float64 `json:"rps"`
+	LatencyMSp50   *uint64  `json:"latency_ms_p50"`
+	LatencyMSp95   *uint64  `json:"latency_ms_p95"`
+	LatencyMSp99   *uint64  `json:"latency_ms_p99"`
+	TLS            *float64 `json:"tls"`
+	TCPConnections uint64   `json:"tcp_open_connections"`
+	TCPReadBytes   float64  `json:"tcp_read_bytes_rate"`
+	TCPWriteBytes  float64  `json:"tcp_write_bytes_rate"`
+}
+
+func printEgressJSON(rows []*pb.EgressRow, out io.Writer, options *egressOptions) {
+	// avoid nil initialization so that if there are no stats it gets
+	// marshalled as an empty array vs null
+	entries := []*jsonEgress{}
+	for _, row := range rows {
+		entry := &jsonEgress{
+			Name:           row.GetResource().GetName(),
+			Namespace:      row.GetResource().GetNamespace(),
+			Type:           row.GetResource().GetType(),
+			Authority:      row.GetAuthority(),
+			TCPConnections: row.GetTcpStats().GetOpenConnections(),
+			TCPReadBytes:   getByteRate(row.GetTcpStats().GetReadBytesTotal(), options.timeWindow),
+			TCPWriteBytes:  getByteRate(row.GetTcpStats().GetWriteBytesTotal(), options.timeWindow),
+		}
+		if tls, ok := egressTLSRate(row); ok {
+			stats := row.GetStats()
+			success := getSuccessRate(stats.GetSuccessCount(), stats.GetFailureCount())
+			rps := getRequestRate(stats.GetSuccessCount(), stats.GetFailureCount(), options.timeWindow)
+			p50, p95, p99 := stats.GetLatencyMsP50(), stats.GetLatencyMsP95(), stats.GetLatencyMsP99()
+			entry.Success = &success
+			entry.Rps = &rps
+			entry.LatencyMSp50 = &p50
+			entry.LatencyMSp95 = &p95
+			entry.LatencyMSp99 = &p99
+			entry.TLS = &tls
+		}
+		entries = append(entries, entry)
+	}
+
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error marshalling JSON: %s\n", err)
+		return
+	}
+	fmt.Fprintf(out, "%s\n", b)
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"testing"
+
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	api "github.com/linkerd/linkerd2/viz/metrics-api"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+)
+
+func egressRow(name, authority string, success, failure, tls uint64) *pb.EgressRow {
+	return &pb.EgressRow{
+		Resource: &pb.Resource{
+			Namespace: "emojivoto",
+			Type:      pkgK8s.Deployment,
+			Name:      name,
+		},
+		Authority: authority,
+		Stats: &pb.BasicStats{
+			SuccessCount: success,
+			FailureCount: failure,
+			LatencyMsP50: 40,
+			LatencyMsP95: 120,
+			LatencyMsP99: 300,
+		},
+		TcpStats: &pb.TcpStats{
+			OpenConnections: 2,
+			ReadBytesTotal:  61440,
+			WriteBytesTotal: 12288,
+		},
+		TlsRequestCount: tls,
+	}
+}
+
+func genEgressResponse() *pb.EgressResponse {
+	return &pb.EgressResponse{
+		Response: &pb.EgressResponse_Ok_{
+			Ok: &pb.EgressResponse_Ok{
+				Rows: []*pb.EgressRow{
+					egressRow("voting", "api.example.com:443", 120, 0, 120),
+					egressRow("web", "10.0.0.1:5432", 0, 0, 0),
+					egressRow("web", "httpbin.org:80", 90, 30, 0),
+				},
+			},
+		},
+	}
+}
+
+func TestEgress(t *testing.T) {
+	t.Run("Returns the traffic to external destinations", func(t *testing.T) {
+		options := newEgressOptions()
+		options.namespace = "emojivoto"
+		testEgressCall(t, options, "egress_output.golden")
+	})
+
+	t.Run("Returns the traffic to external destinations (json)", func(t *testing.T) {
+		options := newEgressOptions()
+		options.namespace = "emojivoto"
+		options.outputFormat = jsonOutput
+		testEgressCall(t, options, "egress_output_json.golden")
+	})
+
+	t.Run("Returns the workloads of all namespaces", func(t *testing.T) {
+		options := newEgressOptions()
+		options.namespace = "emojivoto"
+		options.allNamespaces = true
+
+		req, err := buildEgressRequest("deploy", options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if req.GetResource().GetNamespace() != "" {
+			t.Fatalf("Expected no namespace, got %s", req.GetResource().GetNamespace())
+		}
+	})
+
+	t.Run("Returns an error if request is for service", func(t *testing.T) {
+		options := newEgressOptions()
+		expectedError := "Resource type is not supported: service"
+
+		_, err := buildEgressRequest("svc/web", options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+
+	t.Run("Returns an error if outputFormat specified is not table or json", func(t *testing.T) {
+		options := newEgressOptions()
+		options.outputFormat = wideOutput
+		expectedError := "--output supports table and json"
+
+		_, err := buildEgressRequest("deploy", options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+}
+
+func testEgressCall(t *testing.T, options *egressOptions, file string) {
+	t.Helper()
+	mockClient := &api.MockAPIClient{}
+	mockClient.EgressResponseToReturn = genEgressResponse()
+
+	req, err := buildEgressRequest("deploy", options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	resp, err := requestEgressFromAPI(mockClient, req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	testDataDiffer.DiffTestdata(t, file, renderEgress(resp.GetOk().GetRows(), options))
+}
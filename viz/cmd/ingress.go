@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	pkgcmd "github.com/linkerd/linkerd2/pkg/cmd"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/linkerd/linkerd2/viz/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+type ingressOptions struct {
+	statOptionsBase
+	allNamespaces bool
+}
+
+func newIngressOptions() *ingressOptions {
+	return &ingressOptions{
+		statOptionsBase: *newStatOptionsBase(),
+	}
+}
+
+// NewCmdIngress creates a new cobra command `ingress` for displaying the
+// traffic routed by each Ingress and Gateway
+func NewCmdIngress() *cobra.Command {
+	options := newIngressOptions()
+
+	cmd := &cobra.Command{
+		Use:   "ingress [flags]",
+		Short: "Display the traffic routed by the Ingresses and Gateways",
+		Long: `Display the traffic routed by the Ingresses and Gateways.
+
+  The traffic sent by the meshed ingress controllers to the services is
+  attributed to the Ingresses, and to the Gateway API Gateways through their
+  HTTPRoutes, routing its host to those services. The routes naming the host
+  take precedence over the ones for any host, and the Ingresses of another
+  class than the controller aren't considered.
+
+  The latencies of a resource are the highest ones of its backend services.`,
+		Example: `  # Get the traffic of the Ingresses and Gateways of the emojivoto namespace.
+  linkerd viz ingress -n emojivoto
+
+  # Get the traffic of the Ingresses and Gateways of all the namespaces over the last 10 minutes.
+  linkerd viz ingress -A -t 10m`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.namespace == "" {
+				options.namespace = pkgcmd.GetDefaultNamespace(kubeconfigPath, kubeContext)
+			}
+
+			req, err := buildIngressResourceStatsRequest(options)
+			if err != nil {
+				return fmt.Errorf("Error creating ingress request: %s", err)
+			}
+
+			client := api.CheckClientOrExit(healthcheck.Options{
+				ControlPlaneNamespace: controlPlaneNamespace,
+				KubeConfig:            kubeconfigPath,
+				Impersonate:           impersonate,
+				ImpersonateGroup:      impersonateGroup,
+				KubeContext:           kubeContext,
+				APIAddr:               apiAddr,
+			})
+
+			resp, err := requestIngressResourceStatsFromAPI(client, req)
+			if err != nil {
+				fmt.Fprint(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+
+			_, err = fmt.Print(renderIngressResourceStats(resp.GetOk().GetRows(), options))
+			return err
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the Ingresses and Gateways")
+	cmd.PersistentFlags().BoolVarP(&options.allNamespaces, "all-namespaces", "A", options.allNamespaces, "If present, returns the Ingresses and Gateways of all namespaces, ignoring the \"--namespace\" flag")
+	cmd.PersistentFlags().StringVarP(&options.timeWindow, "time-window", "t", options.timeWindow, "Stat window (for example: \"10s\", \"1m\", \"10m\", \"1h\")")
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; one of: \"table\" or \"json\"")
+
+	pkgcmd.ConfigureNamespaceFlagCompletion(
+		cmd, []string{"namespace"},
+		kubeconfigPath, impersonate, impersonateGroup, kubeContext)
+	return cmd
+}
+
+func buildIngressResourceStatsRequest(options *ingressOptions) (*pb.IngressResourceStatsRequest, error) {
+	switch options.outputFormat {
+	case tableOutput, jsonOutput:
+	default:
+		return nil, fmt.Errorf("--output supports %s and %s", tableOutput, jsonOutput)
+	}
+
+	namespace := options.namespace
+	if options.allNamespaces {
+		namespace = ""
+	}
+	return &pb.IngressResourceStatsRequest{
+		Namespace:  namespace,
+		TimeWindow: options.timeWindow,
+	}, nil
+}
+
+func requestIngressResourceStatsFromAPI(client pb.ApiClient, req *pb.IngressResourceStatsRequest) (*pb.IngressResourceStatsResponse, error) {
+	resp, err := client.IngressResourceStats(context.Background(), req)
+	if err != nil {
+		return nil, fmt.Errorf("IngressResourceStats API error: %v", err)
+	}
+	if e := resp.GetError(); e != nil {
+		return nil, fmt.Errorf("IngressResourceStats API response error: %v", e.Error)
+	}
+	return resp, nil
+}
+
+func renderIngressResourceStats(rows []*pb.IngressResourceStatsRow, options *ingressOptions) string {
+	var buffer bytes.Buffer
+	switch options.outputFormat {
+	case jsonOutput:
+		printIngressResourceStatsJSON(rows, &buffer, options)
+	default:
+		printIngressResourceStatsTable(rows, &buffer, options)
+	}
+	return buffer.String()
+}
+
+func ingressResourceName(row *pb.IngressResourceStatsRow) string {
+	return row.GetResource().GetType() + "/" + row.GetResource().GetName()
+}
+
+// ingressResourceBackends returns the names of the backend services of the
+// resource, qualified by their namespace when it's not the resource's one
+func ingressResourceBackends(row *pb.IngressResourceStatsRow) []string {
+	backends := []string{}
+	for _, backend := range row.GetBackends() {
+		name := backend.GetName()
+		if backend.GetNamespace() != row.GetResource().GetNamespace() {
+			name = backend.GetNamespace() + "/" + name
+		}
+		backends = append(backends, name)
+	}
+	return backends
+}
+
+func printIngressResourceStatsTable(rows []*pb.IngressResourceStatsRow, out io.Writer, options *ingressOptions) {
+	if len(rows) == 0 {
+		fmt.Fprintln(os.Stderr, "No Ingresses or Gateways found.")
+		return
+	}
+
+	nameWidth := len("NAME")
+	for _, row := range rows {
+		if width := len(ingressResourceName(row)); width > nameWidth {
+			nameWidth = width
+		}
+	}
+	// template for left-aligning the name column
+	nameTemplate := fmt.Sprintf("%%-%ds", nameWidth)
+
+	var buffer bytes.Buffer
+	w := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', tabwriter.AlignRight)
+	headers := []string{
+		fmt.Sprintf(nameTemplate, "NAME"),
+		"NAMESPACE",
+		"CLASS",
+		"BACKENDS",
+		"SUCCESS",
+		"RPS",
+		"LATENCY_P50",
+		"LATENCY_P95",
+		"LATENCY_P99\t", // trailing \t is required to format last column
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	for _, row := range rows {
+		class := row.GetIngressClass()
+		if class == "" {
+			class = "-"
+		}
+		backends := strings.Join(ingressResourceBackends(row), ",")
+		if backends == "" {
+			backends = "-"
+		}
+		requests := "-\t-\t-\t-\t-"
+		if stats := row.GetStats(); stats != nil {
+			requests = fmt.Sprintf("%.2f%%\t%.1frps\t%dms\t%dms\t%dms",
+				getSuccessRate(stats.GetSuccessCount(), stats.GetFailureCount())*100,
+				getRequestRate(stats.GetSuccessCount(), stats.GetFailureCount(), options.timeWindow),
+				stats.GetLatencyMsP50(),
+				stats.GetLatencyMsP95(),
+				stats.GetLatencyMsP99(),
+			)
+		}
+		fmt.Fprintf(w, nameTemplate+"\t%s\t%s\t%s\t%s\t\n",
+			ingressResourceName(row),
+			row.GetResource().GetNamespace(),
+			class,
+			backends,
+			requests,
+		)
+	}
+	w.Flush()
+
+	fmt.Fprint(out, renderStats(buffer, &options.statOptionsBase))
+}
+
+// jsonIngressResourceStats represents the JSON output of `linkerd viz
+// ingress`. Using pointers there where the value is NA and the corresponding
+// json is null
+type jsonIngressResourceStats struct {
+	Name         string   `json:"name"`
+	Namespace    string   `json:"namespace"`
+	Type         string   `json:"type"`
+	Class        string   `json:"class"`
+	Backends     []string `json:"backends"`
+	Success      *float64 `json:"success"`
+	Rps          *float64 `json:"rps"`
+	LatencyMSp50 *uint64  `json:"latency_ms_p50"`
+	LatencyMSp95 *uint64  `json:"latency_ms_p95"`
+	LatencyMSp99 *uint64  `json:"latency_ms_p99"`
+}
+
+func printIngressResourceStatsJSON(rows []*pb.IngressResourceStatsRow, out io.Writer, options *ingressOptions) {
+	// avoid nil initialization so that if there are no stats it gets
+	// marshalled as an empty array vs null
+	entries := []*jsonIngressResourceStats{}
+	for _, row := range rows {
+		entry := &jsonIngressResourceStats{
+			Name:      row.GetResource().GetName(),
+			Namespace: row.GetResource().GetNamespace(),
+			Type:      row.GetResource().GetType(),
+			Class:     row.GetIngressClass(),
+			Backends:  ingressResourceBackends(row),
+		}
+		if stats := row.GetStats(); stats != nil {
+			success := getSuccessRate(stats.GetSuccessCount(), stats.GetFailureCount())
+			rps := getRequestRate(stats.GetSuccessCount(), stats.GetFailureCount(), options.timeWindow)
+			p50, p95, p99 := stats.GetLatencyMsP50(), stats.GetLatencyMsP95(), stats.GetLatencyMsP99()
+			entry.Success = &success
+			entry.Rps = &rps
+			entry.LatencyMSp50 = &p50
+			entry.LatencyMSp95 = &p95
+			entry.LatencyMSp99 = &p99
+		}
+		entries = append(entries, entry)
+	}
+
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error marshalling JSON: %s\n", err)
+		return
+	}
+	fmt.Fprintf(out, "%s\n", b)
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"testing"
+
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	api "github.com/linkerd/linkerd2/viz/metrics-api"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+)
+
+func ingressResourceStatsRow(kind, name, class string, backends []*pb.Resource, stats *pb.BasicStats) *pb.IngressResourceStatsRow {
+	return &pb.IngressResourceStatsRow{
+		Resource: &pb.Resource{
+			Namespace: "emojivoto",
+			Type:      kind,
+			Name:      name,
+		},
+		IngressClass: class,
+		Backends:     backends,
+		Stats:        stats,
+	}
+}
+
+func genIngressResourceStatsResponse() *pb.IngressResourceStatsResponse {
+	web := &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Service, Name: "web-svc"}
+	emoji := &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Service, Name: "emoji-svc"}
+	auth := &pb.Resource{Namespace: "auth", Type: pkgK8s.Service, Name: "auth-svc"}
+
+	return &pb.IngressResourceStatsResponse{
+		Response: &pb.IngressResourceStatsResponse_Ok_{
+			Ok: &pb.IngressResourceStatsResponse_Ok{
+				Rows: []*pb.IngressResourceStatsRow{
+					ingressResourceStatsRow("gateway", "internal", "nginx", []*pb.Resource{}, nil),
+					ingressResourceStatsRow("gateway", "public", "nginx", []*pb.Resource{auth, emoji}, &pb.BasicStats{
+						SuccessCount: 90,
+						FailureCount: 30,
+						LatencyMsP50: 20,
+						LatencyMsP95: 80,
+						LatencyMsP99: 150,
+					}),
+					ingressResourceStatsRow("ingress", "web", "", []*pb.Resource{web}, &pb.BasicStats{
+						SuccessCount: 120,
+						LatencyMsP50: 10,
+						LatencyMsP95: 40,
+						LatencyMsP99: 90,
+					}),
+				},
+			},
+		},
+	}
+}
+
+func TestIngress(t *testing.T) {
+	t.Run("Returns the traffic of the Ingresses and Gateways", func(t *testing.T) {
+		options := newIngressOptions()
+		options.namespace = "emojivoto"
+		testIngressCall(t, options, "ingress_output.golden")
+	})
+
+	t.Run("Returns the traffic of the Ingresses and Gateways (json)", func(t *testing.T) {
+		options := newIngressOptions()
+		options.namespace = "emojivoto"
+		options.outputFormat = jsonOutput
+		testIngressCall(t, options, "ingress_output_json.golden")
+	})
+
+	t.Run("Returns the Ingresses and Gateways of all namespaces", func(t *testing.T) {
+		options := newIngressOptions()
+		options.namespace = "emojivoto"
+		options.allNamespaces = true
+
+		req, err := buildIngressResourceStatsRequest(options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if req.GetNamespace() != "" {
+			t.Fatalf("Expected no namespace, got %s", req.GetNamespace())
+		}
+	})
+
+	t.Run("Returns an error if outputFormat specified is not table or json", func(t *testing.T) {
+		options := newIngressOptions()
+		options.outputFormat = wideOutput
+		expectedError := "--output supports table and json"
+
+		_, err := buildIngressResourceStatsRequest(options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+}
+
+func testIngressCall(t *testing.T, options *ingressOptions, file string) {
+	t.Helper()
+	mockClient := &api.MockAPIClient{}
+	mockClient.IngressResourceStatsResponseToReturn = genIngressResourceStatsResponse()
+
+	req, err := buildIngressResourceStatsRequest(options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	resp, err := requestIngressResourceStatsFromAPI(mockClient, req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	testDataDiffer.DiffTestdata(t, file, renderIngressResourceStats(resp.GetOk().GetRows(), options))
+}
@@ -93,6 +93,22 @@ func TestRender(t *testing.T) {
 			},
 			"install_grafana_disabled.golden",
 		},
+		{
+			map[string]interface{}{
+				"dashboard": map[string]interface{}{"multiclusterNamespace": "linkerd-multicluster"},
+			},
+			"install_multicluster_dashboard.golden",
+		},
+		{
+			map[string]interface{}{
+				"adminTLS": map[string]interface{}{
+					"secretName":        "linkerd-viz-admin-tls",
+					"requireClientCert": true,
+				},
+				"prometheus": map[string]interface{}{"adminTLSClientSecret": "prometheus-admin-tls"},
+			},
+			"install_admin_tls.golden",
+		},
 	}
 
 	for i, tc := range testCases {
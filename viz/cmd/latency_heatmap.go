@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	pkgcmd "github.com/linkerd/linkerd2/pkg/cmd"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/linkerd/linkerd2/viz/pkg/api"
+	pkgUtil "github.com/linkerd/linkerd2/viz/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// heatmapShades are the characters of the heatmap cells, from the emptiest to
+// the fullest
+const heatmapShades = " .:-=+*#%@"
+
+type latencyHeatmapOptions struct {
+	namespace    string
+	timeRange    string
+	step         string
+	outputFormat string
+}
+
+func newLatencyHeatmapOptions() *latencyHeatmapOptions {
+	return &latencyHeatmapOptions{
+		timeRange:    "1h",
+		step:         "1m",
+		outputFormat: tableOutput,
+	}
+}
+
+// NewCmdLatencyHeatmap creates a new cobra command `latency-heatmap` for
+// displaying the latency distribution of a resource over time
+func NewCmdLatencyHeatmap() *cobra.Command {
+	options := newLatencyHeatmapOptions()
+
+	cmd := &cobra.Command{
+		Use:   "latency-heatmap [flags] (RESOURCE)",
+		Short: "Display the latency distribution of a resource over time",
+		Long: `Display the latency distribution of a resource over time.
+
+  The RESOURCE argument specifies the resource whose inbound responses are
+  reported, e.g. deploy/web, or all the resources of a type, e.g. deploy.
+
+  Each row of the heatmap is a latency bucket, the slowest on top, and each
+  column a step of the time range. The darker a cell, the more responses fall
+  in its bucket during its step.`,
+		Example: `  # Get the latency heatmap of the web deployment over the last hour.
+  linkerd viz latency-heatmap deploy/web -n emojivoto
+
+  # Get the latency heatmap of the web deployment over the last 6 hours, by steps of 5 minutes.
+  linkerd viz latency-heatmap deploy/web -n emojivoto --time-range 6h --step 5m`,
+		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			if options.namespace == "" {
+				options.namespace = pkgcmd.GetDefaultNamespace(kubeconfigPath, kubeContext)
+			}
+
+			cc := k8s.NewCommandCompletion(k8sAPI, options.namespace)
+
+			results, err := cc.Complete(args, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return results, cobra.ShellCompDirectiveDefault
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.namespace == "" {
+				options.namespace = pkgcmd.GetDefaultNamespace(kubeconfigPath, kubeContext)
+			}
+
+			req, err := buildLatencyHeatmapRequest(args[0], options)
+			if err != nil {
+				return fmt.Errorf("Error creating latency heatmap request: %s", err)
+			}
+
+			client := api.CheckClientOrExit(healthcheck.Options{
+				ControlPlaneNamespace: controlPlaneNamespace,
+				KubeConfig:            kubeconfigPath,
+				Impersonate:           impersonate,
+				ImpersonateGroup:      impersonateGroup,
+				KubeContext:           kubeContext,
+				APIAddr:               apiAddr,
+			})
+
+			resp, err := requestLatencyHeatmapFromAPI(client, req)
+			if err != nil {
+				fmt.Fprint(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+
+			_, err = fmt.Print(renderLatencyHeatmap(resp.GetOk(), options))
+			return err
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the specified resource")
+	cmd.PersistentFlags().StringVar(&options.timeRange, "time-range", options.timeRange, "How far back the heatmap starts (for example: \"30m\", \"1h\", \"6h\")")
+	cmd.PersistentFlags().StringVar(&options.step, "step", options.step, "Duration covered by each column of the heatmap (for example: \"30s\", \"1m\", \"5m\")")
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; one of: \"table\" or \"json\"")
+
+	pkgcmd.ConfigureNamespaceFlagCompletion(
+		cmd, []string{"namespace"},
+		kubeconfigPath, impersonate, impersonateGroup, kubeContext)
+	return cmd
+}
+
+func buildLatencyHeatmapRequest(arg string, options *latencyHeatmapOptions) (*pb.LatencyHeatmapRequest, error) {
+	switch options.outputFormat {
+	case tableOutput, jsonOutput:
+	default:
+		return nil, fmt.Errorf("--output supports %s and %s", tableOutput, jsonOutput)
+	}
+
+	target, err := pkgUtil.BuildResource(options.namespace, arg)
+	if err != nil {
+		return nil, err
+	}
+	switch target.GetType() {
+	case k8s.Service, k8s.Server, k8s.ServerAuthorization, k8s.HTTPRoute, k8s.All:
+		return nil, fmt.Errorf("Resource type is not supported: %s", target.GetType())
+	}
+
+	return &pb.LatencyHeatmapRequest{
+		Resource:  target,
+		TimeRange: options.timeRange,
+		Step:      options.step,
+	}, nil
+}
+
+func requestLatencyHeatmapFromAPI(client pb.ApiClient, req *pb.LatencyHeatmapRequest) (*pb.LatencyHeatmapResponse, error) {
+	resp, err := client.LatencyHeatmap(context.Background(), req)
+	if err != nil {
+		return nil, fmt.Errorf("LatencyHeatmap API error: %+v", err)
+	}
+	if e := resp.GetError(); e != nil {
+		return nil, fmt.Errorf("LatencyHeatmap API response error: %+v", e.Error)
+	}
+	return resp, nil
+}
+
+func renderLatencyHeatmap(heatmap *pb.LatencyHeatmapResponse_Ok, options *latencyHeatmapOptions) string {
+	var buffer bytes.Buffer
+	switch options.outputFormat {
+	case jsonOutput:
+		printLatencyHeatmapJSON(heatmap, &buffer)
+	default:
+		printLatencyHeatmap(heatmap, &buffer, options)
+	}
+	return buffer.String()
+}
+
+func printLatencyHeatmap(heatmap *pb.LatencyHeatmapResponse_Ok, out io.Writer, options *latencyHeatmapOptions) {
+	columns := heatmap.GetColumns()
+	maxCount := uint64(0)
+	// the buckets above the slowest response are left out
+	rows := 0
+	for _, column := range columns {
+		for i, count := range column.GetCounts() {
+			if count > maxCount {
+				maxCount = count
+			}
+			if count > 0 && i+1 > rows {
+				rows = i + 1
+			}
+		}
+	}
+	if maxCount == 0 {
+		fmt.Fprintln(out, "No traffic found.")
+		return
+	}
+
+	labels := make([]string, rows)
+	labelWidth := 0
+	for i := range labels {
+		labels[i] = latencyBucketLabel(heatmap.GetBuckets()[i])
+		if len(labels[i]) > labelWidth {
+			labelWidth = len(labels[i])
+		}
+	}
+
+	for i := rows - 1; i >= 0; i-- {
+		var row strings.Builder
+		for _, column := range columns {
+			count := uint64(0)
+			if i < len(column.GetCounts()) {
+				count = column.GetCounts()[i]
+			}
+			row.WriteByte(heatmapShade(count, maxCount))
+		}
+		fmt.Fprintf(out, "%*s |%s\n", labelWidth, labels[i], row.String())
+	}
+	fmt.Fprintf(out, "%*s +%s\n", labelWidth, "", strings.Repeat("-", len(columns)))
+	fmt.Fprintf(out, "%s to %s, by steps of %s\n", columns[0].GetTimestamp(), columns[len(columns)-1].GetTimestamp(), options.step)
+	fmt.Fprintf(out, "'%c' 1 to '%c' %d responses\n", heatmapShades[1], heatmapShades[len(heatmapShades)-1], maxCount)
+}
+
+// latencyBucketLabel returns the label of the row of a bucket, given its upper
+// bound
+func latencyBucketLabel(bucket string) string {
+	if bucket == "+Inf" {
+		return bucket
+	}
+	return fmt.Sprintf("<=%sms", bucket)
+}
+
+// heatmapShade returns the character of a cell, scaled against the fullest
+// cell of the heatmap; only empty cells are blank
+func heatmapShade(count, maxCount uint64) byte {
+	if count == 0 {
+		return heatmapShades[0]
+	}
+	levels := float64(len(heatmapShades) - 1)
+	return heatmapShades[int(math.Ceil(float64(count)/float64(maxCount)*levels))]
+}
+
+// jsonLatencyHeatmap represents the heatmap in the JSON output, as a matrix
+// of counts indexed by timestamp, then by bucket
+type jsonLatencyHeatmap struct {
+	Buckets    []string   `json:"buckets"`
+	Timestamps []string   `json:"timestamps"`
+	Counts     [][]uint64 `json:"counts"`
+}
+
+func printLatencyHeatmapJSON(heatmap *pb.LatencyHeatmapResponse_Ok, out io.Writer) {
+	// avoid nil initialization so that an empty heatmap gets marshalled with
+	// empty arrays vs null
+	entry := jsonLatencyHeatmap{
+		Buckets:    append([]string{}, heatmap.GetBuckets()...),
+		Timestamps: []string{},
+		Counts:     [][]uint64{},
+	}
+	for _, column := range heatmap.GetColumns() {
+		entry.Timestamps = append(entry.Timestamps, column.GetTimestamp())
+		entry.Counts = append(entry.Counts, column.GetCounts())
+	}
+
+	b, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error marshalling JSON: %s\n", err)
+		return
+	}
+	fmt.Fprintf(out, "%s\n", b)
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"testing"
+
+	api "github.com/linkerd/linkerd2/viz/metrics-api"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+)
+
+func genLatencyHeatmapResponse() *pb.LatencyHeatmapResponse {
+	return &pb.LatencyHeatmapResponse{
+		Response: &pb.LatencyHeatmapResponse_Ok_{
+			Ok: &pb.LatencyHeatmapResponse_Ok{
+				Buckets: []string{"5", "10", "50", "100", "500", "+Inf"},
+				Columns: []*pb.LatencyHeatmapColumn{
+					{Timestamp: "2021-10-01T10:00:00Z", Counts: []uint64{40, 20, 2, 0, 0, 0}},
+					{Timestamp: "2021-10-01T10:01:00Z", Counts: []uint64{38, 22, 5, 1, 0, 0}},
+					{Timestamp: "2021-10-01T10:02:00Z", Counts: []uint64{10, 30, 18, 9, 0, 0}},
+					{Timestamp: "2021-10-01T10:03:00Z", Counts: []uint64{2, 12, 25, 20, 4, 0}},
+					{Timestamp: "2021-10-01T10:04:00Z", Counts: []uint64{35, 25, 3, 0, 0, 0}},
+				},
+			},
+		},
+	}
+}
+
+func TestLatencyHeatmap(t *testing.T) {
+	t.Run("Returns a latency heatmap", func(t *testing.T) {
+		options := newLatencyHeatmapOptions()
+		options.namespace = "emojivoto"
+		testLatencyHeatmapCall(t, options, "latency_heatmap_output.golden")
+	})
+
+	t.Run("Returns a latency heatmap (json)", func(t *testing.T) {
+		options := newLatencyHeatmapOptions()
+		options.namespace = "emojivoto"
+		options.outputFormat = jsonOutput
+		testLatencyHeatmapCall(t, options, "latency_heatmap_output_json.golden")
+	})
+
+	t.Run("Returns an error if request is for service", func(t *testing.T) {
+		options := newLatencyHeatmapOptions()
+		expectedError := "Resource type is not supported: service"
+
+		_, err := buildLatencyHeatmapRequest("svc/web", options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+
+	t.Run("Returns an error if outputFormat specified is not table or json", func(t *testing.T) {
+		options := newLatencyHeatmapOptions()
+		options.outputFormat = wideOutput
+		expectedError := "--output supports table and json"
+
+		_, err := buildLatencyHeatmapRequest("deploy/web", options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+
+	t.Run("Reports the absence of traffic", func(t *testing.T) {
+		output := renderLatencyHeatmap(&pb.LatencyHeatmapResponse_Ok{}, newLatencyHeatmapOptions())
+		if output != "No traffic found.\n" {
+			t.Fatalf("Expected no traffic, got [%s]", output)
+		}
+	})
+}
+
+func testLatencyHeatmapCall(t *testing.T, options *latencyHeatmapOptions, file string) {
+	t.Helper()
+	mockClient := &api.MockAPIClient{}
+	mockClient.LatencyHeatmapToReturn = genLatencyHeatmapResponse()
+
+	req, err := buildLatencyHeatmapRequest("deploy/web", options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	resp, err := requestLatencyHeatmapFromAPI(mockClient, req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	testDataDiffer.DiffTestdata(t, file, renderLatencyHeatmap(resp.GetOk(), options))
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRenderPrometheusRules(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := renderPrometheusRules(&buf, prometheusRulesOptions{}); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		testDataDiffer.DiffTestdata(t, "prometheus_rules.golden", buf.String())
+	})
+
+	t.Run("invalid metric prefix", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := renderPrometheusRules(&buf, prometheusRulesOptions{metricPrefix: "l5d-"}); err == nil {
+			t.Fatalf("Expected an error, got %s", buf.String())
+		}
+	})
+}
@@ -24,9 +24,10 @@ const (
 	defaultLinkerdNamespace = "linkerd"
 	maxRps                  = 100.0
 
-	jsonOutput  = healthcheck.JSONOutput
-	tableOutput = healthcheck.TableOutput
-	wideOutput  = healthcheck.WideOutput
+	jsonOutput     = healthcheck.JSONOutput
+	tableOutput    = healthcheck.TableOutput
+	wideOutput     = healthcheck.WideOutput
+	templateOutput = "template"
 )
 
 var (
@@ -80,10 +81,16 @@ func NewCmdViz() *cobra.Command {
 	vizCmd.AddCommand(NewCmdAuthz())
 	vizCmd.AddCommand(NewCmdCheck())
 	vizCmd.AddCommand(NewCmdDashboard())
+	vizCmd.AddCommand(newCmdDashboards())
+	vizCmd.AddCommand(NewCmdDependencies())
 	vizCmd.AddCommand(NewCmdEdges())
+	vizCmd.AddCommand(NewCmdEgress())
+	vizCmd.AddCommand(NewCmdIngress())
 	vizCmd.AddCommand(newCmdInstall())
+	vizCmd.AddCommand(NewCmdLatencyHeatmap())
 	vizCmd.AddCommand(newCmdList())
 	vizCmd.AddCommand(newCmdProfile())
+	vizCmd.AddCommand(newCmdPrometheusRules())
 	vizCmd.AddCommand(NewCmdRoutes())
 	vizCmd.AddCommand(NewCmdStat())
 	vizCmd.AddCommand(NewCmdTap())
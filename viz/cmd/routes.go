@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"sort"
 	"strings"
@@ -30,6 +32,9 @@ type routesOptions struct {
 	toNamespace   string
 	dstIsService  bool
 	labelSelector string
+	watch         bool
+	watchInterval time.Duration
+	trendPoints   uint32
 }
 
 type routeRowStats struct {
@@ -37,14 +42,24 @@ type routeRowStats struct {
 	actualRequestRate float64
 	actualSuccessRate float64
 	hasRequestData    bool
+	trend             []*pb.BasicStats
 }
 
+// sparklineTicks are the characters of the trend column, from the lowest to
+// the highest success rate
+var sparklineTicks = []rune("▁▂▃▄▅▆▇█")
+
+// clearScreen moves the cursor to the top left corner of the terminal and
+// clears it, so that each refresh of --watch replaces the previous one
+const clearScreen = "\033[H\033[2J"
+
 func newRoutesOptions() *routesOptions {
 	return &routesOptions{
 		statOptionsBase: *newStatOptionsBase(),
 		toResource:      "",
 		toNamespace:     "",
 		labelSelector:   "",
+		watchInterval:   2 * time.Second,
 	}
 }
 
@@ -62,7 +77,10 @@ This command will only display traffic which is sent to a service that has a Ser
   linkerd viz routes service/webapp -n test
 
   # Routes for calls from the traffic deployment to the webapp service in the test namespace.
-  linkerd viz routes deploy/traffic -n test --to svc/webapp`,
+  linkerd viz routes deploy/traffic -n test --to svc/webapp
+
+  # Routes for the webapp service, refreshed every 2 seconds, with the success rate of each of the last 10 minutes.
+  linkerd viz routes service/webapp -n test --watch --trend 10`,
 		Args:      cobra.ExactArgs(1),
 		ValidArgs: pkgUtil.ValidTargets,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -74,18 +92,21 @@ This command will only display traffic which is sent to a service that has a Ser
 				return fmt.Errorf("error creating metrics request while making routes request: %v", err)
 			}
 
-			output, err := requestRouteStatsFromAPI(
-				api.CheckClientOrExit(healthcheck.Options{
-					ControlPlaneNamespace: controlPlaneNamespace,
-					KubeConfig:            kubeconfigPath,
-					Impersonate:           impersonate,
-					ImpersonateGroup:      impersonateGroup,
-					KubeContext:           kubeContext,
-					APIAddr:               apiAddr,
-				}),
-				req,
-				options,
-			)
+			client := api.CheckClientOrExit(healthcheck.Options{
+				ControlPlaneNamespace: controlPlaneNamespace,
+				KubeConfig:            kubeconfigPath,
+				Impersonate:           impersonate,
+				ImpersonateGroup:      impersonateGroup,
+				KubeContext:           kubeContext,
+				APIAddr:               apiAddr,
+			})
+
+			if options.watch {
+				watchRouteStats(client, req, options, os.Stdout)
+				return nil
+			}
+
+			output, err := requestRouteStatsFromAPI(client, req, options)
 			if err != nil {
 				fmt.Fprint(os.Stderr, err.Error())
 				os.Exit(1)
@@ -103,6 +124,9 @@ This command will only display traffic which is sent to a service that has a Ser
 	cmd.PersistentFlags().StringVar(&options.toNamespace, "to-namespace", options.toNamespace, "Sets the namespace used to lookup the \"--to\" resource; by default the current \"--namespace\" is used")
 	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, fmt.Sprintf("Output format; one of: \"%s\", \"%s\", or \"%s\"", tableOutput, wideOutput, jsonOutput))
 	cmd.PersistentFlags().StringVarP(&options.labelSelector, "selector", "l", options.labelSelector, "Selector (label query) to filter on, supports '=', '==', and '!='")
+	cmd.PersistentFlags().BoolVarP(&options.watch, "watch", "w", options.watch, "Refresh the stats until interrupted")
+	cmd.PersistentFlags().DurationVar(&options.watchInterval, "watch-interval", options.watchInterval, "How often the stats are refreshed with --watch")
+	cmd.PersistentFlags().Uint32Var(&options.trendPoints, "trend", options.trendPoints, "Number of time windows, the last one ending now, whose success rates are shown as a sparkline in a TREND column (none when 0)")
 
 	pkgcmd.ConfigureNamespaceFlagCompletion(
 		cmd, []string{"namespace", "to-namespace"},
@@ -122,6 +146,22 @@ func requestRouteStatsFromAPI(client pb.ApiClient, req *pb.TopRoutesRequest, opt
 	return renderRouteStats(resp, options), nil
 }
 
+// watchRouteStats renders the route stats every watch interval, replacing the
+// previous ones, until the command is interrupted. Errors are rendered in
+// place of the stats, so that a transient failure doesn't end the watch.
+func watchRouteStats(client pb.ApiClient, req *pb.TopRoutesRequest, options *routesOptions, out io.Writer) {
+	ticker := time.NewTicker(options.watchInterval)
+	defer ticker.Stop()
+	for {
+		output, err := requestRouteStatsFromAPI(client, req, options)
+		if err != nil {
+			output = err.Error() + "\n"
+		}
+		fmt.Fprintf(out, "%sEvery %s: %s\n\n%s", clearScreen, options.watchInterval, time.Now().Format("15:04:05"), output)
+		<-ticker.C
+	}
+}
+
 func renderRouteStats(resp *pb.TopRoutesResponse, options *routesOptions) string {
 	var buffer bytes.Buffer
 	w := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', tabwriter.AlignRight)
@@ -155,6 +195,7 @@ func writeRouteStatsToBuffer(resp *pb.TopRoutesResponse, w *tabwriter.Writer, op
 					actualRequestRate: getRequestRate(r.Stats.GetActualSuccessCount(), r.Stats.GetActualFailureCount(), r.TimeWindow),
 					actualSuccessRate: getSuccessRate(r.Stats.GetActualSuccessCount(), r.Stats.GetActualFailureCount()),
 					hasRequestData:    statHasRequestData(r.Stats),
+					trend:             r.GetTrend(),
 				})
 			}
 		}
@@ -217,8 +258,14 @@ func printRouteTable(stats []*routeRowStats, w *tabwriter.Writer, options *route
 	headers = append(headers, []string{
 		"LATENCY_P50",
 		"LATENCY_P95",
-		"LATENCY_P99\t", // trailing \t is required to format last column
+		"LATENCY_P99",
 	}...)
+	outputTrend := options.trendPoints > 0
+	if outputTrend {
+		headers = append(headers, "TREND")
+	}
+	// trailing \t is required to format last column
+	headers[len(headers)-1] += "\t"
 
 	fmt.Fprintln(w, strings.Join(headers, "\t"))
 
@@ -229,14 +276,22 @@ func printRouteTable(stats []*routeRowStats, w *tabwriter.Writer, options *route
 		templateString = templateString + "%.2f%%\t%.1frps\t"
 	}
 	// p50, p95, p99
-	templateString = templateString + "%dms\t%dms\t%dms\t\n"
+	templateString = templateString + "%dms\t%dms\t%dms\t"
 
 	var emptyTemplateString string
 	if outputActual {
-		emptyTemplateString = routeTemplate + "\t%s\t-\t-\t-\t-\t-\t-\t-\t\n"
+		emptyTemplateString = routeTemplate + "\t%s\t-\t-\t-\t-\t-\t-\t-\t"
 	} else {
-		emptyTemplateString = routeTemplate + "\t%s\t-\t-\t-\t-\t-\t\n"
+		emptyTemplateString = routeTemplate + "\t%s\t-\t-\t-\t-\t-\t"
+	}
+
+	if outputTrend {
+		// trend
+		templateString = templateString + "%s\t"
+		emptyTemplateString = emptyTemplateString + "%s\t"
 	}
+	templateString = templateString + "\n"
+	emptyTemplateString = emptyTemplateString + "\n"
 
 	for _, row := range stats {
 
@@ -262,14 +317,42 @@ func printRouteTable(stats []*routeRowStats, w *tabwriter.Writer, options *route
 				row.latencyP95,
 				row.latencyP99,
 			}...)
+			if outputTrend {
+				values = append(values, sparkline(row.trend))
+			}
 
 			fmt.Fprintf(w, templateString, values...)
 		} else {
+			if outputTrend {
+				values = append(values, sparkline(row.trend))
+			}
 			fmt.Fprintf(w, emptyTemplateString, values...)
 		}
 	}
 }
 
+// sparkline renders the success rates of the trend, one character per time
+// window, leaving blank the windows without requests; it returns "-" if none
+// had requests
+func sparkline(trend []*pb.BasicStats) string {
+	var line strings.Builder
+	hasRequestData := false
+	for _, stats := range trend {
+		if stats.GetSuccessCount()+stats.GetFailureCount() == 0 {
+			line.WriteRune(' ')
+			continue
+		}
+		hasRequestData = true
+		successRate := getSuccessRate(stats.GetSuccessCount(), stats.GetFailureCount())
+		tick := int(math.Round(successRate * float64(len(sparklineTicks)-1)))
+		line.WriteRune(sparklineTicks[tick])
+	}
+	if !hasRequestData {
+		return "-"
+	}
+	return line.String()
+}
+
 // getRequestRate calculates request rate from Public API BasicStats.
 func getRequestRate(success, failure uint64, timeWindow string) float64 {
 	windowLength, err := time.ParseDuration(timeWindow)
@@ -302,6 +385,9 @@ type JSONRouteStats struct {
 	LatencyMSp50     *uint64  `json:"latency_ms_p50"`
 	LatencyMSp95     *uint64  `json:"latency_ms_p95"`
 	LatencyMSp99     *uint64  `json:"latency_ms_p99"`
+	// Trend holds the success rate of each time window of the trend, null
+	// for the windows without requests
+	Trend []*float64 `json:"trend,omitempty"`
 }
 
 func printRouteJSON(tables map[string][]*routeRowStats, w *tabwriter.Writer, options *routesOptions) {
@@ -327,6 +413,15 @@ func printRouteJSON(tables map[string][]*routeRowStats, w *tabwriter.Writer, opt
 			entry.LatencyMSp50 = &row.latencyP50
 			entry.LatencyMSp95 = &row.latencyP95
 			entry.LatencyMSp99 = &row.latencyP99
+			if options.trendPoints > 0 {
+				entry.Trend = make([]*float64, len(row.trend))
+				for i, stats := range row.trend {
+					if stats.GetSuccessCount()+stats.GetFailureCount() > 0 {
+						successRate := getSuccessRate(stats.GetSuccessCount(), stats.GetFailureCount())
+						entry.Trend[i] = &successRate
+					}
+				}
+			}
 
 			entries[resource] = append(entries[resource], entry)
 		}
@@ -358,6 +453,14 @@ func buildTopRoutesRequest(resource string, options *routesOptions) (*pb.TopRout
 	if err != nil {
 		return nil, err
 	}
+	if options.watch {
+		if options.outputFormat == jsonOutput {
+			return nil, fmt.Errorf("--watch is not available with %s output", jsonOutput)
+		}
+		if options.watchInterval <= 0 {
+			return nil, errors.New("--watch-interval must be positive")
+		}
+	}
 
 	target, err := pkgUtil.BuildResource(options.namespace, resource)
 	if err != nil {
@@ -372,6 +475,7 @@ func buildTopRoutesRequest(resource string, options *routesOptions) (*pb.TopRout
 			Namespace:    options.namespace,
 		},
 		LabelSelector: options.labelSelector,
+		TrendPoints:   options.trendPoints,
 	}
 
 	options.dstIsService = target.GetType() != k8s.Authority
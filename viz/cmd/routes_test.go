@@ -1,15 +1,18 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
 
 	api "github.com/linkerd/linkerd2/viz/metrics-api"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
 )
 
 type routesParamsExp struct {
 	options *routesOptions
 	routes  []string
 	counts  []uint64
+	trend   []*pb.BasicStats
 	file    string
 }
 
@@ -45,12 +48,140 @@ func TestRoutes(t *testing.T) {
 			file:    "routes_one_output_wide.golden",
 		}, t)
 	})
+
+	trend := []*pb.BasicStats{
+		{},
+		{SuccessCount: 10},
+		{SuccessCount: 5, FailureCount: 5},
+		{FailureCount: 3},
+	}
+	trendOptions := newRoutesOptions()
+	trendOptions.trendPoints = 4
+	t.Run("Returns route stats with trends", func(t *testing.T) {
+		testRoutesCall(routesParamsExp{
+			routes:  []string{"/a", "/b", "/c"},
+			counts:  []uint64{90, 60, 0, 30},
+			trend:   trend,
+			options: trendOptions,
+			file:    "routes_one_output_trend.golden",
+		}, t)
+	})
+
+	jsonTrendOptions := newRoutesOptions()
+	jsonTrendOptions.trendPoints = 4
+	jsonTrendOptions.outputFormat = jsonOutput
+	t.Run("Returns route stats with trends (json)", func(t *testing.T) {
+		testRoutesCall(routesParamsExp{
+			routes:  []string{"/a", "/b", "/c"},
+			counts:  []uint64{90, 60, 0, 30},
+			trend:   trend,
+			options: jsonTrendOptions,
+			file:    "routes_one_output_trend_json.golden",
+		}, t)
+	})
+}
+
+func TestSparkline(t *testing.T) {
+	testCases := []struct {
+		trend    []*pb.BasicStats
+		expected string
+	}{
+		{
+			trend:    nil,
+			expected: "-",
+		},
+		{
+			trend:    []*pb.BasicStats{{}, {}},
+			expected: "-",
+		},
+		{
+			trend:    []*pb.BasicStats{{SuccessCount: 1}, {}, {SuccessCount: 1, FailureCount: 1}, {FailureCount: 1}},
+			expected: "█ ▅▁",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.expected, func(t *testing.T) {
+			if line := sparkline(tc.trend); line != tc.expected {
+				t.Fatalf("Expected sparkline %q, got %q", tc.expected, line)
+			}
+		})
+	}
+}
+
+func TestRoutesWatchValidation(t *testing.T) {
+	options := newRoutesOptions()
+	options.watch = true
+	options.outputFormat = jsonOutput
+	if _, err := buildTopRoutesRequest("deploy/foobar", options); err == nil {
+		t.Fatal("Expected an error for --watch with json output")
+	}
+
+	options.outputFormat = tableOutput
+	options.watchInterval = 0
+	if _, err := buildTopRoutesRequest("deploy/foobar", options); err == nil {
+		t.Fatal("Expected an error for a zero --watch-interval")
+	}
+}
+
+func TestRequestRouteStatsTrendPoints(t *testing.T) {
+	options := newRoutesOptions()
+	options.trendPoints = 10
+	req, err := buildTopRoutesRequest("deploy/foobar", options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if req.GetTrendPoints() != 10 {
+		t.Fatalf("Expected 10 trend points to be requested, got %d", req.GetTrendPoints())
+	}
+}
+
+// watchRouteStats doesn't return, so its rendering is checked through the
+// header of a single refresh
+func TestWatchRouteStatsHeader(t *testing.T) {
+	mockClient := &api.MockAPIClient{}
+	mockClient.TopRoutesResponseToReturn = api.GenTopRoutesResponse([]string{"/a"}, []uint64{90, 30}, false, "foobar")
+	options := newRoutesOptions()
+	req, err := buildTopRoutesRequest("deploy/foobar", options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	out := newSignallingBuffer()
+	go watchRouteStats(mockClient, req, options, out)
+	output := <-out.written
+	if !strings.HasPrefix(output, clearScreen+"Every 2s: ") || !strings.Contains(output, "ROUTE") {
+		t.Fatalf("Expected the screen to be cleared before the stats, got %q", output)
+	}
+}
+
+// signallingBuffer sends each write to a channel
+type signallingBuffer struct {
+	written chan string
+}
+
+func newSignallingBuffer() *signallingBuffer {
+	return &signallingBuffer{written: make(chan string, 1)}
+}
+
+func (b *signallingBuffer) Write(p []byte) (int, error) {
+	select {
+	case b.written <- string(p):
+	default:
+	}
+	return len(p), nil
 }
 
 func testRoutesCall(exp routesParamsExp, t *testing.T) {
 	mockClient := &api.MockAPIClient{}
 
 	response := api.GenTopRoutesResponse(exp.routes, exp.counts, exp.options.toResource != "", "foobar")
+	for _, table := range response.GetOk().GetRoutes() {
+		for _, row := range table.GetRows() {
+			row.Trend = exp.trend
+		}
+	}
 
 	mockClient.TopRoutesResponseToReturn = response
 
@@ -5,22 +5,26 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
 	"text/tabwriter"
+	"text/template"
 	"time"
 
 	"github.com/linkerd/linkerd2/pkg/cmd"
 	pkgcmd "github.com/linkerd/linkerd2/pkg/cmd"
 	"github.com/linkerd/linkerd2/pkg/healthcheck"
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	vizClient "github.com/linkerd/linkerd2/viz/metrics-api/client"
 	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
 	"github.com/linkerd/linkerd2/viz/metrics-api/util"
 	"github.com/linkerd/linkerd2/viz/pkg/api"
 	pkgUtil "github.com/linkerd/linkerd2/viz/pkg/util"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	healthPb "google.golang.org/grpc/health/grpc_health_v1"
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -30,9 +34,21 @@ type statOptions struct {
 	toResource    string
 	fromNamespace string
 	fromResource  string
+	fromIdentity  string
 	allNamespaces bool
 	labelSelector string
 	unmeshed      bool
+	meshedOnly    bool
+	unmeshedOnly  bool
+	showQueries   bool
+	currentPods   bool
+	versionLabel  string
+	byPort        bool
+	sloTarget     float64
+	sloWindow     string
+	columns       []string
+	template      string
+	rollup        bool
 }
 
 type statOptionsBase struct {
@@ -70,12 +86,28 @@ func newStatOptions() *statOptions {
 		toResource:      "",
 		fromNamespace:   "",
 		fromResource:    "",
+		fromIdentity:    "",
 		allNamespaces:   false,
 		labelSelector:   "",
 		unmeshed:        false,
+		meshedOnly:      false,
+		unmeshedOnly:    false,
+		showQueries:     false,
+		currentPods:     false,
+		versionLabel:    "",
+		byPort:          false,
+		sloTarget:       0,
+		sloWindow:       "",
+		columns:         []string{},
+		template:        "",
+		rollup:          false,
 	}
 }
 
+// versionLabelDefault is the pod label --by-version breaks the stats down by
+// when no label is given, which tells the ReplicaSets of a deployment apart
+const versionLabelDefault = "pod-template-hash"
+
 // NewCmdStat creates a new cobra command `stat` for stat functionality
 func NewCmdStat() *cobra.Command {
 	options := newStatOptions()
@@ -120,11 +152,21 @@ func NewCmdStat() *cobra.Command {
   * replicationcontrollers
   * statefulsets
   * authorities (not supported in --from)
-  * services (not supported in --from)
+  * services (in --from, only with the workload resources, restricting their
+    stats to the traffic sent through the service)
   * servers (not supported in --from)
   * serverauthorizations (not supported in --from)
+  * httproutes (not supported in --from)
   * all (all resource types, not supported in --from or --to)
 
+  The special "gateway" RESOURCES argument (or "gw") displays the traffic sent
+  by the ingress controllers to each of their backend services, grouped by
+  ingress class. Controllers are recognized from the labels set by the charts
+  of ingress-nginx, Traefik, Contour, Emissary-ingress, Kong and HAProxy, or
+  from the viz.linkerd.io/ingress-class annotation on their pods. Pods injected
+  in ingress mode are reported even when their class is unknown. Gateway stats
+  span all namespaces unless --namespace is given.
+
 This command will hide resources that have completed, such as pods that are in the Succeeded or Failed phases.
 If no resource name is specified, displays stats about all resources of the specified RESOURCETYPE`,
 		Example: `  # Get all deployments in the test namespace.
@@ -166,6 +208,9 @@ If no resource name is specified, displays stats about all resources of the spec
   # Get all services in all namespaces that receive calls from hello1 deployment in the test namespace.
   linkerd viz stat services --from deploy/hello1 --from-namespace test --all-namespaces
 
+  # Get the deployments in the test namespace that receive traffic through the web service.
+  linkerd viz stat deploy --from svc/web -n test
+
   # Get all namespaces that receive traffic from the default namespace.
   linkerd viz stat namespaces --from ns/default
 
@@ -177,6 +222,41 @@ If no resource name is specified, displays stats about all resources of the spec
 
   # Get all inbound stats to the web-public server authorization resource
   linkerd viz stat serverauthorization/web-public
+
+  # Get the stats of each HTTPRoute in the test namespace
+  linkerd viz stat httproute -n test
+
+  # Get the traffic sent by all the ingress controllers to their backend services.
+  linkerd viz stat gateway
+
+  # Get the deployments in the test namespace, along with the Prometheus queries
+  # the stats were computed from.
+  linkerd viz stat deployments -n test --show-queries
+
+  # Compare the stats of the old and new ReplicaSets of the web deployment
+  # during a rollout.
+  linkerd viz stat deploy/web -n test --by-version
+
+  # Get the authorities in the test namespace, telling apart the requests sent
+  # to the different ports of each of them.
+  linkerd viz stat authorities -n test --by-port
+
+  # List the deployments in the test namespace that still have pods without the
+  # proxy, e.g. to follow the progress of a rollout of the injection.
+  linkerd viz stat deployments -n test --unmeshed-only
+
+  # Evaluate the success rate of the deployments in the test namespace against a
+  # 99.5% objective over 1h, displaying how fast their error budget is burnt
+  # over the last minute and how much of it is left over the last hour.
+  linkerd viz stat deployments -n test --slo 99.5 --slo-window 1h
+
+  # Get the success rate, request rate and p99 latency of the deployments in the
+  # test namespace.
+  linkerd viz stat deployments -n test --columns success,rps,p99
+
+  # Print the name and success rate of each deployment in the test namespace,
+  # one per line. The template is applied to the fields of the json output.
+  linkerd viz stat deployments -n test -o template --template '{{.name}} {{.success}}'
   `,
 		Args: cobra.MinimumNArgs(1),
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -204,6 +284,10 @@ If no resource name is specified, displays stats about all resources of the spec
 			return results, cobra.ShellCompDirectiveDefault
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if isGatewayStat(args) {
+				return runGatewayStat(options)
+			}
+
 			if options.namespace == "" {
 				options.namespace = pkgcmd.GetDefaultNamespace(kubeconfigPath, kubeContext)
 			}
@@ -259,10 +343,23 @@ If no resource name is specified, displays stats about all resources of the spec
 	cmd.PersistentFlags().StringVar(&options.toNamespace, "to-namespace", options.toNamespace, "Sets the namespace used to lookup the \"--to\" resource; by default the current \"--namespace\" is used")
 	cmd.PersistentFlags().StringVar(&options.fromResource, "from", options.fromResource, "If present, restricts outbound stats from the specified resource name")
 	cmd.PersistentFlags().StringVar(&options.fromNamespace, "from-namespace", options.fromNamespace, "Sets the namespace used from lookup the \"--from\" resource; by default the current \"--namespace\" is used")
+	cmd.PersistentFlags().StringVar(&options.fromIdentity, "from-identity", options.fromIdentity, "If present, restricts inbound stats to the traffic from the clients with this TLS identity (for example: \"web.emojivoto.serviceaccount.identity.linkerd.cluster.local\"), including the unmeshed clients presenting one")
 	cmd.PersistentFlags().BoolVarP(&options.allNamespaces, "all-namespaces", "A", options.allNamespaces, "If present, returns stats across all namespaces, ignoring the \"--namespace\" flag")
-	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; one of: \"table\" or \"json\" or \"wide\"")
-	cmd.PersistentFlags().StringVarP(&options.labelSelector, "selector", "l", options.labelSelector, "Selector (label query) to filter on, supports '=', '==', and '!='")
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; one of: \"table\" or \"json\" or \"wide\" or \"template\"")
+	cmd.PersistentFlags().StringVarP(&options.labelSelector, "selector", "l", options.labelSelector, "Selector (label query) to filter on, supports '=', '==', and '!='; authorities are filtered by the labels of the pods serving them")
 	cmd.PersistentFlags().BoolVar(&options.unmeshed, "unmeshed", options.unmeshed, "If present, include unmeshed resources in the output")
+	cmd.PersistentFlags().BoolVar(&options.meshedOnly, "meshed-only", options.meshedOnly, "If present, only include the workloads whose running pods all have the proxy")
+	cmd.PersistentFlags().BoolVar(&options.unmeshedOnly, "unmeshed-only", options.unmeshedOnly, "If present, only include the workloads with running pods lacking the proxy, including the partially meshed ones")
+	cmd.PersistentFlags().BoolVar(&options.showQueries, "show-queries", options.showQueries, "If present, display the Prometheus queries the stats were computed from, along with their evaluation time")
+	cmd.PersistentFlags().BoolVar(&options.currentPods, "current-pods-only", options.currentPods, "If present, only include the metrics of the current pods of the workloads, leaving out the ones of the workloads they were recreated from")
+	cmd.PersistentFlags().StringVar(&options.versionLabel, "by-version", options.versionLabel, "If present, breaks the stats of the workloads down by the value of this label of their pods (\"pod-template-hash\" when no label is given), e.g. to compare the old and new ReplicaSets of a deployment during a rollout")
+	cmd.PersistentFlags().Lookup("by-version").NoOptDefVal = versionLabelDefault
+	cmd.PersistentFlags().BoolVar(&options.byPort, "by-port", options.byPort, "If present, breaks the stats of the authorities down by the port the requests were sent to, e.g. to tell apart the traffic to the different ports of a host")
+	cmd.PersistentFlags().Float64Var(&options.sloTarget, "slo", options.sloTarget, "If present, evaluates the success rate of each resource against this objective, as a percentage (for example: 99.5), displaying the rate its error budget is burnt at over the time window and the fraction of it left over the SLO window")
+	cmd.PersistentFlags().StringVar(&options.sloWindow, "slo-window", options.sloWindow, "Period the error budget of --slo is computed over (for example: \"1h\", \"30d\"); by default the --time-window")
+	cmd.PersistentFlags().StringSliceVar(&options.columns, "columns", options.columns, fmt.Sprintf("Comma-separated list of the columns to display after the resource names, in the given order; any of: %s. Columns only displayed in the wide output also require \"-o wide\"", strings.Join(statColumnNames(), ", ")))
+	cmd.PersistentFlags().BoolVar(&options.rollup, "rollup", options.rollup, "If present, append a TOTAL row to each namespace of the tables, summing the requests and TCP stats of its resources; the latencies are the highest ones of the resources")
+	cmd.PersistentFlags().StringVar(&options.template, "template", options.template, "Go template applied to each resource with \"-o template\", using the fields of the json output (for example: '{{.name}} {{.success}}')")
 
 	pkgcmd.ConfigureNamespaceFlagCompletion(
 		cmd, []string{"namespace", "to-namespace", "from-namespace"},
@@ -283,22 +380,77 @@ func respToRows(resp *pb.StatSummaryResponse) []*pb.StatTable_PodGroup_Row {
 func requestStatsFromAPI(client pb.ApiClient, req *pb.StatSummaryRequest) (*pb.StatSummaryResponse, error) {
 	resp, err := client.StatSummary(context.Background(), req)
 	if err != nil {
-		return nil, fmt.Errorf("StatSummary API error: %v", err)
+		return nil, fmt.Errorf("StatSummary API error: %v", explainAPIError(client, err))
 	}
 	if e := resp.GetError(); e != nil {
 		return nil, fmt.Errorf("StatSummary API response error: %v", e.Error)
 	}
+	for _, warning := range resp.GetOk().GetWarnings() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
 
 	return resp, nil
 }
 
+// explainAPIError points at Prometheus when the metrics-api health checks
+// report it as unreachable, as opposed to the metrics-api itself failing
+func explainAPIError(client pb.ApiClient, err error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	status, healthErr := vizClient.CheckHealth(ctx, client, vizClient.PrometheusHealthService)
+	if healthErr == nil && status == healthPb.HealthCheckResponse_NOT_SERVING {
+		return fmt.Errorf("%s (the metrics-api is up but can't reach Prometheus)", err)
+	}
+	return err
+}
+
 func renderStatStats(rows []*pb.StatTable_PodGroup_Row, options *statOptions) string {
 	var buffer bytes.Buffer
+	if options.outputFormat == templateOutput {
+		// the template is in charge of the layout of the output
+		writeStatsToBuffer(rows, &buffer, options)
+		return buffer.String()
+	}
+
 	w := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', tabwriter.AlignRight)
 	writeStatsToBuffer(rows, w, options)
 	w.Flush()
 
-	return renderStats(buffer, &options.statOptionsBase)
+	out := renderStats(buffer, &options.statOptionsBase)
+	if options.showQueries && options.outputFormat != jsonOutput {
+		out += renderStatQueries(rows)
+	}
+	return out
+}
+
+// renderStatQueries lists the distinct Prometheus queries behind the rows of
+// each resource type, so that they can be run again in the Prometheus UI
+func renderStatQueries(rows []*pb.StatTable_PodGroup_Row) string {
+	queries := make(map[string][]*pb.PromQuery)
+	seen := make(map[string]bool)
+	for _, r := range rows {
+		resourceType := r.GetResource().GetType()
+		for _, q := range r.GetQueries() {
+			key := resourceType + "/" + q.GetName() + "/" + q.GetQuery()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			queries[resourceType] = append(queries[resourceType], q)
+		}
+	}
+	if len(queries) == 0 {
+		return ""
+	}
+
+	var buffer bytes.Buffer
+	fmt.Fprint(&buffer, "\nQUERIES\n")
+	for _, resourceType := range k8s.AllResources {
+		for _, q := range queries[resourceType] {
+			fmt.Fprintf(&buffer, "# %s %s, evaluated at %s\n%s\n", resourceType, q.GetName(), q.GetEvaluationTime(), q.GetQuery())
+		}
+	}
+	return buffer.String()
 }
 
 const padding = 3
@@ -314,19 +466,39 @@ type rowStats struct {
 	tcpOpenConnections uint64
 	tcpReadBytes       float64
 	tcpWriteBytes      float64
+	// tcpOnly is set when there are TCP stats but no request stats, e.g. for
+	// the workloads only serving opaque traffic
+	tcpOnly bool
 }
 
 type srvStats struct {
 	unauthorizedRate float64
 }
 
+type policyStats struct {
+	servers              uint64
+	serverAuthorizations uint64
+	defaultDeny          bool
+}
+
 type row struct {
-	meshed string
-	status string
+	meshed    string
+	status    string
+	version   string
+	port      uint32
+	restarts  uint64
+	oomKilled uint64
+	labels    map[string]string
 	*rowStats
 	*tsStats
 	*dstStats
 	*srvStats
+	*policyStats
+	slo     *pb.SloStats
+	queries []*pb.PromQuery
+	// rollup is set for the rows aggregating the other rows of their
+	// namespace, displayed after them
+	rollup bool
 }
 
 type tsStats struct {
@@ -346,17 +518,29 @@ var (
 	apexHeader      = "APEX"
 	leafHeader      = "LEAF"
 	weightHeader    = "WEIGHT"
+	versionHeader   = "VERSION"
+	portHeader      = "PORT"
 )
 
 func statHasRequestData(stat *pb.BasicStats) bool {
 	return stat.GetSuccessCount() != 0 || stat.GetFailureCount() != 0 || stat.GetActualSuccessCount() != 0 || stat.GetActualFailureCount() != 0
 }
 
+func statHasTCPData(stat *pb.TcpStats) bool {
+	return stat.GetOpenConnections() != 0 || stat.GetReadBytesTotal() != 0 || stat.GetWriteBytesTotal() != 0
+}
+
 func isPodOwnerResource(typ string) bool {
-	return typ != k8s.Authority && typ != k8s.Service && typ != k8s.Server && typ != k8s.ServerAuthorization
+	return typ != k8s.Authority && typ != k8s.Service && !isPolicyResource(typ)
 }
 
-func writeStatsToBuffer(rows []*pb.StatTable_PodGroup_Row, w *tabwriter.Writer, options *statOptions) {
+// isPolicyResource returns true for the resources whose stats are read from
+// the inbound policy metrics of the proxies, without any pod count
+func isPolicyResource(typ string) bool {
+	return typ == k8s.Server || typ == k8s.ServerAuthorization || typ == k8s.HTTPRoute
+}
+
+func writeStatsToBuffer(rows []*pb.StatTable_PodGroup_Row, w io.Writer, options *statOptions) {
 	maxNameLength := len(nameHeader)
 	maxNamespaceLength := len(namespaceHeader)
 	maxApexLength := len(apexHeader)
@@ -375,10 +559,10 @@ func writeStatsToBuffer(rows []*pb.StatTable_PodGroup_Row, w *tabwriter.Writer,
 		usePrefix = true
 	}
 
+	displayedRows := make([]*pb.StatTable_PodGroup_Row, 0, len(rows))
 	for _, r := range rows {
-
 		// Skip unmeshed pods if the unmeshed option isn't enabled.
-		if !options.unmeshed && r.GetMeshedPodCount() == 0 &&
+		if !options.unmeshed && !options.unmeshedOnly && r.GetMeshedPodCount() == 0 &&
 			// Skip only if the resource can own pods
 			isPodOwnerResource(r.Resource.Type) &&
 			// Skip only if --from isn't specified (unmeshed resources can show
@@ -387,7 +571,13 @@ func writeStatsToBuffer(rows []*pb.StatTable_PodGroup_Row, w *tabwriter.Writer,
 			options.fromResource == "" {
 			continue
 		}
+		displayedRows = append(displayedRows, r)
+	}
+	if options.rollup {
+		displayedRows = append(displayedRows, rollupRows(displayedRows)...)
+	}
 
+	for _, r := range displayedRows {
 		name := r.Resource.Name
 		nameWithPrefix := name
 		if usePrefix {
@@ -396,6 +586,12 @@ func writeStatsToBuffer(rows []*pb.StatTable_PodGroup_Row, w *tabwriter.Writer,
 
 		namespace := r.Resource.Namespace
 		key := fmt.Sprintf("%s/%s", namespace, name)
+		if r.GetVersion() != "" {
+			key = fmt.Sprintf("%s/%s", key, r.GetVersion())
+		}
+		if r.GetPort() != 0 {
+			key = fmt.Sprintf("%s/%d", key, r.GetPort())
+		}
 
 		resourceKey := r.Resource.Type
 
@@ -412,17 +608,24 @@ func writeStatsToBuffer(rows []*pb.StatTable_PodGroup_Row, w *tabwriter.Writer,
 		}
 
 		statTables[resourceKey][key] = &row{}
-		if resourceKey != k8s.Server && resourceKey != k8s.ServerAuthorization {
+		if !isPolicyResource(resourceKey) {
 			meshedCount := fmt.Sprintf("%d/%d", r.MeshedPodCount, r.RunningPodCount)
 			if resourceKey == k8s.Authority || resourceKey == k8s.Service {
 				meshedCount = "-"
 			}
 			statTables[resourceKey][key] = &row{
-				meshed: meshedCount,
-				status: r.Status,
+				meshed:    meshedCount,
+				status:    r.Status,
+				version:   r.GetVersion(),
+				port:      r.GetPort(),
+				restarts:  r.GetRestartCount(),
+				oomKilled: r.GetOomKilledCount(),
 			}
 		}
 
+		statTables[resourceKey][key].labels = r.GetLabels()
+		statTables[resourceKey][key].rollup = options.rollup && name == rollupName
+
 		if r.Stats != nil && statHasRequestData(r.Stats) {
 			statTables[resourceKey][key].rowStats = &rowStats{
 				requestRate:        getRequestRate(r.Stats.GetSuccessCount(), r.Stats.GetFailureCount(), r.TimeWindow),
@@ -434,6 +637,13 @@ func writeStatsToBuffer(rows []*pb.StatTable_PodGroup_Row, w *tabwriter.Writer,
 				tcpReadBytes:       getByteRate(r.GetTcpStats().GetReadBytesTotal(), r.TimeWindow),
 				tcpWriteBytes:      getByteRate(r.GetTcpStats().GetWriteBytesTotal(), r.TimeWindow),
 			}
+		} else if statHasTCPData(r.GetTcpStats()) {
+			statTables[resourceKey][key].rowStats = &rowStats{
+				tcpOpenConnections: r.GetTcpStats().GetOpenConnections(),
+				tcpReadBytes:       getByteRate(r.GetTcpStats().GetReadBytesTotal(), r.TimeWindow),
+				tcpWriteBytes:      getByteRate(r.GetTcpStats().GetWriteBytesTotal(), r.TimeWindow),
+				tcpOnly:            true,
+			}
 		}
 
 		if r.SrvStats != nil {
@@ -441,6 +651,17 @@ func writeStatsToBuffer(rows []*pb.StatTable_PodGroup_Row, w *tabwriter.Writer,
 				unauthorizedRate: getSuccessRate(r.SrvStats.GetDeniedCount(), r.SrvStats.GetAllowedCount()),
 			}
 		}
+
+		statTables[resourceKey][key].queries = r.GetQueries()
+		statTables[resourceKey][key].slo = r.GetSloStats()
+
+		if r.PolicyStats != nil {
+			statTables[resourceKey][key].policyStats = &policyStats{
+				servers:              r.PolicyStats.GetServers(),
+				serverAuthorizations: r.PolicyStats.GetServerAuthorizations(),
+				defaultDeny:          r.PolicyStats.GetDefaultDeny(),
+			}
+		}
 	}
 
 	switch options.outputFormat {
@@ -452,10 +673,91 @@ func writeStatsToBuffer(rows []*pb.StatTable_PodGroup_Row, w *tabwriter.Writer,
 		printStatTables(statTables, w, maxNameLength, maxNamespaceLength, maxLeafLength, maxApexLength, maxDstLength, maxWeightLength, options)
 	case jsonOutput:
 		printStatJSON(statTables, w)
+	case templateOutput:
+		printStatTemplate(statTables, w, options.template)
 	}
 }
 
-func printStatTables(statTables map[string]map[string]*row, w *tabwriter.Writer, maxNameLength, maxNamespaceLength, maxLeafLength, maxApexLength, maxDstLength, maxWeightLength int, options *statOptions) {
+// rollupName is the name of the rows aggregating the stats of the resources
+// of a namespace. Being uppercase, it can't clash with a resource name.
+const rollupName = "TOTAL"
+
+// rollupRows returns, for each resource type and namespace of the rows, a row
+// summing their requests, pods, restarts and TCP stats. Their success rate is
+// thus weighted by their traffic, while their latencies can't be aggregated
+// and are the highest ones of the rows. Namespace rows aren't rolled up, as
+// there's a single one per namespace.
+func rollupRows(rows []*pb.StatTable_PodGroup_Row) []*pb.StatTable_PodGroup_Row {
+	rollups := make(map[string]*pb.StatTable_PodGroup_Row)
+	keys := []string{}
+	for _, r := range rows {
+		if r.Resource.Type == k8s.Namespace {
+			continue
+		}
+		key := fmt.Sprintf("%s/%s", r.Resource.Type, r.Resource.Namespace)
+		rollup, ok := rollups[key]
+		if !ok {
+			rollup = &pb.StatTable_PodGroup_Row{
+				Resource: &pb.Resource{
+					Namespace: r.Resource.Namespace,
+					Type:      r.Resource.Type,
+					Name:      rollupName,
+				},
+				TimeWindow: r.TimeWindow,
+				Status:     "-",
+			}
+			rollups[key] = rollup
+			keys = append(keys, key)
+		}
+
+		rollup.MeshedPodCount += r.GetMeshedPodCount()
+		rollup.RunningPodCount += r.GetRunningPodCount()
+		rollup.FailedPodCount += r.GetFailedPodCount()
+		rollup.RestartCount += r.GetRestartCount()
+		rollup.OomKilledCount += r.GetOomKilledCount()
+
+		if r.Stats != nil {
+			if rollup.Stats == nil {
+				rollup.Stats = &pb.BasicStats{}
+			}
+			rollup.Stats.SuccessCount += r.Stats.GetSuccessCount()
+			rollup.Stats.FailureCount += r.Stats.GetFailureCount()
+			rollup.Stats.LatencyMsP50 = maxUint64(rollup.Stats.LatencyMsP50, r.Stats.GetLatencyMsP50())
+			rollup.Stats.LatencyMsP95 = maxUint64(rollup.Stats.LatencyMsP95, r.Stats.GetLatencyMsP95())
+			rollup.Stats.LatencyMsP99 = maxUint64(rollup.Stats.LatencyMsP99, r.Stats.GetLatencyMsP99())
+		}
+		if r.TcpStats != nil {
+			if rollup.TcpStats == nil {
+				rollup.TcpStats = &pb.TcpStats{}
+			}
+			rollup.TcpStats.OpenConnections += r.TcpStats.GetOpenConnections()
+			rollup.TcpStats.ReadBytesTotal += r.TcpStats.GetReadBytesTotal()
+			rollup.TcpStats.WriteBytesTotal += r.TcpStats.GetWriteBytesTotal()
+		}
+		if r.SrvStats != nil {
+			if rollup.SrvStats == nil {
+				rollup.SrvStats = &pb.ServerStats{}
+			}
+			rollup.SrvStats.AllowedCount += r.SrvStats.GetAllowedCount()
+			rollup.SrvStats.DeniedCount += r.SrvStats.GetDeniedCount()
+		}
+	}
+
+	result := make([]*pb.StatTable_PodGroup_Row, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, rollups[key])
+	}
+	return result
+}
+
+func maxUint64(a, b uint64) uint64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func printStatTables(statTables map[string]map[string]*row, w io.Writer, maxNameLength, maxNamespaceLength, maxLeafLength, maxApexLength, maxDstLength, maxWeightLength int, options *statOptions) {
 	usePrefix := false
 	if len(statTables) > 1 {
 		usePrefix = true
@@ -472,7 +774,13 @@ func printStatTables(statTables map[string]map[string]*row, w *tabwriter.Writer,
 			if !usePrefix {
 				resourceTypeLabel = ""
 			}
-			printSingleStatTable(stats, resourceTypeLabel, resourceType, w, maxNameLength, maxNamespaceLength, maxLeafLength, maxApexLength, maxDstLength, maxWeightLength, options)
+			if len(options.columns) == 0 {
+				printSingleStatTable(stats, resourceTypeLabel, resourceType, w, maxNameLength, maxNamespaceLength, maxLeafLength, maxApexLength, maxDstLength, maxWeightLength, options)
+				continue
+			}
+			var table bytes.Buffer
+			printSingleStatTable(stats, resourceTypeLabel, resourceType, &table, maxNameLength, maxNamespaceLength, maxLeafLength, maxApexLength, maxDstLength, maxWeightLength, options)
+			fmt.Fprint(w, selectColumns(table.String(), options.columns))
 		}
 	}
 }
@@ -482,11 +790,25 @@ func showTCPBytes(options *statOptions, resourceType string) bool {
 		showTCPConns(resourceType)
 }
 
+// showPolicyStats returns true when the policy attached to the workloads of
+// the table must be displayed, i.e. for pod owners in wide output
+func showPolicyStats(options *statOptions, resourceType string, stats map[string]*row) bool {
+	if options.outputFormat != wideOutput || !isPodOwnerResource(resourceType) {
+		return false
+	}
+	for _, r := range stats {
+		if r.policyStats != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func showTCPConns(resourceType string) bool {
-	return resourceType != k8s.Authority && resourceType != k8s.ServerAuthorization
+	return resourceType != k8s.Authority && resourceType != k8s.ServerAuthorization && resourceType != k8s.HTTPRoute
 }
 
-func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType string, w *tabwriter.Writer, maxNameLength, maxNamespaceLength, maxLeafLength, maxApexLength, maxDstLength, maxWeightLength int, options *statOptions) {
+func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType string, w io.Writer, maxNameLength, maxNamespaceLength, maxLeafLength, maxApexLength, maxDstLength, maxWeightLength int, options *statOptions) {
 	headers := make([]string, 0)
 	nameTemplate := fmt.Sprintf("%%-%ds", maxNameLength)
 	namespaceTemplate := fmt.Sprintf("%%-%ds", maxNamespaceLength)
@@ -509,6 +831,37 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 		}
 	}
 
+	// only show restarts when at least one pod in the table has restarted, so
+	// that crash-looping workloads stand out
+	showRestarts := false
+	if !hasTsStats && !hasDstStats && isPodOwnerResource(resourceType) {
+		for _, r := range stats {
+			if r.restarts > 0 {
+				showRestarts = true
+			}
+		}
+	}
+
+	showPolicy := !hasTsStats && !hasDstStats && showPolicyStats(options, resourceType, stats)
+
+	showVersion := options.versionLabel != "" && isPodOwnerResource(resourceType)
+	maxVersionLength := len(versionHeader)
+	for _, r := range stats {
+		if len(r.version) > maxVersionLength {
+			maxVersionLength = len(r.version)
+		}
+	}
+	versionTemplate := fmt.Sprintf("%%-%ds", maxVersionLength)
+
+	showPort := options.byPort && resourceType == k8s.Authority
+	maxPortLength := len(portHeader)
+	for _, r := range stats {
+		if len(formatPort(r.port)) > maxPortLength {
+			maxPortLength = len(formatPort(r.port))
+		}
+	}
+	portTemplate := fmt.Sprintf("%%-%ds", maxPortLength)
+
 	if options.allNamespaces {
 		headers = append(headers,
 			fmt.Sprintf(namespaceTemplate, namespaceHeader))
@@ -517,6 +870,14 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 	headers = append(headers,
 		fmt.Sprintf(nameTemplate, nameHeader))
 
+	if showVersion {
+		headers = append(headers, fmt.Sprintf(versionTemplate, versionHeader))
+	}
+
+	if showPort {
+		headers = append(headers, fmt.Sprintf(portTemplate, portHeader))
+	}
+
 	if resourceType == k8s.Pod {
 		headers = append(headers, "STATUS")
 	}
@@ -530,7 +891,7 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 			fmt.Sprintf(apexTemplate, apexHeader),
 			fmt.Sprintf(leafTemplate, leafHeader),
 			fmt.Sprintf(weightTemplate, weightHeader))
-	} else if resourceType != k8s.Server && resourceType != k8s.ServerAuthorization {
+	} else if !isPolicyResource(resourceType) {
 		headers = append(headers, "MESHED")
 	}
 
@@ -557,6 +918,19 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 		}...)
 	}
 
+	if showRestarts {
+		headers = append(headers, "RESTARTS")
+	}
+
+	if showPolicy {
+		headers = append(headers, "POLICY")
+	}
+
+	showSlo := options.sloTarget != 0
+	if showSlo {
+		headers = append(headers, "BURN_RATE", "BUDGET")
+	}
+
 	headers[len(headers)-1] = headers[len(headers)-1] + "\t" // trailing \t is required to format last column
 
 	fmt.Fprintln(w, strings.Join(headers, "\t"))
@@ -578,7 +952,7 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 		} else if hasDstStats {
 			templateString = "%s\t%s\t%s\t%.2f%%\t%.1frps\t%dms\t%dms\t%dms\t"
 			templateStringEmpty = "%s\t%s\t%s\t-\t-\t-\t-\t-\t"
-		} else if resourceType == k8s.ServerAuthorization {
+		} else if resourceType == k8s.ServerAuthorization || resourceType == k8s.HTTPRoute {
 			templateString = "%s\t%.2f%%\t%.1frps\t%dms\t%dms\t%dms\t"
 			templateStringEmpty = "%s\t-\t-\t-\t-\t-\t"
 		} else if resourceType == k8s.Server {
@@ -586,14 +960,52 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 			templateStringEmpty = "%s\t%.1frps\t-\t-\t-\t-\t-\t"
 		}
 
+		// the rows with only TCP stats have dashes for the request stats
+		templateStringTCPOnly := templateStringEmpty
+
 		if showTCPConns(resourceType) {
 			templateString = templateString + "%d\t"
 			templateStringEmpty = templateStringEmpty + "-\t"
+			templateStringTCPOnly = templateStringTCPOnly + "%d\t"
 		}
 
 		if showTCPBytes(options, resourceType) {
 			templateString = templateString + "%.1fB/s\t%.1fB/s\t"
 			templateStringEmpty = templateStringEmpty + "-\t-\t"
+			templateStringTCPOnly = templateStringTCPOnly + "%.1fB/s\t%.1fB/s\t"
+		}
+
+		if showRestarts {
+			templateString = templateString + "%s\t"
+			templateStringEmpty = templateStringEmpty + "%s\t"
+			templateStringTCPOnly = templateStringTCPOnly + "%s\t"
+		}
+
+		if showPolicy {
+			templateString = templateString + "%s\t"
+			templateStringEmpty = templateStringEmpty + "%s\t"
+			templateStringTCPOnly = templateStringTCPOnly + "%s\t"
+		}
+
+		if showSlo {
+			templateString = templateString + "%s\t%s\t"
+			templateStringEmpty = templateStringEmpty + "%s\t%s\t"
+			templateStringTCPOnly = templateStringTCPOnly + "%s\t%s\t"
+		}
+
+		// the version follows the name, and both are strings, so its
+		// placeholder can be prepended like the namespace one
+		if showVersion {
+			templateString = "%s\t" + templateString
+			templateStringEmpty = "%s\t" + templateStringEmpty
+			templateStringTCPOnly = "%s\t" + templateStringTCPOnly
+		}
+
+		// so does the port
+		if showPort {
+			templateString = "%s\t" + templateString
+			templateStringEmpty = "%s\t" + templateStringEmpty
+			templateStringTCPOnly = "%s\t" + templateStringTCPOnly
 		}
 
 		if options.allNamespaces {
@@ -601,10 +1013,12 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 				namespace+strings.Repeat(" ", maxNamespaceLength-len(namespace)))
 			templateString = "%s\t" + templateString
 			templateStringEmpty = "%s\t" + templateStringEmpty
+			templateStringTCPOnly = "%s\t" + templateStringTCPOnly
 		}
 
 		templateString = templateString + "\n"
 		templateStringEmpty = templateStringEmpty + "\n"
+		templateStringTCPOnly = templateStringTCPOnly + "\n"
 
 		padding := 0
 		if maxNameLength > len(name) {
@@ -629,6 +1043,13 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 		}
 
 		values = append(values, name+strings.Repeat(" ", padding))
+		if showVersion {
+			values = append(values, stats[key].version+strings.Repeat(" ", maxVersionLength-len(stats[key].version)))
+		}
+		if showPort {
+			port := formatPort(stats[key].port)
+			values = append(values, port+strings.Repeat(" ", maxPortLength-len(port)))
+		}
 		if resourceType == k8s.Pod {
 			values = append(values, stats[key].status)
 		}
@@ -644,7 +1065,7 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 				stats[key].dstStats.dst+strings.Repeat(" ", dstPadding),
 				stats[key].dstStats.weight,
 			)
-		} else if resourceType != k8s.ServerAuthorization && resourceType != k8s.Server {
+		} else if !isPolicyResource(resourceType) {
 			values = append(values, []interface{}{
 				stats[key].meshed,
 			}...)
@@ -661,13 +1082,17 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 		}
 
 		if stats[key].rowStats != nil {
-			values = append(values, []interface{}{
-				stats[key].successRate * 100,
-				stats[key].requestRate,
-				stats[key].latencyP50,
-				stats[key].latencyP95,
-				stats[key].latencyP99,
-			}...)
+			if stats[key].tcpOnly {
+				templateString = templateStringTCPOnly
+			} else {
+				values = append(values, []interface{}{
+					stats[key].successRate * 100,
+					stats[key].requestRate,
+					stats[key].latencyP50,
+					stats[key].latencyP95,
+					stats[key].latencyP99,
+				}...)
+			}
 
 			if showTCPConns(resourceType) {
 				values = append(values, stats[key].tcpOpenConnections)
@@ -680,13 +1105,153 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 				}...)
 			}
 
+			if showRestarts {
+				values = append(values, formatRestarts(stats[key]))
+			}
+
+			if showPolicy {
+				values = append(values, formatPolicy(stats[key]))
+			}
+
+			if showSlo {
+				values = append(values, formatSlo(stats[key])...)
+			}
+
 			fmt.Fprintf(w, templateString, values...)
 		} else {
+			if showRestarts {
+				values = append(values, formatRestarts(stats[key]))
+			}
+
+			if showPolicy {
+				values = append(values, formatPolicy(stats[key]))
+			}
+
+			if showSlo {
+				values = append(values, formatSlo(stats[key])...)
+			}
+
 			fmt.Fprintf(w, templateStringEmpty, values...)
 		}
 	}
 }
 
+// formatRestarts renders the restart count of a row, calling out how many of
+// those restarts were caused by OOMKills
+func formatRestarts(r *row) string {
+	if r.oomKilled > 0 {
+		return fmt.Sprintf("%d (%d OOMKilled)", r.restarts, r.oomKilled)
+	}
+	return fmt.Sprintf("%d", r.restarts)
+}
+
+// formatPolicy renders the number of Servers and ServerAuthorizations
+// attached to a workload, calling out when its proxies deny by default
+func formatPolicy(r *row) string {
+	if r.policyStats == nil {
+		return "-"
+	}
+	policy := fmt.Sprintf("%d srv / %d authz", r.servers, r.serverAuthorizations)
+	if r.defaultDeny {
+		policy += ", default-deny"
+	}
+	return policy
+}
+
+// formatSlo renders the burn rate of the error budget of a row and the
+// percentage of it left, which is negative once it's overspent
+func formatSlo(r *row) []interface{} {
+	if r.slo == nil {
+		return []interface{}{"-", "-"}
+	}
+	return []interface{}{
+		fmt.Sprintf("%.2fx", r.slo.GetBurnRate()),
+		fmt.Sprintf("%.1f%%", r.slo.GetBudgetRemaining()*100),
+	}
+}
+
+// statColumns maps the names accepted by --columns to the headers of the
+// columns they select
+var statColumns = map[string]string{
+	"status":       "STATUS",
+	"meshed":       "MESHED",
+	"apex":         apexHeader,
+	"leaf":         leafHeader,
+	"dst":          dstHeader,
+	"weight":       weightHeader,
+	"unauthorized": "UNAUTHORIZED",
+	"success":      "SUCCESS",
+	"rps":          "RPS",
+	"p50":          "LATENCY_P50",
+	"p95":          "LATENCY_P95",
+	"p99":          "LATENCY_P99",
+	"tcp_conn":     "TCP_CONN",
+	"read_bytes":   "READ_BYTES/SEC",
+	"write_bytes":  "WRITE_BYTES/SEC",
+	"restarts":     "RESTARTS",
+	"policy":       "POLICY",
+	"burn_rate":    "BURN_RATE",
+	"budget":       "BUDGET",
+}
+
+func statColumnNames() []string {
+	names := make([]string, 0, len(statColumns))
+	for name := range statColumns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// selectColumns keeps the NAMESPACE, NAME, VERSION and PORT columns of a rendered
+// table, followed by the given columns in their order. The columns that the table
+// doesn't have, such as STATUS for resources other than pods, are left out.
+func selectColumns(table string, columns []string) string {
+	lines := strings.Split(strings.TrimSuffix(table, "\n"), "\n")
+	if len(lines) == 0 {
+		return table
+	}
+
+	indexes := make(map[string]int)
+	for i, header := range strings.Split(lines[0], "\t") {
+		indexes[strings.TrimSpace(header)] = i
+	}
+	selected := []int{}
+	for _, header := range []string{namespaceHeader, nameHeader, versionHeader, portHeader} {
+		if i, ok := indexes[header]; ok {
+			selected = append(selected, i)
+		}
+	}
+	for _, column := range columns {
+		if i, ok := indexes[statColumns[column]]; ok {
+			selected = append(selected, i)
+		}
+	}
+
+	var out strings.Builder
+	for _, line := range lines {
+		cells := strings.Split(line, "\t")
+		for _, i := range selected {
+			if i < len(cells) {
+				out.WriteString(cells[i])
+			}
+			// the trailing \t is required to format the last column
+			out.WriteString("\t")
+		}
+		out.WriteString("\n")
+	}
+	return out.String()
+}
+
+// formatPort formats the port of a row, which is 0 when the stats aren't broken
+// down by port
+func formatPort(port uint32) string {
+	if port == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", port)
+}
+
 func namespaceName(resourceType string, key string) (string, string) {
 	parts := strings.Split(key, "/")
 	namespace := parts[0]
@@ -697,26 +1262,90 @@ func namespaceName(resourceType string, key string) (string, string) {
 
 // Using pointers where the value is NA and the corresponding json is null
 type jsonStats struct {
-	Namespace      string   `json:"namespace"`
-	Kind           string   `json:"kind"`
-	Name           string   `json:"name"`
-	Meshed         string   `json:"meshed,omitempty"`
-	Success        *float64 `json:"success"`
-	Rps            *float64 `json:"rps"`
-	LatencyMSp50   *uint64  `json:"latency_ms_p50"`
-	LatencyMSp95   *uint64  `json:"latency_ms_p95"`
-	LatencyMSp99   *uint64  `json:"latency_ms_p99"`
-	TCPConnections *uint64  `json:"tcp_open_connections,omitempty"`
-	TCPReadBytes   *float64 `json:"tcp_read_bytes_rate,omitempty"`
-	TCPWriteBytes  *float64 `json:"tcp_write_bytes_rate,omitempty"`
-	Apex           string   `json:"apex,omitempty"`
-	Leaf           string   `json:"leaf,omitempty"`
-	Dst            string   `json:"dst,omitempty"`
-	Weight         string   `json:"weight,omitempty"`
-	Unauthorized   *float64 `json:"unauthorized,omitempty"`
-}
-
-func printStatJSON(statTables map[string]map[string]*row, w *tabwriter.Writer) {
+	Namespace      string            `json:"namespace"`
+	Kind           string            `json:"kind"`
+	Name           string            `json:"name"`
+	Version        string            `json:"version,omitempty"`
+	Port           uint32            `json:"port,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	Meshed         string            `json:"meshed,omitempty"`
+	Success        *float64          `json:"success"`
+	Rps            *float64          `json:"rps"`
+	LatencyMSp50   *uint64           `json:"latency_ms_p50"`
+	LatencyMSp95   *uint64           `json:"latency_ms_p95"`
+	LatencyMSp99   *uint64           `json:"latency_ms_p99"`
+	TCPConnections *uint64           `json:"tcp_open_connections,omitempty"`
+	TCPReadBytes   *float64          `json:"tcp_read_bytes_rate,omitempty"`
+	TCPWriteBytes  *float64          `json:"tcp_write_bytes_rate,omitempty"`
+	Apex           string            `json:"apex,omitempty"`
+	Leaf           string            `json:"leaf,omitempty"`
+	Dst            string            `json:"dst,omitempty"`
+	Weight         string            `json:"weight,omitempty"`
+	Unauthorized   *float64          `json:"unauthorized,omitempty"`
+	Restarts       uint64            `json:"restarts,omitempty"`
+	OOMKilled      uint64            `json:"oom_killed,omitempty"`
+	Policy         *jsonPolicy       `json:"policy,omitempty"`
+	SLO            *jsonSLO          `json:"slo,omitempty"`
+	Queries        []jsonQuery       `json:"queries,omitempty"`
+}
+
+type jsonPolicy struct {
+	Servers              uint64 `json:"servers"`
+	ServerAuthorizations uint64 `json:"server_authorizations"`
+	DefaultDeny          bool   `json:"default_deny"`
+}
+
+type jsonSLO struct {
+	Target          float64 `json:"target"`
+	Window          string  `json:"window"`
+	BurnRate        float64 `json:"burn_rate"`
+	BudgetRemaining float64 `json:"budget_remaining"`
+}
+
+type jsonQuery struct {
+	Name           string `json:"name"`
+	Query          string `json:"query"`
+	EvaluationTime string `json:"evaluation_time"`
+}
+
+func printStatJSON(statTables map[string]map[string]*row, w io.Writer) {
+	b, err := json.MarshalIndent(statJSONEntries(statTables), "", "  ")
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+	fmt.Fprintf(w, "%s\n", b)
+}
+
+// printStatTemplate executes the template once per resource, on the fields of
+// its json output, and prints each result on its own line
+func printStatTemplate(statTables map[string]map[string]*row, w io.Writer, text string) {
+	tmpl, err := template.New("stat").Parse(text)
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+	for _, entry := range statJSONEntries(statTables) {
+		// go through json so that the template uses the same field names
+		b, err := json.Marshal(entry)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal(b, &fields); err != nil {
+			log.Error(err.Error())
+			return
+		}
+		if err := tmpl.Execute(w, fields); err != nil {
+			log.Error(err.Error())
+			return
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+func statJSONEntries(statTables map[string]map[string]*row) []*jsonStats {
 	// avoid nil initialization so that if there are not stats it gets marshalled as an empty array vs null
 	entries := []*jsonStats{}
 	for _, resourceType := range k8s.AllResources {
@@ -728,14 +1357,21 @@ func printStatJSON(statTables map[string]map[string]*row, w *tabwriter.Writer) {
 					Namespace: namespace,
 					Kind:      resourceType,
 					Name:      name,
+					Version:   stats[key].version,
+					Port:      stats[key].port,
+					Labels:    stats[key].labels,
+					Restarts:  stats[key].restarts,
+					OOMKilled: stats[key].oomKilled,
 				}
 
 				if stats[key].rowStats != nil {
-					entry.Success = &stats[key].successRate
-					entry.Rps = &stats[key].requestRate
-					entry.LatencyMSp50 = &stats[key].latencyP50
-					entry.LatencyMSp95 = &stats[key].latencyP95
-					entry.LatencyMSp99 = &stats[key].latencyP99
+					if !stats[key].tcpOnly {
+						entry.Success = &stats[key].successRate
+						entry.Rps = &stats[key].requestRate
+						entry.LatencyMSp50 = &stats[key].latencyP50
+						entry.LatencyMSp95 = &stats[key].latencyP95
+						entry.LatencyMSp99 = &stats[key].latencyP99
+					}
 
 					if showTCPConns(resourceType) {
 						entry.TCPConnections = &stats[key].tcpOpenConnections
@@ -758,16 +1394,34 @@ func printStatJSON(statTables map[string]map[string]*row, w *tabwriter.Writer) {
 						entry.Unauthorized = &stats[key].srvStats.unauthorizedRate
 					}
 				}
+
+				if stats[key].policyStats != nil && isPodOwnerResource(resourceType) {
+					entry.Policy = &jsonPolicy{
+						Servers:              stats[key].servers,
+						ServerAuthorizations: stats[key].serverAuthorizations,
+						DefaultDeny:          stats[key].defaultDeny,
+					}
+				}
+				if slo := stats[key].slo; slo != nil {
+					entry.SLO = &jsonSLO{
+						Target:          slo.GetTarget(),
+						Window:          slo.GetWindow(),
+						BurnRate:        slo.GetBurnRate(),
+						BudgetRemaining: slo.GetBudgetRemaining(),
+					}
+				}
+				for _, q := range stats[key].queries {
+					entry.Queries = append(entry.Queries, jsonQuery{
+						Name:           q.GetName(),
+						Query:          q.GetQuery(),
+						EvaluationTime: q.GetEvaluationTime(),
+					})
+				}
 				entries = append(entries, entry)
 			}
 		}
 	}
-	b, err := json.MarshalIndent(entries, "", "  ")
-	if err != nil {
-		log.Error(err.Error())
-		return
-	}
-	fmt.Fprintf(w, "%s\n", b)
+	return entries
 }
 
 func getNamePrefix(resourceType string) string {
@@ -814,10 +1468,20 @@ func buildStatSummaryRequests(resources []string, options *statOptions) ([]*pb.S
 				Namespace:     options.namespace,
 				AllNamespaces: options.allNamespaces,
 			},
-			ToNamespace:   options.toNamespace,
-			FromNamespace: options.fromNamespace,
-			TCPStats:      true,
-			LabelSelector: options.labelSelector,
+			ToNamespace:     options.toNamespace,
+			FromNamespace:   options.fromNamespace,
+			TCPStats:        true,
+			PolicyStats:     options.outputFormat == wideOutput || options.outputFormat == jsonOutput,
+			IncludeQueries:  options.showQueries,
+			LabelSelector:   options.labelSelector,
+			CurrentPodsOnly: options.currentPods,
+			VersionLabel:    options.versionLabel,
+			ByPort:          options.byPort,
+			MeshedOnly:      options.meshedOnly,
+			UnmeshedOnly:    options.unmeshedOnly,
+			FromIdentity:    options.fromIdentity,
+			SLOTarget:       options.sloTarget,
+			SLOWindow:       options.sloWindow,
 		}
 		if fromRes != nil {
 			requestParams.FromName = fromRes.Name
@@ -838,12 +1502,21 @@ func buildStatSummaryRequests(resources []string, options *statOptions) ([]*pb.S
 	return requests, nil
 }
 
+// sortStatsKeys sorts the rows by namespace and name, the rollup rows coming
+// last in their namespace
 func sortStatsKeys(stats map[string]*row) []string {
 	var sortedKeys []string
 	for key := range stats {
 		sortedKeys = append(sortedKeys, key)
 	}
-	sort.Strings(sortedKeys)
+	sort.Slice(sortedKeys, func(i, j int) bool {
+		nsI := strings.SplitN(sortedKeys[i], "/", 2)[0]
+		nsJ := strings.SplitN(sortedKeys[j], "/", 2)[0]
+		if nsI == nsJ && stats[sortedKeys[i]].rollup != stats[sortedKeys[j]].rollup {
+			return stats[sortedKeys[j]].rollup
+		}
+		return sortedKeys[i] < sortedKeys[j]
+	})
 	return sortedKeys
 }
 
@@ -855,6 +1528,18 @@ func (o *statOptions) validate(resourceType string) error {
 		return err
 	}
 
+	if o.versionLabel != "" && !isPodOwnerResource(resourceType) {
+		return fmt.Errorf("--by-version is only supported with workload resources")
+	}
+
+	if o.byPort && resourceType != k8s.Authority {
+		return fmt.Errorf("--by-port is only supported with authorities")
+	}
+
+	if (o.meshedOnly || o.unmeshedOnly) && !isPodOwnerResource(resourceType) {
+		return fmt.Errorf("--meshed-only and --unmeshed-only are only supported with workload resources")
+	}
+
 	if resourceType == k8s.Namespace {
 		err := o.validateNamespaceFlags()
 		if err != nil {
@@ -865,6 +1550,40 @@ func (o *statOptions) validate(resourceType string) error {
 	return o.validateOutputFormat()
 }
 
+// validateOutputFormat validates the output formats of statOptionsBase, along
+// with the template output and the --columns of the table outputs.
+func (o *statOptions) validateOutputFormat() error {
+	if o.outputFormat == templateOutput {
+		if o.template == "" {
+			return fmt.Errorf("--template is required with --output %s", templateOutput)
+		}
+		if _, err := template.New("stat").Parse(o.template); err != nil {
+			return fmt.Errorf("invalid --template: %w", err)
+		}
+	} else {
+		if o.template != "" {
+			return fmt.Errorf("--template is only supported with --output %s", templateOutput)
+		}
+		if err := o.statOptionsBase.validateOutputFormat(); err != nil {
+			return fmt.Errorf("--output currently only supports %s, %s, %s and %s", tableOutput, jsonOutput, wideOutput, templateOutput)
+		}
+	}
+
+	if len(o.columns) > 0 && o.outputFormat != tableOutput && o.outputFormat != wideOutput {
+		return fmt.Errorf("--columns is only supported with --output %s or %s", tableOutput, wideOutput)
+	}
+	for _, column := range o.columns {
+		if _, ok := statColumns[column]; !ok {
+			return fmt.Errorf("unknown column %q, must be one of: %s", column, strings.Join(statColumnNames(), ", "))
+		}
+	}
+
+	if o.rollup && o.outputFormat != tableOutput && o.outputFormat != wideOutput {
+		return fmt.Errorf("--rollup is only supported with --output %s or %s", tableOutput, wideOutput)
+	}
+	return nil
+}
+
 // validateConflictingFlags validates that the options do not contain mutually
 // exclusive flags.
 func (o *statOptions) validateConflictingFlags() error {
@@ -876,6 +1595,14 @@ func (o *statOptions) validateConflictingFlags() error {
 		return fmt.Errorf("--to-namespace and --from-namespace flags are mutually exclusive")
 	}
 
+	if o.meshedOnly && o.unmeshedOnly {
+		return fmt.Errorf("--meshed-only and --unmeshed-only flags are mutually exclusive")
+	}
+
+	if o.fromIdentity != "" && (o.toResource != "" || o.fromResource != "") {
+		return fmt.Errorf("--from-identity is mutually exclusive with the --to and --from flags")
+	}
+
 	if o.allNamespaces && o.namespace != cmd.GetDefaultNamespace(kubeconfigPath, kubeContext) {
 		return fmt.Errorf("--all-namespaces and --namespace flags are mutually exclusive")
 	}
@@ -916,7 +1643,7 @@ func getByteRate(bytes uint64, timeWindow string) float64 {
 func renderStats(buffer bytes.Buffer, options *statOptionsBase) string {
 	var out string
 	switch options.outputFormat {
-	case jsonOutput:
+	case jsonOutput, templateOutput:
 		out = buffer.String()
 	default:
 		// strip left padding on the first column
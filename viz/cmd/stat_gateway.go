@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/linkerd/linkerd2/viz/pkg/api"
+)
+
+// isGatewayStat returns true if the stat arguments request the stats of the
+// ingress controllers, e.g. `linkerd viz stat gateway`
+func isGatewayStat(args []string) bool {
+	if len(args) != 1 {
+		return false
+	}
+	switch args[0] {
+	case "gateway", "gateways", "gw":
+		return true
+	}
+	return false
+}
+
+// runGatewayStat displays the traffic of the ingress controllers, split by
+// backend service. Controllers usually live in their own namespace, so all
+// namespaces are reported unless one is explicitly given.
+func runGatewayStat(options *statOptions) error {
+	req, err := buildIngressStatsRequest(options)
+	if err != nil {
+		return fmt.Errorf("error creating metrics request while making stats request: %v", err)
+	}
+
+	client := api.CheckClientOrExit(healthcheck.Options{
+		ControlPlaneNamespace: controlPlaneNamespace,
+		KubeConfig:            kubeconfigPath,
+		Impersonate:           impersonate,
+		ImpersonateGroup:      impersonateGroup,
+		KubeContext:           kubeContext,
+		APIAddr:               apiAddr,
+	})
+
+	resp, err := requestIngressStatsFromAPI(client, req)
+	if err != nil {
+		fmt.Fprint(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+
+	_, err = fmt.Print(renderIngressStats(resp.GetOk().GetRows(), options))
+	return err
+}
+
+func buildIngressStatsRequest(options *statOptions) (*pb.IngressStatsRequest, error) {
+	switch options.outputFormat {
+	case tableOutput, jsonOutput:
+	default:
+		return nil, fmt.Errorf("--output supports %s and %s", tableOutput, jsonOutput)
+	}
+	if options.toResource != "" || options.fromResource != "" || options.labelSelector != "" {
+		return nil, fmt.Errorf("--to, --from and --selector are not supported for gateways")
+	}
+
+	namespace := options.namespace
+	if options.allNamespaces {
+		namespace = ""
+	}
+	return &pb.IngressStatsRequest{
+		Namespace:  namespace,
+		TimeWindow: options.timeWindow,
+	}, nil
+}
+
+func requestIngressStatsFromAPI(client pb.ApiClient, req *pb.IngressStatsRequest) (*pb.IngressStatsResponse, error) {
+	resp, err := client.IngressStats(context.Background(), req)
+	if err != nil {
+		return nil, fmt.Errorf("IngressStats API error: %v", err)
+	}
+	if e := resp.GetError(); e != nil {
+		return nil, fmt.Errorf("IngressStats API response error: %v", e.Error)
+	}
+	return resp, nil
+}
+
+func renderIngressStats(rows []*pb.IngressStatsRow, options *statOptions) string {
+	var buffer bytes.Buffer
+	switch options.outputFormat {
+	case jsonOutput:
+		printIngressStatsJSON(rows, &buffer, options)
+	default:
+		printIngressStatsTable(rows, &buffer, options)
+	}
+	return buffer.String()
+}
+
+func ingressClassName(row *pb.IngressStatsRow) string {
+	if row.GetIngressClass() == "" {
+		return "-"
+	}
+	return row.GetIngressClass()
+}
+
+func ingressControllerName(row *pb.IngressStatsRow) string {
+	controller := row.GetController()
+	return k8s.ShortNameFromCanonicalResourceName(controller.GetType()) + "/" + controller.GetName()
+}
+
+func ingressBackendName(row *pb.IngressStatsRow) string {
+	return row.GetBackend().GetNamespace() + "/" + row.GetBackend().GetName()
+}
+
+func printIngressStatsTable(rows []*pb.IngressStatsRow, out io.Writer, options *statOptions) {
+	if len(rows) == 0 {
+		fmt.Fprintln(os.Stderr, "No traffic found.")
+		return
+	}
+
+	classWidth := len("CLASS")
+	for _, row := range rows {
+		if width := len(ingressClassName(row)); width > classWidth {
+			classWidth = width
+		}
+	}
+	// template for left-aligning the class column
+	classTemplate := fmt.Sprintf("%%-%ds", classWidth)
+
+	var buffer bytes.Buffer
+	w := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', tabwriter.AlignRight)
+	headers := []string{
+		fmt.Sprintf(classTemplate, "CLASS"),
+		"NAMESPACE",
+		"CONTROLLER",
+		"BACKEND",
+		"SUCCESS",
+		"RPS",
+		"LATENCY_P50",
+		"LATENCY_P95",
+		"LATENCY_P99\t", // trailing \t is required to format last column
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	for _, row := range rows {
+		stats := row.GetStats()
+		fmt.Fprintf(w, classTemplate+"\t%s\t%s\t%s\t%.2f%%\t%.1frps\t%dms\t%dms\t%dms\t\n",
+			ingressClassName(row),
+			row.GetController().GetNamespace(),
+			ingressControllerName(row),
+			ingressBackendName(row),
+			getSuccessRate(stats.GetSuccessCount(), stats.GetFailureCount())*100,
+			getRequestRate(stats.GetSuccessCount(), stats.GetFailureCount(), options.timeWindow),
+			stats.GetLatencyMsP50(),
+			stats.GetLatencyMsP95(),
+			stats.GetLatencyMsP99(),
+		)
+	}
+	w.Flush()
+
+	fmt.Fprint(out, renderStats(buffer, &options.statOptionsBase))
+}
+
+// jsonIngressStats represents the JSON output of `linkerd viz stat gateway`
+type jsonIngressStats struct {
+	Class        string  `json:"class"`
+	Namespace    string  `json:"namespace"`
+	Controller   string  `json:"controller"`
+	Backend      string  `json:"backend"`
+	Success      float64 `json:"success"`
+	Rps          float64 `json:"rps"`
+	LatencyMSp50 uint64  `json:"latency_ms_p50"`
+	LatencyMSp95 uint64  `json:"latency_ms_p95"`
+	LatencyMSp99 uint64  `json:"latency_ms_p99"`
+}
+
+func printIngressStatsJSON(rows []*pb.IngressStatsRow, out io.Writer, options *statOptions) {
+	// avoid nil initialization so that if there are no stats it gets
+	// marshalled as an empty array vs null
+	entries := []*jsonIngressStats{}
+	for _, row := range rows {
+		stats := row.GetStats()
+		entries = append(entries, &jsonIngressStats{
+			Class:        row.GetIngressClass(),
+			Namespace:    row.GetController().GetNamespace(),
+			Controller:   ingressControllerName(row),
+			Backend:      ingressBackendName(row),
+			Success:      getSuccessRate(stats.GetSuccessCount(), stats.GetFailureCount()),
+			Rps:          getRequestRate(stats.GetSuccessCount(), stats.GetFailureCount(), options.timeWindow),
+			LatencyMSp50: stats.GetLatencyMsP50(),
+			LatencyMSp95: stats.GetLatencyMsP95(),
+			LatencyMSp99: stats.GetLatencyMsP99(),
+		})
+	}
+
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error marshalling JSON: %s\n", err)
+		return
+	}
+	fmt.Fprintf(out, "%s\n", b)
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"testing"
+
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	api "github.com/linkerd/linkerd2/viz/metrics-api"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+)
+
+func ingressStatsRow(class, kind, namespace, name, backend string, stats *pb.BasicStats) *pb.IngressStatsRow {
+	return &pb.IngressStatsRow{
+		IngressClass: class,
+		Controller: &pb.Resource{
+			Namespace: namespace,
+			Type:      kind,
+			Name:      name,
+		},
+		Backend: &pb.Resource{
+			Namespace: "emojivoto",
+			Type:      pkgK8s.Service,
+			Name:      backend,
+		},
+		Stats: stats,
+	}
+}
+
+func genIngressStatsResponse() *pb.IngressStatsResponse {
+	stats := &pb.BasicStats{
+		SuccessCount: 90,
+		FailureCount: 30,
+		LatencyMsP50: 12,
+		LatencyMsP95: 100,
+		LatencyMsP99: 250,
+	}
+	return &pb.IngressStatsResponse{
+		Response: &pb.IngressStatsResponse_Ok_{
+			Ok: &pb.IngressStatsResponse_Ok{
+				Rows: []*pb.IngressStatsRow{
+					ingressStatsRow("", pkgK8s.Pod, "edge", "edge-router", "web-svc", stats),
+					ingressStatsRow("nginx", pkgK8s.Deployment, "ingress-nginx", "ingress-nginx-controller", "emoji-svc", stats),
+					ingressStatsRow("nginx", pkgK8s.Deployment, "ingress-nginx", "ingress-nginx-controller", "web-svc", stats),
+					ingressStatsRow("traefik", pkgK8s.DaemonSet, "traefik", "traefik", "web-svc", stats),
+				},
+			},
+		},
+	}
+}
+
+func TestStatGateway(t *testing.T) {
+	t.Run("Returns gateway stats", func(t *testing.T) {
+		testStatGatewayCall(t, newStatOptions(), "stat_gateway_output.golden")
+	})
+
+	t.Run("Returns gateway stats (json)", func(t *testing.T) {
+		options := newStatOptions()
+		options.outputFormat = jsonOutput
+		testStatGatewayCall(t, options, "stat_gateway_output_json.golden")
+	})
+
+	t.Run("Recognizes the gateway arguments", func(t *testing.T) {
+		for _, args := range [][]string{{"gateway"}, {"gateways"}, {"gw"}} {
+			if !isGatewayStat(args) {
+				t.Fatalf("Expected %v to request gateway stats", args)
+			}
+		}
+		for _, args := range [][]string{{"deploy"}, {"gw", "nginx"}} {
+			if isGatewayStat(args) {
+				t.Fatalf("Expected %v not to request gateway stats", args)
+			}
+		}
+	})
+
+	t.Run("Ignores the namespace when requesting all namespaces", func(t *testing.T) {
+		options := newStatOptions()
+		options.namespace = "ingress-nginx"
+		options.allNamespaces = true
+
+		req, err := buildIngressStatsRequest(options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if req.GetNamespace() != "" {
+			t.Fatalf("Expected no namespace, got [%s]", req.GetNamespace())
+		}
+	})
+
+	t.Run("Returns an error if --to is specified", func(t *testing.T) {
+		options := newStatOptions()
+		options.toResource = "deploy/web"
+		expectedError := "--to, --from and --selector are not supported for gateways"
+
+		_, err := buildIngressStatsRequest(options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+
+	t.Run("Returns an error if outputFormat specified is not table or json", func(t *testing.T) {
+		options := newStatOptions()
+		options.outputFormat = wideOutput
+		expectedError := "--output supports table and json"
+
+		_, err := buildIngressStatsRequest(options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+}
+
+func testStatGatewayCall(t *testing.T, options *statOptions, file string) {
+	t.Helper()
+	mockClient := &api.MockAPIClient{}
+	mockClient.IngressStatsResponseToReturn = genIngressStatsResponse()
+
+	req, err := buildIngressStatsRequest(options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	resp, err := requestIngressStatsFromAPI(mockClient, req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	testDataDiffer.DiffTestdata(t, file, renderIngressStats(resp.GetOk().GetRows(), options))
+}
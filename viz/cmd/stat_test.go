@@ -1,11 +1,18 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	pkgcmd "github.com/linkerd/linkerd2/pkg/cmd"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	api "github.com/linkerd/linkerd2/viz/metrics-api"
+	vizClient "github.com/linkerd/linkerd2/viz/metrics-api/client"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"google.golang.org/grpc"
+	healthPb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type paramsExp struct {
@@ -13,6 +20,17 @@ type paramsExp struct {
 	options *statOptions
 	resNs   []string
 	file    string
+	queries []*pb.PromQuery
+	// tcpOnly drops the request stats of the rows
+	tcpOnly bool
+	// versions breaks each row down into one row per version
+	versions []string
+	// ports breaks each row down into one row per port
+	ports []uint32
+	// args are the arguments of the command, "ns" when empty
+	args []string
+	// slo is set as the SLO evaluation of the rows
+	slo *pb.SloStats
 }
 
 func TestStat(t *testing.T) {
@@ -44,6 +62,22 @@ func TestStat(t *testing.T) {
 		}, k8s.Pod, t)
 	})
 
+	t.Run("Returns pod stats with restarts", func(t *testing.T) {
+		testStatCall(paramsExp{
+			counts: &api.PodCounts{
+				Status:      "Running",
+				MeshedPods:  1,
+				RunningPods: 1,
+				FailedPods:  0,
+				Restarts:    4,
+				OOMKilled:   1,
+			},
+			options: options,
+			resNs:   []string{"emojivoto1"},
+			file:    "stat_one_pod_restarts_output.golden",
+		}, k8s.Pod, t)
+	})
+
 	options.outputFormat = jsonOutput
 	t.Run("Returns namespace stats (json)", func(t *testing.T) {
 		testStatCall(paramsExp{
@@ -102,6 +136,303 @@ func TestStat(t *testing.T) {
 		}, k8s.Namespace, t)
 	})
 
+	t.Run("Returns TCP stats of the resources only serving opaque traffic", func(t *testing.T) {
+		testStatCall(paramsExp{
+			counts: &api.PodCounts{
+				MeshedPods:  1,
+				RunningPods: 2,
+				FailedPods:  0,
+			},
+			options: options,
+			resNs:   []string{"emojivoto1"},
+			file:    "stat_one_tcp_only_output.golden",
+			tcpOnly: true,
+		}, k8s.Namespace, t)
+	})
+
+	t.Run("Returns the stats broken down by version", func(t *testing.T) {
+		options := newStatOptions()
+		options.versionLabel = versionLabelDefault
+		testStatCall(paramsExp{
+			counts: &api.PodCounts{
+				MeshedPods:  1,
+				RunningPods: 1,
+				FailedPods:  0,
+			},
+			options:  options,
+			resNs:    []string{"emojivoto1"},
+			file:     "stat_by_version_output.golden",
+			versions: []string{"6f9c7b8d5", "75d8c4b7f6"},
+		}, k8s.Deployment, t)
+	})
+
+	t.Run("Returns the stats of authorities broken down by port", func(t *testing.T) {
+		options := newStatOptions()
+		options.byPort = true
+		testStatCall(paramsExp{
+			options: options,
+			resNs:   []string{"emojivoto1"},
+			file:    "stat_by_port_output.golden",
+			ports:   []uint32{80, 8080},
+			args:    []string{"au"},
+		}, k8s.Authority, t)
+	})
+
+	t.Run("Returns the stats of authorities broken down by port (json)", func(t *testing.T) {
+		options := newStatOptions()
+		options.byPort = true
+		options.outputFormat = jsonOutput
+		testStatCall(paramsExp{
+			options: options,
+			resNs:   []string{"emojivoto1"},
+			file:    "stat_by_port_output_json.golden",
+			ports:   []uint32{80, 8080},
+			args:    []string{"au"},
+		}, k8s.Authority, t)
+	})
+
+	t.Run("Returns the stats broken down by version (json)", func(t *testing.T) {
+		options := newStatOptions()
+		options.versionLabel = versionLabelDefault
+		options.outputFormat = jsonOutput
+		testStatCall(paramsExp{
+			counts: &api.PodCounts{
+				MeshedPods:  1,
+				RunningPods: 1,
+				FailedPods:  0,
+			},
+			options:  options,
+			resNs:    []string{"emojivoto1"},
+			file:     "stat_by_version_output_json.golden",
+			versions: []string{"6f9c7b8d5", "75d8c4b7f6"},
+		}, k8s.Deployment, t)
+	})
+
+	t.Run("Returns the policy attached to workloads", func(t *testing.T) {
+		testStatCall(paramsExp{
+			counts: &api.PodCounts{
+				MeshedPods:  2,
+				RunningPods: 2,
+				FailedPods:  0,
+				Policy: &pb.PolicyStats{
+					Servers:              2,
+					ServerAuthorizations: 3,
+					DefaultDeny:          true,
+				},
+			},
+			options: options,
+			resNs:   []string{"emojivoto1"},
+			file:    "stat_one_policy_output.golden",
+		}, k8s.Deployment, t)
+	})
+
+	t.Run("Returns the stats of HTTPRoutes", func(t *testing.T) {
+		testStatCall(paramsExp{
+			options: options,
+			resNs:   []string{"emojivoto1"},
+			file:    "stat_httproute_output.golden",
+		}, k8s.HTTPRoute, t)
+	})
+
+	queries := []*pb.PromQuery{
+		{
+			Name:           "QUERY_LATENCY_P50",
+			Query:          `histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="inbound"}[1m])) by (le, namespace))`,
+			EvaluationTime: "2021-10-05T12:00:00Z",
+		},
+		{
+			Name:           "QUERY_REQUESTS",
+			Query:          `sum(increase(response_total{direction="inbound"}[1m])) by (namespace, classification, tls)`,
+			EvaluationTime: "2021-10-05T12:00:00Z",
+		},
+	}
+
+	options = newStatOptions()
+	options.showQueries = true
+	t.Run("Returns the queries behind the stats", func(t *testing.T) {
+		testStatCall(paramsExp{
+			counts: &api.PodCounts{
+				MeshedPods:  1,
+				RunningPods: 2,
+				FailedPods:  0,
+			},
+			options: options,
+			resNs:   []string{"emojivoto1", "emojivoto2"},
+			file:    "stat_queries_output.golden",
+			queries: queries,
+		}, k8s.Namespace, t)
+	})
+
+	options = newStatOptions()
+	options.showQueries = true
+	options.outputFormat = jsonOutput
+	t.Run("Returns the queries behind the stats (json)", func(t *testing.T) {
+		testStatCall(paramsExp{
+			counts: &api.PodCounts{
+				MeshedPods:  1,
+				RunningPods: 2,
+				FailedPods:  0,
+			},
+			options: options,
+			resNs:   []string{"emojivoto1"},
+			file:    "stat_queries_output_json.golden",
+			queries: queries,
+		}, k8s.Namespace, t)
+	})
+
+	options = newStatOptions()
+	options.allNamespaces = true
+	options.columns = []string{"p99", "success", "tcp_conn"}
+	t.Run("Returns the selected columns", func(t *testing.T) {
+		testStatCall(paramsExp{
+			counts: &api.PodCounts{
+				MeshedPods:  1,
+				RunningPods: 2,
+				FailedPods:  0,
+			},
+			options: options,
+			resNs:   []string{"emojivoto1", "emojivoto2"},
+			file:    "stat_columns_output.golden",
+		}, k8s.Namespace, t)
+	})
+
+	options = newStatOptions()
+	options.outputFormat = templateOutput
+	options.template = "{{.namespace}}/{{.name}} success={{.success}} p99={{.latency_ms_p99}}ms"
+	t.Run("Returns the stats rendered with a template", func(t *testing.T) {
+		testStatCall(paramsExp{
+			counts: &api.PodCounts{
+				MeshedPods:  1,
+				RunningPods: 2,
+				FailedPods:  0,
+			},
+			options: options,
+			resNs:   []string{"emojivoto1", "emojivoto2"},
+			file:    "stat_template_output.golden",
+		}, k8s.Namespace, t)
+	})
+
+	t.Run("Returns the rollup rows of the namespaces", func(t *testing.T) {
+		options := newStatOptions()
+		options.allNamespaces = true
+		options.versionLabel = versionLabelDefault
+		options.rollup = true
+		testStatCall(paramsExp{
+			counts: &api.PodCounts{
+				MeshedPods:  1,
+				RunningPods: 1,
+				FailedPods:  0,
+			},
+			options:  options,
+			resNs:    []string{"emojivoto1", "emojivoto2"},
+			file:     "stat_rollup_output.golden",
+			versions: []string{"6f9c7b8d5", "75d8c4b7f6"},
+		}, k8s.Deployment, t)
+	})
+
+	t.Run("Returns the SLO evaluation of the stats", func(t *testing.T) {
+		options := newStatOptions()
+		options.sloTarget = 99.5
+		options.sloWindow = "1h"
+		testStatCall(paramsExp{
+			counts: &api.PodCounts{
+				MeshedPods:  1,
+				RunningPods: 1,
+				FailedPods:  0,
+			},
+			options: options,
+			resNs:   []string{"emojivoto1"},
+			file:    "stat_slo_output.golden",
+			slo: &pb.SloStats{
+				Target:          99.5,
+				Window:          "1h",
+				BurnRate:        2.5,
+				BudgetRemaining: -0.25,
+			},
+		}, k8s.Deployment, t)
+	})
+
+	t.Run("Returns the SLO evaluation of the stats (json)", func(t *testing.T) {
+		options := newStatOptions()
+		options.sloTarget = 99.5
+		options.outputFormat = jsonOutput
+		testStatCall(paramsExp{
+			counts: &api.PodCounts{
+				MeshedPods:  1,
+				RunningPods: 1,
+				FailedPods:  0,
+			},
+			options: options,
+			resNs:   []string{"emojivoto1"},
+			file:    "stat_slo_output_json.golden",
+			slo: &pb.SloStats{
+				Target:          99.5,
+				Window:          "1m",
+				BurnRate:        0.5,
+				BudgetRemaining: 0.75,
+			},
+		}, k8s.Deployment, t)
+	})
+
+	t.Run("Rejects invalid columns and templates", func(t *testing.T) {
+		testCases := []struct {
+			outputFormat  string
+			columns       []string
+			template      string
+			rollup        bool
+			expectedError string
+		}{
+			{
+				outputFormat:  tableOutput,
+				columns:       []string{"rps", "p90"},
+				expectedError: "unknown column \"p90\", must be one of: apex, budget, burn_rate, dst, leaf, meshed, p50, p95, p99, policy, read_bytes, restarts, rps, status, success, tcp_conn, unauthorized, weight, write_bytes",
+			},
+			{
+				outputFormat:  jsonOutput,
+				columns:       []string{"rps"},
+				expectedError: "--columns is only supported with --output table or wide",
+			},
+			{
+				outputFormat:  templateOutput,
+				expectedError: "--template is required with --output template",
+			},
+			{
+				outputFormat:  tableOutput,
+				template:      "{{.name}}",
+				expectedError: "--template is only supported with --output template",
+			},
+			{
+				outputFormat:  templateOutput,
+				template:      "{{.name",
+				expectedError: "invalid --template: template: stat:1: unclosed action",
+			},
+			{
+				outputFormat:  "yaml",
+				expectedError: "--output currently only supports table, json, wide and template",
+			},
+			{
+				outputFormat:  jsonOutput,
+				rollup:        true,
+				expectedError: "--rollup is only supported with --output table or wide",
+			},
+		}
+
+		for _, tc := range testCases {
+			tc := tc // pin
+			options := newStatOptions()
+			options.namespace = pkgcmd.GetDefaultNamespace(kubeconfigPath, kubeContext)
+			options.outputFormat = tc.outputFormat
+			options.columns = tc.columns
+			options.template = tc.template
+			options.rollup = tc.rollup
+
+			_, err := buildStatSummaryRequests([]string{"deploy"}, options)
+			if err == nil || err.Error() != tc.expectedError {
+				t.Fatalf("Expected error [%s] instead got [%s]", tc.expectedError, err)
+			}
+		}
+	})
+
 	t.Run("Returns an error for named resource queries with the --all-namespaces flag", func(t *testing.T) {
 		options := newStatOptions()
 		options.allNamespaces = true
@@ -133,6 +464,22 @@ func TestStat(t *testing.T) {
 		}
 	})
 
+	t.Run("Rejects commands with both --from-identity and --to flags", func(t *testing.T) {
+		options := newStatOptions()
+		if options.namespace == "" {
+			options.namespace = pkgcmd.GetDefaultNamespace(kubeconfigPath, kubeContext)
+		}
+		options.fromIdentity = "web.emojivoto.serviceaccount.identity.linkerd.cluster.local"
+		options.toResource = "deploy/foo"
+		args := []string{"deploy"}
+		expectedError := "--from-identity is mutually exclusive with the --to and --from flags"
+
+		_, err := buildStatSummaryRequests(args, options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+
 	t.Run("Rejects commands with both --to-namespace and --from-namespace flags", func(t *testing.T) {
 		options := newStatOptions()
 		if options.namespace == "" {
@@ -195,6 +542,67 @@ func TestStat(t *testing.T) {
 		}
 	})
 
+	t.Run("Rejects --by-version for resources that don't own pods", func(t *testing.T) {
+		options := newStatOptions()
+		if options.namespace == "" {
+			options.namespace = pkgcmd.GetDefaultNamespace(kubeconfigPath, kubeContext)
+		}
+		options.versionLabel = versionLabelDefault
+		args := []string{"svc/web"}
+		expectedError := "--by-version is only supported with workload resources"
+
+		_, err := buildStatSummaryRequests(args, options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+
+	t.Run("Rejects --by-port for resources other than authorities", func(t *testing.T) {
+		options := newStatOptions()
+		if options.namespace == "" {
+			options.namespace = pkgcmd.GetDefaultNamespace(kubeconfigPath, kubeContext)
+		}
+		options.byPort = true
+		args := []string{"deploy/web"}
+		expectedError := "--by-port is only supported with authorities"
+
+		_, err := buildStatSummaryRequests(args, options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+
+	t.Run("Rejects --meshed-only and --unmeshed-only together", func(t *testing.T) {
+		options := newStatOptions()
+		if options.namespace == "" {
+			options.namespace = pkgcmd.GetDefaultNamespace(kubeconfigPath, kubeContext)
+		}
+		options.meshedOnly = true
+		options.unmeshedOnly = true
+		args := []string{"deploy"}
+		expectedError := "--meshed-only and --unmeshed-only flags are mutually exclusive"
+
+		_, err := buildStatSummaryRequests(args, options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+
+	t.Run("Rejects --unmeshed-only for resources that don't own pods", func(t *testing.T) {
+		options := newStatOptions()
+		if options.namespace == "" {
+			options.namespace = pkgcmd.GetDefaultNamespace(kubeconfigPath, kubeContext)
+		}
+		options.unmeshedOnly = true
+		args := []string{"authorities"}
+		expectedError := "--meshed-only and --unmeshed-only are only supported with workload resources"
+
+		_, err := buildStatSummaryRequests(args, options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+
 	t.Run("Returns an error if --time-window is not more than 15s", func(t *testing.T) {
 		options := newStatOptions()
 		if options.namespace == "" {
@@ -215,12 +623,47 @@ func testStatCall(exp paramsExp, resourceType string, t *testing.T) {
 	mockClient := &api.MockAPIClient{}
 	response := api.GenStatSummaryResponse("emoji", resourceType, exp.resNs, exp.counts, true, true)
 
+	for _, table := range response.GetOk().GetStatTables() {
+		for _, row := range table.GetPodGroup().GetRows() {
+			row.Queries = exp.queries
+			row.SloStats = exp.slo
+			if exp.tcpOnly {
+				row.Stats = nil
+			}
+		}
+		if len(exp.versions) > 0 {
+			rows := []*pb.StatTable_PodGroup_Row{}
+			for _, row := range table.GetPodGroup().GetRows() {
+				for _, version := range exp.versions {
+					versionRow := proto.Clone(row).(*pb.StatTable_PodGroup_Row)
+					versionRow.Version = version
+					rows = append(rows, versionRow)
+				}
+			}
+			table.GetPodGroup().Rows = rows
+		}
+		if len(exp.ports) > 0 {
+			rows := []*pb.StatTable_PodGroup_Row{}
+			for _, row := range table.GetPodGroup().GetRows() {
+				for _, port := range exp.ports {
+					portRow := proto.Clone(row).(*pb.StatTable_PodGroup_Row)
+					portRow.Port = port
+					rows = append(rows, portRow)
+				}
+			}
+			table.GetPodGroup().Rows = rows
+		}
+	}
 	mockClient.StatSummaryResponseToReturn = response
 
 	if exp.options.namespace == "" {
 		exp.options.namespace = pkgcmd.GetDefaultNamespace(kubeconfigPath, kubeContext)
 	}
-	reqs, err := buildStatSummaryRequests([]string{"ns"}, exp.options)
+	args := exp.args
+	if len(args) == 0 {
+		args = []string{"ns"}
+	}
+	reqs, err := buildStatSummaryRequests(args, exp.options)
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -235,3 +678,46 @@ func testStatCall(exp paramsExp, resourceType string, t *testing.T) {
 
 	testDataDiffer.DiffTestdata(t, exp.file, output)
 }
+
+func TestRequestStatsFromAPI(t *testing.T) {
+	testCases := []struct {
+		name          string
+		statuses      map[string]healthPb.HealthCheckResponse_ServingStatus
+		expectedError string
+	}{
+		{
+			name:          "Reports the metrics-api errors as is when Prometheus is reachable",
+			expectedError: "StatSummary API error: query failed",
+		},
+		{
+			name: "Points at Prometheus when it's unreachable",
+			statuses: map[string]healthPb.HealthCheckResponse_ServingStatus{
+				vizClient.PrometheusHealthService: healthPb.HealthCheckResponse_NOT_SERVING,
+			},
+			expectedError: "StatSummary API error: query failed (the metrics-api is up but can't reach Prometheus)",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			client := &failingStatClient{
+				MockAPIClient: api.MockAPIClient{HealthStatusesToReturn: tc.statuses},
+			}
+			_, err := requestStatsFromAPI(client, &pb.StatSummaryRequest{})
+			if err == nil || err.Error() != tc.expectedError {
+				t.Fatalf("Expected error [%s] instead got [%s]", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+// failingStatClient is a metrics-api client whose StatSummary requests fail
+// while its health checks succeed
+type failingStatClient struct {
+	api.MockAPIClient
+}
+
+func (c *failingStatClient) StatSummary(context.Context, *pb.StatSummaryRequest, ...grpc.CallOption) (*pb.StatSummaryResponse, error) {
+	return nil, errors.New("query failed")
+}
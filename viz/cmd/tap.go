@@ -37,8 +37,14 @@ type tapOptions struct {
 	method        string
 	authority     string
 	path          string
+	grpcStatus    string
+	responseClass string
 	output        string
 	labelSelector string
+	// protoDescriptorSet is the path of the FileDescriptorSet used to
+	// describe the gRPC requests
+	protoDescriptorSet string
+	grpcMethods        grpcMethods
 }
 
 type endpoint struct {
@@ -77,6 +83,8 @@ type requestInitEvent struct {
 	Authority string     `json:"authority"`
 	Path      string     `json:"path"`
 	Headers   []metadata `json:"headers"`
+	// GrpcMethod is only set for the methods of the --proto-descriptor-set
+	GrpcMethod *grpcMethodTypes `json:"grpcMethod,omitempty"`
 }
 
 type responseInitEvent struct {
@@ -116,6 +124,8 @@ func newTapOptions() *tapOptions {
 		method:        "",
 		authority:     "",
 		path:          "",
+		grpcStatus:    "",
+		responseClass: "",
 		output:        "",
 		labelSelector: "",
 	}
@@ -164,7 +174,13 @@ func NewCmdTap() *cobra.Command {
   * replicasets
   * replicationcontrollers
   * statefulsets
-  * services (only supported as a --to resource)`,
+  * services (only supported as a --to resource)
+
+  The requests to the gRPC methods of the FileDescriptorSet given with
+  --proto-descriptor-set are displayed along with the request and response
+  message types of their method, also in the JSON output. The proxies only
+  report the headers, trailers and lengths of the requests and responses they
+  tap, not their bodies, so the messages themselves aren't decoded.`,
 		Example: `  # tap the web deployment in the default namespace
   linkerd viz tap deploy/web
 
@@ -172,7 +188,14 @@ func NewCmdTap() *cobra.Command {
   linkerd viz tap pod/web-dlbvj
 
   # tap the test namespace, filter by request to prod namespace
-  linkerd viz tap ns/test --to ns/prod`,
+  linkerd viz tap ns/test --to ns/prod
+
+  # tap the web deployment, only displaying the requests that timed out
+  linkerd viz tap deploy/web --grpc-status DEADLINE_EXCEEDED
+
+  # tap the web deployment, displaying the message types of its gRPC requests
+  protoc --include_imports --descriptor_set_out=emojivoto.pb Emoji.proto Voting.proto
+  linkerd viz tap deploy/web --proto-descriptor-set emojivoto.pb -o json`,
 		Args: cobra.RangeArgs(1, 2),
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			// This command requires at most two arguments if we already have
@@ -224,6 +247,8 @@ func NewCmdTap() *cobra.Command {
 				Method:        options.method,
 				Authority:     options.authority,
 				Path:          options.path,
+				GrpcStatus:    options.grpcStatus,
+				ResponseClass: options.responseClass,
 				Extract:       options.output == jsonOutput,
 				LabelSelector: options.labelSelector,
 			}
@@ -239,6 +264,14 @@ func NewCmdTap() *cobra.Command {
 				os.Exit(1)
 			}
 
+			if options.protoDescriptorSet != "" {
+				options.grpcMethods, err = loadGrpcMethods(options.protoDescriptorSet)
+				if err != nil {
+					fmt.Fprint(os.Stderr, err.Error())
+					os.Exit(1)
+				}
+			}
+
 			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
 			if err != nil {
 				fmt.Fprint(os.Stderr, err.Error())
@@ -271,10 +304,16 @@ func NewCmdTap() *cobra.Command {
 		"Display requests with this :authority")
 	cmd.PersistentFlags().StringVar(&options.path, "path", options.path,
 		"Display requests with paths that start with this prefix")
+	cmd.PersistentFlags().StringVar(&options.grpcStatus, "grpc-status", options.grpcStatus,
+		"Display requests whose response ends with this gRPC status, given by name (e.g. DEADLINE_EXCEEDED) or code; the events of each request are only displayed once its response ends")
+	cmd.PersistentFlags().StringVar(&options.responseClass, "response-class", options.responseClass,
+		"Display requests whose response is of this class, one of: success, failure; the events of each request are only displayed once its response ends")
 	cmd.PersistentFlags().StringVarP(&options.output, "output", "o", options.output,
 		fmt.Sprintf("Output format. One of: \"%s\", \"%s\"", wideOutput, jsonOutput))
 	cmd.PersistentFlags().StringVarP(&options.labelSelector, "selector", "l", options.labelSelector,
 		"Selector (label query) to filter on, supports '=', '==', and '!='")
+	cmd.PersistentFlags().StringVar(&options.protoDescriptorSet, "proto-descriptor-set", options.protoDescriptorSet,
+		"Path of a FileDescriptorSet (protoc --include_imports --descriptor_set_out) used to display the request and response message types of the gRPC requests; the message bodies aren't reported by the proxies, so they aren't decoded")
 
 	pkgcmd.ConfigureNamespaceFlagCompletion(
 		cmd, []string{"namespace", "to-namespace"},
@@ -294,14 +333,22 @@ func requestTapByResourceFromAPI(ctx context.Context, w io.Writer, k8sAPI *k8s.K
 
 func writeTapEventsToBuffer(w io.Writer, tapByteStream *bufio.Reader, req *tapPb.TapByResourceRequest, options *tapOptions) error {
 	var err error
+	render := renderTapEvent
+	if options.grpcMethods != nil {
+		render = withGrpcMethods(render, options.grpcMethods)
+	}
 	switch options.output {
 	case "":
-		err = renderTapEvents(tapByteStream, w, renderTapEvent, "")
+		err = renderTapEvents(tapByteStream, w, render, "")
 	case wideOutput:
 		resource := req.GetTarget().GetResource().GetType()
-		err = renderTapEvents(tapByteStream, w, renderTapEvent, resource)
+		err = renderTapEvents(tapByteStream, w, render, resource)
 	case jsonOutput:
-		err = renderTapEvents(tapByteStream, w, renderTapEventJSON, "")
+		renderJSON := renderTapEventJSON
+		if options.grpcMethods != nil {
+			renderJSON = withGrpcMethodsJSON(options.grpcMethods)
+		}
+		err = renderTapEvents(tapByteStream, w, renderJSON, "")
 	}
 	if err != nil {
 		return err
@@ -433,7 +480,10 @@ func renderTapEvent(event *tapPb.TapEvent, resource string) string {
 
 // renderTapEventJSON renders a Public API TapEvent to a string in JSON format.
 func renderTapEventJSON(event *tapPb.TapEvent, _ string) string {
-	m := mapPublicToDisplayTapEvent(event)
+	return marshalTapEventJSON(mapPublicToDisplayTapEvent(event))
+}
+
+func marshalTapEventJSON(m *tapEvent) string {
 	e, err := json.MarshalIndent(m, "", "  ")
 	if err != nil {
 		return fmt.Sprintf("{\"error marshalling JSON\": \"%s\"}", err)
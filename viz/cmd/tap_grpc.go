@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	tapPb "github.com/linkerd/linkerd2/viz/tap/gen/tap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// grpcMethods indexes the gRPC methods of a FileDescriptorSet by their
+// request path, i.e. `/<package>.<Service>/<Method>`.
+//
+// The tap events don't carry the messages exchanged by the proxies, so the
+// requests are only described by the message types of their method; their
+// bodies are never decoded.
+type grpcMethods map[string]protoreflect.MethodDescriptor
+
+// loadGrpcMethods reads a binary FileDescriptorSet, as produced by
+// `protoc --include_imports --descriptor_set_out`, and indexes its methods.
+func loadGrpcMethods(path string) (grpcMethods, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, set); err != nil {
+		return nil, fmt.Errorf("failed to parse the FileDescriptorSet in %s: %w", path, err)
+	}
+
+	return newGrpcMethods(set)
+}
+
+func newGrpcMethods(set *descriptorpb.FileDescriptorSet) (grpcMethods, error) {
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid FileDescriptorSet: %w", err)
+	}
+
+	methods := make(grpcMethods)
+	files.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			service := services.Get(i)
+			for j := 0; j < service.Methods().Len(); j++ {
+				method := service.Methods().Get(j)
+				methods[fmt.Sprintf("/%s/%s", service.FullName(), method.Name())] = method
+			}
+		}
+		return true
+	})
+	return methods, nil
+}
+
+// grpcMethodTypes holds the message types of a gRPC method, prefixed with
+// `stream:` for the streaming sides of the method.
+type grpcMethodTypes struct {
+	Request  string `json:"request"`
+	Response string `json:"response"`
+}
+
+// types returns the message types of the gRPC method called with the given
+// path, or nil when the method is unknown.
+func (m grpcMethods) types(path string) *grpcMethodTypes {
+	method, ok := m[path]
+	if !ok {
+		return nil
+	}
+
+	return &grpcMethodTypes{
+		Request:  messageType(method.Input(), method.IsStreamingClient()),
+		Response: messageType(method.Output(), method.IsStreamingServer()),
+	}
+}
+
+// describe returns the message types of the gRPC method called with the
+// given path, formatted to be appended to a rendered tap event. It returns an
+// empty string when the method is unknown.
+func (m grpcMethods) describe(path string) string {
+	types := m.types(path)
+	if types == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(" grpc-request=%s grpc-response=%s", types.Request, types.Response)
+}
+
+func messageType(message protoreflect.MessageDescriptor, streaming bool) string {
+	if streaming {
+		return "stream:" + string(message.FullName())
+	}
+	return string(message.FullName())
+}
+
+// withGrpcMethods decorates render so that the request init events of known
+// gRPC methods are followed by the message types of the method.
+func withGrpcMethods(render renderTapEventFunc, methods grpcMethods) renderTapEventFunc {
+	return func(event *tapPb.TapEvent, resource string) string {
+		rendered := render(event, resource)
+		init := event.GetHttp().GetRequestInit()
+		if init == nil {
+			return rendered
+		}
+		return rendered + methods.describe(init.GetPath())
+	}
+}
+
+// withGrpcMethodsJSON renders the tap events as JSON, adding the message types
+// of the method to the request init events of known gRPC methods.
+func withGrpcMethodsJSON(methods grpcMethods) renderTapEventFunc {
+	return func(event *tapPb.TapEvent, _ string) string {
+		m := mapPublicToDisplayTapEvent(event)
+		if m.RequestInitEvent != nil {
+			m.RequestInitEvent.GrpcMethod = methods.types(m.RequestInitEvent.Path)
+		}
+		return marshalTapEventJSON(m)
+	}
+}
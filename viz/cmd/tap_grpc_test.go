@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	tapPb "github.com/linkerd/linkerd2/viz/tap/gen/tap"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// tapDescriptorSet returns the descriptor set of the tap API, as protoc would
+// generate it
+func tapDescriptorSet() *descriptorpb.FileDescriptorSet {
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := map[string]bool{}
+	var add func(file protoreflect.FileDescriptor)
+	add = func(file protoreflect.FileDescriptor) {
+		if seen[file.Path()] {
+			return
+		}
+		seen[file.Path()] = true
+		for i := 0; i < file.Imports().Len(); i++ {
+			add(file.Imports().Get(i).FileDescriptor)
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(file))
+	}
+	add(tapPb.File_viz_tap_proto)
+	return set
+}
+
+func TestGrpcMethods(t *testing.T) {
+	methods, err := newGrpcMethods(tapDescriptorSet())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	testCases := []struct {
+		path     string
+		expected string
+	}{
+		{
+			path:     "/linkerd2.tap.Tap/TapByResource",
+			expected: " grpc-request=linkerd2.tap.TapByResourceRequest grpc-response=stream:linkerd2.tap.TapEvent",
+		},
+		{
+			path:     "/hello.v1.HelloService/Hello",
+			expected: "",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.path, func(t *testing.T) {
+			description := methods.describe(tc.path)
+			if description != tc.expected {
+				t.Fatalf("Expected [%s], got [%s]", tc.expected, description)
+			}
+		})
+	}
+}
+
+func TestGrpcMethodsJSON(t *testing.T) {
+	methods, err := newGrpcMethods(tapDescriptorSet())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	render := withGrpcMethodsJSON(methods)
+	testCases := []struct {
+		path     string
+		expected *grpcMethodTypes
+	}{
+		{
+			path:     "/linkerd2.tap.Tap/TapByResource",
+			expected: &grpcMethodTypes{Request: "linkerd2.tap.TapByResourceRequest", Response: "stream:linkerd2.tap.TapEvent"},
+		},
+		{
+			path:     "/hello.v1.HelloService/Hello",
+			expected: nil,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.path, func(t *testing.T) {
+			event := &tapPb.TapEvent{
+				Event: &tapPb.TapEvent_Http_{
+					Http: &tapPb.TapEvent_Http{
+						Event: &tapPb.TapEvent_Http_RequestInit_{
+							RequestInit: &tapPb.TapEvent_Http_RequestInit{Path: tc.path},
+						},
+					},
+				},
+			}
+
+			var rendered tapEvent
+			if err := json.Unmarshal([]byte(render(event, "")), &rendered); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			actual := rendered.RequestInitEvent.GrpcMethod
+			if (actual == nil) != (tc.expected == nil) || (actual != nil && *actual != *tc.expected) {
+				t.Fatalf("Expected %+v, got %+v", tc.expected, actual)
+			}
+		})
+	}
+}
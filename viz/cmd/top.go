@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"sort"
 	"strconv"
 	"strings"
@@ -73,6 +74,8 @@ type tableColumn struct {
 	flexible   bool
 	rightAlign bool
 	value      func(tableRow) string
+	// less orders the rows by this column, in ascending order
+	less func(a, b tableRow) bool
 }
 
 type tableRow struct {
@@ -81,12 +84,14 @@ type tableRow struct {
 	route       string
 	source      string
 	destination string
-	count       int
-	best        time.Duration
-	worst       time.Duration
-	last        time.Duration
-	successes   int
-	failures    int
+	// destinationNamespace is only set when the destination is a pod
+	destinationNamespace string
+	count                int
+	best                 time.Duration
+	worst                time.Duration
+	last                 time.Duration
+	successes            int
+	failures             int
 }
 
 func (r tableRow) merge(other tableRow) tableRow {
@@ -103,6 +108,10 @@ func (r tableRow) merge(other tableRow) tableRow {
 	return r
 }
 
+func (r tableRow) successRate() float32 {
+	return float32(r.successes) / float32(r.successes+r.failures)
+}
+
 type column int
 
 const (
@@ -138,6 +147,9 @@ func newTopTable() *topTable {
 			value: func(r tableRow) string {
 				return r.source
 			},
+			less: func(a, b tableRow) bool {
+				return a.source < b.source
+			},
 		}
 
 	table.columns[destinationColumn] =
@@ -150,6 +162,9 @@ func newTopTable() *topTable {
 			value: func(r tableRow) string {
 				return r.destination
 			},
+			less: func(a, b tableRow) bool {
+				return a.destination < b.destination
+			},
 		}
 
 	table.columns[methodColumn] =
@@ -162,6 +177,9 @@ func newTopTable() *topTable {
 			value: func(r tableRow) string {
 				return r.method
 			},
+			less: func(a, b tableRow) bool {
+				return a.method < b.method
+			},
 		}
 
 	table.columns[pathColumn] =
@@ -174,6 +192,9 @@ func newTopTable() *topTable {
 			value: func(r tableRow) string {
 				return r.path
 			},
+			less: func(a, b tableRow) bool {
+				return a.path < b.path
+			},
 		}
 
 	table.columns[routeColumn] =
@@ -186,6 +207,9 @@ func newTopTable() *topTable {
 			value: func(r tableRow) string {
 				return r.route
 			},
+			less: func(a, b tableRow) bool {
+				return a.route < b.route
+			},
 		}
 
 	table.columns[countColumn] =
@@ -199,6 +223,9 @@ func newTopTable() *topTable {
 			value: func(r tableRow) string {
 				return strconv.Itoa(r.count)
 			},
+			less: func(a, b tableRow) bool {
+				return a.count < b.count
+			},
 		}
 
 	table.columns[bestColumn] =
@@ -212,6 +239,9 @@ func newTopTable() *topTable {
 			value: func(r tableRow) string {
 				return formatDuration(r.best)
 			},
+			less: func(a, b tableRow) bool {
+				return a.best < b.best
+			},
 		}
 
 	table.columns[worstColumn] =
@@ -225,6 +255,9 @@ func newTopTable() *topTable {
 			value: func(r tableRow) string {
 				return formatDuration(r.worst)
 			},
+			less: func(a, b tableRow) bool {
+				return a.worst < b.worst
+			},
 		}
 
 	table.columns[lastColumn] =
@@ -238,6 +271,9 @@ func newTopTable() *topTable {
 			value: func(r tableRow) string {
 				return formatDuration(r.last)
 			},
+			less: func(a, b tableRow) bool {
+				return a.last < b.last
+			},
 		}
 
 	table.columns[successRateColumn] =
@@ -249,7 +285,10 @@ func newTopTable() *topTable {
 			flexible:   false,
 			rightAlign: true,
 			value: func(r tableRow) string {
-				return fmt.Sprintf("%.2f%%", 100.0*float32(r.successes)/float32(r.successes+r.failures))
+				return fmt.Sprintf("%.2f%%", 100.0*r.successRate())
+			},
+			less: func(a, b tableRow) bool {
+				return a.successRate() < b.successRate()
 			},
 		}
 
@@ -291,6 +330,10 @@ func NewCmdTop() *cobra.Command {
   The RESOURCE argument specifies the target resource(s) to view traffic for:
   (TYPE [NAME] | TYPE/NAME)
 
+  The traffic is displayed in an interactive table, whose rows can be sorted
+  by any column, filtered and paused. Selecting a row and pressing enter quits
+  the table and taps the requests of the row.
+
   Examples:
   * cronjob/my-cronjob
   * deploy
@@ -398,7 +441,11 @@ func NewCmdTop() *cobra.Command {
 				return err
 			}
 
-			return getTrafficByResourceFromAPI(cmd.Context(), k8sAPI, req, table)
+			row, err := getTrafficByResourceFromAPI(cmd.Context(), k8sAPI, req, table)
+			if err != nil || row == nil {
+				return err
+			}
+			return tapRow(cmd.Context(), k8sAPI, requestParams, table, row)
 		},
 	}
 
@@ -428,16 +475,18 @@ func NewCmdTop() *cobra.Command {
 	return cmd
 }
 
-func getTrafficByResourceFromAPI(ctx context.Context, k8sAPI *k8s.KubernetesAPI, req *tapPb.TapByResourceRequest, table *topTable) error {
+// getTrafficByResourceFromAPI renders the traffic in a terminal UI until it
+// is quit, and returns the row selected to be tapped, if any
+func getTrafficByResourceFromAPI(ctx context.Context, k8sAPI *k8s.KubernetesAPI, req *tapPb.TapByResourceRequest, table *topTable) (*tableRow, error) {
 	reader, body, err := pkg.Reader(ctx, k8sAPI, req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer body.Close()
 
 	err = termbox.Init()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer termbox.Close()
 
@@ -452,15 +501,16 @@ func getTrafficByResourceFromAPI(ctx context.Context, k8sAPI *k8s.KubernetesAPI,
 	requestCh := make(chan topRequest, 100)
 
 	// for closing:
-	// recvEvents() || pollInput() ->
-	//   closing ->
-	//     done ->
-	//       processEvents() && renderTable()
+	// pollInput() ->
+	//   inputCh ->
+	//     renderTable() ->
+	//       done ->
+	//         processEvents() && pollInput()
 	closing := make(chan struct{}, 1)
 	done := make(chan struct{})
-	horizontalScroll := make(chan int)
+	inputCh := make(chan termbox.Event)
 
-	go pollInput(done, horizontalScroll)
+	go pollInput(inputCh, done)
 	go recvEvents(reader, eventCh, closing)
 	go processEvents(eventCh, requestCh, done)
 
@@ -468,9 +518,29 @@ func getTrafficByResourceFromAPI(ctx context.Context, k8sAPI *k8s.KubernetesAPI,
 		<-closing
 	}()
 
-	renderTable(table, requestCh, done, horizontalScroll)
+	return renderTable(newTopView(table), requestCh, inputCh, done), nil
+}
 
-	return nil
+// tapRow taps the requests aggregated in a row of the table, narrowing the
+// tap request of the top command down to the row's destination pod, method
+// and path
+func tapRow(ctx context.Context, k8sAPI *k8s.KubernetesAPI, params pkg.TapRequestParams, table *topTable, row *tableRow) error {
+	if row.destinationNamespace != "" {
+		params.ToResource = k8s.Pod + "/" + row.destination
+		params.ToNamespace = row.destinationNamespace
+	}
+	if table.columns[methodColumn].key {
+		params.Method = row.method
+	}
+	if table.columns[pathColumn].key {
+		params.Path = row.path
+	}
+
+	req, err := pkg.BuildTapByResourceRequest(params)
+	if err != nil {
+		return err
+	}
+	return requestTapByResourceFromAPI(ctx, os.Stdout, k8sAPI, req, newTapOptions())
 }
 
 func recvEvents(tapByteStream *bufio.Reader, eventCh chan<- *tapPb.TapEvent, closing chan<- struct{}) {
@@ -534,47 +604,200 @@ func processEvents(eventCh <-chan *tapPb.TapEvent, requestCh chan<- topRequest,
 	}
 }
 
-func pollInput(done chan<- struct{}, horizontalScroll chan int) {
+func pollInput(inputCh chan<- termbox.Event, done <-chan struct{}) {
 	for {
-		switch ev := termbox.PollEvent(); ev.Type {
-		case termbox.EventKey:
-			if ev.Ch == 'q' || ev.Key == termbox.KeyCtrlC {
-				close(done)
-				return
-			}
-			if ev.Ch == 'a' || ev.Key == termbox.KeyArrowLeft {
-				horizontalScroll <- xOffset
-			}
-			if ev.Ch == 'd' || ev.Key == termbox.KeyArrowRight {
-				horizontalScroll <- -xOffset
+		ev := termbox.PollEvent()
+		select {
+		case <-done:
+			return
+		default:
+		}
+		if ev.Type != termbox.EventKey {
+			continue
+		}
+		select {
+		case inputCh <- ev:
+		case <-done:
+			return
+		}
+	}
+}
+
+// topView is the state of the terminal UI: how the rows of the table are
+// sorted, filtered and scrolled through
+type topView struct {
+	table *topTable
+
+	sortBy    column
+	ascending bool
+
+	// filter only keeps the rows with a displayed value containing it;
+	// filtering is true while it is being typed
+	filter    string
+	filtering bool
+
+	// paused freezes the table, the requests received in the meantime are
+	// inserted once resumed
+	paused  bool
+	pending []topRequest
+
+	// cursor is the index of the selected row among the visible rows, which
+	// are displayed from the offset one
+	cursor    int
+	offset    int
+	scrollpos int
+}
+
+func newTopView(table *topTable) *topView {
+	return &topView{
+		table:     table,
+		sortBy:    countColumn,
+		ascending: false,
+	}
+}
+
+func (v *topView) insert(req topRequest) {
+	if v.paused {
+		v.pending = append(v.pending, req)
+		return
+	}
+	v.table.insert(req)
+}
+
+func (v *topView) togglePause() {
+	v.paused = !v.paused
+	if !v.paused {
+		for _, req := range v.pending {
+			v.table.insert(req)
+		}
+		v.pending = nil
+	}
+}
+
+// nextSortColumn sorts the rows by the next displayed column
+func (v *topView) nextSortColumn() {
+	for i := 1; i <= int(columnCount); i++ {
+		col := column((int(v.sortBy) + i) % int(columnCount))
+		if v.table.columns[col].display {
+			v.sortBy = col
+			return
+		}
+	}
+}
+
+// visibleRows returns the rows matching the filter, sorted
+func (v *topView) visibleRows() []tableRow {
+	rows := []tableRow{}
+	for _, row := range v.table.rows {
+		if v.matches(row) {
+			rows = append(rows, row)
+		}
+	}
+
+	less := v.table.columns[v.sortBy].less
+	sort.SliceStable(rows, func(i, j int) bool {
+		if v.ascending {
+			return less(rows[i], rows[j])
+		}
+		return less(rows[j], rows[i])
+	})
+	return rows
+}
+
+func (v *topView) matches(row tableRow) bool {
+	if v.filter == "" {
+		return true
+	}
+	for _, col := range v.table.columns {
+		if col.display && strings.Contains(col.value(row), v.filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleKey updates the view according to a key press. It returns true when
+// the UI is quit, along with the row selected to be tapped, if any.
+func (v *topView) handleKey(ev termbox.Event, width int) (bool, *tableRow) {
+	if ev.Key == termbox.KeyCtrlC {
+		return true, nil
+	}
+
+	if v.filtering {
+		switch {
+		case ev.Key == termbox.KeyEnter:
+			v.filtering = false
+		case ev.Key == termbox.KeyEsc:
+			v.filtering = false
+			v.filter = ""
+		case ev.Key == termbox.KeyBackspace || ev.Key == termbox.KeyBackspace2:
+			if len(v.filter) > 0 {
+				runes := []rune(v.filter)
+				v.filter = string(runes[:len(runes)-1])
 			}
+		case ev.Key == termbox.KeySpace:
+			v.filter += " "
+		case ev.Ch != 0:
+			v.filter += string(ev.Ch)
 		}
+		v.cursor = 0
+		return false, nil
 	}
+
+	switch {
+	case ev.Ch == 'q':
+		return true, nil
+	case ev.Ch == 'a' || ev.Key == termbox.KeyArrowLeft:
+		if v.scrollpos < 0 {
+			v.scrollpos += xOffset
+		}
+	case ev.Ch == 'd' || ev.Key == termbox.KeyArrowRight:
+		if v.scrollpos > width-v.table.tableWidthCalc() {
+			v.scrollpos -= xOffset
+		}
+	case ev.Ch == 'k' || ev.Key == termbox.KeyArrowUp:
+		if v.cursor > 0 {
+			v.cursor--
+		}
+	case ev.Ch == 'j' || ev.Key == termbox.KeyArrowDown:
+		v.cursor++
+	case ev.Ch == 's':
+		v.nextSortColumn()
+	case ev.Ch == 'r':
+		v.ascending = !v.ascending
+	case ev.Ch == 'p' || ev.Key == termbox.KeySpace:
+		v.togglePause()
+	case ev.Ch == '/':
+		v.filtering = true
+	case ev.Key == termbox.KeyEsc:
+		v.filter = ""
+	case ev.Key == termbox.KeyEnter:
+		rows := v.visibleRows()
+		if v.cursor < len(rows) {
+			return true, &rows[v.cursor]
+		}
+	}
+	return false, nil
 }
 
-func renderTable(table *topTable, requestCh <-chan topRequest, done <-chan struct{}, horizontalScroll chan int) {
-	scrollpos := 0
+func renderTable(view *topView, requestCh <-chan topRequest, inputCh <-chan termbox.Event, done chan<- struct{}) *tableRow {
 	ticker := time.NewTicker(100 * time.Millisecond)
-	width, _ := termbox.Size()
-	tablewidth := table.tableWidthCalc()
+	defer ticker.Stop()
 
 	for {
 		select {
-		case <-done:
-			return
 		case req := <-requestCh:
-			table.insert(req)
+			view.insert(req)
 		case <-ticker.C:
 			termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
-			width, _ = termbox.Size()
-			table.adjustColumnWidths()
-			tablewidth = table.tableWidthCalc()
-			table.renderHeaders(scrollpos)
-			table.renderBody(scrollpos)
+			view.table.adjustColumnWidths()
+			view.render()
 			termbox.Flush()
-		case offset := <-horizontalScroll:
-			if (offset > 0 && scrollpos < 0) || (offset < 0 && scrollpos > (width-tablewidth)) {
-				scrollpos = scrollpos + offset
+		case ev := <-inputCh:
+			width, _ := termbox.Size()
+			if quit, row := view.handleKey(ev, width); quit {
+				close(done)
+				return row
 			}
 		}
 	}
@@ -592,8 +815,10 @@ func newRow(req topRequest) (tableRow, error) {
 		source = pod
 	}
 	destination := stripPort(addr.PublicAddressToString(req.event.GetDestination()))
+	destinationNamespace := ""
 	if pod := req.event.DestinationMeta.Labels["pod"]; pod != "" {
 		destination = pod
+		destinationNamespace = req.event.DestinationMeta.Labels["namespace"]
 	}
 
 	latency, err := ptypes.Duration(req.rspEnd.GetSinceRequestInit())
@@ -631,17 +856,18 @@ func newRow(req topRequest) (tableRow, error) {
 	}
 
 	return tableRow{
-		path:        path,
-		method:      vizutil.HTTPMethodToString(req.reqInit.GetMethod()),
-		route:       route,
-		source:      source,
-		destination: destination,
-		best:        latency,
-		worst:       latency,
-		last:        latency,
-		count:       1,
-		successes:   successes,
-		failures:    failures,
+		path:                 path,
+		method:               vizutil.HTTPMethodToString(req.reqInit.GetMethod()),
+		route:                route,
+		source:               source,
+		destination:          destination,
+		destinationNamespace: destinationNamespace,
+		best:                 latency,
+		worst:                latency,
+		last:                 latency,
+		count:                1,
+		successes:            successes,
+		failures:             failures,
 	}, nil
 }
 
@@ -680,11 +906,48 @@ func stripPort(address string) string {
 	return strings.Split(address, ":")[0]
 }
 
-func (t *topTable) renderHeaders(scrollpos int) {
-	tbprint(0, 0, "(press q to quit)")
-	tbprint(0, 1, "(press a/LeftArrowKey to scroll left, d/RightArrowKey to scroll right)")
-	x := scrollpos
-	for _, col := range t.columns {
+func (v *topView) render() {
+	_, height := termbox.Size()
+	rows := v.visibleRows()
+	if v.cursor >= len(rows) {
+		v.cursor = len(rows) - 1
+	}
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+	// keep the selected row on the screen
+	bodyHeight := height - headerHeight
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	} else if bodyHeight > 0 && v.cursor >= v.offset+bodyHeight {
+		v.offset = v.cursor - bodyHeight + 1
+	}
+
+	v.renderHeaders()
+	v.renderBody(rows)
+}
+
+func (v *topView) renderHeaders() {
+	tbprint(0, 0, "(press q to quit, p to pause, / to filter, s to sort by the next column, r to reverse the order, enter to tap the selected row)")
+	tbprint(0, 1, "(press a/LeftArrowKey to scroll left, d/RightArrowKey to scroll right, k/UpArrowKey and j/DownArrowKey to select a row)")
+
+	order := "descending"
+	if v.ascending {
+		order = "ascending"
+	}
+	status := fmt.Sprintf("Sorted by %s (%s)", v.table.columns[v.sortBy].header, order)
+	if v.filtering {
+		status += fmt.Sprintf(" | Filter: %s_", v.filter)
+	} else if v.filter != "" {
+		status += fmt.Sprintf(" | Filter: %s (press esc to clear)", v.filter)
+	}
+	if v.paused {
+		status += fmt.Sprintf(" | PAUSED (%d new requests)", len(v.pending))
+	}
+	tbprint(0, 2, status)
+
+	x := v.scrollpos
+	for i, col := range v.table.columns {
 		if !col.display {
 			continue
 		}
@@ -692,7 +955,11 @@ func (t *topTable) renderHeaders(scrollpos int) {
 		if col.rightAlign {
 			padding = col.width - runewidth.StringWidth(col.header)
 		}
-		tbprintBold(x+padding, headerHeight-1, col.header)
+		attr := termbox.AttrBold
+		if column(i) == v.sortBy {
+			attr |= termbox.AttrUnderline
+		}
+		tbprintAttr(x+padding, headerHeight-1, col.header, attr)
 		x += col.width + columnSpacing
 	}
 }
@@ -720,39 +987,37 @@ func (t *topTable) adjustColumnWidths() {
 	}
 }
 
-func (t *topTable) renderBody(scrollpos int) {
-	sort.SliceStable(t.rows, func(i, j int) bool {
-		return t.rows[i].count > t.rows[j].count
-	})
-
-	for i, row := range t.rows {
-		x := scrollpos
+func (v *topView) renderBody(rows []tableRow) {
+	for i := v.offset; i < len(rows); i++ {
+		x := v.scrollpos
+		y := i - v.offset + headerHeight
+		attr := termbox.ColorDefault
+		if i == v.cursor {
+			attr = termbox.AttrReverse
+		}
 
-		for _, col := range t.columns {
+		for _, col := range v.table.columns {
 			if !col.display {
 				continue
 			}
-			value := col.value(row)
+			value := col.value(rows[i])
 			padding := 0
 			if col.rightAlign {
 				padding = col.width - runewidth.StringWidth(value)
 			}
-			tbprint(x+padding, i+headerHeight, value)
+			tbprintAttr(x+padding, y, value, attr)
 			x += col.width + columnSpacing
 		}
 	}
 }
 
 func tbprint(x, y int, msg string) {
-	for _, c := range msg {
-		termbox.SetCell(x, y, c, termbox.ColorDefault, termbox.ColorDefault)
-		x += runewidth.RuneWidth(c)
-	}
+	tbprintAttr(x, y, msg, termbox.ColorDefault)
 }
 
-func tbprintBold(x, y int, msg string) {
+func tbprintAttr(x, y int, msg string, attr termbox.Attribute) {
 	for _, c := range msg {
-		termbox.SetCell(x, y, c, termbox.AttrBold, termbox.ColorDefault)
+		termbox.SetCell(x, y, c, attr, termbox.ColorDefault)
 		x += runewidth.RuneWidth(c)
 	}
 }
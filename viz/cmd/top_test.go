@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	termbox "github.com/nsf/termbox-go"
+)
+
+func newTestTopView() *topView {
+	table := newTopTable()
+	table.rows = []tableRow{
+		{source: "web-0", destination: "emoji-0", method: "GET", path: "/list", count: 3, best: time.Millisecond, successes: 3},
+		{source: "web-0", destination: "voting-0", method: "POST", path: "/vote", count: 7, best: 3 * time.Millisecond, successes: 6, failures: 1},
+		{source: "vote-bot-0", destination: "web-0", method: "GET", path: "/api/vote", count: 5, best: 2 * time.Millisecond, successes: 5},
+	}
+	return newTopView(table)
+}
+
+func destinations(rows []tableRow) []string {
+	dsts := []string{}
+	for _, row := range rows {
+		dsts = append(dsts, row.destination)
+	}
+	return dsts
+}
+
+func TestTopViewVisibleRows(t *testing.T) {
+	testCases := []struct {
+		name      string
+		sortBy    column
+		ascending bool
+		filter    string
+		expected  []string
+	}{
+		{
+			name:     "sorts by descending count by default",
+			sortBy:   countColumn,
+			expected: []string{"voting-0", "web-0", "emoji-0"},
+		},
+		{
+			name:      "sorts by ascending latency",
+			sortBy:    bestColumn,
+			ascending: true,
+			expected:  []string{"emoji-0", "web-0", "voting-0"},
+		},
+		{
+			name:     "sorts by descending success rate",
+			sortBy:   successRateColumn,
+			expected: []string{"emoji-0", "web-0", "voting-0"},
+		},
+		{
+			name:     "filters on all the displayed columns",
+			sortBy:   countColumn,
+			filter:   "vote",
+			expected: []string{"voting-0", "web-0"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			view := newTestTopView()
+			view.sortBy = tc.sortBy
+			view.ascending = tc.ascending
+			view.filter = tc.filter
+
+			if dsts := destinations(view.visibleRows()); !reflect.DeepEqual(dsts, tc.expected) {
+				t.Fatalf("Expected rows %v, got %v", tc.expected, dsts)
+			}
+		})
+	}
+}
+
+func TestTopViewHandleKey(t *testing.T) {
+	t.Run("Types the filter", func(t *testing.T) {
+		view := newTestTopView()
+		for _, ev := range []termbox.Event{
+			{Ch: '/'}, {Ch: 'q'}, {Ch: 'x'}, {Key: termbox.KeyBackspace2}, {Key: termbox.KeyEnter},
+		} {
+			if quit, _ := view.handleKey(ev, 80); quit {
+				t.Fatalf("Unexpected quit on %+v", ev)
+			}
+		}
+		if view.filtering || view.filter != "q" {
+			t.Fatalf("Expected the \"q\" filter to be applied, got %q (filtering: %t)", view.filter, view.filtering)
+		}
+	})
+
+	t.Run("Skips the hidden columns when sorting", func(t *testing.T) {
+		view := newTestTopView()
+		view.sortBy = pathColumn
+		view.handleKey(termbox.Event{Ch: 's'}, 80)
+		if view.sortBy != countColumn {
+			t.Fatalf("Expected to sort by the %s column, got %s", view.table.columns[countColumn].header, view.table.columns[view.sortBy].header)
+		}
+	})
+
+	t.Run("Buffers the requests while paused", func(t *testing.T) {
+		view := newTestTopView()
+		view.handleKey(termbox.Event{Ch: 'p'}, 80)
+		view.insert(topRequest{})
+		if !view.paused || len(view.pending) != 1 {
+			t.Fatalf("Expected 1 pending request, got %d (paused: %t)", len(view.pending), view.paused)
+		}
+	})
+
+	t.Run("Returns the selected row", func(t *testing.T) {
+		view := newTestTopView()
+		view.handleKey(termbox.Event{Key: termbox.KeyArrowDown}, 80)
+		quit, row := view.handleKey(termbox.Event{Key: termbox.KeyEnter}, 80)
+		if !quit || row == nil || row.destination != "web-0" {
+			t.Fatalf("Expected the web-0 row to be selected, got %+v", row)
+		}
+	})
+}
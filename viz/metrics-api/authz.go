@@ -0,0 +1,260 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	serverv1beta1 "github.com/linkerd/linkerd2/controller/gen/apis/server/v1beta1"
+	sazv1beta1 "github.com/linkerd/linkerd2/controller/gen/apis/serverauthorization/v1beta1"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Authz simulates the authorization of a request: it finds the Server the
+// request is sent to and evaluates the ServerAuthorizations selecting it, the
+// way the proxies do. Without any Server, the default policy applies and the
+// request can't be simulated.
+func (s *grpcServer) Authz(ctx context.Context, req *pb.AuthzRequest) (*pb.AuthzResponse, error) {
+	log.Debugf("Authz request: %+v", req)
+
+	target := req.GetTarget()
+	if target == nil {
+		return authzError(req, "Authz request missing target"), nil
+	}
+	if req.GetClientIp() != "" && net.ParseIP(req.GetClientIp()) == nil {
+		return authzError(req, fmt.Sprintf("invalid client IP %q", req.GetClientIp())), nil
+	}
+
+	var server *serverv1beta1.Server
+	var err error
+	if target.GetType() == k8s.Server {
+		server, err = s.k8sAPI.Srv().Lister().Servers(target.GetNamespace()).Get(target.GetName())
+	} else {
+		server, err = s.getServerForPort(target, req.GetPort())
+	}
+	if err != nil {
+		return authzError(req, err.Error()), nil
+	}
+
+	sazs, err := s.k8sAPI.Saz().Lister().ServerAuthorizations(server.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(sazs, func(i, j int) bool { return sazs[i].Name < sazs[j].Name })
+
+	rsp := &pb.AuthzResponse_Ok{
+		Server: &pb.Resource{
+			Namespace: server.Namespace,
+			Type:      k8s.Server,
+			Name:      server.Name,
+		},
+	}
+	for _, saz := range sazs {
+		selected, err := authorizationSelectsServer(saz, server)
+		if err != nil {
+			return nil, err
+		}
+		if selected && authorizesClient(saz, req.GetClientIdentity(), req.GetClientIp()) {
+			rsp.Allowed = true
+			rsp.Authorization = &pb.Resource{
+				Namespace: saz.Namespace,
+				Type:      k8s.ServerAuthorization,
+				Name:      saz.Name,
+			}
+			break
+		}
+	}
+
+	return &pb.AuthzResponse{
+		Response: &pb.AuthzResponse_Ok_{Ok: rsp},
+	}, nil
+}
+
+// getServerForPort returns the Server selecting the port of the workload's
+// pods. When several Servers select it, the oldest one is returned.
+func (s *grpcServer) getServerForPort(target *pb.Resource, port string) (*serverv1beta1.Server, error) {
+	if port == "" {
+		return nil, fmt.Errorf("a port is required to find the Server of %s/%s", target.GetType(), target.GetName())
+	}
+
+	objects, err := s.k8sAPI.GetObjects(target.GetNamespace(), target.GetType(), target.GetName(), labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	pods := []*corev1.Pod{}
+	for _, obj := range objects {
+		objPods, err := s.k8sAPI.GetPodsFor(obj, false)
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, objPods...)
+	}
+
+	servers, err := s.k8sAPI.Srv().Lister().Servers(target.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(servers, func(i, j int) bool {
+		if !servers[i].CreationTimestamp.Equal(&servers[j].CreationTimestamp) {
+			return servers[i].CreationTimestamp.Before(&servers[j].CreationTimestamp)
+		}
+		return servers[i].Name < servers[j].Name
+	})
+
+	for _, server := range servers {
+		if server.Spec.PodSelector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(server.Spec.PodSelector)
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range pods {
+			if selector.Matches(labels.Set(pod.Labels)) && serverSelectsPort(server.Spec.Port, pod, port) {
+				return server, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no Server selects port %s of %s/%s, the default policy applies", port, target.GetType(), target.GetName())
+}
+
+// serverSelectsPort returns true if the port of the Server, a number or the
+// name of a container port, is the pod's port given by its number or name
+func serverSelectsPort(serverPort intstr.IntOrString, pod *corev1.Pod, port string) bool {
+	number, _ := strconv.Atoi(port)
+	name := ""
+	for _, c := range pod.Spec.Containers {
+		for _, cp := range c.Ports {
+			if cp.Name == port || strconv.Itoa(int(cp.ContainerPort)) == port {
+				number = int(cp.ContainerPort)
+				name = cp.Name
+			}
+		}
+	}
+
+	if serverPort.Type == intstr.Int {
+		return number != 0 && serverPort.IntValue() == number
+	}
+	return name != "" && serverPort.StrVal == name
+}
+
+// authorizationSelectsServer returns true if the ServerAuthorization refers to
+// the Server by its name or selects its labels
+func authorizationSelectsServer(saz *sazv1beta1.ServerAuthorization, server *serverv1beta1.Server) (bool, error) {
+	if saz.Spec.Server.Name != "" {
+		return saz.Spec.Server.Name == server.Name, nil
+	}
+	if saz.Spec.Server.Selector == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(saz.Spec.Server.Selector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(server.Labels)), nil
+}
+
+// authorizesClient returns true if the client is authorized by the
+// ServerAuthorization, given its identity and IP
+func authorizesClient(saz *sazv1beta1.ServerAuthorization, identity, ip string) bool {
+	client := saz.Spec.Client
+	if ip != "" && len(client.Networks) > 0 && !inNetworks(client.Networks, net.ParseIP(ip)) {
+		return false
+	}
+
+	if identity == "" {
+		return client.Unauthenticated
+	}
+	if client.Unauthenticated {
+		return true
+	}
+	if client.MeshTLS == nil {
+		return false
+	}
+	if client.MeshTLS.UnauthenticatedTLS {
+		return true
+	}
+	for _, id := range client.MeshTLS.Identities {
+		if id == "*" || id == identity || (strings.HasPrefix(id, "*.") && strings.HasSuffix(identity, id[1:])) {
+			return true
+		}
+	}
+	sa, ns, ok := serviceAccountOf(identity)
+	if !ok {
+		return false
+	}
+	for _, account := range client.MeshTLS.ServiceAccounts {
+		accountNs := account.Namespace
+		if accountNs == "" {
+			accountNs = saz.Namespace
+		}
+		if account.Name == sa && accountNs == ns {
+			return true
+		}
+	}
+	return false
+}
+
+// inNetworks returns true if the IP is in one of the networks, and not in one
+// of their exceptions
+func inNetworks(networks []*sazv1beta1.Cidr, ip net.IP) bool {
+	for _, network := range networks {
+		if !inCidr(network.Cidr, ip) {
+			continue
+		}
+		excepted := false
+		for _, except := range network.Except {
+			if inCidr(except, ip) {
+				excepted = true
+				break
+			}
+		}
+		if !excepted {
+			return true
+		}
+	}
+	return false
+}
+
+func inCidr(cidr string, ip net.IP) bool {
+	// a single IP is a valid network
+	if !strings.Contains(cidr, "/") {
+		return net.ParseIP(cidr).Equal(ip)
+	}
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		log.Warnf("invalid network %q: %s", cidr, err)
+		return false
+	}
+	return ipNet.Contains(ip)
+}
+
+// serviceAccountOf returns the service account and the namespace of an
+// identity of the form <sa>.<ns>.serviceaccount.identity.<...>
+func serviceAccountOf(identity string) (string, string, bool) {
+	parts := strings.SplitN(identity, ".", 4)
+	if len(parts) < 4 || parts[2] != "serviceaccount" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+func authzError(req *pb.AuthzRequest, message string) *pb.AuthzResponse {
+	return &pb.AuthzResponse{
+		Response: &pb.AuthzResponse_Error{
+			Error: &pb.ResourceError{
+				Resource: req.GetTarget(),
+				Error:    message,
+			},
+		},
+	}
+}
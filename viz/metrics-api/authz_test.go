@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+)
+
+var authzConfigs = []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji-1
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+spec:
+  containers:
+  - name: emoji-svc
+    ports:
+    - name: grpc
+      containerPort: 8080
+status:
+  phase: Running
+`, `
+apiVersion: policy.linkerd.io/v1beta1
+kind: Server
+metadata:
+  name: emoji-grpc
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+spec:
+  podSelector:
+    matchLabels:
+      app: emoji-svc
+  port: grpc
+`, `
+apiVersion: policy.linkerd.io/v1beta1
+kind: Server
+metadata:
+  name: emoji-admin
+  namespace: emojivoto
+spec:
+  podSelector:
+    matchLabels:
+      app: emoji-svc
+  port: 4191
+`, `
+apiVersion: policy.linkerd.io/v1beta1
+kind: ServerAuthorization
+metadata:
+  name: emoji-grpc
+  namespace: emojivoto
+spec:
+  server:
+    name: emoji-grpc
+  client:
+    meshTLS:
+      serviceAccounts:
+      - name: web
+`, `
+apiVersion: policy.linkerd.io/v1beta1
+kind: ServerAuthorization
+metadata:
+  name: emoji-admin
+  namespace: emojivoto
+spec:
+  server:
+    name: emoji-admin
+  client:
+    networks:
+    - cidr: 10.0.0.0/8
+      except:
+      - 10.1.0.0/16
+    unauthenticated: true
+`, `
+apiVersion: policy.linkerd.io/v1beta1
+kind: ServerAuthorization
+metadata:
+  name: emoji-prometheus
+  namespace: emojivoto
+spec:
+  server:
+    selector:
+      matchLabels:
+        app: emoji-svc
+  client:
+    meshTLS:
+      identities:
+      - "*.linkerd-viz.serviceaccount.identity.linkerd.cluster.local"
+`,
+}
+
+func TestAuthz(t *testing.T) {
+	emojiPod := &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Pod, Name: "emoji-1"}
+	grpcServer := &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Server, Name: "emoji-grpc"}
+	adminServer := &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Server, Name: "emoji-admin"}
+
+	testCases := []struct {
+		name     string
+		req      *pb.AuthzRequest
+		expected *pb.AuthzResponse
+	}{
+		{
+			name: "allowed service account",
+			req: &pb.AuthzRequest{
+				ClientIdentity: "web.emojivoto.serviceaccount.identity.linkerd.cluster.local",
+				Target:         emojiPod,
+				Port:           "8080",
+			},
+			expected: authzAllowed(grpcServer, "emoji-grpc"),
+		},
+		{
+			name: "allowed identity matching a wildcard",
+			req: &pb.AuthzRequest{
+				ClientIdentity: "prometheus.linkerd-viz.serviceaccount.identity.linkerd.cluster.local",
+				Target:         grpcServer,
+			},
+			expected: authzAllowed(grpcServer, "emoji-prometheus"),
+		},
+		{
+			name: "denied service account",
+			req: &pb.AuthzRequest{
+				ClientIdentity: "vote-bot.emojivoto.serviceaccount.identity.linkerd.cluster.local",
+				Target:         emojiPod,
+				Port:           "grpc",
+			},
+			expected: authzDenied(grpcServer),
+		},
+		{
+			name: "denied unmeshed client",
+			req: &pb.AuthzRequest{
+				Target: emojiPod,
+				Port:   "grpc",
+			},
+			expected: authzDenied(grpcServer),
+		},
+		{
+			name: "allowed unmeshed client in the networks",
+			req: &pb.AuthzRequest{
+				ClientIp: "10.2.0.1",
+				Target:   emojiPod,
+				Port:     "4191",
+			},
+			expected: authzAllowed(adminServer, "emoji-admin"),
+		},
+		{
+			name: "denied unmeshed client in an exception of the networks",
+			req: &pb.AuthzRequest{
+				ClientIp: "10.1.0.1",
+				Target:   emojiPod,
+				Port:     "4191",
+			},
+			expected: authzDenied(adminServer),
+		},
+		{
+			name: "no server",
+			req: &pb.AuthzRequest{
+				Target: emojiPod,
+				Port:   "9090",
+			},
+			expected: authzError(&pb.AuthzRequest{Target: emojiPod}, "no Server selects port 9090 of pod/emoji-1, the default policy applies"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{k8sConfigs: authzConfigs})
+			if err != nil {
+				t.Fatalf("Error creating mock grpc server: %s", err)
+			}
+
+			rsp, err := fakeGrpcServer.Authz(context.TODO(), tc.req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !proto.Equal(rsp, tc.expected) {
+				t.Fatalf("Expected: %+v\nGot: %+v", tc.expected, rsp)
+			}
+		})
+	}
+}
+
+func authzAllowed(server *pb.Resource, authorization string) *pb.AuthzResponse {
+	return &pb.AuthzResponse{
+		Response: &pb.AuthzResponse_Ok_{
+			Ok: &pb.AuthzResponse_Ok{
+				Server:  server,
+				Allowed: true,
+				Authorization: &pb.Resource{
+					Namespace: server.Namespace,
+					Type:      pkgK8s.ServerAuthorization,
+					Name:      authorization,
+				},
+			},
+		},
+	}
+}
+
+func authzDenied(server *pb.Resource) *pb.AuthzResponse {
+	return &pb.AuthzResponse{
+		Response: &pb.AuthzResponse_Ok_{
+			Ok: &pb.AuthzResponse_Ok{
+				Server: server,
+			},
+		},
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -15,6 +16,9 @@ import (
 	log "github.com/sirupsen/logrus"
 	"go.opencensus.io/plugin/ochttp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthPb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -29,6 +33,12 @@ const (
 
 	apiPort       = 8085
 	apiDeployment = "metrics-api"
+
+	// PrometheusHealthService is the name of the health service reflecting
+	// whether the metrics-api can query Prometheus. The empty service name
+	// reflects the metrics-api itself, which is serving as long as it can
+	// answer the health checks.
+	PrometheusHealthService = "prometheus"
 )
 
 type grpcOverHTTPClient struct {
@@ -49,6 +59,12 @@ func (c *grpcOverHTTPClient) Edges(ctx context.Context, req *pb.EdgesRequest, _
 	return &msg, err
 }
 
+func (c *grpcOverHTTPClient) Dependencies(ctx context.Context, req *pb.DependenciesRequest, _ ...grpc.CallOption) (*pb.DependenciesResponse, error) {
+	var msg pb.DependenciesResponse
+	err := c.apiRequest(ctx, "Dependencies", req, &msg)
+	return &msg, err
+}
+
 func (c *grpcOverHTTPClient) TopRoutes(ctx context.Context, req *pb.TopRoutesRequest, _ ...grpc.CallOption) (*pb.TopRoutesResponse, error) {
 	var msg pb.TopRoutesResponse
 	err := c.apiRequest(ctx, "TopRoutes", req, &msg)
@@ -61,12 +77,48 @@ func (c *grpcOverHTTPClient) Gateways(ctx context.Context, req *pb.GatewaysReque
 	return &msg, err
 }
 
+func (c *grpcOverHTTPClient) IngressStats(ctx context.Context, req *pb.IngressStatsRequest, _ ...grpc.CallOption) (*pb.IngressStatsResponse, error) {
+	var msg pb.IngressStatsResponse
+	err := c.apiRequest(ctx, "IngressStats", req, &msg)
+	return &msg, err
+}
+
+func (c *grpcOverHTTPClient) Egress(ctx context.Context, req *pb.EgressRequest, _ ...grpc.CallOption) (*pb.EgressResponse, error) {
+	var msg pb.EgressResponse
+	err := c.apiRequest(ctx, "Egress", req, &msg)
+	return &msg, err
+}
+
+func (c *grpcOverHTTPClient) IngressResourceStats(ctx context.Context, req *pb.IngressResourceStatsRequest, _ ...grpc.CallOption) (*pb.IngressResourceStatsResponse, error) {
+	var msg pb.IngressResourceStatsResponse
+	err := c.apiRequest(ctx, "IngressResourceStats", req, &msg)
+	return &msg, err
+}
+
 func (c *grpcOverHTTPClient) SelfCheck(ctx context.Context, req *pb.SelfCheckRequest, _ ...grpc.CallOption) (*pb.SelfCheckResponse, error) {
 	var msg pb.SelfCheckResponse
 	err := c.apiRequest(ctx, "SelfCheck", req, &msg)
 	return &msg, err
 }
 
+func (c *grpcOverHTTPClient) LabelCompatibility(ctx context.Context, req *pb.LabelCompatibilityRequest, _ ...grpc.CallOption) (*pb.LabelCompatibilityResponse, error) {
+	var msg pb.LabelCompatibilityResponse
+	err := c.apiRequest(ctx, "LabelCompatibility", req, &msg)
+	return &msg, err
+}
+
+func (c *grpcOverHTTPClient) LatencyHeatmap(ctx context.Context, req *pb.LatencyHeatmapRequest, _ ...grpc.CallOption) (*pb.LatencyHeatmapResponse, error) {
+	var msg pb.LatencyHeatmapResponse
+	err := c.apiRequest(ctx, "LatencyHeatmap", req, &msg)
+	return &msg, err
+}
+
+func (c *grpcOverHTTPClient) Authz(ctx context.Context, req *pb.AuthzRequest, _ ...grpc.CallOption) (*pb.AuthzResponse, error) {
+	var msg pb.AuthzResponse
+	err := c.apiRequest(ctx, "Authz", req, &msg)
+	return &msg, err
+}
+
 func (c *grpcOverHTTPClient) ListPods(ctx context.Context, req *pb.ListPodsRequest, _ ...grpc.CallOption) (*pb.ListPodsResponse, error) {
 	var msg pb.ListPodsResponse
 	err := c.apiRequest(ctx, "ListPods", req, &msg)
@@ -79,6 +131,33 @@ func (c *grpcOverHTTPClient) ListServices(ctx context.Context, req *pb.ListServi
 	return &msg, err
 }
 
+// Check implements healthPb.HealthClient
+func (c *grpcOverHTTPClient) Check(ctx context.Context, req *healthPb.HealthCheckRequest, _ ...grpc.CallOption) (*healthPb.HealthCheckResponse, error) {
+	var msg healthPb.HealthCheckResponse
+	err := c.apiRequest(ctx, "HealthCheck", req, &msg)
+	return &msg, err
+}
+
+// Watch implements healthPb.HealthClient. The metrics-api doesn't support
+// streaming health checks.
+func (c *grpcOverHTTPClient) Watch(context.Context, *healthPb.HealthCheckRequest, ...grpc.CallOption) (healthPb.Health_WatchClient, error) {
+	return nil, status.Error(codes.Unimplemented, "the metrics-api doesn't support watching its health")
+}
+
+// CheckHealth returns the serving status of a health service of the
+// metrics-api the client talks to
+func CheckHealth(ctx context.Context, client pb.ApiClient, service string) (healthPb.HealthCheckResponse_ServingStatus, error) {
+	healthClient, ok := client.(healthPb.HealthClient)
+	if !ok {
+		return healthPb.HealthCheckResponse_UNKNOWN, errors.New("the client doesn't support health checks")
+	}
+	rsp, err := healthClient.Check(ctx, &healthPb.HealthCheckRequest{Service: service})
+	if err != nil {
+		return healthPb.HealthCheckResponse_UNKNOWN, err
+	}
+	return rsp.GetStatus(), nil
+}
+
 func (c *grpcOverHTTPClient) apiRequest(ctx context.Context, endpoint string, req proto.Message, protoResponse proto.Message) error {
 	url := c.endpointNameToPublicAPIURL(endpoint)
 
@@ -157,6 +236,14 @@ func NewInternalClient(namespace string, kubeAPIHost string) (pb.ApiClient, erro
 // NewExternalClient creates a new Viz API client intended to run from
 // outside a Kubernetes cluster.
 func NewExternalClient(ctx context.Context, namespace string, kubeAPI *k8s.KubernetesAPI) (pb.ApiClient, error) {
+	client, _, err := NewStoppableExternalClient(ctx, namespace, kubeAPI)
+	return client, err
+}
+
+// NewStoppableExternalClient is like NewExternalClient, but also returns a
+// function terminating the port-forward connection of the client, for the
+// long-running processes creating clients for other clusters.
+func NewStoppableExternalClient(ctx context.Context, namespace string, kubeAPI *k8s.KubernetesAPI) (pb.ApiClient, func(), error) {
 	portforward, err := k8s.NewPortForward(
 		ctx,
 		kubeAPI,
@@ -168,22 +255,28 @@ func NewExternalClient(ctx context.Context, namespace string, kubeAPI *k8s.Kuber
 		false,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	apiURL, err := url.Parse(portforward.URLFor(""))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if err = portforward.Init(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	httpClientToUse, err := kubeAPI.NewClient()
 	if err != nil {
-		return nil, err
+		portforward.Stop()
+		return nil, nil, err
 	}
 
-	return newClient(apiURL, httpClientToUse, namespace)
+	client, err := newClient(apiURL, httpClientToUse, namespace)
+	if err != nil {
+		portforward.Stop()
+		return nil, nil, err
+	}
+	return client, portforward.Stop, nil
 }
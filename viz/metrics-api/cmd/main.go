@@ -3,10 +3,13 @@ package main
 import (
 	"context"
 	"flag"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/pkg/admin"
@@ -21,13 +24,30 @@ func main() {
 	cmd := flag.NewFlagSet("metrics-api", flag.ExitOnError)
 
 	addr := cmd.String("addr", ":8085", "address to serve on")
+	grpcAddr := cmd.String("grpc-addr", ":8086", "address to serve the gRPC health service on")
 	kubeConfigPath := cmd.String("kubeconfig", "", "path to kube config")
 	prometheusURL := cmd.String("prometheus-url", "", "prometheus url")
+	prometheusReplicaURLs := cmd.String("prometheus-replica-urls", "", "comma separated list of the urls of the replicas of the prometheus instance, queried in turn when it fails")
+	prometheusFallbackTimeout := cmd.Duration("prometheus-fallback-timeout", 0, "time after which a prometheus request falls back to the next replica; 0 only falls back on errors")
 	metricsAddr := cmd.String("metrics-addr", ":9995", "address to serve scrapable metrics on")
 	controllerNamespace := cmd.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
 	ignoredNamespaces := cmd.String("ignore-namespaces", "kube-system", "comma separated list of namespaces to not list pods from")
 	clusterDomain := cmd.String("cluster-domain", "cluster.local", "kubernetes cluster domain")
+	labelCheckInterval := cmd.Duration("label-check-interval", 10*time.Minute, "interval at which the proxy metrics are checked for the labels the API relies on; 0 disables the check")
+	maxConcurrentQueries := cmd.Int("max-concurrent-queries", 0, "maximum number of Prometheus queries evaluated at a time, the others wait in line; 0 means no limit")
+	queryQueueTimeout := cmd.Duration("query-queue-timeout", 0, "maximum time a Prometheus query waits in line before failing; 0 waits for as long as the request lasts")
+	maxQuerySeries := cmd.Int("max-query-series", 0, "maximum number of series kept from the result of a Prometheus query, the others are dropped and the response carries a warning; 0 means no limit")
+	logQueries := cmd.Bool("log-queries", false, "log every Prometheus query, along with its latency and the number of series it returned")
+	slowQueryThreshold := cmd.Duration("slow-query-threshold", 0, "latency past which Prometheus queries are logged as slow and their span annotated; 0 disables it")
+	namespaceAliases := cmd.String("namespace-aliases", "", "comma separated list of <namespace>=<alias> pairs; the metrics of the namespaces are presented under their alias, e.g. to map the physical namespaces of a vcluster to its tenant's namespaces")
 
+	metricPrefix := cmd.String("metric-prefix", "", "prefix of the names of the proxy metrics in Prometheus, e.g. when they're renamed at scrape time")
+	metricNames := cmd.String("metric-names", "", "comma separated list of <metric>=<name> pairs, overriding the names the proxy metrics are queried as; the names of recording rules holding the per-second rate of a metric are wrapped in rate(), e.g. response_total=rate(namespace:response_total:rate5m)")
+	recordingRules := cmd.Bool("recording-rules", false, "query the recording rules generated by \"linkerd viz prometheus-rules\" instead of the proxy metrics they pre-aggregate; -metric-names takes precedence")
+
+	rowLabels := cmd.String("row-labels", "", "comma separated list of labels whose values are copied from the resources into their StatTable rows, e.g. to group the stats by team or app version")
+
+	adminTLS := flags.AddAdminTLSFlags(cmd)
 	traceCollector := flags.AddTraceFlags(cmd)
 
 	flags.ConfigureAndParse(cmd, os.Args[1:])
@@ -40,7 +60,7 @@ func main() {
 		ctx,
 		*kubeConfigPath,
 		true,
-		k8s.CJ, k8s.DS, k8s.Deploy, k8s.Job, k8s.NS, k8s.Pod, k8s.RC, k8s.RS, k8s.Svc, k8s.SS, k8s.SP,
+		k8s.CJ, k8s.DS, k8s.Deploy, k8s.Job, k8s.NS, k8s.Pod, k8s.RC, k8s.RS, k8s.Svc, k8s.SS, k8s.SP, k8s.Srv, k8s.Saz,
 	)
 	if err != nil {
 		log.Fatalf("Failed to initialize K8s API: %s", err)
@@ -48,7 +68,11 @@ func main() {
 
 	var prometheusClient promApi.Client
 	if *prometheusURL != "" {
-		prometheusClient, err = promApi.NewClient(promApi.Config{Address: *prometheusURL})
+		urls := []string{*prometheusURL}
+		if *prometheusReplicaURLs != "" {
+			urls = append(urls, strings.Split(*prometheusReplicaURLs, ",")...)
+		}
+		prometheusClient, err = api.NewFailoverClient(urls, *prometheusFallbackTimeout)
 		if err != nil {
 			log.Fatal(err.Error())
 		}
@@ -63,13 +87,47 @@ func main() {
 		}
 	}
 
-	server := api.NewServer(
+	aliases, err := api.ParseNamespaceAliases(*namespaceAliases)
+	if err != nil {
+		log.Fatalf("Failed to parse namespace aliases: %s", err)
+	}
+
+	labels, err := api.ParseRowLabels(*rowLabels)
+	if err != nil {
+		log.Fatalf("Failed to parse row labels: %s", err)
+	}
+
+	if err := api.ValidateMetricPrefix(*metricPrefix); err != nil {
+		log.Fatal(err.Error())
+	}
+	names, err := api.ParseMetricNames(*metricNames)
+	if err != nil {
+		log.Fatalf("Failed to parse metric names: %s", err)
+	}
+	if *recordingRules {
+		names = api.WithRecordingRules(names)
+	}
+
+	done := make(chan struct{})
+
+	server, grpcServer := api.NewServer(
 		*addr,
 		prometheusClient,
 		k8sAPI,
 		*controllerNamespace,
 		*clusterDomain,
 		strings.Split(*ignoredNamespaces, ","),
+		*labelCheckInterval,
+		*maxConcurrentQueries,
+		*queryQueueTimeout,
+		*maxQuerySeries,
+		*logQueries,
+		*slowQueryThreshold,
+		aliases,
+		labels,
+		*metricPrefix,
+		names,
+		done,
 	)
 
 	k8sAPI.Sync(nil) // blocks until caches are synced
@@ -79,16 +137,38 @@ func main() {
 		server.ListenAndServe()
 	}()
 
-	adminServer := admin.NewServer(*metricsAddr)
+	lis, err := net.Listen("tcp", *grpcAddr)
+	if err != nil {
+		log.Fatalf("Failed to listen on %s: %s", *grpcAddr, err)
+	}
+
+	go func() {
+		log.Infof("starting gRPC server on %s", *grpcAddr)
+		grpcServer.Serve(lis)
+	}()
+
+	healthHandler, err := api.NewHealthProbeHandler(*grpcAddr)
+	if err != nil {
+		log.Fatalf("Failed to connect to the gRPC health service: %s", err)
+	}
+
+	adminServer := admin.NewServerWithRoutes(*metricsAddr, map[string]http.Handler{
+		"/health": healthHandler,
+	})
+	if err := admin.EnableTLS(ctx, adminServer, adminTLS); err != nil {
+		log.Fatalf("Failed to enable TLS on the admin server: %s", err)
+	}
 
 	go func() {
 		log.Infof("starting admin server on %s", *metricsAddr)
-		adminServer.ListenAndServe()
+		admin.ListenAndServe(adminServer)
 	}()
 
 	<-stop
+	close(done)
 
 	log.Infof("shutting down HTTP server on %+v", *addr)
 	server.Shutdown(ctx)
+	grpcServer.GracefulStop()
 	adminServer.Shutdown(ctx)
 }
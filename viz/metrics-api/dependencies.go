@@ -0,0 +1,215 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/prometheus/common/model"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultDependenciesDepth = 3
+	defaultDependenciesTime  = "1m"
+)
+
+type dependencyKey struct {
+	namespace string
+	name      string
+}
+
+type dependencyEdge struct {
+	src dependencyKey
+	dst dependencyKey
+}
+
+// dependencyGraph holds the request stats of the edges between resources,
+// indexed in both directions
+type dependencyGraph struct {
+	stats       map[dependencyEdge]*pb.BasicStats
+	upstreams   map[dependencyKey][]dependencyKey
+	downstreams map[dependencyKey][]dependencyKey
+}
+
+func (s *grpcServer) Dependencies(ctx context.Context, req *pb.DependenciesRequest) (*pb.DependenciesResponse, error) {
+	log.Debugf("Dependencies request: %+v", req)
+	resource := req.GetResource()
+	if resource == nil || resource.GetName() == "" || resource.GetNamespace() == "" {
+		return dependenciesError(req, "Dependencies request requires a named Resource"), nil
+	}
+	switch resource.GetType() {
+	case k8s.Authority, k8s.Service, k8s.Server, k8s.ServerAuthorization, k8s.HTTPRoute, k8s.Namespace, k8s.All:
+		return dependenciesError(req, fmt.Sprintf("Resource type is not supported: %s", resource.GetType())), nil
+	}
+
+	timeWindow := req.GetTimeWindow()
+	if timeWindow == "" {
+		timeWindow = defaultDependenciesTime
+	}
+	maxDepth := int(req.GetMaxDepth())
+	if maxDepth == 0 {
+		maxDepth = defaultDependenciesDepth
+	}
+
+	graph, err := s.getDependencyGraph(ctx, resource, timeWindow)
+	if err != nil {
+		return dependenciesError(req, err.Error()), nil
+	}
+
+	root := dependencyKey{namespace: resource.GetNamespace(), name: resource.GetName()}
+	return &pb.DependenciesResponse{
+		Response: &pb.DependenciesResponse_Ok_{
+			Ok: &pb.DependenciesResponse_Ok{
+				Upstreams:   graph.walk(root, resource.GetType(), maxDepth, true),
+				Downstreams: graph.walk(root, resource.GetType(), maxDepth, false),
+			},
+		},
+	}, nil
+}
+
+// getDependencyGraph queries the outbound requests between all the resources
+// of the requested type, so that the graph can be walked without further
+// round-trips to Prometheus
+func (s *grpcServer) getDependencyGraph(ctx context.Context, resource *pb.Resource, timeWindow string) (*dependencyGraph, error) {
+	resourceType := promResourceType(resource)
+	dstResourceType := "dst_" + resourceType
+	groupBy := model.LabelNames{namespaceLabel, resourceType, dstNamespaceLabel, dstResourceType}
+	labels := generateLabelStringWithExclusion(promDirectionLabels("outbound"), string(resourceType), string(dstResourceType))
+
+	promQueries := map[promType]string{
+		promRequests: fmt.Sprintf(reqQuery, labels, timeWindow, groupBy.String()),
+	}
+	quantileQueries := generateQuantileQueries(latencyQuantileQuery, labels, timeWindow, groupBy.String())
+	results, err := s.getPrometheusMetrics(ctx, promQueries, quantileQueries)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &dependencyGraph{
+		stats:       make(map[dependencyEdge]*pb.BasicStats),
+		upstreams:   make(map[dependencyKey][]dependencyKey),
+		downstreams: make(map[dependencyKey][]dependencyKey),
+	}
+	for _, result := range results {
+		for _, sample := range result.vec {
+			edge := dependencyEdge{
+				src: dependencyKey{
+					namespace: string(sample.Metric[namespaceLabel]),
+					name:      string(sample.Metric[resourceType]),
+				},
+				dst: dependencyKey{
+					namespace: string(sample.Metric[dstNamespaceLabel]),
+					name:      string(sample.Metric[dstResourceType]),
+				},
+			}
+			stats, ok := graph.stats[edge]
+			if !ok {
+				stats = &pb.BasicStats{}
+				graph.stats[edge] = stats
+			}
+
+			value := extractSampleValue(sample)
+			switch result.prom {
+			case promRequests:
+				switch string(sample.Metric[model.LabelName("classification")]) {
+				case success:
+					stats.SuccessCount += value
+				case failure:
+					stats.FailureCount += value
+				}
+			case promLatencyP50:
+				stats.LatencyMsP50 = value
+			case promLatencyP95:
+				stats.LatencyMsP95 = value
+			case promLatencyP99:
+				stats.LatencyMsP99 = value
+			}
+		}
+	}
+
+	for edge, stats := range graph.stats {
+		// latency series outlive the requests, only keep the edges that
+		// actually saw traffic during the time window
+		if stats.SuccessCount+stats.FailureCount == 0 {
+			delete(graph.stats, edge)
+			continue
+		}
+		graph.upstreams[edge.src] = append(graph.upstreams[edge.src], edge.dst)
+		graph.downstreams[edge.dst] = append(graph.downstreams[edge.dst], edge.src)
+	}
+	for _, neighbours := range []map[dependencyKey][]dependencyKey{graph.upstreams, graph.downstreams} {
+		for key := range neighbours {
+			sort.Slice(neighbours[key], func(i, j int) bool {
+				a, b := neighbours[key][i], neighbours[key][j]
+				return a.namespace < b.namespace || (a.namespace == b.namespace && a.name < b.name)
+			})
+		}
+	}
+
+	return graph, nil
+}
+
+// walk builds the tree of the resources transitively reachable from root,
+// following requests forward for upstreams or backward for downstreams. A
+// resource appearing twice on the same path is reported as a cycle and not
+// walked further.
+func (g *dependencyGraph) walk(root dependencyKey, resourceType string, maxDepth int, upstreams bool) *pb.DependencyNode {
+	onPath := make(map[dependencyKey]bool)
+
+	var visit func(key dependencyKey, depth int) *pb.DependencyNode
+	visit = func(key dependencyKey, depth int) *pb.DependencyNode {
+		node := &pb.DependencyNode{
+			Resource: &pb.Resource{
+				Namespace: key.namespace,
+				Name:      key.name,
+				Type:      resourceType,
+			},
+		}
+
+		neighbours := g.downstreams[key]
+		if upstreams {
+			neighbours = g.upstreams[key]
+		}
+		if len(neighbours) == 0 {
+			return node
+		}
+		if onPath[key] {
+			node.Cycle = true
+			return node
+		}
+		if depth == maxDepth {
+			node.Truncated = true
+			return node
+		}
+
+		onPath[key] = true
+		for _, neighbour := range neighbours {
+			child := visit(neighbour, depth+1)
+			edge := dependencyEdge{src: neighbour, dst: key}
+			if upstreams {
+				edge = dependencyEdge{src: key, dst: neighbour}
+			}
+			child.Stats = g.stats[edge]
+			node.Children = append(node.Children, child)
+		}
+		delete(onPath, key)
+
+		return node
+	}
+
+	return visit(root, 0)
+}
+
+func dependenciesError(req *pb.DependenciesRequest, message string) *pb.DependenciesResponse {
+	return &pb.DependenciesResponse{
+		Response: &pb.DependenciesResponse_Error{
+			Error: &pb.ResourceError{
+				Resource: req.GetResource(),
+				Error:    message,
+			},
+		},
+	}
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/prometheus/common/model"
+)
+
+func genDependencyPromSample(src, dst string) *model.Sample {
+	return &model.Sample{
+		Metric: model.Metric{
+			resourceLabel:                     model.LabelValue(src),
+			namespaceLabel:                    "emojivoto",
+			"dst_" + resourceLabel:            model.LabelValue(dst),
+			dstNamespaceLabel:                 "emojivoto",
+			model.LabelName("classification"): success,
+		},
+		Value:     123,
+		Timestamp: 456,
+	}
+}
+
+func dependencyNode(name string, stats bool, children ...*pb.DependencyNode) *pb.DependencyNode {
+	node := &pb.DependencyNode{
+		Resource: &pb.Resource{
+			Namespace: "emojivoto",
+			Name:      name,
+			Type:      pkgK8s.Deployment,
+		},
+		Children: children,
+	}
+	if stats {
+		node.Stats = &pb.BasicStats{
+			SuccessCount: 123,
+			LatencyMsP50: 123,
+			LatencyMsP95: 123,
+			LatencyMsP99: 123,
+		}
+	}
+	return node
+}
+
+func TestDependencies(t *testing.T) {
+	mockPromResponse := model.Vector{
+		genDependencyPromSample("web", "emoji"),
+		genDependencyPromSample("web", "voting"),
+		genDependencyPromSample("voting", "web"),
+		genDependencyPromSample("vote-bot", "web"),
+	}
+
+	t.Run("Walks upstreams and downstreams, stopping at cycles", func(t *testing.T) {
+		mockProm, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{
+			mockPromResponse: mockPromResponse,
+			expectedPrometheusQueries: []string{
+				`sum(increase(response_total{deployment!="", direction="outbound", dst_deployment!=""}[1m])) by (namespace, deployment, dst_namespace, dst_deployment, classification, tls)`,
+				`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{deployment!="", direction="outbound", dst_deployment!=""}[1m])) by (le, namespace, deployment, dst_namespace, dst_deployment))`,
+				`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{deployment!="", direction="outbound", dst_deployment!=""}[1m])) by (le, namespace, deployment, dst_namespace, dst_deployment))`,
+				`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{deployment!="", direction="outbound", dst_deployment!=""}[1m])) by (le, namespace, deployment, dst_namespace, dst_deployment))`,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.Dependencies(context.TODO(), &pb.DependenciesRequest{
+			Resource: &pb.Resource{
+				Namespace: "emojivoto",
+				Name:      "web",
+				Type:      pkgK8s.Deployment,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		err = expectedStatRPC{expectedPrometheusQueries: nil}.verifyPromQueries(mockProm)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cycle := dependencyNode("web", true)
+		cycle.Cycle = true
+		expectedUpstreams := dependencyNode("web", false,
+			dependencyNode("emoji", true),
+			dependencyNode("voting", true, cycle),
+		)
+		expectedDownstreams := dependencyNode("web", false,
+			dependencyNode("vote-bot", true),
+			dependencyNode("voting", true, cycle),
+		)
+
+		if !proto.Equal(rsp.GetOk().GetUpstreams(), expectedUpstreams) {
+			t.Fatalf("Expected upstreams: %+v\n Got: %+v", expectedUpstreams, rsp.GetOk().GetUpstreams())
+		}
+		if !proto.Equal(rsp.GetOk().GetDownstreams(), expectedDownstreams) {
+			t.Fatalf("Expected downstreams: %+v\n Got: %+v", expectedDownstreams, rsp.GetOk().GetDownstreams())
+		}
+	})
+
+	t.Run("Stops at the maximum depth", func(t *testing.T) {
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{mockPromResponse: mockPromResponse})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.Dependencies(context.TODO(), &pb.DependenciesRequest{
+			Resource: &pb.Resource{
+				Namespace: "emojivoto",
+				Name:      "vote-bot",
+				Type:      pkgK8s.Deployment,
+			},
+			MaxDepth: 1,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		truncated := dependencyNode("web", true)
+		truncated.Truncated = true
+		expectedUpstreams := dependencyNode("vote-bot", false, truncated)
+		if !proto.Equal(rsp.GetOk().GetUpstreams(), expectedUpstreams) {
+			t.Fatalf("Expected upstreams: %+v\n Got: %+v", expectedUpstreams, rsp.GetOk().GetUpstreams())
+		}
+	})
+
+	t.Run("Rejects requests without a named resource", func(t *testing.T) {
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.Dependencies(context.TODO(), &pb.DependenciesRequest{
+			Resource: &pb.Resource{
+				Namespace: "emojivoto",
+				Type:      pkgK8s.Deployment,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if rsp.GetError() == nil {
+			t.Fatal("Expected an error response")
+		}
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
@@ -14,8 +16,28 @@ import (
 
 const (
 	edgesQuery = "sum(%s%s) by (%s, dst_%s, pod, server_id, namespace, dst_namespace, no_tls_reason)"
+
+	// allEdgesQuery groups the connections by all the owners of the pods,
+	// which are then attributed to their top-level owner
+	allEdgesQuery = "sum(%s%s) by (%s, server_id, namespace, dst_namespace, no_tls_reason)"
+
+	// namespaceWildcard selects all the namespaces, like an empty namespace
+	namespaceWildcard = "*"
 )
 
+// edgeOwnerTypes are the workload types the edges of the "all" resource type
+// are attributed to, from the top-level owners to the pods
+var edgeOwnerTypes = []string{
+	k8s.CronJob,
+	k8s.Deployment,
+	k8s.StatefulSet,
+	k8s.DaemonSet,
+	k8s.Job,
+	k8s.ReplicationController,
+	k8s.ReplicaSet,
+	k8s.Pod,
+}
+
 var formatMsg = map[string]string{
 	"disabled":                          "Disabled",
 	"loopback":                          "Loopback",
@@ -26,10 +48,12 @@ var formatMsg = map[string]string{
 }
 
 type edgeKey struct {
-	src   string
-	srcNs string
-	dst   string
-	dstNs string
+	srcType string
+	src     string
+	srcNs   string
+	dstType string
+	dst     string
+	dstNs   string
 }
 
 func (s *grpcServer) Edges(ctx context.Context, req *pb.EdgesRequest) (*pb.EdgesResponse, error) {
@@ -38,17 +62,34 @@ func (s *grpcServer) Edges(ctx context.Context, req *pb.EdgesRequest) (*pb.Edges
 		return edgesError(req, "Edges request missing Selector Resource"), nil
 	}
 
+	allTypes := req.GetSelector().GetResource().GetType() == k8s.All
 	resourceType := promResourceType(req.GetSelector().GetResource())
 	dstResourceType := "dst_" + resourceType
 	labelsOutbound := promDirectionLabels("outbound")
-	labelsOutboundStr := generateLabelStringWithExclusion(labelsOutbound, string(resourceType), string(dstResourceType))
-	query := fmt.Sprintf(edgesQuery, "tcp_open_connections", labelsOutboundStr, resourceType, resourceType)
+	var query string
+	if allTypes {
+		owners := []string{}
+		for _, typ := range edgeOwnerTypes {
+			owners = append(owners, typ, "dst_"+typ)
+		}
+		query = fmt.Sprintf(allEdgesQuery, "tcp_open_connections", generateLabelStringWithExclusion(labelsOutbound), strings.Join(owners, ", "))
+	} else {
+		labelsOutboundStr := generateLabelStringWithExclusion(labelsOutbound, string(resourceType), string(dstResourceType))
+		query = fmt.Sprintf(edgesQuery, "tcp_open_connections", labelsOutboundStr, resourceType, resourceType)
+	}
 
 	promResult, err := s.queryProm(ctx, query)
 	if err != nil {
 		return edgesError(req, err.Error()), nil
 	}
 
+	requestedNs := req.GetSelector().GetResource().GetNamespace()
+	if requestedNs == namespaceWildcard {
+		requestedNs = v1.NamespaceAll
+	}
+
+	// the edges are de-duplicated, as the connections of the pods of a
+	// resource are reported separately
 	edgeMap := make(map[edgeKey]*pb.Edge)
 
 	for _, sample := range promResult {
@@ -56,12 +97,21 @@ func (s *grpcServer) Edges(ctx context.Context, req *pb.EdgesRequest) (*pb.Edges
 			continue
 		}
 		key := edgeKey{
-			src:   string(sample.Metric[resourceType]),
 			srcNs: string(sample.Metric[model.LabelName("namespace")]),
-			dst:   string(sample.Metric[dstResourceType]),
 			dstNs: string(sample.Metric[model.LabelName("dst_namespace")]),
 		}
-		requestedNs := req.GetSelector().GetResource().GetNamespace()
+		if allTypes {
+			key.srcType, key.src = edgeOwner(sample.Metric, "")
+			key.dstType, key.dst = edgeOwner(sample.Metric, "dst_")
+			if key.src == "" || key.dst == "" {
+				continue
+			}
+		} else {
+			key.srcType = string(resourceType)
+			key.src = string(sample.Metric[resourceType])
+			key.dstType = string(resourceType)
+			key.dst = string(sample.Metric[dstResourceType])
+		}
 		if requestedNs != v1.NamespaceAll {
 			if requestedNs != key.srcNs && requestedNs != key.dstNs {
 				continue
@@ -79,12 +129,12 @@ func (s *grpcServer) Edges(ctx context.Context, req *pb.EdgesRequest) (*pb.Edges
 				Src: &pb.Resource{
 					Namespace: key.srcNs,
 					Name:      key.src,
-					Type:      string(resourceType),
+					Type:      key.srcType,
 				},
 				Dst: &pb.Resource{
 					Namespace: key.dstNs,
 					Name:      key.dst,
-					Type:      string(resourceType),
+					Type:      key.dstType,
 				},
 				ServerId:      string(sample.Metric[model.LabelName("server_id")]),
 				ClientId:      clientID,
@@ -99,15 +149,56 @@ func (s *grpcServer) Edges(ctx context.Context, req *pb.EdgesRequest) (*pb.Edges
 	}
 	edges = sortEdgeRows(edges)
 
+	edges, nextPageToken, err := paginateEdges(edges, req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return edgesError(req, err.Error()), nil
+	}
+
 	return &pb.EdgesResponse{
 		Response: &pb.EdgesResponse_Ok_{
 			Ok: &pb.EdgesResponse_Ok{
-				Edges: edges,
+				Edges:         edges,
+				NextPageToken: nextPageToken,
 			},
 		},
 	}, nil
 }
 
+// edgeOwner returns the type and name of the top-level owner of the source
+// or, with the "dst_" prefix, destination pod of a sample
+func edgeOwner(metric model.Metric, prefix string) (string, string) {
+	for _, typ := range edgeOwnerTypes {
+		if name := metric[model.LabelName(prefix+typ)]; name != "" {
+			return typ, string(name)
+		}
+	}
+	return "", ""
+}
+
+// paginateEdges returns the page of sorted edges starting at the offset
+// encoded in the page token, and the token of the next page, if any. As the
+// edges are recomputed for each page, edges that appear or disappear between
+// the requests can shift the pages.
+func paginateEdges(edges []*pb.Edge, pageSize uint32, pageToken string) ([]*pb.Edge, string, error) {
+	offset := 0
+	if pageToken != "" {
+		var err error
+		offset, err = strconv.Atoi(pageToken)
+		if err != nil || offset < 0 {
+			return nil, "", fmt.Errorf("invalid page token: %s", pageToken)
+		}
+	}
+	if offset > len(edges) {
+		offset = len(edges)
+	}
+	edges = edges[offset:]
+
+	if pageSize == 0 || int(pageSize) >= len(edges) {
+		return edges, "", nil
+	}
+	return edges[:pageSize], strconv.Itoa(offset + int(pageSize)), nil
+}
+
 func edgesError(req *pb.EdgesRequest, message string) *pb.EdgesResponse {
 	return &pb.EdgesResponse{
 		Response: &pb.EdgesResponse_Error{
@@ -129,8 +220,8 @@ func (s *grpcServer) getPodIdentity(pod string, namespace string) (string, error
 
 func sortEdgeRows(rows []*pb.Edge) []*pb.Edge {
 	sort.Slice(rows, func(i, j int) bool {
-		keyI := rows[i].GetSrc().GetNamespace() + rows[i].GetDst().GetNamespace() + rows[i].GetSrc().GetName() + rows[i].GetDst().GetName()
-		keyJ := rows[j].GetSrc().GetNamespace() + rows[j].GetDst().GetNamespace() + rows[j].GetSrc().GetName() + rows[j].GetDst().GetName()
+		keyI := rows[i].GetSrc().GetNamespace() + rows[i].GetDst().GetNamespace() + rows[i].GetSrc().GetName() + rows[i].GetDst().GetName() + rows[i].GetSrc().GetType() + rows[i].GetDst().GetType()
+		keyJ := rows[j].GetSrc().GetNamespace() + rows[j].GetDst().GetNamespace() + rows[j].GetSrc().GetName() + rows[j].GetDst().GetName() + rows[j].GetSrc().GetType() + rows[j].GetDst().GetType()
 		return keyI < keyJ
 	})
 	return rows
@@ -14,7 +14,6 @@ import (
 const (
 	serverIDLabel = model.LabelName("server_id")
 	resourceLabel = model.LabelName("deployment")
-	podLabel      = model.LabelName("pod")
 )
 
 type edgesExpected struct {
@@ -177,4 +176,129 @@ func TestEdges(t *testing.T) {
 
 		testEdges(t, expectations)
 	})
+
+	t.Run("Successfully returns de-duplicated edges for all resource types and all namespaces", func(t *testing.T) {
+		sample := func(src, dst model.Metric) *model.Sample {
+			metric := model.Metric{
+				namespaceLabel:    "emojivoto",
+				dstNamespaceLabel: "emojivoto",
+				serverIDLabel:     "emoji.emojivoto.serviceaccount.identity.linkerd.cluster.local",
+			}
+			for k, v := range src {
+				metric[k] = v
+			}
+			for k, v := range dst {
+				metric["dst_"+k] = v
+			}
+			return &model.Sample{Metric: metric, Value: 1}
+		}
+		web := func(pod string) model.Metric {
+			return model.Metric{podLabel: model.LabelValue(pod), "deployment": "web", "replicaset": "web-5f86686c4d"}
+		}
+		emoji := model.Metric{"statefulset": "emoji", "pod": "emoji-0"}
+
+		expectations := []edgesExpected{
+			{
+				expectedStatRPC: expectedStatRPC{
+					err: nil,
+					mockPromResponse: model.Vector{
+						sample(web("web-0"), emoji),
+						sample(web("web-1"), emoji),
+						// the pods of the connections without owners are skipped
+						sample(model.Metric{}, emoji),
+					},
+					k8sConfigs: []string{
+						genPod("web-0", "emojivoto", "web"),
+						genPod("web-1", "emojivoto", "web"),
+					},
+					expectedPrometheusQueries: []string{
+						`sum(tcp_open_connections{direction="outbound"}) by (cronjob, dst_cronjob, deployment, dst_deployment, statefulset, dst_statefulset, daemonset, dst_daemonset, job, dst_job, replicationcontroller, dst_replicationcontroller, replicaset, dst_replicaset, pod, dst_pod, server_id, namespace, dst_namespace, no_tls_reason)`,
+					},
+				},
+				req: &pb.EdgesRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "*",
+							Type:      pkgK8s.All,
+						},
+					},
+				},
+				expectedResponse: &pb.EdgesResponse{
+					Response: &pb.EdgesResponse_Ok_{
+						Ok: &pb.EdgesResponse_Ok{
+							Edges: []*pb.Edge{
+								{
+									Src:      &pb.Resource{Namespace: "emojivoto", Name: "web", Type: pkgK8s.Deployment},
+									Dst:      &pb.Resource{Namespace: "emojivoto", Name: "emoji", Type: pkgK8s.StatefulSet},
+									ClientId: "web.emojivoto.serviceaccount.identity.linkerd.cluster.local",
+									ServerId: "emoji.emojivoto.serviceaccount.identity.linkerd.cluster.local",
+								},
+							},
+						},
+					},
+				},
+			}}
+
+		testEdges(t, expectations)
+	})
+
+	t.Run("Successfully paginates edges", func(t *testing.T) {
+		edges := GenEdgesResponse("deployment", "all").GetOk().GetEdges()
+		pages := []struct {
+			token         string
+			edges         []*pb.Edge
+			nextPageToken string
+		}{
+			{"", edges[:3], "3"},
+			{"3", edges[3:], ""},
+		}
+
+		for _, page := range pages {
+			page := page // pin
+			expectations := []edgesExpected{
+				{
+					expectedStatRPC: expectedStatRPC{
+						err:              nil,
+						mockPromResponse: mockPromResponse,
+						k8sConfigs:       pods,
+					},
+					req: &pb.EdgesRequest{
+						Selector: &pb.ResourceSelection{
+							Resource: &pb.Resource{
+								Type: pkgK8s.Deployment,
+							},
+						},
+						PageSize:  3,
+						PageToken: page.token,
+					},
+					expectedResponse: &pb.EdgesResponse{
+						Response: &pb.EdgesResponse_Ok_{
+							Ok: &pb.EdgesResponse_Ok{
+								Edges:         page.edges,
+								NextPageToken: page.nextPageToken,
+							},
+						},
+					},
+				}}
+
+			testEdges(t, expectations)
+		}
+	})
+
+	t.Run("Returns an error for an invalid page token", func(t *testing.T) {
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{mockPromResponse: mockPromResponse, k8sConfigs: pods})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+		rsp, err := fakeGrpcServer.Edges(context.TODO(), &pb.EdgesRequest{
+			Selector:  &pb.ResourceSelection{Resource: &pb.Resource{Type: pkgK8s.Deployment}},
+			PageToken: "invalid",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if rsp.GetError().GetError() != "invalid page token: invalid" {
+			t.Fatalf("Expected an invalid page token error, got %+v", rsp)
+		}
+	})
 }
@@ -0,0 +1,198 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/prometheus/common/model"
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultEgressTime = "1m"
+
+// egressKey identifies the traffic sent by a workload to an external
+// destination
+type egressKey struct {
+	namespace string
+	name      string
+	authority string
+}
+
+func (s *grpcServer) Egress(ctx context.Context, req *pb.EgressRequest) (*pb.EgressResponse, error) {
+	log.Debugf("Egress request: %+v", req)
+	resource := req.GetResource()
+	switch resource.GetType() {
+	case "":
+		return egressError("Egress request requires a Resource type"), nil
+	case k8s.Authority, k8s.Service, k8s.Server, k8s.ServerAuthorization, k8s.HTTPRoute, k8s.All:
+		return egressError("Resource type is not supported: " + resource.GetType()), nil
+	}
+
+	timeWindow := req.GetTimeWindow()
+	if timeWindow == "" {
+		timeWindow = defaultEgressTime
+	}
+
+	stats, err := s.getEgressMetrics(ctx, resource, timeWindow)
+	if err != nil {
+		return egressError(err.Error()), nil
+	}
+
+	rows := make([]*pb.EgressRow, 0, len(stats))
+	for key, row := range stats {
+		row.Resource = &pb.Resource{
+			Namespace: key.namespace,
+			Type:      resource.GetType(),
+			Name:      key.name,
+		}
+		row.Authority = key.authority
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return egressRowKey(rows[i]) < egressRowKey(rows[j])
+	})
+
+	return &pb.EgressResponse{
+		Response: &pb.EgressResponse_Ok_{
+			Ok: &pb.EgressResponse_Ok{
+				Rows: rows,
+			},
+		},
+	}, nil
+}
+
+// getEgressMetrics queries the outbound traffic of the selected workloads
+// whose destination couldn't be resolved to a resource of the cluster, i.e.
+// that has no dst_namespace label, and groups it by authority. The authorities
+// under the cluster domain, such as the ones of services without endpoints,
+// are dropped.
+func (s *grpcServer) getEgressMetrics(ctx context.Context, resource *pb.Resource, timeWindow string) (map[egressKey]*pb.EgressRow, error) {
+	resourceLabel := promResourceType(resource)
+	groupBy := model.LabelNames{namespaceLabel, resourceLabel, authorityLabel}
+	if resourceLabel == namespaceLabel {
+		groupBy = model.LabelNames{namespaceLabel, authorityLabel}
+	}
+
+	labels := promDirectionLabels("outbound")
+	labels[dstNamespaceLabel] = ""
+	exclusions := []string{string(authorityLabel)}
+	if resource.GetNamespace() != "" {
+		labels[namespaceLabel] = model.LabelValue(resource.GetNamespace())
+	}
+	if resource.GetName() != "" {
+		labels[resourceLabel] = model.LabelValue(resource.GetName())
+	} else if resourceLabel != namespaceLabel {
+		exclusions = append(exclusions, string(resourceLabel))
+	}
+	labelString := generateLabelStringWithExclusion(labels, exclusions...)
+	tcpLabelString := generateLabelStringWithExclusion(labels.Merge(promPeerLabel("dst")), exclusions...)
+
+	promQueries := map[promType]string{
+		promRequests:       fmt.Sprintf(reqQuery, labelString, timeWindow, groupBy.String()),
+		promTCPConnections: fmt.Sprintf(tcpConnectionsQuery, tcpLabelString, groupBy.String()),
+		promTCPReadBytes:   fmt.Sprintf(tcpReadBytesQuery, tcpLabelString, timeWindow, groupBy.String()),
+		promTCPWriteBytes:  fmt.Sprintf(tcpWriteBytesQuery, tcpLabelString, timeWindow, groupBy.String()),
+	}
+	quantileQueries := generateQuantileQueries(latencyQuantileQuery, labelString, timeWindow, groupBy.String())
+	results, err := s.getPrometheusMetrics(ctx, promQueries, quantileQueries)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make(map[egressKey]*pb.EgressRow)
+	for _, result := range results {
+		for _, sample := range result.vec {
+			authority := string(sample.Metric[authorityLabel])
+			if !s.isExternalAuthority(authority) {
+				continue
+			}
+			key := egressKey{
+				namespace: string(sample.Metric[namespaceLabel]),
+				name:      string(sample.Metric[resourceLabel]),
+				authority: authority,
+			}
+			if _, ok := rows[key]; !ok {
+				rows[key] = &pb.EgressRow{
+					Stats:    &pb.BasicStats{},
+					TcpStats: &pb.TcpStats{},
+				}
+			}
+			row := rows[key]
+
+			value := extractSampleValue(sample)
+			switch result.prom {
+			case promRequests:
+				switch string(sample.Metric[model.LabelName("classification")]) {
+				case success:
+					row.Stats.SuccessCount += value
+				case failure:
+					row.Stats.FailureCount += value
+				}
+				if sample.Metric[model.LabelName("tls")] == "true" {
+					row.TlsRequestCount += value
+				}
+			case promLatencyP50:
+				row.Stats.LatencyMsP50 = value
+			case promLatencyP95:
+				row.Stats.LatencyMsP95 = value
+			case promLatencyP99:
+				row.Stats.LatencyMsP99 = value
+			case promTCPConnections:
+				row.TcpStats.OpenConnections = value
+			case promTCPReadBytes:
+				row.TcpStats.ReadBytesTotal = value
+			case promTCPWriteBytes:
+				row.TcpStats.WriteBytesTotal = value
+			}
+		}
+	}
+
+	// the destinations that were neither sent requests nor connected to
+	// during the time window are stale
+	for key, row := range rows {
+		stats, tcpStats := row.GetStats(), row.GetTcpStats()
+		if stats.GetSuccessCount()+stats.GetFailureCount() == 0 &&
+			tcpStats.GetOpenConnections()+tcpStats.GetReadBytesTotal()+tcpStats.GetWriteBytesTotal() == 0 {
+			delete(rows, key)
+		}
+	}
+	return rows, nil
+}
+
+// isExternalAuthority returns whether the authority names a destination
+// outside of the cluster, i.e. an IP or a host name outside of the cluster
+// domain
+func (s *grpcServer) isExternalAuthority(authority string) bool {
+	if authority == "" {
+		return false
+	}
+	host := authority
+	if h, _, err := net.SplitHostPort(authority); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(host, ".")
+	return host != s.clusterDomain && !strings.HasSuffix(host, "."+s.clusterDomain)
+}
+
+func egressRowKey(row *pb.EgressRow) string {
+	return strings.Join([]string{
+		row.GetResource().GetNamespace(),
+		row.GetResource().GetName(),
+		row.GetAuthority(),
+	}, "/")
+}
+
+func egressError(message string) *pb.EgressResponse {
+	return &pb.EgressResponse{
+		Response: &pb.EgressResponse_Error{
+			Error: &pb.ResourceError{
+				Error: message,
+			},
+		},
+	}
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/prometheus/common/model"
+)
+
+func genEgressPromSample(name, authority string) *model.Sample {
+	return &model.Sample{
+		Metric: model.Metric{
+			model.LabelName("deployment"):     model.LabelValue(name),
+			namespaceLabel:                    "emojivoto",
+			authorityLabel:                    model.LabelValue(authority),
+			model.LabelName("classification"): success,
+			model.LabelName("tls"):            "true",
+		},
+		Value:     123,
+		Timestamp: 456,
+	}
+}
+
+func egressRow(name, authority string) *pb.EgressRow {
+	return &pb.EgressRow{
+		Resource: &pb.Resource{
+			Namespace: "emojivoto",
+			Type:      pkgK8s.Deployment,
+			Name:      name,
+		},
+		Authority: authority,
+		Stats: &pb.BasicStats{
+			SuccessCount: 123,
+			LatencyMsP50: 123,
+			LatencyMsP95: 123,
+			LatencyMsP99: 123,
+		},
+		TcpStats: &pb.TcpStats{
+			OpenConnections: 123,
+			ReadBytesTotal:  123,
+			WriteBytesTotal: 123,
+		},
+		TlsRequestCount: 123,
+	}
+}
+
+func TestEgress(t *testing.T) {
+	mockPromResponse := model.Vector{
+		genEgressPromSample("web", "api.example.com:443"),
+		genEgressPromSample("web", "10.0.0.1:5432"),
+		genEgressPromSample("voting", "api.example.com:443"),
+		// a service without endpoints isn't an external destination
+		genEgressPromSample("web", "emoji-svc.emojivoto.svc.cluster.local:8080"),
+	}
+
+	t.Run("Reports the traffic to external destinations by workload", func(t *testing.T) {
+		mockProm, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{
+			mockPromResponse: mockPromResponse,
+			expectedPrometheusQueries: []string{
+				`sum(increase(response_total{authority!="", deployment!="", direction="outbound", dst_namespace="", namespace="emojivoto"}[1m])) by (namespace, deployment, authority, classification, tls)`,
+				`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{authority!="", deployment!="", direction="outbound", dst_namespace="", namespace="emojivoto"}[1m])) by (le, namespace, deployment, authority))`,
+				`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{authority!="", deployment!="", direction="outbound", dst_namespace="", namespace="emojivoto"}[1m])) by (le, namespace, deployment, authority))`,
+				`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{authority!="", deployment!="", direction="outbound", dst_namespace="", namespace="emojivoto"}[1m])) by (le, namespace, deployment, authority))`,
+				`sum(tcp_open_connections{authority!="", deployment!="", direction="outbound", dst_namespace="", namespace="emojivoto", peer="dst"}) by (namespace, deployment, authority)`,
+				`sum(increase(tcp_read_bytes_total{authority!="", deployment!="", direction="outbound", dst_namespace="", namespace="emojivoto", peer="dst"}[1m])) by (namespace, deployment, authority)`,
+				`sum(increase(tcp_write_bytes_total{authority!="", deployment!="", direction="outbound", dst_namespace="", namespace="emojivoto", peer="dst"}[1m])) by (namespace, deployment, authority)`,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.Egress(context.TODO(), &pb.EgressRequest{
+			Resource: &pb.Resource{
+				Namespace: "emojivoto",
+				Type:      pkgK8s.Deployment,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		err = expectedStatRPC{expectedPrometheusQueries: nil}.verifyPromQueries(mockProm)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedRows := []*pb.EgressRow{
+			egressRow("voting", "api.example.com:443"),
+			egressRow("web", "10.0.0.1:5432"),
+			egressRow("web", "api.example.com:443"),
+		}
+		rows := rsp.GetOk().GetRows()
+		if len(rows) != len(expectedRows) {
+			t.Fatalf("Expected %d rows, got %d: %+v", len(expectedRows), len(rows), rows)
+		}
+		for i, row := range rows {
+			if !proto.Equal(row, expectedRows[i]) {
+				t.Fatalf("Expected row %d: %+v\n Got: %+v", i, expectedRows[i], row)
+			}
+		}
+	})
+
+	t.Run("Rejects unsupported resource types", func(t *testing.T) {
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.Egress(context.TODO(), &pb.EgressRequest{
+			Resource: &pb.Resource{Type: pkgK8s.Service},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expectedError := "Resource type is not supported: service"
+		if rsp.GetError().GetError() != expectedError {
+			t.Fatalf("Expected error [%s], got [%s]", expectedError, rsp.GetError().GetError())
+		}
+	})
+}
@@ -134,7 +134,7 @@ func (x HttpMethod_Registered) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use HttpMethod_Registered.Descriptor instead.
 func (HttpMethod_Registered) EnumDescriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{10, 0}
+	return file_viz_proto_rawDescGZIP(), []int{12, 0}
 }
 
 type Scheme_Registered int32
@@ -180,7 +180,56 @@ func (x Scheme_Registered) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Scheme_Registered.Descriptor instead.
 func (Scheme_Registered) EnumDescriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{11, 0}
+	return file_viz_proto_rawDescGZIP(), []int{13, 0}
+}
+
+type StatSummaryRequest_MeshFilter int32
+
+const (
+	StatSummaryRequest_ALL      StatSummaryRequest_MeshFilter = 0
+	StatSummaryRequest_MESHED   StatSummaryRequest_MeshFilter = 1
+	StatSummaryRequest_UNMESHED StatSummaryRequest_MeshFilter = 2
+)
+
+// Enum value maps for StatSummaryRequest_MeshFilter.
+var (
+	StatSummaryRequest_MeshFilter_name = map[int32]string{
+		0: "ALL",
+		1: "MESHED",
+		2: "UNMESHED",
+	}
+	StatSummaryRequest_MeshFilter_value = map[string]int32{
+		"ALL":      0,
+		"MESHED":   1,
+		"UNMESHED": 2,
+	}
+)
+
+func (x StatSummaryRequest_MeshFilter) Enum() *StatSummaryRequest_MeshFilter {
+	p := new(StatSummaryRequest_MeshFilter)
+	*p = x
+	return p
+}
+
+func (x StatSummaryRequest_MeshFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatSummaryRequest_MeshFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_viz_proto_enumTypes[3].Descriptor()
+}
+
+func (StatSummaryRequest_MeshFilter) Type() protoreflect.EnumType {
+	return &file_viz_proto_enumTypes[3]
+}
+
+func (x StatSummaryRequest_MeshFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatSummaryRequest_MeshFilter.Descriptor instead.
+func (StatSummaryRequest_MeshFilter) EnumDescriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{21, 0}
 }
 
 type Empty struct {
@@ -377,6 +426,101 @@ func (x *SelfCheckResponse) GetResults() []*CheckResult {
 	return nil
 }
 
+type LabelCompatibilityRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *LabelCompatibilityRequest) Reset() {
+	*x = LabelCompatibilityRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LabelCompatibilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelCompatibilityRequest) ProtoMessage() {}
+
+func (x *LabelCompatibilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelCompatibilityRequest.ProtoReflect.Descriptor instead.
+func (*LabelCompatibilityRequest) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{4}
+}
+
+type LabelCompatibilityResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// one report per proxy version found in the mesh
+	Reports []*LabelCompatibilityResponse_ProxyVersionReport `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+	// time since the report was computed in the background
+	SinceLastCheck *duration.Duration `protobuf:"bytes,2,opt,name=since_last_check,json=sinceLastCheck,proto3" json:"since_last_check,omitempty"`
+}
+
+func (x *LabelCompatibilityResponse) Reset() {
+	*x = LabelCompatibilityResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LabelCompatibilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelCompatibilityResponse) ProtoMessage() {}
+
+func (x *LabelCompatibilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelCompatibilityResponse.ProtoReflect.Descriptor instead.
+func (*LabelCompatibilityResponse) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LabelCompatibilityResponse) GetReports() []*LabelCompatibilityResponse_ProxyVersionReport {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+func (x *LabelCompatibilityResponse) GetSinceLastCheck() *duration.Duration {
+	if x != nil {
+		return x.SinceLastCheck
+	}
+	return nil
+}
+
 type ListServicesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -388,7 +532,7 @@ type ListServicesRequest struct {
 func (x *ListServicesRequest) Reset() {
 	*x = ListServicesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[4]
+		mi := &file_viz_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -401,7 +545,7 @@ func (x *ListServicesRequest) String() string {
 func (*ListServicesRequest) ProtoMessage() {}
 
 func (x *ListServicesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[4]
+	mi := &file_viz_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -414,7 +558,7 @@ func (x *ListServicesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListServicesRequest.ProtoReflect.Descriptor instead.
 func (*ListServicesRequest) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{4}
+	return file_viz_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListServicesRequest) GetNamespace() string {
@@ -435,7 +579,7 @@ type ListServicesResponse struct {
 func (x *ListServicesResponse) Reset() {
 	*x = ListServicesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[5]
+		mi := &file_viz_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -448,7 +592,7 @@ func (x *ListServicesResponse) String() string {
 func (*ListServicesResponse) ProtoMessage() {}
 
 func (x *ListServicesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[5]
+	mi := &file_viz_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -461,7 +605,7 @@ func (x *ListServicesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListServicesResponse.ProtoReflect.Descriptor instead.
 func (*ListServicesResponse) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{5}
+	return file_viz_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ListServicesResponse) GetServices() []*Service {
@@ -483,7 +627,7 @@ type Service struct {
 func (x *Service) Reset() {
 	*x = Service{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[6]
+		mi := &file_viz_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -496,7 +640,7 @@ func (x *Service) String() string {
 func (*Service) ProtoMessage() {}
 
 func (x *Service) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[6]
+	mi := &file_viz_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -509,7 +653,7 @@ func (x *Service) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Service.ProtoReflect.Descriptor instead.
 func (*Service) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{6}
+	return file_viz_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Service) GetName() string {
@@ -539,7 +683,7 @@ type ListPodsRequest struct {
 func (x *ListPodsRequest) Reset() {
 	*x = ListPodsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[7]
+		mi := &file_viz_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -552,7 +696,7 @@ func (x *ListPodsRequest) String() string {
 func (*ListPodsRequest) ProtoMessage() {}
 
 func (x *ListPodsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[7]
+	mi := &file_viz_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -565,7 +709,7 @@ func (x *ListPodsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPodsRequest.ProtoReflect.Descriptor instead.
 func (*ListPodsRequest) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{7}
+	return file_viz_proto_rawDescGZIP(), []int{9}
 }
 
 // Deprecated: Do not use.
@@ -594,7 +738,7 @@ type ListPodsResponse struct {
 func (x *ListPodsResponse) Reset() {
 	*x = ListPodsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[8]
+		mi := &file_viz_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -607,7 +751,7 @@ func (x *ListPodsResponse) String() string {
 func (*ListPodsResponse) ProtoMessage() {}
 
 func (x *ListPodsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[8]
+	mi := &file_viz_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -620,7 +764,7 @@ func (x *ListPodsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPodsResponse.ProtoReflect.Descriptor instead.
 func (*ListPodsResponse) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{8}
+	return file_viz_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ListPodsResponse) GetPods() []*Pod {
@@ -659,7 +803,7 @@ type Pod struct {
 func (x *Pod) Reset() {
 	*x = Pod{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[9]
+		mi := &file_viz_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -672,7 +816,7 @@ func (x *Pod) String() string {
 func (*Pod) ProtoMessage() {}
 
 func (x *Pod) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[9]
+	mi := &file_viz_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -685,7 +829,7 @@ func (x *Pod) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Pod.ProtoReflect.Descriptor instead.
 func (*Pod) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{9}
+	return file_viz_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *Pod) GetName() string {
@@ -868,7 +1012,7 @@ type HttpMethod struct {
 func (x *HttpMethod) Reset() {
 	*x = HttpMethod{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[10]
+		mi := &file_viz_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -881,7 +1025,7 @@ func (x *HttpMethod) String() string {
 func (*HttpMethod) ProtoMessage() {}
 
 func (x *HttpMethod) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[10]
+	mi := &file_viz_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -894,7 +1038,7 @@ func (x *HttpMethod) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HttpMethod.ProtoReflect.Descriptor instead.
 func (*HttpMethod) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{10}
+	return file_viz_proto_rawDescGZIP(), []int{12}
 }
 
 func (m *HttpMethod) GetType() isHttpMethod_Type {
@@ -948,7 +1092,7 @@ type Scheme struct {
 func (x *Scheme) Reset() {
 	*x = Scheme{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[11]
+		mi := &file_viz_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -961,7 +1105,7 @@ func (x *Scheme) String() string {
 func (*Scheme) ProtoMessage() {}
 
 func (x *Scheme) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[11]
+	mi := &file_viz_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -974,7 +1118,7 @@ func (x *Scheme) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Scheme.ProtoReflect.Descriptor instead.
 func (*Scheme) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{11}
+	return file_viz_proto_rawDescGZIP(), []int{13}
 }
 
 func (m *Scheme) GetType() isScheme_Type {
@@ -1025,7 +1169,7 @@ type Headers struct {
 func (x *Headers) Reset() {
 	*x = Headers{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[12]
+		mi := &file_viz_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1038,7 +1182,7 @@ func (x *Headers) String() string {
 func (*Headers) ProtoMessage() {}
 
 func (x *Headers) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[12]
+	mi := &file_viz_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1051,7 +1195,7 @@ func (x *Headers) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Headers.ProtoReflect.Descriptor instead.
 func (*Headers) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{12}
+	return file_viz_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Headers) GetHeaders() []*Headers_Header {
@@ -1075,7 +1219,7 @@ type Eos struct {
 func (x *Eos) Reset() {
 	*x = Eos{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[13]
+		mi := &file_viz_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1088,7 +1232,7 @@ func (x *Eos) String() string {
 func (*Eos) ProtoMessage() {}
 
 func (x *Eos) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[13]
+	mi := &file_viz_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1101,7 +1245,7 @@ func (x *Eos) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Eos.ProtoReflect.Descriptor instead.
 func (*Eos) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{13}
+	return file_viz_proto_rawDescGZIP(), []int{15}
 }
 
 func (m *Eos) GetEnd() isEos_End {
@@ -1152,7 +1296,7 @@ type ApiError struct {
 func (x *ApiError) Reset() {
 	*x = ApiError{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[14]
+		mi := &file_viz_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1165,7 +1309,7 @@ func (x *ApiError) String() string {
 func (*ApiError) ProtoMessage() {}
 
 func (x *ApiError) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[14]
+	mi := &file_viz_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1178,7 +1322,7 @@ func (x *ApiError) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApiError.ProtoReflect.Descriptor instead.
 func (*ApiError) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{14}
+	return file_viz_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ApiError) GetError() string {
@@ -1199,7 +1343,7 @@ type PodErrors struct {
 func (x *PodErrors) Reset() {
 	*x = PodErrors{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[15]
+		mi := &file_viz_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1212,7 +1356,7 @@ func (x *PodErrors) String() string {
 func (*PodErrors) ProtoMessage() {}
 
 func (x *PodErrors) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[15]
+	mi := &file_viz_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1225,7 +1369,7 @@ func (x *PodErrors) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PodErrors.ProtoReflect.Descriptor instead.
 func (*PodErrors) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{15}
+	return file_viz_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *PodErrors) GetErrors() []*PodErrors_PodError {
@@ -1258,7 +1402,7 @@ type Resource struct {
 func (x *Resource) Reset() {
 	*x = Resource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[16]
+		mi := &file_viz_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1271,7 +1415,7 @@ func (x *Resource) String() string {
 func (*Resource) ProtoMessage() {}
 
 func (x *Resource) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[16]
+	mi := &file_viz_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1284,7 +1428,7 @@ func (x *Resource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Resource.ProtoReflect.Descriptor instead.
 func (*Resource) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{16}
+	return file_viz_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *Resource) GetNamespace() string {
@@ -1326,7 +1470,7 @@ type ResourceSelection struct {
 func (x *ResourceSelection) Reset() {
 	*x = ResourceSelection{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[17]
+		mi := &file_viz_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1339,7 +1483,7 @@ func (x *ResourceSelection) String() string {
 func (*ResourceSelection) ProtoMessage() {}
 
 func (x *ResourceSelection) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[17]
+	mi := &file_viz_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1352,7 +1496,7 @@ func (x *ResourceSelection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceSelection.ProtoReflect.Descriptor instead.
 func (*ResourceSelection) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{17}
+	return file_viz_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *ResourceSelection) GetResource() *Resource {
@@ -1381,7 +1525,7 @@ type ResourceError struct {
 func (x *ResourceError) Reset() {
 	*x = ResourceError{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[18]
+		mi := &file_viz_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1394,7 +1538,7 @@ func (x *ResourceError) String() string {
 func (*ResourceError) ProtoMessage() {}
 
 func (x *ResourceError) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[18]
+	mi := &file_viz_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1407,7 +1551,7 @@ func (x *ResourceError) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceError.ProtoReflect.Descriptor instead.
 func (*ResourceError) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{18}
+	return file_viz_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *ResourceError) GetResource() *Resource {
@@ -1435,15 +1579,40 @@ type StatSummaryRequest struct {
 	//	*StatSummaryRequest_None
 	//	*StatSummaryRequest_ToResource
 	//	*StatSummaryRequest_FromResource
-	Outbound  isStatSummaryRequest_Outbound `protobuf_oneof:"outbound"`
-	SkipStats bool                          `protobuf:"varint,6,opt,name=skip_stats,json=skipStats,proto3" json:"skip_stats,omitempty"` // true if we want to skip stats from Prometheus
-	TcpStats  bool                          `protobuf:"varint,7,opt,name=tcp_stats,json=tcpStats,proto3" json:"tcp_stats,omitempty"`
+	Outbound       isStatSummaryRequest_Outbound `protobuf_oneof:"outbound"`
+	SkipStats      bool                          `protobuf:"varint,6,opt,name=skip_stats,json=skipStats,proto3" json:"skip_stats,omitempty"` // true if we want to skip stats from Prometheus
+	TcpStats       bool                          `protobuf:"varint,7,opt,name=tcp_stats,json=tcpStats,proto3" json:"tcp_stats,omitempty"`
+	PolicyStats    bool                          `protobuf:"varint,8,opt,name=policy_stats,json=policyStats,proto3" json:"policy_stats,omitempty"`          // true if we want the policy attached to workloads
+	IncludeQueries bool                          `protobuf:"varint,9,opt,name=include_queries,json=includeQueries,proto3" json:"include_queries,omitempty"` // true if we want the Prometheus queries behind each row
+	ServerName     string                        `protobuf:"bytes,10,opt,name=server_name,json=serverName,proto3" json:"server_name,omitempty"`             // narrows the policy resource stats down to a Server
+	// true if we want only the metrics of the pods currently backing the
+	// workloads, leaving out the ones of the workloads' previous incarnations
+	CurrentPodsOnly bool `protobuf:"varint,11,opt,name=current_pods_only,json=currentPodsOnly,proto3" json:"current_pods_only,omitempty"`
+	// when set, the stats of the workloads are broken down by the value of this
+	// pod label, e.g. pod-template-hash to compare the ReplicaSets of a
+	// Deployment during a rollout
+	VersionLabel string `protobuf:"bytes,12,opt,name=version_label,json=versionLabel,proto3" json:"version_label,omitempty"`
+	// when set, only the inbound traffic from the clients with this TLS
+	// identity is counted, e.g. to isolate the traffic of an external client;
+	// incompatible with the to and from resources
+	FromIdentity string `protobuf:"bytes,13,opt,name=from_identity,json=fromIdentity,proto3" json:"from_identity,omitempty"`
+	// when set, the success rate of each row is evaluated against this
+	// objective, reporting how fast its error budget is burnt
+	Slo *SloObjective `protobuf:"bytes,14,opt,name=slo,proto3" json:"slo,omitempty"`
+	// true if the stats of authorities are broken down by the port the
+	// requests were sent to, as authorities without a port collapse the
+	// traffic sent to the different ports of the same host
+	ByPort bool `protobuf:"varint,15,opt,name=by_port,json=byPort,proto3" json:"by_port,omitempty"`
+	// when set, only the rows of the resources whose running pods all have the
+	// proxy, or of the ones with running pods lacking it, are returned; the
+	// resources without running pods are left out by both
+	MeshFilter StatSummaryRequest_MeshFilter `protobuf:"varint,16,opt,name=mesh_filter,json=meshFilter,proto3,enum=linkerd2.viz.StatSummaryRequest_MeshFilter" json:"mesh_filter,omitempty"`
 }
 
 func (x *StatSummaryRequest) Reset() {
 	*x = StatSummaryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[19]
+		mi := &file_viz_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1456,7 +1625,7 @@ func (x *StatSummaryRequest) String() string {
 func (*StatSummaryRequest) ProtoMessage() {}
 
 func (x *StatSummaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[19]
+	mi := &file_viz_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1469,7 +1638,7 @@ func (x *StatSummaryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatSummaryRequest.ProtoReflect.Descriptor instead.
 func (*StatSummaryRequest) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{19}
+	return file_viz_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *StatSummaryRequest) GetSelector() *ResourceSelection {
@@ -1528,6 +1697,69 @@ func (x *StatSummaryRequest) GetTcpStats() bool {
 	return false
 }
 
+func (x *StatSummaryRequest) GetPolicyStats() bool {
+	if x != nil {
+		return x.PolicyStats
+	}
+	return false
+}
+
+func (x *StatSummaryRequest) GetIncludeQueries() bool {
+	if x != nil {
+		return x.IncludeQueries
+	}
+	return false
+}
+
+func (x *StatSummaryRequest) GetServerName() string {
+	if x != nil {
+		return x.ServerName
+	}
+	return ""
+}
+
+func (x *StatSummaryRequest) GetCurrentPodsOnly() bool {
+	if x != nil {
+		return x.CurrentPodsOnly
+	}
+	return false
+}
+
+func (x *StatSummaryRequest) GetVersionLabel() string {
+	if x != nil {
+		return x.VersionLabel
+	}
+	return ""
+}
+
+func (x *StatSummaryRequest) GetFromIdentity() string {
+	if x != nil {
+		return x.FromIdentity
+	}
+	return ""
+}
+
+func (x *StatSummaryRequest) GetSlo() *SloObjective {
+	if x != nil {
+		return x.Slo
+	}
+	return nil
+}
+
+func (x *StatSummaryRequest) GetByPort() bool {
+	if x != nil {
+		return x.ByPort
+	}
+	return false
+}
+
+func (x *StatSummaryRequest) GetMeshFilter() StatSummaryRequest_MeshFilter {
+	if x != nil {
+		return x.MeshFilter
+	}
+	return StatSummaryRequest_ALL
+}
+
 type isStatSummaryRequest_Outbound interface {
 	isStatSummaryRequest_Outbound()
 }
@@ -1550,34 +1782,36 @@ func (*StatSummaryRequest_ToResource) isStatSummaryRequest_Outbound() {}
 
 func (*StatSummaryRequest_FromResource) isStatSummaryRequest_Outbound() {}
 
-type StatSummaryResponse struct {
+// SloObjective is a success rate objective, e.g. 99.5% over 1h
+type SloObjective struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Response:
-	//	*StatSummaryResponse_Ok_
-	//	*StatSummaryResponse_Error
-	Response isStatSummaryResponse_Response `protobuf_oneof:"response"`
+	// the targeted success rate, as a percentage strictly between 0 and 100
+	Target float64 `protobuf:"fixed64,1,opt,name=target,proto3" json:"target,omitempty"`
+	// the period the error budget is computed over, e.g. 1h; defaults to the
+	// time window of the request
+	Window string `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
 }
 
-func (x *StatSummaryResponse) Reset() {
-	*x = StatSummaryResponse{}
+func (x *SloObjective) Reset() {
+	*x = SloObjective{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[20]
+		mi := &file_viz_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StatSummaryResponse) String() string {
+func (x *SloObjective) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatSummaryResponse) ProtoMessage() {}
+func (*SloObjective) ProtoMessage() {}
 
-func (x *StatSummaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[20]
+func (x *SloObjective) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1588,12 +1822,69 @@ func (x *StatSummaryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatSummaryResponse.ProtoReflect.Descriptor instead.
-func (*StatSummaryResponse) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use SloObjective.ProtoReflect.Descriptor instead.
+func (*SloObjective) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{22}
 }
 
-func (m *StatSummaryResponse) GetResponse() isStatSummaryResponse_Response {
+func (x *SloObjective) GetTarget() float64 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+func (x *SloObjective) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+type StatSummaryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Response:
+	//	*StatSummaryResponse_Ok_
+	//	*StatSummaryResponse_Error
+	Response isStatSummaryResponse_Response `protobuf_oneof:"response"`
+}
+
+func (x *StatSummaryResponse) Reset() {
+	*x = StatSummaryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatSummaryResponse) ProtoMessage() {}
+
+func (x *StatSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatSummaryResponse.ProtoReflect.Descriptor instead.
+func (*StatSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{23}
+}
+
+func (m *StatSummaryResponse) GetResponse() isStatSummaryResponse_Response {
 	if m != nil {
 		return m.Response
 	}
@@ -1647,7 +1938,7 @@ type BasicStats struct {
 func (x *BasicStats) Reset() {
 	*x = BasicStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[21]
+		mi := &file_viz_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1660,7 +1951,7 @@ func (x *BasicStats) String() string {
 func (*BasicStats) ProtoMessage() {}
 
 func (x *BasicStats) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[21]
+	mi := &file_viz_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1673,7 +1964,7 @@ func (x *BasicStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BasicStats.ProtoReflect.Descriptor instead.
 func (*BasicStats) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{21}
+	return file_viz_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *BasicStats) GetSuccessCount() uint64 {
@@ -1725,6 +2016,82 @@ func (x *BasicStats) GetActualFailureCount() uint64 {
 	return 0
 }
 
+// SloStats evaluates the success rate of a resource against an SloObjective
+type SloStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Target float64 `protobuf:"fixed64,1,opt,name=target,proto3" json:"target,omitempty"`
+	Window string  `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
+	// the rate the error budget is burnt at over the time window of the
+	// request: at 1, it's exactly used up by the end of the SLO window
+	BurnRate float64 `protobuf:"fixed64,3,opt,name=burn_rate,json=burnRate,proto3" json:"burn_rate,omitempty"`
+	// the fraction of the error budget left over the SLO window, negative
+	// once it's overspent
+	BudgetRemaining float64 `protobuf:"fixed64,4,opt,name=budget_remaining,json=budgetRemaining,proto3" json:"budget_remaining,omitempty"`
+}
+
+func (x *SloStats) Reset() {
+	*x = SloStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SloStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SloStats) ProtoMessage() {}
+
+func (x *SloStats) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SloStats.ProtoReflect.Descriptor instead.
+func (*SloStats) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SloStats) GetTarget() float64 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+func (x *SloStats) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *SloStats) GetBurnRate() float64 {
+	if x != nil {
+		return x.BurnRate
+	}
+	return 0
+}
+
+func (x *SloStats) GetBudgetRemaining() float64 {
+	if x != nil {
+		return x.BudgetRemaining
+	}
+	return 0
+}
+
 type TcpStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1741,7 +2108,7 @@ type TcpStats struct {
 func (x *TcpStats) Reset() {
 	*x = TcpStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[22]
+		mi := &file_viz_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1754,7 +2121,7 @@ func (x *TcpStats) String() string {
 func (*TcpStats) ProtoMessage() {}
 
 func (x *TcpStats) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[22]
+	mi := &file_viz_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1767,7 +2134,7 @@ func (x *TcpStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TcpStats.ProtoReflect.Descriptor instead.
 func (*TcpStats) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{22}
+	return file_viz_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *TcpStats) GetOpenConnections() uint64 {
@@ -1804,7 +2171,7 @@ type TrafficSplitStats struct {
 func (x *TrafficSplitStats) Reset() {
 	*x = TrafficSplitStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[23]
+		mi := &file_viz_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1817,7 +2184,7 @@ func (x *TrafficSplitStats) String() string {
 func (*TrafficSplitStats) ProtoMessage() {}
 
 func (x *TrafficSplitStats) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[23]
+	mi := &file_viz_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1830,7 +2197,7 @@ func (x *TrafficSplitStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TrafficSplitStats.ProtoReflect.Descriptor instead.
 func (*TrafficSplitStats) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{23}
+	return file_viz_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *TrafficSplitStats) GetApex() string {
@@ -1866,7 +2233,7 @@ type ServerStats struct {
 func (x *ServerStats) Reset() {
 	*x = ServerStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[24]
+		mi := &file_viz_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1879,7 +2246,7 @@ func (x *ServerStats) String() string {
 func (*ServerStats) ProtoMessage() {}
 
 func (x *ServerStats) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[24]
+	mi := &file_viz_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1892,7 +2259,7 @@ func (x *ServerStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerStats.ProtoReflect.Descriptor instead.
 func (*ServerStats) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{24}
+	return file_viz_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *ServerStats) GetAllowedCount() uint64 {
@@ -1909,6 +2276,136 @@ func (x *ServerStats) GetDeniedCount() uint64 {
 	return 0
 }
 
+type PolicyStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Servers              uint64 `protobuf:"varint,1,opt,name=servers,proto3" json:"servers,omitempty"`
+	ServerAuthorizations uint64 `protobuf:"varint,2,opt,name=server_authorizations,json=serverAuthorizations,proto3" json:"server_authorizations,omitempty"`
+	DefaultDeny          bool   `protobuf:"varint,3,opt,name=default_deny,json=defaultDeny,proto3" json:"default_deny,omitempty"`
+}
+
+func (x *PolicyStats) Reset() {
+	*x = PolicyStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PolicyStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyStats) ProtoMessage() {}
+
+func (x *PolicyStats) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyStats.ProtoReflect.Descriptor instead.
+func (*PolicyStats) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PolicyStats) GetServers() uint64 {
+	if x != nil {
+		return x.Servers
+	}
+	return 0
+}
+
+func (x *PolicyStats) GetServerAuthorizations() uint64 {
+	if x != nil {
+		return x.ServerAuthorizations
+	}
+	return 0
+}
+
+func (x *PolicyStats) GetDefaultDeny() bool {
+	if x != nil {
+		return x.DefaultDeny
+	}
+	return false
+}
+
+// PromQuery is a Prometheus query run to compute stats, so that they can be
+// reproduced in the Prometheus UI
+type PromQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// what the query computes, e.g. QUERY_REQUESTS
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Query string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	// the time the query was evaluated at, in RFC 3339 format
+	EvaluationTime string `protobuf:"bytes,3,opt,name=evaluation_time,json=evaluationTime,proto3" json:"evaluation_time,omitempty"`
+}
+
+func (x *PromQuery) Reset() {
+	*x = PromQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PromQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromQuery) ProtoMessage() {}
+
+func (x *PromQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromQuery.ProtoReflect.Descriptor instead.
+func (*PromQuery) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PromQuery) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PromQuery) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *PromQuery) GetEvaluationTime() string {
+	if x != nil {
+		return x.EvaluationTime
+	}
+	return ""
+}
+
 type StatTable struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1922,7 +2419,7 @@ type StatTable struct {
 func (x *StatTable) Reset() {
 	*x = StatTable{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[25]
+		mi := &file_viz_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1935,7 +2432,7 @@ func (x *StatTable) String() string {
 func (*StatTable) ProtoMessage() {}
 
 func (x *StatTable) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[25]
+	mi := &file_viz_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1948,7 +2445,7 @@ func (x *StatTable) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatTable.ProtoReflect.Descriptor instead.
 func (*StatTable) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{25}
+	return file_viz_proto_rawDescGZIP(), []int{31}
 }
 
 func (m *StatTable) GetTable() isStatTable_Table {
@@ -1980,13 +2477,20 @@ type EdgesRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// The resource type "all" returns the edges between the workloads of any
+	// type, each pod being attributed to its top-level owner. An empty or "*"
+	// namespace returns the edges of all the namespaces.
 	Selector *ResourceSelection `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
+	// maximum number of edges returned, 0 means all of them
+	PageSize uint32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous response, to get the following page
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 }
 
 func (x *EdgesRequest) Reset() {
 	*x = EdgesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[26]
+		mi := &file_viz_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1999,7 +2503,7 @@ func (x *EdgesRequest) String() string {
 func (*EdgesRequest) ProtoMessage() {}
 
 func (x *EdgesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[26]
+	mi := &file_viz_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2012,7 +2516,7 @@ func (x *EdgesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EdgesRequest.ProtoReflect.Descriptor instead.
 func (*EdgesRequest) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{26}
+	return file_viz_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *EdgesRequest) GetSelector() *ResourceSelection {
@@ -2022,6 +2526,20 @@ func (x *EdgesRequest) GetSelector() *ResourceSelection {
 	return nil
 }
 
+func (x *EdgesRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *EdgesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
 type EdgesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2036,7 +2554,7 @@ type EdgesResponse struct {
 func (x *EdgesResponse) Reset() {
 	*x = EdgesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[27]
+		mi := &file_viz_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2049,7 +2567,7 @@ func (x *EdgesResponse) String() string {
 func (*EdgesResponse) ProtoMessage() {}
 
 func (x *EdgesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[27]
+	mi := &file_viz_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2062,7 +2580,7 @@ func (x *EdgesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EdgesResponse.ProtoReflect.Descriptor instead.
 func (*EdgesResponse) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{27}
+	return file_viz_proto_rawDescGZIP(), []int{33}
 }
 
 func (m *EdgesResponse) GetResponse() isEdgesResponse_Response {
@@ -2117,7 +2635,7 @@ type Edge struct {
 func (x *Edge) Reset() {
 	*x = Edge{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[28]
+		mi := &file_viz_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2130,7 +2648,7 @@ func (x *Edge) String() string {
 func (*Edge) ProtoMessage() {}
 
 func (x *Edge) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[28]
+	mi := &file_viz_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2143,7 +2661,7 @@ func (x *Edge) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Edge.ProtoReflect.Descriptor instead.
 func (*Edge) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{28}
+	return file_viz_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *Edge) GetSrc() *Resource {
@@ -2181,6 +2699,234 @@ func (x *Edge) GetNoIdentityMsg() string {
 	return ""
 }
 
+type DependenciesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Resource   *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	TimeWindow string    `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
+	// maximum number of hops walked from the resource, 0 means the default
+	MaxDepth uint32 `protobuf:"varint,3,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`
+}
+
+func (x *DependenciesRequest) Reset() {
+	*x = DependenciesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DependenciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependenciesRequest) ProtoMessage() {}
+
+func (x *DependenciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependenciesRequest.ProtoReflect.Descriptor instead.
+func (*DependenciesRequest) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DependenciesRequest) GetResource() *Resource {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *DependenciesRequest) GetTimeWindow() string {
+	if x != nil {
+		return x.TimeWindow
+	}
+	return ""
+}
+
+func (x *DependenciesRequest) GetMaxDepth() uint32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+type DependenciesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Response:
+	//	*DependenciesResponse_Ok_
+	//	*DependenciesResponse_Error
+	Response isDependenciesResponse_Response `protobuf_oneof:"response"`
+}
+
+func (x *DependenciesResponse) Reset() {
+	*x = DependenciesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DependenciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependenciesResponse) ProtoMessage() {}
+
+func (x *DependenciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependenciesResponse.ProtoReflect.Descriptor instead.
+func (*DependenciesResponse) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{36}
+}
+
+func (m *DependenciesResponse) GetResponse() isDependenciesResponse_Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (x *DependenciesResponse) GetOk() *DependenciesResponse_Ok {
+	if x, ok := x.GetResponse().(*DependenciesResponse_Ok_); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+func (x *DependenciesResponse) GetError() *ResourceError {
+	if x, ok := x.GetResponse().(*DependenciesResponse_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+type isDependenciesResponse_Response interface {
+	isDependenciesResponse_Response()
+}
+
+type DependenciesResponse_Ok_ struct {
+	Ok *DependenciesResponse_Ok `protobuf:"bytes,1,opt,name=ok,proto3,oneof"`
+}
+
+type DependenciesResponse_Error struct {
+	Error *ResourceError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*DependenciesResponse_Ok_) isDependenciesResponse_Response() {}
+
+func (*DependenciesResponse_Error) isDependenciesResponse_Response() {}
+
+type DependencyNode struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Resource *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// stats of the requests between this resource and its parent in the tree
+	Stats *BasicStats `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats,omitempty"`
+	// true if the resource already appears on the path from the root, in which
+	// case its dependencies aren't walked again
+	Cycle bool `protobuf:"varint,3,opt,name=cycle,proto3" json:"cycle,omitempty"`
+	// true if the resource has dependencies that weren't walked because the
+	// maximum depth was reached
+	Truncated bool              `protobuf:"varint,4,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	Children  []*DependencyNode `protobuf:"bytes,5,rep,name=children,proto3" json:"children,omitempty"`
+}
+
+func (x *DependencyNode) Reset() {
+	*x = DependencyNode{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DependencyNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependencyNode) ProtoMessage() {}
+
+func (x *DependencyNode) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependencyNode.ProtoReflect.Descriptor instead.
+func (*DependencyNode) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DependencyNode) GetResource() *Resource {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *DependencyNode) GetStats() *BasicStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *DependencyNode) GetCycle() bool {
+	if x != nil {
+		return x.Cycle
+	}
+	return false
+}
+
+func (x *DependencyNode) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *DependencyNode) GetChildren() []*DependencyNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
 type TopRoutesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2192,12 +2938,16 @@ type TopRoutesRequest struct {
 	//	*TopRoutesRequest_None
 	//	*TopRoutesRequest_ToResource
 	Outbound isTopRoutesRequest_Outbound `protobuf_oneof:"outbound"`
+	// the number of consecutive time windows, the last one ending now, whose
+	// success and failure counts are returned as the trend of each route; no
+	// trend is returned when 0
+	TrendPoints uint32 `protobuf:"varint,8,opt,name=trend_points,json=trendPoints,proto3" json:"trend_points,omitempty"`
 }
 
 func (x *TopRoutesRequest) Reset() {
 	*x = TopRoutesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[29]
+		mi := &file_viz_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2210,7 +2960,7 @@ func (x *TopRoutesRequest) String() string {
 func (*TopRoutesRequest) ProtoMessage() {}
 
 func (x *TopRoutesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[29]
+	mi := &file_viz_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2223,7 +2973,7 @@ func (x *TopRoutesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopRoutesRequest.ProtoReflect.Descriptor instead.
 func (*TopRoutesRequest) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{29}
+	return file_viz_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *TopRoutesRequest) GetSelector() *ResourceSelection {
@@ -2261,6 +3011,13 @@ func (x *TopRoutesRequest) GetToResource() *Resource {
 	return nil
 }
 
+func (x *TopRoutesRequest) GetTrendPoints() uint32 {
+	if x != nil {
+		return x.TrendPoints
+	}
+	return 0
+}
+
 type isTopRoutesRequest_Outbound interface {
 	isTopRoutesRequest_Outbound()
 }
@@ -2291,7 +3048,7 @@ type TopRoutesResponse struct {
 func (x *TopRoutesResponse) Reset() {
 	*x = TopRoutesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[30]
+		mi := &file_viz_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2304,7 +3061,7 @@ func (x *TopRoutesResponse) String() string {
 func (*TopRoutesResponse) ProtoMessage() {}
 
 func (x *TopRoutesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[30]
+	mi := &file_viz_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2317,7 +3074,7 @@ func (x *TopRoutesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopRoutesResponse.ProtoReflect.Descriptor instead.
 func (*TopRoutesResponse) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{30}
+	return file_viz_proto_rawDescGZIP(), []int{39}
 }
 
 func (m *TopRoutesResponse) GetResponse() isTopRoutesResponse_Response {
@@ -2369,7 +3126,7 @@ type RouteTable struct {
 func (x *RouteTable) Reset() {
 	*x = RouteTable{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[31]
+		mi := &file_viz_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2382,7 +3139,7 @@ func (x *RouteTable) String() string {
 func (*RouteTable) ProtoMessage() {}
 
 func (x *RouteTable) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[31]
+	mi := &file_viz_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2395,7 +3152,7 @@ func (x *RouteTable) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RouteTable.ProtoReflect.Descriptor instead.
 func (*RouteTable) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{31}
+	return file_viz_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *RouteTable) GetRows() []*RouteTable_Row {
@@ -2423,7 +3180,7 @@ type GatewaysTable struct {
 func (x *GatewaysTable) Reset() {
 	*x = GatewaysTable{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[32]
+		mi := &file_viz_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2436,7 +3193,7 @@ func (x *GatewaysTable) String() string {
 func (*GatewaysTable) ProtoMessage() {}
 
 func (x *GatewaysTable) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[32]
+	mi := &file_viz_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2449,7 +3206,7 @@ func (x *GatewaysTable) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GatewaysTable.ProtoReflect.Descriptor instead.
 func (*GatewaysTable) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{32}
+	return file_viz_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *GatewaysTable) GetRows() []*GatewaysTable_Row {
@@ -2472,7 +3229,7 @@ type GatewaysRequest struct {
 func (x *GatewaysRequest) Reset() {
 	*x = GatewaysRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[33]
+		mi := &file_viz_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2485,7 +3242,7 @@ func (x *GatewaysRequest) String() string {
 func (*GatewaysRequest) ProtoMessage() {}
 
 func (x *GatewaysRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[33]
+	mi := &file_viz_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2498,7 +3255,7 @@ func (x *GatewaysRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GatewaysRequest.ProtoReflect.Descriptor instead.
 func (*GatewaysRequest) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{33}
+	return file_viz_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *GatewaysRequest) GetRemoteClusterName() string {
@@ -2536,7 +3293,7 @@ type GatewaysResponse struct {
 func (x *GatewaysResponse) Reset() {
 	*x = GatewaysResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[34]
+		mi := &file_viz_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2549,7 +3306,7 @@ func (x *GatewaysResponse) String() string {
 func (*GatewaysResponse) ProtoMessage() {}
 
 func (x *GatewaysResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[34]
+	mi := &file_viz_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2562,7 +3319,7 @@ func (x *GatewaysResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GatewaysResponse.ProtoReflect.Descriptor instead.
 func (*GatewaysResponse) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{34}
+	return file_viz_proto_rawDescGZIP(), []int{43}
 }
 
 func (m *GatewaysResponse) GetResponse() isGatewaysResponse_Response {
@@ -2602,40 +3359,34 @@ func (*GatewaysResponse_Ok_) isGatewaysResponse_Response() {}
 
 func (*GatewaysResponse_Error) isGatewaysResponse_Response() {}
 
-type Headers_Header struct {
+// IngressStatsRequest selects the ingress controllers whose traffic is
+// reported, i.e. the ones in namespace, or in all namespaces if it's empty
+type IngressStatsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The name of a header in a request.
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// The value of a header in a request. If the value consists entirely of
-	// UTF-8 encodings, `value` will be set; otherwise a binary value is
-	// assumed and `value_bin` will be set.
-	//
-	// Types that are assignable to Value:
-	//	*Headers_Header_ValueStr
-	//	*Headers_Header_ValueBin
-	Value isHeaders_Header_Value `protobuf_oneof:"value"`
+	Namespace  string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	TimeWindow string `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
 }
 
-func (x *Headers_Header) Reset() {
-	*x = Headers_Header{}
+func (x *IngressStatsRequest) Reset() {
+	*x = IngressStatsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[35]
+		mi := &file_viz_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Headers_Header) String() string {
+func (x *IngressStatsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Headers_Header) ProtoMessage() {}
+func (*IngressStatsRequest) ProtoMessage() {}
 
-func (x *Headers_Header) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[35]
+func (x *IngressStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2646,82 +3397,53 @@ func (x *Headers_Header) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Headers_Header.ProtoReflect.Descriptor instead.
-func (*Headers_Header) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{12, 0}
+// Deprecated: Use IngressStatsRequest.ProtoReflect.Descriptor instead.
+func (*IngressStatsRequest) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *Headers_Header) GetName() string {
+func (x *IngressStatsRequest) GetNamespace() string {
 	if x != nil {
-		return x.Name
+		return x.Namespace
 	}
 	return ""
 }
 
-func (m *Headers_Header) GetValue() isHeaders_Header_Value {
-	if m != nil {
-		return m.Value
-	}
-	return nil
-}
-
-func (x *Headers_Header) GetValueStr() string {
-	if x, ok := x.GetValue().(*Headers_Header_ValueStr); ok {
-		return x.ValueStr
+func (x *IngressStatsRequest) GetTimeWindow() string {
+	if x != nil {
+		return x.TimeWindow
 	}
 	return ""
 }
 
-func (x *Headers_Header) GetValueBin() []byte {
-	if x, ok := x.GetValue().(*Headers_Header_ValueBin); ok {
-		return x.ValueBin
-	}
-	return nil
-}
+type IngressStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-type isHeaders_Header_Value interface {
-	isHeaders_Header_Value()
+	// Types that are assignable to Response:
+	//	*IngressStatsResponse_Ok_
+	//	*IngressStatsResponse_Error
+	Response isIngressStatsResponse_Response `protobuf_oneof:"response"`
 }
 
-type Headers_Header_ValueStr struct {
-	ValueStr string `protobuf:"bytes,2,opt,name=value_str,json=valueStr,proto3,oneof"`
+func (x *IngressStatsResponse) Reset() {
+	*x = IngressStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-type Headers_Header_ValueBin struct {
-	ValueBin []byte `protobuf:"bytes,3,opt,name=value_bin,json=valueBin,proto3,oneof"`
+func (x *IngressStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Headers_Header_ValueStr) isHeaders_Header_Value() {}
+func (*IngressStatsResponse) ProtoMessage() {}
 
-func (*Headers_Header_ValueBin) isHeaders_Header_Value() {}
-
-type PodErrors_PodError struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	// Types that are assignable to Error:
-	//	*PodErrors_PodError_Container
-	Error isPodErrors_PodError_Error `protobuf_oneof:"error"`
-}
-
-func (x *PodErrors_PodError) Reset() {
-	*x = PodErrors_PodError{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[36]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *PodErrors_PodError) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*PodErrors_PodError) ProtoMessage() {}
-
-func (x *PodErrors_PodError) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[36]
+func (x *IngressStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2732,64 +3454,80 @@ func (x *PodErrors_PodError) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PodErrors_PodError.ProtoReflect.Descriptor instead.
-func (*PodErrors_PodError) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{15, 0}
+// Deprecated: Use IngressStatsResponse.ProtoReflect.Descriptor instead.
+func (*IngressStatsResponse) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{45}
 }
 
-func (m *PodErrors_PodError) GetError() isPodErrors_PodError_Error {
+func (m *IngressStatsResponse) GetResponse() isIngressStatsResponse_Response {
 	if m != nil {
-		return m.Error
+		return m.Response
 	}
 	return nil
 }
 
-func (x *PodErrors_PodError) GetContainer() *PodErrors_PodError_ContainerError {
-	if x, ok := x.GetError().(*PodErrors_PodError_Container); ok {
-		return x.Container
+func (x *IngressStatsResponse) GetOk() *IngressStatsResponse_Ok {
+	if x, ok := x.GetResponse().(*IngressStatsResponse_Ok_); ok {
+		return x.Ok
 	}
 	return nil
 }
 
-type isPodErrors_PodError_Error interface {
-	isPodErrors_PodError_Error()
+func (x *IngressStatsResponse) GetError() *ResourceError {
+	if x, ok := x.GetResponse().(*IngressStatsResponse_Error); ok {
+		return x.Error
+	}
+	return nil
 }
 
-type PodErrors_PodError_Container struct {
-	Container *PodErrors_PodError_ContainerError `protobuf:"bytes,1,opt,name=container,proto3,oneof"`
+type isIngressStatsResponse_Response interface {
+	isIngressStatsResponse_Response()
 }
 
-func (*PodErrors_PodError_Container) isPodErrors_PodError_Error() {}
+type IngressStatsResponse_Ok_ struct {
+	Ok *IngressStatsResponse_Ok `protobuf:"bytes,1,opt,name=ok,proto3,oneof"`
+}
 
-// To report init-container and container failures
-type PodErrors_PodError_ContainerError struct {
+type IngressStatsResponse_Error struct {
+	Error *ResourceError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*IngressStatsResponse_Ok_) isIngressStatsResponse_Response() {}
+
+func (*IngressStatsResponse_Error) isIngressStatsResponse_Response() {}
+
+// IngressStatsRow holds the stats of the requests sent by an ingress
+// controller to one of its backend services
+type IngressStatsRow struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message   string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	Container string `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"`
-	Image     string `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
-	Reason    string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	IngressClass string `protobuf:"bytes,1,opt,name=ingress_class,json=ingressClass,proto3" json:"ingress_class,omitempty"`
+	// the workload running the ingress controller
+	Controller *Resource `protobuf:"bytes,2,opt,name=controller,proto3" json:"controller,omitempty"`
+	// the service the requests are sent to
+	Backend *Resource   `protobuf:"bytes,3,opt,name=backend,proto3" json:"backend,omitempty"`
+	Stats   *BasicStats `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"`
 }
 
-func (x *PodErrors_PodError_ContainerError) Reset() {
-	*x = PodErrors_PodError_ContainerError{}
+func (x *IngressStatsRow) Reset() {
+	*x = IngressStatsRow{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[37]
+		mi := &file_viz_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PodErrors_PodError_ContainerError) String() string {
+func (x *IngressStatsRow) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PodErrors_PodError_ContainerError) ProtoMessage() {}
+func (*IngressStatsRow) ProtoMessage() {}
 
-func (x *PodErrors_PodError_ContainerError) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[37]
+func (x *IngressStatsRow) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2800,64 +3538,68 @@ func (x *PodErrors_PodError_ContainerError) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PodErrors_PodError_ContainerError.ProtoReflect.Descriptor instead.
-func (*PodErrors_PodError_ContainerError) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{15, 0, 0}
+// Deprecated: Use IngressStatsRow.ProtoReflect.Descriptor instead.
+func (*IngressStatsRow) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *PodErrors_PodError_ContainerError) GetMessage() string {
+func (x *IngressStatsRow) GetIngressClass() string {
 	if x != nil {
-		return x.Message
+		return x.IngressClass
 	}
 	return ""
 }
 
-func (x *PodErrors_PodError_ContainerError) GetContainer() string {
+func (x *IngressStatsRow) GetController() *Resource {
 	if x != nil {
-		return x.Container
+		return x.Controller
 	}
-	return ""
+	return nil
 }
 
-func (x *PodErrors_PodError_ContainerError) GetImage() string {
+func (x *IngressStatsRow) GetBackend() *Resource {
 	if x != nil {
-		return x.Image
+		return x.Backend
 	}
-	return ""
+	return nil
 }
 
-func (x *PodErrors_PodError_ContainerError) GetReason() string {
+func (x *IngressStatsRow) GetStats() *BasicStats {
 	if x != nil {
-		return x.Reason
+		return x.Stats
 	}
-	return ""
+	return nil
 }
 
-type StatSummaryResponse_Ok struct {
+// IngressResourceStatsRequest selects the Ingresses and Gateway API Gateways
+// whose traffic is reported, i.e. the ones in namespace, or in all namespaces
+// if it's empty
+type IngressResourceStatsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	StatTables []*StatTable `protobuf:"bytes,1,rep,name=stat_tables,json=statTables,proto3" json:"stat_tables,omitempty"`
+	Namespace  string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	TimeWindow string `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
 }
 
-func (x *StatSummaryResponse_Ok) Reset() {
-	*x = StatSummaryResponse_Ok{}
+func (x *IngressResourceStatsRequest) Reset() {
+	*x = IngressResourceStatsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[38]
+		mi := &file_viz_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StatSummaryResponse_Ok) String() string {
+func (x *IngressResourceStatsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatSummaryResponse_Ok) ProtoMessage() {}
+func (*IngressResourceStatsRequest) ProtoMessage() {}
 
-func (x *StatSummaryResponse_Ok) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[38]
+func (x *IngressResourceStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2868,43 +3610,53 @@ func (x *StatSummaryResponse_Ok) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatSummaryResponse_Ok.ProtoReflect.Descriptor instead.
-func (*StatSummaryResponse_Ok) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{20, 0}
+// Deprecated: Use IngressResourceStatsRequest.ProtoReflect.Descriptor instead.
+func (*IngressResourceStatsRequest) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *StatSummaryResponse_Ok) GetStatTables() []*StatTable {
+func (x *IngressResourceStatsRequest) GetNamespace() string {
 	if x != nil {
-		return x.StatTables
+		return x.Namespace
 	}
-	return nil
+	return ""
 }
 
-type StatTable_PodGroup struct {
+func (x *IngressResourceStatsRequest) GetTimeWindow() string {
+	if x != nil {
+		return x.TimeWindow
+	}
+	return ""
+}
+
+type IngressResourceStatsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Rows []*StatTable_PodGroup_Row `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	// Types that are assignable to Response:
+	//	*IngressResourceStatsResponse_Ok_
+	//	*IngressResourceStatsResponse_Error
+	Response isIngressResourceStatsResponse_Response `protobuf_oneof:"response"`
 }
 
-func (x *StatTable_PodGroup) Reset() {
-	*x = StatTable_PodGroup{}
+func (x *IngressResourceStatsResponse) Reset() {
+	*x = IngressResourceStatsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[39]
+		mi := &file_viz_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StatTable_PodGroup) String() string {
+func (x *IngressResourceStatsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatTable_PodGroup) ProtoMessage() {}
+func (*IngressResourceStatsResponse) ProtoMessage() {}
 
-func (x *StatTable_PodGroup) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[39]
+func (x *IngressResourceStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2915,58 +3667,85 @@ func (x *StatTable_PodGroup) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatTable_PodGroup.ProtoReflect.Descriptor instead.
-func (*StatTable_PodGroup) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{25, 0}
+// Deprecated: Use IngressResourceStatsResponse.ProtoReflect.Descriptor instead.
+func (*IngressResourceStatsResponse) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *StatTable_PodGroup) GetRows() []*StatTable_PodGroup_Row {
-	if x != nil {
-		return x.Rows
+func (m *IngressResourceStatsResponse) GetResponse() isIngressResourceStatsResponse_Response {
+	if m != nil {
+		return m.Response
 	}
 	return nil
 }
 
-type StatTable_PodGroup_Row struct {
+func (x *IngressResourceStatsResponse) GetOk() *IngressResourceStatsResponse_Ok {
+	if x, ok := x.GetResponse().(*IngressResourceStatsResponse_Ok_); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+func (x *IngressResourceStatsResponse) GetError() *ResourceError {
+	if x, ok := x.GetResponse().(*IngressResourceStatsResponse_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+type isIngressResourceStatsResponse_Response interface {
+	isIngressResourceStatsResponse_Response()
+}
+
+type IngressResourceStatsResponse_Ok_ struct {
+	Ok *IngressResourceStatsResponse_Ok `protobuf:"bytes,1,opt,name=ok,proto3,oneof"`
+}
+
+type IngressResourceStatsResponse_Error struct {
+	Error *ResourceError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*IngressResourceStatsResponse_Ok_) isIngressResourceStatsResponse_Response() {}
+
+func (*IngressResourceStatsResponse_Error) isIngressResourceStatsResponse_Response() {}
+
+// IngressResourceStatsRow holds the stats of the requests routed by an
+// Ingress, or by the HTTPRoutes attached to a Gateway, as sent by the ingress
+// controllers to its backend services. The requests are attributed from
+// their backend service and authority, so the requests to a host routed by
+// several Ingresses to the same service are counted for each of them.
+type IngressResourceStatsRow struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Resource   *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
-	TimeWindow string    `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
-	// pod status on Kubernetes
-	Status string `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
-	// number of pending or running pods in this resource that have linkerd injected
-	MeshedPodCount uint64 `protobuf:"varint,3,opt,name=meshed_pod_count,json=meshedPodCount,proto3" json:"meshed_pod_count,omitempty"`
-	// number of pending or running pods in this resource
-	RunningPodCount uint64 `protobuf:"varint,4,opt,name=running_pod_count,json=runningPodCount,proto3" json:"running_pod_count,omitempty"`
-	// number of pods in this resource that have Phase PodFailed
-	FailedPodCount uint64             `protobuf:"varint,6,opt,name=failed_pod_count,json=failedPodCount,proto3" json:"failed_pod_count,omitempty"`
-	Stats          *BasicStats        `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty"`
-	TcpStats       *TcpStats          `protobuf:"bytes,8,opt,name=tcp_stats,json=tcpStats,proto3" json:"tcp_stats,omitempty"`
-	TsStats        *TrafficSplitStats `protobuf:"bytes,10,opt,name=ts_stats,json=tsStats,proto3" json:"ts_stats,omitempty"`
-	SrvStats       *ServerStats       `protobuf:"bytes,11,opt,name=srv_stats,json=srvStats,proto3" json:"srv_stats,omitempty"`
-	// Stores a set of errors for each pod name. If a pod has no errors, it may be omitted.
-	ErrorsByPod map[string]*PodErrors `protobuf:"bytes,7,rep,name=errors_by_pod,json=errorsByPod,proto3" json:"errors_by_pod,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the Ingress or Gateway, whose type is ingress or gateway
+	Resource *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// the class of the Ingress, or the GatewayClass of the Gateway
+	IngressClass string `protobuf:"bytes,2,opt,name=ingress_class,json=ingressClass,proto3" json:"ingress_class,omitempty"`
+	// the services the requests are routed to
+	Backends []*Resource `protobuf:"bytes,3,rep,name=backends,proto3" json:"backends,omitempty"`
+	// unset when no request was routed by the resource
+	Stats *BasicStats `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"`
 }
 
-func (x *StatTable_PodGroup_Row) Reset() {
-	*x = StatTable_PodGroup_Row{}
+func (x *IngressResourceStatsRow) Reset() {
+	*x = IngressResourceStatsRow{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[40]
+		mi := &file_viz_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StatTable_PodGroup_Row) String() string {
+func (x *IngressResourceStatsRow) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatTable_PodGroup_Row) ProtoMessage() {}
+func (*IngressResourceStatsRow) ProtoMessage() {}
 
-func (x *StatTable_PodGroup_Row) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[40]
+func (x *IngressResourceStatsRow) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2977,113 +3756,147 @@ func (x *StatTable_PodGroup_Row) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatTable_PodGroup_Row.ProtoReflect.Descriptor instead.
-func (*StatTable_PodGroup_Row) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{25, 0, 0}
+// Deprecated: Use IngressResourceStatsRow.ProtoReflect.Descriptor instead.
+func (*IngressResourceStatsRow) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *StatTable_PodGroup_Row) GetResource() *Resource {
+func (x *IngressResourceStatsRow) GetResource() *Resource {
 	if x != nil {
 		return x.Resource
 	}
 	return nil
 }
 
-func (x *StatTable_PodGroup_Row) GetTimeWindow() string {
+func (x *IngressResourceStatsRow) GetIngressClass() string {
 	if x != nil {
-		return x.TimeWindow
+		return x.IngressClass
 	}
 	return ""
 }
 
-func (x *StatTable_PodGroup_Row) GetStatus() string {
+func (x *IngressResourceStatsRow) GetBackends() []*Resource {
 	if x != nil {
-		return x.Status
+		return x.Backends
 	}
-	return ""
+	return nil
 }
 
-func (x *StatTable_PodGroup_Row) GetMeshedPodCount() uint64 {
+func (x *IngressResourceStatsRow) GetStats() *BasicStats {
 	if x != nil {
-		return x.MeshedPodCount
+		return x.Stats
 	}
-	return 0
+	return nil
 }
 
-func (x *StatTable_PodGroup_Row) GetRunningPodCount() uint64 {
-	if x != nil {
-		return x.RunningPodCount
-	}
-	return 0
+// AuthzRequest describes a request whose authorization is simulated: the
+// target is either a Server, or a workload along with the port the request is
+// sent to
+type AuthzRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the identity of the client, e.g.
+	// web.emojivoto.serviceaccount.identity.linkerd.cluster.local, or empty for
+	// an unmeshed client
+	ClientIdentity string `protobuf:"bytes,1,opt,name=client_identity,json=clientIdentity,proto3" json:"client_identity,omitempty"`
+	// the IP of the client; when empty, the client is assumed to be in the
+	// authorized networks
+	ClientIp string    `protobuf:"bytes,2,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	Target   *Resource `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	// the number or the name of the port, for a workload target
+	Port string `protobuf:"bytes,4,opt,name=port,proto3" json:"port,omitempty"`
 }
 
-func (x *StatTable_PodGroup_Row) GetFailedPodCount() uint64 {
-	if x != nil {
-		return x.FailedPodCount
+func (x *AuthzRequest) Reset() {
+	*x = AuthzRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *StatTable_PodGroup_Row) GetStats() *BasicStats {
-	if x != nil {
-		return x.Stats
+func (x *AuthzRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthzRequest) ProtoMessage() {}
+
+func (x *AuthzRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *StatTable_PodGroup_Row) GetTcpStats() *TcpStats {
+// Deprecated: Use AuthzRequest.ProtoReflect.Descriptor instead.
+func (*AuthzRequest) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *AuthzRequest) GetClientIdentity() string {
 	if x != nil {
-		return x.TcpStats
+		return x.ClientIdentity
 	}
-	return nil
+	return ""
 }
 
-func (x *StatTable_PodGroup_Row) GetTsStats() *TrafficSplitStats {
+func (x *AuthzRequest) GetClientIp() string {
 	if x != nil {
-		return x.TsStats
+		return x.ClientIp
 	}
-	return nil
+	return ""
 }
 
-func (x *StatTable_PodGroup_Row) GetSrvStats() *ServerStats {
+func (x *AuthzRequest) GetTarget() *Resource {
 	if x != nil {
-		return x.SrvStats
+		return x.Target
 	}
 	return nil
 }
 
-func (x *StatTable_PodGroup_Row) GetErrorsByPod() map[string]*PodErrors {
+func (x *AuthzRequest) GetPort() string {
 	if x != nil {
-		return x.ErrorsByPod
+		return x.Port
 	}
-	return nil
+	return ""
 }
 
-type EdgesResponse_Ok struct {
+type AuthzResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Edges []*Edge `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
+	// Types that are assignable to Response:
+	//	*AuthzResponse_Ok_
+	//	*AuthzResponse_Error
+	Response isAuthzResponse_Response `protobuf_oneof:"response"`
 }
 
-func (x *EdgesResponse_Ok) Reset() {
-	*x = EdgesResponse_Ok{}
+func (x *AuthzResponse) Reset() {
+	*x = AuthzResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[42]
+		mi := &file_viz_proto_msgTypes[51]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *EdgesResponse_Ok) String() string {
+func (x *AuthzResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EdgesResponse_Ok) ProtoMessage() {}
+func (*AuthzResponse) ProtoMessage() {}
 
-func (x *EdgesResponse_Ok) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[42]
+func (x *AuthzResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3094,43 +3907,80 @@ func (x *EdgesResponse_Ok) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EdgesResponse_Ok.ProtoReflect.Descriptor instead.
-func (*EdgesResponse_Ok) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{27, 0}
+// Deprecated: Use AuthzResponse.ProtoReflect.Descriptor instead.
+func (*AuthzResponse) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *EdgesResponse_Ok) GetEdges() []*Edge {
-	if x != nil {
-		return x.Edges
+func (m *AuthzResponse) GetResponse() isAuthzResponse_Response {
+	if m != nil {
+		return m.Response
 	}
 	return nil
 }
 
-type TopRoutesResponse_Ok struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Routes []*RouteTable `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`
+func (x *AuthzResponse) GetOk() *AuthzResponse_Ok {
+	if x, ok := x.GetResponse().(*AuthzResponse_Ok_); ok {
+		return x.Ok
+	}
+	return nil
 }
 
-func (x *TopRoutesResponse_Ok) Reset() {
-	*x = TopRoutesResponse_Ok{}
+func (x *AuthzResponse) GetError() *ResourceError {
+	if x, ok := x.GetResponse().(*AuthzResponse_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+type isAuthzResponse_Response interface {
+	isAuthzResponse_Response()
+}
+
+type AuthzResponse_Ok_ struct {
+	Ok *AuthzResponse_Ok `protobuf:"bytes,1,opt,name=ok,proto3,oneof"`
+}
+
+type AuthzResponse_Error struct {
+	Error *ResourceError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*AuthzResponse_Ok_) isAuthzResponse_Response() {}
+
+func (*AuthzResponse_Error) isAuthzResponse_Response() {}
+
+// LatencyHeatmapRequest selects the resource whose distribution of the
+// latencies of its inbound responses is returned over a time range, e.g. the
+// last hour by steps of one minute
+type LatencyHeatmapRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Resource *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// how far back the time range starts, e.g. "1h"
+	TimeRange string `protobuf:"bytes,2,opt,name=time_range,json=timeRange,proto3" json:"time_range,omitempty"`
+	// the duration covered by each column of the heatmap, e.g. "1m"
+	Step string `protobuf:"bytes,3,opt,name=step,proto3" json:"step,omitempty"`
+}
+
+func (x *LatencyHeatmapRequest) Reset() {
+	*x = LatencyHeatmapRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[43]
+		mi := &file_viz_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TopRoutesResponse_Ok) String() string {
+func (x *LatencyHeatmapRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TopRoutesResponse_Ok) ProtoMessage() {}
+func (*LatencyHeatmapRequest) ProtoMessage() {}
 
-func (x *TopRoutesResponse_Ok) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[43]
+func (x *LatencyHeatmapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3141,46 +3991,60 @@ func (x *TopRoutesResponse_Ok) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TopRoutesResponse_Ok.ProtoReflect.Descriptor instead.
-func (*TopRoutesResponse_Ok) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{30, 0}
+// Deprecated: Use LatencyHeatmapRequest.ProtoReflect.Descriptor instead.
+func (*LatencyHeatmapRequest) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *TopRoutesResponse_Ok) GetRoutes() []*RouteTable {
+func (x *LatencyHeatmapRequest) GetResource() *Resource {
 	if x != nil {
-		return x.Routes
+		return x.Resource
 	}
 	return nil
 }
 
-type RouteTable_Row struct {
+func (x *LatencyHeatmapRequest) GetTimeRange() string {
+	if x != nil {
+		return x.TimeRange
+	}
+	return ""
+}
+
+func (x *LatencyHeatmapRequest) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+type LatencyHeatmapResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Route      string      `protobuf:"bytes,1,opt,name=route,proto3" json:"route,omitempty"`
-	TimeWindow string      `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
-	Authority  string      `protobuf:"bytes,6,opt,name=authority,proto3" json:"authority,omitempty"`
-	Stats      *BasicStats `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty"`
+	// Types that are assignable to Response:
+	//	*LatencyHeatmapResponse_Ok_
+	//	*LatencyHeatmapResponse_Error
+	Response isLatencyHeatmapResponse_Response `protobuf_oneof:"response"`
 }
 
-func (x *RouteTable_Row) Reset() {
-	*x = RouteTable_Row{}
+func (x *LatencyHeatmapResponse) Reset() {
+	*x = LatencyHeatmapResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[44]
+		mi := &file_viz_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RouteTable_Row) String() string {
+func (x *LatencyHeatmapResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RouteTable_Row) ProtoMessage() {}
+func (*LatencyHeatmapResponse) ProtoMessage() {}
 
-func (x *RouteTable_Row) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[44]
+func (x *LatencyHeatmapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3191,71 +4055,136 @@ func (x *RouteTable_Row) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RouteTable_Row.ProtoReflect.Descriptor instead.
-func (*RouteTable_Row) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{31, 0}
+// Deprecated: Use LatencyHeatmapResponse.ProtoReflect.Descriptor instead.
+func (*LatencyHeatmapResponse) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *RouteTable_Row) GetRoute() string {
-	if x != nil {
-		return x.Route
+func (m *LatencyHeatmapResponse) GetResponse() isLatencyHeatmapResponse_Response {
+	if m != nil {
+		return m.Response
 	}
-	return ""
+	return nil
 }
 
-func (x *RouteTable_Row) GetTimeWindow() string {
-	if x != nil {
-		return x.TimeWindow
+func (x *LatencyHeatmapResponse) GetOk() *LatencyHeatmapResponse_Ok {
+	if x, ok := x.GetResponse().(*LatencyHeatmapResponse_Ok_); ok {
+		return x.Ok
 	}
-	return ""
+	return nil
 }
 
-func (x *RouteTable_Row) GetAuthority() string {
+func (x *LatencyHeatmapResponse) GetError() *ResourceError {
+	if x, ok := x.GetResponse().(*LatencyHeatmapResponse_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+type isLatencyHeatmapResponse_Response interface {
+	isLatencyHeatmapResponse_Response()
+}
+
+type LatencyHeatmapResponse_Ok_ struct {
+	Ok *LatencyHeatmapResponse_Ok `protobuf:"bytes,1,opt,name=ok,proto3,oneof"`
+}
+
+type LatencyHeatmapResponse_Error struct {
+	Error *ResourceError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*LatencyHeatmapResponse_Ok_) isLatencyHeatmapResponse_Response() {}
+
+func (*LatencyHeatmapResponse_Error) isLatencyHeatmapResponse_Response() {}
+
+// LatencyHeatmapColumn holds the number of responses of each latency bucket
+// over a step of the time range
+type LatencyHeatmapColumn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the end of the step, in RFC 3339 format
+	Timestamp string `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// the number of responses whose latency falls in each bucket, i.e. above
+	// the bound of the previous bucket
+	Counts []uint64 `protobuf:"varint,2,rep,packed,name=counts,proto3" json:"counts,omitempty"`
+}
+
+func (x *LatencyHeatmapColumn) Reset() {
+	*x = LatencyHeatmapColumn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LatencyHeatmapColumn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatencyHeatmapColumn) ProtoMessage() {}
+
+func (x *LatencyHeatmapColumn) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatencyHeatmapColumn.ProtoReflect.Descriptor instead.
+func (*LatencyHeatmapColumn) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *LatencyHeatmapColumn) GetTimestamp() string {
 	if x != nil {
-		return x.Authority
+		return x.Timestamp
 	}
 	return ""
 }
 
-func (x *RouteTable_Row) GetStats() *BasicStats {
+func (x *LatencyHeatmapColumn) GetCounts() []uint64 {
 	if x != nil {
-		return x.Stats
+		return x.Counts
 	}
 	return nil
 }
 
-type GatewaysTable_Row struct {
+// EgressRequest selects the workloads whose traffic to destinations outside
+// of the cluster is reported. The resource name and namespace are optional.
+type EgressRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Namespace      string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Name           string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	ClusterName    string `protobuf:"bytes,3,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
-	PairedServices uint64 `protobuf:"varint,4,opt,name=paired_services,json=pairedServices,proto3" json:"paired_services,omitempty"`
-	Alive          bool   `protobuf:"varint,5,opt,name=alive,proto3" json:"alive,omitempty"`
-	LatencyMsP50   uint64 `protobuf:"varint,6,opt,name=latency_ms_p50,json=latencyMsP50,proto3" json:"latency_ms_p50,omitempty"`
-	LatencyMsP95   uint64 `protobuf:"varint,7,opt,name=latency_ms_p95,json=latencyMsP95,proto3" json:"latency_ms_p95,omitempty"`
-	LatencyMsP99   uint64 `protobuf:"varint,8,opt,name=latency_ms_p99,json=latencyMsP99,proto3" json:"latency_ms_p99,omitempty"`
+	Resource   *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	TimeWindow string    `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
 }
 
-func (x *GatewaysTable_Row) Reset() {
-	*x = GatewaysTable_Row{}
+func (x *EgressRequest) Reset() {
+	*x = EgressRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[45]
+		mi := &file_viz_proto_msgTypes[55]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GatewaysTable_Row) String() string {
+func (x *EgressRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GatewaysTable_Row) ProtoMessage() {}
+func (*EgressRequest) ProtoMessage() {}
 
-func (x *GatewaysTable_Row) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[45]
+func (x *EgressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[55]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3266,92 +4195,139 @@ func (x *GatewaysTable_Row) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GatewaysTable_Row.ProtoReflect.Descriptor instead.
-func (*GatewaysTable_Row) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{32, 0}
+// Deprecated: Use EgressRequest.ProtoReflect.Descriptor instead.
+func (*EgressRequest) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *GatewaysTable_Row) GetNamespace() string {
+func (x *EgressRequest) GetResource() *Resource {
 	if x != nil {
-		return x.Namespace
+		return x.Resource
 	}
-	return ""
+	return nil
 }
 
-func (x *GatewaysTable_Row) GetName() string {
+func (x *EgressRequest) GetTimeWindow() string {
 	if x != nil {
-		return x.Name
+		return x.TimeWindow
 	}
 	return ""
 }
 
-func (x *GatewaysTable_Row) GetClusterName() string {
-	if x != nil {
-		return x.ClusterName
-	}
-	return ""
+type EgressResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Response:
+	//	*EgressResponse_Ok_
+	//	*EgressResponse_Error
+	Response isEgressResponse_Response `protobuf_oneof:"response"`
 }
 
-func (x *GatewaysTable_Row) GetPairedServices() uint64 {
-	if x != nil {
-		return x.PairedServices
+func (x *EgressResponse) Reset() {
+	*x = EgressResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *GatewaysTable_Row) GetAlive() bool {
-	if x != nil {
-		return x.Alive
+func (x *EgressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EgressResponse) ProtoMessage() {}
+
+func (x *EgressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *GatewaysTable_Row) GetLatencyMsP50() uint64 {
-	if x != nil {
-		return x.LatencyMsP50
+// Deprecated: Use EgressResponse.ProtoReflect.Descriptor instead.
+func (*EgressResponse) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{56}
+}
+
+func (m *EgressResponse) GetResponse() isEgressResponse_Response {
+	if m != nil {
+		return m.Response
 	}
-	return 0
+	return nil
 }
 
-func (x *GatewaysTable_Row) GetLatencyMsP95() uint64 {
-	if x != nil {
-		return x.LatencyMsP95
+func (x *EgressResponse) GetOk() *EgressResponse_Ok {
+	if x, ok := x.GetResponse().(*EgressResponse_Ok_); ok {
+		return x.Ok
 	}
-	return 0
+	return nil
 }
 
-func (x *GatewaysTable_Row) GetLatencyMsP99() uint64 {
-	if x != nil {
-		return x.LatencyMsP99
+func (x *EgressResponse) GetError() *ResourceError {
+	if x, ok := x.GetResponse().(*EgressResponse_Error); ok {
+		return x.Error
 	}
-	return 0
+	return nil
 }
 
-type GatewaysResponse_Ok struct {
+type isEgressResponse_Response interface {
+	isEgressResponse_Response()
+}
+
+type EgressResponse_Ok_ struct {
+	Ok *EgressResponse_Ok `protobuf:"bytes,1,opt,name=ok,proto3,oneof"`
+}
+
+type EgressResponse_Error struct {
+	Error *ResourceError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*EgressResponse_Ok_) isEgressResponse_Response() {}
+
+func (*EgressResponse_Error) isEgressResponse_Response() {}
+
+// EgressRow holds the stats of the traffic sent by a workload to a
+// destination outside of the cluster
+type EgressRow struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	GatewaysTable *GatewaysTable `protobuf:"bytes,1,opt,name=gateways_table,json=gatewaysTable,proto3" json:"gateways_table,omitempty"`
+	// the workload sending the traffic
+	Resource *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// the authority of the destination, i.e. its host name or IP, and port
+	Authority string      `protobuf:"bytes,2,opt,name=authority,proto3" json:"authority,omitempty"`
+	Stats     *BasicStats `protobuf:"bytes,3,opt,name=stats,proto3" json:"stats,omitempty"`
+	TcpStats  *TcpStats   `protobuf:"bytes,4,opt,name=tcp_stats,json=tcpStats,proto3" json:"tcp_stats,omitempty"`
+	// the number of the requests, among the ones in stats, sent over TLS
+	TlsRequestCount uint64 `protobuf:"varint,5,opt,name=tls_request_count,json=tlsRequestCount,proto3" json:"tls_request_count,omitempty"`
 }
 
-func (x *GatewaysResponse_Ok) Reset() {
-	*x = GatewaysResponse_Ok{}
+func (x *EgressRow) Reset() {
+	*x = EgressRow{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_proto_msgTypes[46]
+		mi := &file_viz_proto_msgTypes[57]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GatewaysResponse_Ok) String() string {
+func (x *EgressRow) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GatewaysResponse_Ok) ProtoMessage() {}
+func (*EgressRow) ProtoMessage() {}
 
-func (x *GatewaysResponse_Ok) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_proto_msgTypes[46]
+func (x *EgressRow) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[57]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3362,427 +4338,2087 @@ func (x *GatewaysResponse_Ok) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GatewaysResponse_Ok.ProtoReflect.Descriptor instead.
-func (*GatewaysResponse_Ok) Descriptor() ([]byte, []int) {
-	return file_viz_proto_rawDescGZIP(), []int{34, 0}
+// Deprecated: Use EgressRow.ProtoReflect.Descriptor instead.
+func (*EgressRow) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *GatewaysResponse_Ok) GetGatewaysTable() *GatewaysTable {
+func (x *EgressRow) GetResource() *Resource {
 	if x != nil {
-		return x.GatewaysTable
+		return x.Resource
 	}
 	return nil
 }
 
-var File_viz_proto protoreflect.FileDescriptor
+func (x *EgressRow) GetAuthority() string {
+	if x != nil {
+		return x.Authority
+	}
+	return ""
+}
 
-var file_viz_proto_rawDesc = []byte{
-	0x0a, 0x09, 0x76, 0x69, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x6c, 0x69, 0x6e,
-	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x22, 0xc8, 0x01, 0x0a, 0x0b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x53, 0x75, 0x62, 0x73, 0x79,
-	0x73, 0x74, 0x65, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x43, 0x68, 0x65, 0x63,
-	0x6b, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x10, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
-	0x76, 0x69, 0x7a, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
-	0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x34, 0x0a, 0x15, 0x46, 0x72, 0x69, 0x65, 0x6e,
-	0x64, 0x6c, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x55, 0x73, 0x65, 0x72,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x6c, 0x79,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x55, 0x73, 0x65, 0x72, 0x22, 0x12, 0x0a,
-	0x10, 0x53, 0x65, 0x6c, 0x66, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x22, 0x48, 0x0a, 0x11, 0x53, 0x65, 0x6c, 0x66, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
-	0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x33, 0x0a, 0x13, 0x4c,
-	0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
-	0x22, 0x49, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x69, 0x6e,
-	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x22, 0x3b, 0x0a, 0x07, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61,
-	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e,
-	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x70, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74,
-	0x50, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x09, 0x6e,
-	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02,
-	0x18, 0x01, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x3b, 0x0a,
-	0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52,
-	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x39, 0x0a, 0x10, 0x4c, 0x69,
-	0x73, 0x74, 0x50, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25,
-	0x0a, 0x04, 0x70, 0x6f, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6c,
-	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x50, 0x6f, 0x64, 0x52,
-	0x04, 0x70, 0x6f, 0x64, 0x73, 0x22, 0xfa, 0x04, 0x0a, 0x03, 0x50, 0x6f, 0x64, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x6f, 0x64, 0x49, 0x50, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x70, 0x6f, 0x64, 0x49, 0x50, 0x12, 0x20, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f,
-	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x64,
-	0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0b, 0x72, 0x65, 0x70,
-	0x6c, 0x69, 0x63, 0x61, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
-	0x52, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x53, 0x65, 0x74, 0x12, 0x37, 0x0a, 0x16,
-	0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x74,
-	0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x15,
-	0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x72,
-	0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x65, 0x66, 0x75,
-	0x6c, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x73,
-	0x74, 0x61, 0x74, 0x65, 0x66, 0x75, 0x6c, 0x53, 0x65, 0x74, 0x12, 0x1f, 0x0a, 0x0a, 0x64, 0x61,
-	0x65, 0x6d, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
-	0x52, 0x09, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x53, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x03, 0x6a,
-	0x6f, 0x62, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12,
-	0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x12, 0x43, 0x0a,
-	0x0f, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x0f, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f,
-	0x72, 0x74, 0x12, 0x30, 0x0a, 0x13, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72,
-	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x13, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73,
-	0x70, 0x61, 0x63, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x50,
-	0x6c, 0x61, 0x6e, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74,
-	0x72, 0x6f, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x75, 0x70, 0x74, 0x69,
-	0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x70,
-	0x72, 0x6f, 0x78, 0x79, 0x52, 0x65, 0x61, 0x64, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x0a, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x52, 0x65, 0x61, 0x64, 0x79, 0x12, 0x22, 0x0a, 0x0c, 0x70,
-	0x72, 0x6f, 0x78, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
-	0x28, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69,
-	0x6f, 0x6e, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x07, 0x0a, 0x05, 0x6f, 0x77, 0x6e,
-	0x65, 0x72, 0x22, 0xf1, 0x01, 0x0a, 0x0a, 0x48, 0x74, 0x74, 0x70, 0x4d, 0x65, 0x74, 0x68, 0x6f,
-	0x64, 0x12, 0x45, 0x0a, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
-	0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x2e,
-	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65,
-	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x0c, 0x75, 0x6e, 0x72, 0x65,
-	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
-	0x52, 0x0c, 0x75, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x22, 0x6e,
-	0x0a, 0x0a, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x07, 0x0a, 0x03,
-	0x47, 0x45, 0x54, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x4f, 0x53, 0x54, 0x10, 0x01, 0x12,
-	0x07, 0x0a, 0x03, 0x50, 0x55, 0x54, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45,
-	0x54, 0x45, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x41, 0x54, 0x43, 0x48, 0x10, 0x04, 0x12,
-	0x0b, 0x0a, 0x07, 0x4f, 0x50, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x05, 0x12, 0x0b, 0x0a, 0x07,
-	0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x10, 0x06, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x45, 0x41,
-	0x44, 0x10, 0x07, 0x12, 0x09, 0x0a, 0x05, 0x54, 0x52, 0x41, 0x43, 0x45, 0x10, 0x08, 0x42, 0x06,
-	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x9c, 0x01, 0x0a, 0x06, 0x53, 0x63, 0x68, 0x65, 0x6d,
-	0x65, 0x12, 0x41, 0x0a, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
-	0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x2e, 0x52, 0x65, 0x67, 0x69,
-	0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
-	0x65, 0x72, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x0c, 0x75, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
-	0x65, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0c, 0x75, 0x6e,
-	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x22, 0x21, 0x0a, 0x0a, 0x52, 0x65,
-	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x54, 0x54, 0x50,
-	0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x48, 0x54, 0x54, 0x50, 0x53, 0x10, 0x01, 0x42, 0x06, 0x0a,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0xa6, 0x01, 0x0a, 0x07, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
-	0x73, 0x12, 0x36, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69,
-	0x7a, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
-	0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x1a, 0x63, 0x0a, 0x06, 0x48, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x5f, 0x73, 0x74, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x53, 0x74, 0x72, 0x12, 0x1d, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f,
-	0x62, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x08, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x42, 0x69, 0x6e, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x64,
-	0x0a, 0x03, 0x45, 0x6f, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x48,
-	0x00, 0x52, 0x0e, 0x67, 0x72, 0x70, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64,
-	0x65, 0x12, 0x2a, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72,
-	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0e, 0x72,
-	0x65, 0x73, 0x65, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x42, 0x05, 0x0a,
-	0x03, 0x65, 0x6e, 0x64, 0x22, 0x20, 0x0a, 0x08, 0x41, 0x70, 0x69, 0x45, 0x72, 0x72, 0x6f, 0x72,
-	0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xa4, 0x02, 0x0a, 0x09, 0x50, 0x6f, 0x64, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x73, 0x12, 0x38, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
-	0x76, 0x69, 0x7a, 0x2e, 0x50, 0x6f, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x50, 0x6f,
-	0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x1a, 0xdc,
-	0x01, 0x0a, 0x08, 0x50, 0x6f, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x4f, 0x0a, 0x09, 0x63,
-	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f,
-	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x50, 0x6f,
-	0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x50, 0x6f, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72,
-	0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48,
-	0x00, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x1a, 0x76, 0x0a, 0x0e,
-	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x18,
-	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74,
-	0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e,
-	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06,
-	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
-	0x61, 0x73, 0x6f, 0x6e, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x50, 0x0a,
-	0x08, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d,
-	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61,
-	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22,
-	0x6e, 0x0a, 0x11, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x32, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
-	0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x08,
-	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x61, 0x62, 0x65,
-	0x6c, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0d, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22,
-	0x59, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72,
-	0x12, 0x32, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69,
-	0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xdf, 0x02, 0x0a, 0x12, 0x53,
-	0x74, 0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x3b, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76,
-	0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x1f,
-	0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12,
-	0x29, 0x0a, 0x04, 0x6e, 0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
-	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x48, 0x00, 0x52, 0x04, 0x6e, 0x6f, 0x6e, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x74, 0x6f,
-	0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+func (x *EgressRow) GetStats() *BasicStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *EgressRow) GetTcpStats() *TcpStats {
+	if x != nil {
+		return x.TcpStats
+	}
+	return nil
+}
+
+func (x *EgressRow) GetTlsRequestCount() uint64 {
+	if x != nil {
+		return x.TlsRequestCount
+	}
+	return 0
+}
+
+type LabelCompatibilityResponse_ProxyVersionReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProxyVersion string `protobuf:"bytes,1,opt,name=proxy_version,json=proxyVersion,proto3" json:"proxy_version,omitempty"`
+	PodCount     uint64 `protobuf:"varint,2,opt,name=pod_count,json=podCount,proto3" json:"pod_count,omitempty"`
+	// labels the metrics-api relies on that are not present in the metrics
+	// reported by proxies of this version
+	MissingLabels []*LabelCompatibilityResponse_MissingLabel `protobuf:"bytes,3,rep,name=missing_labels,json=missingLabels,proto3" json:"missing_labels,omitempty"`
+}
+
+func (x *LabelCompatibilityResponse_ProxyVersionReport) Reset() {
+	*x = LabelCompatibilityResponse_ProxyVersionReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LabelCompatibilityResponse_ProxyVersionReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelCompatibilityResponse_ProxyVersionReport) ProtoMessage() {}
+
+func (x *LabelCompatibilityResponse_ProxyVersionReport) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelCompatibilityResponse_ProxyVersionReport.ProtoReflect.Descriptor instead.
+func (*LabelCompatibilityResponse_ProxyVersionReport) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *LabelCompatibilityResponse_ProxyVersionReport) GetProxyVersion() string {
+	if x != nil {
+		return x.ProxyVersion
+	}
+	return ""
+}
+
+func (x *LabelCompatibilityResponse_ProxyVersionReport) GetPodCount() uint64 {
+	if x != nil {
+		return x.PodCount
+	}
+	return 0
+}
+
+func (x *LabelCompatibilityResponse_ProxyVersionReport) GetMissingLabels() []*LabelCompatibilityResponse_MissingLabel {
+	if x != nil {
+		return x.MissingLabels
+	}
+	return nil
+}
+
+type LabelCompatibilityResponse_MissingLabel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metric string `protobuf:"bytes,1,opt,name=metric,proto3" json:"metric,omitempty"`
+	Label  string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+}
+
+func (x *LabelCompatibilityResponse_MissingLabel) Reset() {
+	*x = LabelCompatibilityResponse_MissingLabel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LabelCompatibilityResponse_MissingLabel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelCompatibilityResponse_MissingLabel) ProtoMessage() {}
+
+func (x *LabelCompatibilityResponse_MissingLabel) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelCompatibilityResponse_MissingLabel.ProtoReflect.Descriptor instead.
+func (*LabelCompatibilityResponse_MissingLabel) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{5, 1}
+}
+
+func (x *LabelCompatibilityResponse_MissingLabel) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *LabelCompatibilityResponse_MissingLabel) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+type Headers_Header struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of a header in a request.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The value of a header in a request. If the value consists entirely of
+	// UTF-8 encodings, `value` will be set; otherwise a binary value is
+	// assumed and `value_bin` will be set.
+	//
+	// Types that are assignable to Value:
+	//	*Headers_Header_ValueStr
+	//	*Headers_Header_ValueBin
+	Value isHeaders_Header_Value `protobuf_oneof:"value"`
+}
+
+func (x *Headers_Header) Reset() {
+	*x = Headers_Header{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Headers_Header) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Headers_Header) ProtoMessage() {}
+
+func (x *Headers_Header) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Headers_Header.ProtoReflect.Descriptor instead.
+func (*Headers_Header) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{14, 0}
+}
+
+func (x *Headers_Header) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (m *Headers_Header) GetValue() isHeaders_Header_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (x *Headers_Header) GetValueStr() string {
+	if x, ok := x.GetValue().(*Headers_Header_ValueStr); ok {
+		return x.ValueStr
+	}
+	return ""
+}
+
+func (x *Headers_Header) GetValueBin() []byte {
+	if x, ok := x.GetValue().(*Headers_Header_ValueBin); ok {
+		return x.ValueBin
+	}
+	return nil
+}
+
+type isHeaders_Header_Value interface {
+	isHeaders_Header_Value()
+}
+
+type Headers_Header_ValueStr struct {
+	ValueStr string `protobuf:"bytes,2,opt,name=value_str,json=valueStr,proto3,oneof"`
+}
+
+type Headers_Header_ValueBin struct {
+	ValueBin []byte `protobuf:"bytes,3,opt,name=value_bin,json=valueBin,proto3,oneof"`
+}
+
+func (*Headers_Header_ValueStr) isHeaders_Header_Value() {}
+
+func (*Headers_Header_ValueBin) isHeaders_Header_Value() {}
+
+type PodErrors_PodError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Error:
+	//	*PodErrors_PodError_Container
+	Error isPodErrors_PodError_Error `protobuf_oneof:"error"`
+}
+
+func (x *PodErrors_PodError) Reset() {
+	*x = PodErrors_PodError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PodErrors_PodError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PodErrors_PodError) ProtoMessage() {}
+
+func (x *PodErrors_PodError) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PodErrors_PodError.ProtoReflect.Descriptor instead.
+func (*PodErrors_PodError) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{17, 0}
+}
+
+func (m *PodErrors_PodError) GetError() isPodErrors_PodError_Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (x *PodErrors_PodError) GetContainer() *PodErrors_PodError_ContainerError {
+	if x, ok := x.GetError().(*PodErrors_PodError_Container); ok {
+		return x.Container
+	}
+	return nil
+}
+
+type isPodErrors_PodError_Error interface {
+	isPodErrors_PodError_Error()
+}
+
+type PodErrors_PodError_Container struct {
+	Container *PodErrors_PodError_ContainerError `protobuf:"bytes,1,opt,name=container,proto3,oneof"`
+}
+
+func (*PodErrors_PodError_Container) isPodErrors_PodError_Error() {}
+
+// To report init-container and container failures
+type PodErrors_PodError_ContainerError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message   string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Container string `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"`
+	Image     string `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	Reason    string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *PodErrors_PodError_ContainerError) Reset() {
+	*x = PodErrors_PodError_ContainerError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PodErrors_PodError_ContainerError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PodErrors_PodError_ContainerError) ProtoMessage() {}
+
+func (x *PodErrors_PodError_ContainerError) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PodErrors_PodError_ContainerError.ProtoReflect.Descriptor instead.
+func (*PodErrors_PodError_ContainerError) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{17, 0, 0}
+}
+
+func (x *PodErrors_PodError_ContainerError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PodErrors_PodError_ContainerError) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *PodErrors_PodError_ContainerError) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *PodErrors_PodError_ContainerError) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type StatSummaryResponse_Ok struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatTables []*StatTable `protobuf:"bytes,1,rep,name=stat_tables,json=statTables,proto3" json:"stat_tables,omitempty"`
+	// warnings about the stats being incomplete, e.g. because the results of
+	// some queries were truncated to the series limit of the metrics-api
+	Warnings []string `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
+}
+
+func (x *StatSummaryResponse_Ok) Reset() {
+	*x = StatSummaryResponse_Ok{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatSummaryResponse_Ok) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatSummaryResponse_Ok) ProtoMessage() {}
+
+func (x *StatSummaryResponse_Ok) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatSummaryResponse_Ok.ProtoReflect.Descriptor instead.
+func (*StatSummaryResponse_Ok) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{23, 0}
+}
+
+func (x *StatSummaryResponse_Ok) GetStatTables() []*StatTable {
+	if x != nil {
+		return x.StatTables
+	}
+	return nil
+}
+
+func (x *StatSummaryResponse_Ok) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type StatTable_PodGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rows []*StatTable_PodGroup_Row `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+}
+
+func (x *StatTable_PodGroup) Reset() {
+	*x = StatTable_PodGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatTable_PodGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatTable_PodGroup) ProtoMessage() {}
+
+func (x *StatTable_PodGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatTable_PodGroup.ProtoReflect.Descriptor instead.
+func (*StatTable_PodGroup) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{31, 0}
+}
+
+func (x *StatTable_PodGroup) GetRows() []*StatTable_PodGroup_Row {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type StatTable_PodGroup_Row struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Resource   *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	TimeWindow string    `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
+	// pod status on Kubernetes
+	Status string `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	// number of pending or running pods in this resource that have linkerd injected
+	MeshedPodCount uint64 `protobuf:"varint,3,opt,name=meshed_pod_count,json=meshedPodCount,proto3" json:"meshed_pod_count,omitempty"`
+	// number of pending or running pods in this resource
+	RunningPodCount uint64 `protobuf:"varint,4,opt,name=running_pod_count,json=runningPodCount,proto3" json:"running_pod_count,omitempty"`
+	// number of pods in this resource that have Phase PodFailed
+	FailedPodCount uint64 `protobuf:"varint,6,opt,name=failed_pod_count,json=failedPodCount,proto3" json:"failed_pod_count,omitempty"`
+	// sum of the container restart counts across the pods in this resource
+	RestartCount uint64 `protobuf:"varint,12,opt,name=restart_count,json=restartCount,proto3" json:"restart_count,omitempty"`
+	// number of containers in this resource whose last termination was an
+	// OOMKill
+	OomKilledCount uint64             `protobuf:"varint,13,opt,name=oom_killed_count,json=oomKilledCount,proto3" json:"oom_killed_count,omitempty"`
+	Stats          *BasicStats        `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty"`
+	TcpStats       *TcpStats          `protobuf:"bytes,8,opt,name=tcp_stats,json=tcpStats,proto3" json:"tcp_stats,omitempty"`
+	TsStats        *TrafficSplitStats `protobuf:"bytes,10,opt,name=ts_stats,json=tsStats,proto3" json:"ts_stats,omitempty"`
+	SrvStats       *ServerStats       `protobuf:"bytes,11,opt,name=srv_stats,json=srvStats,proto3" json:"srv_stats,omitempty"`
+	PolicyStats    *PolicyStats       `protobuf:"bytes,14,opt,name=policy_stats,json=policyStats,proto3" json:"policy_stats,omitempty"`
+	// the Prometheus queries the stats were computed from, when requested
+	Queries []*PromQuery `protobuf:"bytes,15,rep,name=queries,proto3" json:"queries,omitempty"`
+	// the value of the version label of the pods the row is about, when the
+	// stats are broken down by version
+	Version string `protobuf:"bytes,16,opt,name=version,proto3" json:"version,omitempty"`
+	// the values of the labels of the resource the metrics-api is
+	// configured to propagate into the rows, e.g. the team owning it
+	Labels map[string]string `protobuf:"bytes,17,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the evaluation of the success rate against the SLO, when requested
+	// and the resource received requests
+	SloStats *SloStats `protobuf:"bytes,18,opt,name=slo_stats,json=sloStats,proto3" json:"slo_stats,omitempty"`
+	// the port the requests to the authority were sent to, when the stats
+	// are broken down by port
+	Port uint32 `protobuf:"varint,19,opt,name=port,proto3" json:"port,omitempty"`
+	// Stores a set of errors for each pod name. If a pod has no errors, it may be omitted.
+	ErrorsByPod map[string]*PodErrors `protobuf:"bytes,7,rep,name=errors_by_pod,json=errorsByPod,proto3" json:"errors_by_pod,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *StatTable_PodGroup_Row) Reset() {
+	*x = StatTable_PodGroup_Row{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatTable_PodGroup_Row) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatTable_PodGroup_Row) ProtoMessage() {}
+
+func (x *StatTable_PodGroup_Row) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatTable_PodGroup_Row.ProtoReflect.Descriptor instead.
+func (*StatTable_PodGroup_Row) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{31, 0, 0}
+}
+
+func (x *StatTable_PodGroup_Row) GetResource() *Resource {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *StatTable_PodGroup_Row) GetTimeWindow() string {
+	if x != nil {
+		return x.TimeWindow
+	}
+	return ""
+}
+
+func (x *StatTable_PodGroup_Row) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StatTable_PodGroup_Row) GetMeshedPodCount() uint64 {
+	if x != nil {
+		return x.MeshedPodCount
+	}
+	return 0
+}
+
+func (x *StatTable_PodGroup_Row) GetRunningPodCount() uint64 {
+	if x != nil {
+		return x.RunningPodCount
+	}
+	return 0
+}
+
+func (x *StatTable_PodGroup_Row) GetFailedPodCount() uint64 {
+	if x != nil {
+		return x.FailedPodCount
+	}
+	return 0
+}
+
+func (x *StatTable_PodGroup_Row) GetRestartCount() uint64 {
+	if x != nil {
+		return x.RestartCount
+	}
+	return 0
+}
+
+func (x *StatTable_PodGroup_Row) GetOomKilledCount() uint64 {
+	if x != nil {
+		return x.OomKilledCount
+	}
+	return 0
+}
+
+func (x *StatTable_PodGroup_Row) GetStats() *BasicStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *StatTable_PodGroup_Row) GetTcpStats() *TcpStats {
+	if x != nil {
+		return x.TcpStats
+	}
+	return nil
+}
+
+func (x *StatTable_PodGroup_Row) GetTsStats() *TrafficSplitStats {
+	if x != nil {
+		return x.TsStats
+	}
+	return nil
+}
+
+func (x *StatTable_PodGroup_Row) GetSrvStats() *ServerStats {
+	if x != nil {
+		return x.SrvStats
+	}
+	return nil
+}
+
+func (x *StatTable_PodGroup_Row) GetPolicyStats() *PolicyStats {
+	if x != nil {
+		return x.PolicyStats
+	}
+	return nil
+}
+
+func (x *StatTable_PodGroup_Row) GetQueries() []*PromQuery {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+func (x *StatTable_PodGroup_Row) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *StatTable_PodGroup_Row) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *StatTable_PodGroup_Row) GetSloStats() *SloStats {
+	if x != nil {
+		return x.SloStats
+	}
+	return nil
+}
+
+func (x *StatTable_PodGroup_Row) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *StatTable_PodGroup_Row) GetErrorsByPod() map[string]*PodErrors {
+	if x != nil {
+		return x.ErrorsByPod
+	}
+	return nil
+}
+
+type EdgesResponse_Ok struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Edges []*Edge `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
+	// set when there are more edges than the page size; opaque
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *EdgesResponse_Ok) Reset() {
+	*x = EdgesResponse_Ok{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EdgesResponse_Ok) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EdgesResponse_Ok) ProtoMessage() {}
+
+func (x *EdgesResponse_Ok) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EdgesResponse_Ok.ProtoReflect.Descriptor instead.
+func (*EdgesResponse_Ok) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{33, 0}
+}
+
+func (x *EdgesResponse_Ok) GetEdges() []*Edge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *EdgesResponse_Ok) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type DependenciesResponse_Ok struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the resource and, transitively, the resources it sends requests to
+	Upstreams *DependencyNode `protobuf:"bytes,1,opt,name=upstreams,proto3" json:"upstreams,omitempty"`
+	// the resource and, transitively, the resources it receives requests from
+	Downstreams *DependencyNode `protobuf:"bytes,2,opt,name=downstreams,proto3" json:"downstreams,omitempty"`
+}
+
+func (x *DependenciesResponse_Ok) Reset() {
+	*x = DependenciesResponse_Ok{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DependenciesResponse_Ok) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependenciesResponse_Ok) ProtoMessage() {}
+
+func (x *DependenciesResponse_Ok) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependenciesResponse_Ok.ProtoReflect.Descriptor instead.
+func (*DependenciesResponse_Ok) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{36, 0}
+}
+
+func (x *DependenciesResponse_Ok) GetUpstreams() *DependencyNode {
+	if x != nil {
+		return x.Upstreams
+	}
+	return nil
+}
+
+func (x *DependenciesResponse_Ok) GetDownstreams() *DependencyNode {
+	if x != nil {
+		return x.Downstreams
+	}
+	return nil
+}
+
+type TopRoutesResponse_Ok struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Routes []*RouteTable `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`
+}
+
+func (x *TopRoutesResponse_Ok) Reset() {
+	*x = TopRoutesResponse_Ok{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopRoutesResponse_Ok) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopRoutesResponse_Ok) ProtoMessage() {}
+
+func (x *TopRoutesResponse_Ok) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopRoutesResponse_Ok.ProtoReflect.Descriptor instead.
+func (*TopRoutesResponse_Ok) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{39, 0}
+}
+
+func (x *TopRoutesResponse_Ok) GetRoutes() []*RouteTable {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
+type RouteTable_Row struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Route      string      `protobuf:"bytes,1,opt,name=route,proto3" json:"route,omitempty"`
+	TimeWindow string      `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
+	Authority  string      `protobuf:"bytes,6,opt,name=authority,proto3" json:"authority,omitempty"`
+	Stats      *BasicStats `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty"`
+	// the success and failure counts of the route over each time window of
+	// the trend, the oldest first, if trend points were requested
+	Trend []*BasicStats `protobuf:"bytes,7,rep,name=trend,proto3" json:"trend,omitempty"`
+}
+
+func (x *RouteTable_Row) Reset() {
+	*x = RouteTable_Row{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[71]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RouteTable_Row) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteTable_Row) ProtoMessage() {}
+
+func (x *RouteTable_Row) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[71]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteTable_Row.ProtoReflect.Descriptor instead.
+func (*RouteTable_Row) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{40, 0}
+}
+
+func (x *RouteTable_Row) GetRoute() string {
+	if x != nil {
+		return x.Route
+	}
+	return ""
+}
+
+func (x *RouteTable_Row) GetTimeWindow() string {
+	if x != nil {
+		return x.TimeWindow
+	}
+	return ""
+}
+
+func (x *RouteTable_Row) GetAuthority() string {
+	if x != nil {
+		return x.Authority
+	}
+	return ""
+}
+
+func (x *RouteTable_Row) GetStats() *BasicStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *RouteTable_Row) GetTrend() []*BasicStats {
+	if x != nil {
+		return x.Trend
+	}
+	return nil
+}
+
+type GatewaysTable_Row struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Namespace      string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name           string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ClusterName    string `protobuf:"bytes,3,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	PairedServices uint64 `protobuf:"varint,4,opt,name=paired_services,json=pairedServices,proto3" json:"paired_services,omitempty"`
+	Alive          bool   `protobuf:"varint,5,opt,name=alive,proto3" json:"alive,omitempty"`
+	LatencyMsP50   uint64 `protobuf:"varint,6,opt,name=latency_ms_p50,json=latencyMsP50,proto3" json:"latency_ms_p50,omitempty"`
+	LatencyMsP95   uint64 `protobuf:"varint,7,opt,name=latency_ms_p95,json=latencyMsP95,proto3" json:"latency_ms_p95,omitempty"`
+	LatencyMsP99   uint64 `protobuf:"varint,8,opt,name=latency_ms_p99,json=latencyMsP99,proto3" json:"latency_ms_p99,omitempty"`
+}
+
+func (x *GatewaysTable_Row) Reset() {
+	*x = GatewaysTable_Row{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[72]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GatewaysTable_Row) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GatewaysTable_Row) ProtoMessage() {}
+
+func (x *GatewaysTable_Row) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[72]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GatewaysTable_Row.ProtoReflect.Descriptor instead.
+func (*GatewaysTable_Row) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{41, 0}
+}
+
+func (x *GatewaysTable_Row) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *GatewaysTable_Row) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GatewaysTable_Row) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *GatewaysTable_Row) GetPairedServices() uint64 {
+	if x != nil {
+		return x.PairedServices
+	}
+	return 0
+}
+
+func (x *GatewaysTable_Row) GetAlive() bool {
+	if x != nil {
+		return x.Alive
+	}
+	return false
+}
+
+func (x *GatewaysTable_Row) GetLatencyMsP50() uint64 {
+	if x != nil {
+		return x.LatencyMsP50
+	}
+	return 0
+}
+
+func (x *GatewaysTable_Row) GetLatencyMsP95() uint64 {
+	if x != nil {
+		return x.LatencyMsP95
+	}
+	return 0
+}
+
+func (x *GatewaysTable_Row) GetLatencyMsP99() uint64 {
+	if x != nil {
+		return x.LatencyMsP99
+	}
+	return 0
+}
+
+type GatewaysResponse_Ok struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GatewaysTable *GatewaysTable `protobuf:"bytes,1,opt,name=gateways_table,json=gatewaysTable,proto3" json:"gateways_table,omitempty"`
+}
+
+func (x *GatewaysResponse_Ok) Reset() {
+	*x = GatewaysResponse_Ok{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[73]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GatewaysResponse_Ok) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GatewaysResponse_Ok) ProtoMessage() {}
+
+func (x *GatewaysResponse_Ok) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[73]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GatewaysResponse_Ok.ProtoReflect.Descriptor instead.
+func (*GatewaysResponse_Ok) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{43, 0}
+}
+
+func (x *GatewaysResponse_Ok) GetGatewaysTable() *GatewaysTable {
+	if x != nil {
+		return x.GatewaysTable
+	}
+	return nil
+}
+
+type IngressStatsResponse_Ok struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rows []*IngressStatsRow `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+}
+
+func (x *IngressStatsResponse_Ok) Reset() {
+	*x = IngressStatsResponse_Ok{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[74]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IngressStatsResponse_Ok) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngressStatsResponse_Ok) ProtoMessage() {}
+
+func (x *IngressStatsResponse_Ok) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[74]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngressStatsResponse_Ok.ProtoReflect.Descriptor instead.
+func (*IngressStatsResponse_Ok) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{45, 0}
+}
+
+func (x *IngressStatsResponse_Ok) GetRows() []*IngressStatsRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type IngressResourceStatsResponse_Ok struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rows []*IngressResourceStatsRow `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+}
+
+func (x *IngressResourceStatsResponse_Ok) Reset() {
+	*x = IngressResourceStatsResponse_Ok{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[75]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IngressResourceStatsResponse_Ok) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngressResourceStatsResponse_Ok) ProtoMessage() {}
+
+func (x *IngressResourceStatsResponse_Ok) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[75]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngressResourceStatsResponse_Ok.ProtoReflect.Descriptor instead.
+func (*IngressResourceStatsResponse_Ok) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{48, 0}
+}
+
+func (x *IngressResourceStatsResponse_Ok) GetRows() []*IngressResourceStatsRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type AuthzResponse_Ok struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the Server the request is sent to
+	Server  *Resource `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	Allowed bool      `protobuf:"varint,2,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	// the ServerAuthorization allowing the request, unset if it's denied
+	Authorization *Resource `protobuf:"bytes,3,opt,name=authorization,proto3" json:"authorization,omitempty"`
+}
+
+func (x *AuthzResponse_Ok) Reset() {
+	*x = AuthzResponse_Ok{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[76]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuthzResponse_Ok) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthzResponse_Ok) ProtoMessage() {}
+
+func (x *AuthzResponse_Ok) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[76]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthzResponse_Ok.ProtoReflect.Descriptor instead.
+func (*AuthzResponse_Ok) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{51, 0}
+}
+
+func (x *AuthzResponse_Ok) GetServer() *Resource {
+	if x != nil {
+		return x.Server
+	}
+	return nil
+}
+
+func (x *AuthzResponse_Ok) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *AuthzResponse_Ok) GetAuthorization() *Resource {
+	if x != nil {
+		return x.Authorization
+	}
+	return nil
+}
+
+type LatencyHeatmapResponse_Ok struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the upper bounds of the latency buckets in milliseconds, in increasing
+	// order, as given by the le label of the histogram, e.g. "10" or "+Inf"
+	Buckets []string                `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	Columns []*LatencyHeatmapColumn `protobuf:"bytes,2,rep,name=columns,proto3" json:"columns,omitempty"`
+}
+
+func (x *LatencyHeatmapResponse_Ok) Reset() {
+	*x = LatencyHeatmapResponse_Ok{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[77]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LatencyHeatmapResponse_Ok) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatencyHeatmapResponse_Ok) ProtoMessage() {}
+
+func (x *LatencyHeatmapResponse_Ok) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[77]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatencyHeatmapResponse_Ok.ProtoReflect.Descriptor instead.
+func (*LatencyHeatmapResponse_Ok) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{53, 0}
+}
+
+func (x *LatencyHeatmapResponse_Ok) GetBuckets() []string {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *LatencyHeatmapResponse_Ok) GetColumns() []*LatencyHeatmapColumn {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+type EgressResponse_Ok struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rows []*EgressRow `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+}
+
+func (x *EgressResponse_Ok) Reset() {
+	*x = EgressResponse_Ok{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_proto_msgTypes[78]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EgressResponse_Ok) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EgressResponse_Ok) ProtoMessage() {}
+
+func (x *EgressResponse_Ok) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_proto_msgTypes[78]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EgressResponse_Ok.ProtoReflect.Descriptor instead.
+func (*EgressResponse_Ok) Descriptor() ([]byte, []int) {
+	return file_viz_proto_rawDescGZIP(), []int{56, 0}
+}
+
+func (x *EgressResponse_Ok) GetRows() []*EgressRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+var File_viz_proto protoreflect.FileDescriptor
+
+var file_viz_proto_rawDesc = []byte{
+	0x0a, 0x09, 0x76, 0x69, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x6c, 0x69, 0x6e,
+	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0xc8, 0x01, 0x0a, 0x0b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x53, 0x75, 0x62, 0x73, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x10, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
+	0x76, 0x69, 0x7a, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x34, 0x0a, 0x15, 0x46, 0x72, 0x69, 0x65, 0x6e,
+	0x64, 0x6c, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x55, 0x73, 0x65, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x6c, 0x79,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x55, 0x73, 0x65, 0x72, 0x22, 0x12, 0x0a,
+	0x10, 0x53, 0x65, 0x6c, 0x66, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x48, 0x0a, 0x11, 0x53, 0x65, 0x6c, 0x66, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
+	0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x1b, 0x0a, 0x19, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xad, 0x03, 0x0a, 0x1a, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
+	0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x43, 0x6f, 0x6d,
+	0x70, 0x61, 0x74, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x43,
+	0x0a, 0x10, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x4c, 0x61, 0x73, 0x74, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x1a, 0xb4, 0x01, 0x0a, 0x12, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72,
+	0x6f, 0x78, 0x79, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x1b, 0x0a, 0x09, 0x70, 0x6f, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x70, 0x6f, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x5c, 0x0a, 0x0e,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
+	0x76, 0x69, 0x7a, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x69,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4d,
+	0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x0d, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6e, 0x67, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a, 0x3c, 0x0a, 0x0c, 0x4d, 0x69,
+	0x73, 0x73, 0x69, 0x6e, 0x67, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x22, 0x33, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x49, 0x0a,
+	0x14, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
+	0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x08,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x22, 0x3b, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x70, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x64,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52,
+	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x3b, 0x0a, 0x08, 0x73, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x39, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x50,
+	0x6f, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x70,
+	0x6f, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x50, 0x6f, 0x64, 0x52, 0x04, 0x70, 0x6f,
+	0x64, 0x73, 0x22, 0xfa, 0x04, 0x0a, 0x03, 0x50, 0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x70, 0x6f, 0x64, 0x49, 0x50, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70,
+	0x6f, 0x64, 0x49, 0x50, 0x12, 0x20, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x72,
+	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x53, 0x65, 0x74, 0x12, 0x37, 0x0a, 0x16, 0x72, 0x65, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x6c, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x15, 0x72, 0x65, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c,
+	0x65, 0x72, 0x12, 0x23, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x65, 0x66, 0x75, 0x6c, 0x5f, 0x73,
+	0x65, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x66, 0x75, 0x6c, 0x53, 0x65, 0x74, 0x12, 0x1f, 0x0a, 0x0a, 0x64, 0x61, 0x65, 0x6d, 0x6f,
+	0x6e, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x64,
+	0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x53, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x12, 0x43, 0x0a, 0x0f, 0x73, 0x69,
+	0x6e, 0x63, 0x65, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f,
+	0x73, 0x69, 0x6e, 0x63, 0x65, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12,
+	0x30, 0x0a, 0x13, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x50, 0x6c, 0x61, 0x6e,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x50, 0x6c, 0x61, 0x6e, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x78,
+	0x79, 0x52, 0x65, 0x61, 0x64, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x70, 0x72,
+	0x6f, 0x78, 0x79, 0x52, 0x65, 0x61, 0x64, 0x79, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x78,
+	0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x70, 0x72, 0x6f, 0x78, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x0f,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x07, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x22,
+	0xf1, 0x01, 0x0a, 0x0a, 0x48, 0x74, 0x74, 0x70, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x45,
+	0x0a, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x23, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69,
+	0x7a, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x2e, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x0c, 0x75, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0c, 0x75,
+	0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x22, 0x6e, 0x0a, 0x0a, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x45, 0x54,
+	0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x4f, 0x53, 0x54, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03,
+	0x50, 0x55, 0x54, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10,
+	0x03, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x41, 0x54, 0x43, 0x48, 0x10, 0x04, 0x12, 0x0b, 0x0a, 0x07,
+	0x4f, 0x50, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x05, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4f, 0x4e,
+	0x4e, 0x45, 0x43, 0x54, 0x10, 0x06, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x45, 0x41, 0x44, 0x10, 0x07,
+	0x12, 0x09, 0x0a, 0x05, 0x54, 0x52, 0x41, 0x43, 0x45, 0x10, 0x08, 0x42, 0x06, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x22, 0x9c, 0x01, 0x0a, 0x06, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x41,
+	0x0a, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69,
+	0x7a, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
+	0x72, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65,
+	0x64, 0x12, 0x24, 0x0a, 0x0c, 0x75, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0c, 0x75, 0x6e, 0x72, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x22, 0x21, 0x0a, 0x0a, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x54, 0x54, 0x50, 0x10, 0x00, 0x12,
+	0x09, 0x0a, 0x05, 0x48, 0x54, 0x54, 0x50, 0x53, 0x10, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x22, 0xa6, 0x01, 0x0a, 0x07, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x36,
+	0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x07, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x1a, 0x63, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x73, 0x74,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x53, 0x74, 0x72, 0x12, 0x1d, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x62, 0x69, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x08, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42,
+	0x69, 0x6e, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x64, 0x0a, 0x03, 0x45,
+	0x6f, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0e,
+	0x67, 0x72, 0x70, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x2a,
+	0x0a, 0x10, 0x72, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0e, 0x72, 0x65, 0x73, 0x65,
+	0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x42, 0x05, 0x0a, 0x03, 0x65, 0x6e,
+	0x64, 0x22, 0x20, 0x0a, 0x08, 0x41, 0x70, 0x69, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x22, 0xa4, 0x02, 0x0a, 0x09, 0x50, 0x6f, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x73, 0x12, 0x38, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a,
+	0x2e, 0x50, 0x6f, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x50, 0x6f, 0x64, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x1a, 0xdc, 0x01, 0x0a, 0x08,
+	0x50, 0x6f, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x4f, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6c, 0x69,
+	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x50, 0x6f, 0x64, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x73, 0x2e, 0x50, 0x6f, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x2e, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x09,
+	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x1a, 0x76, 0x0a, 0x0e, 0x43, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x50, 0x0a, 0x08, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x6e, 0x0a, 0x11,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x32, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76,
+	0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x73,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x59, 0x0a, 0x0d,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x32, 0x0a,
+	0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
 	0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52,
-	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x74, 0x6f, 0x52, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x0d, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x72, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x88, 0x06, 0x0a, 0x12, 0x53, 0x74, 0x61, 0x74,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3b,
+	0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x74,
+	0x69, 0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x29, 0x0a, 0x04,
+	0x6e, 0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x48,
+	0x00, 0x52, 0x04, 0x6e, 0x6f, 0x6e, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x74, 0x6f, 0x5f, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c,
 	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x52, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x73, 0x74, 0x61,
-	0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x6b, 0x69, 0x70, 0x53, 0x74,
-	0x61, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x63, 0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x74, 0x63, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73,
-	0x42, 0x0a, 0x0a, 0x08, 0x6f, 0x75, 0x74, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0xce, 0x01, 0x0a,
-	0x13, 0x53, 0x74, 0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x24, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e,
-	0x53, 0x74, 0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x2e, 0x4f, 0x6b, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x33, 0x0a, 0x05,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x69,
-	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x1a, 0x3e, 0x0a, 0x02, 0x4f, 0x6b, 0x12, 0x38, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x5f,
-	0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c,
+	0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x12, 0x3d, 0x0a, 0x0d, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x48, 0x00, 0x52, 0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x6b, 0x69, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x1b, 0x0a, 0x09, 0x74, 0x63, 0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x74, 0x63, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x71, 0x75, 0x65, 0x72,
+	0x69, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6f, 0x64, 0x73, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x50, 0x6f,
+	0x64, 0x73, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x66,
+	0x72, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x12, 0x2c, 0x0a, 0x03, 0x73, 0x6c, 0x6f, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x6c, 0x6f,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x52, 0x03, 0x73, 0x6c, 0x6f, 0x12, 0x17,
+	0x0a, 0x07, 0x62, 0x79, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x62, 0x79, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x4c, 0x0a, 0x0b, 0x6d, 0x65, 0x73, 0x68, 0x5f,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x6c,
 	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x74, 0x61, 0x74,
-	0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65,
-	0x73, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xac, 0x02,
-	0x0a, 0x0a, 0x42, 0x61, 0x73, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d,
-	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x0c, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72,
-	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63,
-	0x79, 0x5f, 0x6d, 0x73, 0x5f, 0x70, 0x35, 0x30, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c,
-	0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x50, 0x35, 0x30, 0x12, 0x24, 0x0a, 0x0e,
-	0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x5f, 0x70, 0x39, 0x35, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x50,
-	0x39, 0x35, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73,
-	0x5f, 0x70, 0x39, 0x39, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x61, 0x74, 0x65,
-	0x6e, 0x63, 0x79, 0x4d, 0x73, 0x50, 0x39, 0x39, 0x12, 0x30, 0x0a, 0x14, 0x61, 0x63, 0x74, 0x75,
-	0x61, 0x6c, 0x5f, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x53, 0x75,
-	0x63, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x61, 0x63,
-	0x74, 0x75, 0x61, 0x6c, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c,
-	0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x8b, 0x01, 0x0a,
-	0x08, 0x54, 0x63, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x6f, 0x70, 0x65,
-	0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x0f, 0x6f, 0x70, 0x65, 0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x62, 0x79, 0x74,
-	0x65, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e,
-	0x72, 0x65, 0x61, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x2a,
-	0x0a, 0x11, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x74, 0x6f,
-	0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x77, 0x72, 0x69, 0x74, 0x65,
-	0x42, 0x79, 0x74, 0x65, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x53, 0x0a, 0x11, 0x54, 0x72,
-	0x61, 0x66, 0x66, 0x69, 0x63, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
-	0x12, 0x0a, 0x04, 0x61, 0x70, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61,
-	0x70, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x65, 0x61, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x6c, 0x65, 0x61, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68,
-	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22,
-	0x55, 0x0a, 0x0b, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x23,
-	0x0a, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65, 0x6e, 0x69, 0x65, 0x64, 0x5f, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x64, 0x65, 0x6e, 0x69, 0x65,
-	0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x9e, 0x06, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x74, 0x54,
-	0x61, 0x62, 0x6c, 0x65, 0x12, 0x3f, 0x0a, 0x09, 0x70, 0x6f, 0x64, 0x5f, 0x67, 0x72, 0x6f, 0x75,
-	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
-	0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65,
-	0x2e, 0x50, 0x6f, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x48, 0x00, 0x52, 0x08, 0x70, 0x6f, 0x64,
-	0x47, 0x72, 0x6f, 0x75, 0x70, 0x1a, 0xc6, 0x05, 0x0a, 0x08, 0x50, 0x6f, 0x64, 0x47, 0x72, 0x6f,
-	0x75, 0x70, 0x12, 0x38, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x24, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e,
-	0x53, 0x74, 0x61, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x50, 0x6f, 0x64, 0x47, 0x72, 0x6f,
-	0x75, 0x70, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x1a, 0xff, 0x04, 0x0a,
-	0x03, 0x52, 0x6f, 0x77, 0x12, 0x32, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
-	0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x08,
-	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65,
-	0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74,
-	0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x65, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x70, 0x6f, 0x64, 0x5f,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6d, 0x65, 0x73,
-	0x68, 0x65, 0x64, 0x50, 0x6f, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x72,
-	0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x6f, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x50,
-	0x6f, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x66, 0x61, 0x69, 0x6c, 0x65,
-	0x64, 0x5f, 0x70, 0x6f, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x0e, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x50, 0x6f, 0x64, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x12, 0x2e, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x18, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e,
-	0x42, 0x61, 0x73, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74,
-	0x73, 0x12, 0x33, 0x0a, 0x09, 0x74, 0x63, 0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
-	0x76, 0x69, 0x7a, 0x2e, 0x54, 0x63, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x08, 0x74, 0x63,
-	0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x3a, 0x0a, 0x08, 0x74, 0x73, 0x5f, 0x73, 0x74, 0x61,
-	0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
-	0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x53,
-	0x70, 0x6c, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x07, 0x74, 0x73, 0x53, 0x74, 0x61,
-	0x74, 0x73, 0x12, 0x36, 0x0a, 0x09, 0x73, 0x72, 0x76, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18,
-	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
-	0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73,
-	0x52, 0x08, 0x73, 0x72, 0x76, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x59, 0x0a, 0x0d, 0x65, 0x72,
-	0x72, 0x6f, 0x72, 0x73, 0x5f, 0x62, 0x79, 0x5f, 0x70, 0x6f, 0x64, 0x18, 0x07, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x35, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a,
-	0x2e, 0x53, 0x74, 0x61, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x50, 0x6f, 0x64, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x2e, 0x52, 0x6f, 0x77, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x42, 0x79,
-	0x50, 0x6f, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73,
-	0x42, 0x79, 0x50, 0x6f, 0x64, 0x1a, 0x57, 0x0a, 0x10, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x42,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d,
+	0x65, 0x73, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0a, 0x6d, 0x65, 0x73, 0x68, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x2f, 0x0a, 0x0a, 0x4d, 0x65, 0x73, 0x68, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06,
+	0x4d, 0x45, 0x53, 0x48, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x55, 0x4e, 0x4d, 0x45,
+	0x53, 0x48, 0x45, 0x44, 0x10, 0x02, 0x42, 0x0a, 0x0a, 0x08, 0x6f, 0x75, 0x74, 0x62, 0x6f, 0x75,
+	0x6e, 0x64, 0x22, 0x3e, 0x0a, 0x0c, 0x53, 0x6c, 0x6f, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x69,
+	0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64,
+	0x6f, 0x77, 0x22, 0xea, 0x01, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x02, 0x6f, 0x6b,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
+	0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4f, 0x6b, 0x48, 0x00, 0x52, 0x02,
+	0x6f, 0x6b, 0x12, 0x33, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a,
+	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x1a, 0x5a, 0x0a, 0x02, 0x4f, 0x6b, 0x12, 0x38, 0x0a,
+	0x0b, 0x73, 0x74, 0x61, 0x74, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69,
+	0x7a, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x0a, 0x73, 0x74, 0x61,
+	0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69,
+	0x6e, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69,
+	0x6e, 0x67, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0xac, 0x02, 0x0a, 0x0a, 0x42, 0x61, 0x73, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x23,
+	0x0a, 0x0d, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x66, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x5f, 0x70, 0x35, 0x30, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x50, 0x35, 0x30, 0x12, 0x24,
+	0x0a, 0x0e, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x5f, 0x70, 0x39, 0x35,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d,
+	0x73, 0x50, 0x39, 0x35, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f,
+	0x6d, 0x73, 0x5f, 0x70, 0x39, 0x39, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x61,
+	0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x50, 0x39, 0x39, 0x12, 0x30, 0x0a, 0x14, 0x61, 0x63,
+	0x74, 0x75, 0x61, 0x6c, 0x5f, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c,
+	0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x14,
+	0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x61, 0x63, 0x74, 0x75,
+	0x61, 0x6c, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x82,
+	0x01, 0x0a, 0x08, 0x53, 0x6c, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x1b, 0x0a, 0x09, 0x62,
+	0x75, 0x72, 0x6e, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08,
+	0x62, 0x75, 0x72, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x62, 0x75, 0x64, 0x67,
+	0x65, 0x74, 0x5f, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0f, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e,
+	0x69, 0x6e, 0x67, 0x22, 0x8b, 0x01, 0x0a, 0x08, 0x54, 0x63, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x29, 0x0a, 0x10, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6f, 0x70, 0x65, 0x6e,
+	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x72,
+	0x65, 0x61, 0x64, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x72, 0x65, 0x61, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x2a, 0x0a, 0x11, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x54, 0x6f, 0x74, 0x61,
+	0x6c, 0x22, 0x53, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x53, 0x70, 0x6c, 0x69,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x70, 0x65, 0x78, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x70, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x65,
+	0x61, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x65, 0x61, 0x66, 0x12, 0x16,
+	0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x55, 0x0a, 0x0b, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65,
+	0x6e, 0x69, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0b, 0x64, 0x65, 0x6e, 0x69, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x7f, 0x0a,
+	0x0b, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x33, 0x0a, 0x15, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x14, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x41, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x64,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x64, 0x65, 0x6e, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0b, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x44, 0x65, 0x6e, 0x79, 0x22, 0x5e,
+	0x0a, 0x09, 0x50, 0x72, 0x6f, 0x6d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x65, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x22, 0xc6,
+	0x09, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x3f, 0x0a, 0x09,
+	0x70, 0x6f, 0x64, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x50, 0x6f, 0x64, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x48, 0x00, 0x52, 0x08, 0x70, 0x6f, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x1a, 0xee, 0x08,
+	0x0a, 0x08, 0x50, 0x6f, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x38, 0x0a, 0x04, 0x72, 0x6f,
+	0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
+	0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x54, 0x61, 0x62, 0x6c,
+	0x65, 0x2e, 0x50, 0x6f, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x04,
+	0x72, 0x6f, 0x77, 0x73, 0x1a, 0xa7, 0x08, 0x0a, 0x03, 0x52, 0x6f, 0x77, 0x12, 0x32, 0x0a, 0x08,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f,
+	0x77, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x65, 0x73,
+	0x68, 0x65, 0x64, 0x5f, 0x70, 0x6f, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0e, 0x6d, 0x65, 0x73, 0x68, 0x65, 0x64, 0x50, 0x6f, 0x64, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x70,
+	0x6f, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f,
+	0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x28, 0x0a, 0x10, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x6f, 0x64, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x66, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x50, 0x6f, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0c, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x28,
+	0x0a, 0x10, 0x6f, 0x6f, 0x6d, 0x5f, 0x6b, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6f, 0x6f, 0x6d, 0x4b, 0x69, 0x6c,
+	0x6c, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
+	0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x42, 0x61, 0x73, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x12, 0x33, 0x0a, 0x09, 0x74, 0x63, 0x70, 0x5f,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69,
+	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x54, 0x63, 0x70, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x08, 0x74, 0x63, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x3a, 0x0a,
+	0x08, 0x74, 0x73, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x54,
+	0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x07, 0x74, 0x73, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x36, 0x0a, 0x09, 0x73, 0x72, 0x76,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x08, 0x73, 0x72, 0x76, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x12, 0x3c, 0x0a, 0x0c, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
+	0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x0b, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x31, 0x0a, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e,
+	0x50, 0x72, 0x6f, 0x6d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69,
+	0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x10, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x48, 0x0a, 0x06,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x54, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x50, 0x6f, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x2e, 0x52,
+	0x6f, 0x77, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x33, 0x0a, 0x09, 0x73, 0x6c, 0x6f, 0x5f, 0x73, 0x74,
+	0x61, 0x74, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x6c, 0x6f, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x08, 0x73, 0x6c, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x6f, 0x72, 0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12,
+	0x59, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x5f, 0x62, 0x79, 0x5f, 0x70, 0x6f, 0x64,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
+	0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x2e,
+	0x50, 0x6f, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x2e, 0x52, 0x6f, 0x77, 0x2e, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x73, 0x42, 0x79, 0x50, 0x6f, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x73, 0x42, 0x79, 0x50, 0x6f, 0x64, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x57, 0x0a, 0x10, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x42,
 	0x79, 0x50, 0x6f, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2d, 0x0a, 0x05, 0x76,
 	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c, 0x69, 0x6e,
 	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x50, 0x6f, 0x64, 0x45, 0x72, 0x72,
 	0x6f, 0x72, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x07,
-	0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x4b, 0x0a, 0x0c, 0x45, 0x64, 0x67, 0x65, 0x73,
+	0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x87, 0x01, 0x0a, 0x0c, 0x45, 0x64, 0x67, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x22, 0xda, 0x01, 0x0a, 0x0d, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x45,
+	0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4f, 0x6b, 0x48,
+	0x00, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x33, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
+	0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x1a, 0x56, 0x0a, 0x02, 0x4f, 0x6b,
+	0x12, 0x28, 0x0a, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x45,
+	0x64, 0x67, 0x65, 0x52, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65,
+	0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xbc,
+	0x01, 0x0a, 0x04, 0x45, 0x64, 0x67, 0x65, 0x12, 0x28, 0x0a, 0x03, 0x73, 0x72, 0x63, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
+	0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x03, 0x73, 0x72,
+	0x63, 0x12, 0x28, 0x0a, 0x03, 0x64, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x03, 0x64, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x6f, 0x5f, 0x69, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x6e, 0x6f, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x4d, 0x73, 0x67, 0x22, 0x87, 0x01,
+	0x0a, 0x13, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
+	0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52,
+	0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d,
+	0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61,
+	0x78, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d,
+	0x61, 0x78, 0x44, 0x65, 0x70, 0x74, 0x68, 0x22, 0x93, 0x02, 0x0a, 0x14, 0x44, 0x65, 0x70, 0x65,
+	0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x37, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x44, 0x65, 0x70, 0x65,
+	0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x4f, 0x6b, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x33, 0x0a, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
+	0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x1a, 0x80,
+	0x01, 0x0a, 0x02, 0x4f, 0x6b, 0x12, 0x3a, 0x0a, 0x09, 0x75, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
+	0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e,
+	0x63, 0x79, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x75, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x73, 0x12, 0x3e, 0x0a, 0x0b, 0x64, 0x6f, 0x77, 0x6e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
+	0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79,
+	0x4e, 0x6f, 0x64, 0x65, 0x52, 0x0b, 0x64, 0x6f, 0x77, 0x6e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x73, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xe2, 0x01,
+	0x0a, 0x0e, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x4e, 0x6f, 0x64, 0x65,
+	0x12, 0x32, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69,
+	0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x12, 0x2e, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76,
+	0x69, 0x7a, 0x2e, 0x42, 0x61, 0x73, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72,
+	0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74,
+	0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x38, 0x0a, 0x08, 0x63, 0x68, 0x69, 0x6c,
+	0x64, 0x72, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64,
+	0x65, 0x6e, 0x63, 0x79, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72,
+	0x65, 0x6e, 0x22, 0x85, 0x02, 0x0a, 0x10, 0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63,
 	0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
 	0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
 	0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65,
-	0x63, 0x74, 0x6f, 0x72, 0x22, 0xb2, 0x01, 0x0a, 0x0d, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69,
-	0x7a, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
-	0x4f, 0x6b, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x33, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
-	0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45,
-	0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x1a, 0x2e, 0x0a,
-	0x02, 0x4f, 0x6b, 0x12, 0x28, 0x0a, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69,
-	0x7a, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x52, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x42, 0x0a, 0x0a,
-	0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xbc, 0x01, 0x0a, 0x04, 0x45, 0x64,
-	0x67, 0x65, 0x12, 0x28, 0x0a, 0x03, 0x73, 0x72, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52,
-	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x03, 0x73, 0x72, 0x63, 0x12, 0x28, 0x0a, 0x03,
-	0x64, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x63, 0x74, 0x6f, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e,
+	0x64, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57,
+	0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x29, 0x0a, 0x04, 0x6e, 0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76,
+	0x69, 0x7a, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x48, 0x00, 0x52, 0x04, 0x6e, 0x6f, 0x6e, 0x65,
+	0x12, 0x39, 0x0a, 0x0b, 0x74, 0x6f, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
+	0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52,
+	0x0a, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x74,
+	0x72, 0x65, 0x6e, 0x64, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0b, 0x74, 0x72, 0x65, 0x6e, 0x64, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x42, 0x0a,
+	0x0a, 0x08, 0x6f, 0x75, 0x74, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0xc2, 0x01, 0x0a, 0x11, 0x54,
+	0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x33, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x34, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a,
+	0x2e, 0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x2e, 0x4f, 0x6b, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x6b, 0x1a, 0x36, 0x0a, 0x02, 0x4f,
+	0x6b, 0x12, 0x30, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a,
+	0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x06, 0x72, 0x6f, 0x75,
+	0x74, 0x65, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x97, 0x02, 0x0a, 0x0a, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x30,
+	0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x6f, 0x75, 0x74,
+	0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a, 0xba, 0x01, 0x0a,
+	0x03, 0x52, 0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69,
+	0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x1c, 0x0a, 0x09, 0x61,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x2e, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
+	0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x42, 0x61, 0x73, 0x69, 0x63, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x05, 0x74, 0x72, 0x65,
+	0x6e, 0x64, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
+	0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x42, 0x61, 0x73, 0x69, 0x63, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x05, 0x74, 0x72, 0x65, 0x6e, 0x64, 0x22, 0xd2, 0x02, 0x0a, 0x0d, 0x47, 0x61,
+	0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x33, 0x0a, 0x04, 0x72,
+	0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x73, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73,
+	0x1a, 0x8b, 0x02, 0x0a, 0x03, 0x52, 0x6f, 0x77, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6c,
+	0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a,
+	0x0f, 0x70, 0x61, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x70, 0x61, 0x69, 0x72, 0x65, 0x64, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x24, 0x0a, 0x0e,
+	0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x5f, 0x70, 0x35, 0x30, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x50,
+	0x35, 0x30, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73,
+	0x5f, 0x70, 0x39, 0x35, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x4d, 0x73, 0x50, 0x39, 0x35, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x5f, 0x70, 0x39, 0x39, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x50, 0x39, 0x39, 0x22, 0x8f,
+	0x01, 0x0a, 0x0f, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x6c, 0x75,
+	0x73, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x11, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x67,
+	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12,
+	0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77,
+	0x22, 0xd2, 0x01, 0x0a, 0x10, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a,
+	0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x4f, 0x6b, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x33, 0x0a, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
 	0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x52, 0x03, 0x64, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
-	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e,
-	0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64,
-	0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x6f, 0x5f, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f,
-	0x6d, 0x73, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x6f, 0x49, 0x64, 0x65,
-	0x6e, 0x74, 0x69, 0x74, 0x79, 0x4d, 0x73, 0x67, 0x22, 0xe2, 0x01, 0x0a, 0x10, 0x54, 0x6f, 0x70,
-	0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a,
-	0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52,
-	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69,
+	0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x1a,
+	0x48, 0x0a, 0x02, 0x4f, 0x6b, 0x12, 0x42, 0x0a, 0x0e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x73, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x47, 0x61, 0x74,
+	0x65, 0x77, 0x61, 0x79, 0x73, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x0d, 0x67, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x73, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x54, 0x0a, 0x13, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69,
 	0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x29, 0x0a, 0x04, 0x6e,
-	0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
-	0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x48, 0x00,
-	0x52, 0x04, 0x6e, 0x6f, 0x6e, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x74, 0x6f, 0x5f, 0x72, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69,
-	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x42, 0x0a, 0x0a, 0x08, 0x6f, 0x75, 0x74, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0xc2, 0x01,
-	0x0a, 0x11, 0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69,
-	0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48,
-	0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x34, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
-	0x76, 0x69, 0x7a, 0x2e, 0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4f, 0x6b, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x6b, 0x1a, 0x36,
-	0x0a, 0x02, 0x4f, 0x6b, 0x12, 0x30, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
-	0x76, 0x69, 0x7a, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x06,
-	0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0xe7, 0x01, 0x0a, 0x0a, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c,
-	0x65, 0x12, 0x30, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x1c, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52,
-	0x6f, 0x75, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72,
-	0x6f, 0x77, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a,
-	0x8a, 0x01, 0x0a, 0x03, 0x52, 0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x1f, 0x0a,
-	0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x1c,
-	0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x2e, 0x0a, 0x05,
-	0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x69,
-	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x42, 0x61, 0x73, 0x69, 0x63,
-	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0xd2, 0x02, 0x0a,
-	0x0d, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x33,
-	0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6c,
-	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x47, 0x61, 0x74, 0x65,
-	0x77, 0x61, 0x79, 0x73, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72,
-	0x6f, 0x77, 0x73, 0x1a, 0x8b, 0x02, 0x0a, 0x03, 0x52, 0x6f, 0x77, 0x12, 0x1c, 0x0a, 0x09, 0x6e,
-	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a,
-	0x0c, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65,
-	0x12, 0x27, 0x0a, 0x0f, 0x70, 0x61, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x70, 0x61, 0x69, 0x72, 0x65,
-	0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x69,
-	0x76, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x12,
-	0x24, 0x0a, 0x0e, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x5f, 0x70, 0x35,
-	0x30, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79,
-	0x4d, 0x73, 0x50, 0x35, 0x30, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79,
-	0x5f, 0x6d, 0x73, 0x5f, 0x70, 0x39, 0x35, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c,
-	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x50, 0x39, 0x35, 0x12, 0x24, 0x0a, 0x0e, 0x6c,
-	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x5f, 0x70, 0x39, 0x39, 0x18, 0x08, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x50, 0x39,
-	0x39, 0x22, 0x8f, 0x01, 0x0a, 0x0f, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f,
-	0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x11, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65,
-	0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
-	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x10, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
-	0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f,
-	0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e,
-	0x64, 0x6f, 0x77, 0x22, 0xd2, 0x01, 0x0a, 0x10, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
-	0x76, 0x69, 0x7a, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x22, 0xc9, 0x01, 0x0a, 0x14,
+	0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x25, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e,
+	0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
 	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4f, 0x6b, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x33, 0x0a,
 	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c,
 	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f,
 	0x75, 0x72, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72,
-	0x6f, 0x72, 0x1a, 0x48, 0x0a, 0x02, 0x4f, 0x6b, 0x12, 0x42, 0x0a, 0x0e, 0x67, 0x61, 0x74, 0x65,
-	0x77, 0x61, 0x79, 0x73, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e,
-	0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x0d, 0x67,
-	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x0a, 0x0a, 0x08,
-	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0x2a, 0x0a, 0x0b, 0x43, 0x68, 0x65, 0x63,
+	0x6f, 0x72, 0x1a, 0x37, 0x0a, 0x02, 0x4f, 0x6b, 0x12, 0x31, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
+	0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xd0, 0x01, 0x0a, 0x0f, 0x49, 0x6e, 0x67, 0x72,
+	0x65, 0x73, 0x73, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x6f, 0x77, 0x12, 0x23, 0x0a, 0x0d, 0x69,
+	0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x43, 0x6c, 0x61, 0x73, 0x73,
+	0x12, 0x36, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
+	0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0a, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x30, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b,
+	0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x12, 0x2e, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x42, 0x61, 0x73, 0x69, 0x63, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0x5c, 0x0a, 0x1b, 0x49, 0x6e,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f,
+	0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69,
+	0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x22, 0xe1, 0x01, 0x0a, 0x1c, 0x49, 0x6e, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x02, 0x6f, 0x6b, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
+	0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x4f, 0x6b, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x33, 0x0a, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x1a,
+	0x3f, 0x0a, 0x02, 0x4f, 0x6b, 0x12, 0x39, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76,
+	0x69, 0x7a, 0x2e, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73,
+	0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xd6, 0x01, 0x0a,
+	0x17, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x6f, 0x77, 0x12, 0x32, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d,
+	0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x43, 0x6c, 0x61, 0x73,
+	0x73, 0x12, 0x32, 0x0a, 0x08, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76,
+	0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x08, 0x62, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x73, 0x12, 0x2e, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
+	0x76, 0x69, 0x7a, 0x2e, 0x42, 0x61, 0x73, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0x98, 0x01, 0x0a, 0x0c, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x5f, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12,
+	0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x70, 0x12, 0x2e, 0x0a, 0x06,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74,
+	0x22, 0x91, 0x02, 0x0a, 0x0d, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x30, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x41, 0x75,
+	0x74, 0x68, 0x7a, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4f, 0x6b, 0x48, 0x00,
+	0x52, 0x02, 0x6f, 0x6b, 0x12, 0x33, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76,
+	0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x1a, 0x8c, 0x01, 0x0a, 0x02, 0x4f, 0x6b,
+	0x12, 0x2e, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x12, 0x18, 0x0a, 0x07, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x12, 0x3c, 0x0a, 0x0d, 0x61, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a,
+	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0d, 0x61, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x7e, 0x0a, 0x15, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x48,
+	0x65, 0x61, 0x74, 0x6d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a,
+	0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x73, 0x74, 0x65, 0x70, 0x22, 0xf2, 0x01, 0x0a, 0x16, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x48, 0x65, 0x61, 0x74, 0x6d, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x39, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6c, 0x69,
+	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x4c, 0x61, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x48, 0x65, 0x61, 0x74, 0x6d, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x4f, 0x6b, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x33, 0x0a, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x1a,
+	0x5c, 0x0a, 0x02, 0x4f, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12,
+	0x3c, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e,
+	0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x48, 0x65, 0x61, 0x74, 0x6d, 0x61, 0x70, 0x43, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x42, 0x0a, 0x0a,
+	0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4c, 0x0a, 0x14, 0x4c, 0x61, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x48, 0x65, 0x61, 0x74, 0x6d, 0x61, 0x70, 0x43, 0x6f, 0x6c, 0x75, 0x6d,
+	0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12,
+	0x16, 0x0a, 0x06, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x04, 0x52,
+	0x06, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x22, 0x64, 0x0a, 0x0d, 0x45, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
+	0x74, 0x69, 0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x22, 0xb7, 0x01,
+	0x0a, 0x0e, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x31, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x45, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4f, 0x6b, 0x48, 0x00, 0x52,
+	0x02, 0x6f, 0x6b, 0x12, 0x33, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69,
+	0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48,
+	0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x1a, 0x31, 0x0a, 0x02, 0x4f, 0x6b, 0x12, 0x2b,
+	0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x45, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xee, 0x01, 0x0a, 0x09, 0x45, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x52, 0x6f, 0x77, 0x12, 0x32, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
+	0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52,
+	0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x2e, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
+	0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x42, 0x61, 0x73, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x12, 0x33, 0x0a, 0x09, 0x74, 0x63, 0x70, 0x5f, 0x73,
+	0x74, 0x61, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x54, 0x63, 0x70, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x08, 0x74, 0x63, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x11,
+	0x74, 0x6c, 0x73, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x74, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x2a, 0x2a, 0x0a, 0x0b, 0x43, 0x68, 0x65, 0x63,
 	0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x00, 0x12,
 	0x08, 0x0a, 0x04, 0x46, 0x41, 0x49, 0x4c, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52,
-	0x4f, 0x52, 0x10, 0x02, 0x32, 0xb2, 0x04, 0x0a, 0x03, 0x41, 0x70, 0x69, 0x12, 0x54, 0x0a, 0x0b,
+	0x4f, 0x52, 0x10, 0x02, 0x32, 0xaa, 0x09, 0x0a, 0x03, 0x41, 0x70, 0x69, 0x12, 0x54, 0x0a, 0x0b,
 	0x53, 0x74, 0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x20, 0x2e, 0x6c, 0x69,
 	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x53,
 	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e,
@@ -3792,36 +6428,75 @@ var file_viz_proto_rawDesc = []byte{
 	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
 	0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x08, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61,
-	0x79, 0x73, 0x12, 0x1d, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69,
-	0x7a, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a,
-	0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x09, 0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73,
-	0x12, 0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e,
-	0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e,
-	0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x64, 0x73, 0x12,
-	0x1d, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x50, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e,
-	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x4c, 0x69,
-	0x73, 0x74, 0x50, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x57, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73,
-	0x12, 0x21, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e,
-	0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76,
-	0x69, 0x7a, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x09, 0x53, 0x65, 0x6c,
-	0x66, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
-	0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x65, 0x6c, 0x66, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
-	0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x65, 0x6c, 0x66, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x2f,
-	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2f, 0x76, 0x69, 0x7a, 0x2f, 0x6d, 0x65, 0x74,
-	0x72, 0x69, 0x63, 0x73, 0x2d, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x76, 0x69, 0x7a,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x0c, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64,
+	0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x12, 0x21, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
+	0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65,
+	0x6e, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x5d, 0x0a, 0x0e, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x48, 0x65, 0x61, 0x74, 0x6d, 0x61,
+	0x70, 0x12, 0x23, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a,
+	0x2e, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x48, 0x65, 0x61, 0x74, 0x6d, 0x61, 0x70, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
+	0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x48, 0x65, 0x61,
+	0x74, 0x6d, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b,
+	0x0a, 0x08, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x12, 0x1d, 0x2e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x0c, 0x49,
+	0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x21, 0x2e, 0x6c, 0x69,
+	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x49, 0x6e, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22,
+	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x49, 0x6e,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x6f, 0x0a, 0x14, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x29, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x49, 0x6e, 0x67, 0x72,
+	0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
+	0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x06, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x45,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x45, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x09,
+	0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x08,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x64, 0x73, 0x12, 0x1d, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
+	0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x64, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
+	0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x64, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x0c, 0x4c, 0x69, 0x73,
+	0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x21, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x4e, 0x0a, 0x09, 0x53, 0x65, 0x6c, 0x66, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12,
+	0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53,
+	0x65, 0x6c, 0x66, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53,
+	0x65, 0x6c, 0x66, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x69, 0x0a, 0x12, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x74, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x27, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
+	0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x43, 0x6f, 0x6d,
+	0x70, 0x61, 0x74, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x28, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a,
+	0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x69, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a,
+	0x05, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x12, 0x1a, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
+	0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69,
+	0x7a, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x2f, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
+	0x2f, 0x76, 0x69, 0x7a, 0x2f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2d, 0x61, 0x70, 0x69,
+	0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x76, 0x69, 0x7a, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -3836,129 +6511,222 @@ func file_viz_proto_rawDescGZIP() []byte {
 	return file_viz_proto_rawDescData
 }
 
-var file_viz_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_viz_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
+var file_viz_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_viz_proto_msgTypes = make([]protoimpl.MessageInfo, 79)
 var file_viz_proto_goTypes = []interface{}{
-	(CheckStatus)(0),                          // 0: linkerd2.viz.CheckStatus
-	(HttpMethod_Registered)(0),                // 1: linkerd2.viz.HttpMethod.Registered
-	(Scheme_Registered)(0),                    // 2: linkerd2.viz.Scheme.Registered
-	(*Empty)(nil),                             // 3: linkerd2.viz.Empty
-	(*CheckResult)(nil),                       // 4: linkerd2.viz.CheckResult
-	(*SelfCheckRequest)(nil),                  // 5: linkerd2.viz.SelfCheckRequest
-	(*SelfCheckResponse)(nil),                 // 6: linkerd2.viz.SelfCheckResponse
-	(*ListServicesRequest)(nil),               // 7: linkerd2.viz.ListServicesRequest
-	(*ListServicesResponse)(nil),              // 8: linkerd2.viz.ListServicesResponse
-	(*Service)(nil),                           // 9: linkerd2.viz.Service
-	(*ListPodsRequest)(nil),                   // 10: linkerd2.viz.ListPodsRequest
-	(*ListPodsResponse)(nil),                  // 11: linkerd2.viz.ListPodsResponse
-	(*Pod)(nil),                               // 12: linkerd2.viz.Pod
-	(*HttpMethod)(nil),                        // 13: linkerd2.viz.HttpMethod
-	(*Scheme)(nil),                            // 14: linkerd2.viz.Scheme
-	(*Headers)(nil),                           // 15: linkerd2.viz.Headers
-	(*Eos)(nil),                               // 16: linkerd2.viz.Eos
-	(*ApiError)(nil),                          // 17: linkerd2.viz.ApiError
-	(*PodErrors)(nil),                         // 18: linkerd2.viz.PodErrors
-	(*Resource)(nil),                          // 19: linkerd2.viz.Resource
-	(*ResourceSelection)(nil),                 // 20: linkerd2.viz.ResourceSelection
-	(*ResourceError)(nil),                     // 21: linkerd2.viz.ResourceError
-	(*StatSummaryRequest)(nil),                // 22: linkerd2.viz.StatSummaryRequest
-	(*StatSummaryResponse)(nil),               // 23: linkerd2.viz.StatSummaryResponse
-	(*BasicStats)(nil),                        // 24: linkerd2.viz.BasicStats
-	(*TcpStats)(nil),                          // 25: linkerd2.viz.TcpStats
-	(*TrafficSplitStats)(nil),                 // 26: linkerd2.viz.TrafficSplitStats
-	(*ServerStats)(nil),                       // 27: linkerd2.viz.ServerStats
-	(*StatTable)(nil),                         // 28: linkerd2.viz.StatTable
-	(*EdgesRequest)(nil),                      // 29: linkerd2.viz.EdgesRequest
-	(*EdgesResponse)(nil),                     // 30: linkerd2.viz.EdgesResponse
-	(*Edge)(nil),                              // 31: linkerd2.viz.Edge
-	(*TopRoutesRequest)(nil),                  // 32: linkerd2.viz.TopRoutesRequest
-	(*TopRoutesResponse)(nil),                 // 33: linkerd2.viz.TopRoutesResponse
-	(*RouteTable)(nil),                        // 34: linkerd2.viz.RouteTable
-	(*GatewaysTable)(nil),                     // 35: linkerd2.viz.GatewaysTable
-	(*GatewaysRequest)(nil),                   // 36: linkerd2.viz.GatewaysRequest
-	(*GatewaysResponse)(nil),                  // 37: linkerd2.viz.GatewaysResponse
-	(*Headers_Header)(nil),                    // 38: linkerd2.viz.Headers.Header
-	(*PodErrors_PodError)(nil),                // 39: linkerd2.viz.PodErrors.PodError
-	(*PodErrors_PodError_ContainerError)(nil), // 40: linkerd2.viz.PodErrors.PodError.ContainerError
-	(*StatSummaryResponse_Ok)(nil),            // 41: linkerd2.viz.StatSummaryResponse.Ok
-	(*StatTable_PodGroup)(nil),                // 42: linkerd2.viz.StatTable.PodGroup
-	(*StatTable_PodGroup_Row)(nil),            // 43: linkerd2.viz.StatTable.PodGroup.Row
-	nil,                                       // 44: linkerd2.viz.StatTable.PodGroup.Row.ErrorsByPodEntry
-	(*EdgesResponse_Ok)(nil),                  // 45: linkerd2.viz.EdgesResponse.Ok
-	(*TopRoutesResponse_Ok)(nil),              // 46: linkerd2.viz.TopRoutesResponse.Ok
-	(*RouteTable_Row)(nil),                    // 47: linkerd2.viz.RouteTable.Row
-	(*GatewaysTable_Row)(nil),                 // 48: linkerd2.viz.GatewaysTable.Row
-	(*GatewaysResponse_Ok)(nil),               // 49: linkerd2.viz.GatewaysResponse.Ok
-	(*duration.Duration)(nil),                 // 50: google.protobuf.Duration
+	(CheckStatus)(0),                                      // 0: linkerd2.viz.CheckStatus
+	(HttpMethod_Registered)(0),                            // 1: linkerd2.viz.HttpMethod.Registered
+	(Scheme_Registered)(0),                                // 2: linkerd2.viz.Scheme.Registered
+	(StatSummaryRequest_MeshFilter)(0),                    // 3: linkerd2.viz.StatSummaryRequest.MeshFilter
+	(*Empty)(nil),                                         // 4: linkerd2.viz.Empty
+	(*CheckResult)(nil),                                   // 5: linkerd2.viz.CheckResult
+	(*SelfCheckRequest)(nil),                              // 6: linkerd2.viz.SelfCheckRequest
+	(*SelfCheckResponse)(nil),                             // 7: linkerd2.viz.SelfCheckResponse
+	(*LabelCompatibilityRequest)(nil),                     // 8: linkerd2.viz.LabelCompatibilityRequest
+	(*LabelCompatibilityResponse)(nil),                    // 9: linkerd2.viz.LabelCompatibilityResponse
+	(*ListServicesRequest)(nil),                           // 10: linkerd2.viz.ListServicesRequest
+	(*ListServicesResponse)(nil),                          // 11: linkerd2.viz.ListServicesResponse
+	(*Service)(nil),                                       // 12: linkerd2.viz.Service
+	(*ListPodsRequest)(nil),                               // 13: linkerd2.viz.ListPodsRequest
+	(*ListPodsResponse)(nil),                              // 14: linkerd2.viz.ListPodsResponse
+	(*Pod)(nil),                                           // 15: linkerd2.viz.Pod
+	(*HttpMethod)(nil),                                    // 16: linkerd2.viz.HttpMethod
+	(*Scheme)(nil),                                        // 17: linkerd2.viz.Scheme
+	(*Headers)(nil),                                       // 18: linkerd2.viz.Headers
+	(*Eos)(nil),                                           // 19: linkerd2.viz.Eos
+	(*ApiError)(nil),                                      // 20: linkerd2.viz.ApiError
+	(*PodErrors)(nil),                                     // 21: linkerd2.viz.PodErrors
+	(*Resource)(nil),                                      // 22: linkerd2.viz.Resource
+	(*ResourceSelection)(nil),                             // 23: linkerd2.viz.ResourceSelection
+	(*ResourceError)(nil),                                 // 24: linkerd2.viz.ResourceError
+	(*StatSummaryRequest)(nil),                            // 25: linkerd2.viz.StatSummaryRequest
+	(*SloObjective)(nil),                                  // 26: linkerd2.viz.SloObjective
+	(*StatSummaryResponse)(nil),                           // 27: linkerd2.viz.StatSummaryResponse
+	(*BasicStats)(nil),                                    // 28: linkerd2.viz.BasicStats
+	(*SloStats)(nil),                                      // 29: linkerd2.viz.SloStats
+	(*TcpStats)(nil),                                      // 30: linkerd2.viz.TcpStats
+	(*TrafficSplitStats)(nil),                             // 31: linkerd2.viz.TrafficSplitStats
+	(*ServerStats)(nil),                                   // 32: linkerd2.viz.ServerStats
+	(*PolicyStats)(nil),                                   // 33: linkerd2.viz.PolicyStats
+	(*PromQuery)(nil),                                     // 34: linkerd2.viz.PromQuery
+	(*StatTable)(nil),                                     // 35: linkerd2.viz.StatTable
+	(*EdgesRequest)(nil),                                  // 36: linkerd2.viz.EdgesRequest
+	(*EdgesResponse)(nil),                                 // 37: linkerd2.viz.EdgesResponse
+	(*Edge)(nil),                                          // 38: linkerd2.viz.Edge
+	(*DependenciesRequest)(nil),                           // 39: linkerd2.viz.DependenciesRequest
+	(*DependenciesResponse)(nil),                          // 40: linkerd2.viz.DependenciesResponse
+	(*DependencyNode)(nil),                                // 41: linkerd2.viz.DependencyNode
+	(*TopRoutesRequest)(nil),                              // 42: linkerd2.viz.TopRoutesRequest
+	(*TopRoutesResponse)(nil),                             // 43: linkerd2.viz.TopRoutesResponse
+	(*RouteTable)(nil),                                    // 44: linkerd2.viz.RouteTable
+	(*GatewaysTable)(nil),                                 // 45: linkerd2.viz.GatewaysTable
+	(*GatewaysRequest)(nil),                               // 46: linkerd2.viz.GatewaysRequest
+	(*GatewaysResponse)(nil),                              // 47: linkerd2.viz.GatewaysResponse
+	(*IngressStatsRequest)(nil),                           // 48: linkerd2.viz.IngressStatsRequest
+	(*IngressStatsResponse)(nil),                          // 49: linkerd2.viz.IngressStatsResponse
+	(*IngressStatsRow)(nil),                               // 50: linkerd2.viz.IngressStatsRow
+	(*IngressResourceStatsRequest)(nil),                   // 51: linkerd2.viz.IngressResourceStatsRequest
+	(*IngressResourceStatsResponse)(nil),                  // 52: linkerd2.viz.IngressResourceStatsResponse
+	(*IngressResourceStatsRow)(nil),                       // 53: linkerd2.viz.IngressResourceStatsRow
+	(*AuthzRequest)(nil),                                  // 54: linkerd2.viz.AuthzRequest
+	(*AuthzResponse)(nil),                                 // 55: linkerd2.viz.AuthzResponse
+	(*LatencyHeatmapRequest)(nil),                         // 56: linkerd2.viz.LatencyHeatmapRequest
+	(*LatencyHeatmapResponse)(nil),                        // 57: linkerd2.viz.LatencyHeatmapResponse
+	(*LatencyHeatmapColumn)(nil),                          // 58: linkerd2.viz.LatencyHeatmapColumn
+	(*EgressRequest)(nil),                                 // 59: linkerd2.viz.EgressRequest
+	(*EgressResponse)(nil),                                // 60: linkerd2.viz.EgressResponse
+	(*EgressRow)(nil),                                     // 61: linkerd2.viz.EgressRow
+	(*LabelCompatibilityResponse_ProxyVersionReport)(nil), // 62: linkerd2.viz.LabelCompatibilityResponse.ProxyVersionReport
+	(*LabelCompatibilityResponse_MissingLabel)(nil),       // 63: linkerd2.viz.LabelCompatibilityResponse.MissingLabel
+	(*Headers_Header)(nil),                                // 64: linkerd2.viz.Headers.Header
+	(*PodErrors_PodError)(nil),                            // 65: linkerd2.viz.PodErrors.PodError
+	(*PodErrors_PodError_ContainerError)(nil),             // 66: linkerd2.viz.PodErrors.PodError.ContainerError
+	(*StatSummaryResponse_Ok)(nil),                        // 67: linkerd2.viz.StatSummaryResponse.Ok
+	(*StatTable_PodGroup)(nil),                            // 68: linkerd2.viz.StatTable.PodGroup
+	(*StatTable_PodGroup_Row)(nil),                        // 69: linkerd2.viz.StatTable.PodGroup.Row
+	nil,                                                   // 70: linkerd2.viz.StatTable.PodGroup.Row.LabelsEntry
+	nil,                                                   // 71: linkerd2.viz.StatTable.PodGroup.Row.ErrorsByPodEntry
+	(*EdgesResponse_Ok)(nil),                              // 72: linkerd2.viz.EdgesResponse.Ok
+	(*DependenciesResponse_Ok)(nil),                       // 73: linkerd2.viz.DependenciesResponse.Ok
+	(*TopRoutesResponse_Ok)(nil),                          // 74: linkerd2.viz.TopRoutesResponse.Ok
+	(*RouteTable_Row)(nil),                                // 75: linkerd2.viz.RouteTable.Row
+	(*GatewaysTable_Row)(nil),                             // 76: linkerd2.viz.GatewaysTable.Row
+	(*GatewaysResponse_Ok)(nil),                           // 77: linkerd2.viz.GatewaysResponse.Ok
+	(*IngressStatsResponse_Ok)(nil),                       // 78: linkerd2.viz.IngressStatsResponse.Ok
+	(*IngressResourceStatsResponse_Ok)(nil),               // 79: linkerd2.viz.IngressResourceStatsResponse.Ok
+	(*AuthzResponse_Ok)(nil),                              // 80: linkerd2.viz.AuthzResponse.Ok
+	(*LatencyHeatmapResponse_Ok)(nil),                     // 81: linkerd2.viz.LatencyHeatmapResponse.Ok
+	(*EgressResponse_Ok)(nil),                             // 82: linkerd2.viz.EgressResponse.Ok
+	(*duration.Duration)(nil),                             // 83: google.protobuf.Duration
 }
 var file_viz_proto_depIdxs = []int32{
-	0,  // 0: linkerd2.viz.CheckResult.Status:type_name -> linkerd2.viz.CheckStatus
-	4,  // 1: linkerd2.viz.SelfCheckResponse.results:type_name -> linkerd2.viz.CheckResult
-	9,  // 2: linkerd2.viz.ListServicesResponse.services:type_name -> linkerd2.viz.Service
-	20, // 3: linkerd2.viz.ListPodsRequest.selector:type_name -> linkerd2.viz.ResourceSelection
-	12, // 4: linkerd2.viz.ListPodsResponse.pods:type_name -> linkerd2.viz.Pod
-	50, // 5: linkerd2.viz.Pod.sinceLastReport:type_name -> google.protobuf.Duration
-	50, // 6: linkerd2.viz.Pod.uptime:type_name -> google.protobuf.Duration
-	1,  // 7: linkerd2.viz.HttpMethod.registered:type_name -> linkerd2.viz.HttpMethod.Registered
-	2,  // 8: linkerd2.viz.Scheme.registered:type_name -> linkerd2.viz.Scheme.Registered
-	38, // 9: linkerd2.viz.Headers.headers:type_name -> linkerd2.viz.Headers.Header
-	39, // 10: linkerd2.viz.PodErrors.errors:type_name -> linkerd2.viz.PodErrors.PodError
-	19, // 11: linkerd2.viz.ResourceSelection.resource:type_name -> linkerd2.viz.Resource
-	19, // 12: linkerd2.viz.ResourceError.resource:type_name -> linkerd2.viz.Resource
-	20, // 13: linkerd2.viz.StatSummaryRequest.selector:type_name -> linkerd2.viz.ResourceSelection
-	3,  // 14: linkerd2.viz.StatSummaryRequest.none:type_name -> linkerd2.viz.Empty
-	19, // 15: linkerd2.viz.StatSummaryRequest.to_resource:type_name -> linkerd2.viz.Resource
-	19, // 16: linkerd2.viz.StatSummaryRequest.from_resource:type_name -> linkerd2.viz.Resource
-	41, // 17: linkerd2.viz.StatSummaryResponse.ok:type_name -> linkerd2.viz.StatSummaryResponse.Ok
-	21, // 18: linkerd2.viz.StatSummaryResponse.error:type_name -> linkerd2.viz.ResourceError
-	42, // 19: linkerd2.viz.StatTable.pod_group:type_name -> linkerd2.viz.StatTable.PodGroup
-	20, // 20: linkerd2.viz.EdgesRequest.selector:type_name -> linkerd2.viz.ResourceSelection
-	45, // 21: linkerd2.viz.EdgesResponse.ok:type_name -> linkerd2.viz.EdgesResponse.Ok
-	21, // 22: linkerd2.viz.EdgesResponse.error:type_name -> linkerd2.viz.ResourceError
-	19, // 23: linkerd2.viz.Edge.src:type_name -> linkerd2.viz.Resource
-	19, // 24: linkerd2.viz.Edge.dst:type_name -> linkerd2.viz.Resource
-	20, // 25: linkerd2.viz.TopRoutesRequest.selector:type_name -> linkerd2.viz.ResourceSelection
-	3,  // 26: linkerd2.viz.TopRoutesRequest.none:type_name -> linkerd2.viz.Empty
-	19, // 27: linkerd2.viz.TopRoutesRequest.to_resource:type_name -> linkerd2.viz.Resource
-	21, // 28: linkerd2.viz.TopRoutesResponse.error:type_name -> linkerd2.viz.ResourceError
-	46, // 29: linkerd2.viz.TopRoutesResponse.ok:type_name -> linkerd2.viz.TopRoutesResponse.Ok
-	47, // 30: linkerd2.viz.RouteTable.rows:type_name -> linkerd2.viz.RouteTable.Row
-	48, // 31: linkerd2.viz.GatewaysTable.rows:type_name -> linkerd2.viz.GatewaysTable.Row
-	49, // 32: linkerd2.viz.GatewaysResponse.ok:type_name -> linkerd2.viz.GatewaysResponse.Ok
-	21, // 33: linkerd2.viz.GatewaysResponse.error:type_name -> linkerd2.viz.ResourceError
-	40, // 34: linkerd2.viz.PodErrors.PodError.container:type_name -> linkerd2.viz.PodErrors.PodError.ContainerError
-	28, // 35: linkerd2.viz.StatSummaryResponse.Ok.stat_tables:type_name -> linkerd2.viz.StatTable
-	43, // 36: linkerd2.viz.StatTable.PodGroup.rows:type_name -> linkerd2.viz.StatTable.PodGroup.Row
-	19, // 37: linkerd2.viz.StatTable.PodGroup.Row.resource:type_name -> linkerd2.viz.Resource
-	24, // 38: linkerd2.viz.StatTable.PodGroup.Row.stats:type_name -> linkerd2.viz.BasicStats
-	25, // 39: linkerd2.viz.StatTable.PodGroup.Row.tcp_stats:type_name -> linkerd2.viz.TcpStats
-	26, // 40: linkerd2.viz.StatTable.PodGroup.Row.ts_stats:type_name -> linkerd2.viz.TrafficSplitStats
-	27, // 41: linkerd2.viz.StatTable.PodGroup.Row.srv_stats:type_name -> linkerd2.viz.ServerStats
-	44, // 42: linkerd2.viz.StatTable.PodGroup.Row.errors_by_pod:type_name -> linkerd2.viz.StatTable.PodGroup.Row.ErrorsByPodEntry
-	18, // 43: linkerd2.viz.StatTable.PodGroup.Row.ErrorsByPodEntry.value:type_name -> linkerd2.viz.PodErrors
-	31, // 44: linkerd2.viz.EdgesResponse.Ok.edges:type_name -> linkerd2.viz.Edge
-	34, // 45: linkerd2.viz.TopRoutesResponse.Ok.routes:type_name -> linkerd2.viz.RouteTable
-	24, // 46: linkerd2.viz.RouteTable.Row.stats:type_name -> linkerd2.viz.BasicStats
-	35, // 47: linkerd2.viz.GatewaysResponse.Ok.gateways_table:type_name -> linkerd2.viz.GatewaysTable
-	22, // 48: linkerd2.viz.Api.StatSummary:input_type -> linkerd2.viz.StatSummaryRequest
-	29, // 49: linkerd2.viz.Api.Edges:input_type -> linkerd2.viz.EdgesRequest
-	36, // 50: linkerd2.viz.Api.Gateways:input_type -> linkerd2.viz.GatewaysRequest
-	32, // 51: linkerd2.viz.Api.TopRoutes:input_type -> linkerd2.viz.TopRoutesRequest
-	10, // 52: linkerd2.viz.Api.ListPods:input_type -> linkerd2.viz.ListPodsRequest
-	7,  // 53: linkerd2.viz.Api.ListServices:input_type -> linkerd2.viz.ListServicesRequest
-	5,  // 54: linkerd2.viz.Api.SelfCheck:input_type -> linkerd2.viz.SelfCheckRequest
-	23, // 55: linkerd2.viz.Api.StatSummary:output_type -> linkerd2.viz.StatSummaryResponse
-	30, // 56: linkerd2.viz.Api.Edges:output_type -> linkerd2.viz.EdgesResponse
-	37, // 57: linkerd2.viz.Api.Gateways:output_type -> linkerd2.viz.GatewaysResponse
-	33, // 58: linkerd2.viz.Api.TopRoutes:output_type -> linkerd2.viz.TopRoutesResponse
-	11, // 59: linkerd2.viz.Api.ListPods:output_type -> linkerd2.viz.ListPodsResponse
-	8,  // 60: linkerd2.viz.Api.ListServices:output_type -> linkerd2.viz.ListServicesResponse
-	6,  // 61: linkerd2.viz.Api.SelfCheck:output_type -> linkerd2.viz.SelfCheckResponse
-	55, // [55:62] is the sub-list for method output_type
-	48, // [48:55] is the sub-list for method input_type
-	48, // [48:48] is the sub-list for extension type_name
-	48, // [48:48] is the sub-list for extension extendee
-	0,  // [0:48] is the sub-list for field type_name
+	0,   // 0: linkerd2.viz.CheckResult.Status:type_name -> linkerd2.viz.CheckStatus
+	5,   // 1: linkerd2.viz.SelfCheckResponse.results:type_name -> linkerd2.viz.CheckResult
+	62,  // 2: linkerd2.viz.LabelCompatibilityResponse.reports:type_name -> linkerd2.viz.LabelCompatibilityResponse.ProxyVersionReport
+	83,  // 3: linkerd2.viz.LabelCompatibilityResponse.since_last_check:type_name -> google.protobuf.Duration
+	12,  // 4: linkerd2.viz.ListServicesResponse.services:type_name -> linkerd2.viz.Service
+	23,  // 5: linkerd2.viz.ListPodsRequest.selector:type_name -> linkerd2.viz.ResourceSelection
+	15,  // 6: linkerd2.viz.ListPodsResponse.pods:type_name -> linkerd2.viz.Pod
+	83,  // 7: linkerd2.viz.Pod.sinceLastReport:type_name -> google.protobuf.Duration
+	83,  // 8: linkerd2.viz.Pod.uptime:type_name -> google.protobuf.Duration
+	1,   // 9: linkerd2.viz.HttpMethod.registered:type_name -> linkerd2.viz.HttpMethod.Registered
+	2,   // 10: linkerd2.viz.Scheme.registered:type_name -> linkerd2.viz.Scheme.Registered
+	64,  // 11: linkerd2.viz.Headers.headers:type_name -> linkerd2.viz.Headers.Header
+	65,  // 12: linkerd2.viz.PodErrors.errors:type_name -> linkerd2.viz.PodErrors.PodError
+	22,  // 13: linkerd2.viz.ResourceSelection.resource:type_name -> linkerd2.viz.Resource
+	22,  // 14: linkerd2.viz.ResourceError.resource:type_name -> linkerd2.viz.Resource
+	23,  // 15: linkerd2.viz.StatSummaryRequest.selector:type_name -> linkerd2.viz.ResourceSelection
+	4,   // 16: linkerd2.viz.StatSummaryRequest.none:type_name -> linkerd2.viz.Empty
+	22,  // 17: linkerd2.viz.StatSummaryRequest.to_resource:type_name -> linkerd2.viz.Resource
+	22,  // 18: linkerd2.viz.StatSummaryRequest.from_resource:type_name -> linkerd2.viz.Resource
+	26,  // 19: linkerd2.viz.StatSummaryRequest.slo:type_name -> linkerd2.viz.SloObjective
+	3,   // 20: linkerd2.viz.StatSummaryRequest.mesh_filter:type_name -> linkerd2.viz.StatSummaryRequest.MeshFilter
+	67,  // 21: linkerd2.viz.StatSummaryResponse.ok:type_name -> linkerd2.viz.StatSummaryResponse.Ok
+	24,  // 22: linkerd2.viz.StatSummaryResponse.error:type_name -> linkerd2.viz.ResourceError
+	68,  // 23: linkerd2.viz.StatTable.pod_group:type_name -> linkerd2.viz.StatTable.PodGroup
+	23,  // 24: linkerd2.viz.EdgesRequest.selector:type_name -> linkerd2.viz.ResourceSelection
+	72,  // 25: linkerd2.viz.EdgesResponse.ok:type_name -> linkerd2.viz.EdgesResponse.Ok
+	24,  // 26: linkerd2.viz.EdgesResponse.error:type_name -> linkerd2.viz.ResourceError
+	22,  // 27: linkerd2.viz.Edge.src:type_name -> linkerd2.viz.Resource
+	22,  // 28: linkerd2.viz.Edge.dst:type_name -> linkerd2.viz.Resource
+	22,  // 29: linkerd2.viz.DependenciesRequest.resource:type_name -> linkerd2.viz.Resource
+	73,  // 30: linkerd2.viz.DependenciesResponse.ok:type_name -> linkerd2.viz.DependenciesResponse.Ok
+	24,  // 31: linkerd2.viz.DependenciesResponse.error:type_name -> linkerd2.viz.ResourceError
+	22,  // 32: linkerd2.viz.DependencyNode.resource:type_name -> linkerd2.viz.Resource
+	28,  // 33: linkerd2.viz.DependencyNode.stats:type_name -> linkerd2.viz.BasicStats
+	41,  // 34: linkerd2.viz.DependencyNode.children:type_name -> linkerd2.viz.DependencyNode
+	23,  // 35: linkerd2.viz.TopRoutesRequest.selector:type_name -> linkerd2.viz.ResourceSelection
+	4,   // 36: linkerd2.viz.TopRoutesRequest.none:type_name -> linkerd2.viz.Empty
+	22,  // 37: linkerd2.viz.TopRoutesRequest.to_resource:type_name -> linkerd2.viz.Resource
+	24,  // 38: linkerd2.viz.TopRoutesResponse.error:type_name -> linkerd2.viz.ResourceError
+	74,  // 39: linkerd2.viz.TopRoutesResponse.ok:type_name -> linkerd2.viz.TopRoutesResponse.Ok
+	75,  // 40: linkerd2.viz.RouteTable.rows:type_name -> linkerd2.viz.RouteTable.Row
+	76,  // 41: linkerd2.viz.GatewaysTable.rows:type_name -> linkerd2.viz.GatewaysTable.Row
+	77,  // 42: linkerd2.viz.GatewaysResponse.ok:type_name -> linkerd2.viz.GatewaysResponse.Ok
+	24,  // 43: linkerd2.viz.GatewaysResponse.error:type_name -> linkerd2.viz.ResourceError
+	78,  // 44: linkerd2.viz.IngressStatsResponse.ok:type_name -> linkerd2.viz.IngressStatsResponse.Ok
+	24,  // 45: linkerd2.viz.IngressStatsResponse.error:type_name -> linkerd2.viz.ResourceError
+	22,  // 46: linkerd2.viz.IngressStatsRow.controller:type_name -> linkerd2.viz.Resource
+	22,  // 47: linkerd2.viz.IngressStatsRow.backend:type_name -> linkerd2.viz.Resource
+	28,  // 48: linkerd2.viz.IngressStatsRow.stats:type_name -> linkerd2.viz.BasicStats
+	79,  // 49: linkerd2.viz.IngressResourceStatsResponse.ok:type_name -> linkerd2.viz.IngressResourceStatsResponse.Ok
+	24,  // 50: linkerd2.viz.IngressResourceStatsResponse.error:type_name -> linkerd2.viz.ResourceError
+	22,  // 51: linkerd2.viz.IngressResourceStatsRow.resource:type_name -> linkerd2.viz.Resource
+	22,  // 52: linkerd2.viz.IngressResourceStatsRow.backends:type_name -> linkerd2.viz.Resource
+	28,  // 53: linkerd2.viz.IngressResourceStatsRow.stats:type_name -> linkerd2.viz.BasicStats
+	22,  // 54: linkerd2.viz.AuthzRequest.target:type_name -> linkerd2.viz.Resource
+	80,  // 55: linkerd2.viz.AuthzResponse.ok:type_name -> linkerd2.viz.AuthzResponse.Ok
+	24,  // 56: linkerd2.viz.AuthzResponse.error:type_name -> linkerd2.viz.ResourceError
+	22,  // 57: linkerd2.viz.LatencyHeatmapRequest.resource:type_name -> linkerd2.viz.Resource
+	81,  // 58: linkerd2.viz.LatencyHeatmapResponse.ok:type_name -> linkerd2.viz.LatencyHeatmapResponse.Ok
+	24,  // 59: linkerd2.viz.LatencyHeatmapResponse.error:type_name -> linkerd2.viz.ResourceError
+	22,  // 60: linkerd2.viz.EgressRequest.resource:type_name -> linkerd2.viz.Resource
+	82,  // 61: linkerd2.viz.EgressResponse.ok:type_name -> linkerd2.viz.EgressResponse.Ok
+	24,  // 62: linkerd2.viz.EgressResponse.error:type_name -> linkerd2.viz.ResourceError
+	22,  // 63: linkerd2.viz.EgressRow.resource:type_name -> linkerd2.viz.Resource
+	28,  // 64: linkerd2.viz.EgressRow.stats:type_name -> linkerd2.viz.BasicStats
+	30,  // 65: linkerd2.viz.EgressRow.tcp_stats:type_name -> linkerd2.viz.TcpStats
+	63,  // 66: linkerd2.viz.LabelCompatibilityResponse.ProxyVersionReport.missing_labels:type_name -> linkerd2.viz.LabelCompatibilityResponse.MissingLabel
+	66,  // 67: linkerd2.viz.PodErrors.PodError.container:type_name -> linkerd2.viz.PodErrors.PodError.ContainerError
+	35,  // 68: linkerd2.viz.StatSummaryResponse.Ok.stat_tables:type_name -> linkerd2.viz.StatTable
+	69,  // 69: linkerd2.viz.StatTable.PodGroup.rows:type_name -> linkerd2.viz.StatTable.PodGroup.Row
+	22,  // 70: linkerd2.viz.StatTable.PodGroup.Row.resource:type_name -> linkerd2.viz.Resource
+	28,  // 71: linkerd2.viz.StatTable.PodGroup.Row.stats:type_name -> linkerd2.viz.BasicStats
+	30,  // 72: linkerd2.viz.StatTable.PodGroup.Row.tcp_stats:type_name -> linkerd2.viz.TcpStats
+	31,  // 73: linkerd2.viz.StatTable.PodGroup.Row.ts_stats:type_name -> linkerd2.viz.TrafficSplitStats
+	32,  // 74: linkerd2.viz.StatTable.PodGroup.Row.srv_stats:type_name -> linkerd2.viz.ServerStats
+	33,  // 75: linkerd2.viz.StatTable.PodGroup.Row.policy_stats:type_name -> linkerd2.viz.PolicyStats
+	34,  // 76: linkerd2.viz.StatTable.PodGroup.Row.queries:type_name -> linkerd2.viz.PromQuery
+	70,  // 77: linkerd2.viz.StatTable.PodGroup.Row.labels:type_name -> linkerd2.viz.StatTable.PodGroup.Row.LabelsEntry
+	29,  // 78: linkerd2.viz.StatTable.PodGroup.Row.slo_stats:type_name -> linkerd2.viz.SloStats
+	71,  // 79: linkerd2.viz.StatTable.PodGroup.Row.errors_by_pod:type_name -> linkerd2.viz.StatTable.PodGroup.Row.ErrorsByPodEntry
+	21,  // 80: linkerd2.viz.StatTable.PodGroup.Row.ErrorsByPodEntry.value:type_name -> linkerd2.viz.PodErrors
+	38,  // 81: linkerd2.viz.EdgesResponse.Ok.edges:type_name -> linkerd2.viz.Edge
+	41,  // 82: linkerd2.viz.DependenciesResponse.Ok.upstreams:type_name -> linkerd2.viz.DependencyNode
+	41,  // 83: linkerd2.viz.DependenciesResponse.Ok.downstreams:type_name -> linkerd2.viz.DependencyNode
+	44,  // 84: linkerd2.viz.TopRoutesResponse.Ok.routes:type_name -> linkerd2.viz.RouteTable
+	28,  // 85: linkerd2.viz.RouteTable.Row.stats:type_name -> linkerd2.viz.BasicStats
+	28,  // 86: linkerd2.viz.RouteTable.Row.trend:type_name -> linkerd2.viz.BasicStats
+	45,  // 87: linkerd2.viz.GatewaysResponse.Ok.gateways_table:type_name -> linkerd2.viz.GatewaysTable
+	50,  // 88: linkerd2.viz.IngressStatsResponse.Ok.rows:type_name -> linkerd2.viz.IngressStatsRow
+	53,  // 89: linkerd2.viz.IngressResourceStatsResponse.Ok.rows:type_name -> linkerd2.viz.IngressResourceStatsRow
+	22,  // 90: linkerd2.viz.AuthzResponse.Ok.server:type_name -> linkerd2.viz.Resource
+	22,  // 91: linkerd2.viz.AuthzResponse.Ok.authorization:type_name -> linkerd2.viz.Resource
+	58,  // 92: linkerd2.viz.LatencyHeatmapResponse.Ok.columns:type_name -> linkerd2.viz.LatencyHeatmapColumn
+	61,  // 93: linkerd2.viz.EgressResponse.Ok.rows:type_name -> linkerd2.viz.EgressRow
+	25,  // 94: linkerd2.viz.Api.StatSummary:input_type -> linkerd2.viz.StatSummaryRequest
+	36,  // 95: linkerd2.viz.Api.Edges:input_type -> linkerd2.viz.EdgesRequest
+	39,  // 96: linkerd2.viz.Api.Dependencies:input_type -> linkerd2.viz.DependenciesRequest
+	56,  // 97: linkerd2.viz.Api.LatencyHeatmap:input_type -> linkerd2.viz.LatencyHeatmapRequest
+	46,  // 98: linkerd2.viz.Api.Gateways:input_type -> linkerd2.viz.GatewaysRequest
+	48,  // 99: linkerd2.viz.Api.IngressStats:input_type -> linkerd2.viz.IngressStatsRequest
+	51,  // 100: linkerd2.viz.Api.IngressResourceStats:input_type -> linkerd2.viz.IngressResourceStatsRequest
+	59,  // 101: linkerd2.viz.Api.Egress:input_type -> linkerd2.viz.EgressRequest
+	42,  // 102: linkerd2.viz.Api.TopRoutes:input_type -> linkerd2.viz.TopRoutesRequest
+	13,  // 103: linkerd2.viz.Api.ListPods:input_type -> linkerd2.viz.ListPodsRequest
+	10,  // 104: linkerd2.viz.Api.ListServices:input_type -> linkerd2.viz.ListServicesRequest
+	6,   // 105: linkerd2.viz.Api.SelfCheck:input_type -> linkerd2.viz.SelfCheckRequest
+	8,   // 106: linkerd2.viz.Api.LabelCompatibility:input_type -> linkerd2.viz.LabelCompatibilityRequest
+	54,  // 107: linkerd2.viz.Api.Authz:input_type -> linkerd2.viz.AuthzRequest
+	27,  // 108: linkerd2.viz.Api.StatSummary:output_type -> linkerd2.viz.StatSummaryResponse
+	37,  // 109: linkerd2.viz.Api.Edges:output_type -> linkerd2.viz.EdgesResponse
+	40,  // 110: linkerd2.viz.Api.Dependencies:output_type -> linkerd2.viz.DependenciesResponse
+	57,  // 111: linkerd2.viz.Api.LatencyHeatmap:output_type -> linkerd2.viz.LatencyHeatmapResponse
+	47,  // 112: linkerd2.viz.Api.Gateways:output_type -> linkerd2.viz.GatewaysResponse
+	49,  // 113: linkerd2.viz.Api.IngressStats:output_type -> linkerd2.viz.IngressStatsResponse
+	52,  // 114: linkerd2.viz.Api.IngressResourceStats:output_type -> linkerd2.viz.IngressResourceStatsResponse
+	60,  // 115: linkerd2.viz.Api.Egress:output_type -> linkerd2.viz.EgressResponse
+	43,  // 116: linkerd2.viz.Api.TopRoutes:output_type -> linkerd2.viz.TopRoutesResponse
+	14,  // 117: linkerd2.viz.Api.ListPods:output_type -> linkerd2.viz.ListPodsResponse
+	11,  // 118: linkerd2.viz.Api.ListServices:output_type -> linkerd2.viz.ListServicesResponse
+	7,   // 119: linkerd2.viz.Api.SelfCheck:output_type -> linkerd2.viz.SelfCheckResponse
+	9,   // 120: linkerd2.viz.Api.LabelCompatibility:output_type -> linkerd2.viz.LabelCompatibilityResponse
+	55,  // 121: linkerd2.viz.Api.Authz:output_type -> linkerd2.viz.AuthzResponse
+	108, // [108:122] is the sub-list for method output_type
+	94,  // [94:108] is the sub-list for method input_type
+	94,  // [94:94] is the sub-list for extension type_name
+	94,  // [94:94] is the sub-list for extension extendee
+	0,   // [0:94] is the sub-list for field type_name
 }
 
 func init() { file_viz_proto_init() }
@@ -3979,8 +6747,236 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CheckResult); i {
+		file_viz_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SelfCheckRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SelfCheckResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabelCompatibilityRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabelCompatibilityResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListServicesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListServicesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Service); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPodsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPodsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pod); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HttpMethod); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Scheme); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Headers); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Eos); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApiError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PodErrors); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Resource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceSelection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceError); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3991,8 +6987,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SelfCheckRequest); i {
+		file_viz_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatSummaryRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4003,8 +6999,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SelfCheckResponse); i {
+		file_viz_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SloObjective); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4015,8 +7011,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListServicesRequest); i {
+		file_viz_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatSummaryResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4027,8 +7023,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListServicesResponse); i {
+		file_viz_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BasicStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4039,8 +7035,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Service); i {
+		file_viz_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SloStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4051,8 +7047,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListPodsRequest); i {
+		file_viz_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TcpStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4063,8 +7059,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListPodsResponse); i {
+		file_viz_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrafficSplitStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4075,8 +7071,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Pod); i {
+		file_viz_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4087,8 +7083,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HttpMethod); i {
+		file_viz_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PolicyStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4099,8 +7095,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Scheme); i {
+		file_viz_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PromQuery); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4111,8 +7107,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Headers); i {
+		file_viz_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatTable); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4123,8 +7119,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Eos); i {
+		file_viz_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EdgesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4135,8 +7131,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ApiError); i {
+		file_viz_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EdgesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4147,8 +7143,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PodErrors); i {
+		file_viz_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Edge); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4159,8 +7155,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Resource); i {
+		file_viz_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DependenciesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4171,8 +7167,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResourceSelection); i {
+		file_viz_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DependenciesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4183,8 +7179,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResourceError); i {
+		file_viz_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DependencyNode); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4195,8 +7191,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatSummaryRequest); i {
+		file_viz_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopRoutesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4207,8 +7203,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatSummaryResponse); i {
+		file_viz_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopRoutesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4219,8 +7215,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BasicStats); i {
+		file_viz_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteTable); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4231,8 +7227,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TcpStats); i {
+		file_viz_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GatewaysTable); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4243,8 +7239,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TrafficSplitStats); i {
+		file_viz_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GatewaysRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4255,8 +7251,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ServerStats); i {
+		file_viz_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GatewaysResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4267,8 +7263,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatTable); i {
+		file_viz_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngressStatsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4279,8 +7275,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EdgesRequest); i {
+		file_viz_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngressStatsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4291,8 +7287,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EdgesResponse); i {
+		file_viz_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngressStatsRow); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4303,8 +7299,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Edge); i {
+		file_viz_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngressResourceStatsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4315,8 +7311,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TopRoutesRequest); i {
+		file_viz_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngressResourceStatsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4327,8 +7323,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TopRoutesResponse); i {
+		file_viz_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngressResourceStatsRow); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4339,8 +7335,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RouteTable); i {
+		file_viz_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthzRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4351,8 +7347,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GatewaysTable); i {
+		file_viz_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthzResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4363,8 +7359,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GatewaysRequest); i {
+		file_viz_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LatencyHeatmapRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4375,8 +7371,8 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GatewaysResponse); i {
+		file_viz_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LatencyHeatmapResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4387,7 +7383,79 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+		file_viz_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LatencyHeatmapColumn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EgressRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EgressResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EgressRow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabelCompatibilityResponse_ProxyVersionReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabelCompatibilityResponse_MissingLabel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Headers_Header); i {
 			case 0:
 				return &v.state
@@ -4399,7 +7467,7 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+		file_viz_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PodErrors_PodError); i {
 			case 0:
 				return &v.state
@@ -4411,7 +7479,7 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+		file_viz_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PodErrors_PodError_ContainerError); i {
 			case 0:
 				return &v.state
@@ -4423,7 +7491,7 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+		file_viz_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StatSummaryResponse_Ok); i {
 			case 0:
 				return &v.state
@@ -4435,7 +7503,7 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+		file_viz_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StatTable_PodGroup); i {
 			case 0:
 				return &v.state
@@ -4447,7 +7515,7 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+		file_viz_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StatTable_PodGroup_Row); i {
 			case 0:
 				return &v.state
@@ -4459,7 +7527,7 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+		file_viz_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EdgesResponse_Ok); i {
 			case 0:
 				return &v.state
@@ -4471,7 +7539,19 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+		file_viz_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DependenciesResponse_Ok); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TopRoutesResponse_Ok); i {
 			case 0:
 				return &v.state
@@ -4483,7 +7563,7 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+		file_viz_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RouteTable_Row); i {
 			case 0:
 				return &v.state
@@ -4495,7 +7575,7 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+		file_viz_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GatewaysTable_Row); i {
 			case 0:
 				return &v.state
@@ -4507,7 +7587,7 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
-		file_viz_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+		file_viz_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GatewaysResponse_Ok); i {
 			case 0:
 				return &v.state
@@ -4519,8 +7599,68 @@ func file_viz_proto_init() {
 				return nil
 			}
 		}
+		file_viz_proto_msgTypes[74].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngressStatsResponse_Ok); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[75].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngressResourceStatsResponse_Ok); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[76].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthzResponse_Ok); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[77].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LatencyHeatmapResponse_Ok); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_proto_msgTypes[78].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EgressResponse_Ok); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_viz_proto_msgTypes[9].OneofWrappers = []interface{}{
+	file_viz_proto_msgTypes[11].OneofWrappers = []interface{}{
 		(*Pod_Deployment)(nil),
 		(*Pod_ReplicaSet)(nil),
 		(*Pod_ReplicationController)(nil),
@@ -4528,51 +7668,75 @@ func file_viz_proto_init() {
 		(*Pod_DaemonSet)(nil),
 		(*Pod_Job)(nil),
 	}
-	file_viz_proto_msgTypes[10].OneofWrappers = []interface{}{
+	file_viz_proto_msgTypes[12].OneofWrappers = []interface{}{
 		(*HttpMethod_Registered_)(nil),
 		(*HttpMethod_Unregistered)(nil),
 	}
-	file_viz_proto_msgTypes[11].OneofWrappers = []interface{}{
+	file_viz_proto_msgTypes[13].OneofWrappers = []interface{}{
 		(*Scheme_Registered_)(nil),
 		(*Scheme_Unregistered)(nil),
 	}
-	file_viz_proto_msgTypes[13].OneofWrappers = []interface{}{
+	file_viz_proto_msgTypes[15].OneofWrappers = []interface{}{
 		(*Eos_GrpcStatusCode)(nil),
 		(*Eos_ResetErrorCode)(nil),
 	}
-	file_viz_proto_msgTypes[19].OneofWrappers = []interface{}{
+	file_viz_proto_msgTypes[21].OneofWrappers = []interface{}{
 		(*StatSummaryRequest_None)(nil),
 		(*StatSummaryRequest_ToResource)(nil),
 		(*StatSummaryRequest_FromResource)(nil),
 	}
-	file_viz_proto_msgTypes[20].OneofWrappers = []interface{}{
+	file_viz_proto_msgTypes[23].OneofWrappers = []interface{}{
 		(*StatSummaryResponse_Ok_)(nil),
 		(*StatSummaryResponse_Error)(nil),
 	}
-	file_viz_proto_msgTypes[25].OneofWrappers = []interface{}{
+	file_viz_proto_msgTypes[31].OneofWrappers = []interface{}{
 		(*StatTable_PodGroup_)(nil),
 	}
-	file_viz_proto_msgTypes[27].OneofWrappers = []interface{}{
+	file_viz_proto_msgTypes[33].OneofWrappers = []interface{}{
 		(*EdgesResponse_Ok_)(nil),
 		(*EdgesResponse_Error)(nil),
 	}
-	file_viz_proto_msgTypes[29].OneofWrappers = []interface{}{
+	file_viz_proto_msgTypes[36].OneofWrappers = []interface{}{
+		(*DependenciesResponse_Ok_)(nil),
+		(*DependenciesResponse_Error)(nil),
+	}
+	file_viz_proto_msgTypes[38].OneofWrappers = []interface{}{
 		(*TopRoutesRequest_None)(nil),
 		(*TopRoutesRequest_ToResource)(nil),
 	}
-	file_viz_proto_msgTypes[30].OneofWrappers = []interface{}{
+	file_viz_proto_msgTypes[39].OneofWrappers = []interface{}{
 		(*TopRoutesResponse_Error)(nil),
 		(*TopRoutesResponse_Ok_)(nil),
 	}
-	file_viz_proto_msgTypes[34].OneofWrappers = []interface{}{
+	file_viz_proto_msgTypes[43].OneofWrappers = []interface{}{
 		(*GatewaysResponse_Ok_)(nil),
 		(*GatewaysResponse_Error)(nil),
 	}
-	file_viz_proto_msgTypes[35].OneofWrappers = []interface{}{
+	file_viz_proto_msgTypes[45].OneofWrappers = []interface{}{
+		(*IngressStatsResponse_Ok_)(nil),
+		(*IngressStatsResponse_Error)(nil),
+	}
+	file_viz_proto_msgTypes[48].OneofWrappers = []interface{}{
+		(*IngressResourceStatsResponse_Ok_)(nil),
+		(*IngressResourceStatsResponse_Error)(nil),
+	}
+	file_viz_proto_msgTypes[51].OneofWrappers = []interface{}{
+		(*AuthzResponse_Ok_)(nil),
+		(*AuthzResponse_Error)(nil),
+	}
+	file_viz_proto_msgTypes[53].OneofWrappers = []interface{}{
+		(*LatencyHeatmapResponse_Ok_)(nil),
+		(*LatencyHeatmapResponse_Error)(nil),
+	}
+	file_viz_proto_msgTypes[56].OneofWrappers = []interface{}{
+		(*EgressResponse_Ok_)(nil),
+		(*EgressResponse_Error)(nil),
+	}
+	file_viz_proto_msgTypes[60].OneofWrappers = []interface{}{
 		(*Headers_Header_ValueStr)(nil),
 		(*Headers_Header_ValueBin)(nil),
 	}
-	file_viz_proto_msgTypes[36].OneofWrappers = []interface{}{
+	file_viz_proto_msgTypes[61].OneofWrappers = []interface{}{
 		(*PodErrors_PodError_Container)(nil),
 	}
 	type x struct{}
@@ -4580,8 +7744,8 @@ func file_viz_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_viz_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   47,
+			NumEnums:      4,
+			NumMessages:   79,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -24,11 +24,18 @@ const _ = grpc.SupportPackageIsVersion7
 type ApiClient interface {
 	StatSummary(ctx context.Context, in *StatSummaryRequest, opts ...grpc.CallOption) (*StatSummaryResponse, error)
 	Edges(ctx context.Context, in *EdgesRequest, opts ...grpc.CallOption) (*EdgesResponse, error)
+	Dependencies(ctx context.Context, in *DependenciesRequest, opts ...grpc.CallOption) (*DependenciesResponse, error)
+	LatencyHeatmap(ctx context.Context, in *LatencyHeatmapRequest, opts ...grpc.CallOption) (*LatencyHeatmapResponse, error)
 	Gateways(ctx context.Context, in *GatewaysRequest, opts ...grpc.CallOption) (*GatewaysResponse, error)
+	IngressStats(ctx context.Context, in *IngressStatsRequest, opts ...grpc.CallOption) (*IngressStatsResponse, error)
+	IngressResourceStats(ctx context.Context, in *IngressResourceStatsRequest, opts ...grpc.CallOption) (*IngressResourceStatsResponse, error)
+	Egress(ctx context.Context, in *EgressRequest, opts ...grpc.CallOption) (*EgressResponse, error)
 	TopRoutes(ctx context.Context, in *TopRoutesRequest, opts ...grpc.CallOption) (*TopRoutesResponse, error)
 	ListPods(ctx context.Context, in *ListPodsRequest, opts ...grpc.CallOption) (*ListPodsResponse, error)
 	ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error)
 	SelfCheck(ctx context.Context, in *SelfCheckRequest, opts ...grpc.CallOption) (*SelfCheckResponse, error)
+	LabelCompatibility(ctx context.Context, in *LabelCompatibilityRequest, opts ...grpc.CallOption) (*LabelCompatibilityResponse, error)
+	Authz(ctx context.Context, in *AuthzRequest, opts ...grpc.CallOption) (*AuthzResponse, error)
 }
 
 type apiClient struct {
@@ -57,6 +64,24 @@ func (c *apiClient) Edges(ctx context.Context, in *EdgesRequest, opts ...grpc.Ca
 	return out, nil
 }
 
+func (c *apiClient) Dependencies(ctx context.Context, in *DependenciesRequest, opts ...grpc.CallOption) (*DependenciesResponse, error) {
+	out := new(DependenciesResponse)
+	err := c.cc.Invoke(ctx, "/linkerd2.viz.Api/Dependencies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiClient) LatencyHeatmap(ctx context.Context, in *LatencyHeatmapRequest, opts ...grpc.CallOption) (*LatencyHeatmapResponse, error) {
+	out := new(LatencyHeatmapResponse)
+	err := c.cc.Invoke(ctx, "/linkerd2.viz.Api/LatencyHeatmap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *apiClient) Gateways(ctx context.Context, in *GatewaysRequest, opts ...grpc.CallOption) (*GatewaysResponse, error) {
 	out := new(GatewaysResponse)
 	err := c.cc.Invoke(ctx, "/linkerd2.viz.Api/Gateways", in, out, opts...)
@@ -66,6 +91,33 @@ func (c *apiClient) Gateways(ctx context.Context, in *GatewaysRequest, opts ...g
 	return out, nil
 }
 
+func (c *apiClient) IngressStats(ctx context.Context, in *IngressStatsRequest, opts ...grpc.CallOption) (*IngressStatsResponse, error) {
+	out := new(IngressStatsResponse)
+	err := c.cc.Invoke(ctx, "/linkerd2.viz.Api/IngressStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiClient) IngressResourceStats(ctx context.Context, in *IngressResourceStatsRequest, opts ...grpc.CallOption) (*IngressResourceStatsResponse, error) {
+	out := new(IngressResourceStatsResponse)
+	err := c.cc.Invoke(ctx, "/linkerd2.viz.Api/IngressResourceStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiClient) Egress(ctx context.Context, in *EgressRequest, opts ...grpc.CallOption) (*EgressResponse, error) {
+	out := new(EgressResponse)
+	err := c.cc.Invoke(ctx, "/linkerd2.viz.Api/Egress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *apiClient) TopRoutes(ctx context.Context, in *TopRoutesRequest, opts ...grpc.CallOption) (*TopRoutesResponse, error) {
 	out := new(TopRoutesResponse)
 	err := c.cc.Invoke(ctx, "/linkerd2.viz.Api/TopRoutes", in, out, opts...)
@@ -102,17 +154,42 @@ func (c *apiClient) SelfCheck(ctx context.Context, in *SelfCheckRequest, opts ..
 	return out, nil
 }
 
+func (c *apiClient) LabelCompatibility(ctx context.Context, in *LabelCompatibilityRequest, opts ...grpc.CallOption) (*LabelCompatibilityResponse, error) {
+	out := new(LabelCompatibilityResponse)
+	err := c.cc.Invoke(ctx, "/linkerd2.viz.Api/LabelCompatibility", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiClient) Authz(ctx context.Context, in *AuthzRequest, opts ...grpc.CallOption) (*AuthzResponse, error) {
+	out := new(AuthzResponse)
+	err := c.cc.Invoke(ctx, "/linkerd2.viz.Api/Authz", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ApiServer is the server API for Api service.
 // All implementations must embed UnimplementedApiServer
 // for forward compatibility
 type ApiServer interface {
 	StatSummary(context.Context, *StatSummaryRequest) (*StatSummaryResponse, error)
 	Edges(context.Context, *EdgesRequest) (*EdgesResponse, error)
+	Dependencies(context.Context, *DependenciesRequest) (*DependenciesResponse, error)
+	LatencyHeatmap(context.Context, *LatencyHeatmapRequest) (*LatencyHeatmapResponse, error)
 	Gateways(context.Context, *GatewaysRequest) (*GatewaysResponse, error)
+	IngressStats(context.Context, *IngressStatsRequest) (*IngressStatsResponse, error)
+	IngressResourceStats(context.Context, *IngressResourceStatsRequest) (*IngressResourceStatsResponse, error)
+	Egress(context.Context, *EgressRequest) (*EgressResponse, error)
 	TopRoutes(context.Context, *TopRoutesRequest) (*TopRoutesResponse, error)
 	ListPods(context.Context, *ListPodsRequest) (*ListPodsResponse, error)
 	ListServices(context.Context, *ListServicesRequest) (*ListServicesResponse, error)
 	SelfCheck(context.Context, *SelfCheckRequest) (*SelfCheckResponse, error)
+	LabelCompatibility(context.Context, *LabelCompatibilityRequest) (*LabelCompatibilityResponse, error)
+	Authz(context.Context, *AuthzRequest) (*AuthzResponse, error)
 	mustEmbedUnimplementedApiServer()
 }
 
@@ -126,9 +203,24 @@ func (UnimplementedApiServer) StatSummary(context.Context, *StatSummaryRequest)
 func (UnimplementedApiServer) Edges(context.Context, *EdgesRequest) (*EdgesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Edges not implemented")
 }
+func (UnimplementedApiServer) Dependencies(context.Context, *DependenciesRequest) (*DependenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Dependencies not implemented")
+}
+func (UnimplementedApiServer) LatencyHeatmap(context.Context, *LatencyHeatmapRequest) (*LatencyHeatmapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LatencyHeatmap not implemented")
+}
 func (UnimplementedApiServer) Gateways(context.Context, *GatewaysRequest) (*GatewaysResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Gateways not implemented")
 }
+func (UnimplementedApiServer) IngressStats(context.Context, *IngressStatsRequest) (*IngressStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IngressStats not implemented")
+}
+func (UnimplementedApiServer) IngressResourceStats(context.Context, *IngressResourceStatsRequest) (*IngressResourceStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IngressResourceStats not implemented")
+}
+func (UnimplementedApiServer) Egress(context.Context, *EgressRequest) (*EgressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Egress not implemented")
+}
 func (UnimplementedApiServer) TopRoutes(context.Context, *TopRoutesRequest) (*TopRoutesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TopRoutes not implemented")
 }
@@ -141,6 +233,12 @@ func (UnimplementedApiServer) ListServices(context.Context, *ListServicesRequest
 func (UnimplementedApiServer) SelfCheck(context.Context, *SelfCheckRequest) (*SelfCheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SelfCheck not implemented")
 }
+func (UnimplementedApiServer) LabelCompatibility(context.Context, *LabelCompatibilityRequest) (*LabelCompatibilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LabelCompatibility not implemented")
+}
+func (UnimplementedApiServer) Authz(context.Context, *AuthzRequest) (*AuthzResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Authz not implemented")
+}
 func (UnimplementedApiServer) mustEmbedUnimplementedApiServer() {}
 
 // UnsafeApiServer may be embedded to opt out of forward compatibility for this service.
@@ -190,6 +288,42 @@ func _Api_Edges_Handler(srv interface{}, ctx context.Context, dec func(interface
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Api_Dependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServer).Dependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/linkerd2.viz.Api/Dependencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServer).Dependencies(ctx, req.(*DependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Api_LatencyHeatmap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LatencyHeatmapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServer).LatencyHeatmap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/linkerd2.viz.Api/LatencyHeatmap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServer).LatencyHeatmap(ctx, req.(*LatencyHeatmapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Api_Gateways_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GatewaysRequest)
 	if err := dec(in); err != nil {
@@ -208,6 +342,60 @@ func _Api_Gateways_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Api_IngressStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngressStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServer).IngressStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/linkerd2.viz.Api/IngressStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServer).IngressStats(ctx, req.(*IngressStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Api_IngressResourceStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngressResourceStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServer).IngressResourceStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/linkerd2.viz.Api/IngressResourceStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServer).IngressResourceStats(ctx, req.(*IngressResourceStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Api_Egress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServer).Egress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/linkerd2.viz.Api/Egress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServer).Egress(ctx, req.(*EgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Api_TopRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TopRoutesRequest)
 	if err := dec(in); err != nil {
@@ -280,6 +468,42 @@ func _Api_SelfCheck_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Api_LabelCompatibility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelCompatibilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServer).LabelCompatibility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/linkerd2.viz.Api/LabelCompatibility",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServer).LabelCompatibility(ctx, req.(*LabelCompatibilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Api_Authz_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthzRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServer).Authz(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/linkerd2.viz.Api/Authz",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServer).Authz(ctx, req.(*AuthzRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Api_ServiceDesc is the grpc.ServiceDesc for Api service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -295,10 +519,30 @@ var Api_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Edges",
 			Handler:    _Api_Edges_Handler,
 		},
+		{
+			MethodName: "Dependencies",
+			Handler:    _Api_Dependencies_Handler,
+		},
+		{
+			MethodName: "LatencyHeatmap",
+			Handler:    _Api_LatencyHeatmap_Handler,
+		},
 		{
 			MethodName: "Gateways",
 			Handler:    _Api_Gateways_Handler,
 		},
+		{
+			MethodName: "IngressStats",
+			Handler:    _Api_IngressStats_Handler,
+		},
+		{
+			MethodName: "IngressResourceStats",
+			Handler:    _Api_IngressResourceStats_Handler,
+		},
+		{
+			MethodName: "Egress",
+			Handler:    _Api_Egress_Handler,
+		},
 		{
 			MethodName: "TopRoutes",
 			Handler:    _Api_TopRoutes_Handler,
@@ -315,6 +559,14 @@ var Api_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SelfCheck",
 			Handler:    _Api_SelfCheck_Handler,
 		},
+		{
+			MethodName: "LabelCompatibility",
+			Handler:    _Api_LabelCompatibility_Handler,
+		},
+		{
+			MethodName: "Authz",
+			Handler:    _Api_Authz_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "viz.proto",
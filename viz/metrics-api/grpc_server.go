@@ -30,6 +30,20 @@ type grpcServer struct {
 	controllerNamespace string
 	clusterDomain       string
 	ignoredNamespaces   []string
+	labelCompat         labelCompatReport
+	queryLimiter        *queryLimiter
+	queryLog            queryLog
+	// maxQuerySeries is the number of resources the stat queries are bounded
+	// to; 0 means no limit
+	maxQuerySeries int
+	// namespaceAliases is nil when the namespaces aren't aliased
+	namespaceAliases *namespaceAliases
+	// metricNames is nil when the proxy metrics aren't renamed
+	metricNames *metricNames
+	// rowLabels are the labels of the resources copied into their StatTable
+	// rows
+	rowLabels        []string
+	prometheusHealth *prometheusHealth
 }
 
 type podReport struct {
@@ -59,6 +73,7 @@ func newGrpcServer(
 		controllerNamespace: controllerNamespace,
 		clusterDomain:       clusterDomain,
 		ignoredNamespaces:   ignoredNamespaces,
+		prometheusHealth:    &prometheusHealth{},
 	}
 
 	pb.RegisterApiServer(prometheus.NewGrpcServer(), grpcServer)
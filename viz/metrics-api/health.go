@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/linkerd/linkerd2/viz/metrics-api/client"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthPb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// prometheusHealthTTL is the duration the outcome of a query is trusted
+	// for; past it, the health checks probe Prometheus again
+	prometheusHealthTTL  = 30 * time.Second
+	prometheusProbeQuery = "vector(1)"
+	healthProbeTimeout   = 5 * time.Second
+)
+
+type (
+	// healthServer implements the standard gRPC health service on top of the
+	// metrics-api
+	healthServer struct {
+		healthPb.UnimplementedHealthServer
+		grpcServer *grpcServer
+	}
+
+	// prometheusHealth tracks the outcome of the most recent Prometheus query
+	prometheusHealth struct {
+		sync.Mutex
+		checkedAt time.Time
+		err       error
+	}
+)
+
+// Check implements healthPb.HealthServer
+func (h *healthServer) Check(ctx context.Context, req *healthPb.HealthCheckRequest) (*healthPb.HealthCheckResponse, error) {
+	switch req.GetService() {
+	case "":
+		return &healthPb.HealthCheckResponse{Status: healthPb.HealthCheckResponse_SERVING}, nil
+	case client.PrometheusHealthService:
+		if err := h.grpcServer.checkPrometheus(ctx); err != nil {
+			log.Debugf("Prometheus health check failed: %s", err)
+			return &healthPb.HealthCheckResponse{Status: healthPb.HealthCheckResponse_NOT_SERVING}, nil
+		}
+		return &healthPb.HealthCheckResponse{Status: healthPb.HealthCheckResponse_SERVING}, nil
+	default:
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+}
+
+// NewHealthProbeHandler returns a handler querying the gRPC health service
+// served at addr, for the kubelet probes, which can't speak gRPC on the
+// Kubernetes versions Linkerd supports
+func NewHealthProbeHandler(addr string) (http.Handler, error) {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	healthClient := healthPb.NewHealthClient(conn)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx, cancel := context.WithTimeout(req.Context(), healthProbeTimeout)
+		defer cancel()
+		rsp, err := healthClient.Check(ctx, &healthPb.HealthCheckRequest{})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		if rsp.GetStatus() != healthPb.HealthCheckResponse_SERVING {
+			http.Error(w, rsp.GetStatus().String(), http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	}), nil
+}
+
+// checkPrometheus returns the error of the most recent Prometheus query,
+// probing Prometheus if there was none recently
+func (s *grpcServer) checkPrometheus(ctx context.Context) error {
+	if s.prometheusAPI == nil {
+		return ErrNoPrometheusInstance
+	}
+	if fresh, err := s.prometheusHealth.get(); fresh {
+		return err
+	}
+	// the outcome is recorded by queryProm
+	_, err := s.queryProm(ctx, prometheusProbeQuery)
+	return err
+}
+
+// observe records the outcome of a Prometheus query. The queries cancelled
+// by their clients say nothing about Prometheus and are ignored.
+func (ph *prometheusHealth) observe(err error) {
+	if ph == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+	ph.Lock()
+	defer ph.Unlock()
+	ph.checkedAt = time.Now()
+	ph.err = err
+}
+
+// get returns whether there was a Prometheus query recently, and the error
+// of the most recent one
+func (ph *prometheusHealth) get() (bool, error) {
+	if ph == nil {
+		return false, nil
+	}
+	ph.Lock()
+	defer ph.Unlock()
+	if ph.checkedAt.IsZero() || time.Since(ph.checkedAt) > prometheusHealthTTL {
+		return false, nil
+	}
+	return true, ph.err
+}
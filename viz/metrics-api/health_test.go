@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/viz/metrics-api/client"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"google.golang.org/grpc"
+	healthPb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// failingProm is a Prometheus API whose queries fail
+type failingProm struct {
+	promv1.API
+	queries int
+}
+
+func (p *failingProm) Query(context.Context, string, time.Time) (model.Value, promv1.Warnings, error) {
+	p.queries++
+	return nil, nil, errors.New("connection refused")
+}
+
+func TestHealthCheck(t *testing.T) {
+	t.Run("Serves the metrics-api and Prometheus when reachable", func(t *testing.T) {
+		mockProm, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{mockPromResponse: model.Vector{}})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+		health := &healthServer{grpcServer: fakeGrpcServer}
+
+		for _, service := range []string{"", client.PrometheusHealthService} {
+			rsp, err := health.Check(context.Background(), &healthPb.HealthCheckRequest{Service: service})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if rsp.GetStatus() != healthPb.HealthCheckResponse_SERVING {
+				t.Fatalf("Expected service %q to be serving, got %s", service, rsp.GetStatus())
+			}
+		}
+		if len(mockProm.QueriesExecuted) != 1 || mockProm.QueriesExecuted[0] != prometheusProbeQuery {
+			t.Fatalf("Expected Prometheus to be probed once, got queries %v", mockProm.QueriesExecuted)
+		}
+	})
+
+	t.Run("Reports Prometheus as not serving when its queries fail", func(t *testing.T) {
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+		prom := &failingProm{}
+		fakeGrpcServer.prometheusAPI = prom
+		health := &healthServer{grpcServer: fakeGrpcServer}
+
+		for i := 0; i < 2; i++ {
+			rsp, err := health.Check(context.Background(), &healthPb.HealthCheckRequest{Service: client.PrometheusHealthService})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if rsp.GetStatus() != healthPb.HealthCheckResponse_NOT_SERVING {
+				t.Fatalf("Expected Prometheus not to be serving, got %s", rsp.GetStatus())
+			}
+		}
+		if prom.queries != 1 {
+			t.Fatalf("Expected the outcome of the probe to be reused, got %d queries", prom.queries)
+		}
+
+		rsp, err := health.Check(context.Background(), &healthPb.HealthCheckRequest{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if rsp.GetStatus() != healthPb.HealthCheckResponse_SERVING {
+			t.Fatalf("Expected the metrics-api to be serving, got %s", rsp.GetStatus())
+		}
+	})
+
+	t.Run("Rejects unknown services", func(t *testing.T) {
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+		health := &healthServer{grpcServer: fakeGrpcServer}
+
+		if _, err := health.Check(context.Background(), &healthPb.HealthCheckRequest{Service: "tap"}); err == nil {
+			t.Fatal("Expected an error, got nil")
+		}
+	})
+}
+
+func TestHealthProbeHandler(t *testing.T) {
+	_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{})
+	if err != nil {
+		t.Fatalf("Error creating mock grpc server: %s", err)
+	}
+	s := grpc.NewServer()
+	healthPb.RegisterHealthServer(s, &healthServer{grpcServer: fakeGrpcServer})
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	go s.Serve(lis)
+	defer s.Stop()
+
+	handler, err := NewHealthProbeHandler(lis.Addr().String())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	s.Stop()
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected status %d once the gRPC server stopped, got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/pkg/prometheus"
@@ -12,26 +13,42 @@ import (
 	promApi "github.com/prometheus/client_golang/api"
 	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	healthPb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 var (
-	gatewaysPath     = fullURLPathFor("Gateways")
-	statSummaryPath  = fullURLPathFor("StatSummary")
-	topRoutesPath    = fullURLPathFor("TopRoutes")
-	listPodsPath     = fullURLPathFor("ListPods")
-	listServicesPath = fullURLPathFor("ListServices")
-	selfCheckPath    = fullURLPathFor("SelfCheck")
-	edgesPath        = fullURLPathFor("Edges")
+	gatewaysPath             = fullURLPathFor("Gateways")
+	ingressStatsPath         = fullURLPathFor("IngressStats")
+	ingressResourceStatsPath = fullURLPathFor("IngressResourceStats")
+	egressPath               = fullURLPathFor("Egress")
+	statSummaryPath          = fullURLPathFor("StatSummary")
+	topRoutesPath            = fullURLPathFor("TopRoutes")
+	listPodsPath             = fullURLPathFor("ListPods")
+	listServicesPath         = fullURLPathFor("ListServices")
+	selfCheckPath            = fullURLPathFor("SelfCheck")
+	labelCompatibilityPath   = fullURLPathFor("LabelCompatibility")
+	authzPath                = fullURLPathFor("Authz")
+	edgesPath                = fullURLPathFor("Edges")
+	dependenciesPath         = fullURLPathFor("Dependencies")
+	latencyHeatmapPath       = fullURLPathFor("LatencyHeatmap")
+	healthCheckPath          = fullURLPathFor("HealthCheck")
 )
 
 type handler struct {
-	grpcServer Server
+	grpcServer   Server
+	healthServer healthPb.HealthServer
 }
 
 func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	log.WithFields(log.Fields{
 		"req.Method": req.Method, "req.URL": req.URL, "req.Form": req.Form,
 	}).Debugf("Serving %s %s", req.Method, req.URL.Path)
+	if isJSONAPIPath(req.URL.Path) {
+		h.serveJSON(w, req)
+		return
+	}
+
 	// Validate request method
 	if req.Method != http.MethodPost {
 		protohttp.WriteErrorToHTTPResponse(w, fmt.Errorf("POST required"))
@@ -42,6 +59,12 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	switch req.URL.Path {
 	case gatewaysPath:
 		h.handleGateways(w, req)
+	case ingressStatsPath:
+		h.handleIngressStats(w, req)
+	case ingressResourceStatsPath:
+		h.handleIngressResourceStats(w, req)
+	case egressPath:
+		h.handleEgress(w, req)
 	case statSummaryPath:
 		h.handleStatSummary(w, req)
 	case topRoutesPath:
@@ -52,8 +75,18 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		h.handleListServices(w, req)
 	case selfCheckPath:
 		h.handleSelfCheck(w, req)
+	case labelCompatibilityPath:
+		h.handleLabelCompatibility(w, req)
+	case authzPath:
+		h.handleAuthz(w, req)
 	case edgesPath:
 		h.handleEdges(w, req)
+	case dependenciesPath:
+		h.handleDependencies(w, req)
+	case latencyHeatmapPath:
+		h.handleLatencyHeatmap(w, req)
+	case healthCheckPath:
+		h.handleHealthCheck(w, req)
 	default:
 		http.NotFound(w, req)
 	}
@@ -81,6 +114,69 @@ func (h *handler) handleGateways(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+func (h *handler) handleIngressStats(w http.ResponseWriter, req *http.Request) {
+	var protoRequest pb.IngressStatsRequest
+
+	err := protohttp.HTTPRequestToProto(req, &protoRequest)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+
+	rsp, err := h.grpcServer.IngressStats(req.Context(), &protoRequest)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+	err = protohttp.WriteProtoToHTTPResponse(w, rsp)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+}
+
+func (h *handler) handleEgress(w http.ResponseWriter, req *http.Request) {
+	var protoRequest pb.EgressRequest
+
+	err := protohttp.HTTPRequestToProto(req, &protoRequest)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+
+	rsp, err := h.grpcServer.Egress(req.Context(), &protoRequest)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+	err = protohttp.WriteProtoToHTTPResponse(w, rsp)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+}
+
+func (h *handler) handleIngressResourceStats(w http.ResponseWriter, req *http.Request) {
+	var protoRequest pb.IngressResourceStatsRequest
+
+	err := protohttp.HTTPRequestToProto(req, &protoRequest)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+
+	rsp, err := h.grpcServer.IngressResourceStats(req.Context(), &protoRequest)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+	err = protohttp.WriteProtoToHTTPResponse(w, rsp)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+}
+
 func (h *handler) handleStatSummary(w http.ResponseWriter, req *http.Request) {
 	var protoRequest pb.StatSummaryRequest
 
@@ -123,6 +219,48 @@ func (h *handler) handleEdges(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+func (h *handler) handleDependencies(w http.ResponseWriter, req *http.Request) {
+	var protoRequest pb.DependenciesRequest
+
+	err := protohttp.HTTPRequestToProto(req, &protoRequest)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+
+	rsp, err := h.grpcServer.Dependencies(req.Context(), &protoRequest)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+	err = protohttp.WriteProtoToHTTPResponse(w, rsp)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+}
+
+func (h *handler) handleLatencyHeatmap(w http.ResponseWriter, req *http.Request) {
+	var protoRequest pb.LatencyHeatmapRequest
+
+	err := protohttp.HTTPRequestToProto(req, &protoRequest)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+
+	rsp, err := h.grpcServer.LatencyHeatmap(req.Context(), &protoRequest)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+	err = protohttp.WriteProtoToHTTPResponse(w, rsp)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+}
+
 func (h *handler) handleTopRoutes(w http.ResponseWriter, req *http.Request) {
 	var protoRequest pb.TopRoutesRequest
 
@@ -165,6 +303,48 @@ func (h *handler) handleSelfCheck(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+func (h *handler) handleAuthz(w http.ResponseWriter, req *http.Request) {
+	var protoRequest pb.AuthzRequest
+	err := protohttp.HTTPRequestToProto(req, &protoRequest)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+
+	rsp, err := h.grpcServer.Authz(req.Context(), &protoRequest)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+
+	err = protohttp.WriteProtoToHTTPResponse(w, rsp)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+}
+
+func (h *handler) handleLabelCompatibility(w http.ResponseWriter, req *http.Request) {
+	var protoRequest pb.LabelCompatibilityRequest
+	err := protohttp.HTTPRequestToProto(req, &protoRequest)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+
+	rsp, err := h.grpcServer.LabelCompatibility(req.Context(), &protoRequest)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+
+	err = protohttp.WriteProtoToHTTPResponse(w, rsp)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+}
+
 func (h *handler) handleListPods(w http.ResponseWriter, req *http.Request) {
 	var protoRequest pb.ListPodsRequest
 	err := protohttp.HTTPRequestToProto(req, &protoRequest)
@@ -208,11 +388,33 @@ func (h *handler) handleListServices(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+func (h *handler) handleHealthCheck(w http.ResponseWriter, req *http.Request) {
+	var protoRequest healthPb.HealthCheckRequest
+	err := protohttp.HTTPRequestToProto(req, &protoRequest)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+
+	rsp, err := h.healthServer.Check(req.Context(), &protoRequest)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+
+	err = protohttp.WriteProtoToHTTPResponse(w, rsp)
+	if err != nil {
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+}
+
 func fullURLPathFor(method string) string {
 	return client.APIRoot + client.APIPrefix + method
 }
 
-// NewServer creates a Public API HTTP server.
+// NewServer creates a Public API HTTP server, along with a gRPC server serving
+// the standard health service.
 func NewServer(
 	addr string,
 	prometheusClient promApi.Client,
@@ -220,7 +422,18 @@ func NewServer(
 	controllerNamespace string,
 	clusterDomain string,
 	ignoredNamespaces []string,
-) *http.Server {
+	labelCheckInterval time.Duration,
+	maxConcurrentQueries int,
+	queryQueueTimeout time.Duration,
+	maxQuerySeries int,
+	logQueries bool,
+	slowQueryThreshold time.Duration,
+	namespaceAliases map[string]string,
+	rowLabels []string,
+	metricPrefix string,
+	metricNames map[string]string,
+	stop <-chan struct{},
+) (*http.Server, *grpc.Server) {
 
 	var promAPI promv1.API
 	if prometheusClient != nil {
@@ -234,14 +447,29 @@ func NewServer(
 		clusterDomain,
 		ignoredNamespaces,
 	)
+	grpcServer.queryLimiter = newQueryLimiter(maxConcurrentQueries, queryQueueTimeout)
+	grpcServer.maxQuerySeries = maxQuerySeries
+	grpcServer.queryLog = queryLog{all: logQueries, slowThreshold: slowQueryThreshold}
+	grpcServer.namespaceAliases = newNamespaceAliases(namespaceAliases)
+	grpcServer.rowLabels = rowLabels
+	grpcServer.metricNames = newMetricNames(metricPrefix, metricNames)
+	if promAPI != nil && labelCheckInterval > 0 {
+		go grpcServer.runLabelCompatibilityChecks(labelCheckInterval, stop)
+	}
+
+	health := &healthServer{grpcServer: grpcServer}
 	baseHandler := &handler{
-		grpcServer: grpcServer,
+		grpcServer:   grpcServer,
+		healthServer: health,
 	}
 
 	instrumentedHandler := prometheus.WithTelemetry(baseHandler)
 
+	healthGrpcServer := prometheus.NewGrpcServer()
+	healthPb.RegisterHealthServer(healthGrpcServer, health)
+
 	return &http.Server{
 		Addr:    addr,
 		Handler: instrumentedHandler,
-	}
+	}, healthGrpcServer
 }
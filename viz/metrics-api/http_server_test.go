@@ -33,6 +33,21 @@ func (m *mockGrpcServer) Gateways(ctx context.Context, req *pb.GatewaysRequest)
 	return m.ResponseToReturn.(*pb.GatewaysResponse), m.ErrorToReturn
 }
 
+func (m *mockGrpcServer) IngressStats(ctx context.Context, req *pb.IngressStatsRequest) (*pb.IngressStatsResponse, error) {
+	m.LastRequestReceived = req
+	return m.ResponseToReturn.(*pb.IngressStatsResponse), m.ErrorToReturn
+}
+
+func (m *mockGrpcServer) Egress(ctx context.Context, req *pb.EgressRequest) (*pb.EgressResponse, error) {
+	m.LastRequestReceived = req
+	return m.ResponseToReturn.(*pb.EgressResponse), m.ErrorToReturn
+}
+
+func (m *mockGrpcServer) IngressResourceStats(ctx context.Context, req *pb.IngressResourceStatsRequest) (*pb.IngressResourceStatsResponse, error) {
+	m.LastRequestReceived = req
+	return m.ResponseToReturn.(*pb.IngressResourceStatsResponse), m.ErrorToReturn
+}
+
 func (m *mockGrpcServer) TopRoutes(ctx context.Context, req *pb.TopRoutesRequest) (*pb.TopRoutesResponse, error) {
 	m.LastRequestReceived = req
 	return m.ResponseToReturn.(*pb.TopRoutesResponse), m.ErrorToReturn
@@ -43,6 +58,11 @@ func (m *mockGrpcServer) Edges(ctx context.Context, req *pb.EdgesRequest) (*pb.E
 	return m.ResponseToReturn.(*pb.EdgesResponse), m.ErrorToReturn
 }
 
+func (m *mockGrpcServer) Dependencies(ctx context.Context, req *pb.DependenciesRequest) (*pb.DependenciesResponse, error) {
+	m.LastRequestReceived = req
+	return m.ResponseToReturn.(*pb.DependenciesResponse), m.ErrorToReturn
+}
+
 func (m *mockGrpcServer) ListPods(ctx context.Context, req *pb.ListPodsRequest) (*pb.ListPodsResponse, error) {
 	m.LastRequestReceived = req
 	return m.ResponseToReturn.(*pb.ListPodsResponse), m.ErrorToReturn
@@ -58,6 +78,11 @@ func (m *mockGrpcServer) SelfCheck(ctx context.Context, req *pb.SelfCheckRequest
 	return m.ResponseToReturn.(*pb.SelfCheckResponse), m.ErrorToReturn
 }
 
+func (m *mockGrpcServer) LabelCompatibility(ctx context.Context, req *pb.LabelCompatibilityRequest) (*pb.LabelCompatibilityResponse, error) {
+	m.LastRequestReceived = req
+	return m.ResponseToReturn.(*pb.LabelCompatibilityResponse), m.ErrorToReturn
+}
+
 type grpcCallTestCase struct {
 	expectedRequest  proto.Message
 	expectedResponse proto.Message
@@ -86,7 +111,20 @@ func TestServer(t *testing.T) {
 			functionCall:     func() (proto.Message, error) { return client.StatSummary(context.TODO(), statSummaryReq) },
 		}
 
-		for _, testCase := range []grpcCallTestCase{testListPods, testStatSummary} {
+		labelCompatibilityReq := &pb.LabelCompatibilityRequest{}
+		testLabelCompatibility := grpcCallTestCase{
+			expectedRequest: labelCompatibilityReq,
+			expectedResponse: &pb.LabelCompatibilityResponse{
+				Reports: []*pb.LabelCompatibilityResponse_ProxyVersionReport{
+					{ProxyVersion: "stable-2.11.1", PodCount: 1},
+				},
+			},
+			functionCall: func() (proto.Message, error) {
+				return client.LabelCompatibility(context.TODO(), labelCompatibilityReq)
+			},
+		}
+
+		for _, testCase := range []grpcCallTestCase{testListPods, testStatSummary, testLabelCompatibility} {
 			assertCallWasForwarded(t, &mockGrpcServer.mockServer, testCase.expectedRequest, testCase.expectedResponse, testCase.functionCall)
 		}
 	})
@@ -0,0 +1,429 @@
+package api
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	log "github.com/sirupsen/logrus"
+	networkingv1 "k8s.io/api/networking/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	ingressResourceType = "ingress"
+	gatewayResourceType = "gateway"
+
+	// ingressClassAnnotation is the annotation setting the class of the
+	// Ingresses predating their ingressClassName field
+	ingressClassAnnotation = "kubernetes.io/ingress.class"
+)
+
+// ingressResourceKey identifies an Ingress or a Gateway
+type ingressResourceKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+type serviceKey struct {
+	namespace string
+	name      string
+}
+
+// ingressResource is an Ingress or a Gateway, along with the stats of the
+// requests it routes
+type ingressResource struct {
+	class    string
+	backends map[serviceKey]struct{}
+	stats    *pb.BasicStats
+}
+
+// ingressRoute routes the requests for its hosts to its backends on behalf of
+// an Ingress or a Gateway: a rule of an Ingress, or an HTTPRoute attached to a
+// Gateway. A route without hosts routes the requests for any host. created is
+// the creation time of the Ingress or the HTTPRoute, as the oldest one wins
+// the conflicts between routes.
+type ingressRoute struct {
+	owner    ingressResourceKey
+	class    string
+	hosts    []string
+	backends map[serviceKey]struct{}
+	created  metav1.Time
+}
+
+func (s *grpcServer) IngressResourceStats(ctx context.Context, req *pb.IngressResourceStatsRequest) (*pb.IngressResourceStatsResponse, error) {
+	log.Debugf("IngressResourceStats request: %+v", req)
+
+	timeWindow := req.GetTimeWindow()
+	if timeWindow == "" {
+		timeWindow = defaultIngressStatsTime
+	}
+
+	routes, err := s.getIngressRoutes(ctx, req.GetNamespace())
+	if err != nil {
+		return ingressResourceStatsError(err.Error()), nil
+	}
+	resources := make(map[ingressResourceKey]*ingressResource)
+	for _, route := range routes {
+		resource, ok := resources[route.owner]
+		if !ok {
+			resource = &ingressResource{class: route.class, backends: make(map[serviceKey]struct{})}
+			resources[route.owner] = resource
+		}
+		for backend := range route.backends {
+			resource.backends[backend] = struct{}{}
+		}
+	}
+
+	if len(resources) > 0 {
+		// the controllers usually live in their own namespace, so they're
+		// looked up in all of them
+		controllers, err := s.getIngressControllers(ctx, "")
+		if err != nil {
+			return ingressResourceStatsError(err.Error()), nil
+		}
+		stats, err := s.getIngressMetrics(ctx, controllers, timeWindow, true)
+		if err != nil {
+			return ingressResourceStatsError(err.Error()), nil
+		}
+		for key, basicStats := range stats {
+			backend := serviceKey{namespace: key.namespace, name: key.service}
+			if owner, ok := matchIngressRoute(routes, controllers[key.controller], backend, key.authority); ok {
+				resources[owner].addStats(basicStats)
+			}
+		}
+	}
+
+	rows := make([]*pb.IngressResourceStatsRow, 0, len(resources))
+	for key, resource := range resources {
+		backends := make([]*pb.Resource, 0, len(resource.backends))
+		for backend := range resource.backends {
+			backends = append(backends, &pb.Resource{
+				Namespace: backend.namespace,
+				Type:      k8s.Service,
+				Name:      backend.name,
+			})
+		}
+		sort.Slice(backends, func(i, j int) bool {
+			if backends[i].GetNamespace() != backends[j].GetNamespace() {
+				return backends[i].GetNamespace() < backends[j].GetNamespace()
+			}
+			return backends[i].GetName() < backends[j].GetName()
+		})
+
+		rows = append(rows, &pb.IngressResourceStatsRow{
+			Resource: &pb.Resource{
+				Namespace: key.namespace,
+				Type:      key.kind,
+				Name:      key.name,
+			},
+			IngressClass: resource.class,
+			Backends:     backends,
+			Stats:        resource.stats,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return ingressResourceRowKey(rows[i]) < ingressResourceRowKey(rows[j])
+	})
+
+	return &pb.IngressResourceStatsResponse{
+		Response: &pb.IngressResourceStatsResponse_Ok_{
+			Ok: &pb.IngressResourceStatsResponse_Ok{
+				Rows: rows,
+			},
+		},
+	}, nil
+}
+
+// addStats adds the requests of a backend to the stats of the resource. The
+// latencies can't be combined, so the highest ones are kept.
+func (r *ingressResource) addStats(stats *pb.BasicStats) {
+	if r.stats == nil {
+		r.stats = &pb.BasicStats{}
+	}
+	r.stats.SuccessCount += stats.GetSuccessCount()
+	r.stats.FailureCount += stats.GetFailureCount()
+	if stats.GetLatencyMsP50() > r.stats.LatencyMsP50 {
+		r.stats.LatencyMsP50 = stats.GetLatencyMsP50()
+	}
+	if stats.GetLatencyMsP95() > r.stats.LatencyMsP95 {
+		r.stats.LatencyMsP95 = stats.GetLatencyMsP95()
+	}
+	if stats.GetLatencyMsP99() > r.stats.LatencyMsP99 {
+		r.stats.LatencyMsP99 = stats.GetLatencyMsP99()
+	}
+}
+
+// getIngressRoutes returns the routes of the Ingresses and the Gateways of
+// namespace, or of all namespaces if it's empty
+func (s *grpcServer) getIngressRoutes(ctx context.Context, namespace string) ([]ingressRoute, error) {
+	ingresses, err := s.k8sAPI.Client.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	routes := []ingressRoute{}
+	for i := range ingresses.Items {
+		routes = append(routes, ingressRoutesFor(&ingresses.Items[i])...)
+	}
+
+	gatewayRoutes, err := s.getGatewayRoutes(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return append(routes, gatewayRoutes...), nil
+}
+
+// ingressRoutesFor returns a route per rule of the Ingress, and one for its
+// default backend
+func ingressRoutesFor(ingress *networkingv1.Ingress) []ingressRoute {
+	owner := ingressResourceKey{kind: ingressResourceType, namespace: ingress.GetNamespace(), name: ingress.GetName()}
+	class := ingress.GetAnnotations()[ingressClassAnnotation]
+	if ingress.Spec.IngressClassName != nil {
+		class = *ingress.Spec.IngressClassName
+	}
+	route := func(host string) ingressRoute {
+		r := ingressRoute{owner: owner, class: class, backends: make(map[serviceKey]struct{}), created: ingress.GetCreationTimestamp()}
+		if host != "" {
+			r.hosts = []string{host}
+		}
+		return r
+	}
+
+	routes := []ingressRoute{}
+	if backend := ingress.Spec.DefaultBackend; backend != nil && backend.Service != nil {
+		r := route("")
+		r.backends[serviceKey{namespace: ingress.GetNamespace(), name: backend.Service.Name}] = struct{}{}
+		routes = append(routes, r)
+	}
+	for _, rule := range ingress.Spec.Rules {
+		r := route(rule.Host)
+		if rule.HTTP != nil {
+			for _, path := range rule.HTTP.Paths {
+				if path.Backend.Service != nil {
+					r.backends[serviceKey{namespace: ingress.GetNamespace(), name: path.Backend.Service.Name}] = struct{}{}
+				}
+			}
+		}
+		routes = append(routes, r)
+	}
+	if len(routes) == 0 {
+		// report the Ingress even though it doesn't route anything yet
+		routes = append(routes, route(""))
+	}
+	return routes
+}
+
+// getGatewayRoutes returns a route per HTTPRoute attached to the Gateways of
+// namespace, or of all namespaces if it's empty. There are no routes when the
+// Gateway API isn't installed.
+func (s *grpcServer) getGatewayRoutes(ctx context.Context, namespace string) ([]ingressRoute, error) {
+	gatewayGVR, err := k8s.GatewayAPIGVR(s.k8sAPI.Client.Discovery(), k8s.GatewayResource)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	gateways, err := s.k8sAPI.DynamicClient.Resource(gatewayGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(gateways.Items) == 0 {
+		return nil, nil
+	}
+
+	routes := []ingressRoute{}
+	classes := make(map[ingressResourceKey]string)
+	for _, gateway := range gateways.Items {
+		owner := ingressResourceKey{kind: gatewayResourceType, namespace: gateway.GetNamespace(), name: gateway.GetName()}
+		class, _, _ := unstructured.NestedString(gateway.Object, "spec", "gatewayClassName")
+		classes[owner] = class
+		// report the Gateway even though no HTTPRoute is attached to it yet
+		routes = append(routes, ingressRoute{owner: owner, class: class, backends: map[serviceKey]struct{}{}})
+	}
+
+	// the HTTPRoutes may be attached to Gateways of other namespaces
+	httpRouteGVR, err := k8s.GatewayAPIGVR(s.k8sAPI.Client.Discovery(), k8s.HTTPRouteResource)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return routes, nil
+		}
+		return nil, err
+	}
+	httpRoutes, err := s.k8sAPI.DynamicClient.Resource(httpRouteGVR).Namespace("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return routes, nil
+		}
+		return nil, err
+	}
+	for i := range httpRoutes.Items {
+		httpRoute := &httpRoutes.Items[i]
+		hosts, _, _ := unstructured.NestedStringSlice(httpRoute.Object, "spec", "hostnames")
+		backends := httpRouteBackends(httpRoute)
+		parents, _, _ := unstructured.NestedSlice(httpRoute.Object, "spec", "parentRefs")
+		for _, parent := range parents {
+			ref, ok := parent.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if kind, ok := ref["kind"].(string); ok && kind != "Gateway" {
+				continue
+			}
+			owner := ingressResourceKey{kind: gatewayResourceType, namespace: httpRoute.GetNamespace()}
+			owner.name, _ = ref["name"].(string)
+			if ns, ok := ref["namespace"].(string); ok && ns != "" {
+				owner.namespace = ns
+			}
+			class, ok := classes[owner]
+			if !ok {
+				continue
+			}
+			routes = append(routes, ingressRoute{owner: owner, class: class, hosts: hosts, backends: backends, created: httpRoute.GetCreationTimestamp()})
+		}
+	}
+	return routes, nil
+}
+
+// httpRouteBackends returns the services the rules of the HTTPRoute send the
+// requests to
+func httpRouteBackends(httpRoute *unstructured.Unstructured) map[serviceKey]struct{} {
+	backends := make(map[serviceKey]struct{})
+	rules, _, _ := unstructured.NestedSlice(httpRoute.Object, "spec", "rules")
+	for _, r := range rules {
+		rule, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		refs, _, _ := unstructured.NestedSlice(rule, "backendRefs")
+		for _, r := range refs {
+			ref, ok := r.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if kind, ok := ref["kind"].(string); ok && kind != "Service" {
+				continue
+			}
+			backend := serviceKey{namespace: httpRoute.GetNamespace()}
+			backend.name, _ = ref["name"].(string)
+			if ns, ok := ref["namespace"].(string); ok && ns != "" {
+				backend.namespace = ns
+			}
+			backends[backend] = struct{}{}
+		}
+	}
+	return backends
+}
+
+// matchIngressRoute returns the Ingress or Gateway routing the requests sent
+// by a controller of the given class to backend for authority, so that they're
+// counted once even when several routes match them. The routes naming the host
+// of the authority take precedence over the wildcard ones, which take
+// precedence over the ones for any host; the oldest route wins the remaining
+// conflicts. The routes of another class are left out.
+func matchIngressRoute(routes []ingressRoute, class string, backend serviceKey, authority string) (ingressResourceKey, bool) {
+	host := authority
+	if h, _, err := net.SplitHostPort(authority); err == nil {
+		host = h
+	}
+
+	const (
+		noHostMatch = iota
+		anyHostMatch
+		wildcardHostMatch
+		exactHostMatch
+	)
+	var best *ingressRoute
+	bestMatch := noHostMatch
+	for i := range routes {
+		route := &routes[i]
+		if class != "" && route.class != "" && class != route.class {
+			continue
+		}
+		if _, ok := route.backends[backend]; !ok {
+			continue
+		}
+		match := noHostMatch
+		if len(route.hosts) == 0 {
+			match = anyHostMatch
+		}
+		for _, pattern := range route.hosts {
+			if !hostMatch(pattern, host) {
+				continue
+			}
+			if strings.HasPrefix(pattern, "*.") {
+				if match < wildcardHostMatch {
+					match = wildcardHostMatch
+				}
+				continue
+			}
+			match = exactHostMatch
+			break
+		}
+		if match == noHostMatch {
+			continue
+		}
+		if match > bestMatch || (match == bestMatch && olderIngressRoute(route, best)) {
+			best = route
+			bestMatch = match
+		}
+	}
+	if best == nil {
+		return ingressResourceKey{}, false
+	}
+	return best.owner, true
+}
+
+// olderIngressRoute returns true if route a was created before route b, the
+// routes created at the same time being ordered by their owner
+func olderIngressRoute(a, b *ingressRoute) bool {
+	if !a.created.Equal(&b.created) {
+		return a.created.Before(&b.created)
+	}
+	if a.owner.namespace != b.owner.namespace {
+		return a.owner.namespace < b.owner.namespace
+	}
+	if a.owner.kind != b.owner.kind {
+		return a.owner.kind < b.owner.kind
+	}
+	return a.owner.name < b.owner.name
+}
+
+// hostMatch returns true if the host matches the host of a route, which may
+// be a wildcard matching a single label, e.g. *.example.com
+func hostMatch(pattern, host string) bool {
+	pattern = strings.ToLower(pattern)
+	host = strings.ToLower(host)
+	if !strings.HasPrefix(pattern, "*.") {
+		return pattern == host
+	}
+	suffix := pattern[1:]
+	return strings.HasSuffix(host, suffix) && !strings.Contains(strings.TrimSuffix(host, suffix), ".") && len(host) > len(suffix)
+}
+
+func ingressResourceRowKey(row *pb.IngressResourceStatsRow) string {
+	return strings.Join([]string{
+		row.GetResource().GetNamespace(),
+		row.GetResource().GetType(),
+		row.GetResource().GetName(),
+	}, "/")
+}
+
+func ingressResourceStatsError(message string) *pb.IngressResourceStatsResponse {
+	return &pb.IngressResourceStatsResponse{
+		Response: &pb.IngressResourceStatsResponse_Error{
+			Error: &pb.ResourceError{
+				Error: message,
+			},
+		},
+	}
+}
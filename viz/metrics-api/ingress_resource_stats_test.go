@@ -0,0 +1,424 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/prometheus/common/model"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var ingressResourceK8sConfigs = append([]string{`
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web
+  namespace: emojivoto
+spec:
+  ingressClassName: nginx
+  rules:
+  - host: emoji.example.com
+    http:
+      paths:
+      - path: /
+        pathType: Prefix
+        backend:
+          service:
+            name: web-svc
+            port:
+              number: 80
+`, `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: api
+  namespace: emojivoto
+  annotations:
+    kubernetes.io/ingress.class: nginx
+spec:
+  rules:
+  - host: "*.api.example.com"
+    http:
+      paths:
+      - path: /
+        pathType: Prefix
+        backend:
+          service:
+            name: web-svc
+            port:
+              number: 80
+      - path: /emoji
+        pathType: Prefix
+        backend:
+          service:
+            name: emoji-svc
+            port:
+              number: 8080
+`, `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: fallback
+  namespace: emojivoto
+spec:
+  ingressClassName: nginx
+  defaultBackend:
+    service:
+      name: web-svc
+      port:
+        number: 80
+`}, ingressK8sConfigs...)
+
+func genIngressResourcePromSample(dstService, authority string, value model.SampleValue) *model.Sample {
+	sample := genIngressPromSample(pkgK8s.Deployment, "ingress-nginx", "ingress-nginx-controller", dstService)
+	sample.Metric[authorityLabel] = model.LabelValue(authority)
+	sample.Value = value
+	return sample
+}
+
+func ingressResourceRow(kind, name, class string, backends []string, success uint64) *pb.IngressResourceStatsRow {
+	row := &pb.IngressResourceStatsRow{
+		Resource: &pb.Resource{
+			Namespace: "emojivoto",
+			Type:      kind,
+			Name:      name,
+		},
+		IngressClass: class,
+		Backends:     []*pb.Resource{},
+	}
+	for _, backend := range backends {
+		row.Backends = append(row.Backends, &pb.Resource{
+			Namespace: "emojivoto",
+			Type:      pkgK8s.Service,
+			Name:      backend,
+		})
+	}
+	if success != 0 {
+		row.Stats = &pb.BasicStats{
+			SuccessCount: success,
+			LatencyMsP50: success,
+			LatencyMsP95: success,
+			LatencyMsP99: success,
+		}
+	}
+	return row
+}
+
+// gatewayAPIResources serves the Gateway API resources in the version of
+// gatewayAPIObject.
+var gatewayAPIResources = `
+kind: APIResourceList
+apiVersion: v1
+groupVersion: gateway.networking.k8s.io/v1alpha2
+resources:
+  - name: gateways
+    singularName: gateway
+    namespaced: true
+    kind: Gateway
+    verbs: [get, list, watch]
+  - name: httproutes
+    singularName: httproute
+    namespaced: true
+    kind: HTTPRoute
+    verbs: [get, list, watch]
+`
+
+var gatewayGVR = schema.GroupVersionResource{
+	Group:    pkgK8s.GatewayAPIGroup,
+	Version:  "v1alpha2",
+	Resource: pkgK8s.GatewayResource,
+}
+
+var httpRouteGVR = schema.GroupVersionResource{
+	Group:    pkgK8s.GatewayAPIGroup,
+	Version:  "v1alpha2",
+	Resource: pkgK8s.HTTPRouteResource,
+}
+
+// newIngressDynamicClient returns a dynamic client serving the given Gateways
+// and HTTPRoutes. They're added with their resource, as the fake client would
+// guess "gatewaies" for the Gateways.
+func newIngressDynamicClient(t *testing.T, gateways []*unstructured.Unstructured, httpRoutes []*unstructured.Unstructured) *dynamicfake.FakeDynamicClient {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			gatewayGVR:   "GatewayList",
+			httpRouteGVR: "HTTPRouteList",
+		},
+	)
+	for gvr, objs := range map[schema.GroupVersionResource][]*unstructured.Unstructured{
+		gatewayGVR:   gateways,
+		httpRouteGVR: httpRoutes,
+	} {
+		for _, obj := range objs {
+			if err := client.Tracker().Create(gvr, obj, obj.GetNamespace()); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+	}
+	return client
+}
+
+func gatewayAPIObject(kind, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1alpha2",
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "emojivoto",
+		},
+		"spec": spec,
+	}}
+}
+
+func TestIngressResourceStats(t *testing.T) {
+	t.Run("Attributes the traffic of the ingress controllers to the Ingresses routing it", func(t *testing.T) {
+		mockProm, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{
+			k8sConfigs: ingressResourceK8sConfigs,
+			mockPromResponse: model.Vector{
+				genIngressResourcePromSample("web-svc", "emoji.example.com", 10),
+				genIngressResourcePromSample("web-svc", "v1.api.example.com:80", 20),
+				genIngressResourcePromSample("emoji-svc", "v1.api.example.com", 30),
+				// not routed by a rule, so routed by the default backend
+				genIngressResourcePromSample("web-svc", "10.1.2.3", 40),
+			},
+		})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+		fakeGrpcServer.k8sAPI.DynamicClient = newIngressDynamicClient(t, nil, nil)
+
+		rsp, err := fakeGrpcServer.IngressResourceStats(context.TODO(), &pb.IngressResourceStatsRequest{
+			Namespace: "emojivoto",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if rsp.GetError() != nil {
+			t.Fatalf("Unexpected error response: %s", rsp.GetError().GetError())
+		}
+
+		expectedQuery := `sum(increase(response_total{deployment=~"^(ingress-nginx-controller)$", direction="outbound", dst_service!=""}[1m])) by (namespace, deployment, dst_namespace, dst_service, authority, classification, tls)`
+		found := false
+		for _, query := range mockProm.QueriesExecuted {
+			if query == expectedQuery {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("Expected query %s, got %v", expectedQuery, mockProm.QueriesExecuted)
+		}
+
+		api := ingressResourceRow(ingressResourceType, "api", "nginx", []string{"emoji-svc", "web-svc"}, 50)
+		api.Stats.LatencyMsP50 = 30
+		api.Stats.LatencyMsP95 = 30
+		api.Stats.LatencyMsP99 = 30
+		expectedRows := []*pb.IngressResourceStatsRow{
+			api,
+			ingressResourceRow(ingressResourceType, "fallback", "nginx", []string{"web-svc"}, 40),
+			ingressResourceRow(ingressResourceType, "web", "nginx", []string{"web-svc"}, 10),
+		}
+		rows := rsp.GetOk().GetRows()
+		if len(rows) != len(expectedRows) {
+			t.Fatalf("Expected %d rows, got %d: %+v", len(expectedRows), len(rows), rows)
+		}
+		for i, row := range rows {
+			if !proto.Equal(row, expectedRows[i]) {
+				t.Fatalf("Expected row %d: %+v\n Got: %+v", i, expectedRows[i], row)
+			}
+		}
+	})
+
+	t.Run("Counts the traffic matching several Ingresses once", func(t *testing.T) {
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{
+			k8sConfigs: append([]string{`
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web-old
+  namespace: emojivoto
+  creationTimestamp: "2021-01-01T00:00:00Z"
+spec:
+  ingressClassName: nginx
+  rules:
+  - host: emoji.example.com
+    http:
+      paths:
+      - path: /
+        pathType: Prefix
+        backend:
+          service:
+            name: web-svc
+            port:
+              number: 80
+`, `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web-new
+  namespace: emojivoto
+  creationTimestamp: "2022-01-01T00:00:00Z"
+spec:
+  ingressClassName: nginx
+  rules:
+  - host: emoji.example.com
+    http:
+      paths:
+      - path: /
+        pathType: Prefix
+        backend:
+          service:
+            name: web-svc
+            port:
+              number: 80
+`, `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web-wildcard
+  namespace: emojivoto
+  creationTimestamp: "2020-01-01T00:00:00Z"
+spec:
+  ingressClassName: nginx
+  rules:
+  - host: "*.example.com"
+    http:
+      paths:
+      - path: /
+        pathType: Prefix
+        backend:
+          service:
+            name: web-svc
+            port:
+              number: 80
+`}, ingressK8sConfigs...),
+			mockPromResponse: model.Vector{
+				genIngressResourcePromSample("web-svc", "emoji.example.com", 10),
+				genIngressResourcePromSample("web-svc", "vote.example.com", 20),
+			},
+		})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+		fakeGrpcServer.k8sAPI.DynamicClient = newIngressDynamicClient(t, nil, nil)
+
+		rsp, err := fakeGrpcServer.IngressResourceStats(context.TODO(), &pb.IngressResourceStatsRequest{
+			Namespace: "emojivoto",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if rsp.GetError() != nil {
+			t.Fatalf("Unexpected error response: %s", rsp.GetError().GetError())
+		}
+
+		expectedRows := []*pb.IngressResourceStatsRow{
+			ingressResourceRow(ingressResourceType, "web-new", "nginx", []string{"web-svc"}, 0),
+			ingressResourceRow(ingressResourceType, "web-old", "nginx", []string{"web-svc"}, 10),
+			ingressResourceRow(ingressResourceType, "web-wildcard", "nginx", []string{"web-svc"}, 20),
+		}
+		rows := rsp.GetOk().GetRows()
+		if len(rows) != len(expectedRows) {
+			t.Fatalf("Expected %d rows, got %d: %+v", len(expectedRows), len(rows), rows)
+		}
+		for i, row := range rows {
+			if !proto.Equal(row, expectedRows[i]) {
+				t.Fatalf("Expected row %d: %+v\n Got: %+v", i, expectedRows[i], row)
+			}
+		}
+	})
+
+	t.Run("Attributes the traffic of the ingress controllers to the Gateways routing it", func(t *testing.T) {
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{
+			k8sConfigs: append([]string{gatewayAPIResources}, ingressK8sConfigs...),
+			mockPromResponse: model.Vector{
+				genIngressResourcePromSample("web-svc", "emoji.example.com", 10),
+				genIngressResourcePromSample("emoji-svc", "emoji.example.com", 20),
+			},
+		})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+		gateways := []*unstructured.Unstructured{
+			gatewayAPIObject("Gateway", "public", map[string]interface{}{
+				"gatewayClassName": "nginx",
+			}),
+			gatewayAPIObject("Gateway", "internal", map[string]interface{}{
+				"gatewayClassName": "nginx",
+			}),
+		}
+		httpRoutes := []*unstructured.Unstructured{
+			gatewayAPIObject("HTTPRoute", "emoji", map[string]interface{}{
+				"parentRefs": []interface{}{
+					map[string]interface{}{"name": "public"},
+				},
+				"hostnames": []interface{}{"emoji.example.com"},
+				"rules": []interface{}{
+					map[string]interface{}{
+						"backendRefs": []interface{}{
+							map[string]interface{}{"name": "web-svc", "port": int64(80)},
+							map[string]interface{}{"name": "emoji-svc", "port": int64(8080)},
+						},
+					},
+				},
+			}),
+		}
+		fakeGrpcServer.k8sAPI.DynamicClient = newIngressDynamicClient(t, gateways, httpRoutes)
+
+		rsp, err := fakeGrpcServer.IngressResourceStats(context.TODO(), &pb.IngressResourceStatsRequest{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if rsp.GetError() != nil {
+			t.Fatalf("Unexpected error response: %s", rsp.GetError().GetError())
+		}
+
+		public := ingressResourceRow(gatewayResourceType, "public", "nginx", []string{"emoji-svc", "web-svc"}, 30)
+		public.Stats.LatencyMsP50 = 20
+		public.Stats.LatencyMsP95 = 20
+		public.Stats.LatencyMsP99 = 20
+		expectedRows := []*pb.IngressResourceStatsRow{
+			ingressResourceRow(gatewayResourceType, "internal", "nginx", nil, 0),
+			public,
+		}
+		rows := rsp.GetOk().GetRows()
+		if len(rows) != len(expectedRows) {
+			t.Fatalf("Expected %d rows, got %d: %+v", len(expectedRows), len(rows), rows)
+		}
+		for i, row := range rows {
+			if !proto.Equal(row, expectedRows[i]) {
+				t.Fatalf("Expected row %d: %+v\n Got: %+v", i, expectedRows[i], row)
+			}
+		}
+	})
+}
+
+func TestHostMatch(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		host     string
+		expected bool
+	}{
+		{"emoji.example.com", "emoji.example.com", true},
+		{"emoji.example.com", "Emoji.Example.com", true},
+		{"emoji.example.com", "web.example.com", false},
+		{"*.example.com", "emoji.example.com", true},
+		{"*.example.com", "example.com", false},
+		{"*.example.com", "v1.emoji.example.com", false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.pattern+"/"+tc.host, func(t *testing.T) {
+			if match := hostMatch(tc.pattern, tc.host); match != tc.expected {
+				t.Fatalf("Expected %t, got %t", tc.expected, match)
+			}
+		})
+	}
+}
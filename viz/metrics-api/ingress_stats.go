@@ -0,0 +1,261 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	vizLabels "github.com/linkerd/linkerd2/viz/pkg/labels"
+	"github.com/prometheus/common/model"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	defaultIngressStatsTime = "1m"
+
+	dstServiceLabel = model.LabelName("dst_service")
+)
+
+// ingressController describes how to recognize the pods of an ingress
+// controller from their labels
+type ingressController struct {
+	class    string
+	selector labels.Set
+}
+
+// ingressControllers is the registry of the ingress controllers recognized
+// out of the box, as labeled by their official charts. Other controllers can
+// be reported by annotating their pods with the ingress class.
+var ingressControllers = []ingressController{
+	{class: "nginx", selector: labels.Set{"app.kubernetes.io/name": "ingress-nginx"}},
+	{class: "traefik", selector: labels.Set{"app.kubernetes.io/name": "traefik"}},
+	{class: "contour", selector: labels.Set{"app.kubernetes.io/name": "contour", "app.kubernetes.io/component": "envoy"}},
+	{class: "ambassador", selector: labels.Set{"app.kubernetes.io/name": "emissary-ingress"}},
+	{class: "ambassador", selector: labels.Set{"app.kubernetes.io/name": "ambassador"}},
+	{class: "kong", selector: labels.Set{"app.kubernetes.io/name": "kong"}},
+	{class: "haproxy", selector: labels.Set{"app.kubernetes.io/name": "kubernetes-ingress"}},
+}
+
+// ingressKey identifies the workload running an ingress controller
+type ingressKey struct {
+	namespace string
+	kind      string
+	name      string
+}
+
+type backendKey struct {
+	controller ingressKey
+	namespace  string
+	service    string
+	// authority is only set when the requests are broken down by authority
+	authority string
+}
+
+func (s *grpcServer) IngressStats(ctx context.Context, req *pb.IngressStatsRequest) (*pb.IngressStatsResponse, error) {
+	log.Debugf("IngressStats request: %+v", req)
+
+	timeWindow := req.GetTimeWindow()
+	if timeWindow == "" {
+		timeWindow = defaultIngressStatsTime
+	}
+
+	controllers, err := s.getIngressControllers(ctx, req.GetNamespace())
+	if err != nil {
+		return ingressStatsError(err.Error()), nil
+	}
+
+	stats, err := s.getIngressMetrics(ctx, controllers, timeWindow, false)
+	if err != nil {
+		return ingressStatsError(err.Error()), nil
+	}
+
+	rows := make([]*pb.IngressStatsRow, 0, len(stats))
+	for key, basicStats := range stats {
+		rows = append(rows, &pb.IngressStatsRow{
+			IngressClass: controllers[key.controller],
+			Controller: &pb.Resource{
+				Namespace: key.controller.namespace,
+				Type:      key.controller.kind,
+				Name:      key.controller.name,
+			},
+			Backend: &pb.Resource{
+				Namespace: key.namespace,
+				Type:      k8s.Service,
+				Name:      key.service,
+			},
+			Stats: basicStats,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return ingressRowKey(rows[i]) < ingressRowKey(rows[j])
+	})
+
+	return &pb.IngressStatsResponse{
+		Response: &pb.IngressStatsResponse_Ok_{
+			Ok: &pb.IngressStatsResponse_Ok{
+				Rows: rows,
+			},
+		},
+	}, nil
+}
+
+// getIngressControllers returns the ingress class of the workloads running
+// ingress controllers
+func (s *grpcServer) getIngressControllers(ctx context.Context, namespace string) (map[ingressKey]string, error) {
+	var pods []*corev1.Pod
+	var err error
+	if namespace != "" {
+		pods, err = s.k8sAPI.Pod().Lister().Pods(namespace).List(labels.Everything())
+	} else {
+		pods, err = s.k8sAPI.Pod().Lister().List(labels.Everything())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	controllers := make(map[ingressKey]string)
+	for _, pod := range pods {
+		if s.shouldIgnore(pod) {
+			continue
+		}
+		class, ok := ingressClass(pod)
+		if !ok {
+			continue
+		}
+		kind, name := s.k8sAPI.GetOwnerKindAndName(ctx, pod, false)
+		controllers[ingressKey{namespace: pod.GetNamespace(), kind: kind, name: name}] = class
+	}
+	return controllers, nil
+}
+
+// ingressClass returns the ingress class of the pod if it runs an ingress
+// controller, which is either set explicitly through an annotation or
+// inferred from the pod's labels. Pods injected in ingress mode whose
+// controller isn't recognized are reported without a class.
+func ingressClass(pod *corev1.Pod) (string, bool) {
+	if class, ok := pod.GetAnnotations()[vizLabels.VizIngressClass]; ok {
+		return class, true
+	}
+	podLabels := labels.Set(pod.GetLabels())
+	for _, controller := range ingressControllers {
+		if labels.SelectorFromSet(controller.selector).Matches(podLabels) {
+			return controller.class, true
+		}
+	}
+	if pod.GetAnnotations()[k8s.ProxyInjectAnnotation] == k8s.ProxyInjectIngress {
+		return "", true
+	}
+	return "", false
+}
+
+// getIngressMetrics queries the requests sent by the ingress controllers to
+// each of their backend services, also broken down by authority if
+// byAuthority is true. The controllers are grouped by kind, as each kind is
+// reported under its own label.
+func (s *grpcServer) getIngressMetrics(ctx context.Context, controllers map[ingressKey]string, timeWindow string, byAuthority bool) (map[backendKey]*pb.BasicStats, error) {
+	namesByKind := make(map[string][]string)
+	for key := range controllers {
+		namesByKind[key.kind] = append(namesByKind[key.kind], regexp.QuoteMeta(key.name))
+	}
+
+	stats := make(map[backendKey]*pb.BasicStats)
+	for kind, names := range namesByKind {
+		sort.Strings(names)
+		resourceLabel := model.LabelName(k8s.KindToL5DLabel(kind))
+		groupBy := model.LabelNames{namespaceLabel, resourceLabel, dstNamespaceLabel, dstServiceLabel}
+		if byAuthority {
+			groupBy = append(groupBy, authorityLabel)
+		}
+
+		labelStrings := []string{
+			`direction="outbound"`,
+			fmt.Sprintf(`%s!=""`, dstServiceLabel),
+			fmt.Sprintf("%s=~%q", resourceLabel, "^("+strings.Join(names, "|")+")$"),
+		}
+		sort.Strings(labelStrings)
+		labelString := fmt.Sprintf("{%s}", strings.Join(labelStrings, ", "))
+
+		promQueries := map[promType]string{
+			promRequests: fmt.Sprintf(reqQuery, labelString, timeWindow, groupBy.String()),
+		}
+		quantileQueries := generateQuantileQueries(latencyQuantileQuery, labelString, timeWindow, groupBy.String())
+		results, err := s.getPrometheusMetrics(ctx, promQueries, quantileQueries)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range results {
+			for _, sample := range result.vec {
+				controller := ingressKey{
+					namespace: string(sample.Metric[namespaceLabel]),
+					kind:      kind,
+					name:      string(sample.Metric[resourceLabel]),
+				}
+				// the name regex matches workloads of all namespaces
+				if _, ok := controllers[controller]; !ok {
+					continue
+				}
+				key := backendKey{
+					controller: controller,
+					namespace:  string(sample.Metric[dstNamespaceLabel]),
+					service:    string(sample.Metric[dstServiceLabel]),
+					authority:  string(sample.Metric[authorityLabel]),
+				}
+				if _, ok := stats[key]; !ok {
+					stats[key] = &pb.BasicStats{}
+				}
+
+				value := extractSampleValue(sample)
+				switch result.prom {
+				case promRequests:
+					switch string(sample.Metric[model.LabelName("classification")]) {
+					case success:
+						stats[key].SuccessCount += value
+					case failure:
+						stats[key].FailureCount += value
+					}
+				case promLatencyP50:
+					stats[key].LatencyMsP50 = value
+				case promLatencyP95:
+					stats[key].LatencyMsP95 = value
+				case promLatencyP99:
+					stats[key].LatencyMsP99 = value
+				}
+			}
+		}
+	}
+
+	for key, basicStats := range stats {
+		if basicStats.SuccessCount+basicStats.FailureCount == 0 {
+			delete(stats, key)
+		}
+	}
+	return stats, nil
+}
+
+func ingressRowKey(row *pb.IngressStatsRow) string {
+	return strings.Join([]string{
+		row.GetIngressClass(),
+		row.GetController().GetNamespace(),
+		row.GetController().GetType(),
+		row.GetController().GetName(),
+		row.GetBackend().GetNamespace(),
+		row.GetBackend().GetName(),
+	}, "/")
+}
+
+func ingressStatsError(message string) *pb.IngressStatsResponse {
+	return &pb.IngressStatsResponse{
+		Response: &pb.IngressStatsResponse_Error{
+			Error: &pb.ResourceError{
+				Error: message,
+			},
+		},
+	}
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/prometheus/common/model"
+)
+
+var ingressK8sConfigs = []string{`
+apiVersion: apps/v1
+kind: ReplicaSet
+metadata:
+  name: ingress-nginx-controller-5c8d66c76d
+  namespace: ingress-nginx
+  uid: a1b2c3d4
+  labels:
+    app.kubernetes.io/name: ingress-nginx
+    pod-template-hash: 5c8d66c76d
+  ownerReferences:
+  - apiVersion: apps/v1
+    kind: Deployment
+    name: ingress-nginx-controller
+    uid: a1b2c3
+spec:
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: ingress-nginx
+      pod-template-hash: 5c8d66c76d
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: ingress-nginx-controller-5c8d66c76d-x7k2p
+  namespace: ingress-nginx
+  labels:
+    app.kubernetes.io/name: ingress-nginx
+    pod-template-hash: 5c8d66c76d
+  ownerReferences:
+  - apiVersion: apps/v1
+    kind: ReplicaSet
+    name: ingress-nginx-controller-5c8d66c76d
+    uid: a1b2c3d4
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: edge-router-qv4tl
+  namespace: edge
+  annotations:
+    viz.linkerd.io/ingress-class: custom
+  labels:
+    app: edge-router
+  ownerReferences:
+  - apiVersion: apps/v1
+    kind: DaemonSet
+    name: edge-router
+    uid: e1f2g3
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web-7b4d8f6d5c-9bqsz
+  namespace: emojivoto
+  labels:
+    app: web-svc
+status:
+  phase: Running
+`,
+}
+
+func genIngressPromSample(kind, namespace, name, dstService string) *model.Sample {
+	return &model.Sample{
+		Metric: model.Metric{
+			model.LabelName(kind):             model.LabelValue(name),
+			namespaceLabel:                    model.LabelValue(namespace),
+			dstNamespaceLabel:                 "emojivoto",
+			dstServiceLabel:                   model.LabelValue(dstService),
+			model.LabelName("classification"): success,
+		},
+		Value:     123,
+		Timestamp: 456,
+	}
+}
+
+func ingressRow(class, kind, namespace, name, backend string) *pb.IngressStatsRow {
+	return &pb.IngressStatsRow{
+		IngressClass: class,
+		Controller: &pb.Resource{
+			Namespace: namespace,
+			Type:      kind,
+			Name:      name,
+		},
+		Backend: &pb.Resource{
+			Namespace: "emojivoto",
+			Type:      pkgK8s.Service,
+			Name:      backend,
+		},
+		Stats: &pb.BasicStats{
+			SuccessCount: 123,
+			LatencyMsP50: 123,
+			LatencyMsP95: 123,
+			LatencyMsP99: 123,
+		},
+	}
+}
+
+func TestIngressStats(t *testing.T) {
+	mockPromResponse := model.Vector{
+		genIngressPromSample(pkgK8s.Deployment, "ingress-nginx", "ingress-nginx-controller", "web-svc"),
+		genIngressPromSample(pkgK8s.Deployment, "ingress-nginx", "ingress-nginx-controller", "emoji-svc"),
+		genIngressPromSample(pkgK8s.DaemonSet, "edge", "edge-router", "web-svc"),
+		// a workload named like a controller, in a namespace without one
+		genIngressPromSample(pkgK8s.Deployment, "emojivoto", "ingress-nginx-controller", "web-svc"),
+	}
+
+	t.Run("Reports the traffic of ingress controllers by backend service", func(t *testing.T) {
+		mockProm, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{
+			k8sConfigs:       ingressK8sConfigs,
+			mockPromResponse: mockPromResponse,
+			expectedPrometheusQueries: []string{
+				`sum(increase(response_total{daemonset=~"^(edge-router)$", direction="outbound", dst_service!=""}[1m])) by (namespace, daemonset, dst_namespace, dst_service, classification, tls)`,
+				`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{daemonset=~"^(edge-router)$", direction="outbound", dst_service!=""}[1m])) by (le, namespace, daemonset, dst_namespace, dst_service))`,
+				`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{daemonset=~"^(edge-router)$", direction="outbound", dst_service!=""}[1m])) by (le, namespace, daemonset, dst_namespace, dst_service))`,
+				`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{daemonset=~"^(edge-router)$", direction="outbound", dst_service!=""}[1m])) by (le, namespace, daemonset, dst_namespace, dst_service))`,
+				`sum(increase(response_total{deployment=~"^(ingress-nginx-controller)$", direction="outbound", dst_service!=""}[1m])) by (namespace, deployment, dst_namespace, dst_service, classification, tls)`,
+				`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{deployment=~"^(ingress-nginx-controller)$", direction="outbound", dst_service!=""}[1m])) by (le, namespace, deployment, dst_namespace, dst_service))`,
+				`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{deployment=~"^(ingress-nginx-controller)$", direction="outbound", dst_service!=""}[1m])) by (le, namespace, deployment, dst_namespace, dst_service))`,
+				`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{deployment=~"^(ingress-nginx-controller)$", direction="outbound", dst_service!=""}[1m])) by (le, namespace, deployment, dst_namespace, dst_service))`,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.IngressStats(context.TODO(), &pb.IngressStatsRequest{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		err = expectedStatRPC{expectedPrometheusQueries: nil}.verifyPromQueries(mockProm)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedRows := []*pb.IngressStatsRow{
+			ingressRow("custom", pkgK8s.DaemonSet, "edge", "edge-router", "web-svc"),
+			ingressRow("nginx", pkgK8s.Deployment, "ingress-nginx", "ingress-nginx-controller", "emoji-svc"),
+			ingressRow("nginx", pkgK8s.Deployment, "ingress-nginx", "ingress-nginx-controller", "web-svc"),
+		}
+		rows := rsp.GetOk().GetRows()
+		if len(rows) != len(expectedRows) {
+			t.Fatalf("Expected %d rows, got %d: %+v", len(expectedRows), len(rows), rows)
+		}
+		for i, row := range rows {
+			if !proto.Equal(row, expectedRows[i]) {
+				t.Fatalf("Expected row %d: %+v\n Got: %+v", i, expectedRows[i], row)
+			}
+		}
+	})
+
+	t.Run("Only reports the controllers of the requested namespace", func(t *testing.T) {
+		mockProm, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{
+			k8sConfigs:       ingressK8sConfigs,
+			mockPromResponse: mockPromResponse,
+		})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.IngressStats(context.TODO(), &pb.IngressStatsRequest{
+			Namespace:  "edge",
+			TimeWindow: "10s",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(mockProm.QueriesExecuted) != 4 {
+			t.Fatalf("Expected 4 queries, got %d: %v", len(mockProm.QueriesExecuted), mockProm.QueriesExecuted)
+		}
+
+		expectedRow := ingressRow("custom", pkgK8s.DaemonSet, "edge", "edge-router", "web-svc")
+		rows := rsp.GetOk().GetRows()
+		if len(rows) != 1 || !proto.Equal(rows[0], expectedRow) {
+			t.Fatalf("Expected rows: [%+v]\n Got: %+v", expectedRow, rows)
+		}
+	})
+}
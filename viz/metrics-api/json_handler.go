@@ -0,0 +1,102 @@
+package api
+
+import (
+	_ "embed" // required for go:embed
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/linkerd/linkerd2/viz/metrics-api/client"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	log "github.com/sirupsen/logrus"
+)
+
+// jsonAPIPrefix is the path under which the RPCs are exposed over plain
+// HTTP/JSON, for consumers that can't speak protobuf
+var jsonAPIPrefix = client.APIRoot + client.APIPrefix + "json/"
+
+var (
+	jsonStatSummaryPath = jsonAPIPrefix + "StatSummary"
+	jsonEdgesPath       = jsonAPIPrefix + "Edges"
+	openAPIPath         = jsonAPIPrefix + "openapi.json"
+)
+
+// openAPISpec documents the JSON endpoints; keep it in sync with viz.proto
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+var (
+	jsonUnmarshaler = jsonpb.Unmarshaler{AllowUnknownFields: true}
+	jsonMarshaler   = jsonpb.Marshaler{EmitDefaults: true, OrigName: true}
+)
+
+func isJSONAPIPath(path string) bool {
+	return strings.HasPrefix(path, jsonAPIPrefix)
+}
+
+func (h *handler) serveJSON(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == openAPIPath {
+		if req.Method != http.MethodGet {
+			writeJSONError(w, http.StatusMethodNotAllowed, "GET required")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(openAPISpec)
+		return
+	}
+
+	if req.Method != http.MethodPost {
+		writeJSONError(w, http.StatusMethodNotAllowed, "POST required")
+		return
+	}
+
+	switch req.URL.Path {
+	case jsonStatSummaryPath:
+		var protoRequest pb.StatSummaryRequest
+		if !decodeJSONRequest(w, req, &protoRequest) {
+			return
+		}
+		rsp, err := h.grpcServer.StatSummary(req.Context(), &protoRequest)
+		writeJSONResponse(w, rsp, err)
+	case jsonEdgesPath:
+		var protoRequest pb.EdgesRequest
+		if !decodeJSONRequest(w, req, &protoRequest) {
+			return
+		}
+		rsp, err := h.grpcServer.Edges(req.Context(), &protoRequest)
+		writeJSONResponse(w, rsp, err)
+	default:
+		writeJSONError(w, http.StatusNotFound, "unknown endpoint "+req.URL.Path)
+	}
+}
+
+func decodeJSONRequest(w http.ResponseWriter, req *http.Request, msg proto.Message) bool {
+	if err := jsonUnmarshaler.Unmarshal(req.Body, msg); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSONResponse(w http.ResponseWriter, rsp proto.Message, err error) {
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := jsonMarshaler.Marshal(w, rsp); err != nil {
+		log.Errorf("failed to write JSON response: %s", err)
+	}
+}
+
+func writeJSONError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(map[string]string{"error": msg}); err != nil {
+		log.Errorf("failed to write JSON error: %s", err)
+	}
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+)
+
+func TestServeJSON(t *testing.T) {
+	t.Run("Decodes JSON requests and encodes JSON responses", func(t *testing.T) {
+		mockGrpcServer := &mockGrpcServer{}
+		mockGrpcServer.ResponseToReturn = &pb.EdgesResponse{
+			Response: &pb.EdgesResponse_Ok_{
+				Ok: &pb.EdgesResponse_Ok{
+					Edges: []*pb.Edge{
+						{
+							Src:      &pb.Resource{Namespace: "emojivoto", Type: "deployment", Name: "web"},
+							Dst:      &pb.Resource{Namespace: "emojivoto", Type: "deployment", Name: "emoji"},
+							ClientId: "web.emojivoto.serviceaccount.identity.linkerd.cluster.local",
+						},
+					},
+				},
+			},
+		}
+		h := &handler{grpcServer: mockGrpcServer}
+
+		body := `{"selector": {"resource": {"namespace": "emojivoto", "type": "deployment"}}}`
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, jsonEdgesPath, strings.NewReader(body)))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		expectedReq := &pb.EdgesRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{Namespace: "emojivoto", Type: "deployment"},
+			},
+		}
+		if !proto.Equal(mockGrpcServer.LastRequestReceived, expectedReq) {
+			t.Fatalf("Expected request %v, got %v", expectedReq, mockGrpcServer.LastRequestReceived)
+		}
+
+		if !strings.Contains(rec.Body.String(), `"client_id":"web.emojivoto.serviceaccount.identity.linkerd.cluster.local"`) {
+			t.Fatalf("Expected snake_case fields in response, got %s", rec.Body.String())
+		}
+		var rsp pb.EdgesResponse
+		if err := jsonpb.UnmarshalString(rec.Body.String(), &rsp); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !proto.Equal(&rsp, mockGrpcServer.ResponseToReturn) {
+			t.Fatalf("Expected response %v, got %v", mockGrpcServer.ResponseToReturn, &rsp)
+		}
+	})
+
+	t.Run("Returns JSON errors", func(t *testing.T) {
+		mockGrpcServer := &mockGrpcServer{}
+		mockGrpcServer.ResponseToReturn = &pb.StatSummaryResponse{}
+		mockGrpcServer.ErrorToReturn = errors.New("expected")
+		h := &handler{grpcServer: mockGrpcServer}
+
+		testCases := []struct {
+			method string
+			path   string
+			body   string
+			status int
+		}{
+			{http.MethodPost, jsonStatSummaryPath, `{"time_window": "1m"}`, http.StatusInternalServerError},
+			{http.MethodPost, jsonStatSummaryPath, `not json`, http.StatusBadRequest},
+			{http.MethodGet, jsonStatSummaryPath, ``, http.StatusMethodNotAllowed},
+			{http.MethodPost, jsonAPIPrefix + "TapByResource", `{}`, http.StatusNotFound},
+		}
+
+		for _, tc := range testCases {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body)))
+			if rec.Code != tc.status {
+				t.Fatalf("%s %s: expected status %d, got %d", tc.method, tc.path, tc.status, rec.Code)
+			}
+			var rsp map[string]string
+			if err := json.Unmarshal(rec.Body.Bytes(), &rsp); err != nil || rsp["error"] == "" {
+				t.Fatalf("%s %s: expected JSON error, got %s", tc.method, tc.path, rec.Body.String())
+			}
+		}
+	})
+
+	t.Run("Serves the OpenAPI document", func(t *testing.T) {
+		h := &handler{grpcServer: &mockGrpcServer{}}
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, openAPIPath, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", rec.Code)
+		}
+
+		var spec struct {
+			Paths map[string]interface{} `json:"paths"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &spec); err != nil {
+			t.Fatalf("Invalid OpenAPI document: %s", err)
+		}
+		for _, path := range []string{"/StatSummary", "/Edges"} {
+			if _, ok := spec.Paths[path]; !ok {
+				t.Fatalf("Expected OpenAPI document to describe %s", path)
+			}
+		}
+	})
+}
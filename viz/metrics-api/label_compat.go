@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/prometheus/common/model"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	labelPresenceQuery = "count(%s) by (%s)"
+
+	// maximum number of pods per proxy version used to sample the metrics, so
+	// that the regex in the queries stays reasonably sized
+	maxLabelCompatPodsPerVersion = 10
+)
+
+// expectedProxyLabels lists, for each proxy metric, the labels the
+// metrics-api relies on when building its queries
+var expectedProxyLabels = map[string][]string{
+	"response_total":                 {"classification", "tls", "direction"},
+	"response_latency_ms_bucket":     {"le", "direction"},
+	"route_response_total":           {"rt_route", "classification"},
+	"inbound_http_authz_allow_total": {string(serverLabel), string(serverAuthorizationLabel)},
+	"tcp_open_connections":           {"direction"},
+}
+
+type labelCompatReport struct {
+	sync.RWMutex
+	report    *pb.LabelCompatibilityResponse
+	checkedAt time.Time
+}
+
+// LabelCompatibility returns the latest label compatibility report computed in
+// the background, computing it on the spot if there isn't one yet
+func (s *grpcServer) LabelCompatibility(ctx context.Context, req *pb.LabelCompatibilityRequest) (*pb.LabelCompatibilityResponse, error) {
+	s.labelCompat.RLock()
+	report, checkedAt := s.labelCompat.report, s.labelCompat.checkedAt
+	s.labelCompat.RUnlock()
+
+	if report == nil {
+		var err error
+		report, err = s.updateLabelCompatibility(ctx)
+		if err != nil {
+			return nil, err
+		}
+		checkedAt = time.Now()
+	}
+
+	since := time.Since(checkedAt)
+	return &pb.LabelCompatibilityResponse{
+		Reports: report.GetReports(),
+		SinceLastCheck: &duration.Duration{
+			Seconds: int64(since / time.Second),
+			Nanos:   int32(since % time.Second),
+		},
+	}, nil
+}
+
+// runLabelCompatibilityChecks refreshes the label compatibility report right
+// away and then every interval, until the stop channel is closed
+func (s *grpcServer) runLabelCompatibilityChecks(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.updateLabelCompatibility(context.Background()); err != nil {
+			log.Warnf("failed to check metric label compatibility: %s", err)
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *grpcServer) updateLabelCompatibility(ctx context.Context) (*pb.LabelCompatibilityResponse, error) {
+	report, err := s.checkLabelCompatibility(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.labelCompat.Lock()
+	s.labelCompat.report = report
+	s.labelCompat.checkedAt = time.Now()
+	s.labelCompat.Unlock()
+
+	return report, nil
+}
+
+// checkLabelCompatibility groups the meshed pods by proxy version and, for
+// each version, verifies that the metrics reported by a sample of those pods
+// carry the labels listed in expectedProxyLabels. Metrics for which no series
+// are found are skipped, as their labels can't be asserted.
+func (s *grpcServer) checkLabelCompatibility(ctx context.Context) (*pb.LabelCompatibilityResponse, error) {
+	if s.prometheusAPI == nil {
+		// nothing to validate against
+		return &pb.LabelCompatibilityResponse{}, nil
+	}
+
+	pods, err := s.k8sAPI.Pod().Lister().List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	podsByVersion := make(map[string]podsByNamespace)
+	podCounts := make(map[string]uint64)
+	for _, pod := range pods {
+		if s.shouldIgnore(pod) || pod.Status.Phase != corev1.PodRunning || !k8s.IsMeshed(pod, s.controllerNamespace) {
+			continue
+		}
+		version := k8s.GetProxyVersion(*pod)
+		if podCounts[version] < maxLabelCompatPodsPerVersion {
+			if podsByVersion[version] == nil {
+				podsByVersion[version] = podsByNamespace{}
+			}
+			podsByVersion[version][pod.Namespace] = append(podsByVersion[version][pod.Namespace], pod.Name)
+		}
+		podCounts[version]++
+	}
+
+	versions := make([]string, 0, len(podsByVersion))
+	for version := range podsByVersion {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	metrics := make([]string, 0, len(expectedProxyLabels))
+	for metric := range expectedProxyLabels {
+		metrics = append(metrics, metric)
+	}
+	sort.Strings(metrics)
+
+	reports := make([]*pb.LabelCompatibilityResponse_ProxyVersionReport, 0, len(versions))
+	for _, version := range versions {
+		report := &pb.LabelCompatibilityResponse_ProxyVersionReport{
+			ProxyVersion: version,
+			PodCount:     podCounts[version],
+		}
+
+		for _, metric := range metrics {
+			series := podsSeriesSelector(metric, podsByVersion[version])
+			for _, label := range expectedProxyLabels[metric] {
+				vec, err := s.queryProm(ctx, fmt.Sprintf(labelPresenceQuery, series, label))
+				if err != nil {
+					return nil, err
+				}
+				if isLabelMissing(vec, model.LabelName(label)) {
+					report.MissingLabels = append(report.MissingLabels, &pb.LabelCompatibilityResponse_MissingLabel{
+						Metric: metric,
+						Label:  label,
+					})
+				}
+			}
+		}
+		reports = append(reports, report)
+	}
+
+	return &pb.LabelCompatibilityResponse{Reports: reports}, nil
+}
+
+// podsSeriesSelector returns the series of the metric reported by the pods.
+// Pods are matched by name within their namespace, as pods with the same name
+// can live in other namespaces.
+func podsSeriesSelector(metric string, pods podsByNamespace) string {
+	namespaces := make([]string, 0, len(pods))
+	for ns := range pods {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	selectors := make([]string, len(namespaces))
+	for i, ns := range namespaces {
+		selectors[i] = fmt.Sprintf("%s{%s=%q, %s=~%q}", metric, namespaceLabel, ns, podLabel, "^"+namesRegex(pods[ns])+"$")
+	}
+	return strings.Join(selectors, " or ")
+}
+
+// isLabelMissing returns true when series were found for the queried metric
+// but none of them carried the given label
+func isLabelMissing(vec model.Vector, label model.LabelName) bool {
+	if len(vec) == 0 {
+		return false
+	}
+	for _, sample := range vec {
+		if sample.Metric[label] != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/prometheus/common/model"
+)
+
+const meshedPodConfig = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji
+  namespace: emojivoto
+  labels:
+    linkerd.io/control-plane-ns: linkerd
+spec:
+  containers:
+  - name: linkerd-proxy
+    image: cr.l5d.io/linkerd/proxy:stable-2.11.1
+status:
+  phase: Running
+`
+
+func TestLabelCompatibility(t *testing.T) {
+	testCases := []struct {
+		name             string
+		mockPromResponse model.Value
+		expected         *pb.LabelCompatibilityResponse
+	}{
+		{
+			name:             "reports no missing labels when there are no series",
+			mockPromResponse: model.Vector{},
+			expected: &pb.LabelCompatibilityResponse{
+				Reports: []*pb.LabelCompatibilityResponse_ProxyVersionReport{
+					{
+						ProxyVersion: "stable-2.11.1",
+						PodCount:     1,
+					},
+				},
+			},
+		},
+		{
+			name: "reports the labels absent from the returned series",
+			mockPromResponse: model.Vector{
+				&model.Sample{
+					Metric: model.Metric{
+						"classification": "success",
+						"direction":      "inbound",
+					},
+					Value: 1,
+				},
+			},
+			expected: &pb.LabelCompatibilityResponse{
+				Reports: []*pb.LabelCompatibilityResponse_ProxyVersionReport{
+					{
+						ProxyVersion: "stable-2.11.1",
+						PodCount:     1,
+						MissingLabels: []*pb.LabelCompatibilityResponse_MissingLabel{
+							{Metric: "inbound_http_authz_allow_total", Label: "srv_name"},
+							{Metric: "inbound_http_authz_allow_total", Label: "saz_name"},
+							{Metric: "response_latency_ms_bucket", Label: "le"},
+							{Metric: "response_total", Label: "tls"},
+							{Metric: "route_response_total", Label: "rt_route"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{
+				k8sConfigs:       []string{meshedPodConfig},
+				mockPromResponse: tc.mockPromResponse,
+			})
+			if err != nil {
+				t.Fatalf("Error creating mock grpc server: %s", err)
+			}
+
+			rsp, err := fakeGrpcServer.LabelCompatibility(context.TODO(), &pb.LabelCompatibilityRequest{})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			rsp.SinceLastCheck = nil
+			if !proto.Equal(rsp, tc.expected) {
+				t.Fatalf("Expected: %+v\n Got: %+v", tc.expected, rsp)
+			}
+		})
+	}
+}
+
+func TestPodsSeriesSelector(t *testing.T) {
+	pods := podsByNamespace{
+		"emojivoto": {"web", "emoji"},
+		"books":     {"web"},
+	}
+	expected := `response_total{namespace="books", pod=~"^(web)$"} or response_total{namespace="emojivoto", pod=~"^(emoji|web)$"}`
+	if selector := podsSeriesSelector("response_total", pods); selector != expected {
+		t.Fatalf("Expected: %s\n Got: %s", expected, selector)
+	}
+}
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultHeatmapTimeRange = "1h"
+	defaultHeatmapStep      = "1m"
+	// maxHeatmapColumns bounds the number of steps of the time range, so that
+	// the heatmap stays readable and cheap to compute
+	maxHeatmapColumns = 1000
+
+	latencyBucketsQuery = "sum(increase(response_latency_ms_bucket%s[%s])) by (le)"
+
+	leLabel = model.LabelName("le")
+)
+
+// LatencyHeatmap returns the distribution of the latencies of the inbound
+// responses of a resource over a time range, by running a range query on the
+// latency histogram buckets
+func (s *grpcServer) LatencyHeatmap(ctx context.Context, req *pb.LatencyHeatmapRequest) (*pb.LatencyHeatmapResponse, error) {
+	log.Debugf("LatencyHeatmap request: %+v", req)
+	resource := req.GetResource()
+	if resource == nil || resource.GetNamespace() == "" {
+		return latencyHeatmapError(req, "LatencyHeatmap request requires a Resource in a namespace"), nil
+	}
+	switch resource.GetType() {
+	case k8s.Service, k8s.Server, k8s.ServerAuthorization, k8s.HTTPRoute, k8s.All:
+		return latencyHeatmapError(req, fmt.Sprintf("Resource type is not supported: %s", resource.GetType())), nil
+	}
+
+	timeRange, step, err := heatmapRange(req.GetTimeRange(), req.GetStep())
+	if err != nil {
+		return latencyHeatmapError(req, err.Error()), nil
+	}
+
+	labels := promQueryLabels(resource).Merge(promDirectionLabels("inbound"))
+	query := fmt.Sprintf(latencyBucketsQuery, labels, model.Duration(step))
+	end := time.Now().UTC().Truncate(step)
+	matrix, err := s.queryPromRange(ctx, query, promv1.Range{
+		Start: end.Add(-timeRange).Add(step),
+		End:   end,
+		Step:  step,
+	})
+	if err != nil {
+		return latencyHeatmapError(req, err.Error()), nil
+	}
+
+	return &pb.LatencyHeatmapResponse{
+		Response: &pb.LatencyHeatmapResponse_Ok_{
+			Ok: buildLatencyHeatmap(matrix),
+		},
+	}, nil
+}
+
+// heatmapRange parses the time range and the step of the request, falling
+// back to their defaults
+func heatmapRange(timeRangeStr, stepStr string) (time.Duration, time.Duration, error) {
+	if timeRangeStr == "" {
+		timeRangeStr = defaultHeatmapTimeRange
+	}
+	if stepStr == "" {
+		stepStr = defaultHeatmapStep
+	}
+	timeRange, err := time.ParseDuration(timeRangeStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid time range %q: %s", timeRangeStr, err)
+	}
+	step, err := time.ParseDuration(stepStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid step %q: %s", stepStr, err)
+	}
+	if step < time.Second || timeRange < step {
+		return 0, 0, fmt.Errorf("the step must be at least 1s and at most the time range, got %s and %s", stepStr, timeRangeStr)
+	}
+	if timeRange/step > maxHeatmapColumns {
+		return 0, 0, fmt.Errorf("the time range can't span more than %d steps, got %s by steps of %s", maxHeatmapColumns, timeRangeStr, stepStr)
+	}
+	return timeRange, step, nil
+}
+
+// buildLatencyHeatmap turns the cumulative histogram buckets returned by
+// Prometheus, one series per bucket, into the number of responses of each
+// bucket for each step
+func buildLatencyHeatmap(matrix model.Matrix) *pb.LatencyHeatmapResponse_Ok {
+	type bucket struct {
+		le     string
+		bound  float64
+		values map[model.Time]float64
+	}
+	buckets := []bucket{}
+	timestamps := map[model.Time]struct{}{}
+	for _, stream := range matrix {
+		le := string(stream.Metric[leLabel])
+		bound, err := strconv.ParseFloat(le, 64)
+		if err != nil {
+			log.Warnf("Ignoring latency bucket with invalid bound %q", le)
+			continue
+		}
+		values := make(map[model.Time]float64, len(stream.Values))
+		for _, pair := range stream.Values {
+			timestamps[pair.Timestamp] = struct{}{}
+			if !math.IsNaN(float64(pair.Value)) {
+				values[pair.Timestamp] = float64(pair.Value)
+			}
+		}
+		buckets = append(buckets, bucket{le: le, bound: bound, values: values})
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].bound < buckets[j].bound })
+
+	sortedTimestamps := make([]model.Time, 0, len(timestamps))
+	for ts := range timestamps {
+		sortedTimestamps = append(sortedTimestamps, ts)
+	}
+	sort.Slice(sortedTimestamps, func(i, j int) bool { return sortedTimestamps[i] < sortedTimestamps[j] })
+
+	heatmap := &pb.LatencyHeatmapResponse_Ok{
+		Buckets: make([]string, len(buckets)),
+		Columns: make([]*pb.LatencyHeatmapColumn, len(sortedTimestamps)),
+	}
+	for i, b := range buckets {
+		heatmap.Buckets[i] = b.le
+	}
+	for i, ts := range sortedTimestamps {
+		column := &pb.LatencyHeatmapColumn{
+			Timestamp: ts.Time().UTC().Format(time.RFC3339),
+			Counts:    make([]uint64, len(buckets)),
+		}
+		previous := 0.0
+		for j, b := range buckets {
+			cumulative := b.values[ts]
+			// the counts are estimated by increase(), which can make a bucket
+			// slightly smaller than the previous one
+			if count := cumulative - previous; count > 0 {
+				column.Counts[j] = uint64(math.Round(count))
+			}
+			if cumulative > previous {
+				previous = cumulative
+			}
+		}
+		heatmap.Columns[i] = column
+	}
+	return heatmap
+}
+
+func latencyHeatmapError(req *pb.LatencyHeatmapRequest, message string) *pb.LatencyHeatmapResponse {
+	return &pb.LatencyHeatmapResponse{
+		Response: &pb.LatencyHeatmapResponse_Error{
+			Error: &pb.ResourceError{
+				Resource: req.GetResource(),
+				Error:    message,
+			},
+		},
+	}
+}
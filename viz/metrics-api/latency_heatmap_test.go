@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/prometheus/common/model"
+)
+
+func genLatencyBucketStream(le string, values ...model.SampleValue) *model.SampleStream {
+	stream := &model.SampleStream{
+		Metric: model.Metric{leLabel: model.LabelValue(le)},
+	}
+	for i, value := range values {
+		stream.Values = append(stream.Values, model.SamplePair{
+			Timestamp: model.TimeFromUnix(int64(60 * (i + 1))),
+			Value:     value,
+		})
+	}
+	return stream
+}
+
+func TestLatencyHeatmap(t *testing.T) {
+	web := &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Deployment, Name: "web"}
+
+	t.Run("Returns the number of responses of each bucket by step", func(t *testing.T) {
+		expectedQueries := []string{
+			`sum(increase(response_latency_ms_bucket{deployment="web", direction="inbound", namespace="emojivoto"}[1m])) by (le)`,
+		}
+		mockProm, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{
+			mockPromResponse: model.Matrix{
+				genLatencyBucketStream("+Inf", 12, 20),
+				genLatencyBucketStream("10", 5, 15),
+				genLatencyBucketStream("100", 10, 14.6),
+			},
+			expectedPrometheusQueries: expectedQueries,
+		})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.LatencyHeatmap(context.TODO(), &pb.LatencyHeatmapRequest{Resource: web})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		err = expectedStatRPC{expectedPrometheusQueries: expectedQueries}.verifyPromQueries(mockProm)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := &pb.LatencyHeatmapResponse{
+			Response: &pb.LatencyHeatmapResponse_Ok_{
+				Ok: &pb.LatencyHeatmapResponse_Ok{
+					Buckets: []string{"10", "100", "+Inf"},
+					Columns: []*pb.LatencyHeatmapColumn{
+						{Timestamp: "1970-01-01T00:01:00Z", Counts: []uint64{5, 5, 2}},
+						// the 100 bucket is estimated to be smaller than the
+						// 10 one, so it's considered empty
+						{Timestamp: "1970-01-01T00:02:00Z", Counts: []uint64{15, 0, 5}},
+					},
+				},
+			},
+		}
+		if !proto.Equal(rsp, expected) {
+			t.Fatalf("Expected: %+v\nGot: %+v", expected, rsp)
+		}
+	})
+
+	t.Run("Rejects invalid requests", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			req      *pb.LatencyHeatmapRequest
+			expected string
+		}{
+			{
+				name:     "no resource",
+				req:      &pb.LatencyHeatmapRequest{},
+				expected: "LatencyHeatmap request requires a Resource in a namespace",
+			},
+			{
+				name:     "service",
+				req:      &pb.LatencyHeatmapRequest{Resource: &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Service, Name: "web-svc"}},
+				expected: "Resource type is not supported: service",
+			},
+			{
+				name:     "invalid step",
+				req:      &pb.LatencyHeatmapRequest{Resource: web, Step: "1"},
+				expected: `invalid step "1": time: missing unit in duration "1"`,
+			},
+			{
+				name:     "step longer than the time range",
+				req:      &pb.LatencyHeatmapRequest{Resource: web, TimeRange: "1m", Step: "5m"},
+				expected: "the step must be at least 1s and at most the time range, got 5m and 1m",
+			},
+			{
+				name:     "too many steps",
+				req:      &pb.LatencyHeatmapRequest{Resource: web, TimeRange: "24h", Step: "10s"},
+				expected: "the time range can't span more than 1000 steps, got 24h by steps of 10s",
+			},
+		}
+
+		for _, tc := range testCases {
+			tc := tc // pin
+			t.Run(tc.name, func(t *testing.T) {
+				_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{})
+				if err != nil {
+					t.Fatalf("Error creating mock grpc server: %s", err)
+				}
+
+				rsp, err := fakeGrpcServer.LatencyHeatmap(context.TODO(), tc.req)
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				if rsp.GetError().GetError() != tc.expected {
+					t.Fatalf("Expected error %q, got %+v", tc.expected, rsp)
+				}
+			})
+		}
+	})
+}
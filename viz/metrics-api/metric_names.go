@@ -0,0 +1,171 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+var (
+	// proxyMetrics lists the metrics of the proxies the queries rely on, which
+	// can be renamed
+	proxyMetrics = map[string]struct{}{
+		"response_total":                   {},
+		"response_latency_ms_bucket":       {},
+		"route_response_total":             {},
+		"route_actual_response_total":      {},
+		"route_response_latency_ms_bucket": {},
+		"inbound_http_authz_allow_total":   {},
+		"inbound_http_authz_deny_total":    {},
+		"tcp_open_connections":             {},
+		"tcp_read_bytes_total":             {},
+		"tcp_write_bytes_total":            {},
+		"process_start_time_seconds":       {},
+		"gateway_alive":                    {},
+		"gateway_probe_latency_ms_bucket":  {},
+	}
+
+	// matches, in order, the quoted strings, whose content is left as is, the
+	// range functions applied to a metric, e.g. `increase(response_total{direction="inbound"}[1m])`,
+	// and the names of metrics
+	metricUseRegex = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|(increase|irate|rate)\(([a-zA-Z_:][a-zA-Z0-9_:]*)(\{[^}]*\})?\[([^\]]+)\]\)|[a-zA-Z_:][a-zA-Z0-9_:]*`)
+	// matches the names of recording rules holding the per-second rate of a
+	// metric, e.g. `rate(namespace:response_total:rate5m)`
+	rateNameRegex = regexp.MustCompile(`^rate\((.+)\)$`)
+)
+
+// metricName is the name a proxy metric is queried as. When rate is set, the
+// name is the one of a recording rule holding the per-second rate of the
+// metric, so the range functions applied to the metric are rewritten.
+type metricName struct {
+	name string
+	rate bool
+}
+
+// metricNames maps the proxy metrics to the names they are queried as, e.g.
+// when they're renamed at scrape time or pre-aggregated by recording rules.
+// The queries are rewritten to use those names, so the recording rules must
+// preserve the labels the queries filter and group by.
+type metricNames struct {
+	prefix string
+	names  map[string]metricName
+}
+
+// newMetricNames returns the metricNames for the given prefix, prepended to
+// the names of the proxy metrics, and map of proxy metrics to names, as
+// returned by ParseMetricNames, which take precedence over the prefix. It
+// returns nil when the metrics aren't renamed.
+func newMetricNames(prefix string, names map[string]string) *metricNames {
+	if prefix == "" && len(names) == 0 {
+		return nil
+	}
+	mn := &metricNames{
+		prefix: prefix,
+		names:  make(map[string]metricName, len(names)),
+	}
+	for metric, name := range names {
+		if rate := rateNameRegex.FindStringSubmatch(name); rate != nil {
+			mn.names[metric] = metricName{name: rate[1], rate: true}
+			continue
+		}
+		mn.names[metric] = metricName{name: name}
+	}
+	return mn
+}
+
+// ValidateMetricPrefix checks that the prefix can be prepended to the names of
+// the proxy metrics
+func ValidateMetricPrefix(prefix string) error {
+	if prefix != "" && !model.IsValidMetricName(model.LabelValue(prefix+"response_total")) {
+		return fmt.Errorf("invalid metric prefix %q", prefix)
+	}
+	return nil
+}
+
+// ParseMetricNames parses a comma-separated list of <metric>=<name> pairs,
+// where metric is one of the proxy metrics the queries rely on. The name of a
+// recording rule holding the per-second rate of the metric is wrapped in
+// rate(), e.g. `response_total=rate(namespace:response_total:rate5m)`.
+func ParseMetricNames(value string) (map[string]string, error) {
+	names := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.Split(pair, "=")
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("invalid metric name %q, expected <metric>=<name>", pair)
+		}
+		metric, name := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if _, ok := proxyMetrics[metric]; !ok {
+			return nil, fmt.Errorf("unknown proxy metric %q", metric)
+		}
+		if _, ok := names[metric]; ok {
+			return nil, fmt.Errorf("metric %q is renamed more than once", metric)
+		}
+		ruleName := name
+		if rate := rateNameRegex.FindStringSubmatch(name); rate != nil {
+			ruleName = rate[1]
+		}
+		if !model.IsValidMetricName(model.LabelValue(ruleName)) {
+			return nil, fmt.Errorf("invalid name %q for metric %q", name, metric)
+		}
+		names[metric] = name
+	}
+	return names, nil
+}
+
+// rewriteQuery replaces the proxy metrics of the query with the names they
+// are queried as. The range functions applied to the metrics held by rate
+// recording rules are replaced with the average of the rules over the range,
+// scaled to the range for increase(), and with the rules themselves for
+// irate().
+func (mn *metricNames) rewriteQuery(query string) string {
+	if mn == nil {
+		return query
+	}
+
+	return metricUseRegex.ReplaceAllStringFunc(query, func(use string) string {
+		if strings.HasPrefix(use, `"`) {
+			return use
+		}
+		parts := metricUseRegex.FindStringSubmatch(use)
+		if parts[1] == "" {
+			return mn.name(use).name
+		}
+
+		function, metric, labels, window := parts[1], parts[2], parts[3], parts[4]
+		name := mn.name(metric)
+		if !name.rate {
+			return fmt.Sprintf("%s(%s%s[%s])", function, name.name, labels, window)
+		}
+		switch function {
+		case "irate":
+			return name.name + labels
+		case "rate":
+			return fmt.Sprintf("avg_over_time(%s%s[%s])", name.name, labels, window)
+		}
+		duration, err := model.ParseDuration(window)
+		if err != nil {
+			// the window is left to Prometheus to reject
+			return fmt.Sprintf("%s(%s%s[%s])", function, name.name, labels, window)
+		}
+		return fmt.Sprintf("(avg_over_time(%s%s[%s]) * %v)", name.name, labels, window, time.Duration(duration).Seconds())
+	})
+}
+
+// name returns the name the metric is queried as, the metric itself when it
+// isn't a proxy metric
+func (mn *metricNames) name(metric string) metricName {
+	if _, ok := proxyMetrics[metric]; !ok {
+		return metricName{name: metric}
+	}
+	if name, ok := mn.names[metric]; ok {
+		return name
+	}
+	return metricName{name: mn.prefix + metric}
+}
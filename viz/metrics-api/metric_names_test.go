@@ -0,0 +1,130 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMetricNames(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected map[string]string
+		err      bool
+	}{
+		{
+			value:    "",
+			expected: map[string]string{},
+		},
+		{
+			value: "response_total=rate(namespace:response_total:rate5m), tcp_open_connections = l5d_tcp_open_connections",
+			expected: map[string]string{
+				"response_total":       "rate(namespace:response_total:rate5m)",
+				"tcp_open_connections": "l5d_tcp_open_connections",
+			},
+		},
+		{
+			value: "response_total",
+			err:   true,
+		},
+		{
+			value: "request_total=l5d_request_total",
+			err:   true,
+		},
+		{
+			value: "response_total=l5d-response-total",
+			err:   true,
+		},
+		{
+			value: "response_total=rate()",
+			err:   true,
+		},
+		{
+			value: "response_total=a,response_total=b",
+			err:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.value, func(t *testing.T) {
+			names, err := ParseMetricNames(tc.value)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("Expected an error, got %v", names)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(names, tc.expected) {
+				t.Fatalf("Expected %v, got %v", tc.expected, names)
+			}
+		})
+	}
+}
+
+func TestMetricNamesRewriteQuery(t *testing.T) {
+	names := newMetricNames("l5d_", map[string]string{
+		"response_total":             "rate(namespace:response_total:rate5m)",
+		"response_latency_ms_bucket": "rate(namespace:response_latency_ms_bucket:rate5m)",
+		"tcp_read_bytes_total":       "proxy_tcp_read_bytes_total",
+	})
+
+	testCases := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{
+			name:     "prefixed metric",
+			query:    `sum(tcp_open_connections{direction="inbound", namespace="emojivoto"}) by (namespace)`,
+			expected: `sum(l5d_tcp_open_connections{direction="inbound", namespace="emojivoto"}) by (namespace)`,
+		},
+		{
+			name:     "renamed metric",
+			query:    `sum(increase(tcp_read_bytes_total{peer="src"}[1m])) by (pod)`,
+			expected: `sum(increase(proxy_tcp_read_bytes_total{peer="src"}[1m])) by (pod)`,
+		},
+		{
+			name:     "increase of a rate recording rule",
+			query:    `sum(increase(response_total{direction="inbound"}[1m])) by (pod, classification, tls)`,
+			expected: `sum((avg_over_time(namespace:response_total:rate5m{direction="inbound"}[1m]) * 60)) by (pod, classification, tls)`,
+		},
+		{
+			name:     "rate of a rate recording rule",
+			query:    `sum(rate(response_total{direction="inbound"}[10m])) by (pod)`,
+			expected: `sum(avg_over_time(namespace:response_total:rate5m{direction="inbound"}[10m])) by (pod)`,
+		},
+		{
+			name:     "irate of a rate recording rule",
+			query:    `histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="outbound"}[30s])) by (le, pod))`,
+			expected: `histogram_quantile(0.5, sum(namespace:response_latency_ms_bucket:rate5m{direction="outbound"}) by (le, pod))`,
+		},
+		{
+			name:     "metric names in label values are left as is",
+			query:    `count(response_total{pod=~"response_total|web"}) by (tls)`,
+			expected: `count(namespace:response_total:rate5m{pod=~"response_total|web"}) by (tls)`,
+		},
+		{
+			name:     "other metrics are left as is",
+			query:    `sum(prometheus_query_duration_seconds_count) by (le)`,
+			expected: `sum(prometheus_query_duration_seconds_count) by (le)`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			query := names.rewriteQuery(tc.query)
+			if query != tc.expected {
+				t.Fatalf("Expected query:\n%s\ngot:\n%s", tc.expected, query)
+			}
+		})
+	}
+
+	var noNames *metricNames
+	if query := noNames.rewriteQuery(testCases[0].query); query != testCases[0].query {
+		t.Fatalf("Expected the query to be unchanged, got %s", query)
+	}
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/common/model"
+)
+
+var (
+	// namespaceLabels lists the labels holding a namespace in the proxy
+	// metrics, along with the regex matching their use in a query
+	namespaceLabels = []model.LabelName{namespaceLabel, dstNamespaceLabel, gatewayNamespaceLabel}
+	labelUseRegexes = map[model.LabelName]*regexp.Regexp{
+		namespaceLabel:        regexp.MustCompile(`\bnamespace\b`),
+		dstNamespaceLabel:     regexp.MustCompile(`\bdst_namespace\b`),
+		gatewayNamespaceLabel: regexp.MustCompile(`\bgateway_namespace\b`),
+	}
+
+	// matches the label matchers on the namespace labels, e.g.
+	// `dst_namespace="emojivoto"` or `namespace=~"^(books|emojivoto)$"`
+	namespaceMatcherRegex = regexp.MustCompile(`\b(namespace|dst_namespace|gateway_namespace)(=~|!~|!=|=)"([^"]*)"`)
+	// matches the values of the regex matchers built by namesRegex
+	namesRegexValue = regexp.MustCompile(`^\^\(([^()]*)\)\$$`)
+)
+
+// namespaceAliases maps the namespaces found in the metrics to the names they
+// are presented with, e.g. the physical namespaces of a vcluster to the
+// namespaces of its tenant. The aliases are used in the queries, which are
+// rewritten to match the physical namespaces and to relabel the results with
+// label_replace, so that they can be matched against the Kubernetes resources.
+type namespaceAliases struct {
+	// aliases maps the physical namespaces to their alias
+	aliases map[string]string
+	// physical maps the aliases to their physical namespace
+	physical map[string]string
+}
+
+// newNamespaceAliases returns the namespaceAliases for the given map of
+// physical namespaces to aliases, as returned by ParseNamespaceAliases. It
+// returns nil when there are no aliases.
+func newNamespaceAliases(aliases map[string]string) *namespaceAliases {
+	if len(aliases) == 0 {
+		return nil
+	}
+	na := &namespaceAliases{
+		aliases:  make(map[string]string, len(aliases)),
+		physical: make(map[string]string, len(aliases)),
+	}
+	for ns, alias := range aliases {
+		na.aliases[ns] = alias
+		na.physical[alias] = ns
+	}
+	return na
+}
+
+// ParseNamespaceAliases parses a comma-separated list of <namespace>=<alias>
+// pairs. Each namespace can only be aliased once, and the aliases must be
+// unique.
+func ParseNamespaceAliases(value string) (map[string]string, error) {
+	aliases := make(map[string]string)
+	namespaces := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.Split(pair, "=")
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("invalid namespace alias %q, expected <namespace>=<alias>", pair)
+		}
+		ns, alias := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if _, ok := aliases[ns]; ok {
+			return nil, fmt.Errorf("namespace %q is aliased more than once", ns)
+		}
+		if other, ok := namespaces[alias]; ok {
+			return nil, fmt.Errorf("namespaces %q and %q have the same alias %q", other, ns, alias)
+		}
+		aliases[ns] = alias
+		namespaces[alias] = ns
+	}
+	return aliases, nil
+}
+
+// rewriteQuery rewrites the matchers on the namespace labels of the query to
+// match the physical namespaces, and wraps the query with label_replace so
+// that its results are labeled with the aliases
+func (na *namespaceAliases) rewriteQuery(query string) string {
+	if na == nil {
+		return query
+	}
+
+	query = namespaceMatcherRegex.ReplaceAllStringFunc(query, func(matcher string) string {
+		parts := namespaceMatcherRegex.FindStringSubmatch(matcher)
+		label, op, value := parts[1], parts[2], parts[3]
+		if op == "=" || op == "!=" {
+			return fmt.Sprintf("%s%s%q", label, op, na.physicalName(value))
+		}
+		names := namesRegexValue.FindStringSubmatch(value)
+		if names == nil {
+			// only the regexes matching a list of names are rewritten
+			return matcher
+		}
+		physical := []string{}
+		for _, name := range strings.Split(names[1], "|") {
+			physical = append(physical, na.physicalName(name))
+		}
+		return fmt.Sprintf("%s%s%q", label, op, "^"+namesRegex(physical)+"$")
+	})
+
+	namespaces := make([]string, 0, len(na.aliases))
+	for ns := range na.aliases {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	for _, label := range namespaceLabels {
+		// the results can only hold the labels the query refers to
+		if !labelUseRegexes[label].MatchString(query) {
+			continue
+		}
+		for _, ns := range namespaces {
+			query = fmt.Sprintf("label_replace(%s, %q, %q, %q, %q)",
+				query, label, na.aliases[ns], label, "^"+regexp.QuoteMeta(ns)+"$")
+		}
+	}
+	return query
+}
+
+func (na *namespaceAliases) physicalName(name string) string {
+	if ns, ok := na.physical[name]; ok {
+		return ns
+	}
+	return name
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseNamespaceAliases(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected map[string]string
+		err      bool
+	}{
+		{
+			value:    "",
+			expected: map[string]string{},
+		},
+		{
+			value:    "web-x-tenant1=web, db-x-tenant1 = db",
+			expected: map[string]string{"web-x-tenant1": "web", "db-x-tenant1": "db"},
+		},
+		{
+			value: "web-x-tenant1",
+			err:   true,
+		},
+		{
+			value: "web-x-tenant1=",
+			err:   true,
+		},
+		{
+			value: "web-x-tenant1=web,web-x-tenant1=db",
+			err:   true,
+		},
+		{
+			value: "web-x-tenant1=web,web-x-tenant2=web",
+			err:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.value, func(t *testing.T) {
+			aliases, err := ParseNamespaceAliases(tc.value)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("Expected an error, got %v", aliases)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(aliases, tc.expected) {
+				t.Fatalf("Expected %v, got %v", tc.expected, aliases)
+			}
+		})
+	}
+}
+
+func TestNamespaceAliasesRewriteQuery(t *testing.T) {
+	aliases := newNamespaceAliases(map[string]string{
+		"web-x-tenant1":   "web",
+		"books-x-tenant1": "books",
+	})
+
+	testCases := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{
+			name:     "query without namespace labels",
+			query:    `sum(increase(response_total{direction="inbound"}[1m])) by (pod)`,
+			expected: `sum(increase(response_total{direction="inbound"}[1m])) by (pod)`,
+		},
+		{
+			name:     "query matching an aliased namespace",
+			query:    `sum(increase(response_total{direction="inbound", namespace="web"}[1m])) by (namespace, pod)`,
+			expected: `label_replace(label_replace(sum(increase(response_total{direction="inbound", namespace="web-x-tenant1"}[1m])) by (namespace, pod), "namespace", "books", "namespace", "^books-x-tenant1$"), "namespace", "web", "namespace", "^web-x-tenant1$")`,
+		},
+		{
+			name:     "query matching a list of namespaces",
+			query:    `sum(tcp_open_connections{namespace=~"^(books|emojivoto|web)$"}) by (namespace)`,
+			expected: `label_replace(label_replace(sum(tcp_open_connections{namespace=~"^(books-x-tenant1|emojivoto|web-x-tenant1)$"}) by (namespace), "namespace", "books", "namespace", "^books-x-tenant1$"), "namespace", "web", "namespace", "^web-x-tenant1$")`,
+		},
+		{
+			name:     "query on destination namespaces",
+			query:    `sum(increase(response_total{dst_namespace="books"}[1m])) by (dst_namespace)`,
+			expected: `label_replace(label_replace(sum(increase(response_total{dst_namespace="books-x-tenant1"}[1m])) by (dst_namespace), "dst_namespace", "books", "dst_namespace", "^books-x-tenant1$"), "dst_namespace", "web", "dst_namespace", "^web-x-tenant1$")`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			query := aliases.rewriteQuery(tc.query)
+			if query != tc.expected {
+				t.Fatalf("Expected query:\n%s\ngot:\n%s", tc.expected, query)
+			}
+		})
+	}
+
+	var noAliases *namespaceAliases
+	if query := noAliases.rewriteQuery(testCases[1].query); query != testCases[1].query {
+		t.Fatalf("Expected the query to be unchanged, got %s", query)
+	}
+}
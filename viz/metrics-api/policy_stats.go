@@ -0,0 +1,97 @@
+package api
+
+import (
+	serverv1beta1 "github.com/linkerd/linkerd2/controller/gen/apis/server/v1beta1"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const proxyInboundDefaultPolicyEnv = "LINKERD2_PROXY_INBOUND_DEFAULT_POLICY"
+
+// getPolicyStats returns the number of Servers selecting any of the given
+// pods, the number of ServerAuthorizations attached to those Servers, and
+// whether all the meshed pods deny inbound traffic by default.
+func (s *grpcServer) getPolicyStats(namespace string, pods []*corev1.Pod) (*pb.PolicyStats, error) {
+	stats := &pb.PolicyStats{}
+	if len(pods) == 0 {
+		return stats, nil
+	}
+
+	servers, err := s.k8sAPI.Srv().Lister().Servers(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	attached := make([]*serverv1beta1.Server, 0)
+	for _, server := range servers {
+		if server.Spec.PodSelector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(server.Spec.PodSelector)
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range pods {
+			if selector.Matches(labels.Set(pod.Labels)) {
+				attached = append(attached, server)
+				break
+			}
+		}
+	}
+	stats.Servers = uint64(len(attached))
+
+	sazs, err := s.k8sAPI.Saz().Lister().ServerAuthorizations(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, saz := range sazs {
+		var selector labels.Selector
+		if saz.Spec.Server.Selector != nil {
+			selector, err = metav1.LabelSelectorAsSelector(saz.Spec.Server.Selector)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, server := range attached {
+			if (saz.Spec.Server.Name != "" && saz.Spec.Server.Name == server.GetName()) ||
+				(selector != nil && selector.Matches(labels.Set(server.GetLabels()))) {
+				stats.ServerAuthorizations++
+				break
+			}
+		}
+	}
+
+	meshed := 0
+	denied := 0
+	for _, pod := range pods {
+		if !k8s.IsMeshed(pod, s.controllerNamespace) {
+			continue
+		}
+		meshed++
+		if getInboundDefaultPolicy(pod) == k8s.Deny {
+			denied++
+		}
+	}
+	stats.DefaultDeny = meshed > 0 && meshed == denied
+
+	return stats, nil
+}
+
+// getInboundDefaultPolicy returns the default inbound policy the pod's proxy
+// was injected with
+func getInboundDefaultPolicy(pod *corev1.Pod) string {
+	for _, container := range pod.Spec.Containers {
+		if container.Name != k8s.ProxyContainerName {
+			continue
+		}
+		for _, env := range container.Env {
+			if env.Name == proxyInboundDefaultPolicyEnv {
+				return env.Value
+			}
+		}
+	}
+	return ""
+}
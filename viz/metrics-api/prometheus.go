@@ -5,12 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"regexp"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/prometheus/common/model"
 	log "github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
@@ -44,6 +47,13 @@ const (
 	authorityLabel           = model.LabelName("authority")
 	serverLabel              = model.LabelName("srv_name")
 	serverAuthorizationLabel = model.LabelName("saz_name")
+	routeNameLabel           = model.LabelName("route_name")
+	routeKindLabel           = model.LabelName("route_kind")
+	podLabel                 = model.LabelName("pod")
+	podUIDLabel              = model.LabelName("pod_uid")
+	dstPodLabel              = model.LabelName("dst_pod")
+	clientIDLabel            = model.LabelName("client_id")
+	targetPortLabel          = model.LabelName("target_port")
 )
 
 var (
@@ -51,6 +61,64 @@ var (
 	ErrNoPrometheusInstance = errors.New("No prometheus instance to connect")
 )
 
+// queryRecorder collects the queries run on behalf of a request. They're all
+// evaluated at the same time, so that the results can be reproduced by
+// running them again.
+type queryRecorder struct {
+	sync.Mutex
+	at      time.Time
+	queries []*pb.PromQuery
+}
+
+type queryRecorderKey struct{}
+
+func withQueryRecorder(ctx context.Context, at time.Time) (context.Context, *queryRecorder) {
+	recorder := &queryRecorder{at: at.UTC().Truncate(time.Second)}
+	return context.WithValue(ctx, queryRecorderKey{}, recorder), recorder
+}
+
+func queryRecorderFrom(ctx context.Context) *queryRecorder {
+	recorder, _ := ctx.Value(queryRecorderKey{}).(*queryRecorder)
+	return recorder
+}
+
+func (r *queryRecorder) record(typ promType, query string) {
+	r.Lock()
+	defer r.Unlock()
+	r.queries = append(r.queries, &pb.PromQuery{
+		Name:           promQueryName(typ),
+		Query:          query,
+		EvaluationTime: r.at.Format(time.RFC3339),
+	})
+}
+
+// recorded returns the recorded queries, sorted by name
+func (r *queryRecorder) recorded() []*pb.PromQuery {
+	r.Lock()
+	defer r.Unlock()
+	queries := append([]*pb.PromQuery{}, r.queries...)
+	sort.Slice(queries, func(i, j int) bool {
+		if queries[i].GetName() == queries[j].GetName() {
+			return queries[i].GetQuery() < queries[j].GetQuery()
+		}
+		return queries[i].GetName() < queries[j].GetName()
+	})
+	return queries
+}
+
+func promQueryName(typ promType) string {
+	switch typ {
+	case promLatencyP50:
+		return "QUERY_LATENCY_P50"
+	case promLatencyP95:
+		return "QUERY_LATENCY_P95"
+	case promLatencyP99:
+		return "QUERY_LATENCY_P99"
+	default:
+		return string(typ)
+	}
+}
+
 func extractSampleValue(sample *model.Sample) uint64 {
 	value := uint64(0)
 	if !math.IsNaN(float64(sample.Value)) {
@@ -60,9 +128,59 @@ func extractSampleValue(sample *model.Sample) uint64 {
 }
 
 func (s *grpcServer) queryProm(ctx context.Context, query string) (model.Vector, error) {
+	// single data point (aka summary) query, evaluated now unless the queries
+	// are recorded or bounded
+	evalTime := evalTimeFrom(ctx)
+	if recorder := queryRecorderFrom(ctx); recorder != nil {
+		evalTime = recorder.at
+	}
+
+	res, err := s.runPromQuery(ctx, "query.prometheus", query, func(ctx context.Context, query string) (model.Value, promv1.Warnings, error) {
+		return s.prometheusAPI.Query(ctx, query, evalTime)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Type() != model.ValVector {
+		err = fmt.Errorf("Unexpected query result type (expected Vector): %s", res.Type())
+		log.Error(err)
+		return nil, err
+	}
+
+	return res.(model.Vector), nil
+}
+
+// queryPromRange runs a range query, returning a series of data points per
+// label set
+func (s *grpcServer) queryPromRange(ctx context.Context, query string, r promv1.Range) (model.Matrix, error) {
+	res, err := s.runPromQuery(ctx, "query.prometheus.range", query, func(ctx context.Context, query string) (model.Value, promv1.Warnings, error) {
+		return s.prometheusAPI.QueryRange(ctx, query, r)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Type() != model.ValMatrix {
+		err = fmt.Errorf("Unexpected query result type (expected Matrix): %s", res.Type())
+		log.Error(err)
+		return nil, err
+	}
+
+	return res.(model.Matrix), nil
+}
+
+// runPromQuery runs a query through the query limiter, tracing and logging it
+func (s *grpcServer) runPromQuery(
+	ctx context.Context,
+	spanName, query string,
+	run func(context.Context, string) (model.Value, promv1.Warnings, error),
+) (model.Value, error) {
+	query = s.metricNames.rewriteQuery(query)
+	query = s.namespaceAliases.rewriteQuery(query)
 	log.Debugf("Query request:\n\t%+v", query)
 
-	_, span := trace.StartSpan(ctx, "query.prometheus")
+	_, span := trace.StartSpan(ctx, spanName)
 	defer span.End()
 	span.AddAttributes(trace.StringAttribute("queryString", query))
 
@@ -70,24 +188,35 @@ func (s *grpcServer) queryProm(ctx context.Context, query string) (model.Vector,
 		return nil, ErrNoPrometheusInstance
 	}
 
-	// single data point (aka summary) query
-	res, warn, err := s.prometheusAPI.Query(ctx, query, time.Time{})
+	release, err := s.queryLimiter.acquire(ctx)
+	if err != nil {
+		log.Errorf("Query(%+v) failed with: %+v", query, err)
+		return nil, err
+	}
+	start := time.Now()
+	res, warn, err := run(ctx, query)
+	observeQueryDuration(start, err)
+	s.prometheusHealth.observe(err)
+	release()
+	series := 0
+	switch value := res.(type) {
+	case model.Vector:
+		series = len(value)
+	case model.Matrix:
+		series = len(value)
+	}
+	s.queryLog.observe(span, query, time.Since(start), series, err)
 	if err != nil {
 		log.Errorf("Query(%+v) failed with: %+v", query, err)
 		return nil, err
 	}
+	querySeriesHistogram.Observe(float64(series))
 	if warn != nil {
 		log.Warnf("%v", warn)
 	}
 	log.Debugf("Query response:\n\t%+v", res)
 
-	if res.Type() != model.ValVector {
-		err = fmt.Errorf("Unexpected query result type (expected Vector): %s", res.Type())
-		log.Error(err)
-		return nil, err
-	}
-
-	return res.(model.Vector), nil
+	return res, nil
 }
 
 // add filtering by resource type
@@ -123,6 +252,8 @@ func promQueryLabels(resource *pb.Resource) model.LabelSet {
 				set[serverLabel] = model.LabelValue(resource.GetName())
 			} else if resource.GetType() == k8s.ServerAuthorization {
 				set[serverAuthorizationLabel] = model.LabelValue(resource.GetName())
+			} else if resource.GetType() == k8s.HTTPRoute {
+				set[routeNameLabel] = model.LabelValue(resource.GetName())
 			} else if resource.GetType() != k8s.Service {
 				set[promResourceType(resource)] = model.LabelValue(resource.Name)
 			}
@@ -180,6 +311,39 @@ func generateLabelStringWithRegex(l model.LabelSet, labelName string, stringToMa
 	return fmt.Sprintf("{%s}", strings.Join(lstrs, ", "))
 }
 
+// generateLabelStringWithNames is like model.LabelSet.String, but also
+// restricts the values of the given label to a list of names. The names are
+// ignored when nil, so that the label can take any value.
+func generateLabelStringWithNames(l model.LabelSet, labelName model.LabelName, names []string) string {
+	if names == nil {
+		return l.String()
+	}
+
+	lstrs := make([]string, 0, len(l)+1)
+	for l, v := range l {
+		lstrs = append(lstrs, fmt.Sprintf("%s=%q", l, v))
+	}
+	lstrs = append(lstrs, fmt.Sprintf("%s=~%q", labelName, "^"+namesRegex(names)+"$"))
+
+	sort.Strings(lstrs)
+	return fmt.Sprintf("{%s}", strings.Join(lstrs, ", "))
+}
+
+// namesRegex returns a regex group matching any of the names
+func namesRegex(names []string) string {
+	seen := make(map[string]struct{})
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		quoted = append(quoted, regexp.QuoteMeta(name))
+	}
+	sort.Strings(quoted)
+	return "(" + strings.Join(quoted, "|") + ")"
+}
+
 // generate Prometheus queries for latency quantiles, based on a quantile query
 // template, query labels, a time window and grouping.
 func generateQuantileQueries(quantileQuery, labels, timeWindow, groupBy string) map[promType]string {
@@ -214,6 +378,15 @@ func promResourceType(resource *pb.Resource) model.LabelName {
 }
 
 func (s *grpcServer) getPrometheusMetrics(ctx context.Context, requestQueries map[promType]string, latencyQueries map[promType]string) ([]promResult, error) {
+	if recorder := queryRecorderFrom(ctx); recorder != nil {
+		for pt, query := range requestQueries {
+			recorder.record(pt, s.namespaceAliases.rewriteQuery(query))
+		}
+		for quantile, query := range latencyQueries {
+			recorder.record(quantile, s.namespaceAliases.rewriteQuery(query))
+		}
+	}
+
 	resultChan := make(chan promResult)
 
 	for pt, query := range requestQueries {
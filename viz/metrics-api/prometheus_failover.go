@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	promApi "github.com/prometheus/client_golang/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+)
+
+var backendQueriesCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "prometheus_backend_queries_total",
+	Help: "Number of requests sent to each Prometheus backend, by outcome",
+}, []string{"backend", "status"})
+
+// failoverClient is a Prometheus client sending the requests to the primary
+// instance of an HA pair, and to its replicas in turn when it fails or times
+// out, so that the stats remain available while an instance restarts.
+// Requests rejected by a backend, such as invalid queries, aren't retried.
+type failoverClient struct {
+	backends []*promBackend
+	// timeout bounds the time spent on each backend but the last one; 0 lets
+	// a backend take as long as the request lasts
+	timeout time.Duration
+}
+
+type promBackend struct {
+	endpoint *url.URL
+	client   promApi.Client
+}
+
+// NewFailoverClient returns a Prometheus client for the given URLs, the first
+// one being the primary and the others its replicas. A single URL returns a
+// plain client.
+func NewFailoverClient(urls []string, timeout time.Duration) (promApi.Client, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("no Prometheus URL provided")
+	}
+	if len(urls) == 1 {
+		return promApi.NewClient(promApi.Config{Address: urls[0]})
+	}
+
+	c := &failoverClient{timeout: timeout}
+	for _, u := range urls {
+		endpoint, err := url.Parse(u)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Prometheus URL %s: %s", u, err)
+		}
+		endpoint.Path = strings.TrimRight(endpoint.Path, "/")
+		client, err := promApi.NewClient(promApi.Config{Address: u})
+		if err != nil {
+			return nil, err
+		}
+		c.backends = append(c.backends, &promBackend{endpoint, client})
+	}
+	return c, nil
+}
+
+// URL returns the URL of the endpoint on the primary backend; Do rewrites it
+// for the replicas
+func (c *failoverClient) URL(ep string, args map[string]string) *url.URL {
+	return c.backends[0].client.URL(ep, args)
+}
+
+func (c *failoverClient) Do(ctx context.Context, req *http.Request) (*http.Response, []byte, error) {
+	for i, backend := range c.backends {
+		r, err := c.backendRequest(req, backend)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		backendCtx, cancel := ctx, func() {}
+		if c.timeout > 0 && i < len(c.backends)-1 {
+			backendCtx, cancel = context.WithTimeout(ctx, c.timeout)
+		}
+		resp, body, err := backend.client.Do(backendCtx, r)
+		cancel()
+
+		if err == nil && resp.StatusCode < http.StatusInternalServerError {
+			backendQueriesCounter.WithLabelValues(backend.endpoint.Host, "success").Inc()
+			log.Debugf("Prometheus request %s served by %s", req.URL.Path, backend.endpoint.Host)
+			return resp, body, nil
+		}
+		backendQueriesCounter.WithLabelValues(backend.endpoint.Host, "failure").Inc()
+
+		// the response of the last backend is returned as is, for its error
+		// to be decoded; and once the request itself is over, the next
+		// backends would fail as well
+		if i == len(c.backends)-1 || ctx.Err() != nil {
+			return resp, body, err
+		}
+		if err == nil {
+			err = fmt.Errorf("%s returned %s", backend.endpoint.Host, resp.Status)
+		}
+		log.Warnf("Prometheus backend %s failed, falling back to %s: %s", backend.endpoint.Host, c.backends[i+1].endpoint.Host, err)
+	}
+	return nil, nil, errors.New("no Prometheus backend")
+}
+
+// backendRequest returns a copy of the request built for the primary backend,
+// pointing at the given backend
+func (c *failoverClient) backendRequest(req *http.Request, backend *promBackend) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	primary := c.backends[0].endpoint
+	r.URL.Scheme = backend.endpoint.Scheme
+	r.URL.Host = backend.endpoint.Host
+	r.URL.User = backend.endpoint.User
+	r.URL.Path = backend.endpoint.Path + strings.TrimPrefix(req.URL.Path, primary.Path)
+	r.URL.RawPath = ""
+	r.Host = ""
+
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	return r, nil
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+)
+
+func newPromBackend(name string, handler func(w http.ResponseWriter, r *http.Request)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if handler != nil {
+			handler(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"backend":"` + name + `"},"value":[1,"1"]}]}}`))
+	}))
+}
+
+func TestFailoverClient(t *testing.T) {
+	unavailable := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	slow := func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}
+	badQuery := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+	}
+
+	testCases := []struct {
+		name     string
+		primary  func(w http.ResponseWriter, r *http.Request)
+		replica  func(w http.ResponseWriter, r *http.Request)
+		expected string
+		err      bool
+	}{
+		{
+			name:     "primary serves the query",
+			expected: "primary",
+		},
+		{
+			name:     "replica serves the query when the primary fails",
+			primary:  unavailable,
+			expected: "replica",
+		},
+		{
+			name:     "replica serves the query when the primary times out",
+			primary:  slow,
+			expected: "replica",
+		},
+		{
+			name:    "invalid queries aren't retried",
+			primary: badQuery,
+			err:     true,
+		},
+		{
+			name:    "query fails when all the backends fail",
+			primary: unavailable,
+			replica: unavailable,
+			err:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			primary := newPromBackend("primary", tc.primary)
+			defer primary.Close()
+			replica := newPromBackend("replica", tc.replica)
+			defer replica.Close()
+
+			client, err := NewFailoverClient([]string{primary.URL, replica.URL}, 100*time.Millisecond)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			res, _, err := promv1.NewAPI(client).Query(context.Background(), "up", time.Time{})
+			if tc.err {
+				if err == nil {
+					t.Fatalf("Expected an error, got %s", res)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if backend := res.String(); backend != `{backend="`+tc.expected+`"} => 1 @[1]` {
+				t.Fatalf("Expected the query to be served by %s, got %s", tc.expected, backend)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	queuedQueriesGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "prometheus_queries_queued",
+		Help: "Number of Prometheus queries waiting for a free slot",
+	})
+
+	inflightQueriesGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "prometheus_queries_in_flight",
+		Help: "Number of Prometheus queries being evaluated",
+	})
+
+	queueWaitHistogram = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "prometheus_query_queue_duration_seconds",
+		Help:    "Time Prometheus queries spent waiting for a free slot",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	queryDurationHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "prometheus_query_duration_seconds",
+		Help:    "Time spent evaluating Prometheus queries, by outcome",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"status"})
+)
+
+// queryLimiter bounds the number of Prometheus queries evaluated at a time.
+// A single StatSummary request can fan out to a handful of queries per
+// resource type, which is enough to overload a small Prometheus instance.
+// Queries past the limit wait in line until a slot is freed, their request is
+// canceled or the queue timeout expires.
+type queryLimiter struct {
+	// slots is nil when the number of queries isn't limited
+	slots        chan struct{}
+	queueTimeout time.Duration
+}
+
+// newQueryLimiter returns a limiter allowing up to maxConcurrent queries at a
+// time; 0 disables the limit. A queueTimeout of 0 lets the queries wait for as
+// long as their request lasts.
+func newQueryLimiter(maxConcurrent int, queueTimeout time.Duration) *queryLimiter {
+	limiter := &queryLimiter{queueTimeout: queueTimeout}
+	if maxConcurrent > 0 {
+		limiter.slots = make(chan struct{}, maxConcurrent)
+	}
+	return limiter
+}
+
+// acquire waits for a free slot. On success, the returned function must be
+// called once the query is done to free the slot.
+func (l *queryLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil || l.slots == nil {
+		inflightQueriesGauge.Inc()
+		return inflightQueriesGauge.Dec, nil
+	}
+
+	start := time.Now()
+	queuedQueriesGauge.Inc()
+	defer queuedQueriesGauge.Dec()
+
+	var timeout <-chan time.Time
+	if l.queueTimeout > 0 {
+		timer := time.NewTimer(l.queueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		queueWaitHistogram.Observe(time.Since(start).Seconds())
+		inflightQueriesGauge.Inc()
+		return func() {
+			inflightQueriesGauge.Dec()
+			<-l.slots
+		}, nil
+	case <-timeout:
+		return nil, fmt.Errorf("timed out after %s waiting to query Prometheus, %d queries are already in flight", l.queueTimeout, cap(l.slots))
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func observeQueryDuration(start time.Time, err error) {
+	status := "success"
+	if err != nil {
+		status = "failure"
+	}
+	queryDurationHistogram.WithLabelValues(status).Observe(time.Since(start).Seconds())
+}
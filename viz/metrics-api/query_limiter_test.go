@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestQueryLimiter(t *testing.T) {
+	t.Run("Doesn't limit queries by default", func(t *testing.T) {
+		limiter := newQueryLimiter(0, 0)
+		for i := 0; i < 10; i++ {
+			if _, err := limiter.acquire(context.Background()); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+
+		var nilLimiter *queryLimiter
+		release, err := nilLimiter.acquire(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		release()
+	})
+
+	t.Run("Queues queries past the limit until a slot is freed", func(t *testing.T) {
+		limiter := newQueryLimiter(1, 0)
+		release, err := limiter.acquire(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		acquired := make(chan error)
+		go func() {
+			release, err := limiter.acquire(context.Background())
+			if err == nil {
+				release()
+			}
+			acquired <- err
+		}()
+
+		select {
+		case err := <-acquired:
+			t.Fatalf("Expected the query to wait for a free slot, got: %v", err)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		release()
+		select {
+		case err := <-acquired:
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for the queued query")
+		}
+	})
+
+	t.Run("Fails queued queries once the queue timeout expires", func(t *testing.T) {
+		limiter := newQueryLimiter(1, 10*time.Millisecond)
+		if _, err := limiter.acquire(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if _, err := limiter.acquire(context.Background()); err == nil {
+			t.Fatal("Expected the queued query to time out")
+		}
+	})
+
+	t.Run("Fails queued queries once their request is canceled", func(t *testing.T) {
+		limiter := newQueryLimiter(1, 0)
+		if _, err := limiter.acquire(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := limiter.acquire(ctx); !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected the queued query to be canceled, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// queryLog configures the logging of the Prometheus queries, to find the
+// requests that are the most expensive for Prometheus. It's disabled by
+// default.
+type queryLog struct {
+	// all logs every query, along with its latency and series count
+	all bool
+	// slowThreshold is the latency past which queries are logged as slow, and
+	// their span annotated; 0 disables it
+	slowThreshold time.Duration
+}
+
+func (l queryLog) isSlow(latency time.Duration) bool {
+	return l.slowThreshold > 0 && latency >= l.slowThreshold
+}
+
+// observe logs the query according to the configuration, and attaches its
+// latency and series count to its span
+func (l queryLog) observe(span *trace.Span, query string, latency time.Duration, series int, err error) {
+	span.AddAttributes(
+		trace.Int64Attribute("latencyMs", latency.Milliseconds()),
+		trace.Int64Attribute("series", int64(series)),
+	)
+	if err != nil {
+		span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
+	}
+
+	slow := l.isSlow(latency)
+	if slow {
+		span.Annotate([]trace.Attribute{
+			trace.Int64Attribute("thresholdMs", l.slowThreshold.Milliseconds()),
+		}, "slow query")
+	}
+	if !l.all && !slow {
+		return
+	}
+
+	entry := log.WithFields(log.Fields{
+		"query":   query,
+		"latency": latency,
+		"series":  series,
+	})
+	if err != nil {
+		entry = entry.WithError(err)
+	}
+	if slow {
+		entry.Warn("Slow Prometheus query")
+	} else {
+		entry.Info("Prometheus query")
+	}
+}
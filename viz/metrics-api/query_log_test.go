@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"go.opencensus.io/trace"
+)
+
+func TestQueryLog(t *testing.T) {
+	query := `sum(increase(response_total{direction="inbound"}[1m])) by (namespace)`
+
+	testCases := []struct {
+		name            string
+		queryLog        queryLog
+		latency         time.Duration
+		err             error
+		expectedLevel   log.Level
+		expectedMessage string
+	}{
+		{
+			name:     "doesn't log queries by default",
+			queryLog: queryLog{},
+			latency:  time.Minute,
+		},
+		{
+			name:            "logs every query",
+			queryLog:        queryLog{all: true},
+			latency:         10 * time.Millisecond,
+			expectedLevel:   log.InfoLevel,
+			expectedMessage: "Prometheus query",
+		},
+		{
+			name:     "doesn't log queries under the slow threshold",
+			queryLog: queryLog{slowThreshold: time.Second},
+			latency:  10 * time.Millisecond,
+		},
+		{
+			name:            "logs slow queries",
+			queryLog:        queryLog{all: true, slowThreshold: time.Second},
+			latency:         2 * time.Second,
+			err:             errors.New("query timed out"),
+			expectedLevel:   log.WarnLevel,
+			expectedMessage: "Slow Prometheus query",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			hook := logtest.NewGlobal()
+			defer hook.Reset()
+
+			_, span := trace.StartSpan(context.Background(), "query.prometheus")
+			tc.queryLog.observe(span, query, tc.latency, 3, tc.err)
+			span.End()
+
+			entries := hook.AllEntries()
+			if tc.expectedMessage == "" {
+				if len(entries) != 0 {
+					t.Fatalf("Expected no log, got: %v", entries[0].Message)
+				}
+				return
+			}
+			if len(entries) != 1 {
+				t.Fatalf("Expected 1 log, got %d", len(entries))
+			}
+			entry := entries[0]
+			if entry.Level != tc.expectedLevel || entry.Message != tc.expectedMessage {
+				t.Fatalf("Expected %s log %q, got %s log %q", tc.expectedLevel, tc.expectedMessage, entry.Level, entry.Message)
+			}
+			if entry.Data["query"] != query || entry.Data["latency"] != tc.latency || entry.Data["series"] != 3 {
+				t.Fatalf("Unexpected log fields: %v", entry.Data)
+			}
+			if tc.err != nil && entry.Data[log.ErrorKey] != tc.err {
+				t.Fatalf("Expected the error to be logged, got: %v", entry.Data)
+			}
+		})
+	}
+}
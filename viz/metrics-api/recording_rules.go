@@ -0,0 +1,108 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// recordingRuleWindow is the window over which the rates of the counters
+	// are recorded; queries on longer windows average the recorded rates
+	recordingRuleWindow = "1m"
+
+	recordingRuleGroup = "linkerd-viz"
+)
+
+var (
+	// recordedCounters are the proxy counters, and histograms, whose rates are
+	// recorded
+	recordedCounters = []string{
+		"response_total",
+		"response_latency_ms_bucket",
+		"route_response_total",
+		"route_actual_response_total",
+		"route_response_latency_ms_bucket",
+		"inbound_http_authz_allow_total",
+		"inbound_http_authz_deny_total",
+		"tcp_read_bytes_total",
+		"tcp_write_bytes_total",
+	}
+
+	// recordedGauges are the proxy gauges whose sums are recorded
+	recordedGauges = []string{
+		"tcp_open_connections",
+	}
+
+	// aggregatedLabels are the labels of the proxy metrics the recording rules
+	// aggregate away, as the queries neither filter nor group by them
+	aggregatedLabels = []string{
+		"instance",
+		"job",
+		"control_plane_ns",
+		"pod_template_hash",
+		"status_code",
+		"grpc_status",
+		"error",
+		"target_addr",
+		"target_ip",
+	}
+)
+
+// RecordingRuleGroup is a group of Prometheus recording rules, as found in a
+// rule file
+type RecordingRuleGroup struct {
+	Name  string          `json:"name"`
+	Rules []RecordingRule `json:"rules"`
+}
+
+// RecordingRule is a Prometheus recording rule pre-aggregating a proxy metric
+type RecordingRule struct {
+	Record string `json:"record"`
+	Expr   string `json:"expr"`
+}
+
+// RecordingRules returns the recording rules the metrics-api queries instead
+// of the proxy metrics when run with -recording-rules. The prefix is the one of
+// the names of the proxy metrics in Prometheus, if any.
+func RecordingRules(prefix string) RecordingRuleGroup {
+	without := strings.Join(aggregatedLabels, ", ")
+	group := RecordingRuleGroup{Name: recordingRuleGroup}
+	for _, metric := range recordedCounters {
+		group.Rules = append(group.Rules, RecordingRule{
+			Record: counterRuleName(metric),
+			Expr:   fmt.Sprintf("sum without (%s) (rate(%s%s[%s]))", without, prefix, metric, recordingRuleWindow),
+		})
+	}
+	for _, metric := range recordedGauges {
+		group.Rules = append(group.Rules, RecordingRule{
+			Record: gaugeRuleName(metric),
+			Expr:   fmt.Sprintf("sum without (%s) (%s%s)", without, prefix, metric),
+		})
+	}
+	return group
+}
+
+// WithRecordingRules adds the names of the recording rules to the names the
+// proxy metrics are queried as, as returned by ParseMetricNames, unless they
+// were explicitly set
+func WithRecordingRules(names map[string]string) map[string]string {
+	withRules := make(map[string]string, len(names)+len(recordedCounters)+len(recordedGauges))
+	for _, metric := range recordedCounters {
+		withRules[metric] = fmt.Sprintf("rate(%s)", counterRuleName(metric))
+	}
+	for _, metric := range recordedGauges {
+		withRules[metric] = gaugeRuleName(metric)
+	}
+	for metric, name := range names {
+		withRules[metric] = name
+	}
+	return withRules
+}
+
+func counterRuleName(metric string) string {
+	return fmt.Sprintf("linkerd:%s:rate%s", metric, recordingRuleWindow)
+}
+
+func gaugeRuleName(metric string) string {
+	return fmt.Sprintf("linkerd:%s:sum", metric)
+}
@@ -0,0 +1,44 @@
+package api
+
+import "testing"
+
+func TestWithRecordingRules(t *testing.T) {
+	names := WithRecordingRules(map[string]string{
+		"tcp_open_connections": "l5d_tcp_open_connections",
+	})
+
+	for metric, expected := range map[string]string{
+		"response_total":       "rate(linkerd:response_total:rate1m)",
+		"tcp_read_bytes_total": "rate(linkerd:tcp_read_bytes_total:rate1m)",
+		"tcp_open_connections": "l5d_tcp_open_connections",
+	} {
+		if names[metric] != expected {
+			t.Fatalf("Expected %s to be queried as %s, got %s", metric, expected, names[metric])
+		}
+	}
+
+	// the names of the rules are valid names for the metrics
+	for metric, name := range names {
+		if _, err := ParseMetricNames(metric + "=" + name); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	query := newMetricNames("", names).rewriteQuery(`sum(increase(response_total{direction="inbound"}[10m])) by (pod, classification, tls)`)
+	expected := `sum((avg_over_time(linkerd:response_total:rate1m{direction="inbound"}[10m]) * 600)) by (pod, classification, tls)`
+	if query != expected {
+		t.Fatalf("Expected query:\n%s\ngot:\n%s", expected, query)
+	}
+}
+
+func TestRecordingRules(t *testing.T) {
+	group := RecordingRules("l5d_")
+	if len(group.Rules) != len(recordedCounters)+len(recordedGauges) {
+		t.Fatalf("Expected %d rules, got %d", len(recordedCounters)+len(recordedGauges), len(group.Rules))
+	}
+	rule := group.Rules[0]
+	expected := "sum without (instance, job, control_plane_ns, pod_template_hash, status_code, grpc_status, error, target_addr, target_ip) (rate(l5d_response_total[1m]))"
+	if rule.Record != "linkerd:response_total:rate1m" || rule.Expr != expected {
+		t.Fatalf("Expected rule linkerd:response_total:rate1m recording %s, got %s recording %s", expected, rule.Record, rule.Expr)
+	}
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ParseRowLabels parses a comma-separated list of the labels copied from the
+// resources into their StatTable rows.
+func ParseRowLabels(value string) ([]string, error) {
+	var labels []string
+	seen := make(map[string]struct{})
+	for _, label := range strings.Split(value, ",") {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			continue
+		}
+		if errs := validation.IsQualifiedName(label); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label %q: %s", label, strings.Join(errs, "; "))
+		}
+		if _, ok := seen[label]; ok {
+			continue
+		}
+		seen[label] = struct{}{}
+		labels = append(labels, label)
+	}
+	return labels, nil
+}
+
+// getRowLabels returns the values of the propagated labels among the labels
+// of a resource, or nil when it has none of them
+func (s *grpcServer) getRowLabels(labels map[string]string) map[string]string {
+	var values map[string]string
+	for _, label := range s.rowLabels {
+		value, ok := labels[label]
+		if !ok {
+			continue
+		}
+		if values == nil {
+			values = make(map[string]string)
+		}
+		values[label] = value
+	}
+	return values
+}
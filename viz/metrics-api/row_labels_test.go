@@ -0,0 +1,45 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRowLabels(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected []string
+		err      string
+	}{
+		{
+			value: "",
+		},
+		{
+			value:    "team, app.kubernetes.io/version,team",
+			expected: []string{"team", "app.kubernetes.io/version"},
+		},
+		{
+			value: "team,not a label",
+			err:   `invalid label "not a label": name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.value, func(t *testing.T) {
+			labels, err := ParseRowLabels(tc.value)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("Expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(labels, tc.expected) {
+				t.Fatalf("Expected labels %v, got %v", tc.expected, labels)
+			}
+		})
+	}
+}
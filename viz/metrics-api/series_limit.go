@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/common/model"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	querySeriesHistogram = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "prometheus_query_series",
+		Help:    "Number of series returned by the Prometheus queries",
+		Buckets: prometheus.ExponentialBuckets(1, 4, 10),
+	})
+
+	truncatedQueriesCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "prometheus_queries_truncated_total",
+		Help: "Number of requests whose Prometheus queries were bounded to the series limit",
+	})
+)
+
+// warningRecorder collects the warnings raised while serving a request, e.g.
+// about the results of its queries being truncated, so that they're returned
+// along with the response
+type warningRecorder struct {
+	sync.Mutex
+	warnings map[string]struct{}
+}
+
+type warningRecorderKey struct{}
+
+func withWarningRecorder(ctx context.Context) (context.Context, *warningRecorder) {
+	recorder := &warningRecorder{warnings: make(map[string]struct{})}
+	return context.WithValue(ctx, warningRecorderKey{}, recorder), recorder
+}
+
+func warningRecorderFrom(ctx context.Context) *warningRecorder {
+	recorder, _ := ctx.Value(warningRecorderKey{}).(*warningRecorder)
+	return recorder
+}
+
+func (r *warningRecorder) record(warning string) {
+	r.Lock()
+	defer r.Unlock()
+	r.warnings[warning] = struct{}{}
+}
+
+// recorded returns the distinct recorded warnings, sorted
+func (r *warningRecorder) recorded() []string {
+	r.Lock()
+	defer r.Unlock()
+	warnings := make([]string, 0, len(r.warnings))
+	for warning := range r.warnings {
+		warnings = append(warnings, warning)
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
+type evalTimeKey struct{}
+
+// withEvalTime pins the evaluation time of the queries run with ctx, so that
+// they're consistent with each other
+func withEvalTime(ctx context.Context, at time.Time) context.Context {
+	return context.WithValue(ctx, evalTimeKey{}, at)
+}
+
+func evalTimeFrom(ctx context.Context) time.Time {
+	at, _ := ctx.Value(evalTimeKey{}).(time.Time)
+	return at
+}
+
+// boundQueries bounds the queries of a request to the maxQuerySeries
+// resources receiving the most requests, when more of them receive some, so
+// that Prometheus cuts the results of all the queries the same way. The
+// resources are identified by the groupBy labels, which all the queries are
+// grouped by. The queries are updated in place, and the returned context pins
+// their evaluation time so that they all select the same resources.
+func (s *grpcServer) boundQueries(ctx context.Context, requestQueries, latencyQueries map[promType]string, groupBy model.LabelNames) (context.Context, error) {
+	reqQuery, ok := requestQueries[promRequests]
+	if s.maxQuerySeries <= 0 || !ok {
+		return ctx, nil
+	}
+	if evalTimeFrom(ctx).IsZero() && queryRecorderFrom(ctx) == nil {
+		ctx = withEvalTime(ctx, time.Now())
+	}
+
+	perResource := fmt.Sprintf("sum(%s) by (%s)", reqQuery, groupBy)
+	count, err := s.queryProm(ctx, fmt.Sprintf("count(%s)", perResource))
+	if err != nil {
+		return ctx, err
+	}
+	if len(count) == 0 || int(count[0].Value) <= s.maxQuerySeries {
+		return ctx, nil
+	}
+
+	truncatedQueriesCounter.Inc()
+	log.Warnf("%d resources match %s, keeping the %d receiving the most requests", int(count[0].Value), reqQuery, s.maxQuerySeries)
+	if recorder := warningRecorderFrom(ctx); recorder != nil {
+		recorder.record(truncationWarning(s.maxQuerySeries))
+	}
+
+	bound := fmt.Sprintf(" and on(%s) topk(%d, %s)", groupBy, s.maxQuerySeries, perResource)
+	for typ, query := range requestQueries {
+		requestQueries[typ] = query + bound
+	}
+	for typ, query := range latencyQueries {
+		latencyQueries[typ] = query + bound
+	}
+	return ctx, nil
+}
+
+func truncationWarning(maxSeries int) string {
+	return fmt.Sprintf("the results of some Prometheus queries were truncated to %d series; the stats of some resources are missing or incomplete, narrow down the request with a namespace, a resource name or a label selector", maxSeries)
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/prometheus/common/model"
+)
+
+func TestBoundQueries(t *testing.T) {
+	countSample := func(count int) model.Vector {
+		return model.Vector{&model.Sample{Metric: model.Metric{}, Value: model.SampleValue(count)}}
+	}
+
+	testCases := []struct {
+		name            string
+		maxQuerySeries  int
+		count           model.Vector
+		expectedQueries []string
+		expectedBound   bool
+	}{
+		{"no limit", 0, countSample(3), []string{}, false},
+		{"no traffic", 2, model.Vector{}, []string{"count(sum(REQUESTS) by (namespace, deployment))"}, false},
+		{"under the limit", 3, countSample(3), []string{"count(sum(REQUESTS) by (namespace, deployment))"}, false},
+		{"past the limit", 2, countSample(3), []string{"count(sum(REQUESTS) by (namespace, deployment))"}, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			mockProm, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{mockPromResponse: tc.count})
+			if err != nil {
+				t.Fatalf("Error creating mock grpc server: %s", err)
+			}
+			fakeGrpcServer.maxQuerySeries = tc.maxQuerySeries
+
+			requestQueries := map[promType]string{promRequests: "REQUESTS", promTCPConnections: "CONNECTIONS"}
+			latencyQueries := map[promType]string{promLatencyP50: "P50"}
+			ctx, warnings := withWarningRecorder(context.Background())
+			if _, err := fakeGrpcServer.boundQueries(ctx, requestQueries, latencyQueries, model.LabelNames{"namespace", "deployment"}); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			err = expectedStatRPC{expectedPrometheusQueries: tc.expectedQueries}.verifyPromQueries(mockProm)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			bound := ""
+			if tc.expectedBound {
+				bound = " and on(namespace, deployment) topk(2, sum(REQUESTS) by (namespace, deployment))"
+				if recorded := warnings.recorded(); len(recorded) != 1 || recorded[0] != truncationWarning(tc.maxQuerySeries) {
+					t.Fatalf("Expected a truncation warning, got %v", recorded)
+				}
+			} else if recorded := warnings.recorded(); len(recorded) != 0 {
+				t.Fatalf("Expected no warnings, got %v", recorded)
+			}
+			for query, expected := range map[string]string{
+				requestQueries[promRequests]:       "REQUESTS" + bound,
+				requestQueries[promTCPConnections]: "CONNECTIONS" + bound,
+				latencyQueries[promLatencyP50]:     "P50" + bound,
+			} {
+				if query != expected {
+					t.Fatalf("Expected query %s, got %s", expected, query)
+				}
+			}
+		})
+	}
+}
+
+func TestStatSummarySeriesLimit(t *testing.T) {
+	mockProm, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{
+		mockPromResponse: model.Vector{
+			genPromSample("web.emojivoto.svc.cluster.local", "authority", "emojivoto", false),
+			genPromSample("emoji.emojivoto.svc.cluster.local", "authority", "emojivoto", false),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error creating mock grpc server: %s", err)
+	}
+	fakeGrpcServer.maxQuerySeries = 1
+
+	rsp, err := fakeGrpcServer.StatSummary(context.TODO(), &pb.StatSummaryRequest{
+		Selector: &pb.ResourceSelection{
+			Resource: &pb.Resource{
+				Namespace: "emojivoto",
+				Type:      pkgK8s.Authority,
+			},
+		},
+		TimeWindow: "1m",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expectedWarning := truncationWarning(1)
+	if warnings := rsp.GetOk().GetWarnings(); len(warnings) != 1 || warnings[0] != expectedWarning {
+		t.Fatalf("Expected warning [%s], got %v", expectedWarning, warnings)
+	}
+
+	// every stat query selects the same resources
+	bound := ` and on(namespace, authority) topk(1, sum(sum(increase(response_total{direction="inbound", namespace="emojivoto"}[1m])) by (namespace, authority, classification, tls)) by (namespace, authority))`
+	for _, query := range mockProm.QueriesExecuted {
+		if strings.HasPrefix(query, "count(") {
+			continue
+		}
+		if !strings.HasSuffix(query, bound) {
+			t.Fatalf("Expected query %s to be bounded with%s", query, bound)
+		}
+	}
+}
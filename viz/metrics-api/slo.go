@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	proto "github.com/golang/protobuf/proto"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/prometheus/common/model"
+)
+
+// validateSlo checks that the target of the objective is a percentage and
+// that its window, when set, is a Prometheus duration
+func validateSlo(slo *pb.SloObjective) error {
+	if slo.GetTarget() <= 0 || slo.GetTarget() >= 100 {
+		return fmt.Errorf("invalid SLO target %v: must be a percentage strictly between 0 and 100", slo.GetTarget())
+	}
+	if slo.GetWindow() != "" {
+		if _, err := model.ParseDuration(slo.GetWindow()); err != nil {
+			return fmt.Errorf("invalid SLO window %q: %w", slo.GetWindow(), err)
+		}
+	}
+	return nil
+}
+
+// evaluateSlo sets the SloStats of the rows of the table that received
+// requests. The burn rate is computed from their stats over the time window,
+// while the error budget left is computed from their stats over the SLO
+// window, which are queried separately when the windows differ.
+func (s *grpcServer) evaluateSlo(ctx context.Context, req *pb.StatSummaryRequest, table *pb.StatTable) error {
+	slo := req.GetSlo()
+	window := slo.GetWindow()
+	if window == "" {
+		window = req.GetTimeWindow()
+	}
+
+	rows := table.GetPodGroup().GetRows()
+	budgetRows := rows
+	if window != req.GetTimeWindow() {
+		budgetReq := proto.Clone(req).(*pb.StatSummaryRequest)
+		budgetReq.TimeWindow = window
+		budgetReq.Slo = nil
+		budgetReq.TcpStats = false
+		budgetReq.PolicyStats = false
+		result := s.resourceQuery(ctx, budgetReq)
+		if result.err != nil {
+			return result.err
+		}
+		budgetRows = result.res.GetPodGroup().GetRows()
+	}
+	budgetStats := make(map[rKey]*pb.BasicStats)
+	for _, row := range budgetRows {
+		budgetStats[sloRowKey(row)] = row.GetStats()
+	}
+
+	errorBudget := 1 - slo.GetTarget()/100
+	for _, row := range rows {
+		if !hasRequests(row.GetStats()) {
+			continue
+		}
+		row.SloStats = &pb.SloStats{
+			Target:          slo.GetTarget(),
+			Window:          window,
+			BurnRate:        failureRatio(row.GetStats()) / errorBudget,
+			BudgetRemaining: 1 - failureRatio(budgetStats[sloRowKey(row)])/errorBudget,
+		}
+	}
+	return nil
+}
+
+func sloRowKey(row *pb.StatTable_PodGroup_Row) rKey {
+	return rKey{
+		Namespace: row.GetResource().GetNamespace(),
+		Type:      row.GetResource().GetType(),
+		Name:      row.GetResource().GetName(),
+		Version:   row.GetVersion(),
+	}
+}
+
+func hasRequests(stats *pb.BasicStats) bool {
+	return stats.GetSuccessCount()+stats.GetFailureCount() > 0
+}
+
+// failureRatio returns the fraction of the requests that failed, 0 when there
+// were none
+func failureRatio(stats *pb.BasicStats) float64 {
+	if !hasRequests(stats) {
+		return 0
+	}
+	return float64(stats.GetFailureCount()) / float64(stats.GetSuccessCount()+stats.GetFailureCount())
+}
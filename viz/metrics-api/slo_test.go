@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+)
+
+func TestValidateSlo(t *testing.T) {
+	for _, tc := range []struct {
+		slo   *pb.SloObjective
+		valid bool
+	}{
+		{&pb.SloObjective{Target: 99.9}, true},
+		{&pb.SloObjective{Target: 99, Window: "30d"}, true},
+		{&pb.SloObjective{Target: 0}, false},
+		{&pb.SloObjective{Target: 100}, false},
+		{&pb.SloObjective{Target: 99, Window: "a month"}, false},
+	} {
+		if err := validateSlo(tc.slo); (err == nil) != tc.valid {
+			t.Fatalf("Expected %v to be valid: %t, got error: %v", tc.slo, tc.valid, err)
+		}
+	}
+}
+
+func TestEvaluateSlo(t *testing.T) {
+	row := func(name string, success, failure uint64) *pb.StatTable_PodGroup_Row {
+		return &pb.StatTable_PodGroup_Row{
+			Resource: &pb.Resource{Namespace: "emojivoto", Type: "deployment", Name: name},
+			Stats:    &pb.BasicStats{SuccessCount: success, FailureCount: failure},
+		}
+	}
+	table := &pb.StatTable{
+		Table: &pb.StatTable_PodGroup_{
+			PodGroup: &pb.StatTable_PodGroup{
+				Rows: []*pb.StatTable_PodGroup_Row{
+					row("web", 980, 20),
+					row("voting", 1000, 0),
+					row("emoji", 0, 0),
+				},
+			},
+		},
+	}
+	req := &pb.StatSummaryRequest{
+		TimeWindow: "1m",
+		Slo:        &pb.SloObjective{Target: 99},
+	}
+
+	s := &grpcServer{}
+	if err := s.evaluateSlo(context.Background(), req, table); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	rows := table.GetPodGroup().GetRows()
+	for i, expected := range []*pb.SloStats{
+		{Target: 99, Window: "1m", BurnRate: 2, BudgetRemaining: -1},
+		{Target: 99, Window: "1m", BurnRate: 0, BudgetRemaining: 1},
+		nil,
+	} {
+		actual := rows[i].GetSloStats()
+		if expected == nil {
+			if actual != nil {
+				t.Fatalf("Expected no SLO stats for %s, got %v", rows[i].GetResource().GetName(), actual)
+			}
+			continue
+		}
+		if actual == nil ||
+			actual.GetTarget() != expected.GetTarget() ||
+			actual.GetWindow() != expected.GetWindow() ||
+			!almostEqual(actual.GetBurnRate(), expected.GetBurnRate()) ||
+			!almostEqual(actual.GetBudgetRemaining(), expected.GetBudgetRemaining()) {
+			t.Fatalf("Expected SLO stats %v for %s, got %v", expected, rows[i].GetResource().GetName(), actual)
+		}
+	}
+}
+
+func almostEqual(a, b float64) bool {
+	d := a - b
+	return d < 1e-9 && d > -1e-9
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	proto "github.com/golang/protobuf/proto"
 	"github.com/linkerd/linkerd2/pkg/k8s"
@@ -36,6 +38,10 @@ type rKey struct {
 	Namespace string
 	Type      string
 	Name      string
+	// Version is only set when the stats are broken down by version
+	Version string
+	// Port is only set when the stats of authorities are broken down by port
+	Port string
 }
 
 type dstKey struct {
@@ -53,19 +59,25 @@ const (
 	latencyQuantileQuery = "histogram_quantile(%s, sum(irate(response_latency_ms_bucket%s[%s])) by (le, %s))"
 	httpAuthzDenyQuery   = "sum(increase(inbound_http_authz_deny_total%s[%s])) by (%s)"
 	httpAuthzAllowQuery  = "sum(increase(inbound_http_authz_allow_total%s[%s])) by (%s)"
+	routeLabelsQuery     = "count(inbound_http_authz_allow_total%s) by (%s)"
 	tcpConnectionsQuery  = "sum(tcp_open_connections%s) by (%s)"
 	tcpReadBytesQuery    = "sum(increase(tcp_read_bytes_total%s[%s])) by (%s)"
 	tcpWriteBytesQuery   = "sum(increase(tcp_write_bytes_total%s[%s])) by (%s)"
 
 	regexAny = ".+"
+
+	oomKilledReason = "OOMKilled"
 )
 
 type podStats struct {
-	status string
-	inMesh uint64
-	total  uint64
-	failed uint64
-	errors map[string]*pb.PodErrors
+	status    string
+	inMesh    uint64
+	total     uint64
+	failed    uint64
+	restarts  uint64
+	oomKilled uint64
+	errors    map[string]*pb.PodErrors
+	pods      []*corev1.Pod
 }
 
 func (s *grpcServer) StatSummary(ctx context.Context, req *pb.StatSummaryRequest) (*pb.StatSummaryResponse, error) {
@@ -75,9 +87,30 @@ func (s *grpcServer) StatSummary(ctx context.Context, req *pb.StatSummaryRequest
 		return statSummaryError(req, "StatSummary request missing Selector Resource"), nil
 	}
 
-	// err if --from is a service
-	if req.GetFromResource() != nil && req.GetFromResource().GetType() == k8s.Service {
-		return statSummaryError(req, "service is not supported as a target on 'from' queries, or as a target with 'to' queries"), nil
+	// err if --from is a service and the stats aren't the ones of workloads
+	if req.GetFromResource().GetType() == k8s.Service {
+		switch req.GetSelector().GetResource().GetType() {
+		case k8s.Service, k8s.Authority, k8s.All:
+			return statSummaryError(req, "'from' service queries are only supported with workload resources, as they restrict the workload stats to the traffic sent through the service"), nil
+		}
+	}
+
+	// err if the stats of resources that don't own pods are broken down by
+	// version
+	if req.GetVersionLabel() != "" && !hasVersions(req.GetSelector().GetResource()) {
+		return statSummaryError(req, "version breakdowns are only supported with workload resources"), nil
+	}
+
+	// err if the stats of resources other than authorities are broken down
+	// by port
+	if req.GetByPort() && req.GetSelector().GetResource().GetType() != k8s.Authority {
+		return statSummaryError(req, "port breakdowns are only supported with authorities"), nil
+	}
+
+	// err if resources without pods of their own are filtered by whether
+	// their pods are meshed
+	if req.GetMeshFilter() != pb.StatSummaryRequest_ALL && !hasVersions(req.GetSelector().GetResource()) {
+		return statSummaryError(req, "mesh filters are only supported with workload resources"), nil
 	}
 
 	// err if --from is added with policy resources
@@ -85,6 +118,27 @@ func (s *grpcServer) StatSummary(ctx context.Context, req *pb.StatSummaryRequest
 		return statSummaryError(req, "'from' queries are not supported with policy resources, as they have inbound metrics only"), nil
 	}
 
+	// err if the traffic from an identity isn't the inbound traffic of
+	// workloads
+	if req.GetFromIdentity() != "" {
+		if req.GetToResource() != nil || req.GetFromResource() != nil {
+			return statSummaryError(req, "'from identity' queries can't be combined with 'to' or 'from' resources"), nil
+		}
+		switch req.GetSelector().GetResource().GetType() {
+		case k8s.Service, k8s.Server, k8s.ServerAuthorization, k8s.HTTPRoute:
+			return statSummaryError(req, "'from identity' queries are only supported with the resources whose inbound traffic is measured by the proxies' identity, such as workloads"), nil
+		}
+	}
+
+	if slo := req.GetSlo(); slo != nil {
+		if err := validateSlo(slo); err != nil {
+			return statSummaryError(req, err.Error()), nil
+		}
+		if req.GetSkipStats() {
+			return statSummaryError(req, "SLOs can't be evaluated without stats"), nil
+		}
+	}
+
 	switch req.Outbound.(type) {
 	case *pb.StatSummaryRequest_ToResource:
 		if req.Outbound.(*pb.StatSummaryRequest_ToResource).ToResource.Type == k8s.All {
@@ -101,6 +155,15 @@ func (s *grpcServer) StatSummary(ctx context.Context, req *pb.StatSummaryRequest
 	var resourcesToQuery []string
 	if req.Selector.Resource.Type == k8s.All {
 		resourcesToQuery = k8s.StatAllResourceTypes
+		if req.GetMeshFilter() != pb.StatSummaryRequest_ALL {
+			// the resources without pods of their own can't be filtered
+			resourcesToQuery = []string{}
+			for _, resource := range k8s.StatAllResourceTypes {
+				if hasVersions(&pb.Resource{Type: resource}) {
+					resourcesToQuery = append(resourcesToQuery, resource)
+				}
+			}
+		}
 	} else {
 		resourcesToQuery = []string{req.Selector.Resource.Type}
 	}
@@ -108,20 +171,37 @@ func (s *grpcServer) StatSummary(ctx context.Context, req *pb.StatSummaryRequest
 	// request stats for the resourcesToQuery, in parallel
 	resultChan := make(chan resourceResult)
 
+	// recorded queries are all evaluated at the same time, so that the stats
+	// of the different resource types are consistent when reproduced
+	now := time.Now()
+	ctx, warnings := withWarningRecorder(ctx)
 	for _, resource := range resourcesToQuery {
 		statReq := proto.Clone(req).(*pb.StatSummaryRequest)
 		statReq.Selector.Resource.Type = resource
+		if !hasVersions(statReq.Selector.Resource) {
+			statReq.VersionLabel = ""
+		}
 
 		go func() {
-			if isNonK8sResourceQuery(statReq.GetSelector().GetResource().GetType()) {
-				resultChan <- s.nonK8sResourceQuery(ctx, statReq)
-			} else if statReq.GetSelector().GetResource().GetType() == k8s.Service {
-				resultChan <- s.serviceResourceQuery(ctx, statReq)
-			} else if isPolicyResource(statReq.GetSelector().GetResource()) {
-				resultChan <- s.policyResourceQuery(ctx, statReq)
-			} else {
-				resultChan <- s.k8sResourceQuery(ctx, statReq)
+			ctx := ctx
+			var recorder *queryRecorder
+			// no queries are issued when skipping the stats
+			if statReq.IncludeQueries && !statReq.SkipStats {
+				ctx, recorder = withQueryRecorder(ctx, now)
+			}
+
+			result := s.resourceQuery(ctx, statReq)
+			if statReq.GetSlo() != nil && result.err == nil {
+				result.err = s.evaluateSlo(ctx, statReq, result.res)
+			}
+
+			if recorder != nil && result.err == nil {
+				queries := recorder.recorded()
+				for _, row := range result.res.GetPodGroup().GetRows() {
+					row.Queries = queries
+				}
 			}
+			resultChan <- result
 		}()
 	}
 
@@ -137,6 +217,7 @@ func (s *grpcServer) StatSummary(ctx context.Context, req *pb.StatSummaryRequest
 		Response: &pb.StatSummaryResponse_Ok_{ // https://github.com/golang/protobuf/issues/205
 			Ok: &pb.StatSummaryResponse_Ok{
 				StatTables: statTables,
+				Warnings:   warnings.recorded(),
 			},
 		},
 	}
@@ -145,15 +226,38 @@ func (s *grpcServer) StatSummary(ctx context.Context, req *pb.StatSummaryRequest
 	return &rsp, nil
 }
 
+// resourceQuery returns the stats of the resources of a single type
+func (s *grpcServer) resourceQuery(ctx context.Context, req *pb.StatSummaryRequest) resourceResult {
+	if isNonK8sResourceQuery(req.GetSelector().GetResource().GetType()) {
+		return s.nonK8sResourceQuery(ctx, req)
+	} else if req.GetSelector().GetResource().GetType() == k8s.Service {
+		return s.serviceResourceQuery(ctx, req)
+	} else if isPolicyResource(req.GetSelector().GetResource()) {
+		return s.policyResourceQuery(ctx, req)
+	}
+	return s.k8sResourceQuery(ctx, req)
+}
+
 func isPolicyResource(resource *pb.Resource) bool {
 	if resource != nil {
-		if resource.GetType() == k8s.Server || resource.GetType() == k8s.ServerAuthorization {
+		switch resource.GetType() {
+		case k8s.Server, k8s.ServerAuthorization, k8s.HTTPRoute:
 			return true
 		}
 	}
 	return false
 }
 
+// hasVersions returns true for the resources whose stats can be broken down
+// by the version of their pods
+func hasVersions(resource *pb.Resource) bool {
+	switch resource.GetType() {
+	case k8s.Service, k8s.Authority:
+		return false
+	}
+	return !isPolicyResource(resource)
+}
+
 func statSummaryError(req *pb.StatSummaryRequest, message string) *pb.StatSummaryResponse {
 	return &pb.StatSummaryResponse{
 		Response: &pb.StatSummaryResponse_Error{
@@ -215,14 +319,25 @@ func (s *grpcServer) k8sResourceQuery(ctx context.Context, req *pb.StatSummaryRe
 	var requestMetrics map[rKey]*pb.BasicStats
 	var tcpMetrics map[rKey]*pb.TcpStats
 	if !req.SkipStats {
-		requestMetrics, tcpMetrics, err = s.getStatMetrics(ctx, req, req.TimeWindow)
-		if err != nil {
-			return resourceResult{res: nil, err: err}
+		label, pods := statPodLabel(req), podsByNamespace(nil)
+		if req.CurrentPodsOnly {
+			label, pods = currentPods(req, k8sObjects)
+		}
+		// without any current pod, there are no metrics to query
+		if pods == nil || len(pods) > 0 {
+			requestMetrics, tcpMetrics, err = s.getPodsStatMetrics(ctx, req, req.TimeWindow, label, pods)
+			if err != nil {
+				return resourceResult{res: nil, err: err}
+			}
 		}
 	}
 
+	if req.GetVersionLabel() != "" {
+		k8sObjects = s.splitByVersion(req, k8sObjects, requestMetrics, tcpMetrics)
+	}
+
 	rows := make([]*pb.StatTable_PodGroup_Row, 0)
-	keys := getResultKeys(req, k8sObjects, requestMetrics)
+	keys := getResultKeys(req, k8sObjects, requestMetrics, tcpMetrics)
 
 	for _, key := range keys {
 		objInfo, ok := k8sObjects[key]
@@ -250,15 +365,34 @@ func (s *grpcServer) k8sResourceQuery(ctx context.Context, req *pb.StatSummaryRe
 			TimeWindow: req.TimeWindow,
 			Stats:      basicStats,
 			TcpStats:   tcpStats,
+			Version:    key.Version,
+			Labels:     s.getRowLabels(k8sResource.GetLabels()),
 		}
 
 		podStat := objInfo.podStats
+		if !meshFilterMatch(req.GetMeshFilter(), podStat) {
+			continue
+		}
 		row.Status = podStat.status
 		row.MeshedPodCount = podStat.inMesh
 		row.RunningPodCount = podStat.total
 		row.FailedPodCount = podStat.failed
+		row.RestartCount = podStat.restarts
+		row.OomKilledCount = podStat.oomKilled
 		row.ErrorsByPod = podStat.errors
 
+		if req.PolicyStats {
+			// the policies of a namespace row are the ones living in it
+			namespace := k8sResource.GetNamespace()
+			if key.Type == k8s.Namespace {
+				namespace = k8sResource.GetName()
+			}
+			row.PolicyStats, err = s.getPolicyStats(namespace, podStat.pods)
+			if err != nil {
+				return resourceResult{res: nil, err: err}
+			}
+		}
+
 		rows = append(rows, &row)
 	}
 
@@ -273,7 +407,110 @@ func (s *grpcServer) k8sResourceQuery(ctx context.Context, req *pb.StatSummaryRe
 	return resourceResult{res: &rsp, err: nil}
 }
 
+// meshFilterMatch returns true if the pods of a resource pass the mesh filter:
+// all of the running pods are meshed with MESHED, and at least one of them
+// isn't with UNMESHED
+func meshFilterMatch(filter pb.StatSummaryRequest_MeshFilter, stats *podStats) bool {
+	switch filter {
+	case pb.StatSummaryRequest_MESHED:
+		return stats.total > 0 && stats.inMesh >= stats.total
+	case pb.StatSummaryRequest_UNMESHED:
+		return stats.inMesh < stats.total
+	}
+	return true
+}
+
+// splitByVersion breaks the objects down by the value of the version label of
+// their pods. The versions that only have metrics, e.g. the old ReplicaSet of a
+// completed rollout, are kept too, without any pod.
+func (s *grpcServer) splitByVersion(
+	req *pb.StatSummaryRequest,
+	objects map[rKey]k8sStat,
+	metricResults map[rKey]*pb.BasicStats,
+	tcpResults map[rKey]*pb.TcpStats,
+) map[rKey]k8sStat {
+	split := make(map[rKey]k8sStat)
+	for key, obj := range objects {
+		if len(obj.podStats.pods) == 0 {
+			split[key] = obj
+			continue
+		}
+
+		podsByVersion := make(map[string][]*corev1.Pod)
+		for _, pod := range obj.podStats.pods {
+			version := pod.Labels[req.GetVersionLabel()]
+			podsByVersion[version] = append(podsByVersion[version], pod)
+		}
+		for version, pods := range podsByVersion {
+			versionStats := s.getPodsStats(pods)
+			versionStats.status = obj.podStats.status
+			key.Version = version
+			split[key] = k8sStat{
+				object:   obj.object,
+				podStats: versionStats,
+			}
+		}
+	}
+
+	addVersion := func(key rKey) {
+		if _, ok := split[key]; ok {
+			return
+		}
+		object, ok := objects[rKey{Namespace: key.Namespace, Type: key.Type, Name: key.Name}]
+		if !ok {
+			return
+		}
+		split[key] = k8sStat{
+			object:   object.object,
+			podStats: s.getPodsStats(nil),
+		}
+	}
+	for key := range metricResults {
+		addVersion(key)
+	}
+	for key := range tcpResults {
+		addVersion(key)
+	}
+	return split
+}
+
+// podsByNamespace holds the pods the metrics of a request are filtered on,
+// keyed by their namespace, as pods with the same name can live in different
+// namespaces. Pods identified by their UID are kept under the empty namespace.
+type podsByNamespace map[string][]string
+
+// currentPods returns the pods currently backing the objects, so that the
+// metrics of their previous incarnations are left out. The pods are identified
+// by their UID, as a recreated StatefulSet reuses the names of its pods; the
+// destination metrics of --from queries only carry the names of the pods.
+func currentPods(req *pb.StatSummaryRequest, objects map[rKey]k8sStat) (model.LabelName, podsByNamespace) {
+	label := podUIDLabel
+	if req.GetFromResource() != nil {
+		label = dstPodLabel
+	}
+
+	pods := podsByNamespace{}
+	for _, obj := range objects {
+		for _, pod := range obj.podStats.pods {
+			if label == dstPodLabel {
+				pods[pod.Namespace] = append(pods[pod.Namespace], pod.Name)
+			} else {
+				pods[""] = append(pods[""], string(pod.UID))
+			}
+		}
+	}
+	for _, names := range pods {
+		sort.Strings(names)
+	}
+	return label, pods
+}
+
 func (s *grpcServer) getPolicyResourceKeys(req *pb.StatSummaryRequest) ([]rKey, error) {
+	// HTTPRoutes aren't cached, as they aren't watched by the controllers
+	if req.SkipStats && req.GetSelector().GetResource().GetType() != k8s.HTTPRoute {
+		return s.getCachedPolicyResourceKeys(req)
+	}
+
 	var err error
 	var unstructuredResources *unstructured.UnstructuredList
 
@@ -282,6 +519,11 @@ func (s *grpcServer) getPolicyResourceKeys(req *pb.StatSummaryRequest) ([]rKey,
 		gvr = k8s.ServerGVR
 	} else if req.GetSelector().Resource.GetType() == k8s.ServerAuthorization {
 		gvr = k8s.SazGVR
+	} else if req.GetSelector().Resource.GetType() == k8s.HTTPRoute {
+		gvr, err = k8s.GatewayAPIGVR(s.k8sAPI.Client.Discovery(), k8s.HTTPRouteResource)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	res := req.GetSelector().GetResource()
@@ -300,7 +542,10 @@ func (s *grpcServer) getPolicyResourceKeys(req *pb.StatSummaryRequest) ([]rKey,
 		if err != nil {
 			return nil, err
 		}
-		unstructuredResources = &unstructured.UnstructuredList{Items: []unstructured.Unstructured{*ts}}
+		unstructuredResources = &unstructured.UnstructuredList{}
+		if labelSelector.Matches(labels.Set(ts.GetLabels())) {
+			unstructuredResources.Items = []unstructured.Unstructured{*ts}
+		}
 	}
 	if err != nil {
 		return nil, err
@@ -314,6 +559,51 @@ func (s *grpcServer) getPolicyResourceKeys(req *pb.StatSummaryRequest) ([]rKey,
 	return resourceKeys, nil
 }
 
+// getCachedPolicyResourceKeys reads the policy resources from the informers
+// cache rather than from the Kubernetes API, so that the requests skipping the
+// stats are served without any round trip
+func (s *grpcServer) getCachedPolicyResourceKeys(req *pb.StatSummaryRequest) ([]rKey, error) {
+	res := req.GetSelector().GetResource()
+	labelSelector, err := getLabelSelector(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []metav1.Object
+	if res.GetType() == k8s.Server {
+		servers, err := s.k8sAPI.Srv().Lister().Servers(res.GetNamespace()).List(labelSelector)
+		if err != nil {
+			return nil, err
+		}
+		for _, server := range servers {
+			objects = append(objects, server)
+		}
+	} else if res.GetType() == k8s.ServerAuthorization {
+		sazs, err := s.k8sAPI.Saz().Lister().ServerAuthorizations(res.GetNamespace()).List(labelSelector)
+		if err != nil {
+			return nil, err
+		}
+		for _, saz := range sazs {
+			objects = append(objects, saz)
+		}
+	}
+
+	var resourceKeys []rKey
+	for _, obj := range objects {
+		if res.GetName() != "" && obj.GetName() != res.GetName() {
+			continue
+		}
+		resourceKeys = append(resourceKeys, rKey{Namespace: obj.GetNamespace(), Type: res.GetType(), Name: obj.GetName()})
+	}
+	sort.Slice(resourceKeys, func(i, j int) bool {
+		if resourceKeys[i].Namespace != resourceKeys[j].Namespace {
+			return resourceKeys[i].Namespace < resourceKeys[j].Namespace
+		}
+		return resourceKeys[i].Name < resourceKeys[j].Name
+	})
+	return resourceKeys, nil
+}
+
 func (s *grpcServer) policyResourceQuery(ctx context.Context, req *pb.StatSummaryRequest) resourceResult {
 
 	policyResources, err := s.getPolicyResourceKeys(req)
@@ -321,11 +611,21 @@ func (s *grpcServer) policyResourceQuery(ctx context.Context, req *pb.StatSummar
 		return resourceResult{res: nil, err: err}
 	}
 
+	// when filtering by labels, only the metrics of the selected resources
+	// are queried
+	var names []string
+	if req.GetSelector().GetLabelSelector() != "" && req.GetSelector().GetResource().GetName() == "" {
+		names = make([]string, len(policyResources))
+		for i, key := range policyResources {
+			names[i] = key.Name
+		}
+	}
+
 	var requestMetrics map[rKey]*pb.BasicStats
 	var tcpMetrics map[rKey]*pb.TcpStats
 	var authzMetrics map[rKey]*pb.ServerStats
-	if !req.SkipStats {
-		requestMetrics, tcpMetrics, authzMetrics, err = s.getPolicyMetrics(ctx, req, req.TimeWindow)
+	if !req.SkipStats && len(policyResources) > 0 {
+		requestMetrics, tcpMetrics, authzMetrics, err = s.getPolicyMetrics(ctx, req, req.TimeWindow, names)
 		if err != nil {
 			return resourceResult{res: nil, err: err}
 		}
@@ -364,9 +664,25 @@ func (s *grpcServer) serviceResourceQuery(ctx context.Context, req *pb.StatSumma
 	dstBasicStats := make(map[dstKey]*pb.BasicStats)
 	dstTCPStats := make(map[dstKey]*pb.TcpStats)
 
+	name := req.GetSelector().GetResource().GetName()
+	namespace := req.GetSelector().GetResource().GetNamespace()
+
+	selected, err := s.getSelectedServices(req)
+	if err != nil {
+		return resourceResult{res: nil, err: err}
+	}
+	if selected != nil && len(selected) == 0 {
+		return resourceResult{res: emptyPodGroupTable(), err: nil}
+	}
+
 	if !req.SkipStats {
-		var err error
-		dstBasicStats, dstTCPStats, err = s.getServiceMetrics(ctx, req, req.TimeWindow)
+		var names []string
+		if selected != nil && name == "" {
+			for k := range selected {
+				names = append(names, k.Name)
+			}
+		}
+		dstBasicStats, dstTCPStats, err = s.getServiceMetrics(ctx, req, req.TimeWindow, names)
 		if err != nil {
 			return resourceResult{res: nil, err: err}
 		}
@@ -374,11 +690,28 @@ func (s *grpcServer) serviceResourceQuery(ctx context.Context, req *pb.StatSumma
 
 	weights := make(map[dstKey]string)
 	for k := range dstBasicStats {
+		if selected != nil {
+			// the names of the services are matched in all namespaces
+			if _, ok := selected[rKey{Namespace: k.Namespace, Type: k8s.Service, Name: k.Service}]; !ok {
+				continue
+			}
+		}
 		weights[k] = ""
 	}
 
-	name := req.GetSelector().GetResource().GetName()
-	namespace := req.GetSelector().GetResource().GetNamespace()
+	if req.SkipStats {
+		// without metrics to list the services from, they're read from the
+		// informers cache
+		if selected == nil {
+			selected, err = s.listServices(req)
+			if err != nil {
+				return resourceResult{res: nil, err: err}
+			}
+		}
+		for k := range selected {
+			weights[dstKey{Namespace: k.Namespace, Service: k.Name, Dst: k.Name}] = ""
+		}
+	}
 
 	// Check if a ServiceProfile exists for the Service
 	spName := fmt.Sprintf("%s.%s.svc.%s", name, namespace, s.clusterDomain)
@@ -407,6 +740,15 @@ func (s *grpcServer) serviceResourceQuery(ctx context.Context, req *pb.StatSumma
 			TcpStats:   dstTCPStats[k],
 		}
 
+		if len(s.rowLabels) > 0 {
+			svc, err := s.k8sAPI.Svc().Lister().Services(k.Namespace).Get(k.Service)
+			if err == nil {
+				row.Labels = s.getRowLabels(svc.GetLabels())
+			} else if !kerrors.IsNotFound(err) {
+				log.Errorf("Failed to get the labels of service %s/%s: %v", k.Namespace, k.Service, err)
+			}
+		}
+
 		// Set TrafficSplitStats only when weight is not empty
 		if weight != "" {
 			row.TsStats = &pb.TrafficSplitStats{
@@ -432,6 +774,49 @@ func (s *grpcServer) serviceResourceQuery(ctx context.Context, req *pb.StatSumma
 	return resourceResult{res: &rsp, err: nil}
 }
 
+// getSelectedServices returns the services matching the label selector of the
+// request, or nil when the request doesn't filter services by labels
+func (s *grpcServer) getSelectedServices(req *pb.StatSummaryRequest) (map[rKey]struct{}, error) {
+	if req.GetSelector().GetLabelSelector() == "" {
+		return nil, nil
+	}
+	return s.listServices(req)
+}
+
+// listServices returns the services matching the selector of the request,
+// read from the informers cache
+func (s *grpcServer) listServices(req *pb.StatSummaryRequest) (map[rKey]struct{}, error) {
+	labelSelector, err := getLabelSelector(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := req.GetSelector().GetResource()
+	services, err := s.k8sAPI.Svc().Lister().Services(res.GetNamespace()).List(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[rKey]struct{})
+	for _, svc := range services {
+		if res.GetName() != "" && svc.Name != res.GetName() {
+			continue
+		}
+		selected[rKey{Namespace: svc.Namespace, Type: k8s.Service, Name: svc.Name}] = struct{}{}
+	}
+	return selected, nil
+}
+
+func emptyPodGroupTable() *pb.StatTable {
+	return &pb.StatTable{
+		Table: &pb.StatTable_PodGroup_{
+			PodGroup: &pb.StatTable_PodGroup{
+				Rows: []*pb.StatTable_PodGroup_Row{},
+			},
+		},
+	}
+}
+
 func sortTrafficSplitRows(rows []*pb.StatTable_PodGroup_Row) []*pb.StatTable_PodGroup_Row {
 	sort.Slice(rows, func(i, j int) bool {
 		if rows[i].TsStats != nil && rows[j].TsStats != nil {
@@ -445,10 +830,19 @@ func sortTrafficSplitRows(rows []*pb.StatTable_PodGroup_Row) []*pb.StatTable_Pod
 }
 
 func (s *grpcServer) nonK8sResourceQuery(ctx context.Context, req *pb.StatSummaryRequest) resourceResult {
+	// authorities have no labels of their own, so the label selector applies
+	// to the pods serving them, or sending requests to them with --to
+	pods, err := s.getSelectedPods(req)
+	if err != nil {
+		return resourceResult{res: nil, err: err}
+	}
+	if pods != nil && len(pods) == 0 {
+		return resourceResult{res: emptyPodGroupTable(), err: nil}
+	}
+
 	var requestMetrics map[rKey]*pb.BasicStats
 	if !req.SkipStats {
-		var err error
-		requestMetrics, _, err = s.getStatMetrics(ctx, req, req.TimeWindow)
+		requestMetrics, _, err = s.getPodsStatMetrics(ctx, req, req.TimeWindow, statPodLabel(req), pods)
 		if err != nil {
 			return resourceResult{res: nil, err: err}
 		}
@@ -467,6 +861,13 @@ func (s *grpcServer) nonK8sResourceQuery(ctx context.Context, req *pb.StatSummar
 			TimeWindow: req.TimeWindow,
 			Stats:      metrics,
 		}
+		if rkey.Port != "" {
+			port, err := strconv.ParseUint(rkey.Port, 10, 32)
+			if err != nil {
+				return resourceResult{res: nil, err: fmt.Errorf("invalid %s label %q: %s", targetPortLabel, rkey.Port, err)}
+			}
+			row.Port = uint32(port)
+		}
 		rows = append(rows, &row)
 	}
 
@@ -480,6 +881,29 @@ func (s *grpcServer) nonK8sResourceQuery(ctx context.Context, req *pb.StatSummar
 	return resourceResult{res: &rsp, err: nil}
 }
 
+// getSelectedPods returns the names of the pods matching the label selector of
+// the request, or nil when the request doesn't filter pods by labels
+func (s *grpcServer) getSelectedPods(req *pb.StatSummaryRequest) (podsByNamespace, error) {
+	if req.GetSelector().GetLabelSelector() == "" {
+		return nil, nil
+	}
+	labelSelector, err := getLabelSelector(req)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := s.k8sAPI.Pod().Lister().Pods(req.GetSelector().GetResource().GetNamespace()).List(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	names := podsByNamespace{}
+	for _, pod := range pods {
+		names[pod.Namespace] = append(names[pod.Namespace], pod.Name)
+	}
+	return names, nil
+}
+
 func isNonK8sResourceQuery(resourceType string) bool {
 	return resourceType == k8s.Authority
 }
@@ -489,6 +913,7 @@ func getResultKeys(
 	req *pb.StatSummaryRequest,
 	k8sObjects map[rKey]k8sStat,
 	metricResults map[rKey]*pb.BasicStats,
+	tcpResults map[rKey]*pb.TcpStats,
 ) []rKey {
 	var keys []rKey
 
@@ -499,10 +924,16 @@ func getResultKeys(
 		}
 	} else {
 		// if the request does have outbound filtering,
-		// only return rows for which we have stats
+		// only return rows for which we have stats, be it request or TCP
+		// stats, so that the workloads only serving opaque traffic are kept
 		for key := range metricResults {
 			keys = append(keys, key)
 		}
+		for key := range tcpResults {
+			if _, ok := metricResults[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
 	}
 	return keys
 }
@@ -522,7 +953,14 @@ func buildRequestLabels(req *pb.StatSummaryRequest) (labels model.LabelSet, labe
 	case *pb.StatSummaryRequest_FromResource:
 		labelNames = promDstGroupByLabelNames(req.Selector.Resource)
 
-		labels = labels.Merge(promQueryLabels(out.FromResource))
+		if out.FromResource.GetType() == k8s.Service {
+			// services don't send traffic, so the traffic from a service is
+			// the traffic sent through it, i.e. whose destination is the
+			// service
+			labels = labels.Merge(promDstQueryLabels(out.FromResource))
+		} else {
+			labels = labels.Merge(promQueryLabels(out.FromResource))
+		}
 		labels = labels.Merge(promDstQueryLabels(req.Selector.Resource))
 		labels = labels.Merge(promDirectionLabels("outbound"))
 
@@ -531,6 +969,11 @@ func buildRequestLabels(req *pb.StatSummaryRequest) (labels model.LabelSet, labe
 
 		labels = labels.Merge(promQueryLabels(req.Selector.Resource))
 		labels = labels.Merge(promDirectionLabels("inbound"))
+		if id := req.GetFromIdentity(); id != "" {
+			// the inbound metrics are labeled with the TLS identity of the
+			// client, whether it's meshed or not
+			labels = labels.Merge(model.LabelSet{clientIDLabel: model.LabelValue(id)})
+		}
 	}
 
 	return
@@ -575,6 +1018,13 @@ func buildServerRequestLabels(req *pb.StatSummaryRequest) (labels model.LabelSet
 		resourceLabel = serverLabel
 	} else if req.GetSelector().GetResource().GetType() == k8s.ServerAuthorization {
 		resourceLabel = serverAuthorizationLabel
+	} else if req.GetSelector().GetResource().GetType() == k8s.HTTPRoute {
+		// the proxies label the metrics of the requests matching a route with
+		// its kind and name
+		resourceLabel = routeNameLabel
+		labels = labels.Merge(model.LabelSet{
+			routeKindLabel: model.LabelValue("HTTPRoute"),
+		})
 	}
 
 	if req.GetSelector().GetResource().GetName() != "" {
@@ -582,6 +1032,11 @@ func buildServerRequestLabels(req *pb.StatSummaryRequest) (labels model.LabelSet
 			resourceLabel: model.LabelValue(req.GetSelector().GetResource().GetName()),
 		})
 	}
+	if req.GetServerName() != "" {
+		labels = labels.Merge(model.LabelSet{
+			serverLabel: model.LabelValue(req.GetServerName()),
+		})
+	}
 
 	switch out := req.Outbound.(type) {
 	case *pb.StatSummaryRequest_ToResource:
@@ -600,7 +1055,7 @@ func buildServerRequestLabels(req *pb.StatSummaryRequest) (labels model.LabelSet
 	return labels, groupBy
 }
 
-func buildTCPStatsRequestLabels(req *pb.StatSummaryRequest, reqLabels model.LabelSet) string {
+func buildTCPStatsRequestLabels(req *pb.StatSummaryRequest, reqLabels model.LabelSet, podLabel model.LabelName, pods []string) string {
 	switch req.Outbound.(type) {
 	case *pb.StatSummaryRequest_ToResource, *pb.StatSummaryRequest_FromResource:
 		// If TCP stats are queried from a resource to another one (i.e outbound -- from/to), then append peer='dst'
@@ -610,24 +1065,92 @@ func buildTCPStatsRequestLabels(req *pb.StatSummaryRequest, reqLabels model.Labe
 		// If TCP stats are not queried from a specific resource (i.e inbound -- no to/from), then append peer='src'
 		reqLabels = reqLabels.Merge(promPeerLabel("src"))
 	}
-	return reqLabels.String()
+	return generateLabelStringWithNames(reqLabels, podLabel, pods)
+}
+
+// statPodLabel returns the label holding the names of the pods the metrics of
+// the request are filtered on: with --from, the pods are the destination of
+// the requests.
+func statPodLabel(req *pb.StatSummaryRequest) model.LabelName {
+	if req.GetFromResource() != nil {
+		return dstPodLabel
+	}
+	return podLabel
 }
 
-func (s *grpcServer) getStatMetrics(ctx context.Context, req *pb.StatSummaryRequest, timeWindow string) (map[rKey]*pb.BasicStats, map[rKey]*pb.TcpStats, error) {
+// getPodsStatMetrics queries the metrics of the requested resources. When pods
+// isn't nil, only the metrics whose podLabel matches one of the pods are
+// queried, one namespace at a time so that the pods of other namespaces
+// sharing their names are left out.
+func (s *grpcServer) getPodsStatMetrics(ctx context.Context, req *pb.StatSummaryRequest, timeWindow string, podLabel model.LabelName, pods podsByNamespace) (map[rKey]*pb.BasicStats, map[rKey]*pb.TcpStats, error) {
+	if pods == nil {
+		return s.getStatMetrics(ctx, req, timeWindow, podLabel, "", nil)
+	}
+
+	namespaces := make([]string, 0, len(pods))
+	for ns := range pods {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	basicStats := make(map[rKey]*pb.BasicStats)
+	tcpStats := make(map[rKey]*pb.TcpStats)
+	for _, ns := range namespaces {
+		// the metrics are grouped by the namespace of the pods, so the
+		// results of different namespaces don't overlap
+		nsBasicStats, nsTCPStats, err := s.getStatMetrics(ctx, req, timeWindow, podLabel, ns, pods[ns])
+		if err != nil {
+			return nil, nil, err
+		}
+		for k, v := range nsBasicStats {
+			basicStats[k] = v
+		}
+		for k, v := range nsTCPStats {
+			tcpStats[k] = v
+		}
+	}
+	return basicStats, tcpStats, nil
+}
+
+// getStatMetrics queries the metrics of the requested resources. When pods
+// isn't nil, only the metrics whose podLabel matches one of the pods are
+// queried; podNamespace, when set, is the namespace of those pods.
+func (s *grpcServer) getStatMetrics(ctx context.Context, req *pb.StatSummaryRequest, timeWindow string, podLabel model.LabelName, podNamespace string, pods []string) (map[rKey]*pb.BasicStats, map[rKey]*pb.TcpStats, error) {
 	reqLabels, groupBy := buildRequestLabels(req)
+	if podNamespace != "" {
+		nsLabel := namespaceLabel
+		if podLabel == dstPodLabel {
+			nsLabel = dstNamespaceLabel
+		}
+		reqLabels = reqLabels.Merge(model.LabelSet{nsLabel: model.LabelValue(podNamespace)})
+	}
+	// the version is put first, as metricToKey expects the resource labels
+	// last
+	queryGroupBy := groupBy
+	if label := versionLabel(req); label != "" {
+		queryGroupBy = append(model.LabelNames{label}, groupBy...)
+	}
+	if req.GetByPort() {
+		queryGroupBy = append(model.LabelNames{targetPortLabel}, queryGroupBy...)
+	}
+	reqLabelString := generateLabelStringWithNames(reqLabels, podLabel, pods)
 	promQueries := map[promType]string{
-		promRequests: fmt.Sprintf(reqQuery, reqLabels.String(), timeWindow, groupBy.String()),
+		promRequests: fmt.Sprintf(reqQuery, reqLabelString, timeWindow, queryGroupBy.String()),
 	}
 
 	if req.TcpStats {
-		promQueries[promTCPConnections] = fmt.Sprintf(tcpConnectionsQuery, reqLabels.String(), groupBy.String())
+		promQueries[promTCPConnections] = fmt.Sprintf(tcpConnectionsQuery, reqLabelString, queryGroupBy.String())
 		// For TCP read/write bytes total we add an additional 'peer' label with a value of either 'src' or 'dst'
-		tcpLabels := buildTCPStatsRequestLabels(req, reqLabels)
-		promQueries[promTCPReadBytes] = fmt.Sprintf(tcpReadBytesQuery, tcpLabels, timeWindow, groupBy.String())
-		promQueries[promTCPWriteBytes] = fmt.Sprintf(tcpWriteBytesQuery, tcpLabels, timeWindow, groupBy.String())
+		tcpLabels := buildTCPStatsRequestLabels(req, reqLabels, podLabel, pods)
+		promQueries[promTCPReadBytes] = fmt.Sprintf(tcpReadBytesQuery, tcpLabels, timeWindow, queryGroupBy.String())
+		promQueries[promTCPWriteBytes] = fmt.Sprintf(tcpWriteBytesQuery, tcpLabels, timeWindow, queryGroupBy.String())
 	}
 
-	quantileQueries := generateQuantileQueries(latencyQuantileQuery, reqLabels.String(), timeWindow, groupBy.String())
+	quantileQueries := generateQuantileQueries(latencyQuantileQuery, reqLabelString, timeWindow, queryGroupBy.String())
+	ctx, err := s.boundQueries(ctx, promQueries, quantileQueries, queryGroupBy)
+	if err != nil {
+		return nil, nil, err
+	}
 	results, err := s.getPrometheusMetrics(ctx, promQueries, quantileQueries)
 
 	if err != nil {
@@ -638,7 +1161,9 @@ func (s *grpcServer) getStatMetrics(ctx context.Context, req *pb.StatSummaryRequ
 	return basicStats, tcpStats, nil
 }
 
-func (s *grpcServer) getServiceMetrics(ctx context.Context, req *pb.StatSummaryRequest, timeWindow string) (map[dstKey]*pb.BasicStats, map[dstKey]*pb.TcpStats, error) {
+// getServiceMetrics queries the metrics of the services. When names isn't
+// nil, only the metrics of the services with those names are queried.
+func (s *grpcServer) getServiceMetrics(ctx context.Context, req *pb.StatSummaryRequest, timeWindow string, names []string) (map[dstKey]*pb.BasicStats, map[dstKey]*pb.TcpStats, error) {
 	dstBasicStats := make(map[dstKey]*pb.BasicStats)
 	dstTCPStats := make(map[dstKey]*pb.TcpStats)
 	labels, groupBy := buildServiceRequestLabels(req)
@@ -646,8 +1171,12 @@ func (s *grpcServer) getServiceMetrics(ctx context.Context, req *pb.StatSummaryR
 	service := req.GetSelector().GetResource().GetName()
 	namespace := req.GetSelector().GetResource().GetNamespace()
 
-	if service == "" {
+	anyService := service == ""
+	if anyService {
 		service = regexAny
+		if names != nil {
+			service = namesRegex(names)
+		}
 	}
 	authority := fmt.Sprintf("%s.%s.svc.%s", service, namespace, s.clusterDomain)
 
@@ -667,6 +1196,10 @@ func (s *grpcServer) getServiceMetrics(ctx context.Context, req *pb.StatSummaryR
 	}
 
 	quantileQueries := generateQuantileQueries(latencyQuantileQuery, reqLabels, timeWindow, groupBy.String())
+	ctx, err := s.boundQueries(ctx, promQueries, quantileQueries, groupBy)
+	if err != nil {
+		return nil, nil, err
+	}
 	results, err := s.getPrometheusMetrics(ctx, promQueries, quantileQueries)
 	if err != nil {
 		return nil, nil, err
@@ -678,7 +1211,7 @@ func (s *grpcServer) getServiceMetrics(ctx context.Context, req *pb.StatSummaryR
 
 		// Use the returned `dst_service` in the `all` svc case
 		svcName := service
-		if svcName == regexAny {
+		if anyService {
 			svcName = rKey.Name
 		}
 
@@ -693,7 +1226,7 @@ func (s *grpcServer) getServiceMetrics(ctx context.Context, req *pb.StatSummaryR
 
 		// Use the returned `dst_service` in the `all` svc case
 		svcName := service
-		if svcName == regexAny {
+		if anyService {
 			svcName = rKey.Name
 		}
 
@@ -707,30 +1240,46 @@ func (s *grpcServer) getServiceMetrics(ctx context.Context, req *pb.StatSummaryR
 	return dstBasicStats, dstTCPStats, nil
 }
 
-func (s *grpcServer) getPolicyMetrics(ctx context.Context, req *pb.StatSummaryRequest, timeWindow string) (map[rKey]*pb.BasicStats, map[rKey]*pb.TcpStats, map[rKey]*pb.ServerStats, error) {
+// getPolicyMetrics queries the metrics of the policy resources. When names
+// isn't nil, only the metrics of the resources with those names are queried.
+func (s *grpcServer) getPolicyMetrics(ctx context.Context, req *pb.StatSummaryRequest, timeWindow string, names []string) (map[rKey]*pb.BasicStats, map[rKey]*pb.TcpStats, map[rKey]*pb.ServerStats, error) {
+	if req.GetSelector().GetResource().GetType() == k8s.HTTPRoute {
+		if err := s.checkRouteLabels(ctx, req); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
 	labels, groupBy := buildServerRequestLabels(req)
+	// the resource label is the last one policy metrics are grouped by
+	resourceLabel := groupBy[len(groupBy)-1]
 	// Server metrics are always inbound
 	reqLabels := labels.Merge(model.LabelSet{
 		"direction": model.LabelValue("inbound"),
 	})
+	reqLabelString := generateLabelStringWithNames(reqLabels, resourceLabel, names)
 
 	promQueries := make(map[promType]string)
 	if req.GetSelector().GetResource().GetType() == k8s.Server {
 		// TCP metrics are only supported with servers
 		if req.TcpStats {
 			// peer is always `src` as these are inbound metrics
-			tcpLabels := reqLabels.Merge(promPeerLabel("src"))
-			promQueries[promTCPConnections] = fmt.Sprintf(tcpConnectionsQuery, tcpLabels.String(), groupBy.String())
-			promQueries[promTCPReadBytes] = fmt.Sprintf(tcpReadBytesQuery, tcpLabels.String(), timeWindow, groupBy.String())
-			promQueries[promTCPWriteBytes] = fmt.Sprintf(tcpWriteBytesQuery, tcpLabels.String(), timeWindow, groupBy.String())
+			tcpLabels := generateLabelStringWithNames(reqLabels.Merge(promPeerLabel("src")), resourceLabel, names)
+			promQueries[promTCPConnections] = fmt.Sprintf(tcpConnectionsQuery, tcpLabels, groupBy.String())
+			promQueries[promTCPReadBytes] = fmt.Sprintf(tcpReadBytesQuery, tcpLabels, timeWindow, groupBy.String())
+			promQueries[promTCPWriteBytes] = fmt.Sprintf(tcpWriteBytesQuery, tcpLabels, timeWindow, groupBy.String())
 		}
 	}
 
-	promQueries[promRequests] = fmt.Sprintf(reqQuery, reqLabels, timeWindow, groupBy.String())
 	// Use `labels` as direction isn't present with authorization metrics
-	promQueries[promAllowedRequests] = fmt.Sprintf(httpAuthzAllowQuery, labels, timeWindow, groupBy.String())
-	promQueries[promDeniedRequests] = fmt.Sprintf(httpAuthzDenyQuery, labels, timeWindow, groupBy.String())
-	quantileQueries := generateQuantileQueries(latencyQuantileQuery, reqLabels.String(), timeWindow, groupBy.String())
+	authzLabelString := generateLabelStringWithNames(labels, resourceLabel, names)
+	promQueries[promRequests] = fmt.Sprintf(reqQuery, reqLabelString, timeWindow, groupBy.String())
+	promQueries[promAllowedRequests] = fmt.Sprintf(httpAuthzAllowQuery, authzLabelString, timeWindow, groupBy.String())
+	promQueries[promDeniedRequests] = fmt.Sprintf(httpAuthzDenyQuery, authzLabelString, timeWindow, groupBy.String())
+	quantileQueries := generateQuantileQueries(latencyQuantileQuery, reqLabelString, timeWindow, groupBy.String())
+	ctx, err := s.boundQueries(ctx, promQueries, quantileQueries, groupBy)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 	results, err := s.getPrometheusMetrics(ctx, promQueries, quantileQueries)
 	if err != nil {
 		return nil, nil, nil, err
@@ -740,6 +1289,26 @@ func (s *grpcServer) getPolicyMetrics(ctx context.Context, req *pb.StatSummaryRe
 	return basicStats, tcpStats, authzStats, nil
 }
 
+// checkRouteLabels returns an error when the proxies of the requested
+// namespace authorize requests without labelling the metrics with the route
+// they matched, as older proxies do, which would silently report no HTTPRoute
+// traffic.
+func (s *grpcServer) checkRouteLabels(ctx context.Context, req *pb.StatSummaryRequest) error {
+	labels := model.LabelSet{}
+	if ns := req.GetSelector().GetResource().GetNamespace(); ns != "" {
+		labels[namespaceLabel] = model.LabelValue(ns)
+	}
+	query := fmt.Sprintf(routeLabelsQuery, labels, routeNameLabel)
+	vec, err := s.queryProm(ctx, query)
+	if err != nil {
+		return err
+	}
+	if isLabelMissing(vec, routeNameLabel) {
+		return fmt.Errorf("HTTPRoute stats require proxies labelling their metrics with %s and %s; upgrade the proxies to a version emitting these labels", routeNameLabel, routeKindLabel)
+	}
+	return nil
+}
+
 func processPrometheusMetrics(req *pb.StatSummaryRequest, results []promResult, groupBy model.LabelNames) (map[rKey]*pb.BasicStats, map[rKey]*pb.TcpStats, map[rKey]*pb.ServerStats) {
 	basicStats := make(map[rKey]*pb.BasicStats)
 	tcpStats := make(map[rKey]*pb.TcpStats)
@@ -821,20 +1390,54 @@ func metricToKey(req *pb.StatSummaryRequest, metric model.Metric, groupBy model.
 		key.Namespace = string(metric[groupBy[0]])
 	}
 
+	if label := versionLabel(req); label != "" {
+		key.Version = string(metric[label])
+	}
+
+	if req.GetByPort() {
+		key.Port = string(metric[targetPortLabel])
+	}
+
 	return key
 }
 
+// versionLabel returns the Prometheus label holding the version the stats are
+// broken down by, if any. Prometheus replaces the characters of the pod label
+// names that are invalid in its label names with underscores, and the labels
+// of the destination pods of --from queries are prefixed with dst_.
+func versionLabel(req *pb.StatSummaryRequest) model.LabelName {
+	if req.GetVersionLabel() == "" {
+		return ""
+	}
+	name := strings.Map(func(r rune) rune {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, req.GetVersionLabel())
+	if req.GetFromResource() != nil {
+		name = "dst_" + name
+	}
+	return model.LabelName(name)
+}
+
 func (s *grpcServer) getPodStats(obj runtime.Object) (*podStats, error) {
 	pods, err := s.k8sAPI.GetPodsFor(obj, true)
 	if err != nil {
 		return nil, err
 	}
-	podErrors := make(map[string]*pb.PodErrors)
-	meshCount := &podStats{}
+	meshCount := s.getPodsStats(pods)
 
 	if pod, ok := obj.(*corev1.Pod); ok {
 		meshCount.status = k8s.GetPodStatus(*pod)
 	}
+	return meshCount, nil
+}
+
+// getPodsStats returns the stats of a set of pods, leaving out their status
+func (s *grpcServer) getPodsStats(pods []*corev1.Pod) *podStats {
+	podErrors := make(map[string]*pb.PodErrors)
+	meshCount := &podStats{}
 
 	for _, pod := range pods {
 		if pod.Status.Phase == corev1.PodFailed {
@@ -846,6 +1449,13 @@ func (s *grpcServer) getPodStats(obj runtime.Object) (*podStats, error) {
 			}
 		}
 
+		for _, st := range pod.Status.ContainerStatuses {
+			meshCount.restarts += uint64(st.RestartCount)
+			if isOOMKilled(st) {
+				meshCount.oomKilled++
+			}
+		}
+
 		errors := checkContainerErrors(pod.Status.ContainerStatuses)
 		errors = append(errors, checkContainerErrors(pod.Status.InitContainerStatuses)...)
 
@@ -854,7 +1464,17 @@ func (s *grpcServer) getPodStats(obj runtime.Object) (*podStats, error) {
 		}
 	}
 	meshCount.errors = podErrors
-	return meshCount, nil
+	meshCount.pods = pods
+	return meshCount
+}
+
+// isOOMKilled returns true if the container's current or last termination
+// was caused by the kernel OOM killer
+func isOOMKilled(st corev1.ContainerStatus) bool {
+	if st.State.Terminated != nil && st.State.Terminated.Reason == oomKilledReason {
+		return true
+	}
+	return st.LastTerminationState.Terminated != nil && st.LastTerminationState.Terminated.Reason == oomKilledReason
 }
 
 func toPodError(container, image, reason, message string) *pb.PodErrors_PodError {
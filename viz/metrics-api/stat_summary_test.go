@@ -3,8 +3,11 @@ package api
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/linkerd/linkerd2/controller/k8s"
@@ -12,6 +15,7 @@ import (
 	"github.com/linkerd/linkerd2/pkg/prometheus"
 	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
 	"github.com/prometheus/common/model"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 type statSumExpected struct {
@@ -273,6 +277,259 @@ status:
 		testStatSummary(t, expectations)
 	})
 
+	t.Run("Successfully performs a query based on resource type Pod when containers have restarted", func(t *testing.T) {
+		expectations := []statSumExpected{
+			{
+				expectedStatRPC: expectedStatRPC{
+					err: nil,
+					k8sConfigs: []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+  containerStatuses:
+  - name: emoji-svc
+    ready: true
+    restartCount: 3
+    lastState:
+      terminated:
+        reason: OOMKilled
+        exitCode: 137
+  - name: linkerd-proxy
+    ready: true
+    restartCount: 1
+`,
+					},
+					mockPromResponse: prometheusMetric("emoji", "pod"),
+				},
+				req: &pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Pod,
+						},
+					},
+					TimeWindow: "1m",
+				},
+				expectedResponse: GenStatSummaryResponse("emoji", pkgK8s.Pod, []string{"emojivoto"}, &PodCounts{
+					Status:      "Running",
+					MeshedPods:  1,
+					RunningPods: 1,
+					FailedPods:  0,
+					Restarts:    4,
+					OOMKilled:   1,
+				}, true, false),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
+	t.Run("Successfully returns the policy attached to pods when requested", func(t *testing.T) {
+		expectations := []statSumExpected{
+			{
+				expectedStatRPC: expectedStatRPC{
+					err: nil,
+					k8sConfigs: []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+spec:
+  containers:
+  - name: linkerd-proxy
+    env:
+    - name: LINKERD2_PROXY_INBOUND_DEFAULT_POLICY
+      value: deny
+status:
+  phase: Running
+`, `
+apiVersion: policy.linkerd.io/v1beta1
+kind: Server
+metadata:
+  name: emoji-grpc
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+spec:
+  podSelector:
+    matchLabels:
+      app: emoji-svc
+  port: grpc
+`, `
+apiVersion: policy.linkerd.io/v1beta1
+kind: Server
+metadata:
+  name: voting-grpc
+  namespace: emojivoto
+spec:
+  podSelector:
+    matchLabels:
+      app: voting-svc
+  port: grpc
+`, `
+apiVersion: policy.linkerd.io/v1beta1
+kind: ServerAuthorization
+metadata:
+  name: emoji-grpc
+  namespace: emojivoto
+spec:
+  server:
+    name: emoji-grpc
+  client:
+    unauthenticated: true
+`, `
+apiVersion: policy.linkerd.io/v1beta1
+kind: ServerAuthorization
+metadata:
+  name: emojivoto-grpc
+  namespace: emojivoto
+spec:
+  server:
+    selector:
+      matchLabels:
+        app: emoji-svc
+  client:
+    unauthenticated: true
+`, `
+apiVersion: policy.linkerd.io/v1beta1
+kind: ServerAuthorization
+metadata:
+  name: voting-grpc
+  namespace: emojivoto
+spec:
+  server:
+    name: voting-grpc
+  client:
+    unauthenticated: true
+`,
+					},
+					mockPromResponse: prometheusMetric("emoji", "pod"),
+				},
+				req: &pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Pod,
+						},
+					},
+					TimeWindow:  "1m",
+					PolicyStats: true,
+				},
+				expectedResponse: GenStatSummaryResponse("emoji", pkgK8s.Pod, []string{"emojivoto"}, &PodCounts{
+					Status:      "Running",
+					MeshedPods:  1,
+					RunningPods: 1,
+					FailedPods:  0,
+					Policy: &pb.PolicyStats{
+						Servers:              1,
+						ServerAuthorizations: 2,
+						DefaultDeny:          true,
+					},
+				}, true, false),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
+	t.Run("Successfully returns the policy of the namespace only for namespace rows", func(t *testing.T) {
+		k8sConfigs := []string{`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: emojivoto
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji
+  namespace: books
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`}
+		for _, ns := range []string{"emojivoto", "books"} {
+			k8sConfigs = append(k8sConfigs, fmt.Sprintf(`
+apiVersion: policy.linkerd.io/v1beta1
+kind: Server
+metadata:
+  name: emoji-grpc
+  namespace: %s
+spec:
+  podSelector:
+    matchLabels:
+      app: emoji-svc
+  port: grpc
+`, ns), fmt.Sprintf(`
+apiVersion: policy.linkerd.io/v1beta1
+kind: ServerAuthorization
+metadata:
+  name: emoji-grpc
+  namespace: %s
+spec:
+  server:
+    name: emoji-grpc
+  client:
+    unauthenticated: true
+`, ns))
+		}
+
+		expectations := []statSumExpected{
+			{
+				expectedStatRPC: expectedStatRPC{
+					err:              nil,
+					k8sConfigs:       k8sConfigs,
+					mockPromResponse: prometheusMetric("emojivoto", "namespace"),
+				},
+				req: &pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Name: "emojivoto",
+							Type: pkgK8s.Namespace,
+						},
+					},
+					TimeWindow:  "1m",
+					PolicyStats: true,
+				},
+				expectedResponse: GenStatSummaryResponse("emojivoto", pkgK8s.Namespace, []string{""}, &PodCounts{
+					MeshedPods:  1,
+					RunningPods: 1,
+					FailedPods:  0,
+					Policy: &pb.PolicyStats{
+						Servers:              1,
+						ServerAuthorizations: 1,
+					},
+				}, true, false),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
 	t.Run("Successfully performs a query based on resource type Deployment", func(t *testing.T) {
 		expectations := []statSumExpected{
 			{
@@ -629,6 +886,103 @@ status:
 		testStatSummary(t, expectations)
 	})
 
+	t.Run("Queries prometheus for the metrics of the current pods only when requested", func(t *testing.T) {
+		expectations := []statSumExpected{
+			{
+				expectedStatRPC: expectedStatRPC{
+					err: nil,
+					k8sConfigs: []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-1
+  namespace: emojivoto
+  uid: 9f6a2c1e
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+					},
+					mockPromResponse: prometheusMetric("emojivoto-1", "pod"),
+					expectedPrometheusQueries: []string{
+						`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", pod_uid=~"^(9f6a2c1e)$"}[1m])) by (le, namespace, pod))`,
+						`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", pod_uid=~"^(9f6a2c1e)$"}[1m])) by (le, namespace, pod))`,
+						`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", pod_uid=~"^(9f6a2c1e)$"}[1m])) by (le, namespace, pod))`,
+						`sum(increase(response_total{direction="inbound", namespace="emojivoto", pod_uid=~"^(9f6a2c1e)$"}[1m])) by (namespace, pod, classification, tls)`,
+					},
+				},
+				req: &pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Pod,
+						},
+					},
+					TimeWindow:      "1m",
+					CurrentPodsOnly: true,
+				},
+				expectedResponse: GenStatSummaryResponse("emojivoto-1", pkgK8s.Pod, []string{"emojivoto"}, &PodCounts{
+					Status:      "Running",
+					MeshedPods:  1,
+					RunningPods: 1,
+					FailedPods:  0,
+				}, true, false),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
+	t.Run("Queries prometheus for the inbound metrics of a client identity when requested", func(t *testing.T) {
+		expectations := []statSumExpected{
+			{
+				expectedStatRPC: expectedStatRPC{
+					err: nil,
+					k8sConfigs: []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-1
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+					},
+					mockPromResponse: prometheusMetric("emojivoto-1", "pod"),
+					expectedPrometheusQueries: []string{
+						`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{client_id="web.emojivoto.serviceaccount.identity.linkerd.cluster.local", direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, pod))`,
+						`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{client_id="web.emojivoto.serviceaccount.identity.linkerd.cluster.local", direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, pod))`,
+						`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{client_id="web.emojivoto.serviceaccount.identity.linkerd.cluster.local", direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, pod))`,
+						`sum(increase(response_total{client_id="web.emojivoto.serviceaccount.identity.linkerd.cluster.local", direction="inbound", namespace="emojivoto"}[1m])) by (namespace, pod, classification, tls)`,
+					},
+				},
+				req: &pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Pod,
+						},
+					},
+					TimeWindow:   "1m",
+					FromIdentity: "web.emojivoto.serviceaccount.identity.linkerd.cluster.local",
+				},
+				expectedResponse: GenStatSummaryResponse("emojivoto-1", pkgK8s.Pod, []string{"emojivoto"}, &PodCounts{
+					Status:      "Running",
+					MeshedPods:  1,
+					RunningPods: 1,
+					FailedPods:  0,
+				}, true, false),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
 	t.Run("Queries prometheus for TCP stats when requested", func(t *testing.T) {
 
 		expectations := []statSumExpected{
@@ -1023,7 +1377,7 @@ status:
 		testStatSummary(t, expectations)
 	})
 
-	t.Run("Queries prometheus for outbound metrics if --from resource is specified and --from-namespace is different from the resource namespace", func(t *testing.T) {
+	t.Run("Queries prometheus for the traffic sent through a service if --from service is specified", func(t *testing.T) {
 		expectations := []statSumExpected{
 			{
 				expectedStatRPC: expectedStatRPC{
@@ -1039,27 +1393,83 @@ metadata:
     linkerd.io/control-plane-ns: linkerd
 status:
   phase: Running
-`, `
-apiVersion: v1
-kind: Pod
-metadata:
-  name: emojivoto-2
-  namespace: totallydifferent
-  labels:
-    app: emoji-svc
-    linkerd.io/control-plane-ns: linkerd
-status:
-  phase: Running
 `,
 					},
 					mockPromResponse: model.Vector{
 						genPromSample("emojivoto-1", "pod", "emojivoto", true),
 					},
 					expectedPrometheusQueries: []string{
-						`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", dst_pod="emojivoto-1", namespace="totallydifferent", pod="emojivoto-2"}[1m])) by (le, dst_namespace, dst_pod))`,
-						`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", dst_pod="emojivoto-1", namespace="totallydifferent", pod="emojivoto-2"}[1m])) by (le, dst_namespace, dst_pod))`,
-						`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", dst_pod="emojivoto-1", namespace="totallydifferent", pod="emojivoto-2"}[1m])) by (le, dst_namespace, dst_pod))`,
-						`sum(increase(response_total{direction="outbound", dst_namespace="emojivoto", dst_pod="emojivoto-1", namespace="totallydifferent", pod="emojivoto-2"}[1m])) by (dst_namespace, dst_pod, classification, tls)`,
+						`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", dst_service="emoji-svc"}[1m])) by (le, dst_namespace, dst_pod))`,
+						`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", dst_service="emoji-svc"}[1m])) by (le, dst_namespace, dst_pod))`,
+						`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", dst_service="emoji-svc"}[1m])) by (le, dst_namespace, dst_pod))`,
+						`sum(increase(response_total{direction="outbound", dst_namespace="emojivoto", dst_service="emoji-svc"}[1m])) by (dst_namespace, dst_pod, classification, tls)`,
+					},
+				},
+				req: &pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Pod,
+						},
+					},
+					TimeWindow: "1m",
+					Outbound: &pb.StatSummaryRequest_FromResource{
+						FromResource: &pb.Resource{
+							Name:      "emoji-svc",
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Service,
+						},
+					},
+				},
+				expectedResponse: GenStatSummaryResponse("emojivoto-1", pkgK8s.Pod, []string{"emojivoto"}, &PodCounts{
+					Status:      "Running",
+					MeshedPods:  1,
+					RunningPods: 1,
+					FailedPods:  0,
+				}, true, false),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
+	t.Run("Queries prometheus for outbound metrics if --from resource is specified and --from-namespace is different from the resource namespace", func(t *testing.T) {
+		expectations := []statSumExpected{
+			{
+				expectedStatRPC: expectedStatRPC{
+					err: nil,
+					k8sConfigs: []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-1
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-2
+  namespace: totallydifferent
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+					},
+					mockPromResponse: model.Vector{
+						genPromSample("emojivoto-1", "pod", "emojivoto", true),
+					},
+					expectedPrometheusQueries: []string{
+						`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", dst_pod="emojivoto-1", namespace="totallydifferent", pod="emojivoto-2"}[1m])) by (le, dst_namespace, dst_pod))`,
+						`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", dst_pod="emojivoto-1", namespace="totallydifferent", pod="emojivoto-2"}[1m])) by (le, dst_namespace, dst_pod))`,
+						`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", dst_pod="emojivoto-1", namespace="totallydifferent", pod="emojivoto-2"}[1m])) by (le, dst_namespace, dst_pod))`,
+						`sum(increase(response_total{direction="outbound", dst_namespace="emojivoto", dst_pod="emojivoto-1", namespace="totallydifferent", pod="emojivoto-2"}[1m])) by (dst_namespace, dst_pod, classification, tls)`,
 					},
 				},
 				req: &pb.StatSummaryRequest{
@@ -1422,7 +1832,7 @@ status:
 				req: &pb.StatSummaryRequest{
 					Selector: &pb.ResourceSelection{
 						Resource: &pb.Resource{
-							Type: pkgK8s.Pod,
+							Type: pkgK8s.Service,
 						},
 					},
 					Outbound: &pb.StatSummaryRequest_FromResource{
@@ -1494,6 +1904,20 @@ status:
 					},
 				},
 			},
+			{
+				req: &pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Type: pkgK8s.Pod,
+						},
+					},
+					Outbound: &pb.StatSummaryRequest_FromResource{
+						FromResource: &pb.Resource{
+							Type: pkgK8s.Service,
+						},
+					},
+				},
+			},
 		}
 
 		for _, valid := range validRequests {
@@ -1821,12 +2245,84 @@ status:
 		testStatSummary(t, expectations)
 	})
 
-	t.Run("Stats returned are nil when SkipStats is true", func(t *testing.T) {
+	t.Run("Queries prometheus for the authorities served by the pods matching the label selector", func(t *testing.T) {
+		k8sConfigs := []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-1
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-2
+  namespace: emojivoto
+  labels:
+    app: web-svc
+status:
+  phase: Running
+`,
+		}
 		expectations := []statSumExpected{
 			{
 				expectedStatRPC: expectedStatRPC{
-					err: nil,
-					k8sConfigs: []string{`
+					err:        nil,
+					k8sConfigs: k8sConfigs,
+					mockPromResponse: model.Vector{
+						genPromSample("10.1.1.239:9995", "authority", "emojivoto", false),
+					},
+					expectedPrometheusQueries: []string{
+						`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", pod=~"^(emojivoto-1)$"}[1m])) by (le, namespace, authority))`,
+						`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", pod=~"^(emojivoto-1)$"}[1m])) by (le, namespace, authority))`,
+						`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", pod=~"^(emojivoto-1)$"}[1m])) by (le, namespace, authority))`,
+						`sum(increase(response_total{direction="inbound", namespace="emojivoto", pod=~"^(emojivoto-1)$"}[1m])) by (namespace, authority, classification, tls)`,
+					},
+				},
+				req: &pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Authority,
+						},
+						LabelSelector: "app=emoji-svc",
+					},
+					TimeWindow: "1m",
+				},
+				expectedResponse: GenStatSummaryResponse("10.1.1.239:9995", pkgK8s.Authority, []string{"emojivoto"}, nil, true, false),
+			},
+			{
+				expectedStatRPC: expectedStatRPC{
+					err:        nil,
+					k8sConfigs: k8sConfigs,
+					mockPromResponse: model.Vector{
+						genPromSample("10.1.1.239:9995", "authority", "emojivoto", false),
+					},
+					expectedPrometheusQueries: []string{},
+				},
+				req: &pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Authority,
+						},
+						LabelSelector: "app=voting-svc",
+					},
+					TimeWindow: "1m",
+				},
+				expectedResponse: GenStatSummaryResponse("10.1.1.239:9995", pkgK8s.Authority, nil, nil, true, false),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
+	t.Run("Queries prometheus for the pods matching the label selector in their own namespace", func(t *testing.T) {
+		k8sConfigs := []string{`
 apiVersion: v1
 kind: Pod
 metadata:
@@ -1834,33 +2330,953 @@ metadata:
   namespace: emojivoto
   labels:
     app: emoji-svc
-    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-1
+  namespace: books
+  labels:
+    app: books-svc
 status:
   phase: Running
 `,
+		}
+		expectations := []statSumExpected{
+			{
+				expectedStatRPC: expectedStatRPC{
+					err:        nil,
+					k8sConfigs: k8sConfigs,
+					mockPromResponse: model.Vector{
+						genPromSample("10.1.1.239:9995", "authority", "emojivoto", false),
 					},
-					mockPromResponse:          model.Vector{},
+					expectedPrometheusQueries: []string{
+						`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", pod=~"^(emojivoto-1)$"}[1m])) by (le, namespace, authority))`,
+						`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", pod=~"^(emojivoto-1)$"}[1m])) by (le, namespace, authority))`,
+						`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", pod=~"^(emojivoto-1)$"}[1m])) by (le, namespace, authority))`,
+						`sum(increase(response_total{direction="inbound", namespace="emojivoto", pod=~"^(emojivoto-1)$"}[1m])) by (namespace, authority, classification, tls)`,
+					},
+				},
+				req: &pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Type: pkgK8s.Authority,
+						},
+						LabelSelector: "app=emoji-svc",
+					},
+					TimeWindow: "1m",
+				},
+				expectedResponse: GenStatSummaryResponse("10.1.1.239:9995", pkgK8s.Authority, []string{"emojivoto"}, nil, true, false),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
+	t.Run("Queries prometheus for the services matching the label selector", func(t *testing.T) {
+		k8sConfigs := []string{`
+apiVersion: v1
+kind: Service
+metadata:
+  name: emoji-svc
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web-svc
+  namespace: emojivoto
+  labels:
+    app: web-svc
+`,
+		}
+		mockPromResponse := model.Vector{
+			genPromSample("emoji-svc", "service", "emojivoto", true),
+			genPromSample("web-svc", "service", "emojivoto", true),
+		}
+		expectations := []statSumExpected{
+			{
+				expectedStatRPC: expectedStatRPC{
+					err:              nil,
+					k8sConfigs:       k8sConfigs,
+					mockPromResponse: mockPromResponse,
+					expectedPrometheusQueries: []string{
+						`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{authority=~"^(web-svc).emojivoto.svc.cluster.local.*", direction="outbound"}[1m])) by (le, dst_namespace, dst_service))`,
+						`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{authority=~"^(web-svc).emojivoto.svc.cluster.local.*", direction="outbound"}[1m])) by (le, dst_namespace, dst_service))`,
+						`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{authority=~"^(web-svc).emojivoto.svc.cluster.local.*", direction="outbound"}[1m])) by (le, dst_namespace, dst_service))`,
+						`sum(increase(response_total{authority=~"^(web-svc).emojivoto.svc.cluster.local.*", direction="outbound"}[1m])) by (dst_namespace, dst_service, classification, tls)`,
+					},
+				},
+				req: &pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Service,
+						},
+						LabelSelector: "app=web-svc",
+					},
+					TimeWindow: "1m",
+				},
+				expectedResponse: GenStatSummaryResponse("web-svc", pkgK8s.Service, []string{"emojivoto"}, nil, true, false),
+			},
+			{
+				expectedStatRPC: expectedStatRPC{
+					err:                       nil,
+					k8sConfigs:                k8sConfigs,
+					mockPromResponse:          mockPromResponse,
 					expectedPrometheusQueries: []string{},
 				},
 				req: &pb.StatSummaryRequest{
 					Selector: &pb.ResourceSelection{
 						Resource: &pb.Resource{
 							Namespace: "emojivoto",
-							Type:      pkgK8s.Pod,
+							Type:      pkgK8s.Service,
+							Name:      "emoji-svc",
 						},
+						LabelSelector: "app=web-svc",
 					},
 					TimeWindow: "1m",
-					SkipStats:  true,
 				},
-				expectedResponse: GenStatSummaryResponse("emojivoto-1", pkgK8s.Pod, []string{"emojivoto"}, &PodCounts{
-					Status:      "Running",
-					MeshedPods:  1,
-					RunningPods: 1,
-					FailedPods:  0,
-				}, false, false),
+				expectedResponse: GenStatSummaryResponse("emoji-svc", pkgK8s.Service, nil, nil, true, false),
 			},
 		}
 
 		testStatSummary(t, expectations)
 	})
+
+	t.Run("Queries prometheus for the policy resources matching the label selector", func(t *testing.T) {
+		mockProm, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{mockPromResponse: model.Vector{}})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		req := &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{
+					Namespace: "emojivoto",
+					Type:      pkgK8s.Server,
+				},
+				LabelSelector: "app=emoji-svc",
+			},
+			TimeWindow: "1m",
+		}
+		_, _, _, err = fakeGrpcServer.getPolicyMetrics(context.TODO(), req, req.TimeWindow, []string{"emoji-http", "emoji-grpc"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		err = expectedStatRPC{
+			expectedPrometheusQueries: []string{
+				`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", srv_name=~"^(emoji-grpc|emoji-http)$"}[1m])) by (le, namespace, srv_name))`,
+				`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", srv_name=~"^(emoji-grpc|emoji-http)$"}[1m])) by (le, namespace, srv_name))`,
+				`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", srv_name=~"^(emoji-grpc|emoji-http)$"}[1m])) by (le, namespace, srv_name))`,
+				`sum(increase(inbound_http_authz_allow_total{namespace="emojivoto", srv_name=~"^(emoji-grpc|emoji-http)$"}[1m])) by (namespace, srv_name)`,
+				`sum(increase(inbound_http_authz_deny_total{namespace="emojivoto", srv_name=~"^(emoji-grpc|emoji-http)$"}[1m])) by (namespace, srv_name)`,
+				`sum(increase(response_total{direction="inbound", namespace="emojivoto", srv_name=~"^(emoji-grpc|emoji-http)$"}[1m])) by (namespace, srv_name, classification, tls)`,
+			},
+		}.verifyPromQueries(mockProm)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Queries prometheus for the authorizations of a server receiving traffic sent to a workload", func(t *testing.T) {
+		mockProm, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{mockPromResponse: model.Vector{}})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		req := &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{
+					Namespace: "emojivoto",
+					Type:      pkgK8s.ServerAuthorization,
+					Name:      "emoji-grpc",
+				},
+			},
+			TimeWindow: "10m",
+			Outbound: &pb.StatSummaryRequest_ToResource{
+				ToResource: &pb.Resource{
+					Namespace: "emojivoto",
+					Type:      pkgK8s.Deployment,
+					Name:      "emoji",
+				},
+			},
+			ServerName: "emoji-grpc",
+		}
+		_, _, _, err = fakeGrpcServer.getPolicyMetrics(context.TODO(), req, req.TimeWindow, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		err = expectedStatRPC{
+			expectedPrometheusQueries: []string{
+				`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{deployment="emoji", direction="inbound", namespace="emojivoto", saz_name="emoji-grpc", srv_name="emoji-grpc"}[10m])) by (le, namespace, saz_name))`,
+				`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{deployment="emoji", direction="inbound", namespace="emojivoto", saz_name="emoji-grpc", srv_name="emoji-grpc"}[10m])) by (le, namespace, saz_name))`,
+				`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{deployment="emoji", direction="inbound", namespace="emojivoto", saz_name="emoji-grpc", srv_name="emoji-grpc"}[10m])) by (le, namespace, saz_name))`,
+				`sum(increase(inbound_http_authz_allow_total{deployment="emoji", namespace="emojivoto", saz_name="emoji-grpc", srv_name="emoji-grpc"}[10m])) by (namespace, saz_name)`,
+				`sum(increase(inbound_http_authz_deny_total{deployment="emoji", namespace="emojivoto", saz_name="emoji-grpc", srv_name="emoji-grpc"}[10m])) by (namespace, saz_name)`,
+				`sum(increase(response_total{deployment="emoji", direction="inbound", namespace="emojivoto", saz_name="emoji-grpc", srv_name="emoji-grpc"}[10m])) by (namespace, saz_name, classification, tls)`,
+			},
+		}.verifyPromQueries(mockProm)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Queries prometheus for the requests matching the HTTPRoutes of a namespace", func(t *testing.T) {
+		mockProm, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{mockPromResponse: model.Vector{}})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		req := &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{
+					Namespace: "emojivoto",
+					Type:      pkgK8s.HTTPRoute,
+				},
+			},
+			TimeWindow: "1m",
+		}
+		_, _, _, err = fakeGrpcServer.getPolicyMetrics(context.TODO(), req, req.TimeWindow, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		err = expectedStatRPC{
+			expectedPrometheusQueries: []string{
+				`count(inbound_http_authz_allow_total{namespace="emojivoto"}) by (route_name)`,
+				`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", route_kind="HTTPRoute"}[1m])) by (le, namespace, route_name))`,
+				`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", route_kind="HTTPRoute"}[1m])) by (le, namespace, route_name))`,
+				`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", route_kind="HTTPRoute"}[1m])) by (le, namespace, route_name))`,
+				`sum(increase(inbound_http_authz_allow_total{namespace="emojivoto", route_kind="HTTPRoute"}[1m])) by (namespace, route_name)`,
+				`sum(increase(inbound_http_authz_deny_total{namespace="emojivoto", route_kind="HTTPRoute"}[1m])) by (namespace, route_name)`,
+				`sum(increase(response_total{direction="inbound", namespace="emojivoto", route_kind="HTTPRoute"}[1m])) by (namespace, route_name, classification, tls)`,
+			},
+		}.verifyPromQueries(mockProm)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Returns the stats of the HTTPRoutes from the series labelled with their name", func(t *testing.T) {
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{
+			k8sConfigs:       []string{gatewayAPIResources},
+			mockPromResponse: prometheusMetric("emoji-route", "route_name"),
+		})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+		fakeGrpcServer.k8sAPI.DynamicClient = newIngressDynamicClient(t, nil, []*unstructured.Unstructured{
+			gatewayAPIObject("HTTPRoute", "emoji-route", map[string]interface{}{}),
+			gatewayAPIObject("HTTPRoute", "idle-route", map[string]interface{}{}),
+		})
+
+		rsp, err := fakeGrpcServer.StatSummary(context.TODO(), &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{
+					Namespace: "emojivoto",
+					Type:      pkgK8s.HTTPRoute,
+				},
+			},
+			TimeWindow: "1m",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if rsp.GetError() != nil {
+			t.Fatalf("Unexpected error response: %s", rsp.GetError().GetError())
+		}
+
+		rows := rsp.GetOk().GetStatTables()[0].GetPodGroup().GetRows()
+		if len(rows) != 2 {
+			t.Fatalf("Expected 2 rows, got %d: %+v", len(rows), rows)
+		}
+		stats := make(map[string]*pb.StatTable_PodGroup_Row)
+		for _, row := range rows {
+			stats[row.GetResource().GetName()] = row
+		}
+		route := stats["emoji-route"]
+		if route.GetStats().GetSuccessCount() != 123 || route.GetSrvStats().GetAllowedCount() != 123 {
+			t.Fatalf("Expected the stats of emoji-route, got %+v", route)
+		}
+		if idle := stats["idle-route"]; idle.GetStats() != nil || idle.GetSrvStats() != nil {
+			t.Fatalf("Expected no stats for idle-route, got %+v", idle)
+		}
+	})
+
+	t.Run("Rejects HTTPRoute stats when the proxies don't label their metrics with the route", func(t *testing.T) {
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{
+			mockPromResponse: prometheusMetric("emoji", "deployment"),
+		})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		req := &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{
+					Namespace: "emojivoto",
+					Type:      pkgK8s.HTTPRoute,
+				},
+			},
+			TimeWindow: "1m",
+		}
+		_, _, _, err = fakeGrpcServer.getPolicyMetrics(context.TODO(), req, req.TimeWindow, nil)
+		if err == nil {
+			t.Fatal("Expected an error, got none")
+		}
+	})
+
+	t.Run("Returns the queries behind each row when requested", func(t *testing.T) {
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{
+			k8sConfigs: []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-1
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+			},
+			mockPromResponse: model.Vector{
+				genPromSample("10.1.1.239:9995", "authority", "linkerd", false),
+			},
+		})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.StatSummary(context.TODO(), &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{
+					Namespace: "linkerd",
+					Type:      pkgK8s.Authority,
+					Name:      "10.1.1.239:9995",
+				},
+			},
+			TimeWindow:     "1m",
+			IncludeQueries: true,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		rows := rsp.GetOk().GetStatTables()[0].GetPodGroup().GetRows()
+		if len(rows) != 1 {
+			t.Fatalf("Expected 1 row, got %d", len(rows))
+		}
+
+		expectedQueries := []*pb.PromQuery{
+			{
+				Name:  "QUERY_LATENCY_P50",
+				Query: `histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{authority="10.1.1.239:9995", direction="inbound", namespace="linkerd"}[1m])) by (le, namespace, authority))`,
+			},
+			{
+				Name:  "QUERY_LATENCY_P95",
+				Query: `histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{authority="10.1.1.239:9995", direction="inbound", namespace="linkerd"}[1m])) by (le, namespace, authority))`,
+			},
+			{
+				Name:  "QUERY_LATENCY_P99",
+				Query: `histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{authority="10.1.1.239:9995", direction="inbound", namespace="linkerd"}[1m])) by (le, namespace, authority))`,
+			},
+			{
+				Name:  "QUERY_REQUESTS",
+				Query: `sum(increase(response_total{authority="10.1.1.239:9995", direction="inbound", namespace="linkerd"}[1m])) by (namespace, authority, classification, tls)`,
+			},
+		}
+		queries := rows[0].GetQueries()
+		if len(queries) != len(expectedQueries) {
+			t.Fatalf("Expected %d queries, got %d: %+v", len(expectedQueries), len(queries), queries)
+		}
+
+		evaluationTime := queries[0].GetEvaluationTime()
+		if _, err := time.Parse(time.RFC3339, evaluationTime); err != nil {
+			t.Fatalf("Invalid evaluation time %q: %s", evaluationTime, err)
+		}
+		for i, query := range queries {
+			expectedQueries[i].EvaluationTime = evaluationTime
+			if !proto.Equal(query, expectedQueries[i]) {
+				t.Fatalf("Expected: %+v\n Got: %+v", expectedQueries[i], query)
+			}
+		}
+	})
+
+	t.Run("Breaks the stats down by version when requested", func(t *testing.T) {
+		versionSample := func(version string) *model.Sample {
+			sample := genPromSample("emoji", "deployment", "emojivoto", false)
+			sample.Metric["pod_template_hash"] = model.LabelValue(version)
+			return sample
+		}
+		rs := func(hash string) string {
+			return fmt.Sprintf(`
+apiVersion: apps/v1
+kind: ReplicaSet
+metadata:
+  uid: rs-%[1]s
+  name: emoji-%[1]s
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    pod-template-hash: %[1]s
+  ownerReferences:
+  - apiVersion: apps/v1
+    uid: a1b2c3
+spec:
+  selector:
+    matchLabels:
+      app: emoji-svc
+      pod-template-hash: %[1]s
+`, hash)
+		}
+		pod := func(hash string) string {
+			return fmt.Sprintf(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji-%[1]s-1
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+    pod-template-hash: %[1]s
+  ownerReferences:
+  - apiVersion: apps/v1
+    uid: rs-%[1]s
+status:
+  phase: Running
+`, hash)
+		}
+
+		mockProm, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{
+			k8sConfigs: []string{`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: emoji
+  namespace: emojivoto
+  uid: a1b2c3
+spec:
+  selector:
+    matchLabels:
+      app: emoji-svc
+  template:
+    spec:
+      containers:
+      - image: buoyantio/emojivoto-emoji-svc:v10
+`, rs("6f9c7b8d5"), pod("6f9c7b8d5"), rs("75d8c4b7f6"), pod("75d8c4b7f6"),
+			},
+			// the oldest version has no pod left
+			mockPromResponse: model.Vector{
+				versionSample("5c8d9b7f4"),
+				versionSample("6f9c7b8d5"),
+				versionSample("75d8c4b7f6"),
+			},
+		})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.StatSummary(context.TODO(), &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{
+					Namespace: "emojivoto",
+					Type:      pkgK8s.Deployment,
+					Name:      "emoji",
+				},
+			},
+			TimeWindow:   "1m",
+			VersionLabel: "pod-template-hash",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expectedQuery := `sum(increase(response_total{deployment="emoji", direction="inbound", namespace="emojivoto"}[1m])) by (pod_template_hash, namespace, deployment, classification, tls)`
+		found := false
+		for _, query := range mockProm.QueriesExecuted {
+			if query == expectedQuery {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("Expected query %s, got %v", expectedQuery, mockProm.QueriesExecuted)
+		}
+
+		rows := rsp.GetOk().GetStatTables()[0].GetPodGroup().GetRows()
+		sort.Slice(rows, func(i, j int) bool { return rows[i].GetVersion() < rows[j].GetVersion() })
+		expected := []struct {
+			version    string
+			meshedPods uint64
+		}{
+			{"5c8d9b7f4", 0},
+			{"6f9c7b8d5", 1},
+			{"75d8c4b7f6", 1},
+		}
+		if len(rows) != len(expected) {
+			t.Fatalf("Expected %d rows, got %d: %+v", len(expected), len(rows), rows)
+		}
+		for i, exp := range expected {
+			if rows[i].GetVersion() != exp.version || rows[i].GetMeshedPodCount() != exp.meshedPods {
+				t.Fatalf("Expected version %s with %d meshed pods, got %s with %d", exp.version, exp.meshedPods, rows[i].GetVersion(), rows[i].GetMeshedPodCount())
+			}
+			if rows[i].GetStats().GetSuccessCount() != 123 {
+				t.Fatalf("Expected the stats of version %s, got %+v", exp.version, rows[i].GetStats())
+			}
+		}
+	})
+
+	t.Run("Rejects version breakdowns of resources that don't own pods", func(t *testing.T) {
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.StatSummary(context.TODO(), &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{
+					Namespace: "emojivoto",
+					Type:      pkgK8s.Service,
+				},
+			},
+			TimeWindow:   "1m",
+			VersionLabel: "pod-template-hash",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if rsp.GetError() == nil {
+			t.Fatalf("Expected an error response, got %+v", rsp)
+		}
+	})
+
+	t.Run("Breaks the stats of authorities down by port", func(t *testing.T) {
+		portSample := func(port string) *model.Sample {
+			sample := genPromSample("web.emojivoto.svc.cluster.local", "authority", "emojivoto", false)
+			sample.Metric["target_port"] = model.LabelValue(port)
+			return sample
+		}
+
+		mockProm, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{
+			mockPromResponse: model.Vector{
+				portSample("80"),
+				portSample("8080"),
+			},
+		})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.StatSummary(context.TODO(), &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{
+					Namespace: "emojivoto",
+					Type:      pkgK8s.Authority,
+				},
+			},
+			TimeWindow: "1m",
+			ByPort:     true,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expectedQuery := `sum(increase(response_total{direction="inbound", namespace="emojivoto"}[1m])) by (target_port, namespace, authority, classification, tls)`
+		found := false
+		for _, query := range mockProm.QueriesExecuted {
+			if query == expectedQuery {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("Expected query %s, got %v", expectedQuery, mockProm.QueriesExecuted)
+		}
+
+		rows := rsp.GetOk().GetStatTables()[0].GetPodGroup().GetRows()
+		sort.Slice(rows, func(i, j int) bool { return rows[i].GetPort() < rows[j].GetPort() })
+		expected := []uint32{80, 8080}
+		if len(rows) != len(expected) {
+			t.Fatalf("Expected %d rows, got %d: %+v", len(expected), len(rows), rows)
+		}
+		for i, port := range expected {
+			if rows[i].GetPort() != port || rows[i].GetResource().GetName() != "web.emojivoto.svc.cluster.local" {
+				t.Fatalf("Expected the stats of port %d, got %+v", port, rows[i])
+			}
+			if rows[i].GetStats().GetSuccessCount() != 123 {
+				t.Fatalf("Expected the stats of port %d, got %+v", port, rows[i].GetStats())
+			}
+		}
+	})
+
+	t.Run("Rejects port breakdowns of resources other than authorities", func(t *testing.T) {
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.StatSummary(context.TODO(), &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{
+					Namespace: "emojivoto",
+					Type:      pkgK8s.Deployment,
+				},
+			},
+			TimeWindow: "1m",
+			ByPort:     true,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if rsp.GetError() == nil {
+			t.Fatalf("Expected an error response, got %+v", rsp)
+		}
+	})
+
+	t.Run("Rejects client identity queries of resources whose inbound traffic isn't measured by identity", func(t *testing.T) {
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.StatSummary(context.TODO(), &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{
+					Namespace: "emojivoto",
+					Type:      pkgK8s.Service,
+				},
+			},
+			TimeWindow:   "1m",
+			FromIdentity: "web.emojivoto.serviceaccount.identity.linkerd.cluster.local",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if rsp.GetError() == nil {
+			t.Fatalf("Expected an error response, got %+v", rsp)
+		}
+	})
+
+	t.Run("Filters the workloads by whether their pods are meshed", func(t *testing.T) {
+		k8sConfigs := []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji-meshed
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji-unmeshed
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+status:
+  phase: Running
+`,
+		}
+
+		testCases := []struct {
+			filter   pb.StatSummaryRequest_MeshFilter
+			expected []string
+		}{
+			{pb.StatSummaryRequest_ALL, []string{"emoji-meshed", "emoji-unmeshed"}},
+			{pb.StatSummaryRequest_MESHED, []string{"emoji-meshed"}},
+			{pb.StatSummaryRequest_UNMESHED, []string{"emoji-unmeshed"}},
+		}
+
+		for _, tc := range testCases {
+			tc := tc // pin
+			t.Run(tc.filter.String(), func(t *testing.T) {
+				_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{k8sConfigs: k8sConfigs})
+				if err != nil {
+					t.Fatalf("Error creating mock grpc server: %s", err)
+				}
+
+				rsp, err := fakeGrpcServer.StatSummary(context.TODO(), &pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Pod,
+						},
+					},
+					TimeWindow: "1m",
+					SkipStats:  true,
+					MeshFilter: tc.filter,
+				})
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+
+				var names []string
+				for _, row := range rsp.GetOk().GetStatTables()[0].GetPodGroup().GetRows() {
+					names = append(names, row.GetResource().GetName())
+				}
+				sort.Strings(names)
+				if !reflect.DeepEqual(names, tc.expected) {
+					t.Fatalf("Expected the rows of %v, got %v", tc.expected, names)
+				}
+			})
+		}
+	})
+
+	t.Run("Rejects mesh filters of resources that don't own pods", func(t *testing.T) {
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.StatSummary(context.TODO(), &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{
+					Namespace: "emojivoto",
+					Type:      pkgK8s.Authority,
+				},
+			},
+			TimeWindow: "1m",
+			MeshFilter: pb.StatSummaryRequest_UNMESHED,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if rsp.GetError() == nil {
+			t.Fatalf("Expected an error response, got %+v", rsp)
+		}
+	})
+
+	t.Run("Copies the configured labels of the resources into the rows", func(t *testing.T) {
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{
+			k8sConfigs: []string{`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: emoji
+  namespace: emojivoto
+  uid: a1b2c3
+  labels:
+    app: emoji-svc
+    team: emojis
+    app.kubernetes.io/version: v10
+spec:
+  selector:
+    matchLabels:
+      app: emoji-svc
+`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: emoji-svc
+  namespace: emojivoto
+  labels:
+    team: emojis
+`,
+			},
+			mockPromResponse: model.Vector{
+				genPromSample("emoji", "deployment", "emojivoto", false),
+			},
+		})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+		fakeGrpcServer.rowLabels = []string{"team", "app.kubernetes.io/version", "owner"}
+
+		testCases := []struct {
+			resource *pb.Resource
+			expected map[string]string
+		}{
+			{
+				resource: &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Deployment, Name: "emoji"},
+				expected: map[string]string{"team": "emojis", "app.kubernetes.io/version": "v10"},
+			},
+			{
+				resource: &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Service, Name: "emoji-svc"},
+				expected: map[string]string{"team": "emojis"},
+			},
+		}
+
+		for _, tc := range testCases {
+			rsp, err := fakeGrpcServer.StatSummary(context.TODO(), &pb.StatSummaryRequest{
+				Selector:   &pb.ResourceSelection{Resource: tc.resource},
+				TimeWindow: "1m",
+				SkipStats:  true,
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			rows := rsp.GetOk().GetStatTables()[0].GetPodGroup().GetRows()
+			if len(rows) != 1 {
+				t.Fatalf("Expected 1 row for %s, got %+v", tc.resource.GetType(), rows)
+			}
+			if !reflect.DeepEqual(rows[0].GetLabels(), tc.expected) {
+				t.Fatalf("Expected labels %v for %s, got %v", tc.expected, tc.resource.GetType(), rows[0].GetLabels())
+			}
+		}
+	})
+
+	t.Run("Stats returned are nil when SkipStats is true", func(t *testing.T) {
+		expectations := []statSumExpected{
+			{
+				expectedStatRPC: expectedStatRPC{
+					err: nil,
+					k8sConfigs: []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-1
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+					},
+					mockPromResponse:          model.Vector{},
+					expectedPrometheusQueries: []string{},
+				},
+				req: &pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Pod,
+						},
+					},
+					TimeWindow: "1m",
+					SkipStats:  true,
+				},
+				expectedResponse: GenStatSummaryResponse("emojivoto-1", pkgK8s.Pod, []string{"emojivoto"}, &PodCounts{
+					Status:      "Running",
+					MeshedPods:  1,
+					RunningPods: 1,
+					FailedPods:  0,
+				}, false, false),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
+	t.Run("Services and servers are read from the cache when SkipStats is true", func(t *testing.T) {
+		k8sConfigs := []string{`
+apiVersion: v1
+kind: Service
+metadata:
+  name: web-svc
+  namespace: emojivoto
+`, `
+apiVersion: policy.linkerd.io/v1beta1
+kind: Server
+metadata:
+  name: web-http
+  namespace: emojivoto
+spec:
+  podSelector:
+    matchLabels:
+      app: web-svc
+  port: http
+`,
+		}
+		expectations := []statSumExpected{
+			{
+				expectedStatRPC: expectedStatRPC{
+					err:                       nil,
+					k8sConfigs:                k8sConfigs,
+					mockPromResponse:          model.Vector{},
+					expectedPrometheusQueries: []string{},
+				},
+				req: &pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Service,
+						},
+					},
+					TimeWindow:     "1m",
+					SkipStats:      true,
+					IncludeQueries: true,
+				},
+				expectedResponse: GenStatSummaryResponse("web-svc", pkgK8s.Service, []string{"emojivoto"}, nil, false, false),
+			},
+			{
+				expectedStatRPC: expectedStatRPC{
+					err:                       nil,
+					k8sConfigs:                k8sConfigs,
+					mockPromResponse:          model.Vector{},
+					expectedPrometheusQueries: []string{},
+				},
+				req: &pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Server,
+						},
+					},
+					TimeWindow: "1m",
+					SkipStats:  true,
+				},
+				expectedResponse: GenStatSummaryResponse("web-http", pkgK8s.Server, []string{"emojivoto"}, nil, false, false),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+}
+
+func TestGetResultKeys(t *testing.T) {
+	web := rKey{Namespace: "emojivoto", Type: pkgK8s.Deployment, Name: "web"}
+	voting := rKey{Namespace: "emojivoto", Type: pkgK8s.Deployment, Name: "voting"}
+	emoji := rKey{Namespace: "emojivoto", Type: pkgK8s.Deployment, Name: "emoji"}
+	k8sObjects := map[rKey]k8sStat{web: {}, voting: {}, emoji: {}}
+	requestMetrics := map[rKey]*pb.BasicStats{web: {SuccessCount: 1}}
+	tcpMetrics := map[rKey]*pb.TcpStats{web: {OpenConnections: 1}, voting: {OpenConnections: 1}}
+	toResource := &pb.StatSummaryRequest_ToResource{
+		ToResource: &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Deployment, Name: "emoji"},
+	}
+
+	testCases := []struct {
+		name     string
+		req      *pb.StatSummaryRequest
+		expected []rKey
+	}{
+		{
+			name:     "returns all the objects without outbound filtering",
+			req:      &pb.StatSummaryRequest{},
+			expected: []rKey{emoji, voting, web},
+		},
+		{
+			name:     "returns the objects with request or TCP stats with outbound filtering",
+			req:      &pb.StatSummaryRequest{Outbound: toResource},
+			expected: []rKey{voting, web},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			keys := getResultKeys(tc.req, k8sObjects, requestMetrics, tcpMetrics)
+			sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+			if len(keys) != len(tc.expected) {
+				t.Fatalf("Expected keys %v, got %v", tc.expected, keys)
+			}
+			for i := range keys {
+				if keys[i] != tc.expected[i] {
+					t.Fatalf("Expected keys %v, got %v", tc.expected, keys)
+				}
+			}
+		})
+	}
 }
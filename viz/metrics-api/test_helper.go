@@ -11,18 +11,29 @@ import (
 	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
 	"github.com/prometheus/common/model"
 	"google.golang.org/grpc"
+	healthPb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 // MockAPIClient satisfies the metrics-api gRPC interfaces
 type MockAPIClient struct {
-	ErrorToReturn                error
-	ListPodsResponseToReturn     *pb.ListPodsResponse
-	ListServicesResponseToReturn *pb.ListServicesResponse
-	StatSummaryResponseToReturn  *pb.StatSummaryResponse
-	GatewaysResponseToReturn     *pb.GatewaysResponse
-	TopRoutesResponseToReturn    *pb.TopRoutesResponse
-	EdgesResponseToReturn        *pb.EdgesResponse
-	SelfCheckResponseToReturn    *pb.SelfCheckResponse
+	ErrorToReturn                        error
+	ListPodsResponseToReturn             *pb.ListPodsResponse
+	ListServicesResponseToReturn         *pb.ListServicesResponse
+	StatSummaryResponseToReturn          *pb.StatSummaryResponse
+	GatewaysResponseToReturn             *pb.GatewaysResponse
+	IngressStatsResponseToReturn         *pb.IngressStatsResponse
+	IngressResourceStatsResponseToReturn *pb.IngressResourceStatsResponse
+	EgressResponseToReturn               *pb.EgressResponse
+	TopRoutesResponseToReturn            *pb.TopRoutesResponse
+	EdgesResponseToReturn                *pb.EdgesResponse
+	DependenciesResponseToReturn         *pb.DependenciesResponse
+	LatencyHeatmapToReturn               *pb.LatencyHeatmapResponse
+	SelfCheckResponseToReturn            *pb.SelfCheckResponse
+	LabelCompatibilityToReturn           *pb.LabelCompatibilityResponse
+	// HealthStatusesToReturn holds the status of the health services, which
+	// are serving when missing
+	HealthStatusesToReturn map[string]healthPb.HealthCheckResponse_ServingStatus
+	AuthzResponseToReturn  *pb.AuthzResponse
 }
 
 // StatSummary provides a mock of a metrics-api method.
@@ -30,11 +41,40 @@ func (c *MockAPIClient) StatSummary(ctx context.Context, in *pb.StatSummaryReque
 	return c.StatSummaryResponseToReturn, c.ErrorToReturn
 }
 
+// Check provides a mock of the metrics-api health checks.
+func (c *MockAPIClient) Check(ctx context.Context, in *healthPb.HealthCheckRequest, opts ...grpc.CallOption) (*healthPb.HealthCheckResponse, error) {
+	status, ok := c.HealthStatusesToReturn[in.GetService()]
+	if !ok {
+		status = healthPb.HealthCheckResponse_SERVING
+	}
+	return &healthPb.HealthCheckResponse{Status: status}, c.ErrorToReturn
+}
+
+// Watch provides a mock of the metrics-api health checks.
+func (c *MockAPIClient) Watch(ctx context.Context, in *healthPb.HealthCheckRequest, opts ...grpc.CallOption) (healthPb.Health_WatchClient, error) {
+	return nil, c.ErrorToReturn
+}
+
 // Gateways provides a mock of a metrics-api method.
 func (c *MockAPIClient) Gateways(ctx context.Context, in *pb.GatewaysRequest, opts ...grpc.CallOption) (*pb.GatewaysResponse, error) {
 	return c.GatewaysResponseToReturn, c.ErrorToReturn
 }
 
+// IngressStats provides a mock of a metrics-api method.
+func (c *MockAPIClient) IngressStats(ctx context.Context, in *pb.IngressStatsRequest, opts ...grpc.CallOption) (*pb.IngressStatsResponse, error) {
+	return c.IngressStatsResponseToReturn, c.ErrorToReturn
+}
+
+// Egress provides a mock of a metrics-api method.
+func (c *MockAPIClient) Egress(ctx context.Context, in *pb.EgressRequest, opts ...grpc.CallOption) (*pb.EgressResponse, error) {
+	return c.EgressResponseToReturn, c.ErrorToReturn
+}
+
+// IngressResourceStats provides a mock of a metrics-api method.
+func (c *MockAPIClient) IngressResourceStats(ctx context.Context, in *pb.IngressResourceStatsRequest, opts ...grpc.CallOption) (*pb.IngressResourceStatsResponse, error) {
+	return c.IngressResourceStatsResponseToReturn, c.ErrorToReturn
+}
+
 // TopRoutes provides a mock of a metrics-api method.
 func (c *MockAPIClient) TopRoutes(ctx context.Context, in *pb.TopRoutesRequest, opts ...grpc.CallOption) (*pb.TopRoutesResponse, error) {
 	return c.TopRoutesResponseToReturn, c.ErrorToReturn
@@ -45,6 +85,16 @@ func (c *MockAPIClient) Edges(ctx context.Context, in *pb.EdgesRequest, opts ...
 	return c.EdgesResponseToReturn, c.ErrorToReturn
 }
 
+// Dependencies provides a mock of a metrics-api method.
+func (c *MockAPIClient) Dependencies(ctx context.Context, in *pb.DependenciesRequest, opts ...grpc.CallOption) (*pb.DependenciesResponse, error) {
+	return c.DependenciesResponseToReturn, c.ErrorToReturn
+}
+
+// LatencyHeatmap provides a mock of a metrics-api method.
+func (c *MockAPIClient) LatencyHeatmap(ctx context.Context, in *pb.LatencyHeatmapRequest, opts ...grpc.CallOption) (*pb.LatencyHeatmapResponse, error) {
+	return c.LatencyHeatmapToReturn, c.ErrorToReturn
+}
+
 // ListPods provides a mock of a metrics-api method.
 func (c *MockAPIClient) ListPods(ctx context.Context, in *pb.ListPodsRequest, opts ...grpc.CallOption) (*pb.ListPodsResponse, error) {
 	return c.ListPodsResponseToReturn, c.ErrorToReturn
@@ -60,6 +110,16 @@ func (c *MockAPIClient) SelfCheck(ctx context.Context, in *pb.SelfCheckRequest,
 	return c.SelfCheckResponseToReturn, c.ErrorToReturn
 }
 
+// LabelCompatibility provides a mock of a metrics-api method.
+func (c *MockAPIClient) LabelCompatibility(ctx context.Context, in *pb.LabelCompatibilityRequest, _ ...grpc.CallOption) (*pb.LabelCompatibilityResponse, error) {
+	return c.LabelCompatibilityToReturn, c.ErrorToReturn
+}
+
+// Authz provides a mock of a metrics-api method.
+func (c *MockAPIClient) Authz(ctx context.Context, in *pb.AuthzRequest, _ ...grpc.CallOption) (*pb.AuthzResponse, error) {
+	return c.AuthzResponseToReturn, c.ErrorToReturn
+}
+
 // PodCounts is a test helper struct that is used for representing data in a
 // StatTable.PodGroup.Row.
 type PodCounts struct {
@@ -67,7 +127,10 @@ type PodCounts struct {
 	MeshedPods  uint64
 	RunningPods uint64
 	FailedPods  uint64
+	Restarts    uint64
+	OOMKilled   uint64
 	Errors      map[string]*pb.PodErrors
+	Policy      *pb.PolicyStats
 }
 
 // GenStatSummaryResponse generates a mock metrics-api StatSummaryResponse
@@ -106,8 +169,11 @@ func GenStatSummaryResponse(resName, resType string, resNs []string, counts *Pod
 			statTableRow.MeshedPodCount = counts.MeshedPods
 			statTableRow.RunningPodCount = counts.RunningPods
 			statTableRow.FailedPodCount = counts.FailedPods
+			statTableRow.RestartCount = counts.Restarts
+			statTableRow.OomKilledCount = counts.OOMKilled
 			statTableRow.Status = counts.Status
 			statTableRow.ErrorsByPod = counts.Errors
+			statTableRow.PolicyStats = counts.Policy
 		}
 
 		rows = append(rows, statTableRow)
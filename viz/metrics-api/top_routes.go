@@ -4,13 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
+	"time"
 
 	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha2"
 	api "github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/prometheus/common/model"
 	log "github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/labels"
@@ -22,6 +25,8 @@ const (
 	actualRouteReqQuery       = "sum(increase(route_actual_response_total%s[%s])) by (%s, dst, classification)"
 	routeLatencyQuantileQuery = "histogram_quantile(%s, sum(irate(route_response_latency_ms_bucket%s[%s])) by (le, dst, %s))"
 	dstLabel                  = `dst=~"(%s)(:\\d+)?"`
+	// maxRouteTrendPoints bounds the number of time windows of the trends
+	maxRouteTrendPoints = 60
 	// DefaultRouteName is the name to display for requests that don't match any routes.
 	DefaultRouteName = "[DEFAULT]"
 )
@@ -195,6 +200,10 @@ func validateRequest(req *pb.TopRoutesRequest) *pb.TopRoutesResponse {
 			return topRoutesError(req, fmt.Sprintf("The %s resource type is not supported with 'to' queries", targetType))
 		}
 	}
+
+	if req.GetTrendPoints() > maxRouteTrendPoints {
+		return topRoutesError(req, fmt.Sprintf("The trend can't span more than %d time windows", maxRouteTrendPoints))
+	}
 	return nil
 }
 
@@ -280,9 +289,41 @@ func (s *grpcServer) getRouteMetrics(ctx context.Context, req *pb.TopRoutesReque
 
 	processRouteMetrics(results, timeWindow, table)
 
+	if trendPoints := req.GetTrendPoints(); trendPoints > 0 {
+		trendQuery := fmt.Sprintf(routeReqQuery, reqLabels, timeWindow, groupBy)
+		err = s.getRouteTrend(ctx, trendQuery, timeWindow, trendPoints, table)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return table, nil
 }
 
+// getRouteTrend fills the trend of each route of the table with its success
+// and failure counts over the last trendPoints time windows, by running the
+// requests query over a range stepping by the time window
+func (s *grpcServer) getRouteTrend(ctx context.Context, query string, timeWindow string, trendPoints uint32, table indexedTable) error {
+	window, err := model.ParseDuration(timeWindow)
+	if err != nil {
+		return err
+	}
+	step := time.Duration(window)
+	end := time.Now().UTC().Truncate(time.Second)
+	start := end.Add(-step * time.Duration(trendPoints-1))
+	matrix, err := s.queryPromRange(ctx, query, promv1.Range{
+		Start: start,
+		End:   end,
+		Step:  step,
+	})
+	if err != nil {
+		return err
+	}
+
+	processRouteTrend(matrix, start, step, int(trendPoints), table)
+	return nil
+}
+
 func (s *grpcServer) buildRouteLabels(req *pb.TopRoutesRequest, dsts []string, resource *pb.Resource) string {
 	// labels: the labels for the resource we want to query for
 	var labels model.LabelSet
@@ -356,6 +397,43 @@ func processRouteMetrics(results []promResult, timeWindow string, table indexedT
 	}
 }
 
+// processRouteTrend fills the trend of the rows of the table from the series of
+// the requests query, whose data points are spaced by step from start
+func processRouteTrend(matrix model.Matrix, start time.Time, step time.Duration, trendPoints int, table indexedTable) {
+	for _, row := range table {
+		row.Trend = make([]*pb.BasicStats, trendPoints)
+		for i := range row.Trend {
+			row.Trend[i] = &pb.BasicStats{}
+		}
+	}
+
+	for _, stream := range matrix {
+		route := string(stream.Metric[model.LabelName("rt_route")])
+		dst := string(stream.Metric[model.LabelName("dst")])
+		dst = strings.Split(dst, ":")[0] // Truncate port, if there is one.
+
+		row := table[dstAndRoute{dst, route}]
+		if row == nil {
+			// already reported by processRouteMetrics
+			continue
+		}
+
+		for _, pair := range stream.Values {
+			i := int(math.Round(float64(pair.Timestamp.Time().Sub(start)) / float64(step)))
+			if i < 0 || i >= trendPoints {
+				continue
+			}
+			value := extractSampleValue(&model.Sample{Value: pair.Value})
+			switch string(stream.Metric[model.LabelName("classification")]) {
+			case success:
+				row.Trend[i].SuccessCount += value
+			case failure:
+				row.Trend[i].FailureCount += value
+			}
+		}
+	}
+}
+
 //generate correct label.Selector object according to the request
 func getTopLabelSelector(req *pb.TopRoutesRequest) (labels.Selector, error) {
 	labelSelector := labels.Everything()
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
@@ -500,3 +501,78 @@ func TestTopRoutes(t *testing.T) {
 		testTopRoutes(t, expectations)
 	})
 }
+
+func TestProcessRouteTrend(t *testing.T) {
+	start := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	step := time.Minute
+	series := func(route, classification string, values ...float64) *model.SampleStream {
+		stream := &model.SampleStream{
+			Metric: model.Metric{
+				"rt_route":       model.LabelValue(route),
+				"dst":            "books.default.svc.cluster.local:7002",
+				"classification": model.LabelValue(classification),
+			},
+		}
+		for i, v := range values {
+			// the last data point is slightly late, as the range isn't
+			// aligned on the step
+			ts := start.Add(time.Duration(i+1) * step)
+			if i == len(values)-1 {
+				ts = ts.Add(time.Second)
+			}
+			stream.Values = append(stream.Values, model.SamplePair{Timestamp: model.TimeFromUnixNano(ts.UnixNano()), Value: model.SampleValue(v)})
+		}
+		return stream
+	}
+
+	table := indexedTable{
+		{dst: "books.default.svc.cluster.local", route: "/a"}: {Route: "/a", Stats: &pb.BasicStats{}},
+		{dst: "books.default.svc.cluster.local", route: ""}:   {Route: DefaultRouteName, Stats: &pb.BasicStats{}},
+	}
+	matrix := model.Matrix{
+		series("/a", success, 10, 20, 30),
+		series("/a", failure, 0, 5),
+		series("/unknown", success, 1),
+	}
+
+	// the range starts a step before the first data point
+	processRouteTrend(matrix, start, step, 4, table)
+
+	expected := []*pb.BasicStats{
+		{},
+		{SuccessCount: 10},
+		{SuccessCount: 20, FailureCount: 5},
+		{SuccessCount: 30},
+	}
+	trend := table[dstAndRoute{dst: "books.default.svc.cluster.local", route: "/a"}].GetTrend()
+	if len(trend) != len(expected) {
+		t.Fatalf("Expected %d trend points, got %d", len(expected), len(trend))
+	}
+	for i := range expected {
+		if !proto.Equal(trend[i], expected[i]) {
+			t.Fatalf("Expected trend point %d to be %v, got %v", i, expected[i], trend[i])
+		}
+	}
+
+	defaultTrend := table[dstAndRoute{dst: "books.default.svc.cluster.local", route: ""}].GetTrend()
+	if len(defaultTrend) != 4 || defaultTrend[0].GetSuccessCount() != 0 {
+		t.Fatalf("Expected an empty trend for the default route, got %v", defaultTrend)
+	}
+}
+
+func TestTopRoutesTrendPointsLimit(t *testing.T) {
+	req := &pb.TopRoutesRequest{
+		Selector: &pb.ResourceSelection{
+			Resource: &pb.Resource{Namespace: "default", Type: pkgK8s.Deployment, Name: "books"},
+		},
+		Outbound:    &pb.TopRoutesRequest_None{None: &pb.Empty{}},
+		TrendPoints: maxRouteTrendPoints + 1,
+	}
+	if rsp := validateRequest(req); rsp.GetError() == nil {
+		t.Fatalf("Expected an error for %d trend points", req.TrendPoints)
+	}
+	req.TrendPoints = maxRouteTrendPoints
+	if rsp := validateRequest(req); rsp != nil {
+		t.Fatalf("Unexpected error: %v", rsp.GetError())
+	}
+}
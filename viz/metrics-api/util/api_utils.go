@@ -2,10 +2,12 @@ package util
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/prometheus/common/model"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -28,15 +30,42 @@ type StatsBaseRequestParams struct {
 // StatSummary requests.
 type StatsSummaryRequestParams struct {
 	StatsBaseRequestParams
-	ToNamespace   string
-	ToType        string
-	ToName        string
-	FromNamespace string
-	FromType      string
-	FromName      string
-	SkipStats     bool
-	TCPStats      bool
-	LabelSelector string
+	ToNamespace    string
+	ToType         string
+	ToName         string
+	FromNamespace  string
+	FromType       string
+	FromName       string
+	SkipStats      bool
+	TCPStats       bool
+	PolicyStats    bool
+	IncludeQueries bool
+	LabelSelector  string
+	// ServerName narrows the stats of a ServerAuthorization down to a Server
+	ServerName string
+	// CurrentPodsOnly restricts the stats of the workloads to the metrics of
+	// their current pods
+	CurrentPodsOnly bool
+	// VersionLabel breaks the stats of the workloads down by the value of this
+	// pod label
+	VersionLabel string
+	// ByPort breaks the stats of authorities down by the port the requests
+	// were sent to
+	ByPort bool
+	// MeshedOnly and UnmeshedOnly restrict the stats to the workloads whose
+	// running pods all have the proxy, or to the ones with running pods
+	// lacking it
+	MeshedOnly   bool
+	UnmeshedOnly bool
+	// FromIdentity restricts the stats to the inbound traffic from the
+	// clients with this TLS identity
+	FromIdentity string
+	// SLOTarget is the success rate objective, as a percentage, the stats are
+	// evaluated against when set
+	SLOTarget float64
+	// SLOWindow is the period the error budget of the SLO is computed over,
+	// the time window when empty
+	SLOWindow string
 }
 
 // EdgesRequestParams contains parameters that are used to build
@@ -55,6 +84,9 @@ type TopRoutesRequestParams struct {
 	ToType        string
 	ToName        string
 	LabelSelector string
+	// TrendPoints is the number of time windows whose stats are returned as
+	// the trend of each route
+	TrendPoints uint32
 }
 
 // GatewayRequestParams contains parameters that are used to build a
@@ -107,9 +139,40 @@ func BuildStatSummaryRequest(p StatsSummaryRequestParams) (*pb.StatSummaryReques
 			},
 			LabelSelector: p.LabelSelector,
 		},
-		TimeWindow: window,
-		SkipStats:  p.SkipStats,
-		TcpStats:   p.TCPStats,
+		TimeWindow:      window,
+		SkipStats:       p.SkipStats,
+		TcpStats:        p.TCPStats,
+		PolicyStats:     p.PolicyStats,
+		IncludeQueries:  p.IncludeQueries,
+		ServerName:      p.ServerName,
+		CurrentPodsOnly: p.CurrentPodsOnly,
+		VersionLabel:    p.VersionLabel,
+		ByPort:          p.ByPort,
+		FromIdentity:    p.FromIdentity,
+	}
+
+	switch {
+	case p.MeshedOnly && p.UnmeshedOnly:
+		return nil, errors.New("the meshed and unmeshed only filters are mutually exclusive")
+	case p.MeshedOnly:
+		statRequest.MeshFilter = pb.StatSummaryRequest_MESHED
+	case p.UnmeshedOnly:
+		statRequest.MeshFilter = pb.StatSummaryRequest_UNMESHED
+	}
+
+	if p.SLOTarget != 0 || p.SLOWindow != "" {
+		if p.SLOTarget <= 0 || p.SLOTarget >= 100 {
+			return nil, errors.New("SLO target needs to be a percentage strictly between 0 and 100")
+		}
+		if p.SLOWindow != "" {
+			if _, err := model.ParseDuration(p.SLOWindow); err != nil {
+				return nil, fmt.Errorf("invalid SLO window: %w", err)
+			}
+		}
+		statRequest.Slo = &pb.SloObjective{
+			Target: p.SLOTarget,
+			Window: p.SLOWindow,
+		}
 	}
 
 	if p.ToName != "" || p.ToType != "" || p.ToNamespace != "" {
@@ -225,7 +288,8 @@ func BuildTopRoutesRequest(p TopRoutesRequestParams) (*pb.TopRoutesRequest, erro
 			},
 			LabelSelector: p.LabelSelector,
 		},
-		TimeWindow: window,
+		TimeWindow:  window,
+		TrendPoints: p.TrendPoints,
 	}
 
 	if p.ToName != "" || p.ToType != "" || p.ToNamespace != "" {
@@ -86,6 +86,97 @@ func TestBuildStatSummaryRequest(t *testing.T) {
 		}
 	})
 
+	t.Run("Sets the SLO objective", func(t *testing.T) {
+		req, err := BuildStatSummaryRequest(
+			StatsSummaryRequestParams{
+				StatsBaseRequestParams: StatsBaseRequestParams{
+					ResourceType: "deploy",
+				},
+				SLOTarget: 99.5,
+				SLOWindow: "30d",
+			},
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if req.GetSlo().GetTarget() != 99.5 || req.GetSlo().GetWindow() != "30d" {
+			t.Fatalf("Expected a 99.5%% SLO over 30d, got %v", req.GetSlo())
+		}
+	})
+
+	t.Run("Rejects invalid SLO objectives", func(t *testing.T) {
+		expectations := []struct {
+			target float64
+			window string
+			msg    string
+		}{
+			{100, "", "SLO target needs to be a percentage strictly between 0 and 100"},
+			{0, "1h", "SLO target needs to be a percentage strictly between 0 and 100"},
+			{99.5, "1", `invalid SLO window: not a valid duration string: "1"`},
+		}
+
+		for _, exp := range expectations {
+			_, err := BuildStatSummaryRequest(
+				StatsSummaryRequestParams{
+					StatsBaseRequestParams: StatsBaseRequestParams{
+						ResourceType: "deploy",
+					},
+					SLOTarget: exp.target,
+					SLOWindow: exp.window,
+				},
+			)
+			if err == nil {
+				t.Fatalf("BuildStatSummaryRequest(%v, %s) unexpectedly succeeded, should have returned %s", exp.target, exp.window, exp.msg)
+			}
+			if err.Error() != exp.msg {
+				t.Fatalf("BuildStatSummaryRequest(%v, %s) should have returned: %s but got unexpected message: %s", exp.target, exp.window, exp.msg, err)
+			}
+		}
+	})
+
+	t.Run("Maps the mesh filters", func(t *testing.T) {
+		expectations := []struct {
+			meshedOnly   bool
+			unmeshedOnly bool
+			filter       pb.StatSummaryRequest_MeshFilter
+		}{
+			{false, false, pb.StatSummaryRequest_ALL},
+			{true, false, pb.StatSummaryRequest_MESHED},
+			{false, true, pb.StatSummaryRequest_UNMESHED},
+		}
+
+		for _, exp := range expectations {
+			req, err := BuildStatSummaryRequest(
+				StatsSummaryRequestParams{
+					StatsBaseRequestParams: StatsBaseRequestParams{
+						ResourceType: "deploy",
+					},
+					MeshedOnly:   exp.meshedOnly,
+					UnmeshedOnly: exp.unmeshedOnly,
+				},
+			)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if req.GetMeshFilter() != exp.filter {
+				t.Fatalf("Expected mesh filter %s, got %s", exp.filter, req.GetMeshFilter())
+			}
+		}
+
+		_, err := BuildStatSummaryRequest(
+			StatsSummaryRequestParams{
+				StatsBaseRequestParams: StatsBaseRequestParams{
+					ResourceType: "deploy",
+				},
+				MeshedOnly:   true,
+				UnmeshedOnly: true,
+			},
+		)
+		if err == nil {
+			t.Fatal("Expected an error with both mesh filters")
+		}
+	})
+
 	t.Run("Rejects invalid Kubernetes resource types", func(t *testing.T) {
 		expectations := map[string]string{
 			"foo": "cannot find Kubernetes canonical name from friendly name [foo]",
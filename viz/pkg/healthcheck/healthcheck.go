@@ -284,6 +284,17 @@ func (hc *HealthChecker) VizCategory() *healthcheck.Category {
 				errsStr := strings.Join(errs, "\n    ")
 				return errors.New(errsStr)
 			}),
+		*healthcheck.NewChecker("proxy metrics have the labels expected by the viz extension").
+			WithHintAnchor("l5d-viz-metrics-labels").
+			Warning().
+			WithCheck(func(ctx context.Context) error {
+				rsp, err := hc.vizAPIClient.LabelCompatibility(ctx, &pb.LabelCompatibilityRequest{})
+				if err != nil {
+					return err
+				}
+
+				return validateLabelCompatibility(rsp.GetReports())
+			}),
 	}, true)
 }
 
@@ -343,6 +354,25 @@ func (hc *HealthChecker) getDataPlanePodsFromVizAPI(ctx context.Context) ([]*pb.
 	return pods, nil
 }
 
+func validateLabelCompatibility(reports []*pb.LabelCompatibilityResponse_ProxyVersionReport) error {
+	errs := []string{}
+	for _, report := range reports {
+		missing := make([]string, len(report.GetMissingLabels()))
+		for i, label := range report.GetMissingLabels() {
+			missing[i] = fmt.Sprintf("%s{%s}", label.GetMetric(), label.GetLabel())
+		}
+		if len(missing) > 0 {
+			errs = append(errs, fmt.Sprintf("proxy version %s (%d pods) is missing: %s", report.GetProxyVersion(), report.GetPodCount(), strings.Join(missing, ", ")))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("some proxies don't report the labels expected by the viz extension, some stats columns may be empty; consider upgrading the data plane:\n    %s", strings.Join(errs, "\n    "))
+	}
+
+	return nil
+}
+
 func validateDataPlanePodReporting(pods []*pb.Pod) error {
 	notInPrometheus := []string{}
 
@@ -3,6 +3,7 @@ package labels
 import (
 	"strconv"
 
+	"github.com/linkerd/linkerd2/pkg/k8s"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -14,12 +15,21 @@ const (
 	// enabled on a pod.
 	VizTapEnabled = VizAnnotationsPrefix + "/tap-enabled"
 
-	// VizTapDisabled can be used to disable tap on the injected proxy.
+	// VizTapDisabled can be used to disable tap on the injected proxy. It can
+	// be set on a namespace or on a workload's pod template, the latter taking
+	// precedence: a workload annotated with "false" is tappable even when its
+	// namespace disables tap.
 	VizTapDisabled = VizAnnotationsPrefix + "/disable-tap"
 
 	// VizExternalPrometheus is only set on the namespace by the install
 	// when a external prometheus is being used
 	VizExternalPrometheus = VizAnnotationsPrefix + "/external-prometheus"
+
+	// VizIngressClass can be set on the pods of an ingress controller, to
+	// have their traffic reported by `linkerd viz stat gateway` under the
+	// given ingress class. It's only needed for ingress controllers that
+	// aren't recognized from their labels.
+	VizIngressClass = VizAnnotationsPrefix + "/ingress-class"
 )
 
 // IsTapEnabled returns true if a pod has an annotation indicating that tap
@@ -35,6 +45,32 @@ func IsTapEnabled(pod *corev1.Pod) bool {
 	return false
 }
 
+// TapDisabledBy returns the type of the resource whose annotation disables
+// tap on the pod, "pod" or "namespace", or an empty string when tap isn't
+// disabled. The pod's annotation takes precedence over its namespace's.
+func TapDisabledBy(namespace *corev1.Namespace, pod *corev1.Pod) string {
+	if disabled, ok := parseTapDisabled(pod.GetAnnotations()); ok {
+		if disabled {
+			return k8s.Pod
+		}
+		return ""
+	}
+	if disabled, ok := parseTapDisabled(namespace.GetAnnotations()); ok && disabled {
+		return k8s.Namespace
+	}
+	return ""
+}
+
+// parseTapDisabled returns the value of the annotation disabling tap, and
+// whether it's set to a valid boolean
+func parseTapDisabled(annotations map[string]string) (bool, bool) {
+	disabled, err := strconv.ParseBool(annotations[VizTapDisabled])
+	if err != nil {
+		return false, false
+	}
+	return disabled, true
+}
+
 // IsTapDisabled returns true if a namespace or pod has an annotation for
 // explicitly disabling tap
 func IsTapDisabled(obj interface{}) bool {
@@ -0,0 +1,285 @@
+package api
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/linkerd/linkerd2/pkg/addr"
+	metricsPb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	pkgUtil "github.com/linkerd/linkerd2/viz/pkg/util"
+	tapPb "github.com/linkerd/linkerd2/viz/tap/gen/tap"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultAggregateInterval = 1 * time.Second
+
+	// latencySamples is the number of most recent latencies per route the
+	// percentiles are computed over
+	latencySamples = 1000
+
+	// maxRoutes bounds the number of routes summarized per interval, as each
+	// distinct path is a route
+	maxRoutes = 1000
+
+	// maxPendingRequests bounds the number of requests waiting for their
+	// response, as the proxies stop reporting the events of a request once
+	// their tap limit is reached
+	maxPendingRequests = 10000
+
+	// pendingRequestTTL is how long a request waits for its response before
+	// it's dropped, so that the requests whose events were lost don't fill
+	// up the pending requests
+	pendingRequestTTL = 1 * time.Minute
+)
+
+type streamID struct {
+	src    string
+	dst    string
+	stream uint64
+}
+
+type pendingRequest struct {
+	reqInit *tapPb.TapEvent_Http_RequestInit
+	rspInit *tapPb.TapEvent_Http_ResponseInit
+	// added is when the request init event was received
+	added time.Time
+}
+
+type routeKey struct {
+	method string
+	path   string
+}
+
+type routeSummary struct {
+	method    *metricsPb.HttpMethod
+	path      string
+	count     uint64
+	successes uint64
+	// ring buffer of the latencies of the most recent requests
+	latencies []time.Duration
+	next      int
+}
+
+// aggregator correlates the events of the tapped requests and summarizes the
+// requests completed during each interval by method and path
+type aggregator struct {
+	pending map[streamID]pendingRequest
+	routes  map[routeKey]*routeSummary
+	now     func() time.Time
+}
+
+// eventStreamID returns the ID of the HTTP stream an event is about
+func eventStreamID(event *tapPb.TapEvent) streamID {
+	id := streamID{
+		src: addr.PublicAddressToString(event.GetSource()),
+		dst: addr.PublicAddressToString(event.GetDestination()),
+	}
+	switch ev := event.GetHttp().GetEvent().(type) {
+	case *tapPb.TapEvent_Http_RequestInit_:
+		id.stream = ev.RequestInit.GetId().GetStream()
+	case *tapPb.TapEvent_Http_ResponseInit_:
+		id.stream = ev.ResponseInit.GetId().GetStream()
+	case *tapPb.TapEvent_Http_ResponseEnd_:
+		id.stream = ev.ResponseEnd.GetId().GetStream()
+	}
+	return id
+}
+
+func newAggregator() *aggregator {
+	return &aggregator{
+		pending: make(map[streamID]pendingRequest),
+		routes:  make(map[routeKey]*routeSummary),
+		now:     time.Now,
+	}
+}
+
+// aggregateInterval returns the interval at which the summaries of an
+// aggregating tap are sent
+func aggregateInterval(aggregate *tapPb.TapByResourceRequest_Aggregate) (time.Duration, error) {
+	if aggregate.GetInterval() == nil {
+		return defaultAggregateInterval, nil
+	}
+	interval, err := ptypes.Duration(aggregate.GetInterval())
+	if err != nil || interval <= 0 {
+		return 0, status.Error(codes.InvalidArgument, "aggregate interval must be a positive duration")
+	}
+	return interval, nil
+}
+
+// sendSummaries aggregates the events of the taps and sends a summary of
+// them at every interval, until the stream is closed
+func sendSummaries(stream tapPb.Tap_TapByResourceServer, interval time.Duration, events <-chan *tapPb.TapEvent) error {
+	agg := newAggregator()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event := <-events:
+			agg.add(event)
+		case <-ticker.C:
+			agg.expire()
+			err := stream.Send(agg.summary())
+			if err != nil {
+				return pkgUtil.GRPCError(err)
+			}
+		}
+	}
+}
+
+func (a *aggregator) add(event *tapPb.TapEvent) {
+	id := eventStreamID(event)
+
+	switch ev := event.GetHttp().GetEvent().(type) {
+	case *tapPb.TapEvent_Http_RequestInit_:
+		if len(a.pending) >= maxPendingRequests {
+			a.expire()
+		}
+		if len(a.pending) >= maxPendingRequests {
+			log.Debugf("Too many pending requests, skipping stream %d", id.stream)
+			return
+		}
+		a.pending[id] = pendingRequest{reqInit: ev.RequestInit, added: a.now()}
+
+	case *tapPb.TapEvent_Http_ResponseInit_:
+		if req, ok := a.pending[id]; ok {
+			req.rspInit = ev.ResponseInit
+			a.pending[id] = req
+		}
+
+	case *tapPb.TapEvent_Http_ResponseEnd_:
+		req, ok := a.pending[id]
+		if !ok {
+			return
+		}
+		delete(a.pending, id)
+
+		latency, err := ptypes.Duration(ev.ResponseEnd.GetSinceRequestInit())
+		if err != nil {
+			log.Debugf("Error parsing duration %v: %s", ev.ResponseEnd.GetSinceRequestInit(), err)
+			return
+		}
+		a.record(req.reqInit, latency, isSuccess(req.rspInit, ev.ResponseEnd))
+	}
+}
+
+// expire drops the requests that have been waiting for their response for
+// longer than pendingRequestTTL
+func (a *aggregator) expire() {
+	deadline := a.now().Add(-pendingRequestTTL)
+	for id, req := range a.pending {
+		if req.added.Before(deadline) {
+			delete(a.pending, id)
+		}
+	}
+}
+
+func (a *aggregator) record(reqInit *tapPb.TapEvent_Http_RequestInit, latency time.Duration, success bool) {
+	key := routeKey{
+		method: pkgUtil.HTTPMethodToString(reqInit.GetMethod()),
+		path:   reqInit.GetPath(),
+	}
+	route, ok := a.routes[key]
+	if !ok {
+		if len(a.routes) >= maxRoutes {
+			log.Debugf("Too many routes, skipping %s %s", key.method, key.path)
+			return
+		}
+		route = &routeSummary{
+			method: reqInit.GetMethod(),
+			path:   reqInit.GetPath(),
+		}
+		a.routes[key] = route
+	}
+
+	route.count++
+	if success {
+		route.successes++
+	}
+	if len(route.latencies) < latencySamples {
+		route.latencies = append(route.latencies, latency)
+	} else {
+		route.latencies[route.next] = latency
+	}
+	route.next = (route.next + 1) % latencySamples
+}
+
+// summary returns the event summarizing the requests completed since the
+// previous summary, sorted by path and method, and starts a new interval
+func (a *aggregator) summary() *tapPb.TapEvent {
+	keys := make([]routeKey, 0, len(a.routes))
+	for key := range a.routes {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].path != keys[j].path {
+			return keys[i].path < keys[j].path
+		}
+		return keys[i].method < keys[j].method
+	})
+
+	routes := make([]*tapPb.TapEvent_Summary_Route, len(keys))
+	for i, key := range keys {
+		route := a.routes[key]
+		latencies := append([]time.Duration{}, route.latencies...)
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		routes[i] = &tapPb.TapEvent_Summary_Route{
+			Method:     route.method,
+			Path:       route.path,
+			Count:      route.count,
+			Successes:  route.successes,
+			LatencyP50: ptypes.DurationProto(percentile(latencies, 0.5)),
+			LatencyP99: ptypes.DurationProto(percentile(latencies, 0.99)),
+		}
+	}
+	a.routes = make(map[routeKey]*routeSummary)
+
+	return &tapPb.TapEvent{
+		Event: &tapPb.TapEvent_Summary_{
+			Summary: &tapPb.TapEvent_Summary{
+				Routes: routes,
+			},
+		},
+	}
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies
+func percentile(sorted []time.Duration, quantile float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(quantile * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// isSuccess classifies a request the same way `linkerd viz top` does: HTTP
+// 5xx responses, resets and gRPC server errors are failures
+func isSuccess(rspInit *tapPb.TapEvent_Http_ResponseInit, rspEnd *tapPb.TapEvent_Http_ResponseEnd) bool {
+	if rspInit.GetHttpStatus() >= 500 {
+		return false
+	}
+	switch eos := rspEnd.GetEos().GetEnd().(type) {
+	case *metricsPb.Eos_GrpcStatusCode:
+		switch codes.Code(eos.GrpcStatusCode) {
+		case codes.Unknown,
+			codes.DeadlineExceeded,
+			codes.Internal,
+			codes.Unavailable,
+			codes.DataLoss:
+			return false
+		}
+	case *metricsPb.Eos_ResetErrorCode:
+		return false
+	}
+	return true
+}
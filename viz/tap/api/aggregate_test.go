@@ -0,0 +1,198 @@
+package api
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	netPb "github.com/linkerd/linkerd2/controller/gen/common/net"
+	metricsPb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	pkgUtil "github.com/linkerd/linkerd2/viz/pkg/util"
+	tapPb "github.com/linkerd/linkerd2/viz/tap/gen/tap"
+	"google.golang.org/grpc/codes"
+)
+
+// requestEvents returns the events of a tapped request
+func requestEvents(stream uint64, method metricsPb.HttpMethod_Registered, path string, httpStatus uint32, eos *metricsPb.Eos, latency time.Duration) []*tapPb.TapEvent {
+	event := func(ev *tapPb.TapEvent_Http) *tapPb.TapEvent {
+		return &tapPb.TapEvent{
+			Source:      &netPb.TcpAddress{Ip: &netPb.IPAddress{Ip: &netPb.IPAddress_Ipv4{Ipv4: 1}}, Port: 1234},
+			Destination: &netPb.TcpAddress{Ip: &netPb.IPAddress{Ip: &netPb.IPAddress_Ipv4{Ipv4: 2}}, Port: 8080},
+			Event:       &tapPb.TapEvent_Http_{Http: ev},
+		}
+	}
+	id := &tapPb.TapEvent_Http_StreamId{Base: 1, Stream: stream}
+
+	return []*tapPb.TapEvent{
+		event(&tapPb.TapEvent_Http{
+			Event: &tapPb.TapEvent_Http_RequestInit_{
+				RequestInit: &tapPb.TapEvent_Http_RequestInit{
+					Id:     id,
+					Method: &metricsPb.HttpMethod{Type: &metricsPb.HttpMethod_Registered_{Registered: method}},
+					Path:   path,
+				},
+			},
+		}),
+		event(&tapPb.TapEvent_Http{
+			Event: &tapPb.TapEvent_Http_ResponseInit_{
+				ResponseInit: &tapPb.TapEvent_Http_ResponseInit{
+					Id:         id,
+					HttpStatus: httpStatus,
+				},
+			},
+		}),
+		event(&tapPb.TapEvent_Http{
+			Event: &tapPb.TapEvent_Http_ResponseEnd_{
+				ResponseEnd: &tapPb.TapEvent_Http_ResponseEnd{
+					Id:               id,
+					SinceRequestInit: ptypes.DurationProto(latency),
+					Eos:              eos,
+				},
+			},
+		}),
+	}
+}
+
+func TestAggregator(t *testing.T) {
+	grpcStatus := func(code codes.Code) *metricsPb.Eos {
+		return &metricsPb.Eos{End: &metricsPb.Eos_GrpcStatusCode{GrpcStatusCode: uint32(code)}}
+	}
+
+	agg := newAggregator()
+	events := [][]*tapPb.TapEvent{}
+	for i := 1; i <= 100; i++ {
+		events = append(events, requestEvents(uint64(i), metricsPb.HttpMethod_GET, "/api/list", 200, nil, time.Duration(i)*time.Millisecond))
+	}
+	events = append(events,
+		requestEvents(101, metricsPb.HttpMethod_GET, "/api/vote", 500, nil, time.Millisecond),
+		requestEvents(102, metricsPb.HttpMethod_POST, "/api/vote", 200, grpcStatus(codes.OK), 2*time.Millisecond),
+		requestEvents(103, metricsPb.HttpMethod_POST, "/api/vote", 200, grpcStatus(codes.Unavailable), 3*time.Millisecond),
+		requestEvents(104, metricsPb.HttpMethod_POST, "/api/vote", 200, &metricsPb.Eos{End: &metricsPb.Eos_ResetErrorCode{ResetErrorCode: 2}}, 4*time.Millisecond),
+	)
+	for _, request := range events {
+		for _, event := range request {
+			agg.add(event)
+		}
+	}
+	// a request still waiting for its response isn't summarized
+	agg.add(requestEvents(105, metricsPb.HttpMethod_GET, "/api/list", 200, nil, time.Second)[0])
+
+	expected := []struct {
+		method    string
+		path      string
+		count     uint64
+		successes uint64
+		p50       time.Duration
+		p99       time.Duration
+	}{
+		{"GET", "/api/list", 100, 100, 50 * time.Millisecond, 99 * time.Millisecond},
+		{"GET", "/api/vote", 1, 0, time.Millisecond, time.Millisecond},
+		{"POST", "/api/vote", 3, 1, 3 * time.Millisecond, 4 * time.Millisecond},
+	}
+
+	routes := agg.summary().GetSummary().GetRoutes()
+	if len(routes) != len(expected) {
+		t.Fatalf("Expected %d routes, got %d", len(expected), len(routes))
+	}
+	for i, exp := range expected {
+		exp := exp // pin
+		route := routes[i]
+		t.Run(fmt.Sprintf("%s %s", exp.method, exp.path), func(t *testing.T) {
+			if method := pkgUtil.HTTPMethodToString(route.GetMethod()); method != exp.method || route.GetPath() != exp.path {
+				t.Fatalf("Expected route %s %s, got %s %s", exp.method, exp.path, method, route.GetPath())
+			}
+			if route.GetCount() != exp.count || route.GetSuccesses() != exp.successes {
+				t.Fatalf("Expected %d/%d successes, got %d/%d", exp.successes, exp.count, route.GetSuccesses(), route.GetCount())
+			}
+			p50, _ := ptypes.Duration(route.GetLatencyP50())
+			p99, _ := ptypes.Duration(route.GetLatencyP99())
+			if p50 != exp.p50 || p99 != exp.p99 {
+				t.Fatalf("Expected p50 %s and p99 %s, got %s and %s", exp.p50, exp.p99, p50, p99)
+			}
+		})
+	}
+}
+
+func TestAggregatorLatencySamples(t *testing.T) {
+	agg := newAggregator()
+	// the slow requests fall out of the samples as faster ones complete
+	for i := 0; i < latencySamples; i++ {
+		for _, event := range requestEvents(uint64(i), metricsPb.HttpMethod_GET, "/", 200, nil, time.Second) {
+			agg.add(event)
+		}
+	}
+	for i := latencySamples; i < 2*latencySamples; i++ {
+		for _, event := range requestEvents(uint64(i), metricsPb.HttpMethod_GET, "/", 200, nil, time.Millisecond) {
+			agg.add(event)
+		}
+	}
+
+	route := agg.summary().GetSummary().GetRoutes()[0]
+	if route.GetCount() != 2*latencySamples {
+		t.Fatalf("Expected %d requests, got %d", 2*latencySamples, route.GetCount())
+	}
+	p99, _ := ptypes.Duration(route.GetLatencyP99())
+	if p99 != time.Millisecond {
+		t.Fatalf("Expected p99 of 1ms, got %s", p99)
+	}
+}
+
+func TestAggregatorExpiresPendingRequests(t *testing.T) {
+	now := time.Now()
+	agg := newAggregator()
+	agg.now = func() time.Time { return now }
+
+	// requests whose response events were lost
+	for i := 0; i < maxPendingRequests; i++ {
+		agg.add(requestEvents(uint64(i), metricsPb.HttpMethod_GET, "/", 200, nil, time.Millisecond)[0])
+	}
+
+	// the pending requests are full until the lost requests expire
+	request := requestEvents(maxPendingRequests, metricsPb.HttpMethod_GET, "/", 200, nil, time.Millisecond)
+	agg.add(request[0])
+	if len(agg.pending) != maxPendingRequests {
+		t.Fatalf("Expected %d pending requests, got %d", maxPendingRequests, len(agg.pending))
+	}
+
+	now = now.Add(pendingRequestTTL + time.Second)
+	for _, event := range request {
+		agg.add(event)
+	}
+	if len(agg.pending) != 0 {
+		t.Fatalf("Expected no pending requests, got %d", len(agg.pending))
+	}
+	routes := agg.summary().GetSummary().GetRoutes()
+	if len(routes) != 1 || routes[0].GetCount() != 1 {
+		t.Fatalf("Expected the request to be summarized, got %v", routes)
+	}
+}
+
+func TestAggregatorSummarizesIntervals(t *testing.T) {
+	agg := newAggregator()
+	for _, event := range requestEvents(1, metricsPb.HttpMethod_GET, "/api/list", 200, nil, time.Millisecond) {
+		agg.add(event)
+	}
+	if routes := agg.summary().GetSummary().GetRoutes(); len(routes) != 1 || routes[0].GetCount() != 1 {
+		t.Fatalf("Expected the request to be summarized, got %v", routes)
+	}
+
+	// the next summary only holds the requests completed since
+	for _, event := range requestEvents(2, metricsPb.HttpMethod_GET, "/api/vote", 200, nil, time.Millisecond) {
+		agg.add(event)
+	}
+	routes := agg.summary().GetSummary().GetRoutes()
+	if len(routes) != 1 || routes[0].GetPath() != "/api/vote" || routes[0].GetCount() != 1 {
+		t.Fatalf("Expected only the last request to be summarized, got %v", routes)
+	}
+
+	// the routes are bounded
+	for i := 0; i <= maxRoutes; i++ {
+		for _, event := range requestEvents(uint64(i), metricsPb.HttpMethod_GET, fmt.Sprintf("/api/%d", i), 200, nil, time.Millisecond) {
+			agg.add(event)
+		}
+	}
+	if routes := agg.summary().GetSummary().GetRoutes(); len(routes) != maxRoutes {
+		t.Fatalf("Expected %d routes, got %d", maxRoutes, len(routes))
+	}
+}
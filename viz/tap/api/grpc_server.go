@@ -42,6 +42,7 @@ type GRPCTapServer struct {
 	k8sAPI              *k8s.API
 	controllerNamespace string
 	trustDomain         string
+	limiter             *tapLimiter
 }
 
 var (
@@ -62,7 +63,7 @@ func (s *GRPCTapServer) TapByResource(req *tapPb.TapByResourceRequest, stream ta
 		return status.Error(codes.InvalidArgument, "TapByResource received nil target ResourceSelection")
 	}
 	res := req.GetTarget().GetResource()
-	labelSelector, err := getLabelSelector(req)
+	labelSelector, err := getLabelSelector(req.GetTarget())
 	if err != nil {
 		return err
 	}
@@ -72,6 +73,13 @@ func (s *GRPCTapServer) TapByResource(req *tapPb.TapByResourceRequest, stream ta
 	if req.GetMaxRps() == 0.0 {
 		req.MaxRps = defaultMaxRps
 	}
+	var interval time.Duration
+	if req.GetAggregate() != nil {
+		interval, err = aggregateInterval(req.GetAggregate())
+		if err != nil {
+			return err
+		}
+	}
 
 	objects, err := s.k8sAPI.GetObjects(res.GetNamespace(), res.GetType(), res.GetName(), labelSelector)
 	if err != nil {
@@ -120,9 +128,15 @@ func (s *GRPCTapServer) TapByResource(req *tapPb.TapByResourceRequest, stream ta
 		return status.Errorf(codes.NotFound, errs.String())
 	}
 
+	release, err := s.limiter.acquire(pods)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	log.Infof("Tapping %d pods for target: %s", len(pods), res.String())
 
-	events := make(chan *tapPb.TapEvent)
+	tapEvents := make(chan *tapPb.TapEvent)
 
 	// divide the rps evenly between all pods to tap
 	rpsPerPod := req.GetMaxRps() / float32(len(pods))
@@ -130,10 +144,17 @@ func (s *GRPCTapServer) TapByResource(req *tapPb.TapByResourceRequest, stream ta
 		rpsPerPod = 1
 	}
 
-	match, err := makeByResourceMatch(req.GetMatch())
+	match, responseMatches, err := makeByResourceMatch(req.GetMatch())
 	if err != nil {
 		return pkgUtil.GRPCError(err)
 	}
+	var filter *responseFilter
+	if len(responseMatches) > 0 {
+		filter, err = newResponseFilter(responseMatches)
+		if err != nil {
+			return err
+		}
+	}
 
 	extract := &proxy.ObserveRequest_Extract{}
 
@@ -158,7 +179,16 @@ func (s *GRPCTapServer) TapByResource(req *tapPb.TapByResourceRequest, stream ta
 		ctx = metadata.AppendToOutgoingContext(ctx, pkgK8s.RequireIDHeader, name)
 
 		// initiate a tap on the pod
-		go s.tapProxy(ctx, rpsPerPod, match, extract, pod.Status.PodIP, events)
+		go s.tapProxy(ctx, rpsPerPod, match, extract, pod.Status.PodIP, tapEvents)
+	}
+
+	var events <-chan *tapPb.TapEvent = tapEvents
+	if filter != nil {
+		events = filter.filterEvents(stream.Context(), tapEvents)
+	}
+
+	if req.GetAggregate() != nil {
+		return sendSummaries(stream, interval, events)
 	}
 
 	// read events from the taps and send them back
@@ -175,14 +205,17 @@ func (s *GRPCTapServer) TapByResource(req *tapPb.TapByResourceRequest, stream ta
 	}
 }
 
-func makeByResourceMatch(match *tapPb.TapByResourceRequest_Match) (*proxy.ObserveRequest_Match, error) {
+// makeByResourceMatch returns the match sent to the proxies, along with the
+// response matches, which are evaluated by the tap server
+func makeByResourceMatch(match *tapPb.TapByResourceRequest_Match) (*proxy.ObserveRequest_Match, []*tapPb.TapByResourceRequest_Match_Response, error) {
 	// TODO: for now assume it's always a single, flat `All` match list
 	seq := match.GetAll()
 	if seq == nil {
-		return nil, status.Errorf(codes.Unimplemented, "unexpected match specified: %+v", match)
+		return nil, nil, status.Errorf(codes.Unimplemented, "unexpected match specified: %+v", match)
 	}
 
 	matches := []*proxy.ObserveRequest_Match{}
+	var responseMatches []*tapPb.TapByResourceRequest_Match_Response
 
 	for _, reqMatch := range seq.Matches {
 		switch typed := reqMatch.Match.(type) {
@@ -237,7 +270,7 @@ func makeByResourceMatch(match *tapPb.TapByResourceRequest_Match) (*proxy.Observ
 					},
 				}
 			default:
-				return nil, status.Errorf(codes.Unimplemented, "unknown HTTP match type: %v", httpTyped)
+				return nil, nil, status.Errorf(codes.Unimplemented, "unknown HTTP match type: %v", httpTyped)
 			}
 
 			matches = append(matches, &proxy.ObserveRequest_Match{
@@ -246,8 +279,11 @@ func makeByResourceMatch(match *tapPb.TapByResourceRequest_Match) (*proxy.Observ
 				},
 			})
 
+		case *tapPb.TapByResourceRequest_Match_Response_:
+			responseMatches = append(responseMatches, typed.Response)
+
 		default:
-			return nil, status.Errorf(codes.Unimplemented, "unknown match type: %v", typed)
+			return nil, nil, status.Errorf(codes.Unimplemented, "unknown match type: %v", typed)
 		}
 	}
 
@@ -257,7 +293,7 @@ func makeByResourceMatch(match *tapPb.TapByResourceRequest_Match) (*proxy.Observ
 				Matches: matches,
 			},
 		},
-	}, nil
+	}, responseMatches, nil
 }
 
 // TODO: factor out with `promLabels` in public-api
@@ -526,23 +562,30 @@ func (s *GRPCTapServer) translateEvent(ctx context.Context, orig *proxy.TapEvent
 	return ev
 }
 
-// NewGrpcTapServer creates a new gRPC Tap server
+// NewGrpcTapServer creates a new gRPC Tap server. When positive, maxTaps
+// bounds the number of concurrent taps and maxTapsPerPod the number of
+// concurrent taps against a single pod; taps past these limits are rejected
+// with a ResourceExhausted status.
 func NewGrpcTapServer(
 	tapPort uint,
 	controllerNamespace string,
 	trustDomain string,
+	maxTaps int,
+	maxTapsPerPod int,
 	k8sAPI *k8s.API,
 ) *GRPCTapServer {
 	k8sAPI.Pod().Informer().AddIndexers(cache.Indexers{ipIndex: indexByIP})
 	k8sAPI.Node().Informer().AddIndexers(cache.Indexers{ipIndex: indexByIP})
 
-	return newGRPCTapServer(tapPort, controllerNamespace, trustDomain, k8sAPI)
+	return newGRPCTapServer(tapPort, controllerNamespace, trustDomain, maxTaps, maxTapsPerPod, k8sAPI)
 }
 
 func newGRPCTapServer(
 	tapPort uint,
 	controllerNamespace string,
 	trustDomain string,
+	maxTaps int,
+	maxTapsPerPod int,
 	k8sAPI *k8s.API,
 ) *GRPCTapServer {
 	srv := &GRPCTapServer{
@@ -550,6 +593,7 @@ func newGRPCTapServer(
 		k8sAPI:              k8sAPI,
 		controllerNamespace: controllerNamespace,
 		trustDomain:         trustDomain,
+		limiter:             newTapLimiter(maxTaps, maxTapsPerPod),
 	}
 
 	s := prometheus.NewGrpcServer()
@@ -672,9 +716,9 @@ func (s *GRPCTapServer) resourceForIP(ip *netPb.IPAddress) (runtime.Object, erro
 	return singleRunningPod, nil
 }
 
-func getLabelSelector(req *tapPb.TapByResourceRequest) (labels.Selector, error) {
+func getLabelSelector(target *metricsPb.ResourceSelection) (labels.Selector, error) {
 	labelSelector := labels.Everything()
-	if s := req.GetTarget().GetLabelSelector(); s != "" {
+	if s := target.GetLabelSelector(); s != "" {
 		var err error
 		labelSelector, err = labels.Parse(s)
 		if err != nil {
@@ -7,7 +7,9 @@ import (
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	proxy "github.com/linkerd/linkerd2-proxy-api/go/tap"
 	"github.com/linkerd/linkerd2/controller/api/util"
 	"github.com/linkerd/linkerd2/controller/k8s"
@@ -57,6 +59,22 @@ func TestTapByResource(t *testing.T) {
 			k8sRes: []string{},
 			req:    &tapPb.TapByResourceRequest{},
 		},
+		{
+			err:    status.Error(codes.InvalidArgument, "aggregate interval must be a positive duration"),
+			k8sRes: []string{},
+			req: &tapPb.TapByResourceRequest{
+				Target: &metricsPb.ResourceSelection{
+					Resource: &metricsPb.Resource{
+						Namespace: "emojivoto",
+						Type:      pkgK8s.Pod,
+						Name:      "emojivoto-meshed",
+					},
+				},
+				Aggregate: &tapPb.TapByResourceRequest_Aggregate{
+					Interval: ptypes.DurationProto(-time.Second),
+				},
+			},
+		},
 		{
 			err: status.Errorf(codes.Unimplemented, "unexpected match specified: any:{}"),
 			k8sRes: []string{`
@@ -425,7 +443,7 @@ status:
 				t.Fatalf("Invalid port: %s", port)
 			}
 
-			fakeGrpcServer := newGRPCTapServer(uint(tapPort), "controller-ns", "cluster.local", k8sAPI)
+			fakeGrpcServer := newGRPCTapServer(uint(tapPort), "controller-ns", "cluster.local", 0, 0, k8sAPI)
 
 			k8sAPI.Sync(nil)
 
@@ -664,7 +682,7 @@ status:
 			if err != nil {
 				t.Fatalf("NewFakeAPI returned an error: %s", err)
 			}
-			s := NewGrpcTapServer(4190, "controller-ns", "cluster.local", k8sAPI)
+			s := NewGrpcTapServer(4190, "controller-ns", "cluster.local", 0, 0, k8sAPI)
 			k8sAPI.Sync(nil)
 
 			labels := make(map[string]string)
@@ -96,6 +96,7 @@ func initRouter(h *handler) *httprouter.Router {
 
 		router.GET(route, handleRoot)
 		router.POST(route+"/tap", h.handleTap)
+		router.POST(route+"/tappable", h.handleTappable)
 	}
 
 	return router
@@ -104,6 +105,47 @@ func initRouter(h *handler) *httprouter.Router {
 // POST /apis/tap.linkerd.io/v1alpha1/watch/namespaces/:namespace/tap
 // POST /apis/tap.linkerd.io/v1alpha1/watch/namespaces/:namespace/:resource/:name/tap
 func (h *handler) handleTap(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	if !h.authorize(w, req, p) {
+		return
+	}
+
+	tapReq := pb.TapByResourceRequest{}
+	err := protohttp.HTTPRequestToProto(req, &tapReq)
+	if err != nil {
+		err = fmt.Errorf("Error decoding Tap Request proto: %s", err)
+		h.log.Error(err)
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+
+	url := pkg.TapReqToURL(&tapReq)
+	if url != req.URL.Path {
+		err = fmt.Errorf("tap request body did not match APIServer URL: %+v != %+v", url, req.URL.Path)
+		h.log.Error(err)
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+
+	flushableWriter, err := protohttp.NewStreamingWriter(w)
+	if err != nil {
+		h.log.Error(err)
+		protohttp.WriteErrorToHTTPResponse(w, err)
+		return
+	}
+
+	serverStream := serverStream{w: flushableWriter, req: req, log: h.log}
+	err = h.grpcTapServer.TapByResource(&tapReq, &serverStream)
+	if err != nil {
+		h.log.Error(err)
+		protohttp.WriteErrorToHTTPResponse(flushableWriter, err)
+		return
+	}
+}
+
+// authorize checks that the user is allowed to tap the resource of the
+// request path, rendering an error otherwise. The tappability of a resource
+// is subject to the same authorization as tapping it.
+func (h *handler) authorize(w http.ResponseWriter, req *http.Request, p httprouter.Params) bool {
 	namespace := p.ByName("namespace")
 	name := p.ByName("name")
 	resource := ""
@@ -117,7 +159,7 @@ func (h *handler) handleTap(w http.ResponseWriter, req *http.Request, p httprout
 		err := fmt.Errorf("invalid path: %s", req.URL.Path)
 		h.log.Error(err)
 		renderJSONError(w, err, http.StatusBadRequest)
-		return
+		return false
 	}
 
 	h.log.Debugf("SubjectAccessReview: namespace: %s, resource: %s, name: %s, user: <%s>, group: <%s>",
@@ -143,38 +185,46 @@ func (h *handler) handleTap(w http.ResponseWriter, req *http.Request, p httprout
 		err = fmt.Errorf("tap authorization failed (%s), visit %s for more information", err, pkg.TapRbacURL)
 		h.log.Error(err)
 		renderJSONError(w, err, http.StatusForbidden)
+		return false
+	}
+
+	return true
+}
+
+// POST /apis/tap.linkerd.io/v1alpha1/watch/namespaces/:namespace/tappable
+// POST /apis/tap.linkerd.io/v1alpha1/watch/namespaces/:namespace/:resource/:name/tappable
+func (h *handler) handleTappable(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	if !h.authorize(w, req, p) {
 		return
 	}
 
-	tapReq := pb.TapByResourceRequest{}
-	err = protohttp.HTTPRequestToProto(req, &tapReq)
+	tappableReq := pb.TappableRequest{}
+	err := protohttp.HTTPRequestToProto(req, &tappableReq)
 	if err != nil {
-		err = fmt.Errorf("Error decoding Tap Request proto: %s", err)
+		err = fmt.Errorf("Error decoding Tappable Request proto: %s", err)
 		h.log.Error(err)
 		protohttp.WriteErrorToHTTPResponse(w, err)
 		return
 	}
 
-	url := pkg.TapReqToURL(&tapReq)
+	url := pkg.TappableReqToURL(&tappableReq)
 	if url != req.URL.Path {
-		err = fmt.Errorf("tap request body did not match APIServer URL: %+v != %+v", url, req.URL.Path)
+		err = fmt.Errorf("tappable request body did not match APIServer URL: %+v != %+v", url, req.URL.Path)
 		h.log.Error(err)
 		protohttp.WriteErrorToHTTPResponse(w, err)
 		return
 	}
 
-	flushableWriter, err := protohttp.NewStreamingWriter(w)
+	rsp, err := h.grpcTapServer.Tappable(req.Context(), &tappableReq)
 	if err != nil {
 		h.log.Error(err)
 		protohttp.WriteErrorToHTTPResponse(w, err)
 		return
 	}
-
-	serverStream := serverStream{w: flushableWriter, req: req, log: h.log}
-	err = h.grpcTapServer.TapByResource(&tapReq, &serverStream)
+	err = protohttp.WriteProtoToHTTPResponse(w, rsp)
 	if err != nil {
 		h.log.Error(err)
-		protohttp.WriteErrorToHTTPResponse(flushableWriter, err)
+		protohttp.WriteErrorToHTTPResponse(w, err)
 		return
 	}
 }
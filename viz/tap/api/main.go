@@ -26,6 +26,10 @@ func Main(args []string) {
 	tapPort := cmd.Uint("tap-port", 4190, "proxy tap port to connect to")
 	disableCommonNames := cmd.Bool("disable-common-names", false, "disable checks for Common Names (for development)")
 	trustDomain := cmd.String("identity-trust-domain", defaultDomain, "configures the name suffix used for identities")
+	maxTaps := cmd.Int("max-concurrent-taps", 0, "Maximum number of concurrent taps served by this replica; past it, taps are rejected (0 means no limit)")
+	maxTapsPerPod := cmd.Int("max-concurrent-taps-per-pod", 0, "Maximum number of concurrent taps against a single pod served by this replica; past it, taps of the pod are rejected (0 means no limit)")
+	cryptoMode := flags.AddCryptoModeFlag(cmd)
+	adminTLS := flags.AddAdminTLSFlags(cmd)
 	traceCollector := flags.AddTraceFlags(cmd)
 	flags.ConfigureAndParse(cmd, args)
 	ctx := context.Background()
@@ -56,8 +60,8 @@ func Main(args []string) {
 			log.Warnf("failed to initialize tracing: %s", err)
 		}
 	}
-	grpcTapServer := NewGrpcTapServer(*tapPort, *apiNamespace, *trustDomain, k8sAPI)
-	apiServer, err := NewServer(ctx, *apiServerAddr, k8sAPI, grpcTapServer, *disableCommonNames)
+	grpcTapServer := NewGrpcTapServer(*tapPort, *apiNamespace, *trustDomain, *maxTaps, *maxTapsPerPod, k8sAPI)
+	apiServer, err := NewServer(ctx, *apiServerAddr, k8sAPI, grpcTapServer, *disableCommonNames, *cryptoMode)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
@@ -65,10 +69,14 @@ func Main(args []string) {
 	go apiServer.Start(ctx)
 
 	adminServer := admin.NewServer(*metricsAddr)
+	adminTLS.CryptoMode = *cryptoMode
+	if err := admin.EnableTLS(ctx, adminServer, adminTLS); err != nil {
+		log.Fatalf("Failed to enable TLS on the admin server: %s", err)
+	}
 
 	go func() {
 		log.Infof("starting admin server on %s", *metricsAddr)
-		adminServer.ListenAndServe()
+		admin.ListenAndServe(adminServer)
 	}()
 
 	<-stop
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	metricsPb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	tapPb "github.com/linkerd/linkerd2/viz/tap/gen/tap"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// responseFilter holds the events of the tapped requests back until their
+// response ends, and only releases the events of the requests whose response
+// matches all of the response matches
+type responseFilter struct {
+	matches []*tapPb.TapByResourceRequest_Match_Response
+	pending map[streamID]pendingEvents
+	now     func() time.Time
+}
+
+type pendingEvents struct {
+	events []*tapPb.TapEvent
+	// added is when the request init event was received
+	added time.Time
+}
+
+func newResponseFilter(matches []*tapPb.TapByResourceRequest_Match_Response) (*responseFilter, error) {
+	for _, match := range matches {
+		switch typed := match.GetMatch().(type) {
+		case *tapPb.TapByResourceRequest_Match_Response_GrpcStatus:
+			if typed.GrpcStatus > uint32(codes.Unauthenticated) {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid gRPC status code: %d", typed.GrpcStatus)
+			}
+		case *tapPb.TapByResourceRequest_Match_Response_Class_:
+			if typed.Class == tapPb.TapByResourceRequest_Match_Response_UNKNOWN {
+				return nil, status.Error(codes.InvalidArgument, "response class must be specified")
+			}
+		default:
+			return nil, status.Errorf(codes.Unimplemented, "unknown response match type: %v", typed)
+		}
+	}
+	return &responseFilter{
+		matches: matches,
+		pending: make(map[streamID]pendingEvents),
+		now:     time.Now,
+	}, nil
+}
+
+// filterEvents forwards the events of the requests with a matching response,
+// until the context is done
+func (f *responseFilter) filterEvents(ctx context.Context, in <-chan *tapPb.TapEvent) <-chan *tapPb.TapEvent {
+	out := make(chan *tapPb.TapEvent)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-in:
+				for _, ev := range f.add(event) {
+					select {
+					case <-ctx.Done():
+						return
+					case out <- ev:
+					}
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// add returns the events to release once event is received: all the events
+// of its request when it ends a matching response, none otherwise
+func (f *responseFilter) add(event *tapPb.TapEvent) []*tapPb.TapEvent {
+	id := eventStreamID(event)
+
+	switch ev := event.GetHttp().GetEvent().(type) {
+	case *tapPb.TapEvent_Http_RequestInit_:
+		if len(f.pending) >= maxPendingRequests {
+			f.expire()
+		}
+		if len(f.pending) >= maxPendingRequests {
+			log.Debugf("Too many pending requests, skipping stream %d", id.stream)
+			return nil
+		}
+		f.pending[id] = pendingEvents{events: []*tapPb.TapEvent{event}, added: f.now()}
+
+	case *tapPb.TapEvent_Http_ResponseInit_:
+		if pending, ok := f.pending[id]; ok {
+			pending.events = append(pending.events, event)
+			f.pending[id] = pending
+		}
+
+	case *tapPb.TapEvent_Http_ResponseEnd_:
+		pending, ok := f.pending[id]
+		if !ok {
+			return nil
+		}
+		delete(f.pending, id)
+		events := pending.events
+
+		var rspInit *tapPb.TapEvent_Http_ResponseInit
+		if len(events) > 1 {
+			rspInit = events[1].GetHttp().GetResponseInit()
+		}
+		if f.matchResponse(rspInit, ev.ResponseEnd) {
+			return append(events, event)
+		}
+	}
+	return nil
+}
+
+// expire drops the requests that have been waiting for their response for
+// longer than pendingRequestTTL
+func (f *responseFilter) expire() {
+	deadline := f.now().Add(-pendingRequestTTL)
+	for id, pending := range f.pending {
+		if pending.added.Before(deadline) {
+			delete(f.pending, id)
+		}
+	}
+}
+
+func (f *responseFilter) matchResponse(rspInit *tapPb.TapEvent_Http_ResponseInit, rspEnd *tapPb.TapEvent_Http_ResponseEnd) bool {
+	for _, match := range f.matches {
+		switch typed := match.GetMatch().(type) {
+		case *tapPb.TapByResourceRequest_Match_Response_GrpcStatus:
+			eos, ok := rspEnd.GetEos().GetEnd().(*metricsPb.Eos_GrpcStatusCode)
+			if !ok || eos.GrpcStatusCode != typed.GrpcStatus {
+				return false
+			}
+		case *tapPb.TapByResourceRequest_Match_Response_Class_:
+			success := typed.Class == tapPb.TapByResourceRequest_Match_Response_SUCCESS
+			if isSuccess(rspInit, rspEnd) != success {
+				return false
+			}
+		}
+	}
+	return true
+}
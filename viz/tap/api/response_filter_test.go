@@ -0,0 +1,110 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	metricsPb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	tapPb "github.com/linkerd/linkerd2/viz/tap/gen/tap"
+	"google.golang.org/grpc/codes"
+)
+
+func TestResponseFilter(t *testing.T) {
+	grpcEos := func(code codes.Code) *metricsPb.Eos {
+		return &metricsPb.Eos{End: &metricsPb.Eos_GrpcStatusCode{GrpcStatusCode: uint32(code)}}
+	}
+	grpcStatus := func(code codes.Code) *tapPb.TapByResourceRequest_Match_Response {
+		return &tapPb.TapByResourceRequest_Match_Response{
+			Match: &tapPb.TapByResourceRequest_Match_Response_GrpcStatus{GrpcStatus: uint32(code)},
+		}
+	}
+	class := func(class tapPb.TapByResourceRequest_Match_Response_Class) *tapPb.TapByResourceRequest_Match_Response {
+		return &tapPb.TapByResourceRequest_Match_Response{
+			Match: &tapPb.TapByResourceRequest_Match_Response_Class_{Class: class},
+		}
+	}
+
+	ok := requestEvents(1, metricsPb.HttpMethod_POST, "/emojivoto.v1.EmojiService/ListAll", 200, grpcEos(codes.OK), time.Millisecond)
+	timedOut := requestEvents(2, metricsPb.HttpMethod_POST, "/emojivoto.v1.EmojiService/ListAll", 200, grpcEos(codes.DeadlineExceeded), time.Second)
+	notFound := requestEvents(3, metricsPb.HttpMethod_GET, "/api/vote", 404, nil, time.Millisecond)
+	failed := requestEvents(4, metricsPb.HttpMethod_GET, "/api/vote", 503, nil, time.Millisecond)
+
+	testCases := []struct {
+		name     string
+		matches  []*tapPb.TapByResourceRequest_Match_Response
+		expected []uint64
+		err      string
+	}{
+		{
+			name:     "gRPC status",
+			matches:  []*tapPb.TapByResourceRequest_Match_Response{grpcStatus(codes.DeadlineExceeded)},
+			expected: []uint64{2},
+		},
+		{
+			name:     "failures",
+			matches:  []*tapPb.TapByResourceRequest_Match_Response{class(tapPb.TapByResourceRequest_Match_Response_FAILURE)},
+			expected: []uint64{2, 4},
+		},
+		{
+			name:     "successes",
+			matches:  []*tapPb.TapByResourceRequest_Match_Response{class(tapPb.TapByResourceRequest_Match_Response_SUCCESS)},
+			expected: []uint64{1, 3},
+		},
+		{
+			name: "all matches must hold",
+			matches: []*tapPb.TapByResourceRequest_Match_Response{
+				grpcStatus(codes.OK),
+				class(tapPb.TapByResourceRequest_Match_Response_FAILURE),
+			},
+		},
+		{
+			name:    "invalid gRPC status",
+			matches: []*tapPb.TapByResourceRequest_Match_Response{grpcStatus(17)},
+			err:     "rpc error: code = InvalidArgument desc = invalid gRPC status code: 17",
+		},
+		{
+			name:    "missing response class",
+			matches: []*tapPb.TapByResourceRequest_Match_Response{class(tapPb.TapByResourceRequest_Match_Response_UNKNOWN)},
+			err:     "rpc error: code = InvalidArgument desc = response class must be specified",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := newResponseFilter(tc.matches)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("Expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			// interleave the events of the requests
+			released := []*tapPb.TapEvent{}
+			for i := 0; i < 3; i++ {
+				for _, events := range [][]*tapPb.TapEvent{ok, timedOut, notFound, failed} {
+					released = append(released, filter.add(events[i])...)
+				}
+			}
+
+			if len(released) != 3*len(tc.expected) {
+				t.Fatalf("Expected %d events, got %d: %v", 3*len(tc.expected), len(released), released)
+			}
+			for i, stream := range tc.expected {
+				for j := 0; j < 3; j++ {
+					id := eventStreamID(released[3*i+j])
+					if id.stream != stream {
+						t.Fatalf("Expected the events of stream %d, got %v", stream, released[3*i:3*i+3])
+					}
+				}
+			}
+			if len(filter.pending) != 0 {
+				t.Fatalf("Expected no pending request, got %d", len(filter.pending))
+			}
+		})
+	}
+}
@@ -38,6 +38,7 @@ func NewServer(
 	k8sAPI *k8s.API,
 	grpcTapServer pb.TapServer,
 	disableCommonNames bool,
+	cryptoMode pkgTls.CryptoMode,
 ) (*Server, error) {
 	updateEvent := make(chan struct{})
 	errEvent := make(chan error)
@@ -74,6 +75,7 @@ func NewServer(
 			ClientCAs:  clientCertPool,
 		},
 	}
+	cryptoMode.ConfigureServer(httpServer.TLSConfig)
 
 	var emptyCert atomic.Value
 	h := &handler{
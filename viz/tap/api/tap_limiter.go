@@ -0,0 +1,77 @@
+package api
+
+import (
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// tapLimiter bounds the number of concurrent taps served by this replica, in
+// total and against each pod, so that many clients tapping the same workloads,
+// e.g. dashboards left open, can't degrade their proxies with the overhead of
+// tap. A limit of 0 disables it.
+type tapLimiter struct {
+	maxTaps       int
+	maxTapsPerPod int
+
+	taps      int
+	tapsByPod map[string]int
+	sync.Mutex
+}
+
+func newTapLimiter(maxTaps, maxTapsPerPod int) *tapLimiter {
+	return &tapLimiter{
+		maxTaps:       maxTaps,
+		maxTapsPerPod: maxTapsPerPod,
+		tapsByPod:     make(map[string]int),
+	}
+}
+
+// acquire accounts for a tap against the given pods, returning a function
+// releasing it once the tap is over. It fails with a ResourceExhausted status
+// when a limit is reached, in which case nothing is accounted for.
+func (l *tapLimiter) acquire(pods []*corev1.Pod) (func(), error) {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.maxTaps > 0 && l.taps >= l.maxTaps {
+		return nil, status.Errorf(codes.ResourceExhausted, "too many concurrent taps: the limit of %d taps is reached; stop another tap or retry later", l.maxTaps)
+	}
+	if l.maxTapsPerPod > 0 {
+		for _, pod := range pods {
+			if l.tapsByPod[podKey(pod)] >= l.maxTapsPerPod {
+				return nil, status.Errorf(codes.ResourceExhausted, "too many concurrent taps against pod %s: the limit of %d taps per pod is reached; stop another tap of this workload or retry later", podKey(pod), l.maxTapsPerPod)
+			}
+		}
+	}
+
+	l.taps++
+	for _, pod := range pods {
+		l.tapsByPod[podKey(pod)]++
+	}
+
+	released := false
+	return func() {
+		l.Lock()
+		defer l.Unlock()
+		if released {
+			return
+		}
+		released = true
+		l.taps--
+		for _, pod := range pods {
+			key := podKey(pod)
+			l.tapsByPod[key]--
+			if l.tapsByPod[key] <= 0 {
+				delete(l.tapsByPod, key)
+			}
+		}
+	}, nil
+}
+
+func podKey(pod *corev1.Pod) string {
+	return fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTapLimiter(t *testing.T) {
+	pod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "emojivoto", Name: name}}
+	}
+	web, voting, emoji := pod("web"), pod("voting"), pod("emoji")
+
+	t.Run("limits the taps per pod", func(t *testing.T) {
+		l := newTapLimiter(0, 1)
+		release, err := l.acquire([]*corev1.Pod{web, voting})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		_, err = l.acquire([]*corev1.Pod{emoji, voting})
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("Expected a ResourceExhausted error, got %v", err)
+		}
+		// the rejected tap isn't accounted for
+		releaseEmoji, err := l.acquire([]*corev1.Pod{emoji})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		releaseEmoji()
+
+		release()
+		// releasing twice has no effect
+		release()
+		if len(l.tapsByPod) != 0 || l.taps != 0 {
+			t.Fatalf("Expected no taps to be accounted for, got %d taps and %v", l.taps, l.tapsByPod)
+		}
+		if _, err = l.acquire([]*corev1.Pod{voting}); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("limits the taps in total", func(t *testing.T) {
+		l := newTapLimiter(2, 0)
+		for _, p := range []*corev1.Pod{web, web} {
+			if _, err := l.acquire([]*corev1.Pod{p}); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+		_, err := l.acquire([]*corev1.Pod{emoji})
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("Expected a ResourceExhausted error, got %v", err)
+		}
+	})
+
+	t.Run("no limits", func(t *testing.T) {
+		l := newTapLimiter(0, 0)
+		for i := 0; i < 100; i++ {
+			if _, err := l.acquire([]*corev1.Pod{web}); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+	})
+}
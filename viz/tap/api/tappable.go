@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	metricsPb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	vizLabels "github.com/linkerd/linkerd2/viz/pkg/labels"
+	pkgUtil "github.com/linkerd/linkerd2/viz/pkg/util"
+	tapPb "github.com/linkerd/linkerd2/viz/tap/gen/tap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// Tappable reports which pods of the resources matched by the request can
+// be tapped, and why the others can't.
+func (s *GRPCTapServer) Tappable(ctx context.Context, req *tapPb.TappableRequest) (*tapPb.TappableResponse, error) {
+	res := req.GetTarget().GetResource()
+	if res == nil {
+		return nil, status.Error(codes.InvalidArgument, "Tappable received nil target Resource")
+	}
+	labelSelector, err := getLabelSelector(req.GetTarget())
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := s.k8sAPI.GetObjects(res.GetNamespace(), res.GetType(), res.GetName(), labelSelector)
+	if err != nil {
+		return nil, pkgUtil.GRPCError(err)
+	}
+
+	namespaces := make(map[string]*corev1.Namespace)
+	rsp := &tapPb.TappableResponse{}
+	for _, object := range objects {
+		objMeta, err := meta.Accessor(object)
+		if err != nil {
+			return nil, pkgUtil.GRPCError(err)
+		}
+		pods, err := s.k8sAPI.GetPodsFor(object, false)
+		if err != nil {
+			return nil, pkgUtil.GRPCError(err)
+		}
+
+		workload := &tapPb.TappableResponse_Workload{
+			Resource: &metricsPb.Resource{
+				Namespace: objMeta.GetNamespace(),
+				Type:      res.GetType(),
+				Name:      objMeta.GetName(),
+			},
+		}
+		for _, pod := range pods {
+			ns, ok := namespaces[pod.Namespace]
+			if !ok {
+				ns, err = s.k8sAPI.NS().Lister().Get(pod.Namespace)
+				if err != nil && !kerrors.IsNotFound(err) {
+					return nil, pkgUtil.GRPCError(err)
+				}
+				if ns == nil {
+					ns = &corev1.Namespace{}
+				}
+				namespaces[pod.Namespace] = ns
+			}
+			workload.Pods = append(workload.Pods, &tapPb.TappableResponse_Pod{
+				Name:   pod.Name,
+				Reason: s.tappableReason(ns, pod),
+			})
+		}
+		rsp.Workloads = append(rsp.Workloads, workload)
+	}
+
+	return rsp, nil
+}
+
+// tappableReason mirrors the checks of TapByResource, telling apart the pods
+// whose tap is disabled by their namespace from the ones that only need to be
+// restarted
+func (s *GRPCTapServer) tappableReason(namespace *corev1.Namespace, pod *corev1.Pod) tapPb.TappableResponse_Reason {
+	switch {
+	case !pkgK8s.IsMeshed(pod, s.controllerNamespace):
+		return tapPb.TappableResponse_NOT_MESHED
+	case vizLabels.IsTapDisabled(pod):
+		return tapPb.TappableResponse_DISABLED_BY_POD
+	case vizLabels.IsTapEnabled(pod):
+		return tapPb.TappableResponse_TAPPABLE
+	case vizLabels.TapDisabledBy(namespace, pod) == pkgK8s.Namespace:
+		return tapPb.TappableResponse_DISABLED_BY_NAMESPACE
+	default:
+		return tapPb.TappableResponse_NOT_ENABLED
+	}
+}
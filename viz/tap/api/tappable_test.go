@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/linkerd/linkerd2/controller/k8s"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	metricsPb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	tapPb "github.com/linkerd/linkerd2/viz/tap/gen/tap"
+)
+
+func TestTappable(t *testing.T) {
+	pod := func(name string, meshed bool, annotations string) string {
+		controlPlane := ""
+		if meshed {
+			controlPlane = "\n    linkerd.io/control-plane-ns: controller-ns"
+		}
+		return fmt.Sprintf(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: %s
+  namespace: emojivoto
+  labels:
+    app: emoji-svc%s
+  annotations:
+    linkerd.io/proxy-version: testinjectversion%s
+status:
+  phase: Running
+  podIP: 127.0.0.1
+`, name, controlPlane, annotations)
+	}
+
+	k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: emojivoto
+  annotations:
+    viz.linkerd.io/disable-tap: "true"
+`,
+		pod("tap-enabled", true, `
+    viz.linkerd.io/tap-enabled: "true"`),
+		pod("namespace-disabled", true, ""),
+		pod("pod-reenabled", true, `
+    viz.linkerd.io/disable-tap: "false"`),
+		pod("pod-disabled", true, `
+    viz.linkerd.io/disable-tap: "true"`),
+		pod("not-meshed", false, ""),
+	)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+	k8sAPI.Sync(nil)
+	server := newGRPCTapServer(4190, "controller-ns", "cluster.local", 0, 0, k8sAPI)
+
+	rsp, err := server.Tappable(context.Background(), &tapPb.TappableRequest{
+		Target: &metricsPb.ResourceSelection{
+			Resource: &metricsPb.Resource{
+				Type: pkgK8s.Namespace,
+				Name: "emojivoto",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(rsp.GetWorkloads()) != 1 {
+		t.Fatalf("Expected 1 workload, got %d", len(rsp.GetWorkloads()))
+	}
+
+	reasons := make(map[string]tapPb.TappableResponse_Reason)
+	for _, pod := range rsp.GetWorkloads()[0].GetPods() {
+		reasons[pod.GetName()] = pod.GetReason()
+	}
+	expected := map[string]tapPb.TappableResponse_Reason{
+		"tap-enabled":        tapPb.TappableResponse_TAPPABLE,
+		"namespace-disabled": tapPb.TappableResponse_DISABLED_BY_NAMESPACE,
+		"pod-reenabled":      tapPb.TappableResponse_NOT_ENABLED,
+		"pod-disabled":       tapPb.TappableResponse_DISABLED_BY_POD,
+		"not-meshed":         tapPb.TappableResponse_NOT_MESHED,
+	}
+	if !reflect.DeepEqual(reasons, expected) {
+		t.Fatalf("Expected reasons %v, got %v", expected, reasons)
+	}
+}
@@ -23,6 +23,57 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Classifies the responses the same way `linkerd viz top` does: HTTP
+// 5xx responses, resets and gRPC server errors are failures.
+type TapByResourceRequest_Match_Response_Class int32
+
+const (
+	TapByResourceRequest_Match_Response_UNKNOWN TapByResourceRequest_Match_Response_Class = 0
+	TapByResourceRequest_Match_Response_SUCCESS TapByResourceRequest_Match_Response_Class = 1
+	TapByResourceRequest_Match_Response_FAILURE TapByResourceRequest_Match_Response_Class = 2
+)
+
+// Enum value maps for TapByResourceRequest_Match_Response_Class.
+var (
+	TapByResourceRequest_Match_Response_Class_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "SUCCESS",
+		2: "FAILURE",
+	}
+	TapByResourceRequest_Match_Response_Class_value = map[string]int32{
+		"UNKNOWN": 0,
+		"SUCCESS": 1,
+		"FAILURE": 2,
+	}
+)
+
+func (x TapByResourceRequest_Match_Response_Class) Enum() *TapByResourceRequest_Match_Response_Class {
+	p := new(TapByResourceRequest_Match_Response_Class)
+	*p = x
+	return p
+}
+
+func (x TapByResourceRequest_Match_Response_Class) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TapByResourceRequest_Match_Response_Class) Descriptor() protoreflect.EnumDescriptor {
+	return file_viz_tap_proto_enumTypes[0].Descriptor()
+}
+
+func (TapByResourceRequest_Match_Response_Class) Type() protoreflect.EnumType {
+	return &file_viz_tap_proto_enumTypes[0]
+}
+
+func (x TapByResourceRequest_Match_Response_Class) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TapByResourceRequest_Match_Response_Class.Descriptor instead.
+func (TapByResourceRequest_Match_Response_Class) EnumDescriptor() ([]byte, []int) {
+	return file_viz_tap_proto_rawDescGZIP(), []int{1, 0, 2, 0}
+}
+
 type TapEvent_ProxyDirection int32
 
 const (
@@ -56,11 +107,11 @@ func (x TapEvent_ProxyDirection) String() string {
 }
 
 func (TapEvent_ProxyDirection) Descriptor() protoreflect.EnumDescriptor {
-	return file_viz_tap_proto_enumTypes[0].Descriptor()
+	return file_viz_tap_proto_enumTypes[1].Descriptor()
 }
 
 func (TapEvent_ProxyDirection) Type() protoreflect.EnumType {
-	return &file_viz_tap_proto_enumTypes[0]
+	return &file_viz_tap_proto_enumTypes[1]
 }
 
 func (x TapEvent_ProxyDirection) Number() protoreflect.EnumNumber {
@@ -72,6 +123,68 @@ func (TapEvent_ProxyDirection) EnumDescriptor() ([]byte, []int) {
 	return file_viz_tap_proto_rawDescGZIP(), []int{2, 0}
 }
 
+type TappableResponse_Reason int32
+
+const (
+	// The pod can be tapped.
+	TappableResponse_TAPPABLE TappableResponse_Reason = 0
+	// The pod isn't injected with the proxy.
+	TappableResponse_NOT_MESHED TappableResponse_Reason = 1
+	// Tap is disabled by the annotation of the pod, set through its
+	// workload's template.
+	TappableResponse_DISABLED_BY_POD TappableResponse_Reason = 2
+	// Tap is disabled by the annotation of the pod's namespace.
+	TappableResponse_DISABLED_BY_NAMESPACE TappableResponse_Reason = 3
+	// Tap isn't disabled, but the pod was created before it was enabled and
+	// must be restarted.
+	TappableResponse_NOT_ENABLED TappableResponse_Reason = 4
+)
+
+// Enum value maps for TappableResponse_Reason.
+var (
+	TappableResponse_Reason_name = map[int32]string{
+		0: "TAPPABLE",
+		1: "NOT_MESHED",
+		2: "DISABLED_BY_POD",
+		3: "DISABLED_BY_NAMESPACE",
+		4: "NOT_ENABLED",
+	}
+	TappableResponse_Reason_value = map[string]int32{
+		"TAPPABLE":              0,
+		"NOT_MESHED":            1,
+		"DISABLED_BY_POD":       2,
+		"DISABLED_BY_NAMESPACE": 3,
+		"NOT_ENABLED":           4,
+	}
+)
+
+func (x TappableResponse_Reason) Enum() *TappableResponse_Reason {
+	p := new(TappableResponse_Reason)
+	*p = x
+	return p
+}
+
+func (x TappableResponse_Reason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TappableResponse_Reason) Descriptor() protoreflect.EnumDescriptor {
+	return file_viz_tap_proto_enumTypes[2].Descriptor()
+}
+
+func (TappableResponse_Reason) Type() protoreflect.EnumType {
+	return &file_viz_tap_proto_enumTypes[2]
+}
+
+func (x TappableResponse_Reason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TappableResponse_Reason.Descriptor instead.
+func (TappableResponse_Reason) EnumDescriptor() ([]byte, []int) {
+	return file_viz_tap_proto_rawDescGZIP(), []int{4, 0}
+}
+
 // Deprecated: Do not use.
 type TapRequest struct {
 	state         protoimpl.MessageState
@@ -243,6 +356,9 @@ type TapByResourceRequest struct {
 	// Conditionally extracts components from requests and responses to include
 	// in tap events
 	Extract *TapByResourceRequest_Extract `protobuf:"bytes,4,opt,name=extract,proto3" json:"extract,omitempty"`
+	// If set, the events are aggregated by the tap server, which streams
+	// summaries of the tapped requests instead of the events themselves.
+	Aggregate *TapByResourceRequest_Aggregate `protobuf:"bytes,5,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
 }
 
 func (x *TapByResourceRequest) Reset() {
@@ -305,6 +421,13 @@ func (x *TapByResourceRequest) GetExtract() *TapByResourceRequest_Extract {
 	return nil
 }
 
+func (x *TapByResourceRequest) GetAggregate() *TapByResourceRequest_Aggregate {
+	if x != nil {
+		return x.Aggregate
+	}
+	return nil
+}
+
 // This is used only by the tap APIServer.
 type TapEvent struct {
 	state         protoimpl.MessageState
@@ -319,6 +442,7 @@ type TapEvent struct {
 	ProxyDirection  TapEvent_ProxyDirection `protobuf:"varint,6,opt,name=proxy_direction,json=proxyDirection,proto3,enum=linkerd2.tap.TapEvent_ProxyDirection" json:"proxy_direction,omitempty"`
 	// Types that are assignable to Event:
 	//	*TapEvent_Http_
+	//	*TapEvent_Summary_
 	Event isTapEvent_Event `protobuf_oneof:"event"`
 }
 
@@ -410,6 +534,13 @@ func (x *TapEvent) GetHttp() *TapEvent_Http {
 	return nil
 }
 
+func (x *TapEvent) GetSummary() *TapEvent_Summary {
+	if x, ok := x.GetEvent().(*TapEvent_Summary_); ok {
+		return x.Summary
+	}
+	return nil
+}
+
 type isTapEvent_Event interface {
 	isTapEvent_Event()
 }
@@ -418,8 +549,114 @@ type TapEvent_Http_ struct {
 	Http *TapEvent_Http `protobuf:"bytes,3,opt,name=http,proto3,oneof"`
 }
 
+type TapEvent_Summary_ struct {
+	// Only sent by aggregating taps, in which case the other fields of the
+	// event are left empty.
+	Summary *TapEvent_Summary `protobuf:"bytes,8,opt,name=summary,proto3,oneof"`
+}
+
 func (*TapEvent_Http_) isTapEvent_Event() {}
 
+func (*TapEvent_Summary_) isTapEvent_Event() {}
+
+// A request for the tappability of kubernetes resources.
+//
+// This is used only by the tap APIServer.
+type TappableRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Describes the kubernetes resources whose pods are reported.
+	Target *viz.ResourceSelection `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+}
+
+func (x *TappableRequest) Reset() {
+	*x = TappableRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_tap_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TappableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TappableRequest) ProtoMessage() {}
+
+func (x *TappableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_tap_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TappableRequest.ProtoReflect.Descriptor instead.
+func (*TappableRequest) Descriptor() ([]byte, []int) {
+	return file_viz_tap_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TappableRequest) GetTarget() *viz.ResourceSelection {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+type TappableResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Workloads []*TappableResponse_Workload `protobuf:"bytes,1,rep,name=workloads,proto3" json:"workloads,omitempty"`
+}
+
+func (x *TappableResponse) Reset() {
+	*x = TappableResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_tap_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TappableResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TappableResponse) ProtoMessage() {}
+
+func (x *TappableResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_tap_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TappableResponse.ProtoReflect.Descriptor instead.
+func (*TappableResponse) Descriptor() ([]byte, []int) {
+	return file_viz_tap_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TappableResponse) GetWorkloads() []*TappableResponse_Workload {
+	if x != nil {
+		return x.Workloads
+	}
+	return nil
+}
+
 type TapByResourceRequest_Match struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -431,13 +668,14 @@ type TapByResourceRequest_Match struct {
 	//	*TapByResourceRequest_Match_Not
 	//	*TapByResourceRequest_Match_Destinations
 	//	*TapByResourceRequest_Match_Http_
+	//	*TapByResourceRequest_Match_Response_
 	Match isTapByResourceRequest_Match_Match `protobuf_oneof:"match"`
 }
 
 func (x *TapByResourceRequest_Match) Reset() {
 	*x = TapByResourceRequest_Match{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_tap_proto_msgTypes[3]
+		mi := &file_viz_tap_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -450,7 +688,7 @@ func (x *TapByResourceRequest_Match) String() string {
 func (*TapByResourceRequest_Match) ProtoMessage() {}
 
 func (x *TapByResourceRequest_Match) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_tap_proto_msgTypes[3]
+	mi := &file_viz_tap_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -508,6 +746,13 @@ func (x *TapByResourceRequest_Match) GetHttp() *TapByResourceRequest_Match_Http
 	return nil
 }
 
+func (x *TapByResourceRequest_Match) GetResponse() *TapByResourceRequest_Match_Response {
+	if x, ok := x.GetMatch().(*TapByResourceRequest_Match_Response_); ok {
+		return x.Response
+	}
+	return nil
+}
+
 type isTapByResourceRequest_Match_Match interface {
 	isTapByResourceRequest_Match_Match()
 }
@@ -537,6 +782,13 @@ type TapByResourceRequest_Match_Http_ struct {
 	Http *TapByResourceRequest_Match_Http `protobuf:"bytes,5,opt,name=http,proto3,oneof"`
 }
 
+type TapByResourceRequest_Match_Response_ struct {
+	// Matches HTTP requests by their response. Unlike the other matches,
+	// these are evaluated by the tap server rather than by the proxies, so
+	// the events of a request are held back until its response ends.
+	Response *TapByResourceRequest_Match_Response `protobuf:"bytes,6,opt,name=response,proto3,oneof"`
+}
+
 func (*TapByResourceRequest_Match_All) isTapByResourceRequest_Match_Match() {}
 
 func (*TapByResourceRequest_Match_Any) isTapByResourceRequest_Match_Match() {}
@@ -547,6 +799,8 @@ func (*TapByResourceRequest_Match_Destinations) isTapByResourceRequest_Match_Mat
 
 func (*TapByResourceRequest_Match_Http_) isTapByResourceRequest_Match_Match() {}
 
+func (*TapByResourceRequest_Match_Response_) isTapByResourceRequest_Match_Match() {}
+
 type TapByResourceRequest_Extract struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -560,7 +814,7 @@ type TapByResourceRequest_Extract struct {
 func (x *TapByResourceRequest_Extract) Reset() {
 	*x = TapByResourceRequest_Extract{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_tap_proto_msgTypes[4]
+		mi := &file_viz_tap_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -573,7 +827,7 @@ func (x *TapByResourceRequest_Extract) String() string {
 func (*TapByResourceRequest_Extract) ProtoMessage() {}
 
 func (x *TapByResourceRequest_Extract) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_tap_proto_msgTypes[4]
+	mi := &file_viz_tap_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -613,6 +867,54 @@ type TapByResourceRequest_Extract_Http_ struct {
 
 func (*TapByResourceRequest_Extract_Http_) isTapByResourceRequest_Extract_Extract() {}
 
+type TapByResourceRequest_Aggregate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The interval at which summaries are sent. Defaults to 1s.
+	Interval *duration.Duration `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
+}
+
+func (x *TapByResourceRequest_Aggregate) Reset() {
+	*x = TapByResourceRequest_Aggregate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_tap_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TapByResourceRequest_Aggregate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TapByResourceRequest_Aggregate) ProtoMessage() {}
+
+func (x *TapByResourceRequest_Aggregate) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_tap_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TapByResourceRequest_Aggregate.ProtoReflect.Descriptor instead.
+func (*TapByResourceRequest_Aggregate) Descriptor() ([]byte, []int) {
+	return file_viz_tap_proto_rawDescGZIP(), []int{1, 2}
+}
+
+func (x *TapByResourceRequest_Aggregate) GetInterval() *duration.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
 type TapByResourceRequest_Match_Seq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -624,7 +926,7 @@ type TapByResourceRequest_Match_Seq struct {
 func (x *TapByResourceRequest_Match_Seq) Reset() {
 	*x = TapByResourceRequest_Match_Seq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_tap_proto_msgTypes[5]
+		mi := &file_viz_tap_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -637,7 +939,7 @@ func (x *TapByResourceRequest_Match_Seq) String() string {
 func (*TapByResourceRequest_Match_Seq) ProtoMessage() {}
 
 func (x *TapByResourceRequest_Match_Seq) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_tap_proto_msgTypes[5]
+	mi := &file_viz_tap_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -676,7 +978,7 @@ type TapByResourceRequest_Match_Http struct {
 func (x *TapByResourceRequest_Match_Http) Reset() {
 	*x = TapByResourceRequest_Match_Http{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_tap_proto_msgTypes[6]
+		mi := &file_viz_tap_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -689,7 +991,7 @@ func (x *TapByResourceRequest_Match_Http) String() string {
 func (*TapByResourceRequest_Match_Http) ProtoMessage() {}
 
 func (x *TapByResourceRequest_Match_Http) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_tap_proto_msgTypes[6]
+	mi := &file_viz_tap_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -768,6 +1070,89 @@ func (*TapByResourceRequest_Match_Http_Authority) isTapByResourceRequest_Match_H
 
 func (*TapByResourceRequest_Match_Http_Path) isTapByResourceRequest_Match_Http_Match() {}
 
+type TapByResourceRequest_Match_Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Match:
+	//	*TapByResourceRequest_Match_Response_GrpcStatus
+	//	*TapByResourceRequest_Match_Response_Class_
+	Match isTapByResourceRequest_Match_Response_Match `protobuf_oneof:"match"`
+}
+
+func (x *TapByResourceRequest_Match_Response) Reset() {
+	*x = TapByResourceRequest_Match_Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_tap_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TapByResourceRequest_Match_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TapByResourceRequest_Match_Response) ProtoMessage() {}
+
+func (x *TapByResourceRequest_Match_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_tap_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TapByResourceRequest_Match_Response.ProtoReflect.Descriptor instead.
+func (*TapByResourceRequest_Match_Response) Descriptor() ([]byte, []int) {
+	return file_viz_tap_proto_rawDescGZIP(), []int{1, 0, 2}
+}
+
+func (m *TapByResourceRequest_Match_Response) GetMatch() isTapByResourceRequest_Match_Response_Match {
+	if m != nil {
+		return m.Match
+	}
+	return nil
+}
+
+func (x *TapByResourceRequest_Match_Response) GetGrpcStatus() uint32 {
+	if x, ok := x.GetMatch().(*TapByResourceRequest_Match_Response_GrpcStatus); ok {
+		return x.GrpcStatus
+	}
+	return 0
+}
+
+func (x *TapByResourceRequest_Match_Response) GetClass() TapByResourceRequest_Match_Response_Class {
+	if x, ok := x.GetMatch().(*TapByResourceRequest_Match_Response_Class_); ok {
+		return x.Class
+	}
+	return TapByResourceRequest_Match_Response_UNKNOWN
+}
+
+type isTapByResourceRequest_Match_Response_Match interface {
+	isTapByResourceRequest_Match_Response_Match()
+}
+
+type TapByResourceRequest_Match_Response_GrpcStatus struct {
+	// Matches the responses ending with this gRPC status code.
+	GrpcStatus uint32 `protobuf:"varint,1,opt,name=grpc_status,json=grpcStatus,proto3,oneof"`
+}
+
+type TapByResourceRequest_Match_Response_Class_ struct {
+	// Matches the responses of this class.
+	Class TapByResourceRequest_Match_Response_Class `protobuf:"varint,2,opt,name=class,proto3,enum=linkerd2.tap.TapByResourceRequest_Match_Response_Class,oneof"`
+}
+
+func (*TapByResourceRequest_Match_Response_GrpcStatus) isTapByResourceRequest_Match_Response_Match() {
+}
+
+func (*TapByResourceRequest_Match_Response_Class_) isTapByResourceRequest_Match_Response_Match() {}
+
 type TapByResourceRequest_Extract_Http struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -781,7 +1166,7 @@ type TapByResourceRequest_Extract_Http struct {
 func (x *TapByResourceRequest_Extract_Http) Reset() {
 	*x = TapByResourceRequest_Extract_Http{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_tap_proto_msgTypes[7]
+		mi := &file_viz_tap_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -794,7 +1179,7 @@ func (x *TapByResourceRequest_Extract_Http) String() string {
 func (*TapByResourceRequest_Extract_Http) ProtoMessage() {}
 
 func (x *TapByResourceRequest_Extract_Http) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_tap_proto_msgTypes[7]
+	mi := &file_viz_tap_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -843,7 +1228,7 @@ type TapByResourceRequest_Extract_Http_Headers struct {
 func (x *TapByResourceRequest_Extract_Http_Headers) Reset() {
 	*x = TapByResourceRequest_Extract_Http_Headers{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_tap_proto_msgTypes[8]
+		mi := &file_viz_tap_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -856,7 +1241,7 @@ func (x *TapByResourceRequest_Extract_Http_Headers) String() string {
 func (*TapByResourceRequest_Extract_Http_Headers) ProtoMessage() {}
 
 func (x *TapByResourceRequest_Extract_Http_Headers) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_tap_proto_msgTypes[8]
+	mi := &file_viz_tap_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -883,7 +1268,7 @@ type TapEvent_EndpointMeta struct {
 func (x *TapEvent_EndpointMeta) Reset() {
 	*x = TapEvent_EndpointMeta{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_tap_proto_msgTypes[9]
+		mi := &file_viz_tap_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -896,7 +1281,7 @@ func (x *TapEvent_EndpointMeta) String() string {
 func (*TapEvent_EndpointMeta) ProtoMessage() {}
 
 func (x *TapEvent_EndpointMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_tap_proto_msgTypes[9]
+	mi := &file_viz_tap_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -930,7 +1315,7 @@ type TapEvent_RouteMeta struct {
 func (x *TapEvent_RouteMeta) Reset() {
 	*x = TapEvent_RouteMeta{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_tap_proto_msgTypes[10]
+		mi := &file_viz_tap_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -943,7 +1328,7 @@ func (x *TapEvent_RouteMeta) String() string {
 func (*TapEvent_RouteMeta) ProtoMessage() {}
 
 func (x *TapEvent_RouteMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_tap_proto_msgTypes[10]
+	mi := &file_viz_tap_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -981,7 +1366,7 @@ type TapEvent_Http struct {
 func (x *TapEvent_Http) Reset() {
 	*x = TapEvent_Http{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_tap_proto_msgTypes[11]
+		mi := &file_viz_tap_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -994,7 +1379,7 @@ func (x *TapEvent_Http) String() string {
 func (*TapEvent_Http) ProtoMessage() {}
 
 func (x *TapEvent_Http) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_tap_proto_msgTypes[11]
+	mi := &file_viz_tap_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1060,6 +1445,55 @@ func (*TapEvent_Http_ResponseInit_) isTapEvent_Http_Event() {}
 
 func (*TapEvent_Http_ResponseEnd_) isTapEvent_Http_Event() {}
 
+// Summarizes the requests completed since the previous summary, by method
+// and path.
+type TapEvent_Summary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Routes []*TapEvent_Summary_Route `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`
+}
+
+func (x *TapEvent_Summary) Reset() {
+	*x = TapEvent_Summary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_tap_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TapEvent_Summary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TapEvent_Summary) ProtoMessage() {}
+
+func (x *TapEvent_Summary) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_tap_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TapEvent_Summary.ProtoReflect.Descriptor instead.
+func (*TapEvent_Summary) Descriptor() ([]byte, []int) {
+	return file_viz_tap_proto_rawDescGZIP(), []int{2, 3}
+}
+
+func (x *TapEvent_Summary) GetRoutes() []*TapEvent_Summary_Route {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
 type TapEvent_Http_StreamId struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1074,7 +1508,7 @@ type TapEvent_Http_StreamId struct {
 func (x *TapEvent_Http_StreamId) Reset() {
 	*x = TapEvent_Http_StreamId{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_tap_proto_msgTypes[14]
+		mi := &file_viz_tap_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1087,7 +1521,7 @@ func (x *TapEvent_Http_StreamId) String() string {
 func (*TapEvent_Http_StreamId) ProtoMessage() {}
 
 func (x *TapEvent_Http_StreamId) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_tap_proto_msgTypes[14]
+	mi := &file_viz_tap_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1133,7 +1567,7 @@ type TapEvent_Http_RequestInit struct {
 func (x *TapEvent_Http_RequestInit) Reset() {
 	*x = TapEvent_Http_RequestInit{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_tap_proto_msgTypes[15]
+		mi := &file_viz_tap_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1146,7 +1580,7 @@ func (x *TapEvent_Http_RequestInit) String() string {
 func (*TapEvent_Http_RequestInit) ProtoMessage() {}
 
 func (x *TapEvent_Http_RequestInit) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_tap_proto_msgTypes[15]
+	mi := &file_viz_tap_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1218,7 +1652,7 @@ type TapEvent_Http_ResponseInit struct {
 func (x *TapEvent_Http_ResponseInit) Reset() {
 	*x = TapEvent_Http_ResponseInit{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_tap_proto_msgTypes[16]
+		mi := &file_viz_tap_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1231,7 +1665,7 @@ func (x *TapEvent_Http_ResponseInit) String() string {
 func (*TapEvent_Http_ResponseInit) ProtoMessage() {}
 
 func (x *TapEvent_Http_ResponseInit) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_tap_proto_msgTypes[16]
+	mi := &file_viz_tap_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1291,7 +1725,7 @@ type TapEvent_Http_ResponseEnd struct {
 func (x *TapEvent_Http_ResponseEnd) Reset() {
 	*x = TapEvent_Http_ResponseEnd{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_viz_tap_proto_msgTypes[17]
+		mi := &file_viz_tap_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1304,7 +1738,7 @@ func (x *TapEvent_Http_ResponseEnd) String() string {
 func (*TapEvent_Http_ResponseEnd) ProtoMessage() {}
 
 func (x *TapEvent_Http_ResponseEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_viz_tap_proto_msgTypes[17]
+	mi := &file_viz_tap_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1362,6 +1796,205 @@ func (x *TapEvent_Http_ResponseEnd) GetTrailers() *viz.Headers {
 	return nil
 }
 
+type TapEvent_Summary_Route struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Method    *viz.HttpMethod `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Path      string          `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Count     uint64          `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Successes uint64          `protobuf:"varint,4,opt,name=successes,proto3" json:"successes,omitempty"`
+	// Latency percentiles, computed over the most recent requests of the
+	// route.
+	LatencyP50 *duration.Duration `protobuf:"bytes,5,opt,name=latency_p50,json=latencyP50,proto3" json:"latency_p50,omitempty"`
+	LatencyP99 *duration.Duration `protobuf:"bytes,6,opt,name=latency_p99,json=latencyP99,proto3" json:"latency_p99,omitempty"`
+}
+
+func (x *TapEvent_Summary_Route) Reset() {
+	*x = TapEvent_Summary_Route{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_tap_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TapEvent_Summary_Route) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TapEvent_Summary_Route) ProtoMessage() {}
+
+func (x *TapEvent_Summary_Route) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_tap_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TapEvent_Summary_Route.ProtoReflect.Descriptor instead.
+func (*TapEvent_Summary_Route) Descriptor() ([]byte, []int) {
+	return file_viz_tap_proto_rawDescGZIP(), []int{2, 3, 0}
+}
+
+func (x *TapEvent_Summary_Route) GetMethod() *viz.HttpMethod {
+	if x != nil {
+		return x.Method
+	}
+	return nil
+}
+
+func (x *TapEvent_Summary_Route) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *TapEvent_Summary_Route) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *TapEvent_Summary_Route) GetSuccesses() uint64 {
+	if x != nil {
+		return x.Successes
+	}
+	return 0
+}
+
+func (x *TapEvent_Summary_Route) GetLatencyP50() *duration.Duration {
+	if x != nil {
+		return x.LatencyP50
+	}
+	return nil
+}
+
+func (x *TapEvent_Summary_Route) GetLatencyP99() *duration.Duration {
+	if x != nil {
+		return x.LatencyP99
+	}
+	return nil
+}
+
+type TappableResponse_Pod struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string                  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Reason TappableResponse_Reason `protobuf:"varint,2,opt,name=reason,proto3,enum=linkerd2.tap.TappableResponse_Reason" json:"reason,omitempty"`
+}
+
+func (x *TappableResponse_Pod) Reset() {
+	*x = TappableResponse_Pod{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_tap_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TappableResponse_Pod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TappableResponse_Pod) ProtoMessage() {}
+
+func (x *TappableResponse_Pod) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_tap_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TappableResponse_Pod.ProtoReflect.Descriptor instead.
+func (*TappableResponse_Pod) Descriptor() ([]byte, []int) {
+	return file_viz_tap_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *TappableResponse_Pod) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TappableResponse_Pod) GetReason() TappableResponse_Reason {
+	if x != nil {
+		return x.Reason
+	}
+	return TappableResponse_TAPPABLE
+}
+
+type TappableResponse_Workload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Resource *viz.Resource           `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	Pods     []*TappableResponse_Pod `protobuf:"bytes,2,rep,name=pods,proto3" json:"pods,omitempty"`
+}
+
+func (x *TappableResponse_Workload) Reset() {
+	*x = TappableResponse_Workload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_viz_tap_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TappableResponse_Workload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TappableResponse_Workload) ProtoMessage() {}
+
+func (x *TappableResponse_Workload) ProtoReflect() protoreflect.Message {
+	mi := &file_viz_tap_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TappableResponse_Workload.ProtoReflect.Descriptor instead.
+func (*TappableResponse_Workload) Descriptor() ([]byte, []int) {
+	return file_viz_tap_proto_rawDescGZIP(), []int{4, 1}
+}
+
+func (x *TappableResponse_Workload) GetResource() *viz.Resource {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *TappableResponse_Workload) GetPods() []*TappableResponse_Pod {
+	if x != nil {
+		return x.Pods
+	}
+	return nil
+}
+
 var File_viz_tap_proto protoreflect.FileDescriptor
 
 var file_viz_tap_proto_rawDesc = []byte{
@@ -1389,7 +2022,7 @@ var file_viz_tap_proto_rawDesc = []byte{
 	0x72, 0x69, 0x74, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x75, 0x74, 0x68,
 	0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x0b, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x3a, 0x02, 0x18, 0x01, 0x42, 0x08, 0x0a,
-	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0xe5, 0x07, 0x0a, 0x14, 0x54, 0x61, 0x70, 0x42,
+	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x80, 0x0b, 0x0a, 0x14, 0x54, 0x61, 0x70, 0x42,
 	0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x12, 0x37, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e,
@@ -1404,179 +2037,259 @@ var file_viz_tap_proto_rawDesc = []byte{
 	0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61,
 	0x70, 0x2e, 0x54, 0x61, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x07,
-	0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x1a, 0xa4, 0x04, 0x0a, 0x05, 0x4d, 0x61, 0x74, 0x63,
-	0x68, 0x12, 0x40, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c,
+	0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x4a, 0x0a, 0x09, 0x61, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x42, 0x79, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x41,
+	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x09, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x65, 0x1a, 0xaf, 0x06, 0x0a, 0x05, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x40, 0x0a,
+	0x03, 0x61, 0x6c, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x42, 0x79, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x2e, 0x53, 0x65, 0x71, 0x48, 0x00, 0x52, 0x03, 0x61, 0x6c, 0x6c, 0x12,
+	0x40, 0x0a, 0x03, 0x61, 0x6e, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x42,
+	0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x53, 0x65, 0x71, 0x48, 0x00, 0x52, 0x03, 0x61, 0x6e,
+	0x79, 0x12, 0x3c, 0x0a, 0x03, 0x6e, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28,
 	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61,
 	0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x53, 0x65, 0x71, 0x48, 0x00, 0x52, 0x03,
-	0x61, 0x6c, 0x6c, 0x12, 0x40, 0x0a, 0x03, 0x61, 0x6e, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x2c, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e,
-	0x54, 0x61, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x53, 0x65, 0x71, 0x48, 0x00,
-	0x52, 0x03, 0x61, 0x6e, 0x79, 0x12, 0x3c, 0x0a, 0x03, 0x6e, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61,
-	0x70, 0x2e, 0x54, 0x61, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x03,
-	0x6e, 0x6f, 0x74, 0x12, 0x45, 0x0a, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
-	0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0c, 0x64, 0x65,
-	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x43, 0x0a, 0x04, 0x68, 0x74,
-	0x74, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
-	0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x74,
-	0x63, 0x68, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x48, 0x00, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x1a,
-	0x49, 0x0a, 0x03, 0x53, 0x65, 0x71, 0x12, 0x42, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
-	0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x74, 0x63,
-	0x68, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x1a, 0x79, 0x0a, 0x04, 0x48, 0x74,
-	0x74, 0x70, 0x12, 0x18, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x06,
-	0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06,
-	0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1e, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
-	0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x61, 0x75, 0x74,
-	0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x42, 0x07, 0x0a, 0x05,
-	0x6d, 0x61, 0x74, 0x63, 0x68, 0x42, 0x07, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x1a, 0xce,
-	0x01, 0x0a, 0x07, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x45, 0x0a, 0x04, 0x68, 0x74,
-	0x74, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
-	0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x78, 0x74,
-	0x72, 0x61, 0x63, 0x74, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x48, 0x00, 0x52, 0x04, 0x68, 0x74, 0x74,
-	0x70, 0x1a, 0x71, 0x0a, 0x04, 0x48, 0x74, 0x74, 0x70, 0x12, 0x53, 0x0a, 0x07, 0x68, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x6c, 0x69, 0x6e,
-	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x42, 0x79, 0x52,
-	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x45,
-	0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x2e, 0x48, 0x65, 0x61, 0x64,
-	0x65, 0x72, 0x73, 0x48, 0x00, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x1a, 0x09,
-	0x0a, 0x07, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x65, 0x78, 0x74,
-	0x72, 0x61, 0x63, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x22,
-	0xc2, 0x0f, 0x0a, 0x08, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x37, 0x0a, 0x06,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6c,
-	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x6e,
-	0x65, 0x74, 0x2e, 0x54, 0x63, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x06, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x44, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
-	0x6d, 0x65, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6c, 0x69, 0x6e,
-	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x52,
-	0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x41, 0x0a, 0x0b, 0x64,
-	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
-	0x6f, 0x6e, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x54, 0x63, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4e,
-	0x0a, 0x10, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x65,
-	0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
-	0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x0f, 0x64,
-	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x3f,
-	0x0a, 0x0a, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61,
-	0x70, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65,
-	0x4d, 0x65, 0x74, 0x61, 0x52, 0x09, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x12,
-	0x4e, 0x0a, 0x0f, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
-	0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x2e, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x0e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x31, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x73, 0x74, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x03, 0x6e, 0x6f, 0x74, 0x12,
+	0x45, 0x0a, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
+	0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x43, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
+	0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x48,
+	0x74, 0x74, 0x70, 0x48, 0x00, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x12, 0x4f, 0x0a, 0x08, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x31, 0x2e,
 	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x48, 0x00, 0x52, 0x04, 0x68, 0x74,
-	0x74, 0x70, 0x1a, 0x92, 0x01, 0x0a, 0x0c, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4d,
-	0x65, 0x74, 0x61, 0x12, 0x47, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74,
+	0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x48, 0x00, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x49, 0x0a, 0x03,
+	0x53, 0x65, 0x71, 0x12, 0x42, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
+	0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x07,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x1a, 0x79, 0x0a, 0x04, 0x48, 0x74, 0x74, 0x70, 0x12,
+	0x18, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x00, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x06, 0x6d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x6d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x12, 0x1e, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x42, 0x07, 0x0a, 0x05, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x1a, 0xb7, 0x01, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x21, 0x0a, 0x0b, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0a, 0x67, 0x72, 0x70, 0x63, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x4f, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x37, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70,
+	0x2e, 0x54, 0x61, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x48, 0x00, 0x52, 0x05, 0x63, 0x6c,
+	0x61, 0x73, 0x73, 0x22, 0x2e, 0x0a, 0x05, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x0b, 0x0a, 0x07,
+	0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x55, 0x43,
+	0x43, 0x45, 0x53, 0x53, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52,
+	0x45, 0x10, 0x02, 0x42, 0x07, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x42, 0x07, 0x0a, 0x05,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x1a, 0xce, 0x01, 0x0a, 0x07, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63,
+	0x74, 0x12, 0x45, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54,
+	0x61, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x48, 0x74, 0x74, 0x70,
+	0x48, 0x00, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x1a, 0x71, 0x0a, 0x04, 0x48, 0x74, 0x74, 0x70,
+	0x12, 0x53, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x37, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70,
+	0x2e, 0x54, 0x61, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x48, 0x74,
+	0x74, 0x70, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x48, 0x00, 0x52, 0x07, 0x68, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x73, 0x1a, 0x09, 0x0a, 0x07, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
+	0x42, 0x09, 0x0a, 0x07, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x65,
+	0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x1a, 0x42, 0x0a, 0x09, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0xc4, 0x12, 0x0a, 0x08, 0x54,
+	0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x37, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
+	0x64, 0x32, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x54, 0x63,
+	0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x12, 0x44, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
+	0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x41, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6c, 0x69,
+	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x6e, 0x65,
+	0x74, 0x2e, 0x54, 0x63, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4e, 0x0a, 0x10, 0x64, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74,
 	0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6e, 0x64, 0x70,
-	0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a, 0x39, 0x0a, 0x0b,
-	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x8c, 0x01, 0x0a, 0x09, 0x52, 0x6f, 0x75, 0x74,
-	0x65, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x44, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
-	0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x6f,
-	0x75, 0x74, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c,
-	0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0xf8, 0x08, 0x0a, 0x04, 0x48, 0x74, 0x74, 0x70, 0x12,
-	0x4c, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x6e, 0x69, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
-	0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x48, 0x74,
-	0x74, 0x70, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x69, 0x74, 0x48, 0x00,
-	0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x69, 0x74, 0x12, 0x4f, 0x0a,
-	0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x69, 0x6e, 0x69, 0x74, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
-	0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x48, 0x74, 0x74,
-	0x70, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x49, 0x6e, 0x69, 0x74, 0x48, 0x00,
-	0x52, 0x0c, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x49, 0x6e, 0x69, 0x74, 0x12, 0x4c,
-	0x0a, 0x0c, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
-	0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x48, 0x74, 0x74,
-	0x70, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x45, 0x6e, 0x64, 0x48, 0x00, 0x52,
-	0x0b, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x45, 0x6e, 0x64, 0x1a, 0x36, 0x0a, 0x08,
-	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06,
-	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x1a, 0x86, 0x02, 0x0a, 0x0b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x49, 0x6e, 0x69, 0x74, 0x12, 0x34, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x24, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e,
-	0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x2e, 0x53, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x52, 0x02, 0x69, 0x64, 0x12, 0x30, 0x0a, 0x06, 0x6d, 0x65,
-	0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x69, 0x6e,
-	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x4d, 0x65,
-	0x74, 0x68, 0x6f, 0x64, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x2c, 0x0a, 0x06,
-	0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c,
-	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x53, 0x63, 0x68, 0x65,
-	0x6d, 0x65, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x75,
-	0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61,
-	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x2f, 0x0a, 0x07,
-	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
-	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x48, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x73, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x1a, 0xdf, 0x01,
-	0x0a, 0x0c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x49, 0x6e, 0x69, 0x74, 0x12, 0x34,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6c, 0x69, 0x6e,
+	0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x0f, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x3f, 0x0a, 0x0a, 0x72, 0x6f, 0x75,
+	0x74, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e,
+	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x52,
+	0x09, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x4e, 0x0a, 0x0f, 0x70, 0x72,
+	0x6f, 0x78, 0x79, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74,
+	0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x78,
+	0x79, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x70, 0x72, 0x6f, 0x78,
+	0x79, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x04, 0x68, 0x74,
+	0x74, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
+	0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x2e, 0x48, 0x74, 0x74, 0x70, 0x48, 0x00, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x12, 0x3a, 0x0a,
+	0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61,
+	0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x48, 0x00,
+	0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x1a, 0x92, 0x01, 0x0a, 0x0c, 0x45, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x47, 0x0a, 0x06, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6c, 0x69, 0x6e,
 	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x64,
-	0x52, 0x02, 0x69, 0x64, 0x12, 0x47, 0x0a, 0x12, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x6e, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x73, 0x69, 0x6e,
-	0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x69, 0x74, 0x12, 0x1f, 0x0a,
-	0x0b, 0x68, 0x74, 0x74, 0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x0a, 0x68, 0x74, 0x74, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2f,
-	0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x15, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x48,
-	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x1a,
-	0xd6, 0x02, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x45, 0x6e, 0x64, 0x12,
-	0x34, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6c, 0x69,
+	0x6e, 0x74, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x2e,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x8c,
+	0x01, 0x0a, 0x09, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x44, 0x0a, 0x06,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x2e, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0xf8, 0x08,
+	0x0a, 0x04, 0x48, 0x74, 0x74, 0x70, 0x12, 0x4c, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x5f, 0x69, 0x6e, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x49, 0x6e, 0x69, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x49, 0x6e, 0x69, 0x74, 0x12, 0x4f, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x5f, 0x69, 0x6e, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6c, 0x69,
 	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49,
-	0x64, 0x52, 0x02, 0x69, 0x64, 0x12, 0x47, 0x0a, 0x12, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x72,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x6e, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x73, 0x69,
-	0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x69, 0x74, 0x12, 0x49,
-	0x0a, 0x13, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x5f, 0x69, 0x6e, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x65, 0x6e, 0x74, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x49, 0x6e, 0x69, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x49, 0x6e, 0x69, 0x74, 0x12, 0x4c, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6c, 0x69,
+	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x45, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x45, 0x6e, 0x64, 0x1a, 0x36, 0x0a, 0x08, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x62,
+	0x61, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x1a, 0x86, 0x02, 0x0a, 0x0b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x69, 0x74, 0x12, 0x34, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
+	0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e,
+	0x48, 0x74, 0x74, 0x70, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x30, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a,
+	0x2e, 0x48, 0x74, 0x74, 0x70, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x06, 0x6d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76,
+	0x69, 0x7a, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70,
+	0x61, 0x74, 0x68, 0x12, 0x2f, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
+	0x76, 0x69, 0x7a, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x52, 0x07, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x73, 0x1a, 0xdf, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x49, 0x6e, 0x69, 0x74, 0x12, 0x34, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70,
+	0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x2e, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x52, 0x02, 0x69, 0x64, 0x12, 0x47, 0x0a, 0x12, 0x73,
+	0x69, 0x6e, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x6e, 0x69,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x10, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x49, 0x6e, 0x69, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x68, 0x74, 0x74, 0x70, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x68, 0x74, 0x74, 0x70, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2f, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
+	0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x52, 0x07, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x1a, 0xd6, 0x02, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x45, 0x6e, 0x64, 0x12, 0x34, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61,
+	0x70, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x2e,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x52, 0x02, 0x69, 0x64, 0x12, 0x47, 0x0a, 0x12,
+	0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x6e,
+	0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x10, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x49, 0x6e, 0x69, 0x74, 0x12, 0x49, 0x0a, 0x13, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x69, 0x6e, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x73,
+	0x69, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x49, 0x6e, 0x69, 0x74,
+	0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x03, 0x65, 0x6f, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
+	0x76, 0x69, 0x7a, 0x2e, 0x45, 0x6f, 0x73, 0x52, 0x03, 0x65, 0x6f, 0x73, 0x12, 0x31, 0x0a, 0x08,
+	0x74, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x73, 0x52, 0x08, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x42,
+	0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x1a, 0xc3, 0x02, 0x0a, 0x07, 0x53, 0x75, 0x6d,
+	0x6d, 0x61, 0x72, 0x79, 0x12, 0x3c, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
+	0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x75, 0x6d,
+	0x6d, 0x61, 0x72, 0x79, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x06, 0x72, 0x6f, 0x75, 0x74,
+	0x65, 0x73, 0x1a, 0xf9, 0x01, 0x0a, 0x05, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x30, 0x0a, 0x06,
+	0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x48, 0x74, 0x74, 0x70,
+	0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61,
+	0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x73, 0x75, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x3a, 0x0a, 0x0b, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x5f, 0x70, 0x35, 0x30, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
 	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x49, 0x6e, 0x69, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73,
-	0x12, 0x23, 0x0a, 0x03, 0x65, 0x6f, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
-	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x45, 0x6f, 0x73,
-	0x52, 0x03, 0x65, 0x6f, 0x73, 0x12, 0x31, 0x0a, 0x08, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72,
-	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
-	0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x52, 0x08,
-	0x74, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e,
-	0x74, 0x22, 0x38, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00,
-	0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x42, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x01, 0x12, 0x0c, 0x0a,
-	0x08, 0x4f, 0x55, 0x54, 0x42, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x02, 0x42, 0x07, 0x0a, 0x05, 0x65,
-	0x76, 0x65, 0x6e, 0x74, 0x32, 0x99, 0x01, 0x0a, 0x03, 0x54, 0x61, 0x70, 0x12, 0x3e, 0x0a, 0x03,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50,
+	0x35, 0x30, 0x12, 0x3a, 0x0a, 0x0b, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x70, 0x39,
+	0x39, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0a, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x39, 0x39, 0x22, 0x38,
+	0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0b, 0x0a,
+	0x07, 0x49, 0x4e, 0x42, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x4f, 0x55,
+	0x54, 0x42, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x02, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x22, 0x4a, 0x0a, 0x0f, 0x54, 0x61, 0x70, 0x70, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
+	0x76, 0x69, 0x7a, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x94, 0x03,
+	0x0a, 0x10, 0x54, 0x61, 0x70, 0x70, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x45, 0x0a, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
+	0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x70, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x09,
+	0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x1a, 0x58, 0x0a, 0x03, 0x50, 0x6f, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3d, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
+	0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x70, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x1a, 0x76, 0x0a, 0x08, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x12,
+	0x32, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x76, 0x69, 0x7a,
+	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x12, 0x36, 0x0a, 0x04, 0x70, 0x6f, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70,
+	0x2e, 0x54, 0x61, 0x70, 0x70, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x50, 0x6f, 0x64, 0x52, 0x04, 0x70, 0x6f, 0x64, 0x73, 0x22, 0x67, 0x0a, 0x06, 0x52,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x0c, 0x0a, 0x08, 0x54, 0x41, 0x50, 0x50, 0x41, 0x42, 0x4c,
+	0x45, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x4e, 0x4f, 0x54, 0x5f, 0x4d, 0x45, 0x53, 0x48, 0x45,
+	0x44, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x44, 0x49, 0x53, 0x41, 0x42, 0x4c, 0x45, 0x44, 0x5f,
+	0x42, 0x59, 0x5f, 0x50, 0x4f, 0x44, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x44, 0x49, 0x53, 0x41,
+	0x42, 0x4c, 0x45, 0x44, 0x5f, 0x42, 0x59, 0x5f, 0x4e, 0x41, 0x4d, 0x45, 0x53, 0x50, 0x41, 0x43,
+	0x45, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x4e, 0x41, 0x42, 0x4c,
+	0x45, 0x44, 0x10, 0x04, 0x32, 0xe4, 0x01, 0x0a, 0x03, 0x54, 0x61, 0x70, 0x12, 0x3e, 0x0a, 0x03,
 	0x54, 0x61, 0x70, 0x12, 0x18, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74,
 	0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
 	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70,
@@ -1586,10 +2299,15 @@ var file_viz_tap_proto_rawDesc = []byte{
 	0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x1a, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70,
 	0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x03, 0x88, 0x02, 0x01, 0x30, 0x01,
-	0x42, 0x2d, 0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c,
-	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x2f, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2f,
-	0x76, 0x69, 0x7a, 0x2f, 0x74, 0x61, 0x70, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x74, 0x61, 0x70, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x12, 0x49, 0x0a, 0x08, 0x54, 0x61, 0x70, 0x70, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1d, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x70,
+	0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6c, 0x69,
+	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x74, 0x61, 0x70, 0x2e, 0x54, 0x61, 0x70, 0x70, 0x61,
+	0x62, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2d, 0x5a, 0x2b, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
+	0x64, 0x2f, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2f, 0x76, 0x69, 0x7a, 0x2f, 0x74,
+	0x61, 0x70, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x74, 0x61, 0x70, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -1604,81 +2322,108 @@ func file_viz_tap_proto_rawDescGZIP() []byte {
 	return file_viz_tap_proto_rawDescData
 }
 
-var file_viz_tap_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_viz_tap_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_viz_tap_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_viz_tap_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_viz_tap_proto_goTypes = []interface{}{
-	(TapEvent_ProxyDirection)(0),                      // 0: linkerd2.tap.TapEvent.ProxyDirection
-	(*TapRequest)(nil),                                // 1: linkerd2.tap.TapRequest
-	(*TapByResourceRequest)(nil),                      // 2: linkerd2.tap.TapByResourceRequest
-	(*TapEvent)(nil),                                  // 3: linkerd2.tap.TapEvent
-	(*TapByResourceRequest_Match)(nil),                // 4: linkerd2.tap.TapByResourceRequest.Match
-	(*TapByResourceRequest_Extract)(nil),              // 5: linkerd2.tap.TapByResourceRequest.Extract
-	(*TapByResourceRequest_Match_Seq)(nil),            // 6: linkerd2.tap.TapByResourceRequest.Match.Seq
-	(*TapByResourceRequest_Match_Http)(nil),           // 7: linkerd2.tap.TapByResourceRequest.Match.Http
-	(*TapByResourceRequest_Extract_Http)(nil),         // 8: linkerd2.tap.TapByResourceRequest.Extract.Http
-	(*TapByResourceRequest_Extract_Http_Headers)(nil), // 9: linkerd2.tap.TapByResourceRequest.Extract.Http.Headers
-	(*TapEvent_EndpointMeta)(nil),                     // 10: linkerd2.tap.TapEvent.EndpointMeta
-	(*TapEvent_RouteMeta)(nil),                        // 11: linkerd2.tap.TapEvent.RouteMeta
-	(*TapEvent_Http)(nil),                             // 12: linkerd2.tap.TapEvent.Http
-	nil,                                               // 13: linkerd2.tap.TapEvent.EndpointMeta.LabelsEntry
-	nil,                                               // 14: linkerd2.tap.TapEvent.RouteMeta.LabelsEntry
-	(*TapEvent_Http_StreamId)(nil),                    // 15: linkerd2.tap.TapEvent.Http.StreamId
-	(*TapEvent_Http_RequestInit)(nil),                 // 16: linkerd2.tap.TapEvent.Http.RequestInit
-	(*TapEvent_Http_ResponseInit)(nil),                // 17: linkerd2.tap.TapEvent.Http.ResponseInit
-	(*TapEvent_Http_ResponseEnd)(nil),                 // 18: linkerd2.tap.TapEvent.Http.ResponseEnd
-	(*viz.ResourceSelection)(nil),                     // 19: linkerd2.viz.ResourceSelection
-	(*net.TcpAddress)(nil),                            // 20: linkerd2.common.net.TcpAddress
-	(*viz.HttpMethod)(nil),                            // 21: linkerd2.viz.HttpMethod
-	(*viz.Scheme)(nil),                                // 22: linkerd2.viz.Scheme
-	(*viz.Headers)(nil),                               // 23: linkerd2.viz.Headers
-	(*duration.Duration)(nil),                         // 24: google.protobuf.Duration
-	(*viz.Eos)(nil),                                   // 25: linkerd2.viz.Eos
+	(TapByResourceRequest_Match_Response_Class)(0),    // 0: linkerd2.tap.TapByResourceRequest.Match.Response.Class
+	(TapEvent_ProxyDirection)(0),                      // 1: linkerd2.tap.TapEvent.ProxyDirection
+	(TappableResponse_Reason)(0),                      // 2: linkerd2.tap.TappableResponse.Reason
+	(*TapRequest)(nil),                                // 3: linkerd2.tap.TapRequest
+	(*TapByResourceRequest)(nil),                      // 4: linkerd2.tap.TapByResourceRequest
+	(*TapEvent)(nil),                                  // 5: linkerd2.tap.TapEvent
+	(*TappableRequest)(nil),                           // 6: linkerd2.tap.TappableRequest
+	(*TappableResponse)(nil),                          // 7: linkerd2.tap.TappableResponse
+	(*TapByResourceRequest_Match)(nil),                // 8: linkerd2.tap.TapByResourceRequest.Match
+	(*TapByResourceRequest_Extract)(nil),              // 9: linkerd2.tap.TapByResourceRequest.Extract
+	(*TapByResourceRequest_Aggregate)(nil),            // 10: linkerd2.tap.TapByResourceRequest.Aggregate
+	(*TapByResourceRequest_Match_Seq)(nil),            // 11: linkerd2.tap.TapByResourceRequest.Match.Seq
+	(*TapByResourceRequest_Match_Http)(nil),           // 12: linkerd2.tap.TapByResourceRequest.Match.Http
+	(*TapByResourceRequest_Match_Response)(nil),       // 13: linkerd2.tap.TapByResourceRequest.Match.Response
+	(*TapByResourceRequest_Extract_Http)(nil),         // 14: linkerd2.tap.TapByResourceRequest.Extract.Http
+	(*TapByResourceRequest_Extract_Http_Headers)(nil), // 15: linkerd2.tap.TapByResourceRequest.Extract.Http.Headers
+	(*TapEvent_EndpointMeta)(nil),                     // 16: linkerd2.tap.TapEvent.EndpointMeta
+	(*TapEvent_RouteMeta)(nil),                        // 17: linkerd2.tap.TapEvent.RouteMeta
+	(*TapEvent_Http)(nil),                             // 18: linkerd2.tap.TapEvent.Http
+	(*TapEvent_Summary)(nil),                          // 19: linkerd2.tap.TapEvent.Summary
+	nil,                                               // 20: linkerd2.tap.TapEvent.EndpointMeta.LabelsEntry
+	nil,                                               // 21: linkerd2.tap.TapEvent.RouteMeta.LabelsEntry
+	(*TapEvent_Http_StreamId)(nil),                    // 22: linkerd2.tap.TapEvent.Http.StreamId
+	(*TapEvent_Http_RequestInit)(nil),                 // 23: linkerd2.tap.TapEvent.Http.RequestInit
+	(*TapEvent_Http_ResponseInit)(nil),                // 24: linkerd2.tap.TapEvent.Http.ResponseInit
+	(*TapEvent_Http_ResponseEnd)(nil),                 // 25: linkerd2.tap.TapEvent.Http.ResponseEnd
+	(*TapEvent_Summary_Route)(nil),                    // 26: linkerd2.tap.TapEvent.Summary.Route
+	(*TappableResponse_Pod)(nil),                      // 27: linkerd2.tap.TappableResponse.Pod
+	(*TappableResponse_Workload)(nil),                 // 28: linkerd2.tap.TappableResponse.Workload
+	(*viz.ResourceSelection)(nil),                     // 29: linkerd2.viz.ResourceSelection
+	(*net.TcpAddress)(nil),                            // 30: linkerd2.common.net.TcpAddress
+	(*duration.Duration)(nil),                         // 31: google.protobuf.Duration
+	(*viz.HttpMethod)(nil),                            // 32: linkerd2.viz.HttpMethod
+	(*viz.Scheme)(nil),                                // 33: linkerd2.viz.Scheme
+	(*viz.Headers)(nil),                               // 34: linkerd2.viz.Headers
+	(*viz.Eos)(nil),                                   // 35: linkerd2.viz.Eos
+	(*viz.Resource)(nil),                              // 36: linkerd2.viz.Resource
 }
 var file_viz_tap_proto_depIdxs = []int32{
-	19, // 0: linkerd2.tap.TapByResourceRequest.target:type_name -> linkerd2.viz.ResourceSelection
-	4,  // 1: linkerd2.tap.TapByResourceRequest.match:type_name -> linkerd2.tap.TapByResourceRequest.Match
-	5,  // 2: linkerd2.tap.TapByResourceRequest.extract:type_name -> linkerd2.tap.TapByResourceRequest.Extract
-	20, // 3: linkerd2.tap.TapEvent.source:type_name -> linkerd2.common.net.TcpAddress
-	10, // 4: linkerd2.tap.TapEvent.source_meta:type_name -> linkerd2.tap.TapEvent.EndpointMeta
-	20, // 5: linkerd2.tap.TapEvent.destination:type_name -> linkerd2.common.net.TcpAddress
-	10, // 6: linkerd2.tap.TapEvent.destination_meta:type_name -> linkerd2.tap.TapEvent.EndpointMeta
-	11, // 7: linkerd2.tap.TapEvent.route_meta:type_name -> linkerd2.tap.TapEvent.RouteMeta
-	0,  // 8: linkerd2.tap.TapEvent.proxy_direction:type_name -> linkerd2.tap.TapEvent.ProxyDirection
-	12, // 9: linkerd2.tap.TapEvent.http:type_name -> linkerd2.tap.TapEvent.Http
-	6,  // 10: linkerd2.tap.TapByResourceRequest.Match.all:type_name -> linkerd2.tap.TapByResourceRequest.Match.Seq
-	6,  // 11: linkerd2.tap.TapByResourceRequest.Match.any:type_name -> linkerd2.tap.TapByResourceRequest.Match.Seq
-	4,  // 12: linkerd2.tap.TapByResourceRequest.Match.not:type_name -> linkerd2.tap.TapByResourceRequest.Match
-	19, // 13: linkerd2.tap.TapByResourceRequest.Match.destinations:type_name -> linkerd2.viz.ResourceSelection
-	7,  // 14: linkerd2.tap.TapByResourceRequest.Match.http:type_name -> linkerd2.tap.TapByResourceRequest.Match.Http
-	8,  // 15: linkerd2.tap.TapByResourceRequest.Extract.http:type_name -> linkerd2.tap.TapByResourceRequest.Extract.Http
-	4,  // 16: linkerd2.tap.TapByResourceRequest.Match.Seq.matches:type_name -> linkerd2.tap.TapByResourceRequest.Match
-	9,  // 17: linkerd2.tap.TapByResourceRequest.Extract.Http.headers:type_name -> linkerd2.tap.TapByResourceRequest.Extract.Http.Headers
-	13, // 18: linkerd2.tap.TapEvent.EndpointMeta.labels:type_name -> linkerd2.tap.TapEvent.EndpointMeta.LabelsEntry
-	14, // 19: linkerd2.tap.TapEvent.RouteMeta.labels:type_name -> linkerd2.tap.TapEvent.RouteMeta.LabelsEntry
-	16, // 20: linkerd2.tap.TapEvent.Http.request_init:type_name -> linkerd2.tap.TapEvent.Http.RequestInit
-	17, // 21: linkerd2.tap.TapEvent.Http.response_init:type_name -> linkerd2.tap.TapEvent.Http.ResponseInit
-	18, // 22: linkerd2.tap.TapEvent.Http.response_end:type_name -> linkerd2.tap.TapEvent.Http.ResponseEnd
-	15, // 23: linkerd2.tap.TapEvent.Http.RequestInit.id:type_name -> linkerd2.tap.TapEvent.Http.StreamId
-	21, // 24: linkerd2.tap.TapEvent.Http.RequestInit.method:type_name -> linkerd2.viz.HttpMethod
-	22, // 25: linkerd2.tap.TapEvent.Http.RequestInit.scheme:type_name -> linkerd2.viz.Scheme
-	23, // 26: linkerd2.tap.TapEvent.Http.RequestInit.headers:type_name -> linkerd2.viz.Headers
-	15, // 27: linkerd2.tap.TapEvent.Http.ResponseInit.id:type_name -> linkerd2.tap.TapEvent.Http.StreamId
-	24, // 28: linkerd2.tap.TapEvent.Http.ResponseInit.since_request_init:type_name -> google.protobuf.Duration
-	23, // 29: linkerd2.tap.TapEvent.Http.ResponseInit.headers:type_name -> linkerd2.viz.Headers
-	15, // 30: linkerd2.tap.TapEvent.Http.ResponseEnd.id:type_name -> linkerd2.tap.TapEvent.Http.StreamId
-	24, // 31: linkerd2.tap.TapEvent.Http.ResponseEnd.since_request_init:type_name -> google.protobuf.Duration
-	24, // 32: linkerd2.tap.TapEvent.Http.ResponseEnd.since_response_init:type_name -> google.protobuf.Duration
-	25, // 33: linkerd2.tap.TapEvent.Http.ResponseEnd.eos:type_name -> linkerd2.viz.Eos
-	23, // 34: linkerd2.tap.TapEvent.Http.ResponseEnd.trailers:type_name -> linkerd2.viz.Headers
-	1,  // 35: linkerd2.tap.Tap.Tap:input_type -> linkerd2.tap.TapRequest
-	2,  // 36: linkerd2.tap.Tap.TapByResource:input_type -> linkerd2.tap.TapByResourceRequest
-	3,  // 37: linkerd2.tap.Tap.Tap:output_type -> linkerd2.tap.TapEvent
-	3,  // 38: linkerd2.tap.Tap.TapByResource:output_type -> linkerd2.tap.TapEvent
-	37, // [37:39] is the sub-list for method output_type
-	35, // [35:37] is the sub-list for method input_type
-	35, // [35:35] is the sub-list for extension type_name
-	35, // [35:35] is the sub-list for extension extendee
-	0,  // [0:35] is the sub-list for field type_name
+	29, // 0: linkerd2.tap.TapByResourceRequest.target:type_name -> linkerd2.viz.ResourceSelection
+	8,  // 1: linkerd2.tap.TapByResourceRequest.match:type_name -> linkerd2.tap.TapByResourceRequest.Match
+	9,  // 2: linkerd2.tap.TapByResourceRequest.extract:type_name -> linkerd2.tap.TapByResourceRequest.Extract
+	10, // 3: linkerd2.tap.TapByResourceRequest.aggregate:type_name -> linkerd2.tap.TapByResourceRequest.Aggregate
+	30, // 4: linkerd2.tap.TapEvent.source:type_name -> linkerd2.common.net.TcpAddress
+	16, // 5: linkerd2.tap.TapEvent.source_meta:type_name -> linkerd2.tap.TapEvent.EndpointMeta
+	30, // 6: linkerd2.tap.TapEvent.destination:type_name -> linkerd2.common.net.TcpAddress
+	16, // 7: linkerd2.tap.TapEvent.destination_meta:type_name -> linkerd2.tap.TapEvent.EndpointMeta
+	17, // 8: linkerd2.tap.TapEvent.route_meta:type_name -> linkerd2.tap.TapEvent.RouteMeta
+	1,  // 9: linkerd2.tap.TapEvent.proxy_direction:type_name -> linkerd2.tap.TapEvent.ProxyDirection
+	18, // 10: linkerd2.tap.TapEvent.http:type_name -> linkerd2.tap.TapEvent.Http
+	19, // 11: linkerd2.tap.TapEvent.summary:type_name -> linkerd2.tap.TapEvent.Summary
+	29, // 12: linkerd2.tap.TappableRequest.target:type_name -> linkerd2.viz.ResourceSelection
+	28, // 13: linkerd2.tap.TappableResponse.workloads:type_name -> linkerd2.tap.TappableResponse.Workload
+	11, // 14: linkerd2.tap.TapByResourceRequest.Match.all:type_name -> linkerd2.tap.TapByResourceRequest.Match.Seq
+	11, // 15: linkerd2.tap.TapByResourceRequest.Match.any:type_name -> linkerd2.tap.TapByResourceRequest.Match.Seq
+	8,  // 16: linkerd2.tap.TapByResourceRequest.Match.not:type_name -> linkerd2.tap.TapByResourceRequest.Match
+	29, // 17: linkerd2.tap.TapByResourceRequest.Match.destinations:type_name -> linkerd2.viz.ResourceSelection
+	12, // 18: linkerd2.tap.TapByResourceRequest.Match.http:type_name -> linkerd2.tap.TapByResourceRequest.Match.Http
+	13, // 19: linkerd2.tap.TapByResourceRequest.Match.response:type_name -> linkerd2.tap.TapByResourceRequest.Match.Response
+	14, // 20: linkerd2.tap.TapByResourceRequest.Extract.http:type_name -> linkerd2.tap.TapByResourceRequest.Extract.Http
+	31, // 21: linkerd2.tap.TapByResourceRequest.Aggregate.interval:type_name -> google.protobuf.Duration
+	8,  // 22: linkerd2.tap.TapByResourceRequest.Match.Seq.matches:type_name -> linkerd2.tap.TapByResourceRequest.Match
+	0,  // 23: linkerd2.tap.TapByResourceRequest.Match.Response.class:type_name -> linkerd2.tap.TapByResourceRequest.Match.Response.Class
+	15, // 24: linkerd2.tap.TapByResourceRequest.Extract.Http.headers:type_name -> linkerd2.tap.TapByResourceRequest.Extract.Http.Headers
+	20, // 25: linkerd2.tap.TapEvent.EndpointMeta.labels:type_name -> linkerd2.tap.TapEvent.EndpointMeta.LabelsEntry
+	21, // 26: linkerd2.tap.TapEvent.RouteMeta.labels:type_name -> linkerd2.tap.TapEvent.RouteMeta.LabelsEntry
+	23, // 27: linkerd2.tap.TapEvent.Http.request_init:type_name -> linkerd2.tap.TapEvent.Http.RequestInit
+	24, // 28: linkerd2.tap.TapEvent.Http.response_init:type_name -> linkerd2.tap.TapEvent.Http.ResponseInit
+	25, // 29: linkerd2.tap.TapEvent.Http.response_end:type_name -> linkerd2.tap.TapEvent.Http.ResponseEnd
+	26, // 30: linkerd2.tap.TapEvent.Summary.routes:type_name -> linkerd2.tap.TapEvent.Summary.Route
+	22, // 31: linkerd2.tap.TapEvent.Http.RequestInit.id:type_name -> linkerd2.tap.TapEvent.Http.StreamId
+	32, // 32: linkerd2.tap.TapEvent.Http.RequestInit.method:type_name -> linkerd2.viz.HttpMethod
+	33, // 33: linkerd2.tap.TapEvent.Http.RequestInit.scheme:type_name -> linkerd2.viz.Scheme
+	34, // 34: linkerd2.tap.TapEvent.Http.RequestInit.headers:type_name -> linkerd2.viz.Headers
+	22, // 35: linkerd2.tap.TapEvent.Http.ResponseInit.id:type_name -> linkerd2.tap.TapEvent.Http.StreamId
+	31, // 36: linkerd2.tap.TapEvent.Http.ResponseInit.since_request_init:type_name -> google.protobuf.Duration
+	34, // 37: linkerd2.tap.TapEvent.Http.ResponseInit.headers:type_name -> linkerd2.viz.Headers
+	22, // 38: linkerd2.tap.TapEvent.Http.ResponseEnd.id:type_name -> linkerd2.tap.TapEvent.Http.StreamId
+	31, // 39: linkerd2.tap.TapEvent.Http.ResponseEnd.since_request_init:type_name -> google.protobuf.Duration
+	31, // 40: linkerd2.tap.TapEvent.Http.ResponseEnd.since_response_init:type_name -> google.protobuf.Duration
+	35, // 41: linkerd2.tap.TapEvent.Http.ResponseEnd.eos:type_name -> linkerd2.viz.Eos
+	34, // 42: linkerd2.tap.TapEvent.Http.ResponseEnd.trailers:type_name -> linkerd2.viz.Headers
+	32, // 43: linkerd2.tap.TapEvent.Summary.Route.method:type_name -> linkerd2.viz.HttpMethod
+	31, // 44: linkerd2.tap.TapEvent.Summary.Route.latency_p50:type_name -> google.protobuf.Duration
+	31, // 45: linkerd2.tap.TapEvent.Summary.Route.latency_p99:type_name -> google.protobuf.Duration
+	2,  // 46: linkerd2.tap.TappableResponse.Pod.reason:type_name -> linkerd2.tap.TappableResponse.Reason
+	36, // 47: linkerd2.tap.TappableResponse.Workload.resource:type_name -> linkerd2.viz.Resource
+	27, // 48: linkerd2.tap.TappableResponse.Workload.pods:type_name -> linkerd2.tap.TappableResponse.Pod
+	3,  // 49: linkerd2.tap.Tap.Tap:input_type -> linkerd2.tap.TapRequest
+	4,  // 50: linkerd2.tap.Tap.TapByResource:input_type -> linkerd2.tap.TapByResourceRequest
+	6,  // 51: linkerd2.tap.Tap.Tappable:input_type -> linkerd2.tap.TappableRequest
+	5,  // 52: linkerd2.tap.Tap.Tap:output_type -> linkerd2.tap.TapEvent
+	5,  // 53: linkerd2.tap.Tap.TapByResource:output_type -> linkerd2.tap.TapEvent
+	7,  // 54: linkerd2.tap.Tap.Tappable:output_type -> linkerd2.tap.TappableResponse
+	52, // [52:55] is the sub-list for method output_type
+	49, // [49:52] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
 }
 
 func init() { file_viz_tap_proto_init() }
@@ -1724,7 +2469,7 @@ func file_viz_tap_proto_init() {
 			}
 		}
 		file_viz_tap_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TapByResourceRequest_Match); i {
+			switch v := v.(*TappableRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1736,7 +2481,7 @@ func file_viz_tap_proto_init() {
 			}
 		}
 		file_viz_tap_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TapByResourceRequest_Extract); i {
+			switch v := v.(*TappableResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1748,7 +2493,7 @@ func file_viz_tap_proto_init() {
 			}
 		}
 		file_viz_tap_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TapByResourceRequest_Match_Seq); i {
+			switch v := v.(*TapByResourceRequest_Match); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1760,7 +2505,7 @@ func file_viz_tap_proto_init() {
 			}
 		}
 		file_viz_tap_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TapByResourceRequest_Match_Http); i {
+			switch v := v.(*TapByResourceRequest_Extract); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1772,7 +2517,7 @@ func file_viz_tap_proto_init() {
 			}
 		}
 		file_viz_tap_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TapByResourceRequest_Extract_Http); i {
+			switch v := v.(*TapByResourceRequest_Aggregate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1784,7 +2529,7 @@ func file_viz_tap_proto_init() {
 			}
 		}
 		file_viz_tap_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TapByResourceRequest_Extract_Http_Headers); i {
+			switch v := v.(*TapByResourceRequest_Match_Seq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1796,7 +2541,7 @@ func file_viz_tap_proto_init() {
 			}
 		}
 		file_viz_tap_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TapEvent_EndpointMeta); i {
+			switch v := v.(*TapByResourceRequest_Match_Http); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1808,7 +2553,7 @@ func file_viz_tap_proto_init() {
 			}
 		}
 		file_viz_tap_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TapEvent_RouteMeta); i {
+			switch v := v.(*TapByResourceRequest_Match_Response); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1820,7 +2565,31 @@ func file_viz_tap_proto_init() {
 			}
 		}
 		file_viz_tap_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TapEvent_Http); i {
+			switch v := v.(*TapByResourceRequest_Extract_Http); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_tap_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TapByResourceRequest_Extract_Http_Headers); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_tap_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TapEvent_EndpointMeta); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1832,7 +2601,7 @@ func file_viz_tap_proto_init() {
 			}
 		}
 		file_viz_tap_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TapEvent_Http_StreamId); i {
+			switch v := v.(*TapEvent_RouteMeta); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1844,7 +2613,7 @@ func file_viz_tap_proto_init() {
 			}
 		}
 		file_viz_tap_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TapEvent_Http_RequestInit); i {
+			switch v := v.(*TapEvent_Http); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1856,6 +2625,42 @@ func file_viz_tap_proto_init() {
 			}
 		}
 		file_viz_tap_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TapEvent_Summary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_tap_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TapEvent_Http_StreamId); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_tap_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TapEvent_Http_RequestInit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_tap_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TapEvent_Http_ResponseInit); i {
 			case 0:
 				return &v.state
@@ -1867,7 +2672,7 @@ func file_viz_tap_proto_init() {
 				return nil
 			}
 		}
-		file_viz_tap_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_viz_tap_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TapEvent_Http_ResponseEnd); i {
 			case 0:
 				return &v.state
@@ -1879,6 +2684,42 @@ func file_viz_tap_proto_init() {
 				return nil
 			}
 		}
+		file_viz_tap_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TapEvent_Summary_Route); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_tap_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TappableResponse_Pod); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_viz_tap_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TappableResponse_Workload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_viz_tap_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*TapRequest_Pod)(nil),
@@ -1886,27 +2727,33 @@ func file_viz_tap_proto_init() {
 	}
 	file_viz_tap_proto_msgTypes[2].OneofWrappers = []interface{}{
 		(*TapEvent_Http_)(nil),
+		(*TapEvent_Summary_)(nil),
 	}
-	file_viz_tap_proto_msgTypes[3].OneofWrappers = []interface{}{
+	file_viz_tap_proto_msgTypes[5].OneofWrappers = []interface{}{
 		(*TapByResourceRequest_Match_All)(nil),
 		(*TapByResourceRequest_Match_Any)(nil),
 		(*TapByResourceRequest_Match_Not)(nil),
 		(*TapByResourceRequest_Match_Destinations)(nil),
 		(*TapByResourceRequest_Match_Http_)(nil),
+		(*TapByResourceRequest_Match_Response_)(nil),
 	}
-	file_viz_tap_proto_msgTypes[4].OneofWrappers = []interface{}{
+	file_viz_tap_proto_msgTypes[6].OneofWrappers = []interface{}{
 		(*TapByResourceRequest_Extract_Http_)(nil),
 	}
-	file_viz_tap_proto_msgTypes[6].OneofWrappers = []interface{}{
+	file_viz_tap_proto_msgTypes[9].OneofWrappers = []interface{}{
 		(*TapByResourceRequest_Match_Http_Scheme)(nil),
 		(*TapByResourceRequest_Match_Http_Method)(nil),
 		(*TapByResourceRequest_Match_Http_Authority)(nil),
 		(*TapByResourceRequest_Match_Http_Path)(nil),
 	}
-	file_viz_tap_proto_msgTypes[7].OneofWrappers = []interface{}{
-		(*TapByResourceRequest_Extract_Http_Headers_)(nil),
+	file_viz_tap_proto_msgTypes[10].OneofWrappers = []interface{}{
+		(*TapByResourceRequest_Match_Response_GrpcStatus)(nil),
+		(*TapByResourceRequest_Match_Response_Class_)(nil),
 	}
 	file_viz_tap_proto_msgTypes[11].OneofWrappers = []interface{}{
+		(*TapByResourceRequest_Extract_Http_Headers_)(nil),
+	}
+	file_viz_tap_proto_msgTypes[15].OneofWrappers = []interface{}{
 		(*TapEvent_Http_RequestInit_)(nil),
 		(*TapEvent_Http_ResponseInit_)(nil),
 		(*TapEvent_Http_ResponseEnd_)(nil),
@@ -1916,8 +2763,8 @@ func file_viz_tap_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_viz_tap_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   18,
+			NumEnums:      3,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -26,6 +26,8 @@ type TapClient interface {
 	Tap(ctx context.Context, in *TapRequest, opts ...grpc.CallOption) (Tap_TapClient, error)
 	// Deprecated: Do not use.
 	TapByResource(ctx context.Context, in *TapByResourceRequest, opts ...grpc.CallOption) (Tap_TapByResourceClient, error)
+	// Reports which pods of the targeted resources can be tapped, and why not.
+	Tappable(ctx context.Context, in *TappableRequest, opts ...grpc.CallOption) (*TappableResponse, error)
 }
 
 type tapClient struct {
@@ -102,6 +104,15 @@ func (x *tapTapByResourceClient) Recv() (*TapEvent, error) {
 	return m, nil
 }
 
+func (c *tapClient) Tappable(ctx context.Context, in *TappableRequest, opts ...grpc.CallOption) (*TappableResponse, error) {
+	out := new(TappableResponse)
+	err := c.cc.Invoke(ctx, "/linkerd2.tap.Tap/Tappable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TapServer is the server API for Tap service.
 // All implementations must embed UnimplementedTapServer
 // for forward compatibility
@@ -110,6 +121,8 @@ type TapServer interface {
 	Tap(*TapRequest, Tap_TapServer) error
 	// Deprecated: Do not use.
 	TapByResource(*TapByResourceRequest, Tap_TapByResourceServer) error
+	// Reports which pods of the targeted resources can be tapped, and why not.
+	Tappable(context.Context, *TappableRequest) (*TappableResponse, error)
 	mustEmbedUnimplementedTapServer()
 }
 
@@ -123,6 +136,9 @@ func (UnimplementedTapServer) Tap(*TapRequest, Tap_TapServer) error {
 func (UnimplementedTapServer) TapByResource(*TapByResourceRequest, Tap_TapByResourceServer) error {
 	return status.Errorf(codes.Unimplemented, "method TapByResource not implemented")
 }
+func (UnimplementedTapServer) Tappable(context.Context, *TappableRequest) (*TappableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Tappable not implemented")
+}
 func (UnimplementedTapServer) mustEmbedUnimplementedTapServer() {}
 
 // UnsafeTapServer may be embedded to opt out of forward compatibility for this service.
@@ -178,13 +194,36 @@ func (x *tapTapByResourceServer) Send(m *TapEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Tap_Tappable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TappableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TapServer).Tappable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/linkerd2.tap.Tap/Tappable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TapServer).Tappable(ctx, req.(*TappableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Tap_ServiceDesc is the grpc.ServiceDesc for Tap service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var Tap_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "linkerd2.tap.Tap",
 	HandlerType: (*TapServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Tappable",
+			Handler:    _Tap_Tappable_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "Tap",
@@ -17,6 +17,8 @@ func Main(args []string) {
 	addr := cmd.String("addr", ":8443", "address to serve on")
 	kubeconfig := cmd.String("kubeconfig", "", "path to kubeconfig")
 	tapSvcName := cmd.String("tap-service-name", "", "name of the tap service")
+	cryptoMode := flags.AddCryptoModeFlag(cmd)
+	adminTLS := flags.AddAdminTLSFlags(cmd)
 	flags.ConfigureAndParse(cmd, args)
 	webhook.Launch(
 		context.Background(),
@@ -26,5 +28,7 @@ func Main(args []string) {
 		*metricsAddr,
 		*addr,
 		*kubeconfig,
+		*cryptoMode,
+		adminTLS,
 	)
 }
@@ -23,7 +23,7 @@ type Params struct {
 
 // Mutate mutates an AdmissionRequest and adds the LINKERD2_PROXY_TAP_SVC_NAME
 // env var to a pod's proxy container if tap is not disabled via annotation on the
-// pod or the namespace.
+// pod or the namespace, the pod's annotation taking precedence.
 func Mutate(tapSvcName string) webhook.Handler {
 	return func(
 		ctx context.Context,
@@ -52,7 +52,8 @@ func Mutate(tapSvcName string) webhook.Handler {
 			return nil, err
 		}
 		var t *template.Template
-		if vizLabels.IsTapDisabled(namespace) || vizLabels.IsTapDisabled(pod) {
+		if disabledBy := vizLabels.TapDisabledBy(namespace, pod); disabledBy != "" {
+			log.Debugf("tap disabled by the %s annotation of the %s", vizLabels.VizTapDisabled, disabledBy)
 			return admissionResponse, nil
 		}
 		t, err = template.New("tpl").Parse(tpl)
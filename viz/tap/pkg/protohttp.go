@@ -4,25 +4,34 @@ import (
 	"fmt"
 
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	metricsPb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
 	tapPb "github.com/linkerd/linkerd2/viz/tap/gen/tap"
 )
 
 // TapReqToURL converts a TapByResourceRequest protobuf object to a URL for use
 // with the Kubernetes tap.linkerd.io APIService.
 func TapReqToURL(req *tapPb.TapByResourceRequest) string {
-	res := req.GetTarget().GetResource()
+	return resourceURL(req.GetTarget().GetResource(), "tap")
+}
+
+// TappableReqToURL converts a TappableRequest protobuf object to a URL for
+// use with the Kubernetes tap.linkerd.io APIService.
+func TappableReqToURL(req *tapPb.TappableRequest) string {
+	return resourceURL(req.GetTarget().GetResource(), "tappable")
+}
 
+func resourceURL(res *metricsPb.Resource, subresource string) string {
 	// non-namespaced
 	if res.GetType() == k8s.Namespace {
 		return fmt.Sprintf(
-			"/apis/tap.linkerd.io/v1alpha1/watch/namespaces/%s/tap",
-			res.GetName(),
+			"/apis/tap.linkerd.io/v1alpha1/watch/namespaces/%s/%s",
+			res.GetName(), subresource,
 		)
 	}
 
 	// namespaced
 	return fmt.Sprintf(
-		"/apis/tap.linkerd.io/v1alpha1/watch/namespaces/%s/%s/%s/tap",
-		res.GetNamespace(), res.GetType()+"s", res.GetName(),
+		"/apis/tap.linkerd.io/v1alpha1/watch/namespaces/%s/%s/%s/%s",
+		res.GetNamespace(), res.GetType()+"s", res.GetName(), subresource,
 	)
 }
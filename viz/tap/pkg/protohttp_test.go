@@ -52,3 +52,19 @@ func TestTapReqToURL(t *testing.T) {
 		})
 	}
 }
+
+func TestTappableReqToURL(t *testing.T) {
+	req := &tapPb.TappableRequest{
+		Target: &metricsPb.ResourceSelection{
+			Resource: &metricsPb.Resource{
+				Namespace: "test-ns",
+				Type:      "test-type",
+				Name:      "test-name",
+			},
+		},
+	}
+	expected := "/apis/tap.linkerd.io/v1alpha1/watch/namespaces/test-ns/test-types/test-name/tappable"
+	if url := TappableReqToURL(req); url != expected {
+		t.Fatalf("Unexpected url: %s, Expected: %s", url, expected)
+	}
+}
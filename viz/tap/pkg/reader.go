@@ -28,21 +28,50 @@ const TapRbacURL = "https://linkerd.io/tap-rbac"
 // Reader initiates a TapByResourceRequest and returns a buffered Reader.
 // It is the caller's responsibility to call Close() on the io.ReadCloser.
 func Reader(ctx context.Context, k8sAPI *k8s.KubernetesAPI, req *pb.TapByResourceRequest) (*bufio.Reader, io.ReadCloser, error) {
-	client, err := k8sAPI.NewClient()
+	httpRsp, err := post(ctx, k8sAPI, TapReqToURL(req), req)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	reader := bufio.NewReader(httpRsp.Body)
+
+	return reader, httpRsp.Body, nil
+}
+
+// Tappable reports which pods of the resources targeted by the request can
+// be tapped, and why the others can't.
+func Tappable(ctx context.Context, k8sAPI *k8s.KubernetesAPI, req *pb.TappableRequest) (*pb.TappableResponse, error) {
+	httpRsp, err := post(ctx, k8sAPI, TappableReqToURL(req), req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpRsp.Body.Close()
+
+	var rsp pb.TappableResponse
+	if err := protohttp.FromByteStreamToProtocolBuffers(bufio.NewReader(httpRsp.Body), &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+// post sends the request to the path of the tap APIService, returning the
+// response if it's not an error
+func post(ctx context.Context, k8sAPI *k8s.KubernetesAPI, path string, req proto.Message) (*http.Response, error) {
+	client, err := k8sAPI.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
 	reqBytes, err := proto.Marshal(req)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	url, err := url.Parse(k8sAPI.Host)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	url.Path = fmt.Sprintf("%s%s", url.Path, TapReqToURL(req))
+	url.Path = fmt.Sprintf("%s%s", url.Path, path)
 
 	httpReq, err := http.NewRequest(
 		http.MethodPost,
@@ -50,23 +79,21 @@ func Reader(ctx context.Context, k8sAPI *k8s.KubernetesAPI, req *pb.TapByResourc
 		bytes.NewReader(reqBytes),
 	)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	httpRsp, err := client.Do(httpReq.WithContext(ctx))
 	if err != nil {
 		log.Debugf("Error invoking [%s]: %v", url, err)
-		return nil, nil, err
+		return nil, err
 	}
 
 	log.Debugf("Response from [%s] had headers: %v", url, httpRsp.Header)
 
 	if err := protohttp.CheckIfResponseHasError(httpRsp); err != nil {
 		httpRsp.Body.Close()
-		return nil, nil, err
+		return nil, err
 	}
 
-	reader := bufio.NewReader(httpRsp.Body)
-
-	return reader, httpRsp.Body, nil
+	return httpRsp, nil
 }
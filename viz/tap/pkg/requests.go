@@ -2,11 +2,14 @@ package pkg
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	metricsPb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
 	"github.com/linkerd/linkerd2/viz/pkg/util"
 	tapPb "github.com/linkerd/linkerd2/viz/tap/gen/tap"
+	"google.golang.org/grpc/codes"
 )
 
 // ValidTapDestinations specifies resource types allowed as a tap destination:
@@ -36,6 +39,8 @@ type TapRequestParams struct {
 	Method        string
 	Authority     string
 	Path          string
+	GrpcStatus    string
+	ResponseClass string
 	Extract       bool
 	LabelSelector string
 }
@@ -97,6 +102,29 @@ func BuildTapByResourceRequest(params TapRequestParams) (*tapPb.TapByResourceReq
 		matches = append(matches, &match)
 	}
 
+	if params.GrpcStatus != "" {
+		code, err := parseGrpcStatus(params.GrpcStatus)
+		if err != nil {
+			return nil, err
+		}
+		match := buildMatchResponse(&tapPb.TapByResourceRequest_Match_Response{
+			Match: &tapPb.TapByResourceRequest_Match_Response_GrpcStatus{GrpcStatus: uint32(code)},
+		})
+		matches = append(matches, &match)
+	}
+	if params.ResponseClass != "" {
+		class, ok := tapPb.TapByResourceRequest_Match_Response_Class_value[strings.ToUpper(params.ResponseClass)]
+		if !ok || class == int32(tapPb.TapByResourceRequest_Match_Response_UNKNOWN) {
+			return nil, fmt.Errorf("invalid response class %q, must be one of: success, failure", params.ResponseClass)
+		}
+		match := buildMatchResponse(&tapPb.TapByResourceRequest_Match_Response{
+			Match: &tapPb.TapByResourceRequest_Match_Response_Class_{
+				Class: tapPb.TapByResourceRequest_Match_Response_Class(class),
+			},
+		})
+		matches = append(matches, &match)
+	}
+
 	extract := &tapPb.TapByResourceRequest_Extract{}
 	if params.Extract {
 		extract = buildExtractHTTP(&tapPb.TapByResourceRequest_Extract_Http{
@@ -131,6 +159,27 @@ func buildMatchHTTP(match *tapPb.TapByResourceRequest_Match_Http) tapPb.TapByRes
 	}
 }
 
+func buildMatchResponse(match *tapPb.TapByResourceRequest_Match_Response) tapPb.TapByResourceRequest_Match {
+	return tapPb.TapByResourceRequest_Match{
+		Match: &tapPb.TapByResourceRequest_Match_Response_{
+			Response: match,
+		},
+	}
+}
+
+// parseGrpcStatus parses a gRPC status code, given either by its name (e.g.
+// DEADLINE_EXCEEDED) or its value
+func parseGrpcStatus(value string) (codes.Code, error) {
+	var code codes.Code
+	if err := code.UnmarshalJSON([]byte(value)); err == nil {
+		return code, nil
+	}
+	if err := code.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(value)))); err != nil {
+		return 0, fmt.Errorf("invalid gRPC status %q", value)
+	}
+	return code, nil
+}
+
 func buildExtractHTTP(extract *tapPb.TapByResourceRequest_Extract_Http) *tapPb.TapByResourceRequest_Extract {
 	return &tapPb.TapByResourceRequest_Extract{
 		Extract: &tapPb.TapByResourceRequest_Extract_Http_{
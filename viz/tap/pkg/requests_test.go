@@ -0,0 +1,72 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	tapPb "github.com/linkerd/linkerd2/viz/tap/gen/tap"
+)
+
+func TestBuildTapByResourceRequestResponseMatches(t *testing.T) {
+	testCases := []struct {
+		name     string
+		params   TapRequestParams
+		expected *tapPb.TapByResourceRequest_Match_Response
+		err      string
+	}{
+		{
+			name:   "gRPC status by name",
+			params: TapRequestParams{GrpcStatus: "deadline_exceeded"},
+			expected: &tapPb.TapByResourceRequest_Match_Response{
+				Match: &tapPb.TapByResourceRequest_Match_Response_GrpcStatus{GrpcStatus: 4},
+			},
+		},
+		{
+			name:   "gRPC status by code",
+			params: TapRequestParams{GrpcStatus: "14"},
+			expected: &tapPb.TapByResourceRequest_Match_Response{
+				Match: &tapPb.TapByResourceRequest_Match_Response_GrpcStatus{GrpcStatus: 14},
+			},
+		},
+		{
+			name:   "response class",
+			params: TapRequestParams{ResponseClass: "failure"},
+			expected: &tapPb.TapByResourceRequest_Match_Response{
+				Match: &tapPb.TapByResourceRequest_Match_Response_Class_{
+					Class: tapPb.TapByResourceRequest_Match_Response_FAILURE,
+				},
+			},
+		},
+		{
+			name:   "invalid gRPC status",
+			params: TapRequestParams{GrpcStatus: "TIMEOUT"},
+			err:    `invalid gRPC status "TIMEOUT"`,
+		},
+		{
+			name:   "invalid response class",
+			params: TapRequestParams{ResponseClass: "unknown"},
+			err:    `invalid response class "unknown", must be one of: success, failure`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			tc.params.Resource = "deploy/web"
+			req, err := BuildTapByResourceRequest(tc.params)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("Expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			matches := req.GetMatch().GetAll().GetMatches()
+			if len(matches) != 1 || !proto.Equal(matches[0].GetResponse(), tc.expected) {
+				t.Fatalf("Expected the response match %v, got %v", tc.expected, matches)
+			}
+		})
+	}
+}
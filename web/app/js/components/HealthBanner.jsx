@@ -0,0 +1,85 @@
+import Alert from '@material-ui/lab/Alert';
+import PropTypes from 'prop-types';
+import React from 'react';
+import { Trans } from '@lingui/macro';
+import _filter from 'lodash/filter';
+import _isEmpty from 'lodash/isEmpty';
+import { apiErrorPropType } from './util/ApiHelpers.jsx';
+import withREST from './util/withREST.jsx';
+import { withStyles } from '@material-ui/core/styles';
+
+const styles = theme => ({
+  banner: {
+    marginBottom: theme.spacing(2),
+  },
+});
+
+// failedChecks returns the descriptions of the failed checks of a component,
+// along with their error
+const failedChecks = (component, warnings) => _filter(component.results, r => !_isEmpty(r.error) && Boolean(r.warning) === warnings)
+  .map(r => `${r.description}: ${r.error}`);
+
+// HealthBannerBase summarizes the health of the control plane and of the
+// installed extensions, as reported by the health summary endpoint; nothing
+// is displayed while they're all healthy
+export class HealthBannerBase extends React.Component {
+  render() {
+    const { classes, data, error } = this.props;
+
+    // the banner is an overlay of the pages, which report their own errors
+    if (error || _isEmpty(data)) {
+      return null;
+    }
+
+    const summary = data[0];
+    const failing = _filter(summary.components, c => !c.success);
+    const warning = _filter(summary.components, c => c.success && c.warning);
+    if (_isEmpty(failing) && _isEmpty(warning)) {
+      return null;
+    }
+
+    const severity = _isEmpty(failing) ? 'warning' : 'error';
+    const components = _isEmpty(failing) ? warning : failing;
+    return (
+      <Alert className={classes.banner} severity={severity}>
+        {severity === 'error' ?
+          <Trans>healthBannerFailing</Trans> :
+          <Trans>healthBannerWarning</Trans>}
+        <ul>
+          {components.map(c => (
+            <li key={c.name}>
+              <strong>{c.name}</strong> ({c.namespace}): {failedChecks(c, severity === 'warning').join('; ')}
+            </li>
+          ))}
+        </ul>
+      </Alert>
+    );
+  }
+}
+
+HealthBannerBase.propTypes = {
+  data: PropTypes.arrayOf(PropTypes.shape({
+    success: PropTypes.bool,
+    components: PropTypes.arrayOf(PropTypes.shape({
+      name: PropTypes.string,
+      namespace: PropTypes.string,
+      success: PropTypes.bool,
+      warning: PropTypes.bool,
+      results: PropTypes.arrayOf(PropTypes.shape({
+        description: PropTypes.string,
+        warning: PropTypes.bool,
+        error: PropTypes.string,
+      })),
+    })),
+  })).isRequired,
+  error: apiErrorPropType,
+};
+
+HealthBannerBase.defaultProps = {
+  error: null,
+};
+
+export default withREST(
+  withStyles(styles)(HealthBannerBase),
+  ({ api }) => [api.fetchHealthSummary()],
+);
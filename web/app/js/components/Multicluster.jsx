@@ -0,0 +1,133 @@
+import 'whatwg-fetch';
+
+import BaseTable from './BaseTable.jsx';
+import ErrorBanner from './ErrorBanner.jsx';
+import PropTypes from 'prop-types';
+import React from 'react';
+import Spinner from './util/Spinner.jsx';
+import SuccessRateMiniChart from './util/SuccessRateMiniChart.jsx';
+import { Trans } from '@lingui/macro';
+import _get from 'lodash/get';
+import _has from 'lodash/has';
+import _isEmpty from 'lodash/isEmpty';
+import _map from 'lodash/map';
+import { apiErrorPropType } from './util/ApiHelpers.jsx';
+import { metricToFormatter } from './util/Utils.js';
+import { processMulticlusterResults } from './util/MetricUtils.jsx';
+import withREST from './util/withREST.jsx';
+
+const resourceColumns = [
+  {
+    title: <Trans>columnTitleNamespace</Trans>,
+    dataIndex: 'namespace',
+    sorter: d => d.namespace,
+  },
+  {
+    title: <Trans>columnTitleName</Trans>,
+    dataIndex: 'name',
+    sorter: d => d.name,
+  },
+];
+
+// clusterColumns returns the stat columns of a cluster; the resources that
+// weren't found in it are displayed with dashes
+const clusterColumns = cluster => {
+  const stat = (d, path) => _get(d.clusters, [cluster, ...path]);
+  return [
+    {
+      title: <Trans>columnTitleClusterSuccessRate {cluster}</Trans>,
+      dataIndex: `${cluster}.successRate`,
+      isNumeric: true,
+      render: d => _has(d.clusters, cluster) ? <SuccessRateMiniChart sr={stat(d, ['successRate'])} /> : '---',
+      sorter: d => stat(d, ['successRate']),
+    },
+    {
+      title: <Trans>columnTitleClusterRPS {cluster}</Trans>,
+      dataIndex: `${cluster}.requestRate`,
+      isNumeric: true,
+      render: d => metricToFormatter.NO_UNIT(stat(d, ['requestRate'])),
+      sorter: d => stat(d, ['requestRate']),
+    },
+    {
+      title: <Trans>columnTitleClusterP99Latency {cluster}</Trans>,
+      dataIndex: `${cluster}.P99`,
+      isNumeric: true,
+      render: d => metricToFormatter.LATENCY(stat(d, ['latency', 'P99'])),
+      sorter: d => stat(d, ['latency', 'P99']),
+    },
+  ];
+};
+
+// clusterErrors renders the errors of the linked clusters that couldn't be
+// queried, whose columns are left out of the table
+const clusterErrors = errors => {
+  if (_isEmpty(errors)) {
+    return null;
+  }
+  const message = _map(errors, (error, cluster) => `${cluster}: ${error}`).join('; ');
+  return { error: message };
+};
+
+export class MulticlusterBase extends React.Component {
+  banner = metrics => {
+    const { error } = this.props;
+    if (error) {
+      return <ErrorBanner message={error} />;
+    }
+    const errors = clusterErrors(metrics.errors);
+    return errors ? <ErrorBanner message={errors} /> : null;
+  };
+
+  render() {
+    const { data, loading, error, resource } = this.props;
+
+    if (loading && !error) {
+      return <Spinner />;
+    }
+
+    const metrics = data.length === 1 ?
+      processMulticlusterResults(data[0]) :
+      { clusters: [], rows: [], errors: {} };
+    const columns = resourceColumns.concat(...metrics.clusters.map(clusterColumns));
+
+    return (
+      <div className="page-content">
+        <div>
+          {this.banner(metrics)}
+          <BaseTable
+            enableFilter
+            tableRows={metrics.rows}
+            tableColumns={columns}
+            tableClassName="metric-table"
+            title={<Trans>tableTitleMulticluster {resource}</Trans>}
+            defaultOrderBy="namespace"
+            padding="dense" />
+        </div>
+      </div>
+    );
+  }
+}
+
+MulticlusterBase.propTypes = {
+  data: PropTypes.arrayOf(PropTypes.shape({
+    clusters: PropTypes.arrayOf(PropTypes.string),
+    rows: PropTypes.arrayOf(PropTypes.shape({})),
+    errors: PropTypes.objectOf(PropTypes.string),
+  })).isRequired,
+  error: apiErrorPropType,
+  loading: PropTypes.bool.isRequired,
+  resource: PropTypes.string,
+};
+
+MulticlusterBase.defaultProps = {
+  error: null,
+  resource: 'deployment',
+};
+
+export default withREST(
+  MulticlusterBase,
+  ({ api, resource, selectedNamespace }) => [api.fetchMetrics(api.urlsForMulticlusterResource(resource || 'deployment', selectedNamespace))],
+  {
+    resetProps: ['resource', 'selectedNamespace'],
+  },
+);
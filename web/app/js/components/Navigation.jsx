@@ -21,6 +21,7 @@ import Divider from '@material-ui/core/Divider';
 import Drawer from '@material-ui/core/Drawer';
 import EmailIcon from '@material-ui/icons/Email';
 import { FontAwesomeIcon } from '@fortawesome/react-fontawesome';
+import HealthBanner from './HealthBanner.jsx';
 import Hidden from '@material-ui/core/Hidden';
 import IconButton from '@material-ui/core/IconButton';
 import LibraryBooksIcon from '@material-ui/icons/LibraryBooks';
@@ -46,6 +47,7 @@ import { faDungeon } from '@fortawesome/free-solid-svg-icons/faDungeon';
 import { faExternalLinkAlt } from '@fortawesome/free-solid-svg-icons/faExternalLinkAlt';
 import { faFilter } from '@fortawesome/free-solid-svg-icons/faFilter';
 import { faMicroscope } from '@fortawesome/free-solid-svg-icons/faMicroscope';
+import { faNetworkWired } from '@fortawesome/free-solid-svg-icons/faNetworkWired';
 import { faRandom } from '@fortawesome/free-solid-svg-icons/faRandom';
 import { faSmile } from '@fortawesome/free-regular-svg-icons/faSmile';
 import { faStream } from '@fortawesome/free-solid-svg-icons/faStream';
@@ -500,6 +502,12 @@ class NavigationBase extends React.Component {
             <FontAwesomeIcon icon={faDungeon} className={classes.shrinkIcon} />,
           ) }
 
+          { showGatewayLink && this.menuItem(
+            '/multicluster',
+            <Trans>menuItemMulticluster</Trans>,
+            <FontAwesomeIcon icon={faNetworkWired} className={classes.shrinkIcon} />,
+          ) }
+
         </MenuList>
 
         <Divider />
@@ -683,6 +691,7 @@ class NavigationBase extends React.Component {
         <main className={classes.content}>
           <div className={classes.toolbar} />
           <div>
+            <HealthBanner api={api} />
             <ChildComponent {...otherProps} />
           </div>
         </main>
@@ -147,6 +147,10 @@ const ApiHelpers = (pathPrefix, defaultMetricsWindow = '1m') => {
     return apiFetch('/api/check');
   };
 
+  const fetchHealthSummary = () => {
+    return apiFetch('/api/health-summary');
+  };
+
   const fetchExtension = name => {
     let extensionPath = l5dExtensionsPath;
     if (name) {
@@ -175,6 +179,20 @@ const ApiHelpers = (pathPrefix, defaultMetricsWindow = '1m') => {
     return resourceUrl;
   };
 
+  const urlsForMulticlusterResource = (type, namespace) => {
+    // Traffic Performance Summary of the resource in this cluster and the
+    // linked ones
+    let resourceUrl = `/api/multicluster/tps-reports?resource_type=${type}`;
+
+    if (_isEmpty(namespace) || namespace === '_all') {
+      resourceUrl += '&all_namespaces=true';
+    } else {
+      resourceUrl += `&namespace=${namespace}`;
+    }
+
+    return resourceUrl;
+  };
+
   const urlsForResourceNoStats = (type, namespace) => {
     // Traffic Performance Summary. This retrieves (non-Prometheus) stats for the given resource.
     let resourceUrl = `/api/tps-reports?skip_stats=true&resource_type=${type}`;
@@ -267,12 +285,14 @@ const ApiHelpers = (pathPrefix, defaultMetricsWindow = '1m') => {
     fetchGateways,
     fetchExtension,
     fetchCheck,
+    fetchHealthSummary,
     fetchResourceDefinition,
     getMetricsWindow,
     setMetricsWindow,
     getValidMetricsWindows: () => Object.keys(validMetricsWindows),
     getMetricsWindowDisplayText,
     urlsForResource,
+    urlsForMulticlusterResource,
     urlsForResourceNoStats,
     PrefixedLink,
     prefixLink,
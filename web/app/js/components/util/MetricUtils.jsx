@@ -179,6 +179,35 @@ export const processGatewayResults = rawMetrics => {
   }
   return processGatewayTable(rawMetrics.ok.gatewaysTable);
 };
+// processMulticlusterResults flattens the stats of each resource in each
+// cluster, as returned by the multicluster stat endpoint, into rows holding
+// their stats by cluster name
+export const processMulticlusterResults = rawMetrics => {
+  const rows = _map(rawMetrics.rows, row => {
+    const clusters = {};
+    _each(row.clusters, (clusterRow, cluster) => {
+      clusters[cluster] = {
+        requestRate: getRequestRate(clusterRow),
+        successRate: getSuccessRate(clusterRow),
+        latency: getLatency(clusterRow),
+      };
+    });
+    return {
+      key: `${row.resource.namespace}-${row.resource.type}-${row.resource.name}`,
+      name: row.resource.name,
+      namespace: row.resource.namespace,
+      type: row.resource.type,
+      clusters,
+    };
+  });
+
+  return {
+    clusters: rawMetrics.clusters || [],
+    rows: _orderBy(rows, [r => r.namespace, r => r.name]),
+    errors: rawMetrics.errors || {},
+  };
+};
+
 export const processMultiResourceRollup = (rawMetrics, resourceType) => {
   if (_isEmpty(rawMetrics.ok) || _isEmpty(rawMetrics.ok.statTables)) {
     return {};
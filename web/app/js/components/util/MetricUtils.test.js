@@ -5,6 +5,7 @@ import gatewayFixtures from '../../../test/fixtures/gateway.json';
 import Percentage from './Percentage';
 import {
   processGatewayResults,
+  processMulticlusterResults,
   processMultiResourceRollup,
   processSingleResourceRollup
 } from './MetricUtils.jsx';
@@ -94,4 +95,31 @@ describe('MetricUtils', () => {
       expect(result).toEqual(expectedResult);
     });
   })
+  describe('processMulticlusterResults', () => {
+    it('Extracts the metrics of each resource by cluster', () => {
+      const row = (name, successCount) => ({
+        resource: { namespace: 'emojivoto', type: 'deployment', name },
+        timeWindow: '1m',
+        stats: { successCount, failureCount: '0', latencyMsP50: '1', latencyMsP95: '2', latencyMsP99: '3' },
+      });
+      let result = processMulticlusterResults({
+        clusters: ['local', 'west'],
+        rows: [
+          { resource: { namespace: 'emojivoto', type: 'deployment', name: 'web' }, clusters: { local: row('web', '60'), west: row('web', '120') } },
+          { resource: { namespace: 'emojivoto', type: 'deployment', name: 'emoji' }, clusters: { west: row('emoji', '60') } },
+        ],
+        errors: { east: 'connection refused' },
+      });
+      expect(result.clusters).toEqual(['local', 'west']);
+      expect(result.errors).toEqual({ east: 'connection refused' });
+      expect(result.rows).toHaveLength(2);
+      expect(result.rows[0].name).toEqual('emoji');
+      expect(result.rows[0].clusters).toEqual({
+        west: { requestRate: 1, successRate: 1, latency: { P50: 1, P95: 2, P99: 3 } },
+      });
+      expect(result.rows[1].name).toEqual('web');
+      expect(result.rows[1].clusters.local.requestRate).toEqual(1);
+      expect(result.rows[1].clusters.west.requestRate).toEqual(2);
+    });
+  });
 });
@@ -14,6 +14,7 @@ import Gateway from './components/Gateway.jsx';
 import { I18nProvider } from '@lingui/react';
 import { i18n } from '@lingui/core';
 import { en, es } from 'make-plural/plurals';
+import Multicluster from './components/Multicluster.jsx';
 import Namespace from './components/Namespace.jsx';
 import Navigation from './components/Navigation.jsx';
 import NoMatch from './components/NoMatch.jsx';
@@ -140,6 +141,9 @@ const AppHTML = function() {
               <Route
                 path={`${pathPrefix}/gateways`}
                 render={props => <Navigation {...props} ChildComponent={Gateway} resource="gateway" />} />
+              <Route
+                path={`${pathPrefix}/multicluster`}
+                render={props => <Navigation {...props} ChildComponent={Multicluster} resource="deployment" />} />
               <Route
                 exact
                 path={`${pathPrefix}/namespaces/:namespace`}
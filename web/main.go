@@ -12,6 +12,8 @@ import (
 
 	"github.com/linkerd/linkerd2/pkg/config"
 
+	jaegerCmd "github.com/linkerd/linkerd2/jaeger/cmd"
+	multiclusterCmd "github.com/linkerd/linkerd2/multicluster/cmd"
 	"github.com/linkerd/linkerd2/pkg/admin"
 	"github.com/linkerd/linkerd2/pkg/charts/linkerd2"
 	"github.com/linkerd/linkerd2/pkg/flags"
@@ -19,6 +21,7 @@ import (
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/trace"
 	"github.com/linkerd/linkerd2/viz/metrics-api/client"
+	vizHealthCheck "github.com/linkerd/linkerd2/viz/pkg/healthcheck"
 	"github.com/linkerd/linkerd2/web/srv"
 	log "github.com/sirupsen/logrus"
 )
@@ -39,7 +42,9 @@ func main() {
 	enforcedHost := cmd.String("enforced-host", "", "regexp describing the allowed values for the Host header; protects from DNS-rebinding attacks")
 	kubeConfigPath := cmd.String("kubeconfig", "", "path to kube config")
 	clusterDomain := cmd.String("cluster-domain", "", "kubernetes cluster domain")
+	linkNamespace := cmd.String("multicluster-namespace", "", "namespace of the Link resources of the clusters linked to this one, whose stats are then also queried; leave empty to disable the multicluster views")
 
+	adminTLS := flags.AddAdminTLSFlags(cmd)
 	traceCollector := flags.AddTraceFlags(cmd)
 
 	flags.ConfigureAndParse(cmd, os.Args[1:])
@@ -78,6 +83,39 @@ func main() {
 		KubeConfig:            *kubeConfigPath,
 	})
 
+	vizHC := vizHealthCheck.NewHealthChecker([]healthcheck.CategoryID{}, &healthcheck.Options{
+		ControlPlaneNamespace: *controllerNamespace,
+		KubeConfig:            *kubeConfigPath,
+	})
+	if err := vizHC.InitializeKubeAPIClient(); err != nil {
+		log.Fatalf("failed to initialize the viz health checker: %s", err)
+	}
+	vizHC.AppendCategories(vizHC.VizCategory())
+	extensionChecks := map[string]srv.HealthChecker{"viz": vizHC}
+
+	jaegerHC, err := jaegerCmd.NewHealthChecker(&healthcheck.Options{
+		ControlPlaneNamespace: *controllerNamespace,
+		KubeConfig:            *kubeConfigPath,
+	})
+	if err != nil {
+		log.Fatalf("failed to initialize the jaeger health checker: %s", err)
+	}
+	extensionChecks[jaegerCmd.JaegerExtensionName] = jaegerHC
+
+	// the multicluster checks read the credentials of the linked clusters,
+	// which the web component can only access with the multicluster views
+	if *linkNamespace != "" {
+		multiclusterHC, err := multiclusterCmd.NewHealthChecker(ctx, &healthcheck.Options{
+			ControlPlaneNamespace: *controllerNamespace,
+			KubeConfig:            *kubeConfigPath,
+		})
+		if err != nil {
+			log.Warnf("failed to initialize the multicluster health checker, falling back to checking its pods: %s", err)
+		} else {
+			extensionChecks[multiclusterCmd.MulticlusterExtensionName] = multiclusterHC
+		}
+	}
+
 	uuid, version := getUUIDAndVersion(ctx, k8sAPI, *controllerNamespace)
 
 	stop := make(chan os.Signal, 1)
@@ -95,7 +133,7 @@ func main() {
 	}
 
 	server := srv.NewServer(*addr, *grafanaAddr, *jaegerAddr, *templateDir, *staticDir, uuid, version,
-		*controllerNamespace, *clusterDomain, *reload, reHost, client, k8sAPI, hc)
+		*controllerNamespace, *vizNamespace, *linkNamespace, *clusterDomain, *reload, reHost, client, k8sAPI, hc, extensionChecks)
 
 	go func() {
 		log.Infof("starting HTTP server on %+v", *addr)
@@ -103,10 +141,13 @@ func main() {
 	}()
 
 	adminServer := admin.NewServer(*metricsAddr)
+	if err := admin.EnableTLS(ctx, adminServer, adminTLS); err != nil {
+		log.Fatalf("Failed to enable TLS on the admin server: %s", err)
+	}
 
 	go func() {
 		log.Infof("starting admin server on %s", *metricsAddr)
-		adminServer.ListenAndServe()
+		admin.ListenAndServe(adminServer)
 	}()
 
 	<-stop
@@ -18,11 +18,13 @@ import (
 	"github.com/linkerd/linkerd2/pkg/healthcheck"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/protohttp"
+	vizClient "github.com/linkerd/linkerd2/viz/metrics-api/client"
 	metricsPb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
 	vizUtil "github.com/linkerd/linkerd2/viz/metrics-api/util"
 	tapPb "github.com/linkerd/linkerd2/viz/tap/gen/tap"
 	tappkg "github.com/linkerd/linkerd2/viz/tap/pkg"
 	log "github.com/sirupsen/logrus"
+	healthPb "google.golang.org/grpc/health/grpc_health_v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
@@ -130,32 +132,7 @@ func (h *handler) handleAPIStat(w http.ResponseWriter, req *http.Request, p http
 		return
 	}
 
-	trueStr := fmt.Sprintf("%t", true)
-
-	requestParams := vizUtil.StatsSummaryRequestParams{
-		StatsBaseRequestParams: vizUtil.StatsBaseRequestParams{
-			TimeWindow:    req.FormValue("window"),
-			ResourceName:  req.FormValue("resource_name"),
-			ResourceType:  req.FormValue("resource_type"),
-			Namespace:     req.FormValue("namespace"),
-			AllNamespaces: req.FormValue("all_namespaces") == trueStr,
-		},
-		ToName:        req.FormValue("to_name"),
-		ToType:        req.FormValue("to_type"),
-		ToNamespace:   req.FormValue("to_namespace"),
-		FromName:      req.FormValue("from_name"),
-		FromType:      req.FormValue("from_type"),
-		FromNamespace: req.FormValue("from_namespace"),
-		SkipStats:     req.FormValue("skip_stats") == trueStr,
-		TCPStats:      req.FormValue("tcp_stats") == trueStr,
-	}
-
-	// default to returning deployment stats
-	if requestParams.ResourceType == "" {
-		requestParams.ResourceType = defaultResourceType
-	}
-
-	statRequest, err := vizUtil.BuildStatSummaryRequest(requestParams)
+	statRequest, err := statRequestFromForm(req)
 	if err != nil {
 		renderJSONError(w, err, http.StatusInternalServerError)
 		return
@@ -178,6 +155,40 @@ func (h *handler) handleAPIStat(w http.ResponseWriter, req *http.Request, p http
 	renderJSONBytes(w, resultJSON.Bytes())
 }
 
+// statRequestFromForm builds the stat summary request described by the query
+// parameters of the request
+func statRequestFromForm(req *http.Request) (*metricsPb.StatSummaryRequest, error) {
+	trueStr := fmt.Sprintf("%t", true)
+
+	requestParams := vizUtil.StatsSummaryRequestParams{
+		StatsBaseRequestParams: vizUtil.StatsBaseRequestParams{
+			TimeWindow:    req.FormValue("window"),
+			ResourceName:  req.FormValue("resource_name"),
+			ResourceType:  req.FormValue("resource_type"),
+			Namespace:     req.FormValue("namespace"),
+			AllNamespaces: req.FormValue("all_namespaces") == trueStr,
+		},
+		ToName:          req.FormValue("to_name"),
+		ToType:          req.FormValue("to_type"),
+		ToNamespace:     req.FormValue("to_namespace"),
+		FromName:        req.FormValue("from_name"),
+		FromType:        req.FormValue("from_type"),
+		FromNamespace:   req.FormValue("from_namespace"),
+		SkipStats:       req.FormValue("skip_stats") == trueStr,
+		TCPStats:        req.FormValue("tcp_stats") == trueStr,
+		CurrentPodsOnly: req.FormValue("current_pods_only") == trueStr,
+		VersionLabel:    req.FormValue("version_label"),
+		FromIdentity:    req.FormValue("from_identity"),
+	}
+
+	// default to returning deployment stats
+	if requestParams.ResourceType == "" {
+		requestParams.ResourceType = defaultResourceType
+	}
+
+	return vizUtil.BuildStatSummaryRequest(requestParams)
+}
+
 func (h *handler) handleAPITopRoutes(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
 	requestParams := vizUtil.TopRoutesRequestParams{
 		StatsBaseRequestParams: vizUtil.StatsBaseRequestParams{
@@ -372,7 +383,9 @@ func (h *handler) handleAPICheck(w http.ResponseWriter, req *http.Request, p htt
 	}
 	// TODO (tegioz): ignore runchecks results until we stop filtering checks
 	// in this method (see #3670 for more details)
+	h.healthMu.Lock()
 	_, _ = h.hc.RunChecks(collectResults)
+	h.healthMu.Unlock()
 
 	renderJSON(w, map[string]interface{}{
 		"success": success,
@@ -380,6 +393,31 @@ func (h *handler) handleAPICheck(w http.ResponseWriter, req *http.Request, p htt
 	})
 }
 
+// handleAPIHealth reports the status of the metrics-api and of the Prometheus
+// instance it queries, so that their failures can be told apart
+func (h *handler) handleAPIHealth(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	resp := map[string]string{
+		"metricsApi": healthPb.HealthCheckResponse_UNKNOWN.String(),
+		"prometheus": healthPb.HealthCheckResponse_UNKNOWN.String(),
+	}
+	status, err := vizClient.CheckHealth(req.Context(), h.apiClient, "")
+	if err != nil {
+		resp["metricsApi"] = healthPb.HealthCheckResponse_NOT_SERVING.String()
+		resp["error"] = err.Error()
+		renderJSON(w, resp)
+		return
+	}
+	resp["metricsApi"] = status.String()
+
+	status, err = vizClient.CheckHealth(req.Context(), h.apiClient, vizClient.PrometheusHealthService)
+	if err != nil {
+		resp["error"] = err.Error()
+	} else {
+		resp["prometheus"] = status.String()
+	}
+	renderJSON(w, resp)
+}
+
 func (h *handler) handleAPIResourceDefinition(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
 	var missingParams []string
 	requiredParams := []string{"namespace", "resource_type", "resource_name"}
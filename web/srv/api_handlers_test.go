@@ -8,19 +8,35 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/linkerd/linkerd2/pkg/healthcheck"
 	vizApi "github.com/linkerd/linkerd2/viz/metrics-api"
+	vizClient "github.com/linkerd/linkerd2/viz/metrics-api/client"
 	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	healthPb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type mockHealthChecker struct {
 	results []*healthcheck.CheckResult
+	// delay is the duration of the checks; running, runs and overlaps track
+	// their concurrent and total runs
+	delay    time.Duration
+	running  int32
+	runs     int32
+	overlaps int32
 }
 
 func (c *mockHealthChecker) RunChecks(observer healthcheck.CheckObserver) (bool, bool) {
+	if atomic.AddInt32(&c.running, 1) > 1 {
+		atomic.AddInt32(&c.overlaps, 1)
+	}
+	defer atomic.AddInt32(&c.running, -1)
+	atomic.AddInt32(&c.runs, 1)
+	time.Sleep(c.delay)
 	for _, result := range c.results {
 		observer(result)
 	}
@@ -176,3 +192,59 @@ func TestHandleApiGateway(t *testing.T) {
 		}
 	})
 }
+
+func TestHandleApiHealth(t *testing.T) {
+	testCases := []struct {
+		name     string
+		client   *vizApi.MockAPIClient
+		expected map[string]string
+	}{
+		{
+			name:   "Reports the metrics-api and Prometheus as serving",
+			client: &vizApi.MockAPIClient{},
+			expected: map[string]string{
+				"metricsApi": "SERVING",
+				"prometheus": "SERVING",
+			},
+		},
+		{
+			name: "Reports Prometheus as unreachable",
+			client: &vizApi.MockAPIClient{
+				HealthStatusesToReturn: map[string]healthPb.HealthCheckResponse_ServingStatus{
+					vizClient.PrometheusHealthService: healthPb.HealthCheckResponse_NOT_SERVING,
+				},
+			},
+			expected: map[string]string{
+				"metricsApi": "SERVING",
+				"prometheus": "NOT_SERVING",
+			},
+		},
+		{
+			name:   "Reports the metrics-api as unreachable",
+			client: &vizApi.MockAPIClient{ErrorToReturn: errors.New("connection refused")},
+			expected: map[string]string{
+				"metricsApi": "NOT_SERVING",
+				"prometheus": "UNKNOWN",
+				"error":      "connection refused",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			handler := &handler{apiClient: tc.client}
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/api/health", nil)
+			handler.handleAPIHealth(recorder, req, httprouter.Params{})
+
+			var result map[string]string
+			if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Fatalf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"sync"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/linkerd/linkerd2/pkg/k8s"
@@ -31,8 +32,17 @@ type (
 		jaeger              string
 		grafanaProxy        *reverseProxy
 		jaegerProxy         *reverseProxy
-		hc                  healthChecker
-		statCache           *cache.Cache
+		hc                  HealthChecker
+		// extensionChecks holds the checkers of the extensions, by name
+		extensionChecks map[string]HealthChecker
+		statCache       *cache.Cache
+		healthCache     *cache.Cache
+		// healthMu serializes the runs of the health checks, as the checkers
+		// keep state between their checks
+		healthMu sync.Mutex
+		// linkedClusters holds the clients of the linked clusters, nil when
+		// the multicluster views are disabled
+		linkedClusters *linkedClusters
 	}
 )
 
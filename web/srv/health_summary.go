@@ -0,0 +1,156 @@
+package srv
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// healthSummaryExpiration indicates for how long the health summary is
+	// cached, as running all the checks is expensive.
+	healthSummaryExpiration = 30 * time.Second
+	healthSummaryCacheKey   = "health-summary"
+)
+
+type (
+	healthSummary struct {
+		Success    bool               `json:"success"`
+		Components []*healthComponent `json:"components"`
+	}
+
+	// healthComponent holds the results of the checks of the control plane
+	// or of an extension
+	healthComponent struct {
+		Name      string               `json:"name"`
+		Namespace string               `json:"namespace"`
+		Success   bool                 `json:"success"`
+		Warning   bool                 `json:"warning"`
+		Results   []*healthCheckResult `json:"results"`
+	}
+
+	healthCheckResult struct {
+		Category    healthcheck.CategoryID `json:"category"`
+		Description string                 `json:"description"`
+		Warning     bool                   `json:"warning,omitempty"`
+		Error       string                 `json:"error,omitempty"`
+		HintURL     string                 `json:"hintURL,omitempty"`
+	}
+)
+
+// handleAPIHealthSummary returns the results of the checks of the control
+// plane and of each installed extension, so that the dashboard can report
+// their health at a glance. The summary is cached for healthSummaryExpiration.
+func (h *handler) handleAPIHealthSummary(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+	if cached, ok := h.healthCache.Get(healthSummaryCacheKey); ok {
+		renderJSON(w, cached)
+		return
+	}
+
+	h.healthMu.Lock()
+	defer h.healthMu.Unlock()
+	// the summary may have been refreshed while waiting for the lock
+	if cached, ok := h.healthCache.Get(healthSummaryCacheKey); ok {
+		renderJSON(w, cached)
+		return
+	}
+
+	summary, err := h.healthSummary(req.Context())
+	if err != nil {
+		renderJSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.healthCache.SetDefault(healthSummaryCacheKey, summary)
+
+	renderJSON(w, summary)
+}
+
+func (h *handler) healthSummary(ctx context.Context) (*healthSummary, error) {
+	components := []*healthComponent{runHealthChecks("linkerd", h.controllerNamespace, h.hc)}
+
+	extensions, err := h.k8sAPI.GetAllNamespacesWithExtensionLabel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(extensions, func(i, j int) bool {
+		return extensions[i].Labels[k8s.LinkerdExtensionLabel] < extensions[j].Labels[k8s.LinkerdExtensionLabel]
+	})
+	for _, ns := range extensions {
+		name := ns.Labels[k8s.LinkerdExtensionLabel]
+		if hc, ok := h.extensionChecks[name]; ok {
+			components = append(components, runHealthChecks(name, ns.Name, hc))
+		} else {
+			// the checks of the other extensions are only available through
+			// their CLI, fall back to checking their pods
+			components = append(components, h.checkExtensionPods(ctx, name, ns.Name))
+		}
+	}
+
+	summary := &healthSummary{Success: true, Components: components}
+	for _, component := range components {
+		summary.Success = summary.Success && component.Success
+	}
+	return summary, nil
+}
+
+func runHealthChecks(name, namespace string, hc HealthChecker) *healthComponent {
+	component := &healthComponent{
+		Name:      name,
+		Namespace: namespace,
+		Success:   true,
+		Results:   []*healthCheckResult{},
+	}
+	hc.RunChecks(func(result *healthcheck.CheckResult) {
+		if result.Retry || excludedChecksRE.MatchString(result.Description) {
+			return
+		}
+		component.add(result)
+	})
+	return component
+}
+
+func (h *handler) checkExtensionPods(ctx context.Context, name, namespace string) *healthComponent {
+	component := &healthComponent{
+		Name:      name,
+		Namespace: namespace,
+		Success:   true,
+		Results:   []*healthCheckResult{},
+	}
+	result := &healthcheck.CheckResult{
+		Category:    healthcheck.CategoryID(fmt.Sprintf("linkerd-%s", name)),
+		Description: fmt.Sprintf("%s extension pods are running", name),
+	}
+	pods, err := h.k8sAPI.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		result.Err = err
+	} else {
+		result.Err = healthcheck.CheckPodsRunning(pods.Items, namespace)
+	}
+	component.add(result)
+	return component
+}
+
+func (c *healthComponent) add(result *healthcheck.CheckResult) {
+	checkResult := &healthCheckResult{
+		Category:    result.Category,
+		Description: result.Description,
+	}
+	if result.Err != nil {
+		checkResult.Error = result.Err.Error()
+		checkResult.HintURL = result.HintURL
+		if result.Warning {
+			checkResult.Warning = true
+			c.Warning = true
+		} else {
+			c.Success = false
+		}
+	}
+	c.Results = append(c.Results, checkResult)
+}
@@ -0,0 +1,182 @@
+package srv
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/patrickmn/go-cache"
+)
+
+func TestHandleAPIHealthSummary(t *testing.T) {
+	k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: linkerd-viz
+  labels:
+    linkerd.io/extension: viz
+`, `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: linkerd-jaeger
+  labels:
+    linkerd.io/extension: jaeger
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: collector-5b8d4c9c8d-x2k4p
+  namespace: linkerd-jaeger
+status:
+  phase: Pending
+`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	h := &handler{
+		k8sAPI:              k8sAPI,
+		controllerNamespace: "linkerd",
+		hc: &mockHealthChecker{
+			results: []*healthcheck.CheckResult{
+				{
+					Category:    healthcheck.KubernetesAPIChecks,
+					Description: "can query the Kubernetes API",
+				},
+				{
+					Category:    healthcheck.LinkerdVersionChecks,
+					Description: "is running the latest version",
+					HintURL:     healthcheck.DefaultHintBaseURL + "l5d-version-latest",
+					Warning:     true,
+					Err:         errors.New("is running version 1.2.3 but the latest version is 1.2.4"),
+				},
+			},
+		},
+		extensionChecks: map[string]HealthChecker{
+			"viz": &mockHealthChecker{
+				results: []*healthcheck.CheckResult{
+					{
+						Category:    "linkerd-viz",
+						Description: "linkerd-viz Namespace exists",
+					},
+				},
+			},
+		},
+		healthCache: cache.New(time.Minute, time.Minute),
+	}
+
+	expected := &healthSummary{
+		Success: false,
+		Components: []*healthComponent{
+			{
+				Name:      "linkerd",
+				Namespace: "linkerd",
+				Success:   true,
+				Warning:   true,
+				Results: []*healthCheckResult{
+					{
+						Category:    healthcheck.KubernetesAPIChecks,
+						Description: "can query the Kubernetes API",
+					},
+					{
+						Category:    healthcheck.LinkerdVersionChecks,
+						Description: "is running the latest version",
+						Warning:     true,
+						Error:       "is running version 1.2.3 but the latest version is 1.2.4",
+						HintURL:     healthcheck.DefaultHintBaseURL + "l5d-version-latest",
+					},
+				},
+			},
+			{
+				Name:      "jaeger",
+				Namespace: "linkerd-jaeger",
+				Success:   false,
+				Results: []*healthCheckResult{
+					{
+						Category:    "linkerd-jaeger",
+						Description: "jaeger extension pods are running",
+						Error:       "pod \"collector-5b8d4c9c8d-x2k4p\" status is Pending",
+					},
+				},
+			},
+			{
+				Name:      "viz",
+				Namespace: "linkerd-viz",
+				Success:   true,
+				Results: []*healthCheckResult{
+					{
+						Category:    "linkerd-viz",
+						Description: "linkerd-viz Namespace exists",
+					},
+				},
+			},
+		},
+	}
+
+	// the second request is served from the cache
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		h.handleAPIHealthSummary(w, httptest.NewRequest("GET", "/api/health-summary", nil), httprouter.Params{})
+
+		summary := &healthSummary{}
+		if err := json.Unmarshal(w.Body.Bytes(), summary); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !reflect.DeepEqual(summary, expected) {
+			got, _ := json.Marshal(summary)
+			want, _ := json.Marshal(expected)
+			t.Fatalf("Expected summary\n%s\nbut got\n%s", want, got)
+		}
+		if _, ok := h.healthCache.Get(healthSummaryCacheKey); !ok {
+			t.Fatal("Expected the summary to be cached")
+		}
+	}
+}
+
+func TestHandleAPIHealthSummaryConcurrently(t *testing.T) {
+	k8sAPI, err := k8s.NewFakeAPI()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	hc := &mockHealthChecker{delay: 10 * time.Millisecond}
+	h := &handler{
+		k8sAPI:              k8sAPI,
+		controllerNamespace: "linkerd",
+		hc:                  hc,
+		healthCache:         cache.New(time.Minute, time.Minute),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h.handleAPIHealthSummary(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/health-summary", nil), httprouter.Params{})
+		}()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h.handleAPICheck(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/check", nil), httprouter.Params{})
+		}()
+	}
+	wg.Wait()
+
+	if overlaps := atomic.LoadInt32(&hc.overlaps); overlaps != 0 {
+		t.Fatalf("Expected the checks to run one at a time, %d runs overlapped", overlaps)
+	}
+	// the summary is computed once, the other requests being served from the
+	// cache; each check request runs the checks
+	if runs := atomic.LoadInt32(&hc.runs); runs != 11 {
+		t.Fatalf("Expected the checks to run 11 times, got %d", runs)
+	}
+}
@@ -0,0 +1,312 @@
+package srv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/multicluster"
+	"github.com/linkerd/linkerd2/pkg/servicemirror"
+	vizClient "github.com/linkerd/linkerd2/viz/metrics-api/client"
+	vizPb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// localClusterName is the name the stats of the dashboard's own cluster are
+// reported under
+const localClusterName = "local"
+
+type (
+	// linkedClusters maintains clients for the metrics-api instances of the
+	// clusters linked to this one, as discovered from the Link resources
+	linkedClusters struct {
+		k8sAPI        *k8s.KubernetesAPI
+		linkNamespace string
+		vizNamespace  string
+		// newClient creates a client for the metrics-api of the cluster the
+		// credentials give access to, along with a function closing it
+		newClient func(ctx context.Context, creds []byte, vizNamespace string) (vizPb.ApiClient, func(), error)
+
+		sync.Mutex
+		// clients holds the clients of the linked clusters, by Link name
+		clients map[string]*linkedClusterClient
+	}
+
+	linkedClusterClient struct {
+		clusterName string
+		// secretVersion is the resource version of the credentials the client
+		// was created with, so that it's recreated when they're updated
+		secretVersion string
+		client        vizPb.ApiClient
+		stop          func()
+	}
+
+	clusterClient struct {
+		name   string
+		client vizPb.ApiClient
+		// link is the name of the Link of the cluster, empty for the local one
+		link string
+	}
+
+	// multiclusterStats is the response of the multicluster stats endpoint,
+	// holding the stats of each resource in each cluster, so that they can be
+	// displayed as per-cluster columns
+	multiclusterStats struct {
+		// Clusters are the names of the clusters that were queried, starting
+		// with the local one
+		Clusters []string          `json:"clusters"`
+		Rows     []multiclusterRow `json:"rows"`
+		// Errors holds the errors of the clusters that couldn't be queried, by
+		// name
+		Errors map[string]string `json:"errors,omitempty"`
+	}
+
+	multiclusterRow struct {
+		Resource multiclusterResource `json:"resource"`
+		// Clusters holds the stat rows of the resource, by cluster name, as
+		// returned by the metrics-api instances
+		Clusters map[string]json.RawMessage `json:"clusters"`
+	}
+
+	multiclusterResource struct {
+		Type      string `json:"type"`
+		Namespace string `json:"namespace"`
+		Name      string `json:"name"`
+	}
+)
+
+func newLinkedClusters(k8sAPI *k8s.KubernetesAPI, linkNamespace, vizNamespace string) *linkedClusters {
+	return &linkedClusters{
+		k8sAPI:        k8sAPI,
+		linkNamespace: linkNamespace,
+		vizNamespace:  vizNamespace,
+		newClient:     newRemoteClient,
+		clients:       make(map[string]*linkedClusterClient),
+	}
+}
+
+// newRemoteClient creates a client port-forwarding to the metrics-api of the
+// cluster the credentials give access to. The service account of the
+// credentials must be allowed to do so.
+func newRemoteClient(ctx context.Context, creds []byte, vizNamespace string) (vizPb.ApiClient, func(), error) {
+	config, err := clientcmd.RESTConfigFromKubeConfig(creds)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse kube config: %s", err)
+	}
+	remoteAPI, err := k8s.NewAPIForConfig(config, "", []string{}, timeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	return vizClient.NewStoppableExternalClient(ctx, vizNamespace, remoteAPI)
+}
+
+// get returns the clients of the linked clusters, creating the missing ones
+// and closing the ones of the clusters that were unlinked. The errors of the
+// clusters whose client couldn't be created are returned by cluster name.
+func (lc *linkedClusters) get(ctx context.Context) ([]clusterClient, map[string]error, error) {
+	list, err := lc.k8sAPI.DynamicClient.Resource(multicluster.LinkGVR).Namespace(lc.linkNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lc.Lock()
+	defer lc.Unlock()
+
+	clients := []clusterClient{}
+	errs := make(map[string]error)
+	linked := make(map[string]struct{})
+	for _, u := range list.Items {
+		link, err := multicluster.NewLink(u)
+		if err != nil {
+			log.Errorf("failed to parse Link %s: %s", u.GetName(), err)
+			continue
+		}
+		linked[link.Name] = struct{}{}
+
+		secret, err := lc.k8sAPI.CoreV1().Secrets(link.Namespace).Get(ctx, link.ClusterCredentialsSecret, metav1.GetOptions{})
+		if err != nil {
+			errs[link.TargetClusterName] = fmt.Errorf("failed to load credentials secret %s: %s", link.ClusterCredentialsSecret, err)
+			continue
+		}
+
+		cached, ok := lc.clients[link.Name]
+		if ok && cached.secretVersion == secret.ResourceVersion && cached.clusterName == link.TargetClusterName {
+			clients = append(clients, clusterClient{name: cached.clusterName, client: cached.client, link: link.Name})
+			continue
+		}
+		if ok {
+			cached.stop()
+			delete(lc.clients, link.Name)
+		}
+
+		creds, err := servicemirror.ParseRemoteClusterSecret(secret)
+		if err != nil {
+			errs[link.TargetClusterName] = err
+			continue
+		}
+		client, stop, err := lc.newClient(ctx, creds, lc.vizNamespace)
+		if err != nil {
+			errs[link.TargetClusterName] = fmt.Errorf("failed to connect to the metrics-api: %s", err)
+			continue
+		}
+		lc.clients[link.Name] = &linkedClusterClient{
+			clusterName:   link.TargetClusterName,
+			secretVersion: secret.ResourceVersion,
+			client:        client,
+			stop:          stop,
+		}
+		clients = append(clients, clusterClient{name: link.TargetClusterName, client: client, link: link.Name})
+	}
+
+	for name, cached := range lc.clients {
+		if _, ok := linked[name]; !ok {
+			cached.stop()
+			delete(lc.clients, name)
+		}
+	}
+	return clients, errs, nil
+}
+
+// evict closes the client of a linked cluster, e.g. after it failed because
+// the metrics-api pod it port-forwards to went away, so that it's recreated
+// on the next request
+func (lc *linkedClusters) evict(link string) {
+	lc.Lock()
+	defer lc.Unlock()
+	if cached, ok := lc.clients[link]; ok {
+		cached.stop()
+		delete(lc.clients, link)
+	}
+}
+
+func (h *handler) handleAPIMulticlusterStat(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	if h.linkedClusters == nil {
+		renderJSONError(w, fmt.Errorf("multicluster views are not enabled"), http.StatusNotFound)
+		return
+	}
+
+	cacheKey := "multicluster?" + req.URL.RawQuery
+	cachedResultJSON, ok := h.statCache.Get(cacheKey)
+	if ok {
+		renderJSONBytes(w, cachedResultJSON.([]byte))
+		return
+	}
+
+	statRequest, err := statRequestFromForm(req)
+	if err != nil {
+		renderJSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	remotes, errs, err := h.linkedClusters.get(req.Context())
+	if err != nil {
+		renderJSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+	clusters := append([]clusterClient{{name: localClusterName, client: h.apiClient}}, remotes...)
+
+	responses := make([]*vizPb.StatSummaryResponse, len(clusters))
+	callErrs := make([]error, len(clusters))
+	var wg sync.WaitGroup
+	for i, cluster := range clusters {
+		wg.Add(1)
+		go func(i int, cluster clusterClient) {
+			defer wg.Done()
+			responses[i], callErrs[i] = cluster.client.StatSummary(req.Context(), statRequest)
+		}(i, cluster)
+	}
+	wg.Wait()
+
+	names := []string{}
+	merged := make(map[string]*vizPb.StatSummaryResponse)
+	for i, cluster := range clusters {
+		if callErrs[i] != nil {
+			errs[cluster.name] = callErrs[i]
+			if cluster.link != "" {
+				h.linkedClusters.evict(cluster.link)
+			}
+			continue
+		}
+		if e := responses[i].GetError(); e != nil {
+			errs[cluster.name] = errors.New(e.GetError())
+			continue
+		}
+		names = append(names, cluster.name)
+		merged[cluster.name] = responses[i]
+	}
+
+	// the local cluster failing fails the whole request, while the linked
+	// clusters failing only leaves their columns out
+	if err, ok := errs[localClusterName]; ok {
+		renderJSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	result, err := mergeStatSummaries(names, merged)
+	if err != nil {
+		renderJSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+	if len(errs) > 0 {
+		result.Errors = make(map[string]string)
+		for name, err := range errs {
+			log.Errorf("failed to get the stats of cluster %s: %s", name, err)
+			result.Errors[name] = err.Error()
+		}
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		renderJSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.statCache.SetDefault(cacheKey, resultJSON)
+
+	renderJSONBytes(w, resultJSON)
+}
+
+// mergeStatSummaries merges the stat summaries of the clusters into a row per
+// resource, holding its stats in each cluster it was found in. The rows are
+// ordered as in the summaries, in the order of the clusters.
+func mergeStatSummaries(clusters []string, summaries map[string]*vizPb.StatSummaryResponse) (*multiclusterStats, error) {
+	result := &multiclusterStats{
+		Clusters: clusters,
+		Rows:     []multiclusterRow{},
+	}
+	index := make(map[multiclusterResource]int)
+	for _, cluster := range clusters {
+		for _, table := range summaries[cluster].GetOk().GetStatTables() {
+			for _, row := range table.GetPodGroup().GetRows() {
+				resource := multiclusterResource{
+					Type:      row.GetResource().GetType(),
+					Namespace: row.GetResource().GetNamespace(),
+					Name:      row.GetResource().GetName(),
+				}
+				i, ok := index[resource]
+				if !ok {
+					i = len(result.Rows)
+					index[resource] = i
+					result.Rows = append(result.Rows, multiclusterRow{
+						Resource: resource,
+						Clusters: make(map[string]json.RawMessage),
+					})
+				}
+
+				var rowJSON bytes.Buffer
+				if err := pbMarshaler.Marshal(&rowJSON, row); err != nil {
+					return nil, err
+				}
+				result.Rows[i].Clusters[cluster] = rowJSON.Bytes()
+			}
+		}
+	}
+	return result, nil
+}
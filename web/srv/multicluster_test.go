@@ -0,0 +1,207 @@
+package srv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/multicluster"
+	vizApi "github.com/linkerd/linkerd2/viz/metrics-api"
+	pb "github.com/linkerd/linkerd2/viz/metrics-api/gen/viz"
+	"github.com/patrickmn/go-cache"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestHandleAPIMulticlusterStat(t *testing.T) {
+	counts := &vizApi.PodCounts{MeshedPods: 1, RunningPods: 1}
+	localClient := &vizApi.MockAPIClient{
+		StatSummaryResponseToReturn: vizApi.GenStatSummaryResponse("emoji", k8s.Deployment, []string{"emojivoto"}, counts, true, false),
+	}
+	eastClient := &vizApi.MockAPIClient{
+		StatSummaryResponseToReturn: vizApi.GenStatSummaryResponse("emoji", k8s.Deployment, []string{"emojivoto", "books"}, counts, true, false),
+	}
+
+	testCases := []struct {
+		name             string
+		eastErr          error
+		expectedClusters []string
+		expectedRows     map[string][]string
+		expectedErrors   map[string]string
+	}{
+		{
+			name:             "merges the stats of the linked clusters",
+			expectedClusters: []string{"local", "east"},
+			expectedRows: map[string][]string{
+				"emojivoto/emoji": {"east", "local"},
+				"books/emoji":     {"east"},
+			},
+		},
+		{
+			name:             "reports the linked clusters that can't be reached",
+			eastErr:          errors.New("no running pods found for metrics-api"),
+			expectedClusters: []string{"local"},
+			expectedRows: map[string][]string{
+				"emojivoto/emoji": {"local"},
+			},
+			expectedErrors: map[string]string{
+				"east": "failed to connect to the metrics-api: no running pods found for metrics-api",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			h := &handler{
+				apiClient:      localClient,
+				statCache:      cache.New(statExpiration, statCleanupInterval),
+				linkedClusters: fakeLinkedClusters(t, eastClient, tc.eastErr),
+			}
+
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/api/multicluster/tps-reports?resource_type=deployment&all_namespaces=true", nil)
+			h.handleAPIMulticlusterStat(recorder, req, httprouter.Params{})
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+			}
+
+			var result struct {
+				Clusters []string `json:"clusters"`
+				Rows     []struct {
+					Resource multiclusterResource       `json:"resource"`
+					Clusters map[string]json.RawMessage `json:"clusters"`
+				} `json:"rows"`
+				Errors map[string]string `json:"errors"`
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(result.Clusters, tc.expectedClusters) {
+				t.Fatalf("Expected clusters %v, got %v", tc.expectedClusters, result.Clusters)
+			}
+			rows := make(map[string][]string)
+			for _, row := range result.Rows {
+				clusters := []string{}
+				for _, cluster := range []string{"east", "local"} {
+					if _, ok := row.Clusters[cluster]; ok {
+						clusters = append(clusters, cluster)
+					}
+				}
+				rows[row.Resource.Namespace+"/"+row.Resource.Name] = clusters
+			}
+			if !reflect.DeepEqual(rows, tc.expectedRows) {
+				t.Fatalf("Expected rows %v, got %v", tc.expectedRows, rows)
+			}
+			if !reflect.DeepEqual(result.Errors, tc.expectedErrors) {
+				t.Fatalf("Expected errors %v, got %v", tc.expectedErrors, result.Errors)
+			}
+		})
+	}
+
+	t.Run("fails when the multicluster views are disabled", func(t *testing.T) {
+		h := &handler{
+			apiClient: localClient,
+			statCache: cache.New(statExpiration, statCleanupInterval),
+		}
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/multicluster/tps-reports", nil)
+		h.handleAPIMulticlusterStat(recorder, req, httprouter.Params{})
+		if recorder.Code != http.StatusNotFound {
+			t.Fatalf("Expected status %d, got %d", http.StatusNotFound, recorder.Code)
+		}
+	})
+}
+
+func TestLinkedClustersEviction(t *testing.T) {
+	lc := fakeLinkedClusters(t, &vizApi.MockAPIClient{}, nil)
+	created := 0
+	newClient := lc.newClient
+	lc.newClient = func(ctx context.Context, creds []byte, vizNamespace string) (pb.ApiClient, func(), error) {
+		created++
+		return newClient(ctx, creds, vizNamespace)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := lc.get(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+	if created != 1 {
+		t.Fatalf("Expected the client to be created once, got %d", created)
+	}
+
+	lc.evict("east")
+	if _, _, err := lc.get(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if created != 2 {
+		t.Fatalf("Expected the client to be recreated after its eviction, got %d creations", created)
+	}
+}
+
+// fakeLinkedClusters returns the linked clusters of a fake cluster linked to
+// the east cluster, whose client is the given one, or fails with the error
+func fakeLinkedClusters(t *testing.T, client pb.ApiClient, err error) *linkedClusters {
+	t.Helper()
+
+	k8sAPI, e := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Secret
+metadata:
+  name: cluster-credentials-east
+  namespace: linkerd-multicluster
+data:
+  kubeconfig: a3ViZWNvbmZpZw==`)
+	if e != nil {
+		t.Fatalf("Unexpected error: %s", e)
+	}
+
+	link, e := multicluster.Link{
+		Name:                          "east",
+		Namespace:                     "linkerd-multicluster",
+		TargetClusterName:             "east",
+		TargetClusterDomain:           "cluster.local",
+		TargetClusterLinkerdNamespace: "linkerd",
+		ClusterCredentialsSecret:      "cluster-credentials-east",
+		GatewayAddress:                "192.0.2.1",
+		GatewayPort:                   4143,
+		GatewayIdentity:               "linkerd-gateway.linkerd-multicluster.serviceaccount.identity.linkerd.cluster.local",
+		ProbeSpec: multicluster.ProbeSpec{
+			Path:             "/ready",
+			Port:             4191,
+			Period:           3 * time.Second,
+			Timeout:          30 * time.Second,
+			FailureThreshold: multicluster.DefaultProbeFailureThreshold,
+		},
+	}.ToUnstructured()
+	if e != nil {
+		t.Fatalf("Unexpected error: %s", e)
+	}
+	k8sAPI.DynamicClient = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{multicluster.LinkGVR: "LinkList"},
+		&link,
+	)
+
+	lc := newLinkedClusters(k8sAPI, "linkerd-multicluster", "linkerd-viz")
+	lc.newClient = func(_ context.Context, creds []byte, _ string) (pb.ApiClient, func(), error) {
+		if string(creds) != "kubeconfig" {
+			t.Fatalf("Expected the credentials of the east cluster, got %q", creds)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return client, func() {}, nil
+	}
+	return lc
+}
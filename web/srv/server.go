@@ -55,7 +55,8 @@ type (
 		Grafana             string
 	}
 
-	healthChecker interface {
+	// HealthChecker runs the checks of the control plane or of an extension
+	HealthChecker interface {
 		RunChecks(observer healthcheck.CheckObserver) (bool, bool)
 	}
 )
@@ -89,12 +90,15 @@ func NewServer(
 	uuid string,
 	version string,
 	controllerNamespace string,
+	vizNamespace string,
+	linkNamespace string,
 	clusterDomain string,
 	reload bool,
 	reHost *regexp.Regexp,
 	apiClient vizPb.ApiClient,
 	k8sAPI *k8s.KubernetesAPI,
-	hc healthChecker,
+	hc HealthChecker,
+	extensionChecks map[string]HealthChecker,
 ) *http.Server {
 	server := &Server{
 		templateDir: templateDir,
@@ -122,7 +126,12 @@ func NewServer(
 		grafana:             grafanaAddr,
 		jaeger:              jaegerAddr,
 		hc:                  hc,
+		extensionChecks:     extensionChecks,
 		statCache:           cache.New(statExpiration, statCleanupInterval),
+		healthCache:         cache.New(healthSummaryExpiration, statCleanupInterval),
+	}
+	if linkNamespace != "" {
+		handler.linkedClusters = newLinkedClusters(k8sAPI, linkNamespace, vizNamespace)
 	}
 
 	httpServer := &http.Server{
@@ -180,6 +189,7 @@ func NewServer(
 	server.router.GET("/community", handler.handleIndex)
 	server.router.GET("/routes", handler.handleIndex)
 	server.router.GET("/extensions", handler.handleIndex)
+	server.router.GET("/multicluster", handler.handleIndex)
 	server.router.GET("/profiles/new", handler.handleProfileDownload)
 
 	// add catch-all parameter to match all files in dir
@@ -191,12 +201,17 @@ func NewServer(
 	// but was renamed to avoid triggering ad blockers.
 	// See: https://github.com/linkerd/linkerd2/issues/970
 	server.router.GET("/api/tps-reports", handler.handleAPIStat)
+	// Traffic Performance Summary of the resources in this cluster and the
+	// linked ones, when enabled
+	server.router.GET("/api/multicluster/tps-reports", handler.handleAPIMulticlusterStat)
 	server.router.GET("/api/pods", handler.handleAPIPods)
 	server.router.GET("/api/services", handler.handleAPIServices)
 	server.router.GET("/api/tap", handler.handleAPITap)
 	server.router.GET("/api/routes", handler.handleAPITopRoutes)
 	server.router.GET("/api/edges", handler.handleAPIEdges)
 	server.router.GET("/api/check", handler.handleAPICheck)
+	server.router.GET("/api/health", handler.handleAPIHealth)
+	server.router.GET("/api/health-summary", handler.handleAPIHealthSummary)
 	server.router.GET("/api/resource-definition", handler.handleAPIResourceDefinition)
 	server.router.GET("/api/gateways", handler.handleAPIGateways)
 	server.router.GET("/api/extensions", handler.handleGetExtensions)